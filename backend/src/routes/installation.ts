@@ -220,7 +220,7 @@ const installation = new Hono()
       crdFound: installationStatus.crdFound,
       operatorRunning: installationStatus.operatorRunning,
       requiresCRD: installationStatus.requiresCRD ?? provider.requiresCRD,
-      version: status.version,
+      version: status.observedProviderVersion ?? status.version,
       message: hasInstallMetadata || provider.requiresCRD === false
         ? installationStatus.message
         : `No installation metadata found for provider ${providerId}`,
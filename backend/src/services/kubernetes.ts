@@ -768,7 +768,7 @@ class KubernetesService {
               crdFound: runtimeStatus.crdFound ?? runtimeStatus.installed,
               operatorRunning: runtimeStatus.operatorRunning ?? false,
               requiresCRD: runtimeStatus.requiresCRD ?? requiresCRD,
-              version: status.version,
+              version: status.observedProviderVersion ?? status.version,
               message: runtimeStatus.message,
             };
           })
@@ -17,7 +17,10 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"fmt"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -27,6 +30,39 @@ const (
 
 	// AnnotationDocumentation is the annotation key for the provider documentation URL.
 	AnnotationDocumentation = "airunway.ai/documentation"
+
+	// ConditionTypeUpstreamCompatible indicates the upstream operator and CRD versions in the
+	// cluster match spec.compatibility
+	ConditionTypeUpstreamCompatible = "UpstreamCompatible"
+
+	// ConditionTypeUpstreamInstalled indicates the upstream CRDs the provider creates
+	// resources of are installed in the cluster
+	ConditionTypeUpstreamInstalled = "UpstreamInstalled"
+
+	// ConditionTypeHeartbeat indicates the provider controller sent a heartbeat within the
+	// heartbeat timeout of the core controller
+	ConditionTypeHeartbeat = "Heartbeat"
+
+	// ReasonHeartbeatReceived, ReasonHeartbeatStale, and ReasonHeartbeatMissing are the
+	// reasons of the Heartbeat condition
+	ReasonHeartbeatReceived = "HeartbeatReceived"
+	ReasonHeartbeatStale    = "HeartbeatStale"
+	ReasonHeartbeatMissing  = "HeartbeatMissing"
+
+	// ReasonProviderReady and ReasonProviderNotReady are the reasons of the Ready condition
+	// the core controller sets from status.ready once the provider sent a heartbeat
+	ReasonProviderReady    = "ProviderReady"
+	ReasonProviderNotReady = "ProviderNotReady"
+
+	// ConditionTypePermissionsAggregated indicates the permissions of the ClusterRoles the
+	// provider aggregates into the controller role were granted to the controller
+	ConditionTypePermissionsAggregated = "PermissionsAggregated"
+
+	// ReasonPermissionsGranted, ReasonPermissionsMissing, and ReasonNoAggregatedRoles are
+	// the reasons of the PermissionsAggregated condition
+	ReasonPermissionsGranted = "PermissionsGranted"
+	ReasonPermissionsMissing = "PermissionsMissing"
+	ReasonNoAggregatedRoles  = "NoAggregatedRoles"
 )
 
 // ProviderCapabilities defines what a provider supports
@@ -47,6 +83,12 @@ type ProviderCapabilities struct {
 	// +optional
 	GPUSupport bool `json:"gpuSupport,omitempty"`
 
+	// cpuEngines lists the engines the provider can run on CPU-only deployments.
+	// When empty, all engines except the GPU-only vllm, sglang, and trtllm are assumed.
+	// Only applicable when cpuSupport is true.
+	// +optional
+	CPUEngines []EngineType `json:"cpuEngines,omitempty"`
+
 	// requiresCRD indicates if this provider needs an upstream CRD/operator installation.
 	// When omitted, clients should treat this as true for backward compatibility.
 	// +optional
@@ -55,10 +97,93 @@ type ProviderCapabilities struct {
 	// gateway defines the provider's gateway-related capabilities.
 	// +optional
 	Gateway *GatewayCapabilities `json:"gateway,omitempty"`
+
+	// hotModelSwapEngines lists the engines for which the provider swaps the model of a
+	// running deployment in place, reusing its pods instead of recreating the upstream resource.
+	// spec.model.id may only be changed on deployments served by these engines.
+	// +optional
+	HotModelSwapEngines []EngineType `json:"hotModelSwapEngines,omitempty"`
+
+	// networkDependencies are services outside the deployment's namespace that the model
+	// pods of the provider connect to. The NetworkPolicy of spec.networking.isolate allows
+	// egress to them.
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	NetworkDependencies []NetworkDependency `json:"networkDependencies,omitempty"`
+}
+
+// NetworkDependency is a service in another namespace that model pods connect to
+type NetworkDependency struct {
+	// name identifies the dependency, e.g. etcd
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// namespace is the namespace of the service
+	// +kubebuilder:validation:Required
+	Namespace string `json:"namespace"`
+
+	// ports are the TCP ports model pods connect to. Empty allows every port.
+	// +kubebuilder:validation:MaxItems=8
+	// +optional
+	Ports []int32 `json:"ports,omitempty"`
+}
+
+// SupportsCPUEngine reports whether the provider can run engine on a CPU-only deployment.
+func (c *ProviderCapabilities) SupportsCPUEngine(engine EngineType) bool {
+	if c == nil || !c.CPUSupport {
+		return false
+	}
+	if len(c.CPUEngines) > 0 {
+		for _, e := range c.CPUEngines {
+			if e == engine {
+				return true
+			}
+		}
+		return false
+	}
+	switch engine {
+	case EngineTypeVLLM, EngineTypeSGLang, EngineTypeTRTLLM:
+		return false
+	}
+	return true
+}
+
+// SupportsHotModelSwap reports whether the provider can change the model of a running
+// deployment using engine without recreating it.
+func (c *ProviderCapabilities) SupportsHotModelSwap(engine EngineType) bool {
+	if c == nil || engine == "" {
+		return false
+	}
+	for _, e := range c.HotModelSwapEngines {
+		if e == engine {
+			return true
+		}
+	}
+	return false
 }
 
+// GatewayManagement identifies who creates the InferencePool and EPP for a deployment.
+// +kubebuilder:validation:Enum=controller;provider
+type GatewayManagement string
+
+const (
+	// GatewayManagementController means the core controller creates the InferencePool and EPP.
+	GatewayManagementController GatewayManagement = "controller"
+	// GatewayManagementProvider means the provider creates the InferencePool and EPP; the core
+	// controller discovers the pool and only manages the HTTPRoute and gateway status.
+	GatewayManagementProvider GatewayManagement = "provider"
+)
+
 // GatewayCapabilities defines gateway-related capabilities for a specific provider.
 type GatewayCapabilities struct {
+	// gatewayManagement selects who creates the InferencePool and EPP.
+	// "provider" skips controller-created EPP/InferencePool and routes to the pool found
+	// via inferencePoolNamePattern and inferencePoolNamespace, e.g. a KV-aware EPP managed
+	// by the provider. "controller" always uses the controller-created pool and EPP.
+	// When unset, declaring gateway capabilities implies "provider".
+	// +optional
+	GatewayManagement GatewayManagement `json:"gatewayManagement,omitempty"`
+
 	// inferencePoolNamePattern is the naming pattern for provider-created pools.
 	// Supports {name} and {namespace} placeholders.
 	// +optional
@@ -72,6 +197,12 @@ type GatewayCapabilities struct {
 	InferencePoolNamespace string `json:"inferencePoolNamespace,omitempty"`
 }
 
+// ProviderManaged reports whether the provider creates the InferencePool and EPP.
+// It is safe to call on a nil receiver.
+func (c *GatewayCapabilities) ProviderManaged() bool {
+	return c != nil && c.GatewayManagement != GatewayManagementController
+}
+
 // HelmRepo defines a Helm repository needed for installation
 type HelmRepo struct {
 	// name is the local name for the Helm repository
@@ -170,6 +301,66 @@ type SelectionRule struct {
 	Priority int32 `json:"priority,omitempty"`
 }
 
+// ProviderCompatibility declares the upstream operator and CRD versions a provider works with
+type ProviderCompatibility struct {
+	// crdVersions lists the upstream API versions (group/version, e.g. "nvidia.com/v1alpha1")
+	// the provider creates resources in. The cluster must serve at least one of them.
+	// +optional
+	CRDVersions []string `json:"crdVersions,omitempty"`
+
+	// minOperatorVersion is the oldest supported upstream operator version (semver, e.g. "1.0.0")
+	// +optional
+	MinOperatorVersion string `json:"minOperatorVersion,omitempty"`
+
+	// maxOperatorVersion is the newest supported upstream operator version, inclusive (semver)
+	// +optional
+	MaxOperatorVersion string `json:"maxOperatorVersion,omitempty"`
+}
+
+// ImageUpgradeSpec staggers the rollout of a new provider default image across the
+// ModelDeployments that use it
+type ImageUpgradeSpec struct {
+	// maxConcurrent is the number of deployments that may roll to the new image at once
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1
+	// +optional
+	MaxConcurrent int32 `json:"maxConcurrent,omitempty"`
+
+	// canaryNamespaces roll first. Deployments in other namespaces keep the previous image
+	// until every deployment in these namespaces runs the new image and is Running.
+	// +optional
+	CanaryNamespaces []string `json:"canaryNamespaces,omitempty"`
+
+	// interval is how long a deployment counts as upgrading after it moved to the new
+	// image, even once Running, so problems surface before the next batch. Defaults to 5m.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// ImagePolicySpec restricts the images the provider runs model servers with
+type ImagePolicySpec struct {
+	// allowedRegistries are the registries, or repository prefixes, images must come from,
+	// e.g. nvcr.io or docker.io/vllm. Docker Hub images without a registry, such as
+	// vllm/vllm-openai, are in docker.io. When empty, images may come from any registry.
+	// +kubebuilder:validation:MaxItems=64
+	// +optional
+	AllowedRegistries []string `json:"allowedRegistries,omitempty"`
+
+	// cosign requires images to carry a cosign signature verified with a public key
+	// +optional
+	Cosign *CosignPolicy `json:"cosign,omitempty"`
+}
+
+// CosignPolicy verifies the cosign signatures of images with public keys. Keyless
+// (Fulcio and Rekor) signatures are not supported.
+type CosignPolicy struct {
+	// publicKeys are PEM-encoded ECDSA, RSA, or Ed25519 public keys, as written by
+	// cosign generate-key-pair. An image passes when a signature verifies with any of them.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	PublicKeys []string `json:"publicKeys"`
+}
+
 // InferenceProviderConfigSpec defines the desired state of InferenceProviderConfig
 type InferenceProviderConfigSpec struct {
 	// capabilities defines what this provider supports
@@ -180,22 +371,76 @@ type InferenceProviderConfigSpec struct {
 	// Conditions use CEL (Common Expression Language)
 	// +optional
 	SelectionRules []SelectionRule `json:"selectionRules,omitempty"`
+
+	// compatibility declares the upstream operator and CRD versions this provider supports.
+	// The provider controller checks it against the cluster and reports a mismatch through the
+	// UpstreamCompatible condition instead of failing when applying resources.
+	// +optional
+	Compatibility *ProviderCompatibility `json:"compatibility,omitempty"`
+
+	// namespaceSelector restricts the provider to ModelDeployments in namespaces whose labels
+	// match. Other namespaces cannot select the provider, explicitly or automatically.
+	// When unset, the provider serves all namespaces.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// imageUpgrade staggers the rollout when the provider's default image changes, e.g. after
+	// a provider upgrade, instead of restarting every deployment that uses it at once. Only
+	// deployments without spec.image are affected. Like namespaceSelector, it is set by
+	// cluster admins and kept when the provider registers. When unset, deployments move to
+	// the new image on their next reconcile.
+	// +optional
+	ImageUpgrade *ImageUpgradeSpec `json:"imageUpgrade,omitempty"`
+
+	// imagePolicy restricts the model server images of deployments, from spec.image or the
+	// provider's default runtime image, to allowed registries and signed images. The
+	// provider checks it before creating resources and fails violating deployments with
+	// the ImagePolicyViolation reason. Like namespaceSelector, it is set by cluster admins
+	// and kept when the provider registers.
+	// +optional
+	ImagePolicy *ImagePolicySpec `json:"imagePolicy,omitempty"`
+}
+
+// ServesNamespace reports whether spec.namespaceSelector matches a namespace with the given
+// labels.
+func (s *InferenceProviderConfigSpec) ServesNamespace(namespaceLabels map[string]string) (bool, error) {
+	if s.NamespaceSelector == nil {
+		return true, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(s.NamespaceSelector)
+	if err != nil {
+		return false, fmt.Errorf("invalid namespaceSelector: %w", err)
+	}
+	return selector.Matches(labels.Set(namespaceLabels)), nil
 }
 
 // InferenceProviderConfigStatus defines the observed state of InferenceProviderConfig.
 type InferenceProviderConfigStatus struct {
-	// ready indicates if the provider is ready to accept workloads
+	// ready indicates if the provider is ready to accept workloads. The provider controller
+	// sets it with each heartbeat; the core controller sets it to false when the heartbeat
+	// is older than its heartbeat timeout.
 	// +optional
 	Ready bool `json:"ready,omitempty"`
 
-	// version is the version of the provider controller
+	// version is the version of the provider controller.
+	// Deprecated: use observedProviderVersion.
 	// +optional
 	Version string `json:"version,omitempty"`
 
-	// lastHeartbeat is the last time the provider controller updated this status
+	// lastHeartbeat is the last time the provider controller updated this status.
+	// Deprecated: use lastHeartbeatTime.
 	// +optional
 	LastHeartbeat *metav1.Time `json:"lastHeartbeat,omitempty"`
 
+	// lastHeartbeatTime is the last time the provider controller sent a heartbeat
+	// +optional
+	LastHeartbeatTime *metav1.Time `json:"lastHeartbeatTime,omitempty"`
+
+	// observedProviderVersion is the version of the provider controller that sent the
+	// last heartbeat
+	// +optional
+	ObservedProviderVersion string `json:"observedProviderVersion,omitempty"`
+
 	// upstreamCRDVersion is the API version of the upstream CRD this provider creates
 	// +optional
 	UpstreamCRDVersion string `json:"upstreamCRDVersion,omitempty"`
@@ -204,6 +449,22 @@ type InferenceProviderConfigStatus struct {
 	// +optional
 	UpstreamSchemaHash string `json:"upstreamSchemaHash,omitempty"`
 
+	// upstreamOperatorVersion is the upstream operator version detected by the provider
+	// controller, checked against spec.compatibility. Empty when it cannot be detected.
+	// +optional
+	UpstreamOperatorVersion string `json:"upstreamOperatorVersion,omitempty"`
+
+	// supportedFields lists the ModelDeployment spec fields the provider transformer honors,
+	// e.g. spec.env or spec.tolerations. The core controller warns on deployments that set a
+	// field missing from the list. Empty when the provider does not report its fields.
+	// +listType=set
+	// +optional
+	SupportedFields []string `json:"supportedFields,omitempty"`
+
+	// observedGeneration is the spec generation the core controller last observed
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
 	// conditions represent the current state of the InferenceProviderConfig resource
 	// +listType=map
 	// +listMapKey=type
@@ -213,9 +474,10 @@ type InferenceProviderConfigStatus struct {
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
-// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:resource:scope=Cluster,shortName=ipc
 // +kubebuilder:printcolumn:name="Ready",type="boolean",JSONPath=".status.ready",description="Provider ready"
-// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".status.version",description="Provider version"
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".status.observedProviderVersion",description="Provider version"
+// +kubebuilder:printcolumn:name="Heartbeat",type="date",JSONPath=".status.lastHeartbeatTime",description="Last provider heartbeat"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // InferenceProviderConfig is the Schema for the inferenceproviderconfigs API
@@ -233,6 +495,15 @@ type InferenceProviderConfig struct {
 	Status InferenceProviderConfigStatus `json:"status,omitempty"`
 }
 
+// HeartbeatTime returns the time of the last provider heartbeat, falling back to the
+// deprecated lastHeartbeat for provider controllers that do not set lastHeartbeatTime.
+func (s *InferenceProviderConfigStatus) HeartbeatTime() *metav1.Time {
+	if s.LastHeartbeatTime != nil {
+		return s.LastHeartbeatTime
+	}
+	return s.LastHeartbeat
+}
+
 // +kubebuilder:object:root=true
 
 // InferenceProviderConfigList contains a list of InferenceProviderConfig
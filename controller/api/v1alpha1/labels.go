@@ -0,0 +1,152 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import "strings"
+
+// Label keys set on resources created for a ModelDeployment by the controller and providers
+const (
+	// LabelManagedBy is set to ManagedByAIRunway on every generated resource
+	LabelManagedBy = "airunway.ai/managed-by"
+	// LabelModelDeployment is the name of the owning ModelDeployment
+	LabelModelDeployment = "airunway.ai/model-deployment"
+	// LabelDeployment is the name of the owning ModelDeployment, used by provider pod selectors
+	LabelDeployment = "airunway.ai/deployment"
+	// LabelModelSource is the spec.model.source of the owning ModelDeployment
+	LabelModelSource = "airunway.ai/model-source"
+	// LabelEngineType is the resolved engine type of the owning ModelDeployment
+	LabelEngineType = "airunway.ai/engine-type"
+	// LabelModelID is spec.model.id, sanitized to a label value
+	LabelModelID = "airunway.ai/model-id"
+	// LabelJobType is the kind of a Job created for a ModelDeployment
+	LabelJobType = "airunway.ai/job-type"
+
+	// ManagedByAIRunway is the value of LabelManagedBy
+	ManagedByAIRunway = "airunway"
+
+	// LabelGPUProduct is the node label GPU feature discovery sets to the GPU model,
+	// matched against spec.resources.gpu.types
+	LabelGPUProduct = "nvidia.com/gpu.product"
+)
+
+// Label keys set on ModelDeployments
+const (
+	// LabelShard pins a ModelDeployment to a controller shard when the controller runs
+	// with --shard-count. Without it, the shard is a hash of the namespace and name.
+	LabelShard = "airunway.ai/shard"
+
+	// LabelFleet is the name of the ModelFleet that created a ModelDeployment
+	LabelFleet = "airunway.ai/fleet"
+	// LabelFleetItem is the name of the ModelFleet item a ModelDeployment was created for
+	LabelFleetItem = "airunway.ai/fleet-item"
+
+	// LabelBatchJob is the name of the ModelBatchJob that created a transient
+	// ModelDeployment or a batch Job
+	LabelBatchJob = "airunway.ai/batch-job"
+)
+
+// Label keys set on the ClusterRoles providers aggregate into the controller role
+const (
+	// LabelAggregateToController selects a ClusterRole into the aggregated provider role of
+	// the AI Runway controller when set to "true"
+	LabelAggregateToController = "airunway.ai/aggregate-to-controller"
+	// LabelProvider is the name of the InferenceProviderConfig of the provider that ships
+	// an aggregated ClusterRole
+	LabelProvider = "airunway.ai/provider"
+)
+
+// Annotation keys set on model server pods
+const (
+	// AnnotationCPUPinning is the number of exclusive cores of a pod with
+	// spec.resources.cpuPinning
+	AnnotationCPUPinning = "airunway.ai/cpu-pinning"
+	// AnnotationNUMAPolicy is spec.resources.cpuPinning.numaPolicy
+	AnnotationNUMAPolicy = "airunway.ai/numa-policy"
+	// AnnotationSecretsChecksum is status.secretsChecksum of a deployment with
+	// spec.secrets.rolloutOnChange
+	AnnotationSecretsChecksum = "airunway.ai/secrets-checksum"
+)
+
+// Annotation keys set on ModelDeployments
+const (
+	HTTPRouteCreated = "airunway.ai/httproute-created"
+	BBRRestarted     = "airunway.ai/bbr-restarted"
+
+	// AnnotationReconcilePaused is the legacy annotation form of spec.paused.
+	AnnotationReconcilePaused = "airunway.ai/reconcile-paused"
+
+	// AnnotationServingModeReason and AnnotationServingModeMessage record how the
+	// admission webhook resolved serving.mode auto. The controller surfaces them as
+	// the ServingModeSelected condition.
+	AnnotationServingModeReason  = "airunway.ai/serving-mode-reason"
+	AnnotationServingModeMessage = "airunway.ai/serving-mode-message"
+
+	// AnnotationModelRevisionRef records the branch or tag the admission webhook resolved
+	// to the commit SHA in spec.model.revision.
+	AnnotationModelRevisionRef = "airunway.ai/model-revision-ref"
+
+	// AnnotationSelectionExplain set to "true" makes the controller write
+	// status.selectionReport.
+	AnnotationSelectionExplain = "airunway.ai/selection-explain"
+)
+
+// reservedMetadataDomains are the label and annotation domains that spec.labels and
+// spec.annotations cannot propagate, including their subdomains, since the controller,
+// providers, and Kubernetes itself interpret them.
+var reservedMetadataDomains = []string{"airunway.ai", "kubernetes.io", "k8s.io"}
+
+// IsReservedMetadataKey reports whether a label or annotation key is in a domain that is
+// not propagated from spec.labels and spec.annotations.
+func IsReservedMetadataKey(key string) bool {
+	domain, _, found := strings.Cut(key, "/")
+	if !found {
+		return false
+	}
+	for _, reserved := range reservedMetadataDomains {
+		if domain == reserved || strings.HasSuffix(domain, "."+reserved) {
+			return true
+		}
+	}
+	return false
+}
+
+// PropagatedLabels returns spec.labels without reserved keys. They are added to every
+// resource and pod created for the deployment, without replacing labels set by the
+// controller or providers.
+func (md *ModelDeployment) PropagatedLabels() map[string]string {
+	return withoutReservedKeys(md.Spec.Labels)
+}
+
+// PropagatedAnnotations returns spec.annotations without reserved keys, propagated like
+// PropagatedLabels.
+func (md *ModelDeployment) PropagatedAnnotations() map[string]string {
+	return withoutReservedKeys(md.Spec.Annotations)
+}
+
+func withoutReservedKeys(m map[string]string) map[string]string {
+	var out map[string]string
+	for k, v := range m {
+		if IsReservedMetadataKey(k) {
+			continue
+		}
+		if out == nil {
+			out = make(map[string]string, len(m))
+		}
+		out[k] = v
+	}
+	return out
+}
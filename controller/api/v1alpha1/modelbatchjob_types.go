@@ -0,0 +1,196 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BatchJobPhase is the lifecycle phase of a ModelBatchJob
+// +kubebuilder:validation:Enum=Pending;Running;Succeeded;Failed
+type BatchJobPhase string
+
+const (
+	// BatchJobPhasePending is set while the ModelDeployment of the job is not Running yet
+	BatchJobPhasePending BatchJobPhase = "Pending"
+	// BatchJobPhaseRunning is set while the batch Job sends requests
+	BatchJobPhaseRunning BatchJobPhase = "Running"
+	// BatchJobPhaseSucceeded is set when every input record was sent and the output written
+	BatchJobPhaseSucceeded BatchJobPhase = "Succeeded"
+	// BatchJobPhaseFailed is set when the job is invalid, its transient ModelDeployment
+	// failed, or the batch Job failed
+	BatchJobPhaseFailed BatchJobPhase = "Failed"
+)
+
+// ModelBatchJobSpec defines an offline inference run over a dataset
+type ModelBatchJobSpec struct {
+	// deploymentRef names a ModelDeployment in the namespace of the job whose endpoint
+	// serves the requests. Exactly one of deploymentRef and deployment must be set.
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	DeploymentRef string `json:"deploymentRef,omitempty"`
+
+	// deployment is the spec of a transient ModelDeployment, named after the job, that is
+	// created for the job and deleted when it finishes. It is validated as part of the
+	// generated ModelDeployment rather than by this schema.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	Deployment *ModelDeploymentSpec `json:"deployment,omitempty"`
+
+	// input is the URI of the dataset, a JSON Lines file with one JSON object per request.
+	// Either pvc://<claim>/<path> for a file on a PersistentVolumeClaim in the namespace of
+	// the job, or an http(s) URL the file is downloaded from, such as a pre-signed object
+	// storage URL.
+	// +kubebuilder:validation:Pattern=`^(pvc|https?)://.+`
+	Input string `json:"input"`
+
+	// output is the URI the results are written to as JSON Lines. Either
+	// pvc://<claim>/<path>, or an http(s) URL the file is uploaded to with PUT, such as a
+	// pre-signed object storage URL.
+	// +kubebuilder:validation:Pattern=`^(pvc|https?)://.+`
+	Output string `json:"output"`
+
+	// promptTemplate is a Go template rendered with each input record to build the prompt,
+	// e.g. "Summarize: {{ .text }}". When empty, each record must have a prompt field.
+	// +kubebuilder:validation:MaxLength=16384
+	// +optional
+	PromptTemplate string `json:"promptTemplate,omitempty"`
+
+	// maxTokens caps the tokens generated per request
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxTokens *int32 `json:"maxTokens,omitempty"`
+
+	// concurrency is the number of requests in flight at once
+	// +kubebuilder:default=8
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=256
+	// +optional
+	Concurrency int32 `json:"concurrency,omitempty"`
+}
+
+// ModelBatchJobStatus defines the observed state of a ModelBatchJob
+type ModelBatchJobStatus struct {
+	// phase is the lifecycle phase of the job
+	// +optional
+	Phase BatchJobPhase `json:"phase,omitempty"`
+
+	// message explains the phase
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// deployment is the name of the ModelDeployment serving the requests
+	// +optional
+	Deployment string `json:"deployment,omitempty"`
+
+	// job is the name of the batch Job sending the requests
+	// +optional
+	Job string `json:"job,omitempty"`
+
+	// totalRequests is the number of records in the input
+	// +optional
+	TotalRequests int64 `json:"totalRequests,omitempty"`
+
+	// completedRequests is the number of records answered by the model
+	// +optional
+	CompletedRequests int64 `json:"completedRequests,omitempty"`
+
+	// failedRequests is the number of records that failed after retries. They are written
+	// to the output with an error instead of a completion.
+	// +optional
+	FailedRequests int64 `json:"failedRequests,omitempty"`
+
+	// requestsPerSecond is the average rate of finished records since the batch Job
+	// started, as a decimal string (e.g. 12.5)
+	// +optional
+	RequestsPerSecond string `json:"requestsPerSecond,omitempty"`
+
+	// startTime is when the batch Job started
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// completionTime is when the job succeeded or failed
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// observedGeneration is the spec generation the status was computed for
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// conditions report the health of the job: Ready once it succeeded, Reconciling while
+	// it runs and Stalled when it failed
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=mbj
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Job phase"
+// +kubebuilder:printcolumn:name="Deployment",type="string",JSONPath=".status.deployment",description="Serving ModelDeployment"
+// +kubebuilder:printcolumn:name="Total",type="integer",JSONPath=".status.totalRequests",description="Input records"
+// +kubebuilder:printcolumn:name="Completed",type="integer",JSONPath=".status.completedRequests",description="Answered records"
+// +kubebuilder:printcolumn:name="Failed",type="integer",JSONPath=".status.failedRequests",description="Failed records"
+// +kubebuilder:printcolumn:name="RPS",type="string",JSONPath=".status.requestsPerSecond",description="Records per second",priority=1
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ModelBatchJob is the Schema for the modelbatchjobs API
+// ModelBatchJob runs offline inference over a JSON Lines dataset against the endpoint of a
+// Running ModelDeployment, or of a transient one created for the job, and writes the
+// completions to an output file.
+type ModelBatchJob struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// spec defines the dataset, output and serving deployment
+	Spec ModelBatchJobSpec `json:"spec"`
+
+	// status is written by the controller
+	// +optional
+	Status ModelBatchJobStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ModelBatchJobList contains a list of ModelBatchJob
+type ModelBatchJobList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ModelBatchJob `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ModelBatchJob{}, &ModelBatchJobList{})
+}
+
+// DeploymentName returns the name of the ModelDeployment serving the job: deploymentRef,
+// or the name of the job for a transient deployment
+func (j *ModelBatchJob) DeploymentName() string {
+	if j.Spec.DeploymentRef != "" {
+		return j.Spec.DeploymentRef
+	}
+	return j.Name
+}
+
+// Finished reports whether the job succeeded or failed
+func (j *ModelBatchJob) Finished() bool {
+	return j.Status.Phase == BatchJobPhaseSucceeded || j.Status.Phase == BatchJobPhaseFailed
+}
@@ -18,8 +18,12 @@ package v1alpha1
 
 import (
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -36,6 +40,18 @@ const (
 	ModelSourceCustom ModelSource = "custom"
 )
 
+// ModelLoadFormat defines how the engine reads the model weights
+type ModelLoadFormat string
+
+const (
+	// ModelLoadFormatSafetensors loads safetensors files without probing other formats
+	ModelLoadFormatSafetensors ModelLoadFormat = "safetensors"
+	// ModelLoadFormatTensorizer deserializes tensorizer-serialized weights
+	ModelLoadFormatTensorizer ModelLoadFormat = "tensorizer"
+	// ModelLoadFormatRunAIStreamer streams safetensors files with the Run:ai Model Streamer
+	ModelLoadFormatRunAIStreamer ModelLoadFormat = "runai_streamer"
+)
+
 // EngineType defines the inference engine type
 // +kubebuilder:validation:Enum=vllm;sglang;trtllm;llamacpp
 type EngineType string
@@ -47,27 +63,60 @@ const (
 	EngineTypeLlamaCpp EngineType = "llamacpp"
 )
 
+// EngineDevice defines the hardware the inference engine runs on
+// +kubebuilder:validation:Enum=gpu;cpu;auto
+type EngineDevice string
+
+const (
+	EngineDeviceGPU  EngineDevice = "gpu"
+	EngineDeviceCPU  EngineDevice = "cpu"
+	EngineDeviceAuto EngineDevice = "auto"
+)
+
 // ServingMode defines the serving mode
-// +kubebuilder:validation:Enum=aggregated;disaggregated
+// +kubebuilder:validation:Enum=aggregated;disaggregated;auto
 type ServingMode string
 
 const (
 	ServingModeAggregated    ServingMode = "aggregated"
 	ServingModeDisaggregated ServingMode = "disaggregated"
+	// ServingModeAuto lets the admission webhook choose aggregated or disaggregated
+	// serving, and the prefill/decode split, from the model size and GPU count.
+	ServingModeAuto ServingMode = "auto"
 )
 
 // DeploymentPhase defines the phase of the deployment
-// +kubebuilder:validation:Enum=Pending;Deploying;Running;Failed;Terminating
+// +kubebuilder:validation:Enum=Pending;Queued;Deploying;Running;Failed;Terminating
 type DeploymentPhase string
 
 const (
 	DeploymentPhasePending     DeploymentPhase = "Pending"
+	DeploymentPhaseQueued      DeploymentPhase = "Queued"
 	DeploymentPhaseDeploying   DeploymentPhase = "Deploying"
 	DeploymentPhaseRunning     DeploymentPhase = "Running"
 	DeploymentPhaseFailed      DeploymentPhase = "Failed"
 	DeploymentPhaseTerminating DeploymentPhase = "Terminating"
 )
 
+// MigrationPhase defines the phase of a spec.provider.migrateTo migration
+// +kubebuilder:validation:Enum=Provisioning;Verifying;Switching;Draining;Completed;Failed
+type MigrationPhase string
+
+const (
+	// MigrationPhaseProvisioning waits for the new provider to report the deployment Running
+	MigrationPhaseProvisioning MigrationPhase = "Provisioning"
+	// MigrationPhaseVerifying waits for the new provider's endpoint to answer /v1/models
+	MigrationPhaseVerifying MigrationPhase = "Verifying"
+	// MigrationPhaseSwitching waits for the gateway to route to the new provider
+	MigrationPhaseSwitching MigrationPhase = "Switching"
+	// MigrationPhaseDraining waits for the old provider to delete its resources
+	MigrationPhaseDraining MigrationPhase = "Draining"
+	// MigrationPhaseCompleted is set once the old provider released the deployment
+	MigrationPhaseCompleted MigrationPhase = "Completed"
+	// MigrationPhaseFailed is set when the deployment was rolled back to the old provider
+	MigrationPhaseFailed MigrationPhase = "Failed"
+)
+
 // VolumePurpose defines the intended purpose of a storage volume
 // +kubebuilder:validation:Enum=modelCache;compilationCache;custom
 type VolumePurpose string
@@ -144,6 +193,45 @@ func (v *StorageVolume) ResolvedClaimName(mdName string) string {
 	return fmt.Sprintf("%s-%s", mdName, v.Name)
 }
 
+// KVOffloadMedium defines the storage tier KV cache blocks are offloaded to
+// +kubebuilder:validation:Enum=ram;nvme
+type KVOffloadMedium string
+
+const (
+	// KVOffloadMediumRAM offloads KV cache blocks to CPU memory of the engine process
+	KVOffloadMediumRAM KVOffloadMedium = "ram"
+	// KVOffloadMediumNVMe offloads KV cache blocks to a local disk volume
+	KVOffloadMediumNVMe KVOffloadMedium = "nvme"
+)
+
+// KVOffloadSpec defines offloading of the KV cache from GPU memory to a slower tier
+type KVOffloadSpec struct {
+	// size is the KV cache offload capacity of each engine pod (e.g., "64Gi")
+	// +kubebuilder:validation:Required
+	Size resource.Quantity `json:"size"`
+
+	// medium is the storage tier the KV cache is offloaded to.
+	// ram uses CPU memory, which counts against the container memory limit.
+	// nvme uses a local disk volume mounted in the engine container.
+	// +kubebuilder:default=ram
+	// +optional
+	Medium KVOffloadMedium `json:"medium,omitempty"`
+
+	// storageClassName is the StorageClass of a generic ephemeral volume backing nvme
+	// offload, such as a local NVMe class. When omitted, an emptyDir on the node's disk is used.
+	// Only applicable when medium is nvme.
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+}
+
+// ResolvedMedium returns the offload medium, defaulting to ram
+func (k *KVOffloadSpec) ResolvedMedium() KVOffloadMedium {
+	if k.Medium == "" {
+		return KVOffloadMediumRAM
+	}
+	return k.Medium
+}
+
 // StorageSpec defines persistent storage configuration for model data
 type StorageSpec struct {
 	// volumes is a list of PVC references to mount into inference containers
@@ -152,6 +240,11 @@ type StorageSpec struct {
 	// +kubebuilder:validation:MaxItems=8
 	// +optional
 	Volumes []StorageVolume `json:"volumes,omitempty"`
+
+	// kvOffload offloads the KV cache to CPU memory or local disk, so more prefixes stay
+	// cached than fit in GPU memory. Supported by the vllm and sglang engines.
+	// +optional
+	KVOffload *KVOffloadSpec `json:"kvOffload,omitempty"`
 }
 
 // ModelSpec defines the model specification
@@ -161,6 +254,14 @@ type ModelSpec struct {
 	// +optional
 	ID string `json:"id,omitempty"`
 
+	// revision is the HuggingFace branch, tag, or commit SHA of the model
+	// Maps to --revision for vllm and sglang and to the model download Job
+	// When the controller runs with --resolve-model-revisions, a branch or tag, or the
+	// default branch when unset, is resolved to its commit SHA on creation
+	// +kubebuilder:validation:MaxLength=128
+	// +optional
+	Revision string `json:"revision,omitempty"`
+
 	// servedName is the API-facing model name
 	// Defaults to model ID basename if not specified
 	// Not applicable for source=custom
@@ -175,6 +276,86 @@ type ModelSpec struct {
 	// storage defines persistent storage for model data (e.g., model weights, compilation caches)
 	// +optional
 	Storage *StorageSpec `json:"storage,omitempty"`
+
+	// license is the license identifier of the model (e.g., apache-2.0, llama3.1, cc-by-nc-4.0)
+	// It is matched against the allowed and denied licenses of ModelPolicies
+	// +kubebuilder:validation:MaxLength=128
+	// +optional
+	License string `json:"license,omitempty"`
+
+	// chatTemplate overrides the Jinja chat template of the model, for fine-tunes whose
+	// tokenizer config has none or the wrong one
+	// Maps to --chat-template for vllm and sglang
+	// +optional
+	ChatTemplate *ChatTemplateSource `json:"chatTemplate,omitempty"`
+
+	// tokenizer is the HuggingFace ID or path of the tokenizer, when it differs from the model
+	// Maps to --tokenizer for vllm and --tokenizer-path for sglang
+	// +kubebuilder:validation:MaxLength=256
+	// +optional
+	Tokenizer string `json:"tokenizer,omitempty"`
+
+	// loadFormat selects how the engine reads the weights. safetensors skips the format
+	// probe, tensorizer deserializes pre-serialized weights from tensorizerURI straight into
+	// GPU memory, and runai_streamer streams the safetensors files concurrently; both cut
+	// the cold start of large models. Defaults to the engine's own detection.
+	// Maps to --load-format for vllm
+	// +kubebuilder:validation:Enum=safetensors;tensorizer;runai_streamer
+	// +optional
+	LoadFormat ModelLoadFormat `json:"loadFormat,omitempty"`
+
+	// tensorizerURI is the location of the tensorizer-serialized weights, e.g.
+	// s3://bucket/llama-3.1-8b/model.tensors. Required when loadFormat is tensorizer.
+	// +kubebuilder:validation:MaxLength=1024
+	// +kubebuilder:validation:Pattern=`^(s3|https?)://`
+	// +optional
+	TensorizerURI string `json:"tensorizerURI,omitempty"`
+
+	// loadCredentialsSecret is the name of a Secret whose keys are exposed as environment
+	// variables to the engine, e.g. S3_ACCESS_KEY_ID, S3_SECRET_ACCESS_KEY and
+	// S3_ENDPOINT_URL for tensorizer, or AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY for
+	// runai_streamer. Not needed with spec.identity workload identity.
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	LoadCredentialsSecret string `json:"loadCredentialsSecret,omitempty"`
+
+	// adapters are LoRA adapters served next to the model, each under its own model name.
+	// The controller loads and unloads them on every replica through the vllm runtime
+	// adapter API, so changing the list does not restart the engine. Requires the vllm engine.
+	// +kubebuilder:validation:MaxItems=64
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Adapters []LoRAAdapter `json:"adapters,omitempty"`
+}
+
+// LoRAAdapter is a LoRA adapter of the model
+type LoRAAdapter struct {
+	// name is the model name clients request the adapter by
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=128
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9][A-Za-z0-9._/-]*$`
+	Name string `json:"name"`
+
+	// source is the adapter directory in the engine container, e.g. on a spec.model.storage
+	// volume, or a HuggingFace repository ID
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=512
+	Source string `json:"source"`
+}
+
+// ChatTemplateSource is a chat template set inline or read from a ConfigMap.
+// Exactly one of inline and configMapKeyRef must be set.
+type ChatTemplateSource struct {
+	// inline is the Jinja chat template
+	// +kubebuilder:validation:MaxLength=65536
+	// +optional
+	Inline string `json:"inline,omitempty"`
+
+	// configMapKeyRef selects the key of a ConfigMap in the same namespace holding the chat template
+	// The template is read when the engine starts, so pods must be restarted after editing the ConfigMap
+	// +optional
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
 }
 
 // ProviderSpec defines the provider selection
@@ -189,6 +370,16 @@ type ProviderSpec struct {
 	// +kubebuilder:pruning:PreserveUnknownFields
 	// +optional
 	Overrides *runtime.RawExtension `json:"overrides,omitempty"`
+
+	// migrateTo moves a deployment to another provider without downtime. The controller
+	// brings the deployment up on the new provider while the current one keeps serving,
+	// waits for it to be Running and to answer /v1/models, switches the gateway to it, and
+	// then has the old provider delete its resources. Progress is reported in
+	// status.migration and the Migrating and ServingVerified conditions. The deployment
+	// stays on this provider once the migration completes.
+	// +kubebuilder:validation:MaxLength=63
+	// +optional
+	MigrateTo string `json:"migrateTo,omitempty"`
 }
 
 // EngineSpec defines the inference engine configuration
@@ -198,6 +389,12 @@ type EngineSpec struct {
 	// +optional
 	Type EngineType `json:"type,omitempty"`
 
+	// device selects GPU or CPU inference. Defaults to auto, which uses the GPU
+	// when resources.gpu.count > 0 and the CPU otherwise.
+	// Set cpu to run vllm on its CPU backend; the selected provider must support it.
+	// +optional
+	Device EngineDevice `json:"device,omitempty"`
+
 	// contextLength is the maximum context length
 	// Maps to engine-specific flags (--max-model-len for vllm, etc.)
 	// +optional
@@ -226,14 +423,152 @@ type EngineSpec struct {
 	// These are passed directly to the engine and vary by type
 	// +optional
 	Args map[string]string `json:"args,omitempty"`
+
+	// remediation lets the controller change engine settings when the engine
+	// crash-loops with a recognized error. Only applicable for vllm and sglang engines.
+	// +optional
+	Remediation *EngineRemediationSpec `json:"remediation,omitempty"`
+}
+
+// EngineRemediationSpec configures crash-loop remediation. When an engine container is
+// in CrashLoopBackOff, the controller reads its previous logs and applies the next
+// fallback for the error it recognizes: a lower GPU memory fraction or context length
+// for CUDA out-of-memory errors, a lower context length when the KV cache cannot hold
+// it, or float16 for an unsupported dtype. Each attempt is recorded in
+// status.remediation.
+type EngineRemediationSpec struct {
+	// enabled turns remediation on
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// maxAttempts is how many fallbacks the controller applies before giving up
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=5
+	// +optional
+	MaxAttempts int32 `json:"maxAttempts,omitempty"`
 }
 
+// RemediationReason is an engine error crash-loop remediation recognizes
+type RemediationReason string
+
+const (
+	// RemediationReasonCUDAOutOfMemory means the engine ran out of GPU memory
+	RemediationReasonCUDAOutOfMemory RemediationReason = "CUDAOutOfMemory"
+	// RemediationReasonKVCacheTooSmall means the KV cache cannot hold a sequence of the
+	// maximum context length
+	RemediationReasonKVCacheTooSmall RemediationReason = "KVCacheTooSmall"
+	// RemediationReasonUnsupportedDtype means the GPU does not support the model dtype
+	RemediationReasonUnsupportedDtype RemediationReason = "UnsupportedDtype"
+)
+
 // ServingSpec defines the serving mode configuration
 type ServingSpec struct {
-	// mode is the serving mode (aggregated or disaggregated)
+	// mode is the serving mode (aggregated or disaggregated).
+	// auto is resolved to one of them on admission; see the ServingModeSelected condition.
 	// +kubebuilder:default=aggregated
 	// +optional
 	Mode ServingMode `json:"mode,omitempty"`
+
+	// placement defines co-location constraints between prefill and decode workers.
+	// Only applicable in disaggregated mode.
+	// +optional
+	Placement *PlacementSpec `json:"placement,omitempty"`
+
+	// router configures the request router in front of the workers: the Dynamo
+	// frontend or the Ray Serve proxy. Provider overrides take precedence.
+	// +optional
+	Router *RouterSpec `json:"router,omitempty"`
+}
+
+// RouterMode is the strategy the router uses to pick a worker for a request
+// +kubebuilder:validation:Enum=kv;round-robin;none
+type RouterMode string
+
+const (
+	// RouterModeKV routes requests to the worker holding the most matching KV cache
+	RouterModeKV RouterMode = "kv"
+	// RouterModeRoundRobin spreads requests evenly across workers
+	RouterModeRoundRobin RouterMode = "round-robin"
+	// RouterModeNone routes requests to a random worker, without scoring workers
+	RouterModeNone RouterMode = "none"
+)
+
+// RouterSpec defines replicas and resources for the request router
+type RouterSpec struct {
+	// replicas is the number of router replicas
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// cpu is the CPU requirement for each router replica (e.g., "2")
+	// +optional
+	CPU string `json:"cpu,omitempty"`
+
+	// memory is the memory requirement for each router replica (e.g., "4Gi")
+	// +optional
+	Memory string `json:"memory,omitempty"`
+
+	// routerMode is the request routing strategy of the Dynamo frontend, or of the
+	// controller-managed Endpoint Picker (EPP) unless spec.gateway.eppConfig or
+	// spec.gateway.sessionAffinity is set. Changing it only restarts the router: the
+	// frontend or the EPP, never the model servers.
+	// +optional
+	RouterMode RouterMode `json:"routerMode,omitempty"`
+}
+
+// PlacementDomain defines the failure domain prefill and decode workers share
+// +kubebuilder:validation:Enum=zone;nvlinkDomain;node
+type PlacementDomain string
+
+const (
+	// PlacementDomainZone co-locates workers in the same availability zone
+	PlacementDomainZone PlacementDomain = "zone"
+	// PlacementDomainNVLinkDomain co-locates workers in the same NVLink domain
+	PlacementDomainNVLinkDomain PlacementDomain = "nvlinkDomain"
+	// PlacementDomainNode co-locates workers on the same node
+	PlacementDomainNode PlacementDomain = "node"
+)
+
+// Default node labels for each placement domain
+const (
+	TopologyKeyZone         = "topology.kubernetes.io/zone"
+	TopologyKeyNVLinkDomain = "nvidia.com/gpu.clique"
+	TopologyKeyNode         = "kubernetes.io/hostname"
+)
+
+// PlacementSpec defines co-location constraints for disaggregated workers.
+// Cross-domain KV cache transfer between prefill and decode is slow, so workers
+// are scheduled into the same failure domain via pod affinity.
+type PlacementSpec struct {
+	// colocate is the failure domain prefill and decode workers must share
+	// +kubebuilder:validation:Required
+	Colocate PlacementDomain `json:"colocate"`
+
+	// topologyKey overrides the node label identifying the failure domain.
+	// Defaults to topology.kubernetes.io/zone for zone, nvidia.com/gpu.clique for
+	// nvlinkDomain, and kubernetes.io/hostname for node.
+	// +optional
+	TopologyKey string `json:"topologyKey,omitempty"`
+
+	// preferred makes co-location a scheduling preference instead of a requirement
+	// +optional
+	Preferred bool `json:"preferred,omitempty"`
+}
+
+// ResolvedTopologyKey returns the node label used for the placement domain.
+func (p *PlacementSpec) ResolvedTopologyKey() string {
+	if p.TopologyKey != "" {
+		return p.TopologyKey
+	}
+	switch p.Colocate {
+	case PlacementDomainNVLinkDomain:
+		return TopologyKeyNVLinkDomain
+	case PlacementDomainNode:
+		return TopologyKeyNode
+	default:
+		return TopologyKeyZone
+	}
 }
 
 // GPUSpec defines GPU resource requirements
@@ -249,6 +584,24 @@ type GPUSpec struct {
 	// +kubebuilder:default="nvidia.com/gpu"
 	// +optional
 	Type string `json:"type,omitempty"`
+
+	// class names the GPU class (e.g. nvidia-h100) these GPUs are counted against in
+	// ModelDeploymentQuota limits. When unset, they are counted against type.
+	// It does not affect scheduling; use nodeSelector to place pods on matching nodes.
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`
+	// +optional
+	Class string `json:"class,omitempty"`
+
+	// types is an ordered preference of GPU models, matched against the nvidia.com/gpu.product
+	// node label (e.g. NVIDIA-H100-80GB-HBM3, NVIDIA-A100-SXM4-80GB). Pods only run on nodes
+	// with a listed model and prefer earlier ones; the controller records the first model
+	// with enough allocatable GPUs in status.gpuType and prefers it over the others.
+	// Only supported in spec.resources.gpu.
+	// +kubebuilder:validation:MaxItems=8
+	// +listType=set
+	// +optional
+	Types []string `json:"types,omitempty"`
 }
 
 // ResourceSpec defines resource requirements
@@ -264,6 +617,89 @@ type ResourceSpec struct {
 	// cpu is the CPU requirement (e.g., "4")
 	// +optional
 	CPU string `json:"cpu,omitempty"`
+
+	// requests sets the container cpu and memory requests explicitly. Providers map the
+	// memory and cpu shorthand to requests, limits, or both; requests takes precedence.
+	// +optional
+	Requests *ResourceQuantities `json:"requests,omitempty"`
+
+	// limits sets the container cpu and memory limits explicitly, taking precedence over
+	// the memory and cpu shorthand.
+	// +optional
+	Limits *ResourceQuantities `json:"limits,omitempty"`
+
+	// autotune applies status.recommendations to cpu and memory once enough usage
+	// has been observed, clamped to autotuneBounds. Requires the controller to run
+	// with --enable-resource-recommender.
+	// +optional
+	Autotune bool `json:"autotune,omitempty"`
+
+	// autotuneBounds limits the cpu and memory values autotune may apply.
+	// Required when autotune is enabled.
+	// +optional
+	AutotuneBounds *AutotuneBounds `json:"autotuneBounds,omitempty"`
+
+	// cpuPinning reserves exclusive CPU cores for CPU inference. The admission webhook sets
+	// cpu and memory requests equal to limits, so the pods get Guaranteed QoS and the
+	// static CPU manager of the kubelet pins them to whole cores.
+	// +optional
+	CPUPinning *CPUPinningSpec `json:"cpuPinning,omitempty"`
+}
+
+// NUMAPolicy defines how the pinned cores of a replica are placed across NUMA nodes
+// +kubebuilder:validation:Enum=none;single-numa-node
+type NUMAPolicy string
+
+const (
+	// NUMAPolicyNone places the pinned cores on any NUMA node
+	NUMAPolicyNone NUMAPolicy = "none"
+	// NUMAPolicySingleNUMANode requests the cores and memory of a replica from one NUMA
+	// node, which the nodes enforce with the single-numa-node topology manager policy
+	NUMAPolicySingleNUMANode NUMAPolicy = "single-numa-node"
+)
+
+// CPUPinningSpec defines the exclusive CPU cores of each replica
+type CPUPinningSpec struct {
+	// exclusiveCores is the number of whole CPU cores reserved for each replica
+	// Defaults to the cpu request rounded up
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ExclusiveCores int32 `json:"exclusiveCores,omitempty"`
+
+	// numaPolicy places the cores of a replica on a single NUMA node, or on any
+	// Defaults to single-numa-node
+	// +optional
+	NUMAPolicy NUMAPolicy `json:"numaPolicy,omitempty"`
+}
+
+// ResourceQuantities defines cpu and memory quantities for container requests or limits
+type ResourceQuantities struct {
+	// cpu is the CPU quantity (e.g., "4")
+	// +optional
+	CPU string `json:"cpu,omitempty"`
+
+	// memory is the memory quantity (e.g., "32Gi")
+	// +optional
+	Memory string `json:"memory,omitempty"`
+}
+
+// AutotuneBounds defines the range autotune may set cpu and memory within
+type AutotuneBounds struct {
+	// minCPU is the lowest CPU value autotune may apply (e.g., "1")
+	// +optional
+	MinCPU string `json:"minCPU,omitempty"`
+
+	// maxCPU is the highest CPU value autotune may apply (e.g., "16")
+	// +optional
+	MaxCPU string `json:"maxCPU,omitempty"`
+
+	// minMemory is the lowest memory value autotune may apply (e.g., "8Gi")
+	// +optional
+	MinMemory string `json:"minMemory,omitempty"`
+
+	// maxMemory is the highest memory value autotune may apply (e.g., "64Gi")
+	// +optional
+	MaxMemory string `json:"maxMemory,omitempty"`
 }
 
 // ComponentScalingSpec defines scaling for prefill/decode components
@@ -283,6 +719,44 @@ type ComponentScalingSpec struct {
 	// Required for disaggregated mode
 	// +optional
 	Memory string `json:"memory,omitempty"`
+
+	// requests sets this component's container cpu and memory requests explicitly,
+	// taking precedence over memory
+	// +optional
+	Requests *ResourceQuantities `json:"requests,omitempty"`
+
+	// limits sets this component's container cpu and memory limits explicitly,
+	// taking precedence over memory
+	// +optional
+	Limits *ResourceQuantities `json:"limits,omitempty"`
+
+	// image overrides spec.image for this component's workers, e.g. a NIXL-enabled
+	// runtime build for prefill. Only applicable in disaggregated mode.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// scheduling constrains where this component's workers run, e.g. prefill on H100
+	// nodes and decode on A100 nodes. Only applicable in disaggregated mode.
+	// +optional
+	Scheduling *ComponentSchedulingSpec `json:"scheduling,omitempty"`
+}
+
+// ComponentSchedulingSpec defines scheduling constraints for the workers of one
+// disaggregated component, on top of the deployment-wide constraints
+type ComponentSchedulingSpec struct {
+	// nodeSelector is merged into spec.nodeSelector for this component, taking
+	// precedence on conflicting keys
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// tolerations are added to spec.tolerations for this component
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// affinity is the affinity of this component's pods. Pod affinity terms from
+	// spec.serving.placement are added to it.
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
 }
 
 // ScalingSpec defines the scaling configuration
@@ -300,6 +774,21 @@ type ScalingSpec struct {
 	// decode defines decode worker configuration for disaggregated mode
 	// +optional
 	Decode *ComponentScalingSpec `json:"decode,omitempty"`
+
+	// podDeletionCost sets the controller.kubernetes.io/pod-deletion-cost annotation of
+	// the model server pods from their in-flight requests and KV cache usage, so a
+	// scale-down removes the coldest replica rather than a busy one. Only workloads
+	// scaled through a ReplicaSet honor the annotation.
+	// +optional
+	PodDeletionCost bool `json:"podDeletionCost,omitempty"`
+}
+
+// MultinodeSpec defines multi-node inference of one model server replica
+type MultinodeSpec struct {
+	// nodeCount is the number of nodes each replica runs on
+	// +kubebuilder:validation:Minimum=2
+	// +kubebuilder:validation:Required
+	NodeCount int32 `json:"nodeCount"`
 }
 
 // PodTemplateMetadata defines metadata for created pods
@@ -325,6 +814,31 @@ type SecretsSpec struct {
 	// huggingFaceToken is the name of the Kubernetes secret containing HF_TOKEN
 	// +optional
 	HuggingFaceToken string `json:"huggingFaceToken,omitempty"`
+
+	// rolloutOnChange restarts the model pods when a Secret the deployment references
+	// changes: huggingFaceToken, the Secrets of spec.env, and spec.caching.kv
+	// connectionSecretRef. Without it, pods keep the values they started with until they
+	// are deleted.
+	// +optional
+	RolloutOnChange bool `json:"rolloutOnChange,omitempty"`
+}
+
+// IdentitySpec defines the Kubernetes identity model pods run as, so cloud workload
+// identity (Azure Workload Identity, EKS IRSA, GKE Workload Identity) can grant access
+// to model weights in object storage without long-lived keys in Secrets
+type IdentitySpec struct {
+	// serviceAccountName is an existing ServiceAccount in the ModelDeployment namespace,
+	// already annotated for workload identity
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+
+	// annotations are workload identity annotations for a ServiceAccount the controller
+	// creates with the ModelDeployment name, e.g. azure.workload.identity/client-id,
+	// eks.amazonaws.com/role-arn, or iam.gke.io/gcp-service-account.
+	// Mutually exclusive with serviceAccountName.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
 }
 
 // GatewaySpec defines the Gateway API integration configuration
@@ -337,107 +851,960 @@ type GatewaySpec struct {
 	// Defaults to spec.model.servedName or spec.model.id
 	// +optional
 	ModelName string `json:"modelName,omitempty"`
+	// modelNameTemplate is a Go template for the public model name clients send, e.g.
+	// "{{ .Namespace }}/{{ .ModelName }}". Available fields are .Namespace, .Name, and
+	// .ModelName (the name the engine serves). When the rendered name differs from the
+	// served name, the controller creates an InferenceModelRewrite mapping one to the other,
+	// so tenants can deploy the same model without gateway model-name collisions.
+	// +optional
+	ModelNameTemplate string `json:"modelNameTemplate,omitempty"`
+	// modelAliases are additional model names clients may send, e.g. the names of models the
+	// deployment replaced. The generated HTTPRoute matches them, and an
+	// InferenceModelRewrite maps them to the served name, so clients using legacy model
+	// names keep working after backend upgrades.
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=256
+	// +listType=set
+	// +optional
+	ModelAliases []string `json:"modelAliases,omitempty"`
 	// httpRouteRef references an existing HTTPRoute by name instead of auto-creating one.
 	// When set, the controller skips HTTPRoute creation and uses the referenced route.
-	// The HTTPRoute must be in the same namespace as the ModelDeployment.
+	// The HTTPRoute must be in the same namespace as the ModelDeployment, route to its
+	// InferencePool, and be accepted by a Gateway; otherwise GatewayReady is False.
 	// +optional
 	HTTPRouteRef string `json:"httpRouteRef,omitempty"`
+	// streaming tunes the generated route for long-lived streaming responses (SSE).
+	// When true, the default request timeout is raised.
+	// +optional
+	Streaming bool `json:"streaming,omitempty"`
+	// timeout is the HTTPRoute request timeout (e.g. "300s", "1h").
+	// Defaults to 300s, or 1h when streaming is enabled. "0s" disables the timeout.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// rateLimit caps request rate and concurrency for this model at the gateway.
+	// Programmed through implementation-specific policies (Envoy Gateway, kgateway).
+	// +optional
+	RateLimit *RateLimitSpec `json:"rateLimit,omitempty"`
+	// eppConfig is the EndpointPickerConfig YAML loaded by the controller-created Endpoint
+	// Picker (EPP). Defaults to a config generated from sessionAffinity and engineMetrics.
+	// Changes roll the EPP Deployment so the new config takes effect. Ignored when the
+	// provider manages its own EPP.
+	// +kubebuilder:validation:MaxLength=65536
+	// +optional
+	EPPConfig string `json:"eppConfig,omitempty"`
+	// sessionAffinity selects how the controller-created EPP spreads requests over replicas.
+	// prefixCache routes requests sharing a prompt prefix, such as a long system prompt or the
+	// earlier turns of a chat, to the replica that already holds its KV cache; none balances
+	// by queue depth and KV cache utilization only. When unset, queue depth is weighted
+	// highest, then KV cache utilization, then prefix cache hits.
+	// Cannot be combined with eppConfig. Ignored when the provider manages its own EPP.
+	// +optional
+	SessionAffinity SessionAffinity `json:"sessionAffinity,omitempty"`
+	// engineMetrics makes the generated EPP config scrape the queue depth, running requests,
+	// and KV cache utilization of the engine by their own metric names, through the GAIE data
+	// layer. Without it the EPP reads the vLLM metric names, so replicas of other engines
+	// score the same. Ignored with eppConfig, for engines without known metrics, and when the
+	// provider manages its own EPP.
+	// +optional
+	EngineMetrics bool `json:"engineMetrics,omitempty"`
+	// responseHeaders lists the standard headers the generated HTTPRoute adds to every
+	// response, for tracing and per-model billing at the edge: model (X-AIRunway-Model, the
+	// public model name), deployment (X-AIRunway-Deployment, as namespace/name), and provider
+	// (X-AIRunway-Provider, once a provider is selected). Ignored with httpRouteRef.
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=3
+	// +optional
+	ResponseHeaders []ResponseHeader `json:"responseHeaders,omitempty"`
+	// promptPolicy rewrites requests at the gateway, so guardrails apply to every client
+	// without changing client code. It is enforced by an external processor the controller
+	// deploys next to the model, which requires Envoy Gateway.
+	// +optional
+	PromptPolicy *PromptPolicySpec `json:"promptPolicy,omitempty"`
+	// guardrails screens requests with a content moderation service before they reach the
+	// InferencePool. It is enforced by the same external processor as promptPolicy, which
+	// requires Envoy Gateway.
+	// +optional
+	Guardrails *GuardrailsSpec `json:"guardrails,omitempty"`
+	// responseCache answers repeated identical requests from a cache at the gateway instead
+	// of the model, for workloads such as eval suites that send the same prompts again. It
+	// is served by the same external processor as promptPolicy, which requires Envoy Gateway.
+	// +optional
+	ResponseCache *ResponseCacheSpec `json:"responseCache,omitempty"`
+	// fallback is a secondary backend the generated HTTPRoute sends requests to while the
+	// deployment is not Running, and in part while its replicas are saturated, so clients
+	// degrade gracefully instead of receiving 503s. Cannot be combined with httpRouteRef.
+	// +optional
+	Fallback *GatewayFallbackSpec `json:"fallback,omitempty"`
 }
 
-// ModelDeploymentSpec defines the desired state of ModelDeployment
-type ModelDeploymentSpec struct {
-	// model defines the model specification
-	// +kubebuilder:validation:Required
-	Model ModelSpec `json:"model"`
-
-	// provider defines the provider selection
+// GatewayFallbackSpec configures the fallback backend of the generated HTTPRoute.
+// Exactly one of backendRef and url must be set.
+type GatewayFallbackSpec struct {
+	// backendRef is an InferencePool or Service in the namespace of the deployment, such as
+	// the InferencePool of another ModelDeployment serving the same model
 	// +optional
-	Provider *ProviderSpec `json:"provider,omitempty"`
+	BackendRef *FallbackBackendRef `json:"backendRef,omitempty"`
 
-	// engine defines the inference engine configuration
+	// url is the base URL of an external OpenAI-compatible API, such as
+	// https://api.example.com/openai. The controller routes to it through an ExternalName
+	// Service and, for https, a BackendTLSPolicy. Request paths are appended to the URL path.
+	// +kubebuilder:validation:MaxLength=2048
+	// +kubebuilder:validation:Pattern=`^https?://`
 	// +optional
-	Engine EngineSpec `json:"engine,omitempty"`
+	URL string `json:"url,omitempty"`
 
-	// serving defines the serving mode configuration
+	// overflowPercent is the share of requests sent to the fallback while the replicas are
+	// saturated. 0 only uses the fallback while the deployment is not Running. Defaults to 20.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
 	// +optional
-	Serving *ServingSpec `json:"serving,omitempty"`
+	OverflowPercent *int32 `json:"overflowPercent,omitempty"`
 
-	// scaling defines the scaling configuration
+	// saturationThreshold is the mean KV cache utilization of the replicas, in percent, at
+	// which they are saturated. Defaults to 90.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
 	// +optional
-	Scaling *ScalingSpec `json:"scaling,omitempty"`
+	SaturationThreshold *int32 `json:"saturationThreshold,omitempty"`
+}
 
-	// resources defines the resource requirements
-	// Not allowed in disaggregated mode (use scaling.prefill/decode instead)
-	// +optional
-	Resources *ResourceSpec `json:"resources,omitempty"`
+// FallbackBackendKind is the kind of a fallback backendRef
+// +kubebuilder:validation:Enum=InferencePool;Service
+type FallbackBackendKind string
 
-	// image is a custom container image
-	// +optional
-	Image string `json:"image,omitempty"`
+const (
+	// FallbackBackendKindInferencePool routes to an InferencePool
+	FallbackBackendKindInferencePool FallbackBackendKind = "InferencePool"
+	// FallbackBackendKindService routes to a Service port
+	FallbackBackendKindService FallbackBackendKind = "Service"
+)
 
-	// env defines environment variables for the container
+// FallbackBackendRef references the fallback backend in the namespace of the deployment
+type FallbackBackendRef struct {
+	// kind of the backend. Defaults to InferencePool.
 	// +optional
-	Env []corev1.EnvVar `json:"env,omitempty"`
+	Kind FallbackBackendKind `json:"kind,omitempty"`
 
-	// podTemplate defines pod customization
-	// +optional
-	PodTemplate *PodTemplateSpec `json:"podTemplate,omitempty"`
+	// name of the backend
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name"`
 
-	// secrets defines secret references
+	// port of the Service. Required when kind is Service.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
 	// +optional
-	Secrets *SecretsSpec `json:"secrets,omitempty"`
+	Port *int32 `json:"port,omitempty"`
+}
 
-	// gateway defines the Gateway API integration configuration
+// GatewayFallbackState is how the generated HTTPRoute uses spec.gateway.fallback
+type GatewayFallbackState string
+
+const (
+	// GatewayFallbackStandby routes every request to the deployment
+	GatewayFallbackStandby GatewayFallbackState = "Standby"
+	// GatewayFallbackOverflow sends overflowPercent of requests to the fallback while the
+	// replicas are saturated
+	GatewayFallbackOverflow GatewayFallbackState = "Overflow"
+	// GatewayFallbackActive routes every request to the fallback while the deployment is not Running
+	GatewayFallbackActive GatewayFallbackState = "Active"
+)
+
+// ResponseCacheSpec configures the gateway response cache
+type ResponseCacheSpec struct {
+	// ttl is how long a response is served from the cache. Defaults to 5m.
 	// +optional
-	Gateway *GatewaySpec `json:"gateway,omitempty"`
+	TTL *metav1.Duration `json:"ttl,omitempty"`
 
-	// nodeSelector constrains scheduling to nodes with specific labels
+	// maxEntries bounds the number of cached responses. The least recently used response
+	// is evicted first. Defaults to 1000.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100000
 	// +optional
-	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	MaxEntries int32 `json:"maxEntries,omitempty"`
 
-	// tolerations are tolerations for the pods
+	// normalizeWhitespace collapses whitespace in the prompt and messages content and
+	// ignores field order when keying requests, so requests that only differ in formatting
+	// share a cached response. By default requests are keyed by their exact body.
 	// +optional
-	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	NormalizeWhitespace bool `json:"normalizeWhitespace,omitempty"`
 }
 
-// ProviderStatus contains information about the selected provider
-type ProviderStatus struct {
-	// name is the selected provider name
-	// +optional
-	Name string `json:"name,omitempty"`
+// GuardrailsSpec configures request screening by a content moderation service
+type GuardrailsSpec struct {
+	// endpoint is the URL of an OpenAI-compatible moderation API, such as
+	// http://llama-guard.safety:8000/v1/moderations. The processor posts the text of each
+	// request as {"input": "..."} and reads results[].flagged from the response.
+	// +kubebuilder:validation:MaxLength=2048
+	// +kubebuilder:validation:Pattern=`^https?://`
+	Endpoint string `json:"endpoint"`
 
-	// resourceName is the name of the created provider resource
+	// mode is what happens to flagged requests: block rejects them with a 400 content_filter
+	// error, flag forwards them with the X-AIRunway-Guardrails: flagged header. Defaults to block.
 	// +optional
-	ResourceName string `json:"resourceName,omitempty"`
+	Mode GuardrailsMode `json:"mode,omitempty"`
 
-	// resourceKind is the kind of the created provider resource
+	// failureMode is what happens when the moderation service or the processor fails or
+	// times out: closed rejects requests with 503, open forwards them unscreened. Defaults
+	// to closed.
 	// +optional
-	ResourceKind string `json:"resourceKind,omitempty"`
+	FailureMode GuardrailsFailureMode `json:"failureMode,omitempty"`
 
-	// selectedReason explains why this provider was selected
+	// timeout of the moderation request. Defaults to 5s.
 	// +optional
-	SelectedReason string `json:"selectedReason,omitempty"`
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
 }
 
-// ReplicaStatus contains replica count information
-type ReplicaStatus struct {
-	// desired is the desired number of replicas
+// GuardrailsMode is what happens to requests the moderation service flags
+// +kubebuilder:validation:Enum=block;flag
+type GuardrailsMode string
+
+const (
+	// GuardrailsModeBlock rejects flagged requests
+	GuardrailsModeBlock GuardrailsMode = "block"
+	// GuardrailsModeFlag forwards flagged requests with a header
+	GuardrailsModeFlag GuardrailsMode = "flag"
+)
+
+// GuardrailsFailureMode is what happens to requests that cannot be screened
+// +kubebuilder:validation:Enum=open;closed
+type GuardrailsFailureMode string
+
+const (
+	// GuardrailsFailureModeOpen forwards requests that cannot be screened
+	GuardrailsFailureModeOpen GuardrailsFailureMode = "open"
+	// GuardrailsFailureModeClosed rejects requests that cannot be screened
+	GuardrailsFailureModeClosed GuardrailsFailureMode = "closed"
+)
+
+// PromptPolicySpec defines the changes the gateway makes to OpenAI-compatible requests
+type PromptPolicySpec struct {
+	// systemPrompt is prepended as a system message to the messages of chat completion
+	// requests, before any system message the client sends
+	// +kubebuilder:validation:MaxLength=32768
 	// +optional
-	Desired int32 `json:"desired,omitempty"`
+	SystemPrompt string `json:"systemPrompt,omitempty"`
 
-	// ready is the number of ready replicas
+	// maxTokens caps max_tokens and max_completion_tokens. Requests asking for more, or
+	// setting neither, are limited to maxTokens.
+	// +kubebuilder:validation:Minimum=1
 	// +optional
-	Ready int32 `json:"ready,omitempty"`
+	MaxTokens *int32 `json:"maxTokens,omitempty"`
 
-	// available is the number of available replicas
+	// stop sequences are added to the stop sequences of every request
+	// +kubebuilder:validation:MaxItems=4
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=256
 	// +optional
-	Available int32 `json:"available,omitempty"`
+	Stop []string `json:"stop,omitempty"`
 }
 
-// EndpointStatus contains service endpoint information
-type EndpointStatus struct {
-	// service is the name of the service
-	// +optional
+// SessionAffinity is how the Endpoint Picker keeps related requests on one replica
+// +kubebuilder:validation:Enum=prefixCache;none
+type SessionAffinity string
+
+const (
+	// SessionAffinityPrefixCache prefers the replica with the longest cached prompt prefix
+	SessionAffinityPrefixCache SessionAffinity = "prefixCache"
+	// SessionAffinityNone picks replicas by load only
+	SessionAffinityNone SessionAffinity = "none"
+)
+
+// ResponseHeader is a standard response header added by the gateway
+// +kubebuilder:validation:Enum=model;deployment;provider
+type ResponseHeader string
+
+const (
+	// ResponseHeaderModel adds the public model name
+	ResponseHeaderModel ResponseHeader = "model"
+	// ResponseHeaderDeployment adds the ModelDeployment namespace and name
+	ResponseHeaderDeployment ResponseHeader = "deployment"
+	// ResponseHeaderProvider adds the selected provider
+	ResponseHeaderProvider ResponseHeader = "provider"
+)
+
+// RateLimitSpec defines per-model request rate and concurrency limits
+type RateLimitSpec struct {
+	// requestsPerMinute is the sustained number of requests allowed per minute
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RequestsPerMinute int32 `json:"requestsPerMinute,omitempty"`
+
+	// burst is the number of requests allowed above requestsPerMinute in a short spike
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Burst int32 `json:"burst,omitempty"`
+
+	// maxConcurrent is the maximum number of in-flight requests to the model
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConcurrent int32 `json:"maxConcurrent,omitempty"`
+}
+
+// GangScheduler is a batch scheduler that can place the pods of a deployment as one gang
+// +kubebuilder:validation:Enum=kueue;volcano;kai
+type GangScheduler string
+
+const (
+	// GangSchedulerKueue admits the pods as a Kueue pod group
+	GangSchedulerKueue GangScheduler = "kueue"
+	// GangSchedulerVolcano schedules the pods as a Volcano PodGroup
+	GangSchedulerVolcano GangScheduler = "volcano"
+	// GangSchedulerKAI schedules the pods with the NVIDIA KAI scheduler
+	GangSchedulerKAI GangScheduler = "kai"
+)
+
+// SchedulingSpec configures how the model server pods are placed by a batch scheduler
+type SchedulingSpec struct {
+	// gang schedules all model server pods of the deployment together or not at all, so a
+	// partially scheduled deployment cannot hold GPUs while its remaining workers wait.
+	// Requires scheduler.
+	// +optional
+	Gang bool `json:"gang,omitempty"`
+
+	// scheduler is the batch scheduler that enforces the gang
+	// +optional
+	Scheduler GangScheduler `json:"scheduler,omitempty"`
+
+	// schedulerName overrides the pod spec.schedulerName. Defaults to volcano for the
+	// volcano scheduler and kai-scheduler for kai; kueue pods keep the default scheduler.
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	SchedulerName string `json:"schedulerName,omitempty"`
+
+	// queue is the Kueue LocalQueue, Volcano queue, or KAI queue the pods are submitted to.
+	// Required for kueue and kai; volcano uses its default queue when unset.
+	// +kubebuilder:validation:MaxLength=63
+	// +optional
+	Queue string `json:"queue,omitempty"`
+
+	// kueueAdmission creates a Kueue Workload for the deployment's GPUs in the queue LocalQueue
+	// and holds the deployment in the Queued phase, before provider resources are created,
+	// until Kueue admits it. Requires queue.
+	// +optional
+	KueueAdmission bool `json:"kueueAdmission,omitempty"`
+}
+
+// WarmupSpec defines synthetic requests the controller sends once a deployment is Running,
+// so the first user request does not pay compilation and cache warmup costs
+type WarmupSpec struct {
+	// requests is the number of /v1/completions requests to send, one after another
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	Requests int32 `json:"requests,omitempty"`
+
+	// prompt is a Go template for the prompt of each request. Available fields are
+	// .Index (0-based request number) and .Model (the served model name), e.g.
+	// "Request {{ .Index }}: write a haiku". Defaults to "Hello".
+	// +kubebuilder:validation:MaxLength=4096
+	// +optional
+	Prompt string `json:"prompt,omitempty"`
+
+	// maxTokens is the number of tokens to generate per request
+	// +kubebuilder:default=16
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=4096
+	// +optional
+	MaxTokens int32 `json:"maxTokens,omitempty"`
+}
+
+// KVCacheBackend defines the backend of the shared KV cache
+// +kubebuilder:validation:Enum=lmcache;redis
+type KVCacheBackend string
+
+const (
+	// KVCacheBackendLMCache caches KV blocks with LMCache, in CPU memory of each engine pod
+	// and, with a connection secret, in a shared LMCache server
+	KVCacheBackendLMCache KVCacheBackend = "lmcache"
+	// KVCacheBackendRedis caches KV blocks with LMCache in a Redis server
+	KVCacheBackendRedis KVCacheBackend = "redis"
+)
+
+// CachingSpec configures caching of inference state across requests
+type CachingSpec struct {
+	// kv configures a KV cache the engine reuses across requests and pods through the
+	// LMCache KV connector. Supported by the vllm engine.
+	// +optional
+	KV *KVCacheSpec `json:"kv,omitempty"`
+}
+
+// KVCacheSpec configures the LMCache KV cache
+type KVCacheSpec struct {
+	// backend is the KV cache backend
+	// +kubebuilder:validation:Required
+	Backend KVCacheBackend `json:"backend"`
+
+	// connectionSecretRef selects a key of a Secret in the deployment's namespace holding
+	// the URL of the remote cache, e.g. lm://lmcache-server.cache:65432 for lmcache or
+	// redis://redis.cache:6379 for redis. Required for redis.
+	// +optional
+	ConnectionSecretRef *corev1.SecretKeySelector `json:"connectionSecretRef,omitempty"`
+
+	// maxSize is the CPU memory each engine pod caches KV blocks in (e.g., "20Gi").
+	// It counts against the container memory limit. Defaults to the LMCache default.
+	// +optional
+	MaxSize *resource.Quantity `json:"maxSize,omitempty"`
+}
+
+// KVCacheStatus reports prefix cache hit rates read from the engine metrics of the model
+// server pods, cumulative since the pods started
+type KVCacheStatus struct {
+	// prefixHitPercent is the percentage of prompt tokens served from the engine's
+	// prefix cache in GPU memory
+	// +optional
+	PrefixHitPercent *int32 `json:"prefixHitPercent,omitempty"`
+
+	// externalHitPercent is the percentage of tokens looked up in LMCache that were found
+	// +optional
+	ExternalHitPercent *int32 `json:"externalHitPercent,omitempty"`
+}
+
+// PodPlacement reports where a model server pod runs
+type PodPlacement struct {
+	// pod is the name of the pod
+	Pod string `json:"pod"`
+
+	// node is the name of the node the pod runs on
+	Node string `json:"node"`
+
+	// gpus is the number of GPUs the pod requests
+	// +optional
+	GPUs int32 `json:"gpus,omitempty"`
+
+	// gpuType is the GPU product of the node, from its nvidia.com/gpu.product label
+	// +optional
+	GPUType string `json:"gpuType,omitempty"`
+
+	// gpuIndices are the indices of the GPUs attached to the pod on its node. Only
+	// populated when the controller runs with --dcgm-exporter-namespace.
+	// +listType=atomic
+	// +optional
+	GPUIndices []string `json:"gpuIndices,omitempty"`
+}
+
+// AdapterStatus reports on how many replicas a spec.model.adapters entry is loaded
+type AdapterStatus struct {
+	// name is the adapter name
+	Name string `json:"name"`
+
+	// loadedReplicas is the number of running replicas serving the adapter
+	LoadedReplicas int32 `json:"loadedReplicas"`
+}
+
+// MetricsSnapshot is a summary of the golden signals of a deployment over the interval
+// between two scrapes of its engine metrics
+type MetricsSnapshot struct {
+	// time is when the engine metrics were scraped
+	Time metav1.Time `json:"time"`
+
+	// window is the interval the snapshot covers
+	Window metav1.Duration `json:"window"`
+
+	// requestsPerSecond is the rate of completed requests, as a decimal string (e.g. 12.5)
+	// Unset for engines that do not count requests
+	// +optional
+	RequestsPerSecond string `json:"requestsPerSecond,omitempty"`
+
+	// p50Latency is the median end-to-end request latency
+	// Unset when no requests completed in the window
+	// +optional
+	P50Latency *metav1.Duration `json:"p50Latency,omitempty"`
+
+	// p95Latency is the 95th percentile end-to-end request latency
+	// Unset when no requests completed in the window
+	// +optional
+	P95Latency *metav1.Duration `json:"p95Latency,omitempty"`
+
+	// errorRate is the percentage of completed requests that were aborted, as a decimal
+	// string (e.g. 0.5%)
+	// Unset for engines that do not count aborted requests, or when no requests completed
+	// +optional
+	ErrorRate string `json:"errorRate,omitempty"`
+}
+
+// ObservabilitySpec configures observability of inference traffic
+type ObservabilitySpec struct {
+	// tracing configures OpenTelemetry tracing of requests through the gateway
+	// +optional
+	Tracing *TracingSpec `json:"tracing,omitempty"`
+
+	// logSink ships the logs of the model server and EPP pods to a log backend
+	// +optional
+	LogSink *LogSinkSpec `json:"logSink,omitempty"`
+
+	// genAIMetrics records OpenTelemetry GenAI semantic convention metrics of requests
+	// through the gateway
+	// +optional
+	GenAIMetrics *GenAIMetricsSpec `json:"genAIMetrics,omitempty"`
+}
+
+// GenAIMetricsSpec configures the prompt policy external processor to record the token
+// usage, duration, and time to first token of requests through the gateway as
+// OpenTelemetry GenAI semantic convention metrics, served in the Prometheus format
+type GenAIMetricsSpec struct {
+	// enabled turns on the metrics
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// TracingSpec configures the controller-created Endpoint Picker (EPP) to join W3C
+// traceparent traces, or start new ones, and export spans tagged with the model name and
+// deployment over OTLP
+type TracingSpec struct {
+	// enabled turns on tracing
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// endpoint is the OTLP gRPC collector endpoint, e.g. http://otel-collector.observability:4317.
+	// Defaults to the controller's --tracing-endpoint.
+	// +kubebuilder:validation:MaxLength=2048
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// samplingPercent is the percentage of new traces sampled. Requests with a sampled
+	// traceparent are always traced.
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	SamplingPercent *int32 `json:"samplingPercent,omitempty"`
+}
+
+// LogSinkType is the protocol a log sink receives logs with
+// +kubebuilder:validation:Enum=loki;otlp
+type LogSinkType string
+
+const (
+	// LogSinkTypeLoki pushes logs to the Loki push API
+	LogSinkTypeLoki LogSinkType = "loki"
+	// LogSinkTypeOTLP pushes logs to an OTLP/HTTP collector
+	LogSinkTypeOTLP LogSinkType = "otlp"
+)
+
+// LogSinkSpec adds a Fluent Bit sidecar to the model server and EPP pods that tails the
+// logs of the other containers of its pod and pushes them to a log backend, labeled with
+// the namespace and name of the ModelDeployment and the pod name. The sidecar reads the
+// pod logs from the node, so the namespace must allow hostPath volumes.
+type LogSinkSpec struct {
+	// type is the protocol of the log backend
+	// +kubebuilder:validation:Required
+	Type LogSinkType `json:"type"`
+
+	// endpoint is the URL logs are pushed to, e.g. http://loki.observability:3100 or
+	// http://otel-collector.observability:4318. Without a path, /loki/api/v1/push is used
+	// for loki and /v1/logs for otlp.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=2048
+	Endpoint string `json:"endpoint"`
+
+	// labels are added to every log line, next to the namespace, model_deployment, and pod
+	// labels. Keys must be valid Loki label names.
+	// +kubebuilder:validation:MaxProperties=16
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// image is the Fluent Bit image of the sidecar.
+	// Defaults to the image the provider was built with.
+	// +kubebuilder:validation:MaxLength=512
+	// +optional
+	Image string `json:"image,omitempty"`
+}
+
+// ExposeType defines how a ModelDeployment is exposed without Gateway API
+// +kubebuilder:validation:Enum=ClusterIP;NodePort;LoadBalancer;Ingress
+type ExposeType string
+
+const (
+	// ExposeTypeClusterIP exposes the deployment on a stable in-cluster Service
+	ExposeTypeClusterIP ExposeType = "ClusterIP"
+	// ExposeTypeNodePort exposes the deployment on a port of every node
+	ExposeTypeNodePort ExposeType = "NodePort"
+	// ExposeTypeLoadBalancer exposes the deployment through a cloud load balancer
+	ExposeTypeLoadBalancer ExposeType = "LoadBalancer"
+	// ExposeTypeIngress exposes the deployment through an Ingress
+	ExposeTypeIngress ExposeType = "Ingress"
+)
+
+// ExposeSpec defines a Service, and optionally an Ingress, the controller creates in front
+// of the model server for clusters without Gateway API
+type ExposeSpec struct {
+	// type is how the deployment is exposed
+	// +kubebuilder:validation:Required
+	Type ExposeType `json:"type"`
+
+	// ingressClassName is the IngressClass of the Ingress. Only valid when type is Ingress.
+	// Defaults to the cluster's default IngressClass.
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	IngressClassName string `json:"ingressClassName,omitempty"`
+
+	// host is the host name the Ingress routes to the deployment. Only valid when type is
+	// Ingress. When empty, the Ingress matches any host.
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	Host string `json:"host,omitempty"`
+}
+
+// NetworkingSpec defines the IP families of the Services the controller and providers
+// create for a deployment, and its network isolation. Unset fields keep the cluster defaults.
+type NetworkingSpec struct {
+	// ipFamilyPolicy is the IP family policy of the Services
+	// +kubebuilder:validation:Enum=SingleStack;PreferDualStack;RequireDualStack
+	// +optional
+	IPFamilyPolicy *corev1.IPFamilyPolicy `json:"ipFamilyPolicy,omitempty"`
+
+	// ipFamilies are the IP families of the Services, in order of preference. The first
+	// family is the primary one.
+	// +kubebuilder:validation:MaxItems=2
+	// +kubebuilder:validation:items:Enum=IPv4;IPv6
+	// +optional
+	IPFamilies []corev1.IPFamily `json:"ipFamilies,omitempty"`
+
+	// isolate creates a NetworkPolicy for the model pods that only admits traffic from
+	// the deployment's namespace, the Gateway and the controller, and only allows egress
+	// to these namespaces, the provider's network dependencies, the log sink, DNS and
+	// HTTP(S) for model downloads. Requires a network plugin that enforces NetworkPolicies.
+	// +optional
+	Isolate bool `json:"isolate,omitempty"`
+
+	// egress adds egress rules to the NetworkPolicy of isolate, e.g. for a KV cache server
+	// in another namespace
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	Egress []networkingv1.NetworkPolicyEgressRule `json:"egress,omitempty"`
+}
+
+// ModelDeploymentSpec defines the desired state of ModelDeployment
+type ModelDeploymentSpec struct {
+	// model defines the model specification
+	// +kubebuilder:validation:Required
+	Model ModelSpec `json:"model"`
+
+	// provider defines the provider selection
+	// +optional
+	Provider *ProviderSpec `json:"provider,omitempty"`
+
+	// engine defines the inference engine configuration
+	// +optional
+	Engine EngineSpec `json:"engine,omitempty"`
+
+	// serving defines the serving mode configuration
+	// +optional
+	Serving *ServingSpec `json:"serving,omitempty"`
+
+	// scaling defines the scaling configuration
+	// +optional
+	Scaling *ScalingSpec `json:"scaling,omitempty"`
+
+	// multinode spreads each replica of the model server across several nodes, for
+	// models that do not fit on the GPUs of one node. Each node runs resources.gpu.count
+	// GPUs, which set the tensor parallel size, and the nodes form the pipeline stages.
+	// Only supported in aggregated mode.
+	// +optional
+	Multinode *MultinodeSpec `json:"multinode,omitempty"`
+
+	// resources defines the resource requirements
+	// Not allowed in disaggregated mode (use scaling.prefill/decode instead)
+	// +optional
+	Resources *ResourceSpec `json:"resources,omitempty"`
+
+	// image is a custom container image
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// env defines environment variables for the container
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// podTemplate defines pod customization
+	// +optional
+	PodTemplate *PodTemplateSpec `json:"podTemplate,omitempty"`
+
+	// labels are added to every resource and pod created for the deployment, e.g. for
+	// cost allocation. Keys in the airunway.ai, kubernetes.io, and k8s.io domains are not
+	// propagated, and labels set by the controller or providers take precedence.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// annotations are added to every resource and pod created for the deployment, with the
+	// same restrictions as labels
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// secrets defines secret references
+	// +optional
+	Secrets *SecretsSpec `json:"secrets,omitempty"`
+
+	// identity sets the ServiceAccount model pods, and model download Jobs, run as
+	// +optional
+	Identity *IdentitySpec `json:"identity,omitempty"`
+
+	// gateway defines the Gateway API integration configuration
+	// +optional
+	Gateway *GatewaySpec `json:"gateway,omitempty"`
+
+	// expose creates a stable Service, or Ingress, in front of the model server for
+	// clusters without Gateway API. The address is reported in status.expose.
+	// +optional
+	Expose *ExposeSpec `json:"expose,omitempty"`
+
+	// networking sets the IP families of the Services created for the deployment, for
+	// IPv6-only and dual-stack clusters
+	// +optional
+	Networking *NetworkingSpec `json:"networking,omitempty"`
+
+	// warmup sends synthetic requests to the deployment each time it becomes Running.
+	// Results are reported in status.warmup and the WarmedUp condition.
+	// +optional
+	Warmup *WarmupSpec `json:"warmup,omitempty"`
+
+	// caching configures a KV cache shared across requests and pods. Hit rates are
+	// reported in status.kvCache.
+	// +optional
+	Caching *CachingSpec `json:"caching,omitempty"`
+
+	// observability configures tracing of inference requests
+	// +optional
+	Observability *ObservabilitySpec `json:"observability,omitempty"`
+
+	// nodeSelector constrains scheduling to nodes with specific labels
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// tolerations are tolerations for the pods
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// scheduling configures gang scheduling of the model server pods through a batch
+	// scheduler (Kueue, Volcano, or KAI)
+	// +optional
+	Scheduling *SchedulingSpec `json:"scheduling,omitempty"`
+
+	// progressDeadlineSeconds is the maximum time the deployment may stay in the Deploying
+	// phase, for example while a model download or image pull is stuck. When exceeded, the
+	// phase becomes Failed with a Progressing=False condition (reason ProgressDeadlineExceeded).
+	// The deadline restarts when the spec changes. Unset means no deadline.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ProgressDeadlineSeconds *int32 `json:"progressDeadlineSeconds,omitempty"`
+
+	// ttlSecondsAfterCreation deletes the ModelDeployment this many seconds after it was
+	// created, reclaiming GPUs held by short-lived (e.g. hackathon or notebook) deployments.
+	// Unset means the deployment is never deleted for its age.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TTLSecondsAfterCreation *int32 `json:"ttlSecondsAfterCreation,omitempty"`
+
+	// ttlSecondsAfterLastRequest deletes the ModelDeployment once it has served no requests
+	// for this many seconds while Running. Activity is read from the engine request metrics
+	// of the model server pods and recorded in status.lastRequestTime; the idle clock starts
+	// when the deployment becomes Running. Unset means idle deployments are kept.
+	// +kubebuilder:validation:Minimum=60
+	// +optional
+	TTLSecondsAfterLastRequest *int32 `json:"ttlSecondsAfterLastRequest,omitempty"`
+
+	// paused stops the core and provider controllers from reconciling this deployment.
+	// Existing provider and gateway resources are left as they are until unpaused;
+	// deletion is still processed.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+}
+
+// ProviderStatus contains information about the selected provider
+type ProviderStatus struct {
+	// name is the selected provider name
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// resourceName is the name of the created provider resource
+	// +optional
+	ResourceName string `json:"resourceName,omitempty"`
+
+	// resourceKind is the kind of the created provider resource
+	// +optional
+	ResourceKind string `json:"resourceKind,omitempty"`
+
+	// selectedReason explains why this provider was selected
+	// +optional
+	SelectedReason string `json:"selectedReason,omitempty"`
+
+	// partialApply records the last apply of the provider resources that failed part way.
+	// It is cleared once all resources apply.
+	// +optional
+	PartialApply *PartialApplyStatus `json:"partialApply,omitempty"`
+
+	// defaultImage is the provider default image the resources were last applied with. It
+	// is empty when spec.image is set or the provider has no default image.
+	// +optional
+	DefaultImage string `json:"defaultImage,omitempty"`
+
+	// defaultImageTime is when defaultImage last replaced a previous default image
+	// +optional
+	DefaultImageTime *metav1.Time `json:"defaultImageTime,omitempty"`
+}
+
+// MigrationStatus contains the progress of a spec.provider.migrateTo migration
+type MigrationStatus struct {
+	// from is the provider the deployment is moving away from
+	From string `json:"from"`
+
+	// to is the provider the deployment is moving to
+	To string `json:"to"`
+
+	// phase is the current phase of the migration
+	Phase MigrationPhase `json:"phase"`
+
+	// fromEndpoint is the endpoint of the old provider, which the gateway keeps routing to
+	// until the new provider is verified
+	// +optional
+	FromEndpoint *EndpointStatus `json:"fromEndpoint,omitempty"`
+
+	// message is a human-readable message about the current phase
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// startTime is when the migration started
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// lastTransitionTime is when phase last changed
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// completionTime is when the migration completed or failed
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// InProgress reports whether the migration has not completed or failed yet
+func (m *MigrationStatus) InProgress() bool {
+	return m != nil && m.Phase != MigrationPhaseCompleted && m.Phase != MigrationPhaseFailed
+}
+
+// Switched reports whether the gateway routes to the new provider of the migration
+func (m *MigrationStatus) Switched() bool {
+	return m != nil && (m.Phase == MigrationPhaseSwitching || m.Phase == MigrationPhaseDraining ||
+		m.Phase == MigrationPhaseCompleted)
+}
+
+// PartialApplyStatus records which provider resources were applied when one failed.
+// Resources are listed as Kind.group/name.
+type PartialApplyStatus struct {
+	// failedResource is the resource that failed to apply
+	FailedResource string `json:"failedResource"`
+
+	// appliedResources are the resources applied before the failure, in apply order
+	// +optional
+	AppliedResources []string `json:"appliedResources,omitempty"`
+
+	// rolledBackResources are the applied resources that the failed apply created and
+	// then deleted again. Updated resources are not rolled back.
+	// +optional
+	RolledBackResources []string `json:"rolledBackResources,omitempty"`
+}
+
+// SelectionReport explains provider selection, written when the ModelDeployment has the
+// airunway.ai/selection-explain annotation
+type SelectionReport struct {
+	// engine is the engine type the providers were evaluated for
+	// +optional
+	Engine EngineType `json:"engine,omitempty"`
+
+	// selected is the provider the selection algorithm picks, whether or not the deployment
+	// uses it. It is empty when no provider is eligible.
+	// +optional
+	Selected string `json:"selected,omitempty"`
+
+	// strategy is the provider selection strategy that picks among the eligible providers
+	// +optional
+	Strategy string `json:"strategy,omitempty"`
+
+	// providers are the evaluations of every registered provider, sorted by name
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Providers []ProviderEvaluation `json:"providers,omitempty"`
+
+	// evaluatedTime is when the report was generated
+	// +optional
+	EvaluatedTime *metav1.Time `json:"evaluatedTime,omitempty"`
+}
+
+// ProviderEvaluation is the selection result for one provider
+type ProviderEvaluation struct {
+	// name is the InferenceProviderConfig name
+	Name string `json:"name"`
+
+	// eligible is true when the provider passes every criterion
+	Eligible bool `json:"eligible"`
+
+	// criteria are the capability checks, in evaluation order
+	// +optional
+	Criteria []SelectionCriterion `json:"criteria,omitempty"`
+
+	// rules are the results of the provider's CEL selection rules
+	// +optional
+	Rules []SelectionRuleResult `json:"rules,omitempty"`
+
+	// score is the highest priority of the matched rules. Eligible providers with a higher
+	// score win, with ties broken by name.
+	// +optional
+	Score int32 `json:"score,omitempty"`
+}
+
+// SelectionCriterion is the result of one capability check
+type SelectionCriterion struct {
+	// name is the criterion: Ready, Capabilities, Engine, Device, or ServingMode
+	Name string `json:"name"`
+
+	// passed is true when the provider meets the criterion
+	Passed bool `json:"passed"`
+
+	// message describes the requirement and what the provider offers
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// SelectionRuleResult is the result of one CEL selection rule
+type SelectionRuleResult struct {
+	// condition is the CEL expression of the rule
+	Condition string `json:"condition"`
+
+	// priority is the priority of the rule
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+
+	// matched is true when the condition evaluated to true
+	Matched bool `json:"matched"`
+
+	// error is the evaluation error of a rule that failed to evaluate
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// ReplicaStatus contains replica count information
+type ReplicaStatus struct {
+	// desired is the desired number of replicas
+	// +optional
+	Desired int32 `json:"desired,omitempty"`
+
+	// ready is the number of ready replicas
+	// +optional
+	Ready int32 `json:"ready,omitempty"`
+
+	// available is the number of available replicas
+	// +optional
+	Available int32 `json:"available,omitempty"`
+}
+
+// EndpointStatus contains service endpoint information
+type EndpointStatus struct {
+	// service is the name of the service
+	// +optional
 	Service string `json:"service,omitempty"`
 
 	// port is the service port
@@ -467,6 +1834,226 @@ type GatewayStatus struct {
 	// gatewayNamespace is the namespace of the Gateway resource used for routing.
 	// +optional
 	GatewayNamespace string `json:"gatewayNamespace,omitempty"`
+	// modelDiscovery caches the model name discovered from the model server's /v1/models
+	// endpoint, so the probe does not run on every reconcile.
+	// +optional
+	ModelDiscovery *ModelDiscoveryStatus `json:"modelDiscovery,omitempty"`
+	// fallback is how the HTTPRoute uses spec.gateway.fallback: Standby, Overflow or Active.
+	// Unset without a fallback.
+	// +optional
+	Fallback GatewayFallbackState `json:"fallback,omitempty"`
+}
+
+// ModelDiscoveryStatus is the result of the last /v1/models probe
+type ModelDiscoveryStatus struct {
+	// modelName is the model name reported by the server, empty when the probe failed
+	// +optional
+	ModelName string `json:"modelName,omitempty"`
+	// probeTime is when the server was last probed
+	ProbeTime metav1.Time `json:"probeTime"`
+	// observedGeneration is the generation of the ModelDeployment the probe was made for
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// ExposeStatus contains the address of the spec.expose Service or Ingress
+type ExposeStatus struct {
+	// service is the name of the Service created for spec.expose
+	// +optional
+	Service string `json:"service,omitempty"`
+
+	// nodePort is the node port of the Service when spec.expose.type is NodePort
+	// +optional
+	NodePort int32 `json:"nodePort,omitempty"`
+
+	// url is the base URL of the OpenAI-compatible API. For ClusterIP and NodePort it is
+	// the in-cluster Service URL; for LoadBalancer and Ingress it is set once an external
+	// address is assigned.
+	// +optional
+	URL string `json:"url,omitempty"`
+}
+
+// WarmupStatus contains the result of a warmup run
+type WarmupStatus struct {
+	// completedRequests is the number of warmup requests that succeeded
+	// +optional
+	CompletedRequests int32 `json:"completedRequests,omitempty"`
+
+	// firstRequestLatency is the latency of the first request, which pays the cold start cost
+	// +optional
+	FirstRequestLatency *metav1.Duration `json:"firstRequestLatency,omitempty"`
+
+	// averageLatency is the mean latency of the successful requests
+	// +optional
+	AverageLatency *metav1.Duration `json:"averageLatency,omitempty"`
+
+	// completionTime is when the warmup run finished
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// observedGeneration is the spec generation the warmup ran for
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// AdmissionStatus contains the state of the Kueue Workload created for spec.scheduling.kueueAdmission
+type AdmissionStatus struct {
+	// workloadName is the name of the Kueue Workload
+	// +optional
+	WorkloadName string `json:"workloadName,omitempty"`
+
+	// clusterQueue is the ClusterQueue that admitted the Workload
+	// +optional
+	ClusterQueue string `json:"clusterQueue,omitempty"`
+
+	// flavors maps each pod set (main, prefill, decode) to the ResourceFlavor assigned to its GPUs
+	// +optional
+	Flavors map[string]string `json:"flavors,omitempty"`
+
+	// admissionTime is when Kueue admitted the Workload
+	// +optional
+	AdmissionTime *metav1.Time `json:"admissionTime,omitempty"`
+}
+
+// AppliedChange summarizes the last update a provider controller made to an upstream resource
+type AppliedChange struct {
+	// resourceKind is the kind of the updated resource
+	// +optional
+	ResourceKind string `json:"resourceKind,omitempty"`
+
+	// resourceName is the name of the updated resource
+	// +optional
+	ResourceName string `json:"resourceName,omitempty"`
+
+	// paths are the JSON pointers of the changed fields, e.g. /spec/replicas. At most 20 are listed.
+	// +optional
+	Paths []string `json:"paths,omitempty"`
+
+	// operations is the number of JSON patch operations in the change. The full patch is
+	// recorded in a ResourceUpdated event.
+	// +optional
+	Operations int32 `json:"operations,omitempty"`
+
+	// observedGeneration is the spec generation that caused the change
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// time is when the change was applied
+	// +optional
+	Time *metav1.Time `json:"time,omitempty"`
+}
+
+// ResourceRecommendations contains right-sizing recommendations derived from observed usage.
+// Peak values are per replica and cover the window since observedSince.
+type ResourceRecommendations struct {
+	// cpu is the recommended CPU value
+	// +optional
+	CPU string `json:"cpu,omitempty"`
+
+	// memory is the recommended memory value
+	// +optional
+	Memory string `json:"memory,omitempty"`
+
+	// peakCPU is the highest CPU usage observed for a single replica
+	// +optional
+	PeakCPU string `json:"peakCPU,omitempty"`
+
+	// peakMemory is the highest memory usage observed for a single replica
+	// +optional
+	PeakMemory string `json:"peakMemory,omitempty"`
+
+	// peakGPUMemory is the highest GPU memory usage observed for a single replica.
+	// Only reported when a DCGM exporter is configured.
+	// +optional
+	PeakGPUMemory string `json:"peakGPUMemory,omitempty"`
+
+	// sources lists the usage sources that contributed to the peaks (e.g., metrics-api, dcgm)
+	// +optional
+	Sources []string `json:"sources,omitempty"`
+
+	// observedGeneration is the spec generation the peaks were observed for.
+	// Observation restarts when the spec changes.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// observedSince is when observation started for observedGeneration
+	// +optional
+	ObservedSince *metav1.Time `json:"observedSince,omitempty"`
+
+	// lastUpdateTime is when usage was last sampled
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+
+	// lastAppliedTime is when autotune last applied a recommendation
+	// +optional
+	LastAppliedTime *metav1.Time `json:"lastAppliedTime,omitempty"`
+}
+
+// GPUUtilizationStatus is the GPU utilization of a Running deployment. The peak covers
+// the window since observedGeneration was first sampled.
+type GPUUtilizationStatus struct {
+	// percent is the mean utilization of the GPUs of the model pods at the last sample,
+	// as a decimal string (e.g. 37.5)
+	Percent string `json:"percent"`
+
+	// peakPercent is the highest mean utilization observed for observedGeneration, as a
+	// decimal string
+	// +optional
+	PeakPercent string `json:"peakPercent,omitempty"`
+
+	// gpus is the number of GPUs sampled
+	GPUs int32 `json:"gpus"`
+
+	// idleSince is when the mean utilization dropped below the idle threshold of the
+	// controller (--gpu-idle-threshold). Unset while the GPUs are busy.
+	// +optional
+	IdleSince *metav1.Time `json:"idleSince,omitempty"`
+
+	// observedGeneration is the spec generation the peak was observed for.
+	// Observation restarts when the spec changes.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// lastUpdateTime is when utilization was last sampled
+	LastUpdateTime metav1.Time `json:"lastUpdateTime"`
+}
+
+// RemediationStatus records the fallbacks crash-loop remediation applied
+type RemediationStatus struct {
+	// attempts lists the applied fallbacks, oldest first
+	// +kubebuilder:validation:MaxItems=5
+	// +optional
+	Attempts []RemediationAttempt `json:"attempts,omitempty"`
+
+	// exhausted is set when the engine still crash-loops after maxAttempts fallbacks,
+	// or no fallback is left for its error
+	// +optional
+	Exhausted bool `json:"exhausted,omitempty"`
+
+	// message explains why remediation stopped
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// observedGeneration is the spec generation remediation stopped at. Changing the
+	// spec after that restarts remediation.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// RemediationAttempt is a fallback applied by crash-loop remediation
+type RemediationAttempt struct {
+	// time is when the fallback was applied
+	Time metav1.Time `json:"time"`
+
+	// reason is the recognized engine error
+	Reason RemediationReason `json:"reason"`
+
+	// action describes the changed setting, e.g. engine.args.gpu-memory-utilization=0.8
+	Action string `json:"action"`
+
+	// pod is the crash-looping pod the error was read from
+	// +optional
+	Pod string `json:"pod,omitempty"`
 }
 
 // ModelDeploymentStatus defines the observed state of ModelDeployment.
@@ -483,6 +2070,10 @@ type ModelDeploymentStatus struct {
 	// +optional
 	Provider *ProviderStatus `json:"provider,omitempty"`
 
+	// migration contains the progress of the last spec.provider.migrateTo migration
+	// +optional
+	Migration *MigrationStatus `json:"migration,omitempty"`
+
 	// engine contains information about the selected engine
 	// +optional
 	Engine *EngineStatus `json:"engine,omitempty"`
@@ -491,6 +2082,22 @@ type ModelDeploymentStatus struct {
 	// +optional
 	Gateway *GatewayStatus `json:"gateway,omitempty"`
 
+	// expose contains the address of the spec.expose Service or Ingress
+	// +optional
+	Expose *ExposeStatus `json:"expose,omitempty"`
+
+	// gpuType is the entry of spec.resources.gpu.types selected by the capacity check: the
+	// first GPU model with a node that has enough allocatable GPUs for a replica. It is kept
+	// while it remains listed so the pods are not rescheduled as capacity changes.
+	// +optional
+	GPUType string `json:"gpuType,omitempty"`
+
+	// secretsChecksum is a checksum of the versions of the Secrets the deployment
+	// references, set when spec.secrets.rolloutOnChange is true. Providers stamp it on the
+	// model pods, so a changed Secret rolls them.
+	// +optional
+	SecretsChecksum string `json:"secretsChecksum,omitempty"`
+
 	// replicas contains replica count information
 	// +optional
 	Replicas *ReplicaStatus `json:"replicas,omitempty"`
@@ -499,6 +2106,64 @@ type ModelDeploymentStatus struct {
 	// +optional
 	Endpoint *EndpointStatus `json:"endpoint,omitempty"`
 
+	// warmup contains the result of the last warmup run
+	// +optional
+	Warmup *WarmupStatus `json:"warmup,omitempty"`
+
+	// admission is the Kueue admission state when spec.scheduling.kueueAdmission is set
+	// +optional
+	Admission *AdmissionStatus `json:"admission,omitempty"`
+
+	// lastAppliedChange summarizes the last update the provider controller made to an upstream resource
+	// +optional
+	LastAppliedChange *AppliedChange `json:"lastAppliedChange,omitempty"`
+
+	// lastRequestTime is when request activity was last observed on the model server pods,
+	// tracked for spec.ttlSecondsAfterLastRequest
+	// +optional
+	LastRequestTime *metav1.Time `json:"lastRequestTime,omitempty"`
+
+	// kvCache reports the KV cache hit rates of a deployment with spec.caching.kv
+	// +optional
+	KVCache *KVCacheStatus `json:"kvCache,omitempty"`
+
+	// adapters reports the spec.model.adapters loaded on the running replicas
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Adapters []AdapterStatus `json:"adapters,omitempty"`
+
+	// placement lists the node and GPUs of each Running model server pod
+	// +listType=map
+	// +listMapKey=pod
+	// +optional
+	Placement []PodPlacement `json:"placement,omitempty"`
+
+	// metricsSnapshot is the latest summary of the request rate, latency and error rate of
+	// a Running deployment, recorded every --metrics-snapshot-interval
+	// +optional
+	MetricsSnapshot *MetricsSnapshot `json:"metricsSnapshot,omitempty"`
+
+	// recommendations contains resource right-sizing recommendations.
+	// Only populated when the controller runs with --enable-resource-recommender.
+	// +optional
+	Recommendations *ResourceRecommendations `json:"recommendations,omitempty"`
+
+	// gpuUtilization is the GPU utilization of the model pods, sampled from the NVIDIA DCGM
+	// exporter. Only populated when the controller runs with --enable-resource-recommender
+	// and --dcgm-exporter-namespace.
+	// +optional
+	GPUUtilization *GPUUtilizationStatus `json:"gpuUtilization,omitempty"`
+
+	// selectionReport explains provider selection. Only populated while the
+	// airunway.ai/selection-explain annotation is "true".
+	// +optional
+	SelectionReport *SelectionReport `json:"selectionReport,omitempty"`
+
+	// remediation records the fallbacks applied by spec.engine.remediation
+	// +optional
+	Remediation *RemediationStatus `json:"remediation,omitempty"`
+
 	// conditions represent the current state of the ModelDeployment resource
 	// +listType=map
 	// +listMapKey=type
@@ -512,10 +2177,17 @@ type ModelDeploymentStatus struct {
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=md
 // +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Current phase"
 // +kubebuilder:printcolumn:name="Provider",type="string",JSONPath=".status.provider.name",description="Selected provider"
 // +kubebuilder:printcolumn:name="Engine",type="string",JSONPath=".status.engine.type",description="Inference engine"
+// +kubebuilder:printcolumn:name="Model",type="string",JSONPath=".spec.model.id",description="Model ID"
 // +kubebuilder:printcolumn:name="Replicas",type="integer",JSONPath=".status.replicas.ready",description="Ready replicas"
+// +kubebuilder:printcolumn:name="Endpoint",type="string",JSONPath=".status.gateway.endpoint",description="Gateway endpoint"
+// +kubebuilder:printcolumn:name="RPS",type="string",JSONPath=".status.metricsSnapshot.requestsPerSecond",description="Requests per second",priority=1
+// +kubebuilder:printcolumn:name="P50",type="string",JSONPath=".status.metricsSnapshot.p50Latency",description="Median request latency",priority=1
+// +kubebuilder:printcolumn:name="P95",type="string",JSONPath=".status.metricsSnapshot.p95Latency",description="95th percentile request latency",priority=1
+// +kubebuilder:printcolumn:name="Errors",type="string",JSONPath=".status.metricsSnapshot.errorRate",description="Aborted request percentage",priority=1
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // ModelDeployment is the Schema for the modeldeployments API
@@ -557,6 +2229,235 @@ func (md *ModelDeployment) ResolvedEngineType() EngineType {
 	return ""
 }
 
+// RemediationEnabled reports whether spec.engine.remediation is enabled
+func (md *ModelDeployment) RemediationEnabled() bool {
+	return md.Spec.Engine.Remediation != nil && md.Spec.Engine.Remediation.Enabled
+}
+
+// ResolvedDevice returns the device the engine runs on: gpu or cpu.
+// Disaggregated deployments always run on GPUs.
+func (md *ModelDeployment) ResolvedDevice() EngineDevice {
+	switch md.Spec.Engine.Device {
+	case EngineDeviceGPU, EngineDeviceCPU:
+		return md.Spec.Engine.Device
+	}
+	if md.Spec.Serving != nil && md.Spec.Serving.Mode == ServingModeDisaggregated {
+		return EngineDeviceGPU
+	}
+	if md.Spec.Resources != nil && md.Spec.Resources.GPU != nil && md.Spec.Resources.GPU.Count > 0 {
+		return EngineDeviceGPU
+	}
+	return EngineDeviceCPU
+}
+
+// ServiceAccountName returns the ServiceAccount model pods run as, or "" for the
+// namespace default. With identity annotations, the controller-created ServiceAccount
+// shares the ModelDeployment name.
+func (md *ModelDeployment) ServiceAccountName() string {
+	if md.Spec.Identity == nil {
+		return ""
+	}
+	if md.Spec.Identity.ServiceAccountName != "" {
+		return md.Spec.Identity.ServiceAccountName
+	}
+	if len(md.Spec.Identity.Annotations) > 0 {
+		return md.Name
+	}
+	return ""
+}
+
+// TracingEnabled reports whether spec.observability.tracing is enabled
+func (md *ModelDeployment) TracingEnabled() bool {
+	return md.Spec.Observability != nil && md.Spec.Observability.Tracing != nil && md.Spec.Observability.Tracing.Enabled
+}
+
+// RouterMode returns spec.serving.router.routerMode, or an empty string when it is not set
+func (md *ModelDeployment) RouterMode() RouterMode {
+	if md.Spec.Serving == nil || md.Spec.Serving.Router == nil {
+		return ""
+	}
+	return md.Spec.Serving.Router.RouterMode
+}
+
+// LogSink returns spec.observability.logSink, or nil when it is not set
+func (md *ModelDeployment) LogSink() *LogSinkSpec {
+	if md.Spec.Observability == nil {
+		return nil
+	}
+	return md.Spec.Observability.LogSink
+}
+
+// ExposeServiceName returns the name of the Service the controller creates for spec.expose
+func (md *ModelDeployment) ExposeServiceName() string {
+	return md.Name + "-endpoint"
+}
+
+// ProviderReleased reports whether the provider named name no longer serves the
+// deployment and should delete its resources: another provider is selected, and the
+// deployment is not migrating away from name or its migration is past the gateway switch.
+func (md *ModelDeployment) ProviderReleased(name string) bool {
+	if md.Status.Provider == nil || md.Status.Provider.Name == "" || md.Status.Provider.Name == name {
+		return false
+	}
+	migration := md.Status.Migration
+	return migration == nil || migration.From != name || migration.Phase == MigrationPhaseDraining ||
+		migration.Phase == MigrationPhaseCompleted
+}
+
+// ProviderFinalizer returns the finalizer provider controllers add to the deployments they
+// serve, which they remove once their resources are deleted
+func ProviderFinalizer(provider string) string {
+	return "airunway.ai/" + provider + "-provider"
+}
+
+// NetworkPolicyName returns the name of the NetworkPolicy the controller creates for
+// spec.networking.isolate
+func (md *ModelDeployment) NetworkPolicyName() string {
+	return md.Name + "-isolation"
+}
+
+// NetworkIsolated reports whether spec.networking.isolate is set
+func (md *ModelDeployment) NetworkIsolated() bool {
+	return md.Spec.Networking != nil && md.Spec.Networking.Isolate
+}
+
+// workloadIdentityAnnotationPrefixes are the annotation prefixes allowed in spec.identity.annotations
+var workloadIdentityAnnotationPrefixes = []string{
+	"azure.workload.identity/",
+	"eks.amazonaws.com/",
+	"iam.gke.io/",
+}
+
+// IsWorkloadIdentityAnnotation reports whether key is an Azure Workload Identity, EKS IRSA,
+// or GKE Workload Identity ServiceAccount annotation.
+func IsWorkloadIdentityAnnotation(key string) bool {
+	for _, prefix := range workloadIdentityAnnotationPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// IdentityPodLabels returns labels model pods need for workload identity. Azure Workload
+// Identity only injects tokens into pods labeled azure.workload.identity/use=true.
+func (md *ModelDeployment) IdentityPodLabels() map[string]string {
+	if md.Spec.Identity == nil || md.Spec.Identity.ServiceAccountName != "" {
+		return nil
+	}
+	for key := range md.Spec.Identity.Annotations {
+		if strings.HasPrefix(key, "azure.workload.identity/") {
+			return map[string]string{"azure.workload.identity/use": "true"}
+		}
+	}
+	return nil
+}
+
+// CPUPinningPodAnnotations returns the annotations recording spec.resources.cpuPinning on
+// model pods, for node agents and operators inspecting their placement
+func (md *ModelDeployment) CPUPinningPodAnnotations() map[string]string {
+	if md.Spec.Resources == nil || md.Spec.Resources.CPUPinning == nil {
+		return nil
+	}
+	pinning := md.Spec.Resources.CPUPinning
+	annotations := map[string]string{}
+	if pinning.ExclusiveCores > 0 {
+		annotations[AnnotationCPUPinning] = strconv.Itoa(int(pinning.ExclusiveCores))
+	}
+	if pinning.NUMAPolicy != "" {
+		annotations[AnnotationNUMAPolicy] = string(pinning.NUMAPolicy)
+	}
+	return annotations
+}
+
+// ReferencedSecrets returns the sorted names of the Secrets in the deployment's namespace
+// that the model pods read: spec.secrets.huggingFaceToken, the Secrets of spec.env, and
+// spec.caching.kv.connectionSecretRef
+func (md *ModelDeployment) ReferencedSecrets() []string {
+	var names []string
+	if md.Spec.Secrets != nil && md.Spec.Secrets.HuggingFaceToken != "" {
+		names = append(names, md.Spec.Secrets.HuggingFaceToken)
+	}
+	for _, e := range md.Spec.Env {
+		if e.ValueFrom != nil && e.ValueFrom.SecretKeyRef != nil && e.ValueFrom.SecretKeyRef.Name != "" {
+			names = append(names, e.ValueFrom.SecretKeyRef.Name)
+		}
+	}
+	if c := md.Spec.Caching; c != nil && c.KV != nil && c.KV.ConnectionSecretRef != nil && c.KV.ConnectionSecretRef.Name != "" {
+		names = append(names, c.KV.ConnectionSecretRef.Name)
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// SecretsPodAnnotations returns the annotation recording status.secretsChecksum on model
+// pods when spec.secrets.rolloutOnChange is true, so a changed Secret rolls them
+func (md *ModelDeployment) SecretsPodAnnotations() map[string]string {
+	if md.Spec.Secrets == nil || !md.Spec.Secrets.RolloutOnChange || md.Status.SecretsChecksum == "" {
+		return nil
+	}
+	return map[string]string{AnnotationSecretsChecksum: md.Status.SecretsChecksum}
+}
+
+// ComponentNodeSelector returns spec.nodeSelector merged with the node selector of a
+// component's scheduling, which takes precedence on conflicting keys.
+func (md *ModelDeployment) ComponentNodeSelector(scheduling *ComponentSchedulingSpec) map[string]string {
+	if scheduling == nil || len(scheduling.NodeSelector) == 0 {
+		return md.Spec.NodeSelector
+	}
+	nodeSelector := make(map[string]string, len(md.Spec.NodeSelector)+len(scheduling.NodeSelector))
+	for k, v := range md.Spec.NodeSelector {
+		nodeSelector[k] = v
+	}
+	for k, v := range scheduling.NodeSelector {
+		nodeSelector[k] = v
+	}
+	return nodeSelector
+}
+
+// ComponentTolerations returns spec.tolerations followed by the tolerations of a
+// component's scheduling.
+func (md *ModelDeployment) ComponentTolerations(scheduling *ComponentSchedulingSpec) []corev1.Toleration {
+	if scheduling == nil || len(scheduling.Tolerations) == 0 {
+		return md.Spec.Tolerations
+	}
+	tolerations := make([]corev1.Toleration, 0, len(md.Spec.Tolerations)+len(scheduling.Tolerations))
+	tolerations = append(tolerations, md.Spec.Tolerations...)
+	return append(tolerations, scheduling.Tolerations...)
+}
+
+// GPUTypePreference returns spec.resources.gpu.types, most preferred first: status.gpuType
+// followed by the other types in order. It returns nil when no types are set.
+func (md *ModelDeployment) GPUTypePreference() []string {
+	if md.Spec.Resources == nil || md.Spec.Resources.GPU == nil || len(md.Spec.Resources.GPU.Types) == 0 {
+		return nil
+	}
+	types := md.Spec.Resources.GPU.Types
+	if md.Status.GPUType == "" || !slices.Contains(types, md.Status.GPUType) {
+		return types
+	}
+	preference := make([]string, 0, len(types))
+	preference = append(preference, md.Status.GPUType)
+	for _, t := range types {
+		if t != md.Status.GPUType {
+			preference = append(preference, t)
+		}
+	}
+	return preference
+}
+
+// IsQueued reports whether the deployment is waiting for Kueue admission. Provider
+// controllers must not create resources for a queued deployment.
+func (md *ModelDeployment) IsQueued() bool {
+	return md.Status.Phase == DeploymentPhaseQueued
+}
+
+// IsPaused reports whether reconciliation is paused, either through spec.paused
+// or the legacy airunway.ai/reconcile-paused annotation.
+func (md *ModelDeployment) IsPaused() bool {
+	return md.Spec.Paused || md.Annotations[AnnotationReconcilePaused] == "true"
+}
+
 // Condition types for ModelDeployment
 const (
 	// ConditionTypeValidated indicates the spec has been validated
@@ -577,12 +2478,74 @@ const (
 	ConditionTypeReady = "Ready"
 	// ConditionTypeGatewayReady indicates the gateway route is active
 	ConditionTypeGatewayReady = "GatewayReady"
+	// ConditionTypeGatewayReachable indicates the gateway endpoint answered the last /v1/models probe
+	ConditionTypeGatewayReachable = "GatewayReachable"
+	// ConditionTypePausedReconciliation indicates reconciliation is paused
+	ConditionTypePausedReconciliation = "PausedReconciliation"
+	// ConditionTypeProgressing tracks progress towards Running against spec.progressDeadlineSeconds
+	ConditionTypeProgressing = "Progressing"
+	// ConditionTypeServingModeSelected explains how serving.mode auto was resolved
+	ConditionTypeServingModeSelected = "ServingModeSelected"
+	// ConditionTypeWarmedUp indicates the spec.warmup requests completed after the deployment became Running
+	ConditionTypeWarmedUp = "WarmedUp"
+	// ConditionTypeOverridesValid indicates spec.provider.overrides match the schema the cluster publishes for the upstream kind
+	ConditionTypeOverridesValid = "OverridesValid"
+	// ConditionTypeAdaptersLoaded indicates every spec.model.adapters entry is loaded on every running replica
+	ConditionTypeAdaptersLoaded = "AdaptersLoaded"
+	// ConditionTypeAdmitted indicates Kueue admitted the Workload for spec.scheduling.kueueAdmission
+	ConditionTypeAdmitted = "Admitted"
+	// ConditionTypeExposed indicates the spec.expose Service or Ingress has an address
+	ConditionTypeExposed = "Exposed"
+	// ConditionTypeNetworkIsolated indicates the spec.networking.isolate NetworkPolicy is in place
+	ConditionTypeNetworkIsolated = "NetworkIsolated"
+	// ConditionTypeMigrating indicates a spec.provider.migrateTo migration is in progress
+	ConditionTypeMigrating = "Migrating"
+	// ConditionTypeServingVerified indicates the endpoint of the provider a deployment is
+	// migrating to answered /v1/models
+	ConditionTypeServingVerified = "ServingVerified"
+	// ConditionTypeFieldsIgnored indicates the spec sets fields the selected provider does not honor
+	ConditionTypeFieldsIgnored = "FieldsIgnored"
+	// ConditionTypeImageUpgradePending indicates the deployment waits for its turn to roll to
+	// a new provider default image
+	ConditionTypeImageUpgradePending = "ImageUpgradePending"
+	// ConditionTypeReconciling is present while the controller works towards the spec, for
+	// kstatus-based health checks such as Flux and Argo CD. Removed once current.
+	ConditionTypeReconciling = "Reconciling"
+	// ConditionTypeStalled is present while the resource cannot make progress without a
+	// change, for kstatus-based health checks. Removed once the resource recovers.
+	ConditionTypeStalled = "Stalled"
 )
 
+// Condition reasons for the Progressing condition
 const (
-	HTTPRouteCreated     = "airunway.ai/httproute-created"
-	BBRRestarted         = "airunway.ai/bbr-restarted"
-	LabelModelDeployment = "airunway.ai/model-deployment"
-	LabelManagedBy       = "airunway.ai/managed-by"
-	LabelJobType         = "airunway.ai/job-type"
+	// ReasonDeploying means the deployment is progressing towards Running
+	ReasonDeploying = "Deploying"
+	// ReasonDeploymentAvailable means the deployment reached Running
+	ReasonDeploymentAvailable = "DeploymentAvailable"
+	// ReasonProgressDeadlineExceeded means the deployment did not reach Running in time
+	ReasonProgressDeadlineExceeded = "ProgressDeadlineExceeded"
+	// ReasonResourcesAutotuned means autotune applied a resource recommendation
+	ReasonResourcesAutotuned = "ResourcesAutotuned"
+	// ReasonEngineRemediated means crash-loop remediation applied a fallback
+	ReasonEngineRemediated = "EngineRemediated"
+	// ReasonRemediationExhausted means crash-loop remediation gave up
+	ReasonRemediationExhausted = "RemediationExhausted"
+	// ReasonResourceUpdated is the event reason for an update a provider controller made to an upstream resource
+	ReasonResourceUpdated = "ResourceUpdated"
+	// ReasonTTLExpired is the event reason for deleting a deployment whose spec.ttlSecondsAfter* elapsed
+	ReasonTTLExpired = "TTLExpired"
+	// ReasonModelPolicyViolation is the event reason for a ModelDeployment that violates a ModelPolicy
+	ReasonModelPolicyViolation = "ModelPolicyViolation"
+	// ReasonProviderIgnoresFields is the FieldsIgnored reason and event reason for spec fields the selected provider drops
+	ReasonProviderIgnoresFields = "ProviderIgnoresFields"
+	// ReasonSecretsChanged is the event reason for rolling the pods after a referenced Secret changed
+	ReasonSecretsChanged = "SecretsChanged"
+	// ReasonGatewayMigrated is the event reason for moving a generated HTTPRoute to the Gateway
+	// the controller resolves after its gateway flags changed
+	ReasonGatewayMigrated = "GatewayMigrated"
+	// ReasonGatewayFallback is the event reason for a change of status.gateway.fallback
+	ReasonGatewayFallback = "GatewayFallback"
+	// ReasonAdapterLoadFailed is the event and condition reason when spec.model.adapters
+	// could not be loaded on a replica
+	ReasonAdapterLoadFailed = "AdapterLoadFailed"
 )
@@ -0,0 +1,124 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultGPUType is the GPU resource name used when spec.resources.gpu.type is unset
+const DefaultGPUType = "nvidia.com/gpu"
+
+// ModelDeploymentQuotaSpec defines the GPU limits for ModelDeployments in a namespace
+type ModelDeploymentQuotaSpec struct {
+	// limits caps the total number of GPUs ModelDeployments in the namespace may request,
+	// keyed by GPU class (e.g. nvidia-h100). GPUs without a class are counted against their
+	// resource type (e.g. nvidia.com/gpu). Classes without a limit are not restricted.
+	// +optional
+	Limits map[string]int32 `json:"limits,omitempty"`
+}
+
+// ModelDeploymentQuotaStatus defines the observed usage of a ModelDeploymentQuota
+type ModelDeploymentQuotaStatus struct {
+	// used is the number of GPUs requested by ModelDeployments in the namespace, keyed by
+	// GPU class. Only classes listed in spec.limits are reported.
+	// +optional
+	Used map[string]int32 `json:"used,omitempty"`
+
+	// observedGeneration is the spec generation the status was computed for
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=mdquota
+// +kubebuilder:printcolumn:name="Limits",type="string",JSONPath=".spec.limits",description="GPU limits per class"
+// +kubebuilder:printcolumn:name="Used",type="string",JSONPath=".status.used",description="GPUs used per class"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ModelDeploymentQuota is the Schema for the modeldeploymentquotas API
+// ModelDeploymentQuota lets cluster admins delegate a GPU budget per GPU class to a namespace.
+// The admission webhook rejects ModelDeployments that would exceed it.
+type ModelDeploymentQuota struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// spec defines the GPU limits
+	// +optional
+	Spec ModelDeploymentQuotaSpec `json:"spec,omitempty"`
+
+	// status is written by the controller
+	// +optional
+	Status ModelDeploymentQuotaStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ModelDeploymentQuotaList contains a list of ModelDeploymentQuota
+type ModelDeploymentQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ModelDeploymentQuota `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ModelDeploymentQuota{}, &ModelDeploymentQuotaList{})
+}
+
+// GPUClass returns the quota class the GPUs are counted against
+func (g *GPUSpec) GPUClass() string {
+	if g.Class != "" {
+		return g.Class
+	}
+	if g.Type != "" {
+		return g.Type
+	}
+	return DefaultGPUType
+}
+
+// GPUUsage returns the number of GPUs the deployment requests across all replicas, keyed
+// by GPU class. Disaggregated deployments count their prefill and decode components.
+func (md *ModelDeployment) GPUUsage() map[string]int32 {
+	usage := map[string]int32{}
+	add := func(gpu *GPUSpec, replicas int32) {
+		if gpu == nil || gpu.Count <= 0 || replicas <= 0 {
+			return
+		}
+		usage[gpu.GPUClass()] += gpu.Count * replicas
+	}
+
+	scaling := md.Spec.Scaling
+	if md.Spec.Serving != nil && md.Spec.Serving.Mode == ServingModeDisaggregated {
+		if scaling != nil && scaling.Prefill != nil {
+			add(scaling.Prefill.GPU, scaling.Prefill.Replicas)
+		}
+		if scaling != nil && scaling.Decode != nil {
+			add(scaling.Decode.GPU, scaling.Decode.Replicas)
+		}
+		return usage
+	}
+
+	if md.Spec.Resources != nil {
+		replicas := int32(1)
+		if scaling != nil {
+			replicas = scaling.Replicas
+		}
+		add(md.Spec.Resources.GPU, replicas)
+	}
+	return usage
+}
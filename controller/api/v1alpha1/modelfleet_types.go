@@ -0,0 +1,212 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Condition reasons for the Ready condition of a ModelFleet
+const (
+	// FleetReasonAllReady is set when every ModelDeployment of the fleet is Running
+	FleetReasonAllReady = "AllReady"
+	// FleetReasonNotReady is set while some ModelDeployments are not Running yet
+	FleetReasonNotReady = "NotReady"
+	// FleetReasonFailed is set when some ModelDeployments failed or could not be created
+	FleetReasonFailed = "Failed"
+)
+
+// ModelFleetSpec defines the ModelDeployments of a ModelFleet
+type ModelFleetSpec struct {
+	// template is the ModelDeployment spec every item starts from. It is validated as part
+	// of each generated ModelDeployment rather than by this schema, which would otherwise
+	// repeat the whole ModelDeployment schema.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Template ModelDeploymentSpec `json:"template"`
+
+	// items lists the ModelDeployments of the fleet. Each item creates a ModelDeployment
+	// named <fleet>-<item> from the template with the overrides of the item applied.
+	// ModelDeployments of removed items are deleted.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=256
+	// +listType=map
+	// +listMapKey=name
+	Items []ModelFleetItem `json:"items"`
+}
+
+// ModelFleetItem is a ModelDeployment of a ModelFleet and its overrides of the template
+type ModelFleetItem struct {
+	// name identifies the item and suffixes the name of its ModelDeployment
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// model overrides spec.model of the template. Fields set here replace those of the
+	// template, so items usually only set id.
+	// +optional
+	Model *ModelSpec `json:"model,omitempty"`
+
+	// resources replaces spec.resources of the template
+	// +optional
+	Resources *ResourceSpec `json:"resources,omitempty"`
+
+	// replicas overrides spec.scaling.replicas of the template
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// labels are added to the ModelDeployment of the item
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// ModelFleetItemStatus is the observed state of the ModelDeployment of an item
+type ModelFleetItemStatus struct {
+	// name is the name of the item
+	Name string `json:"name"`
+
+	// deployment is the name of the ModelDeployment of the item
+	// +optional
+	Deployment string `json:"deployment,omitempty"`
+
+	// phase is the phase of the ModelDeployment
+	// +optional
+	Phase DeploymentPhase `json:"phase,omitempty"`
+
+	// message explains why the ModelDeployment could not be created or updated, or is the
+	// status message of the ModelDeployment
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ModelFleetStatus defines the aggregate state of the ModelDeployments of a ModelFleet
+type ModelFleetStatus struct {
+	// total is the number of items in the fleet
+	// +optional
+	Total int32 `json:"total,omitempty"`
+
+	// ready is the number of items whose ModelDeployment is Running
+	// +optional
+	Ready int32 `json:"ready,omitempty"`
+
+	// failed is the number of items whose ModelDeployment failed or could not be created
+	// +optional
+	Failed int32 `json:"failed,omitempty"`
+
+	// items is the state of each item
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Items []ModelFleetItemStatus `json:"items,omitempty"`
+
+	// conditions holds the Ready condition of the fleet
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// observedGeneration is the spec generation the status was computed for
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=mfleet
+// +kubebuilder:printcolumn:name="Total",type="integer",JSONPath=".status.total",description="Number of ModelDeployments"
+// +kubebuilder:printcolumn:name="Ready",type="integer",JSONPath=".status.ready",description="Running ModelDeployments"
+// +kubebuilder:printcolumn:name="Failed",type="integer",JSONPath=".status.failed",description="Failed ModelDeployments"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ModelFleet is the Schema for the modelfleets API
+// ModelFleet stamps out a ModelDeployment per item from a shared template, for teams
+// serving many small models with the same settings. The ModelDeployments are labeled with
+// airunway.ai/fleet so they can be listed and operated on together.
+type ModelFleet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// spec defines the template and items
+	Spec ModelFleetSpec `json:"spec"`
+
+	// status is written by the controller
+	// +optional
+	Status ModelFleetStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ModelFleetList contains a list of ModelFleet
+type ModelFleetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ModelFleet `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ModelFleet{}, &ModelFleetList{})
+}
+
+// DeploymentName returns the name of the ModelDeployment of item
+func (f *ModelFleet) DeploymentName(item *ModelFleetItem) string {
+	return f.Name + "-" + item.Name
+}
+
+// DeploymentSpec returns the ModelDeployment spec of item: the template with the overrides
+// of the item applied
+func (f *ModelFleet) DeploymentSpec(item *ModelFleetItem) ModelDeploymentSpec {
+	spec := *f.Spec.Template.DeepCopy()
+	if m := item.Model; m != nil {
+		if m.ID != "" {
+			spec.Model.ID = m.ID
+		}
+		if m.Revision != "" {
+			spec.Model.Revision = m.Revision
+		}
+		if m.ServedName != "" {
+			spec.Model.ServedName = m.ServedName
+		}
+		if m.Source != "" {
+			spec.Model.Source = m.Source
+		}
+		if m.Storage != nil {
+			spec.Model.Storage = m.Storage.DeepCopy()
+		}
+		if m.License != "" {
+			spec.Model.License = m.License
+		}
+		if m.ChatTemplate != nil {
+			spec.Model.ChatTemplate = m.ChatTemplate.DeepCopy()
+		}
+		if m.Tokenizer != "" {
+			spec.Model.Tokenizer = m.Tokenizer
+		}
+	}
+	if item.Resources != nil {
+		spec.Resources = item.Resources.DeepCopy()
+	}
+	if item.Replicas != nil {
+		if spec.Scaling == nil {
+			spec.Scaling = &ScalingSpec{}
+		}
+		spec.Scaling.Replicas = *item.Replicas
+	}
+	return spec
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ModelPolicyAction is what the admission webhook does with a ModelDeployment that violates a ModelPolicy
+// +kubebuilder:validation:Enum=Deny;Audit
+type ModelPolicyAction string
+
+const (
+	// ModelPolicyActionDeny rejects violating ModelDeployments
+	ModelPolicyActionDeny ModelPolicyAction = "Deny"
+	// ModelPolicyActionAudit admits violating ModelDeployments with a warning and an event
+	ModelPolicyActionAudit ModelPolicyAction = "Audit"
+)
+
+// ModelPolicySpec defines which models ModelDeployments in the selected namespaces may serve
+type ModelPolicySpec struct {
+	// namespaceSelector selects the namespaces the policy applies to.
+	// When unset, the policy applies to all namespaces.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// allowedLicenses lists the spec.model.license values that are allowed (case-insensitive).
+	// When set, deployments with another or no license violate the policy.
+	// +optional
+	AllowedLicenses []string `json:"allowedLicenses,omitempty"`
+
+	// deniedLicenses lists spec.model.license values that are not allowed (case-insensitive),
+	// e.g. cc-by-nc-4.0 to block non-commercial models
+	// +optional
+	DeniedLicenses []string `json:"deniedLicenses,omitempty"`
+
+	// allowedModels lists glob patterns (e.g. meta-llama/*) of spec.model.id values that are
+	// allowed. When set, deployments whose model matches none of them violate the policy.
+	// +optional
+	AllowedModels []string `json:"allowedModels,omitempty"`
+
+	// deniedModels lists glob patterns of spec.model.id values that are not allowed
+	// +optional
+	DeniedModels []string `json:"deniedModels,omitempty"`
+
+	// action is what the admission webhook does with a violating ModelDeployment.
+	// Deny rejects it; Audit admits it with a warning. Both emit an event.
+	// +kubebuilder:default=Deny
+	// +optional
+	Action ModelPolicyAction `json:"action,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,shortName=mpolicy
+// +kubebuilder:printcolumn:name="Action",type="string",JSONPath=".spec.action",description="Deny or Audit"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ModelPolicy is the Schema for the modelpolicies API
+// ModelPolicy lets cluster admins restrict the licenses and models ModelDeployments may serve,
+// e.g. to block non-commercial models in production namespaces. The admission webhook enforces it.
+type ModelPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// spec defines the allowed and denied licenses and models
+	// +optional
+	Spec ModelPolicySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ModelPolicyList contains a list of ModelPolicy
+type ModelPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ModelPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ModelPolicy{}, &ModelPolicyList{})
+}
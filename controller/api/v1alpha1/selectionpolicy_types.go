@@ -0,0 +1,112 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// SelectionPolicySpec defines how providers are selected for ModelDeployments in the selected namespaces
+type SelectionPolicySpec struct {
+	// namespaceSelector selects the namespaces the policy applies to.
+	// When unset, the policy applies to all namespaces.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// strategy picks one provider from those that pass every selection criterion.
+	// Built-in strategies are priority, weighted-capacity, round-robin and cost-aware;
+	// controllers may register others.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Strategy string `json:"strategy"`
+
+	// providers sets the weight and cost of providers for the weighted-capacity and
+	// cost-aware strategies. Unlisted providers have weight 1 and cost 0.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=64
+	// +optional
+	Providers []ProviderSelectionParameters `json:"providers,omitempty"`
+}
+
+// SelectsNamespace reports whether spec.namespaceSelector matches a namespace with the
+// given labels.
+func (s *SelectionPolicySpec) SelectsNamespace(namespaceLabels map[string]string) (bool, error) {
+	if s.NamespaceSelector == nil {
+		return true, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(s.NamespaceSelector)
+	if err != nil {
+		return false, fmt.Errorf("invalid namespaceSelector: %w", err)
+	}
+	return selector.Matches(labels.Set(namespaceLabels)), nil
+}
+
+// ProviderSelectionParameters are the strategy inputs for one provider
+type ProviderSelectionParameters struct {
+	// name is the name of the InferenceProviderConfig
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// weight is the relative capacity of the provider for the weighted-capacity strategy,
+	// which places deployments on the provider with the fewest deployments per unit of weight
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Weight int32 `json:"weight,omitempty"`
+
+	// cost is the relative cost of the provider for the cost-aware strategy, which places
+	// deployments on the cheapest provider
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Cost int32 `json:"cost,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,shortName=spolicy
+// +kubebuilder:printcolumn:name="Strategy",type="string",JSONPath=".spec.strategy",description="Provider selection strategy"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// SelectionPolicy is the Schema for the selectionpolicies API
+// SelectionPolicy lets cluster admins choose the provider selection strategy per namespace,
+// overriding --provider-selection-strategy. When several policies select a namespace, one
+// with a namespaceSelector wins over one without, then the first by name.
+type SelectionPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// spec defines the strategy and its provider parameters
+	// +required
+	Spec SelectionPolicySpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// SelectionPolicyList contains a list of SelectionPolicy
+type SelectionPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SelectionPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SelectionPolicy{}, &SelectionPolicyList{})
+}
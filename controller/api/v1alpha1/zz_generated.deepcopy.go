@@ -22,733 +22,2620 @@ package v1alpha1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ComponentScalingSpec) DeepCopyInto(out *ComponentScalingSpec) {
+func (in *AdapterStatus) DeepCopyInto(out *AdapterStatus) {
 	*out = *in
-	if in.GPU != nil {
-		in, out := &in.GPU, &out.GPU
-		*out = new(GPUSpec)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentScalingSpec.
-func (in *ComponentScalingSpec) DeepCopy() *ComponentScalingSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdapterStatus.
+func (in *AdapterStatus) DeepCopy() *AdapterStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ComponentScalingSpec)
+	out := new(AdapterStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EndpointStatus) DeepCopyInto(out *EndpointStatus) {
+func (in *AdmissionStatus) DeepCopyInto(out *AdmissionStatus) {
 	*out = *in
+	if in.Flavors != nil {
+		in, out := &in.Flavors, &out.Flavors
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AdmissionTime != nil {
+		in, out := &in.AdmissionTime, &out.AdmissionTime
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointStatus.
-func (in *EndpointStatus) DeepCopy() *EndpointStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionStatus.
+func (in *AdmissionStatus) DeepCopy() *AdmissionStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(EndpointStatus)
+	out := new(AdmissionStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EngineSpec) DeepCopyInto(out *EngineSpec) {
+func (in *AppliedChange) DeepCopyInto(out *AppliedChange) {
 	*out = *in
-	if in.ContextLength != nil {
-		in, out := &in.ContextLength, &out.ContextLength
-		*out = new(int32)
-		**out = **in
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.Args != nil {
-		in, out := &in.Args, &out.Args
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.Time != nil {
+		in, out := &in.Time, &out.Time
+		*out = (*in).DeepCopy()
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EngineSpec.
-func (in *EngineSpec) DeepCopy() *EngineSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppliedChange.
+func (in *AppliedChange) DeepCopy() *AppliedChange {
 	if in == nil {
 		return nil
 	}
-	out := new(EngineSpec)
+	out := new(AppliedChange)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EngineStatus) DeepCopyInto(out *EngineStatus) {
+func (in *AutotuneBounds) DeepCopyInto(out *AutotuneBounds) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EngineStatus.
-func (in *EngineStatus) DeepCopy() *EngineStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutotuneBounds.
+func (in *AutotuneBounds) DeepCopy() *AutotuneBounds {
 	if in == nil {
 		return nil
 	}
-	out := new(EngineStatus)
+	out := new(AutotuneBounds)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GPUSpec) DeepCopyInto(out *GPUSpec) {
+func (in *CPUPinningSpec) DeepCopyInto(out *CPUPinningSpec) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUSpec.
-func (in *GPUSpec) DeepCopy() *GPUSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CPUPinningSpec.
+func (in *CPUPinningSpec) DeepCopy() *CPUPinningSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GPUSpec)
+	out := new(CPUPinningSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GatewayCapabilities) DeepCopyInto(out *GatewayCapabilities) {
+func (in *CachingSpec) DeepCopyInto(out *CachingSpec) {
 	*out = *in
+	if in.KV != nil {
+		in, out := &in.KV, &out.KV
+		*out = new(KVCacheSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayCapabilities.
-func (in *GatewayCapabilities) DeepCopy() *GatewayCapabilities {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CachingSpec.
+func (in *CachingSpec) DeepCopy() *CachingSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GatewayCapabilities)
+	out := new(CachingSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GatewaySpec) DeepCopyInto(out *GatewaySpec) {
+func (in *ChatTemplateSource) DeepCopyInto(out *ChatTemplateSource) {
 	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
-		**out = **in
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewaySpec.
-func (in *GatewaySpec) DeepCopy() *GatewaySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChatTemplateSource.
+func (in *ChatTemplateSource) DeepCopy() *ChatTemplateSource {
 	if in == nil {
 		return nil
 	}
-	out := new(GatewaySpec)
+	out := new(ChatTemplateSource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GatewayStatus) DeepCopyInto(out *GatewayStatus) {
+func (in *ComponentScalingSpec) DeepCopyInto(out *ComponentScalingSpec) {
 	*out = *in
+	if in.GPU != nil {
+		in, out := &in.GPU, &out.GPU
+		*out = new(GPUSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Requests != nil {
+		in, out := &in.Requests, &out.Requests
+		*out = new(ResourceQuantities)
+		**out = **in
+	}
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = new(ResourceQuantities)
+		**out = **in
+	}
+	if in.Scheduling != nil {
+		in, out := &in.Scheduling, &out.Scheduling
+		*out = new(ComponentSchedulingSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayStatus.
-func (in *GatewayStatus) DeepCopy() *GatewayStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentScalingSpec.
+func (in *ComponentScalingSpec) DeepCopy() *ComponentScalingSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GatewayStatus)
+	out := new(ComponentScalingSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HelmChart) DeepCopyInto(out *HelmChart) {
+func (in *ComponentSchedulingSpec) DeepCopyInto(out *ComponentSchedulingSpec) {
 	*out = *in
-	if in.Values != nil {
-		in, out := &in.Values, &out.Values
-		*out = new(runtime.RawExtension)
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmChart.
-func (in *HelmChart) DeepCopy() *HelmChart {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentSchedulingSpec.
+func (in *ComponentSchedulingSpec) DeepCopy() *ComponentSchedulingSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(HelmChart)
+	out := new(ComponentSchedulingSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HelmRepo) DeepCopyInto(out *HelmRepo) {
+func (in *CosignPolicy) DeepCopyInto(out *CosignPolicy) {
 	*out = *in
+	if in.PublicKeys != nil {
+		in, out := &in.PublicKeys, &out.PublicKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmRepo.
-func (in *HelmRepo) DeepCopy() *HelmRepo {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CosignPolicy.
+func (in *CosignPolicy) DeepCopy() *CosignPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(HelmRepo)
+	out := new(CosignPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InferenceProviderConfig) DeepCopyInto(out *InferenceProviderConfig) {
+func (in *EndpointStatus) DeepCopyInto(out *EndpointStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceProviderConfig.
-func (in *InferenceProviderConfig) DeepCopy() *InferenceProviderConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointStatus.
+func (in *EndpointStatus) DeepCopy() *EndpointStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(InferenceProviderConfig)
+	out := new(EndpointStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *InferenceProviderConfig) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InferenceProviderConfigList) DeepCopyInto(out *InferenceProviderConfigList) {
+func (in *EngineRemediationSpec) DeepCopyInto(out *EngineRemediationSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]InferenceProviderConfig, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceProviderConfigList.
-func (in *InferenceProviderConfigList) DeepCopy() *InferenceProviderConfigList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EngineRemediationSpec.
+func (in *EngineRemediationSpec) DeepCopy() *EngineRemediationSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(InferenceProviderConfigList)
+	out := new(EngineRemediationSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *InferenceProviderConfigList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InferenceProviderConfigSpec) DeepCopyInto(out *InferenceProviderConfigSpec) {
+func (in *EngineSpec) DeepCopyInto(out *EngineSpec) {
 	*out = *in
-	if in.Capabilities != nil {
-		in, out := &in.Capabilities, &out.Capabilities
-		*out = new(ProviderCapabilities)
-		(*in).DeepCopyInto(*out)
+	if in.ContextLength != nil {
+		in, out := &in.ContextLength, &out.ContextLength
+		*out = new(int32)
+		**out = **in
 	}
-	if in.SelectionRules != nil {
-		in, out := &in.SelectionRules, &out.SelectionRules
-		*out = make([]SelectionRule, len(*in))
-		copy(*out, *in)
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Remediation != nil {
+		in, out := &in.Remediation, &out.Remediation
+		*out = new(EngineRemediationSpec)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceProviderConfigSpec.
-func (in *InferenceProviderConfigSpec) DeepCopy() *InferenceProviderConfigSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EngineSpec.
+func (in *EngineSpec) DeepCopy() *EngineSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(InferenceProviderConfigSpec)
+	out := new(EngineSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InferenceProviderConfigStatus) DeepCopyInto(out *InferenceProviderConfigStatus) {
+func (in *EngineStatus) DeepCopyInto(out *EngineStatus) {
 	*out = *in
-	if in.LastHeartbeat != nil {
-		in, out := &in.LastHeartbeat, &out.LastHeartbeat
-		*out = (*in).DeepCopy()
-	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceProviderConfigStatus.
-func (in *InferenceProviderConfigStatus) DeepCopy() *InferenceProviderConfigStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EngineStatus.
+func (in *EngineStatus) DeepCopy() *EngineStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(InferenceProviderConfigStatus)
+	out := new(EngineStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InstallationInfo) DeepCopyInto(out *InstallationInfo) {
+func (in *ExposeSpec) DeepCopyInto(out *ExposeSpec) {
 	*out = *in
-	if in.HelmRepos != nil {
-		in, out := &in.HelmRepos, &out.HelmRepos
-		*out = make([]HelmRepo, len(*in))
-		copy(*out, *in)
-	}
-	if in.HelmCharts != nil {
-		in, out := &in.HelmCharts, &out.HelmCharts
-		*out = make([]HelmChart, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Steps != nil {
-		in, out := &in.Steps, &out.Steps
-		*out = make([]InstallationStep, len(*in))
-		copy(*out, *in)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallationInfo.
-func (in *InstallationInfo) DeepCopy() *InstallationInfo {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExposeSpec.
+func (in *ExposeSpec) DeepCopy() *ExposeSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(InstallationInfo)
+	out := new(ExposeSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InstallationStep) DeepCopyInto(out *InstallationStep) {
+func (in *ExposeStatus) DeepCopyInto(out *ExposeStatus) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallationStep.
-func (in *InstallationStep) DeepCopy() *InstallationStep {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExposeStatus.
+func (in *ExposeStatus) DeepCopy() *ExposeStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(InstallationStep)
+	out := new(ExposeStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelDeployment) DeepCopyInto(out *ModelDeployment) {
+func (in *FallbackBackendRef) DeepCopyInto(out *FallbackBackendRef) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelDeployment.
-func (in *ModelDeployment) DeepCopy() *ModelDeployment {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FallbackBackendRef.
+func (in *FallbackBackendRef) DeepCopy() *FallbackBackendRef {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelDeployment)
+	out := new(FallbackBackendRef)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ModelDeployment) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelDeploymentList) DeepCopyInto(out *ModelDeploymentList) {
+func (in *GPUSpec) DeepCopyInto(out *GPUSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]ModelDeployment, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Types != nil {
+		in, out := &in.Types, &out.Types
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelDeploymentList.
-func (in *ModelDeploymentList) DeepCopy() *ModelDeploymentList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUSpec.
+func (in *GPUSpec) DeepCopy() *GPUSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelDeploymentList)
+	out := new(GPUSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ModelDeploymentList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GPUUtilizationStatus) DeepCopyInto(out *GPUUtilizationStatus) {
+	*out = *in
+	if in.IdleSince != nil {
+		in, out := &in.IdleSince, &out.IdleSince
+		*out = (*in).DeepCopy()
 	}
-	return nil
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GPUUtilizationStatus.
+func (in *GPUUtilizationStatus) DeepCopy() *GPUUtilizationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GPUUtilizationStatus)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelDeploymentSpec) DeepCopyInto(out *ModelDeploymentSpec) {
+func (in *GatewayCapabilities) DeepCopyInto(out *GatewayCapabilities) {
 	*out = *in
-	in.Model.DeepCopyInto(&out.Model)
-	if in.Provider != nil {
-		in, out := &in.Provider, &out.Provider
-		*out = new(ProviderSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayCapabilities.
+func (in *GatewayCapabilities) DeepCopy() *GatewayCapabilities {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayCapabilities)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayFallbackSpec) DeepCopyInto(out *GatewayFallbackSpec) {
+	*out = *in
+	if in.BackendRef != nil {
+		in, out := &in.BackendRef, &out.BackendRef
+		*out = new(FallbackBackendRef)
 		(*in).DeepCopyInto(*out)
 	}
-	in.Engine.DeepCopyInto(&out.Engine)
-	if in.Serving != nil {
-		in, out := &in.Serving, &out.Serving
-		*out = new(ServingSpec)
+	if in.OverflowPercent != nil {
+		in, out := &in.OverflowPercent, &out.OverflowPercent
+		*out = new(int32)
 		**out = **in
 	}
-	if in.Scaling != nil {
-		in, out := &in.Scaling, &out.Scaling
-		*out = new(ScalingSpec)
+	if in.SaturationThreshold != nil {
+		in, out := &in.SaturationThreshold, &out.SaturationThreshold
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayFallbackSpec.
+func (in *GatewayFallbackSpec) DeepCopy() *GatewayFallbackSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayFallbackSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewaySpec) DeepCopyInto(out *GatewaySpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ModelAliases != nil {
+		in, out := &in.ModelAliases, &out.ModelAliases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(RateLimitSpec)
+		**out = **in
+	}
+	if in.ResponseHeaders != nil {
+		in, out := &in.ResponseHeaders, &out.ResponseHeaders
+		*out = make([]ResponseHeader, len(*in))
+		copy(*out, *in)
+	}
+	if in.PromptPolicy != nil {
+		in, out := &in.PromptPolicy, &out.PromptPolicy
+		*out = new(PromptPolicySpec)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(ResourceSpec)
+	if in.Guardrails != nil {
+		in, out := &in.Guardrails, &out.Guardrails
+		*out = new(GuardrailsSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Env != nil {
-		in, out := &in.Env, &out.Env
-		*out = make([]corev1.EnvVar, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.ResponseCache != nil {
+		in, out := &in.ResponseCache, &out.ResponseCache
+		*out = new(ResponseCacheSpec)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.PodTemplate != nil {
-		in, out := &in.PodTemplate, &out.PodTemplate
-		*out = new(PodTemplateSpec)
+	if in.Fallback != nil {
+		in, out := &in.Fallback, &out.Fallback
+		*out = new(GatewayFallbackSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Secrets != nil {
-		in, out := &in.Secrets, &out.Secrets
-		*out = new(SecretsSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewaySpec.
+func (in *GatewaySpec) DeepCopy() *GatewaySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewaySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayStatus) DeepCopyInto(out *GatewayStatus) {
+	*out = *in
+	if in.ModelDiscovery != nil {
+		in, out := &in.ModelDiscovery, &out.ModelDiscovery
+		*out = new(ModelDiscoveryStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayStatus.
+func (in *GatewayStatus) DeepCopy() *GatewayStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GenAIMetricsSpec) DeepCopyInto(out *GenAIMetricsSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GenAIMetricsSpec.
+func (in *GenAIMetricsSpec) DeepCopy() *GenAIMetricsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GenAIMetricsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuardrailsSpec) DeepCopyInto(out *GuardrailsSpec) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
 		**out = **in
 	}
-	if in.Gateway != nil {
-		in, out := &in.Gateway, &out.Gateway
-		*out = new(GatewaySpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuardrailsSpec.
+func (in *GuardrailsSpec) DeepCopy() *GuardrailsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GuardrailsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HelmChart) DeepCopyInto(out *HelmChart) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = new(runtime.RawExtension)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.NodeSelector != nil {
-		in, out := &in.NodeSelector, &out.NodeSelector
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmChart.
+func (in *HelmChart) DeepCopy() *HelmChart {
+	if in == nil {
+		return nil
+	}
+	out := new(HelmChart)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HelmRepo) DeepCopyInto(out *HelmRepo) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmRepo.
+func (in *HelmRepo) DeepCopy() *HelmRepo {
+	if in == nil {
+		return nil
+	}
+	out := new(HelmRepo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IdentitySpec) DeepCopyInto(out *IdentitySpec) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
 		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
 		}
 	}
-	if in.Tolerations != nil {
-		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]corev1.Toleration, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdentitySpec.
+func (in *IdentitySpec) DeepCopy() *IdentitySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IdentitySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePolicySpec) DeepCopyInto(out *ImagePolicySpec) {
+	*out = *in
+	if in.AllowedRegistries != nil {
+		in, out := &in.AllowedRegistries, &out.AllowedRegistries
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Cosign != nil {
+		in, out := &in.Cosign, &out.Cosign
+		*out = new(CosignPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePolicySpec.
+func (in *ImagePolicySpec) DeepCopy() *ImagePolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageUpgradeSpec) DeepCopyInto(out *ImageUpgradeSpec) {
+	*out = *in
+	if in.CanaryNamespaces != nil {
+		in, out := &in.CanaryNamespaces, &out.CanaryNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageUpgradeSpec.
+func (in *ImageUpgradeSpec) DeepCopy() *ImageUpgradeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageUpgradeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InferenceProviderConfig) DeepCopyInto(out *InferenceProviderConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceProviderConfig.
+func (in *InferenceProviderConfig) DeepCopy() *InferenceProviderConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(InferenceProviderConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *InferenceProviderConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InferenceProviderConfigList) DeepCopyInto(out *InferenceProviderConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]InferenceProviderConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceProviderConfigList.
+func (in *InferenceProviderConfigList) DeepCopy() *InferenceProviderConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(InferenceProviderConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *InferenceProviderConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InferenceProviderConfigSpec) DeepCopyInto(out *InferenceProviderConfigSpec) {
+	*out = *in
+	if in.Capabilities != nil {
+		in, out := &in.Capabilities, &out.Capabilities
+		*out = new(ProviderCapabilities)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SelectionRules != nil {
+		in, out := &in.SelectionRules, &out.SelectionRules
+		*out = make([]SelectionRule, len(*in))
+		copy(*out, *in)
+	}
+	if in.Compatibility != nil {
+		in, out := &in.Compatibility, &out.Compatibility
+		*out = new(ProviderCompatibility)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImageUpgrade != nil {
+		in, out := &in.ImageUpgrade, &out.ImageUpgrade
+		*out = new(ImageUpgradeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImagePolicy != nil {
+		in, out := &in.ImagePolicy, &out.ImagePolicy
+		*out = new(ImagePolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceProviderConfigSpec.
+func (in *InferenceProviderConfigSpec) DeepCopy() *InferenceProviderConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InferenceProviderConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InferenceProviderConfigStatus) DeepCopyInto(out *InferenceProviderConfigStatus) {
+	*out = *in
+	if in.LastHeartbeat != nil {
+		in, out := &in.LastHeartbeat, &out.LastHeartbeat
+		*out = (*in).DeepCopy()
+	}
+	if in.LastHeartbeatTime != nil {
+		in, out := &in.LastHeartbeatTime, &out.LastHeartbeatTime
+		*out = (*in).DeepCopy()
+	}
+	if in.SupportedFields != nil {
+		in, out := &in.SupportedFields, &out.SupportedFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceProviderConfigStatus.
+func (in *InferenceProviderConfigStatus) DeepCopy() *InferenceProviderConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(InferenceProviderConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstallationInfo) DeepCopyInto(out *InstallationInfo) {
+	*out = *in
+	if in.HelmRepos != nil {
+		in, out := &in.HelmRepos, &out.HelmRepos
+		*out = make([]HelmRepo, len(*in))
+		copy(*out, *in)
+	}
+	if in.HelmCharts != nil {
+		in, out := &in.HelmCharts, &out.HelmCharts
+		*out = make([]HelmChart, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]InstallationStep, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallationInfo.
+func (in *InstallationInfo) DeepCopy() *InstallationInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(InstallationInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstallationStep) DeepCopyInto(out *InstallationStep) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallationStep.
+func (in *InstallationStep) DeepCopy() *InstallationStep {
+	if in == nil {
+		return nil
+	}
+	out := new(InstallationStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KVCacheSpec) DeepCopyInto(out *KVCacheSpec) {
+	*out = *in
+	if in.ConnectionSecretRef != nil {
+		in, out := &in.ConnectionSecretRef, &out.ConnectionSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxSize != nil {
+		in, out := &in.MaxSize, &out.MaxSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KVCacheSpec.
+func (in *KVCacheSpec) DeepCopy() *KVCacheSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KVCacheSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KVCacheStatus) DeepCopyInto(out *KVCacheStatus) {
+	*out = *in
+	if in.PrefixHitPercent != nil {
+		in, out := &in.PrefixHitPercent, &out.PrefixHitPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ExternalHitPercent != nil {
+		in, out := &in.ExternalHitPercent, &out.ExternalHitPercent
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KVCacheStatus.
+func (in *KVCacheStatus) DeepCopy() *KVCacheStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KVCacheStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KVOffloadSpec) DeepCopyInto(out *KVOffloadSpec) {
+	*out = *in
+	out.Size = in.Size.DeepCopy()
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KVOffloadSpec.
+func (in *KVOffloadSpec) DeepCopy() *KVOffloadSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KVOffloadSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoRAAdapter) DeepCopyInto(out *LoRAAdapter) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoRAAdapter.
+func (in *LoRAAdapter) DeepCopy() *LoRAAdapter {
+	if in == nil {
+		return nil
+	}
+	out := new(LoRAAdapter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogSinkSpec) DeepCopyInto(out *LogSinkSpec) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogSinkSpec.
+func (in *LogSinkSpec) DeepCopy() *LogSinkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LogSinkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricsSnapshot) DeepCopyInto(out *MetricsSnapshot) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	out.Window = in.Window
+	if in.P50Latency != nil {
+		in, out := &in.P50Latency, &out.P50Latency
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.P95Latency != nil {
+		in, out := &in.P95Latency, &out.P95Latency
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsSnapshot.
+func (in *MetricsSnapshot) DeepCopy() *MetricsSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricsSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MigrationStatus) DeepCopyInto(out *MigrationStatus) {
+	*out = *in
+	if in.FromEndpoint != nil {
+		in, out := &in.FromEndpoint, &out.FromEndpoint
+		*out = new(EndpointStatus)
+		**out = **in
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastTransitionTime != nil {
+		in, out := &in.LastTransitionTime, &out.LastTransitionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrationStatus.
+func (in *MigrationStatus) DeepCopy() *MigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelBatchJob) DeepCopyInto(out *ModelBatchJob) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelBatchJob.
+func (in *ModelBatchJob) DeepCopy() *ModelBatchJob {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelBatchJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ModelBatchJob) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelBatchJobList) DeepCopyInto(out *ModelBatchJobList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ModelBatchJob, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelBatchJobList.
+func (in *ModelBatchJobList) DeepCopy() *ModelBatchJobList {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelBatchJobList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ModelBatchJobList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelBatchJobSpec) DeepCopyInto(out *ModelBatchJobSpec) {
+	*out = *in
+	if in.Deployment != nil {
+		in, out := &in.Deployment, &out.Deployment
+		*out = new(ModelDeploymentSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxTokens != nil {
+		in, out := &in.MaxTokens, &out.MaxTokens
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelBatchJobSpec.
+func (in *ModelBatchJobSpec) DeepCopy() *ModelBatchJobSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelBatchJobSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelBatchJobStatus) DeepCopyInto(out *ModelBatchJobStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelBatchJobStatus.
+func (in *ModelBatchJobStatus) DeepCopy() *ModelBatchJobStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelBatchJobStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelDeployment) DeepCopyInto(out *ModelDeployment) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelDeployment.
+func (in *ModelDeployment) DeepCopy() *ModelDeployment {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelDeployment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ModelDeployment) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelDeploymentList) DeepCopyInto(out *ModelDeploymentList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ModelDeployment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelDeploymentList.
+func (in *ModelDeploymentList) DeepCopy() *ModelDeploymentList {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelDeploymentList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ModelDeploymentList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelDeploymentQuota) DeepCopyInto(out *ModelDeploymentQuota) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelDeploymentQuota.
+func (in *ModelDeploymentQuota) DeepCopy() *ModelDeploymentQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelDeploymentQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ModelDeploymentQuota) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelDeploymentQuotaList) DeepCopyInto(out *ModelDeploymentQuotaList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ModelDeploymentQuota, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelDeploymentQuotaList.
+func (in *ModelDeploymentQuotaList) DeepCopy() *ModelDeploymentQuotaList {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelDeploymentQuotaList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ModelDeploymentQuotaList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelDeploymentQuotaSpec) DeepCopyInto(out *ModelDeploymentQuotaSpec) {
+	*out = *in
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelDeploymentQuotaSpec.
+func (in *ModelDeploymentQuotaSpec) DeepCopy() *ModelDeploymentQuotaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelDeploymentQuotaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelDeploymentQuotaStatus) DeepCopyInto(out *ModelDeploymentQuotaStatus) {
+	*out = *in
+	if in.Used != nil {
+		in, out := &in.Used, &out.Used
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelDeploymentQuotaStatus.
+func (in *ModelDeploymentQuotaStatus) DeepCopy() *ModelDeploymentQuotaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelDeploymentQuotaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelDeploymentSpec) DeepCopyInto(out *ModelDeploymentSpec) {
+	*out = *in
+	in.Model.DeepCopyInto(&out.Model)
+	if in.Provider != nil {
+		in, out := &in.Provider, &out.Provider
+		*out = new(ProviderSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Engine.DeepCopyInto(&out.Engine)
+	if in.Serving != nil {
+		in, out := &in.Serving, &out.Serving
+		*out = new(ServingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Scaling != nil {
+		in, out := &in.Scaling, &out.Scaling
+		*out = new(ScalingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Multinode != nil {
+		in, out := &in.Multinode, &out.Multinode
+		*out = new(MultinodeSpec)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ResourceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PodTemplate != nil {
+		in, out := &in.PodTemplate, &out.PodTemplate
+		*out = new(PodTemplateSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = new(SecretsSpec)
+		**out = **in
+	}
+	if in.Identity != nil {
+		in, out := &in.Identity, &out.Identity
+		*out = new(IdentitySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Gateway != nil {
+		in, out := &in.Gateway, &out.Gateway
+		*out = new(GatewaySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Expose != nil {
+		in, out := &in.Expose, &out.Expose
+		*out = new(ExposeSpec)
+		**out = **in
+	}
+	if in.Networking != nil {
+		in, out := &in.Networking, &out.Networking
+		*out = new(NetworkingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Warmup != nil {
+		in, out := &in.Warmup, &out.Warmup
+		*out = new(WarmupSpec)
+		**out = **in
+	}
+	if in.Caching != nil {
+		in, out := &in.Caching, &out.Caching
+		*out = new(CachingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Observability != nil {
+		in, out := &in.Observability, &out.Observability
+		*out = new(ObservabilitySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Scheduling != nil {
+		in, out := &in.Scheduling, &out.Scheduling
+		*out = new(SchedulingSpec)
+		**out = **in
+	}
+	if in.ProgressDeadlineSeconds != nil {
+		in, out := &in.ProgressDeadlineSeconds, &out.ProgressDeadlineSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TTLSecondsAfterCreation != nil {
+		in, out := &in.TTLSecondsAfterCreation, &out.TTLSecondsAfterCreation
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TTLSecondsAfterLastRequest != nil {
+		in, out := &in.TTLSecondsAfterLastRequest, &out.TTLSecondsAfterLastRequest
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelDeploymentSpec.
+func (in *ModelDeploymentSpec) DeepCopy() *ModelDeploymentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelDeploymentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelDeploymentStatus) DeepCopyInto(out *ModelDeploymentStatus) {
+	*out = *in
+	if in.Provider != nil {
+		in, out := &in.Provider, &out.Provider
+		*out = new(ProviderStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Migration != nil {
+		in, out := &in.Migration, &out.Migration
+		*out = new(MigrationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Engine != nil {
+		in, out := &in.Engine, &out.Engine
+		*out = new(EngineStatus)
+		**out = **in
+	}
+	if in.Gateway != nil {
+		in, out := &in.Gateway, &out.Gateway
+		*out = new(GatewayStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Expose != nil {
+		in, out := &in.Expose, &out.Expose
+		*out = new(ExposeStatus)
+		**out = **in
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(ReplicaStatus)
+		**out = **in
+	}
+	if in.Endpoint != nil {
+		in, out := &in.Endpoint, &out.Endpoint
+		*out = new(EndpointStatus)
+		**out = **in
+	}
+	if in.Warmup != nil {
+		in, out := &in.Warmup, &out.Warmup
+		*out = new(WarmupStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Admission != nil {
+		in, out := &in.Admission, &out.Admission
+		*out = new(AdmissionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastAppliedChange != nil {
+		in, out := &in.LastAppliedChange, &out.LastAppliedChange
+		*out = new(AppliedChange)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastRequestTime != nil {
+		in, out := &in.LastRequestTime, &out.LastRequestTime
+		*out = (*in).DeepCopy()
+	}
+	if in.KVCache != nil {
+		in, out := &in.KVCache, &out.KVCache
+		*out = new(KVCacheStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Adapters != nil {
+		in, out := &in.Adapters, &out.Adapters
+		*out = make([]AdapterStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Placement != nil {
+		in, out := &in.Placement, &out.Placement
+		*out = make([]PodPlacement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MetricsSnapshot != nil {
+		in, out := &in.MetricsSnapshot, &out.MetricsSnapshot
+		*out = new(MetricsSnapshot)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Recommendations != nil {
+		in, out := &in.Recommendations, &out.Recommendations
+		*out = new(ResourceRecommendations)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GPUUtilization != nil {
+		in, out := &in.GPUUtilization, &out.GPUUtilization
+		*out = new(GPUUtilizationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SelectionReport != nil {
+		in, out := &in.SelectionReport, &out.SelectionReport
+		*out = new(SelectionReport)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Remediation != nil {
+		in, out := &in.Remediation, &out.Remediation
+		*out = new(RemediationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelDeploymentStatus.
+func (in *ModelDeploymentStatus) DeepCopy() *ModelDeploymentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelDeploymentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelDiscoveryStatus) DeepCopyInto(out *ModelDiscoveryStatus) {
+	*out = *in
+	in.ProbeTime.DeepCopyInto(&out.ProbeTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelDiscoveryStatus.
+func (in *ModelDiscoveryStatus) DeepCopy() *ModelDiscoveryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelDiscoveryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelFleet) DeepCopyInto(out *ModelFleet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelFleet.
+func (in *ModelFleet) DeepCopy() *ModelFleet {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelFleet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ModelFleet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelFleetItem) DeepCopyInto(out *ModelFleetItem) {
+	*out = *in
+	if in.Model != nil {
+		in, out := &in.Model, &out.Model
+		*out = new(ModelSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ResourceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelFleetItem.
+func (in *ModelFleetItem) DeepCopy() *ModelFleetItem {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelFleetItem)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelFleetItemStatus) DeepCopyInto(out *ModelFleetItemStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelFleetItemStatus.
+func (in *ModelFleetItemStatus) DeepCopy() *ModelFleetItemStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelFleetItemStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelFleetList) DeepCopyInto(out *ModelFleetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ModelFleet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelFleetList.
+func (in *ModelFleetList) DeepCopy() *ModelFleetList {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelFleetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ModelFleetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelFleetSpec) DeepCopyInto(out *ModelFleetSpec) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ModelFleetItem, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelFleetSpec.
+func (in *ModelFleetSpec) DeepCopy() *ModelFleetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelFleetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelFleetStatus) DeepCopyInto(out *ModelFleetStatus) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ModelFleetItemStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelFleetStatus.
+func (in *ModelFleetStatus) DeepCopy() *ModelFleetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelFleetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelPolicy) DeepCopyInto(out *ModelPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelPolicy.
+func (in *ModelPolicy) DeepCopy() *ModelPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ModelPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelPolicyList) DeepCopyInto(out *ModelPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ModelPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelPolicyList.
+func (in *ModelPolicyList) DeepCopy() *ModelPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ModelPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelPolicySpec) DeepCopyInto(out *ModelPolicySpec) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AllowedLicenses != nil {
+		in, out := &in.AllowedLicenses, &out.AllowedLicenses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeniedLicenses != nil {
+		in, out := &in.DeniedLicenses, &out.DeniedLicenses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedModels != nil {
+		in, out := &in.AllowedModels, &out.AllowedModels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeniedModels != nil {
+		in, out := &in.DeniedModels, &out.DeniedModels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelPolicySpec.
+func (in *ModelPolicySpec) DeepCopy() *ModelPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelSpec) DeepCopyInto(out *ModelSpec) {
+	*out = *in
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(StorageSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ChatTemplate != nil {
+		in, out := &in.ChatTemplate, &out.ChatTemplate
+		*out = new(ChatTemplateSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Adapters != nil {
+		in, out := &in.Adapters, &out.Adapters
+		*out = make([]LoRAAdapter, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelSpec.
+func (in *ModelSpec) DeepCopy() *ModelSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultinodeSpec) DeepCopyInto(out *MultinodeSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultinodeSpec.
+func (in *MultinodeSpec) DeepCopy() *MultinodeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MultinodeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkDependency) DeepCopyInto(out *NetworkDependency) {
+	*out = *in
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkDependency.
+func (in *NetworkDependency) DeepCopy() *NetworkDependency {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkDependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkingSpec) DeepCopyInto(out *NetworkingSpec) {
+	*out = *in
+	if in.IPFamilyPolicy != nil {
+		in, out := &in.IPFamilyPolicy, &out.IPFamilyPolicy
+		*out = new(corev1.IPFamilyPolicy)
+		**out = **in
+	}
+	if in.IPFamilies != nil {
+		in, out := &in.IPFamilies, &out.IPFamilies
+		*out = make([]corev1.IPFamily, len(*in))
+		copy(*out, *in)
+	}
+	if in.Egress != nil {
+		in, out := &in.Egress, &out.Egress
+		*out = make([]networkingv1.NetworkPolicyEgressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkingSpec.
+func (in *NetworkingSpec) DeepCopy() *NetworkingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObservabilitySpec) DeepCopyInto(out *ObservabilitySpec) {
+	*out = *in
+	if in.Tracing != nil {
+		in, out := &in.Tracing, &out.Tracing
+		*out = new(TracingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LogSink != nil {
+		in, out := &in.LogSink, &out.LogSink
+		*out = new(LogSinkSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GenAIMetrics != nil {
+		in, out := &in.GenAIMetrics, &out.GenAIMetrics
+		*out = new(GenAIMetricsSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObservabilitySpec.
+func (in *ObservabilitySpec) DeepCopy() *ObservabilitySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ObservabilitySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PartialApplyStatus) DeepCopyInto(out *PartialApplyStatus) {
+	*out = *in
+	if in.AppliedResources != nil {
+		in, out := &in.AppliedResources, &out.AppliedResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RolledBackResources != nil {
+		in, out := &in.RolledBackResources, &out.RolledBackResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PartialApplyStatus.
+func (in *PartialApplyStatus) DeepCopy() *PartialApplyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PartialApplyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlacementSpec) DeepCopyInto(out *PlacementSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlacementSpec.
+func (in *PlacementSpec) DeepCopy() *PlacementSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PlacementSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodPlacement) DeepCopyInto(out *PodPlacement) {
+	*out = *in
+	if in.GPUIndices != nil {
+		in, out := &in.GPUIndices, &out.GPUIndices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodPlacement.
+func (in *PodPlacement) DeepCopy() *PodPlacement {
+	if in == nil {
+		return nil
+	}
+	out := new(PodPlacement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodTemplateMetadata) DeepCopyInto(out *PodTemplateMetadata) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodTemplateMetadata.
+func (in *PodTemplateMetadata) DeepCopy() *PodTemplateMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(PodTemplateMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodTemplateSpec) DeepCopyInto(out *PodTemplateSpec) {
+	*out = *in
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = new(PodTemplateMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodTemplateSpec.
+func (in *PodTemplateSpec) DeepCopy() *PodTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PodTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PromptPolicySpec) DeepCopyInto(out *PromptPolicySpec) {
+	*out = *in
+	if in.MaxTokens != nil {
+		in, out := &in.MaxTokens, &out.MaxTokens
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Stop != nil {
+		in, out := &in.Stop, &out.Stop
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PromptPolicySpec.
+func (in *PromptPolicySpec) DeepCopy() *PromptPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PromptPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderCapabilities) DeepCopyInto(out *ProviderCapabilities) {
+	*out = *in
+	if in.Engines != nil {
+		in, out := &in.Engines, &out.Engines
+		*out = make([]EngineType, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServingModes != nil {
+		in, out := &in.ServingModes, &out.ServingModes
+		*out = make([]ServingMode, len(*in))
+		copy(*out, *in)
+	}
+	if in.CPUEngines != nil {
+		in, out := &in.CPUEngines, &out.CPUEngines
+		*out = make([]EngineType, len(*in))
+		copy(*out, *in)
+	}
+	if in.RequiresCRD != nil {
+		in, out := &in.RequiresCRD, &out.RequiresCRD
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Gateway != nil {
+		in, out := &in.Gateway, &out.Gateway
+		*out = new(GatewayCapabilities)
+		**out = **in
+	}
+	if in.HotModelSwapEngines != nil {
+		in, out := &in.HotModelSwapEngines, &out.HotModelSwapEngines
+		*out = make([]EngineType, len(*in))
+		copy(*out, *in)
+	}
+	if in.NetworkDependencies != nil {
+		in, out := &in.NetworkDependencies, &out.NetworkDependencies
+		*out = make([]NetworkDependency, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderCapabilities.
+func (in *ProviderCapabilities) DeepCopy() *ProviderCapabilities {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderCapabilities)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderCompatibility) DeepCopyInto(out *ProviderCompatibility) {
+	*out = *in
+	if in.CRDVersions != nil {
+		in, out := &in.CRDVersions, &out.CRDVersions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderCompatibility.
+func (in *ProviderCompatibility) DeepCopy() *ProviderCompatibility {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderCompatibility)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderEvaluation) DeepCopyInto(out *ProviderEvaluation) {
+	*out = *in
+	if in.Criteria != nil {
+		in, out := &in.Criteria, &out.Criteria
+		*out = make([]SelectionCriterion, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]SelectionRuleResult, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderEvaluation.
+func (in *ProviderEvaluation) DeepCopy() *ProviderEvaluation {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderEvaluation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderSelectionParameters) DeepCopyInto(out *ProviderSelectionParameters) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderSelectionParameters.
+func (in *ProviderSelectionParameters) DeepCopy() *ProviderSelectionParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderSelectionParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderSpec) DeepCopyInto(out *ProviderSpec) {
+	*out = *in
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderSpec.
+func (in *ProviderSpec) DeepCopy() *ProviderSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderStatus) DeepCopyInto(out *ProviderStatus) {
+	*out = *in
+	if in.PartialApply != nil {
+		in, out := &in.PartialApply, &out.PartialApply
+		*out = new(PartialApplyStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DefaultImageTime != nil {
+		in, out := &in.DefaultImageTime, &out.DefaultImageTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderStatus.
+func (in *ProviderStatus) DeepCopy() *ProviderStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimitSpec) DeepCopyInto(out *RateLimitSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitSpec.
+func (in *RateLimitSpec) DeepCopy() *RateLimitSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimitSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediationAttempt) DeepCopyInto(out *RemediationAttempt) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediationAttempt.
+func (in *RemediationAttempt) DeepCopy() *RemediationAttempt {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediationAttempt)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediationStatus) DeepCopyInto(out *RemediationStatus) {
+	*out = *in
+	if in.Attempts != nil {
+		in, out := &in.Attempts, &out.Attempts
+		*out = make([]RemediationAttempt, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediationStatus.
+func (in *RemediationStatus) DeepCopy() *RemediationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicaStatus) DeepCopyInto(out *ReplicaStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaStatus.
+func (in *ReplicaStatus) DeepCopy() *ReplicaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceQuantities) DeepCopyInto(out *ResourceQuantities) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceQuantities.
+func (in *ResourceQuantities) DeepCopy() *ResourceQuantities {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceQuantities)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceRecommendations) DeepCopyInto(out *ResourceRecommendations) {
+	*out = *in
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ObservedSince != nil {
+		in, out := &in.ObservedSince, &out.ObservedSince
+		*out = (*in).DeepCopy()
+	}
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastAppliedTime != nil {
+		in, out := &in.LastAppliedTime, &out.LastAppliedTime
+		*out = (*in).DeepCopy()
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelDeploymentSpec.
-func (in *ModelDeploymentSpec) DeepCopy() *ModelDeploymentSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRecommendations.
+func (in *ResourceRecommendations) DeepCopy() *ResourceRecommendations {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelDeploymentSpec)
+	out := new(ResourceRecommendations)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelDeploymentStatus) DeepCopyInto(out *ModelDeploymentStatus) {
+func (in *ResourceSpec) DeepCopyInto(out *ResourceSpec) {
 	*out = *in
-	if in.Provider != nil {
-		in, out := &in.Provider, &out.Provider
-		*out = new(ProviderStatus)
-		**out = **in
+	if in.GPU != nil {
+		in, out := &in.GPU, &out.GPU
+		*out = new(GPUSpec)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.Engine != nil {
-		in, out := &in.Engine, &out.Engine
-		*out = new(EngineStatus)
+	if in.Requests != nil {
+		in, out := &in.Requests, &out.Requests
+		*out = new(ResourceQuantities)
 		**out = **in
 	}
-	if in.Gateway != nil {
-		in, out := &in.Gateway, &out.Gateway
-		*out = new(GatewayStatus)
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = new(ResourceQuantities)
 		**out = **in
 	}
-	if in.Replicas != nil {
-		in, out := &in.Replicas, &out.Replicas
-		*out = new(ReplicaStatus)
+	if in.AutotuneBounds != nil {
+		in, out := &in.AutotuneBounds, &out.AutotuneBounds
+		*out = new(AutotuneBounds)
 		**out = **in
 	}
-	if in.Endpoint != nil {
-		in, out := &in.Endpoint, &out.Endpoint
-		*out = new(EndpointStatus)
+	if in.CPUPinning != nil {
+		in, out := &in.CPUPinning, &out.CPUPinning
+		*out = new(CPUPinningSpec)
 		**out = **in
 	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelDeploymentStatus.
-func (in *ModelDeploymentStatus) DeepCopy() *ModelDeploymentStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceSpec.
+func (in *ResourceSpec) DeepCopy() *ResourceSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelDeploymentStatus)
+	out := new(ResourceSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelSpec) DeepCopyInto(out *ModelSpec) {
+func (in *ResponseCacheSpec) DeepCopyInto(out *ResponseCacheSpec) {
 	*out = *in
-	if in.Storage != nil {
-		in, out := &in.Storage, &out.Storage
-		*out = new(StorageSpec)
-		(*in).DeepCopyInto(*out)
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(v1.Duration)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelSpec.
-func (in *ModelSpec) DeepCopy() *ModelSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResponseCacheSpec.
+func (in *ResponseCacheSpec) DeepCopy() *ResponseCacheSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelSpec)
+	out := new(ResponseCacheSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodTemplateMetadata) DeepCopyInto(out *PodTemplateMetadata) {
+func (in *RouterSpec) DeepCopyInto(out *RouterSpec) {
 	*out = *in
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodTemplateMetadata.
-func (in *PodTemplateMetadata) DeepCopy() *PodTemplateMetadata {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouterSpec.
+func (in *RouterSpec) DeepCopy() *RouterSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PodTemplateMetadata)
+	out := new(RouterSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodTemplateSpec) DeepCopyInto(out *PodTemplateSpec) {
+func (in *ScalingSpec) DeepCopyInto(out *ScalingSpec) {
 	*out = *in
-	if in.Metadata != nil {
-		in, out := &in.Metadata, &out.Metadata
-		*out = new(PodTemplateMetadata)
+	if in.Prefill != nil {
+		in, out := &in.Prefill, &out.Prefill
+		*out = new(ComponentScalingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Decode != nil {
+		in, out := &in.Decode, &out.Decode
+		*out = new(ComponentScalingSpec)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodTemplateSpec.
-func (in *PodTemplateSpec) DeepCopy() *PodTemplateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingSpec.
+func (in *ScalingSpec) DeepCopy() *ScalingSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PodTemplateSpec)
+	out := new(ScalingSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProviderCapabilities) DeepCopyInto(out *ProviderCapabilities) {
+func (in *SchedulingSpec) DeepCopyInto(out *SchedulingSpec) {
 	*out = *in
-	if in.Engines != nil {
-		in, out := &in.Engines, &out.Engines
-		*out = make([]EngineType, len(*in))
-		copy(*out, *in)
-	}
-	if in.ServingModes != nil {
-		in, out := &in.ServingModes, &out.ServingModes
-		*out = make([]ServingMode, len(*in))
-		copy(*out, *in)
-	}
-	if in.RequiresCRD != nil {
-		in, out := &in.RequiresCRD, &out.RequiresCRD
-		*out = new(bool)
-		**out = **in
-	}
-	if in.Gateway != nil {
-		in, out := &in.Gateway, &out.Gateway
-		*out = new(GatewayCapabilities)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderCapabilities.
-func (in *ProviderCapabilities) DeepCopy() *ProviderCapabilities {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulingSpec.
+func (in *SchedulingSpec) DeepCopy() *SchedulingSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ProviderCapabilities)
+	out := new(SchedulingSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProviderSpec) DeepCopyInto(out *ProviderSpec) {
+func (in *SecretsSpec) DeepCopyInto(out *SecretsSpec) {
 	*out = *in
-	if in.Overrides != nil {
-		in, out := &in.Overrides, &out.Overrides
-		*out = new(runtime.RawExtension)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderSpec.
-func (in *ProviderSpec) DeepCopy() *ProviderSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretsSpec.
+func (in *SecretsSpec) DeepCopy() *SecretsSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ProviderSpec)
+	out := new(SecretsSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProviderStatus) DeepCopyInto(out *ProviderStatus) {
+func (in *SelectionCriterion) DeepCopyInto(out *SelectionCriterion) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderStatus.
-func (in *ProviderStatus) DeepCopy() *ProviderStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelectionCriterion.
+func (in *SelectionCriterion) DeepCopy() *SelectionCriterion {
 	if in == nil {
 		return nil
 	}
-	out := new(ProviderStatus)
+	out := new(SelectionCriterion)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ReplicaStatus) DeepCopyInto(out *ReplicaStatus) {
+func (in *SelectionPolicy) DeepCopyInto(out *SelectionPolicy) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaStatus.
-func (in *ReplicaStatus) DeepCopy() *ReplicaStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelectionPolicy.
+func (in *SelectionPolicy) DeepCopy() *SelectionPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(ReplicaStatus)
+	out := new(SelectionPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SelectionPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResourceSpec) DeepCopyInto(out *ResourceSpec) {
+func (in *SelectionPolicyList) DeepCopyInto(out *SelectionPolicyList) {
 	*out = *in
-	if in.GPU != nil {
-		in, out := &in.GPU, &out.GPU
-		*out = new(GPUSpec)
-		**out = **in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SelectionPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceSpec.
-func (in *ResourceSpec) DeepCopy() *ResourceSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelectionPolicyList.
+func (in *SelectionPolicyList) DeepCopy() *SelectionPolicyList {
 	if in == nil {
 		return nil
 	}
-	out := new(ResourceSpec)
+	out := new(SelectionPolicyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SelectionPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ScalingSpec) DeepCopyInto(out *ScalingSpec) {
+func (in *SelectionPolicySpec) DeepCopyInto(out *SelectionPolicySpec) {
 	*out = *in
-	if in.Prefill != nil {
-		in, out := &in.Prefill, &out.Prefill
-		*out = new(ComponentScalingSpec)
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Decode != nil {
-		in, out := &in.Decode, &out.Decode
-		*out = new(ComponentScalingSpec)
-		(*in).DeepCopyInto(*out)
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]ProviderSelectionParameters, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingSpec.
-func (in *ScalingSpec) DeepCopy() *ScalingSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelectionPolicySpec.
+func (in *SelectionPolicySpec) DeepCopy() *SelectionPolicySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ScalingSpec)
+	out := new(SelectionPolicySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecretsSpec) DeepCopyInto(out *SecretsSpec) {
+func (in *SelectionReport) DeepCopyInto(out *SelectionReport) {
 	*out = *in
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]ProviderEvaluation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.EvaluatedTime != nil {
+		in, out := &in.EvaluatedTime, &out.EvaluatedTime
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretsSpec.
-func (in *SecretsSpec) DeepCopy() *SecretsSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelectionReport.
+func (in *SelectionReport) DeepCopy() *SelectionReport {
 	if in == nil {
 		return nil
 	}
-	out := new(SecretsSpec)
+	out := new(SelectionReport)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -768,9 +2655,34 @@ func (in *SelectionRule) DeepCopy() *SelectionRule {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SelectionRuleResult) DeepCopyInto(out *SelectionRuleResult) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelectionRuleResult.
+func (in *SelectionRuleResult) DeepCopy() *SelectionRuleResult {
+	if in == nil {
+		return nil
+	}
+	out := new(SelectionRuleResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServingSpec) DeepCopyInto(out *ServingSpec) {
 	*out = *in
+	if in.Placement != nil {
+		in, out := &in.Placement, &out.Placement
+		*out = new(PlacementSpec)
+		**out = **in
+	}
+	if in.Router != nil {
+		in, out := &in.Router, &out.Router
+		*out = new(RouterSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServingSpec.
@@ -793,6 +2705,11 @@ func (in *StorageSpec) DeepCopyInto(out *StorageSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.KVOffload != nil {
+		in, out := &in.KVOffload, &out.KVOffload
+		*out = new(KVOffloadSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageSpec.
@@ -829,3 +2746,67 @@ func (in *StorageVolume) DeepCopy() *StorageVolume {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracingSpec) DeepCopyInto(out *TracingSpec) {
+	*out = *in
+	if in.SamplingPercent != nil {
+		in, out := &in.SamplingPercent, &out.SamplingPercent
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracingSpec.
+func (in *TracingSpec) DeepCopy() *TracingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TracingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WarmupSpec) DeepCopyInto(out *WarmupSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WarmupSpec.
+func (in *WarmupSpec) DeepCopy() *WarmupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WarmupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WarmupStatus) DeepCopyInto(out *WarmupStatus) {
+	*out = *in
+	if in.FirstRequestLatency != nil {
+		in, out := &in.FirstRequestLatency, &out.FirstRequestLatency
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.AverageLatency != nil {
+		in, out := &in.AverageLatency, &out.AverageLatency
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WarmupStatus.
+func (in *WarmupStatus) DeepCopy() *WarmupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WarmupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command admission-policy writes the ValidatingAdmissionPolicy and binding that mirror the
+// ModelDeployment webhook's spec checks, for clusters that run with ENABLE_WEBHOOKS=false.
+//
+//	admission-policy [-o admission-policy.yaml] [--validation-actions=Deny]
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"sigs.k8s.io/yaml"
+
+	webhookv1alpha1 "github.com/kaito-project/airunway/controller/internal/webhook/v1alpha1"
+)
+
+func main() {
+	var output, actions string
+	flag.StringVar(&output, "o", "", "Write the manifests to this file instead of stdout.")
+	flag.StringVar(&actions, "validation-actions", string(admissionregistrationv1.Deny),
+		"Comma-separated validation actions of the binding: Deny, Warn or Audit.")
+	flag.Parse()
+
+	if flag.NArg() != 0 {
+		fmt.Fprintln(os.Stderr, "usage: admission-policy [-o file] [--validation-actions=Deny,Warn,Audit]")
+		os.Exit(2)
+	}
+
+	if err := run(output, actions); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+func run(output, actions string) error {
+	var validationActions []admissionregistrationv1.ValidationAction
+	for _, a := range strings.Split(actions, ",") {
+		switch action := admissionregistrationv1.ValidationAction(strings.TrimSpace(a)); action {
+		case admissionregistrationv1.Deny, admissionregistrationv1.Warn, admissionregistrationv1.Audit:
+			validationActions = append(validationActions, action)
+		default:
+			return fmt.Errorf("unknown validation action %q, must be Deny, Warn or Audit", a)
+		}
+	}
+
+	policy, binding := webhookv1alpha1.AdmissionPolicy(validationActions...)
+	var buf bytes.Buffer
+	for i, obj := range []any{policy, binding} {
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return fmt.Errorf("marshaling manifest: %w", err)
+		}
+		if i > 0 {
+			buf.WriteString("---\n")
+		}
+		buf.Write(data)
+	}
+
+	if output == "" {
+		_, err := os.Stdout.Write(buf.Bytes())
+		return err
+	}
+	return os.WriteFile(output, buf.Bytes(), 0o600)
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command batch-runner is the batch Job the controller runs for a ModelBatchJob. It serves
+// its progress on --progress-port while it runs and writes the final counts to
+// /dev/termination-log when it exits.
+//
+//	batch-runner --input <path|url> --output <path|url> --endpoint http://llama.default.svc:8000
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/kaito-project/airunway/controller/internal/batch"
+)
+
+// terminationLog is where Kubernetes reads the termination message of the container
+const terminationLog = "/dev/termination-log"
+
+func main() {
+	var cfg batch.Config
+	var maxTokens, concurrency, port int
+	flag.StringVar(&cfg.Input, "input", "", "Path or http(s) URL of the JSON Lines dataset.")
+	flag.StringVar(&cfg.Output, "output", "", "Path or http(s) URL the results are written to.")
+	flag.StringVar(&cfg.BaseURL, "endpoint", "", "URL of the OpenAI-compatible model server.")
+	flag.StringVar(&cfg.Model, "model", "", "Served model name. Defaults to the first model of /v1/models.")
+	flag.StringVar(&cfg.PromptTemplate, "prompt-template", "", "Go template rendering a record into a prompt.")
+	flag.IntVar(&maxTokens, "max-tokens", 0, "Maximum tokens generated per request. 0 leaves it to the model server.")
+	flag.IntVar(&concurrency, "concurrency", batch.DefaultConcurrency, "Number of requests in flight.")
+	flag.StringVar(&cfg.WorkDir, "work-dir", os.TempDir(), "Directory for the downloaded input and the output before it is uploaded.")
+	flag.IntVar(&port, "progress-port", int(batch.ProgressPort), "Port of the progress endpoint.")
+	flag.Parse()
+	cfg.MaxTokens = int32(maxTokens)
+	cfg.Concurrency = concurrency
+	cfg.HTTPClient = &http.Client{Timeout: 10 * time.Minute}
+	cfg.RetryDelay = 2 * time.Second
+
+	var tracker batch.Tracker
+	err := run(cfg, port, &tracker)
+	progress := tracker.Progress()
+	if err != nil {
+		progress.Error = err.Error()
+	}
+	if data, marshalErr := json.Marshal(progress); marshalErr == nil {
+		_ = os.WriteFile(terminationLog, data, 0o644)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+	fmt.Printf("processed %d records: %d completed, %d failed\n", progress.Total, progress.Completed, progress.Failed)
+}
+
+func run(cfg batch.Config, port int, tracker *batch.Tracker) error {
+	if cfg.Input == "" || cfg.Output == "" || cfg.BaseURL == "" {
+		return errors.New("--input, --output and --endpoint are required")
+	}
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return fmt.Errorf("failed to listen on port %d: %w", port, err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle(batch.ProgressPath, tracker)
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() { _ = srv.Serve(lis) }()
+	defer srv.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	return batch.Run(ctx, cfg, tracker)
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command export writes a portable YAML bundle for a ModelDeployment.
+//
+//	export -n <namespace> [-o bundle.yaml] [--include-generated=false] <name>
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/discovery"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/pkg/export"
+)
+
+func main() {
+	var namespace, output string
+	var includeGenerated bool
+	flag.StringVar(&namespace, "n", "default", "Namespace of the ModelDeployment.")
+	flag.StringVar(&output, "o", "", "Write the bundle to this file instead of stdout.")
+	flag.BoolVar(&includeGenerated, "include-generated", true,
+		"Include the resolved provider resource and gateway objects in the bundle.")
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: export -n <namespace> [-o file] [--include-generated=false] <modeldeployment>")
+		os.Exit(2)
+	}
+
+	if err := run(context.Background(), namespace, flag.Arg(0), output, includeGenerated); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, namespace, name, output string, includeGenerated bool) error {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(airunwayv1alpha1.AddToScheme(scheme))
+
+	cfg, err := ctrl.GetConfig()
+	if err != nil {
+		return fmt.Errorf("loading kubeconfig: %w", err)
+	}
+	c, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		return fmt.Errorf("creating client: %w", err)
+	}
+	dc, err := discovery.NewDiscoveryClientForConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("creating discovery client: %w", err)
+	}
+
+	exporter := &export.Exporter{Client: c, Discovery: dc}
+	bundle, err := exporter.Bundle(ctx, namespace, name, export.Options{
+		IncludeGenerated: includeGenerated,
+		Warnings:         os.Stderr,
+	})
+	if err != nil {
+		return err
+	}
+
+	if output == "" {
+		_, err = os.Stdout.Write(bundle)
+		return err
+	}
+	return os.WriteFile(output, bundle, 0o600)
+}
@@ -24,11 +24,13 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/pem"
+	"errors"
 	"flag"
 	"fmt"
 	"math/big"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
@@ -41,19 +43,30 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/internal/batch"
+	"github.com/kaito-project/airunway/controller/internal/config"
 	"github.com/kaito-project/airunway/controller/internal/controller"
 	"github.com/kaito-project/airunway/controller/internal/gateway"
+	"github.com/kaito-project/airunway/controller/internal/recommender"
+	"github.com/kaito-project/airunway/controller/internal/remediation"
+	"github.com/kaito-project/airunway/controller/internal/startup"
 	webhookv1alpha1 "github.com/kaito-project/airunway/controller/internal/webhook/v1alpha1"
+	"github.com/kaito-project/airunway/controller/pkg/provider"
+	"github.com/kaito-project/airunway/controller/pkg/selection"
 	inferencev1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	inferencev1alpha2 "sigs.k8s.io/gateway-api-inference-extension/apix/v1alpha2"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 	// +kubebuilder:scaffold:imports
@@ -80,9 +93,70 @@ func init() {
 	utilruntime.Must(gatewayv1.Install(scheme))
 	utilruntime.Must(gatewayv1beta1.Install(scheme))
 	utilruntime.Must(inferencev1.Install(scheme))
+	utilruntime.Must(inferencev1alpha2.Install(scheme))
 	// +kubebuilder:scaffold:scheme
 }
 
+// startupChecks returns the checks the manager runs when it boots. Gateway API and GAIE
+// CRDs are only required when a Gateway is configured or provisioned; otherwise the
+// gateway integration is turned off while they are missing. The webhook checks are
+// skipped when webhooks are disabled.
+func startupChecks(dc discovery.DiscoveryInterface, reader client.Reader, detector *gateway.Detector, webhookServer webhook.Server, webhookDNSName string) []startup.Check {
+	gatewayRequired := detector.HasExplicitGateway() || detector.ShouldProvisionGateway()
+	checks := []startup.Check{
+		{
+			Name:     "airunway-crds",
+			Required: true,
+			Run: startup.CRDsServed(dc,
+				provider.UpstreamCRD{Group: airunwayv1alpha1.GroupVersion.Group, Resource: "modeldeployments", Kind: "ModelDeployment"},
+				provider.UpstreamCRD{Group: airunwayv1alpha1.GroupVersion.Group, Resource: "inferenceproviderconfigs", Kind: "InferenceProviderConfig"},
+				provider.UpstreamCRD{Group: airunwayv1alpha1.GroupVersion.Group, Resource: "modelfleets", Kind: "ModelFleet"},
+				provider.UpstreamCRD{Group: airunwayv1alpha1.GroupVersion.Group, Resource: "modeldeploymentquotas", Kind: "ModelDeploymentQuota"},
+			),
+		},
+		{
+			Name:     "gateway-api-crds",
+			Required: gatewayRequired,
+			Disables: "HTTPRoutes and the gateway endpoint of ModelDeployments",
+			Run: startup.CRDsServed(dc,
+				provider.UpstreamCRD{Group: gateway.HTTPRouteCRDGroup, Resource: gateway.HTTPRouteCRDResource, Kind: "HTTPRoute"},
+				provider.UpstreamCRD{Group: gateway.HTTPRouteCRDGroup, Resource: gateway.GatewayCRDResource, Kind: "Gateway"},
+			),
+		},
+		{
+			Name:     "inference-extension-crds",
+			Required: gatewayRequired,
+			Disables: "InferencePools and the endpoint picker of ModelDeployments",
+			Run: startup.AnyCRDServed(dc,
+				provider.UpstreamCRD{Group: gateway.InferencePoolCRDGroup, Resource: gateway.InferencePoolCRDResource, Kind: "InferencePool"},
+				provider.UpstreamCRD{Group: gateway.InferencePoolAlphaCRDGroup, Resource: gateway.InferencePoolCRDResource, Kind: "InferencePool"},
+			),
+		},
+		{
+			Name:     "provider-crds",
+			Disables: "ModelDeployments of providers whose upstream operator is not installed",
+			Run:      startup.ProviderUpstreamsInstalled(reader),
+		},
+	}
+	// nolint:goconst
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		started := webhookServer.StartedChecker()
+		checks = append(checks,
+			startup.Check{
+				Name:     "webhook-server",
+				Required: true,
+				Run:      func(context.Context) error { return started(nil) },
+			},
+			startup.Check{
+				Name:     "webhook-cert",
+				Required: true,
+				Run:      startup.CertValid(filepath.Join(certDir, "tls.crt"), webhookDNSName),
+			},
+		)
+	}
+	return checks
+}
+
 // ensureBootstrapCerts creates temporary self-signed TLS certificates in certDir
 // so the webhook server can start. The cert-rotator will overwrite these with
 // properly signed certificates once it runs.
@@ -141,69 +215,308 @@ func ensureBootstrapCerts(dir string) error {
 	return nil
 }
 
-// nolint:gocyclo
-func main() {
-	var metricsAddr string
-	var metricsCertPath, metricsCertName, metricsCertKey string
-	var enableLeaderElection bool
-	var probeAddr string
-	var secureMetrics bool
-	var enableHTTP2 bool
-	var enableProviderSelector bool
-	var disableCertRotation bool
-	var certServiceName string
-	var gatewayName string
-	var gatewayNamespace string
-	var eppServicePort int
-	var eppImage string
-	var patchGateway bool
-	var tlsOpts []func(*tls.Config)
-	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
+// options are the command-line flags of the controller manager. The --config file sets
+// the flags that were not set on the command line.
+type options struct {
+	configFile                 string
+	metricsAddr                string
+	metricsCertPath            string
+	metricsCertName            string
+	metricsCertKey             string
+	enableLeaderElection       bool
+	probeAddr                  string
+	pprofAddr                  string
+	secureMetrics              bool
+	enableHTTP2                bool
+	enableProviderSelector     bool
+	selectionStrategy          string
+	disableCertRotation        bool
+	certServiceName            string
+	namespaces                 string
+	gatewayName                string
+	gatewayNamespace           string
+	eppServicePort             int
+	eppImage                   string
+	eppImageDigest             string
+	eppImagePullSecrets        string
+	eppSecurityContext         string
+	eppRBACMode                string
+	promptPolicyImage          string
+	batchRunnerImage           string
+	patchGateway               bool
+	gatewayMappingNamespace    string
+	gatewayMappingOverrides    bool
+	provisionGatewayClass      string
+	provisionGatewayName       string
+	provisionGatewayNamespace  string
+	enableResourceRecommender  bool
+	recommenderInterval        time.Duration
+	dcgmExporterNamespace      string
+	gpuIdleThreshold           float64
+	gatewayProbeInterval       time.Duration
+	tracingEndpoint            string
+	admissionPollInterval      time.Duration
+	activityPollInterval       time.Duration
+	metricsSnapshotInterval    time.Duration
+	providerHeartbeatTimeout   time.Duration
+	shardCount                 int
+	shardID                    int
+	resolveModelRevisions      bool
+	huggingFaceEndpoint        string
+	allowedProviderNames       string
+	networkIsolationNamespaces string
+	grafanaDashboards          bool
+}
+
+func (o *options) bindFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.configFile, "config", "",
+		"Path to a ControllerManagerConfig file. Flags set on the command line take precedence over the file. "+
+			"Changes to the file are reloaded while running for the reloadable settings.")
+	fs.StringVar(&o.metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
-	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
-	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
+	fs.StringVar(&o.probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
+	fs.StringVar(&o.pprofAddr, "pprof-bind-address", "", "The address the pprof endpoint binds to, e.g. "+
+		"127.0.0.1:8082. Leave empty to disable profiling.")
+	fs.BoolVar(&o.enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
-	flag.BoolVar(&secureMetrics, "metrics-secure", true,
+	fs.BoolVar(&o.secureMetrics, "metrics-secure", true,
 		"If set, the metrics endpoint is served securely via HTTPS. Use --metrics-secure=false to use HTTP instead.")
-	flag.StringVar(&metricsCertPath, "metrics-cert-path", "",
+	fs.StringVar(&o.metricsCertPath, "metrics-cert-path", "",
 		"The directory that contains the metrics server certificate.")
-	flag.StringVar(&metricsCertName, "metrics-cert-name", "tls.crt", "The name of the metrics server certificate file.")
-	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
-	flag.BoolVar(&enableHTTP2, "enable-http2", false,
+	fs.StringVar(&o.metricsCertName, "metrics-cert-name", "tls.crt", "The name of the metrics server certificate file.")
+	fs.StringVar(&o.metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
+	fs.BoolVar(&o.enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
-	flag.BoolVar(&enableProviderSelector, "enable-provider-selector", true,
+	fs.BoolVar(&o.enableProviderSelector, "enable-provider-selector", true,
 		"If set, the controller will run provider selection for ModelDeployments without explicit provider.name")
-	flag.BoolVar(&disableCertRotation, "disable-cert-rotation", false,
+	fs.StringVar(&o.selectionStrategy, "provider-selection-strategy", selection.Default,
+		"The strategy that picks one of the eligible providers in namespaces without a SelectionPolicy: "+
+			strings.Join(selection.Names(), ", "))
+	fs.BoolVar(&o.disableCertRotation, "disable-cert-rotation", false,
 		"Disable automatic generation and rotation of webhook TLS certificates/keys")
-	flag.StringVar(&certServiceName, "cert-service-name", "airunway-webhook-service",
+	fs.StringVar(&o.certServiceName, "cert-service-name", "airunway-webhook-service",
 		"The service name used to generate the TLS cert's hostname. Defaults to airunway-webhook-service")
-	flag.StringVar(&gatewayName, "gateway-name", "",
+	fs.StringVar(&o.namespaces, "namespaces", "",
+		"Comma-separated namespaces whose ModelDeployments the controller reconciles. If empty, all namespaces are reconciled.")
+	fs.StringVar(&o.gatewayName, "gateway-name", "",
 		"Explicit Gateway resource name for HTTPRoute parent. If empty, auto-detects from cluster.")
-	flag.StringVar(&gatewayNamespace, "gateway-namespace", "",
+	fs.StringVar(&o.gatewayNamespace, "gateway-namespace", "",
 		"Namespace of the Gateway resource. Required when --gateway-name is set.")
-	flag.IntVar(&eppServicePort, "epp-service-port", 9002,
+	fs.IntVar(&o.eppServicePort, "epp-service-port", 9002,
 		"Port of the Endpoint Picker Proxy (EPP) Service.")
-	flag.StringVar(&eppImage, "epp-image",
-		"registry.k8s.io/gateway-api-inference-extension/epp:"+gateway.DefaultGAIEVersion,
+	fs.StringVar(&o.eppImage, "epp-image", gateway.DefaultEPPImage,
 		"Container image for the Endpoint Picker Proxy (EPP).")
-	flag.BoolVar(&patchGateway, "patch-gateway-allowed-routes", true,
+	fs.StringVar(&o.eppImageDigest, "epp-image-digest", "",
+		"Digest the EPP image is pinned to, e.g. sha256:<hex>, replacing the tag of --epp-image.")
+	fs.StringVar(&o.eppImagePullSecrets, "epp-image-pull-secrets", "",
+		"Comma-separated image pull Secrets of the EPP Deployment. The Secrets must exist in each ModelDeployment namespace.")
+	fs.StringVar(&o.eppSecurityContext, "epp-security-context", "",
+		"Container SecurityContext of the EPP, as JSON or YAML. If empty, a context meeting the restricted "+
+			"Pod Security Standard is used.")
+	fs.StringVar(&o.eppRBACMode, "epp-rbac-mode", string(gateway.EPPRBACModeDeployment),
+		"How EPP RBAC is scoped: 'deployment' gives each EPP its own ServiceAccount, Role, and RoleBinding; "+
+			"'namespace' shares one set, named airunway-epp, between the EPPs of a namespace.")
+	fs.StringVar(&o.promptPolicyImage, "prompt-policy-image", gateway.DefaultPromptPolicyImage,
+		"Image of the external processor deployed for spec.gateway.promptPolicy.")
+	fs.StringVar(&o.batchRunnerImage, "batch-runner-image", batch.DefaultImage,
+		"Image of the batch Jobs run for ModelBatchJobs.")
+	fs.BoolVar(&o.patchGateway, "patch-gateway-allowed-routes", true,
 		"Patch the Gateway's allowedRoutes to accept HTTPRoutes from ModelDeployment namespaces. "+
 			"Set to false when a Gateway admin manages allowedRoutes independently.")
+	fs.StringVar(&o.gatewayMappingNamespace, "gateway-mapping-namespace", "",
+		"Namespace whose Gateways may map other namespaces with the airunway.ai/gateway-for-namespace label. "+
+			"Gateways elsewhere only map their own namespace.")
+	fs.BoolVar(&o.gatewayMappingOverrides, "gateway-mapping-overrides-explicit", false,
+		"Let a Gateway mapped with the airunway.ai/gateway-for-namespace label take precedence over --gateway-name.")
+	fs.StringVar(&o.provisionGatewayClass, "provision-gateway", "",
+		"GatewayClass name used to create a default inference Gateway when the cluster has none. "+
+			"If empty, gateway reconciliation is skipped until a Gateway exists.")
+	fs.StringVar(&o.provisionGatewayName, "provision-gateway-name", gateway.DefaultProvisionedGatewayName,
+		"Name of the Gateway created by --provision-gateway.")
+	fs.StringVar(&o.provisionGatewayNamespace, "provision-gateway-namespace", "",
+		"Namespace of the Gateway created by --provision-gateway. Defaults to the controller namespace (POD_NAMESPACE).")
+	fs.BoolVar(&o.enableResourceRecommender, "enable-resource-recommender", false,
+		"If set, the controller samples model pod usage and writes right-sizing recommendations to "+
+			"status.recommendations, applying them when spec.resources.autotune is enabled.")
+	fs.DurationVar(&o.recommenderInterval, "recommender-interval", controller.DefaultRecommenderInterval,
+		"How often the resource recommender samples usage.")
+	fs.StringVar(&o.dcgmExporterNamespace, "dcgm-exporter-namespace", "",
+		"Namespace of the NVIDIA DCGM exporter pods used to sample GPU memory and utilization, and to look up the "+
+			"GPU indices reported in status.placement. If empty, none of these are sampled.")
+	fs.Float64Var(&o.gpuIdleThreshold, "gpu-idle-threshold", controller.DefaultGPUIdleThreshold,
+		"Mean GPU utilization, in percent, below which the GPUs of a ModelDeployment are reported idle in status.gpuUtilization.idleSince.")
+	fs.DurationVar(&o.gatewayProbeInterval, "gateway-probe-interval", controller.DefaultGatewayProbeInterval,
+		"How often the gateway endpoint of each running ModelDeployment is probed with a /v1/models request. "+
+			"Set to 0 to disable probing.")
+	fs.StringVar(&o.tracingEndpoint, "tracing-endpoint", "",
+		"OTLP gRPC collector endpoint the EPPs of ModelDeployments with spec.observability.tracing enabled "+
+			"export spans to, unless the deployment sets its own endpoint.")
+	fs.DurationVar(&o.admissionPollInterval, "admission-poll-interval", controller.DefaultAdmissionPollInterval,
+		"How often a ModelDeployment queued for Kueue admission checks its Workload.")
+	fs.DurationVar(&o.activityPollInterval, "activity-poll-interval", controller.DefaultActivityPollInterval,
+		"How often request activity is sampled for spec.ttlSecondsAfterLastRequest.")
+	fs.DurationVar(&o.metricsSnapshotInterval, "metrics-snapshot-interval", controller.DefaultMetricsSnapshotInterval,
+		"How often the request rate, latency and error rate of Running ModelDeployments are scraped from "+
+			"their engine metrics into status.metricsSnapshot. 0 disables snapshots.")
+	fs.DurationVar(&o.providerHeartbeatTimeout, "provider-heartbeat-timeout", controller.DefaultProviderHeartbeatTimeout,
+		"How long after the last heartbeat of a provider controller its InferenceProviderConfig is marked not ready.")
+	fs.IntVar(&o.shardCount, "shard-count", 1,
+		"Number of controller shards ModelDeployments are split across. Each shard runs as its own "+
+			"Deployment with a distinct --shard-id and elects its own leader.")
+	fs.IntVar(&o.shardID, "shard-id", 0,
+		"Shard of this controller, from 0 to --shard-count - 1. Shard 0 also runs the cluster-wide controllers.")
+	fs.BoolVar(&o.resolveModelRevisions, "resolve-model-revisions", false,
+		"If set, the admission webhook pins spec.model.revision of new HuggingFace ModelDeployments to the "+
+			"commit SHA of the requested branch or tag, or of the default branch, for reproducible deployments.")
+	fs.StringVar(&o.huggingFaceEndpoint, "huggingface-endpoint", webhookv1alpha1.DefaultHuggingFaceEndpoint,
+		"Hugging Face Hub URL model revisions are resolved against with --resolve-model-revisions.")
+	fs.StringVar(&o.allowedProviderNames, "allowed-provider-names", "",
+		"Comma-separated spec.provider.name values the admission webhook accepts without a registered "+
+			"InferenceProviderConfig. Other unknown provider names are rejected.")
+	fs.StringVar(&o.networkIsolationNamespaces, "network-isolation-namespaces", "",
+		"Comma-separated namespaces whose pods may reach the model pods of ModelDeployments with "+
+			"spec.networking.isolate, besides their own namespace, the Gateway's and the controller's, "+
+			"e.g. the Gateway data plane or monitoring namespace.")
+	fs.BoolVar(&o.grafanaDashboards, "grafana-dashboards", false,
+		"If set, the controller keeps Grafana dashboards of its own metrics and of the ModelDeployments of each "+
+			"namespace in airunway-grafana-dashboards ConfigMaps labeled for the Grafana dashboard sidecar.")
+}
+
+// parseFlags parses the command-line flags into new options, returning the flag set so
+// the --config file can be applied to it.
+func parseFlags(args []string, zapOpts *zap.Options) (*options, *flag.FlagSet, error) {
+	o := &options{}
+	fs := flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+	o.bindFlags(fs)
+	zapOpts.BindFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return nil, nil, err
+	}
+	return o, fs, nil
+}
+
+// namespaceList returns the namespaces of --namespaces
+func (o *options) namespaceList() []string {
+	return splitList(o.namespaces)
+}
+
+// eppImageReference returns --epp-image pinned to --epp-image-digest
+func (o *options) eppImageReference() (string, error) {
+	return gateway.EPPImageWithDigest(o.eppImage, o.eppImageDigest)
+}
+
+// eppSecurityContextValue returns the SecurityContext of --epp-security-context, or nil
+// when it is not set
+func (o *options) eppSecurityContextValue() (*corev1.SecurityContext, error) {
+	if o.eppSecurityContext == "" {
+		return nil, nil
+	}
+	return gateway.ParseEPPSecurityContext(o.eppSecurityContext)
+}
+
+// networkIsolationNamespaceList returns the namespaces of --network-isolation-namespaces
+func (o *options) networkIsolationNamespaceList() []string {
+	return splitList(o.networkIsolationNamespaces)
+}
+
+// allowedProviderList returns the provider names of --allowed-provider-names
+func (o *options) allowedProviderList() []string {
+	return splitList(o.allowedProviderNames)
+}
+
+// splitList returns the non-empty, trimmed items of a comma-separated flag value
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// sharding returns the shard of this controller, validating --shard-count and --shard-id
+func (o *options) sharding() (controller.Sharding, error) {
+	if o.shardCount < 1 {
+		return controller.Sharding{}, fmt.Errorf("--shard-count must be at least 1, got %d", o.shardCount)
+	}
+	if o.shardID < 0 || o.shardID >= o.shardCount {
+		return controller.Sharding{}, fmt.Errorf("--shard-id must be between 0 and %d, got %d", o.shardCount-1, o.shardID)
+	}
+	return controller.Sharding{Count: o.shardCount, ID: o.shardID}, nil
+}
+
+// leaderElectionID returns the leader election lease name, which is per shard so the
+// shards run active-active
+func (o *options) leaderElectionID() string {
+	if o.shardCount > 1 {
+		return fmt.Sprintf("2038fe6a-shard-%d.airunway.ai", o.shardID)
+	}
+	return "2038fe6a.airunway.ai"
+}
+
+// settings returns the reconciler settings that are reloaded from the --config file
+func (o *options) settings() controller.Settings {
+	return controller.Settings{
+		EnableProviderSelector:  o.enableProviderSelector,
+		SelectionStrategy:       o.selectionStrategy,
+		GatewayProbeInterval:    o.gatewayProbeInterval,
+		TracingEndpoint:         o.tracingEndpoint,
+		AdmissionPollInterval:   o.admissionPollInterval,
+		ActivityPollInterval:    o.activityPollInterval,
+		MetricsSnapshotInterval: o.metricsSnapshotInterval,
+	}
+}
+
+// nolint:gocyclo
+func main() {
+	var tlsOpts []func(*tls.Config)
 	opts := zap.Options{
 		Development: true,
 	}
-	opts.BindFlags(flag.CommandLine)
-	flag.Parse()
+	o, fs, err := parseFlags(os.Args[1:], &opts)
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(0)
+	}
+	if err != nil {
+		os.Exit(2)
+	}
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	if o.configFile != "" {
+		cfg, err := config.Load(o.configFile)
+		if err == nil {
+			err = cfg.ApplyToFlags(fs)
+		}
+		if err != nil {
+			setupLog.Error(err, "unable to load config file")
+			os.Exit(1)
+		}
+	}
+
 	// Validate gateway flags: both must be set or both empty
-	if (gatewayName == "") != (gatewayNamespace == "") {
+	if (o.gatewayName == "") != (o.gatewayNamespace == "") {
 		setupLog.Error(fmt.Errorf("--gateway-name and --gateway-namespace must both be set or both be empty"), "invalid gateway flags")
 		os.Exit(1)
 	}
 
+	sharding, err := o.sharding()
+	if err != nil {
+		setupLog.Error(err, "invalid shard flags")
+		os.Exit(1)
+	}
+
+	provisionGatewayNamespace := o.provisionGatewayNamespace
+	if o.provisionGatewayClass != "" && provisionGatewayNamespace == "" {
+		provisionGatewayNamespace = os.Getenv("POD_NAMESPACE")
+		if provisionGatewayNamespace == "" {
+			setupLog.Error(fmt.Errorf("--provision-gateway-namespace or POD_NAMESPACE must be set"), "invalid gateway flags")
+			os.Exit(1)
+		}
+	}
+
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
 	// prevent from being vulnerable to the HTTP/2 Stream Cancellation and
@@ -215,7 +528,7 @@ func main() {
 		c.NextProtos = []string{"http/1.1"}
 	}
 
-	if !enableHTTP2 {
+	if !o.enableHTTP2 {
 		tlsOpts = append(tlsOpts, disableHTTP2)
 	}
 
@@ -241,12 +554,12 @@ func main() {
 	// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.23.1/pkg/metrics/server
 	// - https://book.kubebuilder.io/reference/metrics.html
 	metricsServerOptions := metricsserver.Options{
-		BindAddress:   metricsAddr,
-		SecureServing: secureMetrics,
+		BindAddress:   o.metricsAddr,
+		SecureServing: o.secureMetrics,
 		TLSOpts:       tlsOpts,
 	}
 
-	if secureMetrics {
+	if o.secureMetrics {
 		// FilterProvider is used to protect the metrics endpoint with authn/authz.
 		// These configurations ensure that only authorized users and service accounts
 		// can access the metrics endpoint. The RBAC are configured in 'config/rbac/kustomization.yaml'. More info:
@@ -257,22 +570,23 @@ func main() {
 	// If the certificate is not specified, controller-runtime will automatically
 	// generate self-signed certificates for the metrics server. While convenient for development and testing,
 	// this setup is not recommended for production.
-	if len(metricsCertPath) > 0 {
+	if len(o.metricsCertPath) > 0 {
 		setupLog.Info("Initializing metrics certificate watcher using provided certificates",
-			"metrics-cert-path", metricsCertPath, "metrics-cert-name", metricsCertName, "metrics-cert-key", metricsCertKey)
+			"metrics-cert-path", o.metricsCertPath, "metrics-cert-name", o.metricsCertName, "metrics-cert-key", o.metricsCertKey)
 
-		metricsServerOptions.CertDir = metricsCertPath
-		metricsServerOptions.CertName = metricsCertName
-		metricsServerOptions.KeyName = metricsCertKey
+		metricsServerOptions.CertDir = o.metricsCertPath
+		metricsServerOptions.CertName = o.metricsCertName
+		metricsServerOptions.KeyName = o.metricsCertKey
 	}
 
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
 		Metrics:                metricsServerOptions,
 		WebhookServer:          webhookServer,
-		HealthProbeBindAddress: probeAddr,
-		LeaderElection:         enableLeaderElection,
-		LeaderElectionID:       "2038fe6a.airunway.ai",
+		HealthProbeBindAddress: o.probeAddr,
+		PprofBindAddress:       o.pprofAddr,
+		LeaderElection:         o.enableLeaderElection,
+		LeaderElectionID:       o.leaderElectionID(),
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
 		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
@@ -292,7 +606,8 @@ func main() {
 
 	// Set up cert rotation for webhook TLS certificates.
 	setupFinished := make(chan struct{})
-	if !disableCertRotation && os.Getenv("ENABLE_WEBHOOKS") != "false" {
+	var webhookDNSName string
+	if !o.disableCertRotation && os.Getenv("ENABLE_WEBHOOKS") != "false" {
 		setupLog.Info("setting up cert rotation")
 
 		podNamespace := os.Getenv("POD_NAMESPACE")
@@ -301,7 +616,7 @@ func main() {
 			os.Exit(1)
 		}
 
-		dnsName := fmt.Sprintf("%s.%s.svc", certServiceName, podNamespace)
+		webhookDNSName = fmt.Sprintf("%s.%s.svc", o.certServiceName, podNamespace)
 
 		if err := rotator.AddRotator(mgr, &rotator.CertRotator{
 			SecretKey: types.NamespacedName{
@@ -311,7 +626,7 @@ func main() {
 			CertDir:        certDir,
 			CAName:         caName,
 			CAOrganization: caOrganization,
-			DNSName:        dnsName,
+			DNSName:        webhookDNSName,
 			IsReady:        setupFinished,
 			Webhooks: []rotator.WebhookInfo{
 				{
@@ -360,25 +675,163 @@ func main() {
 		os.Exit(1)
 	}
 	gatewayDetector := gateway.NewDetector(dc)
-	gatewayDetector.ExplicitGatewayName = gatewayName
-	gatewayDetector.ExplicitGatewayNamespace = gatewayNamespace
-	gatewayDetector.EPPServicePort = int32(eppServicePort)
+	gatewayDetector.ExplicitGatewayName = o.gatewayName
+	gatewayDetector.ExplicitGatewayNamespace = o.gatewayNamespace
+	gatewayDetector.EPPServicePort = int32(o.eppServicePort)
+	eppImage, err := o.eppImageReference()
+	if err != nil {
+		setupLog.Error(err, "invalid --epp-image-digest")
+		os.Exit(1)
+	}
+	eppSecurityContext, err := o.eppSecurityContextValue()
+	if err != nil {
+		setupLog.Error(err, "invalid --epp-security-context")
+		os.Exit(1)
+	}
+	eppRBACMode, err := gateway.ParseEPPRBACMode(o.eppRBACMode)
+	if err != nil {
+		setupLog.Error(err, "invalid --epp-rbac-mode")
+		os.Exit(1)
+	}
+	if _, err := selection.Get(o.selectionStrategy); err != nil {
+		setupLog.Error(err, "invalid --provider-selection-strategy")
+		os.Exit(1)
+	}
 	gatewayDetector.EPPImage = eppImage
-	gatewayDetector.PatchGateway = patchGateway
-
-	if err := (&controller.ModelDeploymentReconciler{
-		Client:                 mgr.GetClient(),
-		Scheme:                 mgr.GetScheme(),
-		EnableProviderSelector: enableProviderSelector,
-		GatewayDetector:        gatewayDetector,
-		ProviderResolver:       gateway.NewInferenceProviderConfigResolver(mgr.GetClient()),
-	}).SetupWithManager(mgr); err != nil {
+	gatewayDetector.EPPImagePullSecrets = splitList(o.eppImagePullSecrets)
+	gatewayDetector.EPPSecurityContext = eppSecurityContext
+	gatewayDetector.EPPRBACMode = eppRBACMode
+	gatewayDetector.PromptPolicyImage = o.promptPolicyImage
+	gatewayDetector.PatchGateway = o.patchGateway
+	gatewayDetector.GatewayMappingNamespace = o.gatewayMappingNamespace
+	gatewayDetector.GatewayMappingOverridesExplicit = o.gatewayMappingOverrides
+	gatewayDetector.ProvisionGatewayClassName = o.provisionGatewayClass
+	gatewayDetector.ProvisionGatewayName = o.provisionGatewayName
+	gatewayDetector.ProvisionGatewayNamespace = provisionGatewayNamespace
+
+	providerConfigs := &controller.ProviderConfigCache{Reader: mgr.GetClient()}
+	if err := providerConfigs.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to set up the provider config cache")
+		os.Exit(1)
+	}
+	modelDeploymentReconciler := &controller.ModelDeploymentReconciler{
+		Client:                     mgr.GetClient(),
+		Scheme:                     mgr.GetScheme(),
+		EnableProviderSelector:     o.enableProviderSelector,
+		SelectionStrategy:          o.selectionStrategy,
+		GatewayDetector:            gatewayDetector,
+		ProviderResolver:           gateway.NewInferenceProviderConfigResolver(mgr.GetClient()),
+		Recorder:                   mgr.GetEventRecorder("modeldeployment-controller"),
+		GatewayProbeInterval:       o.gatewayProbeInterval,
+		TracingEndpoint:            o.tracingEndpoint,
+		AdmissionPollInterval:      o.admissionPollInterval,
+		ActivityPollInterval:       o.activityPollInterval,
+		MetricsSnapshotInterval:    o.metricsSnapshotInterval,
+		Namespaces:                 o.namespaceList(),
+		ControllerNamespace:        os.Getenv("POD_NAMESPACE"),
+		NetworkIsolationNamespaces: o.networkIsolationNamespaceList(),
+		Sharding:                   sharding,
+		ProviderConfigs:            providerConfigs,
+	}
+	if o.dcgmExporterNamespace != "" {
+		modelDeploymentReconciler.GPUDevices = &recommender.DCGMSource{Reader: mgr.GetClient(), Namespace: o.dcgmExporterNamespace}
+	}
+	if err := modelDeploymentReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ModelDeployment")
 		os.Exit(1)
 	}
+	// Quotas, fleets, batch jobs, provider heartbeats, provider permission checks and
+	// dashboards are not tied to a single ModelDeployment, so only shard 0 runs them
+	if sharding.ID == 0 {
+		if err := (&controller.ModelDeploymentQuotaReconciler{
+			Client: mgr.GetClient(),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ModelDeploymentQuota")
+			os.Exit(1)
+		}
+		if err := (&controller.ModelFleetReconciler{
+			Client: mgr.GetClient(),
+			Scheme: mgr.GetScheme(),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ModelFleet")
+			os.Exit(1)
+		}
+		if err := (&controller.ModelBatchJobReconciler{
+			Client: mgr.GetClient(),
+			Scheme: mgr.GetScheme(),
+			Image:  o.batchRunnerImage,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ModelBatchJob")
+			os.Exit(1)
+		}
+		if err := (&controller.ProviderHeartbeatReconciler{
+			Client:   mgr.GetClient(),
+			Timeout:  o.providerHeartbeatTimeout,
+			Recorder: mgr.GetEventRecorder("providerheartbeat-controller"),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ProviderHeartbeat")
+			os.Exit(1)
+		}
+		if err := (&controller.ProviderRBACReconciler{
+			Client: mgr.GetClient(),
+			Reader: mgr.GetAPIReader(),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ProviderRBAC")
+			os.Exit(1)
+		}
+		if o.grafanaDashboards {
+			if err := (&controller.DashboardReconciler{
+				Client:    mgr.GetClient(),
+				Gatherer:  ctrlmetrics.Registry,
+				Namespace: os.Getenv("POD_NAMESPACE"),
+			}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "GrafanaDashboard")
+				os.Exit(1)
+			}
+		}
+	}
+	if o.enableResourceRecommender {
+		sources := []recommender.UsageSource{&recommender.MetricsAPISource{Reader: mgr.GetAPIReader()}}
+		var gpuUtilization recommender.GPUUtilizationSource
+		if o.dcgmExporterNamespace != "" {
+			dcgm := &recommender.DCGMSource{Reader: mgr.GetClient(), Namespace: o.dcgmExporterNamespace}
+			sources = append(sources, dcgm)
+			gpuUtilization = dcgm
+		}
+		if err := (&controller.ResourceRecommenderReconciler{
+			Client:           mgr.GetClient(),
+			Sources:          sources,
+			GPUUtilization:   gpuUtilization,
+			GPUIdleThreshold: o.gpuIdleThreshold,
+			Interval:         o.recommenderInterval,
+			Recorder:         mgr.GetEventRecorder("modeldeployment-recommender"),
+			Sharding:         sharding,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ResourceRecommender")
+			os.Exit(1)
+		}
+	}
+	clientset, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create clientset")
+		os.Exit(1)
+	}
+	if err := (&controller.EngineRemediationReconciler{
+		Client:   mgr.GetClient(),
+		Logs:     &remediation.ClientsetLogSource{Client: clientset},
+		Recorder: mgr.GetEventRecorder("modeldeployment-remediation"),
+		Sharding: sharding,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "EngineRemediation")
+		os.Exit(1)
+	}
 	// nolint:goconst
 	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
-		if err := webhookv1alpha1.SetupModelDeploymentWebhookWithManager(mgr); err != nil {
+		var revisionResolver webhookv1alpha1.RevisionResolver
+		if o.resolveModelRevisions {
+			revisionResolver = &webhookv1alpha1.HuggingFaceRevisionResolver{Endpoint: o.huggingFaceEndpoint}
+		}
+		if err := webhookv1alpha1.SetupModelDeploymentWebhookWithManager(mgr, revisionResolver, o.allowedProviderList()); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "ModelDeployment")
 			os.Exit(1)
 		}
@@ -386,6 +839,40 @@ func main() {
 
 	// +kubebuilder:scaffold:builder
 
+	// Reload the settings that can change without a restart when the config file changes.
+	// Flags set on the command line keep precedence over the file.
+	if o.configFile != "" {
+		current := fs
+		if err := mgr.Add(&config.Watcher{
+			Path: o.configFile,
+			OnChange: func(_ context.Context, cfg *config.ControllerManagerConfig) {
+				reloaded, reloadedFS, err := parseFlags(os.Args[1:], &zap.Options{})
+				if err == nil {
+					err = cfg.ApplyToFlags(reloadedFS)
+				}
+				if err == nil {
+					_, err = selection.Get(reloaded.selectionStrategy)
+				}
+				if err != nil {
+					setupLog.Error(err, "ignoring invalid config file", "path", o.configFile)
+					return
+				}
+				reloadable, restart := config.ChangedFlags(current, reloadedFS)
+				if len(restart) > 0 {
+					setupLog.Info("config file changes take effect on restart", "flags", restart)
+				}
+				if len(reloadable) > 0 {
+					modelDeploymentReconciler.UpdateSettings(reloaded.settings())
+					setupLog.Info("reloaded config file", "flags", reloadable)
+				}
+				current = reloadedFS
+			},
+		}); err != nil {
+			setupLog.Error(err, "unable to set up config file watcher")
+			os.Exit(1)
+		}
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
@@ -395,6 +882,20 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Verify the CRDs, the webhook server, and the serving certificate the manager
+	// depends on. Failed required checks keep the manager unready.
+	startupChecker := &startup.Checker{
+		Checks: startupChecks(dc, mgr.GetAPIReader(), gatewayDetector, webhookServer, webhookDNSName),
+	}
+	if err := mgr.Add(startupChecker); err != nil {
+		setupLog.Error(err, "unable to set up startup checks")
+		os.Exit(1)
+	}
+	if err := mgr.AddReadyzCheck("startup", startupChecker.ReadyzCheck); err != nil {
+		setupLog.Error(err, "unable to set up startup ready check")
+		os.Exit(1)
+	}
+
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
 		setupLog.Error(err, "problem running manager")
@@ -0,0 +1,101 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command prompt-policy is the Envoy external processor the controller deploys for
+// spec.gateway.promptPolicy. With GenAI metrics in the policy, it serves them at /metrics.
+//
+//	prompt-policy [--config /config/policy.json] [--grpc-port 9004] [--metrics-port 9090]
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	extprocv3 "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/kaito-project/airunway/controller/internal/gateway"
+	"github.com/kaito-project/airunway/controller/internal/promptpolicy"
+)
+
+func main() {
+	var configPath string
+	var port, metricsPort int
+	flag.StringVar(&configPath, "config", "/config/"+gateway.PromptPolicyConfigFile, "Path of the prompt policy JSON file.")
+	flag.IntVar(&port, "grpc-port", int(gateway.PromptPolicyPort), "Port of the external processor and gRPC health service.")
+	flag.IntVar(&metricsPort, "metrics-port", int(gateway.PromptPolicyMetricsPort), "Port serving the GenAI metrics at /metrics, when the policy records them.")
+	flag.Parse()
+
+	if err := run(configPath, port, metricsPort); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+func run(configPath string, port, metricsPort int) error {
+	policy, err := promptpolicy.Load(configPath)
+	if err != nil {
+		return err
+	}
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return fmt.Errorf("failed to listen on port %d: %w", port, err)
+	}
+
+	processor := &promptpolicy.Server{Policy: policy, Log: zap.New()}
+	var metricsSrv *http.Server
+	if policy.GenAIMetrics != nil {
+		reg := prometheus.NewRegistry()
+		processor.Metrics = promptpolicy.NewMetrics(reg)
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{}))
+		metricsSrv = &http.Server{Addr: fmt.Sprintf(":%d", metricsPort), Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+		go func() {
+			if err := metricsSrv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+				fmt.Fprintln(os.Stderr, "error: metrics server:", err)
+				os.Exit(1)
+			}
+		}()
+	}
+
+	srv := grpc.NewServer()
+	extprocv3.RegisterExternalProcessorServer(srv, processor)
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sigs
+		srv.GracefulStop()
+		if metricsSrv != nil {
+			_ = metricsSrv.Shutdown(context.Background())
+		}
+	}()
+	return srv.Serve(lis)
+}
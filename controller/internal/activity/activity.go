@@ -0,0 +1,317 @@
+// Package activity reads request activity from the Prometheus metrics of model server
+// pods, so idle deployments can be detected without routing traffic through the controller.
+package activity
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// maxResponseBytes bounds how much of a metrics response is read.
+const maxResponseBytes = 16 * 1024 * 1024
+
+// requestCounters are cumulative counters that move with every request on the supported
+// engines. llama.cpp has no request counter, so its prompt token counter is used.
+var requestCounters = map[string]bool{
+	"vllm:request_success_total":   true,
+	"sglang:num_requests_total":    true,
+	"llamacpp:prompt_tokens_total": true,
+}
+
+// runningGauges are the in-flight request gauges of the supported engines. A long
+// request that has not completed yet still counts as activity.
+var runningGauges = map[string]bool{
+	"vllm:num_requests_running":    true,
+	"sglang:num_running_reqs":      true,
+	"llamacpp:requests_processing": true,
+}
+
+// kvCacheGauges are the KV cache usage gauges of the supported engines, as a fraction of
+// the cache. vllm renamed its gauge, so both names are read.
+var kvCacheGauges = map[string]bool{
+	"vllm:kv_cache_usage_perc":      true,
+	"vllm:gpu_cache_usage_perc":     true,
+	"sglang:token_usage":            true,
+	"llamacpp:kv_cache_usage_ratio": true,
+}
+
+// cacheCounters are the cumulative KV cache lookup counters of vllm and LMCache, in
+// tokens, mapped to the Sample field they add to.
+var cacheCounters = map[string]func(*Sample) *float64{
+	"vllm:prefix_cache_queries_total":    func(s *Sample) *float64 { return &s.PrefixCacheQueries },
+	"vllm:prefix_cache_hits_total":       func(s *Sample) *float64 { return &s.PrefixCacheHits },
+	"lmcache:num_requested_tokens_total": func(s *Sample) *float64 { return &s.ExternalCacheQueries },
+	"lmcache:num_hit_tokens_total":       func(s *Sample) *float64 { return &s.ExternalCacheHits },
+}
+
+// latencyHistograms are the end-to-end request latency histograms of the supported
+// engines, in seconds. llama.cpp reports none.
+var latencyHistograms = map[string]bool{
+	"vllm:e2e_request_latency_seconds_bucket":   true,
+	"sglang:e2e_request_latency_seconds_bucket": true,
+}
+
+// abortedReason is the finished_reason label of vllm requests that were aborted, by the
+// client disconnecting or by an engine error
+const abortedReason = "abort"
+
+// Sample is the request activity reported by one or more model server pods.
+type Sample struct {
+	// Requests is the sum of the cumulative request counters.
+	Requests float64
+	// Running is the number of requests in flight.
+	Running float64
+	// KVCacheUsage is the fraction of the KV cache in use. Summed samples of several pods
+	// add up their fractions.
+	KVCacheUsage float64
+	// PrefixCacheQueries and PrefixCacheHits are the prompt tokens looked up in, and
+	// found in, the engine prefix cache.
+	PrefixCacheQueries, PrefixCacheHits float64
+	// ExternalCacheQueries and ExternalCacheHits are the tokens looked up in, and found
+	// in, LMCache.
+	ExternalCacheQueries, ExternalCacheHits float64
+	// Aborted is the part of Requests that was aborted. Only vllm counts aborted requests.
+	Aborted float64
+	// Latency is the end-to-end request latency histogram, or nil when the engine reports
+	// none.
+	Latency *Histogram
+}
+
+// Histogram is a cumulative Prometheus histogram.
+type Histogram struct {
+	// Buckets maps the upper bound of each bucket, in seconds, to the number of
+	// observations less than or equal to it. The +Inf bucket counts all observations.
+	Buckets map[float64]float64
+}
+
+// add returns the sum of h and other, either of which may be nil.
+func (h *Histogram) add(other *Histogram) *Histogram {
+	if h == nil {
+		return other
+	}
+	if other == nil {
+		return h
+	}
+	sum := &Histogram{Buckets: make(map[float64]float64, len(h.Buckets))}
+	for le, count := range h.Buckets {
+		sum.Buckets[le] = count
+	}
+	for le, count := range other.Buckets {
+		sum.Buckets[le] += count
+	}
+	return sum
+}
+
+// Since returns the observations made between previous and h, or nil when either is nil
+// or a bucket went backwards, as it does when a pod restarts.
+func (h *Histogram) Since(previous *Histogram) *Histogram {
+	if h == nil || previous == nil {
+		return nil
+	}
+	delta := &Histogram{Buckets: make(map[float64]float64, len(h.Buckets))}
+	for le, count := range h.Buckets {
+		d := count - previous.Buckets[le]
+		if d < 0 {
+			return nil
+		}
+		delta.Buckets[le] = d
+	}
+	return delta
+}
+
+// Count returns the number of observations.
+func (h *Histogram) Count() float64 {
+	if h == nil {
+		return 0
+	}
+	return h.Buckets[math.Inf(1)]
+}
+
+// Quantile estimates the q-quantile of the observations, in seconds, by linear
+// interpolation within the bucket it falls in, as Prometheus histogram_quantile does. It
+// returns false without observations.
+func (h *Histogram) Quantile(q float64) (float64, bool) {
+	total := h.Count()
+	if total <= 0 {
+		return 0, false
+	}
+	bounds := make([]float64, 0, len(h.Buckets))
+	for le := range h.Buckets {
+		bounds = append(bounds, le)
+	}
+	slices.Sort(bounds)
+
+	rank := q * total
+	lower, below := 0.0, 0.0
+	for _, le := range bounds {
+		count := h.Buckets[le]
+		if count >= rank {
+			if math.IsInf(le, 1) {
+				// Observations above the highest finite bucket report that bound
+				return lower, true
+			}
+			if count == below {
+				return le, true
+			}
+			return lower + (le-lower)*(rank-below)/(count-below), true
+		}
+		lower, below = le, count
+	}
+	return lower, true
+}
+
+// Add returns the sum of s and other.
+func (s Sample) Add(other Sample) Sample {
+	return Sample{
+		Requests:             s.Requests + other.Requests,
+		Running:              s.Running + other.Running,
+		KVCacheUsage:         s.KVCacheUsage + other.KVCacheUsage,
+		PrefixCacheQueries:   s.PrefixCacheQueries + other.PrefixCacheQueries,
+		PrefixCacheHits:      s.PrefixCacheHits + other.PrefixCacheHits,
+		ExternalCacheQueries: s.ExternalCacheQueries + other.ExternalCacheQueries,
+		ExternalCacheHits:    s.ExternalCacheHits + other.ExternalCacheHits,
+		Aborted:              s.Aborted + other.Aborted,
+		Latency:              s.Latency.add(other.Latency),
+	}
+}
+
+// ActiveSince reports whether requests were served between previous and s: a request
+// is in flight, or the counters moved. A counter reset after a pod restart also counts,
+// erring on the side of keeping the deployment.
+func (s Sample) ActiveSince(previous Sample) bool {
+	return s.Running > 0 || s.Requests != previous.Requests
+}
+
+// Scrape fetches url, a Prometheus metrics endpoint of a model server, and returns its
+// request activity.
+func Scrape(ctx context.Context, httpClient *http.Client, url string) (Sample, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return Sample{}, err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return Sample{}, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return Sample{}, fmt.Errorf("unexpected status %d from %s", resp.StatusCode, url)
+	}
+	return Parse(io.LimitReader(resp.Body, maxResponseBytes))
+}
+
+// Reads reports whether samples are read from the engine metric name. Histograms are
+// named without their _bucket suffix.
+func Reads(name string) bool {
+	return requestCounters[name] || runningGauges[name] || kvCacheGauges[name] ||
+		cacheCounters[name] != nil || latencyHistograms[name+"_bucket"]
+}
+
+// Parse extracts request activity, request latency, KV cache usage and KV cache lookups
+// from Prometheus text exposition format. Samples of the same metric with different labels
+// are summed. An error is returned when none of the known request metrics is present,
+// since activity cannot be judged then.
+func Parse(r io.Reader) (Sample, error) {
+	var sample Sample
+	found := false
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, labels, rest := line, "", ""
+		if i := strings.IndexAny(line, "{ "); i >= 0 {
+			name, rest = line[:i], line[i:]
+		}
+		counter, gauge := requestCounters[name], runningGauges[name]
+		cacheField, kvCache := cacheCounters[name], kvCacheGauges[name]
+		histogram := latencyHistograms[name]
+		if !counter && !gauge && cacheField == nil && !kvCache && !histogram {
+			continue
+		}
+		if strings.HasPrefix(rest, "{") {
+			end := strings.LastIndex(rest, "}")
+			if end < 0 {
+				continue
+			}
+			labels, rest = rest[1:end], rest[end+1:]
+		}
+		fields := strings.Fields(rest)
+		if len(fields) == 0 {
+			continue
+		}
+		value, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			continue
+		}
+		switch {
+		case histogram:
+			le, err := strconv.ParseFloat(labelValue(labels, "le"), 64)
+			if err != nil {
+				continue
+			}
+			if sample.Latency == nil {
+				sample.Latency = &Histogram{Buckets: map[float64]float64{}}
+			}
+			sample.Latency.Buckets[le] += value
+		case cacheField != nil:
+			*cacheField(&sample) += value
+		case kvCache:
+			sample.KVCacheUsage += value
+		case counter:
+			found = true
+			sample.Requests += value
+			if labelValue(labels, "finished_reason") == abortedReason {
+				sample.Aborted += value
+			}
+		default:
+			found = true
+			sample.Running += value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return Sample{}, err
+	}
+	if !found {
+		return Sample{}, fmt.Errorf("no request metrics found")
+	}
+	return sample, nil
+}
+
+// labelValue returns the value of the label called name in the labels of a sample, e.g.
+// le="0.5",model_name="llama", or an empty string.
+func labelValue(labels, name string) string {
+	for labels != "" {
+		i := strings.Index(labels, `="`)
+		if i < 0 {
+			return ""
+		}
+		key := strings.TrimSpace(labels[:i])
+		labels = labels[i+2:]
+		end := strings.Index(labels, `"`)
+		for end > 0 && labels[end-1] == '\\' {
+			next := strings.Index(labels[end+1:], `"`)
+			if next < 0 {
+				return ""
+			}
+			end += next + 1
+		}
+		if end < 0 {
+			return ""
+		}
+		if key == name {
+			return labels[:end]
+		}
+		labels = strings.TrimPrefix(strings.TrimSpace(labels[end+1:]), ",")
+	}
+	return ""
+}
@@ -0,0 +1,187 @@
+package activity
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const vllmMetrics = `# HELP vllm:request_success_total Count of successfully processed requests.
+# TYPE vllm:request_success_total counter
+vllm:request_success_total{finished_reason="stop",model_name="llama"} 12.0
+vllm:request_success_total{finished_reason="length",model_name="llama"} 3.0
+# HELP vllm:num_requests_running Number of requests currently running.
+# TYPE vllm:num_requests_running gauge
+vllm:num_requests_running{model_name="llama"} 1.0
+vllm:num_requests_waiting{model_name="llama"} 4.0
+`
+
+func TestParse(t *testing.T) {
+	sample, err := Parse(strings.NewReader(vllmMetrics))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if sample.Requests != 15 || sample.Running != 1 {
+		t.Errorf("expected 15 requests and 1 running, got %+v", sample)
+	}
+
+	sample, err = Parse(strings.NewReader("sglang:num_requests_total 7\nsglang:num_running_reqs 0 1700000000000\n"))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if sample.Requests != 7 || sample.Running != 0 {
+		t.Errorf("expected 7 requests and 0 running, got %+v", sample)
+	}
+
+	if _, err := Parse(strings.NewReader("process_cpu_seconds_total 1.5\n")); err == nil {
+		t.Error("expected an error when no request metrics are present")
+	}
+}
+
+func TestParse_CacheCounters(t *testing.T) {
+	metrics := vllmMetrics + `vllm:prefix_cache_queries_total{model_name="llama"} 1000.0
+vllm:prefix_cache_hits_total{model_name="llama"} 400.0
+lmcache:num_requested_tokens_total{model_name="llama",worker_id="0"} 600.0
+lmcache:num_hit_tokens_total{model_name="llama",worker_id="0"} 300.0
+`
+	sample, err := Parse(strings.NewReader(metrics))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	want := Sample{Requests: 15, Running: 1, PrefixCacheQueries: 1000, PrefixCacheHits: 400, ExternalCacheQueries: 600, ExternalCacheHits: 300}
+	if sample != want {
+		t.Errorf("expected %+v, got %+v", want, sample)
+	}
+	if total := sample.Add(sample); total.PrefixCacheHits != 800 || total.ExternalCacheQueries != 1200 {
+		t.Errorf("expected cache counters to be summed, got %+v", total)
+	}
+
+	// Cache counters alone do not tell whether requests were served
+	if _, err := Parse(strings.NewReader("vllm:prefix_cache_queries_total 10\n")); err == nil {
+		t.Error("expected an error when only cache metrics are present")
+	}
+}
+
+func TestParse_KVCacheUsage(t *testing.T) {
+	metrics := vllmMetrics + `vllm:kv_cache_usage_perc{engine="0",model_name="llama"} 0.25
+`
+	sample, err := Parse(strings.NewReader(metrics))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if sample.KVCacheUsage != 0.25 {
+		t.Errorf("expected KV cache usage 0.25, got %v", sample.KVCacheUsage)
+	}
+
+	sample, err = Parse(strings.NewReader("sglang:num_running_reqs 2\nsglang:token_usage 0.5\n"))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if sample.Running != 2 || sample.KVCacheUsage != 0.5 {
+		t.Errorf("expected sglang running requests and token usage, got %+v", sample)
+	}
+}
+
+func TestParse_LatencyAndAborted(t *testing.T) {
+	metrics := `vllm:request_success_total{finished_reason="stop",model_name="llama"} 18.0
+vllm:request_success_total{finished_reason="abort",model_name="llama"} 2.0
+vllm:e2e_request_latency_seconds_bucket{le="0.5",model_name="llama"} 5.0
+vllm:e2e_request_latency_seconds_bucket{le="1.0",model_name="llama"} 15.0
+vllm:e2e_request_latency_seconds_bucket{le="+Inf",model_name="llama"} 20.0
+vllm:e2e_request_latency_seconds_sum{model_name="llama"} 14.2
+vllm:e2e_request_latency_seconds_count{model_name="llama"} 20.0
+`
+	sample, err := Parse(strings.NewReader(metrics))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if sample.Requests != 20 || sample.Aborted != 2 {
+		t.Errorf("expected 20 requests of which 2 aborted, got %+v", sample)
+	}
+	if sample.Latency == nil || sample.Latency.Count() != 20 || sample.Latency.Buckets[0.5] != 5 {
+		t.Fatalf("expected the latency histogram, got %+v", sample.Latency)
+	}
+
+	total := sample.Add(sample)
+	if total.Aborted != 4 || total.Latency.Count() != 40 || sample.Latency.Count() != 20 {
+		t.Errorf("expected histograms to be summed into a new one, got %+v", total.Latency)
+	}
+	if (Sample{}).Add(sample).Latency == nil {
+		t.Error("expected a nil histogram to be ignored")
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	inf := math.Inf(1)
+	previous := &Histogram{Buckets: map[float64]float64{0.5: 5, 1: 15, 2: 15, inf: 20}}
+	current := &Histogram{Buckets: map[float64]float64{0.5: 5, 1: 65, 2: 95, inf: 120}}
+
+	delta := current.Since(previous)
+	if delta == nil || delta.Count() != 100 {
+		t.Fatalf("expected 100 observations since the previous sample, got %+v", delta)
+	}
+	if p50, ok := delta.Quantile(0.5); !ok || p50 != 1 {
+		t.Errorf("expected p50 at the 1s bound, got %v", p50)
+	}
+	if p95, ok := delta.Quantile(0.95); !ok || p95 != 2 {
+		t.Errorf("expected p95 above the highest finite bound to report it, got %v", p95)
+	}
+	if p25, ok := delta.Quantile(0.25); !ok || p25 != 0.75 {
+		t.Errorf("expected p25 interpolated within the 0.5-1s bucket, got %v", p25)
+	}
+
+	if current.Since(nil) != nil || previous.Since(current) != nil {
+		t.Error("expected no delta without a previous sample or after a counter reset")
+	}
+	if _, ok := (&Histogram{Buckets: map[float64]float64{1: 0, inf: 0}}).Quantile(0.5); ok {
+		t.Error("expected no quantile without observations")
+	}
+}
+
+func TestLabelValue(t *testing.T) {
+	labels := `model_name="a \"quoted\", name",handle="x", le="+Inf"`
+	if got := labelValue(labels, "le"); got != "+Inf" {
+		t.Errorf("expected le +Inf, got %q", got)
+	}
+	if got := labelValue(labels, "missing"); got != "" {
+		t.Errorf("expected no value, got %q", got)
+	}
+}
+
+func TestActiveSince(t *testing.T) {
+	idle := Sample{Requests: 15}
+	if idle.ActiveSince(Sample{Requests: 15}) {
+		t.Error("expected unchanged counters to be idle")
+	}
+	if !(Sample{Requests: 16}).ActiveSince(idle) {
+		t.Error("expected a moved counter to be active")
+	}
+	if !(Sample{Requests: 15, Running: 1}).ActiveSince(idle) {
+		t.Error("expected an in-flight request to be active")
+	}
+}
+
+func TestScrape(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/metrics" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(vllmMetrics))
+	}))
+	defer server.Close()
+
+	sample, err := Scrape(context.Background(), server.Client(), server.URL+"/metrics")
+	if err != nil {
+		t.Fatalf("Scrape failed: %v", err)
+	}
+	if sample.Requests != 15 {
+		t.Errorf("expected 15 requests, got %v", sample.Requests)
+	}
+	if _, err := Scrape(context.Background(), server.Client(), server.URL+"/missing"); err == nil {
+		t.Error("expected an error for a non-200 response")
+	}
+}
@@ -0,0 +1,513 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package batch runs the offline inference of a ModelBatchJob: it renders a prompt for each
+// record of a JSON Lines dataset, sends it to the model server, and writes the completions
+// as JSON Lines.
+package batch
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"text/template"
+	"time"
+)
+
+const (
+	// DefaultImage is the image of the batch runner, the controller image, which ships
+	// the /batch-runner binary.
+	DefaultImage = "ghcr.io/kaito-project/airunway/controller:latest"
+
+	// ProgressPort is the port the runner serves its progress on
+	ProgressPort int32 = 8090
+	// ProgressPath is the path of the progress endpoint
+	ProgressPath = "/progress"
+
+	// DataDir is where the runner pod mounts the PersistentVolumeClaims of pvc:// URIs,
+	// each under its claim name
+	DataDir = "/data"
+
+	// DefaultConcurrency is used when spec.concurrency is unset
+	DefaultConcurrency = 8
+
+	// maxAttempts is how often a record is sent before it is recorded as failed
+	maxAttempts = 3
+	// maxResponseBytes bounds how much of a completion response is read
+	maxResponseBytes = 4 << 20
+	// maxRecordBytes bounds the length of an input line
+	maxRecordBytes = 16 << 20
+)
+
+// Location is a parsed ModelBatchJob input or output URI
+type Location struct {
+	// Claim and Path locate a file on a PersistentVolumeClaim for pvc:// URIs
+	Claim string
+	Path  string
+	// URL is the http(s) URL of other URIs
+	URL string
+}
+
+// ParseURI parses a pvc://<claim>/<path> or http(s) URI
+func ParseURI(uri string) (Location, error) {
+	if isURL(uri) {
+		return Location{URL: uri}, nil
+	}
+	rest, ok := strings.CutPrefix(uri, "pvc://")
+	if !ok {
+		return Location{}, fmt.Errorf("unsupported URI %q: expected pvc://<claim>/<path> or an http(s) URL", uri)
+	}
+	claim, p, _ := strings.Cut(rest, "/")
+	p = path.Clean("/" + p)
+	if claim == "" || p == "/" {
+		return Location{}, fmt.Errorf("invalid URI %q: expected pvc://<claim>/<path>", uri)
+	}
+	return Location{Claim: claim, Path: strings.TrimPrefix(p, "/")}, nil
+}
+
+// RunnerPath returns what the runner reads or writes for loc: the URL, or the path of the
+// file under DataDir
+func (l Location) RunnerPath() string {
+	if l.URL != "" {
+		return l.URL
+	}
+	return path.Join(DataDir, l.Claim, l.Path)
+}
+
+// Progress counts the records of a run. The runner serves it on ProgressPath while it runs
+// and writes it as its termination message when it exits.
+type Progress struct {
+	Total     int64 `json:"total"`
+	Completed int64 `json:"completed"`
+	Failed    int64 `json:"failed"`
+	// Error is why the run failed
+	Error string `json:"error,omitempty"`
+}
+
+// Tracker counts the records of a run as they finish
+type Tracker struct {
+	total, completed, failed atomic.Int64
+}
+
+// Progress returns the current counts
+func (t *Tracker) Progress() Progress {
+	return Progress{Total: t.total.Load(), Completed: t.completed.Load(), Failed: t.failed.Load()}
+}
+
+// ServeHTTP serves the current counts as JSON
+func (t *Tracker) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(t.Progress())
+}
+
+// Config configures a run
+type Config struct {
+	// Input and Output are http(s) URLs or local file paths
+	Input  string
+	Output string
+	// BaseURL is the URL of the OpenAI-compatible model server
+	BaseURL string
+	// Model is the served model name. When empty, the first model of /v1/models is used.
+	Model string
+	// PromptTemplate renders a record into a prompt. When empty, records must have a
+	// prompt field.
+	PromptTemplate string
+	// MaxTokens caps the completion length when set
+	MaxTokens int32
+	// Concurrency is the number of requests in flight
+	Concurrency int
+	// HTTPClient sends the requests
+	HTTPClient *http.Client
+	// RetryDelay is the wait before the first retry of a record, doubled for each retry
+	RetryDelay time.Duration
+	// WorkDir holds the downloaded input and the output before it is uploaded
+	WorkDir string
+}
+
+// result is the output line of a record
+type result struct {
+	Index  int    `json:"index"`
+	Output string `json:"output,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Run sends every record of the input to the model server and writes one result line per
+// record to the output, in the order the records finish. A record that still fails after
+// retries is written with its error and counted as failed rather than failing the run.
+func Run(ctx context.Context, cfg Config, tracker *Tracker) error {
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = http.DefaultClient
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = DefaultConcurrency
+	}
+	if cfg.WorkDir == "" {
+		cfg.WorkDir = os.TempDir()
+	}
+
+	var tmpl *template.Template
+	if cfg.PromptTemplate != "" {
+		var err error
+		if tmpl, err = template.New("prompt").Option("missingkey=error").Parse(cfg.PromptTemplate); err != nil {
+			return fmt.Errorf("parsing prompt template: %w", err)
+		}
+	}
+
+	inputPath, err := fetchInput(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	total, err := countRecords(inputPath)
+	if err != nil {
+		return err
+	}
+	tracker.total.Store(total)
+
+	if cfg.Model == "" {
+		if cfg.Model, err = discoverModel(ctx, cfg.HTTPClient, cfg.BaseURL); err != nil {
+			return err
+		}
+	}
+
+	outputPath := cfg.Output
+	if isURL(cfg.Output) {
+		outputPath = filepath.Join(cfg.WorkDir, "output.jsonl")
+	} else if err := os.MkdirAll(filepath.Dir(outputPath), 0o755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+	out, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("creating output file: %w", err)
+	}
+	defer out.Close()
+
+	if err := process(ctx, cfg, tmpl, inputPath, out, tracker); err != nil {
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("writing output file: %w", err)
+	}
+	if isURL(cfg.Output) {
+		return upload(ctx, cfg.HTTPClient, outputPath, cfg.Output)
+	}
+	return nil
+}
+
+// process fans the records of inputPath out to cfg.Concurrency workers and writes their
+// results to out
+func process(ctx context.Context, cfg Config, tmpl *template.Template, inputPath string, out io.Writer, tracker *Tracker) error {
+	in, err := os.Open(inputPath)
+	if err != nil {
+		return fmt.Errorf("opening input: %w", err)
+	}
+	defer in.Close()
+
+	type record struct {
+		index int
+		line  []byte
+	}
+	records := make(chan record)
+	results := make(chan result)
+
+	var workers sync.WaitGroup
+	for range cfg.Concurrency {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for rec := range records {
+				res := result{Index: rec.index}
+				if output, err := complete(ctx, cfg, tmpl, rec.line); err != nil {
+					res.Error = err.Error()
+				} else {
+					res.Output = output
+				}
+				results <- res
+			}
+		}()
+	}
+
+	writeErr := make(chan error, 1)
+	go func() {
+		w := bufio.NewWriter(out)
+		enc := json.NewEncoder(w)
+		var err error
+		for res := range results {
+			if res.Error != "" {
+				tracker.failed.Add(1)
+			} else {
+				tracker.completed.Add(1)
+			}
+			if err == nil {
+				err = enc.Encode(res)
+			}
+		}
+		if err == nil {
+			err = w.Flush()
+		}
+		writeErr <- err
+	}()
+
+	scanErr := scanRecords(in, func(index int, line []byte) bool {
+		select {
+		case records <- record{index: index, line: line}:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	})
+	close(records)
+	workers.Wait()
+	close(results)
+
+	if err := <-writeErr; err != nil {
+		return fmt.Errorf("writing output: %w", err)
+	}
+	if scanErr != nil {
+		return scanErr
+	}
+	return ctx.Err()
+}
+
+// complete renders the prompt of a record and sends it, retrying failed requests
+func complete(ctx context.Context, cfg Config, tmpl *template.Template, line []byte) (string, error) {
+	prompt, err := renderPrompt(tmpl, line)
+	if err != nil {
+		return "", err
+	}
+	delay := cfg.RetryDelay
+	for attempt := 1; ; attempt++ {
+		output, retry, err := sendCompletion(ctx, cfg, prompt)
+		if err == nil || !retry || attempt == maxAttempts {
+			return output, err
+		}
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+		delay *= 2
+	}
+}
+
+// renderPrompt builds the prompt of a JSON record: the template rendered with the record,
+// or its prompt field without a template
+func renderPrompt(tmpl *template.Template, line []byte) (string, error) {
+	var data map[string]interface{}
+	if err := json.Unmarshal(line, &data); err != nil {
+		return "", fmt.Errorf("record is not a JSON object: %w", err)
+	}
+	if tmpl == nil {
+		prompt, ok := data["prompt"].(string)
+		if !ok {
+			return "", errors.New("record has no prompt field and no prompt template is set")
+		}
+		return prompt, nil
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("rendering prompt template: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// sendCompletion sends a non-streaming /v1/completions request and returns the text of the
+// first choice. retry reports whether the request may succeed when sent again.
+func sendCompletion(ctx context.Context, cfg Config, prompt string) (output string, retry bool, err error) {
+	request := map[string]interface{}{
+		"model":  cfg.Model,
+		"prompt": prompt,
+		"stream": false,
+	}
+	if cfg.MaxTokens > 0 {
+		request["max_tokens"] = cfg.MaxTokens
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return "", false, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(cfg.BaseURL, "/")+"/v1/completions", bytes.NewReader(body))
+	if err != nil {
+		return "", false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := cfg.HTTPClient.Do(req)
+	if err != nil {
+		return "", ctx.Err() == nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return "", true, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		return "", retry, fmt.Errorf("model server returned HTTP %d", resp.StatusCode)
+	}
+
+	var completion struct {
+		Choices []struct {
+			Text string `json:"text"`
+		} `json:"choices"`
+	}
+	if err := json.Unmarshal(data, &completion); err != nil {
+		return "", false, fmt.Errorf("decoding completion: %w", err)
+	}
+	if len(completion.Choices) == 0 {
+		return "", false, errors.New("completion has no choices")
+	}
+	return completion.Choices[0].Text, false, nil
+}
+
+// discoverModel returns the first model the model server lists on /v1/models
+func discoverModel(ctx context.Context, httpClient *http.Client, baseURL string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(baseURL, "/")+"/v1/models", nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("listing models: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("listing models: model server returned HTTP %d", resp.StatusCode)
+	}
+	var models struct {
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(&models); err != nil {
+		return "", fmt.Errorf("decoding models: %w", err)
+	}
+	if len(models.Data) == 0 || models.Data[0].ID == "" {
+		return "", errors.New("model server lists no models")
+	}
+	return models.Data[0].ID, nil
+}
+
+// fetchInput returns the path of a local copy of the input, downloading http(s) inputs
+// into the work directory
+func fetchInput(ctx context.Context, cfg Config) (string, error) {
+	if !isURL(cfg.Input) {
+		return cfg.Input, nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, cfg.Input, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := cfg.HTTPClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("downloading input: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("downloading input: HTTP %d", resp.StatusCode)
+	}
+
+	inputPath := filepath.Join(cfg.WorkDir, "input.jsonl")
+	f, err := os.Create(inputPath)
+	if err != nil {
+		return "", fmt.Errorf("creating input file: %w", err)
+	}
+	defer f.Close()
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		return "", fmt.Errorf("downloading input: %w", err)
+	}
+	return inputPath, f.Close()
+}
+
+// upload PUTs the file at localPath to url
+func upload(ctx context.Context, httpClient *http.Client, localPath, url string) error {
+	f, err := os.Open(localPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, f)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = info.Size()
+	req.Header.Set("Content-Type", "application/jsonl")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("uploading output: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBytes))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("uploading output: HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// countRecords returns the number of non-empty lines of the file at p
+func countRecords(p string) (int64, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return 0, fmt.Errorf("opening input: %w", err)
+	}
+	defer f.Close()
+	var n int64
+	err = scanRecords(f, func(int, []byte) bool {
+		n++
+		return true
+	})
+	return n, err
+}
+
+// scanRecords calls fn with the 0-based index and content of each non-empty line of r
+// until fn returns false
+func scanRecords(r io.Reader, fn func(index int, line []byte) bool) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64<<10), maxRecordBytes)
+	index := 0
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if !fn(index, bytes.Clone(line)) {
+			return nil
+		}
+		index++
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("reading input: %w", err)
+	}
+	return nil
+}
+
+func isURL(s string) bool {
+	return strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://")
+}
@@ -0,0 +1,207 @@
+package batch
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestParseURI(t *testing.T) {
+	tests := []struct {
+		uri     string
+		want    Location
+		runner  string
+		wantErr bool
+	}{
+		{uri: "pvc://datasets/in/prompts.jsonl", want: Location{Claim: "datasets", Path: "in/prompts.jsonl"}, runner: "/data/datasets/in/prompts.jsonl"},
+		{uri: "pvc://datasets/../../etc/passwd", want: Location{Claim: "datasets", Path: "etc/passwd"}, runner: "/data/datasets/etc/passwd"},
+		{uri: "https://bucket.example.com/in.jsonl?sig=abc", want: Location{URL: "https://bucket.example.com/in.jsonl?sig=abc"}, runner: "https://bucket.example.com/in.jsonl?sig=abc"},
+		{uri: "pvc://datasets", wantErr: true},
+		{uri: "pvc:///in.jsonl", wantErr: true},
+		{uri: "s3://bucket/in.jsonl", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseURI(tt.uri)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseURI(%q): expected error", tt.uri)
+			}
+			continue
+		}
+		if err != nil || got != tt.want || got.RunnerPath() != tt.runner {
+			t.Errorf("ParseURI(%q) = %+v (%s), %v; want %+v (%s)", tt.uri, got, got.RunnerPath(), err, tt.want, tt.runner)
+		}
+	}
+}
+
+// modelServer answers completions with the upper-cased prompt, failing prompts that
+// contain "fail" and the first attempt of prompts that contain "flaky"
+func modelServer(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var flaky atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/models":
+			_, _ = w.Write([]byte(`{"data":[{"id":"llama"}]}`))
+		case "/v1/completions":
+			var body struct {
+				Model     string `json:"model"`
+				Prompt    string `json:"prompt"`
+				MaxTokens int32  `json:"max_tokens"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if body.Model != "llama" || body.MaxTokens != 32 {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			if strings.Contains(body.Prompt, "fail") {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			if strings.Contains(body.Prompt, "flaky") && flaky.Add(1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"choices": []map[string]string{{"text": strings.ToUpper(body.Prompt)}},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &flaky
+}
+
+func readResults(t *testing.T, data string) []result {
+	t.Helper()
+	var results []result
+	for _, line := range strings.Split(strings.TrimSpace(data), "\n") {
+		var res result
+		if err := json.Unmarshal([]byte(line), &res); err != nil {
+			t.Fatalf("invalid output line %q: %v", line, err)
+		}
+		results = append(results, res)
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].Index < results[j].Index })
+	return results
+}
+
+func TestRun_LocalFiles(t *testing.T) {
+	server, flaky := modelServer(t)
+	dir := t.TempDir()
+	input := filepath.Join(dir, "in.jsonl")
+	output := filepath.Join(dir, "out", "results.jsonl")
+	data := `{"text":"hello"}
+
+{"text":"flaky"}
+{"text":"fail"}
+{"other":"x"}
+`
+	if err := os.WriteFile(input, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var tracker Tracker
+	err := Run(context.Background(), Config{
+		Input:          input,
+		Output:         output,
+		BaseURL:        server.URL,
+		PromptTemplate: "say {{ .text }}",
+		MaxTokens:      32,
+		Concurrency:    2,
+	}, &tracker)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if got := tracker.Progress(); got != (Progress{Total: 4, Completed: 2, Failed: 2}) {
+		t.Errorf("unexpected progress %+v", got)
+	}
+	if flaky.Load() != 2 {
+		t.Errorf("expected the flaky record to be retried once, got %d attempts", flaky.Load())
+	}
+
+	out, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	results := readResults(t, string(out))
+	if len(results) != 4 {
+		t.Fatalf("expected 4 results, got %+v", results)
+	}
+	if results[0].Output != "SAY HELLO" || results[1].Output != "SAY FLAKY" {
+		t.Errorf("unexpected outputs %+v", results[:2])
+	}
+	if !strings.Contains(results[2].Error, "HTTP 400") {
+		t.Errorf("expected HTTP 400 for the failing record, got %+v", results[2])
+	}
+	if !strings.Contains(results[3].Error, "map has no entry") {
+		t.Errorf("expected a template error for the record without text, got %+v", results[3])
+	}
+}
+
+func TestRun_HTTPStorage(t *testing.T) {
+	model, _ := modelServer(t)
+	var uploaded string
+	storage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/in.jsonl":
+			_, _ = w.Write([]byte(`{"prompt":"a"}` + "\n" + `{"prompt":"b"}` + "\n"))
+		case r.Method == http.MethodPut && r.URL.Path == "/out.jsonl":
+			data, _ := io.ReadAll(r.Body)
+			uploaded = string(data)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer storage.Close()
+
+	var tracker Tracker
+	err := Run(context.Background(), Config{
+		Input:     storage.URL + "/in.jsonl",
+		Output:    storage.URL + "/out.jsonl",
+		BaseURL:   model.URL,
+		Model:     "llama",
+		MaxTokens: 32,
+		WorkDir:   t.TempDir(),
+	}, &tracker)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	results := readResults(t, uploaded)
+	if len(results) != 2 || results[0].Output != "A" || results[1].Output != "B" {
+		t.Errorf("unexpected uploaded results %+v", results)
+	}
+	if got := tracker.Progress(); got != (Progress{Total: 2, Completed: 2}) {
+		t.Errorf("unexpected progress %+v", got)
+	}
+}
+
+func TestRun_InputNotFound(t *testing.T) {
+	var tracker Tracker
+	err := Run(context.Background(), Config{Input: filepath.Join(t.TempDir(), "missing.jsonl"), Output: "out.jsonl"}, &tracker)
+	if err == nil || !strings.Contains(err.Error(), "opening input") {
+		t.Errorf("expected an input error, got %v", err)
+	}
+}
+
+func TestTrackerServeHTTP(t *testing.T) {
+	var tracker Tracker
+	tracker.total.Store(3)
+	tracker.completed.Store(1)
+	rec := httptest.NewRecorder()
+	tracker.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, ProgressPath, nil))
+	var got Progress
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil || got != (Progress{Total: 3, Completed: 1}) {
+		t.Errorf("unexpected progress %s (%v)", rec.Body.String(), err)
+	}
+}
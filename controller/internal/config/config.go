@@ -0,0 +1,338 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package config loads the controller manager config file passed with --config. Each
+// field of the file sets the value of a command-line flag, so flags and the file share
+// defaults and validation, and flags set on the command line take precedence.
+package config
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// APIVersion and Kind identify the controller manager config file format
+	APIVersion = "config.airunway.ai/v1alpha1"
+	Kind       = "ControllerManagerConfig"
+)
+
+// ReloadableFlags are the flags whose values from the config file are applied while the
+// controller runs. Changes to the other flags in the file take effect on restart.
+var ReloadableFlags = []string{
+	"enable-provider-selector",
+	"provider-selection-strategy",
+	"gateway-probe-interval",
+	"tracing-endpoint",
+	"admission-poll-interval",
+	"activity-poll-interval",
+	"metrics-snapshot-interval",
+}
+
+// ControllerManagerConfig is the controller manager config file
+type ControllerManagerConfig struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// ProviderSelection configures provider and engine selection
+	ProviderSelection *ProviderSelectionConfig `json:"providerSelection,omitempty"`
+
+	// Namespaces limits the ModelDeployments the controller reconciles (--namespaces)
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Gateway configures Gateway API integration
+	Gateway *GatewayConfig `json:"gateway,omitempty"`
+
+	// EPP configures the Endpoint Picker Proxy defaults
+	EPP *EPPConfig `json:"epp,omitempty"`
+
+	// Tracing configures EPP OpenTelemetry tracing
+	Tracing *TracingConfig `json:"tracing,omitempty"`
+
+	// Recommender configures the resource recommender
+	Recommender *RecommenderConfig `json:"recommender,omitempty"`
+
+	// Requeue configures how often ModelDeployments are requeued while waiting
+	Requeue *RequeueConfig `json:"requeue,omitempty"`
+
+	// Batch configures the batch Jobs of ModelBatchJobs
+	Batch *BatchConfig `json:"batch,omitempty"`
+
+	// Dashboards configures the generated Grafana dashboards
+	Dashboards *DashboardsConfig `json:"dashboards,omitempty"`
+}
+
+// ProviderSelectionConfig configures provider and engine selection
+type ProviderSelectionConfig struct {
+	// Enabled sets --enable-provider-selector
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Strategy sets --provider-selection-strategy
+	Strategy string `json:"strategy,omitempty"`
+
+	// HeartbeatTimeout sets --provider-heartbeat-timeout
+	HeartbeatTimeout *metav1.Duration `json:"heartbeatTimeout,omitempty"`
+}
+
+// GatewayConfig configures Gateway API integration
+type GatewayConfig struct {
+	// Name and Namespace set --gateway-name and --gateway-namespace
+	Name      string `json:"name,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+
+	// PatchAllowedRoutes sets --patch-gateway-allowed-routes
+	PatchAllowedRoutes *bool `json:"patchAllowedRoutes,omitempty"`
+
+	// MappingNamespace sets --gateway-mapping-namespace
+	MappingNamespace string `json:"mappingNamespace,omitempty"`
+
+	// MappingOverridesExplicit sets --gateway-mapping-overrides-explicit
+	MappingOverridesExplicit *bool `json:"mappingOverridesExplicit,omitempty"`
+
+	// ProbeInterval sets --gateway-probe-interval
+	ProbeInterval *metav1.Duration `json:"probeInterval,omitempty"`
+
+	// Provision configures the default inference Gateway
+	Provision *GatewayProvisionConfig `json:"provision,omitempty"`
+
+	// PromptPolicyImage sets --prompt-policy-image
+	PromptPolicyImage string `json:"promptPolicyImage,omitempty"`
+}
+
+// GatewayProvisionConfig configures the default inference Gateway
+type GatewayProvisionConfig struct {
+	// ClassName, Name, and Namespace set --provision-gateway, --provision-gateway-name,
+	// and --provision-gateway-namespace
+	ClassName string `json:"className,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// EPPConfig configures the Endpoint Picker Proxy defaults
+type EPPConfig struct {
+	// Image sets --epp-image
+	Image string `json:"image,omitempty"`
+
+	// ServicePort sets --epp-service-port
+	ServicePort *int32 `json:"servicePort,omitempty"`
+
+	// ImageDigest sets --epp-image-digest
+	ImageDigest string `json:"imageDigest,omitempty"`
+
+	// ImagePullSecrets sets --epp-image-pull-secrets
+	ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
+
+	// SecurityContext sets --epp-security-context
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+
+	// RBACMode sets --epp-rbac-mode
+	RBACMode string `json:"rbacMode,omitempty"`
+}
+
+// TracingConfig configures EPP OpenTelemetry tracing
+type TracingConfig struct {
+	// Endpoint sets --tracing-endpoint
+	Endpoint string `json:"endpoint,omitempty"`
+}
+
+// RecommenderConfig configures the resource recommender
+type RecommenderConfig struct {
+	// Enabled sets --enable-resource-recommender
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Interval sets --recommender-interval
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// DCGMExporterNamespace sets --dcgm-exporter-namespace
+	DCGMExporterNamespace string `json:"dcgmExporterNamespace,omitempty"`
+
+	// GPUIdleThreshold sets --gpu-idle-threshold
+	GPUIdleThreshold *float64 `json:"gpuIdleThreshold,omitempty"`
+}
+
+// RequeueConfig configures how often ModelDeployments are requeued while waiting
+type RequeueConfig struct {
+	// AdmissionPollInterval sets --admission-poll-interval
+	AdmissionPollInterval *metav1.Duration `json:"admissionPollInterval,omitempty"`
+
+	// ActivityPollInterval sets --activity-poll-interval
+	ActivityPollInterval *metav1.Duration `json:"activityPollInterval,omitempty"`
+
+	// MetricsSnapshotInterval sets --metrics-snapshot-interval
+	MetricsSnapshotInterval *metav1.Duration `json:"metricsSnapshotInterval,omitempty"`
+}
+
+// BatchConfig configures the batch Jobs of ModelBatchJobs
+type BatchConfig struct {
+	// RunnerImage sets --batch-runner-image
+	RunnerImage string `json:"runnerImage,omitempty"`
+}
+
+// DashboardsConfig configures the generated Grafana dashboards
+type DashboardsConfig struct {
+	// Grafana sets --grafana-dashboards
+	Grafana *bool `json:"grafana,omitempty"`
+}
+
+// Load reads and parses the config file at path
+func Load(path string) (*ControllerManagerConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	cfg, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Parse parses a config file, rejecting unknown fields and other API versions
+func Parse(data []byte) (*ControllerManagerConfig, error) {
+	cfg := &ControllerManagerConfig{}
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, err
+	}
+	if cfg.APIVersion != APIVersion || cfg.Kind != Kind {
+		return nil, fmt.Errorf("expected apiVersion %s and kind %s, got %q and %q", APIVersion, Kind, cfg.APIVersion, cfg.Kind)
+	}
+	return cfg, nil
+}
+
+// ApplyToFlags sets the flags of fs from the config file, except for flags that were set
+// on the command line. The flags validate the values as if they had been passed directly.
+func (c *ControllerManagerConfig) ApplyToFlags(fs *flag.FlagSet) error {
+	explicit := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+
+	for _, v := range c.flagValues() {
+		if explicit[v.name] {
+			continue
+		}
+		if err := fs.Set(v.name, v.value); err != nil {
+			return fmt.Errorf("invalid value %q for --%s: %w", v.value, v.name, err)
+		}
+	}
+	return nil
+}
+
+// ChangedFlags returns the names of the flags whose values differ between old and new,
+// split into ReloadableFlags and flags that take effect on restart
+func ChangedFlags(old, new *flag.FlagSet) (reloadable, restart []string) {
+	old.VisitAll(func(f *flag.Flag) {
+		n := new.Lookup(f.Name)
+		if n == nil || n.Value.String() == f.Value.String() {
+			return
+		}
+		if slices.Contains(ReloadableFlags, f.Name) {
+			reloadable = append(reloadable, f.Name)
+		} else {
+			restart = append(restart, f.Name)
+		}
+	})
+	return reloadable, restart
+}
+
+type flagValue struct {
+	name  string
+	value string
+}
+
+// flagValues returns the flag values of the fields set in the config file
+func (c *ControllerManagerConfig) flagValues() []flagValue {
+	var values []flagValue
+	add := func(name, value string) {
+		if value != "" {
+			values = append(values, flagValue{name: name, value: value})
+		}
+	}
+	addBool := func(name string, value *bool) {
+		if value != nil {
+			add(name, strconv.FormatBool(*value))
+		}
+	}
+	addDuration := func(name string, value *metav1.Duration) {
+		if value != nil {
+			add(name, value.Duration.String())
+		}
+	}
+
+	if c.ProviderSelection != nil {
+		addBool("enable-provider-selector", c.ProviderSelection.Enabled)
+		add("provider-selection-strategy", c.ProviderSelection.Strategy)
+		addDuration("provider-heartbeat-timeout", c.ProviderSelection.HeartbeatTimeout)
+	}
+	add("namespaces", strings.Join(c.Namespaces, ","))
+	if g := c.Gateway; g != nil {
+		add("gateway-name", g.Name)
+		add("gateway-namespace", g.Namespace)
+		addBool("patch-gateway-allowed-routes", g.PatchAllowedRoutes)
+		add("gateway-mapping-namespace", g.MappingNamespace)
+		addBool("gateway-mapping-overrides-explicit", g.MappingOverridesExplicit)
+		addDuration("gateway-probe-interval", g.ProbeInterval)
+		add("prompt-policy-image", g.PromptPolicyImage)
+		if p := g.Provision; p != nil {
+			add("provision-gateway", p.ClassName)
+			add("provision-gateway-name", p.Name)
+			add("provision-gateway-namespace", p.Namespace)
+		}
+	}
+	if e := c.EPP; e != nil {
+		add("epp-image", e.Image)
+		if e.ServicePort != nil {
+			add("epp-service-port", strconv.Itoa(int(*e.ServicePort)))
+		}
+		add("epp-image-digest", e.ImageDigest)
+		add("epp-image-pull-secrets", strings.Join(e.ImagePullSecrets, ","))
+		if e.SecurityContext != nil {
+			// A SecurityContext always marshals
+			data, _ := json.Marshal(e.SecurityContext)
+			add("epp-security-context", string(data))
+		}
+		add("epp-rbac-mode", e.RBACMode)
+	}
+	if c.Tracing != nil {
+		add("tracing-endpoint", c.Tracing.Endpoint)
+	}
+	if r := c.Recommender; r != nil {
+		addBool("enable-resource-recommender", r.Enabled)
+		addDuration("recommender-interval", r.Interval)
+		add("dcgm-exporter-namespace", r.DCGMExporterNamespace)
+		if r.GPUIdleThreshold != nil {
+			add("gpu-idle-threshold", strconv.FormatFloat(*r.GPUIdleThreshold, 'f', -1, 64))
+		}
+	}
+	if r := c.Requeue; r != nil {
+		addDuration("admission-poll-interval", r.AdmissionPollInterval)
+		addDuration("activity-poll-interval", r.ActivityPollInterval)
+		addDuration("metrics-snapshot-interval", r.MetricsSnapshotInterval)
+	}
+	if b := c.Batch; b != nil {
+		add("batch-runner-image", b.RunnerImage)
+	}
+	if d := c.Dashboards; d != nil {
+		addBool("grafana-dashboards", d.Grafana)
+	}
+	return values
+}
@@ -0,0 +1,252 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testConfig = `apiVersion: config.airunway.ai/v1alpha1
+kind: ControllerManagerConfig
+providerSelection:
+  enabled: false
+  strategy: round-robin
+namespaces: [team-a, team-b]
+gateway:
+  name: inference-gateway
+  namespace: gateway-system
+  probeInterval: 30s
+  promptPolicyImage: registry.example.com/airunway/controller:v1
+epp:
+  servicePort: 9003
+tracing:
+  endpoint: http://otel-collector.observability:4317
+recommender:
+  gpuIdleThreshold: 2.5
+requeue:
+  admissionPollInterval: 5s
+batch:
+  runnerImage: registry.example.com/airunway/controller:v1
+dashboards:
+  grafana: true
+`
+
+type testFlags struct {
+	providerSelector bool
+	strategy         string
+	namespaces       string
+	gatewayName      string
+	gatewayNamespace string
+	probeInterval    time.Duration
+	promptImage      string
+	eppServicePort   int
+	tracingEndpoint  string
+	gpuIdle          float64
+	admissionPoll    time.Duration
+	batchImage       string
+	dashboards       bool
+}
+
+func newTestFlagSet(f *testFlags) *flag.FlagSet {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.BoolVar(&f.providerSelector, "enable-provider-selector", true, "")
+	fs.StringVar(&f.strategy, "provider-selection-strategy", "priority", "")
+	fs.StringVar(&f.namespaces, "namespaces", "", "")
+	fs.StringVar(&f.gatewayName, "gateway-name", "", "")
+	fs.StringVar(&f.gatewayNamespace, "gateway-namespace", "", "")
+	fs.DurationVar(&f.probeInterval, "gateway-probe-interval", 5*time.Minute, "")
+	fs.StringVar(&f.promptImage, "prompt-policy-image", "", "")
+	fs.IntVar(&f.eppServicePort, "epp-service-port", 9002, "")
+	fs.StringVar(&f.tracingEndpoint, "tracing-endpoint", "", "")
+	fs.Float64Var(&f.gpuIdle, "gpu-idle-threshold", 5, "")
+	fs.DurationVar(&f.admissionPoll, "admission-poll-interval", 10*time.Second, "")
+	fs.StringVar(&f.batchImage, "batch-runner-image", "", "")
+	fs.BoolVar(&f.dashboards, "grafana-dashboards", false, "")
+	return fs
+}
+
+func TestParse(t *testing.T) {
+	cfg, err := Parse([]byte(testConfig))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Gateway == nil || cfg.Gateway.ProbeInterval == nil || cfg.Gateway.ProbeInterval.Duration != 30*time.Second {
+		t.Errorf("expected gateway.probeInterval 30s, got %+v", cfg.Gateway)
+	}
+
+	if _, err := Parse([]byte(testConfig + "unknownField: true\n")); err == nil {
+		t.Error("expected unknown fields to be rejected")
+	}
+	if _, err := Parse([]byte(strings.Replace(testConfig, "v1alpha1", "v1", 1))); err == nil {
+		t.Error("expected other API versions to be rejected")
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	if _, err := Load(filepath.Join(t.TempDir(), "config.yaml")); err == nil {
+		t.Error("expected an error for a missing config file")
+	}
+}
+
+func TestApplyToFlags(t *testing.T) {
+	cfg, err := Parse([]byte(testConfig))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var f testFlags
+	fs := newTestFlagSet(&f)
+	if err := fs.Parse([]string{"--tracing-endpoint=http://cli:4317"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := cfg.ApplyToFlags(fs); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if f.providerSelector {
+		t.Error("expected providerSelection.enabled to disable the provider selector")
+	}
+	if f.strategy != "round-robin" {
+		t.Errorf("expected providerSelection.strategy round-robin, got %q", f.strategy)
+	}
+	if f.namespaces != "team-a,team-b" {
+		t.Errorf("expected namespaces team-a,team-b, got %q", f.namespaces)
+	}
+	if f.gatewayName != "inference-gateway" || f.gatewayNamespace != "gateway-system" {
+		t.Errorf("expected the gateway from the file, got %s/%s", f.gatewayNamespace, f.gatewayName)
+	}
+	if f.probeInterval != 30*time.Second || f.admissionPoll != 5*time.Second {
+		t.Errorf("expected intervals from the file, got %s and %s", f.probeInterval, f.admissionPoll)
+	}
+	if f.eppServicePort != 9003 {
+		t.Errorf("expected epp.servicePort 9003, got %d", f.eppServicePort)
+	}
+	if f.promptImage != "registry.example.com/airunway/controller:v1" {
+		t.Errorf("expected gateway.promptPolicyImage from the file, got %q", f.promptImage)
+	}
+	if f.gpuIdle != 2.5 {
+		t.Errorf("expected recommender.gpuIdleThreshold 2.5, got %v", f.gpuIdle)
+	}
+	if f.batchImage != "registry.example.com/airunway/controller:v1" {
+		t.Errorf("expected batch.runnerImage from the file, got %q", f.batchImage)
+	}
+	if !f.dashboards {
+		t.Error("expected dashboards.grafana to enable Grafana dashboards")
+	}
+	if f.tracingEndpoint != "http://cli:4317" {
+		t.Errorf("expected the command-line flag to take precedence, got %q", f.tracingEndpoint)
+	}
+}
+
+func TestApplyToFlagsEPP(t *testing.T) {
+	cfg, err := Parse([]byte(`apiVersion: config.airunway.ai/v1alpha1
+kind: ControllerManagerConfig
+epp:
+  imageDigest: sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef
+  imagePullSecrets: [mirror-creds, backup-creds]
+  securityContext:
+    runAsUser: 65532
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var digest, pullSecrets, securityContext string
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.StringVar(&digest, "epp-image-digest", "", "")
+	fs.StringVar(&pullSecrets, "epp-image-pull-secrets", "", "")
+	fs.StringVar(&securityContext, "epp-security-context", "", "")
+	if err := cfg.ApplyToFlags(fs); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.HasPrefix(digest, "sha256:") {
+		t.Errorf("expected epp.imageDigest, got %q", digest)
+	}
+	if pullSecrets != "mirror-creds,backup-creds" {
+		t.Errorf("expected epp.imagePullSecrets mirror-creds,backup-creds, got %q", pullSecrets)
+	}
+	if securityContext != `{"runAsUser":65532}` {
+		t.Errorf("expected epp.securityContext as JSON, got %q", securityContext)
+	}
+}
+
+func TestApplyToFlagsUnknownFlag(t *testing.T) {
+	cfg := &ControllerManagerConfig{EPP: &EPPConfig{Image: "epp:v1"}}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	if err := cfg.ApplyToFlags(fs); err == nil {
+		t.Error("expected an error for a field without a flag")
+	}
+}
+
+func TestChangedFlags(t *testing.T) {
+	var oldFlags, newFlags testFlags
+	oldFS, newFS := newTestFlagSet(&oldFlags), newTestFlagSet(&newFlags)
+	if err := newFS.Parse([]string{"--tracing-endpoint=http://new:4317", "--gateway-name=other"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	reloadable, restart := ChangedFlags(oldFS, newFS)
+	if !slices.Equal(reloadable, []string{"tracing-endpoint"}) {
+		t.Errorf("expected tracing-endpoint to be reloadable, got %v", reloadable)
+	}
+	if !slices.Equal(restart, []string{"gateway-name"}) {
+		t.Errorf("expected gateway-name to need a restart, got %v", restart)
+	}
+}
+
+func TestWatcherCheck(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(testConfig), 0o600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var changes []*ControllerManagerConfig
+	w := &Watcher{
+		Path:     path,
+		OnChange: func(_ context.Context, cfg *ControllerManagerConfig) { changes = append(changes, cfg) },
+		last:     []byte(testConfig),
+	}
+	ctx := context.Background()
+
+	w.check(ctx)
+	if len(changes) != 0 {
+		t.Fatalf("expected no change for the same content, got %d", len(changes))
+	}
+
+	if err := os.WriteFile(path, []byte("kind: Unknown\n"), 0o600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	w.check(ctx)
+	if len(changes) != 0 {
+		t.Fatalf("expected an invalid file to be ignored, got %d changes", len(changes))
+	}
+
+	updated := strings.Replace(testConfig, "30s", "1m", 1)
+	if err := os.WriteFile(path, []byte(updated), 0o600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	w.check(ctx)
+	w.check(ctx)
+	if len(changes) != 1 || changes[0].Gateway.ProbeInterval.Duration != time.Minute {
+		t.Errorf("expected one change to the new probe interval, got %v", changes)
+	}
+}
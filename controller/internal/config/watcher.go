@@ -0,0 +1,92 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// DefaultWatchInterval is how often the config file is checked for changes
+const DefaultWatchInterval = 10 * time.Second
+
+// Watcher calls OnChange when the content of the config file changes. The file is polled
+// rather than watched for events, since a mounted ConfigMap is updated by swapping a
+// symlink. Watcher is a manager.Runnable that runs on every replica.
+type Watcher struct {
+	// Path is the config file
+	Path string
+
+	// Interval is how often the file is checked. Zero uses DefaultWatchInterval.
+	Interval time.Duration
+
+	// OnChange is called with the new config. A file that fails to parse is logged and
+	// the previous config is kept.
+	OnChange func(ctx context.Context, cfg *ControllerManagerConfig)
+
+	last []byte
+}
+
+// Start polls the config file until ctx is done
+func (w *Watcher) Start(ctx context.Context) error {
+	interval := w.Interval
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+	w.last, _ = os.ReadFile(w.Path)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			w.check(ctx)
+		}
+	}
+}
+
+// check calls OnChange if the file content differs from the last read
+func (w *Watcher) check(ctx context.Context) {
+	logger := log.FromContext(ctx)
+	data, err := os.ReadFile(w.Path)
+	if err != nil {
+		logger.Error(err, "Failed to read config file", "path", w.Path)
+		return
+	}
+	if bytes.Equal(data, w.last) {
+		return
+	}
+	w.last = data
+	cfg, err := Parse(data)
+	if err != nil {
+		logger.Error(err, "Ignoring invalid config file", "path", w.Path)
+		return
+	}
+	w.OnChange(ctx, cfg)
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, so settings are reloaded
+// on standby replicas too.
+func (w *Watcher) NeedLeaderElection() bool {
+	return false
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/internal/lora"
+)
+
+// adapterClient allows for adapters the engine downloads from HuggingFace while loading
+var adapterClient = &http.Client{Timeout: 2 * time.Minute}
+
+// adapterBaseURL returns the URL of the model server API of a pod
+var adapterBaseURL = func(podIP string, port int32) string {
+	return "http://" + net.JoinHostPort(podIP, strconv.Itoa(int(port)))
+}
+
+// adapterSync is the outcome of syncing the adapters of a deployment on its replicas
+type adapterSync struct {
+	// loaded counts the replicas serving each adapter
+	loaded   map[string]int32
+	replicas int
+	failures []string
+}
+
+// reconcileAdapters loads the spec.model.adapters of a Running deployment on each ready
+// replica through the vllm runtime adapter API, and unloads the adapters removed from the
+// list. Replicas are synced one by one by the background scraper, since the Service would
+// spread the requests across them. It records the result of the last sync of the current
+// generation in status.adapters and the AdaptersLoaded condition, and returns how long to
+// wait before syncing again, e.g. for restarted replicas, or zero.
+func (r *ModelDeploymentReconciler) reconcileAdapters(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) time.Duration {
+	adapters := md.Spec.Model.Adapters
+	if len(adapters) == 0 {
+		// The engine restarts without LoRA support, which drops the loaded adapters
+		md.Status.Adapters = nil
+		meta.RemoveStatusCondition(&md.Status.Conditions, airunwayv1alpha1.ConditionTypeAdaptersLoaded)
+		r.scrapes.forget(types.NamespacedName{Name: md.Name, Namespace: md.Namespace}, scrapeAdapters)
+		return 0
+	}
+	if md.Status.Phase != airunwayv1alpha1.DeploymentPhaseRunning {
+		return 0
+	}
+	interval := r.settings().ActivityPollInterval
+
+	pods, err := modelPods(ctx, r.Client, md)
+	if err != nil {
+		log.FromContext(ctx).Info("Could not list model server pods for adapters", "name", md.Name, "error", err.Error())
+		return interval
+	}
+	port := (&podMetricsActivitySource{Reader: r.Client}).metricsPort(ctx, md)
+
+	wanted := slices.Clone(adapters)
+	result, ok := r.scrape(ctx, md, scrapeAdapters, strconv.FormatInt(md.Generation, 10), interval, func(ctx context.Context) (interface{}, error) {
+		return syncAdapters(ctx, pods, port, wanted), nil
+	})
+	if !ok {
+		return interval
+	}
+	synced := result.value.(adapterSync)
+	loaded, replicas, failures := synced.loaded, synced.replicas, synced.failures
+
+	md.Status.Adapters = make([]airunwayv1alpha1.AdapterStatus, 0, len(adapters))
+	for _, adapter := range adapters {
+		md.Status.Adapters = append(md.Status.Adapters, airunwayv1alpha1.AdapterStatus{Name: adapter.Name, LoadedReplicas: loaded[adapter.Name]})
+	}
+
+	switch {
+	case len(failures) > 0:
+		message := strings.Join(failures, "; ")
+		previous := meta.FindStatusCondition(md.Status.Conditions, airunwayv1alpha1.ConditionTypeAdaptersLoaded)
+		if (previous == nil || previous.Message != message) && r.Recorder != nil {
+			r.Recorder.Eventf(md, nil, corev1.EventTypeWarning, airunwayv1alpha1.ReasonAdapterLoadFailed, "LoadAdapters", message)
+		}
+		r.setCondition(md, airunwayv1alpha1.ConditionTypeAdaptersLoaded, metav1.ConditionFalse, airunwayv1alpha1.ReasonAdapterLoadFailed, message)
+	case replicas == 0:
+		r.setCondition(md, airunwayv1alpha1.ConditionTypeAdaptersLoaded, metav1.ConditionUnknown, "NoReadyReplicas", "No ready model server pods to load adapters on")
+	default:
+		r.setCondition(md, airunwayv1alpha1.ConditionTypeAdaptersLoaded, metav1.ConditionTrue, "AdaptersLoaded",
+			fmt.Sprintf("Loaded %d adapters on %d replicas", len(adapters), replicas))
+	}
+	return interval
+}
+
+// syncAdapters makes each ready pod serve exactly adapters
+func syncAdapters(ctx context.Context, pods []corev1.Pod, port int32, adapters []airunwayv1alpha1.LoRAAdapter) adapterSync {
+	result := adapterSync{loaded: make(map[string]int32, len(adapters))}
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Status.PodIP == "" || !podReady(pod) {
+			continue
+		}
+		result.replicas++
+		names, err := syncPodAdapters(ctx, adapterBaseURL(pod.Status.PodIP, port), adapters)
+		if err != nil {
+			result.failures = append(result.failures, fmt.Sprintf("pod %s: %v", pod.Name, err))
+		}
+		for _, name := range names {
+			result.loaded[name]++
+		}
+	}
+	sort.Strings(result.failures)
+	return result
+}
+
+// syncPodAdapters makes the model server at baseURL serve exactly adapters. An adapter
+// whose source changed is unloaded and loaded again. It returns the names of the adapters
+// served afterwards, and the errors.
+func syncPodAdapters(ctx context.Context, baseURL string, adapters []airunwayv1alpha1.LoRAAdapter) ([]string, error) {
+	current, err := lora.Loaded(ctx, adapterClient, baseURL)
+	if err != nil {
+		return nil, err
+	}
+	wanted := make(map[string]string, len(adapters))
+	for _, adapter := range adapters {
+		wanted[adapter.Name] = adapter.Source
+	}
+
+	var errs []error
+	for name, source := range current {
+		if wanted[name] == source {
+			continue
+		}
+		if err := lora.Unload(ctx, adapterClient, baseURL, name); err != nil {
+			errs = append(errs, fmt.Errorf("unloading adapter %s: %w", name, err))
+			continue
+		}
+		delete(current, name)
+	}
+	names := make([]string, 0, len(adapters))
+	for _, adapter := range adapters {
+		if _, ok := current[adapter.Name]; ok {
+			names = append(names, adapter.Name)
+			continue
+		}
+		if err := lora.Load(ctx, adapterClient, baseURL, adapter.Name, adapter.Source); err != nil {
+			errs = append(errs, fmt.Errorf("loading adapter %s: %w", adapter.Name, err))
+			continue
+		}
+		names = append(names, adapter.Name)
+	}
+	return names, errors.Join(errs...)
+}
+
+// podReady reports whether the Ready condition of pod is True
+func podReady(pod *corev1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+// fakeAdapterServer is a vllm server with the runtime adapter API
+type fakeAdapterServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	adapters map[string]string
+}
+
+func newFakeAdapterServer(t *testing.T, adapters map[string]string) *fakeAdapterServer {
+	s := &fakeAdapterServer{adapters: adapters}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if req.URL.Path == "/v1/models" {
+			base := "meta-llama/Llama-3-8B"
+			models := []map[string]interface{}{{"id": base, "root": base, "parent": nil}}
+			for name, path := range s.adapters {
+				models = append(models, map[string]interface{}{"id": name, "root": path, "parent": base})
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": models})
+			return
+		}
+		var body map[string]string
+		_ = json.NewDecoder(req.Body).Decode(&body)
+		name := body["lora_name"]
+		switch req.URL.Path {
+		case "/v1/load_lora_adapter":
+			if _, ok := s.adapters[name]; ok || body["lora_path"] == "/missing" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			s.adapters[name] = body["lora_path"]
+		case "/v1/unload_lora_adapter":
+			delete(s.adapters, name)
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *fakeAdapterServer) loaded() map[string]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	loaded := make(map[string]string, len(s.adapters))
+	for name, path := range s.adapters {
+		loaded[name] = path
+	}
+	return loaded
+}
+
+func newAdapterPod(name, ip string, ready bool) *corev1.Pod {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    map[string]string{airunwayv1alpha1.LabelModelDeployment: "lora-model"},
+		},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			PodIP:      ip,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+		},
+	}
+}
+
+func TestReconcileAdapters(t *testing.T) {
+	podA := newFakeAdapterServer(t, map[string]string{"old": "/adapters/old"})
+	podB := newFakeAdapterServer(t, map[string]string{})
+	servers := map[string]string{"10.0.0.1": podA.URL, "10.0.0.2": podB.URL}
+	defaultBaseURL := adapterBaseURL
+	adapterBaseURL = func(podIP string, _ int32) string { return servers[podIP] }
+	defer func() { adapterBaseURL = defaultBaseURL }()
+
+	md := newModelDeployment("lora-model", "default")
+	md.Spec.Model.Adapters = []airunwayv1alpha1.LoRAAdapter{
+		{Name: "sql", Source: "/adapters/sql"},
+		{Name: "chat", Source: "org/chat-lora"},
+	}
+	r := newTestReconciler(newTestScheme(), nil, md,
+		newAdapterPod("pod-a", "10.0.0.1", true),
+		newAdapterPod("pod-b", "10.0.0.2", true),
+		newAdapterPod("pod-c", "10.0.0.3", false))
+	ctx := context.Background()
+
+	// The replicas are synced in the background; the result is recorded on the next reconcile
+	if next := r.reconcileAdapters(ctx, md); next != DefaultActivityPollInterval {
+		t.Errorf("expected requeue after %s, got %s", DefaultActivityPollInterval, next)
+	}
+	if md.Status.Adapters != nil {
+		t.Errorf("expected no adapter status before the sync completed, got %+v", md.Status.Adapters)
+	}
+	waitForScrapes(r)
+	if next := r.reconcileAdapters(ctx, md); next != DefaultActivityPollInterval {
+		t.Errorf("expected requeue after %s, got %s", DefaultActivityPollInterval, next)
+	}
+	want := map[string]string{"sql": "/adapters/sql", "chat": "org/chat-lora"}
+	for _, server := range []*fakeAdapterServer{podA, podB} {
+		if got := server.loaded(); len(got) != 2 || got["sql"] != want["sql"] || got["chat"] != want["chat"] {
+			t.Errorf("expected %v loaded, got %v", want, got)
+		}
+	}
+	for _, status := range md.Status.Adapters {
+		if status.LoadedReplicas != 2 {
+			t.Errorf("expected %s on 2 replicas, got %d", status.Name, status.LoadedReplicas)
+		}
+	}
+	cond := meta.FindStatusCondition(md.Status.Conditions, airunwayv1alpha1.ConditionTypeAdaptersLoaded)
+	if cond == nil || cond.Status != metav1.ConditionTrue {
+		t.Errorf("expected AdaptersLoaded True, got %+v", cond)
+	}
+
+	// A changed source is reloaded, and an adapter that fails to load is reported
+	md.Spec.Model.Adapters = []airunwayv1alpha1.LoRAAdapter{
+		{Name: "sql", Source: "/adapters/sql-v2"},
+		{Name: "broken", Source: "/missing"},
+	}
+	md.Generation++
+	r.reconcileAdapters(ctx, md)
+	waitForScrapes(r)
+	r.reconcileAdapters(ctx, md)
+	if got := podA.loaded(); len(got) != 1 || got["sql"] != "/adapters/sql-v2" {
+		t.Errorf("expected only sql-v2 loaded, got %v", got)
+	}
+	if len(md.Status.Adapters) != 2 || md.Status.Adapters[0].LoadedReplicas != 2 || md.Status.Adapters[1].LoadedReplicas != 0 {
+		t.Errorf("expected sql on 2 replicas and broken on none, got %+v", md.Status.Adapters)
+	}
+	cond = meta.FindStatusCondition(md.Status.Conditions, airunwayv1alpha1.ConditionTypeAdaptersLoaded)
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != airunwayv1alpha1.ReasonAdapterLoadFailed {
+		t.Errorf("expected AdaptersLoaded False, got %+v", cond)
+	}
+
+	// Removing the adapters clears the status
+	md.Spec.Model.Adapters = nil
+	if next := r.reconcileAdapters(ctx, md); next != 0 || md.Status.Adapters != nil ||
+		meta.FindStatusCondition(md.Status.Conditions, airunwayv1alpha1.ConditionTypeAdaptersLoaded) != nil {
+		t.Errorf("expected the adapter status to be cleared, got %+v", md.Status.Adapters)
+	}
+}
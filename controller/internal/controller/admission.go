@@ -0,0 +1,362 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/pkg/provider"
+)
+
+// kueueWorkloadGVK is the Kueue Workload kind. It is managed as unstructured since Kueue
+// is optional in the cluster.
+var kueueWorkloadGVK = schema.GroupVersionKind{
+	Group:   "kueue.x-k8s.io",
+	Version: "v1beta1",
+	Kind:    "Workload",
+}
+
+// annotationPodSetsHash records the pod sets a Workload was created with, since Kueue
+// defaults fields of the stored pod sets
+const annotationPodSetsHash = "airunway.ai/pod-sets-hash"
+
+// reconcileAdmission creates a Kueue Workload describing the deployment's GPUs when
+// spec.scheduling.kueueAdmission is set, and holds a deployment that has not been handed
+// off to its provider in the Queued phase until Kueue admits the Workload. It reports
+// whether the deployment is queued. A Workload created for an earlier spec is deleted once
+// kueueAdmission is turned off.
+func (r *ModelDeploymentReconciler) reconcileAdmission(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) (bool, error) {
+	logger := log.FromContext(ctx)
+	workload := &unstructured.Unstructured{}
+	workload.SetGroupVersionKind(kueueWorkloadGVK)
+
+	scheduling := md.Spec.Scheduling
+	if scheduling == nil || !scheduling.KueueAdmission {
+		if md.Status.Admission != nil {
+			workload.SetName(md.Status.Admission.WorkloadName)
+			workload.SetNamespace(md.Namespace)
+			if err := r.deleteWorkload(ctx, md, workload); err != nil {
+				return false, err
+			}
+		}
+		md.Status.Admission = nil
+		meta.RemoveStatusCondition(&md.Status.Conditions, airunwayv1alpha1.ConditionTypeAdmitted)
+		if md.IsQueued() {
+			md.Status.Phase = airunwayv1alpha1.DeploymentPhasePending
+			md.Status.Message = ""
+		}
+		return false, nil
+	}
+
+	// Once the provider has taken over, admission is only reported
+	handedOff := md.Status.Phase != "" && md.Status.Phase != airunwayv1alpha1.DeploymentPhasePending && !md.IsQueued()
+	queue := func(reason, message string) (bool, error) {
+		r.setCondition(md, airunwayv1alpha1.ConditionTypeAdmitted, metav1.ConditionFalse, reason, message)
+		if handedOff {
+			return false, nil
+		}
+		md.Status.Phase = airunwayv1alpha1.DeploymentPhaseQueued
+		md.Status.Message = message
+		return true, nil
+	}
+
+	if _, err := r.Client.RESTMapper().RESTMapping(kueueWorkloadGVK.GroupKind()); err != nil {
+		return queue("KueueNotInstalled", "spec.scheduling.kueueAdmission is set but the Kueue Workload CRD is not installed")
+	}
+
+	podSets, err := workloadPodSets(md)
+	if err != nil {
+		return false, err
+	}
+	hash, err := podSetsHash(podSets)
+	if err != nil {
+		return false, err
+	}
+
+	workload.SetName(md.Name)
+	workload.SetNamespace(md.Namespace)
+	if err := r.Get(ctx, k8stypes.NamespacedName{Name: md.Name, Namespace: md.Namespace}, workload); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return false, fmt.Errorf("failed to get Kueue Workload: %w", err)
+		}
+		if err := r.createWorkload(ctx, md, workload, podSets, hash); err != nil {
+			return false, err
+		}
+		logger.Info("Created Kueue Workload", "name", workload.GetName(), "queue", scheduling.Queue)
+	} else if !metav1.IsControlledBy(workload, md) {
+		return false, fmt.Errorf("kueue Workload %s already exists and is not managed by this ModelDeployment", workload.GetName())
+	}
+	md.Status.Admission = &airunwayv1alpha1.AdmissionStatus{WorkloadName: workload.GetName()}
+
+	conditions, _, _ := unstructured.NestedSlice(workload.Object, "status", "conditions")
+	admitted := findWorkloadCondition(conditions, "Admitted")
+	if admitted != nil && admitted["status"] == string(metav1.ConditionTrue) {
+		clusterQueue, _, _ := unstructured.NestedString(workload.Object, "status", "admission", "clusterQueue")
+		md.Status.Admission.ClusterQueue = clusterQueue
+		md.Status.Admission.Flavors = assignedFlavors(workload)
+		if ts, ok := admitted["lastTransitionTime"].(string); ok {
+			if t, err := time.Parse(time.RFC3339, ts); err == nil {
+				md.Status.Admission.AdmissionTime = &metav1.Time{Time: t}
+			}
+		}
+		r.setCondition(md, airunwayv1alpha1.ConditionTypeAdmitted, metav1.ConditionTrue, "Admitted",
+			fmt.Sprintf("Admitted by ClusterQueue %s", clusterQueue))
+		if md.IsQueued() {
+			md.Status.Phase = airunwayv1alpha1.DeploymentPhasePending
+			md.Status.Message = "Admitted by Kueue"
+		}
+		return false, nil
+	}
+
+	// Kueue does not allow changing the pod sets of a Workload, so a pending Workload that no
+	// longer matches the spec is replaced on the next reconcile
+	if workload.GetAnnotations()[annotationPodSetsHash] != hash && !handedOff {
+		if err := r.deleteWorkload(ctx, md, workload); err != nil {
+			return false, err
+		}
+		return queue("WorkloadUpdated", "Kueue Workload is being recreated for the updated spec")
+	}
+
+	if evicted := findWorkloadCondition(conditions, "Evicted"); evicted != nil && evicted["status"] == string(metav1.ConditionTrue) {
+		message, _ := evicted["message"].(string)
+		return queue("Evicted", fmt.Sprintf("Kueue evicted the Workload: %s", message))
+	}
+	message := fmt.Sprintf("Waiting for Kueue to admit the Workload in LocalQueue %s", scheduling.Queue)
+	if reserved := findWorkloadCondition(conditions, "QuotaReserved"); reserved != nil {
+		if msg, _ := reserved["message"].(string); msg != "" {
+			message = fmt.Sprintf("%s: %s", message, msg)
+		}
+	}
+	return queue("Pending", message)
+}
+
+// createWorkload creates the Kueue Workload for the deployment in its LocalQueue
+func (r *ModelDeploymentReconciler) createWorkload(ctx context.Context, md *airunwayv1alpha1.ModelDeployment, workload *unstructured.Unstructured, podSets []interface{}, hash string) error {
+	workload.SetLabels(map[string]string{
+		airunwayv1alpha1.LabelManagedBy:       airunwayv1alpha1.ManagedByAIRunway,
+		airunwayv1alpha1.LabelModelDeployment: md.Name,
+	})
+	workload.SetAnnotations(map[string]string{annotationPodSetsHash: hash})
+	if err := unstructured.SetNestedField(workload.Object, map[string]interface{}{
+		"queueName": md.Spec.Scheduling.Queue,
+		"podSets":   podSets,
+	}, "spec"); err != nil {
+		return err
+	}
+	provider.ApplyPropagatedMetadataToObject(workload, md)
+	if err := ctrl.SetControllerReference(md, workload, r.Scheme); err != nil {
+		return err
+	}
+	if err := r.Create(ctx, workload); err != nil {
+		return fmt.Errorf("failed to create Kueue Workload: %w", err)
+	}
+	return nil
+}
+
+// deleteWorkload deletes a Kueue Workload owned by the deployment
+func (r *ModelDeploymentReconciler) deleteWorkload(ctx context.Context, md *airunwayv1alpha1.ModelDeployment, workload *unstructured.Unstructured) error {
+	if _, err := r.Client.RESTMapper().RESTMapping(kueueWorkloadGVK.GroupKind()); err != nil {
+		return nil
+	}
+	if err := r.Get(ctx, k8stypes.NamespacedName{Name: workload.GetName(), Namespace: workload.GetNamespace()}, workload); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !metav1.IsControlledBy(workload, md) {
+		return nil
+	}
+	log.FromContext(ctx).Info("Deleting Kueue Workload", "name", workload.GetName())
+	if err := r.Delete(ctx, workload); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete Kueue Workload: %w", err)
+	}
+	return nil
+}
+
+// workloadPodSets describes the deployment's model server pods as Kueue pod sets: one
+// "main" set for aggregated serving, or "prefill" and "decode" sets for disaggregated
+// serving. Each pod requests its GPUs, CPU, and memory, and carries the deployment's node
+// selector and tolerations, merged with the component's scheduling constraints, so Kueue
+// can pick a matching ResourceFlavor.
+func workloadPodSets(md *airunwayv1alpha1.ModelDeployment) ([]interface{}, error) {
+	type podSet struct {
+		name   string
+		count  int32
+		gpu    *airunwayv1alpha1.GPUSpec
+		cpu    string
+		memory string
+		// requests are the explicit cpu and memory requests, which win over cpu and memory
+		requests *airunwayv1alpha1.ResourceQuantities
+		// scheduling are the component's own scheduling constraints
+		scheduling *airunwayv1alpha1.ComponentSchedulingSpec
+	}
+
+	var sets []podSet
+	scaling := md.Spec.Scaling
+	if md.Spec.Serving != nil && md.Spec.Serving.Mode == airunwayv1alpha1.ServingModeDisaggregated {
+		if scaling != nil && scaling.Prefill != nil {
+			sets = append(sets, podSet{name: "prefill", count: scaling.Prefill.Replicas, gpu: scaling.Prefill.GPU, memory: scaling.Prefill.Memory,
+				requests: scaling.Prefill.Requests, scheduling: scaling.Prefill.Scheduling})
+		}
+		if scaling != nil && scaling.Decode != nil {
+			sets = append(sets, podSet{name: "decode", count: scaling.Decode.Replicas, gpu: scaling.Decode.GPU, memory: scaling.Decode.Memory,
+				requests: scaling.Decode.Requests, scheduling: scaling.Decode.Scheduling})
+		}
+	} else {
+		main := podSet{name: "main", count: 1}
+		if scaling != nil {
+			main.count = scaling.Replicas
+		}
+		if res := md.Spec.Resources; res != nil {
+			main.gpu, main.cpu, main.memory, main.requests = res.GPU, res.CPU, res.Memory, res.Requests
+		}
+		sets = append(sets, main)
+	}
+
+	podSets := make([]interface{}, 0, len(sets))
+	for _, set := range sets {
+		if set.requests != nil {
+			if set.requests.CPU != "" {
+				set.cpu = set.requests.CPU
+			}
+			if set.requests.Memory != "" {
+				set.memory = set.requests.Memory
+			}
+		}
+		requests := corev1.ResourceList{}
+		if set.gpu != nil && set.gpu.Count > 0 {
+			gpuType := set.gpu.Type
+			if gpuType == "" {
+				gpuType = airunwayv1alpha1.DefaultGPUType
+			}
+			requests[corev1.ResourceName(gpuType)] = *resource.NewQuantity(int64(set.gpu.Count), resource.DecimalSI)
+		}
+		for name, value := range map[corev1.ResourceName]string{corev1.ResourceCPU: set.cpu, corev1.ResourceMemory: set.memory} {
+			if value == "" {
+				continue
+			}
+			quantity, err := resource.ParseQuantity(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s request %q for pod set %s: %w", name, value, set.name, err)
+			}
+			requests[name] = quantity
+		}
+
+		template := corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{
+				NodeSelector: md.ComponentNodeSelector(set.scheduling),
+				Tolerations:  md.ComponentTolerations(set.scheduling),
+				Containers: []corev1.Container{{
+					Name:      "model",
+					Resources: corev1.ResourceRequirements{Requests: requests},
+				}},
+			},
+		}
+		// Kueue matches ResourceFlavors against node affinity, so the component's is carried
+		if set.scheduling != nil && set.scheduling.Affinity != nil && set.scheduling.Affinity.NodeAffinity != nil {
+			template.Spec.Affinity = &corev1.Affinity{NodeAffinity: set.scheduling.Affinity.NodeAffinity}
+		}
+		obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&template)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert pod template for pod set %s: %w", set.name, err)
+		}
+		delete(obj, "metadata")
+		podSets = append(podSets, map[string]interface{}{
+			"name":     set.name,
+			"count":    int64(set.count),
+			"template": obj,
+		})
+	}
+	return podSets, nil
+}
+
+// podSetsHash returns a short hash of the Workload pod sets
+func podSetsHash(podSets []interface{}) (string, error) {
+	data, err := json.Marshal(podSets)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash Kueue pod sets: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8]), nil
+}
+
+// assignedFlavors returns the ResourceFlavor Kueue assigned to each pod set. The flavor of
+// an extended resource (the GPUs) is preferred over the CPU and memory flavors.
+func assignedFlavors(workload *unstructured.Unstructured) map[string]string {
+	assignments, _, _ := unstructured.NestedSlice(workload.Object, "status", "admission", "podSetAssignments")
+	flavors := map[string]string{}
+	for _, a := range assignments {
+		assignment, ok := a.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := assignment["name"].(string)
+		byResource, _ := assignment["flavors"].(map[string]interface{})
+		resources := make([]string, 0, len(byResource))
+		for res := range byResource {
+			resources = append(resources, res)
+		}
+		sort.Slice(resources, func(i, j int) bool {
+			iGPU, jGPU := isExtendedResource(resources[i]), isExtendedResource(resources[j])
+			if iGPU != jGPU {
+				return iGPU
+			}
+			return resources[i] < resources[j]
+		})
+		if len(resources) > 0 {
+			flavors[name], _ = byResource[resources[0]].(string)
+		}
+	}
+	if len(flavors) == 0 {
+		return nil
+	}
+	return flavors
+}
+
+// isExtendedResource reports whether a resource name is a device plugin resource such as nvidia.com/gpu
+func isExtendedResource(name string) bool {
+	return strings.Contains(name, "/")
+}
+
+// findWorkloadCondition returns the Workload status condition of the given type, or nil
+func findWorkloadCondition(conditions []interface{}, conditionType string) map[string]interface{} {
+	for _, c := range conditions {
+		if cond, ok := c.(map[string]interface{}); ok && cond["type"] == conditionType {
+			return cond
+		}
+	}
+	return nil
+}
@@ -0,0 +1,271 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/meta/testrestmapper"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+func newAdmissionTestReconciler(md *airunwayv1alpha1.ModelDeployment) *ModelDeploymentReconciler {
+	scheme := newTestScheme()
+	workloadMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{kueueWorkloadGVK.GroupVersion()})
+	workloadMapper.Add(kueueWorkloadGVK, meta.RESTScopeNamespace)
+	return &ModelDeploymentReconciler{
+		Client: fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithRESTMapper(meta.MultiRESTMapper{workloadMapper, testrestmapper.TestOnlyStaticRESTMapper(scheme)}).
+			WithObjects(md).
+			Build(),
+		Scheme: scheme,
+	}
+}
+
+func newQueuedModelDeployment() *airunwayv1alpha1.ModelDeployment {
+	md := newModelDeployment("llama", "team-a")
+	md.Status = airunwayv1alpha1.ModelDeploymentStatus{Phase: airunwayv1alpha1.DeploymentPhasePending}
+	md.Spec.Resources = &airunwayv1alpha1.ResourceSpec{
+		GPU:    &airunwayv1alpha1.GPUSpec{Count: 2},
+		Memory: "64Gi",
+	}
+	md.Spec.Scaling = &airunwayv1alpha1.ScalingSpec{Replicas: 3}
+	md.Spec.NodeSelector = map[string]string{"pool": "gpu"}
+	md.Spec.Scheduling = &airunwayv1alpha1.SchedulingSpec{KueueAdmission: true, Queue: "team-a"}
+	return md
+}
+
+func TestReconcileAdmission(t *testing.T) {
+	md := newQueuedModelDeployment()
+	r := newAdmissionTestReconciler(md)
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "llama", Namespace: "team-a"}
+
+	queued, err := r.reconcileAdmission(ctx, md)
+	if err != nil {
+		t.Fatalf("reconcileAdmission failed: %v", err)
+	}
+	if !queued || md.Status.Phase != airunwayv1alpha1.DeploymentPhaseQueued {
+		t.Fatalf("expected deployment to be queued, got queued=%v phase=%s", queued, md.Status.Phase)
+	}
+	cond := meta.FindStatusCondition(md.Status.Conditions, airunwayv1alpha1.ConditionTypeAdmitted)
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != "Pending" {
+		t.Errorf("expected Admitted=False/Pending, got %+v", cond)
+	}
+
+	workload := &unstructured.Unstructured{}
+	workload.SetGroupVersionKind(kueueWorkloadGVK)
+	if err := r.Get(ctx, key, workload); err != nil {
+		t.Fatalf("expected Workload to be created: %v", err)
+	}
+	if !metav1.IsControlledBy(workload, md) {
+		t.Error("expected Workload to be owned by the ModelDeployment")
+	}
+	queueName, _, _ := unstructured.NestedString(workload.Object, "spec", "queueName")
+	if queueName != "team-a" {
+		t.Errorf("expected queueName team-a, got %q", queueName)
+	}
+	podSets, _, _ := unstructured.NestedSlice(workload.Object, "spec", "podSets")
+	if len(podSets) != 1 {
+		t.Fatalf("expected 1 pod set, got %d", len(podSets))
+	}
+	podSet := podSets[0].(map[string]interface{})
+	if count, _, _ := unstructured.NestedInt64(podSet, "count"); count != 3 {
+		t.Errorf("expected pod set count 3, got %d", count)
+	}
+	containers, _, _ := unstructured.NestedSlice(podSet, "template", "spec", "containers")
+	gpus, _, _ := unstructured.NestedString(containers[0].(map[string]interface{}), "resources", "requests", "nvidia.com/gpu")
+	if gpus != "2" {
+		t.Errorf("expected 2 GPUs per pod, got %q", gpus)
+	}
+	if pool, _, _ := unstructured.NestedString(podSet, "template", "spec", "nodeSelector", "pool"); pool != "gpu" {
+		t.Errorf("expected nodeSelector to be carried to the pod set, got %q", pool)
+	}
+
+	// Kueue admits the Workload
+	if err := unstructured.SetNestedField(workload.Object, map[string]interface{}{
+		"conditions": []interface{}{
+			map[string]interface{}{"type": "Admitted", "status": "True", "lastTransitionTime": "2026-01-02T03:04:05Z"},
+		},
+		"admission": map[string]interface{}{
+			"clusterQueue": "gpus",
+			"podSetAssignments": []interface{}{
+				map[string]interface{}{
+					"name":    "main",
+					"flavors": map[string]interface{}{"memory": "default", "nvidia.com/gpu": "h100"},
+				},
+			},
+		},
+	}, "status"); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Update(ctx, workload); err != nil {
+		t.Fatalf("failed to update Workload: %v", err)
+	}
+
+	queued, err = r.reconcileAdmission(ctx, md)
+	if err != nil {
+		t.Fatalf("reconcileAdmission failed: %v", err)
+	}
+	if queued || md.Status.Phase != airunwayv1alpha1.DeploymentPhasePending {
+		t.Fatalf("expected admitted deployment to be handed off, got queued=%v phase=%s", queued, md.Status.Phase)
+	}
+	if !meta.IsStatusConditionTrue(md.Status.Conditions, airunwayv1alpha1.ConditionTypeAdmitted) {
+		t.Error("expected Admitted=True")
+	}
+	if md.Status.Admission == nil || md.Status.Admission.ClusterQueue != "gpus" || md.Status.Admission.Flavors["main"] != "h100" {
+		t.Errorf("unexpected admission status: %+v", md.Status.Admission)
+	}
+	if md.Status.Admission.AdmissionTime == nil {
+		t.Error("expected admissionTime to be set")
+	}
+
+	// Turning admission off deletes the Workload
+	md.Spec.Scheduling.KueueAdmission = false
+	if _, err := r.reconcileAdmission(ctx, md); err != nil {
+		t.Fatalf("reconcileAdmission failed: %v", err)
+	}
+	if err := r.Get(ctx, key, workload); !apierrors.IsNotFound(err) {
+		t.Errorf("expected Workload to be deleted, got %v", err)
+	}
+	if md.Status.Admission != nil || meta.FindStatusCondition(md.Status.Conditions, airunwayv1alpha1.ConditionTypeAdmitted) != nil {
+		t.Error("expected admission status and condition to be cleared")
+	}
+}
+
+func TestReconcileAdmission_SpecChangeRecreatesWorkload(t *testing.T) {
+	md := newQueuedModelDeployment()
+	r := newAdmissionTestReconciler(md)
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "llama", Namespace: "team-a"}
+
+	if _, err := r.reconcileAdmission(ctx, md); err != nil {
+		t.Fatalf("reconcileAdmission failed: %v", err)
+	}
+
+	md.Spec.Scaling.Replicas = 4
+	queued, err := r.reconcileAdmission(ctx, md)
+	if err != nil {
+		t.Fatalf("reconcileAdmission failed: %v", err)
+	}
+	if !queued {
+		t.Error("expected deployment to stay queued")
+	}
+	workload := &unstructured.Unstructured{}
+	workload.SetGroupVersionKind(kueueWorkloadGVK)
+	if err := r.Get(ctx, key, workload); !apierrors.IsNotFound(err) {
+		t.Fatalf("expected stale Workload to be deleted, got %v", err)
+	}
+
+	if _, err := r.reconcileAdmission(ctx, md); err != nil {
+		t.Fatalf("reconcileAdmission failed: %v", err)
+	}
+	if err := r.Get(ctx, key, workload); err != nil {
+		t.Fatalf("expected Workload to be recreated: %v", err)
+	}
+	podSets, _, _ := unstructured.NestedSlice(workload.Object, "spec", "podSets")
+	if count, _, _ := unstructured.NestedInt64(podSets[0].(map[string]interface{}), "count"); count != 4 {
+		t.Errorf("expected recreated pod set count 4, got %d", count)
+	}
+}
+
+func TestReconcileAdmission_HandedOff(t *testing.T) {
+	md := newQueuedModelDeployment()
+	md.Status.Phase = airunwayv1alpha1.DeploymentPhaseRunning
+	r := newAdmissionTestReconciler(md)
+
+	// Admission turned on for a running deployment is reported, not enforced
+	queued, err := r.reconcileAdmission(context.Background(), md)
+	if err != nil {
+		t.Fatalf("reconcileAdmission failed: %v", err)
+	}
+	if queued || md.Status.Phase != airunwayv1alpha1.DeploymentPhaseRunning {
+		t.Errorf("expected running deployment to keep running, got queued=%v phase=%s", queued, md.Status.Phase)
+	}
+	if meta.IsStatusConditionTrue(md.Status.Conditions, airunwayv1alpha1.ConditionTypeAdmitted) {
+		t.Error("expected Admitted to be false until Kueue admits the Workload")
+	}
+}
+
+func TestReconcileAdmission_KueueNotInstalled(t *testing.T) {
+	md := newQueuedModelDeployment()
+	r := newTestReconciler(newTestScheme(), nil, md)
+
+	queued, err := r.reconcileAdmission(context.Background(), md)
+	if err != nil {
+		t.Fatalf("reconcileAdmission failed: %v", err)
+	}
+	if !queued {
+		t.Error("expected deployment to stay queued without Kueue")
+	}
+	cond := meta.FindStatusCondition(md.Status.Conditions, airunwayv1alpha1.ConditionTypeAdmitted)
+	if cond == nil || cond.Reason != "KueueNotInstalled" {
+		t.Errorf("expected Admitted=False/KueueNotInstalled, got %+v", cond)
+	}
+}
+
+func TestWorkloadPodSets_ExplicitRequests(t *testing.T) {
+	md := newQueuedModelDeployment()
+	md.Spec.Resources.CPU = "8"
+	md.Spec.Resources.Requests = &airunwayv1alpha1.ResourceQuantities{Memory: "48Gi"}
+
+	podSets, err := workloadPodSets(md)
+	if err != nil {
+		t.Fatalf("workloadPodSets failed: %v", err)
+	}
+	containers, _, _ := unstructured.NestedSlice(podSets[0].(map[string]interface{}), "template", "spec", "containers")
+	requests, _, _ := unstructured.NestedStringMap(containers[0].(map[string]interface{}), "resources", "requests")
+	if requests["memory"] != "48Gi" || requests["cpu"] != "8" {
+		t.Errorf("expected the explicit memory request and the cpu shorthand, got %v", requests)
+	}
+}
+
+func TestWorkloadPodSets_ComponentScheduling(t *testing.T) {
+	md := newQueuedModelDeployment()
+	md.Spec.Serving = &airunwayv1alpha1.ServingSpec{Mode: airunwayv1alpha1.ServingModeDisaggregated}
+	md.Spec.Scaling = &airunwayv1alpha1.ScalingSpec{
+		Prefill: &airunwayv1alpha1.ComponentScalingSpec{
+			Replicas:   1,
+			Scheduling: &airunwayv1alpha1.ComponentSchedulingSpec{NodeSelector: map[string]string{"pool": "h100"}},
+		},
+		Decode: &airunwayv1alpha1.ComponentScalingSpec{Replicas: 1},
+	}
+
+	podSets, err := workloadPodSets(md)
+	if err != nil {
+		t.Fatalf("workloadPodSets failed: %v", err)
+	}
+	pools := map[string]string{}
+	for _, ps := range podSets {
+		podSet := ps.(map[string]interface{})
+		name, _, _ := unstructured.NestedString(podSet, "name")
+		pools[name], _, _ = unstructured.NestedString(podSet, "template", "spec", "nodeSelector", "pool")
+	}
+	if pools["prefill"] != "h100" || pools["decode"] != "gpu" {
+		t.Errorf("expected the prefill node selector to override the deployment's, got %v", pools)
+	}
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlbuilder "sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/internal/dashboard"
+)
+
+const (
+	// DashboardConfigMapName is the name of the ConfigMaps holding the Grafana dashboards
+	// of the ModelDeployments of a namespace, and of the controller in its own namespace
+	DashboardConfigMapName = "airunway-grafana-dashboards"
+
+	// LabelGrafanaDashboard marks ConfigMaps for the Grafana dashboard sidecar, which
+	// loads every ConfigMap with it by default
+	LabelGrafanaDashboard = "grafana_dashboard"
+
+	// DefaultDashboardInterval is how often dashboards are rendered again, so panels
+	// appear once the controller reports a metric family for the first time
+	DefaultDashboardInterval = 5 * time.Minute
+)
+
+// DashboardReconciler keeps Grafana dashboards of the metrics of the controller and of
+// ModelDeployments in ConfigMaps, for the Grafana dashboard sidecar to load. Panels are
+// rendered from the metric families the controller reports, so they follow its metric
+// names. Each namespace with ModelDeployments gets a dashboard of them, which is deleted
+// with its last ModelDeployment.
+type DashboardReconciler struct {
+	client.Client
+	// Gatherer is the registry the controller metrics are gathered from
+	Gatherer prometheus.Gatherer
+	// Namespace is the namespace of the controller, which holds the controller dashboard.
+	// If empty, only ModelDeployment dashboards are kept.
+	Namespace string
+	// Interval is how often dashboards are rendered again. Zero uses
+	// DefaultDashboardInterval.
+	Interval time.Duration
+}
+
+// +kubebuilder:rbac:groups=airunway.ai,resources=modeldeployments,verbs=get;list;watch
+
+// Reconcile renders the ModelDeployment dashboard of the namespace of req, or the
+// controller dashboard when req names the dashboard ConfigMap.
+func (r *DashboardReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	interval := r.Interval
+	if interval <= 0 {
+		interval = DefaultDashboardInterval
+	}
+	families, err := r.Gatherer.Gather()
+	if err != nil {
+		// Gather returns the families it could collect along with the error
+		log.FromContext(ctx).Info("Could not gather every controller metric", "error", err.Error())
+	}
+
+	if req.Name == DashboardConfigMapName {
+		return ctrl.Result{RequeueAfter: interval}, r.applyDashboard(ctx, req.Namespace, "airunway-controller.json", dashboard.Controller(families))
+	}
+
+	var deployments airunwayv1alpha1.ModelDeploymentList
+	if err := r.List(ctx, &deployments, client.InNamespace(req.Namespace)); err != nil {
+		return ctrl.Result{}, err
+	}
+	key := "airunway-" + req.Namespace + ".json"
+	if len(deployments.Items) == 0 {
+		return ctrl.Result{}, r.removeDashboard(ctx, req.Namespace, key)
+	}
+	d := dashboard.ModelDeployments(req.Namespace, deployments.Items, families)
+	return ctrl.Result{RequeueAfter: interval}, r.applyDashboard(ctx, req.Namespace, key, d)
+}
+
+// applyDashboard writes d to key of the dashboard ConfigMap of namespace, leaving its
+// other keys alone
+func (r *DashboardReconciler) applyDashboard(ctx context.Context, namespace, key string, d *dashboard.Dashboard) error {
+	data, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return err
+	}
+	labels := map[string]string{
+		LabelGrafanaDashboard:           "1",
+		airunwayv1alpha1.LabelManagedBy: airunwayv1alpha1.ManagedByAIRunway,
+	}
+
+	var cm corev1.ConfigMap
+	err = r.Get(ctx, k8stypes.NamespacedName{Name: DashboardConfigMapName, Namespace: namespace}, &cm)
+	if apierrors.IsNotFound(err) {
+		cm = corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: DashboardConfigMapName, Namespace: namespace, Labels: labels},
+			Data:       map[string]string{key: string(data)},
+		}
+		log.FromContext(ctx).Info("Creating Grafana dashboard ConfigMap", "namespace", namespace)
+		return client.IgnoreAlreadyExists(r.Create(ctx, &cm))
+	}
+	if err != nil {
+		return err
+	}
+	if cm.Data[key] == string(data) && cm.Labels[LabelGrafanaDashboard] == "1" {
+		return nil
+	}
+	if cm.Labels == nil {
+		cm.Labels = map[string]string{}
+	}
+	maps.Copy(cm.Labels, labels)
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[key] = string(data)
+	log.FromContext(ctx).V(1).Info("Updating Grafana dashboard ConfigMap", "namespace", namespace)
+	return r.Update(ctx, &cm)
+}
+
+// removeDashboard removes key from the dashboard ConfigMap of namespace, deleting the
+// ConfigMap once it holds no other dashboard
+func (r *DashboardReconciler) removeDashboard(ctx context.Context, namespace, key string) error {
+	var cm corev1.ConfigMap
+	if err := r.Get(ctx, k8stypes.NamespacedName{Name: DashboardConfigMapName, Namespace: namespace}, &cm); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if _, ok := cm.Data[key]; !ok || cm.Labels[airunwayv1alpha1.LabelManagedBy] != airunwayv1alpha1.ManagedByAIRunway {
+		return nil
+	}
+	if len(cm.Data) == 1 {
+		log.FromContext(ctx).Info("Deleting Grafana dashboard ConfigMap", "namespace", namespace)
+		return client.IgnoreNotFound(r.Delete(ctx, &cm))
+	}
+	delete(cm.Data, key)
+	return r.Update(ctx, &cm)
+}
+
+// SetupWithManager sets up the controller with the Manager. ModelDeployments are mapped
+// to their namespace, and only their creation, deletion and engine changes affect the
+// dashboard between periodic renders.
+func (r *DashboardReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	builder := ctrl.NewControllerManagedBy(mgr).
+		Watches(&airunwayv1alpha1.ModelDeployment{},
+			handler.EnqueueRequestsFromMapFunc(func(_ context.Context, obj client.Object) []reconcile.Request {
+				return []reconcile.Request{{NamespacedName: k8stypes.NamespacedName{Namespace: obj.GetNamespace()}}}
+			}),
+			ctrlbuilder.WithPredicates(predicate.Funcs{
+				UpdateFunc: func(e event.UpdateEvent) bool {
+					oldMD, okOld := e.ObjectOld.(*airunwayv1alpha1.ModelDeployment)
+					newMD, okNew := e.ObjectNew.(*airunwayv1alpha1.ModelDeployment)
+					return okOld && okNew && oldMD.ResolvedEngineType() != newMD.ResolvedEngineType()
+				},
+			})).
+		Named("grafana-dashboard")
+	if r.Namespace != "" {
+		controllerDashboard := reconcile.Request{NamespacedName: k8stypes.NamespacedName{Name: DashboardConfigMapName, Namespace: r.Namespace}}
+		builder = builder.WatchesRawSource(source.Func(func(_ context.Context, q workqueue.TypedRateLimitingInterface[reconcile.Request]) error {
+			q.Add(controllerDashboard)
+			return nil
+		}))
+	}
+	return builder.Complete(r)
+}
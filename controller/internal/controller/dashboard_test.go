@@ -0,0 +1,93 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/internal/dashboard"
+)
+
+func TestDashboardReconcile(t *testing.T) {
+	ctx := context.Background()
+	md := &airunwayv1alpha1.ModelDeployment{ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "team-a"}}
+	md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeVLLM
+	c := fake.NewClientBuilder().WithScheme(newTestScheme()).WithObjects(md).Build()
+
+	reg := prometheus.NewRegistry()
+	probe := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "kubeairunway_gateway_probe_success", Help: "Probe result."}, []string{"namespace", "name"})
+	reg.MustRegister(probe)
+	probe.WithLabelValues("team-a", "llama").Set(1)
+	r := &DashboardReconciler{Client: c, Gatherer: reg, Namespace: "airunway-system"}
+
+	getDashboard := func(namespace, key string) *dashboard.Dashboard {
+		t.Helper()
+		var cm corev1.ConfigMap
+		if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: DashboardConfigMapName}, &cm); err != nil {
+			t.Fatalf("failed to get the dashboard ConfigMap: %v", err)
+		}
+		if cm.Labels[LabelGrafanaDashboard] != "1" {
+			t.Errorf("expected the %s label, got %v", LabelGrafanaDashboard, cm.Labels)
+		}
+		var d dashboard.Dashboard
+		if err := json.Unmarshal([]byte(cm.Data[key]), &d); err != nil {
+			t.Fatalf("invalid dashboard %s: %v", key, err)
+		}
+		return &d
+	}
+
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKey{Namespace: "team-a"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.RequeueAfter != DefaultDashboardInterval {
+		t.Errorf("expected a render every %v, got %v", DefaultDashboardInterval, result.RequeueAfter)
+	}
+	if d := getDashboard("team-a", "airunway-team-a.json"); d.Title != "AI Runway / team-a" || len(d.Panels) == 0 {
+		t.Errorf("unexpected ModelDeployment dashboard %q with %d panels", d.Title, len(d.Panels))
+	}
+
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKey{Namespace: "airunway-system", Name: DashboardConfigMapName}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d := getDashboard("airunway-system", "airunway-controller.json"); len(d.Panels) != 1 || d.Panels[0].Title != "Gateway probe success" {
+		t.Errorf("expected a panel of the gathered family, got %+v", d.Panels)
+	}
+
+	// The dashboard goes with the last ModelDeployment of the namespace
+	if err := c.Delete(ctx, md); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKey{Namespace: "team-a"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = c.Get(ctx, client.ObjectKey{Namespace: "team-a", Name: DashboardConfigMapName}, &corev1.ConfigMap{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("expected the dashboard ConfigMap to be deleted, got %v", err)
+	}
+}
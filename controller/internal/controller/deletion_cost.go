@@ -0,0 +1,105 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/internal/activity"
+)
+
+// maxDeletionCostRequests caps the in-flight requests counted in a pod deletion cost
+const maxDeletionCostRequests = 1_000_000
+
+// reconcilePodDeletionCost sets the pod-deletion-cost annotation of the pods of a Running
+// deployment with spec.scaling.podDeletionCost, from the samples of the same engine metrics
+// the EPP picks endpoints by taken by the background scraper. Pods that cannot be sampled
+// keep their cost. It returns how long
+// to wait before sampling again, or zero.
+func (r *ModelDeploymentReconciler) reconcilePodDeletionCost(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) time.Duration {
+	if md.Spec.Scaling == nil || !md.Spec.Scaling.PodDeletionCost || md.Status.Phase != airunwayv1alpha1.DeploymentPhaseRunning {
+		return 0
+	}
+	logger := log.FromContext(ctx)
+	interval := r.settings().ActivityPollInterval
+
+	result, ok := r.samplePods(ctx, md)
+	if !ok {
+		return interval
+	}
+	if result.err != nil {
+		logger.Info("Could not sample pod metrics for deletion costs", "name", md.Name, "error", result.err.Error())
+		return interval
+	}
+	samples := result.value.(map[string]activity.Sample)
+	pods, err := modelPods(ctx, r.Client, md)
+	if err != nil {
+		logger.Info("Could not list pods for deletion costs", "name", md.Name, "error", err.Error())
+		return interval
+	}
+
+	for i := range pods {
+		pod := &pods[i]
+		sample, ok := samples[pod.Name]
+		if !ok {
+			continue
+		}
+		cost := strconv.Itoa(podDeletionCost(sample))
+		if pod.Annotations[corev1.PodDeletionCost] == cost {
+			continue
+		}
+		patch := client.MergeFrom(pod.DeepCopy())
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+		pod.Annotations[corev1.PodDeletionCost] = cost
+		if err := r.Patch(ctx, pod, patch); err != nil {
+			logger.V(1).Info("Could not set the pod deletion cost", "pod", pod.Name, "error", err.Error())
+		}
+	}
+	return interval
+}
+
+// samplePods returns the last per-pod engine metrics samples of a deployment taken by the
+// background scraper, sampled once per ActivityPollInterval
+func (r *ModelDeploymentReconciler) samplePods(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) (scrapeResult, bool) {
+	source := r.PodActivitySource
+	if source == nil {
+		source = &podMetricsActivitySource{Reader: r.Client}
+	}
+	target := md.DeepCopy()
+	return r.scrape(ctx, md, scrapePods, "", r.settings().ActivityPollInterval, func(ctx context.Context) (interface{}, error) {
+		return source.SamplePods(ctx, target)
+	})
+}
+
+// podDeletionCost ranks a pod for scale-down: 100 per in-flight request plus its KV cache
+// usage in percent, rounded to tens so small fluctuations do not update the pod. The pod
+// with the lowest cost is deleted first.
+func podDeletionCost(sample activity.Sample) int {
+	running := int(math.Min(math.Max(sample.Running, 0), maxDeletionCostRequests))
+	warmth := int(math.Round(math.Min(math.Max(sample.KVCacheUsage, 0), 1)*10)) * 10
+	return running*100 + warmth
+}
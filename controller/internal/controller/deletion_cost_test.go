@@ -0,0 +1,107 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/internal/activity"
+)
+
+// fakePodActivitySource returns fixed samples by pod name
+type fakePodActivitySource struct {
+	samples map[string]activity.Sample
+}
+
+func (s *fakePodActivitySource) SamplePods(context.Context, *airunwayv1alpha1.ModelDeployment) (map[string]activity.Sample, error) {
+	return s.samples, nil
+}
+
+func newModelPod(name, mdName string, annotations map[string]string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   "default",
+			Labels:      map[string]string{airunwayv1alpha1.LabelModelDeployment: mdName},
+			Annotations: annotations,
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning, PodIP: "10.0.0.1"},
+	}
+}
+
+func TestPodDeletionCost(t *testing.T) {
+	tests := []struct {
+		name   string
+		sample activity.Sample
+		want   int
+	}{
+		{name: "idle and cold", want: 0},
+		{name: "warm cache", sample: activity.Sample{KVCacheUsage: 0.43}, want: 40},
+		{name: "busy", sample: activity.Sample{Running: 3, KVCacheUsage: 0.96}, want: 400},
+		{name: "summed usage is capped", sample: activity.Sample{KVCacheUsage: 2.5}, want: 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := podDeletionCost(tt.sample); got != tt.want {
+				t.Errorf("expected %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestReconcilePodDeletionCost(t *testing.T) {
+	ctx := context.Background()
+	md := newModelDeployment("test-model", "default")
+	md.Spec.Scaling = &airunwayv1alpha1.ScalingSpec{Replicas: 3, PodDeletionCost: true}
+	r := newTestReconciler(newTestScheme(), nil,
+		newModelPod("busy", md.Name, nil),
+		newModelPod("cold", md.Name, map[string]string{corev1.PodDeletionCost: "500"}),
+		newModelPod("unsampled", md.Name, map[string]string{corev1.PodDeletionCost: "200"}),
+	)
+	r.PodActivitySource = &fakePodActivitySource{samples: map[string]activity.Sample{
+		"busy": {Running: 2, KVCacheUsage: 0.8},
+		"cold": {KVCacheUsage: 0.05},
+	}}
+
+	// The pods are sampled in the background; the costs are set on the next reconcile
+	r.reconcilePodDeletionCost(ctx, md)
+	waitForScrapes(r)
+	if next := r.reconcilePodDeletionCost(ctx, md); next != DefaultActivityPollInterval {
+		t.Errorf("expected to sample again after %s, got %s", DefaultActivityPollInterval, next)
+	}
+	for name, want := range map[string]string{"busy": "280", "cold": "10", "unsampled": "200"} {
+		var pod corev1.Pod
+		if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: "default"}, &pod); err != nil {
+			t.Fatal(err)
+		}
+		if got := pod.Annotations[corev1.PodDeletionCost]; got != want {
+			t.Errorf("expected pod %s to cost %s, got %q", name, want, got)
+		}
+	}
+
+	// Deployments without spec.scaling.podDeletionCost are not sampled
+	md.Spec.Scaling.PodDeletionCost = false
+	if next := r.reconcilePodDeletionCost(ctx, md); next != 0 {
+		t.Errorf("expected no sampling, got %s", next)
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+func TestPatchStatus_Health(t *testing.T) {
+	md := newModelDeployment("test-model", "default")
+	md.Generation = 2
+	c := fake.NewClientBuilder().WithScheme(newTestScheme()).WithObjects(md).
+		WithStatusSubresource(&airunwayv1alpha1.ModelDeployment{}).Build()
+	r := &ModelDeploymentReconciler{Client: c}
+	ctx := context.Background()
+
+	patch := func(mutate func(md *airunwayv1alpha1.ModelDeployment)) *airunwayv1alpha1.ModelDeployment {
+		t.Helper()
+		var current airunwayv1alpha1.ModelDeployment
+		if err := c.Get(ctx, client.ObjectKeyFromObject(md), &current); err != nil {
+			t.Fatal(err)
+		}
+		base := current.DeepCopy()
+		mutate(&current)
+		if err := r.patchStatus(ctx, &current, base); err != nil {
+			t.Fatalf("patchStatus failed: %v", err)
+		}
+		return &current
+	}
+	setReady := func(md *airunwayv1alpha1.ModelDeployment, generation int64) {
+		meta.SetStatusCondition(&md.Status.Conditions, metav1.Condition{
+			Type: airunwayv1alpha1.ConditionTypeReady, Status: metav1.ConditionTrue,
+			Reason: "DeploymentReady", ObservedGeneration: generation,
+		})
+	}
+
+	// Before a provider reports, the core controller sets Ready=False and Reconciling
+	got := patch(func(md *airunwayv1alpha1.ModelDeployment) { md.Status.Phase = airunwayv1alpha1.DeploymentPhasePending })
+	if !meta.IsStatusConditionFalse(got.Status.Conditions, airunwayv1alpha1.ConditionTypeReady) ||
+		!meta.IsStatusConditionTrue(got.Status.Conditions, airunwayv1alpha1.ConditionTypeReconciling) {
+		t.Errorf("expected Ready=False and Reconciling, got %+v", got.Status.Conditions)
+	}
+
+	// Running with a Ready condition of an older generation is still reconciling
+	got = patch(func(md *airunwayv1alpha1.ModelDeployment) {
+		md.Status.Phase = airunwayv1alpha1.DeploymentPhaseRunning
+		setReady(md, 1)
+	})
+	cond := meta.FindStatusCondition(got.Status.Conditions, airunwayv1alpha1.ConditionTypeReconciling)
+	if cond == nil || cond.Reason != airunwayv1alpha1.ReasonDeploying {
+		t.Errorf("expected Reconciling/Deploying for a stale Ready condition, got %+v", cond)
+	}
+
+	// Once the provider reports Ready for the current generation, the deployment is current
+	got = patch(func(md *airunwayv1alpha1.ModelDeployment) { setReady(md, 2) })
+	if meta.FindStatusCondition(got.Status.Conditions, airunwayv1alpha1.ConditionTypeReconciling) != nil ||
+		meta.FindStatusCondition(got.Status.Conditions, airunwayv1alpha1.ConditionTypeStalled) != nil {
+		t.Errorf("expected no Reconciling or Stalled condition, got %+v", got.Status.Conditions)
+	}
+	if cond := meta.FindStatusCondition(got.Status.Conditions, airunwayv1alpha1.ConditionTypeReady); cond.Reason != "DeploymentReady" {
+		t.Errorf("expected the Ready condition of the provider to be kept, got %+v", cond)
+	}
+
+	// A failed deployment is stalled and not ready
+	got = patch(func(md *airunwayv1alpha1.ModelDeployment) {
+		md.Status.Phase = airunwayv1alpha1.DeploymentPhaseFailed
+		md.Status.Message = "Validation failed"
+	})
+	cond = meta.FindStatusCondition(got.Status.Conditions, airunwayv1alpha1.ConditionTypeStalled)
+	if cond == nil || cond.Status != metav1.ConditionTrue || cond.Message != "Validation failed" {
+		t.Errorf("expected Stalled, got %+v", cond)
+	}
+	if !meta.IsStatusConditionFalse(got.Status.Conditions, airunwayv1alpha1.ConditionTypeReady) ||
+		meta.FindStatusCondition(got.Status.Conditions, airunwayv1alpha1.ConditionTypeReconciling) != nil {
+		t.Errorf("expected Ready=False without Reconciling, got %+v", got.Status.Conditions)
+	}
+}
+
+func TestPatchStatus_RecordsStepDuration(t *testing.T) {
+	md := newModelDeployment("test-model", "default")
+	c := fake.NewClientBuilder().WithScheme(newTestScheme()).WithObjects(md).
+		WithStatusSubresource(&airunwayv1alpha1.ModelDeployment{}).Build()
+	r := &ModelDeploymentReconciler{Client: c}
+
+	histogram := reconcileStepDuration.WithLabelValues(reconcileStepStatus).(prometheus.Histogram)
+	before := sampleCount(t, histogram)
+	if err := r.patchStatus(context.Background(), md, md.DeepCopy()); err != nil {
+		t.Fatalf("patchStatus failed: %v", err)
+	}
+	if got := sampleCount(t, histogram); got != before+1 {
+		t.Errorf("expected one status step observation, got %d", got-before)
+	}
+}
+
+func sampleCount(t *testing.T, histogram prometheus.Histogram) uint64 {
+	t.Helper()
+	var m dto.Metric
+	if err := histogram.Write(&m); err != nil {
+		t.Fatal(err)
+	}
+	return m.GetHistogram().GetSampleCount()
+}
@@ -0,0 +1,157 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+func newProviderConfig(name string, caps *airunwayv1alpha1.ProviderCapabilities) *airunwayv1alpha1.InferenceProviderConfig {
+	return &airunwayv1alpha1.InferenceProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       airunwayv1alpha1.InferenceProviderConfigSpec{Capabilities: caps},
+		Status:     airunwayv1alpha1.InferenceProviderConfigStatus{Ready: true},
+	}
+}
+
+// cpuTestProviders returns a CPU-only llamacpp provider and a provider that runs vllm on CPU and GPU.
+func cpuTestProviders() []airunwayv1alpha1.InferenceProviderConfig {
+	return []airunwayv1alpha1.InferenceProviderConfig{
+		*newProviderConfig("kaito", &airunwayv1alpha1.ProviderCapabilities{
+			Engines:      []airunwayv1alpha1.EngineType{airunwayv1alpha1.EngineTypeVLLM, airunwayv1alpha1.EngineTypeLlamaCpp},
+			ServingModes: []airunwayv1alpha1.ServingMode{airunwayv1alpha1.ServingModeAggregated},
+			CPUSupport:   true,
+			GPUSupport:   true,
+		}),
+		*newProviderConfig("llmd", &airunwayv1alpha1.ProviderCapabilities{
+			Engines:      []airunwayv1alpha1.EngineType{airunwayv1alpha1.EngineTypeVLLM},
+			ServingModes: []airunwayv1alpha1.ServingMode{airunwayv1alpha1.ServingModeAggregated},
+			CPUSupport:   true,
+			GPUSupport:   true,
+			CPUEngines:   []airunwayv1alpha1.EngineType{airunwayv1alpha1.EngineTypeVLLM},
+		}),
+	}
+}
+
+func newCPUModelDeployment(engine airunwayv1alpha1.EngineType, device airunwayv1alpha1.EngineDevice) *airunwayv1alpha1.ModelDeployment {
+	md := newModelDeployment("test-model", "default")
+	md.Spec.Engine.Type = engine
+	md.Spec.Engine.Device = device
+	md.Spec.Resources = &airunwayv1alpha1.ResourceSpec{CPU: "8", Memory: "32Gi"}
+	return md
+}
+
+func TestResolvedDevice(t *testing.T) {
+	md := newCPUModelDeployment(airunwayv1alpha1.EngineTypeVLLM, "")
+	if got := md.ResolvedDevice(); got != airunwayv1alpha1.EngineDeviceCPU {
+		t.Errorf("expected auto without GPUs to resolve to cpu, got %s", got)
+	}
+	md.Spec.Resources.GPU = &airunwayv1alpha1.GPUSpec{Count: 1}
+	if got := md.ResolvedDevice(); got != airunwayv1alpha1.EngineDeviceGPU {
+		t.Errorf("expected auto with GPUs to resolve to gpu, got %s", got)
+	}
+	md.Spec.Engine.Device = airunwayv1alpha1.EngineDeviceCPU
+	if got := md.ResolvedDevice(); got != airunwayv1alpha1.EngineDeviceCPU {
+		t.Errorf("expected explicit cpu, got %s", got)
+	}
+}
+
+func TestSupportsCPUEngine(t *testing.T) {
+	providers := cpuTestProviders()
+	kaito, llmd := providers[0].Spec.Capabilities, providers[1].Spec.Capabilities
+
+	if kaito.SupportsCPUEngine(airunwayv1alpha1.EngineTypeVLLM) {
+		t.Error("expected vllm to be GPU-only without cpuEngines")
+	}
+	if !kaito.SupportsCPUEngine(airunwayv1alpha1.EngineTypeLlamaCpp) {
+		t.Error("expected llamacpp to run on CPU without cpuEngines")
+	}
+	if !llmd.SupportsCPUEngine(airunwayv1alpha1.EngineTypeVLLM) {
+		t.Error("expected vllm listed in cpuEngines to run on CPU")
+	}
+	if llmd.SupportsCPUEngine(airunwayv1alpha1.EngineTypeLlamaCpp) {
+		t.Error("expected engines missing from cpuEngines to be unsupported")
+	}
+	if (&airunwayv1alpha1.ProviderCapabilities{CPUEngines: []airunwayv1alpha1.EngineType{"vllm"}}).SupportsCPUEngine(airunwayv1alpha1.EngineTypeVLLM) {
+		t.Error("expected no CPU engines without cpuSupport")
+	}
+}
+
+func TestSelectProvider_CPUVLLM(t *testing.T) {
+	r := &ModelDeploymentReconciler{}
+	md := newCPUModelDeployment(airunwayv1alpha1.EngineTypeVLLM, airunwayv1alpha1.EngineDeviceCPU)
+
+	name, reason, err := r.runSelectionAlgorithm(md, cpuTestProviders(), nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name != "llmd" {
+		t.Errorf("expected the provider with a vllm CPU backend, got %q", name)
+	}
+	if !strings.Contains(reason, "gpu=false") {
+		t.Errorf("unexpected reason %q", reason)
+	}
+
+	// Without a provider that runs vllm on CPU, nothing is selected
+	name, _, err = r.runSelectionAlgorithm(md, cpuTestProviders()[:1], nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name != "" {
+		t.Errorf("expected no provider, got %q", name)
+	}
+}
+
+func TestSelectEngine_CPU(t *testing.T) {
+	providers := cpuTestProviders()
+	scheme := newTestScheme()
+
+	// auto keeps GPU-only engines off CPU deployments
+	md := newCPUModelDeployment("", "")
+	r := newTestReconciler(scheme, nil, md, &providers[0], &providers[1])
+	if err := r.selectEngine(context.Background(), md); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if md.Status.Engine == nil || md.Status.Engine.Type != airunwayv1alpha1.EngineTypeLlamaCpp {
+		t.Errorf("expected llamacpp for an auto CPU deployment, got %+v", md.Status.Engine)
+	}
+
+	// explicit cpu allows vllm's CPU backend
+	md = newCPUModelDeployment("", airunwayv1alpha1.EngineDeviceCPU)
+	r = newTestReconciler(scheme, nil, md, &providers[0], &providers[1])
+	if err := r.selectEngine(context.Background(), md); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if md.Status.Engine == nil || md.Status.Engine.Type != airunwayv1alpha1.EngineTypeVLLM {
+		t.Errorf("expected vllm for an explicit cpu deployment, got %+v", md.Status.Engine)
+	}
+}
+
+func TestValidateSpec_CPUDevice(t *testing.T) {
+	r := &ModelDeploymentReconciler{}
+
+	md := newCPUModelDeployment(airunwayv1alpha1.EngineTypeVLLM, airunwayv1alpha1.EngineDeviceCPU)
+	if err := r.validateSpec(context.Background(), md); err != nil {
+		t.Errorf("expected vllm on cpu to be valid, got %v", err)
+	}
+
+	md = newCPUModelDeployment(airunwayv1alpha1.EngineTypeVLLM, "")
+	if err := r.validateSpec(context.Background(), md); err == nil || !strings.Contains(err.Error(), "requires GPU") {
+		t.Errorf("expected vllm without GPUs to require engine.device cpu, got %v", err)
+	}
+
+	md = newCPUModelDeployment(airunwayv1alpha1.EngineTypeSGLang, airunwayv1alpha1.EngineDeviceCPU)
+	if err := r.validateSpec(context.Background(), md); err == nil {
+		t.Error("expected sglang on cpu to be rejected")
+	}
+
+	md = newCPUModelDeployment(airunwayv1alpha1.EngineTypeVLLM, airunwayv1alpha1.EngineDeviceCPU)
+	md.Spec.Resources.GPU = &airunwayv1alpha1.GPUSpec{Count: 1}
+	if err := r.validateSpec(context.Background(), md); err == nil {
+		t.Error("expected cpu device with GPUs to be rejected")
+	}
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/internal/gateway"
+	"github.com/kaito-project/airunway/controller/pkg/provider"
+)
+
+// eppRBACMode returns the configured EPP RBAC mode
+func (r *ModelDeploymentReconciler) eppRBACMode() gateway.EPPRBACMode {
+	if r.GatewayDetector == nil || r.GatewayDetector.EPPRBACMode == "" {
+		return gateway.EPPRBACModeDeployment
+	}
+	return r.GatewayDetector.EPPRBACMode
+}
+
+// reconcileEPPRBAC creates or updates the ServiceAccount, Role, and RoleBinding of the
+// EPP of md, and returns the name of the ServiceAccount the EPP runs as.
+func (r *ModelDeploymentReconciler) reconcileEPPRBAC(ctx context.Context, md *airunwayv1alpha1.ModelDeployment, eppName string) (string, error) {
+	if r.eppRBACMode() == gateway.EPPRBACModeNamespace {
+		if err := r.reconcileSharedEPPRBAC(ctx, md); err != nil {
+			return "", err
+		}
+		// Remove the dedicated RBAC left from the deployment mode
+		r.deleteEPPRBACObjects(ctx, eppName, md.Namespace)
+		return gateway.SharedEPPRBACName, nil
+	}
+
+	objects := eppRBACObjects(eppName, md.Namespace)
+	for _, obj := range objects {
+		if _, err := ctrl.CreateOrUpdate(ctx, r.Client, obj, func() error {
+			setEPPRBACSpec(obj, eppName, md.Namespace)
+			provider.ApplyPropagatedMetadataToObject(obj, md)
+			return ctrl.SetControllerReference(md, obj, r.Scheme)
+		}); err != nil {
+			return "", fmt.Errorf("failed to create/update EPP %s: %w", eppRBACKind(obj), err)
+		}
+	}
+	return eppName, nil
+}
+
+// reconcileSharedEPPRBAC creates or updates the RBAC shared by the EPPs of the namespace of
+// md and adds md to its owners, so it is garbage collected with the last ModelDeployment.
+func (r *ModelDeploymentReconciler) reconcileSharedEPPRBAC(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) error {
+	for _, obj := range eppRBACObjects(gateway.SharedEPPRBACName, md.Namespace) {
+		if _, err := ctrl.CreateOrUpdate(ctx, r.Client, obj, func() error {
+			setEPPRBACSpec(obj, gateway.SharedEPPRBACName, md.Namespace)
+			labels := obj.GetLabels()
+			if labels == nil {
+				labels = map[string]string{}
+			}
+			labels["app.kubernetes.io/managed-by"] = "airunway"
+			obj.SetLabels(labels)
+			return controllerutil.SetOwnerReference(md, obj, r.Scheme)
+		}); err != nil {
+			return fmt.Errorf("failed to create/update shared EPP %s: %w", eppRBACKind(obj), err)
+		}
+	}
+	return nil
+}
+
+// releaseSharedEPPRBAC removes md from the owners of the shared EPP RBAC of its namespace,
+// deleting the objects no other ModelDeployment owns.
+func (r *ModelDeploymentReconciler) releaseSharedEPPRBAC(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) {
+	logger := log.FromContext(ctx)
+	for _, obj := range eppRBACObjects(gateway.SharedEPPRBACName, md.Namespace) {
+		if err := r.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+			continue
+		}
+		owned, err := controllerutil.HasOwnerReference(obj.GetOwnerReferences(), md, r.Scheme)
+		if err != nil || !owned {
+			continue
+		}
+		if len(obj.GetOwnerReferences()) == 1 {
+			err = client.IgnoreNotFound(r.Delete(ctx, obj))
+		} else if err = controllerutil.RemoveOwnerReference(md, obj, r.Scheme); err == nil {
+			err = r.Update(ctx, obj)
+		}
+		if err != nil {
+			logger.V(1).Info("Could not release shared EPP RBAC", "kind", eppRBACKind(obj), "error", err)
+		}
+	}
+}
+
+// deleteEPPRBACObjects deletes the ServiceAccount, Role, and RoleBinding named name
+func (r *ModelDeploymentReconciler) deleteEPPRBACObjects(ctx context.Context, name, namespace string) {
+	for _, obj := range eppRBACObjects(name, namespace) {
+		if err := r.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+			log.FromContext(ctx).V(1).Info("Could not delete EPP RBAC", "kind", eppRBACKind(obj), "name", name, "error", err)
+		}
+	}
+}
+
+// eppRBACObjects returns the ServiceAccount, Role, and RoleBinding of an EPP, in the order
+// they are created
+func eppRBACObjects(name, namespace string) []client.Object {
+	meta := metav1.ObjectMeta{Name: name, Namespace: namespace}
+	return []client.Object{
+		&corev1.ServiceAccount{ObjectMeta: meta},
+		&rbacv1.Role{ObjectMeta: meta},
+		&rbacv1.RoleBinding{ObjectMeta: meta},
+	}
+}
+
+// setEPPRBACSpec sets the rules of an EPP Role and binds an EPP RoleBinding to the
+// ServiceAccount and Role named name
+func setEPPRBACSpec(obj client.Object, name, namespace string) {
+	switch o := obj.(type) {
+	case *rbacv1.Role:
+		o.Rules = gateway.EPPRoleRules()
+	case *rbacv1.RoleBinding:
+		o.RoleRef = rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "Role",
+			Name:     name,
+		}
+		o.Subjects = []rbacv1.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Name:      name,
+				Namespace: namespace,
+			},
+		}
+	}
+}
+
+// eppRBACKind returns the kind of an EPP RBAC object for messages
+func eppRBACKind(obj client.Object) string {
+	switch obj.(type) {
+	case *corev1.ServiceAccount:
+		return "ServiceAccount"
+	case *rbacv1.Role:
+		return "Role"
+	case *rbacv1.RoleBinding:
+		return "RoleBinding"
+	}
+	return fmt.Sprintf("%T", obj)
+}
@@ -0,0 +1,270 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/pkg/provider"
+)
+
+// exposePollInterval is how often a pending spec.expose address is checked again
+const exposePollInterval = 15 * time.Second
+
+// exposePortName is the name of the port of the spec.expose Service
+const exposePortName = "http"
+
+// reconcileExpose creates the Service of spec.expose in front of the provider's endpoint
+// Service, selecting the same pods, and for type Ingress an Ingress routing to it. The
+// address is reported in status.expose and the Exposed condition. Resources created for an
+// earlier spec.expose are deleted once it is removed. It returns how long to wait before
+// checking a pending address again, or zero.
+func (r *ModelDeploymentReconciler) reconcileExpose(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) (time.Duration, error) {
+	expose := md.Spec.Expose
+	if expose == nil {
+		md.Status.Expose = nil
+		meta.RemoveStatusCondition(&md.Status.Conditions, airunwayv1alpha1.ConditionTypeExposed)
+		if err := r.deleteExposeResource(ctx, md, &corev1.Service{ObjectMeta: exposeObjectMeta(md, md.ExposeServiceName())}); err != nil {
+			return 0, err
+		}
+		return 0, r.deleteExposeResource(ctx, md, &networkingv1.Ingress{ObjectMeta: exposeObjectMeta(md, md.Name)})
+	}
+	if expose.Type != airunwayv1alpha1.ExposeTypeIngress {
+		if err := r.deleteExposeResource(ctx, md, &networkingv1.Ingress{ObjectMeta: exposeObjectMeta(md, md.Name)}); err != nil {
+			return 0, err
+		}
+	}
+
+	if md.Status.Endpoint == nil || md.Status.Endpoint.Service == "" {
+		r.setCondition(md, airunwayv1alpha1.ConditionTypeExposed, metav1.ConditionFalse, "EndpointPending",
+			"Waiting for the provider to report status.endpoint")
+		return 0, nil
+	}
+	var backend corev1.Service
+	if err := r.Get(ctx, k8stypes.NamespacedName{Name: md.Status.Endpoint.Service, Namespace: md.Namespace}, &backend); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.setCondition(md, airunwayv1alpha1.ConditionTypeExposed, metav1.ConditionFalse, "EndpointPending",
+				fmt.Sprintf("Waiting for endpoint Service %s", md.Status.Endpoint.Service))
+			return exposePollInterval, nil
+		}
+		return 0, fmt.Errorf("failed to get endpoint Service: %w", err)
+	}
+	backendPort, ok := endpointServicePort(&backend, md.Status.Endpoint.Port)
+	if !ok {
+		return 0, fmt.Errorf("endpoint Service %s has no ports", backend.Name)
+	}
+
+	svc, err := r.reconcileExposeService(ctx, md, &backend, backendPort)
+	if err != nil {
+		return 0, err
+	}
+
+	status := &airunwayv1alpha1.ExposeStatus{Service: svc.Name}
+	port := strconv.Itoa(int(backendPort.Port))
+	switch expose.Type {
+	case airunwayv1alpha1.ExposeTypeLoadBalancer:
+		if ingress := svc.Status.LoadBalancer.Ingress; len(ingress) > 0 {
+			if address := firstAddress(ingress[0].IP, ingress[0].Hostname); address != "" {
+				status.URL = "http://" + net.JoinHostPort(address, port)
+			}
+		}
+	case airunwayv1alpha1.ExposeTypeIngress:
+		ing, err := r.reconcileExposeIngress(ctx, md, svc)
+		if err != nil {
+			return 0, err
+		}
+		if expose.Host != "" {
+			status.URL = "http://" + expose.Host
+		} else if ingress := ing.Status.LoadBalancer.Ingress; len(ingress) > 0 {
+			if address := firstAddress(ingress[0].IP, ingress[0].Hostname); address != "" {
+				status.URL = "http://" + urlHost(address)
+			}
+		}
+	default:
+		if expose.Type == airunwayv1alpha1.ExposeTypeNodePort && len(svc.Spec.Ports) > 0 {
+			status.NodePort = svc.Spec.Ports[0].NodePort
+		}
+		status.URL = "http://" + net.JoinHostPort(svc.Name+"."+svc.Namespace+".svc", port)
+	}
+	md.Status.Expose = status
+
+	if status.URL == "" {
+		r.setCondition(md, airunwayv1alpha1.ConditionTypeExposed, metav1.ConditionFalse, "AddressPending",
+			fmt.Sprintf("Waiting for an external address for the %s", expose.Type))
+		return exposePollInterval, nil
+	}
+	r.setCondition(md, airunwayv1alpha1.ConditionTypeExposed, metav1.ConditionTrue, "Exposed",
+		fmt.Sprintf("Exposed at %s", status.URL))
+	return 0, nil
+}
+
+// reconcileExposeService creates or updates the spec.expose Service, selecting the pods of
+// backend and forwarding to the target port of backendPort
+func (r *ModelDeploymentReconciler) reconcileExposeService(ctx context.Context, md *airunwayv1alpha1.ModelDeployment,
+	backend *corev1.Service, backendPort corev1.ServicePort) (*corev1.Service, error) {
+	svc := &corev1.Service{ObjectMeta: exposeObjectMeta(md, md.ExposeServiceName())}
+	serviceType := corev1.ServiceTypeClusterIP
+	switch md.Spec.Expose.Type {
+	case airunwayv1alpha1.ExposeTypeNodePort:
+		serviceType = corev1.ServiceTypeNodePort
+	case airunwayv1alpha1.ExposeTypeLoadBalancer:
+		serviceType = corev1.ServiceTypeLoadBalancer
+	}
+
+	_, err := ctrl.CreateOrUpdate(ctx, r.Client, svc, func() error {
+		if svc.ResourceVersion != "" && !metav1.IsControlledBy(svc, md) {
+			return fmt.Errorf("Service %s already exists and is not managed by this ModelDeployment", svc.Name)
+		}
+		if svc.Labels == nil {
+			svc.Labels = map[string]string{}
+		}
+		svc.Labels[airunwayv1alpha1.LabelManagedBy] = airunwayv1alpha1.ManagedByAIRunway
+		svc.Labels[airunwayv1alpha1.LabelModelDeployment] = md.Name
+
+		port := corev1.ServicePort{
+			Name:       exposePortName,
+			Protocol:   corev1.ProtocolTCP,
+			Port:       backendPort.Port,
+			TargetPort: backendPort.TargetPort,
+		}
+		// Keep the allocated node port so the address does not change on every update
+		if serviceType != corev1.ServiceTypeClusterIP && len(svc.Spec.Ports) > 0 {
+			port.NodePort = svc.Spec.Ports[0].NodePort
+		}
+		svc.Spec.Type = serviceType
+		svc.Spec.Selector = maps.Clone(backend.Spec.Selector)
+		svc.Spec.Ports = []corev1.ServicePort{port}
+		provider.ApplyIPFamiliesToService(&svc.Spec, md)
+		provider.ApplyPropagatedMetadataToObject(svc, md)
+		return ctrl.SetControllerReference(md, svc, r.Scheme)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create/update expose Service: %w", err)
+	}
+	return svc, nil
+}
+
+// reconcileExposeIngress creates or updates the spec.expose Ingress routing all paths of
+// spec.expose.host to svc
+func (r *ModelDeploymentReconciler) reconcileExposeIngress(ctx context.Context, md *airunwayv1alpha1.ModelDeployment, svc *corev1.Service) (*networkingv1.Ingress, error) {
+	expose := md.Spec.Expose
+	ing := &networkingv1.Ingress{ObjectMeta: exposeObjectMeta(md, md.Name)}
+	pathType := networkingv1.PathTypePrefix
+
+	_, err := ctrl.CreateOrUpdate(ctx, r.Client, ing, func() error {
+		if ing.ResourceVersion != "" && !metav1.IsControlledBy(ing, md) {
+			return fmt.Errorf("Ingress %s already exists and is not managed by this ModelDeployment", ing.Name)
+		}
+		if ing.Labels == nil {
+			ing.Labels = map[string]string{}
+		}
+		ing.Labels[airunwayv1alpha1.LabelManagedBy] = airunwayv1alpha1.ManagedByAIRunway
+		ing.Labels[airunwayv1alpha1.LabelModelDeployment] = md.Name
+
+		ing.Spec.IngressClassName = nil
+		if expose.IngressClassName != "" {
+			ing.Spec.IngressClassName = strPtr(expose.IngressClassName)
+		}
+		ing.Spec.Rules = []networkingv1.IngressRule{{
+			Host: expose.Host,
+			IngressRuleValue: networkingv1.IngressRuleValue{
+				HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{{
+						Path:     "/",
+						PathType: &pathType,
+						Backend: networkingv1.IngressBackend{
+							Service: &networkingv1.IngressServiceBackend{
+								Name: svc.Name,
+								Port: networkingv1.ServiceBackendPort{Name: exposePortName},
+							},
+						},
+					}},
+				},
+			},
+		}}
+		provider.ApplyPropagatedMetadataToObject(ing, md)
+		return ctrl.SetControllerReference(md, ing, r.Scheme)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create/update expose Ingress: %w", err)
+	}
+	return ing, nil
+}
+
+// exposeObjectMeta returns the metadata of the spec.expose resource name of md
+func exposeObjectMeta(md *airunwayv1alpha1.ModelDeployment, name string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{Name: name, Namespace: md.Namespace}
+}
+
+// deleteExposeResource deletes obj, a spec.expose Service or Ingress, when it exists and is
+// controlled by md
+func (r *ModelDeploymentReconciler) deleteExposeResource(ctx context.Context, md *airunwayv1alpha1.ModelDeployment, obj client.Object) error {
+	if err := r.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil
+		}
+		return err
+	}
+	if !metav1.IsControlledBy(obj, md) {
+		return nil
+	}
+	log.FromContext(ctx).Info("Deleting expose resource", "name", obj.GetName())
+	return client.IgnoreNotFound(r.Delete(ctx, obj))
+}
+
+// endpointServicePort returns the port of svc matching port, or its first port when port is zero
+func endpointServicePort(svc *corev1.Service, port int32) (corev1.ServicePort, bool) {
+	for _, p := range svc.Spec.Ports {
+		if port == 0 || p.Port == port {
+			return p, true
+		}
+	}
+	return corev1.ServicePort{}, false
+}
+
+// firstAddress returns ip, or hostname when ip is empty
+func firstAddress(ip, hostname string) string {
+	if ip != "" {
+		return ip
+	}
+	return hostname
+}
+
+// urlHost returns address as the host of a URL, bracketing IPv6 addresses
+func urlHost(address string) string {
+	if strings.Contains(address, ":") && net.ParseIP(address) != nil {
+		return "[" + address + "]"
+	}
+	return address
+}
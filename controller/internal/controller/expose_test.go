@@ -0,0 +1,215 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+// newEndpointService returns the provider Service reported in status.endpoint by newModelDeployment
+func newEndpointService() *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-model-svc", Namespace: "default"},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "test-model"},
+			Ports:    []corev1.ServicePort{{Name: "http", Port: 8080, TargetPort: intstr.FromInt32(8000)}},
+		},
+	}
+}
+
+func TestReconcileExpose(t *testing.T) {
+	scheme := newTestScheme()
+	md := newModelDeployment("test-model", "default")
+	md.Spec.Expose = &airunwayv1alpha1.ExposeSpec{Type: airunwayv1alpha1.ExposeTypeClusterIP}
+	r := newTestReconciler(scheme, nil, md, newEndpointService())
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "test-model-endpoint", Namespace: "default"}
+
+	if _, err := r.reconcileExpose(ctx, md); err != nil {
+		t.Fatalf("reconcileExpose failed: %v", err)
+	}
+	var svc corev1.Service
+	if err := r.Get(ctx, key, &svc); err != nil {
+		t.Fatalf("expected expose Service to be created: %v", err)
+	}
+	if svc.Spec.Type != corev1.ServiceTypeClusterIP || svc.Spec.Selector["app"] != "test-model" {
+		t.Errorf("unexpected Service spec %+v", svc.Spec)
+	}
+	if port := svc.Spec.Ports[0]; port.Port != 8080 || port.TargetPort.IntValue() != 8000 {
+		t.Errorf("expected port 8080 targeting 8000, got %+v", port)
+	}
+	if !metav1.IsControlledBy(&svc, md) {
+		t.Error("expected Service to be owned by the ModelDeployment")
+	}
+	if md.Status.Expose == nil || md.Status.Expose.URL != "http://test-model-endpoint.default.svc:8080" {
+		t.Errorf("unexpected expose status %+v", md.Status.Expose)
+	}
+	if !meta.IsStatusConditionTrue(md.Status.Conditions, airunwayv1alpha1.ConditionTypeExposed) {
+		t.Error("expected Exposed condition to be true")
+	}
+
+	// A load balancer without an address is pending
+	md.Spec.Expose.Type = airunwayv1alpha1.ExposeTypeLoadBalancer
+	requeue, err := r.reconcileExpose(ctx, md)
+	if err != nil {
+		t.Fatalf("reconcileExpose failed: %v", err)
+	}
+	if requeue != exposePollInterval || md.Status.Expose.URL != "" {
+		t.Errorf("expected a pending address, got requeue %v and status %+v", requeue, md.Status.Expose)
+	}
+	if err := r.Get(ctx, key, &svc); err != nil {
+		t.Fatalf("failed to get Service: %v", err)
+	}
+	if svc.Spec.Type != corev1.ServiceTypeLoadBalancer {
+		t.Errorf("expected LoadBalancer Service, got %s", svc.Spec.Type)
+	}
+	svc.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: "203.0.113.10"}}
+	if err := r.Status().Update(ctx, &svc); err != nil {
+		t.Fatalf("failed to update Service status: %v", err)
+	}
+	if _, err := r.reconcileExpose(ctx, md); err != nil {
+		t.Fatalf("reconcileExpose failed: %v", err)
+	}
+	if md.Status.Expose.URL != "http://203.0.113.10:8080" {
+		t.Errorf("expected load balancer URL, got %q", md.Status.Expose.URL)
+	}
+
+	// Removing spec.expose deletes the Service
+	md.Spec.Expose = nil
+	if _, err := r.reconcileExpose(ctx, md); err != nil {
+		t.Fatalf("reconcileExpose failed: %v", err)
+	}
+	if err := r.Get(ctx, key, &svc); !apierrors.IsNotFound(err) {
+		t.Errorf("expected Service to be deleted, got %v", err)
+	}
+	if md.Status.Expose != nil || meta.FindStatusCondition(md.Status.Conditions, airunwayv1alpha1.ConditionTypeExposed) != nil {
+		t.Error("expected expose status and condition to be cleared")
+	}
+}
+
+func TestReconcileExpose_Ingress(t *testing.T) {
+	scheme := newTestScheme()
+	md := newModelDeployment("test-model", "default")
+	md.Spec.Expose = &airunwayv1alpha1.ExposeSpec{
+		Type:             airunwayv1alpha1.ExposeTypeIngress,
+		IngressClassName: "nginx",
+		Host:             "llm.example.com",
+	}
+	r := newTestReconciler(scheme, nil, md, newEndpointService())
+	ctx := context.Background()
+
+	if _, err := r.reconcileExpose(ctx, md); err != nil {
+		t.Fatalf("reconcileExpose failed: %v", err)
+	}
+	var ing networkingv1.Ingress
+	if err := r.Get(ctx, types.NamespacedName{Name: "test-model", Namespace: "default"}, &ing); err != nil {
+		t.Fatalf("expected Ingress to be created: %v", err)
+	}
+	if ing.Spec.IngressClassName == nil || *ing.Spec.IngressClassName != "nginx" {
+		t.Errorf("expected ingress class nginx, got %v", ing.Spec.IngressClassName)
+	}
+	rule := ing.Spec.Rules[0]
+	if rule.Host != "llm.example.com" || rule.HTTP.Paths[0].Backend.Service.Name != "test-model-endpoint" {
+		t.Errorf("unexpected Ingress rule %+v", rule)
+	}
+	if md.Status.Expose.URL != "http://llm.example.com" {
+		t.Errorf("expected host URL, got %q", md.Status.Expose.URL)
+	}
+
+	// Switching away from Ingress deletes it
+	md.Spec.Expose = &airunwayv1alpha1.ExposeSpec{Type: airunwayv1alpha1.ExposeTypeNodePort}
+	if _, err := r.reconcileExpose(ctx, md); err != nil {
+		t.Fatalf("reconcileExpose failed: %v", err)
+	}
+	if err := r.Get(ctx, types.NamespacedName{Name: "test-model", Namespace: "default"}, &ing); !apierrors.IsNotFound(err) {
+		t.Errorf("expected Ingress to be deleted, got %v", err)
+	}
+}
+
+func TestReconcileExpose_IPv6(t *testing.T) {
+	scheme := newTestScheme()
+	md := newModelDeployment("test-model", "default")
+	md.Spec.Expose = &airunwayv1alpha1.ExposeSpec{Type: airunwayv1alpha1.ExposeTypeLoadBalancer}
+	md.Spec.Networking = &airunwayv1alpha1.NetworkingSpec{IPFamilies: []corev1.IPFamily{corev1.IPv6Protocol}}
+	r := newTestReconciler(scheme, nil, md, newEndpointService())
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "test-model-endpoint", Namespace: "default"}
+
+	if _, err := r.reconcileExpose(ctx, md); err != nil {
+		t.Fatalf("reconcileExpose failed: %v", err)
+	}
+	var svc corev1.Service
+	if err := r.Get(ctx, key, &svc); err != nil {
+		t.Fatalf("expected expose Service to be created: %v", err)
+	}
+	if len(svc.Spec.IPFamilies) != 1 || svc.Spec.IPFamilies[0] != corev1.IPv6Protocol {
+		t.Errorf("expected an IPv6 Service, got %v", svc.Spec.IPFamilies)
+	}
+	svc.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: "2001:db8::10"}}
+	if err := r.Status().Update(ctx, &svc); err != nil {
+		t.Fatalf("failed to update Service status: %v", err)
+	}
+	if _, err := r.reconcileExpose(ctx, md); err != nil {
+		t.Fatalf("reconcileExpose failed: %v", err)
+	}
+	if md.Status.Expose.URL != "http://[2001:db8::10]:8080" {
+		t.Errorf("expected bracketed IPv6 URL, got %q", md.Status.Expose.URL)
+	}
+}
+
+func TestURLHost(t *testing.T) {
+	tests := map[string]string{
+		"10.0.0.42":        "10.0.0.42",
+		"fd00::42":         "[fd00::42]",
+		"[fd00::42]":       "[fd00::42]",
+		"gw.example.com":   "gw.example.com",
+		"10.0.0.42:8080":   "10.0.0.42:8080",
+		"::ffff:10.0.0.42": "[::ffff:10.0.0.42]",
+	}
+	for address, want := range tests {
+		if got := urlHost(address); got != want {
+			t.Errorf("urlHost(%q) = %q, want %q", address, got, want)
+		}
+	}
+}
+
+func TestReconcileExpose_EndpointPending(t *testing.T) {
+	scheme := newTestScheme()
+	md := newModelDeployment("test-model", "default")
+	md.Spec.Expose = &airunwayv1alpha1.ExposeSpec{Type: airunwayv1alpha1.ExposeTypeClusterIP}
+	md.Status.Endpoint = nil
+	r := newTestReconciler(scheme, nil, md)
+
+	if _, err := r.reconcileExpose(context.Background(), md); err != nil {
+		t.Fatalf("reconcileExpose failed: %v", err)
+	}
+	cond := meta.FindStatusCondition(md.Status.Conditions, airunwayv1alpha1.ConditionTypeExposed)
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != "EndpointPending" {
+		t.Errorf("expected EndpointPending condition, got %+v", cond)
+	}
+}
@@ -0,0 +1,405 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/internal/activity"
+	"github.com/kaito-project/airunway/controller/pkg/provider"
+)
+
+const (
+	// defaultFallbackOverflowPercent is the share of requests sent to the fallback while
+	// the replicas are saturated
+	defaultFallbackOverflowPercent = 20
+
+	// defaultFallbackSaturationThreshold is the mean KV cache utilization, in percent, at
+	// which the replicas are saturated
+	defaultFallbackSaturationThreshold = 90
+)
+
+// fallbackSample is the last saturation check of a deployment with a fallback
+type fallbackSample struct {
+	time      time.Time
+	saturated bool
+}
+
+// gatewayFallback returns spec.gateway.fallback, or nil
+func gatewayFallback(md *airunwayv1alpha1.ModelDeployment) *airunwayv1alpha1.GatewayFallbackSpec {
+	if md.Spec.Gateway == nil || md.Spec.Gateway.HTTPRouteRef != "" {
+		return nil
+	}
+	return md.Spec.Gateway.Fallback
+}
+
+// fallbackServiceName returns the name of the ExternalName Service of a fallback url
+func fallbackServiceName(md *airunwayv1alpha1.ModelDeployment) string {
+	return md.Name + "-fallback"
+}
+
+// fallbackURL is the parsed spec.gateway.fallback.url
+type fallbackURL struct {
+	host string
+	port int32
+	// path is the URL path without a trailing slash, empty for the root
+	path string
+	tls  bool
+}
+
+func parseFallbackURL(raw string) (fallbackURL, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fallbackURL{}, err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return fallbackURL{}, fmt.Errorf("fallback url %q must be an http or https URL with a host", raw)
+	}
+	target := fallbackURL{host: u.Hostname(), port: 80, path: strings.TrimSuffix(u.Path, "/"), tls: u.Scheme == "https"}
+	if target.tls {
+		target.port = 443
+	}
+	if p := u.Port(); p != "" {
+		port, err := strconv.ParseInt(p, 10, 32)
+		if err != nil || port < 1 || port > 65535 {
+			return fallbackURL{}, fmt.Errorf("fallback url %q has an invalid port", raw)
+		}
+		target.port = int32(port)
+	}
+	return target, nil
+}
+
+// fallbackBackendRef returns the HTTPRoute backendRef of spec.gateway.fallback. A url
+// routes to the ExternalName Service of the fallback, rewriting the Host header and
+// prefixing the request path with the URL path.
+func (r *ModelDeploymentReconciler) fallbackBackendRef(fallback *airunwayv1alpha1.GatewayFallbackSpec, md *airunwayv1alpha1.ModelDeployment) (*gatewayv1.HTTPBackendRef, error) {
+	if ref := fallback.BackendRef; ref != nil {
+		obj := gatewayv1.BackendObjectReference{Name: gatewayv1.ObjectName(ref.Name)}
+		if ref.Kind == airunwayv1alpha1.FallbackBackendKindService {
+			if ref.Port == nil {
+				return nil, fmt.Errorf("fallback Service %s requires a port", ref.Name)
+			}
+			obj.Group = ptr.To(gatewayv1.Group(""))
+			obj.Kind = ptr.To(gatewayv1.Kind("Service"))
+			obj.Port = ptr.To(*ref.Port)
+		} else {
+			obj.Group = ptr.To(gatewayv1.Group(r.inferencePoolGroup()))
+			obj.Kind = ptr.To(gatewayv1.Kind("InferencePool"))
+		}
+		return &gatewayv1.HTTPBackendRef{BackendRef: gatewayv1.BackendRef{BackendObjectReference: obj}}, nil
+	}
+
+	target, err := parseFallbackURL(fallback.URL)
+	if err != nil {
+		return nil, err
+	}
+	rewrite := &gatewayv1.HTTPURLRewriteFilter{Hostname: ptr.To(gatewayv1.PreciseHostname(target.host))}
+	if target.path != "" {
+		rewrite.Path = &gatewayv1.HTTPPathModifier{
+			Type:               gatewayv1.PrefixMatchHTTPPathModifier,
+			ReplacePrefixMatch: ptr.To(target.path),
+		}
+	}
+	return &gatewayv1.HTTPBackendRef{
+		BackendRef: gatewayv1.BackendRef{
+			BackendObjectReference: gatewayv1.BackendObjectReference{
+				Group: ptr.To(gatewayv1.Group("")),
+				Kind:  ptr.To(gatewayv1.Kind("Service")),
+				Name:  gatewayv1.ObjectName(fallbackServiceName(md)),
+				Port:  ptr.To(target.port),
+			},
+		},
+		Filters: []gatewayv1.HTTPRouteFilter{{
+			Type:       gatewayv1.HTTPRouteFilterURLRewrite,
+			URLRewrite: rewrite,
+		}},
+	}, nil
+}
+
+// reconcileFallbackService creates the ExternalName Service of spec.gateway.fallback.url,
+// and for https the BackendTLSPolicy that makes the Gateway originate TLS to it. Both are
+// deleted when the fallback has no url.
+func (r *ModelDeploymentReconciler) reconcileFallbackService(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) error {
+	name := fallbackServiceName(md)
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: md.Namespace}}
+	policy := &gatewayv1.BackendTLSPolicy{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: md.Namespace}}
+
+	fallback := gatewayFallback(md)
+	if fallback == nil || fallback.URL == "" {
+		if err := r.deleteFallbackResource(ctx, md, policy); err != nil {
+			return fmt.Errorf("failed to delete fallback BackendTLSPolicy: %w", err)
+		}
+		if err := r.deleteFallbackResource(ctx, md, svc); err != nil {
+			return fmt.Errorf("failed to delete fallback Service: %w", err)
+		}
+		return nil
+	}
+	target, err := parseFallbackURL(fallback.URL)
+	if err != nil {
+		return err
+	}
+
+	if _, err := ctrl.CreateOrUpdate(ctx, r.Client, svc, func() error {
+		if svc.ResourceVersion != "" && !metav1.IsControlledBy(svc, md) {
+			return fmt.Errorf("Service %s already exists and is not managed by this ModelDeployment", svc.Name)
+		}
+		if svc.Labels == nil {
+			svc.Labels = map[string]string{}
+		}
+		svc.Labels[airunwayv1alpha1.LabelManagedBy] = airunwayv1alpha1.ManagedByAIRunway
+		svc.Labels[airunwayv1alpha1.LabelModelDeployment] = md.Name
+		portName := "http"
+		if target.tls {
+			portName = "https"
+		}
+		svc.Spec.Type = corev1.ServiceTypeExternalName
+		svc.Spec.ExternalName = target.host
+		svc.Spec.Ports = []corev1.ServicePort{{Name: portName, Protocol: corev1.ProtocolTCP, Port: target.port}}
+		provider.ApplyPropagatedMetadataToObject(svc, md)
+		return ctrl.SetControllerReference(md, svc, r.Scheme)
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile fallback Service: %w", err)
+	}
+
+	if !target.tls {
+		if err := r.deleteFallbackResource(ctx, md, policy); err != nil {
+			return fmt.Errorf("failed to delete fallback BackendTLSPolicy: %w", err)
+		}
+		return nil
+	}
+	if _, err := ctrl.CreateOrUpdate(ctx, r.Client, policy, func() error {
+		if policy.ResourceVersion != "" && !metav1.IsControlledBy(policy, md) {
+			return fmt.Errorf("BackendTLSPolicy %s already exists and is not managed by this ModelDeployment", policy.Name)
+		}
+		policy.Spec.TargetRefs = []gatewayv1.LocalPolicyTargetReferenceWithSectionName{{
+			LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+				Group: "",
+				Kind:  "Service",
+				Name:  gatewayv1.ObjectName(name),
+			},
+		}}
+		policy.Spec.Validation = gatewayv1.BackendTLSPolicyValidation{
+			Hostname:                gatewayv1.PreciseHostname(target.host),
+			WellKnownCACertificates: ptr.To(gatewayv1.WellKnownCACertificatesSystem),
+		}
+		provider.ApplyPropagatedMetadataToObject(policy, md)
+		return ctrl.SetControllerReference(md, policy, r.Scheme)
+	}); err != nil {
+		if meta.IsNoMatchError(err) {
+			log.FromContext(ctx).Info("BackendTLSPolicy CRD not installed, the Gateway will not originate TLS to the fallback url", "name", md.Name)
+			return nil
+		}
+		return fmt.Errorf("failed to reconcile fallback BackendTLSPolicy: %w", err)
+	}
+	return nil
+}
+
+// deleteFallbackResource deletes obj, a fallback Service or BackendTLSPolicy, when it
+// exists and is controlled by md
+func (r *ModelDeploymentReconciler) deleteFallbackResource(ctx context.Context, md *airunwayv1alpha1.ModelDeployment, obj client.Object) error {
+	if err := r.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil
+		}
+		return err
+	}
+	if !metav1.IsControlledBy(obj, md) {
+		return nil
+	}
+	return client.IgnoreNotFound(r.Delete(ctx, obj))
+}
+
+// withGatewayFallback adds spec.gateway.fallback to the HTTPRoute backend of a deployment
+// that serves traffic. A share of requests overflows to the fallback while the replicas
+// are saturated. It returns the fallback state, empty without a fallback.
+func (r *ModelDeploymentReconciler) withGatewayFallback(ctx context.Context, md *airunwayv1alpha1.ModelDeployment, backend httpRouteBackendTarget) (httpRouteBackendTarget, airunwayv1alpha1.GatewayFallbackState, error) {
+	if err := r.reconcileFallbackService(ctx, md); err != nil {
+		return backend, "", err
+	}
+	fallback := gatewayFallback(md)
+	if fallback == nil {
+		r.fallbackSamples.Delete(types.NamespacedName{Name: md.Name, Namespace: md.Namespace})
+		return backend, "", nil
+	}
+	overflow := int32(defaultFallbackOverflowPercent)
+	if fallback.OverflowPercent != nil {
+		overflow = *fallback.OverflowPercent
+	}
+	if overflow == 0 || !r.replicasSaturated(ctx, md, fallback) {
+		return backend, airunwayv1alpha1.GatewayFallbackStandby, nil
+	}
+	ref, err := r.fallbackBackendRef(fallback, md)
+	if err != nil {
+		return backend, "", err
+	}
+	backend.fallback = ref
+	backend.fallbackPercent = overflow
+	return backend, airunwayv1alpha1.GatewayFallbackOverflow, nil
+}
+
+// replicasSaturated reports whether the mean KV cache utilization of the replicas reached
+// the saturation threshold of fallback, from the per-pod samples of the background scraper
+// taken once per ActivityPollInterval. Replicas that cannot be sampled are not saturated.
+func (r *ModelDeploymentReconciler) replicasSaturated(ctx context.Context, md *airunwayv1alpha1.ModelDeployment, fallback *airunwayv1alpha1.GatewayFallbackSpec) bool {
+	key := types.NamespacedName{Name: md.Name, Namespace: md.Namespace}
+	result, ok := r.samplePods(ctx, md)
+	if !ok {
+		return false
+	}
+
+	threshold := int32(defaultFallbackSaturationThreshold)
+	if fallback.SaturationThreshold != nil {
+		threshold = *fallback.SaturationThreshold
+	}
+	saturated := false
+	if result.err != nil {
+		log.FromContext(ctx).Info("Could not sample KV cache usage for the gateway fallback", "name", md.Name, "error", result.err.Error())
+	} else if samples := result.value.(map[string]activity.Sample); len(samples) > 0 {
+		var usage float64
+		for _, sample := range samples {
+			usage += sample.KVCacheUsage
+		}
+		saturated = usage/float64(len(samples))*100 >= float64(threshold)
+	}
+	r.fallbackSamples.Store(key, fallbackSample{time: result.time, saturated: saturated})
+	return saturated
+}
+
+// fallbackRequeue returns how long to wait before the replicas of a deployment with an
+// overflowing fallback are sampled again, or zero
+func (r *ModelDeploymentReconciler) fallbackRequeue(md *airunwayv1alpha1.ModelDeployment) time.Duration {
+	prev, ok := r.fallbackSamples.Load(types.NamespacedName{Name: md.Name, Namespace: md.Namespace})
+	if !ok {
+		return 0
+	}
+	return max(r.settings().ActivityPollInterval-time.Since(prev.(fallbackSample).time), time.Second)
+}
+
+// reconcileInactiveGatewayFallback routes every request of the generated HTTPRoute of a
+// deployment that is not Running to spec.gateway.fallback, and back to the deployment
+// when the fallback is removed. The deployment stays the first backendRef, with weight 0.
+// Otherwise the route is only updated while the deployment is Running.
+func (r *ModelDeploymentReconciler) reconcileInactiveGatewayFallback(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) error {
+	r.fallbackSamples.Delete(types.NamespacedName{Name: md.Name, Namespace: md.Namespace})
+	if md.Status.Gateway == nil || (md.Spec.Gateway != nil && md.Spec.Gateway.HTTPRouteRef != "") {
+		return nil
+	}
+	if err := r.reconcileFallbackService(ctx, md); err != nil {
+		return err
+	}
+
+	var fallback *gatewayv1.HTTPBackendRef
+	if spec := gatewayFallback(md); spec != nil {
+		ref, err := r.fallbackBackendRef(spec, md)
+		if err != nil {
+			return err
+		}
+		fallback = ref
+	}
+
+	var route gatewayv1.HTTPRoute
+	if err := r.Get(ctx, client.ObjectKey{Name: md.Name, Namespace: md.Namespace}, &route); err != nil {
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil
+		}
+		return err
+	}
+	if !metav1.IsControlledBy(&route, md) {
+		return nil
+	}
+	changed := false
+	for i := range route.Spec.Rules {
+		rule := &route.Spec.Rules[i]
+		if len(rule.BackendRefs) == 0 {
+			continue
+		}
+		refs := fallbackBackendRefs(rule.BackendRefs[0].BackendRef.BackendObjectReference, fallback, 100)
+		if !apiequality.Semantic.DeepEqual(rule.BackendRefs, refs) {
+			rule.BackendRefs = refs
+			changed = true
+		}
+	}
+	if changed {
+		if err := r.Update(ctx, &route); err != nil {
+			return fmt.Errorf("failed to update HTTPRoute: %w", err)
+		}
+	}
+
+	state := airunwayv1alpha1.GatewayFallbackState("")
+	if fallback != nil {
+		state = airunwayv1alpha1.GatewayFallbackActive
+	}
+	r.setGatewayFallbackState(md, state)
+	return nil
+}
+
+// fallbackBackendRefs returns the backendRefs of an HTTPRoute rule routing to primary,
+// and fallbackPercent of requests to fallback when it is set
+func fallbackBackendRefs(primary gatewayv1.BackendObjectReference, fallback *gatewayv1.HTTPBackendRef, fallbackPercent int32) []gatewayv1.HTTPBackendRef {
+	refs := []gatewayv1.HTTPBackendRef{{BackendRef: gatewayv1.BackendRef{BackendObjectReference: primary}}}
+	if fallback == nil {
+		return refs
+	}
+	// Weights are relative, so they add up to 100 to read as percentages
+	refs[0].Weight = ptr.To(100 - fallbackPercent)
+	ref := *fallback.DeepCopy()
+	ref.Weight = ptr.To(fallbackPercent)
+	return append(refs, ref)
+}
+
+// setGatewayFallbackState records state in status.gateway.fallback and emits an event when
+// it changes
+func (r *ModelDeploymentReconciler) setGatewayFallbackState(md *airunwayv1alpha1.ModelDeployment, state airunwayv1alpha1.GatewayFallbackState) {
+	if md.Status.Gateway == nil || md.Status.Gateway.Fallback == state {
+		return
+	}
+	previous := md.Status.Gateway.Fallback
+	md.Status.Gateway.Fallback = state
+	if r.Recorder == nil || previous == "" || state == "" {
+		return
+	}
+	message := "Routing every request to the deployment"
+	switch state {
+	case airunwayv1alpha1.GatewayFallbackActive:
+		message = "Routing every request to the fallback while the deployment is not Running"
+	case airunwayv1alpha1.GatewayFallbackOverflow:
+		message = "Routing overflow requests to the fallback while the replicas are saturated"
+	}
+	eventType := corev1.EventTypeNormal
+	if state != airunwayv1alpha1.GatewayFallbackStandby {
+		eventType = corev1.EventTypeWarning
+	}
+	r.Recorder.Eventf(md, nil, eventType, airunwayv1alpha1.ReasonGatewayFallback, "Route", message)
+}
@@ -0,0 +1,242 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/internal/activity"
+	"github.com/kaito-project/airunway/controller/internal/gateway"
+)
+
+func TestParseFallbackURL(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    fallbackURL
+		wantErr bool
+	}{
+		{raw: "https://api.example.com/v1/", want: fallbackURL{host: "api.example.com", port: 443, path: "/v1", tls: true}},
+		{raw: "http://backup.models.svc:8000", want: fallbackURL{host: "backup.models.svc", port: 8000}},
+		{raw: "https://api.example.com", want: fallbackURL{host: "api.example.com", port: 443, tls: true}},
+		{raw: "grpc://api.example.com", wantErr: true},
+		{raw: "https://api.example.com:0", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseFallbackURL(tt.raw)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseFallbackURL(%q) error = %v, wantErr %v", tt.raw, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseFallbackURL(%q) = %+v, want %+v", tt.raw, got, tt.want)
+		}
+	}
+}
+
+// reconcileFallbackRoute reconciles the HTTPRoute of md with its fallback and returns it
+func reconcileFallbackRoute(t *testing.T, r *ModelDeploymentReconciler, md *airunwayv1alpha1.ModelDeployment) (gatewayv1.HTTPRoute, airunwayv1alpha1.GatewayFallbackState) {
+	t.Helper()
+	ctx := context.Background()
+	backend, state, err := r.withGatewayFallback(ctx, md, httpRouteBackendTarget{
+		group:     "inference.networking.k8s.io",
+		kind:      "InferencePool",
+		name:      md.Name,
+		namespace: md.Namespace,
+	})
+	if err != nil {
+		t.Fatalf("withGatewayFallback failed: %v", err)
+	}
+	gwConfig := &gateway.GatewayConfig{GatewayName: "my-gateway", GatewayNamespace: "gateway-ns"}
+	if err := r.reconcileHTTPRoute(ctx, md, gwConfig, "meta-llama/Llama-3-8B", backend); err != nil {
+		t.Fatalf("reconcileHTTPRoute failed: %v", err)
+	}
+	var route gatewayv1.HTTPRoute
+	if err := r.Get(ctx, types.NamespacedName{Name: md.Name, Namespace: md.Namespace}, &route); err != nil {
+		t.Fatalf("HTTPRoute not found: %v", err)
+	}
+	return route, state
+}
+
+func backendWeights(route gatewayv1.HTTPRoute) []int32 {
+	var weights []int32
+	for _, ref := range route.Spec.Rules[0].BackendRefs {
+		weights = append(weights, ptr.Deref(ref.Weight, 1))
+	}
+	return weights
+}
+
+func TestGateway_FallbackOverflow(t *testing.T) {
+	tests := []struct {
+		name        string
+		usage       float64
+		wantState   airunwayv1alpha1.GatewayFallbackState
+		wantWeights []int32
+	}{
+		{name: "standby", usage: 0.5, wantState: airunwayv1alpha1.GatewayFallbackStandby, wantWeights: []int32{1}},
+		{name: "saturated", usage: 0.95, wantState: airunwayv1alpha1.GatewayFallbackOverflow, wantWeights: []int32{70, 30}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md := newModelDeployment("test-model", "default")
+			md.Spec.Gateway = &airunwayv1alpha1.GatewaySpec{Fallback: &airunwayv1alpha1.GatewayFallbackSpec{
+				BackendRef:      &airunwayv1alpha1.FallbackBackendRef{Kind: airunwayv1alpha1.FallbackBackendKindService, Name: "backup", Port: ptr.To[int32](8000)},
+				OverflowPercent: ptr.To[int32](30),
+			}}
+			r := newTestReconciler(newTestScheme(), fakeDetector(true, "my-gateway", "gateway-ns"), md)
+			r.PodActivitySource = &fakePodActivitySource{samples: map[string]activity.Sample{
+				"pod-a": {KVCacheUsage: tt.usage},
+				"pod-b": {KVCacheUsage: tt.usage},
+			}}
+
+			// The replicas are sampled in the background; the first route stays on standby
+			if _, state := reconcileFallbackRoute(t, r, md); state != airunwayv1alpha1.GatewayFallbackStandby {
+				t.Errorf("state = %q before sampling, want Standby", state)
+			}
+			waitForScrapes(r)
+			route, state := reconcileFallbackRoute(t, r, md)
+			if state != tt.wantState {
+				t.Errorf("state = %q, want %q", state, tt.wantState)
+			}
+			weights := backendWeights(route)
+			if len(weights) != len(tt.wantWeights) {
+				t.Fatalf("weights = %v, want %v", weights, tt.wantWeights)
+			}
+			for i := range weights {
+				if weights[i] != tt.wantWeights[i] {
+					t.Errorf("weights = %v, want %v", weights, tt.wantWeights)
+				}
+			}
+			if len(weights) == 2 {
+				ref := route.Spec.Rules[0].BackendRefs[1]
+				if string(ref.Name) != "backup" || ptr.Deref(ref.Port, 0) != 8000 || ptr.Deref(ref.Kind, "") != "Service" {
+					t.Errorf("fallback backendRef = %+v, want Service backup:8000", ref.BackendObjectReference)
+				}
+			}
+			if r.fallbackRequeue(md) == 0 {
+				t.Error("expected a requeue to sample the replicas again")
+			}
+		})
+	}
+}
+
+func TestGateway_FallbackURL(t *testing.T) {
+	md := newModelDeployment("test-model", "default")
+	md.Spec.Gateway = &airunwayv1alpha1.GatewaySpec{Fallback: &airunwayv1alpha1.GatewayFallbackSpec{
+		URL:             "https://api.example.com/v1",
+		OverflowPercent: ptr.To[int32](0),
+	}}
+	r := newTestReconciler(newTestScheme(), fakeDetector(true, "my-gateway", "gateway-ns"), md)
+	ctx := context.Background()
+
+	if _, state := reconcileFallbackRoute(t, r, md); state != airunwayv1alpha1.GatewayFallbackStandby {
+		t.Errorf("state = %q, want %q", state, airunwayv1alpha1.GatewayFallbackStandby)
+	}
+	key := types.NamespacedName{Name: "test-model-fallback", Namespace: "default"}
+	var svc corev1.Service
+	if err := r.Get(ctx, key, &svc); err != nil {
+		t.Fatalf("fallback Service not found: %v", err)
+	}
+	if svc.Spec.Type != corev1.ServiceTypeExternalName || svc.Spec.ExternalName != "api.example.com" {
+		t.Errorf("Service = %s %q, want ExternalName api.example.com", svc.Spec.Type, svc.Spec.ExternalName)
+	}
+	if len(svc.Spec.Ports) != 1 || svc.Spec.Ports[0].Port != 443 {
+		t.Errorf("Service ports = %+v, want 443", svc.Spec.Ports)
+	}
+	var policy gatewayv1.BackendTLSPolicy
+	if err := r.Get(ctx, key, &policy); err != nil {
+		t.Fatalf("fallback BackendTLSPolicy not found: %v", err)
+	}
+	if policy.Spec.Validation.Hostname != "api.example.com" {
+		t.Errorf("BackendTLSPolicy hostname = %q, want api.example.com", policy.Spec.Validation.Hostname)
+	}
+
+	ref, err := r.fallbackBackendRef(md.Spec.Gateway.Fallback, md)
+	if err != nil {
+		t.Fatalf("fallbackBackendRef failed: %v", err)
+	}
+	if len(ref.Filters) != 1 || ref.Filters[0].URLRewrite == nil ||
+		ptr.Deref(ref.Filters[0].URLRewrite.Hostname, "") != "api.example.com" ||
+		ptr.Deref(ref.Filters[0].URLRewrite.Path.ReplacePrefixMatch, "") != "/v1" {
+		t.Errorf("fallback filters = %+v, want a host and path rewrite", ref.Filters)
+	}
+
+	// Removing the fallback deletes the Service and BackendTLSPolicy
+	md.Spec.Gateway.Fallback = nil
+	if _, state := reconcileFallbackRoute(t, r, md); state != "" {
+		t.Errorf("state = %q, want empty", state)
+	}
+	if err := r.Get(ctx, key, &corev1.Service{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected fallback Service to be deleted, got %v", err)
+	}
+	if err := r.Get(ctx, key, &gatewayv1.BackendTLSPolicy{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected fallback BackendTLSPolicy to be deleted, got %v", err)
+	}
+}
+
+func TestGateway_FallbackWhileNotRunning(t *testing.T) {
+	md := newModelDeployment("test-model", "default")
+	md.Spec.Gateway = &airunwayv1alpha1.GatewaySpec{Fallback: &airunwayv1alpha1.GatewayFallbackSpec{
+		BackendRef: &airunwayv1alpha1.FallbackBackendRef{Kind: airunwayv1alpha1.FallbackBackendKindInferencePool, Name: "backup"},
+	}}
+	r := newTestReconciler(newTestScheme(), fakeDetector(true, "my-gateway", "gateway-ns"), md)
+	r.PodActivitySource = &fakePodActivitySource{}
+	ctx := context.Background()
+
+	reconcileFallbackRoute(t, r, md)
+	md.Status.Gateway = &airunwayv1alpha1.GatewayStatus{ModelName: "meta-llama/Llama-3-8B", Fallback: airunwayv1alpha1.GatewayFallbackStandby}
+	md.Status.Phase = airunwayv1alpha1.DeploymentPhaseFailed
+
+	if err := r.reconcileInactiveGatewayFallback(ctx, md); err != nil {
+		t.Fatalf("reconcileInactiveGatewayFallback failed: %v", err)
+	}
+	var route gatewayv1.HTTPRoute
+	if err := r.Get(ctx, types.NamespacedName{Name: "test-model", Namespace: "default"}, &route); err != nil {
+		t.Fatalf("HTTPRoute not found: %v", err)
+	}
+	if weights := backendWeights(route); len(weights) != 2 || weights[0] != 0 || weights[1] != 100 {
+		t.Errorf("weights = %v, want [0 100]", weights)
+	}
+	if ref := route.Spec.Rules[0].BackendRefs[1]; string(ref.Name) != "backup" || ptr.Deref(ref.Kind, "") != "InferencePool" {
+		t.Errorf("fallback backendRef = %+v, want InferencePool backup", ref.BackendObjectReference)
+	}
+	if md.Status.Gateway.Fallback != airunwayv1alpha1.GatewayFallbackActive {
+		t.Errorf("state = %q, want %q", md.Status.Gateway.Fallback, airunwayv1alpha1.GatewayFallbackActive)
+	}
+
+	// Removing the fallback routes back to the deployment
+	md.Spec.Gateway.Fallback = nil
+	if err := r.reconcileInactiveGatewayFallback(ctx, md); err != nil {
+		t.Fatalf("reconcileInactiveGatewayFallback failed: %v", err)
+	}
+	if err := r.Get(ctx, types.NamespacedName{Name: "test-model", Namespace: "default"}, &route); err != nil {
+		t.Fatalf("HTTPRoute not found: %v", err)
+	}
+	if refs := route.Spec.Rules[0].BackendRefs; len(refs) != 1 || refs[0].Weight != nil || string(refs[0].Name) != "test-model" {
+		t.Errorf("backendRefs = %+v, want only the deployment", refs)
+	}
+	if md.Status.Gateway.Fallback != "" {
+		t.Errorf("state = %q, want empty", md.Status.Gateway.Fallback)
+	}
+}
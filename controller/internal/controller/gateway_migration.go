@@ -0,0 +1,88 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/internal/gateway"
+)
+
+// httpRouteGateway returns the Gateway the first parentRef of route points at. The
+// namespace defaults to the namespace of the route.
+func httpRouteGateway(route *gatewayv1.HTTPRoute) (types.NamespacedName, bool) {
+	for _, ref := range route.Spec.ParentRefs {
+		if ref.Kind != nil && *ref.Kind != "Gateway" {
+			continue
+		}
+		parent := types.NamespacedName{Name: string(ref.Name), Namespace: route.Namespace}
+		if ref.Namespace != nil {
+			parent.Namespace = string(*ref.Namespace)
+		}
+		return parent, true
+	}
+	return types.NamespacedName{}, false
+}
+
+// recordGatewayMigration reports that the generated HTTPRoute of md moved from the Gateway
+// previous to the one the controller resolves now, e.g. after --gateway-name or
+// --gateway-namespace changed between restarts. When the controller patched the previous
+// Gateway to accept routes from the namespace of md, and no other HTTPRoute of the namespace
+// is attached to it anymore, the namespace is removed from its allowedRoutes again.
+func (r *ModelDeploymentReconciler) recordGatewayMigration(ctx context.Context, md *airunwayv1alpha1.ModelDeployment, routeName string, previous types.NamespacedName, gwConfig *gateway.GatewayConfig) {
+	logger := log.FromContext(ctx)
+	message := fmt.Sprintf("HTTPRoute %s moved from Gateway %s to %s/%s", routeName, previous, gwConfig.GatewayNamespace, gwConfig.GatewayName)
+	logger.Info("Migrated HTTPRoute to the resolved Gateway", "name", routeName, "from", previous.String(),
+		"to", gwConfig.GatewayNamespace+"/"+gwConfig.GatewayName)
+	if r.Recorder != nil {
+		r.Recorder.Eventf(md, nil, corev1.EventTypeNormal, airunwayv1alpha1.ReasonGatewayMigrated, "Migrate", message)
+	}
+
+	if !r.GatewayDetector.PatchGateway || md.Namespace == previous.Namespace {
+		return
+	}
+	var routes gatewayv1.HTTPRouteList
+	if err := r.List(ctx, &routes, client.InNamespace(md.Namespace)); err != nil {
+		logger.V(1).Info("Could not list HTTPRoutes for previous Gateway cleanup", "error", err)
+		return
+	}
+	for i := range routes.Items {
+		if parent, ok := httpRouteGateway(&routes.Items[i]); ok && parent == previous {
+			return // another route still uses the previous Gateway
+		}
+	}
+	previousConfig := &gateway.GatewayConfig{GatewayName: previous.Name, GatewayNamespace: previous.Namespace}
+	removed, err := r.removeGatewayAllowedNamespace(ctx, previousConfig, md.Namespace)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			logger.V(1).Info("Could not remove namespace from previous Gateway allowedRoutes", "gateway", previous.String(), "error", err)
+		}
+		return
+	}
+	if removed {
+		logger.Info("Removed namespace from previous Gateway allowedRoutes", "gateway", previous.String(), "namespace", md.Namespace)
+	}
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+// DefaultGatewayProbeInterval is how often the gateway endpoint of a running deployment is probed.
+const DefaultGatewayProbeInterval = 5 * time.Minute
+
+// gatewayProbeSuccess reports whether the last gateway endpoint probe of a ModelDeployment succeeded.
+var gatewayProbeSuccess = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "kubeairunway_gateway_probe_success",
+	Help: "Whether the last /v1/models probe through the gateway endpoint of a ModelDeployment succeeded (1) or failed (0).",
+}, []string{"namespace", "name"})
+
+func init() {
+	metrics.Registry.MustRegister(gatewayProbeSuccess)
+}
+
+var gatewayProbeClient = &http.Client{Timeout: 5 * time.Second}
+
+// probeGatewayEndpoint records the result of the last /v1/models request for the
+// deployment's model through status.gateway.endpoint in the GatewayReachable condition and
+// the kubeairunway_gateway_probe_success metric. The request is sent in the background
+// once per GatewayProbeInterval. It returns how long to wait before the next probe is due,
+// or zero when probing is off.
+func (r *ModelDeploymentReconciler) probeGatewayEndpoint(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) time.Duration {
+	key := types.NamespacedName{Name: md.Name, Namespace: md.Namespace}
+	interval := r.settings().GatewayProbeInterval
+	if interval <= 0 || md.Status.Gateway == nil || md.Status.Gateway.Endpoint == "" {
+		r.forgetGatewayProbe(key)
+		meta.RemoveStatusCondition(&md.Status.Conditions, airunwayv1alpha1.ConditionTypeGatewayReachable)
+		return 0
+	}
+
+	endpoint, modelName := md.Status.Gateway.Endpoint, md.Status.Gateway.ModelName
+	result, ok := r.scrape(ctx, md, scrapeGatewayProbe, endpoint+"\n"+modelName, interval, func(ctx context.Context) (interface{}, error) {
+		return nil, sendGatewayProbe(ctx, endpoint, modelName)
+	})
+	if !ok {
+		return interval
+	}
+	if err := result.err; err != nil {
+		log.FromContext(ctx).Info("Gateway endpoint probe failed", "name", md.Name, "endpoint", endpoint, "error", err.Error())
+		gatewayProbeSuccess.WithLabelValues(md.Namespace, md.Name).Set(0)
+		r.setCondition(md, airunwayv1alpha1.ConditionTypeGatewayReachable, metav1.ConditionFalse, "ProbeFailed", err.Error())
+	} else {
+		gatewayProbeSuccess.WithLabelValues(md.Namespace, md.Name).Set(1)
+		r.setCondition(md, airunwayv1alpha1.ConditionTypeGatewayReachable, metav1.ConditionTrue, "ProbeSucceeded", "Gateway endpoint answered /v1/models")
+	}
+	return max(interval-time.Since(result.time), time.Second)
+}
+
+// forgetGatewayProbe drops the probe state and metric series of a ModelDeployment
+func (r *ModelDeploymentReconciler) forgetGatewayProbe(key types.NamespacedName) {
+	r.scrapes.forget(key, scrapeGatewayProbe)
+	gatewayProbeSuccess.DeleteLabelValues(key.Namespace, key.Name)
+}
+
+// sendGatewayProbe requests /v1/models through the gateway. The model name header is set
+// directly because body-based routing cannot derive it from a GET request.
+func sendGatewayProbe(ctx context.Context, endpoint, modelName string) error {
+	base := endpoint
+	if !strings.Contains(base, "://") {
+		base = "http://" + urlHost(base)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(base, "/")+"/v1/models", nil)
+	if err != nil {
+		return fmt.Errorf("invalid gateway endpoint %q: %w", endpoint, err)
+	}
+	if modelName != "" {
+		req.Header.Set("X-Gateway-Model-Name", modelName)
+	}
+
+	resp, err := gatewayProbeClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request to gateway failed: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<20))
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("gateway returned HTTP %d for /v1/models", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+func TestProbeGatewayEndpoint(t *testing.T) {
+	var requests atomic.Int32
+	healthy := atomic.Bool{}
+	healthy.Store(true)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests.Add(1)
+		if req.URL.Path != "/v1/models" || req.Header.Get("X-Gateway-Model-Name") != "llama" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[{"id":"llama"}]}`))
+	}))
+	defer server.Close()
+
+	md := newModelDeployment("probe-model", "default")
+	md.Status.Gateway = &airunwayv1alpha1.GatewayStatus{
+		Endpoint:  strings.TrimPrefix(server.URL, "http://"),
+		ModelName: "llama",
+	}
+	r := &ModelDeploymentReconciler{GatewayProbeInterval: time.Minute}
+	ctx := context.Background()
+	metric := gatewayProbeSuccess.WithLabelValues("default", "probe-model")
+
+	// The probe runs in the background; its result is recorded on the next reconcile
+	if next := r.probeGatewayEndpoint(ctx, md); next != time.Minute {
+		t.Errorf("expected next probe in 1m, got %s", next)
+	}
+	if meta.FindStatusCondition(md.Status.Conditions, airunwayv1alpha1.ConditionTypeGatewayReachable) != nil {
+		t.Error("expected no GatewayReachable condition before the probe completed")
+	}
+	waitForScrapes(r)
+	if next := r.probeGatewayEndpoint(ctx, md); next <= 0 || next > time.Minute {
+		t.Errorf("expected next probe within 1m, got %s", next)
+	}
+	if !meta.IsStatusConditionTrue(md.Status.Conditions, airunwayv1alpha1.ConditionTypeGatewayReachable) {
+		t.Errorf("expected GatewayReachable True, got %+v", md.Status.Conditions)
+	}
+	if got := testutil.ToFloat64(metric); got != 1 {
+		t.Errorf("expected probe success metric 1, got %g", got)
+	}
+
+	// A second reconcile within the interval does not send another request
+	healthy.Store(false)
+	if next := r.probeGatewayEndpoint(ctx, md); next <= 0 || next > time.Minute {
+		t.Errorf("expected remaining interval, got %s", next)
+	}
+	waitForScrapes(r)
+	if requests.Load() != 1 {
+		t.Errorf("expected probing to be rate limited, got %d requests", requests.Load())
+	}
+
+	// Once the interval has passed, the failure is reported
+	expireScrapes(r)
+	r.probeGatewayEndpoint(ctx, md)
+	waitForScrapes(r)
+	r.probeGatewayEndpoint(ctx, md)
+	cond := meta.FindStatusCondition(md.Status.Conditions, airunwayv1alpha1.ConditionTypeGatewayReachable)
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != "ProbeFailed" || !strings.Contains(cond.Message, "503") {
+		t.Errorf("expected GatewayReachable False/ProbeFailed, got %+v", cond)
+	}
+	if got := testutil.ToFloat64(metric); got != 0 {
+		t.Errorf("expected probe success metric 0, got %g", got)
+	}
+
+	// Without a gateway endpoint the condition and metric series are removed
+	md.Status.Gateway = nil
+	if next := r.probeGatewayEndpoint(ctx, md); next != 0 {
+		t.Errorf("expected no requeue without an endpoint, got %s", next)
+	}
+	if meta.FindStatusCondition(md.Status.Conditions, airunwayv1alpha1.ConditionTypeGatewayReachable) != nil {
+		t.Error("expected GatewayReachable condition to be removed")
+	}
+	if n := testutil.CollectAndCount(gatewayProbeSuccess); n != 0 {
+		t.Errorf("expected metric series to be deleted, got %d", n)
+	}
+}
+
+func TestProbeGatewayEndpoint_Disabled(t *testing.T) {
+	md := newModelDeployment("probe-model", "default")
+	md.Status.Gateway = &airunwayv1alpha1.GatewayStatus{Endpoint: "127.0.0.1:1"}
+	r := &ModelDeploymentReconciler{}
+
+	if next := r.probeGatewayEndpoint(context.Background(), md); next != 0 {
+		t.Errorf("expected probing to be disabled, got %s", next)
+	}
+	if meta.FindStatusCondition(md.Status.Conditions, airunwayv1alpha1.ConditionTypeGatewayReachable) != nil {
+		t.Error("expected no GatewayReachable condition when probing is disabled")
+	}
+}
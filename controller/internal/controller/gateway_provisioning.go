@@ -0,0 +1,98 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kaito-project/airunway/controller/internal/gateway"
+)
+
+// provisionGateway creates the default inference Gateway configured by --provision-gateway
+// after validating its GatewayClass. It is only called when the cluster has no Gateway.
+// The Gateway is shared by every ModelDeployment, so it has no owner and is left in place
+// when deployments are deleted.
+func (r *ModelDeploymentReconciler) provisionGateway(ctx context.Context) (*gateway.GatewayConfig, error) {
+	d := r.GatewayDetector
+	if err := r.validateGatewayClass(ctx, d.ProvisionGatewayClassName); err != nil {
+		return nil, err
+	}
+
+	// The controller widens allowedRoutes per ModelDeployment namespace unless patching is
+	// disabled, in which case the Gateway it owns accepts routes from every namespace.
+	from := gatewayv1.NamespacesFromSame
+	if !d.PatchGateway {
+		from = gatewayv1.NamespacesFromAll
+	}
+	gw := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      d.ProvisionGatewayName,
+			Namespace: d.ProvisionGatewayNamespace,
+			Labels: map[string]string{
+				gateway.LabelInferenceGateway:  "true",
+				"app.kubernetes.io/managed-by": "airunway",
+			},
+		},
+		Spec: gatewayv1.GatewaySpec{
+			GatewayClassName: gatewayv1.ObjectName(d.ProvisionGatewayClassName),
+			Listeners: []gatewayv1.Listener{
+				{
+					Name:          "http",
+					Port:          80,
+					Protocol:      gatewayv1.HTTPProtocolType,
+					AllowedRoutes: &gatewayv1.AllowedRoutes{Namespaces: &gatewayv1.RouteNamespaces{From: &from}},
+				},
+			},
+		},
+	}
+	if err := r.Create(ctx, gw); err != nil && !apierrors.IsAlreadyExists(err) {
+		return nil, fmt.Errorf("failed to provision Gateway %s/%s: %w", gw.Namespace, gw.Name, err)
+	} else if err == nil {
+		log.FromContext(ctx).Info("Provisioned inference Gateway", "gateway", gw.Name, "namespace", gw.Namespace,
+			"gatewayClass", d.ProvisionGatewayClassName)
+	}
+
+	return &gateway.GatewayConfig{
+		GatewayName:      d.ProvisionGatewayName,
+		GatewayNamespace: d.ProvisionGatewayNamespace,
+	}, nil
+}
+
+// validateGatewayClass checks that the named GatewayClass exists and has not been
+// rejected by its controller. A class whose Accepted condition is not yet set is allowed.
+func (r *ModelDeploymentReconciler) validateGatewayClass(ctx context.Context, name string) error {
+	var gwClass gatewayv1.GatewayClass
+	if err := r.Get(ctx, client.ObjectKey{Name: name}, &gwClass); err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("no Gateway resources found in cluster and GatewayClass %q for provisioning does not exist", name)
+		}
+		return fmt.Errorf("failed to get GatewayClass %q: %w", name, err)
+	}
+	cond := meta.FindStatusCondition(gwClass.Status.Conditions, string(gatewayv1.GatewayClassConditionStatusAccepted))
+	if cond != nil && cond.Status == metav1.ConditionFalse {
+		return fmt.Errorf("GatewayClass %q is not accepted by its controller: %s", name, cond.Message)
+	}
+	return nil
+}
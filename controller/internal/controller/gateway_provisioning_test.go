@@ -0,0 +1,221 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kaito-project/airunway/controller/internal/gateway"
+)
+
+func provisioningDetector() *gateway.Detector {
+	d := fakeDetector(true, "", "")
+	d.ProvisionGatewayClassName = "istio"
+	d.ProvisionGatewayName = gateway.DefaultProvisionedGatewayName
+	d.ProvisionGatewayNamespace = "airunway-system"
+	return d
+}
+
+func TestResolveGatewayConfig_ProvisionsGateway(t *testing.T) {
+	scheme := newTestScheme()
+	gwClass := &gatewayv1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "istio"},
+		Spec:       gatewayv1.GatewayClassSpec{ControllerName: "istio.io/gateway-controller"},
+	}
+	r := newTestReconciler(scheme, provisioningDetector(), gwClass)
+	ctx := context.Background()
+
+	cfg, err := r.resolveGatewayConfig(ctx, "default")
+	if err != nil {
+		t.Fatalf("resolveGatewayConfig failed: %v", err)
+	}
+	if cfg.GatewayName != "airunway-gateway" || cfg.GatewayNamespace != "airunway-system" {
+		t.Errorf("unexpected gateway config: %+v", cfg)
+	}
+
+	var gw gatewayv1.Gateway
+	if err := r.Get(ctx, types.NamespacedName{Name: "airunway-gateway", Namespace: "airunway-system"}, &gw); err != nil {
+		t.Fatalf("expected Gateway to be provisioned: %v", err)
+	}
+	if gw.Spec.GatewayClassName != "istio" {
+		t.Errorf("expected gatewayClassName istio, got %q", gw.Spec.GatewayClassName)
+	}
+	if gw.Labels[gateway.LabelInferenceGateway] != "true" {
+		t.Error("expected provisioned Gateway to carry the inference-gateway label")
+	}
+	if len(gw.OwnerReferences) != 0 {
+		t.Error("expected provisioned Gateway to have no owner")
+	}
+	if len(gw.Spec.Listeners) != 1 || gw.Spec.Listeners[0].Port != 80 {
+		t.Errorf("expected a single HTTP listener on port 80, got %+v", gw.Spec.Listeners)
+	}
+
+	// The provisioned Gateway is then picked up by auto-detection
+	cfg, err = r.resolveGatewayConfig(ctx, "default")
+	if err != nil {
+		t.Fatalf("resolveGatewayConfig failed: %v", err)
+	}
+	if cfg.GatewayName != "airunway-gateway" {
+		t.Errorf("expected provisioned Gateway to be reused, got %+v", cfg)
+	}
+}
+
+func TestResolveGatewayConfig_ProvisionValidatesGatewayClass(t *testing.T) {
+	scheme := newTestScheme()
+	ctx := context.Background()
+
+	r := newTestReconciler(scheme, provisioningDetector())
+	if _, err := r.resolveGatewayConfig(ctx, "default"); err == nil || !strings.Contains(err.Error(), `GatewayClass "istio"`) {
+		t.Errorf("expected missing GatewayClass error, got %v", err)
+	}
+
+	rejected := &gatewayv1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "istio"},
+		Spec:       gatewayv1.GatewayClassSpec{ControllerName: "istio.io/gateway-controller"},
+		Status: gatewayv1.GatewayClassStatus{Conditions: []metav1.Condition{{
+			Type:    string(gatewayv1.GatewayClassConditionStatusAccepted),
+			Status:  metav1.ConditionFalse,
+			Reason:  string(gatewayv1.GatewayClassReasonUnsupportedVersion),
+			Message: "unsupported Gateway API version",
+		}}},
+	}
+	r = newTestReconciler(scheme, provisioningDetector(), rejected)
+	if _, err := r.resolveGatewayConfig(ctx, "default"); err == nil || !strings.Contains(err.Error(), "not accepted") {
+		t.Errorf("expected rejected GatewayClass error, got %v", err)
+	}
+	var gws gatewayv1.GatewayList
+	if err := r.List(ctx, &gws); err != nil {
+		t.Fatal(err)
+	}
+	if len(gws.Items) != 0 {
+		t.Error("expected no Gateway to be provisioned for a rejected GatewayClass")
+	}
+}
+
+func TestResolveGatewayConfig_NoProvisioning(t *testing.T) {
+	r := newTestReconciler(newTestScheme(), fakeDetector(true, "", ""))
+	if _, err := r.resolveGatewayConfig(context.Background(), "default"); err == nil {
+		t.Error("expected an error when no Gateway exists and provisioning is disabled")
+	}
+}
+
+func labeledGateway(gw *gatewayv1.Gateway, namespaces string) *gatewayv1.Gateway {
+	gw.Labels = map[string]string{gateway.LabelGatewayForNamespace: namespaces}
+	return gw
+}
+
+func TestResolveGatewayConfig_NamespaceMapping(t *testing.T) {
+	ctx := context.Background()
+	gateways := []client.Object{
+		newTestGateway("shared", "gateway-system"),
+		labeledGateway(newTestGateway("team-a", "gateway-system"), "team-a.team-b"),
+		labeledGateway(newTestGateway("team-c", "gateway-system"), "team-c"),
+		labeledGateway(newTestGateway("own", "team-d"), "team-d"),
+		// Gateways outside the mapping namespace cannot claim other namespaces
+		labeledGateway(newTestGateway("rogue", "team-d"), "default.team-e"),
+	}
+	detector := fakeDetector(true, "", "")
+	detector.GatewayMappingNamespace = "gateway-system"
+	r := newTestReconciler(newTestScheme(), detector, gateways...)
+
+	for namespace, want := range map[string]string{"team-a": "team-a", "team-b": "team-a", "team-c": "team-c", "team-d": "own"} {
+		cfg, err := r.resolveGatewayConfig(ctx, namespace)
+		if err != nil {
+			t.Fatalf("resolveGatewayConfig failed for %s: %v", namespace, err)
+		}
+		if cfg.GatewayName != want {
+			t.Errorf("expected namespace %s to use Gateway %s, got %+v", namespace, want, cfg)
+		}
+	}
+	if _, err := r.resolveGatewayConfig(ctx, "team-e"); err == nil {
+		t.Error("expected the label on a Gateway in another namespace to be ignored")
+	}
+
+	// Without a mapping namespace, only Gateways in the mapped namespace itself count
+	r = newTestReconciler(newTestScheme(), fakeDetector(true, "", ""), gateways...)
+	if cfg, err := r.resolveGatewayConfig(ctx, "team-d"); err != nil || cfg.GatewayName != "own" {
+		t.Errorf("expected namespace team-d to use its own Gateway, got %+v, %v", cfg, err)
+	}
+	if _, err := r.resolveGatewayConfig(ctx, "team-a"); err == nil {
+		t.Error("expected the label on a Gateway in gateway-system to be ignored without a mapping namespace")
+	}
+}
+
+func TestResolveGatewayConfig_NamespaceMappingExplicit(t *testing.T) {
+	ctx := context.Background()
+	gateways := []client.Object{
+		newTestGateway("shared", "gateway-system"),
+		labeledGateway(newTestGateway("team-a", "gateway-system"), "team-a"),
+	}
+	detector := fakeDetector(true, "shared", "gateway-system")
+	detector.GatewayMappingNamespace = "gateway-system"
+	r := newTestReconciler(newTestScheme(), detector, gateways...)
+
+	cfg, err := r.resolveGatewayConfig(ctx, "team-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.GatewayName != "shared" {
+		t.Errorf("expected the explicit Gateway to win without the opt-in, got %+v", cfg)
+	}
+
+	detector.GatewayMappingOverridesExplicit = true
+	for namespace, want := range map[string]string{"team-a": "team-a", "default": "shared"} {
+		cfg, err := r.resolveGatewayConfig(ctx, namespace)
+		if err != nil {
+			t.Fatalf("resolveGatewayConfig failed for %s: %v", namespace, err)
+		}
+		if cfg.GatewayName != want {
+			t.Errorf("expected namespace %s to use Gateway %s, got %+v", namespace, want, cfg)
+		}
+	}
+}
+
+func TestResolveGatewayConfig_DefaultGatewayClass(t *testing.T) {
+	ctx := context.Background()
+	inference := newTestGateway("inference", "gateway-system")
+	inference.Spec.GatewayClassName = "kgateway"
+	gateways := []client.Object{
+		newTestGateway("external", "gateway-system"),
+		inference,
+		&gatewayv1.GatewayClass{ObjectMeta: metav1.ObjectMeta{Name: "istio"}},
+	}
+	r := newTestReconciler(newTestScheme(), fakeDetector(true, "", ""), gateways...)
+	if _, err := r.resolveGatewayConfig(ctx, "default"); err == nil {
+		t.Fatal("expected an error when no Gateway is labeled and no GatewayClass is the default")
+	}
+
+	defaultClass := &gatewayv1.GatewayClass{ObjectMeta: metav1.ObjectMeta{
+		Name:        "kgateway",
+		Annotations: map[string]string{gateway.AnnotationDefaultInferenceGateway: "true"},
+	}}
+	r = newTestReconciler(newTestScheme(), fakeDetector(true, "", ""), append(gateways, defaultClass)...)
+	cfg, err := r.resolveGatewayConfig(ctx, "default")
+	if err != nil {
+		t.Fatalf("resolveGatewayConfig failed: %v", err)
+	}
+	if cfg.GatewayName != "inference" {
+		t.Errorf("expected the Gateway of the default GatewayClass, got %+v", cfg)
+	}
+}
@@ -21,8 +21,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -41,7 +44,9 @@ import (
 
 	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
 	"github.com/kaito-project/airunway/controller/internal/gateway"
+	"github.com/kaito-project/airunway/controller/pkg/provider"
 	inferencev1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	inferencev1alpha2 "sigs.k8s.io/gateway-api-inference-extension/apix/v1alpha2"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 )
@@ -73,7 +78,7 @@ func (r *ModelDeploymentReconciler) reconcileGateway(ctx context.Context, md *ai
 	}
 
 	// Resolve gateway configuration
-	gwConfig, err := r.resolveGatewayConfig(ctx)
+	gwConfig, err := r.resolveGatewayConfig(ctx, md.Namespace)
 	if err != nil {
 		logger.Info("No gateway found for routing, skipping gateway reconciliation", "reason", err.Error())
 		r.setCondition(md, airunwayv1alpha1.ConditionTypeGatewayReady, metav1.ConditionFalse, "NoGateway", err.Error())
@@ -108,7 +113,7 @@ func (r *ModelDeploymentReconciler) reconcileGateway(ctx context.Context, md *ai
 	// Use provider managed inference pool if it exists,
 	// otherwise use the default inference pool.
 	if ok, err := r.providerInferencePoolExistsOrCreateDefault(ctx, md, gatewayCapabilities, gwConfig); ok && err == nil {
-		logger.Info("Skipping InferencePool creation, provider manages InferencePool", "provider", md.Spec.Provider.Name)
+		logger.Info("Skipping InferencePool creation, provider manages InferencePool", "provider", providerNameOf(md))
 
 		// Resolve the InferencePool name for the provider.
 		// The provider-managed pool will be configured to be named with the model deployment name and namespace.
@@ -132,54 +137,128 @@ func (r *ModelDeploymentReconciler) reconcileGateway(ctx context.Context, md *ai
 		return err
 	}
 
-	if gatewayCapabilities != nil {
-		logger.Info("Skipping EPP creation, provider manages EPP", "provider", md.Spec.Provider.Name)
+	// Resolve model name early (needed for EPP span tags, HTTPRoute header match and status)
+	servedName := r.resolveModelName(ctx, md)
+	modelName, err := publicModelName(md, servedName)
+	if err != nil {
+		r.setCondition(md, airunwayv1alpha1.ConditionTypeGatewayReady, metav1.ConditionFalse, "InvalidModelNameTemplate", err.Error())
+		return nil
+	}
+
+	if gatewayCapabilities.ProviderManaged() {
+		logger.Info("Skipping EPP creation, provider manages EPP", "provider", providerNameOf(md))
 	} else { // Use default EPP
 		// Create or update EPP (EndPoint Picker) for the InferencePool
-		if err := r.reconcileEPP(ctx, md); err != nil {
+		if err := r.reconcileEPP(ctx, md, modelName); err != nil {
 			r.setCondition(md, airunwayv1alpha1.ConditionTypeGatewayReady, metav1.ConditionFalse, "EPPFailed", err.Error())
 			return fmt.Errorf("reconciling EPP: %w", err)
 		}
 	}
 
 	backend := httpRouteBackendTarget{
-		group:     "inference.networking.k8s.io",
+		group:     gatewayv1.Group(r.inferencePoolGroup()),
 		kind:      "InferencePool",
 		name:      poolName,
 		namespace: poolNamespace,
 	}
 
-	// Resolve model name early (needed for HTTPRoute header match and status)
-	modelName := r.resolveModelName(ctx, md)
+	var fallbackState airunwayv1alpha1.GatewayFallbackState
+	if err := r.reconcileModelRewrite(ctx, md, poolName, poolNamespace, modelName, servedName); err != nil {
+		r.setCondition(md, airunwayv1alpha1.ConditionTypeGatewayReady, metav1.ConditionFalse, "ModelRewriteFailed", err.Error())
+		return fmt.Errorf("reconciling InferenceModelRewrite: %w", err)
+	}
 
 	// Create or update HTTPRoute (skip if user provides their own)
 	if md.Spec.Gateway != nil && md.Spec.Gateway.HTTPRouteRef != "" {
 		logger.V(1).Info("Using user-provided HTTPRoute", "httpRouteRef", md.Spec.Gateway.HTTPRouteRef)
+		if err := r.releaseGeneratedHTTPRoute(ctx, md); err != nil {
+			r.setCondition(md, airunwayv1alpha1.ConditionTypeGatewayReady, metav1.ConditionFalse, "HTTPRouteFailed", err.Error())
+			return fmt.Errorf("releasing generated HTTPRoute: %w", err)
+		}
+		// spec.gateway.fallback is ignored with a user-provided HTTPRoute
+		if err := r.reconcileFallbackService(ctx, md); err != nil {
+			r.setCondition(md, airunwayv1alpha1.ConditionTypeGatewayReady, metav1.ConditionFalse, "HTTPRouteFailed", err.Error())
+			return fmt.Errorf("releasing gateway fallback: %w", err)
+		}
+		reason, message, err := r.checkHTTPRouteRef(ctx, md, backend)
+		if err != nil {
+			r.setCondition(md, airunwayv1alpha1.ConditionTypeGatewayReady, metav1.ConditionFalse, "HTTPRouteFailed", err.Error())
+			return fmt.Errorf("checking HTTPRoute: %w", err)
+		}
+		if reason != "" {
+			logger.Info("User-provided HTTPRoute cannot serve the deployment", "httpRouteRef", md.Spec.Gateway.HTTPRouteRef, "reason", reason)
+			r.setCondition(md, airunwayv1alpha1.ConditionTypeGatewayReady, metav1.ConditionFalse, reason, message)
+			return nil
+		}
 	} else {
+		backend, fallbackState, err = r.withGatewayFallback(ctx, md, backend)
+		if err != nil {
+			r.setCondition(md, airunwayv1alpha1.ConditionTypeGatewayReady, metav1.ConditionFalse, "FallbackFailed", err.Error())
+			return fmt.Errorf("reconciling gateway fallback: %w", err)
+		}
 		if err := r.reconcileHTTPRoute(ctx, md, gwConfig, modelName, backend); err != nil {
 			r.setCondition(md, airunwayv1alpha1.ConditionTypeGatewayReady, metav1.ConditionFalse, "HTTPRouteFailed", err.Error())
 			return fmt.Errorf("reconciling HTTPRoute: %w", err)
 		}
 	}
 
+	if err := r.reconcileRateLimitPolicy(ctx, md, gwConfig); err != nil {
+		r.setCondition(md, airunwayv1alpha1.ConditionTypeGatewayReady, metav1.ConditionFalse, "RateLimitPolicyFailed", err.Error())
+		return fmt.Errorf("reconciling rate limit policy: %w", err)
+	}
+
+	promptPolicyWarning, err := r.reconcilePromptPolicy(ctx, md, gwConfig)
+	if err != nil {
+		r.setCondition(md, airunwayv1alpha1.ConditionTypeGatewayReady, metav1.ConditionFalse, "PromptPolicyFailed", err.Error())
+		return fmt.Errorf("reconciling prompt policy: %w", err)
+	}
+
 	// Update gateway status
 	endpoint := r.resolveGatewayEndpoint(ctx, gwConfig)
+	var previousFallback airunwayv1alpha1.GatewayFallbackState
+	if md.Status.Gateway != nil {
+		previousFallback = md.Status.Gateway.Fallback
+	}
 	md.Status.Gateway = &airunwayv1alpha1.GatewayStatus{
 		Endpoint:         endpoint,
 		ModelName:        modelName,
 		GatewayNamespace: gwConfig.GatewayNamespace,
+		ModelDiscovery:   modelDiscoveryStatus(md),
+		Fallback:         previousFallback,
+	}
+	r.setGatewayFallbackState(md, fallbackState)
+	readyMessage := "InferencePool and HTTPRoute created"
+	if gatewayCapabilities.ProviderManaged() {
+		readyMessage = fmt.Sprintf("HTTPRoute routes to provider-managed InferencePool %s/%s", poolNamespace, poolName)
 	}
-	r.setCondition(md, airunwayv1alpha1.ConditionTypeGatewayReady, metav1.ConditionTrue, "GatewayConfigured", "InferencePool and HTTPRoute created")
+	if md.Spec.Gateway != nil && md.Spec.Gateway.HTTPRouteRef != "" {
+		readyMessage = fmt.Sprintf("HTTPRoute %s is accepted and routes to InferencePool %s/%s", md.Spec.Gateway.HTTPRouteRef, poolNamespace, poolName)
+	}
+	if promptPolicyWarning != "" {
+		readyMessage += "; " + promptPolicyWarning
+	}
+	r.setCondition(md, airunwayv1alpha1.ConditionTypeGatewayReady, metav1.ConditionTrue, "GatewayConfigured", readyMessage)
 
 	logger.Info("Gateway resources reconciled", "name", md.Name, "gateway", gwConfig.GatewayName, "model", modelName)
 	return nil
 }
 
-// resolveGatewayConfig determines which Gateway to use as the HTTPRoute parent.
-func (r *ModelDeploymentReconciler) resolveGatewayConfig(ctx context.Context) (*gateway.GatewayConfig, error) {
-	// Try explicit configuration first
-	if cfg, err := r.GatewayDetector.GetGatewayConfig(); err == nil {
-		return cfg, nil
+// resolveGatewayConfig returns the Gateway the ModelDeployments of namespace route through:
+// the configured Gateway, then a Gateway labeled with gateway.LabelGatewayForNamespace for
+// namespace, then an auto-detected one. The labeled Gateway comes first when the admin opts
+// in with GatewayMappingOverridesExplicit.
+func (r *ModelDeploymentReconciler) resolveGatewayConfig(ctx context.Context, namespace string) (*gateway.GatewayConfig, error) {
+	explicit, explicitErr := r.GatewayDetector.GetGatewayConfig()
+	if explicitErr == nil && !r.GatewayDetector.GatewayMappingOverridesExplicit {
+		return explicit, nil
+	}
+
+	// A Gateway mapped to the namespace takes precedence over auto-detection
+	if cfg, err := r.namespaceGatewayConfig(ctx, namespace); err != nil || cfg != nil {
+		return cfg, err
+	}
+	if explicitErr == nil {
+		return explicit, nil
 	}
 
 	// Auto-detect: list Gateway resources in the cluster
@@ -190,12 +269,16 @@ func (r *ModelDeploymentReconciler) resolveGatewayConfig(ctx context.Context) (*
 
 	switch len(gateways.Items) {
 	case 0:
+		if r.GatewayDetector.ShouldProvisionGateway() {
+			return r.provisionGateway(ctx)
+		}
 		return nil, fmt.Errorf("no Gateway resources found in cluster")
 	case 1:
 		gw := &gateways.Items[0]
 		return gatewayConfigFromResource(gw), nil
 	default:
-		// Multiple gateways: look for ones with the inference-gateway label
+		// Multiple gateways: look for ones with the inference-gateway label, then for ones of
+		// a GatewayClass marked as the default
 		var labeled []*gatewayv1.Gateway
 		for i := range gateways.Items {
 			gw := &gateways.Items[i]
@@ -204,16 +287,81 @@ func (r *ModelDeploymentReconciler) resolveGatewayConfig(ctx context.Context) (*
 			}
 		}
 		if len(labeled) == 0 {
-			return nil, fmt.Errorf("multiple Gateways found but none labeled with %s=true", gateway.LabelInferenceGateway)
+			var err error
+			if labeled, err = r.defaultClassGateways(ctx, gateways.Items); err != nil {
+				return nil, err
+			}
+		}
+		if len(labeled) == 0 {
+			return nil, fmt.Errorf("multiple Gateways found but none labeled with %s=true or of a GatewayClass annotated with %s=true",
+				gateway.LabelInferenceGateway, gateway.AnnotationDefaultInferenceGateway)
 		}
 		if len(labeled) > 1 {
-			log.FromContext(ctx).Info("WARNING: multiple Gateways labeled with inference-gateway, using the first one. Consider using spec.gateway.gatewayRef for explicit selection.",
+			log.FromContext(ctx).Info("WARNING: multiple inference Gateways found, using the first one. Consider using spec.gateway.gatewayRef for explicit selection.",
 				"count", len(labeled), "selected", labeled[0].Name)
 		}
 		return gatewayConfigFromResource(labeled[0]), nil
 	}
 }
 
+// namespaceGatewayConfig returns the Gateway labeled with gateway.LabelGatewayForNamespace
+// for namespace, or nil when there is none. Only Gateways in namespace itself or in the
+// GatewayMappingNamespace are considered, so tenants cannot claim other namespaces. When
+// several match, the first by namespace and name is used.
+func (r *ModelDeploymentReconciler) namespaceGatewayConfig(ctx context.Context, namespace string) (*gateway.GatewayConfig, error) {
+	namespaces := []string{namespace}
+	if ns := r.GatewayDetector.GatewayMappingNamespace; ns != "" && ns != namespace {
+		namespaces = append(namespaces, ns)
+	}
+	var mapped []*gatewayv1.Gateway
+	for _, ns := range namespaces {
+		var gateways gatewayv1.GatewayList
+		if err := r.List(ctx, &gateways, client.InNamespace(ns), client.HasLabels{gateway.LabelGatewayForNamespace}); err != nil {
+			return nil, fmt.Errorf("failed to list gateways: %w", err)
+		}
+		for i := range gateways.Items {
+			gw := &gateways.Items[i]
+			if slices.Contains(strings.Split(gw.Labels[gateway.LabelGatewayForNamespace], "."), namespace) {
+				mapped = append(mapped, gw)
+			}
+		}
+	}
+	if len(mapped) == 0 {
+		return nil, nil
+	}
+	slices.SortFunc(mapped, func(a, b *gatewayv1.Gateway) int {
+		return strings.Compare(a.Namespace+"/"+a.Name, b.Namespace+"/"+b.Name)
+	})
+	if len(mapped) > 1 {
+		log.FromContext(ctx).Info("WARNING: multiple Gateways mapped to the namespace, using the first one",
+			"namespace", namespace, "count", len(mapped), "selected", mapped[0].Namespace+"/"+mapped[0].Name)
+	}
+	return gatewayConfigFromResource(mapped[0]), nil
+}
+
+// defaultClassGateways returns the Gateways whose GatewayClass is annotated with
+// gateway.AnnotationDefaultInferenceGateway=true
+func (r *ModelDeploymentReconciler) defaultClassGateways(ctx context.Context, gateways []gatewayv1.Gateway) ([]*gatewayv1.Gateway, error) {
+	defaults := map[gatewayv1.ObjectName]bool{}
+	var matched []*gatewayv1.Gateway
+	for i := range gateways {
+		gw := &gateways[i]
+		isDefault, ok := defaults[gw.Spec.GatewayClassName]
+		if !ok {
+			var gwClass gatewayv1.GatewayClass
+			if err := r.Get(ctx, client.ObjectKey{Name: string(gw.Spec.GatewayClassName)}, &gwClass); client.IgnoreNotFound(err) != nil {
+				return nil, fmt.Errorf("failed to get GatewayClass %q: %w", gw.Spec.GatewayClassName, err)
+			}
+			isDefault = gwClass.Annotations[gateway.AnnotationDefaultInferenceGateway] == "true"
+			defaults[gw.Spec.GatewayClassName] = isDefault
+		}
+		if isDefault {
+			matched = append(matched, gw)
+		}
+	}
+	return matched, nil
+}
+
 // gatewayConfigFromResource builds a GatewayConfig from a Gateway resource,
 // reading the optional airunway.ai/bbr-namespace annotation.
 func gatewayConfigFromResource(gw *gatewayv1.Gateway) *gateway.GatewayConfig {
@@ -229,39 +377,47 @@ func gatewayConfigFromResource(gw *gatewayv1.Gateway) *gateway.GatewayConfig {
 
 // reconcileInferencePool creates or updates the InferencePool for a ModelDeployment.
 func (r *ModelDeploymentReconciler) reconcileInferencePool(ctx context.Context, md *airunwayv1alpha1.ModelDeployment, port int32, bbrNamespace string) error {
-	pool := &inferencev1.InferencePool{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      md.Name,
-			Namespace: md.Namespace,
-		},
-	}
+	pool := r.newInferencePool(md.Name, md.Namespace)
 
 	eppName := md.Name + "-epp"
 	eppPort := r.GatewayDetector.EPPServicePort
 	if eppPort == 0 {
 		eppPort = 9002
 	}
-
-	result, err := ctrl.CreateOrUpdate(ctx, r.Client, pool, func() error {
-		pool.Spec.Selector = inferencev1.LabelSelector{
-			MatchLabels: map[inferencev1.LabelKey]inferencev1.LabelValue{
-				inferencev1.LabelKey(airunwayv1alpha1.LabelModelDeployment): inferencev1.LabelValue(md.Name),
-			},
+	matchLabels := map[inferencev1.LabelKey]inferencev1.LabelValue{
+		inferencev1.LabelKey(airunwayv1alpha1.LabelModelDeployment): inferencev1.LabelValue(md.Name),
+	}
+	if selector := r.migrationPoolSelector(ctx, md); len(selector) > 0 {
+		matchLabels = make(map[inferencev1.LabelKey]inferencev1.LabelValue, len(selector))
+		for k, v := range selector {
+			matchLabels[inferencev1.LabelKey(k)] = inferencev1.LabelValue(v)
 		}
-		pool.Spec.TargetPorts = []inferencev1.Port{
+	}
+	spec := inferencev1.InferencePoolSpec{
+		Selector: inferencev1.LabelSelector{
+			MatchLabels: matchLabels,
+		},
+		TargetPorts: []inferencev1.Port{
 			{Number: inferencev1.PortNumber(port)},
-		}
-		pool.Spec.EndpointPickerRef = inferencev1.EndpointPickerRef{
+		},
+		EndpointPickerRef: inferencev1.EndpointPickerRef{
 			Name: inferencev1.ObjectName(eppName),
 			Port: &inferencev1.Port{Number: inferencev1.PortNumber(eppPort)},
+		},
+	}
+
+	result, err := ctrl.CreateOrUpdate(ctx, r.Client, pool, func() error {
+		if err := setInferencePoolSpec(pool, spec); err != nil {
+			return err
 		}
+		provider.ApplyPropagatedMetadataToObject(pool, md)
 		return ctrl.SetControllerReference(md, pool, r.Scheme)
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create/update InferencePool: %w", err)
 	}
 
-	log.FromContext(ctx).V(1).Info("InferencePool reconciled", "name", pool.Name, "result", result)
+	log.FromContext(ctx).V(1).Info("InferencePool reconciled", "name", pool.GetName(), "result", result)
 
 	// When a new InferencePool is created, restart the BBR deployment (if present) so it
 	// discovers the new model. BBR watches ConfigMaps via controller-runtime and rebuilds
@@ -281,8 +437,8 @@ func (r *ModelDeploymentReconciler) reconcileProviderManagedInferencePool(ctx co
 	mdNamespace := md.Namespace
 
 	// Wait for the pool to exist (requeue if not ready).
-	pool := &inferencev1.InferencePool{}
-	poolKey := client.ObjectKey{Name: poolName, Namespace: poolNamespace}
+	pool := r.newInferencePool(poolName, poolNamespace)
+	poolKey := client.ObjectKeyFromObject(pool)
 	if err := r.Get(ctx, poolKey, pool); err != nil {
 		if apierrors.IsNotFound(err) {
 			logger.Info("Provider-managed InferencePool not found yet, requeuing",
@@ -336,9 +492,9 @@ func (r *ModelDeploymentReconciler) reconcileProviderManagedInferencePool(ctx co
 			}
 			rg.Spec.To = []gatewayv1beta1.ReferenceGrantTo{
 				{
-					Group: "inference.networking.k8s.io",
+					Group: gatewayv1beta1.Group(r.inferencePoolGroup()),
 					Kind:  "InferencePool",
-					Name:  (*gatewayv1beta1.ObjectName)(&pool.Name),
+					Name:  (*gatewayv1beta1.ObjectName)(&poolName),
 				},
 			}
 			return ctrl.SetControllerReference(pool, rg, r.Scheme)
@@ -351,8 +507,56 @@ func (r *ModelDeploymentReconciler) reconcileProviderManagedInferencePool(ctx co
 	}
 
 	// Return the EPP service name from the InferencePool's EndpointPickerRef
-	eppName := string(pool.Spec.EndpointPickerRef.Name)
-	return eppName, nil
+	return inferencePoolEPPName(pool), nil
+}
+
+// inferencePoolGroup returns the API group of the InferencePools the cluster serves
+func (r *ModelDeploymentReconciler) inferencePoolGroup() string {
+	if r.GatewayDetector == nil {
+		return gateway.InferencePoolCRDGroup
+	}
+	return r.GatewayDetector.InferencePoolGroupVersion().Group
+}
+
+// newInferencePool returns an empty InferencePool of the API version the cluster serves:
+// an inferencev1.InferencePool, or an inferencev1alpha2.InferencePool on clusters that
+// only have an older GAIE install.
+func (r *ModelDeploymentReconciler) newInferencePool(name, namespace string) client.Object {
+	objectMeta := metav1.ObjectMeta{Name: name, Namespace: namespace}
+	if r.inferencePoolGroup() == gateway.InferencePoolAlphaCRDGroup {
+		return &inferencev1alpha2.InferencePool{ObjectMeta: objectMeta}
+	}
+	return &inferencev1.InferencePool{ObjectMeta: objectMeta}
+}
+
+// setInferencePoolSpec sets the spec of an InferencePool from newInferencePool to spec,
+// converting it for v1alpha2 pools.
+func setInferencePoolSpec(pool client.Object, spec inferencev1.InferencePoolSpec) error {
+	switch pool := pool.(type) {
+	case *inferencev1.InferencePool:
+		pool.Spec = spec
+	case *inferencev1alpha2.InferencePool:
+		converted := &inferencev1alpha2.InferencePool{}
+		if err := converted.ConvertFrom(&inferencev1.InferencePool{Spec: spec}); err != nil {
+			return fmt.Errorf("failed to convert InferencePool to %s: %w", inferencev1alpha2.GroupVersion, err)
+		}
+		pool.Spec = converted.Spec
+	default:
+		return fmt.Errorf("unexpected InferencePool type %T", pool)
+	}
+	return nil
+}
+
+// inferencePoolEPPName returns the name of the endpoint picker of an InferencePool from
+// newInferencePool
+func inferencePoolEPPName(pool client.Object) string {
+	switch pool := pool.(type) {
+	case *inferencev1.InferencePool:
+		return string(pool.Spec.EndpointPickerRef.Name)
+	case *inferencev1alpha2.InferencePool:
+		return string(pool.Spec.ExtensionRef.Name)
+	}
+	return ""
 }
 
 // resolveProviderInferencePoolName applies the provider's naming pattern to produce the
@@ -369,7 +573,7 @@ func resolveProviderInferencePoolName(pattern, mdName, mdNamespace string) strin
 
 // reconcileEPP creates or updates the Endpoint Picker Proxy deployment and service
 // for a ModelDeployment's InferencePool.
-func (r *ModelDeploymentReconciler) reconcileEPP(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) error {
+func (r *ModelDeploymentReconciler) reconcileEPP(ctx context.Context, md *airunwayv1alpha1.ModelDeployment, modelName string) error {
 	eppName := md.Name + "-epp"
 	eppPort := r.GatewayDetector.EPPServicePort
 	if eppPort == 0 {
@@ -377,7 +581,15 @@ func (r *ModelDeploymentReconciler) reconcileEPP(ctx context.Context, md *airunw
 	}
 	eppImage := r.GatewayDetector.EPPImage
 	if eppImage == "" {
-		eppImage = "registry.k8s.io/gateway-api-inference-extension/epp:" + gateway.DefaultGAIEVersion
+		eppImage = gateway.DefaultEPPImage
+	}
+	securityContext := r.GatewayDetector.EPPSecurityContext
+	if securityContext == nil {
+		securityContext = gateway.DefaultEPPSecurityContext()
+	}
+	var pullSecrets []corev1.LocalObjectReference
+	for _, name := range r.GatewayDetector.EPPImagePullSecrets {
+		pullSecrets = append(pullSecrets, corev1.LocalObjectReference{Name: name})
 	}
 
 	labels := map[string]string{
@@ -386,96 +598,89 @@ func (r *ModelDeploymentReconciler) reconcileEPP(ctx context.Context, md *airunw
 		"app.kubernetes.io/managed-by": "airunway",
 	}
 
-	// ServiceAccount
-	sa := &corev1.ServiceAccount{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      eppName,
-			Namespace: md.Namespace,
-		},
-	}
-	if _, err := ctrl.CreateOrUpdate(ctx, r.Client, sa, func() error {
-		return ctrl.SetControllerReference(md, sa, r.Scheme)
-	}); err != nil {
-		return fmt.Errorf("failed to create/update EPP ServiceAccount: %w", err)
+	// ServiceAccount, Role, and RoleBinding (the EPP watches pods and inferencepools)
+	serviceAccountName, err := r.reconcileEPPRBAC(ctx, md, eppName)
+	if err != nil {
+		return err
 	}
 
-	// Role for EPP (needs to watch pods and inferencepools)
-	role := &rbacv1.Role{
+	// ConfigMap for EPP plugins config. The EPP only reads it at startup, so the pod
+	// template carries a checksum of the config to roll the Deployment when it changes.
+	eppConfig := gateway.EPPConfigFor(md.Spec.Gateway, md.RouterMode(), md.ResolvedEngineType())
+	cm := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      eppName,
 			Namespace: md.Namespace,
 		},
 	}
-	if _, err := ctrl.CreateOrUpdate(ctx, r.Client, role, func() error {
-		role.Rules = []rbacv1.PolicyRule{
-			{
-				APIGroups: []string{""},
-				Resources: []string{"pods"},
-				Verbs:     []string{"get", "watch", "list"},
-			},
-			{
-				APIGroups: []string{"inference.networking.k8s.io"},
-				Resources: []string{"inferencepools"},
-				Verbs:     []string{"get", "watch", "list"},
-			},
-			{
-				APIGroups: []string{"coordination.k8s.io"},
-				Resources: []string{"leases"},
-				Verbs:     []string{"create", "get", "update"},
-			},
-			{
-				APIGroups: []string{"inference.networking.x-k8s.io"},
-				Resources: []string{"inferenceobjectives", "inferencemodelrewrites"},
-				Verbs:     []string{"get", "watch", "list"},
-			},
+	if _, err := ctrl.CreateOrUpdate(ctx, r.Client, cm, func() error {
+		cm.Data = map[string]string{
+			gateway.EPPConfigFile: eppConfig,
 		}
-		return ctrl.SetControllerReference(md, role, r.Scheme)
+		provider.ApplyPropagatedMetadataToObject(cm, md)
+		return ctrl.SetControllerReference(md, cm, r.Scheme)
 	}); err != nil {
-		return fmt.Errorf("failed to create/update EPP Role: %w", err)
+		return fmt.Errorf("failed to create/update EPP ConfigMap: %w", err)
 	}
 
-	// RoleBinding
-	rb := &rbacv1.RoleBinding{
+	// The EPP ships its logs to spec.observability.logSink with a sidecar whose config
+	// lives in a ConfigMap of its own, apart from the one the provider creates for the
+	// model server pods
+	logSinkConfig, err := provider.LogSinkConfig(md)
+	if err != nil {
+		return err
+	}
+	logSinkCM := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      eppName,
+			Name:      provider.LogSinkConfigMapName(eppName),
 			Namespace: md.Namespace,
 		},
 	}
-	if _, err := ctrl.CreateOrUpdate(ctx, r.Client, rb, func() error {
-		rb.RoleRef = rbacv1.RoleRef{
-			APIGroup: "rbac.authorization.k8s.io",
-			Kind:     "Role",
-			Name:     eppName,
+	if logSinkConfig == "" {
+		if err := r.Get(ctx, client.ObjectKeyFromObject(logSinkCM), logSinkCM); err == nil {
+			if err := r.Delete(ctx, logSinkCM); client.IgnoreNotFound(err) != nil {
+				return fmt.Errorf("failed to delete EPP log sink ConfigMap: %w", err)
+			}
 		}
-		rb.Subjects = []rbacv1.Subject{
-			{
-				Kind:      "ServiceAccount",
-				Name:      eppName,
-				Namespace: md.Namespace,
-			},
+	} else if _, err := ctrl.CreateOrUpdate(ctx, r.Client, logSinkCM, func() error {
+		logSinkCM.Data = map[string]string{
+			provider.LogSinkConfigFileName: logSinkConfig,
 		}
-		return ctrl.SetControllerReference(md, rb, r.Scheme)
+		provider.ApplyPropagatedMetadataToObject(logSinkCM, md)
+		return ctrl.SetControllerReference(md, logSinkCM, r.Scheme)
 	}); err != nil {
-		return fmt.Errorf("failed to create/update EPP RoleBinding: %w", err)
+		return fmt.Errorf("failed to create/update EPP log sink ConfigMap: %w", err)
 	}
 
-	// ConfigMap for EPP plugins config
-	cm := &corev1.ConfigMap{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      eppName,
-			Namespace: md.Namespace,
-		},
+	// The EPP exports spans only when spec.observability.tracing is enabled and a
+	// collector endpoint is known
+	tracingArg := "--tracing=false"
+	env := []corev1.EnvVar{
+		{Name: "NAMESPACE", ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.namespace"},
+		}},
+		{Name: "POD_NAME", ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"},
+		}},
 	}
-	if _, err := ctrl.CreateOrUpdate(ctx, r.Client, cm, func() error {
-		cm.Data = map[string]string{
-			"default-plugins.yaml": `apiVersion: inference.networking.x-k8s.io/v1alpha1
-kind: EndpointPickerConfig
-`,
-		}
-		return ctrl.SetControllerReference(md, cm, r.Scheme)
-	}); err != nil {
-		return fmt.Errorf("failed to create/update EPP ConfigMap: %w", err)
+	if tracing := r.eppTracingConfig(ctx, md, modelName); tracing != nil {
+		tracingArg = "--tracing=true"
+		env = append(env, gateway.TracingEnv(*tracing)...)
+	}
+
+	eppArgs := []string{
+		"--pool-name", md.Name,
+		"--pool-namespace", md.Namespace,
 	}
+	// The EPP watches v1 InferencePools unless told otherwise
+	if group := r.inferencePoolGroup(); group != gateway.InferencePoolCRDGroup {
+		eppArgs = append(eppArgs, "--pool-group", group)
+	}
+	eppArgs = append(eppArgs,
+		"--zap-encoder", "json",
+		"--config-file", "/config/"+gateway.EPPConfigFile,
+		tracingArg,
+	)
 
 	// Deployment
 	replicas := int32(1)
@@ -491,34 +696,28 @@ kind: EndpointPickerConfig
 			Strategy: appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
 			Selector: &metav1.LabelSelector{MatchLabels: labels},
 			Template: corev1.PodTemplateSpec{
-				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+					Annotations: map[string]string{
+						gateway.AnnotationEPPConfigChecksum: gateway.EPPConfigChecksum(eppConfig),
+					},
+				},
 				Spec: corev1.PodSpec{
-					ServiceAccountName:            eppName,
+					ServiceAccountName:            serviceAccountName,
 					TerminationGracePeriodSeconds: int64Ptr(130),
+					ImagePullSecrets:              pullSecrets,
 					Containers: []corev1.Container{
 						{
 							Name:            "epp",
 							Image:           eppImage,
 							ImagePullPolicy: corev1.PullIfNotPresent,
-							Args: []string{
-								"--pool-name", md.Name,
-								"--pool-namespace", md.Namespace,
-								"--zap-encoder", "json",
-								"--config-file", "/config/default-plugins.yaml",
-								"--tracing=false",
-							},
+							SecurityContext: securityContext.DeepCopy(),
+							Args:            eppArgs,
 							Ports: []corev1.ContainerPort{
 								{Name: "grpc", ContainerPort: eppPort},
 								{Name: "grpc-health", ContainerPort: 9003},
 							},
-							Env: []corev1.EnvVar{
-								{Name: "NAMESPACE", ValueFrom: &corev1.EnvVarSource{
-									FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.namespace"},
-								}},
-								{Name: "POD_NAME", ValueFrom: &corev1.EnvVarSource{
-									FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"},
-								}},
-							},
+							Env: env,
 							LivenessProbe: &corev1.Probe{
 								ProbeHandler:        corev1.ProbeHandler{GRPC: &corev1.GRPCAction{Port: 9003, Service: strPtr("inference-extension")}},
 								InitialDelaySeconds: 30,
@@ -548,6 +747,19 @@ kind: EndpointPickerConfig
 				},
 			},
 		}
+		if sidecar := provider.LogSinkContainer(md); sidecar != nil {
+			podSpec := &dep.Spec.Template.Spec
+			podSpec.Containers = append(podSpec.Containers, *sidecar)
+			for _, volume := range provider.LogSinkVolumes(md) {
+				if volume.ConfigMap != nil {
+					volume.ConfigMap.Name = logSinkCM.Name
+				}
+				podSpec.Volumes = append(podSpec.Volumes, volume)
+			}
+			dep.Spec.Template.Annotations[provider.LogSinkConfigHashAnnot] = provider.LogSinkConfigHash(logSinkConfig)
+		}
+		provider.ApplyPropagatedMetadataToObject(dep, md)
+		provider.ApplyPropagatedMetadataToObject(&dep.Spec.Template, md)
 		return ctrl.SetControllerReference(md, dep, r.Scheme)
 	}); err != nil {
 		return fmt.Errorf("failed to create/update EPP Deployment: %w", err)
@@ -569,6 +781,8 @@ kind: EndpointPickerConfig
 			},
 			Type: corev1.ServiceTypeClusterIP,
 		}
+		provider.ApplyIPFamiliesToService(&svc.Spec, md)
+		provider.ApplyPropagatedMetadataToObject(svc, md)
 		return ctrl.SetControllerReference(md, svc, r.Scheme)
 	}); err != nil {
 		return fmt.Errorf("failed to create/update EPP Service: %w", err)
@@ -615,24 +829,149 @@ func (r *ModelDeploymentReconciler) reconcileEPPDestinationRule(ctx context.Cont
 		}, "spec"); err != nil {
 			return err
 		}
+		provider.ApplyPropagatedMetadataToObject(dr, md)
 		return ctrl.SetControllerReference(md, dr, r.Scheme)
 	})
 	return err
 }
 
+// publicModelName renders spec.gateway.modelNameTemplate, if set, into the model name
+// clients use through the gateway. Returns servedName unchanged when no template is set.
+func publicModelName(md *airunwayv1alpha1.ModelDeployment, servedName string) (string, error) {
+	if md.Spec.Gateway == nil || md.Spec.Gateway.ModelNameTemplate == "" {
+		return servedName, nil
+	}
+	return gateway.RenderModelName(md.Spec.Gateway.ModelNameTemplate, gateway.ModelNameTemplateData{
+		Namespace: md.Namespace,
+		Name:      md.Name,
+		ModelName: servedName,
+	})
+}
+
+// gatewayModelNames returns the model names the gateway routes to md: the public name
+// followed by spec.gateway.modelAliases
+func gatewayModelNames(md *airunwayv1alpha1.ModelDeployment, publicName string) []string {
+	names := []string{publicName}
+	if md.Spec.Gateway == nil {
+		return names
+	}
+	for _, alias := range md.Spec.Gateway.ModelAliases {
+		if !slices.Contains(names, alias) {
+			names = append(names, alias)
+		}
+	}
+	return names
+}
+
+// httpRouteModelNames returns the model names the HTTPRoute of md matches: the gateway
+// model names followed by the spec.model.adapters, which the engine serves under their own
+// names and are not rewritten
+func httpRouteModelNames(md *airunwayv1alpha1.ModelDeployment, publicName string) []string {
+	names := gatewayModelNames(md, publicName)
+	for _, adapter := range md.Spec.Model.Adapters {
+		if !slices.Contains(names, adapter.Name) {
+			names = append(names, adapter.Name)
+		}
+	}
+	return names
+}
+
+// reconcileModelRewrite creates an InferenceModelRewrite that maps the public model name
+// and spec.gateway.modelAliases to the served model name, and removes it when clients can
+// only send the served name. The rewrite must live next to the InferencePool, so it is
+// skipped for pools in another namespace.
+func (r *ModelDeploymentReconciler) reconcileModelRewrite(ctx context.Context, md *airunwayv1alpha1.ModelDeployment, poolName, poolNamespace, publicName, servedName string) error {
+	logger := log.FromContext(ctx)
+
+	var rewritten []string
+	for _, name := range gatewayModelNames(md, publicName) {
+		if name != servedName {
+			rewritten = append(rewritten, name)
+		}
+	}
+
+	if _, err := r.Client.RESTMapper().RESTMapping(gateway.InferenceModelRewriteGVK.GroupKind()); err != nil {
+		if len(rewritten) > 0 {
+			logger.Info("InferenceModelRewrite CRD not installed, requests must use the served model name", "publicNames", rewritten, "servedName", servedName)
+		}
+		return nil
+	}
+
+	rewrite := &unstructured.Unstructured{}
+	rewrite.SetGroupVersionKind(gateway.InferenceModelRewriteGVK)
+	rewrite.SetName(md.Name)
+	rewrite.SetNamespace(md.Namespace)
+
+	if len(rewritten) == 0 {
+		if err := r.Delete(ctx, rewrite); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete InferenceModelRewrite: %w", err)
+		}
+		return nil
+	}
+	if poolNamespace != md.Namespace {
+		logger.Info("Skipping InferenceModelRewrite, InferencePool is in another namespace", "poolNamespace", poolNamespace)
+		return nil
+	}
+
+	matches := make([]interface{}, 0, len(rewritten))
+	for _, name := range rewritten {
+		matches = append(matches, map[string]interface{}{
+			"model": map[string]interface{}{
+				"type":  "Exact",
+				"value": name,
+			},
+		})
+	}
+	_, err := ctrl.CreateOrUpdate(ctx, r.Client, rewrite, func() error {
+		if err := unstructured.SetNestedField(rewrite.Object, map[string]interface{}{
+			"poolRef": map[string]interface{}{
+				"group": r.inferencePoolGroup(),
+				"kind":  "InferencePool",
+				"name":  poolName,
+			},
+			"rules": []interface{}{
+				map[string]interface{}{
+					"matches": matches,
+					"targets": []interface{}{
+						map[string]interface{}{
+							"modelRewrite": servedName,
+						},
+					},
+				},
+			},
+		}, "spec"); err != nil {
+			return err
+		}
+		provider.ApplyPropagatedMetadataToObject(rewrite, md)
+		return ctrl.SetControllerReference(md, rewrite, r.Scheme)
+	})
+	return err
+}
+
 func int64Ptr(i int64) *int64 { return &i }
 func strPtr(s string) *string { return &s }
 
+// providerNameOf returns the explicitly requested provider, falling back to the selected one.
+// Once a provider migration started it returns the provider serving the gateway traffic.
+func providerNameOf(md *airunwayv1alpha1.ModelDeployment) string {
+	if name := servingProvider(md); name != "" {
+		return name
+	}
+	if md.Spec.Provider != nil {
+		return md.Spec.Provider.Name
+	}
+	if md.Status.Provider != nil {
+		return md.Status.Provider.Name
+	}
+	return ""
+}
+
 // resolveProviderGatewayCapabilities retrieves provider gateway capabilities from InferenceProviderConfig.
 func (r *ModelDeploymentReconciler) resolveProviderGatewayCapabilities(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) (*airunwayv1alpha1.GatewayCapabilities, error) {
-	var providerName string
-	if md.Spec.Provider != nil {
-		providerName = md.Spec.Provider.Name
-	} else if md.Status.Provider != nil {
-		providerName = md.Status.Provider.Name
-	} else {
+	if md.Spec.Provider == nil && md.Status.Provider == nil {
 		return nil, fmt.Errorf("provider name not specified in ModelDeployment %s/%s", md.Namespace, md.Name)
 	}
+	providerName := providerNameOf(md)
 
 	gatewayCapabilities := r.ProviderResolver.GetGatewayCapabilities(ctx, providerName)
 	if gatewayCapabilities == nil {
@@ -654,26 +993,33 @@ type httpRouteBackendTarget struct {
 	// namespace is the backend object namespace. May differ from the
 	// ModelDeployment namespace for provider-managed backends.
 	namespace string
+	// fallback is the spec.gateway.fallback backendRef receiving fallbackPercent of
+	// requests, or nil.
+	fallback        *gatewayv1.HTTPBackendRef
+	fallbackPercent int32
 }
 
-func buildHTTPRouteSpec(gwConfig *gateway.GatewayConfig, modelName string, backend httpRouteBackendTarget) gatewayv1.HTTPRouteSpec {
+func buildHTTPRouteSpec(gwConfig *gateway.GatewayConfig, modelNames []string, backend httpRouteBackendTarget, timeout gatewayv1.Duration, requestHeaders, responseHeaders map[string]string) gatewayv1.HTTPRouteSpec {
 	ns := gatewayv1.Namespace(gwConfig.GatewayNamespace)
 	pathPrefix := gatewayv1.PathMatchPathPrefix
-	timeout := gatewayv1.Duration("300s")
-
-	match := gatewayv1.HTTPRouteMatch{
-		Path: &gatewayv1.HTTPPathMatch{
-			Type:  &pathPrefix,
-			Value: strPtr("/"),
-		},
-	}
 	headerExact := gatewayv1.HeaderMatchExact
-	match.Headers = []gatewayv1.HTTPHeaderMatch{
-		{
-			Type:  &headerExact,
-			Name:  "X-Gateway-Model-Name", // https://github.com/kubernetes-sigs/gateway-api-inference-extension/blob/main/pkg/bbr/README.md
-			Value: modelName,
-		},
+
+	// One match per model name; the matches of a rule are ORed
+	matches := make([]gatewayv1.HTTPRouteMatch, 0, len(modelNames))
+	for _, modelName := range modelNames {
+		matches = append(matches, gatewayv1.HTTPRouteMatch{
+			Path: &gatewayv1.HTTPPathMatch{
+				Type:  &pathPrefix,
+				Value: strPtr("/"),
+			},
+			Headers: []gatewayv1.HTTPHeaderMatch{
+				{
+					Type:  &headerExact,
+					Name:  "X-Gateway-Model-Name", // https://github.com/kubernetes-sigs/gateway-api-inference-extension/blob/main/pkg/bbr/README.md
+					Value: modelName,
+				},
+			},
+		})
 	}
 
 	backendGroup := backend.group
@@ -686,6 +1032,20 @@ func buildHTTPRouteSpec(gwConfig *gateway.GatewayConfig, modelName string, backe
 		Namespace: &backendNs,
 	}
 
+	var filters []gatewayv1.HTTPRouteFilter
+	if len(requestHeaders) > 0 {
+		filters = append(filters, gatewayv1.HTTPRouteFilter{
+			Type:                  gatewayv1.HTTPRouteFilterRequestHeaderModifier,
+			RequestHeaderModifier: setHeadersFilter(requestHeaders),
+		})
+	}
+	if len(responseHeaders) > 0 {
+		filters = append(filters, gatewayv1.HTTPRouteFilter{
+			Type:                   gatewayv1.HTTPRouteFilterResponseHeaderModifier,
+			ResponseHeaderModifier: setHeadersFilter(responseHeaders),
+		})
+	}
+
 	return gatewayv1.HTTPRouteSpec{
 		CommonRouteSpec: gatewayv1.CommonRouteSpec{
 			ParentRefs: []gatewayv1.ParentReference{
@@ -697,14 +1057,9 @@ func buildHTTPRouteSpec(gwConfig *gateway.GatewayConfig, modelName string, backe
 		},
 		Rules: []gatewayv1.HTTPRouteRule{
 			{
-				Matches: []gatewayv1.HTTPRouteMatch{match},
-				BackendRefs: []gatewayv1.HTTPBackendRef{
-					{
-						BackendRef: gatewayv1.BackendRef{
-							BackendObjectReference: backendRef,
-						},
-					},
-				},
+				Matches:     matches,
+				Filters:     filters,
+				BackendRefs: fallbackBackendRefs(backendRef, backend.fallback, backend.fallbackPercent),
 				Timeouts: &gatewayv1.HTTPRouteTimeouts{
 					Request: &timeout,
 				},
@@ -713,6 +1068,24 @@ func buildHTTPRouteSpec(gwConfig *gateway.GatewayConfig, modelName string, backe
 	}
 }
 
+// setHeadersFilter returns a header filter setting headers, sorted by name so the route
+// spec is stable across reconciles
+func setHeadersFilter(headers map[string]string) *gatewayv1.HTTPHeaderFilter {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	filter := &gatewayv1.HTTPHeaderFilter{}
+	for _, name := range names {
+		filter.Set = append(filter.Set, gatewayv1.HTTPHeader{
+			Name:  gatewayv1.HTTPHeaderName(name),
+			Value: headers[name],
+		})
+	}
+	return filter
+}
+
 // reconcileHTTPRoute creates the HTTPRoute for a ModelDeployment on first reconcile.
 // If the HTTPRoute is subsequently deleted by the user the controller will not recreate.
 // The deletion is treated as intentional. The ModelDeployment is
@@ -721,15 +1094,32 @@ func buildHTTPRouteSpec(gwConfig *gateway.GatewayConfig, modelName string, backe
 func (r *ModelDeploymentReconciler) reconcileHTTPRoute(ctx context.Context, md *airunwayv1alpha1.ModelDeployment, gwConfig *gateway.GatewayConfig, modelName string, backend httpRouteBackendTarget) error {
 	logger := log.FromContext(ctx)
 
+	timeout := gatewayv1.Duration(gateway.FormatDuration(httpRouteTimeout(md)))
+	annotations := httpRouteAnnotations(md)
+	requestHeaders := httpRouteRequestHeaders(md, modelName)
+	responseHeaders := httpRouteResponseHeaders(md, modelName)
+
 	existing := &gatewayv1.HTTPRoute{}
 	err := r.Get(ctx, client.ObjectKey{Name: md.Name, Namespace: md.Namespace}, existing)
 	if err == nil {
 		// HTTPRoute exists — update it in case model name or gateway changed.
-		existing.Spec = buildHTTPRouteSpec(gwConfig, modelName, backend)
+		previous, hadParent := httpRouteGateway(existing)
+		existing.Spec = buildHTTPRouteSpec(gwConfig, httpRouteModelNames(md, modelName), backend, timeout, requestHeaders, responseHeaders)
+		delete(existing.Annotations, gateway.AnnotationStreaming)
+		if len(annotations) > 0 && existing.Annotations == nil {
+			existing.Annotations = make(map[string]string, len(annotations))
+		}
+		for k, v := range annotations {
+			existing.Annotations[k] = v
+		}
+		provider.ApplyPropagatedMetadataToObject(existing, md)
 		if updateErr := r.Update(ctx, existing); updateErr != nil {
 			return fmt.Errorf("failed to update HTTPRoute: %w", updateErr)
 		}
 		logger.V(1).Info("HTTPRoute updated", "name", existing.Name)
+		if hadParent && (previous.Name != gwConfig.GatewayName || previous.Namespace != gwConfig.GatewayNamespace) {
+			r.recordGatewayMigration(ctx, md, existing.Name, previous, gwConfig)
+		}
 		return nil
 	}
 	if apierrors.IsNotFound(err) {
@@ -743,11 +1133,13 @@ func (r *ModelDeploymentReconciler) reconcileHTTPRoute(ctx context.Context, md *
 		// First-time creation.
 		route := &gatewayv1.HTTPRoute{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:      md.Name,
-				Namespace: md.Namespace,
+				Name:        md.Name,
+				Namespace:   md.Namespace,
+				Annotations: annotations,
 			},
-			Spec: buildHTTPRouteSpec(gwConfig, modelName, backend),
+			Spec: buildHTTPRouteSpec(gwConfig, httpRouteModelNames(md, modelName), backend, timeout, requestHeaders, responseHeaders),
 		}
+		provider.ApplyPropagatedMetadataToObject(route, md)
 		if setErr := ctrl.SetControllerReference(md, route, r.Scheme); setErr != nil {
 			return fmt.Errorf("setting controller reference: %w", setErr)
 		}
@@ -771,7 +1163,158 @@ func (r *ModelDeploymentReconciler) reconcileHTTPRoute(ctx context.Context, md *
 	return fmt.Errorf("getting HTTPRoute: %w", err)
 }
 
+// httpRouteTimeout returns the HTTPRoute request timeout for a ModelDeployment.
+// An explicit spec.gateway.timeout wins; otherwise streaming deployments get a
+// longer default so SSE completions are not cut off mid-stream.
+func httpRouteTimeout(md *airunwayv1alpha1.ModelDeployment) time.Duration {
+	timeout := gateway.DefaultRequestTimeout
+	if gw := md.Spec.Gateway; gw != nil {
+		if gw.Streaming {
+			timeout = gateway.DefaultStreamingRequestTimeout
+		}
+		if gw.Timeout != nil {
+			timeout = gw.Timeout.Duration
+		}
+	}
+	return timeout
+}
+
+// httpRouteResponseHeaders returns the standard headers selected by
+// spec.gateway.responseHeaders, which the HTTPRoute adds to every response.
+func httpRouteResponseHeaders(md *airunwayv1alpha1.ModelDeployment, modelName string) map[string]string {
+	if md.Spec.Gateway == nil || len(md.Spec.Gateway.ResponseHeaders) == 0 {
+		return nil
+	}
+	return gateway.ResponseHeaders(md.Spec.Gateway.ResponseHeaders, gateway.ResponseHeaderData{
+		Namespace: md.Namespace,
+		Name:      md.Name,
+		ModelName: modelName,
+		Provider:  providerNameOf(md),
+	})
+}
+
+// httpRouteRequestHeaders returns the headers the HTTPRoute adds to every request when
+// spec.observability.tracing is enabled, naming the model and deployment so gateway and
+// model server spans can be tagged with them.
+func httpRouteRequestHeaders(md *airunwayv1alpha1.ModelDeployment, modelName string) map[string]string {
+	if !md.TracingEnabled() {
+		return nil
+	}
+	return gateway.TracingRequestHeaders(gateway.ResponseHeaderData{
+		Namespace: md.Namespace,
+		Name:      md.Name,
+		ModelName: modelName,
+	})
+}
+
+// eppTracingConfig returns the tracing config of the controller-created EPP of md, or nil
+// when spec.observability.tracing is disabled or no collector endpoint is configured.
+func (r *ModelDeploymentReconciler) eppTracingConfig(ctx context.Context, md *airunwayv1alpha1.ModelDeployment, modelName string) *gateway.TracingConfig {
+	if !md.TracingEnabled() {
+		return nil
+	}
+	tracing := md.Spec.Observability.Tracing
+	endpoint := tracing.Endpoint
+	if endpoint == "" {
+		endpoint = r.settings().TracingEndpoint
+	}
+	if endpoint == "" {
+		log.FromContext(ctx).Info("Tracing enabled without a collector endpoint, EPP tracing stays off",
+			"name", md.Name)
+		return nil
+	}
+	percent := int32(gateway.DefaultTracingSamplingPercent)
+	if tracing.SamplingPercent != nil {
+		percent = *tracing.SamplingPercent
+	}
+	return &gateway.TracingConfig{
+		Endpoint:        endpoint,
+		SamplingPercent: percent,
+		ModelName:       modelName,
+		Deployment:      md.Namespace + "/" + md.Name,
+	}
+}
+
+// httpRouteAnnotations returns the annotations of the generated HTTPRoute of md, which
+// marks the routes of streaming deployments
+func httpRouteAnnotations(md *airunwayv1alpha1.ModelDeployment) map[string]string {
+	if md.Spec.Gateway == nil || !md.Spec.Gateway.Streaming {
+		return nil
+	}
+	return map[string]string{gateway.AnnotationStreaming: "true"}
+}
+
+// reconcileRateLimitPolicy programs spec.gateway.rateLimit through the policy kind of the
+// Gateway's implementation and removes policies that are no longer wanted.
+func (r *ModelDeploymentReconciler) reconcileRateLimitPolicy(ctx context.Context, md *airunwayv1alpha1.ModelDeployment, gwConfig *gateway.GatewayConfig) error {
+	logger := log.FromContext(ctx)
+
+	var desired *unstructured.Unstructured
+	if md.Spec.Gateway != nil && md.Spec.Gateway.RateLimit != nil {
+		routeName := md.Name
+		if md.Spec.Gateway.HTTPRouteRef != "" {
+			routeName = md.Spec.Gateway.HTTPRouteRef
+		}
+		impl := r.resolveGatewayImplementation(ctx, gwConfig)
+		desired = gateway.RateLimitPolicy(impl, routeName, md.Spec.Gateway.RateLimit)
+		if desired == nil {
+			logger.Info("Gateway implementation does not support the requested rate limits, skipping", "implementation", impl)
+		} else if impl == gateway.ImplementationKGateway && md.Spec.Gateway.RateLimit.MaxConcurrent > 0 {
+			logger.Info("kgateway does not support maxConcurrent, only requestsPerMinute and burst are enforced")
+		}
+	}
+
+	for _, gvk := range gateway.RateLimitPolicyGVKs() {
+		wanted := desired != nil && desired.GroupVersionKind() == gvk
+		if _, err := r.Client.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version); err != nil {
+			if wanted {
+				logger.Info("Rate limit policy CRD not installed, skipping", "kind", gvk.Kind)
+			}
+			continue
+		}
+
+		policy := &unstructured.Unstructured{}
+		policy.SetGroupVersionKind(gvk)
+		policy.SetName(md.Name)
+		policy.SetNamespace(md.Namespace)
+
+		if !wanted {
+			if err := r.Delete(ctx, policy); client.IgnoreNotFound(err) != nil {
+				return fmt.Errorf("failed to delete %s: %w", gvk.Kind, err)
+			}
+			continue
+		}
+		if _, err := ctrl.CreateOrUpdate(ctx, r.Client, policy, func() error {
+			policy.Object["spec"] = desired.Object["spec"]
+			provider.ApplyPropagatedMetadataToObject(policy, md)
+			return ctrl.SetControllerReference(md, policy, r.Scheme)
+		}); err != nil {
+			return fmt.Errorf("failed to reconcile %s: %w", gvk.Kind, err)
+		}
+	}
+	return nil
+}
+
+// resolveGatewayImplementation identifies the Gateway API implementation from the
+// Gateway's GatewayClass controllerName. Returns ImplementationUnknown on any lookup failure.
+func (r *ModelDeploymentReconciler) resolveGatewayImplementation(ctx context.Context, gwConfig *gateway.GatewayConfig) gateway.Implementation {
+	logger := log.FromContext(ctx)
+
+	var gw gatewayv1.Gateway
+	if err := r.Get(ctx, client.ObjectKey{Name: gwConfig.GatewayName, Namespace: gwConfig.GatewayNamespace}, &gw); err != nil {
+		logger.V(1).Info("Could not read Gateway to resolve implementation", "error", err)
+		return gateway.ImplementationUnknown
+	}
+	var gwClass gatewayv1.GatewayClass
+	if err := r.Get(ctx, client.ObjectKey{Name: string(gw.Spec.GatewayClassName)}, &gwClass); err != nil {
+		logger.V(1).Info("Could not read GatewayClass to resolve implementation", "gatewayClass", gw.Spec.GatewayClassName, "error", err)
+		return gateway.ImplementationUnknown
+	}
+	return gateway.ImplementationForController(string(gwClass.Spec.ControllerName))
+}
+
 // resolveGatewayEndpoint reads the Gateway resource's status to find the actual endpoint address.
+// IPv6 addresses are bracketed so that the endpoint can be used as the host of a URL.
 func (r *ModelDeploymentReconciler) resolveGatewayEndpoint(ctx context.Context, gwConfig *gateway.GatewayConfig) string {
 	var gw gatewayv1.Gateway
 	if err := r.Get(ctx, client.ObjectKey{Name: gwConfig.GatewayName, Namespace: gwConfig.GatewayNamespace}, &gw); err != nil {
@@ -780,7 +1323,7 @@ func (r *ModelDeploymentReconciler) resolveGatewayEndpoint(ctx context.Context,
 	}
 	for _, addr := range gw.Status.Addresses {
 		if addr.Value != "" {
-			return addr.Value
+			return urlHost(addr.Value)
 		}
 	}
 	return ""
@@ -797,18 +1340,49 @@ func (r *ModelDeploymentReconciler) resolveModelName(ctx context.Context, md *ai
 		return md.Spec.Model.ServedName
 	}
 
-	// Auto-discover from the running model server
-	if md.Status.Endpoint != nil && md.Status.Endpoint.Service != "" {
+	// Auto-discover from the running model server, the old provider's while a migration
+	// has not switched the gateway
+	if endpoint := servingEndpoint(md); endpoint != nil && endpoint.Service != "" {
 		// Look up the actual service port (status.endpoint.port may be the container port)
-		port := r.resolveServicePort(ctx, md.Status.Endpoint.Service, md.Namespace)
+		port := r.resolveServicePort(ctx, endpoint.Service, md.Namespace)
 		if port == 0 {
-			port = md.Status.Endpoint.Port
+			port = endpoint.Port
 		}
 		if port == 0 {
 			port = 8000
 		}
-		if discovered := r.discoverModelName(ctx, md.Status.Endpoint.Service, md.Namespace, port); discovered != "" {
+		if cached, ok := cachedModelDiscovery(md, time.Now()); ok {
+			if cached != "" {
+				return cached
+			}
+			return md.Spec.Model.ID
+		}
+		// The /v1/models request runs in the background. Until it answers, the last
+		// discovered name of this generation is kept so the route does not flap.
+		service, namespace := endpoint.Service, md.Namespace
+		version := fmt.Sprintf("%d/%s/%d", md.Generation, service, port)
+		result, ok := r.scrape(ctx, md, scrapeModelDiscovery, version, modelDiscoveryRetryInterval, func(ctx context.Context) (interface{}, error) {
+			return r.discoverModelName(ctx, service, namespace, port), nil
+		})
+		if !ok {
+			if discovery := modelDiscoveryStatus(md); discovery != nil && discovery.ObservedGeneration == md.Generation && discovery.ModelName != "" {
+				return discovery.ModelName
+			}
+			return md.Spec.Model.ID
+		}
+		discovered := result.value.(string)
+		if discovered != "" {
 			log.FromContext(ctx).Info("Auto-discovered model name from server", "name", md.Name, "modelName", discovered)
+		}
+		if md.Status.Gateway == nil {
+			md.Status.Gateway = &airunwayv1alpha1.GatewayStatus{}
+		}
+		md.Status.Gateway.ModelDiscovery = &airunwayv1alpha1.ModelDiscoveryStatus{
+			ModelName:          discovered,
+			ProbeTime:          metav1.Time{Time: result.time},
+			ObservedGeneration: md.Generation,
+		}
+		if discovered != "" {
 			return discovered
 		}
 	}
@@ -816,6 +1390,56 @@ func (r *ModelDeploymentReconciler) resolveModelName(ctx context.Context, md *ai
 	return md.Spec.Model.ID
 }
 
+const (
+	// modelDiscoveryTTL is how long a model name discovered from /v1/models is reused
+	modelDiscoveryTTL = 10 * time.Minute
+	// modelDiscoveryRetryInterval is how long to wait before probing again after a failed probe
+	modelDiscoveryRetryInterval = time.Minute
+)
+
+// cachedModelDiscovery returns the model name of the last /v1/models probe while it is
+// still fresh: a successful probe is reused for modelDiscoveryTTL and a failed one for
+// modelDiscoveryRetryInterval, as long as the spec has not changed since.
+func cachedModelDiscovery(md *airunwayv1alpha1.ModelDeployment, now time.Time) (string, bool) {
+	if md.Status.Gateway == nil || md.Status.Gateway.ModelDiscovery == nil {
+		return "", false
+	}
+	discovery := md.Status.Gateway.ModelDiscovery
+	if discovery.ObservedGeneration != md.Generation {
+		return "", false
+	}
+	ttl := modelDiscoveryTTL
+	if discovery.ModelName == "" {
+		ttl = modelDiscoveryRetryInterval
+	}
+	if now.Sub(discovery.ProbeTime.Time) >= ttl {
+		return "", false
+	}
+	return discovery.ModelName, true
+}
+
+// discoveringModelName reports whether resolveModelName waits for the first /v1/models
+// answer of the current generation
+func discoveringModelName(md *airunwayv1alpha1.ModelDeployment) bool {
+	if (md.Spec.Gateway != nil && md.Spec.Gateway.ModelName != "") || shouldUseServedNameForGateway(md) {
+		return false
+	}
+	if endpoint := servingEndpoint(md); endpoint == nil || endpoint.Service == "" {
+		return false
+	}
+	discovery := modelDiscoveryStatus(md)
+	return discovery == nil || discovery.ObservedGeneration != md.Generation
+}
+
+// modelDiscoveryStatus returns the cached discovery result to carry over when the
+// gateway status is rebuilt
+func modelDiscoveryStatus(md *airunwayv1alpha1.ModelDeployment) *airunwayv1alpha1.ModelDiscoveryStatus {
+	if md.Status.Gateway == nil {
+		return nil
+	}
+	return md.Status.Gateway.ModelDiscovery
+}
+
 func shouldUseServedNameForGateway(md *airunwayv1alpha1.ModelDeployment) bool {
 	if md.Spec.Model.ServedName == "" {
 		return false
@@ -829,6 +1453,9 @@ func shouldUseServedNameForGateway(md *airunwayv1alpha1.ModelDeployment) bool {
 }
 
 func resolvedProviderName(md *airunwayv1alpha1.ModelDeployment) string {
+	if name := servingProvider(md); name != "" {
+		return name
+	}
 	if md.Spec.Provider != nil && md.Spec.Provider.Name != "" {
 		return md.Spec.Provider.Name
 	}
@@ -840,8 +1467,14 @@ func resolvedProviderName(md *airunwayv1alpha1.ModelDeployment) string {
 
 // resolveServicePort looks up the first HTTP port on the named service.
 func (r *ModelDeploymentReconciler) resolveServicePort(ctx context.Context, serviceName, namespace string) int32 {
+	return lookupServicePort(ctx, r.Client, serviceName, namespace)
+}
+
+// lookupServicePort returns the HTTP port of a model server service, or 0 when the service
+// does not exist or has no ports
+func lookupServicePort(ctx context.Context, c client.Reader, serviceName, namespace string) int32 {
 	var svc corev1.Service
-	if err := r.Get(ctx, client.ObjectKey{Name: serviceName, Namespace: namespace}, &svc); err != nil {
+	if err := c.Get(ctx, client.ObjectKey{Name: serviceName, Namespace: namespace}, &svc); err != nil {
 		return 0
 	}
 	for _, p := range svc.Spec.Ports {
@@ -881,13 +1514,14 @@ func (r *ModelDeploymentReconciler) resolveTargetPort(ctx context.Context, servi
 // labelModelPods finds pods backing the model's service and ensures they have the
 // airunway.ai/model-deployment label so the InferencePool selector can match them.
 func (r *ModelDeploymentReconciler) labelModelPods(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) error {
-	if md.Status.Endpoint == nil || md.Status.Endpoint.Service == "" {
+	endpoint := servingEndpoint(md)
+	if endpoint == nil || endpoint.Service == "" {
 		return nil
 	}
 
 	// Get the service to find its selector
 	var svc corev1.Service
-	if err := r.Get(ctx, client.ObjectKey{Name: md.Status.Endpoint.Service, Namespace: md.Namespace}, &svc); err != nil {
+	if err := r.Get(ctx, client.ObjectKey{Name: endpoint.Service, Namespace: md.Namespace}, &svc); err != nil {
 		return fmt.Errorf("failed to get service: %w", err)
 	}
 
@@ -927,7 +1561,7 @@ func (r *ModelDeploymentReconciler) labelModelPods(ctx context.Context, md *airu
 
 // discoverModelName probes the model server's /v1/models endpoint to find the actual served model name.
 func (r *ModelDeploymentReconciler) discoverModelName(ctx context.Context, service, namespace string, port int32) string {
-	url := fmt.Sprintf("http://%s.%s.svc:%d/v1/models", service, namespace, port)
+	url := "http://" + net.JoinHostPort(service+"."+namespace+".svc", strconv.Itoa(int(port))) + "/v1/models"
 
 	httpClient := &http.Client{Timeout: 5 * time.Second}
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
@@ -1073,18 +1707,13 @@ func (r *ModelDeploymentReconciler) cleanupGatewayResources(ctx context.Context,
 	if gatewayCapabilities, err = r.resolveProviderGatewayCapabilities(ctx, md); err != nil {
 		logger.Info("Error resolving provider gateway capabilities, proceeding without provider-specific gateway capabilities", "error", err)
 	}
-	providerManagedPool := gatewayCapabilities != nil
+	providerManagedPool := gatewayCapabilities.ProviderManaged()
 
 	eppName := md.Name + "-epp"
 
 	if !providerManagedPool {
 		// Delete InferencePool if it exists
-		pool := &inferencev1.InferencePool{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      md.Name,
-				Namespace: md.Namespace,
-			},
-		}
+		pool := r.newInferencePool(md.Name, md.Namespace)
 		if err := r.Delete(ctx, pool); client.IgnoreNotFound(err) != nil {
 			return fmt.Errorf("failed to delete InferencePool: %w", err)
 		}
@@ -1105,17 +1734,59 @@ func (r *ModelDeploymentReconciler) cleanupGatewayResources(ctx context.Context,
 		}
 	}
 
+	// Delete the fallback Service and BackendTLSPolicy of spec.gateway.fallback.url
+	fallbackName := fallbackServiceName(md)
+	if err := r.deleteFallbackResource(ctx, md, &gatewayv1.BackendTLSPolicy{ObjectMeta: metav1.ObjectMeta{Name: fallbackName, Namespace: md.Namespace}}); err != nil {
+		return fmt.Errorf("failed to delete fallback BackendTLSPolicy: %w", err)
+	}
+	if err := r.deleteFallbackResource(ctx, md, &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: fallbackName, Namespace: md.Namespace}}); err != nil {
+		return fmt.Errorf("failed to delete fallback Service: %w", err)
+	}
+
+	// Delete rate limit policies for kinds installed in the cluster
+	for _, gvk := range gateway.RateLimitPolicyGVKs() {
+		if _, err := r.Client.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version); err != nil {
+			continue
+		}
+		policy := &unstructured.Unstructured{}
+		policy.SetGroupVersionKind(gvk)
+		policy.SetName(md.Name)
+		policy.SetNamespace(md.Namespace)
+		if err := r.Delete(ctx, policy); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete %s: %w", gvk.Kind, err)
+		}
+	}
+
+	if err := r.deletePromptPolicy(ctx, md); err != nil {
+		return err
+	}
+
+	// Delete the InferenceModelRewrite if the CRD is installed
+	if _, err := r.Client.RESTMapper().RESTMapping(gateway.InferenceModelRewriteGVK.GroupKind()); err == nil {
+		rewrite := &unstructured.Unstructured{}
+		rewrite.SetGroupVersionKind(gateway.InferenceModelRewriteGVK)
+		rewrite.SetName(md.Name)
+		rewrite.SetNamespace(md.Namespace)
+		if err := r.Delete(ctx, rewrite); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete InferenceModelRewrite: %w", err)
+		}
+	}
+
 	if !providerManagedPool {
 		// Delete EPP resources
 		eppResources := []client.Object{
 			&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: eppName, Namespace: md.Namespace}},
 			&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: eppName, Namespace: md.Namespace}},
 			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: eppName, Namespace: md.Namespace}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: provider.LogSinkConfigMapName(eppName), Namespace: md.Namespace}},
 			&rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: eppName, Namespace: md.Namespace}},
 			&rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: eppName, Namespace: md.Namespace}},
 			&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: eppName, Namespace: md.Namespace}},
 		}
 
+		// Release the RBAC shared by the EPPs of the namespace, if this EPP used it
+		r.releaseSharedEPPRBAC(ctx, md)
+
 		// Conditionally delete the DestinationRule if Istio is present
 		if _, err := r.Client.RESTMapper().RESTMapping(schema.GroupKind{Group: "networking.istio.io", Kind: "DestinationRule"}); err == nil {
 			dr := &unstructured.Unstructured{}
@@ -1162,19 +1833,20 @@ func (r *ModelDeploymentReconciler) cleanupGatewayResources(ctx context.Context,
 func (r *ModelDeploymentReconciler) providerInferencePoolExistsOrCreateDefault(ctx context.Context, md *airunwayv1alpha1.ModelDeployment, gatewayCapabilitities *airunwayv1alpha1.GatewayCapabilities, gwConfig *gateway.GatewayConfig) (bool, error) {
 	logger := log.FromContext(ctx)
 
-	if gatewayCapabilitities != nil {
+	if gatewayCapabilitities.ProviderManaged() {
 		// Provider manages the pool.
 		return true, nil
 	}
 
 	// Traffic routed to the InferencePool will be forwarded to this port on selected pods (needs the pod/container port, not service port).
+	// While a provider migration has not switched the gateway, this is the old provider's port
 	port := int32(8000) // sensible default
-	if md.Status.Endpoint != nil && md.Status.Endpoint.Service != "" {
+	if endpoint := servingEndpoint(md); endpoint != nil && endpoint.Service != "" {
 		// Look up the service's target port (the actual container port)
-		if targetPort := r.resolveTargetPort(ctx, md.Status.Endpoint.Service, md.Namespace); targetPort > 0 {
+		if targetPort := r.resolveTargetPort(ctx, endpoint.Service, md.Namespace); targetPort > 0 {
 			port = targetPort
-		} else if md.Status.Endpoint.Port > 0 {
-			port = md.Status.Endpoint.Port
+		} else if endpoint.Port > 0 {
+			port = endpoint.Port
 		}
 	}
 
@@ -1199,7 +1871,7 @@ func (r *ModelDeploymentReconciler) cleanupGatewayAllowedRoutes(ctx context.Cont
 	logger := log.FromContext(ctx)
 
 	// Resolve gateway config; if we can't find the gateway, nothing to revert.
-	gwConfig, err := r.resolveGatewayConfig(ctx)
+	gwConfig, err := r.resolveGatewayConfig(ctx, md.Namespace)
 	if err != nil {
 		return nil
 	}
@@ -1226,16 +1898,28 @@ func (r *ModelDeploymentReconciler) cleanupGatewayAllowedRoutes(ctx context.Cont
 	}
 
 	// No other MDs need gateway in this namespace — remove it from the In-list.
+	removed, err := r.removeGatewayAllowedNamespace(ctx, gwConfig, md.Namespace)
+	if err != nil || !removed {
+		return err
+	}
+	logger.Info("Removed namespace from Gateway allowedRoutes", "gateway", gwConfig.GatewayName, "namespace", md.Namespace)
+	return nil
+}
+
+// removeGatewayAllowedNamespace removes namespace from the allowedRoutes In-list of the
+// Gateway's listeners, reverting them to SameNamespace when no namespace remains. It reports
+// whether the Gateway was patched.
+func (r *ModelDeploymentReconciler) removeGatewayAllowedNamespace(ctx context.Context, gwConfig *gateway.GatewayConfig, namespace string) (bool, error) {
 	var gw gatewayv1.Gateway
 	if err := r.Get(ctx, client.ObjectKey{Name: gwConfig.GatewayName, Namespace: gwConfig.GatewayNamespace}, &gw); err != nil {
-		return fmt.Errorf("getting Gateway: %w", err)
+		return false, fmt.Errorf("getting Gateway: %w", err)
 	}
 
 	existing := allowedNamespacesFromGateway(&gw)
-	if !existing[md.Namespace] {
-		return nil // not in the list, nothing to do
+	if !existing[namespace] {
+		return false, nil // not in the list, nothing to do
 	}
-	delete(existing, md.Namespace)
+	delete(existing, namespace)
 
 	if len(existing) == 0 {
 		// No cross-namespace routes remain — revert to SameNamespace.
@@ -1249,17 +1933,15 @@ func (r *ModelDeploymentReconciler) cleanupGatewayAllowedRoutes(ctx context.Cont
 			}
 		}
 		if err := r.Patch(ctx, &gw, client.MergeFrom(base)); err != nil {
-			return fmt.Errorf("reverting Gateway listeners: %w", err)
+			return false, fmt.Errorf("reverting Gateway listeners: %w", err)
 		}
 	} else {
 		// Other namespaces still need access — update the In-list without this namespace.
 		if err := r.patchGatewayListenerSelector(ctx, gwConfig, existing); err != nil {
-			return fmt.Errorf("updating Gateway listeners: %w", err)
+			return false, fmt.Errorf("updating Gateway listeners: %w", err)
 		}
 	}
-
-	logger.Info("Removed namespace from Gateway allowedRoutes", "gateway", gwConfig.GatewayName, "namespace", md.Namespace)
-	return nil
+	return true, nil
 }
 
 // cleanupGatewayAllowedRoutesForNamespace removes a namespace from the Gateway's
@@ -1275,7 +1957,7 @@ func (r *ModelDeploymentReconciler) cleanupGatewayAllowedRoutesForNamespace(ctx
 		return
 	}
 
-	gwConfig, err := r.resolveGatewayConfig(ctx)
+	gwConfig, err := r.resolveGatewayConfig(ctx, namespace)
 	if err != nil {
 		return
 	}
@@ -1297,38 +1979,14 @@ func (r *ModelDeploymentReconciler) cleanupGatewayAllowedRoutesForNamespace(ctx
 	}
 
 	// No MDs need gateway in this namespace — remove it from the In-list.
-	var gw gatewayv1.Gateway
-	if err := r.Get(ctx, client.ObjectKey{Name: gwConfig.GatewayName, Namespace: gwConfig.GatewayNamespace}, &gw); err != nil {
-		logger.V(1).Info("Could not get Gateway for cleanup", "error", err)
+	removed, err := r.removeGatewayAllowedNamespace(ctx, gwConfig, namespace)
+	if err != nil {
+		logger.V(1).Info("Could not update Gateway listeners for cleanup", "error", err)
 		return
 	}
-
-	existing := allowedNamespacesFromGateway(&gw)
-	if !existing[namespace] {
+	if !removed {
 		return
 	}
-	delete(existing, namespace)
-
-	if len(existing) == 0 {
-		fromSame := gatewayv1.NamespacesFromSame
-		base := gw.DeepCopy()
-		for i := range gw.Spec.Listeners {
-			if gw.Spec.Listeners[i].AllowedRoutes != nil {
-				gw.Spec.Listeners[i].AllowedRoutes.Namespaces = &gatewayv1.RouteNamespaces{
-					From: &fromSame,
-				}
-			}
-		}
-		if err := r.Patch(ctx, &gw, client.MergeFrom(base)); err != nil {
-			logger.V(1).Info("Could not revert Gateway listeners", "error", err)
-			return
-		}
-	} else {
-		if err := r.patchGatewayListenerSelector(ctx, gwConfig, existing); err != nil {
-			logger.V(1).Info("Could not update Gateway listeners", "error", err)
-			return
-		}
-	}
 
 	logger.Info("Removed namespace from Gateway allowedRoutes after MD deletion", "gateway", gwConfig.GatewayName, "namespace", namespace)
 }
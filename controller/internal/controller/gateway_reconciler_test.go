@@ -19,21 +19,36 @@ package controller
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
-
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/meta/testrestmapper"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	fakediscovery "k8s.io/client-go/discovery/fake"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
 	"github.com/kaito-project/airunway/controller/internal/gateway"
 	inferencev1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+	inferencev1alpha2 "sigs.k8s.io/gateway-api-inference-extension/apix/v1alpha2"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 )
@@ -148,121 +163,1395 @@ func TestGateway_InferencePoolCreation(t *testing.T) {
 		t.Errorf("expected selector label value %q, got %q", "test-model", val)
 	}
 
-	// Check target port
-	if len(pool.Spec.TargetPorts) != 1 {
-		t.Fatalf("expected 1 target port, got %d", len(pool.Spec.TargetPorts))
+	// Check target port
+	if len(pool.Spec.TargetPorts) != 1 {
+		t.Fatalf("expected 1 target port, got %d", len(pool.Spec.TargetPorts))
+	}
+	if pool.Spec.TargetPorts[0].Number != 8080 {
+		t.Errorf("expected target port 8080, got %d", pool.Spec.TargetPorts[0].Number)
+	}
+
+	// Check EndpointPickerRef
+	if string(pool.Spec.EndpointPickerRef.Name) != "test-model-epp" {
+		t.Errorf("expected EndpointPickerRef name %q, got %q", "test-model-epp", pool.Spec.EndpointPickerRef.Name)
+	}
+	if pool.Spec.EndpointPickerRef.Port == nil || pool.Spec.EndpointPickerRef.Port.Number != 9002 {
+		t.Errorf("expected EndpointPickerRef port 9002, got %v", pool.Spec.EndpointPickerRef.Port)
+	}
+
+	// Check OwnerReference
+	if len(pool.OwnerReferences) != 1 {
+		t.Fatalf("expected 1 owner reference, got %d", len(pool.OwnerReferences))
+	}
+	if pool.OwnerReferences[0].Name != "test-model" {
+		t.Errorf("expected owner ref name %q, got %q", "test-model", pool.OwnerReferences[0].Name)
+	}
+	if pool.OwnerReferences[0].Kind != "ModelDeployment" {
+		t.Errorf("expected owner ref kind %q, got %q", "ModelDeployment", pool.OwnerReferences[0].Kind)
+	}
+}
+
+func TestGateway_InferencePoolDefaultPort(t *testing.T) {
+	scheme := newTestScheme()
+	md := newModelDeployment("test-model", "default")
+	md.Status.Endpoint = nil // no endpoint, should use default port
+	detector := fakeDetector(true, "my-gateway", "gateway-ns")
+	r := newTestReconciler(scheme, detector, md)
+	ctx := context.Background()
+
+	// reconcileGateway uses default port 8000 when no endpoint
+	err := r.reconcileInferencePool(ctx, md, 8000, "gateway-ns")
+	if err != nil {
+		t.Fatalf("reconcileInferencePool failed: %v", err)
+	}
+
+	var pool inferencev1.InferencePool
+	if err := r.Get(ctx, types.NamespacedName{Name: "test-model", Namespace: "default"}, &pool); err != nil {
+		t.Fatalf("InferencePool not found: %v", err)
+	}
+	if pool.Spec.TargetPorts[0].Number != 8000 {
+		t.Errorf("expected default target port 8000, got %d", pool.Spec.TargetPorts[0].Number)
+	}
+}
+
+func TestGateway_AlphaInferencePool(t *testing.T) {
+	scheme := newTestScheme()
+	utilruntime.Must(inferencev1alpha2.Install(scheme))
+	md := newModelDeployment("test-model", "default")
+	dc := &fakediscovery.FakeDiscovery{Fake: &k8stesting.Fake{}}
+	dc.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "inference.networking.x-k8s.io/v1alpha2",
+			APIResources: []metav1.APIResource{{Name: "inferencepools"}},
+		},
+		{
+			GroupVersion: "gateway.networking.k8s.io/v1",
+			APIResources: []metav1.APIResource{{Name: "httproutes"}, {Name: "gateways"}},
+		},
+	}
+	detector := gateway.NewDetector(dc)
+	detector.ExplicitGatewayName = "my-gateway"
+	detector.ExplicitGatewayNamespace = "gateway-ns"
+	r := newTestReconciler(scheme, detector, md, newTestGateway("my-gateway", "gateway-ns"))
+	ctx := context.Background()
+
+	if err := r.reconcileGateway(ctx, md); err != nil {
+		t.Fatalf("reconcileGateway failed: %v", err)
+	}
+
+	// The pool is created with the v1alpha2 API the cluster serves
+	key := types.NamespacedName{Name: "test-model", Namespace: "default"}
+	var pool inferencev1alpha2.InferencePool
+	if err := r.Get(ctx, key, &pool); err != nil {
+		t.Fatalf("v1alpha2 InferencePool not found: %v", err)
+	}
+	if pool.Spec.TargetPortNumber != 8080 || string(pool.Spec.Selector[airunwayv1alpha1.LabelModelDeployment]) != "test-model" {
+		t.Errorf("unexpected InferencePool spec %+v", pool.Spec)
+	}
+	if pool.Spec.ExtensionRef.Name != "test-model-epp" || pool.Spec.ExtensionRef.PortNumber == nil || *pool.Spec.ExtensionRef.PortNumber != 9002 {
+		t.Errorf("unexpected extension ref %+v", pool.Spec.ExtensionRef)
+	}
+	if err := r.Get(ctx, key, &inferencev1.InferencePool{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected no v1 InferencePool, got %v", err)
+	}
+
+	var route gatewayv1.HTTPRoute
+	if err := r.Get(ctx, key, &route); err != nil {
+		t.Fatalf("HTTPRoute not found: %v", err)
+	}
+	if group := route.Spec.Rules[0].BackendRefs[0].Group; group == nil || *group != "inference.networking.x-k8s.io" {
+		t.Errorf("expected the backend to use the v1alpha2 group, got %v", group)
+	}
+
+	var dep appsv1.Deployment
+	if err := r.Get(ctx, types.NamespacedName{Name: "test-model-epp", Namespace: "default"}, &dep); err != nil {
+		t.Fatalf("EPP Deployment not found: %v", err)
+	}
+	if args := strings.Join(dep.Spec.Template.Spec.Containers[0].Args, " "); !strings.Contains(args, "--pool-group inference.networking.x-k8s.io") {
+		t.Errorf("expected the EPP to watch v1alpha2 pools, got %s", args)
+	}
+
+	if err := r.cleanupGatewayResources(ctx, md); err != nil {
+		t.Fatalf("cleanupGatewayResources failed: %v", err)
+	}
+	if err := r.Get(ctx, key, &inferencev1alpha2.InferencePool{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the v1alpha2 InferencePool to be deleted, got %v", err)
+	}
+}
+
+func TestGateway_EPPConfigRollout(t *testing.T) {
+	scheme := newTestScheme()
+	md := newModelDeployment("test-model", "default")
+	md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeVLLM
+	detector := fakeDetector(true, "my-gateway", "gateway-ns")
+	r := newTestReconciler(scheme, detector, md)
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "test-model-epp", Namespace: "default"}
+
+	if err := r.reconcileEPP(ctx, md, "test-model"); err != nil {
+		t.Fatalf("reconcileEPP failed: %v", err)
+	}
+	var cm corev1.ConfigMap
+	if err := r.Get(ctx, key, &cm); err != nil {
+		t.Fatalf("EPP ConfigMap not found: %v", err)
+	}
+	if cm.Data[gateway.EPPConfigFile] != gateway.QueueDepthEPPConfig {
+		t.Errorf("expected the queue depth EPP config, got %q", cm.Data[gateway.EPPConfigFile])
+	}
+	var dep appsv1.Deployment
+	if err := r.Get(ctx, key, &dep); err != nil {
+		t.Fatalf("EPP Deployment not found: %v", err)
+	}
+	if got := dep.Spec.Template.Annotations[gateway.AnnotationEPPConfigChecksum]; got != gateway.EPPConfigChecksum(gateway.QueueDepthEPPConfig) {
+		t.Errorf("expected checksum of the queue depth config, got %q", got)
+	}
+
+	// Opting in to engine metrics adds the data layer
+	md.Spec.Gateway = &airunwayv1alpha1.GatewaySpec{EngineMetrics: true}
+	if err := r.reconcileEPP(ctx, md, "test-model"); err != nil {
+		t.Fatalf("reconcileEPP failed: %v", err)
+	}
+	if err := r.Get(ctx, key, &cm); err != nil {
+		t.Fatalf("EPP ConfigMap not found: %v", err)
+	}
+	generated := gateway.EPPConfigFor(md.Spec.Gateway, "", md.ResolvedEngineType())
+	if cm.Data[gateway.EPPConfigFile] != generated || !strings.Contains(generated, "vllm:num_requests_waiting") {
+		t.Errorf("expected the generated EPP config reading vllm queue depth, got %q", cm.Data[gateway.EPPConfigFile])
+	}
+	if err := r.Get(ctx, key, &dep); err != nil {
+		t.Fatalf("EPP Deployment not found: %v", err)
+	}
+	before := dep.Spec.Template.Annotations[gateway.AnnotationEPPConfigChecksum]
+	if before != gateway.EPPConfigChecksum(generated) {
+		t.Errorf("expected checksum of the generated config, got %q", before)
+	}
+
+	// Changing the config updates the ConfigMap and rolls the pods
+	custom := "apiVersion: inference.networking.x-k8s.io/v1alpha1\nkind: EndpointPickerConfig\nplugins:\n- type: queue-scorer\n"
+	md.Spec.Gateway = &airunwayv1alpha1.GatewaySpec{EPPConfig: custom}
+	if err := r.reconcileEPP(ctx, md, "test-model"); err != nil {
+		t.Fatalf("reconcileEPP failed: %v", err)
+	}
+	if err := r.Get(ctx, key, &cm); err != nil {
+		t.Fatalf("EPP ConfigMap not found: %v", err)
+	}
+	if cm.Data[gateway.EPPConfigFile] != custom {
+		t.Errorf("expected custom EPP config, got %q", cm.Data[gateway.EPPConfigFile])
+	}
+	if err := r.Get(ctx, key, &dep); err != nil {
+		t.Fatalf("EPP Deployment not found: %v", err)
+	}
+	after := dep.Spec.Template.Annotations[gateway.AnnotationEPPConfigChecksum]
+	if after == before || after != gateway.EPPConfigChecksum(custom) {
+		t.Errorf("expected checksum to follow the new config, got %q (was %q)", after, before)
+	}
+}
+
+func TestGateway_EPPSessionAffinity(t *testing.T) {
+	scheme := newTestScheme()
+	md := newModelDeployment("test-model", "default")
+	md.Spec.Gateway = &airunwayv1alpha1.GatewaySpec{SessionAffinity: airunwayv1alpha1.SessionAffinityPrefixCache}
+	detector := fakeDetector(true, "my-gateway", "gateway-ns")
+	r := newTestReconciler(scheme, detector, md)
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "test-model-epp", Namespace: "default"}
+
+	if err := r.reconcileEPP(ctx, md, "test-model"); err != nil {
+		t.Fatalf("reconcileEPP failed: %v", err)
+	}
+	var cm corev1.ConfigMap
+	if err := r.Get(ctx, key, &cm); err != nil {
+		t.Fatalf("EPP ConfigMap not found: %v", err)
+	}
+	if cm.Data[gateway.EPPConfigFile] != gateway.EPPConfigFor(md.Spec.Gateway, md.RouterMode(), md.ResolvedEngineType()) ||
+		!strings.Contains(cm.Data[gateway.EPPConfigFile], "prefix-cache-scorer") {
+		t.Errorf("expected the prefix cache EPP config, got %q", cm.Data[gateway.EPPConfigFile])
+	}
+
+	// Turning affinity off swaps the plugin chain and rolls the EPP
+	md.Spec.Gateway.SessionAffinity = airunwayv1alpha1.SessionAffinityNone
+	if err := r.reconcileEPP(ctx, md, "test-model"); err != nil {
+		t.Fatalf("reconcileEPP failed: %v", err)
+	}
+	if err := r.Get(ctx, key, &cm); err != nil {
+		t.Fatalf("EPP ConfigMap not found: %v", err)
+	}
+	loadAware := gateway.EPPConfigFor(md.Spec.Gateway, md.RouterMode(), md.ResolvedEngineType())
+	if cm.Data[gateway.EPPConfigFile] != loadAware || strings.Contains(loadAware, "prefix-cache-scorer") {
+		t.Errorf("expected the load-aware EPP config, got %q", cm.Data[gateway.EPPConfigFile])
+	}
+	var dep appsv1.Deployment
+	if err := r.Get(ctx, key, &dep); err != nil {
+		t.Fatalf("EPP Deployment not found: %v", err)
+	}
+	if got := dep.Spec.Template.Annotations[gateway.AnnotationEPPConfigChecksum]; got != gateway.EPPConfigChecksum(loadAware) {
+		t.Errorf("expected checksum of the load-aware config, got %q", got)
+	}
+}
+
+func TestGateway_EPPRouterMode(t *testing.T) {
+	scheme := newTestScheme()
+	md := newModelDeployment("test-model", "default")
+	md.Spec.Serving = &airunwayv1alpha1.ServingSpec{Router: &airunwayv1alpha1.RouterSpec{RouterMode: airunwayv1alpha1.RouterModeKV}}
+	detector := fakeDetector(true, "my-gateway", "gateway-ns")
+	r := newTestReconciler(scheme, detector, md)
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "test-model-epp", Namespace: "default"}
+
+	if err := r.reconcileEPP(ctx, md, "test-model"); err != nil {
+		t.Fatalf("reconcileEPP failed: %v", err)
+	}
+	var cm corev1.ConfigMap
+	if err := r.Get(ctx, key, &cm); err != nil {
+		t.Fatalf("EPP ConfigMap not found: %v", err)
+	}
+	if !strings.Contains(cm.Data[gateway.EPPConfigFile], "prefix-cache-scorer") {
+		t.Errorf("expected the prefix cache EPP config for router mode kv, got %q", cm.Data[gateway.EPPConfigFile])
+	}
+
+	// Switching the router mode reloads the EPP with the new profile
+	md.Spec.Serving.Router.RouterMode = airunwayv1alpha1.RouterModeNone
+	if err := r.reconcileEPP(ctx, md, "test-model"); err != nil {
+		t.Fatalf("reconcileEPP failed: %v", err)
+	}
+	if err := r.Get(ctx, key, &cm); err != nil {
+		t.Fatalf("EPP ConfigMap not found: %v", err)
+	}
+	random := gateway.EPPConfigFor(md.Spec.Gateway, md.RouterMode(), md.ResolvedEngineType())
+	if cm.Data[gateway.EPPConfigFile] != random || !strings.Contains(random, "random-picker") {
+		t.Errorf("expected the random EPP config, got %q", cm.Data[gateway.EPPConfigFile])
+	}
+	var dep appsv1.Deployment
+	if err := r.Get(ctx, key, &dep); err != nil {
+		t.Fatalf("EPP Deployment not found: %v", err)
+	}
+	if got := dep.Spec.Template.Annotations[gateway.AnnotationEPPConfigChecksum]; got != gateway.EPPConfigChecksum(random) {
+		t.Errorf("expected checksum of the random config, got %q", got)
+	}
+}
+
+func TestGateway_EPPPropagatedMetadata(t *testing.T) {
+	scheme := newTestScheme()
+	md := newModelDeployment("test-model", "default")
+	md.Spec.Labels = map[string]string{"cost-center": "ml-42", "app.kubernetes.io/name": "user-app"}
+	md.Spec.Annotations = map[string]string{"example.com/owner": "search-team"}
+	detector := fakeDetector(true, "my-gateway", "gateway-ns")
+	r := newTestReconciler(scheme, detector, md)
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "test-model-epp", Namespace: "default"}
+
+	if err := r.reconcileEPP(ctx, md, "test-model"); err != nil {
+		t.Fatalf("reconcileEPP failed: %v", err)
+	}
+	var dep appsv1.Deployment
+	if err := r.Get(ctx, key, &dep); err != nil {
+		t.Fatalf("EPP Deployment not found: %v", err)
+	}
+	if dep.Labels["cost-center"] != "ml-42" || dep.Annotations["example.com/owner"] != "search-team" {
+		t.Errorf("expected propagated metadata on the EPP Deployment, got %v %v", dep.Labels, dep.Annotations)
+	}
+	if dep.Spec.Template.Labels["cost-center"] != "ml-42" || dep.Spec.Template.Labels["app.kubernetes.io/name"] != "test-model-epp" {
+		t.Errorf("expected propagated pod labels without overriding the selector, got %v", dep.Spec.Template.Labels)
+	}
+	for _, obj := range []client.Object{&corev1.Service{}, &corev1.ConfigMap{}, &corev1.ServiceAccount{}} {
+		if err := r.Get(ctx, key, obj); err != nil {
+			t.Fatalf("EPP %T not found: %v", obj, err)
+		}
+		if obj.GetLabels()["cost-center"] != "ml-42" {
+			t.Errorf("expected propagated labels on EPP %T, got %v", obj, obj.GetLabels())
+		}
+	}
+}
+
+func TestGateway_EPPImagePullSecretsAndSecurityContext(t *testing.T) {
+	scheme := newTestScheme()
+	md := newModelDeployment("test-model", "default")
+	detector := fakeDetector(true, "my-gateway", "gateway-ns")
+	r := newTestReconciler(scheme, detector, md)
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "test-model-epp", Namespace: "default"}
+
+	podSpec := func() corev1.PodSpec {
+		t.Helper()
+		if err := r.reconcileEPP(ctx, md, "test-model"); err != nil {
+			t.Fatalf("reconcileEPP failed: %v", err)
+		}
+		var dep appsv1.Deployment
+		if err := r.Get(ctx, key, &dep); err != nil {
+			t.Fatalf("EPP Deployment not found: %v", err)
+		}
+		return dep.Spec.Template.Spec
+	}
+
+	// By default the EPP meets the restricted Pod Security Standard
+	spec := podSpec()
+	sc := spec.Containers[0].SecurityContext
+	if sc == nil || sc.RunAsNonRoot == nil || !*sc.RunAsNonRoot || sc.AllowPrivilegeEscalation == nil || *sc.AllowPrivilegeEscalation {
+		t.Errorf("expected the restricted security context, got %+v", sc)
+	}
+	if spec.Containers[0].Image != gateway.DefaultEPPImage || len(spec.ImagePullSecrets) != 0 {
+		t.Errorf("expected the default image without pull secrets, got %q and %v", spec.Containers[0].Image, spec.ImagePullSecrets)
+	}
+
+	runAsUser := int64(1000)
+	detector.EPPImage = "mirror.local/epp@sha256:0123456789abcdef0123456789abcdef"
+	detector.EPPImagePullSecrets = []string{"mirror-creds"}
+	detector.EPPSecurityContext = &corev1.SecurityContext{RunAsUser: &runAsUser}
+	spec = podSpec()
+	if spec.Containers[0].Image != detector.EPPImage {
+		t.Errorf("expected the pinned image, got %q", spec.Containers[0].Image)
+	}
+	if len(spec.ImagePullSecrets) != 1 || spec.ImagePullSecrets[0].Name != "mirror-creds" {
+		t.Errorf("expected the mirror-creds pull secret, got %v", spec.ImagePullSecrets)
+	}
+	if sc := spec.Containers[0].SecurityContext; sc == nil || sc.RunAsUser == nil || *sc.RunAsUser != 1000 || sc.RunAsNonRoot != nil {
+		t.Errorf("expected the configured security context to replace the default, got %+v", sc)
+	}
+}
+
+func TestGateway_EPPSharedRBAC(t *testing.T) {
+	scheme := newTestScheme()
+	first := newModelDeployment("first", "default")
+	second := newModelDeployment("second", "default")
+	detector := fakeDetector(true, "my-gateway", "gateway-ns")
+	detector.EPPRBACMode = gateway.EPPRBACModeNamespace
+	ctx := context.Background()
+	shared := types.NamespacedName{Name: gateway.SharedEPPRBACName, Namespace: "default"}
+
+	// An EPP created in the deployment mode has its own RBAC, replaced on the next reconcile
+	r := newTestReconciler(scheme, fakeDetector(true, "my-gateway", "gateway-ns"), first, second)
+	if err := r.reconcileEPP(ctx, first, "first"); err != nil {
+		t.Fatalf("reconcileEPP failed: %v", err)
+	}
+	r.GatewayDetector = detector
+	for _, md := range []*airunwayv1alpha1.ModelDeployment{first, second} {
+		if err := r.reconcileEPP(ctx, md, md.Name); err != nil {
+			t.Fatalf("reconcileEPP failed: %v", err)
+		}
+		var dep appsv1.Deployment
+		if err := r.Get(ctx, types.NamespacedName{Name: md.Name + "-epp", Namespace: "default"}, &dep); err != nil {
+			t.Fatalf("EPP Deployment not found: %v", err)
+		}
+		if dep.Spec.Template.Spec.ServiceAccountName != gateway.SharedEPPRBACName {
+			t.Errorf("expected the shared ServiceAccount, got %q", dep.Spec.Template.Spec.ServiceAccountName)
+		}
+	}
+	if err := r.Get(ctx, types.NamespacedName{Name: "first-epp", Namespace: "default"}, &rbacv1.Role{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the dedicated Role to be deleted, got %v", err)
+	}
+
+	var role rbacv1.Role
+	if err := r.Get(ctx, shared, &role); err != nil {
+		t.Fatalf("shared Role not found: %v", err)
+	}
+	if len(role.OwnerReferences) != 2 {
+		t.Errorf("expected both deployments to own the shared Role, got %v", role.OwnerReferences)
+	}
+	for _, rule := range role.Rules {
+		if slices.Contains(rule.Resources, "leases") {
+			t.Errorf("expected no lease permissions, got %v", rule)
+		}
+	}
+
+	// The shared RBAC is kept until the last deployment using it is cleaned up
+	if err := r.cleanupGatewayResources(ctx, first); err != nil {
+		t.Fatalf("cleanupGatewayResources failed: %v", err)
+	}
+	var binding rbacv1.RoleBinding
+	if err := r.Get(ctx, shared, &binding); err != nil {
+		t.Fatalf("expected the shared RoleBinding to be kept: %v", err)
+	}
+	if len(binding.OwnerReferences) != 1 || binding.OwnerReferences[0].Name != "second" {
+		t.Errorf("expected only second to own the shared RoleBinding, got %v", binding.OwnerReferences)
+	}
+	if err := r.cleanupGatewayResources(ctx, second); err != nil {
+		t.Fatalf("cleanupGatewayResources failed: %v", err)
+	}
+	for _, obj := range []client.Object{&corev1.ServiceAccount{}, &rbacv1.Role{}, &rbacv1.RoleBinding{}} {
+		if err := r.Get(ctx, shared, obj); !apierrors.IsNotFound(err) {
+			t.Errorf("expected the shared %T to be deleted, got %v", obj, err)
+		}
+	}
+}
+
+func TestGateway_EPPTracing(t *testing.T) {
+	scheme := newTestScheme()
+	md := newModelDeployment("test-model", "default")
+	md.Spec.Observability = &airunwayv1alpha1.ObservabilitySpec{
+		Tracing: &airunwayv1alpha1.TracingSpec{Enabled: true},
+	}
+	detector := fakeDetector(true, "my-gateway", "gateway-ns")
+	r := newTestReconciler(scheme, detector, md)
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "test-model-epp", Namespace: "default"}
+
+	eppContainer := func() corev1.Container {
+		t.Helper()
+		if err := r.reconcileEPP(ctx, md, "test-model"); err != nil {
+			t.Fatalf("reconcileEPP failed: %v", err)
+		}
+		var dep appsv1.Deployment
+		if err := r.Get(ctx, key, &dep); err != nil {
+			t.Fatalf("EPP Deployment not found: %v", err)
+		}
+		return dep.Spec.Template.Spec.Containers[0]
+	}
+	envValue := func(c corev1.Container, name string) string {
+		for _, e := range c.Env {
+			if e.Name == name {
+				return e.Value
+			}
+		}
+		return ""
+	}
+
+	// Without a collector endpoint tracing stays off
+	if c := eppContainer(); !slices.Contains(c.Args, "--tracing=false") {
+		t.Errorf("expected tracing disabled without an endpoint, got args %v", c.Args)
+	}
+
+	// The controller-wide endpoint is used, and the spec endpoint wins over it
+	r.TracingEndpoint = "http://otel-collector.observability:4317"
+	c := eppContainer()
+	if !slices.Contains(c.Args, "--tracing=true") {
+		t.Errorf("expected tracing enabled, got args %v", c.Args)
+	}
+	if got := envValue(c, "OTEL_EXPORTER_OTLP_ENDPOINT"); got != r.TracingEndpoint {
+		t.Errorf("expected controller endpoint, got %q", got)
+	}
+	if got := envValue(c, "OTEL_TRACES_SAMPLER_ARG"); got != "0.1" {
+		t.Errorf("expected default sampling ratio 0.1, got %q", got)
+	}
+	if got := envValue(c, "OTEL_RESOURCE_ATTRIBUTES"); !strings.Contains(got, "airunway.deployment=default%2Ftest-model") {
+		t.Errorf("expected deployment resource attribute, got %q", got)
+	}
+	md.Spec.Observability.Tracing.Endpoint = "http://tempo.tracing:4317"
+	if got := envValue(eppContainer(), "OTEL_EXPORTER_OTLP_ENDPOINT"); got != "http://tempo.tracing:4317" {
+		t.Errorf("expected spec endpoint, got %q", got)
+	}
+
+	// Disabling tracing removes the OpenTelemetry environment
+	md.Spec.Observability.Tracing.Enabled = false
+	c = eppContainer()
+	if !slices.Contains(c.Args, "--tracing=false") || envValue(c, "OTEL_EXPORTER_OTLP_ENDPOINT") != "" {
+		t.Errorf("expected tracing disabled, got args %v and env %v", c.Args, c.Env)
+	}
+}
+
+func TestGateway_EPPLogSink(t *testing.T) {
+	scheme := newTestScheme()
+	md := newModelDeployment("test-model", "default")
+	md.Spec.Observability = &airunwayv1alpha1.ObservabilitySpec{
+		LogSink: &airunwayv1alpha1.LogSinkSpec{
+			Type:     airunwayv1alpha1.LogSinkTypeLoki,
+			Endpoint: "http://loki.observability:3100",
+		},
+	}
+	detector := fakeDetector(true, "my-gateway", "gateway-ns")
+	r := newTestReconciler(scheme, detector, md)
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "test-model-epp", Namespace: "default"}
+	cmKey := types.NamespacedName{Name: "test-model-epp-log-sink", Namespace: "default"}
+
+	if err := r.reconcileEPP(ctx, md, "test-model"); err != nil {
+		t.Fatalf("reconcileEPP failed: %v", err)
+	}
+	var cm corev1.ConfigMap
+	if err := r.Get(ctx, cmKey, &cm); err != nil {
+		t.Fatalf("EPP log sink ConfigMap not found: %v", err)
+	}
+	if !strings.Contains(cm.Data["fluent-bit.yaml"], "model_deployment=test-model") {
+		t.Errorf("expected the ModelDeployment label in the sink config, got %s", cm.Data["fluent-bit.yaml"])
+	}
+	var dep appsv1.Deployment
+	if err := r.Get(ctx, key, &dep); err != nil {
+		t.Fatalf("EPP Deployment not found: %v", err)
+	}
+	containers := dep.Spec.Template.Spec.Containers
+	if len(containers) != 2 || containers[1].Name != "log-sink" {
+		t.Fatalf("expected the log sink sidecar next to the EPP, got %v", containers)
+	}
+	var mountsConfig bool
+	for _, v := range dep.Spec.Template.Spec.Volumes {
+		if v.ConfigMap != nil && v.ConfigMap.Name == cmKey.Name {
+			mountsConfig = true
+		}
+	}
+	if !mountsConfig {
+		t.Errorf("expected the EPP log sink ConfigMap to be mounted, got %v", dep.Spec.Template.Spec.Volumes)
+	}
+
+	// Removing the sink removes the sidecar and its ConfigMap
+	md.Spec.Observability.LogSink = nil
+	if err := r.reconcileEPP(ctx, md, "test-model"); err != nil {
+		t.Fatalf("reconcileEPP failed: %v", err)
+	}
+	if err := r.Get(ctx, key, &dep); err != nil {
+		t.Fatal(err)
+	}
+	if len(dep.Spec.Template.Spec.Containers) != 1 {
+		t.Errorf("expected the sidecar to be removed, got %v", dep.Spec.Template.Spec.Containers)
+	}
+	if err := r.Get(ctx, cmKey, &cm); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the EPP log sink ConfigMap to be deleted, got %v", err)
+	}
+}
+
+func TestGateway_HTTPRouteTracingHeaders(t *testing.T) {
+	scheme := newTestScheme()
+	md := newModelDeployment("test-model", "default")
+	md.Spec.Observability = &airunwayv1alpha1.ObservabilitySpec{
+		Tracing: &airunwayv1alpha1.TracingSpec{Enabled: true},
+	}
+	gw := newTestGateway("my-gateway", "gateway-ns")
+	detector := fakeDetector(true, "my-gateway", "gateway-ns")
+	r := newTestReconciler(scheme, detector, md, gw)
+	ctx := context.Background()
+
+	gwConfig := &gateway.GatewayConfig{GatewayName: "my-gateway", GatewayNamespace: "gateway-ns"}
+	backend := httpRouteBackendTarget{group: "inference.networking.k8s.io", kind: "InferencePool", name: md.Name, namespace: md.Namespace}
+	if err := r.reconcileHTTPRoute(ctx, md, gwConfig, "test-model", backend); err != nil {
+		t.Fatalf("reconcileHTTPRoute failed: %v", err)
+	}
+
+	var route gatewayv1.HTTPRoute
+	if err := r.Get(ctx, types.NamespacedName{Name: "test-model", Namespace: "default"}, &route); err != nil {
+		t.Fatalf("HTTPRoute not found: %v", err)
+	}
+	filters := route.Spec.Rules[0].Filters
+	if len(filters) != 1 || filters[0].Type != gatewayv1.HTTPRouteFilterRequestHeaderModifier {
+		t.Fatalf("expected a RequestHeaderModifier filter, got %+v", filters)
+	}
+	want := []gatewayv1.HTTPHeader{
+		{Name: gateway.HeaderDeployment, Value: "default/test-model"},
+		{Name: gateway.HeaderModel, Value: "test-model"},
+	}
+	if got := filters[0].RequestHeaderModifier.Set; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected request headers %v, got %v", want, got)
+	}
+}
+
+func TestGateway_HTTPRouteCreation(t *testing.T) {
+	scheme := newTestScheme()
+	md := newModelDeployment("test-model", "default")
+	detector := fakeDetector(true, "my-gateway", "gateway-ns")
+	r := newTestReconciler(scheme, detector, md)
+	ctx := context.Background()
+
+	gwConfig := &gateway.GatewayConfig{
+		GatewayName:      "my-gateway",
+		GatewayNamespace: "gateway-ns",
+	}
+
+	err := r.reconcileHTTPRoute(ctx, md, gwConfig, "meta-llama/Llama-3-8B", httpRouteBackendTarget{
+		group:     "inference.networking.k8s.io",
+		kind:      "InferencePool",
+		name:      md.Name,
+		namespace: md.Namespace,
+	})
+	if err != nil {
+		t.Fatalf("reconcileHTTPRoute failed: %v", err)
+	}
+
+	// Verify HTTPRoute was created
+	var route gatewayv1.HTTPRoute
+	if err := r.Get(ctx, types.NamespacedName{Name: "test-model", Namespace: "default"}, &route); err != nil {
+		t.Fatalf("HTTPRoute not found: %v", err)
+	}
+
+	// Check parent ref points to the gateway
+	if len(route.Spec.ParentRefs) != 1 {
+		t.Fatalf("expected 1 parent ref, got %d", len(route.Spec.ParentRefs))
+	}
+	parentRef := route.Spec.ParentRefs[0]
+	if string(parentRef.Name) != "my-gateway" {
+		t.Errorf("expected parent ref name %q, got %q", "my-gateway", parentRef.Name)
+	}
+	if parentRef.Namespace == nil || string(*parentRef.Namespace) != "gateway-ns" {
+		t.Errorf("expected parent ref namespace %q, got %v", "gateway-ns", parentRef.Namespace)
+	}
+
+	// Check backend ref points to InferencePool
+	if len(route.Spec.Rules) != 1 {
+		t.Fatalf("expected 1 rule, got %d", len(route.Spec.Rules))
+	}
+	if len(route.Spec.Rules[0].BackendRefs) != 1 {
+		t.Fatalf("expected 1 backend ref, got %d", len(route.Spec.Rules[0].BackendRefs))
+	}
+	backendRef := route.Spec.Rules[0].BackendRefs[0]
+	if string(backendRef.Name) != "test-model" {
+		t.Errorf("expected backend ref name %q, got %q", "test-model", backendRef.Name)
+	}
+	if backendRef.Group == nil || string(*backendRef.Group) != "inference.networking.k8s.io" {
+		t.Errorf("expected backend ref group %q, got %v", "inference.networking.k8s.io", backendRef.Group)
+	}
+	if backendRef.Kind == nil || string(*backendRef.Kind) != "InferencePool" {
+		t.Errorf("expected backend ref kind %q, got %v", "InferencePool", backendRef.Kind)
+	}
+
+	// Check OwnerReference
+	if len(route.OwnerReferences) != 1 {
+		t.Fatalf("expected 1 owner reference, got %d", len(route.OwnerReferences))
+	}
+	if route.OwnerReferences[0].Name != "test-model" {
+		t.Errorf("expected owner ref name %q, got %q", "test-model", route.OwnerReferences[0].Name)
+	}
+}
+
+func TestGateway_HTTPRouteGatewayMigration(t *testing.T) {
+	scheme := newTestScheme()
+	md := newModelDeployment("test-model", "model-ns")
+	oldGateway := gwWithNamespaceSelector("old-gateway", "old-ns", "model-ns", "other-ns")
+	newGateway := gwWithNamespaceSelector("new-gateway", "gateway-ns", "model-ns")
+	detector := fakeDetector(true, "new-gateway", "gateway-ns")
+	detector.PatchGateway = true
+	r := newTestReconciler(scheme, detector, md, oldGateway, newGateway)
+	recorder := events.NewFakeRecorder(5)
+	r.Recorder = recorder
+	ctx := context.Background()
+	backend := httpRouteBackendTarget{group: "inference.networking.k8s.io", kind: "InferencePool", name: md.Name, namespace: md.Namespace}
+
+	// A route created while the controller used the old gateway flags
+	oldConfig := &gateway.GatewayConfig{GatewayName: "old-gateway", GatewayNamespace: "old-ns"}
+	if err := r.reconcileHTTPRoute(ctx, md, oldConfig, "llama", backend); err != nil {
+		t.Fatalf("reconcileHTTPRoute failed: %v", err)
+	}
+	if len(recorder.Events) != 0 {
+		t.Fatalf("expected no event on creation, got %d", len(recorder.Events))
+	}
+
+	newConfig := &gateway.GatewayConfig{GatewayName: "new-gateway", GatewayNamespace: "gateway-ns"}
+	if err := r.reconcileHTTPRoute(ctx, md, newConfig, "llama", backend); err != nil {
+		t.Fatalf("reconcileHTTPRoute failed: %v", err)
+	}
+	var route gatewayv1.HTTPRoute
+	if err := r.Get(ctx, types.NamespacedName{Name: "test-model", Namespace: "model-ns"}, &route); err != nil {
+		t.Fatalf("HTTPRoute not found: %v", err)
+	}
+	if parent, _ := httpRouteGateway(&route); parent != (types.NamespacedName{Name: "new-gateway", Namespace: "gateway-ns"}) {
+		t.Errorf("expected the route to move to gateway-ns/new-gateway, got %s", parent)
+	}
+	select {
+	case e := <-recorder.Events:
+		if !strings.Contains(e, "Normal GatewayMigrated") || !strings.Contains(e, "from Gateway old-ns/old-gateway to gateway-ns/new-gateway") {
+			t.Errorf("unexpected event %q", e)
+		}
+	default:
+		t.Error("expected a GatewayMigrated event")
+	}
+
+	// The old gateway no longer accepts routes from the namespace
+	var updated gatewayv1.Gateway
+	if err := r.Get(ctx, types.NamespacedName{Name: "old-gateway", Namespace: "old-ns"}, &updated); err != nil {
+		t.Fatalf("failed to get Gateway: %v", err)
+	}
+	if allowed := allowedNamespacesFromGateway(&updated); allowed["model-ns"] || !allowed["other-ns"] {
+		t.Errorf("expected only other-ns to stay allowed on the old gateway, got %v", allowed)
+	}
+
+	// Reconciling again on the same gateway is not a migration
+	if err := r.reconcileHTTPRoute(ctx, md, newConfig, "llama", backend); err != nil {
+		t.Fatalf("reconcileHTTPRoute failed: %v", err)
+	}
+	if len(recorder.Events) != 0 {
+		t.Errorf("expected no further event, got %d", len(recorder.Events))
+	}
+}
+
+// newUserHTTPRoute creates a user-managed HTTPRoute to the InferencePool of mdName, accepted
+// by my-gateway when accepted is set
+func newUserHTTPRoute(name, mdName string, accepted bool) *gatewayv1.HTTPRoute {
+	group := gatewayv1.Group("inference.networking.k8s.io")
+	kind := gatewayv1.Kind("InferencePool")
+	status := metav1.ConditionFalse
+	reason := "NotAllowedByListeners"
+	if accepted {
+		status, reason = metav1.ConditionTrue, "Accepted"
+	}
+	return &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: gatewayv1.HTTPRouteSpec{
+			Rules: []gatewayv1.HTTPRouteRule{{
+				BackendRefs: []gatewayv1.HTTPBackendRef{{
+					BackendRef: gatewayv1.BackendRef{
+						BackendObjectReference: gatewayv1.BackendObjectReference{Group: &group, Kind: &kind, Name: gatewayv1.ObjectName(mdName)},
+					},
+				}},
+			}},
+		},
+		Status: gatewayv1.HTTPRouteStatus{RouteStatus: gatewayv1.RouteStatus{
+			Parents: []gatewayv1.RouteParentStatus{{
+				ParentRef: gatewayv1.ParentReference{Name: "my-gateway"},
+				Conditions: []metav1.Condition{{
+					Type:    string(gatewayv1.RouteConditionAccepted),
+					Status:  status,
+					Reason:  reason,
+					Message: "listener does not allow the route",
+				}},
+			}},
+		}},
+	}
+}
+
+func TestGateway_HTTPRouteRef(t *testing.T) {
+	tests := []struct {
+		name       string
+		route      *gatewayv1.HTTPRoute
+		wantStatus metav1.ConditionStatus
+		wantReason string
+	}{
+		{name: "accepted", route: newUserHTTPRoute("user-route", "test-model", true), wantStatus: metav1.ConditionTrue, wantReason: "GatewayConfigured"},
+		{name: "missing", wantStatus: metav1.ConditionFalse, wantReason: reasonHTTPRouteNotFound},
+		{name: "other backend", route: newUserHTTPRoute("user-route", "other-model", true), wantStatus: metav1.ConditionFalse, wantReason: reasonHTTPRouteBackendMismatch},
+		{name: "not accepted", route: newUserHTTPRoute("user-route", "test-model", false), wantStatus: metav1.ConditionFalse, wantReason: reasonHTTPRouteNotAccepted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md := newModelDeployment("test-model", "default")
+			md.Spec.Gateway = &airunwayv1alpha1.GatewaySpec{HTTPRouteRef: "user-route"}
+			objs := []client.Object{md, newTestGateway("my-gateway", "gateway-ns")}
+			if tt.route != nil {
+				objs = append(objs, tt.route)
+			}
+			r := newTestReconciler(newTestScheme(), fakeDetector(true, "my-gateway", "gateway-ns"), objs...)
+			if err := r.reconcileGateway(context.Background(), md); err != nil {
+				t.Fatalf("reconcileGateway failed: %v", err)
+			}
+			cond := meta.FindStatusCondition(md.Status.Conditions, airunwayv1alpha1.ConditionTypeGatewayReady)
+			if cond == nil || cond.Status != tt.wantStatus || cond.Reason != tt.wantReason {
+				t.Fatalf("expected GatewayReady %s/%s, got %+v", tt.wantStatus, tt.wantReason, cond)
+			}
+			if tt.wantReason == reasonHTTPRouteNotAccepted && !strings.Contains(cond.Message, "listener does not allow the route") {
+				t.Errorf("expected the Gateway rejection in the message, got %q", cond.Message)
+			}
+		})
+	}
+}
+
+func TestGateway_HTTPRouteRefReleasesGeneratedRoute(t *testing.T) {
+	ctx := context.Background()
+	gwConfig := &gateway.GatewayConfig{GatewayName: "my-gateway", GatewayNamespace: "gateway-ns"}
+	backend := httpRouteBackendTarget{group: "inference.networking.k8s.io", kind: "InferencePool", name: "test-model", namespace: "default"}
+
+	// A reference to another route deletes the generated one
+	md := newModelDeployment("test-model", "default")
+	r := newTestReconciler(newTestScheme(), fakeDetector(true, "my-gateway", "gateway-ns"), md)
+	if err := r.reconcileHTTPRoute(ctx, md, gwConfig, "meta-llama/Llama-3-8B", backend); err != nil {
+		t.Fatalf("reconcileHTTPRoute failed: %v", err)
+	}
+	md.Spec.Gateway = &airunwayv1alpha1.GatewaySpec{HTTPRouteRef: "user-route"}
+	if err := r.releaseGeneratedHTTPRoute(ctx, md); err != nil {
+		t.Fatalf("releaseGeneratedHTTPRoute failed: %v", err)
+	}
+	var route gatewayv1.HTTPRoute
+	if err := r.Get(ctx, types.NamespacedName{Name: "test-model", Namespace: "default"}, &route); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the generated HTTPRoute to be deleted, got %v", err)
+	}
+
+	// A reference to the generated route adopts it
+	md = newModelDeployment("test-model", "default")
+	r = newTestReconciler(newTestScheme(), fakeDetector(true, "my-gateway", "gateway-ns"), md)
+	if err := r.reconcileHTTPRoute(ctx, md, gwConfig, "meta-llama/Llama-3-8B", backend); err != nil {
+		t.Fatalf("reconcileHTTPRoute failed: %v", err)
+	}
+	md.Spec.Gateway = &airunwayv1alpha1.GatewaySpec{HTTPRouteRef: "test-model"}
+	if err := r.releaseGeneratedHTTPRoute(ctx, md); err != nil {
+		t.Fatalf("releaseGeneratedHTTPRoute failed: %v", err)
+	}
+	if err := r.Get(ctx, types.NamespacedName{Name: "test-model", Namespace: "default"}, &route); err != nil {
+		t.Fatalf("expected the adopted HTTPRoute to be kept: %v", err)
+	}
+	if metav1.IsControlledBy(&route, md) {
+		t.Error("expected the adopted HTTPRoute to drop its controller reference")
+	}
+}
+
+func TestGateway_HTTPRouteStreaming(t *testing.T) {
+	scheme := newTestScheme()
+	md := newModelDeployment("test-model", "default")
+	md.Spec.Gateway = &airunwayv1alpha1.GatewaySpec{Streaming: true}
+	gw := newTestGateway("my-gateway", "gateway-ns")
+	detector := fakeDetector(true, "my-gateway", "gateway-ns")
+	r := newTestReconciler(scheme, detector, md, gw)
+	ctx := context.Background()
+
+	gwConfig := &gateway.GatewayConfig{GatewayName: "my-gateway", GatewayNamespace: "gateway-ns"}
+	backend := httpRouteBackendTarget{group: "inference.networking.k8s.io", kind: "InferencePool", name: md.Name, namespace: md.Namespace}
+	if err := r.reconcileHTTPRoute(ctx, md, gwConfig, "test-model", backend); err != nil {
+		t.Fatalf("reconcileHTTPRoute failed: %v", err)
+	}
+
+	var route gatewayv1.HTTPRoute
+	if err := r.Get(ctx, types.NamespacedName{Name: "test-model", Namespace: "default"}, &route); err != nil {
+		t.Fatalf("HTTPRoute not found: %v", err)
+	}
+	if got := *route.Spec.Rules[0].Timeouts.Request; got != "1h" {
+		t.Errorf("expected streaming request timeout 1h, got %q", got)
+	}
+	if route.Annotations[gateway.AnnotationStreaming] != "true" {
+		t.Errorf("expected %s annotation, got %v", gateway.AnnotationStreaming, route.Annotations)
+	}
+
+	// Disabling streaming removes the annotation and restores the default timeout.
+	md.Spec.Gateway.Streaming = false
+	if err := r.reconcileHTTPRoute(ctx, md, gwConfig, "test-model", backend); err != nil {
+		t.Fatalf("reconcileHTTPRoute failed: %v", err)
+	}
+	if err := r.Get(ctx, types.NamespacedName{Name: "test-model", Namespace: "default"}, &route); err != nil {
+		t.Fatalf("HTTPRoute not found: %v", err)
+	}
+	if got := *route.Spec.Rules[0].Timeouts.Request; got != "5m" {
+		t.Errorf("expected default request timeout 5m, got %q", got)
+	}
+	if _, ok := route.Annotations[gateway.AnnotationStreaming]; ok {
+		t.Errorf("expected annotation %s to be removed", gateway.AnnotationStreaming)
+	}
+}
+
+func TestGateway_HTTPRouteResponseHeaders(t *testing.T) {
+	scheme := newTestScheme()
+	md := newModelDeployment("test-model", "default")
+	md.Spec.Gateway = &airunwayv1alpha1.GatewaySpec{
+		ResponseHeaders: []airunwayv1alpha1.ResponseHeader{
+			airunwayv1alpha1.ResponseHeaderProvider,
+			airunwayv1alpha1.ResponseHeaderModel,
+			airunwayv1alpha1.ResponseHeaderDeployment,
+		},
+	}
+	md.Status.Provider = &airunwayv1alpha1.ProviderStatus{Name: "kaito"}
+	gw := newTestGateway("my-gateway", "gateway-ns")
+	detector := fakeDetector(true, "my-gateway", "gateway-ns")
+	r := newTestReconciler(scheme, detector, md, gw)
+	ctx := context.Background()
+
+	gwConfig := &gateway.GatewayConfig{GatewayName: "my-gateway", GatewayNamespace: "gateway-ns"}
+	backend := httpRouteBackendTarget{group: "inference.networking.k8s.io", kind: "InferencePool", name: md.Name, namespace: md.Namespace}
+	if err := r.reconcileHTTPRoute(ctx, md, gwConfig, "test-model", backend); err != nil {
+		t.Fatalf("reconcileHTTPRoute failed: %v", err)
+	}
+
+	var route gatewayv1.HTTPRoute
+	if err := r.Get(ctx, types.NamespacedName{Name: "test-model", Namespace: "default"}, &route); err != nil {
+		t.Fatalf("HTTPRoute not found: %v", err)
+	}
+	filters := route.Spec.Rules[0].Filters
+	if len(filters) != 1 || filters[0].Type != gatewayv1.HTTPRouteFilterResponseHeaderModifier {
+		t.Fatalf("expected a ResponseHeaderModifier filter, got %+v", filters)
+	}
+	want := []gatewayv1.HTTPHeader{
+		{Name: gateway.HeaderDeployment, Value: "default/test-model"},
+		{Name: gateway.HeaderModel, Value: "test-model"},
+		{Name: gateway.HeaderProvider, Value: "kaito"},
+	}
+	if got := filters[0].ResponseHeaderModifier.Set; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected sorted response headers %v, got %v", want, got)
+	}
+
+	// Removing the headers removes the filter
+	md.Spec.Gateway.ResponseHeaders = nil
+	if err := r.reconcileHTTPRoute(ctx, md, gwConfig, "test-model", backend); err != nil {
+		t.Fatalf("reconcileHTTPRoute failed: %v", err)
+	}
+	if err := r.Get(ctx, types.NamespacedName{Name: "test-model", Namespace: "default"}, &route); err != nil {
+		t.Fatalf("HTTPRoute not found: %v", err)
+	}
+	if len(route.Spec.Rules[0].Filters) != 0 {
+		t.Errorf("expected no filters, got %+v", route.Spec.Rules[0].Filters)
+	}
+}
+
+func TestGateway_HTTPRouteKeepsUserAnnotations(t *testing.T) {
+	scheme := newTestScheme()
+	md := newModelDeployment("test-model", "default")
+	gw := newTestGateway("my-gateway", "gateway-ns")
+	existing := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-model",
+			Namespace: "default",
+			Annotations: map[string]string{
+				gateway.AnnotationStreaming:   "true",
+				"example.com/user-annotation": "keep",
+			},
+		},
+	}
+	detector := fakeDetector(true, "my-gateway", "gateway-ns")
+	r := newTestReconciler(scheme, detector, md, gw, existing)
+	ctx := context.Background()
+
+	gwConfig := &gateway.GatewayConfig{GatewayName: "my-gateway", GatewayNamespace: "gateway-ns"}
+	backend := httpRouteBackendTarget{group: "inference.networking.k8s.io", kind: "InferencePool", name: md.Name, namespace: md.Namespace}
+	if err := r.reconcileHTTPRoute(ctx, md, gwConfig, "test-model", backend); err != nil {
+		t.Fatalf("reconcileHTTPRoute failed: %v", err)
+	}
+
+	var route gatewayv1.HTTPRoute
+	if err := r.Get(ctx, types.NamespacedName{Name: "test-model", Namespace: "default"}, &route); err != nil {
+		t.Fatalf("HTTPRoute not found: %v", err)
+	}
+	want := map[string]string{"example.com/user-annotation": "keep"}
+	if !reflect.DeepEqual(route.Annotations, want) {
+		t.Errorf("expected annotations %v, got %v", want, route.Annotations)
+	}
+}
+
+func TestHTTPRouteTimeout(t *testing.T) {
+	tests := []struct {
+		name string
+		gw   *airunwayv1alpha1.GatewaySpec
+		want time.Duration
+	}{
+		{name: "default", gw: nil, want: 300 * time.Second},
+		{name: "streaming default", gw: &airunwayv1alpha1.GatewaySpec{Streaming: true}, want: time.Hour},
+		{name: "explicit", gw: &airunwayv1alpha1.GatewaySpec{Streaming: true, Timeout: &metav1.Duration{Duration: 90 * time.Second}}, want: 90 * time.Second},
+		{name: "disabled", gw: &airunwayv1alpha1.GatewaySpec{Timeout: &metav1.Duration{}}, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md := newModelDeployment("test-model", "default")
+			md.Spec.Gateway = tt.gw
+			if got := httpRouteTimeout(md); got != tt.want {
+				t.Errorf("httpRouteTimeout() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPublicModelName(t *testing.T) {
+	md := newModelDeployment("llama", "team-a")
+	if got, err := publicModelName(md, "meta-llama/Llama-3-8B"); err != nil || got != "meta-llama/Llama-3-8B" {
+		t.Errorf("expected served name without template, got %q (err %v)", got, err)
+	}
+
+	md.Spec.Gateway = &airunwayv1alpha1.GatewaySpec{ModelNameTemplate: "{{ .Namespace }}/{{ .ModelName }}"}
+	if got, err := publicModelName(md, "meta-llama/Llama-3-8B"); err != nil || got != "team-a/meta-llama/Llama-3-8B" {
+		t.Errorf("expected templated name, got %q (err %v)", got, err)
+	}
+
+	md.Spec.Gateway.ModelNameTemplate = "{{ .Unknown }}"
+	if _, err := publicModelName(md, "meta-llama/Llama-3-8B"); err == nil {
+		t.Error("expected error for unknown template field")
+	}
+}
+
+func TestGateway_ModelRewrite(t *testing.T) {
+	scheme := newTestScheme()
+	rewriteMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{gateway.InferenceModelRewriteGVK.GroupVersion()})
+	rewriteMapper.Add(gateway.InferenceModelRewriteGVK, meta.RESTScopeNamespace)
+	md := newModelDeployment("llama", "team-a")
+	r := &ModelDeploymentReconciler{
+		Client: fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithRESTMapper(meta.MultiRESTMapper{rewriteMapper, testrestmapper.TestOnlyStaticRESTMapper(scheme)}).
+			WithObjects(md).
+			Build(),
+		Scheme: scheme,
+	}
+	ctx := context.Background()
+
+	if err := r.reconcileModelRewrite(ctx, md, "llama", "team-a", "team-a/llama", "meta-llama/Llama-3-8B"); err != nil {
+		t.Fatalf("reconcileModelRewrite failed: %v", err)
+	}
+
+	rewrite := &unstructured.Unstructured{}
+	rewrite.SetGroupVersionKind(gateway.InferenceModelRewriteGVK)
+	if err := r.Get(ctx, types.NamespacedName{Name: "llama", Namespace: "team-a"}, rewrite); err != nil {
+		t.Fatalf("InferenceModelRewrite not found: %v", err)
+	}
+	poolName, _, _ := unstructured.NestedString(rewrite.Object, "spec", "poolRef", "name")
+	if poolName != "llama" {
+		t.Errorf("expected poolRef name %q, got %q", "llama", poolName)
+	}
+	rules, _, _ := unstructured.NestedSlice(rewrite.Object, "spec", "rules")
+	if len(rules) != 1 {
+		t.Fatalf("expected 1 rule, got %d", len(rules))
+	}
+	rule := rules[0].(map[string]interface{})
+	match, _, _ := unstructured.NestedString(rule["matches"].([]interface{})[0].(map[string]interface{}), "model", "value")
+	if match != "team-a/llama" {
+		t.Errorf("expected match on public name, got %q", match)
+	}
+	target, _, _ := unstructured.NestedString(rule["targets"].([]interface{})[0].(map[string]interface{}), "modelRewrite")
+	if target != "meta-llama/Llama-3-8B" {
+		t.Errorf("expected rewrite to served name, got %q", target)
+	}
+	if len(rewrite.GetOwnerReferences()) != 1 {
+		t.Errorf("expected owner reference on InferenceModelRewrite")
+	}
+
+	// Removing the template deletes the rewrite.
+	if err := r.reconcileModelRewrite(ctx, md, "llama", "team-a", "meta-llama/Llama-3-8B", "meta-llama/Llama-3-8B"); err != nil {
+		t.Fatalf("reconcileModelRewrite failed: %v", err)
+	}
+	if err := r.Get(ctx, types.NamespacedName{Name: "llama", Namespace: "team-a"}, rewrite); err == nil {
+		t.Error("expected InferenceModelRewrite to be deleted")
+	}
+}
+
+func TestGateway_ModelAliases(t *testing.T) {
+	scheme := newTestScheme()
+	rewriteMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{gateway.InferenceModelRewriteGVK.GroupVersion()})
+	rewriteMapper.Add(gateway.InferenceModelRewriteGVK, meta.RESTScopeNamespace)
+	md := newModelDeployment("llama", "team-a")
+	md.Spec.Gateway = &airunwayv1alpha1.GatewaySpec{ModelAliases: []string{"llama-3", "meta-llama/Llama-3-8B", "gpt-legacy"}}
+	md.Spec.Model.Adapters = []airunwayv1alpha1.LoRAAdapter{{Name: "llama-sql", Source: "/adapters/sql"}}
+	r := &ModelDeploymentReconciler{
+		Client: fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithRESTMapper(meta.MultiRESTMapper{rewriteMapper, testrestmapper.TestOnlyStaticRESTMapper(scheme)}).
+			WithObjects(md).
+			Build(),
+		Scheme: scheme,
+	}
+	ctx := context.Background()
+	servedName := "meta-llama/Llama-3-8B"
+
+	// The aliases are rewritten to the served name, which is never rewritten to itself
+	if err := r.reconcileModelRewrite(ctx, md, "llama", "team-a", servedName, servedName); err != nil {
+		t.Fatalf("reconcileModelRewrite failed: %v", err)
+	}
+	rewrite := &unstructured.Unstructured{}
+	rewrite.SetGroupVersionKind(gateway.InferenceModelRewriteGVK)
+	if err := r.Get(ctx, types.NamespacedName{Name: "llama", Namespace: "team-a"}, rewrite); err != nil {
+		t.Fatalf("InferenceModelRewrite not found: %v", err)
+	}
+	rules, _, _ := unstructured.NestedSlice(rewrite.Object, "spec", "rules")
+	if len(rules) != 1 {
+		t.Fatalf("expected 1 rule, got %d", len(rules))
+	}
+	var matched []string
+	for _, m := range rules[0].(map[string]interface{})["matches"].([]interface{}) {
+		value, _, _ := unstructured.NestedString(m.(map[string]interface{}), "model", "value")
+		matched = append(matched, value)
+	}
+	if !slices.Equal(matched, []string{"llama-3", "gpt-legacy"}) {
+		t.Errorf("expected matches on the aliases, got %v", matched)
+	}
+
+	// The generated HTTPRoute matches the public name, every alias and the adapters
+	if err := r.reconcileHTTPRoute(ctx, md, &gateway.GatewayConfig{GatewayName: "my-gateway", GatewayNamespace: "gateway-ns"}, servedName,
+		httpRouteBackendTarget{group: "inference.networking.k8s.io", kind: "InferencePool", name: md.Name, namespace: md.Namespace}); err != nil {
+		t.Fatalf("reconcileHTTPRoute failed: %v", err)
+	}
+	var route gatewayv1.HTTPRoute
+	if err := r.Get(ctx, types.NamespacedName{Name: "llama", Namespace: "team-a"}, &route); err != nil {
+		t.Fatalf("HTTPRoute not found: %v", err)
+	}
+	var headers []string
+	for _, match := range route.Spec.Rules[0].Matches {
+		headers = append(headers, match.Headers[0].Value)
+	}
+	if !slices.Equal(headers, []string{servedName, "llama-3", "gpt-legacy", "llama-sql"}) {
+		t.Errorf("expected route matches on the served name, aliases and adapters, got %v", headers)
+	}
+}
+
+func TestGateway_RateLimitPolicy(t *testing.T) {
+	scheme := newTestScheme()
+	gvk := gateway.EnvoyGatewayBackendTrafficPolicyGVK
+	policyMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{gvk.GroupVersion()})
+	policyMapper.Add(gvk, meta.RESTScopeNamespace)
+	md := newModelDeployment("llama", "default")
+	md.Spec.Gateway = &airunwayv1alpha1.GatewaySpec{
+		RateLimit: &airunwayv1alpha1.RateLimitSpec{RequestsPerMinute: 120, MaxConcurrent: 4},
+	}
+	gw := newTestGateway("my-gateway", "gateway-ns")
+	gwClass := &gatewayv1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "istio"},
+		Spec:       gatewayv1.GatewayClassSpec{ControllerName: "gateway.envoyproxy.io/gatewayclass-controller"},
+	}
+	r := &ModelDeploymentReconciler{
+		Client: fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithRESTMapper(meta.MultiRESTMapper{policyMapper, testrestmapper.TestOnlyStaticRESTMapper(scheme)}).
+			WithObjects(md, gw, gwClass).
+			Build(),
+		Scheme: scheme,
+	}
+	ctx := context.Background()
+	gwConfig := &gateway.GatewayConfig{GatewayName: "my-gateway", GatewayNamespace: "gateway-ns"}
+
+	if err := r.reconcileRateLimitPolicy(ctx, md, gwConfig); err != nil {
+		t.Fatalf("reconcileRateLimitPolicy failed: %v", err)
+	}
+	policy := &unstructured.Unstructured{}
+	policy.SetGroupVersionKind(gvk)
+	if err := r.Get(ctx, types.NamespacedName{Name: "llama", Namespace: "default"}, policy); err != nil {
+		t.Fatalf("BackendTrafficPolicy not found: %v", err)
+	}
+	parallel, _, _ := unstructured.NestedInt64(policy.Object, "spec", "circuitBreaker", "maxParallelRequests")
+	if parallel != 4 {
+		t.Errorf("expected maxParallelRequests 4, got %d", parallel)
+	}
+	if len(policy.GetOwnerReferences()) != 1 {
+		t.Error("expected owner reference on BackendTrafficPolicy")
+	}
+
+	// Removing the rate limit deletes the policy.
+	md.Spec.Gateway.RateLimit = nil
+	if err := r.reconcileRateLimitPolicy(ctx, md, gwConfig); err != nil {
+		t.Fatalf("reconcileRateLimitPolicy failed: %v", err)
+	}
+	if err := r.Get(ctx, types.NamespacedName{Name: "llama", Namespace: "default"}, policy); err == nil {
+		t.Error("expected BackendTrafficPolicy to be deleted")
+	}
+}
+
+func TestGateway_PromptPolicy(t *testing.T) {
+	scheme := newTestScheme()
+	gvk := gateway.EnvoyGatewayExtensionPolicyGVK
+	policyMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{gvk.GroupVersion()})
+	policyMapper.Add(gvk, meta.RESTScopeNamespace)
+	md := newModelDeployment("llama", "default")
+	maxTokens := int32(512)
+	md.Spec.Gateway = &airunwayv1alpha1.GatewaySpec{
+		PromptPolicy: &airunwayv1alpha1.PromptPolicySpec{SystemPrompt: "Be concise.", MaxTokens: &maxTokens},
+	}
+	gw := newTestGateway("my-gateway", "gateway-ns")
+	gwClass := &gatewayv1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "istio"},
+		Spec:       gatewayv1.GatewayClassSpec{ControllerName: "gateway.envoyproxy.io/gatewayclass-controller"},
+	}
+	detector := fakeDetector(true, "my-gateway", "gateway-ns")
+	detector.PromptPolicyImage = "registry.example.com/airunway/controller:v1"
+	r := &ModelDeploymentReconciler{
+		Client: fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithRESTMapper(meta.MultiRESTMapper{policyMapper, testrestmapper.TestOnlyStaticRESTMapper(scheme)}).
+			WithObjects(md, gw, gwClass).
+			Build(),
+		Scheme:          scheme,
+		GatewayDetector: detector,
+	}
+	ctx := context.Background()
+	gwConfig := &gateway.GatewayConfig{GatewayName: "my-gateway", GatewayNamespace: "gateway-ns"}
+	key := types.NamespacedName{Name: "llama-prompt-policy", Namespace: "default"}
+
+	warning, err := r.reconcilePromptPolicy(ctx, md, gwConfig)
+	if err != nil {
+		t.Fatalf("reconcilePromptPolicy failed: %v", err)
+	}
+	if warning != "" {
+		t.Errorf("expected prompt policy to be enforced, got %q", warning)
+	}
+
+	var cm corev1.ConfigMap
+	if err := r.Get(ctx, key, &cm); err != nil {
+		t.Fatalf("prompt policy ConfigMap not found: %v", err)
 	}
-	if pool.Spec.TargetPorts[0].Number != 8080 {
-		t.Errorf("expected target port 8080, got %d", pool.Spec.TargetPorts[0].Number)
+	if got := cm.Data[gateway.PromptPolicyConfigFile]; got != `{"systemPrompt":"Be concise.","maxTokens":512}` {
+		t.Errorf("unexpected policy %s", got)
 	}
-
-	// Check EndpointPickerRef
-	if string(pool.Spec.EndpointPickerRef.Name) != "test-model-epp" {
-		t.Errorf("expected EndpointPickerRef name %q, got %q", "test-model-epp", pool.Spec.EndpointPickerRef.Name)
+	var dep appsv1.Deployment
+	if err := r.Get(ctx, key, &dep); err != nil {
+		t.Fatalf("prompt policy Deployment not found: %v", err)
 	}
-	if pool.Spec.EndpointPickerRef.Port == nil || pool.Spec.EndpointPickerRef.Port.Number != 9002 {
-		t.Errorf("expected EndpointPickerRef port 9002, got %v", pool.Spec.EndpointPickerRef.Port)
+	if img := dep.Spec.Template.Spec.Containers[0].Image; img != detector.PromptPolicyImage {
+		t.Errorf("expected image %s, got %s", detector.PromptPolicyImage, img)
+	}
+	checksum := dep.Spec.Template.Annotations[gateway.AnnotationPromptPolicyChecksum]
+	if checksum == "" {
+		t.Error("expected policy checksum on pod template")
+	}
+	var svc corev1.Service
+	if err := r.Get(ctx, key, &svc); err != nil {
+		t.Fatalf("prompt policy Service not found: %v", err)
+	}
+	if len(svc.Spec.Ports) != 1 || svc.Spec.Ports[0].Port != gateway.PromptPolicyPort {
+		t.Errorf("expected Service port %d, got %v", gateway.PromptPolicyPort, svc.Spec.Ports)
+	}
+	policy := &unstructured.Unstructured{}
+	policy.SetGroupVersionKind(gvk)
+	if err := r.Get(ctx, key, policy); err != nil {
+		t.Fatalf("EnvoyExtensionPolicy not found: %v", err)
+	}
+	targets, _, _ := unstructured.NestedSlice(policy.Object, "spec", "targetRefs")
+	if len(targets) != 1 || targets[0].(map[string]interface{})["name"] != "llama" {
+		t.Errorf("expected targetRef to HTTPRoute llama, got %v", targets)
+	}
+	if len(policy.GetOwnerReferences()) != 1 {
+		t.Error("expected owner reference on EnvoyExtensionPolicy")
 	}
 
-	// Check OwnerReference
-	if len(pool.OwnerReferences) != 1 {
-		t.Fatalf("expected 1 owner reference, got %d", len(pool.OwnerReferences))
+	// Changing the policy rolls the Deployment.
+	md.Spec.Gateway.PromptPolicy.Stop = []string{"</answer>"}
+	if _, err := r.reconcilePromptPolicy(ctx, md, gwConfig); err != nil {
+		t.Fatalf("reconcilePromptPolicy failed: %v", err)
 	}
-	if pool.OwnerReferences[0].Name != "test-model" {
-		t.Errorf("expected owner ref name %q, got %q", "test-model", pool.OwnerReferences[0].Name)
+	if err := r.Get(ctx, key, &dep); err != nil {
+		t.Fatalf("prompt policy Deployment not found: %v", err)
 	}
-	if pool.OwnerReferences[0].Kind != "ModelDeployment" {
-		t.Errorf("expected owner ref kind %q, got %q", "ModelDeployment", pool.OwnerReferences[0].Kind)
+	if dep.Spec.Template.Annotations[gateway.AnnotationPromptPolicyChecksum] == checksum {
+		t.Error("expected policy checksum to change")
+	}
+
+	// Removing the policy deletes everything.
+	md.Spec.Gateway.PromptPolicy = nil
+	if _, err := r.reconcilePromptPolicy(ctx, md, gwConfig); err != nil {
+		t.Fatalf("reconcilePromptPolicy failed: %v", err)
+	}
+	for _, obj := range []client.Object{&appsv1.Deployment{}, &corev1.Service{}, &corev1.ConfigMap{}, policy} {
+		if err := r.Get(ctx, key, obj); err == nil {
+			t.Errorf("expected %T to be deleted", obj)
+		}
 	}
 }
 
-func TestGateway_InferencePoolDefaultPort(t *testing.T) {
+func TestGateway_Guardrails(t *testing.T) {
 	scheme := newTestScheme()
-	md := newModelDeployment("test-model", "default")
-	md.Status.Endpoint = nil // no endpoint, should use default port
-	detector := fakeDetector(true, "my-gateway", "gateway-ns")
-	r := newTestReconciler(scheme, detector, md)
+	gvk := gateway.EnvoyGatewayExtensionPolicyGVK
+	policyMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{gvk.GroupVersion()})
+	policyMapper.Add(gvk, meta.RESTScopeNamespace)
+	md := newModelDeployment("llama", "default")
+	md.Spec.Gateway = &airunwayv1alpha1.GatewaySpec{
+		Guardrails: &airunwayv1alpha1.GuardrailsSpec{
+			Endpoint:    "http://llama-guard.safety:8000/v1/moderations",
+			FailureMode: airunwayv1alpha1.GuardrailsFailureModeOpen,
+			Timeout:     &metav1.Duration{Duration: 2 * time.Second},
+		},
+	}
+	gw := newTestGateway("my-gateway", "gateway-ns")
+	gwClass := &gatewayv1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "istio"},
+		Spec:       gatewayv1.GatewayClassSpec{ControllerName: "gateway.envoyproxy.io/gatewayclass-controller"},
+	}
+	r := &ModelDeploymentReconciler{
+		Client: fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithRESTMapper(meta.MultiRESTMapper{policyMapper, testrestmapper.TestOnlyStaticRESTMapper(scheme)}).
+			WithObjects(md, gw, gwClass).
+			Build(),
+		Scheme:          scheme,
+		GatewayDetector: fakeDetector(true, "my-gateway", "gateway-ns"),
+	}
 	ctx := context.Background()
+	gwConfig := &gateway.GatewayConfig{GatewayName: "my-gateway", GatewayNamespace: "gateway-ns"}
+	key := types.NamespacedName{Name: "llama-prompt-policy", Namespace: "default"}
 
-	// reconcileGateway uses default port 8000 when no endpoint
-	err := r.reconcileInferencePool(ctx, md, 8000, "gateway-ns")
-	if err != nil {
-		t.Fatalf("reconcileInferencePool failed: %v", err)
+	if _, err := r.reconcilePromptPolicy(ctx, md, gwConfig); err != nil {
+		t.Fatalf("reconcilePromptPolicy failed: %v", err)
 	}
-
-	var pool inferencev1.InferencePool
-	if err := r.Get(ctx, types.NamespacedName{Name: "test-model", Namespace: "default"}, &pool); err != nil {
-		t.Fatalf("InferencePool not found: %v", err)
+	var cm corev1.ConfigMap
+	if err := r.Get(ctx, key, &cm); err != nil {
+		t.Fatalf("prompt policy ConfigMap not found: %v", err)
 	}
-	if pool.Spec.TargetPorts[0].Number != 8000 {
-		t.Errorf("expected default target port 8000, got %d", pool.Spec.TargetPorts[0].Number)
+	want := `{"guardrails":{"endpoint":"http://llama-guard.safety:8000/v1/moderations","mode":"block","failureMode":"open","timeout":"2s"}}`
+	if got := cm.Data[gateway.PromptPolicyConfigFile]; got != want {
+		t.Errorf("expected policy %s, got %s", want, got)
+	}
+	policy := &unstructured.Unstructured{}
+	policy.SetGroupVersionKind(gvk)
+	if err := r.Get(ctx, key, policy); err != nil {
+		t.Fatalf("EnvoyExtensionPolicy not found: %v", err)
+	}
+	extProc, _, _ := unstructured.NestedSlice(policy.Object, "spec", "extProc")
+	if failOpen, _, _ := unstructured.NestedBool(extProc[0].(map[string]interface{}), "failOpen"); !failOpen {
+		t.Error("expected the processor to fail open")
+	}
+	if timeout, _, _ := unstructured.NestedString(extProc[0].(map[string]interface{}), "messageTimeout"); timeout != "3s" {
+		t.Errorf("expected messageTimeout 3s, got %q", timeout)
 	}
 }
 
-func TestGateway_HTTPRouteCreation(t *testing.T) {
+func TestGateway_GenAIMetrics(t *testing.T) {
 	scheme := newTestScheme()
-	md := newModelDeployment("test-model", "default")
-	detector := fakeDetector(true, "my-gateway", "gateway-ns")
-	r := newTestReconciler(scheme, detector, md)
+	gvk := gateway.EnvoyGatewayExtensionPolicyGVK
+	policyMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{gvk.GroupVersion()})
+	policyMapper.Add(gvk, meta.RESTScopeNamespace)
+	md := newModelDeployment("llama", "default")
+	md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeVLLM
+	md.Spec.Observability = &airunwayv1alpha1.ObservabilitySpec{
+		GenAIMetrics: &airunwayv1alpha1.GenAIMetricsSpec{Enabled: true},
+	}
+	gw := newTestGateway("my-gateway", "gateway-ns")
+	gwClass := &gatewayv1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "istio"},
+		Spec:       gatewayv1.GatewayClassSpec{ControllerName: "gateway.envoyproxy.io/gatewayclass-controller"},
+	}
+	r := &ModelDeploymentReconciler{
+		Client: fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithRESTMapper(meta.MultiRESTMapper{policyMapper, testrestmapper.TestOnlyStaticRESTMapper(scheme)}).
+			WithObjects(md, gw, gwClass).
+			Build(),
+		Scheme:          scheme,
+		GatewayDetector: fakeDetector(true, "my-gateway", "gateway-ns"),
+	}
 	ctx := context.Background()
+	gwConfig := &gateway.GatewayConfig{GatewayName: "my-gateway", GatewayNamespace: "gateway-ns"}
+	key := types.NamespacedName{Name: "llama-prompt-policy", Namespace: "default"}
 
-	gwConfig := &gateway.GatewayConfig{
-		GatewayName:      "my-gateway",
-		GatewayNamespace: "gateway-ns",
+	if _, err := r.reconcilePromptPolicy(ctx, md, gwConfig); err != nil {
+		t.Fatalf("reconcilePromptPolicy failed: %v", err)
 	}
-
-	err := r.reconcileHTTPRoute(ctx, md, gwConfig, "meta-llama/Llama-3-8B", httpRouteBackendTarget{
-		group:     "inference.networking.k8s.io",
-		kind:      "InferencePool",
-		name:      md.Name,
-		namespace: md.Namespace,
-	})
-	if err != nil {
-		t.Fatalf("reconcileHTTPRoute failed: %v", err)
+	var cm corev1.ConfigMap
+	if err := r.Get(ctx, key, &cm); err != nil {
+		t.Fatalf("prompt policy ConfigMap not found: %v", err)
 	}
-
-	// Verify HTTPRoute was created
-	var route gatewayv1.HTTPRoute
-	if err := r.Get(ctx, types.NamespacedName{Name: "test-model", Namespace: "default"}, &route); err != nil {
-		t.Fatalf("HTTPRoute not found: %v", err)
+	if got := cm.Data[gateway.PromptPolicyConfigFile]; got != `{"genAIMetrics":{"providerName":"vllm"}}` {
+		t.Errorf("unexpected policy %s", got)
 	}
-
-	// Check parent ref points to the gateway
-	if len(route.Spec.ParentRefs) != 1 {
-		t.Fatalf("expected 1 parent ref, got %d", len(route.Spec.ParentRefs))
+	var svc corev1.Service
+	if err := r.Get(ctx, key, &svc); err != nil {
+		t.Fatalf("prompt policy Service not found: %v", err)
 	}
-	parentRef := route.Spec.ParentRefs[0]
-	if string(parentRef.Name) != "my-gateway" {
-		t.Errorf("expected parent ref name %q, got %q", "my-gateway", parentRef.Name)
+	if len(svc.Spec.Ports) != 2 || svc.Spec.Ports[1].Name != "metrics" || svc.Spec.Ports[1].Port != gateway.PromptPolicyMetricsPort {
+		t.Errorf("expected metrics Service port %d, got %v", gateway.PromptPolicyMetricsPort, svc.Spec.Ports)
 	}
-	if parentRef.Namespace == nil || string(*parentRef.Namespace) != "gateway-ns" {
-		t.Errorf("expected parent ref namespace %q, got %v", "gateway-ns", parentRef.Namespace)
+	policy := &unstructured.Unstructured{}
+	policy.SetGroupVersionKind(gvk)
+	if err := r.Get(ctx, key, policy); err != nil {
+		t.Fatalf("EnvoyExtensionPolicy not found: %v", err)
+	}
+	extProc, _, _ := unstructured.NestedSlice(policy.Object, "spec", "extProc")
+	if failOpen, _, _ := unstructured.NestedBool(extProc[0].(map[string]interface{}), "failOpen"); !failOpen {
+		t.Error("expected metrics alone to fail open")
+	}
+	if body, _, _ := unstructured.NestedString(extProc[0].(map[string]interface{}), "processingMode", "response", "body"); body != gateway.ResponseBodyStreamed {
+		t.Errorf("expected streamed response bodies, got %q", body)
 	}
 
-	// Check backend ref points to InferencePool
-	if len(route.Spec.Rules) != 1 {
-		t.Fatalf("expected 1 rule, got %d", len(route.Spec.Rules))
+	// With the response cache, responses stay buffered and the processor fails closed
+	md.Spec.Gateway = &airunwayv1alpha1.GatewaySpec{ResponseCache: &airunwayv1alpha1.ResponseCacheSpec{}}
+	if _, err := r.reconcilePromptPolicy(ctx, md, gwConfig); err != nil {
+		t.Fatalf("reconcilePromptPolicy failed: %v", err)
 	}
-	if len(route.Spec.Rules[0].BackendRefs) != 1 {
-		t.Fatalf("expected 1 backend ref, got %d", len(route.Spec.Rules[0].BackendRefs))
+	if err := r.Get(ctx, key, policy); err != nil {
+		t.Fatalf("EnvoyExtensionPolicy not found: %v", err)
 	}
-	backendRef := route.Spec.Rules[0].BackendRefs[0]
-	if string(backendRef.Name) != "test-model" {
-		t.Errorf("expected backend ref name %q, got %q", "test-model", backendRef.Name)
+	extProc, _, _ = unstructured.NestedSlice(policy.Object, "spec", "extProc")
+	if _, ok := extProc[0].(map[string]interface{})["failOpen"]; ok {
+		t.Error("expected the processor to fail closed with the response cache")
 	}
-	if backendRef.Group == nil || string(*backendRef.Group) != "inference.networking.k8s.io" {
-		t.Errorf("expected backend ref group %q, got %v", "inference.networking.k8s.io", backendRef.Group)
+	if body, _, _ := unstructured.NestedString(extProc[0].(map[string]interface{}), "processingMode", "response", "body"); body != gateway.ResponseBodyBuffered {
+		t.Errorf("expected buffered response bodies, got %q", body)
 	}
-	if backendRef.Kind == nil || string(*backendRef.Kind) != "InferencePool" {
-		t.Errorf("expected backend ref kind %q, got %v", "InferencePool", backendRef.Kind)
+}
+
+func TestGateway_PromptPolicyUnsupportedImplementation(t *testing.T) {
+	scheme := newTestScheme()
+	md := newModelDeployment("llama", "default")
+	md.Spec.Gateway = &airunwayv1alpha1.GatewaySpec{
+		PromptPolicy: &airunwayv1alpha1.PromptPolicySpec{SystemPrompt: "Be concise."},
+	}
+	gw := newTestGateway("my-gateway", "gateway-ns")
+	gwClass := &gatewayv1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "istio"},
+		Spec:       gatewayv1.GatewayClassSpec{ControllerName: "istio.io/gateway-controller"},
 	}
+	r := newTestReconciler(scheme, fakeDetector(true, "my-gateway", "gateway-ns"), md, gw, gwClass)
+	ctx := context.Background()
+	gwConfig := &gateway.GatewayConfig{GatewayName: "my-gateway", GatewayNamespace: "gateway-ns"}
 
-	// Check OwnerReference
-	if len(route.OwnerReferences) != 1 {
-		t.Fatalf("expected 1 owner reference, got %d", len(route.OwnerReferences))
+	warning, err := r.reconcilePromptPolicy(ctx, md, gwConfig)
+	if err != nil {
+		t.Fatalf("reconcilePromptPolicy failed: %v", err)
 	}
-	if route.OwnerReferences[0].Name != "test-model" {
-		t.Errorf("expected owner ref name %q, got %q", "test-model", route.OwnerReferences[0].Name)
+	if !strings.Contains(warning, "requires Envoy Gateway") {
+		t.Errorf("expected unsupported implementation warning, got %q", warning)
+	}
+	var dep appsv1.Deployment
+	if err := r.Get(ctx, types.NamespacedName{Name: "llama-prompt-policy", Namespace: "default"}, &dep); err == nil {
+		t.Error("expected no prompt policy Deployment on an unsupported implementation")
 	}
 }
 
@@ -403,6 +1692,65 @@ func TestGateway_CleanupOnPhaseTransition(t *testing.T) {
 	t.Error("expected GatewayReady condition to be set after phase transition")
 }
 
+func TestGateway_PausedFreezesResources(t *testing.T) {
+	scheme := newTestScheme()
+	md := newModelDeployment("test-model", "default")
+	md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeVLLM
+	md.Spec.Resources = &airunwayv1alpha1.ResourceSpec{GPU: &airunwayv1alpha1.GPUSpec{Count: 1}}
+	// Disabling the gateway would normally delete the resources below
+	md.Spec.Gateway = &airunwayv1alpha1.GatewaySpec{Enabled: boolPtr(false)}
+	md.Spec.Paused = true
+	detector := fakeDetector(true, "my-gateway", "gateway-ns")
+
+	pool := &inferencev1.InferencePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-model", Namespace: "default"},
+	}
+	route := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-model", Namespace: "default"},
+	}
+	r := newTestReconciler(scheme, detector, md, pool, route)
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "test-model", Namespace: "default"}
+
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+
+	if err := r.Get(ctx, key, &inferencev1.InferencePool{}); err != nil {
+		t.Errorf("expected InferencePool to be kept while paused: %v", err)
+	}
+	if err := r.Get(ctx, key, &gatewayv1.HTTPRoute{}); err != nil {
+		t.Errorf("expected HTTPRoute to be kept while paused: %v", err)
+	}
+	var updated airunwayv1alpha1.ModelDeployment
+	if err := r.Get(ctx, key, &updated); err != nil {
+		t.Fatalf("failed to get ModelDeployment: %v", err)
+	}
+	if !meta.IsStatusConditionTrue(updated.Status.Conditions, airunwayv1alpha1.ConditionTypePausedReconciliation) {
+		t.Error("expected PausedReconciliation condition to be True")
+	}
+
+	// Unpausing resumes reconciliation, which applies the pending gateway cleanup
+	updated.Spec.Paused = false
+	if err := r.Update(ctx, &updated); err != nil {
+		t.Fatalf("failed to unpause: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+
+	if err := r.Get(ctx, key, &gatewayv1.HTTPRoute{}); err == nil {
+		t.Error("expected HTTPRoute to be deleted after unpausing")
+	}
+	if err := r.Get(ctx, key, &updated); err != nil {
+		t.Fatalf("failed to get ModelDeployment: %v", err)
+	}
+	cond := meta.FindStatusCondition(updated.Status.Conditions, airunwayv1alpha1.ConditionTypePausedReconciliation)
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != "Resumed" {
+		t.Errorf("expected PausedReconciliation False/Resumed, got %+v", cond)
+	}
+}
+
 func TestGateway_NotAvailableSkipsSilently(t *testing.T) {
 	scheme := newTestScheme()
 	md := newModelDeployment("test-model", "default")
@@ -536,6 +1884,49 @@ func TestGateway_StatusEndpointFromGatewayAddress(t *testing.T) {
 	}
 }
 
+func TestGateway_StatusEndpointIPv6(t *testing.T) {
+	scheme := newTestScheme()
+	md := newModelDeployment("test-model", "default")
+	gw := newTestGateway("my-gateway", "gateway-ns")
+	gw.Status.Addresses = []gatewayv1.GatewayStatusAddress{{Value: "fd00:10::42"}}
+	detector := fakeDetector(true, "my-gateway", "gateway-ns")
+	r := newTestReconciler(scheme, detector, md, gw)
+
+	if err := r.reconcileGateway(context.Background(), md); err != nil {
+		t.Fatalf("reconcileGateway failed: %v", err)
+	}
+	if md.Status.Gateway == nil || md.Status.Gateway.Endpoint != "[fd00:10::42]" {
+		t.Errorf("expected bracketed IPv6 endpoint, got %+v", md.Status.Gateway)
+	}
+}
+
+func TestGateway_EPPServiceIPFamilies(t *testing.T) {
+	scheme := newTestScheme()
+	md := newModelDeployment("test-model", "default")
+	policy := corev1.IPFamilyPolicyPreferDualStack
+	md.Spec.Networking = &airunwayv1alpha1.NetworkingSpec{
+		IPFamilyPolicy: &policy,
+		IPFamilies:     []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol},
+	}
+	detector := fakeDetector(true, "my-gateway", "gateway-ns")
+	r := newTestReconciler(scheme, detector, md)
+	ctx := context.Background()
+
+	if err := r.reconcileEPP(ctx, md, "test-model"); err != nil {
+		t.Fatalf("reconcileEPP failed: %v", err)
+	}
+	var svc corev1.Service
+	if err := r.Get(ctx, types.NamespacedName{Name: "test-model-epp", Namespace: "default"}, &svc); err != nil {
+		t.Fatalf("EPP Service not found: %v", err)
+	}
+	if svc.Spec.IPFamilyPolicy == nil || *svc.Spec.IPFamilyPolicy != corev1.IPFamilyPolicyPreferDualStack {
+		t.Errorf("expected PreferDualStack, got %v", svc.Spec.IPFamilyPolicy)
+	}
+	if len(svc.Spec.IPFamilies) != 2 || svc.Spec.IPFamilies[0] != corev1.IPv6Protocol {
+		t.Errorf("expected IPv6 as the primary family, got %v", svc.Spec.IPFamilies)
+	}
+}
+
 func TestGateway_StatusModelNameOverride(t *testing.T) {
 	scheme := newTestScheme()
 	md := newModelDeployment("test-model", "default")
@@ -586,10 +1977,73 @@ func TestGateway_ModelNameAutoDiscoveryFallsBackToModelID(t *testing.T) {
 	r := newTestReconciler(scheme, detector, md)
 	ctx := context.Background()
 
+	// The probe runs in the background; spec.model.id is used until it answered
+	if name := r.resolveModelName(ctx, md); name != "meta-llama/Llama-3-8B" {
+		t.Errorf("expected spec.model.id %q while probing, got %q", "meta-llama/Llama-3-8B", name)
+	}
+	waitForScrapes(r)
 	name := r.resolveModelName(ctx, md)
 	if name != "meta-llama/Llama-3-8B" {
 		t.Errorf("expected fallback to spec.model.id %q, got %q", "meta-llama/Llama-3-8B", name)
 	}
+	if md.Status.Gateway == nil || md.Status.Gateway.ModelDiscovery == nil {
+		t.Fatal("expected the failed probe to be cached in status.gateway.modelDiscovery")
+	}
+	if md.Status.Gateway.ModelDiscovery.ModelName != "" {
+		t.Errorf("expected empty cached model name, got %q", md.Status.Gateway.ModelDiscovery.ModelName)
+	}
+}
+
+func TestGateway_ModelNameDiscoveryCache(t *testing.T) {
+	scheme := newTestScheme()
+	md := newModelDeployment("test-model", "default")
+	md.Generation = 2
+	md.Status.Endpoint = &airunwayv1alpha1.EndpointStatus{
+		Service: "nonexistent-svc",
+		Port:    8080,
+	}
+	md.Status.Gateway = &airunwayv1alpha1.GatewayStatus{
+		ModelDiscovery: &airunwayv1alpha1.ModelDiscoveryStatus{
+			ModelName:          "cached-model",
+			ProbeTime:          metav1.Now(),
+			ObservedGeneration: 2,
+		},
+	}
+	detector := fakeDetector(true, "my-gateway", "gateway-ns")
+	r := newTestReconciler(scheme, detector, md)
+
+	// A fresh cache entry is used without probing the server
+	if name := r.resolveModelName(context.Background(), md); name != "cached-model" {
+		t.Errorf("expected cached model name %q, got %q", "cached-model", name)
+	}
+
+	// An expired entry is kept while the server is probed again in the background
+	md.Status.Gateway.ModelDiscovery.ProbeTime = metav1.NewTime(time.Now().Add(-modelDiscoveryTTL))
+	if name := r.resolveModelName(context.Background(), md); name != "cached-model" {
+		t.Errorf("expected the expired model name %q while probing, got %q", "cached-model", name)
+	}
+	waitForScrapes(r)
+
+	now := time.Now()
+	tests := []struct {
+		name       string
+		discovery  airunwayv1alpha1.ModelDiscoveryStatus
+		wantCached bool
+	}{
+		{"fresh", airunwayv1alpha1.ModelDiscoveryStatus{ModelName: "m", ProbeTime: metav1.NewTime(now.Add(-5 * time.Minute)), ObservedGeneration: 2}, true},
+		{"expired", airunwayv1alpha1.ModelDiscoveryStatus{ModelName: "m", ProbeTime: metav1.NewTime(now.Add(-modelDiscoveryTTL)), ObservedGeneration: 2}, false},
+		{"older generation", airunwayv1alpha1.ModelDiscoveryStatus{ModelName: "m", ProbeTime: metav1.NewTime(now), ObservedGeneration: 1}, false},
+		{"failed probe fresh", airunwayv1alpha1.ModelDiscoveryStatus{ProbeTime: metav1.NewTime(now.Add(-30 * time.Second)), ObservedGeneration: 2}, true},
+		{"failed probe retry", airunwayv1alpha1.ModelDiscoveryStatus{ProbeTime: metav1.NewTime(now.Add(-2 * time.Minute)), ObservedGeneration: 2}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md.Status.Gateway.ModelDiscovery = &tt.discovery
+			if _, cached := cachedModelDiscovery(md, now); cached != tt.wantCached {
+				t.Errorf("cachedModelDiscovery() cached = %v, want %v", cached, tt.wantCached)
+			}
+		})
+	}
 }
 
 func TestGateway_ModelNameExplicitOverrideTakesPriority(t *testing.T) {
@@ -897,6 +2351,104 @@ func TestGateway_CleanupDeletesControllerManagedResources(t *testing.T) {
 	}
 }
 
+func TestGateway_ProviderManagedSkipsPoolAndEPP(t *testing.T) {
+	scheme := newTestScheme()
+	md := newModelDeployment("llama-70b", "default")
+	md.Status.Provider = &airunwayv1alpha1.ProviderStatus{Name: "dynamo"}
+
+	providerPool := &inferencev1.InferencePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama-70b-pool", Namespace: "default"},
+	}
+	detector := fakeDetector(true, "my-gateway", "gateway-ns")
+	r := newTestReconciler(scheme, detector, md, providerPool, newTestGateway("my-gateway", "gateway-ns"))
+	r.ProviderResolver = &mockProviderResolver{
+		caps: map[string]*airunwayv1alpha1.GatewayCapabilities{
+			"dynamo": {
+				GatewayManagement:        airunwayv1alpha1.GatewayManagementProvider,
+				InferencePoolNamePattern: "{name}-pool",
+				InferencePoolNamespace:   "{namespace}",
+			},
+		},
+	}
+	ctx := context.Background()
+
+	if err := r.reconcileGateway(ctx, md); err != nil {
+		t.Fatalf("reconcileGateway failed: %v", err)
+	}
+
+	if err := r.Get(ctx, types.NamespacedName{Name: "llama-70b", Namespace: "default"}, &inferencev1.InferencePool{}); err == nil {
+		t.Error("expected no controller-created InferencePool for a provider-managed gateway")
+	}
+	if err := r.Get(ctx, types.NamespacedName{Name: "llama-70b-epp", Namespace: "default"}, &appsv1.Deployment{}); err == nil {
+		t.Error("expected no controller-created EPP for a provider-managed gateway")
+	}
+
+	var route gatewayv1.HTTPRoute
+	if err := r.Get(ctx, types.NamespacedName{Name: "llama-70b", Namespace: "default"}, &route); err != nil {
+		t.Fatalf("HTTPRoute not found: %v", err)
+	}
+	if got := string(route.Spec.Rules[0].BackendRefs[0].Name); got != "llama-70b-pool" {
+		t.Errorf("expected HTTPRoute to target the provider pool, got %q", got)
+	}
+
+	cond := meta.FindStatusCondition(md.Status.Conditions, airunwayv1alpha1.ConditionTypeGatewayReady)
+	if cond == nil || cond.Status != metav1.ConditionTrue {
+		t.Fatalf("expected GatewayReady True, got %+v", cond)
+	}
+	if cond.Message != "HTTPRoute routes to provider-managed InferencePool default/llama-70b-pool" {
+		t.Errorf("unexpected GatewayReady message %q", cond.Message)
+	}
+}
+
+func TestGateway_ControllerManagementOverridesProviderCapabilities(t *testing.T) {
+	scheme := newTestScheme()
+	md := newModelDeployment("test-model", "default")
+	md.Spec.Provider = &airunwayv1alpha1.ProviderSpec{Name: "dynamo"}
+
+	pool := &inferencev1.InferencePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-model", Namespace: "default"},
+	}
+	detector := fakeDetector(true, "my-gateway", "gateway-ns")
+	r := newTestReconciler(scheme, detector, md, pool)
+	r.ProviderResolver = &mockProviderResolver{
+		caps: map[string]*airunwayv1alpha1.GatewayCapabilities{
+			"dynamo": {
+				GatewayManagement:        airunwayv1alpha1.GatewayManagementController,
+				InferencePoolNamePattern: "{name}-pool",
+			},
+		},
+	}
+
+	if err := r.cleanupGatewayResources(context.Background(), md); err != nil {
+		t.Fatalf("cleanupGatewayResources failed: %v", err)
+	}
+
+	// The controller owns the pool, so it is cleaned up
+	if err := r.Get(context.Background(), types.NamespacedName{Name: "test-model", Namespace: "default"}, &inferencev1.InferencePool{}); err == nil {
+		t.Error("InferencePool should have been deleted (controller-managed)")
+	}
+}
+
+func TestGatewayCapabilitiesProviderManaged(t *testing.T) {
+	tests := []struct {
+		name string
+		caps *airunwayv1alpha1.GatewayCapabilities
+		want bool
+	}{
+		{name: "nil", caps: nil, want: false},
+		{name: "unset implies provider", caps: &airunwayv1alpha1.GatewayCapabilities{}, want: true},
+		{name: "provider", caps: &airunwayv1alpha1.GatewayCapabilities{GatewayManagement: airunwayv1alpha1.GatewayManagementProvider}, want: true},
+		{name: "controller", caps: &airunwayv1alpha1.GatewayCapabilities{GatewayManagement: airunwayv1alpha1.GatewayManagementController}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.caps.ProviderManaged(); got != tt.want {
+				t.Errorf("ProviderManaged() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 // gwWithNamespaceSelector creates a Gateway with a matchExpressions In-list for the given namespaces.
 func gwWithNamespaceSelector(name, ns string, namespaces ...string) *gatewayv1.Gateway {
 	fromSelector := gatewayv1.NamespacesFromSelector
@@ -0,0 +1,76 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+// reconcileGPUType selects status.gpuType from spec.resources.gpu.types: the first GPU
+// model with a schedulable node whose allocatable GPUs fit one replica. A selected model is
+// kept while it remains listed, so the pods are not rescheduled as capacity changes. When
+// no listed model has capacity, status.gpuType is left empty and the pods keep the order
+// of spec.resources.gpu.types.
+func (r *ModelDeploymentReconciler) reconcileGPUType(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) error {
+	var gpu *airunwayv1alpha1.GPUSpec
+	if md.Spec.Resources != nil {
+		gpu = md.Spec.Resources.GPU
+	}
+	if gpu == nil || len(gpu.Types) == 0 {
+		md.Status.GPUType = ""
+		return nil
+	}
+	if slices.Contains(gpu.Types, md.Status.GPUType) {
+		return nil
+	}
+
+	var nodes corev1.NodeList
+	if err := r.List(ctx, &nodes, client.HasLabels{airunwayv1alpha1.LabelGPUProduct}); err != nil {
+		return fmt.Errorf("failed to list GPU nodes: %w", err)
+	}
+	resourceName := corev1.ResourceName(gpu.Type)
+	if resourceName == "" {
+		resourceName = airunwayv1alpha1.DefaultGPUType
+	}
+	available := map[string]bool{}
+	for _, node := range nodes.Items {
+		if node.Spec.Unschedulable {
+			continue
+		}
+		if allocatable, ok := node.Status.Allocatable[resourceName]; ok && allocatable.Value() >= int64(gpu.Count) {
+			available[node.Labels[airunwayv1alpha1.LabelGPUProduct]] = true
+		}
+	}
+
+	md.Status.GPUType = ""
+	for _, t := range gpu.Types {
+		if available[t] {
+			log.FromContext(ctx).Info("GPU type selected", "name", md.Name, "gpuType", t)
+			md.Status.GPUType = t
+			break
+		}
+	}
+	return nil
+}
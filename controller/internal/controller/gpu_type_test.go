@@ -0,0 +1,100 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+func newGPUNode(name, product string, gpus int64, unschedulable bool) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{airunwayv1alpha1.LabelGPUProduct: product},
+		},
+		Spec: corev1.NodeSpec{Unschedulable: unschedulable},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				airunwayv1alpha1.DefaultGPUType: *resource.NewQuantity(gpus, resource.DecimalSI),
+			},
+		},
+	}
+}
+
+func newGPUTypeTestReconciler(nodes ...client.Object) *ModelDeploymentReconciler {
+	scheme := newTestScheme()
+	return &ModelDeploymentReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(nodes...).Build(),
+		Scheme: scheme,
+	}
+}
+
+func TestReconcileGPUType(t *testing.T) {
+	ctx := context.Background()
+	r := newGPUTypeTestReconciler(
+		newGPUNode("h100-cordoned", "NVIDIA-H100-80GB-HBM3", 8, true),
+		newGPUNode("h100-small", "NVIDIA-H100-80GB-HBM3", 1, false),
+		newGPUNode("a100", "NVIDIA-A100-SXM4-80GB", 8, false),
+		newGPUNode("l40s", "NVIDIA-L40S", 8, false),
+	)
+
+	md := newModelDeployment("llama", "team-a")
+	md.Spec.Resources = &airunwayv1alpha1.ResourceSpec{
+		GPU: &airunwayv1alpha1.GPUSpec{Count: 4, Types: []string{"NVIDIA-H100-80GB-HBM3", "NVIDIA-A100-SXM4-80GB", "NVIDIA-L40S"}},
+	}
+	if err := r.reconcileGPUType(ctx, md); err != nil {
+		t.Fatalf("reconcileGPUType failed: %v", err)
+	}
+	if md.Status.GPUType != "NVIDIA-A100-SXM4-80GB" {
+		t.Errorf("expected the first type with capacity, got %q", md.Status.GPUType)
+	}
+
+	// A selected type is kept while listed, even ahead of a preferred type
+	md.Status.GPUType = "NVIDIA-L40S"
+	if err := r.reconcileGPUType(ctx, md); err != nil {
+		t.Fatalf("reconcileGPUType failed: %v", err)
+	}
+	if md.Status.GPUType != "NVIDIA-L40S" {
+		t.Errorf("expected the selected type to be kept, got %q", md.Status.GPUType)
+	}
+
+	md.Spec.Resources.GPU.Types = []string{"NVIDIA-H100-80GB-HBM3"}
+	if err := r.reconcileGPUType(ctx, md); err != nil {
+		t.Fatalf("reconcileGPUType failed: %v", err)
+	}
+	if md.Status.GPUType != "" {
+		t.Errorf("expected no type without capacity, got %q", md.Status.GPUType)
+	}
+
+	md.Status.GPUType = "NVIDIA-H100-80GB-HBM3"
+	md.Spec.Resources.GPU.Types = nil
+	if err := r.reconcileGPUType(ctx, md); err != nil {
+		t.Fatalf("reconcileGPUType failed: %v", err)
+	}
+	if md.Status.GPUType != "" {
+		t.Errorf("expected status.gpuType to be cleared without types, got %q", md.Status.GPUType)
+	}
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+// GatewayReady reasons for a user-provided HTTPRoute that cannot serve the deployment
+const (
+	reasonHTTPRouteNotFound        = "HTTPRouteNotFound"
+	reasonHTTPRouteBackendMismatch = "HTTPRouteBackendMismatch"
+	reasonHTTPRouteNotAccepted     = "HTTPRouteNotAccepted"
+	reasonHTTPRouteRefsNotResolved = "HTTPRouteRefsNotResolved"
+)
+
+// checkHTTPRouteRef checks the HTTPRoute of spec.gateway.httpRouteRef. It returns a
+// GatewayReady reason and message when the route does not exist, has no rule that routes to
+// backend, or is not accepted by a Gateway, and an empty reason when the route is usable.
+func (r *ModelDeploymentReconciler) checkHTTPRouteRef(ctx context.Context, md *airunwayv1alpha1.ModelDeployment, backend httpRouteBackendTarget) (string, string, error) {
+	name := md.Spec.Gateway.HTTPRouteRef
+	var route gatewayv1.HTTPRoute
+	if err := r.Get(ctx, client.ObjectKey{Name: name, Namespace: md.Namespace}, &route); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reasonHTTPRouteNotFound, fmt.Sprintf("HTTPRoute %s/%s referenced by spec.gateway.httpRouteRef does not exist", md.Namespace, name), nil
+		}
+		return "", "", fmt.Errorf("getting HTTPRoute %s: %w", name, err)
+	}
+
+	if !httpRouteTargets(&route, backend) {
+		return reasonHTTPRouteBackendMismatch, fmt.Sprintf("HTTPRoute %s has no rule that routes to %s %s/%s",
+			name, backend.kind, backend.namespace, backend.name), nil
+	}
+
+	var rejection string
+	for _, parent := range route.Status.Parents {
+		accepted := meta.FindStatusCondition(parent.Conditions, string(gatewayv1.RouteConditionAccepted))
+		if accepted == nil || accepted.Status != metav1.ConditionTrue {
+			if accepted != nil && rejection == "" {
+				rejection = fmt.Sprintf(": Gateway %s: %s", parent.ParentRef.Name, accepted.Message)
+			}
+			continue
+		}
+		if resolved := meta.FindStatusCondition(parent.Conditions, string(gatewayv1.RouteConditionResolvedRefs)); resolved != nil && resolved.Status == metav1.ConditionFalse {
+			return reasonHTTPRouteRefsNotResolved, fmt.Sprintf("Gateway %s cannot resolve the backends of HTTPRoute %s: %s",
+				parent.ParentRef.Name, name, resolved.Message), nil
+		}
+		return "", "", nil
+	}
+	return reasonHTTPRouteNotAccepted, fmt.Sprintf("HTTPRoute %s is not accepted by any Gateway%s", name, rejection), nil
+}
+
+// httpRouteTargets reports whether a rule of route has a backendRef to backend
+func httpRouteTargets(route *gatewayv1.HTTPRoute, backend httpRouteBackendTarget) bool {
+	for _, rule := range route.Spec.Rules {
+		for _, ref := range rule.BackendRefs {
+			group, kind, namespace := gatewayv1.Group(""), gatewayv1.Kind("Service"), route.Namespace
+			if ref.Group != nil {
+				group = *ref.Group
+			}
+			if ref.Kind != nil {
+				kind = *ref.Kind
+			}
+			if ref.Namespace != nil {
+				namespace = string(*ref.Namespace)
+			}
+			if group == backend.group && kind == backend.kind && string(ref.Name) == backend.name && namespace == backend.namespace {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// releaseGeneratedHTTPRoute hands over the HTTPRoute the controller generated for md once
+// spec.gateway.httpRouteRef is set. When the reference names the generated route, the
+// route is adopted: the controller reference is dropped so deleting the deployment keeps
+// it. Otherwise the generated route is deleted, so it does not route alongside the
+// referenced one.
+func (r *ModelDeploymentReconciler) releaseGeneratedHTTPRoute(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) error {
+	logger := log.FromContext(ctx)
+
+	var route gatewayv1.HTTPRoute
+	if err := r.Get(ctx, client.ObjectKey{Name: md.Name, Namespace: md.Namespace}, &route); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !metav1.IsControlledBy(&route, md) {
+		return nil
+	}
+
+	if route.Name != md.Spec.Gateway.HTTPRouteRef {
+		if err := r.Delete(ctx, &route); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete generated HTTPRoute: %w", err)
+		}
+		logger.Info("Deleted the generated HTTPRoute replaced by spec.gateway.httpRouteRef", "name", route.Name, "httpRouteRef", md.Spec.Gateway.HTTPRouteRef)
+		return nil
+	}
+
+	route.OwnerReferences = slices.DeleteFunc(route.OwnerReferences, func(ref metav1.OwnerReference) bool {
+		return ref.UID == md.UID
+	})
+	if err := r.Update(ctx, &route); err != nil {
+		return fmt.Errorf("failed to adopt HTTPRoute: %w", err)
+	}
+	logger.Info("Adopted the generated HTTPRoute as spec.gateway.httpRouteRef", "name", route.Name)
+	return nil
+}
+
+// mapHTTPRouteToModelDeployments returns the ModelDeployments whose spec.gateway.httpRouteRef
+// names the route. Generated routes are not mapped, so deleting one does not recreate it.
+func (r *ModelDeploymentReconciler) mapHTTPRouteToModelDeployments(ctx context.Context, obj client.Object) []reconcile.Request {
+	var mdList airunwayv1alpha1.ModelDeploymentList
+	if err := r.List(ctx, &mdList, client.InNamespace(obj.GetNamespace())); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list ModelDeployments for HTTPRoute", "name", obj.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, md := range mdList.Items {
+		if md.Spec.Gateway != nil && md.Spec.Gateway.HTTPRouteRef == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: k8stypes.NamespacedName{Name: md.Name, Namespace: md.Namespace}})
+		}
+	}
+	return requests
+}
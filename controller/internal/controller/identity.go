@@ -0,0 +1,88 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/pkg/provider"
+)
+
+// reconcileIdentity creates the ServiceAccount for spec.identity.annotations, named after
+// the ModelDeployment, so it exists before provider controllers create pods that run as it.
+// A ServiceAccount created for earlier annotations is deleted once they are removed.
+func (r *ModelDeploymentReconciler) reconcileIdentity(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) error {
+	logger := log.FromContext(ctx)
+	sa := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      md.Name,
+			Namespace: md.Namespace,
+		},
+	}
+
+	identity := md.Spec.Identity
+	if identity == nil || identity.ServiceAccountName != "" || len(identity.Annotations) == 0 {
+		if err := r.Get(ctx, k8stypes.NamespacedName{Name: sa.Name, Namespace: sa.Namespace}, sa); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		if !metav1.IsControlledBy(sa, md) {
+			return nil
+		}
+		logger.Info("Deleting workload identity ServiceAccount", "name", sa.Name)
+		return client.IgnoreNotFound(r.Delete(ctx, sa))
+	}
+
+	_, err := ctrl.CreateOrUpdate(ctx, r.Client, sa, func() error {
+		if sa.ResourceVersion != "" && !metav1.IsControlledBy(sa, md) {
+			return fmt.Errorf("ServiceAccount %s already exists and is not managed by this ModelDeployment; "+
+				"set spec.identity.serviceAccountName to use it", sa.Name)
+		}
+		if sa.Labels == nil {
+			sa.Labels = map[string]string{}
+		}
+		sa.Labels[airunwayv1alpha1.LabelManagedBy] = airunwayv1alpha1.ManagedByAIRunway
+		sa.Labels[airunwayv1alpha1.LabelModelDeployment] = md.Name
+
+		// Drop workload identity annotations removed from the spec, keeping any others
+		if sa.Annotations == nil {
+			sa.Annotations = map[string]string{}
+		}
+		for key := range sa.Annotations {
+			if _, ok := identity.Annotations[key]; !ok && airunwayv1alpha1.IsWorkloadIdentityAnnotation(key) {
+				delete(sa.Annotations, key)
+			}
+		}
+		for key, value := range identity.Annotations {
+			sa.Annotations[key] = value
+		}
+		provider.ApplyPropagatedMetadataToObject(sa, md)
+		return ctrl.SetControllerReference(md, sa, r.Scheme)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create/update workload identity ServiceAccount: %w", err)
+	}
+	return nil
+}
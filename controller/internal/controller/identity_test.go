@@ -0,0 +1,108 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+const testRoleARN = "arn:aws:iam::111122223333:role/model-reader"
+
+func TestReconcileIdentity(t *testing.T) {
+	scheme := newTestScheme()
+	md := newModelDeployment("test-model", "default")
+	md.Spec.Identity = &airunwayv1alpha1.IdentitySpec{
+		Annotations: map[string]string{"eks.amazonaws.com/role-arn": testRoleARN},
+	}
+	r := newTestReconciler(scheme, nil, md)
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "test-model", Namespace: "default"}
+
+	if err := r.reconcileIdentity(ctx, md); err != nil {
+		t.Fatalf("reconcileIdentity failed: %v", err)
+	}
+	var sa corev1.ServiceAccount
+	if err := r.Get(ctx, key, &sa); err != nil {
+		t.Fatalf("expected ServiceAccount to be created: %v", err)
+	}
+	if sa.Annotations["eks.amazonaws.com/role-arn"] != testRoleARN {
+		t.Errorf("expected role-arn annotation, got %v", sa.Annotations)
+	}
+	if !metav1.IsControlledBy(&sa, md) {
+		t.Error("expected ServiceAccount to be owned by the ModelDeployment")
+	}
+
+	// Switching identity providers drops the stale annotation but keeps unrelated ones
+	sa.Annotations["example.com/note"] = "kept"
+	if err := r.Update(ctx, &sa); err != nil {
+		t.Fatalf("failed to update ServiceAccount: %v", err)
+	}
+	md.Spec.Identity.Annotations = map[string]string{"iam.gke.io/gcp-service-account": "reader@project.iam.gserviceaccount.com"}
+	if err := r.reconcileIdentity(ctx, md); err != nil {
+		t.Fatalf("reconcileIdentity failed: %v", err)
+	}
+	if err := r.Get(ctx, key, &sa); err != nil {
+		t.Fatalf("failed to get ServiceAccount: %v", err)
+	}
+	if _, ok := sa.Annotations["eks.amazonaws.com/role-arn"]; ok {
+		t.Error("expected stale role-arn annotation to be removed")
+	}
+	if sa.Annotations["example.com/note"] != "kept" || sa.Annotations["iam.gke.io/gcp-service-account"] == "" {
+		t.Errorf("unexpected annotations %v", sa.Annotations)
+	}
+
+	// Removing the annotations deletes the controller-created ServiceAccount
+	md.Spec.Identity = nil
+	if err := r.reconcileIdentity(ctx, md); err != nil {
+		t.Fatalf("reconcileIdentity failed: %v", err)
+	}
+	if err := r.Get(ctx, key, &sa); !apierrors.IsNotFound(err) {
+		t.Errorf("expected ServiceAccount to be deleted, got %v", err)
+	}
+}
+
+func TestReconcileIdentity_ExistingServiceAccount(t *testing.T) {
+	scheme := newTestScheme()
+	md := newModelDeployment("test-model", "default")
+	md.Spec.Identity = &airunwayv1alpha1.IdentitySpec{
+		Annotations: map[string]string{"eks.amazonaws.com/role-arn": testRoleARN},
+	}
+	existing := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "test-model", Namespace: "default"}}
+	r := newTestReconciler(scheme, nil, md, existing)
+	ctx := context.Background()
+
+	if err := r.reconcileIdentity(ctx, md); err == nil {
+		t.Error("expected an error for a ServiceAccount not managed by the ModelDeployment")
+	}
+
+	// A user-managed ServiceAccount is never deleted
+	md.Spec.Identity = nil
+	if err := r.reconcileIdentity(ctx, md); err != nil {
+		t.Fatalf("reconcileIdentity failed: %v", err)
+	}
+	if err := r.Get(ctx, types.NamespacedName{Name: "test-model", Namespace: "default"}, &corev1.ServiceAccount{}); err != nil {
+		t.Errorf("expected user-managed ServiceAccount to be kept: %v", err)
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/pkg/provider"
+)
+
+// reconcileIgnoredFields sets the FieldsIgnored condition when md sets spec fields the
+// selected provider does not list in status.supportedFields, and emits a warning event when
+// the ignored fields change. Providers that do not publish their fields are not checked.
+func (r *ModelDeploymentReconciler) reconcileIgnoredFields(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) error {
+	if md.Status.Provider == nil || md.Status.Provider.Name == "" {
+		meta.RemoveStatusCondition(&md.Status.Conditions, airunwayv1alpha1.ConditionTypeFieldsIgnored)
+		return nil
+	}
+	providerName := md.Status.Provider.Name
+
+	var config airunwayv1alpha1.InferenceProviderConfig
+	if err := r.Get(ctx, types.NamespacedName{Name: providerName}, &config); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			meta.RemoveStatusCondition(&md.Status.Conditions, airunwayv1alpha1.ConditionTypeFieldsIgnored)
+			return nil
+		}
+		return fmt.Errorf("failed to get InferenceProviderConfig %s: %w", providerName, err)
+	}
+
+	var ignored []string
+	if len(config.Status.SupportedFields) > 0 {
+		ignored = provider.IgnoredFields(md, config.Status.SupportedFields)
+	}
+	if len(ignored) == 0 {
+		meta.RemoveStatusCondition(&md.Status.Conditions, airunwayv1alpha1.ConditionTypeFieldsIgnored)
+		return nil
+	}
+
+	message := fmt.Sprintf("Provider %s ignores %s", providerName, strings.Join(ignored, ", "))
+	previous := meta.FindStatusCondition(md.Status.Conditions, airunwayv1alpha1.ConditionTypeFieldsIgnored)
+	changed := previous == nil || previous.Message != message
+	r.setCondition(md, airunwayv1alpha1.ConditionTypeFieldsIgnored, metav1.ConditionTrue, airunwayv1alpha1.ReasonProviderIgnoresFields, message)
+	if changed && r.Recorder != nil {
+		r.Recorder.Eventf(md, nil, corev1.EventTypeWarning, airunwayv1alpha1.ReasonProviderIgnoresFields, "Validate", message)
+	}
+	return nil
+}
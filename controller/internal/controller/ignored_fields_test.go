@@ -0,0 +1,104 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/pkg/provider"
+)
+
+func TestReconcileIgnoredFields(t *testing.T) {
+	ctx := context.Background()
+	config := &airunwayv1alpha1.InferenceProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "kuberay"},
+		Status: airunwayv1alpha1.InferenceProviderConfigStatus{
+			Ready:           true,
+			SupportedFields: []string{provider.FieldEnv, provider.FieldImage},
+		},
+	}
+	recorder := events.NewFakeRecorder(4)
+	r := &ModelDeploymentReconciler{
+		Client:   fake.NewClientBuilder().WithScheme(newTestScheme()).WithObjects(config).Build(),
+		Recorder: recorder,
+	}
+
+	md := newModelDeployment("llama", "default")
+	md.Status.Provider = &airunwayv1alpha1.ProviderStatus{Name: "kuberay"}
+	md.Spec.Env = []corev1.EnvVar{{Name: "A", Value: "b"}}
+	md.Spec.Tolerations = []corev1.Toleration{{Key: "gpu", Operator: corev1.TolerationOpExists}}
+	if err := r.reconcileIgnoredFields(ctx, md); err != nil {
+		t.Fatalf("reconcileIgnoredFields failed: %v", err)
+	}
+	cond := meta.FindStatusCondition(md.Status.Conditions, airunwayv1alpha1.ConditionTypeFieldsIgnored)
+	if cond == nil || cond.Status != metav1.ConditionTrue || cond.Message != "Provider kuberay ignores spec.tolerations" {
+		t.Fatalf("expected FieldsIgnored for spec.tolerations, got %+v", cond)
+	}
+	select {
+	case e := <-recorder.Events:
+		if !strings.Contains(e, "Warning "+airunwayv1alpha1.ReasonProviderIgnoresFields) {
+			t.Errorf("expected a %s event, got %q", airunwayv1alpha1.ReasonProviderIgnoresFields, e)
+		}
+	default:
+		t.Error("expected a warning event")
+	}
+
+	// The same fields are not reported again
+	if err := r.reconcileIgnoredFields(ctx, md); err != nil {
+		t.Fatalf("reconcileIgnoredFields failed: %v", err)
+	}
+	if len(recorder.Events) != 0 {
+		t.Errorf("expected no repeated event, got %q", <-recorder.Events)
+	}
+
+	// Dropping the field clears the condition
+	md.Spec.Tolerations = nil
+	if err := r.reconcileIgnoredFields(ctx, md); err != nil {
+		t.Fatalf("reconcileIgnoredFields failed: %v", err)
+	}
+	if meta.FindStatusCondition(md.Status.Conditions, airunwayv1alpha1.ConditionTypeFieldsIgnored) != nil {
+		t.Error("expected FieldsIgnored to be removed")
+	}
+}
+
+func TestReconcileIgnoredFields_Unpublished(t *testing.T) {
+	config := &airunwayv1alpha1.InferenceProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "custom"},
+		Status:     airunwayv1alpha1.InferenceProviderConfigStatus{Ready: true},
+	}
+	r := &ModelDeploymentReconciler{
+		Client: fake.NewClientBuilder().WithScheme(newTestScheme()).WithObjects(config).Build(),
+	}
+	md := newModelDeployment("llama", "default")
+	md.Status.Provider = &airunwayv1alpha1.ProviderStatus{Name: "custom"}
+	md.Spec.Tolerations = []corev1.Toleration{{Key: "gpu", Operator: corev1.TolerationOpExists}}
+	if err := r.reconcileIgnoredFields(context.Background(), md); err != nil {
+		t.Fatalf("reconcileIgnoredFields failed: %v", err)
+	}
+	if meta.FindStatusCondition(md.Status.Conditions, airunwayv1alpha1.ConditionTypeFieldsIgnored) != nil {
+		t.Error("expected providers without supportedFields not to be checked")
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/internal/activity"
+)
+
+// reconcileKVCacheStatus reports the KV cache hit rates of a Running deployment with
+// spec.caching.kv in status.kvCache, from the engine metrics samples the background scraper
+// takes for request activity. The last rates are kept while the deployment is not Running,
+// and cleared when spec.caching.kv is removed. It returns how long to wait before sampling
+// again, or zero.
+func (r *ModelDeploymentReconciler) reconcileKVCacheStatus(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) time.Duration {
+	if md.Spec.Caching == nil || md.Spec.Caching.KV == nil {
+		md.Status.KVCache = nil
+		return 0
+	}
+	if md.Status.Phase != airunwayv1alpha1.DeploymentPhaseRunning {
+		return 0
+	}
+
+	interval := r.settings().ActivityPollInterval
+	result, ok := r.sampleActivity(ctx, md, scrapeActivity, interval)
+	if !ok {
+		return interval
+	}
+	if result.err != nil {
+		log.FromContext(ctx).Info("Could not sample KV cache metrics", "name", md.Name, "error", result.err.Error())
+		return interval
+	}
+	md.Status.KVCache = kvCacheStatus(result.value.(activity.Sample))
+	return interval
+}
+
+// kvCacheStatus returns the hit rates of sample, or nil when no lookups were counted
+func kvCacheStatus(sample activity.Sample) *airunwayv1alpha1.KVCacheStatus {
+	status := &airunwayv1alpha1.KVCacheStatus{
+		PrefixHitPercent:   hitPercent(sample.PrefixCacheHits, sample.PrefixCacheQueries),
+		ExternalHitPercent: hitPercent(sample.ExternalCacheHits, sample.ExternalCacheQueries),
+	}
+	if status.PrefixHitPercent == nil && status.ExternalHitPercent == nil {
+		return nil
+	}
+	return status
+}
+
+// hitPercent returns hits as a rounded percentage of queries, or nil without queries
+func hitPercent(hits, queries float64) *int32 {
+	if queries <= 0 {
+		return nil
+	}
+	percent := int32(math.Round(min(hits/queries, 1) * 100))
+	return &percent
+}
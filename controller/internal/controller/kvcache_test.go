@@ -0,0 +1,86 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/internal/activity"
+)
+
+func TestReconcileKVCacheStatus(t *testing.T) {
+	ctx := context.Background()
+	md := newModelDeployment("demo", "default")
+	md.Status.Phase = airunwayv1alpha1.DeploymentPhaseRunning
+	source := &fakeActivitySource{sample: activity.Sample{
+		Requests:             10,
+		PrefixCacheQueries:   1000,
+		PrefixCacheHits:      426,
+		ExternalCacheQueries: 500,
+		ExternalCacheHits:    100,
+	}}
+	r := newTestReconciler(newTestScheme(), nil, md)
+	r.ActivitySource = source
+	// sample starts sampling the engine again in the background and waits for it
+	sample := func() {
+		rescrape(r, func() { r.sampleActivity(ctx, md, scrapeActivity, DefaultActivityPollInterval) })
+	}
+
+	// Without spec.caching.kv nothing is sampled
+	if next := r.reconcileKVCacheStatus(ctx, md); next != 0 || md.Status.KVCache != nil {
+		t.Fatalf("expected no KV cache status, got %v and %+v", next, md.Status.KVCache)
+	}
+
+	md.Spec.Caching = &airunwayv1alpha1.CachingSpec{KV: &airunwayv1alpha1.KVCacheSpec{Backend: airunwayv1alpha1.KVCacheBackendLMCache}}
+	r.reconcileKVCacheStatus(ctx, md)
+	waitForScrapes(r)
+	if next := r.reconcileKVCacheStatus(ctx, md); next != DefaultActivityPollInterval {
+		t.Errorf("expected requeue after %s, got %s", DefaultActivityPollInterval, next)
+	}
+	status := md.Status.KVCache
+	if status == nil || status.PrefixHitPercent == nil || *status.PrefixHitPercent != 43 ||
+		status.ExternalHitPercent == nil || *status.ExternalHitPercent != 20 {
+		t.Fatalf("expected 43%% prefix and 20%% external hits, got %+v", status)
+	}
+
+	// A failed scrape keeps the last rates
+	source.err = errors.New("connection refused")
+	sample()
+	r.reconcileKVCacheStatus(ctx, md)
+	if md.Status.KVCache != status {
+		t.Error("expected the last KV cache status to be kept")
+	}
+
+	// Without lookups there is nothing to report
+	source.err = nil
+	source.sample = activity.Sample{Requests: 10}
+	sample()
+	r.reconcileKVCacheStatus(ctx, md)
+	if md.Status.KVCache != nil {
+		t.Errorf("expected no KV cache status without lookups, got %+v", md.Status.KVCache)
+	}
+
+	md.Status.KVCache = status
+	md.Spec.Caching = nil
+	r.reconcileKVCacheStatus(ctx, md)
+	if md.Status.KVCache != nil {
+		t.Error("expected the KV cache status to be cleared")
+	}
+}
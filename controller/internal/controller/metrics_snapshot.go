@@ -0,0 +1,113 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/internal/activity"
+)
+
+// metricsSample is an engine metrics sample and when it was taken
+type metricsSample struct {
+	sample activity.Sample
+	time   time.Time
+}
+
+// reconcileMetricsSnapshot records the request rate, latency and error rate of a Running
+// deployment between the last two samples of its engine metrics taken by the background
+// scraper in status.metricsSnapshot.
+// The snapshot is cleared while the deployment is not Running or when snapshots are
+// disabled. It returns how long to wait before sampling again, or zero.
+func (r *ModelDeploymentReconciler) reconcileMetricsSnapshot(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) time.Duration {
+	key := types.NamespacedName{Name: md.Name, Namespace: md.Namespace}
+	interval := r.settings().MetricsSnapshotInterval
+	if interval <= 0 || md.Status.Phase != airunwayv1alpha1.DeploymentPhaseRunning {
+		// Counters restart with the pods; take a new baseline once Running again
+		r.metricsSamples.Delete(key)
+		md.Status.MetricsSnapshot = nil
+		return 0
+	}
+
+	result, ok := r.sampleActivity(ctx, md, scrapeMetricsSnapshot, interval)
+	if !ok {
+		return interval
+	}
+	next := max(interval-time.Since(result.time), time.Second)
+	if result.err != nil {
+		log.FromContext(ctx).Info("Could not sample engine metrics", "name", md.Name, "error", result.err.Error())
+		r.metricsSamples.Delete(key)
+		md.Status.MetricsSnapshot = nil
+		return next
+	}
+	current := metricsSample{sample: result.value.(activity.Sample), time: result.time}
+	prev, ok := r.metricsSamples.Swap(key, current)
+	if ok && current.time.After(prev.(metricsSample).time) {
+		if snapshot := metricsSnapshot(md, prev.(metricsSample), current); snapshot != nil {
+			md.Status.MetricsSnapshot = snapshot
+		}
+	}
+	return next
+}
+
+// metricsSnapshot returns the golden signals between two samples, or nil when the
+// counters went backwards, as they do when a pod restarts
+func metricsSnapshot(md *airunwayv1alpha1.ModelDeployment, prev, current metricsSample) *airunwayv1alpha1.MetricsSnapshot {
+	window := current.time.Sub(prev.time)
+	requests := current.sample.Requests - prev.sample.Requests
+	aborted := current.sample.Aborted - prev.sample.Aborted
+	if window <= 0 || requests < 0 || aborted < 0 {
+		return nil
+	}
+	snapshot := &airunwayv1alpha1.MetricsSnapshot{
+		Time:   metav1.Time{Time: current.time},
+		Window: metav1.Duration{Duration: window.Round(time.Second)},
+	}
+
+	// llama.cpp counts prompt tokens rather than requests, and reports no latency. Only
+	// vllm counts aborted requests.
+	engine := md.ResolvedEngineType()
+	if engine != airunwayv1alpha1.EngineTypeLlamaCpp {
+		snapshot.RequestsPerSecond = strconv.FormatFloat(requests/window.Seconds(), 'f', 2, 64)
+	}
+	if latency := current.sample.Latency.Since(prev.sample.Latency); latency != nil {
+		snapshot.P50Latency = latencyQuantile(latency, 0.5)
+		snapshot.P95Latency = latencyQuantile(latency, 0.95)
+	}
+	if engine == airunwayv1alpha1.EngineTypeVLLM && requests > 0 {
+		snapshot.ErrorRate = strconv.FormatFloat(aborted/requests*100, 'f', 1, 64) + "%"
+	}
+	return snapshot
+}
+
+// latencyQuantile returns the q-quantile of a latency histogram rounded to milliseconds,
+// or nil without observations
+func latencyQuantile(h *activity.Histogram, q float64) *metav1.Duration {
+	seconds, ok := h.Quantile(q)
+	if !ok {
+		return nil
+	}
+	d := time.Duration(seconds * float64(time.Second)).Round(time.Millisecond)
+	return &metav1.Duration{Duration: d}
+}
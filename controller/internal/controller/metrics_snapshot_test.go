@@ -0,0 +1,136 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/internal/activity"
+)
+
+func TestMetricsSnapshot(t *testing.T) {
+	md := newModelDeployment("demo", "default")
+	md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeVLLM
+	inf := math.Inf(1)
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	prev := metricsSample{time: start, sample: activity.Sample{
+		Requests: 100,
+		Aborted:  1,
+		Latency:  &activity.Histogram{Buckets: map[float64]float64{0.5: 50, 1: 90, 2: 100, inf: 100}},
+	}}
+	current := metricsSample{time: start.Add(time.Minute), sample: activity.Sample{
+		Requests: 220,
+		Aborted:  4,
+		Latency:  &activity.Histogram{Buckets: map[float64]float64{0.5: 50, 1: 150, 2: 210, inf: 220}},
+	}}
+
+	snapshot := metricsSnapshot(md, prev, current)
+	if snapshot == nil {
+		t.Fatal("expected a snapshot")
+	}
+	if snapshot.Window.Duration != time.Minute || !snapshot.Time.Time.Equal(current.time) {
+		t.Errorf("unexpected window %s at %s", snapshot.Window.Duration, snapshot.Time)
+	}
+	if snapshot.RequestsPerSecond != "2.00" {
+		t.Errorf("expected 2.00 requests per second, got %q", snapshot.RequestsPerSecond)
+	}
+	if snapshot.P50Latency == nil || snapshot.P50Latency.Duration != 1*time.Second {
+		t.Errorf("expected p50 of 1s, got %v", snapshot.P50Latency)
+	}
+	if snapshot.P95Latency == nil || snapshot.P95Latency.Duration != 2*time.Second {
+		t.Errorf("expected p95 of 2s, got %v", snapshot.P95Latency)
+	}
+	if snapshot.ErrorRate != "2.5%" {
+		t.Errorf("expected 2.5%% errors, got %q", snapshot.ErrorRate)
+	}
+
+	// sglang does not count aborted requests
+	md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeSGLang
+	if snapshot := metricsSnapshot(md, prev, current); snapshot.ErrorRate != "" || snapshot.RequestsPerSecond != "2.00" {
+		t.Errorf("expected a rate without errors for sglang, got %+v", snapshot)
+	}
+
+	// llama.cpp counts prompt tokens rather than requests
+	md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeLlamaCpp
+	if snapshot := metricsSnapshot(md, prev, current); snapshot.RequestsPerSecond != "" || snapshot.ErrorRate != "" {
+		t.Errorf("expected no request rate for llamacpp, got %+v", snapshot)
+	}
+
+	// Counters restart with the pods
+	if snapshot := metricsSnapshot(md, current, prev); snapshot != nil {
+		t.Errorf("expected no snapshot after a counter reset, got %+v", snapshot)
+	}
+}
+
+func TestReconcileMetricsSnapshot(t *testing.T) {
+	ctx := context.Background()
+	md := newModelDeployment("demo", "default")
+	md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeVLLM
+	md.Status.Phase = airunwayv1alpha1.DeploymentPhaseRunning
+	source := &fakeActivitySource{sample: activity.Sample{Requests: 10}}
+	r := newTestReconciler(newTestScheme(), nil, md)
+	r.ActivitySource = source
+
+	// Disabled by default
+	if next := r.reconcileMetricsSnapshot(ctx, md); next != 0 || md.Status.MetricsSnapshot != nil {
+		t.Fatalf("expected no snapshot, got %v and %+v", next, md.Status.MetricsSnapshot)
+	}
+
+	r.MetricsSnapshotInterval = time.Minute
+	// The engine is sampled in the background; the first sample is the baseline
+	if next := r.reconcileMetricsSnapshot(ctx, md); next != time.Minute {
+		t.Errorf("expected requeue after the interval, got %s", next)
+	}
+	waitForScrapes(r)
+	r.reconcileMetricsSnapshot(ctx, md)
+	if md.Status.MetricsSnapshot != nil {
+		t.Fatalf("expected no snapshot from a single sample, got %+v", md.Status.MetricsSnapshot)
+	}
+	source.sample = activity.Sample{Requests: 20}
+	rescrape(r, func() { r.reconcileMetricsSnapshot(ctx, md) })
+	if next := r.reconcileMetricsSnapshot(ctx, md); next <= 0 || next > time.Minute {
+		t.Errorf("expected requeue within the interval, got %s", next)
+	}
+	snapshot := md.Status.MetricsSnapshot
+	if snapshot == nil || snapshot.RequestsPerSecond == "" || snapshot.ErrorRate != "0.0%" {
+		t.Fatalf("expected a snapshot, got %+v", snapshot)
+	}
+
+	// Reconciles before the interval elapsed keep the snapshot without scraping
+	source.err = errors.New("connection refused")
+	if next := r.reconcileMetricsSnapshot(ctx, md); next <= 0 || next > time.Minute || md.Status.MetricsSnapshot != snapshot {
+		t.Errorf("expected the snapshot to be kept until the interval elapses, got %s", next)
+	}
+
+	// A failed scrape clears it
+	rescrape(r, func() { r.reconcileMetricsSnapshot(ctx, md) })
+	r.reconcileMetricsSnapshot(ctx, md)
+	if md.Status.MetricsSnapshot != nil {
+		t.Error("expected a failed scrape to clear the snapshot")
+	}
+
+	md.Status.MetricsSnapshot = snapshot
+	md.Status.Phase = airunwayv1alpha1.DeploymentPhaseFailed
+	if next := r.reconcileMetricsSnapshot(ctx, md); next != 0 || md.Status.MetricsSnapshot != nil {
+		t.Error("expected the snapshot to be cleared when not Running")
+	}
+}
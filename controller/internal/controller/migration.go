@@ -0,0 +1,337 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+const (
+	// migrationPollInterval is how often an in-progress migration is checked
+	migrationPollInterval = 10 * time.Second
+	// migrationVerifyTimeout is how long the new provider's endpoint may fail to answer
+	// /v1/models before the migration is rolled back
+	migrationVerifyTimeout = 10 * time.Minute
+)
+
+// migrationClient bounds each /v1/models probe of the provider a deployment migrates to
+var migrationClient = &http.Client{Timeout: 10 * time.Second}
+
+// reconcileMigration moves a deployment to spec.provider.migrateTo without downtime. The
+// migration hands status.provider to the new provider while the gateway keeps routing to
+// the old provider's pods, then goes through these phases:
+//
+//   - Provisioning until the new provider reports the deployment Running
+//   - Verifying until the new provider's endpoint answers /v1/models
+//   - Switching until the gateway routes to the new provider
+//   - Draining until the old provider deleted its resources and removed its finalizer
+//
+// A failure of the new provider, a verification timeout, or a change of migrateTo before
+// Draining rolls the deployment back to the old provider, which releases the new one. It
+// returns how long to wait before checking on the migration, or zero.
+func (r *ModelDeploymentReconciler) reconcileMigration(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) (time.Duration, error) {
+	if md.Status.Provider == nil || md.Status.Provider.Name == "" {
+		return 0, nil
+	}
+	target := ""
+	if md.Spec.Provider != nil {
+		target = md.Spec.Provider.MigrateTo
+	}
+
+	migration := md.Status.Migration
+	if !migration.InProgress() {
+		// A failed migration is not retried until migrateTo changes
+		if target == "" || target == md.Status.Provider.Name ||
+			(migration != nil && migration.Phase == airunwayv1alpha1.MigrationPhaseFailed && migration.To == target) {
+			return 0, nil
+		}
+		return r.startMigration(ctx, md, target)
+	}
+
+	if target != migration.To && migration.Phase != airunwayv1alpha1.MigrationPhaseDraining {
+		message := "spec.provider.migrateTo was removed"
+		if target != "" {
+			message = fmt.Sprintf("spec.provider.migrateTo changed to %s", target)
+		}
+		r.rollbackMigration(ctx, md, message)
+		return 0, nil
+	}
+	if md.Status.Phase == airunwayv1alpha1.DeploymentPhaseFailed && migration.Phase != airunwayv1alpha1.MigrationPhaseDraining {
+		r.rollbackMigration(ctx, md, fmt.Sprintf("provider %s failed: %s", migration.To, md.Status.Message))
+		return 0, nil
+	}
+
+	switch migration.Phase {
+	case airunwayv1alpha1.MigrationPhaseProvisioning:
+		if md.Status.Phase != airunwayv1alpha1.DeploymentPhaseRunning || md.Status.Endpoint == nil || md.Status.Endpoint.Service == "" {
+			return migrationPollInterval, nil
+		}
+		r.setMigrationPhase(md, airunwayv1alpha1.MigrationPhaseVerifying,
+			fmt.Sprintf("Waiting for provider %s to answer /v1/models", migration.To))
+		fallthrough
+
+	case airunwayv1alpha1.MigrationPhaseVerifying:
+		verified, ok := r.verifyServing(ctx, md)
+		if !ok {
+			return migrationPollInterval, nil
+		}
+		if err := verified.err; err != nil {
+			r.setCondition(md, airunwayv1alpha1.ConditionTypeServingVerified, metav1.ConditionFalse, "ProbeFailed", err.Error())
+			if time.Since(migration.LastTransitionTime.Time) > migrationVerifyTimeout {
+				r.rollbackMigration(ctx, md, fmt.Sprintf("provider %s did not answer /v1/models within %s: %v", migration.To, migrationVerifyTimeout, err))
+				return 0, nil
+			}
+			return migrationPollInterval, nil
+		}
+		r.setCondition(md, airunwayv1alpha1.ConditionTypeServingVerified, metav1.ConditionTrue, "ModelsListed",
+			fmt.Sprintf("Provider %s answered /v1/models", migration.To))
+		r.setMigrationPhase(md, airunwayv1alpha1.MigrationPhaseSwitching,
+			fmt.Sprintf("Switching the gateway to provider %s", migration.To))
+		// The gateway step of this reconcile routes to the new provider; rediscover the
+		// served model name from it, and report the gateway ready again once it did
+		if md.Status.Gateway != nil {
+			md.Status.Gateway.ModelDiscovery = nil
+		}
+		r.scrapes.forget(types.NamespacedName{Name: md.Name, Namespace: md.Namespace}, scrapeModelDiscovery, scrapeServing)
+		if meta.FindStatusCondition(md.Status.Conditions, airunwayv1alpha1.ConditionTypeGatewayReady) != nil {
+			r.setCondition(md, airunwayv1alpha1.ConditionTypeGatewayReady, metav1.ConditionFalse, "MigrationSwitching",
+				fmt.Sprintf("Switching the gateway to provider %s", migration.To))
+		}
+		return migrationPollInterval, nil
+
+	case airunwayv1alpha1.MigrationPhaseSwitching:
+		if gatewayReady := meta.FindStatusCondition(md.Status.Conditions, airunwayv1alpha1.ConditionTypeGatewayReady); gatewayReady != nil &&
+			gatewayReady.Status != metav1.ConditionTrue && gatewayReady.Reason != "NoGateway" && gatewayReady.Reason != "CRDsNotAvailable" {
+			return migrationPollInterval, nil
+		}
+		r.setMigrationPhase(md, airunwayv1alpha1.MigrationPhaseDraining,
+			fmt.Sprintf("Waiting for provider %s to delete its resources", migration.From))
+		return migrationPollInterval, nil
+
+	case airunwayv1alpha1.MigrationPhaseDraining:
+		if controllerutil.ContainsFinalizer(md, airunwayv1alpha1.ProviderFinalizer(migration.From)) {
+			return migrationPollInterval, nil
+		}
+		migration.FromEndpoint = nil
+		r.setMigrationPhase(md, airunwayv1alpha1.MigrationPhaseCompleted,
+			fmt.Sprintf("Migrated from provider %s to %s", migration.From, migration.To))
+	}
+	return 0, nil
+}
+
+// startMigration hands the deployment to the provider target once it is ready and
+// compatible, recording the old provider's endpoint for the gateway to keep routing to
+func (r *ModelDeploymentReconciler) startMigration(ctx context.Context, md *airunwayv1alpha1.ModelDeployment, target string) (time.Duration, error) {
+	var pc airunwayv1alpha1.InferenceProviderConfig
+	if err := r.Get(ctx, client.ObjectKey{Name: target}, &pc); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.setCondition(md, airunwayv1alpha1.ConditionTypeMigrating, metav1.ConditionFalse, "ProviderNotFound",
+				fmt.Sprintf("No InferenceProviderConfig named %s is registered", target))
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to get provider config %s: %w", target, err)
+	}
+	namespaceLabels, err := r.providerNamespaceLabels(ctx, md, []airunwayv1alpha1.InferenceProviderConfig{pc})
+	if err != nil {
+		return 0, err
+	}
+	specMap, err := specToMap(&md.Spec)
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert spec for CEL evaluation: %w", err)
+	}
+	eval := evaluateProvider(&pc, md.ResolvedEngineType(), md.ResolvedDevice() == airunwayv1alpha1.EngineDeviceGPU,
+		resolvedServingMode(&md.Spec), namespaceLabels, specMap)
+	if !eval.Eligible {
+		var failed []string
+		for _, criterion := range eval.Criteria {
+			if !criterion.Passed {
+				failed = append(failed, criterion.Message)
+			}
+		}
+		r.setCondition(md, airunwayv1alpha1.ConditionTypeMigrating, metav1.ConditionFalse, "ProviderIncompatible",
+			fmt.Sprintf("Cannot migrate to provider %s: %s", target, strings.Join(failed, "; ")))
+		return 0, nil
+	}
+
+	from := md.Status.Provider.Name
+	log.FromContext(ctx).Info("Starting provider migration", "name", md.Name, "from", from, "to", target)
+	now := metav1.Now()
+	md.Status.Migration = &airunwayv1alpha1.MigrationStatus{
+		From:         from,
+		To:           target,
+		FromEndpoint: md.Status.Endpoint.DeepCopy(),
+		StartTime:    &now,
+	}
+	md.Status.Provider = &airunwayv1alpha1.ProviderStatus{
+		Name:           target,
+		SelectedReason: fmt.Sprintf("migrating from %s", from),
+	}
+	md.Status.Endpoint = nil
+	md.Status.Phase = airunwayv1alpha1.DeploymentPhaseDeploying
+	md.Status.Message = fmt.Sprintf("Migrating from provider %s to %s", from, target)
+	meta.RemoveStatusCondition(&md.Status.Conditions, airunwayv1alpha1.ConditionTypeServingVerified)
+	r.setCondition(md, airunwayv1alpha1.ConditionTypeProviderSelected, metav1.ConditionTrue, "MigrationSelection",
+		fmt.Sprintf("Provider %s selected by spec.provider.migrateTo", target))
+	r.setMigrationPhase(md, airunwayv1alpha1.MigrationPhaseProvisioning,
+		fmt.Sprintf("Waiting for provider %s to report the deployment Running", target))
+	return migrationPollInterval, nil
+}
+
+// rollbackMigration hands the deployment back to the provider it migrated from, which
+// releases the provider it migrated to
+func (r *ModelDeploymentReconciler) rollbackMigration(ctx context.Context, md *airunwayv1alpha1.ModelDeployment, message string) {
+	migration := md.Status.Migration
+	log.FromContext(ctx).Info("Rolling back provider migration", "name", md.Name, "from", migration.From, "to", migration.To, "reason", message)
+	md.Status.Provider = &airunwayv1alpha1.ProviderStatus{
+		Name:           migration.From,
+		SelectedReason: fmt.Sprintf("migration to %s rolled back", migration.To),
+	}
+	md.Status.Endpoint = migration.FromEndpoint
+	migration.FromEndpoint = nil
+	md.Status.Phase = airunwayv1alpha1.DeploymentPhaseDeploying
+	md.Status.Message = fmt.Sprintf("Migration to provider %s rolled back: %s", migration.To, message)
+	r.setMigrationPhase(md, airunwayv1alpha1.MigrationPhaseFailed, message)
+}
+
+// setMigrationPhase moves the migration of md to phase and updates the Migrating condition
+func (r *ModelDeploymentReconciler) setMigrationPhase(md *airunwayv1alpha1.ModelDeployment, phase airunwayv1alpha1.MigrationPhase, message string) {
+	now := metav1.Now()
+	migration := md.Status.Migration
+	migration.Phase = phase
+	migration.Message = message
+	migration.LastTransitionTime = &now
+
+	status, reason := metav1.ConditionTrue, string(phase)
+	switch phase {
+	case airunwayv1alpha1.MigrationPhaseCompleted:
+		status, reason = metav1.ConditionFalse, "MigrationCompleted"
+		migration.CompletionTime = &now
+	case airunwayv1alpha1.MigrationPhaseFailed:
+		status, reason = metav1.ConditionFalse, "MigrationFailed"
+		migration.CompletionTime = &now
+	}
+	r.setCondition(md, airunwayv1alpha1.ConditionTypeMigrating, status, reason, message)
+}
+
+// verifyServing returns the last check of the background scraper that the endpoint of the
+// provider a deployment migrates to lists at least one model on /v1/models, and whether
+// there is one
+func (r *ModelDeploymentReconciler) verifyServing(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) (scrapeResult, bool) {
+	endpoint := md.Status.Endpoint
+	port := r.resolveServicePort(ctx, endpoint.Service, md.Namespace)
+	if port == 0 {
+		port = endpoint.Port
+	}
+	if port == 0 {
+		port = 8000
+	}
+	baseURL := warmupBaseURL(endpoint.Service, md.Namespace, port)
+	version := md.Status.Migration.To + "/" + baseURL
+	return r.scrape(ctx, md, scrapeServing, version, migrationPollInterval, func(ctx context.Context) (interface{}, error) {
+		return nil, listsModels(ctx, baseURL)
+	})
+}
+
+// listsModels checks that the model server at baseURL lists at least one model on
+// /v1/models
+func listsModels(ctx context.Context, baseURL string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/v1/models", nil)
+	if err != nil {
+		return err
+	}
+	resp, err := migrationClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("GET /v1/models failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET /v1/models returned %s", resp.Status)
+	}
+	var models struct {
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&models); err != nil {
+		return fmt.Errorf("failed to decode /v1/models: %w", err)
+	}
+	if len(models.Data) == 0 {
+		return fmt.Errorf("/v1/models lists no models")
+	}
+	return nil
+}
+
+// migratingBeforeSwitch reports whether md migrates to another provider whose pods the
+// gateway does not route to yet
+func migratingBeforeSwitch(md *airunwayv1alpha1.ModelDeployment) bool {
+	return md.Status.Migration.InProgress() && !md.Status.Migration.Switched()
+}
+
+// servingEndpoint returns the endpoint whose pods receive the deployment's gateway
+// traffic: the old provider's until a migration switched the gateway, otherwise
+// status.endpoint
+func servingEndpoint(md *airunwayv1alpha1.ModelDeployment) *airunwayv1alpha1.EndpointStatus {
+	if migratingBeforeSwitch(md) {
+		return md.Status.Migration.FromEndpoint
+	}
+	return md.Status.Endpoint
+}
+
+// servingProvider returns the provider whose pods receive the deployment's gateway traffic
+// once a migration started, or an empty string for a deployment that never migrated
+func servingProvider(md *airunwayv1alpha1.ModelDeployment) string {
+	if migratingBeforeSwitch(md) {
+		return md.Status.Migration.From
+	}
+	if md.Status.Migration != nil && md.Status.Provider != nil {
+		return md.Status.Provider.Name
+	}
+	return ""
+}
+
+// migrationPoolSelector returns the selector of the serving endpoint's Service while md
+// migrates, so the InferencePool only selects the pods of one provider although both
+// carry the model-deployment label. It returns nil when md does not migrate or the
+// Service has no selector.
+func (r *ModelDeploymentReconciler) migrationPoolSelector(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) map[string]string {
+	endpoint := servingEndpoint(md)
+	if !md.Status.Migration.InProgress() || endpoint == nil || endpoint.Service == "" {
+		return nil
+	}
+	var svc corev1.Service
+	if err := r.Get(ctx, client.ObjectKey{Name: endpoint.Service, Namespace: md.Namespace}, &svc); err != nil {
+		log.FromContext(ctx).V(1).Info("Could not get the serving Service for the InferencePool selector", "service", endpoint.Service, "error", err)
+		return nil
+	}
+	return svc.Spec.Selector
+}
@@ -0,0 +1,292 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	inferencev1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+// newMigratingModelDeployment returns a deployment served by kaito that asks to migrate to llmd
+func newMigratingModelDeployment() *airunwayv1alpha1.ModelDeployment {
+	md := newCPUModelDeployment(airunwayv1alpha1.EngineTypeVLLM, airunwayv1alpha1.EngineDeviceCPU)
+	md.Finalizers = []string{airunwayv1alpha1.ProviderFinalizer("kaito")}
+	md.Spec.Provider = &airunwayv1alpha1.ProviderSpec{Name: "kaito", MigrateTo: "llmd"}
+	md.Status.Provider = &airunwayv1alpha1.ProviderStatus{Name: "kaito"}
+	return md
+}
+
+// stubModelsServer serves /v1/models for warmupBaseURL while healthy is set
+func stubModelsServer(t *testing.T, healthy *atomic.Bool) {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/v1/models" || !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[{"id":"meta-llama/Llama-3-8B"}]}`))
+	}))
+	defaultBaseURL := warmupBaseURL
+	warmupBaseURL = func(string, string, int32) string { return server.URL }
+	t.Cleanup(func() {
+		warmupBaseURL = defaultBaseURL
+		server.Close()
+	})
+}
+
+func migrationPhase(md *airunwayv1alpha1.ModelDeployment) airunwayv1alpha1.MigrationPhase {
+	if md.Status.Migration == nil {
+		return ""
+	}
+	return md.Status.Migration.Phase
+}
+
+func TestReconcileMigration(t *testing.T) {
+	var healthy atomic.Bool
+	healthy.Store(true)
+	stubModelsServer(t, &healthy)
+	providers := cpuTestProviders()
+	md := newMigratingModelDeployment()
+	r := newTestReconciler(newTestScheme(), nil, md, &providers[0], &providers[1])
+	ctx := context.Background()
+	step := func() {
+		t.Helper()
+		if _, err := r.reconcileMigration(ctx, md); err != nil {
+			t.Fatalf("reconcileMigration failed: %v", err)
+		}
+	}
+
+	// The new provider takes over status.provider while the old endpoint is kept
+	step()
+	if migrationPhase(md) != airunwayv1alpha1.MigrationPhaseProvisioning || md.Status.Provider.Name != "llmd" ||
+		md.Status.Endpoint != nil || md.Status.Phase != airunwayv1alpha1.DeploymentPhaseDeploying {
+		t.Fatalf("expected llmd to provision, got %+v %+v", md.Status.Migration, md.Status.Provider)
+	}
+	if md.Status.Migration.FromEndpoint == nil || md.Status.Migration.FromEndpoint.Service != "test-model-svc" {
+		t.Errorf("expected the old endpoint to be recorded, got %+v", md.Status.Migration.FromEndpoint)
+	}
+	if cond := meta.FindStatusCondition(md.Status.Conditions, airunwayv1alpha1.ConditionTypeMigrating); cond == nil ||
+		cond.Status != metav1.ConditionTrue || cond.Reason != string(airunwayv1alpha1.MigrationPhaseProvisioning) {
+		t.Errorf("expected Migrating True/Provisioning, got %+v", cond)
+	}
+	if servingEndpoint(md).Service != "test-model-svc" || providerNameOf(md) != "kaito" || md.ProviderReleased("kaito") {
+		t.Error("expected kaito to keep serving while llmd provisions")
+	}
+
+	// Still provisioning until the new provider reports Running
+	step()
+	if migrationPhase(md) != airunwayv1alpha1.MigrationPhaseProvisioning {
+		t.Fatalf("expected Provisioning, got %s", migrationPhase(md))
+	}
+
+	// Running and answering /v1/models switches the gateway
+	md.Status.Phase = airunwayv1alpha1.DeploymentPhaseRunning
+	md.Status.Endpoint = &airunwayv1alpha1.EndpointStatus{Service: "test-model-llmd", Port: 8000}
+	r.setCondition(md, airunwayv1alpha1.ConditionTypeGatewayReady, metav1.ConditionTrue, "GatewayConfigured", "")
+	step()
+	if migrationPhase(md) != airunwayv1alpha1.MigrationPhaseVerifying {
+		t.Fatalf("expected Verifying while /v1/models is requested in the background, got %s", migrationPhase(md))
+	}
+	waitForScrapes(r)
+	step()
+	if migrationPhase(md) != airunwayv1alpha1.MigrationPhaseSwitching {
+		t.Fatalf("expected Switching, got %s", migrationPhase(md))
+	}
+	if !meta.IsStatusConditionTrue(md.Status.Conditions, airunwayv1alpha1.ConditionTypeServingVerified) {
+		t.Error("expected ServingVerified to be true")
+	}
+	if servingEndpoint(md).Service != "test-model-llmd" || providerNameOf(md) != "llmd" {
+		t.Error("expected the gateway to route to llmd once switching")
+	}
+
+	// Draining starts once the gateway is ready again
+	step()
+	if migrationPhase(md) != airunwayv1alpha1.MigrationPhaseSwitching {
+		t.Fatalf("expected Switching until the gateway is ready, got %s", migrationPhase(md))
+	}
+	r.setCondition(md, airunwayv1alpha1.ConditionTypeGatewayReady, metav1.ConditionTrue, "GatewayConfigured", "")
+	step()
+	if migrationPhase(md) != airunwayv1alpha1.MigrationPhaseDraining || !md.ProviderReleased("kaito") {
+		t.Fatalf("expected kaito to be released, got %s", migrationPhase(md))
+	}
+
+	// The migration completes once the old provider removed its finalizer
+	step()
+	if migrationPhase(md) != airunwayv1alpha1.MigrationPhaseDraining {
+		t.Fatalf("expected Draining while kaito holds its finalizer, got %s", migrationPhase(md))
+	}
+	md.Finalizers = nil
+	step()
+	if migrationPhase(md) != airunwayv1alpha1.MigrationPhaseCompleted || md.Status.Migration.CompletionTime == nil {
+		t.Fatalf("expected Completed, got %+v", md.Status.Migration)
+	}
+	if cond := meta.FindStatusCondition(md.Status.Conditions, airunwayv1alpha1.ConditionTypeMigrating); cond == nil ||
+		cond.Status != metav1.ConditionFalse || cond.Reason != "MigrationCompleted" {
+		t.Errorf("expected Migrating False/MigrationCompleted, got %+v", cond)
+	}
+	step()
+	if md.Status.Provider.Name != "llmd" || migrationPhase(md) != airunwayv1alpha1.MigrationPhaseCompleted {
+		t.Errorf("expected the completed migration to stay put, got %+v", md.Status.Migration)
+	}
+}
+
+func TestReconcileMigration_Rollback(t *testing.T) {
+	var healthy atomic.Bool
+	stubModelsServer(t, &healthy)
+	providers := cpuTestProviders()
+	ctx := context.Background()
+
+	// The new endpoint never answers /v1/models
+	md := newMigratingModelDeployment()
+	r := newTestReconciler(newTestScheme(), nil, md, &providers[0], &providers[1])
+	if _, err := r.reconcileMigration(ctx, md); err != nil {
+		t.Fatalf("reconcileMigration failed: %v", err)
+	}
+	md.Status.Phase = airunwayv1alpha1.DeploymentPhaseRunning
+	md.Status.Endpoint = &airunwayv1alpha1.EndpointStatus{Service: "test-model-llmd", Port: 8000}
+	if next, _ := r.reconcileMigration(ctx, md); next != migrationPollInterval || migrationPhase(md) != airunwayv1alpha1.MigrationPhaseVerifying {
+		t.Fatalf("expected Verifying, got %s", migrationPhase(md))
+	}
+	waitForScrapes(r)
+	if _, err := r.reconcileMigration(ctx, md); err != nil {
+		t.Fatalf("reconcileMigration failed: %v", err)
+	}
+	if cond := meta.FindStatusCondition(md.Status.Conditions, airunwayv1alpha1.ConditionTypeServingVerified); cond == nil || cond.Reason != "ProbeFailed" {
+		t.Errorf("expected ServingVerified False/ProbeFailed, got %+v", cond)
+	}
+	md.Status.Migration.LastTransitionTime = &metav1.Time{Time: time.Now().Add(-migrationVerifyTimeout - time.Minute)}
+	if _, err := r.reconcileMigration(ctx, md); err != nil {
+		t.Fatalf("reconcileMigration failed: %v", err)
+	}
+	if migrationPhase(md) != airunwayv1alpha1.MigrationPhaseFailed || md.Status.Provider.Name != "kaito" ||
+		md.Status.Endpoint == nil || md.Status.Endpoint.Service != "test-model-svc" {
+		t.Fatalf("expected a rollback to kaito, got %+v %+v", md.Status.Migration, md.Status.Provider)
+	}
+	if !md.ProviderReleased("llmd") || md.ProviderReleased("kaito") {
+		t.Error("expected llmd to be released after the rollback")
+	}
+	// A failed migration is not retried for the same migrateTo
+	if _, err := r.reconcileMigration(ctx, md); err != nil || md.Status.Provider.Name != "kaito" {
+		t.Errorf("expected the failed migration not to restart, got %+v (%v)", md.Status.Provider, err)
+	}
+
+	// Removing migrateTo cancels a migration before the gateway drained the old provider
+	md = newMigratingModelDeployment()
+	r = newTestReconciler(newTestScheme(), nil, md, &providers[0], &providers[1])
+	_, _ = r.reconcileMigration(ctx, md)
+	md.Spec.Provider.MigrateTo = ""
+	_, _ = r.reconcileMigration(ctx, md)
+	if migrationPhase(md) != airunwayv1alpha1.MigrationPhaseFailed || md.Status.Provider.Name != "kaito" ||
+		md.Status.Migration.Message != "spec.provider.migrateTo was removed" {
+		t.Errorf("expected the migration to be cancelled, got %+v", md.Status.Migration)
+	}
+
+	// A failure of the new provider rolls back too
+	md = newMigratingModelDeployment()
+	r = newTestReconciler(newTestScheme(), nil, md, &providers[0], &providers[1])
+	_, _ = r.reconcileMigration(ctx, md)
+	md.Status.Phase = airunwayv1alpha1.DeploymentPhaseFailed
+	md.Status.Message = "image pull failed"
+	_, _ = r.reconcileMigration(ctx, md)
+	if migrationPhase(md) != airunwayv1alpha1.MigrationPhaseFailed || md.Status.Provider.Name != "kaito" {
+		t.Errorf("expected a rollback after the provider failed, got %+v", md.Status.Migration)
+	}
+}
+
+func TestReconcileMigration_TargetNotEligible(t *testing.T) {
+	providers := cpuTestProviders()
+	ctx := context.Background()
+
+	// llmd does not run llamacpp
+	md := newMigratingModelDeployment()
+	md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeLlamaCpp
+	r := newTestReconciler(newTestScheme(), nil, md, &providers[0], &providers[1])
+	if _, err := r.reconcileMigration(ctx, md); err != nil {
+		t.Fatalf("reconcileMigration failed: %v", err)
+	}
+	if cond := meta.FindStatusCondition(md.Status.Conditions, airunwayv1alpha1.ConditionTypeMigrating); cond == nil ||
+		cond.Status != metav1.ConditionFalse || cond.Reason != "ProviderIncompatible" {
+		t.Errorf("expected Migrating False/ProviderIncompatible, got %+v", cond)
+	}
+	if md.Status.Migration != nil || md.Status.Provider.Name != "kaito" {
+		t.Errorf("expected no migration to start, got %+v", md.Status.Migration)
+	}
+
+	md = newMigratingModelDeployment()
+	md.Spec.Provider.MigrateTo = "dynamo"
+	r = newTestReconciler(newTestScheme(), nil, md, &providers[0], &providers[1])
+	_, _ = r.reconcileMigration(ctx, md)
+	if cond := meta.FindStatusCondition(md.Status.Conditions, airunwayv1alpha1.ConditionTypeMigrating); cond == nil || cond.Reason != "ProviderNotFound" {
+		t.Errorf("expected Migrating False/ProviderNotFound, got %+v", cond)
+	}
+}
+
+func TestReconcileInferencePool_Migration(t *testing.T) {
+	service := func(name string, selector map[string]string) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       corev1.ServiceSpec{Selector: selector, Ports: []corev1.ServicePort{{Port: 80}}},
+		}
+	}
+	md := newMigratingModelDeployment()
+	md.Status.Provider.Name = "llmd"
+	md.Status.Endpoint = &airunwayv1alpha1.EndpointStatus{Service: "test-model-llmd", Port: 8000}
+	md.Status.Migration = &airunwayv1alpha1.MigrationStatus{
+		From: "kaito", To: "llmd", Phase: airunwayv1alpha1.MigrationPhaseVerifying,
+		FromEndpoint: &airunwayv1alpha1.EndpointStatus{Service: "test-model-svc", Port: 80},
+	}
+	r := newTestReconciler(newTestScheme(), fakeDetector(true, "my-gateway", "gateway-ns"), md,
+		service("test-model-svc", map[string]string{"kaito.sh/workspace": "test-model"}),
+		service("test-model-llmd", map[string]string{"app": "test-model-llmd"}))
+	ctx := context.Background()
+	selector := func(t *testing.T) map[inferencev1.LabelKey]inferencev1.LabelValue {
+		t.Helper()
+		if err := r.reconcileInferencePool(ctx, md, 8000, "gateway-ns"); err != nil {
+			t.Fatalf("reconcileInferencePool failed: %v", err)
+		}
+		var pool inferencev1.InferencePool
+		if err := r.Get(ctx, types.NamespacedName{Name: "test-model", Namespace: "default"}, &pool); err != nil {
+			t.Fatalf("InferencePool not found: %v", err)
+		}
+		return pool.Spec.Selector.MatchLabels
+	}
+
+	// The pool only selects the old provider's pods until the gateway switched
+	if got := selector(t); len(got) != 1 || got["kaito.sh/workspace"] != "test-model" {
+		t.Errorf("expected the kaito Service selector, got %v", got)
+	}
+	md.Status.Migration.Phase = airunwayv1alpha1.MigrationPhaseSwitching
+	if got := selector(t); len(got) != 1 || got["app"] != "test-model-llmd" {
+		t.Errorf("expected the llmd Service selector, got %v", got)
+	}
+	md.Status.Migration.Phase = airunwayv1alpha1.MigrationPhaseCompleted
+	if got := selector(t); len(got) != 1 || got[inferencev1.LabelKey(airunwayv1alpha1.LabelModelDeployment)] != "test-model" {
+		t.Errorf("expected the model-deployment selector after the migration, got %v", got)
+	}
+}
@@ -0,0 +1,542 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/internal/batch"
+	"github.com/kaito-project/airunway/controller/internal/gateway"
+	"github.com/kaito-project/airunway/controller/pkg/kstatus"
+)
+
+const (
+	// batchProgressInterval is how often the progress of a running batch Job is read
+	batchProgressInterval = 15 * time.Second
+
+	// batchJobSuffix is appended to the ModelBatchJob name to form the batch Job name
+	batchJobSuffix = "-batch"
+
+	// batchRunnerContainer is the name of the runner container of a batch Job
+	batchRunnerContainer = "batch-runner"
+
+	// batchWorkDir is the emptyDir the runner downloads the input and stages the output in
+	batchWorkDir = "/work"
+)
+
+// BatchProgressSource reads the progress of the runner pod of a batch Job
+type BatchProgressSource interface {
+	Progress(ctx context.Context, pod *corev1.Pod) (batch.Progress, error)
+}
+
+// httpBatchProgressSource reads the progress endpoint of the runner pod
+type httpBatchProgressSource struct{}
+
+// batchProgressClient reads runner progress in the background; a runner that does not
+// answer in time is read again on the next poll
+var batchProgressClient = &http.Client{Timeout: 5 * time.Second}
+
+// Progress implements BatchProgressSource
+func (httpBatchProgressSource) Progress(ctx context.Context, pod *corev1.Pod) (batch.Progress, error) {
+	var progress batch.Progress
+	if pod.Status.PodIP == "" {
+		return progress, fmt.Errorf("pod %s has no IP", pod.Name)
+	}
+	url := "http://" + net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(int(batch.ProgressPort))) + batch.ProgressPath
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return progress, err
+	}
+	resp, err := batchProgressClient.Do(req)
+	if err != nil {
+		return progress, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return progress, fmt.Errorf("runner returned HTTP %d", resp.StatusCode)
+	}
+	return progress, json.NewDecoder(resp.Body).Decode(&progress)
+}
+
+// ModelBatchJobReconciler runs a ModelBatchJob: it creates the transient ModelDeployment
+// of the job if it has one, runs the batch runner as a Job once the deployment is Running,
+// and reports the progress of the runner in the status of the job.
+type ModelBatchJobReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// Image is the batch runner image, batch.DefaultImage when empty
+	Image string
+
+	// Progress reads the progress of running batch Jobs. Defaults to the progress
+	// endpoint of the runner pod.
+	Progress BatchProgressSource
+
+	// scrapes reads the progress of runner pods in the background
+	scrapes scraper
+}
+
+// +kubebuilder:rbac:groups=airunway.ai,resources=modelbatchjobs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=airunway.ai,resources=modelbatchjobs/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
+
+// Reconcile moves a ModelBatchJob through its phases.
+func (r *ModelBatchJobReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var job airunwayv1alpha1.ModelBatchJob
+	if err := r.Get(ctx, req.NamespacedName, &job); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.scrapes.forget(req.NamespacedName)
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !job.DeletionTimestamp.IsZero() {
+		// The batch Job and transient ModelDeployment are garbage collected through their
+		// owner reference
+		r.scrapes.forget(req.NamespacedName)
+		return ctrl.Result{}, nil
+	}
+
+	status := *job.Status.DeepCopy()
+	status.ObservedGeneration = job.Generation
+	requeueAfter, err := r.reconcileBatchJob(ctx, &job, &status)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	status.Conditions = append([]metav1.Condition(nil), status.Conditions...)
+	kstatus.SetReady(&status.Conditions, job.Generation, batchJobHealth(status.Phase), string(status.Phase), status.Message)
+
+	if !equality.Semantic.DeepEqual(status, job.Status) {
+		base := job.DeepCopy()
+		job.Status = status
+		if err := r.Status().Patch(ctx, &job, client.MergeFrom(base)); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// batchJobHealth returns the kstatus state of a job in phase: a job is current once it
+// succeeded
+func batchJobHealth(phase airunwayv1alpha1.BatchJobPhase) kstatus.State {
+	switch phase {
+	case airunwayv1alpha1.BatchJobPhaseSucceeded:
+		return kstatus.Current
+	case airunwayv1alpha1.BatchJobPhaseFailed:
+		return kstatus.Failed
+	default:
+		return kstatus.InProgress
+	}
+}
+
+// reconcileBatchJob computes the status of job, creating the transient ModelDeployment
+// and batch Job as needed. It returns how long to wait before reading the progress again.
+func (r *ModelBatchJobReconciler) reconcileBatchJob(ctx context.Context, job *airunwayv1alpha1.ModelBatchJob, status *airunwayv1alpha1.ModelBatchJobStatus) (time.Duration, error) {
+	if job.Finished() {
+		r.scrapes.forget(k8stypes.NamespacedName{Name: job.Name, Namespace: job.Namespace})
+		return 0, r.deleteTransientDeployment(ctx, job)
+	}
+	if err := validateBatchJob(job); err != nil {
+		finishBatchJob(status, airunwayv1alpha1.BatchJobPhaseFailed, err.Error())
+		return 0, nil
+	}
+	status.Deployment = job.DeploymentName()
+	status.Job = job.Name + batchJobSuffix
+
+	var runner batchv1.Job
+	err := r.Get(ctx, k8stypes.NamespacedName{Name: status.Job, Namespace: job.Namespace}, &runner)
+	if err == nil {
+		if !metav1.IsControlledBy(&runner, job) {
+			finishBatchJob(status, airunwayv1alpha1.BatchJobPhaseFailed, fmt.Sprintf("Job %s exists and is not owned by the ModelBatchJob", runner.Name))
+			return 0, nil
+		}
+		return r.trackRunner(ctx, job, &runner, status)
+	}
+	if !apierrors.IsNotFound(err) {
+		return 0, fmt.Errorf("failed to get batch Job %s: %w", status.Job, err)
+	}
+
+	md, message, err := r.servingDeployment(ctx, job)
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case md == nil:
+		status.Phase = airunwayv1alpha1.BatchJobPhasePending
+		status.Message = message
+		return 0, nil
+	case md.Status.Phase == airunwayv1alpha1.DeploymentPhaseFailed && job.Spec.Deployment != nil:
+		finishBatchJob(status, airunwayv1alpha1.BatchJobPhaseFailed, fmt.Sprintf("ModelDeployment %s failed: %s", md.Name, md.Status.Message))
+		return 0, r.deleteTransientDeployment(ctx, job)
+	case md.Status.Phase != airunwayv1alpha1.DeploymentPhaseRunning || md.Status.Endpoint == nil || md.Status.Endpoint.Service == "":
+		status.Phase = airunwayv1alpha1.BatchJobPhasePending
+		status.Message = fmt.Sprintf("Waiting for ModelDeployment %s to be Running", md.Name)
+		return 0, nil
+	}
+
+	runnerJob := r.buildRunnerJob(ctx, job, md)
+	if err := ctrl.SetControllerReference(job, runnerJob, r.Scheme); err != nil {
+		return 0, err
+	}
+	if err := r.Create(ctx, runnerJob); err != nil && !apierrors.IsAlreadyExists(err) {
+		return 0, fmt.Errorf("failed to create batch Job %s: %w", runnerJob.Name, err)
+	}
+	log.FromContext(ctx).Info("Created batch Job", "name", job.Name, "job", runnerJob.Name, "deployment", md.Name)
+	status.Phase = airunwayv1alpha1.BatchJobPhaseRunning
+	status.Message = fmt.Sprintf("Sending requests to ModelDeployment %s", md.Name)
+	return batchProgressInterval, nil
+}
+
+// validateBatchJob checks what the schema cannot: that exactly one deployment is set and
+// that the URIs can be parsed
+func validateBatchJob(job *airunwayv1alpha1.ModelBatchJob) error {
+	if (job.Spec.DeploymentRef == "") == (job.Spec.Deployment == nil) {
+		return fmt.Errorf("exactly one of spec.deploymentRef and spec.deployment must be set")
+	}
+	if _, err := batch.ParseURI(job.Spec.Input); err != nil {
+		return fmt.Errorf("spec.input: %w", err)
+	}
+	if _, err := batch.ParseURI(job.Spec.Output); err != nil {
+		return fmt.Errorf("spec.output: %w", err)
+	}
+	return nil
+}
+
+// servingDeployment returns the ModelDeployment serving job, creating the transient one. It
+// returns a nil deployment and the reason when the referenced one does not exist.
+func (r *ModelBatchJobReconciler) servingDeployment(ctx context.Context, job *airunwayv1alpha1.ModelBatchJob) (*airunwayv1alpha1.ModelDeployment, string, error) {
+	md := &airunwayv1alpha1.ModelDeployment{}
+	err := r.Get(ctx, k8stypes.NamespacedName{Name: job.DeploymentName(), Namespace: job.Namespace}, md)
+	if client.IgnoreNotFound(err) != nil {
+		return nil, "", fmt.Errorf("failed to get ModelDeployment %s: %w", job.DeploymentName(), err)
+	}
+	if job.Spec.Deployment == nil {
+		if err != nil {
+			return nil, fmt.Sprintf("ModelDeployment %s not found", job.Spec.DeploymentRef), nil
+		}
+		return md, "", nil
+	}
+
+	if err == nil {
+		if !metav1.IsControlledBy(md, job) {
+			return nil, fmt.Sprintf("ModelDeployment %s exists and is not owned by the ModelBatchJob", md.Name), nil
+		}
+		return md, "", nil
+	}
+	md = &airunwayv1alpha1.ModelDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      job.Name,
+			Namespace: job.Namespace,
+			Labels:    map[string]string{airunwayv1alpha1.LabelBatchJob: job.Name},
+		},
+		Spec: *job.Spec.Deployment.DeepCopy(),
+	}
+	if err := ctrl.SetControllerReference(job, md, r.Scheme); err != nil {
+		return nil, "", err
+	}
+	if err := r.Create(ctx, md); err != nil {
+		return nil, "", fmt.Errorf("failed to create ModelDeployment %s: %w", md.Name, err)
+	}
+	log.FromContext(ctx).Info("Created transient ModelDeployment", "name", job.Name, "deployment", md.Name)
+	return md, "", nil
+}
+
+// trackRunner copies the progress of a batch Job into status and finishes the job when the
+// batch Job completed or failed. The progress of a running runner pod is read in the
+// background; status gets the last reading.
+func (r *ModelBatchJobReconciler) trackRunner(ctx context.Context, job *airunwayv1alpha1.ModelBatchJob, runner *batchv1.Job, status *airunwayv1alpha1.ModelBatchJobStatus) (time.Duration, error) {
+	status.Phase = airunwayv1alpha1.BatchJobPhaseRunning
+	if runner.Status.StartTime != nil {
+		status.StartTime = runner.Status.StartTime.DeepCopy()
+	}
+	pod, err := r.runnerPod(ctx, runner)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, cond := range runner.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue || (cond.Type != batchv1.JobComplete && cond.Type != batchv1.JobFailed) {
+			continue
+		}
+		progress, ok := terminationProgress(pod)
+		if ok {
+			setBatchProgress(status, progress, time.Now())
+		}
+		if cond.Type == batchv1.JobComplete {
+			finishBatchJob(status, airunwayv1alpha1.BatchJobPhaseSucceeded, fmt.Sprintf("Processed %d records: %d completed, %d failed",
+				status.TotalRequests, status.CompletedRequests, status.FailedRequests))
+		} else {
+			message := cond.Message
+			if ok && progress.Error != "" {
+				message = progress.Error
+			}
+			finishBatchJob(status, airunwayv1alpha1.BatchJobPhaseFailed, "Batch Job failed: "+message)
+		}
+		return 0, r.deleteTransientDeployment(ctx, job)
+	}
+
+	if pod != nil && pod.Status.Phase == corev1.PodRunning {
+		source := r.Progress
+		if source == nil {
+			source = httpBatchProgressSource{}
+		}
+		logger := log.FromContext(ctx)
+		key := k8stypes.NamespacedName{Name: job.Name, Namespace: job.Namespace}
+		target := pod.DeepCopy()
+		result, ok := r.scrapes.fetch(key, scrapeBatchProgress, pod.Name, batchProgressInterval, func(ctx context.Context) (interface{}, error) {
+			return source.Progress(log.IntoContext(ctx, logger), target)
+		})
+		switch {
+		case !ok:
+		case result.err != nil:
+			logger.V(1).Info("Could not read batch progress", "pod", pod.Name, "error", result.err.Error())
+		default:
+			setBatchProgress(status, result.value.(batch.Progress), result.time)
+		}
+	}
+	return batchProgressInterval, nil
+}
+
+// runnerPod returns the newest pod of a batch Job, or nil
+func (r *ModelBatchJobReconciler) runnerPod(ctx context.Context, runner *batchv1.Job) (*corev1.Pod, error) {
+	var pods corev1.PodList
+	if err := r.List(ctx, &pods, client.InNamespace(runner.Namespace), client.MatchingLabels{batchv1.JobNameLabel: runner.Name}); err != nil {
+		return nil, fmt.Errorf("failed to list pods of batch Job %s: %w", runner.Name, err)
+	}
+	var newest *corev1.Pod
+	for i := range pods.Items {
+		if newest == nil || newest.CreationTimestamp.Before(&pods.Items[i].CreationTimestamp) {
+			newest = &pods.Items[i]
+		}
+	}
+	return newest, nil
+}
+
+// terminationProgress returns the final progress the runner wrote as its termination
+// message
+func terminationProgress(pod *corev1.Pod) (batch.Progress, bool) {
+	var progress batch.Progress
+	if pod == nil {
+		return progress, false
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.Name != batchRunnerContainer || cs.State.Terminated == nil {
+			continue
+		}
+		if err := json.Unmarshal([]byte(cs.State.Terminated.Message), &progress); err == nil {
+			return progress, true
+		}
+	}
+	return progress, false
+}
+
+// setBatchProgress copies the counts of progress into status and computes the rate of
+// finished records since the batch Job started
+func setBatchProgress(status *airunwayv1alpha1.ModelBatchJobStatus, progress batch.Progress, now time.Time) {
+	status.TotalRequests = progress.Total
+	status.CompletedRequests = progress.Completed
+	status.FailedRequests = progress.Failed
+	if status.StartTime == nil {
+		return
+	}
+	if elapsed := now.Sub(status.StartTime.Time); elapsed > 0 {
+		finished := float64(progress.Completed + progress.Failed)
+		status.RequestsPerSecond = strconv.FormatFloat(finished/elapsed.Seconds(), 'f', 2, 64)
+	}
+}
+
+// finishBatchJob moves status to a final phase
+func finishBatchJob(status *airunwayv1alpha1.ModelBatchJobStatus, phase airunwayv1alpha1.BatchJobPhase, message string) {
+	status.Phase = phase
+	status.Message = message
+	status.CompletionTime = &metav1.Time{Time: time.Now()}
+}
+
+// deleteTransientDeployment deletes the ModelDeployment created for job, if any
+func (r *ModelBatchJobReconciler) deleteTransientDeployment(ctx context.Context, job *airunwayv1alpha1.ModelBatchJob) error {
+	if job.Spec.Deployment == nil {
+		return nil
+	}
+	var md airunwayv1alpha1.ModelDeployment
+	if err := r.Get(ctx, k8stypes.NamespacedName{Name: job.Name, Namespace: job.Namespace}, &md); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !metav1.IsControlledBy(&md, job) || !md.DeletionTimestamp.IsZero() {
+		return nil
+	}
+	log.FromContext(ctx).Info("Deleting transient ModelDeployment of finished ModelBatchJob", "name", job.Name)
+	if err := r.Delete(ctx, &md); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete ModelDeployment %s: %w", md.Name, err)
+	}
+	return nil
+}
+
+// buildRunnerJob returns the batch Job running the batch runner against the endpoint of md.
+// The Job is not retried, since a new runner would send every record again.
+func (r *ModelBatchJobReconciler) buildRunnerJob(ctx context.Context, job *airunwayv1alpha1.ModelBatchJob, md *airunwayv1alpha1.ModelDeployment) *batchv1.Job {
+	// Validated by validateBatchJob
+	input, _ := batch.ParseURI(job.Spec.Input)
+	output, _ := batch.ParseURI(job.Spec.Output)
+
+	port := lookupServicePort(ctx, r.Client, md.Status.Endpoint.Service, md.Namespace)
+	if port == 0 {
+		port = md.Status.Endpoint.Port
+	}
+	if port == 0 {
+		port = 8000
+	}
+	concurrency := job.Spec.Concurrency
+	if concurrency <= 0 {
+		concurrency = batch.DefaultConcurrency
+	}
+	args := []string{
+		"--input", input.RunnerPath(),
+		"--output", output.RunnerPath(),
+		"--endpoint", warmupBaseURL(md.Status.Endpoint.Service, md.Namespace, port),
+		"--concurrency", strconv.Itoa(int(concurrency)),
+		"--work-dir", batchWorkDir,
+	}
+	if shouldUseServedNameForGateway(md) {
+		args = append(args, "--model", md.Spec.Model.ServedName)
+	}
+	if job.Spec.PromptTemplate != "" {
+		args = append(args, "--prompt-template", job.Spec.PromptTemplate)
+	}
+	if job.Spec.MaxTokens != nil {
+		args = append(args, "--max-tokens", strconv.Itoa(int(*job.Spec.MaxTokens)))
+	}
+
+	volumes := []corev1.Volume{{Name: "work", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}}
+	mounts := []corev1.VolumeMount{{Name: "work", MountPath: batchWorkDir}}
+	for _, loc := range []batch.Location{output, input} {
+		if loc.Claim == "" || hasVolumeForClaim(volumes, loc.Claim) {
+			continue
+		}
+		name := fmt.Sprintf("data-%d", len(volumes))
+		volumes = append(volumes, corev1.Volume{Name: name, VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: loc.Claim},
+		}})
+		// Only the output claim is written to
+		mounts = append(mounts, corev1.VolumeMount{Name: name, MountPath: batch.DataDir + "/" + loc.Claim, ReadOnly: loc != output})
+	}
+
+	image := r.Image
+	if image == "" {
+		image = batch.DefaultImage
+	}
+	labels := map[string]string{
+		airunwayv1alpha1.LabelManagedBy: airunwayv1alpha1.ManagedByAIRunway,
+		airunwayv1alpha1.LabelBatchJob:  job.Name,
+		airunwayv1alpha1.LabelJobType:   "batch-inference",
+	}
+	backoffLimit := int32(0)
+	// The runner never talks to the API server
+	automountToken := false
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: job.Name + batchJobSuffix, Namespace: job.Namespace, Labels: labels},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					RestartPolicy:                corev1.RestartPolicyNever,
+					AutomountServiceAccountToken: &automountToken,
+					Containers: []corev1.Container{{
+						Name:            batchRunnerContainer,
+						Image:           image,
+						ImagePullPolicy: corev1.PullIfNotPresent,
+						Command:         []string{"/batch-runner"},
+						Args:            args,
+						SecurityContext: gateway.DefaultEPPSecurityContext(),
+						Ports:           []corev1.ContainerPort{{Name: "progress", ContainerPort: batch.ProgressPort}},
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("100m"),
+								corev1.ResourceMemory: resource.MustParse("256Mi"),
+							},
+						},
+						VolumeMounts: mounts,
+					}},
+					Volumes: volumes,
+				},
+			},
+		},
+	}
+}
+
+// hasVolumeForClaim reports whether volumes already mount claim
+func hasVolumeForClaim(volumes []corev1.Volume, claim string) bool {
+	for _, v := range volumes {
+		if v.PersistentVolumeClaim != nil && v.PersistentVolumeClaim.ClaimName == claim {
+			return true
+		}
+	}
+	return false
+}
+
+// mapModelDeploymentToBatchJobs returns the unfinished ModelBatchJobs served by a
+// ModelDeployment, so they start once it is Running
+func (r *ModelBatchJobReconciler) mapModelDeploymentToBatchJobs(ctx context.Context, obj client.Object) []reconcile.Request {
+	var jobs airunwayv1alpha1.ModelBatchJobList
+	if err := r.List(ctx, &jobs, client.InNamespace(obj.GetNamespace())); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list ModelBatchJobs for ModelDeployment", "name", obj.GetName())
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, job := range jobs.Items {
+		if !job.Finished() && job.DeploymentName() == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: k8stypes.NamespacedName{Name: job.Name, Namespace: job.Namespace}})
+		}
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager. Batch Jobs are watched for
+// completion and ModelDeployments so pending jobs start once their deployment is Running.
+func (r *ModelBatchJobReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.Add(&r.scrapes); err != nil {
+		return err
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&airunwayv1alpha1.ModelBatchJob{}).
+		Owns(&batchv1.Job{}).
+		Watches(&airunwayv1alpha1.ModelDeployment{}, handler.EnqueueRequestsFromMapFunc(r.mapModelDeploymentToBatchJobs)).
+		// Reconcile a job when a background read of its runner progress completed
+		WatchesRawSource(r.scrapes.source()).
+		Named("modelbatchjob").
+		Complete(r)
+}
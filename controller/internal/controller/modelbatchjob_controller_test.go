@@ -0,0 +1,268 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/internal/batch"
+)
+
+type fakeBatchProgressSource struct {
+	progress batch.Progress
+}
+
+func (f *fakeBatchProgressSource) Progress(_ context.Context, _ *corev1.Pod) (batch.Progress, error) {
+	return f.progress, nil
+}
+
+func newModelBatchJob() *airunwayv1alpha1.ModelBatchJob {
+	return &airunwayv1alpha1.ModelBatchJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "score", Namespace: "default", UID: "job-uid", Generation: 1},
+		Spec: airunwayv1alpha1.ModelBatchJobSpec{
+			Deployment: &airunwayv1alpha1.ModelDeploymentSpec{
+				Model:  airunwayv1alpha1.ModelSpec{ID: "Qwen/Qwen3-0.6B", Source: airunwayv1alpha1.ModelSourceHuggingFace},
+				Engine: airunwayv1alpha1.EngineSpec{Type: airunwayv1alpha1.EngineTypeVLLM},
+			},
+			Input:          "pvc://datasets/in.jsonl",
+			Output:         "pvc://results/out.jsonl",
+			PromptTemplate: "Summarize: {{ .text }}",
+			Concurrency:    4,
+		},
+	}
+}
+
+func newBatchJobReconciler(progress BatchProgressSource, objs ...client.Object) *ModelBatchJobReconciler {
+	scheme := newTestScheme()
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).
+		WithStatusSubresource(&airunwayv1alpha1.ModelBatchJob{}, &airunwayv1alpha1.ModelDeployment{}).
+		Build()
+	return &ModelBatchJobReconciler{Client: c, Scheme: scheme, Image: "runner:test", Progress: progress}
+}
+
+func reconcileBatchJob(t *testing.T, r *ModelBatchJobReconciler) *airunwayv1alpha1.ModelBatchJob {
+	t.Helper()
+	key := types.NamespacedName{Name: "score", Namespace: "default"}
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	var job airunwayv1alpha1.ModelBatchJob
+	if err := r.Get(context.Background(), key, &job); err != nil {
+		t.Fatal(err)
+	}
+	return &job
+}
+
+func TestModelBatchJobReconcile_TransientDeployment(t *testing.T) {
+	progress := &fakeBatchProgressSource{}
+	r := newBatchJobReconciler(progress, newModelBatchJob())
+	ctx := context.Background()
+
+	// The transient ModelDeployment is created and the job waits for it
+	job := reconcileBatchJob(t, r)
+	if job.Status.Phase != airunwayv1alpha1.BatchJobPhasePending || job.Status.Deployment != "score" {
+		t.Fatalf("expected Pending on the transient deployment, got %+v", job.Status)
+	}
+	var md airunwayv1alpha1.ModelDeployment
+	if err := r.Get(ctx, types.NamespacedName{Name: "score", Namespace: "default"}, &md); err != nil {
+		t.Fatalf("expected the transient ModelDeployment: %v", err)
+	}
+	if !metav1.IsControlledBy(&md, job) || md.Labels[airunwayv1alpha1.LabelBatchJob] != "score" || md.Spec.Model.ID != "Qwen/Qwen3-0.6B" {
+		t.Errorf("unexpected transient ModelDeployment %+v", md.ObjectMeta)
+	}
+
+	// Once it is Running, the batch Job is created against its endpoint
+	md.Status.Phase = airunwayv1alpha1.DeploymentPhaseRunning
+	md.Status.Endpoint = &airunwayv1alpha1.EndpointStatus{Service: "score", Port: 8000}
+	if err := r.Status().Update(ctx, &md); err != nil {
+		t.Fatal(err)
+	}
+	job = reconcileBatchJob(t, r)
+	if job.Status.Phase != airunwayv1alpha1.BatchJobPhaseRunning || job.Status.Job != "score-batch" {
+		t.Fatalf("expected Running, got %+v", job.Status)
+	}
+	var runner batchv1.Job
+	if err := r.Get(ctx, types.NamespacedName{Name: "score-batch", Namespace: "default"}, &runner); err != nil {
+		t.Fatalf("expected the batch Job: %v", err)
+	}
+	container := runner.Spec.Template.Spec.Containers[0]
+	args := strings.Join(container.Args, " ")
+	for _, want := range []string{
+		"--input /data/datasets/in.jsonl",
+		"--output /data/results/out.jsonl",
+		"--endpoint http://score.default.svc:8000",
+		"--concurrency 4",
+		"--prompt-template Summarize: {{ .text }}",
+	} {
+		if !strings.Contains(args, want) {
+			t.Errorf("expected %q in runner args %q", want, args)
+		}
+	}
+	if container.Image != "runner:test" || *runner.Spec.BackoffLimit != 0 {
+		t.Errorf("unexpected runner container %s, backoffLimit %d", container.Image, *runner.Spec.BackoffLimit)
+	}
+	readOnly := map[string]bool{}
+	for _, m := range container.VolumeMounts {
+		readOnly[m.MountPath] = m.ReadOnly
+	}
+	if ro, ok := readOnly["/data/datasets"]; !ok || !ro {
+		t.Errorf("expected the input claim mounted read-only, got %v", readOnly)
+	}
+	if ro, ok := readOnly["/data/results"]; !ok || ro {
+		t.Errorf("expected the output claim mounted writable, got %v", readOnly)
+	}
+
+	// Progress is read from the running runner pod
+	start := metav1.NewTime(time.Now().Add(-10 * time.Second))
+	runner.Status.StartTime = &start
+	if err := r.Status().Update(ctx, &runner); err != nil {
+		t.Fatal(err)
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "score-batch-abc", Namespace: "default", Labels: map[string]string{batchv1.JobNameLabel: "score-batch"}},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning, PodIP: "10.0.0.5"},
+	}
+	if err := r.Create(ctx, pod); err != nil {
+		t.Fatal(err)
+	}
+	progress.progress = batch.Progress{Total: 100, Completed: 40, Failed: 2}
+	// The first reconcile only starts the read; the next one reports its result
+	job = reconcileBatchJob(t, r)
+	if job.Status.TotalRequests != 0 {
+		t.Errorf("expected the progress to be read in the background, got %+v", job.Status)
+	}
+	r.scrapes.wg.Wait()
+	job = reconcileBatchJob(t, r)
+	if job.Status.TotalRequests != 100 || job.Status.CompletedRequests != 40 || job.Status.FailedRequests != 2 || job.Status.RequestsPerSecond == "" {
+		t.Errorf("expected the runner progress, got %+v", job.Status)
+	}
+
+	// The final counts come from the termination message and the transient deployment goes
+	pod.Status.Phase = corev1.PodSucceeded
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+		Name:  batchRunnerContainer,
+		State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Message: `{"total":100,"completed":97,"failed":3}`}},
+	}}
+	if err := r.Status().Update(ctx, pod); err != nil {
+		t.Fatal(err)
+	}
+	runner.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+	if err := r.Status().Update(ctx, &runner); err != nil {
+		t.Fatal(err)
+	}
+	job = reconcileBatchJob(t, r)
+	if job.Status.Phase != airunwayv1alpha1.BatchJobPhaseSucceeded || job.Status.CompletedRequests != 97 || job.Status.FailedRequests != 3 || job.Status.CompletionTime == nil {
+		t.Errorf("expected Succeeded with the final counts, got %+v", job.Status)
+	}
+	if !meta.IsStatusConditionTrue(job.Status.Conditions, airunwayv1alpha1.ConditionTypeReady) || len(job.Status.Conditions) != 1 {
+		t.Errorf("expected a succeeded job to be Ready only, got %+v", job.Status.Conditions)
+	}
+	if err := r.Get(ctx, types.NamespacedName{Name: "score", Namespace: "default"}, &md); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the transient ModelDeployment to be deleted, got %v", err)
+	}
+}
+
+func TestModelBatchJobReconcile_DeploymentRef(t *testing.T) {
+	job := newModelBatchJob()
+	job.Spec.Deployment = nil
+	job.Spec.DeploymentRef = "llama"
+	job.Spec.Output = "https://storage.example.com/out.jsonl?sig=abc"
+	r := newBatchJobReconciler(nil, job)
+	ctx := context.Background()
+
+	got := reconcileBatchJob(t, r)
+	if got.Status.Phase != airunwayv1alpha1.BatchJobPhasePending || !strings.Contains(got.Status.Message, "llama not found") {
+		t.Fatalf("expected Pending on the missing deployment, got %+v", got.Status)
+	}
+
+	md := newModelDeployment("llama", "default")
+	md.Spec.Model.ServedName = "llama-3"
+	md.Status.Endpoint = &airunwayv1alpha1.EndpointStatus{Service: "llama", Port: 8000}
+	if err := r.Create(ctx, md); err != nil {
+		t.Fatal(err)
+	}
+	got = reconcileBatchJob(t, r)
+	if got.Status.Phase != airunwayv1alpha1.BatchJobPhaseRunning || got.Status.Deployment != "llama" {
+		t.Fatalf("expected Running on the referenced deployment, got %+v", got.Status)
+	}
+	var runner batchv1.Job
+	if err := r.Get(ctx, types.NamespacedName{Name: "score-batch", Namespace: "default"}, &runner); err != nil {
+		t.Fatal(err)
+	}
+	args := runner.Spec.Template.Spec.Containers[0].Args
+	if !slices.Contains(args, "llama-3") || !slices.Contains(args, "https://storage.example.com/out.jsonl?sig=abc") {
+		t.Errorf("expected the served name and output URL in the runner args, got %v", args)
+	}
+
+	// The runner fails: the error from the termination message is reported and the
+	// referenced deployment is kept
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "score-batch-abc", Namespace: "default", Labels: map[string]string{batchv1.JobNameLabel: "score-batch"}},
+		Status: corev1.PodStatus{Phase: corev1.PodFailed, ContainerStatuses: []corev1.ContainerStatus{{
+			Name:  batchRunnerContainer,
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Message: `{"total":0,"completed":0,"failed":0,"error":"uploading output: HTTP 403"}`}},
+		}}},
+	}
+	if err := r.Create(ctx, pod); err != nil {
+		t.Fatal(err)
+	}
+	runner.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: "BackoffLimitExceeded"}}
+	if err := r.Status().Update(ctx, &runner); err != nil {
+		t.Fatal(err)
+	}
+	got = reconcileBatchJob(t, r)
+	if got.Status.Phase != airunwayv1alpha1.BatchJobPhaseFailed || !strings.Contains(got.Status.Message, "HTTP 403") {
+		t.Errorf("expected Failed with the runner error, got %+v", got.Status)
+	}
+	if err := r.Get(ctx, types.NamespacedName{Name: "llama", Namespace: "default"}, md); err != nil {
+		t.Errorf("expected the referenced ModelDeployment to be kept: %v", err)
+	}
+}
+
+func TestModelBatchJobReconcile_Invalid(t *testing.T) {
+	job := newModelBatchJob()
+	job.Spec.DeploymentRef = "llama"
+	r := newBatchJobReconciler(nil, job)
+
+	got := reconcileBatchJob(t, r)
+	if got.Status.Phase != airunwayv1alpha1.BatchJobPhaseFailed || !strings.Contains(got.Status.Message, "exactly one of") {
+		t.Errorf("expected Failed for a job with two deployments, got %+v", got.Status)
+	}
+	if !meta.IsStatusConditionTrue(got.Status.Conditions, airunwayv1alpha1.ConditionTypeStalled) ||
+		!meta.IsStatusConditionFalse(got.Status.Conditions, airunwayv1alpha1.ConditionTypeReady) {
+		t.Errorf("expected a failed job to be Stalled and not Ready, got %+v", got.Status.Conditions)
+	}
+	var md airunwayv1alpha1.ModelDeployment
+	if err := r.Get(context.Background(), types.NamespacedName{Name: "score", Namespace: "default"}, &md); !apierrors.IsNotFound(err) {
+		t.Errorf("expected no ModelDeployment for an invalid job, got %v", err)
+	}
+}
@@ -20,16 +20,20 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
+	"sync"
+	"time"
 
-	"github.com/google/cel-go/cel"
 	"github.com/google/cel-go/common/types"
+	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
 	ctrlbuilder "sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -38,10 +42,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
-	inferencev1 "sigs.k8s.io/gateway-api-inference-extension/api/v1"
 
 	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
 	"github.com/kaito-project/airunway/controller/internal/gateway"
+	"github.com/kaito-project/airunway/controller/internal/recommender"
+	"github.com/kaito-project/airunway/controller/pkg/kstatus"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
 // ModelDeploymentReconciler reconciles a ModelDeployment object
@@ -52,29 +58,117 @@ type ModelDeploymentReconciler struct {
 	// EnableProviderSelector controls whether the controller runs provider selection
 	EnableProviderSelector bool
 
+	// SelectionStrategy is the provider selection strategy for namespaces without a
+	// SelectionPolicy. Empty selects the priority strategy.
+	SelectionStrategy string
+
 	// GatewayDetector checks for Gateway API CRD availability and resolves gateway config
 	GatewayDetector *gateway.Detector
 
 	// ProviderResolver looks up gateway capabilities from InferenceProviderConfig CRs.
 	// When nil, the reconciler treats all providers as having no gateway capabilities.
 	ProviderResolver gateway.ProviderCapabilityResolver
+
+	// Recorder emits events on ModelDeployments. When nil, no events are emitted.
+	Recorder events.EventRecorder
+
+	// GatewayProbeInterval is how often status.gateway.endpoint of a running deployment
+	// is probed for the GatewayReachable condition. Zero disables probing.
+	GatewayProbeInterval time.Duration
+
+	// TracingEndpoint is the OTLP collector endpoint of EPPs for deployments with
+	// spec.observability.tracing enabled and no endpoint of their own
+	TracingEndpoint string
+
+	// AdmissionPollInterval is how often a queued deployment checks its Workload for
+	// admission. Zero uses DefaultAdmissionPollInterval.
+	AdmissionPollInterval time.Duration
+
+	// ActivityPollInterval is how often request activity is sampled for
+	// spec.ttlSecondsAfterLastRequest. Zero uses DefaultActivityPollInterval.
+	ActivityPollInterval time.Duration
+
+	// MetricsSnapshotInterval is how often the engine metrics of Running deployments are
+	// summarized in status.metricsSnapshot. Zero disables snapshots.
+	MetricsSnapshotInterval time.Duration
+
+	// Namespaces limits the ModelDeployments the controller reconciles to these
+	// namespaces. When empty, all namespaces are reconciled.
+	Namespaces []string
+
+	// ControllerNamespace is the namespace the controller runs in. Its pods may reach model
+	// pods isolated by spec.networking.isolate, for model discovery and warmup requests.
+	ControllerNamespace string
+
+	// NetworkIsolationNamespaces are further namespaces whose pods may reach model pods
+	// isolated by spec.networking.isolate, such as the Gateway data plane or monitoring
+	NetworkIsolationNamespaces []string
+
+	// Sharding limits the ModelDeployments the controller reconciles to its shard.
+	// The zero value reconciles every ModelDeployment.
+	Sharding Sharding
+
+	// ProviderConfigs caches the InferenceProviderConfigs read by engine and provider
+	// selection. When nil, they are listed on each selection.
+	ProviderConfigs *ProviderConfigCache
+
+	// ActivitySource samples request activity for spec.ttlSecondsAfterLastRequest.
+	// When nil, the engine metrics of the model server pods are scraped.
+	ActivitySource ActivitySource
+
+	// PodActivitySource samples the activity of each pod for spec.scaling.podDeletionCost.
+	// When nil, the engine metrics of the model server pods are scraped.
+	PodActivitySource PodActivitySource
+
+	// GPUDevices looks up the GPU indices reported in status.placement. When nil, only
+	// the GPU count and type are reported.
+	GPUDevices recommender.GPUDeviceSource
+
+	// reloaded holds the settings of UpdateSettings, which replace the fields of Settings
+	reloaded reloadedSettings
+
+	// scrapes runs the HTTP requests to model servers and the gateway in the background
+	scrapes scraper
+
+	// warmups holds the in-flight spec.warmup run per ModelDeployment
+	warmups sync.Map
+
+	// activity holds the last request activity sample observed per ModelDeployment
+	activity sync.Map
+
+	// metricsSamples holds the engine metrics sample of the last snapshot per ModelDeployment
+	metricsSamples sync.Map
+
+	// fallbackSamples holds the last spec.gateway.fallback saturation check per ModelDeployment
+	fallbackSamples sync.Map
 }
 
 // +kubebuilder:rbac:groups=airunway.ai,resources=modeldeployments,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=airunway.ai,resources=modeldeployments/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=airunway.ai,resources=modeldeployments/finalizers,verbs=update
 // +kubebuilder:rbac:groups=airunway.ai,resources=inferenceproviderconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=airunway.ai,resources=selectionpolicies,verbs=get;list;watch
 // +kubebuilder:rbac:groups=inference.networking.k8s.io,resources=inferencepools,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=inference.networking.x-k8s.io,resources=inferencepools,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gateways,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gateways,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gatewayclasses,verbs=get;list;watch
 // +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=referencegrants,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=backendtlspolicies,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=services;serviceaccounts;configmaps,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses;networkpolicies,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings,verbs=create;get;list;watch;update;patch;delete
-// +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=create;get;update
-// +kubebuilder:rbac:groups=inference.networking.x-k8s.io,resources=inferenceobjectives;inferencemodelrewrites,verbs=get;list;watch
+// +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=inference.networking.x-k8s.io,resources=inferenceobjectives,verbs=get;list;watch
+// +kubebuilder:rbac:groups=inference.networking.x-k8s.io,resources=inferencemodelrewrites,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=networking.istio.io,resources=destinationrules,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.envoyproxy.io,resources=backendtrafficpolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.kgateway.dev,resources=trafficpolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloads,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile handles the reconciliation loop for ModelDeployment resources.
 //
@@ -82,13 +176,19 @@ type ModelDeploymentReconciler struct {
 // Instead, it:
 // 1. Validates the ModelDeployment spec
 // 2. Runs provider selection (if enabled and spec.provider.name is empty)
-// 3. Updates status conditions
+// 3. Holds the deployment in the Queued phase until Kueue admits it (if spec.scheduling.kueueAdmission)
+// 4. Updates status conditions
 //
 // Provider controllers (out-of-tree) watch for ModelDeployments where status.provider.name
 // matches their name and handle the actual resource creation.
 func (r *ModelDeploymentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
+	if !r.reconcilesNamespace(req.Namespace) {
+		return ctrl.Result{}, nil
+	}
+	settings := r.settings()
+
 	// Fetch the ModelDeployment
 	var md airunwayv1alpha1.ModelDeployment
 	if err := r.Get(ctx, req.NamespacedName, &md); err != nil {
@@ -96,9 +196,25 @@ func (r *ModelDeploymentReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 			// MD was deleted — check if the namespace should be removed from
 			// the Gateway's allowedRoutes.
 			r.cleanupGatewayAllowedRoutesForNamespace(ctx, req.Namespace)
+			r.forgetGatewayProbe(req.NamespacedName)
+			r.forgetWarmup(req.NamespacedName)
+			r.scrapes.forget(req.NamespacedName)
+			r.activity.Delete(req.NamespacedName)
+			r.metricsSamples.Delete(req.NamespacedName)
+			r.fallbackSamples.Delete(req.NamespacedName)
 		}
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
+	if !r.Sharding.Owns(&md) {
+		// Another shard owns the deployment, e.g. after its shard label changed
+		r.forgetGatewayProbe(req.NamespacedName)
+		r.forgetWarmup(req.NamespacedName)
+		r.scrapes.forget(req.NamespacedName)
+		r.activity.Delete(req.NamespacedName)
+		r.metricsSamples.Delete(req.NamespacedName)
+		r.fallbackSamples.Delete(req.NamespacedName)
+		return ctrl.Result{}, nil
+	}
 
 	// Save a deep copy as the patch base so we only send changed status fields.
 	// This avoids clobbering status fields set by out-of-tree provider controllers.
@@ -112,12 +228,28 @@ func (r *ModelDeploymentReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		if err := r.cleanupGatewayResources(ctx, &md); err != nil {
 			logger.Error(err, "Failed to clean up gateway resources on deletion")
 		}
+		r.forgetWarmup(req.NamespacedName)
+		r.scrapes.forget(req.NamespacedName)
 		return ctrl.Result{}, nil
 	}
 
-	// Check for pause annotation
-	if md.Annotations != nil && md.Annotations["airunway.ai/reconcile-paused"] == "true" {
+	// A paused deployment is frozen: no selection, status transitions, or gateway changes
+	// (including cleanup) until it is unpaused. Deletion above is still processed.
+	if md.IsPaused() {
 		logger.Info("Reconciliation paused", "name", md.Name)
+		r.setCondition(&md, airunwayv1alpha1.ConditionTypePausedReconciliation, metav1.ConditionTrue, "Paused", "Reconciliation is paused")
+		return ctrl.Result{}, r.Status().Patch(ctx, &md, client.MergeFrom(base))
+	}
+	if meta.IsStatusConditionTrue(md.Status.Conditions, airunwayv1alpha1.ConditionTypePausedReconciliation) {
+		r.setCondition(&md, airunwayv1alpha1.ConditionTypePausedReconciliation, metav1.ConditionFalse, "Resumed", "Reconciliation resumed")
+	}
+
+	// Delete ephemeral deployments whose TTL has elapsed
+	expired, ttlRequeue, err := r.reconcileTTL(ctx, &md)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if expired {
 		return ctrl.Result{}, nil
 	}
 
@@ -132,12 +264,15 @@ func (r *ModelDeploymentReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	}
 
 	// Step 1: Select engine if needed (before validation, since validation needs engine type)
-	if r.EnableProviderSelector {
-		if err := r.selectEngine(ctx, &md); err != nil {
+	if settings.EnableProviderSelector {
+		start := time.Now()
+		err := r.selectEngine(ctx, &md)
+		observeReconcileStep(reconcileStepEngineSelection, start)
+		if err != nil {
 			logger.Error(err, "Engine selection failed", "name", md.Name)
 			r.setCondition(&md, airunwayv1alpha1.ConditionTypeEngineSelected, metav1.ConditionFalse, "SelectionFailed", err.Error())
 			md.Status.Message = fmt.Sprintf("Engine selection failed: %s", err.Error())
-			return ctrl.Result{}, r.Status().Patch(ctx, &md, client.MergeFrom(base))
+			return ctrl.Result{RequeueAfter: ttlRequeue}, r.patchStatus(ctx, &md, base)
 		}
 	}
 
@@ -149,22 +284,68 @@ func (r *ModelDeploymentReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	}
 
 	// Step 4: Validate the spec (uses resolved engine type)
-	if err := r.validateSpec(ctx, &md); err != nil {
+	start := time.Now()
+	err = r.validateSpec(ctx, &md)
+	observeReconcileStep(reconcileStepValidation, start)
+	if err != nil {
 		logger.Error(err, "Validation failed", "name", md.Name)
 		r.setCondition(&md, airunwayv1alpha1.ConditionTypeValidated, metav1.ConditionFalse, "ValidationFailed", err.Error())
 		md.Status.Phase = airunwayv1alpha1.DeploymentPhaseFailed
 		md.Status.Message = fmt.Sprintf("Validation failed: %s", err.Error())
-		return ctrl.Result{}, r.Status().Patch(ctx, &md, client.MergeFrom(base))
+		return ctrl.Result{RequeueAfter: ttlRequeue}, r.patchStatus(ctx, &md, base)
 	}
 	r.setCondition(&md, airunwayv1alpha1.ConditionTypeValidated, metav1.ConditionTrue, "ValidationPassed", "Schema validation passed")
 
+	// Surface how the admission webhook resolved serving.mode auto
+	if reason := md.Annotations[airunwayv1alpha1.AnnotationServingModeReason]; reason != "" {
+		r.setCondition(&md, airunwayv1alpha1.ConditionTypeServingModeSelected, metav1.ConditionTrue, reason,
+			md.Annotations[airunwayv1alpha1.AnnotationServingModeMessage])
+	}
+
+	// Create the workload identity ServiceAccount before provider controllers start pods
+	if err := r.reconcileIdentity(ctx, &md); err != nil {
+		logger.Error(err, "Identity reconciliation failed", "name", md.Name)
+		md.Status.Message = fmt.Sprintf("Identity reconciliation failed: %s", err.Error())
+		if patchErr := r.patchStatus(ctx, &md, base); patchErr != nil {
+			return ctrl.Result{}, patchErr
+		}
+		return ctrl.Result{}, err
+	}
+
+	// Pick the GPU model from spec.resources.gpu.types in the same status update that hands
+	// the deployment to the provider, so its first resources already prefer that model
+	if err := r.reconcileGPUType(ctx, &md); err != nil {
+		logger.Error(err, "GPU type selection failed", "name", md.Name)
+	}
+
+	// Record the versions of referenced Secrets, so providers roll the pods when one changes
+	if err := r.reconcileSecretsChecksum(ctx, &md); err != nil {
+		logger.Error(err, "Secrets checksum failed", "name", md.Name)
+	}
+
+	// Explain provider selection when the deployment asks for it
+	if err := r.reconcileSelectionReport(ctx, &md); err != nil {
+		logger.Error(err, "Selection report failed", "name", md.Name)
+	}
+
+	// Move the deployment to spec.provider.migrateTo. This runs before provider selection
+	// since it changes the selected provider.
+	migrationRequeue, err := r.reconcileMigration(ctx, &md)
+	if err != nil {
+		logger.Error(err, "Provider migration failed", "name", md.Name)
+		migrationRequeue = migrationPollInterval
+	}
+
 	// Step 5: Run provider selection if needed
-	if r.EnableProviderSelector {
-		if err := r.selectProvider(ctx, &md); err != nil {
+	if settings.EnableProviderSelector {
+		start := time.Now()
+		err := r.selectProvider(ctx, &md)
+		observeReconcileStep(reconcileStepProviderSelection, start)
+		if err != nil {
 			logger.Error(err, "Provider selection failed", "name", md.Name)
 			r.setCondition(&md, airunwayv1alpha1.ConditionTypeProviderSelected, metav1.ConditionFalse, "SelectionFailed", err.Error())
 			md.Status.Message = fmt.Sprintf("Provider selection failed: %s", err.Error())
-			return ctrl.Result{}, r.Status().Patch(ctx, &md, client.MergeFrom(base))
+			return ctrl.Result{RequeueAfter: ttlRequeue}, r.patchStatus(ctx, &md, base)
 		}
 	}
 
@@ -178,13 +359,33 @@ func (r *ModelDeploymentReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 				SelectedReason: "explicit provider selection",
 			}
 			r.setCondition(&md, airunwayv1alpha1.ConditionTypeProviderSelected, metav1.ConditionTrue, "ExplicitSelection", "Provider explicitly specified in spec")
-		} else if !r.EnableProviderSelector {
+		} else if !settings.EnableProviderSelector {
 			// No provider specified and selector disabled
 			r.setCondition(&md, airunwayv1alpha1.ConditionTypeProviderSelected, metav1.ConditionFalse, "NoProvider", "No provider specified and provider-selector not enabled")
 			md.Status.Message = "No provider specified and provider-selector not enabled"
 		}
 	}
 
+	// Warn about spec fields the selected provider drops
+	if err := r.reconcileIgnoredFields(ctx, &md); err != nil {
+		logger.Error(err, "Ignored field check failed", "name", md.Name)
+	}
+
+	// Hold the handoff to the provider controller until Kueue admits the deployment
+	queued, err := r.reconcileAdmission(ctx, &md)
+	if err != nil {
+		logger.Error(err, "Kueue admission failed", "name", md.Name)
+		md.Status.Message = fmt.Sprintf("Kueue admission failed: %s", err.Error())
+		if patchErr := r.patchStatus(ctx, &md, base); patchErr != nil {
+			return ctrl.Result{}, patchErr
+		}
+		return ctrl.Result{}, err
+	}
+	if queued {
+		logger.Info("Waiting for Kueue admission", "name", md.Name)
+		return ctrl.Result{RequeueAfter: settings.AdmissionPollInterval}, r.patchStatus(ctx, &md, base)
+	}
+
 	// The core controller does NOT create provider resources.
 	// Provider controllers watch for ModelDeployments where status.provider.name matches
 	// their name and handle the actual resource creation.
@@ -198,8 +399,58 @@ func (r *ModelDeploymentReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	// - status.endpoint
 	// - ProviderCompatible, ResourceCreated, Ready conditions
 
-	// Step 7: Reconcile gateway resources (InferencePool + HTTPRoute) when deployment is running
-	if md.Status.Phase == airunwayv1alpha1.DeploymentPhaseRunning {
+	// Step 7: Fail deployments stuck in Deploying beyond spec.progressDeadlineSeconds
+	requeueAfter := r.checkProgressDeadline(&md)
+	if ttlRequeue > 0 && (requeueAfter == 0 || ttlRequeue < requeueAfter) {
+		requeueAfter = ttlRequeue
+	}
+	if migrationRequeue > 0 && (requeueAfter == 0 || migrationRequeue < requeueAfter) {
+		requeueAfter = migrationRequeue
+	}
+
+	// Engine counters restart with the pods: drop the samples of the background scraper
+	// while the deployment is not Running, so a new baseline is taken once it is again
+	if md.Status.Phase != airunwayv1alpha1.DeploymentPhaseRunning {
+		r.scrapes.forget(req.NamespacedName, scrapeActivity, scrapeMetricsSnapshot, scrapePods, scrapeGPUDevices, scrapeAdapters)
+	}
+
+	// Expose the model server without Gateway API when spec.expose is set
+	if next, err := r.reconcileExpose(ctx, &md); err != nil {
+		logger.Error(err, "Expose reconciliation failed", "name", md.Name)
+		r.setCondition(&md, airunwayv1alpha1.ConditionTypeExposed, metav1.ConditionFalse, "ExposeFailed", err.Error())
+	} else if next > 0 && (requeueAfter == 0 || next < requeueAfter) {
+		requeueAfter = next
+	}
+
+	// Report KV cache hit rates when spec.caching.kv is set
+	if next := r.reconcileKVCacheStatus(ctx, &md); next > 0 && (requeueAfter == 0 || next < requeueAfter) {
+		requeueAfter = next
+	}
+
+	// Load spec.model.adapters on the replicas without restarting the engine
+	if next := r.reconcileAdapters(ctx, &md); next > 0 && (requeueAfter == 0 || next < requeueAfter) {
+		requeueAfter = next
+	}
+
+	// Record the nodes and GPUs the model server pods run on in status.placement
+	if next := r.reconcilePlacement(ctx, &md); next > 0 && (requeueAfter == 0 || next < requeueAfter) {
+		requeueAfter = next
+	}
+
+	// Summarize request rate, latency and errors in status.metricsSnapshot
+	if next := r.reconcileMetricsSnapshot(ctx, &md); next > 0 && (requeueAfter == 0 || next < requeueAfter) {
+		requeueAfter = next
+	}
+
+	// Steer scale-down to the coldest replica when spec.scaling.podDeletionCost is set
+	if next := r.reconcilePodDeletionCost(ctx, &md); next > 0 && (requeueAfter == 0 || next < requeueAfter) {
+		requeueAfter = next
+	}
+
+	// Step 8: Reconcile gateway resources (InferencePool + HTTPRoute) when deployment is running,
+	// or keeps routing to the old provider while it migrates
+	if md.Status.Phase == airunwayv1alpha1.DeploymentPhaseRunning || migratingBeforeSwitch(&md) {
+		start := time.Now()
 		if md.Spec.Gateway != nil && md.Spec.Gateway.Enabled != nil && !*md.Spec.Gateway.Enabled {
 			// Gateway explicitly disabled — clean up any existing resources
 			if err := r.cleanupGatewayResources(ctx, &md); err != nil {
@@ -219,12 +470,86 @@ func (r *ModelDeploymentReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 				// Non-fatal: don't block overall reconciliation
 			}
 		}
+		if next := r.fallbackRequeue(&md); next > 0 && (requeueAfter == 0 || next < requeueAfter) {
+			requeueAfter = next
+		}
+		if next := r.probeGatewayEndpoint(ctx, &md); next > 0 && (requeueAfter == 0 || next < requeueAfter) {
+			requeueAfter = next
+		}
+		if next := r.reconcileWarmup(ctx, &md); next > 0 && (requeueAfter == 0 || next < requeueAfter) {
+			requeueAfter = next
+		}
+		observeReconcileStep(reconcileStepGateway, start)
+	} else {
+		r.forgetGatewayProbe(req.NamespacedName)
+		meta.RemoveStatusCondition(&md.Status.Conditions, airunwayv1alpha1.ConditionTypeGatewayReachable)
+		r.resetWarmup(req.NamespacedName, &md)
+		if md.Spec.Gateway == nil || md.Spec.Gateway.Enabled == nil || *md.Spec.Gateway.Enabled {
+			if err := r.reconcileInactiveGatewayFallback(ctx, &md); err != nil {
+				logger.Error(err, "Gateway fallback reconciliation failed", "name", md.Name)
+			}
+		}
+	}
+
+	// Restrict traffic to the model pods when spec.networking.isolate is set. This runs
+	// after the gateway step so the NetworkPolicy admits the resolved Gateway namespace.
+	if err := r.reconcileNetworkPolicy(ctx, &md); err != nil {
+		logger.Error(err, "NetworkPolicy reconciliation failed", "name", md.Name)
+		r.setCondition(&md, airunwayv1alpha1.ConditionTypeNetworkIsolated, metav1.ConditionFalse, "NetworkPolicyFailed", err.Error())
 	}
 	// Kubernetes garbage collection will handle cleanup when the ModelDeployment is deleted.
 
 	logger.Info("Reconciliation complete", "name", md.Name, "phase", md.Status.Phase, "provider", md.Status.Provider)
 
-	return ctrl.Result{}, r.Status().Patch(ctx, &md, client.MergeFrom(base))
+	return ctrl.Result{RequeueAfter: requeueAfter}, r.patchStatus(ctx, &md, base)
+}
+
+// checkProgressDeadline tracks time spent in the Deploying phase through the Progressing
+// condition and fails the deployment once spec.progressDeadlineSeconds is exceeded.
+// It returns how long to wait before the deadline should be checked again, or zero.
+func (r *ModelDeploymentReconciler) checkProgressDeadline(md *airunwayv1alpha1.ModelDeployment) time.Duration {
+	if md.Spec.ProgressDeadlineSeconds == nil {
+		return 0
+	}
+	cond := meta.FindStatusCondition(md.Status.Conditions, airunwayv1alpha1.ConditionTypeProgressing)
+
+	if md.Status.Phase == airunwayv1alpha1.DeploymentPhaseRunning {
+		r.setCondition(md, airunwayv1alpha1.ConditionTypeProgressing, metav1.ConditionTrue, airunwayv1alpha1.ReasonDeploymentAvailable, "Deployment is running")
+		return 0
+	}
+	if md.Status.Phase != airunwayv1alpha1.DeploymentPhaseDeploying {
+		return 0
+	}
+
+	if cond != nil && cond.Reason == airunwayv1alpha1.ReasonProgressDeadlineExceeded && cond.ObservedGeneration == md.Generation {
+		// The provider moved the phase back to Deploying; the deadline stays
+		// exceeded until the spec changes.
+		md.Status.Phase = airunwayv1alpha1.DeploymentPhaseFailed
+		md.Status.Message = cond.Message
+		return 0
+	}
+
+	// Start the clock when the deployment enters Deploying or its spec changes.
+	// The condition is removed first so LastTransitionTime is reset.
+	if cond == nil || cond.Reason != airunwayv1alpha1.ReasonDeploying || cond.ObservedGeneration != md.Generation {
+		meta.RemoveStatusCondition(&md.Status.Conditions, airunwayv1alpha1.ConditionTypeProgressing)
+		r.setCondition(md, airunwayv1alpha1.ConditionTypeProgressing, metav1.ConditionTrue, airunwayv1alpha1.ReasonDeploying, "Waiting for the deployment to become ready")
+		cond = meta.FindStatusCondition(md.Status.Conditions, airunwayv1alpha1.ConditionTypeProgressing)
+	}
+
+	deadline := time.Duration(*md.Spec.ProgressDeadlineSeconds) * time.Second
+	if elapsed := time.Since(cond.LastTransitionTime.Time); elapsed < deadline {
+		return deadline - elapsed
+	}
+
+	message := fmt.Sprintf("Deployment did not become ready within %ds", *md.Spec.ProgressDeadlineSeconds)
+	r.setCondition(md, airunwayv1alpha1.ConditionTypeProgressing, metav1.ConditionFalse, airunwayv1alpha1.ReasonProgressDeadlineExceeded, message)
+	md.Status.Phase = airunwayv1alpha1.DeploymentPhaseFailed
+	md.Status.Message = message
+	if r.Recorder != nil {
+		r.Recorder.Eventf(md, nil, corev1.EventTypeWarning, airunwayv1alpha1.ReasonProgressDeadlineExceeded, "Deploying", message)
+	}
+	return 0
 }
 
 // isNoMatchError checks if an error indicates that a CRD/resource type is not registered.
@@ -255,6 +580,11 @@ func (r *ModelDeploymentReconciler) validateSpec(ctx context.Context, md *airunw
 		return fmt.Errorf("engine.type must be specified or auto-selected from provider capabilities")
 	}
 
+	// serving.mode auto is resolved by the defaulting webhook and must not reach the controller
+	if spec.Serving != nil && spec.Serving.Mode == airunwayv1alpha1.ServingModeAuto {
+		return fmt.Errorf("serving.mode auto must be resolved by the admission webhook")
+	}
+
 	// Validate GPU requirements for certain engines
 	gpuCount := int32(0)
 	if spec.Resources != nil && spec.Resources.GPU != nil {
@@ -270,10 +600,32 @@ func (r *ModelDeploymentReconciler) validateSpec(ctx context.Context, md *airunw
 		}
 
 		if servingMode == airunwayv1alpha1.ServingModeAggregated && gpuCount == 0 {
+			// vllm has a CPU backend, used when engine.device is explicitly cpu
+			if engineType == airunwayv1alpha1.EngineTypeVLLM && spec.Engine.Device == airunwayv1alpha1.EngineDeviceCPU {
+				break
+			}
 			return fmt.Errorf("%s engine requires GPU (set resources.gpu.count > 0)", engineType)
 		}
 	}
 
+	if spec.Engine.Device == airunwayv1alpha1.EngineDeviceCPU && gpuCount > 0 {
+		return fmt.Errorf("engine.device cpu cannot be combined with resources.gpu.count > 0")
+	}
+
+	if spec.Identity != nil && spec.Identity.ServiceAccountName != "" && len(spec.Identity.Annotations) > 0 {
+		return fmt.Errorf("identity.annotations cannot be combined with identity.serviceAccountName")
+	}
+
+	// Multi-node replicas split the model across the GPUs of each node
+	if spec.Multinode != nil {
+		if spec.Serving != nil && spec.Serving.Mode == airunwayv1alpha1.ServingModeDisaggregated {
+			return fmt.Errorf("multinode is only supported in aggregated mode")
+		}
+		if gpuCount == 0 {
+			return fmt.Errorf("multinode requires resources.gpu.count > 0")
+		}
+	}
+
 	// Validate disaggregated mode configuration
 	if spec.Serving != nil && spec.Serving.Mode == airunwayv1alpha1.ServingModeDisaggregated {
 		// Cannot specify resources.gpu in disaggregated mode
@@ -319,18 +671,18 @@ func (r *ModelDeploymentReconciler) selectEngine(ctx context.Context, md *airunw
 		return nil
 	}
 
-	// List all InferenceProviderConfigs
-	var providerConfigs airunwayv1alpha1.InferenceProviderConfigList
-	if err := r.List(ctx, &providerConfigs); err != nil {
-		return fmt.Errorf("failed to list provider configs: %w", err)
+	providerConfigs, err := r.listProviderConfigs(ctx)
+	if err != nil {
+		return err
 	}
 
-	if len(providerConfigs.Items) == 0 {
+	if len(providerConfigs) == 0 {
 		return fmt.Errorf("no providers registered (InferenceProviderConfig resources not found)")
 	}
 
 	// Collect supported engines from ready providers, filtering by compatibility
-	// GPU-requiring engines cannot run on CPU-only deployments
+	// GPU-requiring engines only run on CPU-only deployments when engine.device
+	// is explicitly cpu and the provider supports their CPU backend
 	gpuRequiringEngines := map[airunwayv1alpha1.EngineType]bool{
 		airunwayv1alpha1.EngineTypeVLLM:   true,
 		airunwayv1alpha1.EngineTypeSGLang: true,
@@ -338,25 +690,28 @@ func (r *ModelDeploymentReconciler) selectEngine(ctx context.Context, md *airunw
 	}
 
 	// Determine deployment characteristics
-	hasGPU := false
-	if md.Spec.Resources != nil && md.Spec.Resources.GPU != nil && md.Spec.Resources.GPU.Count > 0 {
-		hasGPU = true
-	}
-	if md.Spec.Serving != nil && md.Spec.Serving.Mode == airunwayv1alpha1.ServingModeDisaggregated {
-		hasGPU = true
-	}
+	hasGPU := md.ResolvedDevice() == airunwayv1alpha1.EngineDeviceGPU
+	explicitCPU := md.Spec.Engine.Device == airunwayv1alpha1.EngineDeviceCPU
 
 	servingMode := airunwayv1alpha1.ServingModeAggregated
 	if md.Spec.Serving != nil && md.Spec.Serving.Mode != "" {
 		servingMode = md.Spec.Serving.Mode
 	}
 
+	namespaceLabels, err := r.providerNamespaceLabels(ctx, md, providerConfigs)
+	if err != nil {
+		return err
+	}
+
 	availableEngines := make(map[airunwayv1alpha1.EngineType]string) // engine -> provider name
 
-	for _, pc := range providerConfigs.Items {
+	for _, pc := range providerConfigs {
 		if !pc.Status.Ready || pc.Spec.Capabilities == nil {
 			continue
 		}
+		if served, _ := pc.Spec.ServesNamespace(namespaceLabels); !served {
+			continue
+		}
 
 		caps := pc.Spec.Capabilities
 
@@ -381,8 +736,8 @@ func (r *ModelDeploymentReconciler) selectEngine(ctx context.Context, md *airunw
 		}
 
 		for _, engine := range caps.Engines {
-			// Skip GPU-requiring engines for CPU-only deployments
-			if !hasGPU && gpuRequiringEngines[engine] {
+			// Skip engines the provider cannot run on CPU-only deployments
+			if !hasGPU && (!caps.SupportsCPUEngine(engine) || (gpuRequiringEngines[engine] && !explicitCPU)) {
 				continue
 			}
 			if _, exists := availableEngines[engine]; !exists {
@@ -439,19 +794,18 @@ func (r *ModelDeploymentReconciler) selectProvider(ctx context.Context, md *airu
 		return nil // Provider already selected
 	}
 
-	// List all InferenceProviderConfigs
-	var providerConfigs airunwayv1alpha1.InferenceProviderConfigList
-	if err := r.List(ctx, &providerConfigs); err != nil {
-		return fmt.Errorf("failed to list provider configs: %w", err)
+	providerConfigs, err := r.listProviderConfigs(ctx)
+	if err != nil {
+		return err
 	}
 
-	if len(providerConfigs.Items) == 0 {
+	if len(providerConfigs) == 0 {
 		return fmt.Errorf("no providers registered (InferenceProviderConfig resources not found)")
 	}
 
 	// Filter to ready providers
 	var readyProviders []airunwayv1alpha1.InferenceProviderConfig
-	for _, pc := range providerConfigs.Items {
+	for _, pc := range providerConfigs {
 		if pc.Status.Ready {
 			readyProviders = append(readyProviders, pc)
 		}
@@ -461,8 +815,18 @@ func (r *ModelDeploymentReconciler) selectProvider(ctx context.Context, md *airu
 		return fmt.Errorf("no healthy providers available")
 	}
 
+	namespaceLabels, err := r.providerNamespaceLabels(ctx, md, readyProviders)
+	if err != nil {
+		return err
+	}
+
+	strategy, err := r.resolveProviderSelection(ctx, md)
+	if err != nil {
+		return err
+	}
+
 	// Run selection algorithm
-	selectedProvider, reason, err := r.runSelectionAlgorithm(md, readyProviders)
+	selectedProvider, reason, err := r.runSelectionAlgorithm(md, readyProviders, namespaceLabels, strategy)
 	if err != nil {
 		return fmt.Errorf("provider selection failed: %w", err)
 	}
@@ -481,110 +845,70 @@ func (r *ModelDeploymentReconciler) selectProvider(ctx context.Context, md *airu
 	return nil
 }
 
-// runSelectionAlgorithm implements the provider selection algorithm
-func (r *ModelDeploymentReconciler) runSelectionAlgorithm(md *airunwayv1alpha1.ModelDeployment, providers []airunwayv1alpha1.InferenceProviderConfig) (string, string, error) {
-	spec := &md.Spec
-	engineType := md.ResolvedEngineType()
+// providerNamespaceLabels returns the labels of the namespace of md, for the namespace
+// selectors of providers. The namespace is only read when a provider has a selector.
+func (r *ModelDeploymentReconciler) providerNamespaceLabels(ctx context.Context, md *airunwayv1alpha1.ModelDeployment, providers []airunwayv1alpha1.InferenceProviderConfig) (map[string]string, error) {
+	if !slices.ContainsFunc(providers, func(pc airunwayv1alpha1.InferenceProviderConfig) bool {
+		return pc.Spec.NamespaceSelector != nil
+	}) {
+		return nil, nil
+	}
+	var namespace corev1.Namespace
+	if err := r.Get(ctx, client.ObjectKey{Name: md.Namespace}, &namespace); err != nil {
+		return nil, fmt.Errorf("failed to get namespace %s: %w", md.Namespace, err)
+	}
+	return namespace.Labels, nil
+}
 
-	// Determine GPU requirements
-	hasGPU := false
-	if spec.Resources != nil && spec.Resources.GPU != nil && spec.Resources.GPU.Count > 0 {
-		hasGPU = true
+// listProviderConfigs returns the InferenceProviderConfigs from r.ProviderConfigs, or
+// lists them when there is no cache. The configs must not be modified.
+func (r *ModelDeploymentReconciler) listProviderConfigs(ctx context.Context) ([]airunwayv1alpha1.InferenceProviderConfig, error) {
+	if r.ProviderConfigs != nil {
+		configs, err := r.ProviderConfigs.List(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list provider configs: %w", err)
+		}
+		return configs, nil
 	}
-	if spec.Serving != nil && spec.Serving.Mode == airunwayv1alpha1.ServingModeDisaggregated {
-		hasGPU = true
+	var providerConfigs airunwayv1alpha1.InferenceProviderConfigList
+	if err := r.List(ctx, &providerConfigs); err != nil {
+		return nil, fmt.Errorf("failed to list provider configs: %w", err)
 	}
+	return providerConfigs.Items, nil
+}
+
+// runSelectionAlgorithm implements the provider selection algorithm: providers that pass
+// every selection criterion are eligible, and strategy picks one of them
+func (r *ModelDeploymentReconciler) runSelectionAlgorithm(md *airunwayv1alpha1.ModelDeployment, providers []airunwayv1alpha1.InferenceProviderConfig, namespaceLabels map[string]string, strategy *providerSelection) (string, string, error) {
+	engineType := md.ResolvedEngineType()
+	hasGPU := md.ResolvedDevice() == airunwayv1alpha1.EngineDeviceGPU
+	servingMode := resolvedServingMode(&md.Spec)
 
 	// Convert spec to map for CEL evaluation
-	specMap, err := specToMap(spec)
+	specMap, err := specToMap(&md.Spec)
 	if err != nil {
 		return "", "", fmt.Errorf("failed to convert spec for CEL evaluation: %w", err)
 	}
 
-	// Build candidate list with scores
-	type candidate struct {
-		name     string
-		reason   string
-		priority int32
-	}
-	var candidates []candidate
-
-	for _, pc := range providers {
-		caps := pc.Spec.Capabilities
-		if caps == nil {
-			continue
-		}
-
-		// Check engine support
-		engineSupported := false
-		for _, e := range caps.Engines {
-			if e == engineType {
-				engineSupported = true
-				break
-			}
-		}
-		if !engineSupported {
-			continue
-		}
-
-		// Check GPU/CPU support
-		if hasGPU && !caps.GPUSupport {
-			continue
-		}
-		if !hasGPU && !caps.CPUSupport {
-			continue
-		}
-
-		// Check serving mode support
-		servingMode := airunwayv1alpha1.ServingModeAggregated
-		if spec.Serving != nil && spec.Serving.Mode != "" {
-			servingMode = spec.Serving.Mode
+	var eligible []airunwayv1alpha1.ProviderEvaluation
+	for i := range providers {
+		if eval := evaluateProvider(&providers[i], engineType, hasGPU, servingMode, namespaceLabels, specMap); eval.Eligible {
+			eligible = append(eligible, eval)
 		}
-		servingModeSupported := false
-		for _, sm := range caps.ServingModes {
-			if sm == servingMode {
-				servingModeSupported = true
-				break
-			}
-		}
-		if !servingModeSupported {
-			continue
-		}
-
-		// This provider is compatible
-		// Evaluate CEL selection rules to calculate priority
-		priority := int32(0)
-		for _, rule := range pc.Spec.SelectionRules {
-			matched, err := evaluateCEL(rule.Condition, specMap)
-			if err != nil {
-				continue // skip rules that fail to evaluate
-			}
-			if matched && rule.Priority > priority {
-				priority = rule.Priority
-			}
-		}
-
-		reason := fmt.Sprintf("matched capabilities: engine=%s, gpu=%v, mode=%s", engineType, hasGPU, servingMode)
-		candidates = append(candidates, candidate{
-			name:     pc.Name,
-			reason:   reason,
-			priority: priority,
-		})
 	}
-
-	if len(candidates) == 0 {
+	if len(eligible) == 0 {
 		return "", "", nil
 	}
+	slices.SortFunc(eligible, func(a, b airunwayv1alpha1.ProviderEvaluation) int {
+		return strings.Compare(a.Name, b.Name)
+	})
 
-	// Select highest priority candidate; use name as stable tiebreaker
-	best := candidates[0]
-	for _, c := range candidates[1:] {
-		if c.priority > best.priority || (c.priority == best.priority && c.name < best.name) {
-			best = c
-		}
+	name, strategyReason, err := strategy.selectProvider(md, eligible)
+	if err != nil {
+		return "", "", err
 	}
-
-	return best.name, best.reason, nil
+	reason := fmt.Sprintf("matched capabilities: engine=%s, gpu=%v, mode=%s; %s", engineType, hasGPU, servingMode, strategyReason)
+	return name, reason, nil
 }
 
 // setCondition updates a condition on the ModelDeployment
@@ -600,6 +924,37 @@ func (r *ModelDeploymentReconciler) setCondition(md *airunwayv1alpha1.ModelDeplo
 	meta.SetStatusCondition(&md.Status.Conditions, condition)
 }
 
+// patchStatus sets the Reconciling and Stalled conditions from the phase of md, and a Ready
+// condition until a provider controller reports one, then patches the status against base.
+// A deployment is only current once its provider reported Ready for the current generation,
+// so kstatus-based tools such as Flux and Argo CD do not report a spec change as healthy
+// before the provider rolled it out.
+func (r *ModelDeploymentReconciler) patchStatus(ctx context.Context, md *airunwayv1alpha1.ModelDeployment, base *airunwayv1alpha1.ModelDeployment) error {
+	defer observeReconcileStep(reconcileStepStatus, time.Now())
+	state, reason := deploymentHealth(md)
+	ready := meta.FindStatusCondition(md.Status.Conditions, airunwayv1alpha1.ConditionTypeReady)
+	if ready == nil || (state == kstatus.Failed && ready.Status == metav1.ConditionTrue) {
+		kstatus.SetReady(&md.Status.Conditions, md.Generation, state, reason, md.Status.Message)
+	} else {
+		kstatus.SetProgress(&md.Status.Conditions, md.Generation, state, reason, md.Status.Message)
+	}
+	return r.Status().Patch(ctx, md, client.MergeFrom(base))
+}
+
+// deploymentHealth returns the kstatus state of md and the reason to report it with
+func deploymentHealth(md *airunwayv1alpha1.ModelDeployment) (kstatus.State, string) {
+	switch {
+	case md.Status.Phase == airunwayv1alpha1.DeploymentPhaseFailed:
+		return kstatus.Failed, string(md.Status.Phase)
+	case md.Status.Phase == airunwayv1alpha1.DeploymentPhaseRunning && kstatus.ReadyForGeneration(md.Status.Conditions, md.Generation):
+		return kstatus.Current, string(md.Status.Phase)
+	case md.Status.Phase == airunwayv1alpha1.DeploymentPhaseRunning, md.Status.Phase == "":
+		return kstatus.InProgress, airunwayv1alpha1.ReasonDeploying
+	default:
+		return kstatus.InProgress, string(md.Status.Phase)
+	}
+}
+
 func providerConfigChangePredicate() predicate.Predicate {
 	return predicate.Funcs{
 		CreateFunc: func(event.CreateEvent) bool {
@@ -615,6 +970,7 @@ func providerConfigChangePredicate() predicate.Predicate {
 				return false
 			}
 			return oldConfig.Status.Ready != newConfig.Status.Ready ||
+				!slices.Equal(oldConfig.Status.SupportedFields, newConfig.Status.SupportedFields) ||
 				!apiequality.Semantic.DeepEqual(oldConfig.Spec, newConfig.Spec)
 		},
 		GenericFunc: func(event.GenericEvent) bool {
@@ -637,6 +993,13 @@ func providerConfigAffectsModelDeployment(md *airunwayv1alpha1.ModelDeployment,
 	if md.Status.Provider != nil && md.Status.Provider.Name == providerName {
 		return true
 	}
+	if md.Spec.Provider != nil && md.Spec.Provider.MigrateTo == providerName {
+		return true
+	}
+	// Selection reports cover every provider
+	if md.Annotations[airunwayv1alpha1.AnnotationSelectionExplain] == "true" {
+		return true
+	}
 	return modelDeploymentNeedsProviderSelection(md)
 }
 
@@ -674,23 +1037,32 @@ func (r *ModelDeploymentReconciler) mapProviderConfigToModelDeployments(ctx cont
 // SetupWithManager sets up the controller with the Manager.
 func (r *ModelDeploymentReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	builder := ctrl.NewControllerManagedBy(mgr).
-		For(&airunwayv1alpha1.ModelDeployment{}).
+		For(&airunwayv1alpha1.ModelDeployment{}, ctrlbuilder.WithPredicates(r.Sharding.Predicate())).
 		Watches(
 			&airunwayv1alpha1.InferenceProviderConfig{},
 			handler.EnqueueRequestsFromMapFunc(r.mapProviderConfigToModelDeployments),
 			ctrlbuilder.WithPredicates(providerConfigChangePredicate()),
 		).
+		// Secrets are watched by metadata only, for spec.secrets.rolloutOnChange
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.mapSecretToModelDeployments), ctrlbuilder.OnlyMetadata).
+		// Reconcile a deployment when a background request to its model server completed
+		WatchesRawSource(r.scrapes.source()).
 		Named("modeldeployment")
 
 	// Watch InferencePool so the controller reconciles when one is created/deleted.
-	// HTTPRoutes are not watched — they may be user-managed (BYO) and we don't
-	// want deletion of an HTTPRoute to trigger a reconcile that recreates it.
-	// Only add this watch if the gateway CRDs are actually installed.
+	// HTTPRoutes are only watched when referenced by spec.gateway.httpRouteRef, so the
+	// GatewayReady condition follows the user-provided route while deleting a generated
+	// route does not trigger a reconcile that recreates it.
+	// Only add these watches if the gateway CRDs are actually installed.
 	if r.GatewayDetector != nil && r.GatewayDetector.IsAvailable(context.Background()) {
 		builder = builder.
-			Owns(&inferencev1.InferencePool{})
+			Owns(r.newInferencePool("", "")).
+			Watches(&gatewayv1.HTTPRoute{}, handler.EnqueueRequestsFromMapFunc(r.mapHTTPRouteToModelDeployments))
 	}
 
+	if err := mgr.Add(&r.scrapes); err != nil {
+		return err
+	}
 	return builder.Complete(r)
 }
 
@@ -707,23 +1079,12 @@ func specToMap(spec *airunwayv1alpha1.ModelDeploymentSpec) (map[string]any, erro
 	return m, nil
 }
 
-// evaluateCEL evaluates a CEL expression against the spec map
+// evaluateCEL evaluates a CEL expression against the spec map. Compiled expressions are
+// kept in celPrograms.
 func evaluateCEL(expression string, specMap map[string]any) (bool, error) {
-	env, err := cel.NewEnv(
-		cel.Variable("spec", cel.DynType),
-	)
-	if err != nil {
-		return false, fmt.Errorf("failed to create CEL environment: %w", err)
-	}
-
-	ast, issues := env.Compile(expression)
-	if issues != nil && issues.Err() != nil {
-		return false, fmt.Errorf("failed to compile CEL expression %q: %w", expression, issues.Err())
-	}
-
-	prg, err := env.Program(ast)
+	prg, err := celPrograms.program(expression)
 	if err != nil {
-		return false, fmt.Errorf("failed to create CEL program: %w", err)
+		return false, err
 	}
 
 	out, _, err := prg.Eval(map[string]any{
@@ -0,0 +1,107 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"maps"
+
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlbuilder "sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+// ModelDeploymentQuotaReconciler records the GPU usage of the ModelDeployments in a
+// namespace in the status of its ModelDeploymentQuotas. Limits are enforced by the
+// admission webhook.
+type ModelDeploymentQuotaReconciler struct {
+	client.Client
+}
+
+// +kubebuilder:rbac:groups=airunway.ai,resources=modeldeploymentquotas,verbs=get;list;watch
+// +kubebuilder:rbac:groups=airunway.ai,resources=modeldeploymentquotas/status,verbs=get;update;patch
+
+// Reconcile recomputes status.used for a ModelDeploymentQuota.
+func (r *ModelDeploymentQuotaReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var quota airunwayv1alpha1.ModelDeploymentQuota
+	if err := r.Get(ctx, req.NamespacedName, &quota); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	var deployments airunwayv1alpha1.ModelDeploymentList
+	if err := r.List(ctx, &deployments, client.InNamespace(quota.Namespace)); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	used := make(map[string]int32, len(quota.Spec.Limits))
+	for class := range quota.Spec.Limits {
+		used[class] = 0
+	}
+	for i := range deployments.Items {
+		for class, count := range deployments.Items[i].GPUUsage() {
+			if _, ok := used[class]; ok {
+				used[class] += count
+			}
+		}
+	}
+
+	if maps.Equal(used, quota.Status.Used) && quota.Status.ObservedGeneration == quota.Generation {
+		return ctrl.Result{}, nil
+	}
+	base := quota.DeepCopy()
+	quota.Status.Used = used
+	quota.Status.ObservedGeneration = quota.Generation
+	log.FromContext(ctx).V(1).Info("Updating quota usage", "name", quota.Name, "used", used)
+	return ctrl.Result{}, r.Status().Patch(ctx, &quota, client.MergeFrom(base))
+}
+
+// quotasForModelDeployment enqueues every ModelDeploymentQuota in the namespace of a
+// changed ModelDeployment.
+func (r *ModelDeploymentQuotaReconciler) quotasForModelDeployment(ctx context.Context, obj client.Object) []reconcile.Request {
+	var quotas airunwayv1alpha1.ModelDeploymentQuotaList
+	if err := r.List(ctx, &quotas, client.InNamespace(obj.GetNamespace())); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list ModelDeploymentQuotas", "namespace", obj.GetNamespace())
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(quotas.Items))
+	for _, quota := range quotas.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: k8stypes.NamespacedName{Name: quota.Name, Namespace: quota.Namespace},
+		})
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager. ModelDeployment status
+// updates do not change GPU usage, so only spec changes and deletions trigger a recount.
+func (r *ModelDeploymentQuotaReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&airunwayv1alpha1.ModelDeploymentQuota{},
+			ctrlbuilder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&airunwayv1alpha1.ModelDeployment{},
+			handler.EnqueueRequestsFromMapFunc(r.quotasForModelDeployment),
+			ctrlbuilder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Named("modeldeploymentquota").
+		Complete(r)
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+func TestModelDeploymentQuotaReconcile(t *testing.T) {
+	scheme := newTestScheme()
+	quota := &airunwayv1alpha1.ModelDeploymentQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-gpus", Namespace: "default", Generation: 1},
+		Spec: airunwayv1alpha1.ModelDeploymentQuotaSpec{
+			Limits: map[string]int32{"nvidia-h100": 8, "nvidia-a100": 4},
+		},
+	}
+
+	h100 := newModelDeployment("h100", "default")
+	h100.Spec.Scaling = &airunwayv1alpha1.ScalingSpec{Replicas: 2}
+	h100.Spec.Resources = &airunwayv1alpha1.ResourceSpec{GPU: &airunwayv1alpha1.GPUSpec{Count: 2, Class: "nvidia-h100"}}
+
+	disagg := newModelDeployment("disagg", "default")
+	disagg.Spec.Serving = &airunwayv1alpha1.ServingSpec{Mode: airunwayv1alpha1.ServingModeDisaggregated}
+	disagg.Spec.Scaling = &airunwayv1alpha1.ScalingSpec{
+		Prefill: &airunwayv1alpha1.ComponentScalingSpec{Replicas: 1, GPU: &airunwayv1alpha1.GPUSpec{Count: 1, Class: "nvidia-h100"}},
+		Decode:  &airunwayv1alpha1.ComponentScalingSpec{Replicas: 1, GPU: &airunwayv1alpha1.GPUSpec{Count: 2, Class: "nvidia-h100"}},
+	}
+
+	unclassed := newModelDeployment("unclassed", "default")
+	unclassed.Spec.Resources = &airunwayv1alpha1.ResourceSpec{GPU: &airunwayv1alpha1.GPUSpec{Count: 1}}
+
+	otherNamespace := newModelDeployment("elsewhere", "other")
+	otherNamespace.Spec.Resources = &airunwayv1alpha1.ResourceSpec{GPU: &airunwayv1alpha1.GPUSpec{Count: 8, Class: "nvidia-h100"}}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(quota, h100, disagg, unclassed, otherNamespace).
+		WithStatusSubresource(&airunwayv1alpha1.ModelDeploymentQuota{}).
+		Build()
+	r := &ModelDeploymentQuotaReconciler{Client: c}
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "team-gpus", Namespace: "default"}
+
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+
+	var updated airunwayv1alpha1.ModelDeploymentQuota
+	if err := c.Get(ctx, key, &updated); err != nil {
+		t.Fatalf("failed to get quota: %v", err)
+	}
+	if updated.Status.Used["nvidia-h100"] != 7 {
+		t.Errorf("expected 7 nvidia-h100 GPUs used, got %v", updated.Status.Used)
+	}
+	if used, ok := updated.Status.Used["nvidia-a100"]; !ok || used != 0 {
+		t.Errorf("expected limited classes without usage to report 0, got %v", updated.Status.Used)
+	}
+	if _, ok := updated.Status.Used["nvidia.com/gpu"]; ok {
+		t.Errorf("expected classes without a limit to be omitted, got %v", updated.Status.Used)
+	}
+	if updated.Status.ObservedGeneration != 1 {
+		t.Errorf("expected observedGeneration 1, got %d", updated.Status.ObservedGeneration)
+	}
+
+	if reqs := r.quotasForModelDeployment(ctx, h100); len(reqs) != 1 || reqs[0].NamespacedName != key {
+		t.Errorf("expected ModelDeployment changes to enqueue the namespace quota, got %v", reqs)
+	}
+	if reqs := r.quotasForModelDeployment(ctx, otherNamespace); len(reqs) != 0 {
+		t.Errorf("expected no quotas for another namespace, got %v", reqs)
+	}
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/internal/remediation"
+	"github.com/kaito-project/airunway/controller/pkg/kstatus"
+)
+
+// ModelFleetReconciler creates a ModelDeployment for each item of a ModelFleet, deletes
+// those of removed items and aggregates their phases in the status of the fleet.
+type ModelFleetReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=airunway.ai,resources=modelfleets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=airunway.ai,resources=modelfleets/status,verbs=get;update;patch
+
+// Reconcile brings the ModelDeployments of a ModelFleet in line with its items.
+func (r *ModelFleetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	var fleet airunwayv1alpha1.ModelFleet
+	if err := r.Get(ctx, req.NamespacedName, &fleet); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !fleet.DeletionTimestamp.IsZero() {
+		// The ModelDeployments are garbage collected through their owner reference
+		return ctrl.Result{}, nil
+	}
+
+	status := airunwayv1alpha1.ModelFleetStatus{
+		Total:              int32(len(fleet.Spec.Items)),
+		Conditions:         fleet.Status.Conditions,
+		ObservedGeneration: fleet.Generation,
+	}
+	wanted := make(map[string]bool, len(fleet.Spec.Items))
+	for i := range fleet.Spec.Items {
+		item := &fleet.Spec.Items[i]
+		itemStatus := airunwayv1alpha1.ModelFleetItemStatus{Name: item.Name, Deployment: fleet.DeploymentName(item)}
+		wanted[itemStatus.Deployment] = true
+
+		md, err := r.reconcileItem(ctx, &fleet, item)
+		if err != nil {
+			logger.Error(err, "Failed to reconcile fleet item", "item", item.Name)
+			itemStatus.Phase = airunwayv1alpha1.DeploymentPhaseFailed
+			itemStatus.Message = err.Error()
+		} else {
+			itemStatus.Phase = md.Status.Phase
+			itemStatus.Message = md.Status.Message
+		}
+		switch itemStatus.Phase {
+		case airunwayv1alpha1.DeploymentPhaseRunning:
+			status.Ready++
+		case airunwayv1alpha1.DeploymentPhaseFailed:
+			status.Failed++
+		}
+		status.Items = append(status.Items, itemStatus)
+	}
+
+	if err := r.pruneDeployments(ctx, &fleet, wanted); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	state, reason := kstatus.InProgress, airunwayv1alpha1.FleetReasonNotReady
+	message := fmt.Sprintf("%d of %d ModelDeployments are running", status.Ready, status.Total)
+	switch {
+	case status.Failed > 0:
+		state, reason = kstatus.Failed, airunwayv1alpha1.FleetReasonFailed
+		message = fmt.Sprintf("%d of %d ModelDeployments failed", status.Failed, status.Total)
+	case status.Ready == status.Total:
+		state, reason = kstatus.Current, airunwayv1alpha1.FleetReasonAllReady
+	}
+	status.Conditions = append([]metav1.Condition(nil), status.Conditions...)
+	kstatus.SetReady(&status.Conditions, fleet.Generation, state, reason, message)
+
+	if equality.Semantic.DeepEqual(status, fleet.Status) {
+		return ctrl.Result{}, nil
+	}
+	base := fleet.DeepCopy()
+	fleet.Status = status
+	return ctrl.Result{}, r.Status().Patch(ctx, &fleet, client.MergeFrom(base))
+}
+
+// reconcileItem creates or updates the ModelDeployment of a fleet item
+func (r *ModelFleetReconciler) reconcileItem(ctx context.Context, fleet *airunwayv1alpha1.ModelFleet, item *airunwayv1alpha1.ModelFleetItem) (*airunwayv1alpha1.ModelDeployment, error) {
+	md := &airunwayv1alpha1.ModelDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: fleet.DeploymentName(item), Namespace: fleet.Namespace},
+	}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(md), md); client.IgnoreNotFound(err) != nil {
+		return nil, err
+	}
+	if md.UID != "" && !metav1.IsControlledBy(md, fleet) {
+		return nil, fmt.Errorf("ModelDeployment %s exists and is not owned by the fleet", md.Name)
+	}
+
+	if _, err := ctrl.CreateOrUpdate(ctx, r.Client, md, func() error {
+		// The admission webhook fills in fields the template leaves unset, such as the
+		// resolved model revision, so those are kept rather than cleared on every update
+		spec := fleet.DeploymentSpec(item)
+		if md.ResourceVersion != "" {
+			if spec.Model.Revision == "" {
+				spec.Model.Revision = md.Spec.Model.Revision
+			}
+			keepManagedFields(&spec, md)
+		}
+		md.Spec = spec
+
+		if md.Labels == nil {
+			md.Labels = map[string]string{}
+		}
+		for k, v := range item.Labels {
+			md.Labels[k] = v
+		}
+		md.Labels[airunwayv1alpha1.LabelFleet] = fleet.Name
+		md.Labels[airunwayv1alpha1.LabelFleetItem] = item.Name
+		return ctrl.SetControllerReference(fleet, md, r.Scheme)
+	}); err != nil {
+		return nil, fmt.Errorf("failed to create/update ModelDeployment %s: %w", md.Name, err)
+	}
+	return md, nil
+}
+
+// keepManagedFields keeps the settings other controllers change on a generated
+// ModelDeployment, so the fleet does not revert them on every update: the cpu and memory
+// applied by autotune, and the engine settings changed by crash-loop remediation
+func keepManagedFields(spec *airunwayv1alpha1.ModelDeploymentSpec, md *airunwayv1alpha1.ModelDeployment) {
+	if spec.Resources != nil && spec.Resources.Autotune && md.Spec.Resources != nil && md.Spec.Resources.Autotune {
+		if md.Spec.Resources.CPU != "" {
+			spec.Resources.CPU = md.Spec.Resources.CPU
+		}
+		if md.Spec.Resources.Memory != "" {
+			spec.Resources.Memory = md.Spec.Resources.Memory
+		}
+	}
+	if md.Status.Remediation != nil {
+		remediation.KeepApplied(spec, &md.Spec, md.Status.Remediation.Attempts)
+	}
+}
+
+// pruneDeployments deletes the ModelDeployments of the fleet whose item was removed
+func (r *ModelFleetReconciler) pruneDeployments(ctx context.Context, fleet *airunwayv1alpha1.ModelFleet, wanted map[string]bool) error {
+	var deployments airunwayv1alpha1.ModelDeploymentList
+	if err := r.List(ctx, &deployments, client.InNamespace(fleet.Namespace),
+		client.MatchingLabels{airunwayv1alpha1.LabelFleet: fleet.Name}); err != nil {
+		return fmt.Errorf("failed to list fleet ModelDeployments: %w", err)
+	}
+	for i := range deployments.Items {
+		md := &deployments.Items[i]
+		if wanted[md.Name] || !metav1.IsControlledBy(md, fleet) {
+			continue
+		}
+		log.FromContext(ctx).Info("Deleting ModelDeployment of removed fleet item", "modelDeployment", md.Name)
+		if err := r.Delete(ctx, md); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete ModelDeployment %s: %w", md.Name, err)
+		}
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager. Owned ModelDeployments are
+// watched so phase changes are reflected in the status of the fleet.
+func (r *ModelFleetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&airunwayv1alpha1.ModelFleet{}).
+		Owns(&airunwayv1alpha1.ModelDeployment{}).
+		Named("modelfleet").
+		Complete(r)
+}
@@ -0,0 +1,277 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+func newModelFleet() *airunwayv1alpha1.ModelFleet {
+	replicas := int32(3)
+	return &airunwayv1alpha1.ModelFleet{
+		ObjectMeta: metav1.ObjectMeta{Name: "small", Namespace: "default", UID: "fleet-uid", Generation: 2},
+		Spec: airunwayv1alpha1.ModelFleetSpec{
+			Template: airunwayv1alpha1.ModelDeploymentSpec{
+				Model:     airunwayv1alpha1.ModelSpec{Source: airunwayv1alpha1.ModelSourceHuggingFace, License: "apache-2.0"},
+				Engine:    airunwayv1alpha1.EngineSpec{Type: airunwayv1alpha1.EngineTypeVLLM},
+				Resources: &airunwayv1alpha1.ResourceSpec{GPU: &airunwayv1alpha1.GPUSpec{Count: 1}},
+			},
+			Items: []airunwayv1alpha1.ModelFleetItem{
+				{Name: "qwen", Model: &airunwayv1alpha1.ModelSpec{ID: "Qwen/Qwen3-0.6B"}, Labels: map[string]string{"team": "search"}},
+				{
+					Name:      "phi",
+					Model:     &airunwayv1alpha1.ModelSpec{ID: "microsoft/Phi-4-mini-instruct", License: "mit"},
+					Resources: &airunwayv1alpha1.ResourceSpec{GPU: &airunwayv1alpha1.GPUSpec{Count: 2}},
+					Replicas:  &replicas,
+				},
+			},
+		},
+	}
+}
+
+func TestModelFleetReconcile(t *testing.T) {
+	scheme := newTestScheme()
+	fleet := newModelFleet()
+
+	// ModelDeployment of an item removed from the fleet
+	removed := newModelDeployment("small-llama", "default")
+	removed.Labels = map[string]string{airunwayv1alpha1.LabelFleet: "small"}
+	if err := controllerutil.SetControllerReference(fleet, removed, scheme); err != nil {
+		t.Fatal(err)
+	}
+	// Labeled by hand, but not owned by the fleet
+	unowned := newModelDeployment("small-manual", "default")
+	unowned.Labels = map[string]string{airunwayv1alpha1.LabelFleet: "small"}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(fleet, removed, unowned).
+		WithStatusSubresource(&airunwayv1alpha1.ModelFleet{}, &airunwayv1alpha1.ModelDeployment{}).
+		Build()
+	r := &ModelFleetReconciler{Client: c, Scheme: scheme}
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "small", Namespace: "default"}
+
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+
+	var qwen airunwayv1alpha1.ModelDeployment
+	if err := c.Get(ctx, types.NamespacedName{Name: "small-qwen", Namespace: "default"}, &qwen); err != nil {
+		t.Fatalf("expected ModelDeployment small-qwen: %v", err)
+	}
+	if qwen.Spec.Model.ID != "Qwen/Qwen3-0.6B" || qwen.Spec.Model.License != "apache-2.0" {
+		t.Errorf("expected the model override merged into the template, got %+v", qwen.Spec.Model)
+	}
+	if qwen.Spec.Engine.Type != airunwayv1alpha1.EngineTypeVLLM || qwen.Spec.Resources.GPU.Count != 1 {
+		t.Errorf("expected template engine and resources, got %+v", qwen.Spec)
+	}
+	if qwen.Labels[airunwayv1alpha1.LabelFleet] != "small" || qwen.Labels[airunwayv1alpha1.LabelFleetItem] != "qwen" || qwen.Labels["team"] != "search" {
+		t.Errorf("unexpected labels %v", qwen.Labels)
+	}
+	if !metav1.IsControlledBy(&qwen, fleet) {
+		t.Error("expected the fleet to own small-qwen")
+	}
+
+	var phi airunwayv1alpha1.ModelDeployment
+	if err := c.Get(ctx, types.NamespacedName{Name: "small-phi", Namespace: "default"}, &phi); err != nil {
+		t.Fatalf("expected ModelDeployment small-phi: %v", err)
+	}
+	if phi.Spec.Model.License != "mit" || phi.Spec.Resources.GPU.Count != 2 || phi.Spec.Scaling == nil || phi.Spec.Scaling.Replicas != 3 {
+		t.Errorf("expected the item overrides, got %+v", phi.Spec)
+	}
+
+	if err := c.Get(ctx, types.NamespacedName{Name: "small-llama", Namespace: "default"}, &airunwayv1alpha1.ModelDeployment{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the ModelDeployment of the removed item to be deleted, got %v", err)
+	}
+	if err := c.Get(ctx, types.NamespacedName{Name: "small-manual", Namespace: "default"}, &airunwayv1alpha1.ModelDeployment{}); err != nil {
+		t.Errorf("expected the unowned ModelDeployment to be kept: %v", err)
+	}
+
+	var updated airunwayv1alpha1.ModelFleet
+	if err := c.Get(ctx, key, &updated); err != nil {
+		t.Fatalf("failed to get fleet: %v", err)
+	}
+	if updated.Status.Total != 2 || updated.Status.Ready != 0 || updated.Status.ObservedGeneration != 2 {
+		t.Errorf("unexpected status %+v", updated.Status)
+	}
+	cond := meta.FindStatusCondition(updated.Status.Conditions, airunwayv1alpha1.ConditionTypeReady)
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != airunwayv1alpha1.FleetReasonNotReady {
+		t.Errorf("expected Ready=False NotReady, got %+v", cond)
+	}
+	if !meta.IsStatusConditionTrue(updated.Status.Conditions, airunwayv1alpha1.ConditionTypeReconciling) {
+		t.Errorf("expected Reconciling while ModelDeployments are not running, got %+v", updated.Status.Conditions)
+	}
+
+	// Once every ModelDeployment runs, the fleet is ready
+	for _, md := range []*airunwayv1alpha1.ModelDeployment{&qwen, &phi} {
+		md.Status.Phase = airunwayv1alpha1.DeploymentPhaseRunning
+		if err := c.Status().Update(ctx, md); err != nil {
+			t.Fatalf("failed to update status: %v", err)
+		}
+	}
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if err := c.Get(ctx, key, &updated); err != nil {
+		t.Fatalf("failed to get fleet: %v", err)
+	}
+	if updated.Status.Ready != 2 {
+		t.Errorf("expected 2 ready, got %d", updated.Status.Ready)
+	}
+	cond = meta.FindStatusCondition(updated.Status.Conditions, airunwayv1alpha1.ConditionTypeReady)
+	if cond == nil || cond.Status != metav1.ConditionTrue || cond.Reason != airunwayv1alpha1.FleetReasonAllReady {
+		t.Errorf("expected Ready=True AllReady, got %+v", cond)
+	}
+	if meta.FindStatusCondition(updated.Status.Conditions, airunwayv1alpha1.ConditionTypeReconciling) != nil {
+		t.Errorf("expected Reconciling to be removed once ready, got %+v", updated.Status.Conditions)
+	}
+}
+
+func TestModelFleetReconcile_ExistingDeploymentNotOwned(t *testing.T) {
+	scheme := newTestScheme()
+	fleet := newModelFleet()
+	existing := newModelDeployment("small-qwen", "default")
+
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(fleet, existing).
+		WithStatusSubresource(&airunwayv1alpha1.ModelFleet{}).
+		Build()
+	r := &ModelFleetReconciler{Client: c, Scheme: scheme}
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "small", Namespace: "default"}
+
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+
+	var md airunwayv1alpha1.ModelDeployment
+	if err := c.Get(ctx, types.NamespacedName{Name: "small-qwen", Namespace: "default"}, &md); err != nil {
+		t.Fatal(err)
+	}
+	if md.Spec.Model.ID == "Qwen/Qwen3-0.6B" || len(md.OwnerReferences) != 0 {
+		t.Error("expected the existing ModelDeployment to be left alone")
+	}
+
+	var updated airunwayv1alpha1.ModelFleet
+	if err := c.Get(ctx, key, &updated); err != nil {
+		t.Fatal(err)
+	}
+	if updated.Status.Failed != 1 || updated.Status.Items[0].Phase != airunwayv1alpha1.DeploymentPhaseFailed {
+		t.Errorf("expected the conflicting item to fail, got %+v", updated.Status)
+	}
+	cond := meta.FindStatusCondition(updated.Status.Conditions, airunwayv1alpha1.ConditionTypeReady)
+	if cond == nil || cond.Reason != airunwayv1alpha1.FleetReasonFailed {
+		t.Errorf("expected Ready reason Failed, got %+v", cond)
+	}
+}
+
+func TestModelFleetReconcile_KeepsManagedFields(t *testing.T) {
+	scheme := newTestScheme()
+	fleet := newModelFleet()
+	fleet.Spec.Items = fleet.Spec.Items[:1]
+	fleet.Spec.Template.Resources = &airunwayv1alpha1.ResourceSpec{
+		GPU:            &airunwayv1alpha1.GPUSpec{Count: 1},
+		CPU:            "4",
+		Memory:         "16Gi",
+		Autotune:       true,
+		AutotuneBounds: &airunwayv1alpha1.AutotuneBounds{MaxCPU: "8", MaxMemory: "32Gi"},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(fleet).
+		WithStatusSubresource(&airunwayv1alpha1.ModelFleet{}, &airunwayv1alpha1.ModelDeployment{}).
+		Build()
+	r := &ModelFleetReconciler{Client: c, Scheme: scheme}
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "small", Namespace: "default"}
+	mdKey := types.NamespacedName{Name: "small-qwen", Namespace: "default"}
+
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+
+	// Autotune and remediation change the generated ModelDeployment
+	var md airunwayv1alpha1.ModelDeployment
+	if err := c.Get(ctx, mdKey, &md); err != nil {
+		t.Fatal(err)
+	}
+	contextLength := int32(4096)
+	md.Spec.Resources.CPU = "2"
+	md.Spec.Resources.Memory = "10Gi"
+	md.Spec.Engine.Args = map[string]string{"gpu-memory-utilization": "0.8"}
+	md.Spec.Engine.ContextLength = &contextLength
+	if err := c.Update(ctx, &md); err != nil {
+		t.Fatal(err)
+	}
+	md.Status.Remediation = &airunwayv1alpha1.RemediationStatus{Attempts: []airunwayv1alpha1.RemediationAttempt{
+		{Reason: airunwayv1alpha1.RemediationReasonCUDAOutOfMemory, Action: "engine.args.gpu-memory-utilization=0.8"},
+		{Reason: airunwayv1alpha1.RemediationReasonKVCacheTooSmall, Action: "engine.contextLength=4096"},
+	}}
+	if err := c.Status().Update(ctx, &md); err != nil {
+		t.Fatal(err)
+	}
+
+	// Editing the template updates the fields it owns and keeps the managed ones
+	if err := c.Get(ctx, key, fleet); err != nil {
+		t.Fatal(err)
+	}
+	fleet.Spec.Template.Engine.Args = map[string]string{"max-num-seqs": "64"}
+	if err := c.Update(ctx, fleet); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if err := c.Get(ctx, mdKey, &md); err != nil {
+		t.Fatal(err)
+	}
+	if md.Spec.Resources.CPU != "2" || md.Spec.Resources.Memory != "10Gi" {
+		t.Errorf("expected the autotuned resources to be kept, got cpu=%s memory=%s", md.Spec.Resources.CPU, md.Spec.Resources.Memory)
+	}
+	if md.Spec.Engine.Args["gpu-memory-utilization"] != "0.8" || md.Spec.Engine.Args["max-num-seqs"] != "64" {
+		t.Errorf("expected the remediated and template args, got %v", md.Spec.Engine.Args)
+	}
+	if md.Spec.Engine.ContextLength == nil || *md.Spec.Engine.ContextLength != 4096 {
+		t.Errorf("expected the remediated context length to be kept, got %v", md.Spec.Engine.ContextLength)
+	}
+
+	// Without autotune, the template resources apply again
+	fleet.Spec.Template.Resources.Autotune = false
+	fleet.Spec.Template.Resources.AutotuneBounds = nil
+	if err := c.Update(ctx, fleet); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if err := c.Get(ctx, mdKey, &md); err != nil {
+		t.Fatal(err)
+	}
+	if md.Spec.Resources.CPU != "4" || md.Spec.Resources.Memory != "16Gi" {
+		t.Errorf("expected the template resources, got cpu=%s memory=%s", md.Spec.Resources.CPU, md.Spec.Resources.Memory)
+	}
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/pkg/provider"
+)
+
+// namespaceNameLabel is the label the API server sets on every namespace to its name
+const namespaceNameLabel = "kubernetes.io/metadata.name"
+
+// isolationEgressPorts are the ports model pods may reach outside their namespace: DNS,
+// and HTTP(S) for model downloads
+var isolationEgressPorts = []struct {
+	protocol corev1.Protocol
+	port     int32
+}{
+	{corev1.ProtocolUDP, 53},
+	{corev1.ProtocolTCP, 53},
+	{corev1.ProtocolTCP, 80},
+	{corev1.ProtocolTCP, 443},
+}
+
+// reconcileNetworkPolicy creates the NetworkPolicy of spec.networking.isolate for the pods
+// labeled with the ModelDeployment. Ingress is allowed from the deployment's namespace,
+// which holds the EPP, from the Gateway and controller namespaces, and from
+// --network-isolation-namespaces. Egress is allowed to the same namespaces, to the network
+// dependencies of the provider, to the log sink, to DNS and HTTP(S), and by the rules of
+// spec.networking.egress. The NetworkPolicy is deleted once spec.networking.isolate is unset.
+func (r *ModelDeploymentReconciler) reconcileNetworkPolicy(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) error {
+	np := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      md.NetworkPolicyName(),
+			Namespace: md.Namespace,
+		},
+	}
+
+	if !md.NetworkIsolated() {
+		meta.RemoveStatusCondition(&md.Status.Conditions, airunwayv1alpha1.ConditionTypeNetworkIsolated)
+		if err := r.Get(ctx, k8stypes.NamespacedName{Name: np.Name, Namespace: np.Namespace}, np); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		if !metav1.IsControlledBy(np, md) {
+			return nil
+		}
+		log.FromContext(ctx).Info("Deleting network isolation NetworkPolicy", "name", np.Name)
+		return client.IgnoreNotFound(r.Delete(ctx, np))
+	}
+
+	namespaces := r.isolationNamespaces(md)
+	dependencies, err := r.networkDependencies(ctx, md)
+	if err != nil {
+		return err
+	}
+	_, err = ctrl.CreateOrUpdate(ctx, r.Client, np, func() error {
+		if np.ResourceVersion != "" && !metav1.IsControlledBy(np, md) {
+			return fmt.Errorf("NetworkPolicy %s already exists and is not managed by this ModelDeployment", np.Name)
+		}
+		if np.Labels == nil {
+			np.Labels = map[string]string{}
+		}
+		np.Labels[airunwayv1alpha1.LabelManagedBy] = airunwayv1alpha1.ManagedByAIRunway
+		np.Labels[airunwayv1alpha1.LabelModelDeployment] = md.Name
+
+		// An empty pod selector matches every pod in the deployment's namespace
+		peers := []networkingv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{}}}
+		for _, ns := range namespaces {
+			peers = append(peers, networkingv1.NetworkPolicyPeer{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{namespaceNameLabel: ns}},
+			})
+		}
+		var egressPorts []networkingv1.NetworkPolicyPort
+		for _, p := range isolationEgressPorts {
+			egressPorts = append(egressPorts, networkPolicyPort(p.protocol, p.port))
+		}
+		egress := []networkingv1.NetworkPolicyEgressRule{{To: peers}, {Ports: egressPorts}}
+		egress = append(egress, dependencies...)
+		if rule := logSinkEgressRule(md); rule != nil {
+			egress = append(egress, *rule)
+		}
+		egress = append(egress, md.Spec.Networking.Egress...)
+
+		np.Spec = networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{airunwayv1alpha1.LabelModelDeployment: md.Name},
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress},
+			Ingress:     []networkingv1.NetworkPolicyIngressRule{{From: peers}},
+			Egress:      egress,
+		}
+		provider.ApplyPropagatedMetadataToObject(np, md)
+		return ctrl.SetControllerReference(md, np, r.Scheme)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create/update NetworkPolicy: %w", err)
+	}
+
+	message := "Only the deployment's namespace can reach the model pods"
+	if len(namespaces) > 0 {
+		message = fmt.Sprintf("Only the deployment's namespace and namespaces %s can reach the model pods", strings.Join(namespaces, ", "))
+	}
+	r.setCondition(md, airunwayv1alpha1.ConditionTypeNetworkIsolated, metav1.ConditionTrue, "NetworkPolicyApplied", message)
+	return nil
+}
+
+// isolationNamespaces returns the namespaces other than its own whose pods may reach the
+// model pods of md: the Gateway's, the controller's and --network-isolation-namespaces
+func (r *ModelDeploymentReconciler) isolationNamespaces(md *airunwayv1alpha1.ModelDeployment) []string {
+	var namespaces []string
+	add := func(ns string) {
+		if ns != "" && ns != md.Namespace && !slices.Contains(namespaces, ns) {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	if md.Status.Gateway != nil {
+		add(md.Status.Gateway.GatewayNamespace)
+	}
+	add(r.ControllerNamespace)
+	for _, ns := range r.NetworkIsolationNamespaces {
+		add(ns)
+	}
+	slices.Sort(namespaces)
+	return namespaces
+}
+
+// networkDependencies returns egress rules to the networkDependencies of the provider of md,
+// such as the etcd and NATS services of the Dynamo platform
+func (r *ModelDeploymentReconciler) networkDependencies(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) ([]networkingv1.NetworkPolicyEgressRule, error) {
+	if md.Status.Provider == nil || md.Status.Provider.Name == "" {
+		return nil, nil
+	}
+	var config airunwayv1alpha1.InferenceProviderConfig
+	if err := r.Get(ctx, k8stypes.NamespacedName{Name: md.Status.Provider.Name}, &config); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	if config.Spec.Capabilities == nil {
+		return nil, nil
+	}
+	var rules []networkingv1.NetworkPolicyEgressRule
+	for _, dep := range config.Spec.Capabilities.NetworkDependencies {
+		rule := networkingv1.NetworkPolicyEgressRule{To: []networkingv1.NetworkPolicyPeer{{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{namespaceNameLabel: dep.Namespace}},
+		}}}
+		for _, port := range dep.Ports {
+			rule.Ports = append(rule.Ports, networkPolicyPort(corev1.ProtocolTCP, port))
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// logSinkEgressRule returns an egress rule to the port of the log sink endpoint, or nil
+// without a log sink. The rule is limited to the namespace of in-cluster endpoints named
+// <service>.<namespace>.svc, and allows any destination on the port otherwise.
+func logSinkEgressRule(md *airunwayv1alpha1.ModelDeployment) *networkingv1.NetworkPolicyEgressRule {
+	sink := md.LogSink()
+	if sink == nil {
+		return nil
+	}
+	u, err := url.Parse(sink.Endpoint)
+	if err != nil {
+		return nil
+	}
+	port := int64(80)
+	if u.Scheme == "https" {
+		port = 443
+	}
+	if u.Port() != "" {
+		if port, err = strconv.ParseInt(u.Port(), 10, 32); err != nil {
+			return nil
+		}
+	}
+	rule := &networkingv1.NetworkPolicyEgressRule{
+		Ports: []networkingv1.NetworkPolicyPort{networkPolicyPort(corev1.ProtocolTCP, int32(port))},
+	}
+	if labels := strings.Split(u.Hostname(), "."); len(labels) >= 3 && labels[2] == "svc" {
+		rule.To = []networkingv1.NetworkPolicyPeer{{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{namespaceNameLabel: labels[1]}},
+		}}
+	}
+	return rule
+}
+
+// networkPolicyPort returns a NetworkPolicyPort for port
+func networkPolicyPort(protocol corev1.Protocol, port int32) networkingv1.NetworkPolicyPort {
+	p := intstr.FromInt32(port)
+	return networkingv1.NetworkPolicyPort{Protocol: &protocol, Port: &p}
+}
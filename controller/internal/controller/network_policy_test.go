@@ -0,0 +1,201 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+func TestReconcileNetworkPolicy(t *testing.T) {
+	md := newModelDeployment("test-model", "default")
+	md.Spec.Networking = &airunwayv1alpha1.NetworkingSpec{Isolate: true}
+	md.Status.Gateway = &airunwayv1alpha1.GatewayStatus{GatewayNamespace: "gateway-system"}
+	r := newTestReconciler(newTestScheme(), nil, md)
+	r.ControllerNamespace = "airunway-system"
+	r.NetworkIsolationNamespaces = []string{"envoy-gateway-system", "default"}
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "test-model-isolation", Namespace: "default"}
+
+	if err := r.reconcileNetworkPolicy(ctx, md); err != nil {
+		t.Fatalf("reconcileNetworkPolicy failed: %v", err)
+	}
+	var np networkingv1.NetworkPolicy
+	if err := r.Get(ctx, key, &np); err != nil {
+		t.Fatalf("expected NetworkPolicy to be created: %v", err)
+	}
+	if !metav1.IsControlledBy(&np, md) {
+		t.Error("expected NetworkPolicy to be owned by the ModelDeployment")
+	}
+	if np.Spec.PodSelector.MatchLabels[airunwayv1alpha1.LabelModelDeployment] != "test-model" {
+		t.Errorf("unexpected pod selector %+v", np.Spec.PodSelector)
+	}
+	if len(np.Spec.PolicyTypes) != 2 {
+		t.Errorf("expected ingress and egress policy types, got %v", np.Spec.PolicyTypes)
+	}
+
+	// The namespace itself, then the other namespaces sorted, without duplicates
+	from := np.Spec.Ingress[0].From
+	if len(from) != 4 || from[0].PodSelector == nil || len(from[0].PodSelector.MatchLabels) != 0 {
+		t.Fatalf("unexpected ingress peers %+v", from)
+	}
+	for i, ns := range []string{"airunway-system", "envoy-gateway-system", "gateway-system"} {
+		if got := from[i+1].NamespaceSelector.MatchLabels[namespaceNameLabel]; got != ns {
+			t.Errorf("expected ingress peer %d from namespace %s, got %s", i+1, ns, got)
+		}
+	}
+
+	// Egress reaches the same namespaces on any port
+	egress := np.Spec.Egress
+	if len(egress) != 2 || len(egress[0].To) != 4 || len(egress[0].Ports) != 0 || len(egress[1].Ports) != len(isolationEgressPorts) {
+		t.Errorf("unexpected egress rules %+v", egress)
+	}
+	if !meta.IsStatusConditionTrue(md.Status.Conditions, airunwayv1alpha1.ConditionTypeNetworkIsolated) {
+		t.Error("expected NetworkIsolated condition to be true")
+	}
+
+	// Unsetting isolate deletes the NetworkPolicy and the condition
+	md.Spec.Networking.Isolate = false
+	if err := r.reconcileNetworkPolicy(ctx, md); err != nil {
+		t.Fatalf("reconcileNetworkPolicy failed: %v", err)
+	}
+	if err := r.Get(ctx, key, &np); !apierrors.IsNotFound(err) {
+		t.Errorf("expected NetworkPolicy to be deleted, got %v", err)
+	}
+	if meta.FindStatusCondition(md.Status.Conditions, airunwayv1alpha1.ConditionTypeNetworkIsolated) != nil {
+		t.Error("expected NetworkIsolated condition to be removed")
+	}
+}
+
+func TestReconcileNetworkPolicy_NotOwned(t *testing.T) {
+	md := newModelDeployment("test-model", "default")
+	md.Spec.Networking = &airunwayv1alpha1.NetworkingSpec{Isolate: true}
+	existing := &networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: "test-model-isolation", Namespace: "default"}}
+	r := newTestReconciler(newTestScheme(), nil, md, existing)
+	ctx := context.Background()
+
+	if err := r.reconcileNetworkPolicy(ctx, md); err == nil {
+		t.Error("expected an error for a NetworkPolicy not managed by the ModelDeployment")
+	}
+
+	// A NetworkPolicy the ModelDeployment does not control is left alone once isolate is unset
+	md.Spec.Networking = nil
+	if err := r.reconcileNetworkPolicy(ctx, md); err != nil {
+		t.Fatalf("reconcileNetworkPolicy failed: %v", err)
+	}
+	if err := r.Get(ctx, types.NamespacedName{Name: "test-model-isolation", Namespace: "default"}, existing); err != nil {
+		t.Errorf("expected the NetworkPolicy to be kept: %v", err)
+	}
+}
+
+func TestReconcileNetworkPolicy_Egress(t *testing.T) {
+	md := newModelDeployment("test-model", "default")
+	md.Spec.Networking = &airunwayv1alpha1.NetworkingSpec{
+		Isolate: true,
+		Egress: []networkingv1.NetworkPolicyEgressRule{{
+			To: []networkingv1.NetworkPolicyPeer{{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{namespaceNameLabel: "cache"}},
+			}},
+			Ports: []networkingv1.NetworkPolicyPort{networkPolicyPort(corev1.ProtocolTCP, 6379)},
+		}},
+	}
+	md.Spec.Observability = &airunwayv1alpha1.ObservabilitySpec{LogSink: &airunwayv1alpha1.LogSinkSpec{
+		Type:     airunwayv1alpha1.LogSinkTypeLoki,
+		Endpoint: "http://loki.observability.svc:3100",
+	}}
+	md.Status.Provider = &airunwayv1alpha1.ProviderStatus{Name: "dynamo"}
+	dynamo := &airunwayv1alpha1.InferenceProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "dynamo"},
+		Spec: airunwayv1alpha1.InferenceProviderConfigSpec{Capabilities: &airunwayv1alpha1.ProviderCapabilities{
+			NetworkDependencies: []airunwayv1alpha1.NetworkDependency{
+				{Name: "etcd", Namespace: "dynamo-system", Ports: []int32{2379}},
+				{Name: "nats", Namespace: "dynamo-system", Ports: []int32{4222}},
+			},
+		}},
+	}
+	r := newTestReconciler(newTestScheme(), nil, md, dynamo)
+	ctx := context.Background()
+
+	if err := r.reconcileNetworkPolicy(ctx, md); err != nil {
+		t.Fatalf("reconcileNetworkPolicy failed: %v", err)
+	}
+	var np networkingv1.NetworkPolicy
+	if err := r.Get(ctx, types.NamespacedName{Name: "test-model-isolation", Namespace: "default"}, &np); err != nil {
+		t.Fatalf("expected NetworkPolicy to be created: %v", err)
+	}
+
+	// The namespace, DNS and HTTP(S), etcd, NATS, the log sink and spec.networking.egress
+	egress := np.Spec.Egress
+	if len(egress) != 6 {
+		t.Fatalf("expected 6 egress rules, got %+v", egress)
+	}
+	for i, want := range []struct {
+		namespace string
+		port      int
+	}{{"dynamo-system", 2379}, {"dynamo-system", 4222}, {"observability", 3100}, {"cache", 6379}} {
+		rule := egress[i+2]
+		if len(rule.To) != 1 || rule.To[0].NamespaceSelector.MatchLabels[namespaceNameLabel] != want.namespace {
+			t.Errorf("expected egress rule %d to namespace %s, got %+v", i+2, want.namespace, rule.To)
+		}
+		if len(rule.Ports) != 1 || rule.Ports[0].Port.IntValue() != want.port {
+			t.Errorf("expected egress rule %d on port %d, got %+v", i+2, want.port, rule.Ports)
+		}
+	}
+}
+
+func TestLogSinkEgressRule(t *testing.T) {
+	md := newModelDeployment("test-model", "default")
+	if logSinkEgressRule(md) != nil {
+		t.Error("expected no rule without a log sink")
+	}
+
+	tests := []struct {
+		endpoint  string
+		namespace string
+		port      int
+	}{
+		{"http://loki.observability.svc.cluster.local:3100", "observability", 3100},
+		{"https://otel-collector.observability.svc", "observability", 443},
+		{"http://logs.example.com:8080/loki/api/v1/push", "", 8080},
+	}
+	for _, tt := range tests {
+		md.Spec.Observability = &airunwayv1alpha1.ObservabilitySpec{LogSink: &airunwayv1alpha1.LogSinkSpec{
+			Type:     airunwayv1alpha1.LogSinkTypeLoki,
+			Endpoint: tt.endpoint,
+		}}
+		rule := logSinkEgressRule(md)
+		if rule == nil || len(rule.Ports) != 1 || rule.Ports[0].Port.IntValue() != tt.port {
+			t.Fatalf("expected a rule on port %d for %s, got %+v", tt.port, tt.endpoint, rule)
+		}
+		if tt.namespace == "" {
+			if len(rule.To) != 0 {
+				t.Errorf("expected any destination for %s, got %+v", tt.endpoint, rule.To)
+			}
+		} else if len(rule.To) != 1 || rule.To[0].NamespaceSelector.MatchLabels[namespaceNameLabel] != tt.namespace {
+			t.Errorf("expected namespace %s for %s, got %+v", tt.namespace, tt.endpoint, rule.To)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+// reconcilePlacement records the node and GPUs of each Running model server pod of a
+// Running deployment in status.placement. GPU indices are looked up with GPUDevices by the
+// background scraper when set. It returns how long to wait before looking again, or zero.
+func (r *ModelDeploymentReconciler) reconcilePlacement(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) time.Duration {
+	if md.Status.Phase != airunwayv1alpha1.DeploymentPhaseRunning {
+		md.Status.Placement = nil
+		return 0
+	}
+	logger := log.FromContext(ctx)
+	interval := r.settings().ActivityPollInterval
+
+	pods, err := modelPods(ctx, r.Client, md)
+	if err != nil {
+		logger.Info("Could not list pods for placement", "name", md.Name, "error", err.Error())
+		return interval
+	}
+	var devices map[string][]string
+	if source := r.GPUDevices; source != nil {
+		scraped := slices.Clone(pods)
+		result, ok := r.scrape(ctx, md, scrapeGPUDevices, "", interval, func(ctx context.Context) (interface{}, error) {
+			return source.SampleGPUDevices(ctx, scraped)
+		})
+		switch {
+		case !ok:
+		case result.err != nil:
+			logger.V(1).Info("Could not look up GPU indices", "name", md.Name, "error", result.err.Error())
+		default:
+			devices = result.value.(map[string][]string)
+		}
+	}
+
+	gpuTypes := map[string]string{}
+	placement := make([]airunwayv1alpha1.PodPlacement, 0, len(pods))
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" {
+			continue
+		}
+		gpuType, ok := gpuTypes[pod.Spec.NodeName]
+		if !ok {
+			var node corev1.Node
+			if err := r.Get(ctx, client.ObjectKey{Name: pod.Spec.NodeName}, &node); err != nil {
+				logger.V(1).Info("Could not get the node of a pod", "pod", pod.Name, "error", err.Error())
+			}
+			gpuType = node.Labels[airunwayv1alpha1.LabelGPUProduct]
+			gpuTypes[pod.Spec.NodeName] = gpuType
+		}
+		placement = append(placement, airunwayv1alpha1.PodPlacement{
+			Pod:        pod.Name,
+			Node:       pod.Spec.NodeName,
+			GPUs:       podGPUs(&pod),
+			GPUType:    gpuType,
+			GPUIndices: devices[pod.Namespace+"/"+pod.Name],
+		})
+	}
+	slices.SortFunc(placement, func(a, b airunwayv1alpha1.PodPlacement) int {
+		return strings.Compare(a.Pod, b.Pod)
+	})
+	if len(placement) == 0 {
+		placement = nil
+	}
+	md.Status.Placement = placement
+	return interval
+}
+
+// podGPUs returns the GPUs requested by the containers of pod, counting extended
+// resources named */gpu
+func podGPUs(pod *corev1.Pod) int32 {
+	var gpus int64
+	for _, c := range pod.Spec.Containers {
+		for name, quantity := range c.Resources.Limits {
+			if strings.HasSuffix(string(name), "/gpu") {
+				gpus += quantity.Value()
+			}
+		}
+	}
+	return int32(gpus)
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+// fakeGPUDeviceSource returns fixed GPU indices keyed by "namespace/pod"
+type fakeGPUDeviceSource struct {
+	devices map[string][]string
+}
+
+func (s *fakeGPUDeviceSource) SampleGPUDevices(context.Context, []corev1.Pod) (map[string][]string, error) {
+	return s.devices, nil
+}
+
+func newPlacedPod(name, mdName, node string, gpus int64) *corev1.Pod {
+	pod := newModelPod(name, mdName, nil)
+	pod.Spec.NodeName = node
+	pod.Spec.Containers = []corev1.Container{{
+		Name: "main",
+		Resources: corev1.ResourceRequirements{Limits: corev1.ResourceList{
+			airunwayv1alpha1.DefaultGPUType: *resource.NewQuantity(gpus, resource.DecimalSI),
+		}},
+	}}
+	return pod
+}
+
+func TestReconcilePlacement(t *testing.T) {
+	ctx := context.Background()
+	md := newModelDeployment("test-model", "default")
+	pending := newPlacedPod("pending", md.Name, "", 1)
+	pending.Status.Phase = corev1.PodPending
+	r := newTestReconciler(newTestScheme(), nil,
+		newPlacedPod("model-1", md.Name, "node-b", 2),
+		newPlacedPod("model-0", md.Name, "node-a", 1),
+		pending,
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name:   "node-a",
+			Labels: map[string]string{airunwayv1alpha1.LabelGPUProduct: "NVIDIA-H100-80GB-HBM3"},
+		}},
+	)
+	r.GPUDevices = &fakeGPUDeviceSource{devices: map[string][]string{"default/model-1": {"2", "3"}}}
+
+	// Nothing is reported before the deployment runs
+	md.Status.Phase = airunwayv1alpha1.DeploymentPhasePending
+	md.Status.Placement = []airunwayv1alpha1.PodPlacement{{Pod: "stale", Node: "node-c"}}
+	if next := r.reconcilePlacement(ctx, md); next != 0 || md.Status.Placement != nil {
+		t.Fatalf("expected the placement to be cleared, got %v and %+v", next, md.Status.Placement)
+	}
+
+	// GPU indices are looked up in the background and reported on the next reconcile
+	md.Status.Phase = airunwayv1alpha1.DeploymentPhaseRunning
+	r.reconcilePlacement(ctx, md)
+	if len(md.Status.Placement) != 2 || md.Status.Placement[1].GPUIndices != nil {
+		t.Errorf("expected the pods without GPU indices before the lookup completed, got %+v", md.Status.Placement)
+	}
+	waitForScrapes(r)
+	if next := r.reconcilePlacement(ctx, md); next != DefaultActivityPollInterval {
+		t.Errorf("expected requeue after %s, got %s", DefaultActivityPollInterval, next)
+	}
+	placement := md.Status.Placement
+	if len(placement) != 2 {
+		t.Fatalf("expected the 2 running pods, got %+v", placement)
+	}
+	if got := placement[0]; got.Pod != "model-0" || got.Node != "node-a" || got.GPUs != 1 ||
+		got.GPUType != "NVIDIA-H100-80GB-HBM3" || got.GPUIndices != nil {
+		t.Errorf("unexpected placement of model-0: %+v", got)
+	}
+	// A node that cannot be read leaves the GPU type empty
+	if got := placement[1]; got.Pod != "model-1" || got.Node != "node-b" || got.GPUs != 2 ||
+		got.GPUType != "" || !slices.Equal(got.GPUIndices, []string{"2", "3"}) {
+		t.Errorf("unexpected placement of model-1: %+v", got)
+	}
+}
@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+func int32Ptr(i int32) *int32 { return &i }
+
+func newDeployingModelDeployment(deadlineSeconds int32) *airunwayv1alpha1.ModelDeployment {
+	md := newModelDeployment("test-model", "default")
+	md.Generation = 1
+	md.Spec.ProgressDeadlineSeconds = int32Ptr(deadlineSeconds)
+	md.Status.Phase = airunwayv1alpha1.DeploymentPhaseDeploying
+	return md
+}
+
+func TestCheckProgressDeadline_NoDeadline(t *testing.T) {
+	md := newDeployingModelDeployment(60)
+	md.Spec.ProgressDeadlineSeconds = nil
+	r := &ModelDeploymentReconciler{}
+
+	if requeue := r.checkProgressDeadline(md); requeue != 0 {
+		t.Errorf("expected no requeue without a deadline, got %s", requeue)
+	}
+	if meta.FindStatusCondition(md.Status.Conditions, airunwayv1alpha1.ConditionTypeProgressing) != nil {
+		t.Error("expected no Progressing condition without a deadline")
+	}
+}
+
+func TestCheckProgressDeadline_StartsClock(t *testing.T) {
+	md := newDeployingModelDeployment(600)
+	r := &ModelDeploymentReconciler{}
+
+	requeue := r.checkProgressDeadline(md)
+	if requeue <= 0 || requeue > 600*time.Second {
+		t.Errorf("expected requeue within the deadline, got %s", requeue)
+	}
+	cond := meta.FindStatusCondition(md.Status.Conditions, airunwayv1alpha1.ConditionTypeProgressing)
+	if cond == nil || cond.Status != metav1.ConditionTrue || cond.Reason != airunwayv1alpha1.ReasonDeploying {
+		t.Errorf("expected Progressing True/Deploying, got %+v", cond)
+	}
+	if md.Status.Phase != airunwayv1alpha1.DeploymentPhaseDeploying {
+		t.Errorf("expected phase to stay Deploying, got %s", md.Status.Phase)
+	}
+}
+
+func TestCheckProgressDeadline_Exceeded(t *testing.T) {
+	md := newDeployingModelDeployment(60)
+	md.Status.Conditions = []metav1.Condition{{
+		Type:               airunwayv1alpha1.ConditionTypeProgressing,
+		Status:             metav1.ConditionTrue,
+		Reason:             airunwayv1alpha1.ReasonDeploying,
+		ObservedGeneration: 1,
+		LastTransitionTime: metav1.NewTime(time.Now().Add(-2 * time.Minute)),
+	}}
+	recorder := events.NewFakeRecorder(1)
+	r := &ModelDeploymentReconciler{Recorder: recorder}
+
+	if requeue := r.checkProgressDeadline(md); requeue != 0 {
+		t.Errorf("expected no requeue after the deadline, got %s", requeue)
+	}
+	if md.Status.Phase != airunwayv1alpha1.DeploymentPhaseFailed {
+		t.Errorf("expected phase Failed, got %s", md.Status.Phase)
+	}
+	cond := meta.FindStatusCondition(md.Status.Conditions, airunwayv1alpha1.ConditionTypeProgressing)
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != airunwayv1alpha1.ReasonProgressDeadlineExceeded {
+		t.Errorf("expected Progressing False/ProgressDeadlineExceeded, got %+v", cond)
+	}
+
+	select {
+	case e := <-recorder.Events:
+		if !strings.Contains(e, "Warning ProgressDeadlineExceeded") {
+			t.Errorf("unexpected event %q", e)
+		}
+	default:
+		t.Error("expected a ProgressDeadlineExceeded event")
+	}
+
+	// A provider moving the phase back to Deploying does not restart the clock
+	md.Status.Phase = airunwayv1alpha1.DeploymentPhaseDeploying
+	r.checkProgressDeadline(md)
+	if md.Status.Phase != airunwayv1alpha1.DeploymentPhaseFailed {
+		t.Errorf("expected phase to stay Failed for the same generation, got %s", md.Status.Phase)
+	}
+
+	// A spec change restarts the clock
+	md.Generation = 2
+	md.Status.Phase = airunwayv1alpha1.DeploymentPhaseDeploying
+	if requeue := r.checkProgressDeadline(md); requeue <= 0 {
+		t.Errorf("expected the deadline to restart after a spec change, got %s", requeue)
+	}
+	if md.Status.Phase != airunwayv1alpha1.DeploymentPhaseDeploying {
+		t.Errorf("expected phase Deploying after a spec change, got %s", md.Status.Phase)
+	}
+}
+
+func TestCheckProgressDeadline_Running(t *testing.T) {
+	md := newDeployingModelDeployment(60)
+	md.Status.Phase = airunwayv1alpha1.DeploymentPhaseRunning
+	r := &ModelDeploymentReconciler{}
+
+	if requeue := r.checkProgressDeadline(md); requeue != 0 {
+		t.Errorf("expected no requeue when running, got %s", requeue)
+	}
+	cond := meta.FindStatusCondition(md.Status.Conditions, airunwayv1alpha1.ConditionTypeProgressing)
+	if cond == nil || cond.Status != metav1.ConditionTrue || cond.Reason != airunwayv1alpha1.ReasonDeploymentAvailable {
+		t.Errorf("expected Progressing True/DeploymentAvailable, got %+v", cond)
+	}
+}
@@ -0,0 +1,257 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/internal/gateway"
+	"github.com/kaito-project/airunway/controller/internal/promptpolicy"
+	"github.com/kaito-project/airunway/controller/pkg/provider"
+)
+
+// +kubebuilder:rbac:groups=gateway.envoyproxy.io,resources=envoyextensionpolicies,verbs=get;list;watch;create;update;patch;delete
+
+// promptPolicyName returns the name of the prompt policy resources of md
+func promptPolicyName(md *airunwayv1alpha1.ModelDeployment) string {
+	return md.Name + "-prompt-policy"
+}
+
+// promptPolicyFields returns the spec.gateway and spec.observability fields implemented
+// by the prompt policy external processor that md sets
+func promptPolicyFields(md *airunwayv1alpha1.ModelDeployment) []string {
+	var fields []string
+	if md.Spec.Gateway != nil && md.Spec.Gateway.PromptPolicy != nil {
+		fields = append(fields, "promptPolicy")
+	}
+	if md.Spec.Gateway != nil && md.Spec.Gateway.Guardrails != nil {
+		fields = append(fields, "guardrails")
+	}
+	if md.Spec.Gateway != nil && md.Spec.Gateway.ResponseCache != nil {
+		fields = append(fields, "responseCache")
+	}
+	if o := md.Spec.Observability; o != nil && o.GenAIMetrics != nil && o.GenAIMetrics.Enabled {
+		fields = append(fields, "genAIMetrics")
+	}
+	return fields
+}
+
+// reconcilePromptPolicy deploys the external processor enforcing spec.gateway.promptPolicy,
+// spec.gateway.guardrails and spec.gateway.responseCache and recording
+// spec.observability.genAIMetrics, and attaches it to the HTTPRoute of md, or removes them
+// when none is set. It returns why set fields are not enforced, or an empty string.
+func (r *ModelDeploymentReconciler) reconcilePromptPolicy(ctx context.Context, md *airunwayv1alpha1.ModelDeployment, gwConfig *gateway.GatewayConfig) (string, error) {
+	logger := log.FromContext(ctx)
+	name := promptPolicyName(md)
+
+	fields := promptPolicyFields(md)
+	if len(fields) == 0 {
+		return "", r.deletePromptPolicy(ctx, md)
+	}
+	notEnforced := fields[0] + " is not enforced"
+	if n := len(fields); n > 1 {
+		notEnforced = strings.Join(fields[:n-1], ", ") + " and " + fields[n-1] + " are not enforced"
+	}
+	routeName := md.Name
+	if md.Spec.Gateway != nil && md.Spec.Gateway.HTTPRouteRef != "" {
+		routeName = md.Spec.Gateway.HTTPRouteRef
+	}
+	policySpec := promptpolicy.PolicyFor(md.Spec.Gateway)
+	policySpec.GenAIMetrics = promptpolicy.GenAIMetricsFor(md.Spec.Observability, md.Spec.Engine.Type)
+
+	// Requests fail closed unless the guardrails fail open, or only metrics are recorded,
+	// and wait for the moderation request rather than Envoy's default 200ms message timeout
+	failOpen := len(fields) == 1 && policySpec.GenAIMetrics != nil
+	var messageTimeout time.Duration
+	if g := policySpec.Guardrails; g != nil {
+		failOpen = g.FailureMode == airunwayv1alpha1.GuardrailsFailureModeOpen
+		messageTimeout = g.Timeout.Duration + time.Second
+	}
+	// Responses are buffered for the cache, or streamed so metrics do not delay tokens
+	responseBody := ""
+	switch {
+	case policySpec.ResponseCache != nil:
+		responseBody = gateway.ResponseBodyBuffered
+	case policySpec.GenAIMetrics != nil:
+		responseBody = gateway.ResponseBodyStreamed
+	}
+	impl := r.resolveGatewayImplementation(ctx, gwConfig)
+	desired := gateway.PromptPolicyExtensionPolicy(impl, routeName, name, failOpen, messageTimeout, responseBody)
+	if desired == nil {
+		logger.Info("Gateway implementation does not support the prompt policy, skipping", "implementation", impl)
+		return notEnforced + ": it requires Envoy Gateway", r.deletePromptPolicy(ctx, md)
+	}
+	gvk := gateway.EnvoyGatewayExtensionPolicyGVK
+	if _, err := r.Client.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version); err != nil {
+		logger.Info("Prompt policy CRD not installed, skipping", "kind", gvk.Kind)
+		return fmt.Sprintf("%s: the %s CRD is not installed", notEnforced, gvk.Kind), nil
+	}
+
+	labels := map[string]string{
+		"app.kubernetes.io/name":       name,
+		"app.kubernetes.io/instance":   md.Name,
+		"app.kubernetes.io/managed-by": "airunway",
+	}
+
+	// ConfigMap holding the policy. The processor only reads it at startup, so the pod
+	// template carries a checksum of the policy to roll the Deployment when it changes.
+	data, err := json.Marshal(policySpec)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode prompt policy: %w", err)
+	}
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: md.Namespace}}
+	if _, err := ctrl.CreateOrUpdate(ctx, r.Client, cm, func() error {
+		cm.Data = map[string]string{gateway.PromptPolicyConfigFile: string(data)}
+		provider.ApplyPropagatedMetadataToObject(cm, md)
+		return ctrl.SetControllerReference(md, cm, r.Scheme)
+	}); err != nil {
+		return "", fmt.Errorf("failed to create/update prompt policy ConfigMap: %w", err)
+	}
+
+	image := r.GatewayDetector.PromptPolicyImage
+	if image == "" {
+		image = gateway.DefaultPromptPolicyImage
+	}
+	port := gateway.PromptPolicyPort
+	ports := []corev1.ContainerPort{{Name: "grpc", ContainerPort: port}}
+	h2c := "kubernetes.io/h2c"
+	svcPorts := []corev1.ServicePort{{Name: "grpc", Protocol: corev1.ProtocolTCP, Port: port, AppProtocol: &h2c}}
+	if policySpec.GenAIMetrics != nil {
+		metricsPort := gateway.PromptPolicyMetricsPort
+		ports = append(ports, corev1.ContainerPort{Name: "metrics", ContainerPort: metricsPort})
+		svcPorts = append(svcPorts, corev1.ServicePort{Name: "metrics", Protocol: corev1.ProtocolTCP, Port: metricsPort})
+	}
+	replicas := int32(1)
+	// The processor never talks to the API server
+	automountToken := false
+	dep := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: md.Namespace}}
+	if _, err := ctrl.CreateOrUpdate(ctx, r.Client, dep, func() error {
+		dep.Spec = appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+					Annotations: map[string]string{
+						gateway.AnnotationPromptPolicyChecksum: gateway.EPPConfigChecksum(string(data)),
+					},
+				},
+				Spec: corev1.PodSpec{
+					AutomountServiceAccountToken: &automountToken,
+					Containers: []corev1.Container{
+						{
+							Name:            "prompt-policy",
+							Image:           image,
+							ImagePullPolicy: corev1.PullIfNotPresent,
+							Command:         []string{"/prompt-policy"},
+							Args:            []string{"--config", "/config/" + gateway.PromptPolicyConfigFile},
+							SecurityContext: gateway.DefaultEPPSecurityContext(),
+							Ports:           ports,
+							ReadinessProbe: &corev1.Probe{
+								ProbeHandler:  corev1.ProbeHandler{GRPC: &corev1.GRPCAction{Port: port}},
+								PeriodSeconds: 5,
+							},
+							LivenessProbe: &corev1.Probe{
+								ProbeHandler:     corev1.ProbeHandler{GRPC: &corev1.GRPCAction{Port: port}},
+								PeriodSeconds:    10,
+								FailureThreshold: 5,
+							},
+							VolumeMounts: []corev1.VolumeMount{{Name: "policy", MountPath: "/config"}},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "policy",
+							VolumeSource: corev1.VolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{
+									LocalObjectReference: corev1.LocalObjectReference{Name: name},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+		provider.ApplyPropagatedMetadataToObject(dep, md)
+		provider.ApplyPropagatedMetadataToObject(&dep.Spec.Template, md)
+		return ctrl.SetControllerReference(md, dep, r.Scheme)
+	}); err != nil {
+		return "", fmt.Errorf("failed to create/update prompt policy Deployment: %w", err)
+	}
+
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: md.Namespace}}
+	if _, err := ctrl.CreateOrUpdate(ctx, r.Client, svc, func() error {
+		svc.Spec.Selector = labels
+		svc.Spec.Ports = svcPorts
+		svc.Spec.Type = corev1.ServiceTypeClusterIP
+		provider.ApplyIPFamiliesToService(&svc.Spec, md)
+		provider.ApplyPropagatedMetadataToObject(svc, md)
+		return ctrl.SetControllerReference(md, svc, r.Scheme)
+	}); err != nil {
+		return "", fmt.Errorf("failed to create/update prompt policy Service: %w", err)
+	}
+
+	policy := &unstructured.Unstructured{}
+	policy.SetGroupVersionKind(gvk)
+	policy.SetName(name)
+	policy.SetNamespace(md.Namespace)
+	if _, err := ctrl.CreateOrUpdate(ctx, r.Client, policy, func() error {
+		policy.Object["spec"] = desired.Object["spec"]
+		provider.ApplyPropagatedMetadataToObject(policy, md)
+		return ctrl.SetControllerReference(md, policy, r.Scheme)
+	}); err != nil {
+		return "", fmt.Errorf("failed to reconcile %s: %w", gvk.Kind, err)
+	}
+	return "", nil
+}
+
+// deletePromptPolicy deletes the prompt policy resources of md
+func (r *ModelDeploymentReconciler) deletePromptPolicy(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) error {
+	name := promptPolicyName(md)
+	objs := []client.Object{
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: md.Namespace}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: md.Namespace}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: md.Namespace}},
+	}
+	gvk := gateway.EnvoyGatewayExtensionPolicyGVK
+	if _, err := r.Client.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version); err == nil {
+		policy := &unstructured.Unstructured{}
+		policy.SetGroupVersionKind(gvk)
+		policy.SetName(name)
+		policy.SetNamespace(md.Namespace)
+		objs = append(objs, policy)
+	}
+	for _, obj := range objs {
+		if err := r.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete prompt policy %T: %w", obj, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	toolscache "k8s.io/client-go/tools/cache"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+// ProviderConfigCache holds the InferenceProviderConfigs in memory for engine and provider
+// selection, with their CEL selection rules compiled. Listing configs from the informer
+// cache copies every config on each call, and compiling their rules dominated selection
+// on clusters with many deployments in flight.
+//
+// The cache is only used once the replica leads: it then subscribes to the
+// InferenceProviderConfig informer, whose events invalidate it, and warms it so the first
+// reconciles after a failover do not compile every rule. Until then List reads through to
+// the client.
+type ProviderConfigCache struct {
+	// Reader lists the configs, normally the informer-backed manager client
+	Reader client.Reader
+
+	mu sync.RWMutex
+	// subscribed is set once informer events invalidate the cache
+	subscribed bool
+	// generation counts invalidations, so a list that raced with one is not cached
+	generation uint64
+	// configs are the cached configs, valid when configsGeneration is generation
+	configs           []airunwayv1alpha1.InferenceProviderConfig
+	configsGeneration uint64
+	valid             bool
+}
+
+// List returns the InferenceProviderConfigs. Callers must not modify the returned configs,
+// which are shared between reconciles.
+func (c *ProviderConfigCache) List(ctx context.Context) ([]airunwayv1alpha1.InferenceProviderConfig, error) {
+	c.mu.RLock()
+	if c.valid && c.configsGeneration == c.generation {
+		configs := c.configs
+		c.mu.RUnlock()
+		return configs, nil
+	}
+	subscribed, generation := c.subscribed, c.generation
+	c.mu.RUnlock()
+
+	var list airunwayv1alpha1.InferenceProviderConfigList
+	if err := c.Reader.List(ctx, &list); err != nil {
+		return nil, err
+	}
+	if !subscribed {
+		return list.Items, nil
+	}
+	expressions := map[string]bool{}
+	for i := range list.Items {
+		for _, rule := range list.Items[i].Spec.SelectionRules {
+			expressions[rule.Condition] = true
+			// Compile errors are reported when the rule is evaluated
+			_, _ = celPrograms.program(rule.Condition)
+		}
+	}
+	celPrograms.retain(expressions)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.generation == generation {
+		c.configs, c.configsGeneration, c.valid = list.Items, generation, true
+	}
+	return list.Items, nil
+}
+
+// invalidate drops the cached configs
+func (c *ProviderConfigCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	c.valid = false
+	c.configs = nil
+}
+
+// Start implements manager.Runnable. It runs once the replica leads, subscribes the cache
+// to the informer events of InferenceProviderConfigs, and warms it.
+func (c *ProviderConfigCache) Start(ctx context.Context) error {
+	c.subscribe()
+	if _, err := c.List(ctx); err != nil {
+		// Reconciles list the configs again
+		log.FromContext(ctx).Info("Could not warm the provider config cache", "error", err.Error())
+	}
+	<-ctx.Done()
+	return nil
+}
+
+// subscribe starts caching the configs listed from now on
+func (c *ProviderConfigCache) subscribe() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.subscribed = true
+	c.generation++
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, so only the leader, which
+// runs provider selection, holds the cache
+func (c *ProviderConfigCache) NeedLeaderElection() bool {
+	return true
+}
+
+// SetupWithManager registers the informer event handler that invalidates the cache and
+// adds the cache to the Manager. Events are handled before Start, so none is missed
+// between the subscription and the first list.
+func (c *ProviderConfigCache) SetupWithManager(mgr ctrl.Manager) error {
+	informer, err := mgr.GetCache().GetInformer(context.Background(), &airunwayv1alpha1.InferenceProviderConfig{})
+	if err != nil {
+		return fmt.Errorf("failed to get the InferenceProviderConfig informer: %w", err)
+	}
+	if _, err := informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc:    func(any) { c.invalidate() },
+		UpdateFunc: func(any, any) { c.invalidate() },
+		DeleteFunc: func(any) { c.invalidate() },
+	}); err != nil {
+		return fmt.Errorf("failed to watch InferenceProviderConfigs: %w", err)
+	}
+	return mgr.Add(c)
+}
+
+// celProgramCache holds compiled CEL selection rules by expression
+type celProgramCache struct {
+	once sync.Once
+	env  *cel.Env
+	err  error
+
+	mu       sync.RWMutex
+	programs map[string]celProgram
+}
+
+// celProgram is a compiled expression, or why it failed to compile
+type celProgram struct {
+	program cel.Program
+	err     error
+}
+
+// celPrograms are the compiled selection rules of every provider config
+var celPrograms = &celProgramCache{programs: map[string]celProgram{}}
+
+// program returns the compiled program of expression, compiling it on first use
+func (c *celProgramCache) program(expression string) (cel.Program, error) {
+	c.mu.RLock()
+	p, ok := c.programs[expression]
+	c.mu.RUnlock()
+	if ok {
+		return p.program, p.err
+	}
+
+	c.once.Do(func() {
+		c.env, c.err = cel.NewEnv(cel.Variable("spec", cel.DynType))
+	})
+	if c.err != nil {
+		return nil, fmt.Errorf("failed to create CEL environment: %w", c.err)
+	}
+	ast, issues := c.env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		p.err = fmt.Errorf("failed to compile CEL expression %q: %w", expression, issues.Err())
+	} else if p.program, p.err = c.env.Program(ast); p.err != nil {
+		p.err = fmt.Errorf("failed to create CEL program: %w", p.err)
+	}
+
+	c.mu.Lock()
+	c.programs[expression] = p
+	c.mu.Unlock()
+	return p.program, p.err
+}
+
+// retain drops the programs of expressions no provider config uses anymore
+func (c *celProgramCache) retain(expressions map[string]bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for expression := range c.programs {
+		if !expressions[expression] {
+			delete(c.programs, expression)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+func TestProviderConfigCache(t *testing.T) {
+	ctx := context.Background()
+	pc := &airunwayv1alpha1.InferenceProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "dynamo"},
+		Spec: airunwayv1alpha1.InferenceProviderConfigSpec{
+			SelectionRules: []airunwayv1alpha1.SelectionRule{{Condition: "spec.serving.mode == 'disaggregated'", Priority: 100}},
+		},
+	}
+	lists := 0
+	c := fake.NewClientBuilder().WithScheme(newTestScheme()).WithObjects(pc).
+		WithInterceptorFuncs(interceptor.Funcs{
+			List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				lists++
+				return c.List(ctx, list, opts...)
+			},
+		}).Build()
+	cache := &ProviderConfigCache{Reader: c}
+	list := func() []airunwayv1alpha1.InferenceProviderConfig {
+		t.Helper()
+		configs, err := cache.List(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return configs
+	}
+
+	// Until the replica leads and subscribes to informer events, configs are listed
+	list()
+	list()
+	if lists != 2 {
+		t.Errorf("expected 2 lists before subscribing, got %d", lists)
+	}
+
+	cache.subscribe()
+	lists = 0
+	list()
+	if configs := list(); len(configs) != 1 || configs[0].Name != "dynamo" {
+		t.Errorf("unexpected configs %+v", configs)
+	}
+	if lists != 1 {
+		t.Errorf("expected the configs to be listed once while cached, got %d lists", lists)
+	}
+	if _, ok := celPrograms.programs[pc.Spec.SelectionRules[0].Condition]; !ok {
+		t.Error("expected the selection rule to be compiled when the configs were cached")
+	}
+
+	// An informer event invalidates the cache
+	cache.invalidate()
+	list()
+	if lists != 2 {
+		t.Errorf("expected the configs to be listed again after an invalidation, got %d lists", lists)
+	}
+}
+
+func TestCELProgramCache(t *testing.T) {
+	programs := &celProgramCache{programs: map[string]celProgram{}}
+	first, err := programs.program("spec.engine.type == 'vllm'")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := programs.program("spec.engine.type == 'vllm'")
+	if err != nil || first != second {
+		t.Errorf("expected the compiled program to be reused, got %v", err)
+	}
+	if _, err := programs.program("spec.engine.type =="); err == nil {
+		t.Error("expected a compile error")
+	}
+	if _, err := programs.program("spec.engine.type =="); err == nil {
+		t.Error("expected the cached compile error")
+	}
+
+	programs.retain(map[string]bool{"spec.engine.type == 'vllm'": true})
+	if len(programs.programs) != 1 {
+		t.Errorf("expected only the program of the retained expression, got %d", len(programs.programs))
+	}
+
+	specMap := map[string]any{"engine": map[string]any{"type": "vllm"}}
+	if matched, err := evaluateCEL("spec.engine.type == 'vllm'", specMap); err != nil || !matched {
+		t.Errorf("expected the expression to match, got %v, %v", matched, err)
+	}
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/pkg/kstatus"
+)
+
+// DefaultProviderHeartbeatTimeout is how long after its last heartbeat a provider is
+// taken out of selection. Provider controllers send a heartbeat every minute.
+const DefaultProviderHeartbeatTimeout = 3 * time.Minute
+
+var providerHeartbeatAgeDesc = prometheus.NewDesc(
+	"kubeairunway_provider_heartbeat_age_seconds",
+	"Seconds since the provider controller of an InferenceProviderConfig last sent a heartbeat.",
+	[]string{"provider"}, nil,
+)
+
+// heartbeatCollector reports the age of the last heartbeat of each provider, computed at
+// scrape time so it keeps growing while a provider is down.
+type heartbeatCollector struct {
+	heartbeats sync.Map
+}
+
+func (c *heartbeatCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- providerHeartbeatAgeDesc
+}
+
+func (c *heartbeatCollector) Collect(ch chan<- prometheus.Metric) {
+	now := time.Now()
+	c.heartbeats.Range(func(provider, heartbeat any) bool {
+		ch <- prometheus.MustNewConstMetric(providerHeartbeatAgeDesc, prometheus.GaugeValue,
+			now.Sub(heartbeat.(time.Time)).Seconds(), provider.(string))
+		return true
+	})
+}
+
+var providerHeartbeats = &heartbeatCollector{}
+
+func init() {
+	metrics.Registry.MustRegister(providerHeartbeats)
+}
+
+// ProviderHeartbeatReconciler takes providers whose controller stopped sending heartbeats
+// out of selection. Provider controllers set status.ready with each heartbeat; once the
+// last heartbeat is older than Timeout, status.ready is set to false until the next one.
+type ProviderHeartbeatReconciler struct {
+	client.Client
+
+	// Timeout is how long after its last heartbeat a provider is stale. Zero uses
+	// DefaultProviderHeartbeatTimeout.
+	Timeout time.Duration
+
+	// Recorder emits events on InferenceProviderConfigs. When nil, no events are emitted.
+	Recorder events.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=airunway.ai,resources=inferenceproviderconfigs/status,verbs=get;update;patch
+
+// Reconcile records the heartbeat age of an InferenceProviderConfig and sets its Heartbeat
+// condition and its Ready condition from status.ready, requeueing for when a fresh heartbeat
+// would become stale.
+func (r *ProviderHeartbeatReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var pc airunwayv1alpha1.InferenceProviderConfig
+	if err := r.Get(ctx, req.NamespacedName, &pc); err != nil {
+		if apierrors.IsNotFound(err) {
+			providerHeartbeats.heartbeats.Delete(req.Name)
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	timeout := r.Timeout
+	if timeout <= 0 {
+		timeout = DefaultProviderHeartbeatTimeout
+	}
+	base := pc.DeepCopy()
+	var requeueAfter time.Duration
+
+	heartbeat := pc.Status.HeartbeatTime()
+	pc.Status.ObservedGeneration = pc.Generation
+	switch {
+	case heartbeat == nil:
+		providerHeartbeats.heartbeats.Delete(pc.Name)
+		pc.Status.Ready = false
+		setHeartbeatCondition(&pc, metav1.ConditionFalse, airunwayv1alpha1.ReasonHeartbeatMissing,
+			"The provider controller has not sent a heartbeat")
+		kstatus.SetReady(&pc.Status.Conditions, pc.Generation, kstatus.InProgress, airunwayv1alpha1.ReasonHeartbeatMissing,
+			"Waiting for the first heartbeat of the provider controller")
+	case time.Since(heartbeat.Time) > timeout:
+		providerHeartbeats.heartbeats.Store(pc.Name, heartbeat.Time)
+		message := fmt.Sprintf("No heartbeat from the provider controller since %s (timeout %s)",
+			heartbeat.UTC().Format(time.RFC3339), timeout)
+		if pc.Status.Ready && r.Recorder != nil {
+			r.Recorder.Eventf(&pc, nil, corev1.EventTypeWarning, airunwayv1alpha1.ReasonHeartbeatStale, "Heartbeat", message)
+		}
+		pc.Status.Ready = false
+		setHeartbeatCondition(&pc, metav1.ConditionFalse, airunwayv1alpha1.ReasonHeartbeatStale, message)
+		kstatus.SetReady(&pc.Status.Conditions, pc.Generation, kstatus.Failed, airunwayv1alpha1.ReasonHeartbeatStale, message)
+	default:
+		providerHeartbeats.heartbeats.Store(pc.Name, heartbeat.Time)
+		setHeartbeatCondition(&pc, metav1.ConditionTrue, airunwayv1alpha1.ReasonHeartbeatReceived,
+			fmt.Sprintf("The provider controller sent a heartbeat within %s", timeout))
+		if pc.Status.Ready {
+			kstatus.SetReady(&pc.Status.Conditions, pc.Generation, kstatus.Current, airunwayv1alpha1.ReasonProviderReady,
+				"The provider controller is ready")
+		} else {
+			kstatus.SetReady(&pc.Status.Conditions, pc.Generation, kstatus.Failed, airunwayv1alpha1.ReasonProviderNotReady,
+				"The provider controller reports not ready, see its other conditions")
+		}
+		requeueAfter = time.Until(heartbeat.Add(timeout)) + time.Second
+	}
+
+	if apiequality.Semantic.DeepEqual(base.Status, pc.Status) {
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+	if base.Status.Ready != pc.Status.Ready {
+		log.FromContext(ctx).Info("Provider heartbeat is stale, marking not ready", "provider", pc.Name)
+	}
+	// The provider controller updates the status with each heartbeat; the optimistic
+	// lock keeps a concurrent heartbeat from being overwritten
+	if err := r.Status().Patch(ctx, &pc, client.MergeFromWithOptions(base, client.MergeFromWithOptimisticLock{})); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// setHeartbeatCondition sets the Heartbeat condition of pc
+func setHeartbeatCondition(pc *airunwayv1alpha1.InferenceProviderConfig, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&pc.Status.Conditions, metav1.Condition{
+		Type:               airunwayv1alpha1.ConditionTypeHeartbeat,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: pc.Generation,
+	})
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ProviderHeartbeatReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&airunwayv1alpha1.InferenceProviderConfig{}).
+		Named("providerheartbeat").
+		Complete(r)
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+func newHeartbeatProvider(name string, ready bool, heartbeat *metav1.Time) *airunwayv1alpha1.InferenceProviderConfig {
+	return &airunwayv1alpha1.InferenceProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: airunwayv1alpha1.InferenceProviderConfigStatus{
+			Ready:             ready,
+			LastHeartbeatTime: heartbeat,
+		},
+	}
+}
+
+func TestProviderHeartbeatReconcile(t *testing.T) {
+	fresh := metav1.NewTime(time.Now().Add(-30 * time.Second))
+	stale := metav1.NewTime(time.Now().Add(-10 * time.Minute))
+	deprecated := newHeartbeatProvider("deprecated", true, nil)
+	deprecated.Status.LastHeartbeat = &fresh
+
+	scheme := newTestScheme()
+	objs := []*airunwayv1alpha1.InferenceProviderConfig{
+		newHeartbeatProvider("fresh", true, &fresh),
+		newHeartbeatProvider("stale", true, &stale),
+		newHeartbeatProvider("missing", false, nil),
+		deprecated,
+	}
+	builder := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&airunwayv1alpha1.InferenceProviderConfig{})
+	for _, obj := range objs {
+		builder = builder.WithObjects(obj)
+	}
+	recorder := events.NewFakeRecorder(10)
+	r := &ProviderHeartbeatReconciler{Client: builder.Build(), Timeout: 3 * time.Minute, Recorder: recorder}
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		wantReady   bool
+		wantReason  string
+		wantRequeue bool
+	}{
+		{name: "fresh", wantReady: true, wantReason: airunwayv1alpha1.ReasonHeartbeatReceived, wantRequeue: true},
+		{name: "stale", wantReady: false, wantReason: airunwayv1alpha1.ReasonHeartbeatStale},
+		{name: "missing", wantReady: false, wantReason: airunwayv1alpha1.ReasonHeartbeatMissing},
+		{name: "deprecated", wantReady: true, wantReason: airunwayv1alpha1.ReasonHeartbeatReceived, wantRequeue: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: tt.name}})
+			if err != nil {
+				t.Fatalf("Reconcile failed: %v", err)
+			}
+			if got := result.RequeueAfter > 0; got != tt.wantRequeue {
+				t.Errorf("expected requeue %v, got %s", tt.wantRequeue, result.RequeueAfter)
+			}
+			if tt.wantRequeue && result.RequeueAfter > 3*time.Minute {
+				t.Errorf("expected requeue before the heartbeat goes stale, got %s", result.RequeueAfter)
+			}
+
+			var pc airunwayv1alpha1.InferenceProviderConfig
+			if err := r.Get(ctx, types.NamespacedName{Name: tt.name}, &pc); err != nil {
+				t.Fatalf("failed to get provider config: %v", err)
+			}
+			if pc.Status.Ready != tt.wantReady {
+				t.Errorf("expected ready %v, got %v", tt.wantReady, pc.Status.Ready)
+			}
+			cond := meta.FindStatusCondition(pc.Status.Conditions, airunwayv1alpha1.ConditionTypeHeartbeat)
+			if cond == nil || cond.Reason != tt.wantReason {
+				t.Errorf("expected Heartbeat condition with reason %s, got %+v", tt.wantReason, cond)
+			}
+			if meta.IsStatusConditionTrue(pc.Status.Conditions, airunwayv1alpha1.ConditionTypeReady) != tt.wantReady {
+				t.Errorf("expected the Ready condition to match ready %v, got %+v", tt.wantReady, pc.Status.Conditions)
+			}
+			if pc.Status.ObservedGeneration != pc.Generation {
+				t.Errorf("expected observedGeneration %d, got %d", pc.Generation, pc.Status.ObservedGeneration)
+			}
+		})
+	}
+
+	select {
+	case event := <-recorder.Events:
+		if event == "" {
+			t.Error("expected a stale heartbeat event")
+		}
+	default:
+		t.Error("expected a stale heartbeat event")
+	}
+	select {
+	case event := <-recorder.Events:
+		t.Errorf("expected a single event, got %q", event)
+	default:
+	}
+
+	// A provider without a heartbeat yet is reconciling, a stale one is stalled
+	var missing, staleProvider airunwayv1alpha1.InferenceProviderConfig
+	if err := r.Get(ctx, types.NamespacedName{Name: "missing"}, &missing); err != nil {
+		t.Fatal(err)
+	}
+	if !meta.IsStatusConditionTrue(missing.Status.Conditions, airunwayv1alpha1.ConditionTypeReconciling) {
+		t.Errorf("expected a provider without heartbeat to be Reconciling, got %+v", missing.Status.Conditions)
+	}
+	if err := r.Get(ctx, types.NamespacedName{Name: "stale"}, &staleProvider); err != nil {
+		t.Fatal(err)
+	}
+	if !meta.IsStatusConditionTrue(staleProvider.Status.Conditions, airunwayv1alpha1.ConditionTypeStalled) {
+		t.Errorf("expected a stale provider to be Stalled, got %+v", staleProvider.Status.Conditions)
+	}
+
+	// A stale provider that was already marked not ready is left unchanged
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "stale"}}); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if len(recorder.Events) != 0 {
+		t.Error("expected no event for a provider already marked not ready")
+	}
+
+	if got := testutil.CollectAndCount(providerHeartbeats); got != 3 {
+		t.Errorf("expected heartbeat age for 3 providers, got %d", got)
+	}
+	if err := r.Delete(ctx, objs[0]); err != nil {
+		t.Fatalf("failed to delete provider config: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "fresh"}}); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if got := testutil.CollectAndCount(providerHeartbeats); got != 2 {
+		t.Errorf("expected the deleted provider to be dropped from the metric, got %d", got)
+	}
+}
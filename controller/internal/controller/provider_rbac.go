@@ -0,0 +1,187 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+// maxReportedPermissions caps the missing permissions listed in the condition message
+const maxReportedPermissions = 10
+
+// ProviderRBACReconciler checks that the ClusterRoles a provider aggregates into the
+// controller role took effect. Providers ship ClusterRoles labeled
+// airunway.ai/aggregate-to-controller=true and airunway.ai/provider=<name>, which the API
+// server aggregates into the provider role of the controller, so installing a provider
+// does not require editing the controller RBAC. Each rule is checked with a
+// SelfSubjectAccessReview and the result is recorded in the PermissionsAggregated
+// condition of the InferenceProviderConfig. The check runs when the controller starts
+// and when the spec of an InferenceProviderConfig changes.
+type ProviderRBACReconciler struct {
+	client.Client
+
+	// Reader lists the aggregated ClusterRoles. Use an uncached reader, such as the
+	// manager's API reader, to avoid caching every ClusterRole in the cluster.
+	Reader client.Reader
+}
+
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles,verbs=get;list
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=selfsubjectaccessreviews,verbs=create
+// +kubebuilder:rbac:groups=airunway.ai,resources=inferenceproviderconfigs/status,verbs=get;update;patch
+
+// Reconcile sets the PermissionsAggregated condition of an InferenceProviderConfig.
+func (r *ProviderRBACReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var pc airunwayv1alpha1.InferenceProviderConfig
+	if err := r.Get(ctx, req.NamespacedName, &pc); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	var roles rbacv1.ClusterRoleList
+	if err := r.Reader.List(ctx, &roles, client.MatchingLabels{
+		airunwayv1alpha1.LabelAggregateToController: "true",
+		airunwayv1alpha1.LabelProvider:              pc.Name,
+	}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to list aggregated ClusterRoles: %w", err)
+	}
+	missing, err := r.missingPermissions(ctx, roles.Items)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	condition := metav1.Condition{
+		Type:               airunwayv1alpha1.ConditionTypePermissionsAggregated,
+		Status:             metav1.ConditionTrue,
+		Reason:             airunwayv1alpha1.ReasonPermissionsGranted,
+		Message:            fmt.Sprintf("The controller holds the permissions of %d aggregated ClusterRoles", len(roles.Items)),
+		ObservedGeneration: pc.Generation,
+	}
+	switch {
+	case len(missing) > 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = airunwayv1alpha1.ReasonPermissionsMissing
+		condition.Message = missingPermissionsMessage(missing)
+	case len(roles.Items) == 0:
+		condition.Reason = airunwayv1alpha1.ReasonNoAggregatedRoles
+		condition.Message = "The provider aggregates no ClusterRoles into the controller role"
+	}
+
+	base := pc.DeepCopy()
+	meta.SetStatusCondition(&pc.Status.Conditions, condition)
+	if apiequality.Semantic.DeepEqual(base.Status, pc.Status) {
+		return ctrl.Result{}, nil
+	}
+	// The provider controller updates the status with each heartbeat; the optimistic
+	// lock keeps a concurrent heartbeat from being overwritten
+	return ctrl.Result{}, r.Status().Patch(ctx, &pc, client.MergeFromWithOptions(base, client.MergeFromWithOptimisticLock{}))
+}
+
+// missingPermissions returns the resource permissions of roles the controller does not
+// hold, as sorted "verb resource.group/subresource" strings. Non-resource URLs are not
+// checked.
+func (r *ProviderRBACReconciler) missingPermissions(ctx context.Context, roles []rbacv1.ClusterRole) ([]string, error) {
+	var missing []string
+	for _, role := range roles {
+		for _, rule := range role.Rules {
+			names := rule.ResourceNames
+			if len(names) == 0 {
+				names = []string{""}
+			}
+			for _, group := range rule.APIGroups {
+				for _, res := range rule.Resources {
+					resource, subresource, _ := strings.Cut(res, "/")
+					for _, verb := range rule.Verbs {
+						for _, name := range names {
+							review := &authorizationv1.SelfSubjectAccessReview{
+								Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+									ResourceAttributes: &authorizationv1.ResourceAttributes{
+										Group:       group,
+										Resource:    resource,
+										Subresource: subresource,
+										Verb:        verb,
+										Name:        name,
+									},
+								},
+							}
+							if err := r.Create(ctx, review); err != nil {
+								return nil, fmt.Errorf("failed to review access to %s: %w", res, err)
+							}
+							if !review.Status.Allowed {
+								missing = append(missing, permissionString(review.Spec.ResourceAttributes))
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	slices.Sort(missing)
+	return slices.Compact(missing), nil
+}
+
+// permissionString formats a permission as "verb resource.group/subresource", followed
+// by the resource name if any
+func permissionString(attrs *authorizationv1.ResourceAttributes) string {
+	s := attrs.Verb + " " + attrs.Resource
+	if attrs.Group != "" {
+		s += "." + attrs.Group
+	}
+	if attrs.Subresource != "" {
+		s += "/" + attrs.Subresource
+	}
+	if attrs.Name != "" {
+		s += " " + attrs.Name
+	}
+	return s
+}
+
+// missingPermissionsMessage lists the first missing permissions
+func missingPermissionsMessage(missing []string) string {
+	listed := missing
+	if len(listed) > maxReportedPermissions {
+		listed = listed[:maxReportedPermissions]
+	}
+	message := fmt.Sprintf("The controller is missing %d permissions of the aggregated ClusterRoles: %s",
+		len(missing), strings.Join(listed, ", "))
+	if len(missing) > len(listed) {
+		message += ", ..."
+	}
+	return message + ". Check that the provider ClusterRoles are aggregated into the controller role"
+}
+
+// SetupWithManager sets up the controller with the Manager. Status updates, such as
+// heartbeats, do not change the generation and do not trigger a check.
+func (r *ProviderRBACReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&airunwayv1alpha1.InferenceProviderConfig{}).
+		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		Named("providerrbac").
+		Complete(r)
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+func newAggregatedRole(name, provider string, rules ...rbacv1.PolicyRule) *rbacv1.ClusterRole {
+	return &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{
+			airunwayv1alpha1.LabelAggregateToController: "true",
+			airunwayv1alpha1.LabelProvider:              provider,
+		}},
+		Rules: rules,
+	}
+}
+
+func TestProviderRBACReconcile(t *testing.T) {
+	scheme := newTestScheme()
+	// The controller may read workspaces, but not their status
+	allowed := map[string]bool{"get workspaces": true, "list workspaces": true}
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(
+			&airunwayv1alpha1.InferenceProviderConfig{ObjectMeta: metav1.ObjectMeta{Name: "kaito"}},
+			&airunwayv1alpha1.InferenceProviderConfig{ObjectMeta: metav1.ObjectMeta{Name: "kuberay"}},
+			&airunwayv1alpha1.InferenceProviderConfig{ObjectMeta: metav1.ObjectMeta{Name: "llmd"}},
+			newAggregatedRole("airunway-kaito-controller-aggregate", "kaito", rbacv1.PolicyRule{
+				APIGroups: []string{"kaito.sh"},
+				Resources: []string{"workspaces", "workspaces/status"},
+				Verbs:     []string{"get", "list"},
+			}),
+			newAggregatedRole("airunway-kuberay-controller-aggregate", "kuberay", rbacv1.PolicyRule{
+				APIGroups: []string{"kaito.sh"},
+				Resources: []string{"workspaces"},
+				Verbs:     []string{"get"},
+			}),
+		).
+		WithStatusSubresource(&airunwayv1alpha1.InferenceProviderConfig{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if review, ok := obj.(*authorizationv1.SelfSubjectAccessReview); ok {
+					attrs := review.Spec.ResourceAttributes
+					resource := attrs.Resource
+					if attrs.Subresource != "" {
+						resource += "/" + attrs.Subresource
+					}
+					review.Status.Allowed = allowed[attrs.Verb+" "+resource]
+					return nil
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+		}).
+		Build()
+	r := &ProviderRBACReconciler{Client: c, Reader: c}
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		wantStatus  metav1.ConditionStatus
+		wantReason  string
+		wantMessage string
+	}{
+		{
+			name:        "kaito",
+			wantStatus:  metav1.ConditionFalse,
+			wantReason:  airunwayv1alpha1.ReasonPermissionsMissing,
+			wantMessage: "missing 2 permissions of the aggregated ClusterRoles: get workspaces.kaito.sh/status, list workspaces.kaito.sh/status",
+		},
+		{name: "kuberay", wantStatus: metav1.ConditionTrue, wantReason: airunwayv1alpha1.ReasonPermissionsGranted},
+		{name: "llmd", wantStatus: metav1.ConditionTrue, wantReason: airunwayv1alpha1.ReasonNoAggregatedRoles},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := types.NamespacedName{Name: tt.name}
+			if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+				t.Fatalf("Reconcile failed: %v", err)
+			}
+			var pc airunwayv1alpha1.InferenceProviderConfig
+			if err := c.Get(ctx, key, &pc); err != nil {
+				t.Fatal(err)
+			}
+			cond := meta.FindStatusCondition(pc.Status.Conditions, airunwayv1alpha1.ConditionTypePermissionsAggregated)
+			if cond == nil || cond.Status != tt.wantStatus || cond.Reason != tt.wantReason {
+				t.Fatalf("expected %s %s, got %+v", tt.wantStatus, tt.wantReason, cond)
+			}
+			if !strings.Contains(cond.Message, tt.wantMessage) {
+				t.Errorf("expected message to contain %q, got %q", tt.wantMessage, cond.Message)
+			}
+		})
+	}
+}
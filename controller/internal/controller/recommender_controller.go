@@ -0,0 +1,405 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlbuilder "sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/internal/recommender"
+)
+
+const (
+	// DefaultRecommenderInterval is how often usage is sampled for running deployments.
+	DefaultRecommenderInterval = time.Minute
+
+	// DefaultAutotuneWindow is how long usage must be observed before autotune applies a recommendation.
+	DefaultAutotuneWindow = time.Hour
+
+	// DefaultGPUIdleThreshold is the mean GPU utilization, in percent, below which the GPUs of a deployment are idle.
+	DefaultGPUIdleThreshold = 5.0
+)
+
+// gpuUtilizationPercent and gpuIdleSeconds export status.gpuUtilization, so idle models
+// can be alerted on or scaled to zero by metric-driven autoscalers.
+var (
+	gpuUtilizationPercent = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kubeairunway_modeldeployment_gpu_utilization_percent",
+		Help: "Mean utilization of the GPUs of a ModelDeployment at the last DCGM sample.",
+	}, []string{"namespace", "name"})
+	gpuIdleSeconds = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kubeairunway_modeldeployment_gpu_idle_seconds",
+		Help: "Seconds the GPUs of a ModelDeployment have been below the idle threshold, or 0 while busy.",
+	}, []string{"namespace", "name"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(gpuUtilizationPercent, gpuIdleSeconds)
+}
+
+// ResourceRecommenderReconciler samples the usage of running ModelDeployments and
+// records right-sizing recommendations in status.recommendations. When
+// spec.resources.autotune is set, it also applies them within autotuneBounds.
+type ResourceRecommenderReconciler struct {
+	client.Client
+
+	// Sources provide usage samples. Results of all sources are merged.
+	Sources []recommender.UsageSource
+
+	// Interval is how often usage is sampled. Defaults to DefaultRecommenderInterval.
+	Interval time.Duration
+
+	// AutotuneWindow is how long usage must be observed for a spec generation before
+	// autotune applies a recommendation. Defaults to DefaultAutotuneWindow.
+	AutotuneWindow time.Duration
+
+	// GPUUtilization samples the GPU utilization recorded in status.gpuUtilization. When
+	// nil, GPU utilization is not sampled.
+	GPUUtilization recommender.GPUUtilizationSource
+
+	// GPUIdleThreshold is the mean GPU utilization, in percent, below which the GPUs of a
+	// deployment are idle. Defaults to DefaultGPUIdleThreshold.
+	GPUIdleThreshold float64
+
+	// Recorder emits events on ModelDeployments. When nil, no events are emitted.
+	Recorder events.EventRecorder
+
+	// Sharding limits the sampled ModelDeployments to the shard of the controller
+	Sharding Sharding
+}
+
+// +kubebuilder:rbac:groups=metrics.k8s.io,resources=pods,verbs=get;list
+
+// Reconcile samples usage for a ModelDeployment and updates its recommendations.
+func (r *ResourceRecommenderReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	interval := r.Interval
+	if interval <= 0 {
+		interval = DefaultRecommenderInterval
+	}
+
+	var md airunwayv1alpha1.ModelDeployment
+	if err := r.Get(ctx, req.NamespacedName, &md); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			forgetGPUUtilization(req.Namespace, req.Name)
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !md.DeletionTimestamp.IsZero() || md.IsPaused() || !r.Sharding.Owns(&md) {
+		forgetGPUUtilization(md.Namespace, md.Name)
+		return ctrl.Result{}, nil
+	}
+	if md.Status.Phase != airunwayv1alpha1.DeploymentPhaseRunning {
+		forgetGPUUtilization(md.Namespace, md.Name)
+		if md.Status.GPUUtilization != nil {
+			// GPUs of stopped pods are neither busy nor idle
+			base := md.DeepCopy()
+			md.Status.GPUUtilization = nil
+			if err := r.Status().Patch(ctx, &md, client.MergeFrom(base)); err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to clear GPU utilization: %w", err)
+			}
+		}
+		return ctrl.Result{RequeueAfter: interval}, nil
+	}
+
+	pods, err := modelPods(ctx, r.Client, &md)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if len(pods) == 0 {
+		return ctrl.Result{RequeueAfter: interval}, nil
+	}
+
+	var sample recommender.Usage
+	var sources []string
+	for _, source := range r.Sources {
+		usage, err := source.Sample(ctx, pods)
+		if err != nil {
+			logger.V(1).Info("Could not sample usage", "source", source.Name(), "error", err)
+			continue
+		}
+		if usage.IsZero() {
+			continue
+		}
+		sample = sample.Max(usage)
+		sources = append(sources, source.Name())
+	}
+	gpu, gpuSampled := r.sampleGPUUtilization(ctx, &md, pods)
+	if sample.IsZero() && !gpuSampled {
+		return ctrl.Result{RequeueAfter: interval}, nil
+	}
+
+	base := md.DeepCopy()
+	now := metav1.Now()
+	var recs recommender.Recommendation
+	if !sample.IsZero() {
+		recs = r.updateRecommendations(&md, sample, sources, now)
+	}
+	if gpuSampled {
+		r.updateGPUUtilization(&md, gpu, now)
+	}
+	if err := r.Status().Patch(ctx, &md, client.MergeFrom(base)); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to update recommendations: %w", err)
+	}
+
+	if !sample.IsZero() {
+		if err := r.autotune(ctx, &md, recs, now); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	return ctrl.Result{RequeueAfter: interval}, nil
+}
+
+// sampleGPUUtilization samples the GPU utilization of pods. It returns false when GPU
+// utilization is not sampled, sampling failed, or no GPU of the pods was observed.
+func (r *ResourceRecommenderReconciler) sampleGPUUtilization(ctx context.Context, md *airunwayv1alpha1.ModelDeployment, pods []corev1.Pod) (recommender.GPUUtilization, bool) {
+	if r.GPUUtilization == nil {
+		return recommender.GPUUtilization{}, false
+	}
+	util, err := r.GPUUtilization.SampleGPUUtilization(ctx, pods)
+	if err != nil {
+		log.FromContext(ctx).V(1).Info("Could not sample GPU utilization", "error", err)
+	}
+	if err != nil || util.GPUs == 0 {
+		forgetGPUUtilization(md.Namespace, md.Name)
+		return recommender.GPUUtilization{}, false
+	}
+	return util, true
+}
+
+// updateGPUUtilization records util in status.gpuUtilization and the GPU utilization
+// metrics. The idle clock starts at the first sample below the idle threshold and
+// stops at the first sample above it. The peak restarts when the spec generation
+// changes, like the usage peaks.
+func (r *ResourceRecommenderReconciler) updateGPUUtilization(md *airunwayv1alpha1.ModelDeployment, util recommender.GPUUtilization, now metav1.Time) {
+	status := md.Status.GPUUtilization
+	if status == nil || status.ObservedGeneration != md.Generation {
+		var idleSince *metav1.Time
+		if status != nil {
+			idleSince = status.IdleSince
+		}
+		status = &airunwayv1alpha1.GPUUtilizationStatus{
+			ObservedGeneration: md.Generation,
+			IdleSince:          idleSince,
+		}
+		md.Status.GPUUtilization = status
+	}
+
+	peak := util.Percent
+	if prev, err := strconv.ParseFloat(status.PeakPercent, 64); err == nil && prev > peak {
+		peak = prev
+	}
+	status.Percent = formatPercent(util.Percent)
+	status.PeakPercent = formatPercent(peak)
+	status.GPUs = int32(util.GPUs)
+	status.LastUpdateTime = now
+
+	threshold := r.GPUIdleThreshold
+	if threshold <= 0 {
+		threshold = DefaultGPUIdleThreshold
+	}
+	if util.Percent >= threshold {
+		status.IdleSince = nil
+	} else if status.IdleSince == nil {
+		status.IdleSince = &now
+	}
+
+	var idle float64
+	if status.IdleSince != nil {
+		idle = now.Sub(status.IdleSince.Time).Seconds()
+	}
+	gpuUtilizationPercent.WithLabelValues(md.Namespace, md.Name).Set(util.Percent)
+	gpuIdleSeconds.WithLabelValues(md.Namespace, md.Name).Set(idle)
+}
+
+// forgetGPUUtilization removes the GPU utilization metrics of a ModelDeployment
+func forgetGPUUtilization(namespace, name string) {
+	gpuUtilizationPercent.DeleteLabelValues(namespace, name)
+	gpuIdleSeconds.DeleteLabelValues(namespace, name)
+}
+
+// formatPercent formats a percentage as a decimal string with one decimal
+func formatPercent(v float64) string {
+	return strconv.FormatFloat(v, 'f', 1, 64)
+}
+
+// updateRecommendations folds sample into the observed peaks and recomputes the
+// recommendation. Peaks restart when the spec generation changes, since new
+// resources or engine settings invalidate earlier usage.
+func (r *ResourceRecommenderReconciler) updateRecommendations(md *airunwayv1alpha1.ModelDeployment, sample recommender.Usage, sources []string, now metav1.Time) recommender.Recommendation {
+	recs := md.Status.Recommendations
+	if recs == nil || recs.ObservedGeneration != md.Generation {
+		var lastApplied *metav1.Time
+		if recs != nil {
+			lastApplied = recs.LastAppliedTime
+		}
+		recs = &airunwayv1alpha1.ResourceRecommendations{
+			ObservedGeneration: md.Generation,
+			ObservedSince:      &now,
+			LastAppliedTime:    lastApplied,
+		}
+		md.Status.Recommendations = recs
+	}
+
+	peak := sample.Max(recommender.Usage{
+		CPU:       parseQuantityOrZero(recs.PeakCPU),
+		Memory:    parseQuantityOrZero(recs.PeakMemory),
+		GPUMemory: parseQuantityOrZero(recs.PeakGPUMemory),
+	})
+	rec := recommender.Recommend(peak)
+
+	recs.PeakCPU = quantityString(peak.CPU)
+	recs.PeakMemory = quantityString(peak.Memory)
+	recs.PeakGPUMemory = quantityString(peak.GPUMemory)
+	recs.CPU = quantityString(rec.CPU)
+	recs.Memory = quantityString(rec.Memory)
+	for _, name := range sources {
+		if !slices.Contains(recs.Sources, name) {
+			recs.Sources = append(recs.Sources, name)
+		}
+	}
+	recs.LastUpdateTime = &now
+	return rec
+}
+
+// autotune applies rec to spec.resources when autotune is enabled and usage has
+// been observed for the full window.
+func (r *ResourceRecommenderReconciler) autotune(ctx context.Context, md *airunwayv1alpha1.ModelDeployment, rec recommender.Recommendation, now metav1.Time) error {
+	resources := md.Spec.Resources
+	if resources == nil || !resources.Autotune || resources.AutotuneBounds == nil {
+		return nil
+	}
+	window := r.AutotuneWindow
+	if window <= 0 {
+		window = DefaultAutotuneWindow
+	}
+	recs := md.Status.Recommendations
+	if recs.ObservedSince == nil || now.Sub(recs.ObservedSince.Time) < window {
+		return nil
+	}
+
+	cpu, memory, changed, err := recommender.Apply(resources, rec)
+	if err != nil {
+		log.FromContext(ctx).Info("Skipping autotune", "error", err)
+		return nil
+	}
+	if !changed {
+		return nil
+	}
+
+	statusBase := md.DeepCopy()
+	recs.LastAppliedTime = &now
+	if err := r.Status().Patch(ctx, md, client.MergeFrom(statusBase)); err != nil {
+		return fmt.Errorf("failed to record autotune: %w", err)
+	}
+
+	base := md.DeepCopy()
+	md.Spec.Resources.CPU = cpu
+	md.Spec.Resources.Memory = memory
+	if err := r.Patch(ctx, md, client.MergeFrom(base)); err != nil {
+		return fmt.Errorf("failed to apply autotuned resources: %w", err)
+	}
+
+	message := fmt.Sprintf("Autotuned resources to cpu=%s memory=%s", cpu, memory)
+	log.FromContext(ctx).Info(message, "name", md.Name)
+	if r.Recorder != nil {
+		r.Recorder.Eventf(md, nil, corev1.EventTypeNormal, airunwayv1alpha1.ReasonResourcesAutotuned, "Autotune", message)
+	}
+	return nil
+}
+
+// modelPods returns the running pods of a ModelDeployment. Pods are matched by the
+// model-deployment label, falling back to the selector of the endpoint Service.
+func modelPods(ctx context.Context, c client.Reader, md *airunwayv1alpha1.ModelDeployment) ([]corev1.Pod, error) {
+	var pods corev1.PodList
+	if err := c.List(ctx, &pods,
+		client.InNamespace(md.Namespace),
+		client.MatchingLabels{airunwayv1alpha1.LabelModelDeployment: md.Name},
+	); err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	if len(pods.Items) == 0 && md.Status.Endpoint != nil && md.Status.Endpoint.Service != "" {
+		var svc corev1.Service
+		if err := c.Get(ctx, client.ObjectKey{Name: md.Status.Endpoint.Service, Namespace: md.Namespace}, &svc); err != nil {
+			return nil, client.IgnoreNotFound(err)
+		}
+		if len(svc.Spec.Selector) == 0 {
+			return nil, nil
+		}
+		if err := c.List(ctx, &pods,
+			client.InNamespace(md.Namespace),
+			client.MatchingLabels(svc.Spec.Selector),
+		); err != nil {
+			return nil, fmt.Errorf("failed to list pods: %w", err)
+		}
+	}
+
+	running := make([]corev1.Pod, 0, len(pods.Items))
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == corev1.PodRunning {
+			running = append(running, pod)
+		}
+	}
+	return running, nil
+}
+
+// SetupWithManager sets up the controller with the Manager. Sampling is driven by
+// RequeueAfter, so status-only updates from other controllers are ignored.
+func (r *ResourceRecommenderReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&airunwayv1alpha1.ModelDeployment{},
+			ctrlbuilder.WithPredicates(
+				predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{}),
+				r.Sharding.Predicate())).
+		Named("modeldeployment-recommender").
+		Complete(r)
+}
+
+func parseQuantityOrZero(s string) resource.Quantity {
+	if s == "" {
+		return resource.Quantity{}
+	}
+	q, err := resource.ParseQuantity(s)
+	if err != nil {
+		return resource.Quantity{}
+	}
+	return q
+}
+
+func quantityString(q resource.Quantity) string {
+	if q.IsZero() {
+		return ""
+	}
+	return q.String()
+}
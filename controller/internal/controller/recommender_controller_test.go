@@ -0,0 +1,240 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/internal/recommender"
+)
+
+// staticUsageSource returns a fixed usage sample.
+type staticUsageSource struct {
+	usage recommender.Usage
+}
+
+func (s *staticUsageSource) Name() string { return "static" }
+
+func (s *staticUsageSource) Sample(context.Context, []corev1.Pod) (recommender.Usage, error) {
+	return s.usage, nil
+}
+
+func newRecommenderTestPod(mdName string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      mdName + "-0",
+			Namespace: "default",
+			Labels:    map[string]string{airunwayv1alpha1.LabelModelDeployment: mdName},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+}
+
+func newTestRecommender(source recommender.UsageSource, objs ...client.Object) *ResourceRecommenderReconciler {
+	c := fake.NewClientBuilder().
+		WithScheme(newTestScheme()).
+		WithStatusSubresource(&airunwayv1alpha1.ModelDeployment{}).
+		WithObjects(objs...).
+		Build()
+	return &ResourceRecommenderReconciler{Client: c, Sources: []recommender.UsageSource{source}}
+}
+
+func reconcileRecommender(t *testing.T, r *ResourceRecommenderReconciler, name string) *airunwayv1alpha1.ModelDeployment {
+	t.Helper()
+	ctx := context.Background()
+	key := types.NamespacedName{Name: name, Namespace: "default"}
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	if err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+	if result.RequeueAfter != DefaultRecommenderInterval {
+		t.Errorf("expected requeue after %s, got %s", DefaultRecommenderInterval, result.RequeueAfter)
+	}
+	var md airunwayv1alpha1.ModelDeployment
+	if err := r.Get(ctx, key, &md); err != nil {
+		t.Fatalf("failed to get ModelDeployment: %v", err)
+	}
+	return &md
+}
+
+func TestRecommender_RecordsPeakUsage(t *testing.T) {
+	md := newModelDeployment("test-model", "default")
+	md.Generation = 1
+	source := &staticUsageSource{usage: recommender.Usage{
+		CPU:    resource.MustParse("2"),
+		Memory: resource.MustParse("20Gi"),
+	}}
+	r := newTestRecommender(source, md, newRecommenderTestPod(md.Name))
+
+	got := reconcileRecommender(t, r, md.Name)
+	recs := got.Status.Recommendations
+	if recs == nil {
+		t.Fatal("expected recommendations")
+	}
+	if recs.PeakCPU != "2" || recs.PeakMemory != "20Gi" || recs.CPU != "2400m" || recs.Memory != "24Gi" {
+		t.Errorf("unexpected recommendations %+v", recs)
+	}
+	if len(recs.Sources) != 1 || recs.Sources[0] != "static" {
+		t.Errorf("expected source static, got %v", recs.Sources)
+	}
+	if recs.ObservedGeneration != 1 || recs.ObservedSince == nil {
+		t.Errorf("expected observation to start for generation 1, got %+v", recs)
+	}
+
+	// Lower usage keeps the previous peak
+	source.usage = recommender.Usage{CPU: resource.MustParse("1"), Memory: resource.MustParse("10Gi")}
+	got = reconcileRecommender(t, r, md.Name)
+	if got.Status.Recommendations.PeakMemory != "20Gi" {
+		t.Errorf("expected peak memory to be kept, got %s", got.Status.Recommendations.PeakMemory)
+	}
+
+	// A spec change restarts observation
+	got.Generation = 2
+	got.Spec.Engine.Type = airunwayv1alpha1.EngineTypeVLLM
+	if err := r.Update(context.Background(), got); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	got = reconcileRecommender(t, r, md.Name)
+	if got.Status.Recommendations.ObservedGeneration != got.Generation || got.Status.Recommendations.PeakMemory != "10Gi" {
+		t.Errorf("expected peaks to restart after a spec change, got %+v", got.Status.Recommendations)
+	}
+}
+
+func TestRecommender_SkipsUntilRunning(t *testing.T) {
+	md := newModelDeployment("test-model", "default")
+	md.Status.Phase = airunwayv1alpha1.DeploymentPhaseDeploying
+	r := newTestRecommender(&staticUsageSource{usage: recommender.Usage{CPU: resource.MustParse("1")}},
+		md, newRecommenderTestPod(md.Name))
+
+	got := reconcileRecommender(t, r, md.Name)
+	if got.Status.Recommendations != nil {
+		t.Errorf("expected no recommendations before Running, got %+v", got.Status.Recommendations)
+	}
+}
+
+func TestRecommender_Autotune(t *testing.T) {
+	md := newModelDeployment("test-model", "default")
+	md.Generation = 1
+	md.Spec.Resources = &airunwayv1alpha1.ResourceSpec{
+		CPU:      "8",
+		Memory:   "64Gi",
+		Autotune: true,
+		AutotuneBounds: &airunwayv1alpha1.AutotuneBounds{
+			MinCPU:    "4",
+			MinMemory: "16Gi",
+			MaxMemory: "128Gi",
+		},
+	}
+	source := &staticUsageSource{usage: recommender.Usage{
+		CPU:    resource.MustParse("1"),
+		Memory: resource.MustParse("20Gi"),
+	}}
+	r := newTestRecommender(source, md, newRecommenderTestPod(md.Name))
+	recorder := events.NewFakeRecorder(1)
+	r.Recorder = recorder
+
+	// Not applied before the observation window has elapsed
+	got := reconcileRecommender(t, r, md.Name)
+	if got.Spec.Resources.CPU != "8" || got.Spec.Resources.Memory != "64Gi" {
+		t.Fatalf("expected resources unchanged within the window, got %+v", got.Spec.Resources)
+	}
+
+	r.AutotuneWindow = time.Nanosecond
+	got = reconcileRecommender(t, r, md.Name)
+	if got.Spec.Resources.CPU != "4" {
+		t.Errorf("expected cpu clamped to minCPU 4, got %s", got.Spec.Resources.CPU)
+	}
+	if got.Spec.Resources.Memory != "24Gi" {
+		t.Errorf("expected memory 24Gi, got %s", got.Spec.Resources.Memory)
+	}
+	if got.Status.Recommendations.LastAppliedTime == nil {
+		t.Error("expected lastAppliedTime to be set")
+	}
+	select {
+	case e := <-recorder.Events:
+		if !strings.Contains(e, "Normal "+airunwayv1alpha1.ReasonResourcesAutotuned) {
+			t.Errorf("unexpected event %q", e)
+		}
+	default:
+		t.Error("expected a ResourcesAutotuned event")
+	}
+}
+
+// staticGPUUtilizationSource returns a fixed GPU utilization sample.
+type staticGPUUtilizationSource struct {
+	util recommender.GPUUtilization
+}
+
+func (s *staticGPUUtilizationSource) SampleGPUUtilization(context.Context, []corev1.Pod) (recommender.GPUUtilization, error) {
+	return s.util, nil
+}
+
+func TestRecommender_RecordsGPUUtilization(t *testing.T) {
+	md := newModelDeployment("gpu-model", "default")
+	md.Generation = 1
+	gpu := &staticGPUUtilizationSource{util: recommender.GPUUtilization{GPUs: 2, Percent: 40}}
+	r := newTestRecommender(&staticUsageSource{}, md, newRecommenderTestPod(md.Name))
+	r.GPUUtilization = gpu
+	metric := gpuUtilizationPercent.WithLabelValues("default", md.Name)
+
+	// Recorded without any other usage
+	got := reconcileRecommender(t, r, md.Name)
+	status := got.Status.GPUUtilization
+	if status == nil || status.Percent != "40.0" || status.PeakPercent != "40.0" || status.GPUs != 2 || status.IdleSince != nil {
+		t.Fatalf("unexpected GPU utilization %+v", status)
+	}
+	if got.Status.Recommendations != nil {
+		t.Errorf("expected no recommendations without usage, got %+v", got.Status.Recommendations)
+	}
+	if v := testutil.ToFloat64(metric); v != 40 {
+		t.Errorf("expected utilization metric 40, got %v", v)
+	}
+
+	// Dropping below the threshold starts the idle clock, which keeps running while idle
+	gpu.util.Percent = 2
+	got = reconcileRecommender(t, r, md.Name)
+	idleSince := got.Status.GPUUtilization.IdleSince
+	if idleSince == nil || got.Status.GPUUtilization.PeakPercent != "40.0" {
+		t.Fatalf("expected idle GPUs with the peak kept, got %+v", got.Status.GPUUtilization)
+	}
+	gpu.util.Percent = 3
+	got = reconcileRecommender(t, r, md.Name)
+	if !got.Status.GPUUtilization.IdleSince.Equal(idleSince) {
+		t.Errorf("expected idleSince to be kept, got %v", got.Status.GPUUtilization.IdleSince)
+	}
+
+	// Busy GPUs stop the idle clock
+	gpu.util.Percent = 60
+	got = reconcileRecommender(t, r, md.Name)
+	if got.Status.GPUUtilization.IdleSince != nil || got.Status.GPUUtilization.PeakPercent != "60.0" {
+		t.Errorf("expected busy GPUs with a new peak, got %+v", got.Status.GPUUtilization)
+	}
+	if v := testutil.ToFloat64(gpuIdleSeconds.WithLabelValues("default", md.Name)); v != 0 {
+		t.Errorf("expected idle seconds 0 while busy, got %v", v)
+	}
+
+	// Stopped pods clear the utilization
+	got.Status.Phase = airunwayv1alpha1.DeploymentPhaseDeploying
+	if err := r.Status().Update(context.Background(), got); err != nil {
+		t.Fatalf("status update failed: %v", err)
+	}
+	got = reconcileRecommender(t, r, md.Name)
+	if got.Status.GPUUtilization != nil {
+		t.Errorf("expected GPU utilization to be cleared, got %+v", got.Status.GPUUtilization)
+	}
+	if n := testutil.CollectAndCount(gpuUtilizationPercent); n != 0 {
+		t.Errorf("expected the utilization metric to be removed, got %d series", n)
+	}
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// Steps of a ModelDeployment reconcile timed by the kubeairunway_reconcile_step_duration_seconds metric
+const (
+	reconcileStepEngineSelection   = "engine_selection"
+	reconcileStepValidation        = "validation"
+	reconcileStepProviderSelection = "provider_selection"
+	reconcileStepGateway           = "gateway"
+	reconcileStepStatus            = "status"
+)
+
+// reconcileStepDuration breaks the ModelDeployment reconcile time down by step, so slow
+// steps such as the gateway can be told apart from API server latency
+var reconcileStepDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "kubeairunway_reconcile_step_duration_seconds",
+	Help:    "Time spent in each step of a ModelDeployment reconcile.",
+	Buckets: []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+}, []string{"step"})
+
+func init() {
+	metrics.Registry.MustRegister(reconcileStepDuration)
+}
+
+// observeReconcileStep records the time since start for step
+func observeReconcileStep(step string, start time.Time) {
+	reconcileStepDuration.WithLabelValues(step).Observe(time.Since(start).Seconds())
+}
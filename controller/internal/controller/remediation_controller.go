@@ -0,0 +1,219 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlbuilder "sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/internal/remediation"
+)
+
+const (
+	// DefaultRemediationInterval is how often the pods of deployments with
+	// spec.engine.remediation are checked for crash loops.
+	DefaultRemediationInterval = 30 * time.Second
+
+	// defaultRemediationAttempts is the default of spec.engine.remediation.maxAttempts
+	defaultRemediationAttempts = 3
+
+	// crashLoopBackOff is the waiting reason of a crash-looping container
+	crashLoopBackOff = "CrashLoopBackOff"
+)
+
+// EngineRemediationReconciler applies fallback engine settings to ModelDeployments with
+// spec.engine.remediation whose engine crash-loops with a recognized error. It reads the
+// previous logs of a crash-looping container, applies the next fallback for the error to
+// the spec, and records the attempt in status.remediation. Pods created before the last
+// attempt are ignored, so each fallback is judged by the pods that run with it.
+type EngineRemediationReconciler struct {
+	client.Client
+
+	// Logs reads the logs of crashed containers
+	Logs remediation.LogSource
+
+	// Interval is how often pods are checked. Defaults to DefaultRemediationInterval.
+	Interval time.Duration
+
+	// Recorder emits events on ModelDeployments. When nil, no events are emitted.
+	Recorder events.EventRecorder
+
+	// Sharding limits the checked ModelDeployments to the shard of the controller
+	Sharding Sharding
+}
+
+// +kubebuilder:rbac:groups="",resources=pods/log,verbs=get
+
+// Reconcile checks the pods of a ModelDeployment for a crash-looping engine.
+func (r *EngineRemediationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	interval := r.Interval
+	if interval <= 0 {
+		interval = DefaultRemediationInterval
+	}
+
+	var md airunwayv1alpha1.ModelDeployment
+	if err := r.Get(ctx, req.NamespacedName, &md); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !md.DeletionTimestamp.IsZero() || md.IsPaused() || !r.Sharding.Owns(&md) || !md.RemediationEnabled() {
+		return ctrl.Result{}, nil
+	}
+	status := md.Status.Remediation
+	if status != nil && status.Exhausted {
+		if status.ObservedGeneration == md.Generation {
+			return ctrl.Result{}, nil
+		}
+		// The spec changed since remediation stopped: start over
+		base := md.DeepCopy()
+		md.Status.Remediation = nil
+		if err := r.Status().Patch(ctx, &md, client.MergeFrom(base)); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to reset remediation: %w", err)
+		}
+		status = nil
+	}
+	if md.Status.Phase == airunwayv1alpha1.DeploymentPhaseRunning {
+		return ctrl.Result{RequeueAfter: interval}, nil
+	}
+
+	var since time.Time
+	if status != nil && len(status.Attempts) > 0 {
+		since = status.Attempts[len(status.Attempts)-1].Time.Time
+	}
+	pod, container := r.crashLoopingContainer(ctx, &md, since)
+	if pod == nil {
+		return ctrl.Result{RequeueAfter: interval}, nil
+	}
+	logs, err := r.Logs.PreviousLogs(ctx, pod, container)
+	if err != nil {
+		logger.V(1).Info("Could not read the logs of a crashed container", "pod", pod.Name, "container", container, "error", err)
+		return ctrl.Result{RequeueAfter: interval}, nil
+	}
+	reason := remediation.Classify(logs)
+	if reason == "" {
+		logger.V(1).Info("Crash loop without a recognized error", "pod", pod.Name, "container", container)
+		return ctrl.Result{RequeueAfter: interval}, nil
+	}
+
+	maxAttempts := int(md.Spec.Engine.Remediation.MaxAttempts)
+	if maxAttempts <= 0 {
+		maxAttempts = defaultRemediationAttempts
+	}
+	if status != nil && len(status.Attempts) >= maxAttempts {
+		return ctrl.Result{}, r.exhaust(ctx, &md, fmt.Sprintf("The engine still fails with %s after %d fallbacks", reason, len(status.Attempts)))
+	}
+	fallback, ok := remediation.Next(&md, reason, logs)
+	if !ok {
+		return ctrl.Result{}, r.exhaust(ctx, &md, fmt.Sprintf("No fallback is left for %s", reason))
+	}
+
+	statusBase := md.DeepCopy()
+	if md.Status.Remediation == nil {
+		md.Status.Remediation = &airunwayv1alpha1.RemediationStatus{}
+	}
+	md.Status.Remediation.Attempts = append(md.Status.Remediation.Attempts, airunwayv1alpha1.RemediationAttempt{
+		Time:   metav1.Now(),
+		Reason: reason,
+		Action: fallback.Action,
+		Pod:    pod.Name,
+	})
+	if err := r.Status().Patch(ctx, &md, client.MergeFrom(statusBase)); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to record remediation: %w", err)
+	}
+
+	base := md.DeepCopy()
+	fallback.Apply(&md.Spec)
+	if err := r.Patch(ctx, &md, client.MergeFrom(base)); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to apply remediation: %w", err)
+	}
+
+	message := fmt.Sprintf("Engine crash-looped with %s, applied %s (attempt %d of %d)",
+		reason, fallback.Action, len(md.Status.Remediation.Attempts), maxAttempts)
+	logger.Info(message, "name", md.Name, "pod", pod.Name)
+	if r.Recorder != nil {
+		r.Recorder.Eventf(&md, nil, corev1.EventTypeWarning, airunwayv1alpha1.ReasonEngineRemediated, "Remediate", message)
+	}
+	return ctrl.Result{RequeueAfter: interval}, nil
+}
+
+// crashLoopingContainer returns a pod of md created after since and the name of its
+// crash-looping container, or nil when there is none
+func (r *EngineRemediationReconciler) crashLoopingContainer(ctx context.Context, md *airunwayv1alpha1.ModelDeployment, since time.Time) (*corev1.Pod, string) {
+	pods, err := modelPods(ctx, r.Client, md)
+	if err != nil {
+		log.FromContext(ctx).V(1).Info("Could not list pods", "error", err)
+		return nil, ""
+	}
+	for i := range pods {
+		pod := &pods[i]
+		if !pod.CreationTimestamp.After(since) {
+			continue
+		}
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.State.Waiting != nil && cs.State.Waiting.Reason == crashLoopBackOff {
+				return pod, cs.Name
+			}
+		}
+	}
+	return nil, ""
+}
+
+// exhaust records that remediation gave up
+func (r *EngineRemediationReconciler) exhaust(ctx context.Context, md *airunwayv1alpha1.ModelDeployment, message string) error {
+	base := md.DeepCopy()
+	if md.Status.Remediation == nil {
+		md.Status.Remediation = &airunwayv1alpha1.RemediationStatus{}
+	}
+	md.Status.Remediation.Exhausted = true
+	md.Status.Remediation.Message = message
+	md.Status.Remediation.ObservedGeneration = md.Generation
+	if err := r.Status().Patch(ctx, md, client.MergeFrom(base)); err != nil {
+		return fmt.Errorf("failed to record remediation: %w", err)
+	}
+	log.FromContext(ctx).Info("Engine remediation exhausted", "name", md.Name, "message", message)
+	if r.Recorder != nil {
+		r.Recorder.Eventf(md, nil, corev1.EventTypeWarning, airunwayv1alpha1.ReasonRemediationExhausted, "Remediate", message)
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager. Only deployments with
+// spec.engine.remediation enabled are checked, driven by RequeueAfter.
+func (r *EngineRemediationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&airunwayv1alpha1.ModelDeployment{},
+			ctrlbuilder.WithPredicates(
+				predicate.GenerationChangedPredicate{},
+				predicate.NewPredicateFuncs(func(obj client.Object) bool {
+					md, ok := obj.(*airunwayv1alpha1.ModelDeployment)
+					return ok && md.RemediationEnabled()
+				}),
+				r.Sharding.Predicate())).
+		Named("modeldeployment-remediation").
+		Complete(r)
+}
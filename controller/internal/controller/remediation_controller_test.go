@@ -0,0 +1,178 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+// staticLogSource returns the same logs for every container
+type staticLogSource struct {
+	logs string
+}
+
+func (s *staticLogSource) PreviousLogs(context.Context, *corev1.Pod, string) (string, error) {
+	return s.logs, nil
+}
+
+func newCrashLoopingPod(name, mdName string, created time.Time) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "default",
+			Labels:            map[string]string{airunwayv1alpha1.LabelModelDeployment: mdName},
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "vllm",
+				State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+			}},
+		},
+	}
+}
+
+func TestEngineRemediation(t *testing.T) {
+	md := newModelDeployment("test-model", "default")
+	md.Generation = 1
+	md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeVLLM
+	md.Spec.Engine.Remediation = &airunwayv1alpha1.EngineRemediationSpec{Enabled: true, MaxAttempts: 2}
+	md.Status.Phase = airunwayv1alpha1.DeploymentPhaseDeploying
+
+	c := fake.NewClientBuilder().
+		WithScheme(newTestScheme()).
+		WithStatusSubresource(&airunwayv1alpha1.ModelDeployment{}).
+		WithObjects(md, newCrashLoopingPod("test-model-0", md.Name, time.Now().Add(-time.Minute))).
+		Build()
+	logs := &staticLogSource{logs: "INFO loading model\ntorch.OutOfMemoryError: CUDA out of memory. Tried to allocate 2.00 GiB\n"}
+	recorder := events.NewFakeRecorder(4)
+	r := &EngineRemediationReconciler{Client: c, Logs: logs, Recorder: recorder}
+	ctx := context.Background()
+	key := types.NamespacedName{Name: md.Name, Namespace: "default"}
+
+	reconcile := func() *airunwayv1alpha1.ModelDeployment {
+		t.Helper()
+		if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+			t.Fatalf("reconcile failed: %v", err)
+		}
+		var got airunwayv1alpha1.ModelDeployment
+		if err := c.Get(ctx, key, &got); err != nil {
+			t.Fatal(err)
+		}
+		return &got
+	}
+	expectEvent := func(reason string) {
+		t.Helper()
+		select {
+		case e := <-recorder.Events:
+			if !strings.Contains(e, "Warning "+reason) {
+				t.Errorf("expected a %s event, got %q", reason, e)
+			}
+		default:
+			t.Errorf("expected a %s event", reason)
+		}
+	}
+
+	got := reconcile()
+	if got.Spec.Engine.Args["gpu-memory-utilization"] != "0.8" {
+		t.Fatalf("expected gpu-memory-utilization lowered to 0.8, got %v", got.Spec.Engine.Args)
+	}
+	attempts := got.Status.Remediation.Attempts
+	if len(attempts) != 1 || attempts[0].Reason != airunwayv1alpha1.RemediationReasonCUDAOutOfMemory ||
+		attempts[0].Action != "engine.args.gpu-memory-utilization=0.8" || attempts[0].Pod != "test-model-0" {
+		t.Errorf("unexpected attempts %+v", attempts)
+	}
+	expectEvent(airunwayv1alpha1.ReasonEngineRemediated)
+
+	// Pods created before the attempt do not count against the new settings
+	got = reconcile()
+	if len(got.Status.Remediation.Attempts) != 1 {
+		t.Errorf("expected the old pod to be ignored, got %+v", got.Status.Remediation.Attempts)
+	}
+
+	if err := c.Create(ctx, newCrashLoopingPod("test-model-1", md.Name, time.Now().Add(time.Minute))); err != nil {
+		t.Fatal(err)
+	}
+	got = reconcile()
+	if got.Spec.Engine.Args["gpu-memory-utilization"] != "0.7" || len(got.Status.Remediation.Attempts) != 2 {
+		t.Fatalf("expected a second fallback, got %v %+v", got.Spec.Engine.Args, got.Status.Remediation)
+	}
+	expectEvent(airunwayv1alpha1.ReasonEngineRemediated)
+
+	// maxAttempts bounds the fallbacks
+	if err := c.Create(ctx, newCrashLoopingPod("test-model-2", md.Name, time.Now().Add(2*time.Minute))); err != nil {
+		t.Fatal(err)
+	}
+	got = reconcile()
+	if !got.Status.Remediation.Exhausted || got.Spec.Engine.Args["gpu-memory-utilization"] != "0.7" {
+		t.Errorf("expected remediation to give up, got %v %+v", got.Spec.Engine.Args, got.Status.Remediation)
+	}
+	expectEvent(airunwayv1alpha1.ReasonRemediationExhausted)
+
+	// A spec change by the user starts over
+	got.Generation = 2
+	got.Spec.Engine.Args["gpu-memory-utilization"] = "0.85"
+	if err := c.Update(ctx, got); err != nil {
+		t.Fatal(err)
+	}
+	got = reconcile()
+	if got.Status.Remediation == nil || got.Status.Remediation.Exhausted || len(got.Status.Remediation.Attempts) != 1 {
+		t.Errorf("expected remediation to restart, got %+v", got.Status.Remediation)
+	}
+}
+
+func TestEngineRemediation_UnrecognizedCrash(t *testing.T) {
+	md := newModelDeployment("test-model", "default")
+	md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeVLLM
+	md.Spec.Engine.Remediation = &airunwayv1alpha1.EngineRemediationSpec{Enabled: true}
+	md.Status.Phase = airunwayv1alpha1.DeploymentPhaseFailed
+
+	c := fake.NewClientBuilder().
+		WithScheme(newTestScheme()).
+		WithStatusSubresource(&airunwayv1alpha1.ModelDeployment{}).
+		WithObjects(md, newCrashLoopingPod("test-model-0", md.Name, time.Now())).
+		Build()
+	r := &EngineRemediationReconciler{Client: c, Logs: &staticLogSource{logs: "ValueError: invalid model path"}}
+	key := types.NamespacedName{Name: md.Name, Namespace: "default"}
+	result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+	if err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+	if result.RequeueAfter != DefaultRemediationInterval {
+		t.Errorf("expected requeue after %s, got %s", DefaultRemediationInterval, result.RequeueAfter)
+	}
+	var got airunwayv1alpha1.ModelDeployment
+	if err := c.Get(context.Background(), key, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Status.Remediation != nil || got.Spec.Engine.Args != nil {
+		t.Errorf("expected an unrecognized crash to be left alone, got %v %+v", got.Spec.Engine.Args, got.Status.Remediation)
+	}
+}
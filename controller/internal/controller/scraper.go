@@ -0,0 +1,206 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+// scrapeConcurrency bounds the background requests in flight across all objects of a reconciler
+const scrapeConcurrency = 16
+
+// Kinds of background requests of a ModelDeployment. scrapeBatchProgress is the one of a
+// ModelBatchJob.
+const (
+	scrapeActivity        = "activity"
+	scrapeMetricsSnapshot = "metricsSnapshot"
+	scrapePods            = "pods"
+	scrapeGPUDevices      = "gpuDevices"
+	scrapeAdapters        = "adapters"
+	scrapeModelDiscovery  = "modelDiscovery"
+	scrapeServing         = "serving"
+	scrapeGatewayProbe    = "gatewayProbe"
+	scrapeBatchProgress   = "batchProgress"
+)
+
+// scrapeResult is the outcome of a background request and when it completed
+type scrapeResult struct {
+	value interface{}
+	err   error
+	time  time.Time
+}
+
+// scrapeKey identifies a kind of background request of an object
+type scrapeKey struct {
+	object types.NamespacedName
+	kind   string
+}
+
+// scrapeJob is a background request repeated for an object
+type scrapeJob struct {
+	// version identifies the inputs of run; result belongs to it
+	version string
+	run     func(ctx context.Context) (interface{}, error)
+	running bool
+	result  *scrapeResult
+}
+
+// scraper sends the HTTP requests of a reconciler to model server pods, Services, the
+// gateway and batch runners in the background, so a slow or unreachable server cannot
+// block a reconcile worker. Reconcile only reads the last result of a request with fetch,
+// which starts the request again once the result is older than its interval. An object is
+// queued for reconciliation when one of its requests completes.
+type scraper struct {
+	mu    sync.Mutex
+	ctx   context.Context
+	jobs  map[scrapeKey]*scrapeJob
+	slots chan struct{}
+	queue workqueue.TypedRateLimitingInterface[reconcile.Request]
+	wg    sync.WaitGroup
+}
+
+// fetch returns the last result of the kind request of an object with inputs version,
+// and whether there is one. run is started in the background when there is no result or
+// it is older than interval. Results of an earlier version are dropped.
+func (s *scraper) fetch(key types.NamespacedName, kind, version string, interval time.Duration, run func(ctx context.Context) (interface{}, error)) (scrapeResult, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.jobs == nil {
+		s.jobs = map[scrapeKey]*scrapeJob{}
+		s.slots = make(chan struct{}, scrapeConcurrency)
+	}
+	k := scrapeKey{object: key, kind: kind}
+	job, ok := s.jobs[k]
+	if !ok {
+		job = &scrapeJob{}
+		s.jobs[k] = job
+	}
+	if job.version != version {
+		job.version = version
+		job.result = nil
+	}
+	job.run = run
+	if !job.running && (job.result == nil || time.Since(job.result.time) >= interval) {
+		job.running = true
+		s.wg.Add(1)
+		go s.do(k, job, version, run)
+	}
+	if job.result == nil {
+		return scrapeResult{}, false
+	}
+	return *job.result, true
+}
+
+// do runs a background request and queues its object for reconciliation
+func (s *scraper) do(k scrapeKey, job *scrapeJob, version string, run func(ctx context.Context) (interface{}, error)) {
+	defer s.wg.Done()
+	s.mu.Lock()
+	ctx, slots := s.ctx, s.slots
+	s.mu.Unlock()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var result *scrapeResult
+	select {
+	case slots <- struct{}{}:
+		value, err := run(ctx)
+		<-slots
+		result = &scrapeResult{value: value, err: err, time: time.Now()}
+	case <-ctx.Done():
+	}
+
+	s.mu.Lock()
+	job.running = false
+	current := s.jobs[k] == job
+	if current && result != nil && job.version == version {
+		job.result = result
+	}
+	queue := s.queue
+	s.mu.Unlock()
+	if current && result != nil && queue != nil {
+		queue.Add(reconcile.Request{NamespacedName: k.object})
+	}
+}
+
+// forget drops the given kinds of requests of an object, or all of them without kinds.
+// Requests in flight complete without recording their result.
+func (s *scraper) forget(key types.NamespacedName, kinds ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for k := range s.jobs {
+		if k.object != key {
+			continue
+		}
+		if len(kinds) == 0 {
+			delete(s.jobs, k)
+			continue
+		}
+		for _, kind := range kinds {
+			if k.kind == kind {
+				delete(s.jobs, k)
+			}
+		}
+	}
+}
+
+// source returns the source that queues objects whose requests completed
+func (s *scraper) source() source.Source {
+	return source.Func(func(_ context.Context, q workqueue.TypedRateLimitingInterface[reconcile.Request]) error {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.queue = q
+		return nil
+	})
+}
+
+// Start implements manager.Runnable. Requests run with the context of the Manager, and
+// are waited for when it stops.
+func (s *scraper) Start(ctx context.Context) error {
+	s.mu.Lock()
+	s.ctx = ctx
+	s.mu.Unlock()
+	<-ctx.Done()
+	s.wg.Wait()
+	return nil
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable: requests are only
+// started by the reconciles of the leader
+func (s *scraper) NeedLeaderElection() bool {
+	return true
+}
+
+// scrape returns the last result of the kind request of md from the background scraper,
+// see scraper.fetch. run logs with the logger of ctx.
+func (r *ModelDeploymentReconciler) scrape(ctx context.Context, md *airunwayv1alpha1.ModelDeployment, kind, version string, interval time.Duration, run func(ctx context.Context) (interface{}, error)) (scrapeResult, bool) {
+	logger := log.FromContext(ctx)
+	key := types.NamespacedName{Name: md.Name, Namespace: md.Namespace}
+	return r.scrapes.fetch(key, kind, version, interval, func(ctx context.Context) (interface{}, error) {
+		return run(log.IntoContext(ctx, logger))
+	})
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// waitForScrapes waits for the background requests of r in flight
+func waitForScrapes(r *ModelDeploymentReconciler) {
+	r.scrapes.wg.Wait()
+}
+
+// expireScrapes ages the results of the background requests of r, so the next reconcile
+// starts them again
+func expireScrapes(r *ModelDeploymentReconciler) {
+	r.scrapes.mu.Lock()
+	defer r.scrapes.mu.Unlock()
+	for _, job := range r.scrapes.jobs {
+		if job.result != nil {
+			job.result.time = job.result.time.Add(-24 * time.Hour)
+		}
+	}
+}
+
+// rescrape runs the background requests of r again and waits for them, so the next
+// reconcile reads fresh results. reconcile starts the requests.
+func rescrape(r *ModelDeploymentReconciler, reconcile func()) {
+	waitForScrapes(r)
+	expireScrapes(r)
+	reconcile()
+	waitForScrapes(r)
+}
+
+func TestScraperFetch(t *testing.T) {
+	s := &scraper{}
+	queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+	defer queue.ShutDown()
+	if err := s.source().Start(context.Background(), queue); err != nil {
+		t.Fatalf("starting the source: %v", err)
+	}
+	key := types.NamespacedName{Name: "demo", Namespace: "default"}
+	var runs atomic.Int32
+	run := func(context.Context) (interface{}, error) {
+		return int(runs.Add(1)), nil
+	}
+
+	// The first fetch starts the request without waiting for it
+	if _, ok := s.fetch(key, scrapeActivity, "v1", time.Minute, run); ok {
+		t.Fatal("expected no result before the request completed")
+	}
+	s.wg.Wait()
+	result, ok := s.fetch(key, scrapeActivity, "v1", time.Minute, run)
+	if !ok || result.value != 1 || result.err != nil {
+		t.Fatalf("expected the result of the first request, got %+v ok=%v", result, ok)
+	}
+	if queue.Len() != 1 {
+		t.Errorf("expected the deployment to be queued when the request completed, got %d", queue.Len())
+	}
+
+	// Within the interval the result is reused
+	s.wg.Wait()
+	if runs.Load() != 1 {
+		t.Errorf("expected one request within the interval, got %d", runs.Load())
+	}
+
+	// A new version drops the result of the old one
+	if _, ok := s.fetch(key, scrapeActivity, "v2", time.Minute, run); ok {
+		t.Error("expected no result for a new version")
+	}
+	s.wg.Wait()
+	if result, ok := s.fetch(key, scrapeActivity, "v2", time.Minute, run); !ok || result.value != 2 {
+		t.Errorf("expected the result of the second request, got %+v ok=%v", result, ok)
+	}
+
+	// Failures are results too
+	failing := func(context.Context) (interface{}, error) {
+		return nil, errors.New("connection refused")
+	}
+	s.fetch(key, scrapePods, "", time.Minute, failing)
+	s.wg.Wait()
+	if result, ok := s.fetch(key, scrapePods, "", time.Minute, failing); !ok || result.err == nil {
+		t.Errorf("expected the failure to be returned, got %+v ok=%v", result, ok)
+	}
+
+	// Forgotten requests start over
+	s.forget(key, scrapePods)
+	if _, ok := s.fetch(key, scrapeActivity, "v2", time.Minute, run); !ok {
+		t.Error("expected the activity result to be kept")
+	}
+	s.forget(key)
+	if _, ok := s.fetch(key, scrapeActivity, "v2", time.Minute, run); ok {
+		t.Error("expected no result after forgetting the deployment")
+	}
+	s.wg.Wait()
+}
+
+func TestScraperFetch_ForgottenInFlight(t *testing.T) {
+	s := &scraper{}
+	key := types.NamespacedName{Name: "demo", Namespace: "default"}
+	release := make(chan struct{})
+	run := func(context.Context) (interface{}, error) {
+		<-release
+		return "stale", nil
+	}
+
+	s.fetch(key, scrapeModelDiscovery, "", time.Minute, run)
+	s.forget(key)
+	close(release)
+	s.wg.Wait()
+	if _, ok := s.fetch(key, scrapeModelDiscovery, "", time.Minute, func(context.Context) (interface{}, error) {
+		return "fresh", nil
+	}); ok {
+		t.Error("expected the result of a forgotten request to be dropped")
+	}
+	s.wg.Wait()
+}
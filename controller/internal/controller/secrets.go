@@ -0,0 +1,94 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+// Secrets are only watched by metadata, so the controller never caches their data
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+// reconcileSecretsChecksum sets status.secretsChecksum from the resource versions of the
+// Secrets the deployment references when spec.secrets.rolloutOnChange is set, and clears
+// it otherwise. Providers stamp the checksum on the model pods, so any update to a
+// referenced Secret rolls them. A missing Secret counts as its own version, so creating it
+// rolls the pods too.
+func (r *ModelDeploymentReconciler) reconcileSecretsChecksum(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) error {
+	if md.Spec.Secrets == nil || !md.Spec.Secrets.RolloutOnChange {
+		md.Status.SecretsChecksum = ""
+		return nil
+	}
+
+	hash := sha256.New()
+	for _, name := range md.ReferencedSecrets() {
+		secret := &metav1.PartialObjectMetadata{}
+		secret.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Secret"))
+		version := ""
+		if err := r.Get(ctx, client.ObjectKey{Name: name, Namespace: md.Namespace}, secret); err == nil {
+			version = secret.ResourceVersion
+		} else if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get Secret %s: %w", name, err)
+		}
+		fmt.Fprintf(hash, "%s=%s\n", name, version)
+	}
+	checksum := hex.EncodeToString(hash.Sum(nil)[:8])
+	if md.Status.SecretsChecksum == checksum {
+		return nil
+	}
+	if md.Status.SecretsChecksum != "" {
+		log.FromContext(ctx).Info("Referenced Secrets changed, rolling the pods", "name", md.Name)
+		if r.Recorder != nil {
+			r.Recorder.Eventf(md, nil, corev1.EventTypeNormal, airunwayv1alpha1.ReasonSecretsChanged, "Rollout",
+				"A referenced Secret changed, rolling the pods")
+		}
+	}
+	md.Status.SecretsChecksum = checksum
+	return nil
+}
+
+// mapSecretToModelDeployments enqueues the ModelDeployments with
+// spec.secrets.rolloutOnChange that reference a Secret
+func (r *ModelDeploymentReconciler) mapSecretToModelDeployments(ctx context.Context, obj client.Object) []reconcile.Request {
+	var mdList airunwayv1alpha1.ModelDeploymentList
+	if err := r.List(ctx, &mdList, client.InNamespace(obj.GetNamespace())); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list ModelDeployments for Secret", "name", obj.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, md := range mdList.Items {
+		if md.Spec.Secrets != nil && md.Spec.Secrets.RolloutOnChange && slices.Contains(md.ReferencedSecrets(), obj.GetName()) {
+			requests = append(requests, reconcile.Request{NamespacedName: k8stypes.NamespacedName{Name: md.Name, Namespace: md.Namespace}})
+		}
+	}
+	return requests
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+func newRolloutModelDeployment(name, namespace string) *airunwayv1alpha1.ModelDeployment {
+	md := newModelDeployment(name, namespace)
+	md.Spec.Secrets = &airunwayv1alpha1.SecretsSpec{HuggingFaceToken: "hf-token", RolloutOnChange: true}
+	md.Spec.Env = []corev1.EnvVar{{
+		Name: "API_KEY",
+		ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "api-key"},
+			Key:                  "key",
+		}},
+	}}
+	return md
+}
+
+func TestReconcileSecretsChecksum(t *testing.T) {
+	ctx := context.Background()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "hf-token", Namespace: "team-a"},
+		Data:       map[string][]byte{"HF_TOKEN": []byte("old")},
+	}
+	scheme := newTestScheme()
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+	r := &ModelDeploymentReconciler{Client: c, Scheme: scheme}
+
+	md := newRolloutModelDeployment("llama", "team-a")
+	if err := r.reconcileSecretsChecksum(ctx, md); err != nil {
+		t.Fatalf("reconcileSecretsChecksum failed: %v", err)
+	}
+	first := md.Status.SecretsChecksum
+	if first == "" {
+		t.Fatal("expected a checksum")
+	}
+	if err := r.reconcileSecretsChecksum(ctx, md); err != nil || md.Status.SecretsChecksum != first {
+		t.Errorf("expected a stable checksum, got %q, %v", md.Status.SecretsChecksum, err)
+	}
+
+	// Rotating a Secret changes the checksum
+	secret.Data["HF_TOKEN"] = []byte("new")
+	if err := c.Update(ctx, secret); err != nil {
+		t.Fatalf("update Secret: %v", err)
+	}
+	if err := r.reconcileSecretsChecksum(ctx, md); err != nil {
+		t.Fatalf("reconcileSecretsChecksum failed: %v", err)
+	}
+	rotated := md.Status.SecretsChecksum
+	if rotated == first {
+		t.Error("expected the checksum to change with the Secret")
+	}
+
+	// Creating a missing Secret changes the checksum too
+	if err := c.Create(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "api-key", Namespace: "team-a"}}); err != nil {
+		t.Fatalf("create Secret: %v", err)
+	}
+	if err := r.reconcileSecretsChecksum(ctx, md); err != nil {
+		t.Fatalf("reconcileSecretsChecksum failed: %v", err)
+	}
+	if md.Status.SecretsChecksum == rotated {
+		t.Error("expected the checksum to change when a referenced Secret is created")
+	}
+
+	md.Spec.Secrets.RolloutOnChange = false
+	if err := r.reconcileSecretsChecksum(ctx, md); err != nil || md.Status.SecretsChecksum != "" {
+		t.Errorf("expected the checksum to be cleared, got %q, %v", md.Status.SecretsChecksum, err)
+	}
+}
+
+func TestMapSecretToModelDeployments(t *testing.T) {
+	rollout := newRolloutModelDeployment("llama", "team-a")
+	static := newModelDeployment("static", "team-a")
+	static.Spec.Secrets = &airunwayv1alpha1.SecretsSpec{HuggingFaceToken: "hf-token"}
+	other := newRolloutModelDeployment("llama", "team-b")
+	scheme := newTestScheme()
+	r := &ModelDeploymentReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(rollout, static, other).Build(),
+		Scheme: scheme,
+	}
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "api-key", Namespace: "team-a"}}
+	requests := r.mapSecretToModelDeployments(context.Background(), secret)
+	if len(requests) != 1 || requests[0].Name != "llama" || requests[0].Namespace != "team-a" {
+		t.Errorf("expected only team-a/llama, got %v", requests)
+	}
+	unrelated := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: "team-a"}}
+	if requests := r.mapSecretToModelDeployments(context.Background(), unrelated); len(requests) != 0 {
+		t.Errorf("expected no requests, got %v", requests)
+	}
+}
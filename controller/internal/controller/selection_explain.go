@@ -0,0 +1,181 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+// Provider selection criteria reported in status.selectionReport
+const (
+	criterionReady        = "Ready"
+	criterionCapabilities = "Capabilities"
+	criterionEngine       = "Engine"
+	criterionDevice       = "Device"
+	criterionServingMode  = "ServingMode"
+	criterionNamespace    = "Namespace"
+)
+
+// resolvedServingMode returns spec.serving.mode, defaulting to aggregated
+func resolvedServingMode(spec *airunwayv1alpha1.ModelDeploymentSpec) airunwayv1alpha1.ServingMode {
+	if spec.Serving != nil && spec.Serving.Mode != "" {
+		return spec.Serving.Mode
+	}
+	return airunwayv1alpha1.ServingModeAggregated
+}
+
+// evaluateProvider checks a provider against every selection criterion and evaluates its
+// CEL selection rules. A provider is eligible when all criteria pass; its score is the
+// highest priority of its matched rules. Rules that fail to evaluate do not match.
+// namespaceLabels are the labels of the deployment namespace.
+func evaluateProvider(pc *airunwayv1alpha1.InferenceProviderConfig, engineType airunwayv1alpha1.EngineType, hasGPU bool, servingMode airunwayv1alpha1.ServingMode, namespaceLabels map[string]string, specMap map[string]any) airunwayv1alpha1.ProviderEvaluation {
+	eval := airunwayv1alpha1.ProviderEvaluation{Name: pc.Name, Eligible: true}
+	check := func(name string, passed bool, format string, args ...any) {
+		eval.Criteria = append(eval.Criteria, airunwayv1alpha1.SelectionCriterion{
+			Name:    name,
+			Passed:  passed,
+			Message: fmt.Sprintf(format, args...),
+		})
+		eval.Eligible = eval.Eligible && passed
+	}
+
+	if pc.Status.Ready {
+		check(criterionReady, true, "provider is ready")
+	} else {
+		check(criterionReady, false, "provider is not ready")
+	}
+
+	if pc.Spec.NamespaceSelector != nil {
+		served, err := pc.Spec.ServesNamespace(namespaceLabels)
+		if err != nil {
+			check(criterionNamespace, false, "%v", err)
+		} else {
+			check(criterionNamespace, served, "provider namespaceSelector matches the namespace: %v", served)
+		}
+	}
+
+	caps := pc.Spec.Capabilities
+	if caps == nil {
+		check(criterionCapabilities, false, "provider does not declare capabilities")
+	} else {
+		check(criterionEngine, slices.Contains(caps.Engines, engineType),
+			"requires engine %s, provider supports [%s]", engineType, joinStrings(caps.Engines))
+		if hasGPU {
+			check(criterionDevice, caps.GPUSupport, "requires GPU, provider GPU support is %v", caps.GPUSupport)
+		} else {
+			check(criterionDevice, caps.SupportsCPUEngine(engineType), "requires engine %s on CPU, provider CPU support for it is %v",
+				engineType, caps.SupportsCPUEngine(engineType))
+		}
+		check(criterionServingMode, slices.Contains(caps.ServingModes, servingMode),
+			"requires serving mode %s, provider supports [%s]", servingMode, joinStrings(caps.ServingModes))
+	}
+
+	for _, rule := range pc.Spec.SelectionRules {
+		result := airunwayv1alpha1.SelectionRuleResult{Condition: rule.Condition, Priority: rule.Priority}
+		matched, err := evaluateCEL(rule.Condition, specMap)
+		if err != nil {
+			result.Error = err.Error()
+		} else {
+			result.Matched = matched
+		}
+		if result.Matched && rule.Priority > eval.Score {
+			eval.Score = rule.Priority
+		}
+		eval.Rules = append(eval.Rules, result)
+	}
+	return eval
+}
+
+func joinStrings[T ~string](values []T) string {
+	s := make([]string, len(values))
+	for i, v := range values {
+		s[i] = string(v)
+	}
+	return strings.Join(s, ", ")
+}
+
+// reconcileSelectionReport writes status.selectionReport while the deployment has the
+// selection-explain annotation. Every registered provider is evaluated, including ones
+// that are not ready, and the report is regenerated on each reconcile so it follows
+// provider and spec changes. It is a simulation: the selected provider is not changed.
+func (r *ModelDeploymentReconciler) reconcileSelectionReport(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) error {
+	if md.Annotations[airunwayv1alpha1.AnnotationSelectionExplain] != "true" {
+		md.Status.SelectionReport = nil
+		return nil
+	}
+
+	providerConfigs, err := r.listProviderConfigs(ctx)
+	if err != nil {
+		return err
+	}
+	specMap, err := specToMap(&md.Spec)
+	if err != nil {
+		return fmt.Errorf("failed to convert spec for CEL evaluation: %w", err)
+	}
+	namespaceLabels, err := r.providerNamespaceLabels(ctx, md, providerConfigs)
+	if err != nil {
+		return err
+	}
+	strategy, err := r.resolveProviderSelection(ctx, md)
+	if err != nil {
+		return err
+	}
+
+	engineType := md.ResolvedEngineType()
+	hasGPU := md.ResolvedDevice() == airunwayv1alpha1.EngineDeviceGPU
+	servingMode := resolvedServingMode(&md.Spec)
+	report := &airunwayv1alpha1.SelectionReport{Engine: engineType, Strategy: strategy.name}
+	for i := range providerConfigs {
+		report.Providers = append(report.Providers,
+			evaluateProvider(&providerConfigs[i], engineType, hasGPU, servingMode, namespaceLabels, specMap))
+	}
+	slices.SortFunc(report.Providers, func(a, b airunwayv1alpha1.ProviderEvaluation) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	var eligible []airunwayv1alpha1.ProviderEvaluation
+	for _, eval := range report.Providers {
+		if eval.Eligible {
+			eligible = append(eligible, eval)
+		}
+	}
+	if len(eligible) > 0 {
+		if report.Selected, _, err = strategy.selectProvider(md, eligible); err != nil {
+			return err
+		}
+	}
+
+	// Keep the previous report, and its time, when nothing changed to avoid a status
+	// update on every reconcile
+	if prev := md.Status.SelectionReport; prev != nil {
+		report.EvaluatedTime = prev.EvaluatedTime
+		if apiequality.Semantic.DeepEqual(prev, report) {
+			return nil
+		}
+	}
+	now := metav1.Now()
+	report.EvaluatedTime = &now
+	md.Status.SelectionReport = report
+	return nil
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+func TestReconcileSelectionReport(t *testing.T) {
+	providers := cpuTestProviders()
+	providers[0].Spec.SelectionRules = []airunwayv1alpha1.SelectionRule{
+		{Condition: "spec.engine.type == 'vllm'", Priority: 50},
+		{Condition: "spec.missing.field == 1", Priority: 90},
+	}
+	notReady := newProviderConfig("dynamo", &airunwayv1alpha1.ProviderCapabilities{
+		Engines:      []airunwayv1alpha1.EngineType{airunwayv1alpha1.EngineTypeVLLM},
+		ServingModes: []airunwayv1alpha1.ServingMode{airunwayv1alpha1.ServingModeAggregated},
+		GPUSupport:   true,
+	})
+	notReady.Status.Ready = false
+
+	md := newCPUModelDeployment(airunwayv1alpha1.EngineTypeVLLM, airunwayv1alpha1.EngineDeviceCPU)
+	md.Annotations = map[string]string{airunwayv1alpha1.AnnotationSelectionExplain: "true"}
+	r := newTestReconciler(newTestScheme(), nil, md, &providers[0], &providers[1], notReady)
+	ctx := context.Background()
+
+	if err := r.reconcileSelectionReport(ctx, md); err != nil {
+		t.Fatalf("reconcileSelectionReport failed: %v", err)
+	}
+	report := md.Status.SelectionReport
+	if report == nil || report.EvaluatedTime == nil {
+		t.Fatalf("expected a selection report, got %+v", report)
+	}
+	if report.Selected != "llmd" || report.Engine != airunwayv1alpha1.EngineTypeVLLM {
+		t.Errorf("expected llmd to be selected for vllm, got %q for %q", report.Selected, report.Engine)
+	}
+	if len(report.Providers) != 3 || report.Providers[0].Name != "dynamo" || report.Providers[2].Name != "llmd" {
+		t.Fatalf("expected every provider sorted by name, got %+v", report.Providers)
+	}
+
+	criteria := func(eval airunwayv1alpha1.ProviderEvaluation) map[string]bool {
+		m := map[string]bool{}
+		for _, c := range eval.Criteria {
+			m[c.Name] = c.Passed
+		}
+		return m
+	}
+	dynamo, kaito := report.Providers[0], report.Providers[1]
+	if c := criteria(dynamo); dynamo.Eligible || c[criterionReady] || c[criterionDevice] || !c[criterionEngine] {
+		t.Errorf("expected dynamo to fail Ready and Device only, got %+v", dynamo)
+	}
+	if c := criteria(kaito); kaito.Eligible || c[criterionDevice] || !c[criterionEngine] || !c[criterionServingMode] {
+		t.Errorf("expected kaito to fail Device only, got %+v", kaito)
+	}
+	// Rules are evaluated even for ineligible providers
+	if kaito.Score != 50 || len(kaito.Rules) != 2 || !kaito.Rules[0].Matched || kaito.Rules[1].Matched || kaito.Rules[1].Error == "" {
+		t.Errorf("expected the first rule to match and the second to fail, got %+v", kaito.Rules)
+	}
+
+	// The report matches the selection algorithm
+	name, _, err := r.runSelectionAlgorithm(md, providers, nil, nil)
+	if err != nil || name != report.Selected {
+		t.Errorf("expected runSelectionAlgorithm to select %q, got %q (%v)", report.Selected, name, err)
+	}
+
+	// An unchanged report keeps its time
+	evaluated := report.EvaluatedTime
+	if err := r.reconcileSelectionReport(ctx, md); err != nil {
+		t.Fatalf("reconcileSelectionReport failed: %v", err)
+	}
+	if md.Status.SelectionReport.EvaluatedTime != evaluated {
+		t.Error("expected an unchanged report to be kept")
+	}
+
+	// Removing the annotation clears the report
+	delete(md.Annotations, airunwayv1alpha1.AnnotationSelectionExplain)
+	if err := r.reconcileSelectionReport(ctx, md); err != nil {
+		t.Fatalf("reconcileSelectionReport failed: %v", err)
+	}
+	if md.Status.SelectionReport != nil {
+		t.Errorf("expected the report to be cleared, got %+v", md.Status.SelectionReport)
+	}
+}
+
+func TestSelectProvider_NamespaceSelector(t *testing.T) {
+	providers := cpuTestProviders()
+	providers[1].Spec.NamespaceSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"airunway.ai/tier": "prod"}}
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
+	ctx := context.Background()
+
+	// llmd, the only provider running vllm on CPU, does not serve the namespace
+	md := newCPUModelDeployment(airunwayv1alpha1.EngineTypeVLLM, airunwayv1alpha1.EngineDeviceCPU)
+	md.Annotations = map[string]string{airunwayv1alpha1.AnnotationSelectionExplain: "true"}
+	r := newTestReconciler(newTestScheme(), nil, md, namespace, &providers[0], &providers[1])
+	if err := r.selectProvider(ctx, md); err == nil {
+		t.Errorf("expected no provider to be selected, got %+v", md.Status.Provider)
+	}
+	if err := r.reconcileSelectionReport(ctx, md); err != nil {
+		t.Fatalf("reconcileSelectionReport failed: %v", err)
+	}
+	llmd := md.Status.SelectionReport.Providers[1]
+	if llmd.Eligible || len(llmd.Criteria) == 0 || llmd.Criteria[1].Name != criterionNamespace || llmd.Criteria[1].Passed {
+		t.Errorf("expected llmd to fail the Namespace criterion, got %+v", llmd)
+	}
+
+	// Engine auto-selection skips providers that do not serve the namespace
+	auto := newCPUModelDeployment("", airunwayv1alpha1.EngineDeviceCPU)
+	r = newTestReconciler(newTestScheme(), nil, auto, namespace, &providers[0], &providers[1])
+	if err := r.selectEngine(ctx, auto); err != nil {
+		t.Fatalf("selectEngine failed: %v", err)
+	}
+	if auto.Status.Engine == nil || auto.Status.Engine.Type != airunwayv1alpha1.EngineTypeLlamaCpp {
+		t.Errorf("expected llamacpp from kaito, got %+v", auto.Status.Engine)
+	}
+
+	namespace.Labels = map[string]string{"airunway.ai/tier": "prod"}
+	md = newCPUModelDeployment(airunwayv1alpha1.EngineTypeVLLM, airunwayv1alpha1.EngineDeviceCPU)
+	r = newTestReconciler(newTestScheme(), nil, md, namespace, &providers[0], &providers[1])
+	if err := r.selectProvider(ctx, md); err != nil {
+		t.Fatalf("selectProvider failed: %v", err)
+	}
+	if md.Status.Provider == nil || md.Status.Provider.Name != "llmd" {
+		t.Errorf("expected llmd in a matching namespace, got %+v", md.Status.Provider)
+	}
+}
+
+func TestProviderConfigAffectsModelDeployment_Explain(t *testing.T) {
+	md := newModelDeployment("test-model", "default")
+	md.Status.Provider = &airunwayv1alpha1.ProviderStatus{Name: "kaito"}
+	if providerConfigAffectsModelDeployment(md, "llmd") {
+		t.Error("expected another provider not to affect a deployment with a selected provider")
+	}
+	md.Annotations = map[string]string{airunwayv1alpha1.AnnotationSelectionExplain: "true"}
+	if !providerConfigAffectsModelDeployment(md, "llmd") {
+		t.Error("expected every provider to affect a deployment with a selection report")
+	}
+}
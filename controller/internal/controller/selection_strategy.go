@@ -0,0 +1,160 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/pkg/selection"
+)
+
+// providerSelection is the strategy that picks one of the eligible providers, and its inputs
+type providerSelection struct {
+	name     string
+	strategy selection.Strategy
+	// policy is the SelectionPolicy the strategy comes from, nil for --provider-selection-strategy
+	policy *airunwayv1alpha1.SelectionPolicy
+	// deployments counts the other ModelDeployments placed on each provider
+	deployments map[string]int
+}
+
+// resolveProviderSelection returns the strategy for md: the one of the SelectionPolicy
+// that selects its namespace, or --provider-selection-strategy
+func (r *ModelDeploymentReconciler) resolveProviderSelection(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) (*providerSelection, error) {
+	policy, err := r.selectionPolicy(ctx, md)
+	if err != nil {
+		return nil, err
+	}
+	name := r.settings().SelectionStrategy
+	if policy != nil {
+		name = policy.Spec.Strategy
+	}
+	if name == "" {
+		name = selection.Default
+	}
+	strategy, err := selection.Get(name)
+	if err != nil {
+		if policy != nil {
+			return nil, fmt.Errorf("SelectionPolicy %s: %w", policy.Name, err)
+		}
+		return nil, err
+	}
+
+	var mdList airunwayv1alpha1.ModelDeploymentList
+	if err := r.List(ctx, &mdList); err != nil {
+		return nil, fmt.Errorf("failed to list ModelDeployments: %w", err)
+	}
+	deployments := map[string]int{}
+	for i := range mdList.Items {
+		other := &mdList.Items[i]
+		if other.Namespace == md.Namespace && other.Name == md.Name {
+			continue
+		}
+		if provider := resolvedProviderName(other); provider != "" {
+			deployments[provider]++
+		}
+	}
+	return &providerSelection{name: name, strategy: strategy, policy: policy, deployments: deployments}, nil
+}
+
+// selectionPolicy returns the SelectionPolicy for the namespace of md. A policy with a
+// namespaceSelector wins over one without, then the first by name.
+func (r *ModelDeploymentReconciler) selectionPolicy(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) (*airunwayv1alpha1.SelectionPolicy, error) {
+	var policies airunwayv1alpha1.SelectionPolicyList
+	if err := r.List(ctx, &policies); err != nil {
+		return nil, fmt.Errorf("failed to list selection policies: %w", err)
+	}
+	if len(policies.Items) == 0 {
+		return nil, nil
+	}
+
+	var namespaceLabels map[string]string
+	for _, p := range policies.Items {
+		if p.Spec.NamespaceSelector != nil {
+			var namespace corev1.Namespace
+			if err := r.Get(ctx, client.ObjectKey{Name: md.Namespace}, &namespace); err != nil {
+				return nil, fmt.Errorf("failed to get namespace %s: %w", md.Namespace, err)
+			}
+			namespaceLabels = namespace.Labels
+			break
+		}
+	}
+
+	var selected *airunwayv1alpha1.SelectionPolicy
+	for i := range policies.Items {
+		p := &policies.Items[i]
+		matches, err := p.Spec.SelectsNamespace(namespaceLabels)
+		if err != nil {
+			return nil, fmt.Errorf("SelectionPolicy %s: %w", p.Name, err)
+		}
+		if !matches {
+			continue
+		}
+		if selected == nil || morePreciseSelectionPolicy(p, selected) {
+			selected = p
+		}
+	}
+	return selected, nil
+}
+
+// morePreciseSelectionPolicy reports whether a takes precedence over b
+func morePreciseSelectionPolicy(a, b *airunwayv1alpha1.SelectionPolicy) bool {
+	if (a.Spec.NamespaceSelector != nil) != (b.Spec.NamespaceSelector != nil) {
+		return a.Spec.NamespaceSelector != nil
+	}
+	return a.Name < b.Name
+}
+
+// selectProvider picks one of the eligible evaluations, sorted by name, and returns its
+// name and the strategy's reason. A nil providerSelection uses the default strategy.
+func (s *providerSelection) selectProvider(md *airunwayv1alpha1.ModelDeployment, eligible []airunwayv1alpha1.ProviderEvaluation) (string, string, error) {
+	if s == nil {
+		strategy, _ := selection.Get(selection.Default)
+		s = &providerSelection{name: selection.Default, strategy: strategy}
+	}
+	params := map[string]airunwayv1alpha1.ProviderSelectionParameters{}
+	if s.policy != nil {
+		for _, p := range s.policy.Spec.Providers {
+			params[p.Name] = p
+		}
+	}
+	candidates := make([]selection.Candidate, len(eligible))
+	for i, eval := range eligible {
+		candidates[i] = selection.Candidate{
+			Name:        eval.Name,
+			Priority:    eval.Score,
+			Deployments: s.deployments[eval.Name],
+			Weight:      max(params[eval.Name].Weight, 1),
+			Cost:        params[eval.Name].Cost,
+		}
+	}
+	name, reason := s.strategy.Select(md, candidates)
+	if !slices.ContainsFunc(candidates, func(c selection.Candidate) bool { return c.Name == name }) {
+		return "", "", fmt.Errorf("%s strategy selected %q, which is not an eligible provider", s.name, name)
+	}
+	source := s.name + " strategy"
+	if s.policy != nil {
+		source += " from SelectionPolicy " + s.policy.Name
+	}
+	return name, fmt.Sprintf("%s: %s", source, reason), nil
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/pkg/selection"
+)
+
+func TestSelectProvider_Strategy(t *testing.T) {
+	providers := cpuTestProviders()
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
+	// One deployment already runs on kaito
+	existing := newModelDeployment("existing", "other")
+	existing.Status.Provider = &airunwayv1alpha1.ProviderStatus{Name: "kaito"}
+	ctx := context.Background()
+
+	selectFor := func(t *testing.T, strategy string, objs ...client.Object) *airunwayv1alpha1.ModelDeployment {
+		t.Helper()
+		md := newCPUModelDeployment(airunwayv1alpha1.EngineTypeVLLM, airunwayv1alpha1.EngineDeviceGPU)
+		objs = append(objs, md, namespace, existing, &providers[0], &providers[1])
+		r := newTestReconciler(newTestScheme(), nil, objs...)
+		r.SelectionStrategy = strategy
+		if err := r.selectProvider(ctx, md); err != nil {
+			t.Fatalf("selectProvider failed: %v", err)
+		}
+		return md
+	}
+
+	// Both providers are eligible and have no selection rules, so priority picks by name
+	if md := selectFor(t, ""); md.Status.Provider.Name != "kaito" ||
+		!strings.Contains(md.Status.Provider.SelectedReason, "priority strategy") {
+		t.Errorf("expected kaito from the priority strategy, got %+v", md.Status.Provider)
+	}
+	// Round-robin moves on from the one placed deployment
+	if md := selectFor(t, selection.RoundRobin); md.Status.Provider.Name != "llmd" {
+		t.Errorf("expected llmd from round-robin, got %+v", md.Status.Provider)
+	}
+	// Weighted capacity avoids the loaded provider
+	if md := selectFor(t, selection.WeightedCapacity); md.Status.Provider.Name != "llmd" {
+		t.Errorf("expected llmd from weighted-capacity, got %+v", md.Status.Provider)
+	}
+
+	// A SelectionPolicy for the namespace overrides the flag, and one with a
+	// namespaceSelector wins over a global one
+	namespace.Labels = map[string]string{"airunway.ai/tier": "prod"}
+	global := &airunwayv1alpha1.SelectionPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "a-global"},
+		Spec:       airunwayv1alpha1.SelectionPolicySpec{Strategy: selection.RoundRobin},
+	}
+	prod := &airunwayv1alpha1.SelectionPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod"},
+		Spec: airunwayv1alpha1.SelectionPolicySpec{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"airunway.ai/tier": "prod"}},
+			Strategy:          selection.CostAware,
+			Providers: []airunwayv1alpha1.ProviderSelectionParameters{
+				{Name: "kaito", Cost: 1},
+				{Name: "llmd", Cost: 5},
+			},
+		},
+	}
+	md := selectFor(t, selection.WeightedCapacity, global, prod)
+	if md.Status.Provider.Name != "kaito" ||
+		!strings.Contains(md.Status.Provider.SelectedReason, "cost-aware strategy from SelectionPolicy prod") {
+		t.Errorf("expected kaito from the prod cost-aware policy, got %+v", md.Status.Provider)
+	}
+}
+
+func TestSelectProvider_UnknownStrategy(t *testing.T) {
+	providers := cpuTestProviders()
+	md := newCPUModelDeployment(airunwayv1alpha1.EngineTypeVLLM, airunwayv1alpha1.EngineDeviceGPU)
+	policy := &airunwayv1alpha1.SelectionPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "custom"},
+		Spec:       airunwayv1alpha1.SelectionPolicySpec{Strategy: "nearest"},
+	}
+	r := newTestReconciler(newTestScheme(), nil, md, policy, &providers[0], &providers[1])
+	err := r.selectProvider(context.Background(), md)
+	if err == nil || !strings.Contains(err.Error(), "SelectionPolicy custom") {
+		t.Errorf("expected an unknown strategy error naming the policy, got %v", err)
+	}
+	if md.Status.Provider != nil {
+		t.Errorf("expected no provider to be selected, got %+v", md.Status.Provider)
+	}
+}
+
+func TestReconcileSelectionReport_Strategy(t *testing.T) {
+	providers := cpuTestProviders()
+	md := newCPUModelDeployment(airunwayv1alpha1.EngineTypeVLLM, airunwayv1alpha1.EngineDeviceGPU)
+	md.Annotations = map[string]string{airunwayv1alpha1.AnnotationSelectionExplain: "true"}
+	existing := newModelDeployment("existing", "other")
+	existing.Status.Provider = &airunwayv1alpha1.ProviderStatus{Name: "kaito"}
+	r := newTestReconciler(newTestScheme(), nil, md, existing, &providers[0], &providers[1])
+	r.SelectionStrategy = selection.RoundRobin
+
+	// The report simulates the configured strategy without changing the rotation
+	for range 2 {
+		if err := r.reconcileSelectionReport(context.Background(), md); err != nil {
+			t.Fatalf("reconcileSelectionReport failed: %v", err)
+		}
+		if report := md.Status.SelectionReport; report.Strategy != selection.RoundRobin || report.Selected != "llmd" {
+			t.Errorf("expected llmd from round-robin, got %+v", report)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+func TestValidateSpec_ServingModeAuto(t *testing.T) {
+	r := &ModelDeploymentReconciler{}
+	md := newModelDeployment("test-model", "default")
+	md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeVLLM
+	md.Spec.Resources = &airunwayv1alpha1.ResourceSpec{GPU: &airunwayv1alpha1.GPUSpec{Count: 8}}
+	md.Spec.Serving = &airunwayv1alpha1.ServingSpec{Mode: airunwayv1alpha1.ServingModeAuto}
+
+	err := r.validateSpec(context.Background(), md)
+	if err == nil || !strings.Contains(err.Error(), "admission webhook") {
+		t.Errorf("expected unresolved serving.mode auto to be rejected, got %v", err)
+	}
+}
+
+func TestValidateSpec_Multinode(t *testing.T) {
+	r := &ModelDeploymentReconciler{}
+	md := newModelDeployment("test-model", "default")
+	md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeVLLM
+	md.Spec.Resources = &airunwayv1alpha1.ResourceSpec{GPU: &airunwayv1alpha1.GPUSpec{Count: 8}}
+	md.Spec.Multinode = &airunwayv1alpha1.MultinodeSpec{NodeCount: 2}
+	if err := r.validateSpec(context.Background(), md); err != nil {
+		t.Errorf("expected multinode with GPUs to be valid, got %v", err)
+	}
+
+	md.Spec.Serving = &airunwayv1alpha1.ServingSpec{Mode: airunwayv1alpha1.ServingModeDisaggregated}
+	if err := r.validateSpec(context.Background(), md); err == nil || !strings.Contains(err.Error(), "aggregated mode") {
+		t.Errorf("expected multinode to be rejected in disaggregated mode, got %v", err)
+	}
+
+	md.Spec.Serving = nil
+	md.Spec.Engine = airunwayv1alpha1.EngineSpec{Type: airunwayv1alpha1.EngineTypeLlamaCpp}
+	md.Spec.Resources = nil
+	if err := r.validateSpec(context.Background(), md); err == nil || !strings.Contains(err.Error(), "resources.gpu.count") {
+		t.Errorf("expected multinode without GPUs to be rejected, got %v", err)
+	}
+}
+
+func TestReconcile_ServingModeSelectedCondition(t *testing.T) {
+	scheme := newTestScheme()
+	md := newModelDeployment("test-model", "default")
+	md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeVLLM
+	md.Spec.Resources = &airunwayv1alpha1.ResourceSpec{GPU: &airunwayv1alpha1.GPUSpec{Count: 1}}
+	md.Annotations = map[string]string{
+		airunwayv1alpha1.AnnotationServingModeReason:  "SmallModel",
+		airunwayv1alpha1.AnnotationServingModeMessage: "8B parameters is below the 30B disaggregation threshold",
+	}
+	r := newTestReconciler(scheme, fakeDetector(false, "", ""), md)
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "test-model", Namespace: "default"}
+
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+
+	var updated airunwayv1alpha1.ModelDeployment
+	if err := r.Get(ctx, key, &updated); err != nil {
+		t.Fatalf("failed to get ModelDeployment: %v", err)
+	}
+	cond := meta.FindStatusCondition(updated.Status.Conditions, airunwayv1alpha1.ConditionTypeServingModeSelected)
+	if cond == nil || cond.Status != metav1.ConditionTrue || cond.Reason != "SmallModel" ||
+		!strings.Contains(cond.Message, "disaggregation threshold") {
+		t.Errorf("expected ServingModeSelected True/SmallModel, got %+v", cond)
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultAdmissionPollInterval is how often a queued deployment checks its Workload for admission
+	DefaultAdmissionPollInterval = 10 * time.Second
+
+	// DefaultActivityPollInterval is how often request activity is sampled for spec.ttlSecondsAfterLastRequest
+	DefaultActivityPollInterval = time.Minute
+
+	// DefaultMetricsSnapshotInterval is how often status.metricsSnapshot is recorded
+	DefaultMetricsSnapshotInterval = time.Minute
+)
+
+// Settings are the ModelDeploymentReconciler settings that can be changed while the
+// controller runs, when the controller manager config file is reloaded.
+type Settings struct {
+	EnableProviderSelector  bool
+	SelectionStrategy       string
+	GatewayProbeInterval    time.Duration
+	TracingEndpoint         string
+	AdmissionPollInterval   time.Duration
+	ActivityPollInterval    time.Duration
+	MetricsSnapshotInterval time.Duration
+}
+
+// reloadedSettings are the settings UpdateSettings applied to a running reconciler
+type reloadedSettings struct {
+	mu       sync.RWMutex
+	settings *Settings
+}
+
+// UpdateSettings replaces the settings of a running reconciler. Reconciles in flight keep
+// the settings they started with.
+func (r *ModelDeploymentReconciler) UpdateSettings(s Settings) {
+	r.reloaded.mu.Lock()
+	defer r.reloaded.mu.Unlock()
+	r.reloaded.settings = &s
+}
+
+// settings returns the current settings: the last ones of UpdateSettings, or else the
+// reconciler fields. Unset requeue intervals get their defaults; a zero
+// MetricsSnapshotInterval is kept, since it disables snapshots.
+func (r *ModelDeploymentReconciler) settings() Settings {
+	r.reloaded.mu.RLock()
+	reloaded := r.reloaded.settings
+	r.reloaded.mu.RUnlock()
+	var s Settings
+	if reloaded != nil {
+		s = *reloaded
+	} else {
+		s = Settings{
+			EnableProviderSelector:  r.EnableProviderSelector,
+			SelectionStrategy:       r.SelectionStrategy,
+			GatewayProbeInterval:    r.GatewayProbeInterval,
+			TracingEndpoint:         r.TracingEndpoint,
+			AdmissionPollInterval:   r.AdmissionPollInterval,
+			ActivityPollInterval:    r.ActivityPollInterval,
+			MetricsSnapshotInterval: r.MetricsSnapshotInterval,
+		}
+	}
+	if s.AdmissionPollInterval <= 0 {
+		s.AdmissionPollInterval = DefaultAdmissionPollInterval
+	}
+	if s.ActivityPollInterval <= 0 {
+		s.ActivityPollInterval = DefaultActivityPollInterval
+	}
+	return s
+}
+
+// reconcilesNamespace reports whether ModelDeployments in namespace are reconciled
+func (r *ModelDeploymentReconciler) reconcilesNamespace(namespace string) bool {
+	return len(r.Namespaces) == 0 || slices.Contains(r.Namespaces, namespace)
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSettings(t *testing.T) {
+	r := &ModelDeploymentReconciler{EnableProviderSelector: true}
+	s := r.settings()
+	if !s.EnableProviderSelector {
+		t.Error("expected the provider selector from the reconciler fields")
+	}
+	if s.AdmissionPollInterval != DefaultAdmissionPollInterval || s.ActivityPollInterval != DefaultActivityPollInterval {
+		t.Errorf("expected default poll intervals, got %s and %s", s.AdmissionPollInterval, s.ActivityPollInterval)
+	}
+	if s.MetricsSnapshotInterval != 0 {
+		t.Errorf("expected metrics snapshots to stay disabled, got %s", s.MetricsSnapshotInterval)
+	}
+
+	r.UpdateSettings(Settings{
+		GatewayProbeInterval:  time.Minute,
+		TracingEndpoint:       "http://otel-collector.observability:4317",
+		AdmissionPollInterval: 5 * time.Second,
+	})
+	s = r.settings()
+	if s.EnableProviderSelector {
+		t.Error("expected the provider selector to be disabled by the update")
+	}
+	if s.GatewayProbeInterval != time.Minute || s.AdmissionPollInterval != 5*time.Second {
+		t.Errorf("expected the updated intervals, got %s and %s", s.GatewayProbeInterval, s.AdmissionPollInterval)
+	}
+	if s.TracingEndpoint != "http://otel-collector.observability:4317" {
+		t.Errorf("expected the updated tracing endpoint, got %q", s.TracingEndpoint)
+	}
+}
+
+func TestReconcilesNamespace(t *testing.T) {
+	r := &ModelDeploymentReconciler{}
+	if !r.reconcilesNamespace("team-a") {
+		t.Error("expected all namespaces to be reconciled without Namespaces")
+	}
+	r.Namespaces = []string{"team-a"}
+	if !r.reconcilesNamespace("team-a") || r.reconcilesNamespace("team-b") {
+		t.Error("expected only team-a to be reconciled")
+	}
+}
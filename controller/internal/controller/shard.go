@@ -0,0 +1,72 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"hash/fnv"
+	"strconv"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+// Sharding splits ModelDeployments across controller replicas. Each shard reconciles
+// and writes the status of its own ModelDeployments only, so shards run active-active.
+// The zero value is a single shard that owns every ModelDeployment.
+type Sharding struct {
+	// Count is the number of shards. Zero or one disables sharding.
+	Count int
+
+	// ID is the shard of this controller, in [0, Count).
+	ID int
+}
+
+// Enabled reports whether ModelDeployments are split across more than one shard
+func (s Sharding) Enabled() bool {
+	return s.Count > 1
+}
+
+// Owns reports whether obj belongs to this shard
+func (s Sharding) Owns(obj client.Object) bool {
+	if !s.Enabled() {
+		return true
+	}
+	return ShardOf(obj, s.Count) == s.ID
+}
+
+// Predicate filters events to the objects of this shard
+func (s Sharding) Predicate() predicate.Predicate {
+	return predicate.NewPredicateFuncs(s.Owns)
+}
+
+// ShardOf returns the shard of obj out of count shards: the value of the LabelShard
+// label when it is a valid shard, otherwise the FNV-1a hash of namespace/name.
+func ShardOf(obj client.Object, count int) int {
+	if count <= 1 {
+		return 0
+	}
+	if v, ok := obj.GetLabels()[airunwayv1alpha1.LabelShard]; ok {
+		if shard, err := strconv.Atoi(v); err == nil && shard >= 0 && shard < count {
+			return shard
+		}
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(obj.GetNamespace() + "/" + obj.GetName()))
+	return int(h.Sum32() % uint32(count))
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+func TestShardOf(t *testing.T) {
+	counts := make([]int, 4)
+	for i := range 400 {
+		md := newModelDeployment(fmt.Sprintf("model-%d", i), "default")
+		shard := ShardOf(md, 4)
+		if shard != ShardOf(md, 4) {
+			t.Fatalf("expected a stable shard for %s", md.Name)
+		}
+		counts[shard]++
+	}
+	for shard, n := range counts {
+		if n < 50 {
+			t.Errorf("expected ModelDeployments to spread across shards, shard %d has %d of 400", shard, n)
+		}
+	}
+
+	md := newModelDeployment("test-model", "default")
+	md.Labels = map[string]string{airunwayv1alpha1.LabelShard: "3"}
+	if got := ShardOf(md, 4); got != 3 {
+		t.Errorf("expected the shard label to pin shard 3, got %d", got)
+	}
+	hashed := ShardOf(newModelDeployment("test-model", "default"), 4)
+	for _, invalid := range []string{"4", "-1", "a"} {
+		md.Labels[airunwayv1alpha1.LabelShard] = invalid
+		if got := ShardOf(md, 4); got != hashed {
+			t.Errorf("expected shard label %q to fall back to the hashed shard %d, got %d", invalid, hashed, got)
+		}
+	}
+	if got := ShardOf(md, 1); got != 0 {
+		t.Errorf("expected shard 0 without sharding, got %d", got)
+	}
+}
+
+func TestShardingOwns(t *testing.T) {
+	md := newModelDeployment("test-model", "default")
+	if !(Sharding{}).Owns(md) {
+		t.Error("expected the zero value to own every ModelDeployment")
+	}
+	owners := 0
+	for id := range 3 {
+		if (Sharding{Count: 3, ID: id}).Owns(md) {
+			owners++
+		}
+	}
+	if owners != 1 {
+		t.Errorf("expected exactly one shard to own the ModelDeployment, got %d", owners)
+	}
+}
+
+func TestReconcile_OtherShard(t *testing.T) {
+	scheme := newTestScheme()
+	md := newModelDeployment("test-model", "default")
+	md.Annotations = map[string]string{airunwayv1alpha1.AnnotationReconcilePaused: "true"}
+	md.Labels = map[string]string{airunwayv1alpha1.LabelShard: "1"}
+	r := newTestReconciler(scheme, nil, md)
+	r.Sharding = Sharding{Count: 2, ID: 0}
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "test-model", Namespace: "default"}
+
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	var got airunwayv1alpha1.ModelDeployment
+	if err := r.Get(ctx, key, &got); err != nil {
+		t.Fatalf("failed to get ModelDeployment: %v", err)
+	}
+	if meta.FindStatusCondition(got.Status.Conditions, airunwayv1alpha1.ConditionTypePausedReconciliation) != nil {
+		t.Error("expected a ModelDeployment of another shard to be left alone")
+	}
+
+	r.Sharding.ID = 1
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if err := r.Get(ctx, key, &got); err != nil {
+		t.Fatalf("failed to get ModelDeployment: %v", err)
+	}
+	if !meta.IsStatusConditionTrue(got.Status.Conditions, airunwayv1alpha1.ConditionTypePausedReconciliation) {
+		t.Errorf("expected the owning shard to reconcile, got %+v", got.Status.Conditions)
+	}
+}
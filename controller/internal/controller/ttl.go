@@ -0,0 +1,229 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/internal/activity"
+)
+
+var activityClient = &http.Client{Timeout: 5 * time.Second}
+
+// ActivitySource samples the request activity of a running ModelDeployment
+type ActivitySource interface {
+	SampleActivity(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) (activity.Sample, error)
+}
+
+// PodActivitySource samples the request activity of each running pod of a ModelDeployment
+type PodActivitySource interface {
+	// SamplePods returns the samples of the pods that answered, by pod name
+	SamplePods(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) (map[string]activity.Sample, error)
+}
+
+// podMetricsActivitySource scrapes the engine metrics of every running model server pod.
+// Pods are scraped individually because the Service would spread the scrapes across
+// replicas whose counters differ.
+type podMetricsActivitySource struct {
+	client.Reader
+}
+
+// SampleActivity implements ActivitySource
+func (s *podMetricsActivitySource) SampleActivity(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) (activity.Sample, error) {
+	pods, err := modelPods(ctx, s.Reader, md)
+	if err != nil {
+		return activity.Sample{}, err
+	}
+	if len(pods) == 0 {
+		return activity.Sample{}, fmt.Errorf("no running model server pods")
+	}
+	port := s.metricsPort(ctx, md)
+	var total activity.Sample
+	for i := range pods {
+		sample, err := scrapePod(ctx, &pods[i], port)
+		if err != nil {
+			return activity.Sample{}, err
+		}
+		total = total.Add(sample)
+	}
+	return total, nil
+}
+
+// SamplePods implements PodActivitySource. Pods that cannot be scraped are left out.
+func (s *podMetricsActivitySource) SamplePods(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) (map[string]activity.Sample, error) {
+	pods, err := modelPods(ctx, s.Reader, md)
+	if err != nil {
+		return nil, err
+	}
+	port := s.metricsPort(ctx, md)
+	samples := make(map[string]activity.Sample, len(pods))
+	for i := range pods {
+		sample, err := scrapePod(ctx, &pods[i], port)
+		if err != nil {
+			log.FromContext(ctx).V(1).Info("Could not sample pod metrics", "pod", pods[i].Name, "error", err.Error())
+			continue
+		}
+		samples[pods[i].Name] = sample
+	}
+	return samples, nil
+}
+
+// scrapePod scrapes the engine metrics of a model server pod
+func scrapePod(ctx context.Context, pod *corev1.Pod, port int32) (activity.Sample, error) {
+	if pod.Status.PodIP == "" {
+		return activity.Sample{}, fmt.Errorf("pod %s has no IP", pod.Name)
+	}
+	url := "http://" + net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(int(port))) + "/metrics"
+	sample, err := activity.Scrape(ctx, activityClient, url)
+	if err != nil {
+		return activity.Sample{}, fmt.Errorf("scraping pod %s: %w", pod.Name, err)
+	}
+	return sample, nil
+}
+
+// metricsPort returns the container port behind the endpoint Service. The engines serve
+// /metrics on their API port.
+func (s *podMetricsActivitySource) metricsPort(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) int32 {
+	if md.Status.Endpoint != nil && md.Status.Endpoint.Service != "" {
+		var svc corev1.Service
+		if err := s.Get(ctx, client.ObjectKey{Name: md.Status.Endpoint.Service, Namespace: md.Namespace}, &svc); err == nil {
+			for _, p := range svc.Spec.Ports {
+				if md.Status.Endpoint.Port != 0 && p.Port != md.Status.Endpoint.Port {
+					continue
+				}
+				if p.TargetPort.IntValue() > 0 {
+					return int32(p.TargetPort.IntValue())
+				}
+				return p.Port
+			}
+		}
+		if md.Status.Endpoint.Port != 0 {
+			return md.Status.Endpoint.Port
+		}
+	}
+	return 8000
+}
+
+// reconcileTTL deletes the ModelDeployment once spec.ttlSecondsAfterCreation or
+// spec.ttlSecondsAfterLastRequest has elapsed. It returns whether the deployment was
+// deleted, and otherwise how long to wait before checking again, or zero.
+func (r *ModelDeploymentReconciler) reconcileTTL(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) (bool, time.Duration, error) {
+	now := time.Now()
+	var requeueAfter time.Duration
+
+	if ttl := md.Spec.TTLSecondsAfterCreation; ttl != nil {
+		expiry := md.CreationTimestamp.Add(time.Duration(*ttl) * time.Second)
+		if !now.Before(expiry) {
+			return true, 0, r.expire(ctx, md, fmt.Sprintf("ttlSecondsAfterCreation of %ds elapsed", *ttl))
+		}
+		requeueAfter = expiry.Sub(now)
+	}
+
+	key := types.NamespacedName{Name: md.Name, Namespace: md.Namespace}
+	ttl := md.Spec.TTLSecondsAfterLastRequest
+	if ttl == nil {
+		r.activity.Delete(key)
+		md.Status.LastRequestTime = nil
+		return false, requeueAfter, nil
+	}
+	if md.Status.Phase != airunwayv1alpha1.DeploymentPhaseRunning {
+		// Counters restart with the pods; take a new baseline once Running again
+		r.activity.Delete(key)
+		return false, requeueAfter, nil
+	}
+
+	if r.observeActivity(ctx, md, now) {
+		expiry := md.Status.LastRequestTime.Add(time.Duration(*ttl) * time.Second)
+		if !now.Before(expiry) {
+			return true, 0, r.expire(ctx, md, fmt.Sprintf("no requests for ttlSecondsAfterLastRequest of %ds", *ttl))
+		}
+	}
+	if interval := r.settings().ActivityPollInterval; requeueAfter == 0 || interval < requeueAfter {
+		requeueAfter = interval
+	}
+	return false, requeueAfter, nil
+}
+
+// observeActivity reads the last request activity sample of the background scraper and
+// advances status.lastRequestTime when requests were served since the previous sample.
+// The idle clock starts when the deployment is first seen Running. It returns false when
+// idleness cannot be judged: without a previous sample, e.g. after a controller restart,
+// or when sampling fails.
+func (r *ModelDeploymentReconciler) observeActivity(ctx context.Context, md *airunwayv1alpha1.ModelDeployment, now time.Time) bool {
+	key := types.NamespacedName{Name: md.Name, Namespace: md.Namespace}
+	if md.Status.LastRequestTime == nil {
+		md.Status.LastRequestTime = &metav1.Time{Time: now}
+	}
+
+	result, ok := r.sampleActivity(ctx, md, scrapeActivity, r.settings().ActivityPollInterval)
+	if !ok {
+		return false
+	}
+	if result.err != nil {
+		log.FromContext(ctx).Info("Could not sample request activity, idle TTL not enforced", "name", md.Name, "error", result.err.Error())
+		r.activity.Delete(key)
+		return false
+	}
+
+	current := metricsSample{sample: result.value.(activity.Sample), time: result.time}
+	prev, ok := r.activity.Swap(key, current)
+	if !ok {
+		return false
+	}
+	if prev := prev.(metricsSample); current.time.After(prev.time) && current.sample.ActiveSince(prev.sample) {
+		md.Status.LastRequestTime = &metav1.Time{Time: current.time}
+	}
+	return true
+}
+
+// sampleActivity returns the last request activity sample of a deployment taken by the
+// background scraper for kind, sampled once per interval
+func (r *ModelDeploymentReconciler) sampleActivity(ctx context.Context, md *airunwayv1alpha1.ModelDeployment, kind string, interval time.Duration) (scrapeResult, bool) {
+	source := r.ActivitySource
+	if source == nil {
+		source = &podMetricsActivitySource{Reader: r.Client}
+	}
+	target := md.DeepCopy()
+	return r.scrape(ctx, md, kind, "", interval, func(ctx context.Context) (interface{}, error) {
+		return source.SampleActivity(ctx, target)
+	})
+}
+
+// expire deletes an ephemeral ModelDeployment whose TTL elapsed
+func (r *ModelDeploymentReconciler) expire(ctx context.Context, md *airunwayv1alpha1.ModelDeployment, reason string) error {
+	log.FromContext(ctx).Info("Deleting expired ModelDeployment", "name", md.Name, "reason", reason)
+	if r.Recorder != nil {
+		r.Recorder.Eventf(md, nil, corev1.EventTypeNormal, airunwayv1alpha1.ReasonTTLExpired, "Delete", "Deleting ModelDeployment: %s", reason)
+	}
+	if err := r.Delete(ctx, md); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete expired ModelDeployment: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/internal/activity"
+)
+
+type fakeActivitySource struct {
+	sample activity.Sample
+	err    error
+}
+
+func (s *fakeActivitySource) SampleActivity(context.Context, *airunwayv1alpha1.ModelDeployment) (activity.Sample, error) {
+	return s.sample, s.err
+}
+
+func TestReconcileTTL_AfterCreation(t *testing.T) {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "demo", Namespace: "default"}
+
+	md := newModelDeployment("demo", "default")
+	md.CreationTimestamp = metav1.NewTime(time.Now().Add(-30 * time.Minute))
+	md.Spec.TTLSecondsAfterCreation = int32Ptr(3600)
+	r := newTestReconciler(newTestScheme(), nil, md)
+
+	expired, requeueAfter, err := r.reconcileTTL(ctx, md)
+	if err != nil {
+		t.Fatalf("reconcileTTL failed: %v", err)
+	}
+	if expired {
+		t.Fatal("expected deployment to be kept before its TTL")
+	}
+	if requeueAfter <= 29*time.Minute || requeueAfter > 30*time.Minute {
+		t.Errorf("expected requeue at the remaining 30m, got %s", requeueAfter)
+	}
+
+	md.CreationTimestamp = metav1.NewTime(time.Now().Add(-2 * time.Hour))
+	expired, _, err = r.reconcileTTL(ctx, md)
+	if err != nil {
+		t.Fatalf("reconcileTTL failed: %v", err)
+	}
+	if !expired {
+		t.Fatal("expected deployment to expire")
+	}
+	if err := r.Get(ctx, key, &airunwayv1alpha1.ModelDeployment{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected ModelDeployment to be deleted, got %v", err)
+	}
+}
+
+func TestReconcileTTL_AfterLastRequest(t *testing.T) {
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "demo", Namespace: "default"}
+
+	md := newModelDeployment("demo", "default")
+	md.Spec.TTLSecondsAfterLastRequest = int32Ptr(600)
+	source := &fakeActivitySource{sample: activity.Sample{Requests: 10}}
+	r := newTestReconciler(newTestScheme(), nil, md)
+	r.ActivitySource = source
+
+	// sample starts sampling activity again in the background and waits for it
+	sample := func() {
+		rescrape(r, func() { r.sampleActivity(ctx, md, scrapeActivity, DefaultActivityPollInterval) })
+	}
+
+	// The first reconcile starts the idle clock and samples in the background
+	expired, requeueAfter, err := r.reconcileTTL(ctx, md)
+	if err != nil || expired {
+		t.Fatalf("expected deployment to be kept, got expired=%v err=%v", expired, err)
+	}
+	if requeueAfter != DefaultActivityPollInterval {
+		t.Errorf("expected requeue after %s, got %s", DefaultActivityPollInterval, requeueAfter)
+	}
+	if md.Status.LastRequestTime == nil {
+		t.Fatal("expected status.lastRequestTime to be set")
+	}
+
+	// The first sample is the baseline
+	waitForScrapes(r)
+	if expired, _, err := r.reconcileTTL(ctx, md); err != nil || expired {
+		t.Fatalf("expected deployment to be kept, got expired=%v err=%v", expired, err)
+	}
+
+	// Requests served since the last sample advance lastRequestTime
+	stale := metav1.NewTime(time.Now().Add(-time.Hour))
+	md.Status.LastRequestTime = &stale
+	source.sample = activity.Sample{Requests: 12}
+	sample()
+	if expired, _, err := r.reconcileTTL(ctx, md); err != nil || expired {
+		t.Fatalf("expected active deployment to be kept, got expired=%v err=%v", expired, err)
+	}
+	if !md.Status.LastRequestTime.After(stale.Time) {
+		t.Error("expected lastRequestTime to advance after activity")
+	}
+
+	// Sampling failures never delete the deployment
+	md.Status.LastRequestTime = &stale
+	source.err = errors.New("connection refused")
+	sample()
+	if expired, _, err := r.reconcileTTL(ctx, md); err != nil || expired {
+		t.Fatalf("expected deployment to be kept when sampling fails, got expired=%v err=%v", expired, err)
+	}
+
+	// A fresh baseline followed by an idle sample expires the deployment
+	source.err = nil
+	sample()
+	if expired, _, err := r.reconcileTTL(ctx, md); err != nil || expired {
+		t.Fatalf("expected baseline sample to keep the deployment, got expired=%v err=%v", expired, err)
+	}
+	sample()
+	expired, _, err = r.reconcileTTL(ctx, md)
+	if err != nil {
+		t.Fatalf("reconcileTTL failed: %v", err)
+	}
+	if !expired {
+		t.Fatal("expected idle deployment to expire")
+	}
+	if err := r.Get(ctx, key, &airunwayv1alpha1.ModelDeployment{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected ModelDeployment to be deleted, got %v", err)
+	}
+}
+
+func TestReconcileTTL_IdleClockOnlyWhileRunning(t *testing.T) {
+	md := newModelDeployment("demo", "default")
+	md.Status.Phase = airunwayv1alpha1.DeploymentPhaseDeploying
+	md.Spec.TTLSecondsAfterLastRequest = int32Ptr(600)
+	r := newTestReconciler(newTestScheme(), nil, md)
+	r.ActivitySource = &fakeActivitySource{}
+
+	expired, requeueAfter, err := r.reconcileTTL(context.Background(), md)
+	if err != nil || expired {
+		t.Fatalf("expected deployment to be kept, got expired=%v err=%v", expired, err)
+	}
+	if requeueAfter != 0 || md.Status.LastRequestTime != nil {
+		t.Errorf("expected no idle tracking before Running, got requeueAfter=%s lastRequestTime=%v", requeueAfter, md.Status.LastRequestTime)
+	}
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/internal/warmup"
+)
+
+// warmupPollInterval is how often an in-flight warmup is checked for completion
+const warmupPollInterval = 10 * time.Second
+
+// warmupClient allows for slow first requests while the engine compiles the model
+var warmupClient = &http.Client{Timeout: 5 * time.Minute}
+
+// warmupBaseURL returns the in-cluster URL of a deployment's model server service
+var warmupBaseURL = func(service, namespace string, port int32) string {
+	return "http://" + net.JoinHostPort(service+"."+namespace+".svc", strconv.Itoa(int(port)))
+}
+
+// warmupRun is a warmup of one ModelDeployment generation running in the background
+type warmupRun struct {
+	generation int64
+	cancel     context.CancelFunc
+	done       chan struct{}
+	status     airunwayv1alpha1.WarmupStatus
+	err        error
+}
+
+// reconcileWarmup sends the spec.warmup requests once per generation after the deployment
+// becomes Running. The requests run in the background so a cold engine cannot block the
+// reconcile loop; the result is recorded in status.warmup and the WarmedUp condition on a
+// later reconcile. It returns how long to wait before checking on the warmup, or zero.
+func (r *ModelDeploymentReconciler) reconcileWarmup(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) time.Duration {
+	key := types.NamespacedName{Name: md.Name, Namespace: md.Namespace}
+	if md.Spec.Warmup == nil {
+		r.resetWarmup(key, md)
+		return 0
+	}
+	if md.Status.Warmup != nil && md.Status.Warmup.ObservedGeneration == md.Generation {
+		return 0
+	}
+
+	if v, ok := r.warmups.Load(key); ok {
+		run := v.(*warmupRun)
+		if run.generation == md.Generation {
+			select {
+			case <-run.done:
+			default:
+				return warmupPollInterval
+			}
+			r.warmups.Delete(key)
+			status := run.status
+			md.Status.Warmup = &status
+			if run.err != nil {
+				r.setCondition(md, airunwayv1alpha1.ConditionTypeWarmedUp, metav1.ConditionFalse, "WarmupFailed", run.err.Error())
+			} else {
+				r.setCondition(md, airunwayv1alpha1.ConditionTypeWarmedUp, metav1.ConditionTrue, "WarmupSucceeded",
+					fmt.Sprintf("Sent %d warmup requests, first request took %s", status.CompletedRequests, status.FirstRequestLatency.Duration))
+			}
+			return 0
+		}
+		// The spec changed while warming up; start over for the new generation
+		run.cancel()
+		r.warmups.Delete(key)
+	}
+
+	if md.Status.Endpoint == nil || md.Status.Endpoint.Service == "" {
+		return warmupPollInterval
+	}
+	port := r.resolveServicePort(ctx, md.Status.Endpoint.Service, md.Namespace)
+	if port == 0 {
+		port = md.Status.Endpoint.Port
+	}
+	if port == 0 {
+		port = 8000
+	}
+	var model string
+	if md.Status.Gateway != nil && md.Status.Gateway.ModelName != "" {
+		model = md.Status.Gateway.ModelName
+	} else {
+		model = r.resolveModelName(ctx, md)
+		if discoveringModelName(md) {
+			return warmupPollInterval
+		}
+	}
+	baseURL := warmupBaseURL(md.Status.Endpoint.Service, md.Namespace, port)
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	run := &warmupRun{generation: md.Generation, cancel: cancel, done: make(chan struct{})}
+	r.warmups.Store(key, run)
+	spec := *md.Spec.Warmup
+	go func() {
+		defer close(run.done)
+		defer cancel()
+		run.status, run.err = warmup.Run(runCtx, warmupClient, baseURL, model, spec)
+		run.status.CompletionTime = &metav1.Time{Time: time.Now()}
+		run.status.ObservedGeneration = run.generation
+	}()
+
+	log.FromContext(ctx).Info("Started warmup", "name", md.Name, "model", model, "requests", spec.Requests)
+	r.setCondition(md, airunwayv1alpha1.ConditionTypeWarmedUp, metav1.ConditionUnknown, "WarmupInProgress", "Sending warmup requests")
+	return warmupPollInterval
+}
+
+// resetWarmup cancels any in-flight warmup and clears the warmup status, so the next time
+// the deployment becomes Running it is warmed up again
+func (r *ModelDeploymentReconciler) resetWarmup(key types.NamespacedName, md *airunwayv1alpha1.ModelDeployment) {
+	r.forgetWarmup(key)
+	md.Status.Warmup = nil
+	meta.RemoveStatusCondition(&md.Status.Conditions, airunwayv1alpha1.ConditionTypeWarmedUp)
+}
+
+// forgetWarmup cancels the in-flight warmup of a ModelDeployment, if any
+func (r *ModelDeploymentReconciler) forgetWarmup(key types.NamespacedName) {
+	if v, ok := r.warmups.LoadAndDelete(key); ok {
+		v.(*warmupRun).cancel()
+	}
+}
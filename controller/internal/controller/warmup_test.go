@@ -0,0 +1,149 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+// waitForWarmup blocks until the in-flight warmup of a ModelDeployment has finished
+func waitForWarmup(t *testing.T, r *ModelDeploymentReconciler, key types.NamespacedName) {
+	t.Helper()
+	v, ok := r.warmups.Load(key)
+	if !ok {
+		t.Fatal("expected a warmup to be in flight")
+	}
+	<-v.(*warmupRun).done
+}
+
+func TestReconcileWarmup(t *testing.T) {
+	var requests atomic.Int32
+	healthy := atomic.Bool{}
+	healthy.Store(true)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/v1/completions" || !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		requests.Add(1)
+		_, _ = w.Write([]byte(`{"choices":[{"text":"hi"}]}`))
+	}))
+	defer server.Close()
+
+	defaultBaseURL := warmupBaseURL
+	warmupBaseURL = func(string, string, int32) string { return server.URL }
+	defer func() { warmupBaseURL = defaultBaseURL }()
+
+	md := newModelDeployment("warm-model", "default")
+	md.Generation = 1
+	md.Spec.Warmup = &airunwayv1alpha1.WarmupSpec{Requests: 3}
+	md.Status.Phase = airunwayv1alpha1.DeploymentPhaseRunning
+	md.Status.Endpoint = &airunwayv1alpha1.EndpointStatus{Service: "warm-model", Port: 8000}
+	md.Status.Gateway = &airunwayv1alpha1.GatewayStatus{ModelName: "llama"}
+	r := newTestReconciler(newTestScheme(), nil)
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "warm-model", Namespace: "default"}
+
+	if next := r.reconcileWarmup(ctx, md); next != warmupPollInterval {
+		t.Errorf("expected requeue while warming up, got %s", next)
+	}
+	cond := meta.FindStatusCondition(md.Status.Conditions, airunwayv1alpha1.ConditionTypeWarmedUp)
+	if cond == nil || cond.Status != metav1.ConditionUnknown {
+		t.Errorf("expected WarmedUp Unknown while in flight, got %+v", cond)
+	}
+
+	waitForWarmup(t, r, key)
+	if next := r.reconcileWarmup(ctx, md); next != 0 {
+		t.Errorf("expected no requeue after warmup, got %s", next)
+	}
+	if !meta.IsStatusConditionTrue(md.Status.Conditions, airunwayv1alpha1.ConditionTypeWarmedUp) {
+		t.Errorf("expected WarmedUp True, got %+v", md.Status.Conditions)
+	}
+	if md.Status.Warmup == nil || md.Status.Warmup.CompletedRequests != 3 || md.Status.Warmup.ObservedGeneration != 1 ||
+		md.Status.Warmup.FirstRequestLatency == nil || md.Status.Warmup.CompletionTime == nil {
+		t.Errorf("expected warmup status for 3 requests, got %+v", md.Status.Warmup)
+	}
+
+	// The same generation is not warmed up twice
+	r.reconcileWarmup(ctx, md)
+	if _, ok := r.warmups.Load(key); ok || requests.Load() != 3 {
+		t.Errorf("expected no second warmup, got %d requests", requests.Load())
+	}
+
+	// A spec change warms up again and reports failures
+	md.Generation = 2
+	healthy.Store(false)
+	r.reconcileWarmup(ctx, md)
+	waitForWarmup(t, r, key)
+	r.reconcileWarmup(ctx, md)
+	cond = meta.FindStatusCondition(md.Status.Conditions, airunwayv1alpha1.ConditionTypeWarmedUp)
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != "WarmupFailed" {
+		t.Errorf("expected WarmedUp False/WarmupFailed, got %+v", cond)
+	}
+	if md.Status.Warmup == nil || md.Status.Warmup.CompletedRequests != 0 || md.Status.Warmup.ObservedGeneration != 2 {
+		t.Errorf("expected failed warmup status for generation 2, got %+v", md.Status.Warmup)
+	}
+
+	// Removing spec.warmup clears the status
+	md.Spec.Warmup = nil
+	r.reconcileWarmup(ctx, md)
+	if md.Status.Warmup != nil || meta.FindStatusCondition(md.Status.Conditions, airunwayv1alpha1.ConditionTypeWarmedUp) != nil {
+		t.Errorf("expected warmup status to be cleared, got %+v", md.Status.Warmup)
+	}
+}
+
+func TestReconcileWarmup_WaitsForModelDiscovery(t *testing.T) {
+	defaultBaseURL := warmupBaseURL
+	warmupBaseURL = func(string, string, int32) string { return "http://127.0.0.1:1" }
+	defer func() { warmupBaseURL = defaultBaseURL }()
+
+	md := newModelDeployment("warm-model", "default")
+	md.Generation = 1
+	md.Spec.Warmup = &airunwayv1alpha1.WarmupSpec{Requests: 1}
+	md.Status.Phase = airunwayv1alpha1.DeploymentPhaseRunning
+	md.Status.Endpoint = &airunwayv1alpha1.EndpointStatus{Service: "nonexistent-svc", Port: 8000}
+	r := newTestReconciler(newTestScheme(), nil)
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "warm-model", Namespace: "default"}
+
+	// The served model name is discovered in the background before warming up
+	if next := r.reconcileWarmup(ctx, md); next != warmupPollInterval {
+		t.Errorf("expected requeue while discovering the model name, got %s", next)
+	}
+	if _, ok := r.warmups.Load(key); ok {
+		t.Error("expected no warmup before the model name was discovered")
+	}
+	waitForScrapes(r)
+
+	md.Status.Gateway = &airunwayv1alpha1.GatewayStatus{ModelDiscovery: &airunwayv1alpha1.ModelDiscoveryStatus{
+		ModelName:          "served",
+		ProbeTime:          metav1.Now(),
+		ObservedGeneration: 1,
+	}}
+	r.reconcileWarmup(ctx, md)
+	waitForWarmup(t, r, key)
+}
@@ -0,0 +1,400 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package dashboard renders Grafana dashboards for the metrics of the controller and of
+// ModelDeployments. Panels are derived from the metric families the controller emits and
+// from the engine series the controller reads, so dashboards follow metric renames
+// without being edited by hand.
+package dashboard
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	dto "github.com/prometheus/client_model/go"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+const (
+	// schemaVersion is the Grafana dashboard schema the dashboards are written in
+	schemaVersion = 39
+
+	// DeploymentLabel is the label engine series must carry with the name of their
+	// ModelDeployment, e.g. relabeled from the airunway.ai/model-deployment pod label
+	DeploymentLabel = "model_deployment"
+
+	// latencyQuantile is the quantile shown for latency histograms
+	latencyQuantile = "0.95"
+
+	// panelWidth and panelHeight are the size of each panel, two to a row of the grid
+	panelWidth  = 12
+	panelHeight = 8
+)
+
+// metricPrefixes are the prefixes of the controller's own metric families. Go runtime and
+// controller-runtime metrics have dashboards of their own.
+var metricPrefixes = []string{"kubeairunway_", "airunway_"}
+
+// Dashboard is a Grafana dashboard
+type Dashboard struct {
+	UID           string     `json:"uid"`
+	Title         string     `json:"title"`
+	Tags          []string   `json:"tags"`
+	SchemaVersion int        `json:"schemaVersion"`
+	Refresh       string     `json:"refresh"`
+	Time          TimeRange  `json:"time"`
+	Templating    Templating `json:"templating"`
+	Panels        []Panel    `json:"panels"`
+}
+
+// TimeRange is the default time range of a dashboard
+type TimeRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Templating holds the variables of a dashboard
+type Templating struct {
+	List []Variable `json:"list"`
+}
+
+// Variable is a dashboard variable
+type Variable struct {
+	Name       string   `json:"name"`
+	Label      string   `json:"label,omitempty"`
+	Type       string   `json:"type"`
+	Query      string   `json:"query"`
+	Multi      bool     `json:"multi,omitempty"`
+	IncludeAll bool     `json:"includeAll,omitempty"`
+	AllValue   string   `json:"allValue,omitempty"`
+	Current    *Current `json:"current,omitempty"`
+}
+
+// Current is the selected value of a variable
+type Current struct {
+	Text  string `json:"text"`
+	Value string `json:"value"`
+}
+
+// Panel is a time series panel, or a row grouping the panels below it
+type Panel struct {
+	ID          int          `json:"id"`
+	Type        string       `json:"type"`
+	Title       string       `json:"title"`
+	Description string       `json:"description,omitempty"`
+	GridPos     GridPos      `json:"gridPos"`
+	Datasource  *Datasource  `json:"datasource,omitempty"`
+	Targets     []Target     `json:"targets,omitempty"`
+	FieldConfig *FieldConfig `json:"fieldConfig,omitempty"`
+}
+
+// GridPos is the position of a panel on the 24 column grid
+type GridPos struct {
+	H int `json:"h"`
+	W int `json:"w"`
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// Datasource refers to the Prometheus data source picked in the datasource variable
+type Datasource struct {
+	Type string `json:"type"`
+	UID  string `json:"uid"`
+}
+
+// Target is a PromQL query of a panel
+type Target struct {
+	RefID        string `json:"refId"`
+	Expr         string `json:"expr"`
+	LegendFormat string `json:"legendFormat,omitempty"`
+}
+
+// FieldConfig sets the unit of the values of a panel
+type FieldConfig struct {
+	Defaults FieldDefaults `json:"defaults"`
+}
+
+// FieldDefaults are the field settings of a panel
+type FieldDefaults struct {
+	Unit string `json:"unit"`
+}
+
+// engineSeries are the metrics of an engine shown on the ModelDeployment dashboard. They
+// are the series the controller reads engine activity from.
+type engineSeries struct {
+	title string
+	// requests is a cumulative counter, of prompt tokens for llama.cpp which has no
+	// request counter
+	requests, requestsTitle string
+	running                 string
+	// kvCache is the fraction of the KV cache in use
+	kvCache string
+	// latency is the end-to-end request latency histogram without its _bucket suffix, or
+	// empty for engines that report none
+	latency string
+}
+
+// engines are the engine series by engine type. TensorRT-LLM metrics are not read by the
+// controller, so deployments of it get no engine panels.
+var engines = map[airunwayv1alpha1.EngineType]engineSeries{
+	airunwayv1alpha1.EngineTypeVLLM: {
+		title:    "vLLM",
+		requests: "vllm:request_success_total", requestsTitle: "Requests",
+		running: "vllm:num_requests_running",
+		kvCache: "vllm:kv_cache_usage_perc",
+		latency: "vllm:e2e_request_latency_seconds",
+	},
+	airunwayv1alpha1.EngineTypeSGLang: {
+		title:    "SGLang",
+		requests: "sglang:num_requests_total", requestsTitle: "Requests",
+		running: "sglang:num_running_reqs",
+		kvCache: "sglang:token_usage",
+		latency: "sglang:e2e_request_latency_seconds",
+	},
+	airunwayv1alpha1.EngineTypeLlamaCpp: {
+		title:    "llama.cpp",
+		requests: "llamacpp:prompt_tokens_total", requestsTitle: "Prompt tokens",
+		running: "llamacpp:requests_processing",
+		kvCache: "llamacpp:kv_cache_usage_ratio",
+	},
+}
+
+// builder lays out panels two to a row
+type builder struct {
+	panels []Panel
+	x, y   int
+}
+
+// row starts a new row titled title
+func (b *builder) row(title string) {
+	if b.x > 0 {
+		b.x, b.y = 0, b.y+panelHeight
+	}
+	b.panels = append(b.panels, Panel{
+		ID:      len(b.panels) + 1,
+		Type:    "row",
+		Title:   title,
+		GridPos: GridPos{H: 1, W: 24, Y: b.y},
+	})
+	b.y++
+}
+
+// panel adds a time series panel of one query
+func (b *builder) panel(title, description, expr, legend, unit string) {
+	b.panels = append(b.panels, Panel{
+		ID:          len(b.panels) + 1,
+		Type:        "timeseries",
+		Title:       title,
+		Description: description,
+		GridPos:     GridPos{H: panelHeight, W: panelWidth, X: b.x, Y: b.y},
+		Datasource:  &Datasource{Type: "prometheus", UID: "${datasource}"},
+		Targets:     []Target{{RefID: "A", Expr: expr, LegendFormat: legend}},
+		FieldConfig: &FieldConfig{Defaults: FieldDefaults{Unit: unit}},
+	})
+	if b.x += panelWidth; b.x >= 24 {
+		b.x, b.y = 0, b.y+panelHeight
+	}
+}
+
+// newDashboard returns a dashboard with a Prometheus datasource variable
+func newDashboard(uid, title string, panels []Panel, variables ...Variable) *Dashboard {
+	return &Dashboard{
+		UID:           uid,
+		Title:         title,
+		Tags:          []string{"airunway"},
+		SchemaVersion: schemaVersion,
+		Refresh:       "30s",
+		Time:          TimeRange{From: "now-6h", To: "now"},
+		Templating: Templating{List: append([]Variable{{
+			Name:  "datasource",
+			Label: "Data source",
+			Type:  "datasource",
+			Query: "prometheus",
+		}}, variables...)},
+		Panels: panels,
+	}
+}
+
+// Controller returns the dashboard of the controller's own metric families, one panel per
+// family, from families gathered from its metrics registry. Families that have no series
+// yet, such as a vector no deployment has reported to, are left out until they do.
+func Controller(families []*dto.MetricFamily) *Dashboard {
+	b := &builder{}
+	for _, family := range controllerFamilies(families) {
+		b.familyPanel(family, "", labelNames(family))
+	}
+	return newDashboard("airunway-controller", "AI Runway / Controller", b.panels)
+}
+
+// ModelDeployments returns the dashboard of the ModelDeployments in namespace, with a
+// deployment variable listing them. It has a row of engine panels for each engine the
+// deployments run, and a row of the controller metric families that are reported per
+// deployment, i.e. that have namespace and name labels.
+func ModelDeployments(namespace string, deployments []airunwayv1alpha1.ModelDeployment, families []*dto.MetricFamily) *Dashboard {
+	names := make([]string, 0, len(deployments))
+	var engineTypes []airunwayv1alpha1.EngineType
+	for i := range deployments {
+		names = append(names, deployments[i].Name)
+		if engine := deployments[i].ResolvedEngineType(); !slices.Contains(engineTypes, engine) {
+			engineTypes = append(engineTypes, engine)
+		}
+	}
+	sort.Strings(names)
+	slices.Sort(engineTypes)
+
+	b := &builder{}
+	selector := fmt.Sprintf(`{namespace=%q, %s=~"$deployment"}`, namespace, DeploymentLabel)
+	for _, engine := range engineTypes {
+		series, ok := engines[engine]
+		if !ok {
+			continue
+		}
+		b.row(series.title)
+		by := "sum by (" + DeploymentLabel + ")"
+		legend := "{{" + DeploymentLabel + "}}"
+		b.panel(series.requestsTitle+" per second", "Rate of "+series.requests+".",
+			fmt.Sprintf("%s (rate(%s%s[$__rate_interval]))", by, series.requests, selector), legend, "short")
+		b.panel("Running requests", "Requests in flight from "+series.running+".",
+			fmt.Sprintf("%s (%s%s)", by, series.running, selector), legend, "short")
+		b.panel("KV cache usage", "Mean fraction of the KV cache in use from "+series.kvCache+".",
+			fmt.Sprintf("avg by (%s) (%s%s)", DeploymentLabel, series.kvCache, selector), legend, "percentunit")
+		if series.latency != "" {
+			b.panel("P95 request latency", "End-to-end request latency from "+series.latency+".",
+				fmt.Sprintf("histogram_quantile(%s, sum by (le, %s) (rate(%s_bucket%s[$__rate_interval])))",
+					latencyQuantile, DeploymentLabel, series.latency, selector), legend, "s")
+		}
+	}
+
+	var perDeployment []*dto.MetricFamily
+	for _, family := range controllerFamilies(families) {
+		labels := labelNames(family)
+		if slices.Contains(labels, "namespace") && slices.Contains(labels, "name") {
+			perDeployment = append(perDeployment, family)
+		}
+	}
+	if len(perDeployment) > 0 {
+		b.row("Controller")
+		for _, family := range perDeployment {
+			b.familyPanel(family, fmt.Sprintf(`{namespace=%q, name=~"$deployment"}`, namespace), labelNames(family))
+		}
+	}
+
+	sum := sha256.Sum256([]byte(namespace))
+	return newDashboard("airunway-ns-"+hex.EncodeToString(sum[:8]), "AI Runway / "+namespace, b.panels, Variable{
+		Name:       "deployment",
+		Label:      "ModelDeployment",
+		Type:       "custom",
+		Query:      strings.Join(names, ","),
+		Multi:      true,
+		IncludeAll: true,
+		AllValue:   ".*",
+		Current:    &Current{Text: "All", Value: "$__all"},
+	})
+}
+
+// familyPanel adds the panel of a metric family: its value for gauges, its rate for
+// counters, and its 95th percentile for histograms. Other types are skipped.
+func (b *builder) familyPanel(family *dto.MetricFamily, selector string, labels []string) {
+	name := family.GetName()
+	legend := make([]string, 0, len(labels))
+	for _, label := range labels {
+		legend = append(legend, "{{"+label+"}}")
+	}
+	by := strings.Join(labels, ", ")
+
+	var expr string
+	switch family.GetType() {
+	case dto.MetricType_GAUGE, dto.MetricType_UNTYPED:
+		expr = name + selector
+	case dto.MetricType_COUNTER:
+		expr = fmt.Sprintf("sum by (%s) (rate(%s%s[$__rate_interval]))", by, name, selector)
+	case dto.MetricType_HISTOGRAM:
+		expr = fmt.Sprintf("histogram_quantile(%s, sum by (%s) (rate(%s_bucket%s[$__rate_interval])))",
+			latencyQuantile, strings.Join(append([]string{"le"}, labels...), ", "), name, selector)
+	default:
+		return
+	}
+	b.panel(panelTitle(name, family.GetType()), family.GetHelp(), expr, strings.Join(legend, " "), unit(name))
+}
+
+// controllerFamilies returns the families with a controller metric prefix, by name
+func controllerFamilies(families []*dto.MetricFamily) []*dto.MetricFamily {
+	var matched []*dto.MetricFamily
+	for _, family := range families {
+		if len(family.GetMetric()) == 0 {
+			continue
+		}
+		if slices.ContainsFunc(metricPrefixes, func(prefix string) bool { return strings.HasPrefix(family.GetName(), prefix) }) {
+			matched = append(matched, family)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].GetName() < matched[j].GetName() })
+	return matched
+}
+
+// labelNames returns the sorted label names of the series of a family
+func labelNames(family *dto.MetricFamily) []string {
+	var names []string
+	for _, metric := range family.GetMetric() {
+		for _, pair := range metric.GetLabel() {
+			if !slices.Contains(names, pair.GetName()) {
+				names = append(names, pair.GetName())
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// panelTitle derives a panel title from a metric name, e.g. "Gateway probe success" from
+// kubeairunway_gateway_probe_success
+func panelTitle(name string, metricType dto.MetricType) string {
+	for _, prefix := range metricPrefixes {
+		name = strings.TrimPrefix(name, prefix)
+	}
+	for _, suffix := range []string{"_total", "_seconds", "_percent"} {
+		name = strings.TrimSuffix(name, suffix)
+	}
+	title := strings.ReplaceAll(name, "_", " ")
+	if title == "" {
+		return name
+	}
+	switch metricType {
+	case dto.MetricType_COUNTER:
+		title += " per second"
+	case dto.MetricType_HISTOGRAM:
+		title = "p95 " + title
+	}
+	return strings.ToUpper(title[:1]) + title[1:]
+}
+
+// unit returns the Grafana unit of a metric from the unit suffix of its name
+func unit(name string) string {
+	switch {
+	case strings.HasSuffix(name, "_seconds"):
+		return "s"
+	case strings.HasSuffix(name, "_percent"):
+		return "percent"
+	case strings.HasSuffix(name, "_bytes"):
+		return "bytes"
+	}
+	return "short"
+}
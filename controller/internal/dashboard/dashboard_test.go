@@ -0,0 +1,141 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dashboard
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/internal/activity"
+)
+
+func testFamilies(t *testing.T) *prometheus.Registry {
+	t.Helper()
+	reg := prometheus.NewRegistry()
+	probe := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kubeairunway_gateway_probe_success",
+		Help: "Whether the last probe succeeded.",
+	}, []string{"namespace", "name"})
+	steps := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "kubeairunway_reconcile_step_duration_seconds",
+		Help: "Time spent in each step.",
+	}, []string{"step"})
+	unused := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "kubeairunway_unused", Help: "No series."}, []string{"provider"})
+	reg.MustRegister(probe, steps, unused, prometheus.NewGoCollector())
+	probe.WithLabelValues("team-a", "llama").Set(1)
+	steps.WithLabelValues("gateway").Observe(0.1)
+	return reg
+}
+
+func exprs(d *Dashboard) map[string]string {
+	byTitle := map[string]string{}
+	for _, panel := range d.Panels {
+		if panel.Type != "row" {
+			byTitle[panel.Title] = panel.Targets[0].Expr
+		}
+	}
+	return byTitle
+}
+
+func TestController(t *testing.T) {
+	families, err := testFamilies(t).Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := Controller(families)
+	got := exprs(d)
+	want := map[string]string{
+		"Gateway probe success": "kubeairunway_gateway_probe_success",
+		"P95 reconcile step duration": "histogram_quantile(0.95, sum by (le, step) " +
+			"(rate(kubeairunway_reconcile_step_duration_seconds_bucket[$__rate_interval])))",
+	}
+	if len(got) != len(want) {
+		t.Errorf("got panels %v, want %v", got, want)
+	}
+	for title, expr := range want {
+		if got[title] != expr {
+			t.Errorf("panel %q: got %q, want %q", title, got[title], expr)
+		}
+	}
+	if _, err := json.Marshal(d); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestModelDeployments(t *testing.T) {
+	families, err := testFamilies(t).Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	deployment := func(name string, engine airunwayv1alpha1.EngineType) airunwayv1alpha1.ModelDeployment {
+		md := airunwayv1alpha1.ModelDeployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-a"}}
+		md.Spec.Engine.Type = engine
+		return md
+	}
+	d := ModelDeployments("team-a", []airunwayv1alpha1.ModelDeployment{
+		deployment("qwen", airunwayv1alpha1.EngineTypeLlamaCpp),
+		deployment("llama", airunwayv1alpha1.EngineTypeVLLM),
+		deployment("mistral", airunwayv1alpha1.EngineTypeVLLM),
+	}, families)
+
+	var rows []string
+	for _, panel := range d.Panels {
+		if panel.Type == "row" {
+			rows = append(rows, panel.Title)
+		}
+	}
+	if strings.Join(rows, ",") != "llama.cpp,vLLM,Controller" {
+		t.Errorf("got rows %v, want llama.cpp, vLLM and Controller", rows)
+	}
+	if v := d.Templating.List[1]; v.Name != "deployment" || v.Query != "llama,mistral,qwen" {
+		t.Errorf("got deployment variable %+v, want the sorted deployment names", v)
+	}
+	if got, want := exprs(d)["Gateway probe success"], `kubeairunway_gateway_probe_success{namespace="team-a", name=~"$deployment"}`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if _, ok := exprs(d)["P95 reconcile step duration"]; ok {
+		t.Error("families without per-deployment labels should not be on the deployment dashboard")
+	}
+
+	seen := map[int]bool{}
+	for _, panel := range d.Panels {
+		if seen[panel.ID] {
+			t.Errorf("duplicate panel id %d", panel.ID)
+		}
+		seen[panel.ID] = true
+		if panel.GridPos.X+panel.GridPos.W > 24 {
+			t.Errorf("panel %q overflows the grid: %+v", panel.Title, panel.GridPos)
+		}
+	}
+}
+
+// TestEngineSeries guards against the dashboards drifting from the engine metrics the
+// controller reads
+func TestEngineSeries(t *testing.T) {
+	for engine, series := range engines {
+		for _, name := range []string{series.requests, series.running, series.kvCache, series.latency} {
+			if name != "" && !activity.Reads(name) {
+				t.Errorf("%s: %s is not read by the activity package", engine, name)
+			}
+		}
+	}
+}
@@ -6,7 +6,9 @@ import (
 	"sync"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/discovery"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -27,6 +29,12 @@ const (
 	// InferencePoolCRDResource is the resource name for InferencePool
 	InferencePoolCRDResource = "inferencepools"
 
+	// InferencePoolAlphaCRDGroup is the API group of the experimental InferencePool served
+	// by GAIE installs older than v1.0
+	InferencePoolAlphaCRDGroup = "inference.networking.x-k8s.io"
+	// InferencePoolAlphaCRDVersion is the API version of the experimental InferencePool
+	InferencePoolAlphaCRDVersion = "v1alpha2"
+
 	// HTTPRouteCRDGroup is the API group for HTTPRoute
 	HTTPRouteCRDGroup = "gateway.networking.k8s.io"
 	// HTTPRouteCRDVersion is the API version for HTTPRoute
@@ -40,12 +48,33 @@ const (
 	// LabelInferenceGateway is the label to identify the inference gateway
 	LabelInferenceGateway = "airunway.ai/inference-gateway"
 
+	// LabelGatewayForNamespace maps namespaces to a Gateway. Its value is a namespace, or
+	// several joined by ".". ModelDeployments in these namespaces route through the Gateway,
+	// taking precedence over the auto-detected Gateway. The label is honored on Gateways in
+	// the mapped namespace itself, or in Detector.GatewayMappingNamespace.
+	LabelGatewayForNamespace = "airunway.ai/gateway-for-namespace"
+
+	// AnnotationDefaultInferenceGateway set to "true" on a GatewayClass makes its Gateways the
+	// default when several Gateways exist and none is labeled with LabelInferenceGateway
+	AnnotationDefaultInferenceGateway = "airunway.ai/default-inference-gateway"
+
+	// DefaultProvisionedGatewayName is the name of the Gateway created by --provision-gateway
+	DefaultProvisionedGatewayName = "airunway-gateway"
+
 	// AnnotationBBRNamespace is the annotation on the Gateway resource that specifies which
 	// namespace the body-based-router (BBR) deployment lives in. If not set, the controller
 	// assumes the BBR is in the same namespace as the Gateway.
 	AnnotationBBRNamespace = "airunway.ai/bbr-namespace"
 )
 
+// InferenceModelRewriteGVK is the GAIE InferenceModelRewrite kind. It is managed as
+// unstructured since the experimental CRD is optional in the cluster.
+var InferenceModelRewriteGVK = schema.GroupVersionKind{
+	Group:   InferencePoolAlphaCRDGroup,
+	Version: InferencePoolAlphaCRDVersion,
+	Kind:    "InferenceModelRewrite",
+}
+
 // GatewayConfig holds the resolved gateway configuration
 type GatewayConfig struct {
 	// GatewayName is the name of the Gateway resource to use as HTTPRoute parent
@@ -74,6 +103,8 @@ type Detector struct {
 	mu        sync.RWMutex
 	available *bool
 	checkedAt time.Time
+	// poolVersion is the InferencePool API version found by the last check
+	poolVersion schema.GroupVersion
 
 	// Explicit gateway override from flags
 	ExplicitGatewayName      string
@@ -83,10 +114,40 @@ type Detector struct {
 	EPPServicePort int32
 	EPPImage       string
 
+	// EPPImagePullSecrets are Secrets in each ModelDeployment namespace used to pull
+	// EPPImage, e.g. from a private mirror in air-gapped clusters.
+	EPPImagePullSecrets []string
+
+	// EPPSecurityContext replaces DefaultEPPSecurityContext on the EPP container when set.
+	EPPSecurityContext *corev1.SecurityContext
+
+	// PromptPolicyImage is the image of the prompt policy external processor. Empty means
+	// DefaultPromptPolicyImage.
+	PromptPolicyImage string
+
+	// EPPRBACMode selects whether EPPs get their own ServiceAccount, Role, and RoleBinding,
+	// or share them per namespace. Empty means EPPRBACModeDeployment.
+	EPPRBACMode EPPRBACMode
+
+	// GatewayMappingNamespace is the namespace whose Gateways may map other namespaces with
+	// LabelGatewayForNamespace. Gateways elsewhere only map their own namespace.
+	GatewayMappingNamespace string
+
+	// GatewayMappingOverridesExplicit lets a Gateway mapped with LabelGatewayForNamespace take
+	// precedence over the explicit Gateway. By default the explicit Gateway wins.
+	GatewayMappingOverridesExplicit bool
+
 	// PatchGateway controls whether the controller patches the Gateway's allowedRoutes
 	// to accept HTTPRoutes from ModelDeployment namespaces. Defaults to true.
 	// Set to false when a Gateway admin manages allowedRoutes independently.
 	PatchGateway bool
+
+	// ProvisionGatewayClassName opts into Gateway provisioning: when no Gateway exists,
+	// the controller creates ProvisionGatewayName in ProvisionGatewayNamespace using this
+	// GatewayClass. Empty disables provisioning.
+	ProvisionGatewayClassName string
+	ProvisionGatewayName      string
+	ProvisionGatewayNamespace string
 }
 
 // NewDetector creates a new Gateway API detector
@@ -140,6 +201,18 @@ func (d *Detector) IsAvailable(ctx context.Context) bool {
 	return available
 }
 
+// InferencePoolGroupVersion returns the InferencePool API version the cluster serves. The
+// v1 API is preferred; clusters that only serve the v1alpha2 API of older GAIE installs
+// get InferencePoolAlphaCRDGroup. It returns the v1 API before IsAvailable found the CRDs.
+func (d *Detector) InferencePoolGroupVersion() schema.GroupVersion {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if d.poolVersion.Empty() {
+		return schema.GroupVersion{Group: InferencePoolCRDGroup, Version: InferencePoolCRDVersion}
+	}
+	return d.poolVersion
+}
+
 // Refresh clears the cached result so the next IsAvailable call re-checks
 func (d *Detector) Refresh() {
 	d.mu.Lock()
@@ -147,10 +220,18 @@ func (d *Detector) Refresh() {
 	d.available = nil
 }
 
-// checkCRDs verifies that both InferencePool and HTTPRoute CRDs exist
+// checkCRDs verifies that both InferencePool and HTTPRoute CRDs exist and records the
+// InferencePool API version
 func (d *Detector) checkCRDs(ctx context.Context) bool {
-	// Check InferencePool CRD
-	if !d.checkCRD(ctx, InferencePoolCRDGroup, InferencePoolCRDVersion, InferencePoolCRDResource) {
+	// Check InferencePool CRD, falling back to the v1alpha2 API
+	switch {
+	case d.checkCRD(ctx, InferencePoolCRDGroup, InferencePoolCRDVersion, InferencePoolCRDResource):
+		d.poolVersion = schema.GroupVersion{Group: InferencePoolCRDGroup, Version: InferencePoolCRDVersion}
+	case d.checkCRD(ctx, InferencePoolAlphaCRDGroup, InferencePoolAlphaCRDVersion, InferencePoolCRDResource):
+		d.poolVersion = schema.GroupVersion{Group: InferencePoolAlphaCRDGroup, Version: InferencePoolAlphaCRDVersion}
+		log.FromContext(ctx).Info("Only the v1alpha2 InferencePool API is installed, creating v1alpha2 InferencePools",
+			"groupVersion", d.poolVersion.String())
+	default:
 		return false
 	}
 
@@ -188,6 +269,11 @@ func (d *Detector) checkCRD(ctx context.Context, group, version, resource string
 	return false
 }
 
+// ShouldProvisionGateway returns true if the controller should create a Gateway when none exists
+func (d *Detector) ShouldProvisionGateway() bool {
+	return d.ProvisionGatewayClassName != "" && d.ProvisionGatewayName != "" && d.ProvisionGatewayNamespace != ""
+}
+
 // HasExplicitGateway returns true if gateway name/namespace were explicitly configured
 func (d *Detector) HasExplicitGateway() bool {
 	return d.ExplicitGatewayName != "" && d.ExplicitGatewayNamespace != ""
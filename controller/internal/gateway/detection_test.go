@@ -2,11 +2,15 @@ package gateway
 
 import (
 	"context"
+	"slices"
 	"testing"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/discovery/fake"
 	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/kaito-project/airunway/controller/pkg/export"
 )
 
 func TestDetector_IsAvailable_AllCRDsPresent(t *testing.T) {
@@ -55,6 +59,51 @@ func TestDetector_IsAvailable_MissingInferencePool(t *testing.T) {
 	}
 }
 
+func TestDetector_IsAvailable_AlphaInferencePool(t *testing.T) {
+	dc := &fake.FakeDiscovery{
+		Fake: &k8stesting.Fake{},
+	}
+	dc.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "inference.networking.x-k8s.io/v1alpha2",
+			APIResources: []metav1.APIResource{
+				{Name: "inferencepools"},
+			},
+		},
+		{
+			GroupVersion: "gateway.networking.k8s.io/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "httproutes"},
+				{Name: "gateways"},
+			},
+		},
+	}
+
+	d := NewDetector(dc)
+	if got := d.InferencePoolGroupVersion().String(); got != "inference.networking.k8s.io/v1" {
+		t.Errorf("expected the v1 API before detection, got %s", got)
+	}
+	if !d.IsAvailable(context.Background()) {
+		t.Fatal("expected gateway API to be available with the v1alpha2 InferencePool")
+	}
+	if got := d.InferencePoolGroupVersion().String(); got != "inference.networking.x-k8s.io/v1alpha2" {
+		t.Errorf("expected the v1alpha2 API, got %s", got)
+	}
+
+	// The v1 API is preferred once it is installed
+	dc.Resources = append(dc.Resources, &metav1.APIResourceList{
+		GroupVersion: "inference.networking.k8s.io/v1",
+		APIResources: []metav1.APIResource{{Name: "inferencepools"}},
+	})
+	d.Refresh()
+	if !d.IsAvailable(context.Background()) {
+		t.Fatal("expected gateway API to be available")
+	}
+	if got := d.InferencePoolGroupVersion().String(); got != "inference.networking.k8s.io/v1" {
+		t.Errorf("expected the v1 API, got %s", got)
+	}
+}
+
 func TestDetector_IsAvailable_NoCRDs(t *testing.T) {
 	dc := &fake.FakeDiscovery{
 		Fake: &k8stesting.Fake{},
@@ -171,3 +220,21 @@ func TestDetector_NoExplicitGateway(t *testing.T) {
 		t.Error("expected error when no explicit gateway configured")
 	}
 }
+
+// TestExportGatewayKinds checks that export covers every gateway kind the controller
+// creates per ModelDeployment
+func TestExportGatewayKinds(t *testing.T) {
+	want := []schema.GroupVersionKind{
+		{Group: InferencePoolCRDGroup, Version: InferencePoolCRDVersion, Kind: "InferencePool"},
+		{Group: InferencePoolAlphaCRDGroup, Version: InferencePoolAlphaCRDVersion, Kind: "InferencePool"},
+		{Group: HTTPRouteCRDGroup, Version: HTTPRouteCRDVersion, Kind: "HTTPRoute"},
+		InferenceModelRewriteGVK,
+	}
+	want = append(want, RateLimitPolicyGVKs()...)
+	got := export.GatewayKinds()
+	for _, gvk := range want {
+		if !slices.Contains(got, gvk) {
+			t.Errorf("expected export.GatewayKinds to include %s", gvk)
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package gateway
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datalayer"
+	dlmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datalayer/metrics"
+	"sigs.k8s.io/yaml"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+const (
+	// DefaultEPPConfig is an empty EndpointPickerConfig, with which the EPP falls back to its
+	// default plugins.
+	DefaultEPPConfig = `apiVersion: inference.networking.x-k8s.io/v1alpha1
+kind: EndpointPickerConfig
+`
+
+	// PrefixCacheEPPConfig is the EndpointPickerConfig for spec.gateway.sessionAffinity
+	// prefixCache. Prefix cache hits outweigh load, so requests sharing a prompt prefix stay
+	// on the replica holding its KV cache until that replica is busier than the others.
+	PrefixCacheEPPConfig = `apiVersion: inference.networking.x-k8s.io/v1alpha1
+kind: EndpointPickerConfig
+plugins:
+- type: prefix-cache-scorer
+- type: queue-scorer
+- type: kv-cache-utilization-scorer
+- type: max-score-picker
+schedulingProfiles:
+- name: default
+  plugins:
+  - pluginRef: prefix-cache-scorer
+    weight: 3
+  - pluginRef: queue-scorer
+    weight: 2
+  - pluginRef: kv-cache-utilization-scorer
+    weight: 2
+  - pluginRef: max-score-picker
+`
+
+	// QueueDepthEPPConfig is the EndpointPickerConfig when spec.gateway.sessionAffinity is
+	// unset. Queue depth outweighs KV cache utilization and prefix cache hits, so requests
+	// go to the replica with the fewest waiting requests, which keeps tail latency down
+	// under load.
+	QueueDepthEPPConfig = `apiVersion: inference.networking.x-k8s.io/v1alpha1
+kind: EndpointPickerConfig
+plugins:
+- type: queue-scorer
+- type: kv-cache-utilization-scorer
+- type: prefix-cache-scorer
+- type: max-score-picker
+schedulingProfiles:
+- name: default
+  plugins:
+  - pluginRef: queue-scorer
+    weight: 3
+  - pluginRef: kv-cache-utilization-scorer
+    weight: 2
+  - pluginRef: prefix-cache-scorer
+    weight: 1
+  - pluginRef: max-score-picker
+`
+
+	// LoadAwareEPPConfig is the EndpointPickerConfig for spec.gateway.sessionAffinity none.
+	// Replicas are picked by queue depth and KV cache utilization only.
+	LoadAwareEPPConfig = `apiVersion: inference.networking.x-k8s.io/v1alpha1
+kind: EndpointPickerConfig
+plugins:
+- type: queue-scorer
+- type: kv-cache-utilization-scorer
+- type: max-score-picker
+schedulingProfiles:
+- name: default
+  plugins:
+  - pluginRef: queue-scorer
+    weight: 1
+  - pluginRef: kv-cache-utilization-scorer
+    weight: 1
+  - pluginRef: max-score-picker
+`
+
+	// RandomEPPConfig is the EndpointPickerConfig for spec.serving.router.routerMode none.
+	// Replicas are picked at random, without scoring them.
+	RandomEPPConfig = `apiVersion: inference.networking.x-k8s.io/v1alpha1
+kind: EndpointPickerConfig
+plugins:
+- type: random-picker
+schedulingProfiles:
+- name: default
+  plugins:
+  - pluginRef: random-picker
+`
+
+	// EPPConfigFile is the ConfigMap key (and file name under /config) holding the EPP config.
+	EPPConfigFile = "default-plugins.yaml"
+
+	// AnnotationEPPConfigChecksum is stamped on the EPP pod template with a hash of the
+	// config. The EPP reads its config only at startup, so a changed checksum rolls the
+	// Deployment and the new pods load the updated ConfigMap.
+	AnnotationEPPConfigChecksum = "airunway.ai/epp-config-checksum"
+)
+
+// engineMetrics are the Prometheus metrics of an engine the EPP scores replicas by
+type engineMetrics struct {
+	queuedRequests  string
+	runningRequests string
+	kvCacheUsage    string
+	lora            string
+	cacheInfo       string
+}
+
+// eppEngineMetrics maps the engines that export load metrics to their metric names.
+// TensorRT-LLM exports none the EPP can read.
+var eppEngineMetrics = map[airunwayv1alpha1.EngineType]engineMetrics{
+	airunwayv1alpha1.EngineTypeVLLM: {
+		queuedRequests:  "vllm:num_requests_waiting",
+		runningRequests: "vllm:num_requests_running",
+		kvCacheUsage:    "vllm:kv_cache_usage_perc",
+		lora:            "vllm:lora_requests_info",
+		cacheInfo:       "vllm:cache_config_info",
+	},
+	airunwayv1alpha1.EngineTypeSGLang: {
+		queuedRequests:  "sglang:num_queue_reqs",
+		runningRequests: "sglang:num_running_reqs",
+		kvCacheUsage:    "sglang:token_usage",
+	},
+	airunwayv1alpha1.EngineTypeLlamaCpp: {
+		queuedRequests:  "llamacpp:requests_deferred",
+		runningRequests: "llamacpp:requests_processing",
+		kvCacheUsage:    "llamacpp:kv_cache_usage_ratio",
+	},
+}
+
+// EPPConfigFor returns the EPP config of a gateway spec: eppConfig when set, else the
+// config for sessionAffinity, else the config for routerMode, QueueDepthEPPConfig when
+// neither is set. With engineMetrics the data layer reads the load metrics of engine.
+// The EPP has no round-robin picker, so round-robin spreads requests by load.
+func EPPConfigFor(spec *airunwayv1alpha1.GatewaySpec, routerMode airunwayv1alpha1.RouterMode, engine airunwayv1alpha1.EngineType) string {
+	if spec != nil && strings.TrimSpace(spec.EPPConfig) != "" {
+		return spec.EPPConfig
+	}
+	config := QueueDepthEPPConfig
+	switch routerMode {
+	case airunwayv1alpha1.RouterModeKV:
+		config = PrefixCacheEPPConfig
+	case airunwayv1alpha1.RouterModeRoundRobin:
+		config = LoadAwareEPPConfig
+	case airunwayv1alpha1.RouterModeNone:
+		config = RandomEPPConfig
+	}
+	if spec == nil {
+		return config
+	}
+	switch spec.SessionAffinity {
+	case airunwayv1alpha1.SessionAffinityPrefixCache:
+		config = PrefixCacheEPPConfig
+	case airunwayv1alpha1.SessionAffinityNone:
+		config = LoadAwareEPPConfig
+	}
+	if spec.EngineMetrics {
+		config = withEngineMetrics(config, engine)
+	}
+	return config
+}
+
+// withEngineMetrics adds the GAIE data layer to config, so the EPP scrapes the queue
+// depth, running requests, and KV cache utilization of engine by their names. Without it
+// the EPP reads the vllm names, which other engines do not export, and scores all their
+// replicas the same. config is returned unchanged for engines without known metrics.
+func withEngineMetrics(config string, engine airunwayv1alpha1.EngineType) string {
+	metrics, ok := eppEngineMetrics[engine]
+	if !ok {
+		return config
+	}
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal([]byte(config), &doc); err != nil {
+		return config
+	}
+	extractor := map[string]interface{}{
+		"queuedRequestsSpec":  metrics.queuedRequests,
+		"runningRequestsSpec": metrics.runningRequests,
+		"kvUsageSpec":         metrics.kvCacheUsage,
+	}
+	if metrics.lora != "" {
+		extractor["loraSpec"] = metrics.lora
+	}
+	if metrics.cacheInfo != "" {
+		extractor["cacheInfoSpec"] = metrics.cacheInfo
+	}
+	plugins, _ := doc["plugins"].([]interface{})
+	doc["plugins"] = append(plugins,
+		map[string]interface{}{
+			"type":       dlmetrics.MetricsDataSourceType,
+			"parameters": map[string]interface{}{"scheme": "http", "path": "/metrics"},
+		},
+		map[string]interface{}{"type": dlmetrics.MetricsExtractorType, "parameters": extractor},
+	)
+	doc["featureGates"] = []interface{}{datalayer.ExperimentalDatalayerFeatureGate}
+	doc["data"] = map[string]interface{}{
+		"sources": []interface{}{map[string]interface{}{
+			"pluginRef":  dlmetrics.MetricsDataSourceType,
+			"extractors": []interface{}{map[string]interface{}{"pluginRef": dlmetrics.MetricsExtractorType}},
+		}},
+	}
+	out, err := yaml.Marshal(doc)
+	if err != nil {
+		return config
+	}
+	return string(out)
+}
+
+// ValidateEPPConfig checks that config is an EndpointPickerConfig YAML document.
+// Plugin names and parameters are left to the EPP to validate.
+func ValidateEPPConfig(config string) error {
+	var header struct {
+		APIVersion string `json:"apiVersion"`
+		Kind       string `json:"kind"`
+	}
+	if err := yaml.Unmarshal([]byte(config), &header); err != nil {
+		return fmt.Errorf("parsing EPP config: %w", err)
+	}
+	if header.Kind != "EndpointPickerConfig" {
+		return fmt.Errorf("EPP config kind must be EndpointPickerConfig, got %q", header.Kind)
+	}
+	if !strings.HasPrefix(header.APIVersion, "inference.networking.x-k8s.io/") {
+		return fmt.Errorf("EPP config apiVersion must be in the inference.networking.x-k8s.io group, got %q", header.APIVersion)
+	}
+	return nil
+}
+
+// EPPConfigChecksum returns the hex SHA-256 of config, for AnnotationEPPConfigChecksum.
+func EPPConfigChecksum(config string) string {
+	sum := sha256.Sum256([]byte(config))
+	return hex.EncodeToString(sum[:])
+}
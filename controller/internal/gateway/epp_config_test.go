@@ -0,0 +1,194 @@
+package gateway
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/config/loader"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datalayer"
+	dlmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datalayer/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/multi/prefix"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/picker"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/profile"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/scorer"
+	runserver "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/server"
+	"sigs.k8s.io/gateway-api-inference-extension/test/utils"
+	"sigs.k8s.io/yaml"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+func TestValidateEPPConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		wantErr bool
+	}{
+		{name: "default", config: DefaultEPPConfig},
+		{name: "prefix cache", config: PrefixCacheEPPConfig},
+		{name: "load aware", config: LoadAwareEPPConfig},
+		{name: "queue depth", config: QueueDepthEPPConfig},
+		{name: "sglang metrics", config: EPPConfigFor(&airunwayv1alpha1.GatewaySpec{EngineMetrics: true}, "", airunwayv1alpha1.EngineTypeSGLang)},
+		{name: "with plugins", config: `apiVersion: inference.networking.x-k8s.io/v1alpha1
+kind: EndpointPickerConfig
+plugins:
+- type: queue-scorer
+schedulingProfiles:
+- name: default
+  plugins:
+  - pluginRef: queue-scorer
+`},
+		{name: "wrong kind", config: "apiVersion: inference.networking.x-k8s.io/v1alpha1\nkind: ConfigMap\n", wantErr: true},
+		{name: "wrong group", config: "apiVersion: v1\nkind: EndpointPickerConfig\n", wantErr: true},
+		{name: "not yaml", config: "plugins: [", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateEPPConfig(tt.config); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateEPPConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEPPConfigChecksum(t *testing.T) {
+	custom := "apiVersion: inference.networking.x-k8s.io/v1alpha1\nkind: EndpointPickerConfig\nplugins: []\n"
+	if EPPConfigChecksum(DefaultEPPConfig) == EPPConfigChecksum(custom) {
+		t.Error("expected different configs to have different checksums")
+	}
+}
+
+// TestEPPConfigFor_LoadsInEPP loads the generated configs with the config loader and the
+// in-tree plugins of the pinned EPP, which reject unknown plugin types and feature gates.
+// The data layer plugins default their parameters to the flags of the EPP.
+func TestEPPConfigFor_LoadsInEPP(t *testing.T) {
+	runserver.NewOptions().AddFlags(pflag.CommandLine)
+	loader.RegisterFeatureGate(datalayer.ExperimentalDatalayerFeatureGate)
+	plugins.Register(prefix.PrefixCachePluginType, prefix.PrefixCachePluginFactory)
+	plugins.Register(picker.MaxScorePickerType, picker.MaxScorePickerFactory)
+	plugins.Register(picker.RandomPickerType, picker.RandomPickerFactory)
+	plugins.Register(profile.SingleProfileHandlerType, profile.SingleProfileHandlerFactory)
+	plugins.Register(scorer.KvCacheUtilizationScorerType, scorer.KvCacheUtilizationScorerFactory)
+	plugins.Register(scorer.QueueScorerType, scorer.QueueScorerFactory)
+	plugins.Register(dlmetrics.MetricsDataSourceType, dlmetrics.MetricsDataSourceFactory)
+	plugins.Register(dlmetrics.MetricsExtractorType, dlmetrics.ModelServerExtractorFactory)
+
+	var configs []string
+	for _, affinity := range []airunwayv1alpha1.SessionAffinity{"", airunwayv1alpha1.SessionAffinityPrefixCache, airunwayv1alpha1.SessionAffinityNone} {
+		for _, mode := range []airunwayv1alpha1.RouterMode{"", airunwayv1alpha1.RouterModeNone} {
+			for _, engine := range []airunwayv1alpha1.EngineType{airunwayv1alpha1.EngineTypeVLLM, airunwayv1alpha1.EngineTypeSGLang, airunwayv1alpha1.EngineTypeLlamaCpp, airunwayv1alpha1.EngineTypeTRTLLM} {
+				for _, metrics := range []bool{false, true} {
+					spec := &airunwayv1alpha1.GatewaySpec{SessionAffinity: affinity, EngineMetrics: metrics}
+					configs = append(configs, EPPConfigFor(spec, mode, engine))
+				}
+			}
+		}
+	}
+	for _, config := range append(configs, DefaultEPPConfig) {
+		raw, _, err := loader.LoadRawConfig([]byte(config), logr.Discard())
+		if err != nil {
+			t.Fatalf("loading config: %v\n%s", err, config)
+		}
+		if _, err := loader.InstantiateAndConfigure(raw, utils.NewTestHandle(context.Background()), logr.Discard()); err != nil {
+			t.Fatalf("instantiating config: %v\n%s", err, config)
+		}
+	}
+}
+
+func TestEPPConfigFor(t *testing.T) {
+	custom := "apiVersion: inference.networking.x-k8s.io/v1alpha1\nkind: EndpointPickerConfig\nplugins: []\n"
+	tests := []struct {
+		name       string
+		spec       *airunwayv1alpha1.GatewaySpec
+		routerMode airunwayv1alpha1.RouterMode
+		engine     airunwayv1alpha1.EngineType
+		want       string
+	}{
+		{name: "no gateway spec", spec: nil, want: QueueDepthEPPConfig},
+		{name: "unset", spec: &airunwayv1alpha1.GatewaySpec{}, want: QueueDepthEPPConfig},
+		{name: "prefix cache", spec: &airunwayv1alpha1.GatewaySpec{SessionAffinity: airunwayv1alpha1.SessionAffinityPrefixCache}, want: PrefixCacheEPPConfig},
+		{name: "none", spec: &airunwayv1alpha1.GatewaySpec{SessionAffinity: airunwayv1alpha1.SessionAffinityNone}, want: LoadAwareEPPConfig},
+		{name: "eppConfig wins", spec: &airunwayv1alpha1.GatewaySpec{EPPConfig: custom, SessionAffinity: airunwayv1alpha1.SessionAffinityNone}, want: custom},
+		{name: "router mode kv", routerMode: airunwayv1alpha1.RouterModeKV, want: PrefixCacheEPPConfig},
+		{name: "router mode round-robin", routerMode: airunwayv1alpha1.RouterModeRoundRobin, want: LoadAwareEPPConfig},
+		{name: "router mode none", spec: &airunwayv1alpha1.GatewaySpec{}, routerMode: airunwayv1alpha1.RouterModeNone, want: RandomEPPConfig},
+		{name: "sessionAffinity wins", spec: &airunwayv1alpha1.GatewaySpec{SessionAffinity: airunwayv1alpha1.SessionAffinityNone}, routerMode: airunwayv1alpha1.RouterModeKV, want: LoadAwareEPPConfig},
+		{name: "eppConfig wins over router mode", spec: &airunwayv1alpha1.GatewaySpec{EPPConfig: custom}, routerMode: airunwayv1alpha1.RouterModeNone, want: custom},
+		{name: "engine metrics without known metrics", spec: &airunwayv1alpha1.GatewaySpec{EngineMetrics: true}, engine: airunwayv1alpha1.EngineTypeTRTLLM, want: QueueDepthEPPConfig},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := tt.engine
+			if engine == "" {
+				engine = airunwayv1alpha1.EngineTypeVLLM
+			}
+			if got := EPPConfigFor(tt.spec, tt.routerMode, engine); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+
+	if !strings.Contains(PrefixCacheEPPConfig, "prefix-cache-scorer") || strings.Contains(LoadAwareEPPConfig, "prefix-cache-scorer") {
+		t.Error("expected only the prefixCache config to score prefix cache hits")
+	}
+}
+
+func TestEPPConfigFor_EngineMetrics(t *testing.T) {
+	var doc struct {
+		FeatureGates []string `json:"featureGates"`
+		Plugins      []struct {
+			Type       string            `json:"type"`
+			Parameters map[string]string `json:"parameters"`
+		} `json:"plugins"`
+		SchedulingProfiles []struct {
+			Plugins []struct {
+				PluginRef string `json:"pluginRef"`
+				Weight    int    `json:"weight"`
+			} `json:"plugins"`
+		} `json:"schedulingProfiles"`
+		Data struct {
+			Sources []struct {
+				PluginRef  string `json:"pluginRef"`
+				Extractors []struct {
+					PluginRef string `json:"pluginRef"`
+				} `json:"extractors"`
+			} `json:"sources"`
+		} `json:"data"`
+	}
+	config := EPPConfigFor(&airunwayv1alpha1.GatewaySpec{EngineMetrics: true}, "", airunwayv1alpha1.EngineTypeSGLang)
+	if err := yaml.Unmarshal([]byte(config), &doc); err != nil {
+		t.Fatalf("parsing config: %v", err)
+	}
+	if len(doc.FeatureGates) != 1 || doc.FeatureGates[0] != datalayer.ExperimentalDatalayerFeatureGate {
+		t.Errorf("expected the dataLayer feature gate, got %v", doc.FeatureGates)
+	}
+	var queued string
+	for _, p := range doc.Plugins {
+		if p.Type == dlmetrics.MetricsExtractorType {
+			queued = p.Parameters["queuedRequestsSpec"]
+		}
+	}
+	if queued != "sglang:num_queue_reqs" {
+		t.Errorf("expected the sglang queue metric, got %q", queued)
+	}
+	if len(doc.Data.Sources) != 1 || doc.Data.Sources[0].PluginRef != dlmetrics.MetricsDataSourceType ||
+		len(doc.Data.Sources[0].Extractors) != 1 || doc.Data.Sources[0].Extractors[0].PluginRef != dlmetrics.MetricsExtractorType {
+		t.Errorf("expected the metrics data source with the model server extractor, got %+v", doc.Data)
+	}
+	if p := doc.SchedulingProfiles[0].Plugins[0]; p.PluginRef != "queue-scorer" || p.Weight != 3 {
+		t.Errorf("expected queue depth to be weighted highest, got %+v", p)
+	}
+
+	// The data layer is opt-in, and a custom config is left alone
+	if got := EPPConfigFor(&airunwayv1alpha1.GatewaySpec{}, "", airunwayv1alpha1.EngineTypeSGLang); got != QueueDepthEPPConfig {
+		t.Errorf("expected the config without the data layer, got %q", got)
+	}
+	custom := "apiVersion: inference.networking.x-k8s.io/v1alpha1\nkind: EndpointPickerConfig\n"
+	if got := EPPConfigFor(&airunwayv1alpha1.GatewaySpec{EPPConfig: custom, EngineMetrics: true}, "", airunwayv1alpha1.EngineTypeVLLM); got != custom {
+		t.Errorf("expected the custom config, got %q", got)
+	}
+}
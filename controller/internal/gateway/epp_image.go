@@ -0,0 +1,75 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// DefaultEPPImage is the upstream GAIE Endpoint Picker image used when --epp-image is unset.
+const DefaultEPPImage = "registry.k8s.io/gateway-api-inference-extension/epp:" + DefaultGAIEVersion
+
+// imageDigestPattern matches an OCI content digest, e.g. sha256:<64 hex characters>
+var imageDigestPattern = regexp.MustCompile(`^[a-z0-9]+(?:[.+_-][a-z0-9]+)*:[a-f0-9]{32,}$`)
+
+// EPPImageWithDigest pins image to digest, replacing any tag or digest in image, so the
+// EPP pulls the same content from a mirror registry. It returns image unchanged when
+// digest is empty.
+func EPPImageWithDigest(image, digest string) (string, error) {
+	if digest == "" {
+		return image, nil
+	}
+	if !imageDigestPattern.MatchString(digest) {
+		return "", fmt.Errorf("invalid image digest %q, expected e.g. sha256:<hex>", digest)
+	}
+	repository, _, _ := strings.Cut(image, "@")
+	// A colon after the last slash starts the tag; earlier ones are a registry port
+	if i := strings.LastIndex(repository, ":"); i > strings.LastIndex(repository, "/") {
+		repository = repository[:i]
+	}
+	return repository + "@" + digest, nil
+}
+
+// DefaultEPPSecurityContext returns the EPP container security context, which meets the
+// restricted Pod Security Standard. The upstream image runs as a non-root user.
+func DefaultEPPSecurityContext() *corev1.SecurityContext {
+	return &corev1.SecurityContext{
+		AllowPrivilegeEscalation: boolPtr(false),
+		Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+		RunAsNonRoot:             boolPtr(true),
+		SeccompProfile:           &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+	}
+}
+
+// ParseEPPSecurityContext parses a container security context written as JSON or YAML,
+// rejecting unknown fields.
+func ParseEPPSecurityContext(value string) (*corev1.SecurityContext, error) {
+	sc := &corev1.SecurityContext{}
+	if err := yaml.UnmarshalStrict([]byte(value), sc); err != nil {
+		return nil, fmt.Errorf("invalid EPP security context: %w", err)
+	}
+	return sc, nil
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
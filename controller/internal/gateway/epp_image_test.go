@@ -0,0 +1,57 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import "testing"
+
+const testDigest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func TestEPPImageWithDigest(t *testing.T) {
+	tests := []struct {
+		image, digest, want string
+	}{
+		{DefaultEPPImage, "", DefaultEPPImage},
+		{DefaultEPPImage, testDigest, "registry.k8s.io/gateway-api-inference-extension/epp@" + testDigest},
+		{"mirror.local:5000/epp", testDigest, "mirror.local:5000/epp@" + testDigest},
+		{"mirror.local:5000/epp:v1@sha256:abc", testDigest, "mirror.local:5000/epp@" + testDigest},
+	}
+	for _, tt := range tests {
+		got, err := EPPImageWithDigest(tt.image, tt.digest)
+		if err != nil || got != tt.want {
+			t.Errorf("EPPImageWithDigest(%q, %q) = %q, %v, want %q", tt.image, tt.digest, got, err, tt.want)
+		}
+	}
+
+	for _, digest := range []string{"0123456789abcdef", "sha256:XYZ", "latest"} {
+		if _, err := EPPImageWithDigest(DefaultEPPImage, digest); err == nil {
+			t.Errorf("expected digest %q to be rejected", digest)
+		}
+	}
+}
+
+func TestParseEPPSecurityContext(t *testing.T) {
+	sc, err := ParseEPPSecurityContext(`{"runAsUser": 1000, "readOnlyRootFilesystem": true}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sc.RunAsUser == nil || *sc.RunAsUser != 1000 || sc.ReadOnlyRootFilesystem == nil || !*sc.ReadOnlyRootFilesystem {
+		t.Errorf("expected the parsed fields, got %+v", sc)
+	}
+	if _, err := ParseEPPSecurityContext("runAsUsr: 1000"); err == nil {
+		t.Error("expected unknown fields to be rejected")
+	}
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"fmt"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// EPPRBACMode selects how the RBAC of controller-created EPPs is scoped.
+type EPPRBACMode string
+
+const (
+	// EPPRBACModeDeployment gives each EPP its own ServiceAccount, Role, and RoleBinding,
+	// named after the EPP and owned by its ModelDeployment.
+	EPPRBACModeDeployment EPPRBACMode = "deployment"
+
+	// EPPRBACModeNamespace shares one ServiceAccount, Role, and RoleBinding, named
+	// SharedEPPRBACName, between the EPPs of a namespace. They are owned by every
+	// ModelDeployment using them and removed with the last one.
+	EPPRBACModeNamespace EPPRBACMode = "namespace"
+
+	// SharedEPPRBACName is the name of the ServiceAccount, Role, and RoleBinding shared by
+	// the EPPs of a namespace in EPPRBACModeNamespace.
+	SharedEPPRBACName = "airunway-epp"
+)
+
+// ParseEPPRBACMode returns the EPPRBACMode of value, defaulting to EPPRBACModeDeployment.
+func ParseEPPRBACMode(value string) (EPPRBACMode, error) {
+	switch mode := EPPRBACMode(value); mode {
+	case "":
+		return EPPRBACModeDeployment, nil
+	case EPPRBACModeDeployment, EPPRBACModeNamespace:
+		return mode, nil
+	}
+	return "", fmt.Errorf("invalid EPP RBAC mode %q, expected %s or %s", value, EPPRBACModeDeployment, EPPRBACModeNamespace)
+}
+
+// EPPRoleRules returns the namespaced permissions of an EPP: reading the model server
+// pods, its InferencePool of either API group, and the InferenceObjectives and
+// InferenceModelRewrites of the pool. EPPs run as a single replica without leader election, so they need no leases.
+func EPPRoleRules() []rbacv1.PolicyRule {
+	return []rbacv1.PolicyRule{
+		{
+			APIGroups: []string{""},
+			Resources: []string{"pods"},
+			Verbs:     []string{"get", "watch", "list"},
+		},
+		{
+			APIGroups: []string{"inference.networking.k8s.io"},
+			Resources: []string{"inferencepools"},
+			Verbs:     []string{"get", "watch", "list"},
+		},
+		{
+			APIGroups: []string{"inference.networking.x-k8s.io"},
+			Resources: []string{"inferencepools", "inferenceobjectives", "inferencemodelrewrites"},
+			Verbs:     []string{"get", "watch", "list"},
+		},
+	}
+}
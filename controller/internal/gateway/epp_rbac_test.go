@@ -0,0 +1,34 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import "testing"
+
+func TestParseEPPRBACMode(t *testing.T) {
+	for value, want := range map[string]EPPRBACMode{
+		"":           EPPRBACModeDeployment,
+		"deployment": EPPRBACModeDeployment,
+		"namespace":  EPPRBACModeNamespace,
+	} {
+		if got, err := ParseEPPRBACMode(value); err != nil || got != want {
+			t.Errorf("ParseEPPRBACMode(%q) = %q, %v, want %q", value, got, err, want)
+		}
+	}
+	if _, err := ParseEPPRBACMode("cluster"); err == nil {
+		t.Error("expected an unknown mode to be rejected")
+	}
+}
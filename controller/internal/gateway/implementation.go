@@ -0,0 +1,37 @@
+package gateway
+
+import (
+	"strings"
+)
+
+// Implementation identifies the Gateway API implementation serving a Gateway.
+type Implementation string
+
+const (
+	ImplementationIstio        Implementation = "istio"
+	ImplementationEnvoyGateway Implementation = "envoy-gateway"
+	ImplementationKGateway     Implementation = "kgateway"
+	ImplementationGKE          Implementation = "gke"
+	ImplementationUnknown      Implementation = ""
+)
+
+// implementationControllers maps GatewayClass controllerName prefixes to implementations.
+var implementationControllers = []struct {
+	prefix string
+	impl   Implementation
+}{
+	{prefix: "istio.io/", impl: ImplementationIstio},
+	{prefix: "gateway.envoyproxy.io/", impl: ImplementationEnvoyGateway},
+	{prefix: "kgateway.dev/", impl: ImplementationKGateway},
+	{prefix: "networking.gke.io/", impl: ImplementationGKE},
+}
+
+// ImplementationForController returns the implementation for a GatewayClass controllerName.
+func ImplementationForController(controllerName string) Implementation {
+	for _, c := range implementationControllers {
+		if strings.HasPrefix(controllerName, c.prefix) {
+			return c.impl
+		}
+	}
+	return ImplementationUnknown
+}
@@ -0,0 +1,19 @@
+package gateway
+
+import "testing"
+
+func TestImplementationForController(t *testing.T) {
+	tests := map[string]Implementation{
+		"istio.io/gateway-controller":                   ImplementationIstio,
+		"gateway.envoyproxy.io/gatewayclass-controller": ImplementationEnvoyGateway,
+		"kgateway.dev/kgateway":                         ImplementationKGateway,
+		"networking.gke.io/gateway":                     ImplementationGKE,
+		"example.com/other":                             ImplementationUnknown,
+		"":                                              ImplementationUnknown,
+	}
+	for controller, want := range tests {
+		if got := ImplementationForController(controller); got != want {
+			t.Errorf("ImplementationForController(%q) = %q, want %q", controller, got, want)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package gateway
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// ModelNameTemplateData is the data available to spec.gateway.modelNameTemplate.
+type ModelNameTemplateData struct {
+	// Namespace is the ModelDeployment namespace.
+	Namespace string
+	// Name is the ModelDeployment name.
+	Name string
+	// ModelName is the name the engine serves the model under.
+	ModelName string
+}
+
+// RenderModelName renders a served model name template such as "{{ .Namespace }}/{{ .ModelName }}".
+// The result must be non-empty and free of whitespace since it is matched against a request header.
+func RenderModelName(tmpl string, data ModelNameTemplateData) (string, error) {
+	t, err := template.New("modelName").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("parsing model name template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("rendering model name template: %w", err)
+	}
+	name := buf.String()
+	if name == "" {
+		return "", fmt.Errorf("model name template rendered an empty name")
+	}
+	if strings.ContainsAny(name, " \t\r\n") {
+		return "", fmt.Errorf("model name template rendered %q, which contains whitespace", name)
+	}
+	return name, nil
+}
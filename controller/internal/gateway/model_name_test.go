@@ -0,0 +1,33 @@
+package gateway
+
+import "testing"
+
+func TestRenderModelName(t *testing.T) {
+	data := ModelNameTemplateData{Namespace: "team-a", Name: "llama", ModelName: "meta-llama/Llama-3-8B"}
+
+	tests := []struct {
+		name    string
+		tmpl    string
+		want    string
+		wantErr bool
+	}{
+		{name: "namespace and name", tmpl: "{{ .Namespace }}/{{ .Name }}", want: "team-a/llama"},
+		{name: "namespace prefix", tmpl: "{{ .Namespace }}/{{ .ModelName }}", want: "team-a/meta-llama/Llama-3-8B"},
+		{name: "literal", tmpl: "shared-model", want: "shared-model"},
+		{name: "unknown field", tmpl: "{{ .Tenant }}", wantErr: true},
+		{name: "parse error", tmpl: "{{ .Namespace", wantErr: true},
+		{name: "empty", tmpl: "{{ if false }}x{{ end }}", wantErr: true},
+		{name: "whitespace", tmpl: "{{ .Namespace }} {{ .Name }}", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderModelName(tt.tmpl, data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RenderModelName() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("RenderModelName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateway
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// DefaultPromptPolicyImage is the image of the prompt policy external processor, the
+	// controller image, which ships the /prompt-policy binary.
+	DefaultPromptPolicyImage = "ghcr.io/kaito-project/airunway/controller:latest"
+
+	// PromptPolicyConfigFile is the ConfigMap key (and file name under /config) holding the
+	// prompt policy.
+	PromptPolicyConfigFile = "policy.json"
+
+	// PromptPolicyPort is the gRPC port of the prompt policy external processor.
+	PromptPolicyPort int32 = 9004
+
+	// PromptPolicyMetricsPort is the HTTP port serving the GenAI metrics of the prompt
+	// policy external processor at /metrics.
+	PromptPolicyMetricsPort int32 = 9090
+
+	// ResponseBodyBuffered sends the whole response body to the processor in one message.
+	ResponseBodyBuffered = "Buffered"
+
+	// ResponseBodyStreamed sends each chunk of the response body to the processor as it
+	// arrives.
+	ResponseBodyStreamed = "Streamed"
+
+	// AnnotationPromptPolicyChecksum is stamped on the prompt policy pod template with a
+	// hash of the policy, so a changed policy rolls the Deployment.
+	AnnotationPromptPolicyChecksum = "airunway.ai/prompt-policy-checksum"
+)
+
+// EnvoyGatewayExtensionPolicyGVK is the Envoy Gateway policy that attaches external
+// processors to a route.
+var EnvoyGatewayExtensionPolicyGVK = schema.GroupVersionKind{
+	Group:   "gateway.envoyproxy.io",
+	Version: "v1alpha1",
+	Kind:    "EnvoyExtensionPolicy",
+}
+
+// PromptPolicyExtensionPolicy builds the implementation-specific policy that sends the
+// request bodies of the named HTTPRoute to the prompt policy external processor Service.
+// failOpen forwards requests when the processor is unavailable, a non-zero messageTimeout
+// overrides how long Envoy waits for each processor response, and a non-empty responseBody
+// (ResponseBodyBuffered or ResponseBodyStreamed) sends the response headers and bodies too,
+// so that the processor can cache them or record metrics. Returns nil
+// when the implementation has no supported policy. The caller sets the name, namespace,
+// and owner of the returned object.
+func PromptPolicyExtensionPolicy(impl Implementation, routeName, serviceName string, failOpen bool, messageTimeout time.Duration, responseBody string) *unstructured.Unstructured {
+	if impl != ImplementationEnvoyGateway {
+		return nil
+	}
+	// The processor needs the whole body; responses are only processed to cache them or
+	// record metrics
+	processingMode := map[string]interface{}{
+		"request": map[string]interface{}{"body": "Buffered"},
+	}
+	if responseBody != "" {
+		processingMode["response"] = map[string]interface{}{"body": responseBody}
+	}
+	extProc := map[string]interface{}{
+		"backendRefs": []interface{}{
+			map[string]interface{}{
+				"name": serviceName,
+				"port": int64(PromptPolicyPort),
+			},
+		},
+		"processingMode": processingMode,
+	}
+	if failOpen {
+		extProc["failOpen"] = true
+	}
+	if messageTimeout > 0 {
+		extProc["messageTimeout"] = FormatDuration(messageTimeout)
+	}
+	return newPolicy(EnvoyGatewayExtensionPolicyGVK, map[string]interface{}{
+		"targetRefs": []interface{}{
+			map[string]interface{}{
+				"group": "gateway.networking.k8s.io",
+				"kind":  "HTTPRoute",
+				"name":  routeName,
+			},
+		},
+		"extProc": []interface{}{extProc},
+	})
+}
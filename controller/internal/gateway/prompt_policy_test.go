@@ -0,0 +1,82 @@
+package gateway
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestPromptPolicyExtensionPolicy_EnvoyGateway(t *testing.T) {
+	policy := PromptPolicyExtensionPolicy(ImplementationEnvoyGateway, "llama", "llama-prompt-policy", false, 0, "")
+	if policy == nil {
+		t.Fatal("expected policy for Envoy Gateway")
+	}
+	if policy.GroupVersionKind() != EnvoyGatewayExtensionPolicyGVK {
+		t.Errorf("unexpected kind %v", policy.GroupVersionKind())
+	}
+	targets, _, _ := unstructured.NestedSlice(policy.Object, "spec", "targetRefs")
+	if len(targets) != 1 || targets[0].(map[string]interface{})["name"] != "llama" {
+		t.Errorf("expected targetRef to HTTPRoute llama, got %v", targets)
+	}
+	extProc, _, _ := unstructured.NestedSlice(policy.Object, "spec", "extProc")
+	if len(extProc) != 1 {
+		t.Fatalf("expected 1 extProc, got %d", len(extProc))
+	}
+	backends, _, _ := unstructured.NestedSlice(extProc[0].(map[string]interface{}), "backendRefs")
+	if len(backends) != 1 || backends[0].(map[string]interface{})["name"] != "llama-prompt-policy" ||
+		backends[0].(map[string]interface{})["port"] != int64(PromptPolicyPort) {
+		t.Errorf("expected backendRef to llama-prompt-policy:%d, got %v", PromptPolicyPort, backends)
+	}
+	body, _, _ := unstructured.NestedString(extProc[0].(map[string]interface{}), "processingMode", "request", "body")
+	if body != "Buffered" {
+		t.Errorf("expected buffered request body, got %q", body)
+	}
+	if _, ok := extProc[0].(map[string]interface{})["processingMode"].(map[string]interface{})["response"]; ok {
+		t.Error("expected responses not to be processed")
+	}
+}
+
+func TestPromptPolicyExtensionPolicy_ResponseBody(t *testing.T) {
+	policy := PromptPolicyExtensionPolicy(ImplementationEnvoyGateway, "llama", "llama-prompt-policy", false, 0, ResponseBodyBuffered)
+	extProc, _, _ := unstructured.NestedSlice(policy.Object, "spec", "extProc")
+	body, _, _ := unstructured.NestedString(extProc[0].(map[string]interface{}), "processingMode", "response", "body")
+	if body != "Buffered" {
+		t.Errorf("expected buffered response body, got %q", body)
+	}
+
+	policy = PromptPolicyExtensionPolicy(ImplementationEnvoyGateway, "llama", "llama-prompt-policy", false, 0, ResponseBodyStreamed)
+	extProc, _, _ = unstructured.NestedSlice(policy.Object, "spec", "extProc")
+	body, _, _ = unstructured.NestedString(extProc[0].(map[string]interface{}), "processingMode", "response", "body")
+	if body != "Streamed" {
+		t.Errorf("expected streamed response body, got %q", body)
+	}
+}
+
+func TestPromptPolicyExtensionPolicy_FailOpenAndTimeout(t *testing.T) {
+	policy := PromptPolicyExtensionPolicy(ImplementationEnvoyGateway, "llama", "llama-prompt-policy", false, 0, "")
+	extProc, _, _ := unstructured.NestedSlice(policy.Object, "spec", "extProc")
+	if _, ok := extProc[0].(map[string]interface{})["failOpen"]; ok {
+		t.Error("expected the processor to fail closed by default")
+	}
+	if _, ok := extProc[0].(map[string]interface{})["messageTimeout"]; ok {
+		t.Error("expected the default message timeout")
+	}
+
+	policy = PromptPolicyExtensionPolicy(ImplementationEnvoyGateway, "llama", "llama-prompt-policy", true, 1500*time.Millisecond, "")
+	extProc, _, _ = unstructured.NestedSlice(policy.Object, "spec", "extProc")
+	if failOpen, _, _ := unstructured.NestedBool(extProc[0].(map[string]interface{}), "failOpen"); !failOpen {
+		t.Error("expected failOpen")
+	}
+	if timeout, _, _ := unstructured.NestedString(extProc[0].(map[string]interface{}), "messageTimeout"); timeout != "1s500ms" {
+		t.Errorf("expected messageTimeout 1s500ms, got %q", timeout)
+	}
+}
+
+func TestPromptPolicyExtensionPolicy_Unsupported(t *testing.T) {
+	for _, impl := range []Implementation{ImplementationKGateway, ImplementationIstio, ImplementationGKE, ImplementationUnknown} {
+		if PromptPolicyExtensionPolicy(impl, "llama", "llama-prompt-policy", false, 0, "") != nil {
+			t.Errorf("expected no policy for %q", impl)
+		}
+	}
+}
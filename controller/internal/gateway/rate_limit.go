@@ -0,0 +1,97 @@
+package gateway
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+var (
+	// EnvoyGatewayBackendTrafficPolicyGVK is the Envoy Gateway policy used for rate limits and circuit breaking.
+	EnvoyGatewayBackendTrafficPolicyGVK = schema.GroupVersionKind{
+		Group:   "gateway.envoyproxy.io",
+		Version: "v1alpha1",
+		Kind:    "BackendTrafficPolicy",
+	}
+	// KGatewayTrafficPolicyGVK is the kgateway policy used for local rate limits.
+	KGatewayTrafficPolicyGVK = schema.GroupVersionKind{
+		Group:   "gateway.kgateway.dev",
+		Version: "v1alpha1",
+		Kind:    "TrafficPolicy",
+	}
+)
+
+// RateLimitPolicyGVKs returns every policy kind RateLimitPolicy may produce,
+// so callers can clean up policies when limits are removed.
+func RateLimitPolicyGVKs() []schema.GroupVersionKind {
+	return []schema.GroupVersionKind{EnvoyGatewayBackendTrafficPolicyGVK, KGatewayTrafficPolicyGVK}
+}
+
+// RateLimitPolicy builds the implementation-specific policy that enforces rl on the
+// named HTTPRoute. Returns nil when the implementation has no supported policy.
+// The caller sets the name, namespace, and owner of the returned object.
+func RateLimitPolicy(impl Implementation, routeName string, rl *airunwayv1alpha1.RateLimitSpec) *unstructured.Unstructured {
+	if rl == nil {
+		return nil
+	}
+	targetRefs := []interface{}{
+		map[string]interface{}{
+			"group": "gateway.networking.k8s.io",
+			"kind":  "HTTPRoute",
+			"name":  routeName,
+		},
+	}
+
+	switch impl {
+	case ImplementationEnvoyGateway:
+		spec := map[string]interface{}{"targetRefs": targetRefs}
+		if rl.RequestsPerMinute > 0 {
+			spec["rateLimit"] = map[string]interface{}{
+				"type": "Local",
+				"local": map[string]interface{}{
+					"rules": []interface{}{
+						map[string]interface{}{
+							"limit": map[string]interface{}{
+								// Envoy Gateway local limits have no separate burst; allow it within the window.
+								"requests": int64(rl.RequestsPerMinute + rl.Burst),
+								"unit":     "Minute",
+							},
+						},
+					},
+				},
+			}
+		}
+		if rl.MaxConcurrent > 0 {
+			spec["circuitBreaker"] = map[string]interface{}{
+				"maxParallelRequests": int64(rl.MaxConcurrent),
+			}
+		}
+		return newPolicy(EnvoyGatewayBackendTrafficPolicyGVK, spec)
+
+	case ImplementationKGateway:
+		if rl.RequestsPerMinute == 0 {
+			return nil
+		}
+		maxTokens := rl.RequestsPerMinute + rl.Burst
+		return newPolicy(KGatewayTrafficPolicyGVK, map[string]interface{}{
+			"targetRefs": targetRefs,
+			"rateLimit": map[string]interface{}{
+				"local": map[string]interface{}{
+					"tokenBucket": map[string]interface{}{
+						"maxTokens":     int64(maxTokens),
+						"tokensPerFill": int64(rl.RequestsPerMinute),
+						"fillInterval":  "60s",
+					},
+				},
+			},
+		})
+	}
+	return nil
+}
+
+func newPolicy(gvk schema.GroupVersionKind, spec map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	obj.SetGroupVersionKind(gvk)
+	return obj
+}
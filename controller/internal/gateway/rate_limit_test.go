@@ -0,0 +1,66 @@
+package gateway
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+func TestRateLimitPolicy_EnvoyGateway(t *testing.T) {
+	rl := &airunwayv1alpha1.RateLimitSpec{RequestsPerMinute: 60, Burst: 20, MaxConcurrent: 8}
+	policy := RateLimitPolicy(ImplementationEnvoyGateway, "llama", rl)
+	if policy == nil {
+		t.Fatal("expected policy for Envoy Gateway")
+	}
+	if policy.GroupVersionKind() != EnvoyGatewayBackendTrafficPolicyGVK {
+		t.Errorf("unexpected kind %v", policy.GroupVersionKind())
+	}
+	targets, _, _ := unstructured.NestedSlice(policy.Object, "spec", "targetRefs")
+	if len(targets) != 1 || targets[0].(map[string]interface{})["name"] != "llama" {
+		t.Errorf("expected targetRef to HTTPRoute llama, got %v", targets)
+	}
+	rules, _, _ := unstructured.NestedSlice(policy.Object, "spec", "rateLimit", "local", "rules")
+	if len(rules) != 1 {
+		t.Fatalf("expected 1 rate limit rule, got %d", len(rules))
+	}
+	requests, _, _ := unstructured.NestedInt64(rules[0].(map[string]interface{}), "limit", "requests")
+	if requests != 80 {
+		t.Errorf("expected requests 80 (rate + burst), got %d", requests)
+	}
+	parallel, _, _ := unstructured.NestedInt64(policy.Object, "spec", "circuitBreaker", "maxParallelRequests")
+	if parallel != 8 {
+		t.Errorf("expected maxParallelRequests 8, got %d", parallel)
+	}
+}
+
+func TestRateLimitPolicy_KGateway(t *testing.T) {
+	rl := &airunwayv1alpha1.RateLimitSpec{RequestsPerMinute: 60, Burst: 20}
+	policy := RateLimitPolicy(ImplementationKGateway, "llama", rl)
+	if policy == nil {
+		t.Fatal("expected policy for kgateway")
+	}
+	maxTokens, _, _ := unstructured.NestedInt64(policy.Object, "spec", "rateLimit", "local", "tokenBucket", "maxTokens")
+	perFill, _, _ := unstructured.NestedInt64(policy.Object, "spec", "rateLimit", "local", "tokenBucket", "tokensPerFill")
+	if maxTokens != 80 || perFill != 60 {
+		t.Errorf("expected maxTokens 80 and tokensPerFill 60, got %d and %d", maxTokens, perFill)
+	}
+
+	// Concurrency-only limits are not expressible in a kgateway TrafficPolicy.
+	if RateLimitPolicy(ImplementationKGateway, "llama", &airunwayv1alpha1.RateLimitSpec{MaxConcurrent: 4}) != nil {
+		t.Error("expected no policy for concurrency-only limit on kgateway")
+	}
+}
+
+func TestRateLimitPolicy_Unsupported(t *testing.T) {
+	rl := &airunwayv1alpha1.RateLimitSpec{RequestsPerMinute: 60}
+	for _, impl := range []Implementation{ImplementationIstio, ImplementationGKE, ImplementationUnknown} {
+		if RateLimitPolicy(impl, "llama", rl) != nil {
+			t.Errorf("expected no policy for %q", impl)
+		}
+	}
+	if RateLimitPolicy(ImplementationEnvoyGateway, "llama", nil) != nil {
+		t.Error("expected no policy without rate limits")
+	}
+}
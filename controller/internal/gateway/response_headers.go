@@ -0,0 +1,47 @@
+package gateway
+
+import (
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+const (
+	// HeaderModel carries the public model name of the deployment that served a response.
+	HeaderModel = "X-AIRunway-Model"
+	// HeaderDeployment carries the namespace/name of the ModelDeployment that served a response.
+	HeaderDeployment = "X-AIRunway-Deployment"
+	// HeaderProvider carries the provider of the ModelDeployment that served a response.
+	HeaderProvider = "X-AIRunway-Provider"
+)
+
+// ResponseHeaderData is the data the standard response headers are built from.
+type ResponseHeaderData struct {
+	// Namespace is the ModelDeployment namespace.
+	Namespace string
+	// Name is the ModelDeployment name.
+	Name string
+	// ModelName is the public model name clients send.
+	ModelName string
+	// Provider is the selected provider, empty until one is selected.
+	Provider string
+}
+
+// ResponseHeaders returns the name and value of each selected standard response header.
+// Headers without a value, such as the provider before one is selected, are omitted.
+func ResponseHeaders(selected []airunwayv1alpha1.ResponseHeader, data ResponseHeaderData) map[string]string {
+	headers := make(map[string]string, len(selected))
+	for _, h := range selected {
+		var name, value string
+		switch h {
+		case airunwayv1alpha1.ResponseHeaderModel:
+			name, value = HeaderModel, data.ModelName
+		case airunwayv1alpha1.ResponseHeaderDeployment:
+			name, value = HeaderDeployment, data.Namespace+"/"+data.Name
+		case airunwayv1alpha1.ResponseHeaderProvider:
+			name, value = HeaderProvider, data.Provider
+		}
+		if name != "" && value != "" {
+			headers[name] = value
+		}
+	}
+	return headers
+}
@@ -0,0 +1,36 @@
+package gateway
+
+import (
+	"reflect"
+	"testing"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+func TestResponseHeaders(t *testing.T) {
+	data := ResponseHeaderData{Namespace: "team-a", Name: "llama", ModelName: "team-a/llama-3"}
+	all := []airunwayv1alpha1.ResponseHeader{
+		airunwayv1alpha1.ResponseHeaderModel,
+		airunwayv1alpha1.ResponseHeaderDeployment,
+		airunwayv1alpha1.ResponseHeaderProvider,
+	}
+
+	// The provider header is omitted until a provider is selected
+	want := map[string]string{
+		HeaderModel:      "team-a/llama-3",
+		HeaderDeployment: "team-a/llama",
+	}
+	if got := ResponseHeaders(all, data); !reflect.DeepEqual(got, want) {
+		t.Errorf("ResponseHeaders() = %v, want %v", got, want)
+	}
+
+	data.Provider = "kaito"
+	want[HeaderProvider] = "kaito"
+	if got := ResponseHeaders(all, data); !reflect.DeepEqual(got, want) {
+		t.Errorf("ResponseHeaders() = %v, want %v", got, want)
+	}
+
+	if got := ResponseHeaders(nil, data); len(got) != 0 {
+		t.Errorf("expected no headers when none are selected, got %v", got)
+	}
+}
@@ -0,0 +1,54 @@
+package gateway
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultRequestTimeout is the HTTPRoute request timeout used when none is configured.
+	DefaultRequestTimeout = 300 * time.Second
+
+	// DefaultStreamingRequestTimeout is the HTTPRoute request timeout used for streaming
+	// deployments when none is configured. It bounds a whole completion, not the gap
+	// between chunks: a 32k-token response decoded at 20 tokens/s streams for about 27
+	// minutes, so 1h leaves headroom for slow decoding and queueing. It stays finite
+	// because it also sets the termination grace period of draining pods.
+	//
+	// The route timeout is the only streaming setting. The EPP detects SSE responses
+	// from their content type and passes the chunks through unbuffered, so its config
+	// needs no streaming plugins.
+	DefaultStreamingRequestTimeout = time.Hour
+
+	// AnnotationStreaming marks an HTTPRoute as carrying long-lived streaming responses.
+	AnnotationStreaming = "airunway.ai/streaming"
+)
+
+// FormatDuration renders d in the Gateway API duration format
+// (e.g. "5m", "1h30m", "500ms"). Sub-millisecond precision is truncated.
+func FormatDuration(d time.Duration) string {
+	if d <= 0 {
+		return "0s"
+	}
+	var b strings.Builder
+	units := []struct {
+		unit   time.Duration
+		suffix string
+	}{
+		{time.Hour, "h"},
+		{time.Minute, "m"},
+		{time.Second, "s"},
+		{time.Millisecond, "ms"},
+	}
+	for _, u := range units {
+		if n := d / u.unit; n > 0 {
+			fmt.Fprintf(&b, "%d%s", n, u.suffix)
+			d -= n * u.unit
+		}
+	}
+	if b.Len() == 0 {
+		return "0s"
+	}
+	return b.String()
+}
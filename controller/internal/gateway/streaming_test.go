@@ -0,0 +1,22 @@
+package gateway
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatDuration(t *testing.T) {
+	tests := map[time.Duration]string{
+		0:                                     "0s",
+		-time.Second:                          "0s",
+		300 * time.Second:                     "5m",
+		time.Hour:                             "1h",
+		90*time.Minute + 500*time.Millisecond: "1h30m500ms",
+		time.Microsecond:                      "0s",
+	}
+	for d, want := range tests {
+		if got := FormatDuration(d); got != want {
+			t.Errorf("FormatDuration(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package gateway
+
+import (
+	"net/url"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+const (
+	// TracingServiceName is the OpenTelemetry service name of controller-created EPPs.
+	TracingServiceName = "airunway-epp"
+
+	// DefaultTracingSamplingPercent is the percentage of new traces sampled when
+	// spec.observability.tracing.samplingPercent is unset.
+	DefaultTracingSamplingPercent = 10
+
+	// TracingAttributeModel and TracingAttributeDeployment are the resource attributes
+	// that tag EPP spans with the public model name and the namespace/name of the
+	// ModelDeployment.
+	TracingAttributeModel      = "airunway.model"
+	TracingAttributeDeployment = "airunway.deployment"
+)
+
+// TracingConfig is the data the EPP tracing environment is built from.
+type TracingConfig struct {
+	// Endpoint is the OTLP gRPC collector endpoint.
+	Endpoint string
+	// SamplingPercent is the percentage of new traces sampled.
+	SamplingPercent int32
+	// ModelName is the public model name clients send.
+	ModelName string
+	// Deployment is the namespace/name of the ModelDeployment.
+	Deployment string
+}
+
+// TracingEnv returns the OpenTelemetry SDK environment that makes the EPP export spans to
+// cfg.Endpoint. The EPP joins W3C traceparent traces of incoming requests, which are
+// always sampled when their parent was, and samples SamplingPercent of new traces.
+func TracingEnv(cfg TracingConfig) []corev1.EnvVar {
+	ratio := strconv.FormatFloat(float64(cfg.SamplingPercent)/100, 'f', -1, 64)
+	// Resource attribute values are percent-decoded by the SDK
+	attributes := TracingAttributeModel + "=" + url.QueryEscape(cfg.ModelName) + "," +
+		TracingAttributeDeployment + "=" + url.QueryEscape(cfg.Deployment)
+	return []corev1.EnvVar{
+		{Name: "OTEL_SERVICE_NAME", Value: TracingServiceName},
+		{Name: "OTEL_RESOURCE_ATTRIBUTES", Value: attributes},
+		{Name: "OTEL_EXPORTER_OTLP_ENDPOINT", Value: cfg.Endpoint},
+		{Name: "OTEL_TRACES_EXPORTER", Value: "otlp"},
+		{Name: "OTEL_PROPAGATORS", Value: "tracecontext,baggage"},
+		{Name: "OTEL_TRACES_SAMPLER", Value: "parentbased_traceidratio"},
+		{Name: "OTEL_TRACES_SAMPLER_ARG", Value: ratio},
+	}
+}
+
+// TracingRequestHeaders returns the headers that name the model and deployment of a
+// request, set on every request of a traced deployment so gateway and model server spans
+// can be tagged from them.
+func TracingRequestHeaders(data ResponseHeaderData) map[string]string {
+	return ResponseHeaders([]airunwayv1alpha1.ResponseHeader{
+		airunwayv1alpha1.ResponseHeaderModel,
+		airunwayv1alpha1.ResponseHeaderDeployment,
+	}, data)
+}
@@ -0,0 +1,39 @@
+package gateway
+
+import (
+	"testing"
+)
+
+func TestTracingEnv(t *testing.T) {
+	env := TracingEnv(TracingConfig{
+		Endpoint:        "http://otel-collector.observability:4317",
+		SamplingPercent: 25,
+		ModelName:       "team-a/llama-3",
+		Deployment:      "team-a/llama",
+	})
+
+	got := make(map[string]string, len(env))
+	for _, e := range env {
+		got[e.Name] = e.Value
+	}
+	want := map[string]string{
+		"OTEL_SERVICE_NAME":           TracingServiceName,
+		"OTEL_RESOURCE_ATTRIBUTES":    "airunway.model=team-a%2Fllama-3,airunway.deployment=team-a%2Fllama",
+		"OTEL_EXPORTER_OTLP_ENDPOINT": "http://otel-collector.observability:4317",
+		"OTEL_PROPAGATORS":            "tracecontext,baggage",
+		"OTEL_TRACES_SAMPLER":         "parentbased_traceidratio",
+		"OTEL_TRACES_SAMPLER_ARG":     "0.25",
+	}
+	for name, value := range want {
+		if got[name] != value {
+			t.Errorf("%s = %q, want %q", name, got[name], value)
+		}
+	}
+
+	env = TracingEnv(TracingConfig{SamplingPercent: 100})
+	for _, e := range env {
+		if e.Name == "OTEL_TRACES_SAMPLER_ARG" && e.Value != "1" {
+			t.Errorf("expected sampler arg 1, got %q", e.Value)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+// Package lora loads and unloads LoRA adapters on a running vllm server through its
+// runtime adapter API, so adapters can change without restarting the engine. vllm only
+// serves the API with VLLM_ALLOW_RUNTIME_LORA_UPDATING=True and --enable-lora.
+package lora
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxResponseBytes bounds how much of a model server response is read.
+const maxResponseBytes = 1 << 20
+
+// Loaded returns the adapters served by the model server at baseURL, mapping each adapter
+// name to the path it was loaded from. vllm lists adapters in /v1/models as models with a
+// parent, the base model.
+func Loaded(ctx context.Context, httpClient *http.Client, baseURL string) (map[string]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(baseURL, "/")+"/v1/models", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBytes))
+		return nil, fmt.Errorf("model server returned HTTP %d for /v1/models", resp.StatusCode)
+	}
+
+	var models struct {
+		Data []struct {
+			ID     string  `json:"id"`
+			Root   string  `json:"root"`
+			Parent *string `json:"parent"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(&models); err != nil {
+		return nil, fmt.Errorf("decoding /v1/models: %w", err)
+	}
+	adapters := map[string]string{}
+	for _, m := range models.Data {
+		if m.Parent != nil && *m.Parent != "" {
+			adapters[m.ID] = m.Root
+		}
+	}
+	return adapters, nil
+}
+
+// Load loads the adapter at path under name on the model server at baseURL. path is a
+// directory in the engine container or a Hugging Face repository ID.
+func Load(ctx context.Context, httpClient *http.Client, baseURL, name, path string) error {
+	return post(ctx, httpClient, baseURL, "/v1/load_lora_adapter", map[string]string{
+		"lora_name": name,
+		"lora_path": path,
+	})
+}
+
+// Unload unloads the adapter name from the model server at baseURL.
+func Unload(ctx context.Context, httpClient *http.Client, baseURL, name string) error {
+	return post(ctx, httpClient, baseURL, "/v1/unload_lora_adapter", map[string]string{
+		"lora_name": name,
+	})
+}
+
+// post sends a JSON request to the adapter API and reports the model server error message
+// of a failed request.
+func post(ctx context.Context, httpClient *http.Client, baseURL, path string, payload map[string]string) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(baseURL, "/")+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("model server returned HTTP %d for %s: %s", resp.StatusCode, path, errorMessage(data))
+	}
+	return nil
+}
+
+// errorMessage returns the message of an OpenAI-style error body, or the body itself
+func errorMessage(data []byte) string {
+	var body struct {
+		Message string `json:"message"`
+		Error   struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(data, &body); err == nil {
+		if body.Error.Message != "" {
+			return body.Error.Message
+		}
+		if body.Message != "" {
+			return body.Message
+		}
+	}
+	msg := strings.TrimSpace(string(data))
+	if len(msg) > 256 {
+		msg = msg[:256]
+	}
+	return msg
+}
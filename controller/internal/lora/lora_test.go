@@ -0,0 +1,69 @@
+package lora
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLoaded(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/models" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"data":[
+			{"id":"meta-llama/Llama-3-8B","root":"meta-llama/Llama-3-8B","parent":null},
+			{"id":"sql","root":"/adapters/sql","parent":"meta-llama/Llama-3-8B"},
+			{"id":"chat","root":"org/chat-lora","parent":"meta-llama/Llama-3-8B"}
+		]}`))
+	}))
+	defer srv.Close()
+
+	adapters, err := Loaded(context.Background(), srv.Client(), srv.URL+"/")
+	if err != nil {
+		t.Fatalf("Loaded failed: %v", err)
+	}
+	if len(adapters) != 2 || adapters["sql"] != "/adapters/sql" || adapters["chat"] != "org/chat-lora" {
+		t.Errorf("adapters = %v, want sql and chat", adapters)
+	}
+}
+
+func TestLoadAndUnload(t *testing.T) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decoding request: %v", err)
+		}
+		requests = append(requests, r.URL.Path+" "+body["lora_name"]+" "+body["lora_path"])
+		if body["lora_name"] == "broken" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":{"message":"adapter rank 64 exceeds max_lora_rank 16"}}`))
+		}
+	}))
+	defer srv.Close()
+	ctx := context.Background()
+
+	if err := Load(ctx, srv.Client(), srv.URL, "sql", "/adapters/sql"); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if err := Unload(ctx, srv.Client(), srv.URL, "sql"); err != nil {
+		t.Fatalf("Unload failed: %v", err)
+	}
+	err := Load(ctx, srv.Client(), srv.URL, "broken", "/adapters/broken")
+	if err == nil || !strings.Contains(err.Error(), "exceeds max_lora_rank") {
+		t.Errorf("Load error = %v, want the model server message", err)
+	}
+
+	want := []string{
+		"/v1/load_lora_adapter sql /adapters/sql",
+		"/v1/unload_lora_adapter sql ",
+		"/v1/load_lora_adapter broken /adapters/broken",
+	}
+	if strings.Join(requests, "\n") != strings.Join(want, "\n") {
+		t.Errorf("requests = %q, want %q", requests, want)
+	}
+}
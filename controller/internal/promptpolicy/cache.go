@@ -0,0 +1,195 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package promptpolicy
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+const (
+	// DefaultResponseCacheTTL is how long responses are cached when
+	// spec.gateway.responseCache sets no ttl
+	DefaultResponseCacheTTL = 5 * time.Minute
+	// DefaultResponseCacheMaxEntries bounds the cache when spec.gateway.responseCache sets no
+	// maxEntries
+	DefaultResponseCacheMaxEntries = 1000
+
+	// CacheHeader is set on responses to cacheable requests: hit when the response was
+	// served from the cache, miss when it came from the model
+	CacheHeader = "X-AIRunway-Cache"
+)
+
+// ResponseCache configures the response cache of the processor
+type ResponseCache struct {
+	TTL        metav1.Duration `json:"ttl"`
+	MaxEntries int32           `json:"maxEntries"`
+	// NormalizeWhitespace keys requests on their canonical JSON with the whitespace of
+	// their prompts collapsed instead of their exact body
+	NormalizeWhitespace bool `json:"normalizeWhitespace,omitempty"`
+}
+
+// ResponseCacheFor returns the ResponseCache of spec.gateway.responseCache with defaults
+// applied
+func ResponseCacheFor(spec *airunwayv1alpha1.ResponseCacheSpec) *ResponseCache {
+	if spec == nil {
+		return nil
+	}
+	c := &ResponseCache{
+		TTL:                 metav1.Duration{Duration: DefaultResponseCacheTTL},
+		MaxEntries:          DefaultResponseCacheMaxEntries,
+		NormalizeWhitespace: spec.NormalizeWhitespace,
+	}
+	if spec.TTL != nil && spec.TTL.Duration > 0 {
+		c.TTL = *spec.TTL
+	}
+	if spec.MaxEntries > 0 {
+		c.MaxEntries = spec.MaxEntries
+	}
+	return c
+}
+
+// cacheKey returns the cache key of a request to path, or an empty string when the request
+// is not cacheable: streamed requests and bodies that are not JSON objects. Requests are
+// keyed by their exact body, including the user field, so responses are only shared
+// between identical requests of the same user. With normalize, requests that only differ
+// in the whitespace of their prompts or the order of their fields share a key.
+func cacheKey(path string, body []byte, normalize bool) string {
+	var request map[string]interface{}
+	if err := json.Unmarshal(body, &request); err != nil || request == nil {
+		return ""
+	}
+	if stream, _ := request["stream"].(bool); stream {
+		return ""
+	}
+	if !normalize {
+		sum := sha256.Sum256(append([]byte(path+"\n"), body...))
+		return hex.EncodeToString(sum[:])
+	}
+	if prompt, ok := request["prompt"]; ok {
+		request["prompt"] = normalizeText(prompt)
+	}
+	if messages, ok := request["messages"].([]interface{}); ok {
+		for _, m := range messages {
+			if message, ok := m.(map[string]interface{}); ok {
+				if content, ok := message["content"]; ok {
+					message["content"] = normalizeText(content)
+				}
+			}
+		}
+	}
+	// Maps marshal with sorted keys
+	canonical, err := json.Marshal(request)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(append([]byte(path+"\n"), canonical...))
+	return hex.EncodeToString(sum[:])
+}
+
+// normalizeText collapses the whitespace of a prompt or message content: a string, a list
+// of strings, or a list of content parts whose text is normalized
+func normalizeText(v interface{}) interface{} {
+	switch t := v.(type) {
+	case string:
+		return strings.Join(strings.Fields(t), " ")
+	case []interface{}:
+		for i, item := range t {
+			if part, ok := item.(map[string]interface{}); ok {
+				if text, ok := part["text"].(string); ok {
+					part["text"] = normalizeText(text)
+				}
+				continue
+			}
+			t[i] = normalizeText(item)
+		}
+	}
+	return v
+}
+
+// responseStore is an LRU cache of response bodies whose entries expire after a TTL
+type responseStore struct {
+	ttl        time.Duration
+	maxEntries int
+	now        func() time.Time
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type cacheEntry struct {
+	key     string
+	body    []byte
+	expires time.Time
+}
+
+func newResponseStore(c *ResponseCache) *responseStore {
+	return &responseStore{
+		ttl:        c.TTL.Duration,
+		maxEntries: int(c.MaxEntries),
+		now:        time.Now,
+		order:      list.New(),
+		entries:    map[string]*list.Element{},
+	}
+}
+
+// get returns the cached body of key, if it has not expired
+func (s *responseStore) get(key string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := e.Value.(*cacheEntry)
+	if !s.now().Before(entry.expires) {
+		s.order.Remove(e)
+		delete(s.entries, key)
+		return nil, false
+	}
+	s.order.MoveToFront(e)
+	return entry.body, true
+}
+
+// put caches body under key, evicting the least recently used entry when full
+func (s *responseStore) put(key string, body []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	expires := s.now().Add(s.ttl)
+	if e, ok := s.entries[key]; ok {
+		entry := e.Value.(*cacheEntry)
+		entry.body, entry.expires = body, expires
+		s.order.MoveToFront(e)
+		return
+	}
+	s.entries[key] = s.order.PushFront(&cacheEntry{key: key, body: body, expires: expires})
+	for s.order.Len() > s.maxEntries {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
@@ -0,0 +1,152 @@
+package promptpolicy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	extprocv3 "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+func TestResponseCacheFor(t *testing.T) {
+	if ResponseCacheFor(nil) != nil {
+		t.Error("expected no cache without spec")
+	}
+	c := ResponseCacheFor(&airunwayv1alpha1.ResponseCacheSpec{})
+	if c.TTL.Duration != DefaultResponseCacheTTL || c.MaxEntries != DefaultResponseCacheMaxEntries {
+		t.Errorf("expected defaults, got %+v", c)
+	}
+	c = ResponseCacheFor(&airunwayv1alpha1.ResponseCacheSpec{TTL: &metav1.Duration{Duration: time.Minute}, MaxEntries: 10, NormalizeWhitespace: true})
+	if c.TTL.Duration != time.Minute || c.MaxEntries != 10 || !c.NormalizeWhitespace {
+		t.Errorf("expected spec values, got %+v", c)
+	}
+}
+
+func TestCacheKey(t *testing.T) {
+	body := `{"model":"m","messages":[{"role":"user","content":"Hello world"}]}`
+	key := cacheKey("/v1/chat/completions", []byte(body), false)
+	if key == "" {
+		t.Fatal("expected a key")
+	}
+	if cacheKey("/v1/chat/completions", []byte(body), false) != key {
+		t.Error("expected identical requests to share a key")
+	}
+	if cacheKey("/v1/chat/completions", []byte(`{"model":"m","messages":[{"role":"user","content":"Hello  world"}]}`), false) == key {
+		t.Error("expected whitespace to change the key by default")
+	}
+	if cacheKey("/v1/chat/completions", []byte(`{"model":"m","messages":[{"role":"user","content":"Hello world"}],"user":"alice"}`), false) == key {
+		t.Error("expected the user to be part of the key")
+	}
+	if cacheKey("/v1/completions", []byte(body), false) == key {
+		t.Error("expected the path to be part of the key")
+	}
+	if cacheKey("/v1/chat/completions", []byte(`{"model":"m","messages":[{"role":"user","content":"Hello"}]}`), false) == key {
+		t.Error("expected different prompts to have different keys")
+	}
+	for _, body := range []string{`{"prompt":"hi","stream":true}`, `[1]`, `not json`, `null`} {
+		if cacheKey("/v1/completions", []byte(body), false) != "" || cacheKey("/v1/completions", []byte(body), true) != "" {
+			t.Errorf("expected %s not to be cacheable", body)
+		}
+	}
+}
+
+func TestCacheKey_Normalized(t *testing.T) {
+	key := cacheKey("/v1/chat/completions", []byte(`{"model":"m","user":"alice","messages":[{"role":"user","content":"Hello   world"}]}`), true)
+	if key == "" {
+		t.Fatal("expected a key")
+	}
+	same := cacheKey("/v1/chat/completions", []byte(`{"user":"alice","messages":[{"content":" Hello world\n","role":"user"}],"model":"m"}`), true)
+	if same != key {
+		t.Error("expected whitespace and field order to be ignored")
+	}
+	if cacheKey("/v1/chat/completions", []byte(`{"model":"m","user":"bob","messages":[{"role":"user","content":"Hello world"}]}`), true) == key {
+		t.Error("expected the user to be part of the key")
+	}
+}
+
+func TestResponseStore(t *testing.T) {
+	now := time.Now()
+	s := newResponseStore(&ResponseCache{TTL: metav1.Duration{Duration: time.Minute}, MaxEntries: 2})
+	s.now = func() time.Time { return now }
+
+	s.put("a", []byte("1"))
+	s.put("b", []byte("2"))
+	if _, ok := s.get("a"); !ok {
+		t.Fatal("expected a to be cached")
+	}
+	// b is the least recently used entry
+	s.put("c", []byte("3"))
+	if _, ok := s.get("b"); ok {
+		t.Error("expected b to be evicted")
+	}
+	if body, ok := s.get("c"); !ok || string(body) != "3" {
+		t.Errorf("expected c to be cached, got %q", body)
+	}
+
+	now = now.Add(time.Minute)
+	if _, ok := s.get("a"); ok {
+		t.Error("expected a to expire")
+	}
+}
+
+func TestHandle_ResponseCache(t *testing.T) {
+	s := &Server{Policy: Policy{ResponseCache: ResponseCacheFor(&airunwayv1alpha1.ResponseCacheSpec{})}}
+	headers := func(key, value string) *corev3.HeaderMap {
+		return &corev3.HeaderMap{Headers: []*corev3.HeaderValue{{Key: key, RawValue: []byte(value)}}}
+	}
+	exchangeOnce := func(status string) *extprocv3.ProcessingResponse {
+		t.Helper()
+		ex := &exchange{}
+		if _, err := s.handle(context.Background(), &extprocv3.ProcessingRequest{Request: &extprocv3.ProcessingRequest_RequestHeaders{
+			RequestHeaders: &extprocv3.HttpHeaders{Headers: headers(":path", "/v1/chat/completions")},
+		}}, ex); err != nil {
+			t.Fatalf("handle: %v", err)
+		}
+		resp, err := s.handle(context.Background(), requestBody("hello"), ex)
+		if err != nil {
+			t.Fatalf("handle: %v", err)
+		}
+		if resp.GetImmediateResponse() != nil {
+			return resp
+		}
+		resp, err = s.handle(context.Background(), &extprocv3.ProcessingRequest{Request: &extprocv3.ProcessingRequest_ResponseHeaders{
+			ResponseHeaders: &extprocv3.HttpHeaders{Headers: headers(":status", status)},
+		}}, ex)
+		if err != nil {
+			t.Fatalf("handle: %v", err)
+		}
+		if _, err := s.handle(context.Background(), &extprocv3.ProcessingRequest{Request: &extprocv3.ProcessingRequest_ResponseBody{
+			ResponseBody: &extprocv3.HttpBody{Body: []byte(`{"id":"1"}`), EndOfStream: true},
+		}}, ex); err != nil {
+			t.Fatalf("handle: %v", err)
+		}
+		return resp
+	}
+
+	// Failed responses are not cached
+	if resp := exchangeOnce("500"); resp.GetResponseHeaders().GetResponse() != nil {
+		t.Errorf("expected no cache header on a failed response, got %v", resp)
+	}
+	resp := exchangeOnce("200")
+	set := resp.GetResponseHeaders().GetResponse().GetHeaderMutation().GetSetHeaders()
+	if len(set) != 1 || set[0].GetHeader().GetKey() != CacheHeader || string(set[0].GetHeader().GetRawValue()) != "miss" {
+		t.Errorf("expected a cache miss, got %v", resp)
+	}
+
+	resp = exchangeOnce("200")
+	immediate := resp.GetImmediateResponse()
+	if immediate == nil || string(immediate.GetBody()) != `{"id":"1"}` {
+		t.Fatalf("expected a cache hit, got %v", resp)
+	}
+	var hit bool
+	for _, h := range immediate.GetHeaders().GetSetHeaders() {
+		hit = hit || (h.GetHeader().GetKey() == CacheHeader && string(h.GetHeader().GetRawValue()) == "hit")
+	}
+	if !hit {
+		t.Errorf("expected the %s: hit header, got %v", CacheHeader, immediate.GetHeaders())
+	}
+}
@@ -0,0 +1,250 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package promptpolicy
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+const (
+	// OperationChat is the gen_ai.operation.name of chat completion requests
+	OperationChat = "chat"
+	// OperationTextCompletion is the gen_ai.operation.name of completion requests
+	OperationTextCompletion = "text_completion"
+	// OperationEmbeddings is the gen_ai.operation.name of embeddings requests
+	OperationEmbeddings = "embeddings"
+
+	// maxMetricsBody bounds the response bytes held to read the usage of one request, so
+	// large responses are forwarded without their token usage rather than held in memory
+	maxMetricsBody = 1 << 20
+)
+
+// GenAIMetrics configures the GenAI metrics of the processor
+type GenAIMetrics struct {
+	// ProviderName is the gen_ai.provider.name of recorded operations: the inference engine
+	ProviderName string `json:"providerName,omitempty"`
+}
+
+// GenAIMetricsFor returns the GenAIMetrics of spec.observability.genAIMetrics for a
+// deployment served by engine, or nil when they are disabled
+func GenAIMetricsFor(spec *airunwayv1alpha1.ObservabilitySpec, engine airunwayv1alpha1.EngineType) *GenAIMetrics {
+	if spec == nil || spec.GenAIMetrics == nil || !spec.GenAIMetrics.Enabled {
+		return nil
+	}
+	return &GenAIMetrics{ProviderName: string(engine)}
+}
+
+// Metrics records the OpenTelemetry GenAI semantic convention metrics of requests, named
+// as the OpenTelemetry Prometheus exporter names them so that GenAI dashboards built on
+// either work unchanged
+type Metrics struct {
+	tokenUsage  *prometheus.HistogramVec
+	duration    *prometheus.HistogramVec
+	timeToFirst *prometheus.HistogramVec
+
+	now func() time.Time
+}
+
+// NewMetrics registers the GenAI metrics with reg
+func NewMetrics(reg prometheus.Registerer) *Metrics {
+	labels := []string{"gen_ai_operation_name", "gen_ai_provider_name", "gen_ai_request_model", "gen_ai_response_model"}
+	m := &Metrics{
+		// Buckets are the explicit bucket boundaries advised by the semantic conventions
+		tokenUsage: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "gen_ai_client_token_usage",
+			Help:    "Number of input and output tokens used.",
+			Buckets: []float64{1, 4, 16, 64, 256, 1024, 4096, 16384, 65536, 262144, 1048576, 4194304, 16777216, 67108864},
+		}, append(labels, "gen_ai_token_type")),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "gen_ai_client_operation_duration_seconds",
+			Help:    "GenAI operation duration.",
+			Buckets: []float64{0.01, 0.02, 0.04, 0.08, 0.16, 0.32, 0.64, 1.28, 2.56, 5.12, 10.24, 20.48, 40.96, 81.92},
+		}, append(labels, "error_type")),
+		timeToFirst: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "gen_ai_server_time_to_first_token_seconds",
+			Help:    "Time to generate the first token of streamed requests.",
+			Buckets: []float64{0.001, 0.005, 0.01, 0.02, 0.04, 0.06, 0.08, 0.1, 0.25, 0.5, 0.75, 1.0, 2.5, 5.0, 7.5, 10.0},
+		}, labels),
+		now: time.Now,
+	}
+	reg.MustRegister(m.tokenUsage, m.duration, m.timeToFirst)
+	return m
+}
+
+// operation is the metrics state of one request
+type operation struct {
+	// name is the gen_ai.operation.name of the request path
+	name          string
+	requestModel  string
+	responseModel string
+	stream        bool
+	start         time.Time
+	// firstChunk is when the first response body bytes arrived, zero before
+	firstChunk time.Time
+	// errorType is the error.type of a failed request: its status code
+	errorType string
+
+	hasUsage     bool
+	inputTokens  int64
+	outputTokens int64
+
+	// body holds a response that is not streamed until it ends, and partial the incomplete
+	// last line of a streamed response
+	body    []byte
+	partial []byte
+}
+
+// operationName returns the gen_ai.operation.name of an OpenAI API request path, or an
+// empty string for paths that are not GenAI operations
+func operationName(path string) string {
+	path, _, _ = strings.Cut(path, "?")
+	switch {
+	case strings.HasSuffix(path, "/chat/completions"):
+		return OperationChat
+	case strings.HasSuffix(path, "/completions"):
+		return OperationTextCompletion
+	case strings.HasSuffix(path, "/embeddings"):
+		return OperationEmbeddings
+	}
+	return ""
+}
+
+// start returns the operation of a request to path, or nil when it is not recorded
+func (m *Metrics) start(path string) *operation {
+	name := operationName(path)
+	if name == "" {
+		return nil
+	}
+	return &operation{name: name, start: m.now()}
+}
+
+// request reads the model and stream flag of a request body
+func (op *operation) request(body []byte) {
+	var req struct {
+		Model  string `json:"model"`
+		Stream bool   `json:"stream"`
+	}
+	if err := json.Unmarshal(body, &req); err == nil {
+		op.requestModel = req.Model
+		op.stream = req.Stream
+	}
+}
+
+// responseHeaders records the status of the response: non-2xx responses are errors
+func (op *operation) responseHeaders(status, contentType string) {
+	if code, err := strconv.Atoi(status); err == nil && (code < 200 || code > 299) {
+		op.errorType = status
+	}
+	if strings.HasPrefix(contentType, "text/event-stream") {
+		op.stream = true
+	}
+}
+
+// responseBody reads the model and usage from a chunk of the response body
+func (op *operation) responseBody(m *Metrics, chunk []byte, endOfStream bool) {
+	if len(chunk) > 0 && op.firstChunk.IsZero() {
+		op.firstChunk = m.now()
+	}
+	if !op.stream {
+		if len(op.body)+len(chunk) <= maxMetricsBody {
+			op.body = append(op.body, chunk...)
+		} else {
+			op.body = nil
+		}
+		if endOfStream && op.body != nil {
+			op.event(op.body)
+		}
+		return
+	}
+	// Server-sent events: the final event carries the usage when the request sets
+	// stream_options.include_usage
+	data := append(op.partial, chunk...)
+	lines := bytes.Split(data, []byte("\n"))
+	for _, line := range lines[:len(lines)-1] {
+		if payload, ok := bytes.CutPrefix(bytes.TrimSpace(line), []byte("data:")); ok {
+			op.event(bytes.TrimSpace(payload))
+		}
+	}
+	op.partial = nil
+	if last := lines[len(lines)-1]; len(last) <= maxMetricsBody {
+		op.partial = bytes.Clone(last)
+	}
+	if endOfStream {
+		if payload, ok := bytes.CutPrefix(bytes.TrimSpace(op.partial), []byte("data:")); ok {
+			op.event(bytes.TrimSpace(payload))
+		}
+	}
+}
+
+// event reads the model and usage of a JSON response or stream event
+func (op *operation) event(data []byte) {
+	var resp struct {
+		Model string `json:"model"`
+		Usage *struct {
+			PromptTokens     int64 `json:"prompt_tokens"`
+			CompletionTokens int64 `json:"completion_tokens"`
+		} `json:"usage"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return
+	}
+	if resp.Model != "" {
+		op.responseModel = resp.Model
+	}
+	if resp.Usage != nil {
+		op.hasUsage = true
+		op.inputTokens = resp.Usage.PromptTokens
+		op.outputTokens = resp.Usage.CompletionTokens
+	}
+}
+
+// record records the metrics of a finished operation. A non-empty errorType overrides
+// the error type of the response, e.g. for requests the client abandoned. The time to first
+// token is only recorded for streamed responses, unless Envoy buffered them.
+func (m *Metrics) record(op *operation, providerName, errorType string, buffered bool) {
+	if errorType == "" {
+		errorType = op.errorType
+	}
+	labels := prometheus.Labels{
+		"gen_ai_operation_name": op.name,
+		"gen_ai_provider_name":  providerName,
+		"gen_ai_request_model":  op.requestModel,
+		"gen_ai_response_model": op.responseModel,
+	}
+	m.duration.MustCurryWith(labels).WithLabelValues(errorType).Observe(m.now().Sub(op.start).Seconds())
+	if errorType != "" {
+		return
+	}
+	if op.stream && !buffered && !op.firstChunk.IsZero() {
+		m.timeToFirst.With(labels).Observe(op.firstChunk.Sub(op.start).Seconds())
+	}
+	if op.hasUsage {
+		tokens := m.tokenUsage.MustCurryWith(labels)
+		tokens.WithLabelValues("input").Observe(float64(op.inputTokens))
+		if op.name != OperationEmbeddings {
+			tokens.WithLabelValues("output").Observe(float64(op.outputTokens))
+		}
+	}
+}
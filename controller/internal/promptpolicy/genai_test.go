@@ -0,0 +1,182 @@
+package promptpolicy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	extprocv3 "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+func TestGenAIMetricsFor(t *testing.T) {
+	if GenAIMetricsFor(nil, airunwayv1alpha1.EngineTypeVLLM) != nil {
+		t.Error("expected no metrics without spec")
+	}
+	if GenAIMetricsFor(&airunwayv1alpha1.ObservabilitySpec{GenAIMetrics: &airunwayv1alpha1.GenAIMetricsSpec{}}, airunwayv1alpha1.EngineTypeVLLM) != nil {
+		t.Error("expected no metrics when disabled")
+	}
+	m := GenAIMetricsFor(&airunwayv1alpha1.ObservabilitySpec{GenAIMetrics: &airunwayv1alpha1.GenAIMetricsSpec{Enabled: true}}, airunwayv1alpha1.EngineTypeVLLM)
+	if m == nil || m.ProviderName != "vllm" {
+		t.Errorf("expected vllm provider, got %+v", m)
+	}
+}
+
+func TestOperationName(t *testing.T) {
+	for path, want := range map[string]string{
+		"/v1/chat/completions":     OperationChat,
+		"/v1/completions?x=1":      OperationTextCompletion,
+		"/openai/v1/embeddings":    OperationEmbeddings,
+		"/v1/models":               "",
+		"/v1/chat/completions/abc": "",
+	} {
+		if got := operationName(path); got != want {
+			t.Errorf("operationName(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+// metricsServer returns a Server recording metrics into a new registry with a clock
+// advancing one second per reading
+func metricsServer(policy Policy) (*Server, *prometheus.Registry) {
+	reg := prometheus.NewRegistry()
+	m := NewMetrics(reg)
+	now := time.Unix(0, 0)
+	m.now = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+	policy.GenAIMetrics = &GenAIMetrics{ProviderName: "vllm"}
+	return &Server{Policy: policy, Metrics: m}, reg
+}
+
+// exchangeMessages sends a request and its response through s and returns the exchange
+func exchangeMessages(t *testing.T, s *Server, path, request, status string, chunks ...string) *exchange {
+	t.Helper()
+	ex := &exchange{}
+	msgs := []*extprocv3.ProcessingRequest{
+		{Request: &extprocv3.ProcessingRequest_RequestHeaders{RequestHeaders: &extprocv3.HttpHeaders{
+			Headers: &corev3.HeaderMap{Headers: []*corev3.HeaderValue{{Key: ":path", Value: path}}},
+		}}},
+		{Request: &extprocv3.ProcessingRequest_RequestBody{RequestBody: &extprocv3.HttpBody{Body: []byte(request), EndOfStream: true}}},
+		{Request: &extprocv3.ProcessingRequest_ResponseHeaders{ResponseHeaders: &extprocv3.HttpHeaders{
+			Headers: &corev3.HeaderMap{Headers: []*corev3.HeaderValue{{Key: ":status", Value: status}}},
+		}}},
+	}
+	for i, chunk := range chunks {
+		msgs = append(msgs, &extprocv3.ProcessingRequest{Request: &extprocv3.ProcessingRequest_ResponseBody{
+			ResponseBody: &extprocv3.HttpBody{Body: []byte(chunk), EndOfStream: i == len(chunks)-1},
+		}})
+	}
+	for _, msg := range msgs {
+		if _, err := s.handle(context.Background(), msg, ex); err != nil {
+			t.Fatalf("handle: %v", err)
+		}
+	}
+	return ex
+}
+
+// histogram returns the histogram of the named metric with the given label values
+func histogram(t *testing.T, reg *prometheus.Registry, name string, labels map[string]string) *dto.Histogram {
+	t.Helper()
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("gather: %v", err)
+	}
+	for _, f := range families {
+		if f.GetName() != name {
+			continue
+		}
+	metrics:
+		for _, m := range f.GetMetric() {
+			for _, l := range m.GetLabel() {
+				if v, ok := labels[l.GetName()]; ok && v != l.GetValue() {
+					continue metrics
+				}
+			}
+			return m.GetHistogram()
+		}
+	}
+	return nil
+}
+
+func TestMetrics_Completion(t *testing.T) {
+	s, reg := metricsServer(Policy{})
+	ex := exchangeMessages(t, s, "/v1/chat/completions", `{"model":"llama","messages":[]}`, "200",
+		`{"model":"llama-3-8b","choices":[],`, `"usage":{"prompt_tokens":12,"completion_tokens":34}}`)
+	if ex.op != nil {
+		t.Error("expected the operation to be recorded at the end of the response")
+	}
+
+	labels := map[string]string{
+		"gen_ai_operation_name": "chat", "gen_ai_provider_name": "vllm",
+		"gen_ai_request_model": "llama", "gen_ai_response_model": "llama-3-8b",
+	}
+	// Start at 1s, first chunk at 2s, end at 3s
+	if h := histogram(t, reg, "gen_ai_client_operation_duration_seconds", labels); h.GetSampleCount() != 1 || h.GetSampleSum() != 2 {
+		t.Errorf("expected one 2s operation, got %v", h)
+	}
+	labels["gen_ai_token_type"] = "input"
+	if h := histogram(t, reg, "gen_ai_client_token_usage", labels); h.GetSampleSum() != 12 {
+		t.Errorf("expected 12 input tokens, got %v", h)
+	}
+	labels["gen_ai_token_type"] = "output"
+	if h := histogram(t, reg, "gen_ai_client_token_usage", labels); h.GetSampleSum() != 34 {
+		t.Errorf("expected 34 output tokens, got %v", h)
+	}
+	if h := histogram(t, reg, "gen_ai_server_time_to_first_token_seconds", nil); h != nil {
+		t.Errorf("expected no time to first token for a request that is not streamed, got %v", h)
+	}
+}
+
+func TestMetrics_Streamed(t *testing.T) {
+	s, reg := metricsServer(Policy{})
+	exchangeMessages(t, s, "/v1/completions", `{"model":"llama","prompt":"hi","stream":true}`, "200",
+		"data: {\"model\":\"llama\",\"choices\":[{\"text\":\"Hel\"}]}\n\n",
+		"data: {\"model\":\"llama\",\"choices\":[],\"usa",
+		"ge\":{\"prompt_tokens\":3,\"completion_tokens\":5}}\n\ndata: [DONE]\n\n")
+
+	if h := histogram(t, reg, "gen_ai_server_time_to_first_token_seconds", map[string]string{"gen_ai_operation_name": "text_completion"}); h.GetSampleCount() != 1 || h.GetSampleSum() != 1 {
+		t.Errorf("expected a 1s time to first token, got %v", h)
+	}
+	if h := histogram(t, reg, "gen_ai_client_token_usage", map[string]string{"gen_ai_token_type": "output"}); h.GetSampleSum() != 5 {
+		t.Errorf("expected 5 output tokens from the final event, got %v", h)
+	}
+
+	// With the response cache, Envoy buffers responses, so the first chunk is the last
+	s, reg = metricsServer(Policy{ResponseCache: &ResponseCache{MaxEntries: 1}})
+	exchangeMessages(t, s, "/v1/completions", `{"model":"llama","prompt":"hi","stream":true}`, "200",
+		"data: {\"model\":\"llama\",\"choices\":[]}\n\ndata: [DONE]\n\n")
+	if h := histogram(t, reg, "gen_ai_server_time_to_first_token_seconds", nil); h != nil {
+		t.Errorf("expected no time to first token for buffered responses, got %v", h)
+	}
+}
+
+func TestMetrics_Errors(t *testing.T) {
+	s, reg := metricsServer(Policy{})
+	exchangeMessages(t, s, "/v1/chat/completions", `{"model":"llama"}`, "503", `{"error":{"message":"overloaded"}}`)
+	if h := histogram(t, reg, "gen_ai_client_operation_duration_seconds", map[string]string{"error_type": "503"}); h.GetSampleCount() != 1 {
+		t.Errorf("expected a failed operation, got %v", h)
+	}
+	if h := histogram(t, reg, "gen_ai_client_token_usage", nil); h != nil {
+		t.Errorf("expected no token usage for failed requests, got %v", h)
+	}
+
+	// Requests abandoned before their response ends are canceled
+	ex := exchangeMessages(t, s, "/v1/chat/completions", `{"model":"llama"}`, "200")
+	s.finish(ex, "canceled")
+	if h := histogram(t, reg, "gen_ai_client_operation_duration_seconds", map[string]string{"error_type": "canceled"}); h.GetSampleCount() != 1 {
+		t.Errorf("expected a canceled operation, got %v", h)
+	}
+
+	// Paths that are not GenAI operations are not recorded
+	s, reg = metricsServer(Policy{})
+	exchangeMessages(t, s, "/v1/models", ``, "200", `{"data":[]}`)
+	if h := histogram(t, reg, "gen_ai_client_operation_duration_seconds", nil); h != nil {
+		t.Errorf("expected no operation for /v1/models, got %v", h)
+	}
+}
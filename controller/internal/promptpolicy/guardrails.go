@@ -0,0 +1,170 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package promptpolicy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+const (
+	// DefaultGuardrailsTimeout is the moderation request timeout when spec.gateway.guardrails
+	// sets none
+	DefaultGuardrailsTimeout = 5 * time.Second
+
+	// GuardrailsHeader is set on forwarded requests that were flagged, or could not be
+	// screened
+	GuardrailsHeader = "X-AIRunway-Guardrails"
+
+	// maxModerationResponse bounds the moderation response read by the processor
+	maxModerationResponse = 1 << 20
+)
+
+// Guardrails screens requests with an OpenAI-compatible moderation API
+type Guardrails struct {
+	Endpoint    string                                 `json:"endpoint"`
+	Mode        airunwayv1alpha1.GuardrailsMode        `json:"mode"`
+	FailureMode airunwayv1alpha1.GuardrailsFailureMode `json:"failureMode"`
+	Timeout     metav1.Duration                        `json:"timeout"`
+}
+
+// GuardrailsFor returns the Guardrails of spec.gateway.guardrails with defaults applied
+func GuardrailsFor(spec *airunwayv1alpha1.GuardrailsSpec) *Guardrails {
+	if spec == nil {
+		return nil
+	}
+	g := &Guardrails{
+		Endpoint:    spec.Endpoint,
+		Mode:        spec.Mode,
+		FailureMode: spec.FailureMode,
+		Timeout:     metav1.Duration{Duration: DefaultGuardrailsTimeout},
+	}
+	if g.Mode == "" {
+		g.Mode = airunwayv1alpha1.GuardrailsModeBlock
+	}
+	if g.FailureMode == "" {
+		g.FailureMode = airunwayv1alpha1.GuardrailsFailureModeClosed
+	}
+	if spec.Timeout != nil && spec.Timeout.Duration > 0 {
+		g.Timeout = *spec.Timeout
+	}
+	return g
+}
+
+// Screen posts text to the moderation API and returns whether any result is flagged
+func (g *Guardrails) Screen(ctx context.Context, client *http.Client, text string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, g.Timeout.Duration)
+	defer cancel()
+
+	payload, err := json.Marshal(map[string]string{"input": text})
+	if err != nil {
+		return false, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.Endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return false, fmt.Errorf("invalid moderation request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("moderation request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("moderation service returned %s", resp.Status)
+	}
+
+	var result struct {
+		Results []struct {
+			Flagged bool `json:"flagged"`
+		} `json:"results"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxModerationResponse)).Decode(&result); err != nil {
+		return false, fmt.Errorf("invalid moderation response: %w", err)
+	}
+	if len(result.Results) == 0 {
+		return false, fmt.Errorf("moderation response has no results")
+	}
+	for _, r := range result.Results {
+		if r.Flagged {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// requestText returns the text a completions or chat completions request sends to the
+// model: the prompt, or the text content of its messages
+func requestText(body []byte) string {
+	var request struct {
+		Prompt   json.RawMessage `json:"prompt"`
+		Messages []struct {
+			Content json.RawMessage `json:"content"`
+		} `json:"messages"`
+	}
+	if err := json.Unmarshal(body, &request); err != nil {
+		return ""
+	}
+	var parts []string
+	parts = append(parts, textOf(request.Prompt)...)
+	for _, m := range request.Messages {
+		parts = append(parts, textOf(m.Content)...)
+	}
+	return strings.Join(parts, "\n")
+}
+
+// textOf returns the strings of a prompt or message content, which is a string, a list of
+// strings, or a list of content parts of which only text parts are screened
+func textOf(raw json.RawMessage) []string {
+	if len(raw) == 0 {
+		return nil
+	}
+	var one string
+	if json.Unmarshal(raw, &one) == nil {
+		if one == "" {
+			return nil
+		}
+		return []string{one}
+	}
+	var items []json.RawMessage
+	if json.Unmarshal(raw, &items) != nil {
+		return nil
+	}
+	var out []string
+	for _, item := range items {
+		var part struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		}
+		if json.Unmarshal(item, &one) == nil {
+			out = append(out, one)
+		} else if json.Unmarshal(item, &part) == nil && part.Type == "text" && part.Text != "" {
+			out = append(out, part.Text)
+		}
+	}
+	return out
+}
@@ -0,0 +1,176 @@
+package promptpolicy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	extprocv3 "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+// newModerationServer returns a moderation API flagging inputs that contain "attack"
+func newModerationServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Input string `json:"input"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		flagged := req.Input == "plan an attack"
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"results": []map[string]interface{}{{"flagged": flagged}},
+		})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func requestBody(content string) *extprocv3.ProcessingRequest {
+	body, _ := json.Marshal(map[string]interface{}{
+		"messages": []map[string]string{{"role": "user", "content": content}},
+	})
+	return &extprocv3.ProcessingRequest{Request: &extprocv3.ProcessingRequest_RequestBody{
+		RequestBody: &extprocv3.HttpBody{Body: body, EndOfStream: true},
+	}}
+}
+
+func guardrailsHeader(resp *extprocv3.ProcessingResponse) string {
+	for _, h := range resp.GetRequestBody().GetResponse().GetHeaderMutation().GetSetHeaders() {
+		if h.GetHeader().GetKey() == GuardrailsHeader {
+			return string(h.GetHeader().GetRawValue())
+		}
+	}
+	return ""
+}
+
+func TestGuardrailsFor(t *testing.T) {
+	g := GuardrailsFor(&airunwayv1alpha1.GuardrailsSpec{Endpoint: "http://moderation/v1/moderations"})
+	if g.Mode != airunwayv1alpha1.GuardrailsModeBlock || g.FailureMode != airunwayv1alpha1.GuardrailsFailureModeClosed ||
+		g.Timeout.Duration != DefaultGuardrailsTimeout {
+		t.Errorf("expected block, closed, and the default timeout, got %+v", g)
+	}
+	if GuardrailsFor(nil) != nil {
+		t.Error("expected no guardrails")
+	}
+}
+
+func TestRequestText(t *testing.T) {
+	tests := []struct {
+		body string
+		want string
+	}{
+		{`{"prompt":"hello"}`, "hello"},
+		{`{"prompt":["a","b"]}`, "a\nb"},
+		{`{"messages":[{"role":"system","content":"be nice"},{"role":"user","content":[{"type":"text","text":"hi"},{"type":"image_url","image_url":{"url":"x"}}]}]}`, "be nice\nhi"},
+		{`not json`, ""},
+	}
+	for _, tt := range tests {
+		if got := requestText([]byte(tt.body)); got != tt.want {
+			t.Errorf("requestText(%s) = %q, want %q", tt.body, got, tt.want)
+		}
+	}
+}
+
+func TestHandle_GuardrailsBlock(t *testing.T) {
+	srv := newModerationServer(t)
+	s := &Server{Policy: Policy{Guardrails: GuardrailsFor(&airunwayv1alpha1.GuardrailsSpec{Endpoint: srv.URL})}}
+
+	resp, err := s.handle(context.Background(), requestBody("plan an attack"), &exchange{})
+	if err != nil {
+		t.Fatalf("handle: %v", err)
+	}
+	immediate := resp.GetImmediateResponse()
+	if immediate == nil || immediate.GetStatus().GetCode() != http.StatusBadRequest {
+		t.Fatalf("expected a 400 immediate response, got %v", resp)
+	}
+	var body struct {
+		Error struct {
+			Code string `json:"code"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(immediate.GetBody(), &body); err != nil || body.Error.Code != "content_filter" {
+		t.Errorf("expected content_filter error, got %s", immediate.GetBody())
+	}
+
+	resp, err = s.handle(context.Background(), requestBody("plan a picnic"), &exchange{})
+	if err != nil {
+		t.Fatalf("handle: %v", err)
+	}
+	if resp.GetRequestBody() == nil || guardrailsHeader(resp) != "" {
+		t.Errorf("expected the request to pass unmarked, got %v", resp)
+	}
+}
+
+func TestHandle_GuardrailsFlag(t *testing.T) {
+	srv := newModerationServer(t)
+	s := &Server{Policy: Policy{
+		MaxTokens: 64,
+		Guardrails: GuardrailsFor(&airunwayv1alpha1.GuardrailsSpec{
+			Endpoint: srv.URL,
+			Mode:     airunwayv1alpha1.GuardrailsModeFlag,
+		}),
+	}}
+	resp, err := s.handle(context.Background(), requestBody("plan an attack"), &exchange{})
+	if err != nil {
+		t.Fatalf("handle: %v", err)
+	}
+	if got := guardrailsHeader(resp); got != "flagged" {
+		t.Errorf("expected %s: flagged, got %q", GuardrailsHeader, got)
+	}
+	if resp.GetRequestBody().GetResponse().GetBodyMutation() == nil {
+		t.Error("expected the prompt policy to still apply to flagged requests")
+	}
+}
+
+func TestHandle_GuardrailsFailureMode(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "overloaded", http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(srv.Close)
+
+	closed := &Server{Policy: Policy{Guardrails: GuardrailsFor(&airunwayv1alpha1.GuardrailsSpec{Endpoint: srv.URL})}}
+	resp, err := closed.handle(context.Background(), requestBody("hello"), &exchange{})
+	if err != nil {
+		t.Fatalf("handle: %v", err)
+	}
+	if resp.GetImmediateResponse().GetStatus().GetCode() != http.StatusServiceUnavailable {
+		t.Errorf("expected a 503 immediate response when failing closed, got %v", resp)
+	}
+
+	open := &Server{Policy: Policy{Guardrails: GuardrailsFor(&airunwayv1alpha1.GuardrailsSpec{
+		Endpoint:    srv.URL,
+		FailureMode: airunwayv1alpha1.GuardrailsFailureModeOpen,
+	})}}
+	resp, err = open.handle(context.Background(), requestBody("hello"), &exchange{})
+	if err != nil {
+		t.Fatalf("handle: %v", err)
+	}
+	if got := guardrailsHeader(resp); got != "unavailable" {
+		t.Errorf("expected %s: unavailable when failing open, got %q", GuardrailsHeader, got)
+	}
+}
+
+func TestScreen_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	t.Cleanup(srv.Close)
+	t.Cleanup(func() { close(release) })
+	g := GuardrailsFor(&airunwayv1alpha1.GuardrailsSpec{
+		Endpoint: srv.URL,
+		Timeout:  &metav1.Duration{Duration: 50 * time.Millisecond},
+	})
+	if _, err := g.Screen(context.Background(), http.DefaultClient, "hello"); err == nil {
+		t.Error("expected the moderation request to time out")
+	}
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package promptpolicy implements the Envoy external processor that applies the
+// spec.gateway.promptPolicy of a ModelDeployment to OpenAI-compatible request bodies, so
+// platform teams can enforce a system prompt, a max tokens cap, and stop sequences without
+// changing clients. It also screens requests with the moderation service of
+// spec.gateway.guardrails, answers repeated requests from the cache of
+// spec.gateway.responseCache, and records the OpenTelemetry GenAI metrics of
+// spec.observability.genAIMetrics.
+package promptpolicy
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+// Policy is the prompt policy applied to requests, stored as JSON in the ConfigMap
+// mounted into the external processor.
+type Policy struct {
+	// SystemPrompt is prepended to the messages of chat completion requests
+	SystemPrompt string `json:"systemPrompt,omitempty"`
+	// MaxTokens caps max_tokens and max_completion_tokens, and is set when neither is
+	MaxTokens int32 `json:"maxTokens,omitempty"`
+	// Stop sequences are added to the stop sequences of every request
+	Stop []string `json:"stop,omitempty"`
+	// Guardrails screens requests before the policy is applied
+	Guardrails *Guardrails `json:"guardrails,omitempty"`
+	// ResponseCache answers repeated requests, after the policy is applied, from a cache
+	ResponseCache *ResponseCache `json:"responseCache,omitempty"`
+	// GenAIMetrics records the GenAI metrics of requests
+	GenAIMetrics *GenAIMetrics `json:"genAIMetrics,omitempty"`
+}
+
+// PolicyFor returns the Policy of spec.gateway.promptPolicy, spec.gateway.guardrails and
+// spec.gateway.responseCache
+func PolicyFor(gw *airunwayv1alpha1.GatewaySpec) Policy {
+	if gw == nil {
+		return Policy{}
+	}
+	p := Policy{Guardrails: GuardrailsFor(gw.Guardrails), ResponseCache: ResponseCacheFor(gw.ResponseCache)}
+	if spec := gw.PromptPolicy; spec != nil {
+		p.SystemPrompt = spec.SystemPrompt
+		p.Stop = spec.Stop
+		if spec.MaxTokens != nil {
+			p.MaxTokens = *spec.MaxTokens
+		}
+	}
+	return p
+}
+
+// Load reads a Policy from a JSON file
+func Load(path string) (Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Policy{}, fmt.Errorf("failed to read prompt policy: %w", err)
+	}
+	var p Policy
+	if err := json.Unmarshal(data, &p); err != nil {
+		return Policy{}, fmt.Errorf("invalid prompt policy %s: %w", path, err)
+	}
+	return p, nil
+}
+
+// Apply returns body with the policy applied and whether it changed. Bodies that are not
+// JSON objects are returned unchanged for the model server to reject. Fields the policy
+// does not touch are kept as sent.
+func (p Policy) Apply(body []byte) ([]byte, bool, error) {
+	var request map[string]json.RawMessage
+	if err := json.Unmarshal(body, &request); err != nil || request == nil {
+		return body, false, nil
+	}
+
+	changed := false
+	set := func(key string, value interface{}) error {
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		request[key] = raw
+		changed = true
+		return nil
+	}
+
+	// Only chat completions have messages; legacy completions take a raw prompt
+	if p.SystemPrompt != "" {
+		var messages []json.RawMessage
+		if raw, ok := request["messages"]; ok && json.Unmarshal(raw, &messages) == nil {
+			system, err := json.Marshal(map[string]string{"role": "system", "content": p.SystemPrompt})
+			if err != nil {
+				return nil, false, err
+			}
+			if err := set("messages", append([]json.RawMessage{system}, messages...)); err != nil {
+				return nil, false, err
+			}
+		}
+	}
+
+	if p.MaxTokens > 0 {
+		capped := false
+		for _, key := range []string{"max_tokens", "max_completion_tokens"} {
+			raw, ok := request[key]
+			if !ok {
+				continue
+			}
+			var n *float64
+			if json.Unmarshal(raw, &n) == nil && n != nil && *n <= float64(p.MaxTokens) {
+				capped = true
+				continue
+			}
+			if err := set(key, p.MaxTokens); err != nil {
+				return nil, false, err
+			}
+			capped = true
+		}
+		if !capped {
+			if err := set("max_tokens", p.MaxTokens); err != nil {
+				return nil, false, err
+			}
+		}
+	}
+
+	if len(p.Stop) > 0 {
+		var stop []string
+		if raw, ok := request["stop"]; ok {
+			var one string
+			if json.Unmarshal(raw, &one) == nil {
+				stop = []string{one}
+			} else {
+				_ = json.Unmarshal(raw, &stop)
+			}
+		}
+		added := false
+		for _, s := range p.Stop {
+			if !slices.Contains(stop, s) {
+				stop = append(stop, s)
+				added = true
+			}
+		}
+		if added {
+			if err := set("stop", stop); err != nil {
+				return nil, false, err
+			}
+		}
+	}
+
+	if !changed {
+		return body, false, nil
+	}
+	out, err := json.Marshal(request)
+	if err != nil {
+		return nil, false, err
+	}
+	return out, true, nil
+}
@@ -0,0 +1,139 @@
+package promptpolicy
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+func applyJSON(t *testing.T, p Policy, body string) (map[string]interface{}, bool) {
+	t.Helper()
+	out, changed, err := p.Apply([]byte(body))
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal(out, &got); err != nil {
+		t.Fatalf("invalid output %s: %v", out, err)
+	}
+	return got, changed
+}
+
+func TestApply_SystemPrompt(t *testing.T) {
+	p := Policy{SystemPrompt: "Be concise."}
+	got, changed := applyJSON(t, p, `{"model":"llama","messages":[{"role":"user","content":"hi"}]}`)
+	if !changed {
+		t.Fatal("expected body to change")
+	}
+	messages := got["messages"].([]interface{})
+	if len(messages) != 2 {
+		t.Fatalf("expected 2 messages, got %v", messages)
+	}
+	first := messages[0].(map[string]interface{})
+	if first["role"] != "system" || first["content"] != "Be concise." {
+		t.Errorf("expected system message first, got %v", first)
+	}
+	if got["model"] != "llama" {
+		t.Errorf("expected model to be kept, got %v", got["model"])
+	}
+
+	// Completions requests have no messages to prepend to
+	_, changed, _ = p.Apply([]byte(`{"model":"llama","prompt":"hi"}`))
+	if changed {
+		t.Error("expected completions request to be unchanged")
+	}
+}
+
+func TestApply_MaxTokens(t *testing.T) {
+	p := Policy{MaxTokens: 256}
+	tests := []struct {
+		name    string
+		body    string
+		want    map[string]float64
+		changed bool
+	}{
+		{"unset", `{"prompt":"hi"}`, map[string]float64{"max_tokens": 256}, true},
+		{"above cap", `{"max_tokens":1024}`, map[string]float64{"max_tokens": 256}, true},
+		{"below cap", `{"max_tokens":16}`, map[string]float64{"max_tokens": 16}, false},
+		{"completion tokens", `{"max_completion_tokens":4096}`, map[string]float64{"max_completion_tokens": 256}, true},
+		{"null", `{"max_tokens":null}`, map[string]float64{"max_tokens": 256}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, changed := applyJSON(t, p, tt.body)
+			if changed != tt.changed {
+				t.Errorf("expected changed %v, got %v", tt.changed, changed)
+			}
+			for key, want := range tt.want {
+				if got[key] != want {
+					t.Errorf("expected %s %v, got %v", key, want, got[key])
+				}
+			}
+		})
+	}
+}
+
+func TestApply_Stop(t *testing.T) {
+	p := Policy{Stop: []string{"</answer>"}}
+	tests := []struct {
+		name string
+		body string
+		want []interface{}
+	}{
+		{"unset", `{}`, []interface{}{"</answer>"}},
+		{"string", `{"stop":"\n\n"}`, []interface{}{"\n\n", "</answer>"}},
+		{"array", `{"stop":["END","</answer>"]}`, []interface{}{"END", "</answer>"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _ := applyJSON(t, p, tt.body)
+			if !reflect.DeepEqual(got["stop"], tt.want) {
+				t.Errorf("expected stop %v, got %v", tt.want, got["stop"])
+			}
+		})
+	}
+}
+
+func TestApply_NonJSONBody(t *testing.T) {
+	p := Policy{SystemPrompt: "Be concise.", MaxTokens: 256}
+	for _, body := range []string{"not json", `["a"]`, "null", ""} {
+		out, changed, err := p.Apply([]byte(body))
+		if err != nil || changed || string(out) != body {
+			t.Errorf("expected %q to pass through unchanged, got %q, %v, %v", body, out, changed, err)
+		}
+	}
+}
+
+func TestPolicyForAndLoad(t *testing.T) {
+	maxTokens := int32(128)
+	p := PolicyFor(&airunwayv1alpha1.GatewaySpec{
+		PromptPolicy: &airunwayv1alpha1.PromptPolicySpec{SystemPrompt: "Be concise.", MaxTokens: &maxTokens, Stop: []string{"END"}},
+		Guardrails:   &airunwayv1alpha1.GuardrailsSpec{Endpoint: "http://moderation:8000/v1/moderations"},
+	})
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "policy.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if !reflect.DeepEqual(loaded, p) {
+		t.Errorf("expected %+v, got %+v", p, loaded)
+	}
+
+	if err := os.WriteFile(path, []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("expected error for invalid policy")
+	}
+}
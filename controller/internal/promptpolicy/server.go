@@ -0,0 +1,310 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package promptpolicy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	extprocv3 "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/go-logr/logr"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+// Server is the Envoy external processor applying Policy to request bodies. Envoy must
+// send the request body in buffered mode, so each body message holds the whole body, and
+// the response body in buffered mode too when Policy.ResponseCache is set. With
+// Policy.GenAIMetrics, Envoy sends the response headers and body, streamed unless the
+// response cache is set, to record Metrics.
+type Server struct {
+	extprocv3.UnimplementedExternalProcessorServer
+
+	Policy Policy
+
+	// Client calls the moderation API of Policy.Guardrails. Nil means http.DefaultClient.
+	Client *http.Client
+
+	// Metrics records the GenAI metrics of requests. Nil means they are not recorded.
+	Metrics *Metrics
+
+	// Log receives the errors of requests that are forwarded or rejected. The zero value
+	// discards them.
+	Log logr.Logger
+
+	storeOnce sync.Once
+	store     *responseStore
+}
+
+// exchange is the state of the HTTP request of one stream
+type exchange struct {
+	// path is the :path of the request
+	path string
+	// cacheKey is the key the response is cached under, empty when it is not cached
+	cacheKey string
+	// op is the metrics state of the request, nil when it is not recorded
+	op *operation
+}
+
+// Process handles the messages of one HTTP request
+func (s *Server) Process(stream extprocv3.ExternalProcessor_ProcessServer) error {
+	ex := &exchange{}
+	// Requests that end before their response, e.g. when the client disconnects, are
+	// recorded as canceled
+	defer s.finish(ex, "canceled")
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) || status.Code(err) == codes.Canceled {
+			return nil
+		}
+		if err != nil {
+			return status.Errorf(codes.Unknown, "cannot receive stream request: %v", err)
+		}
+
+		resp, err := s.handle(stream.Context(), req, ex)
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(resp); err != nil {
+			return status.Errorf(codes.Unknown, "cannot send stream response: %v", err)
+		}
+	}
+}
+
+// handle returns the response to one message, continuing every phase but the request
+// body unchanged, apart from the response cache
+func (s *Server) handle(ctx context.Context, req *extprocv3.ProcessingRequest, ex *exchange) (*extprocv3.ProcessingResponse, error) {
+	switch req.Request.(type) {
+	case *extprocv3.ProcessingRequest_RequestHeaders:
+		ex.path = headerValue(req.GetRequestHeaders().GetHeaders(), ":path")
+		if s.Metrics != nil {
+			ex.op = s.Metrics.start(ex.path)
+		}
+		return &extprocv3.ProcessingResponse{Response: &extprocv3.ProcessingResponse_RequestHeaders{
+			RequestHeaders: &extprocv3.HeadersResponse{},
+		}}, nil
+	case *extprocv3.ProcessingRequest_RequestBody:
+		resp, err := s.handleRequestBody(ctx, req.GetRequestBody().GetBody(), ex)
+		if ex.op != nil {
+			// Requests answered by the processor itself never reach the model
+			if err != nil || resp.GetImmediateResponse() != nil {
+				ex.op = nil
+			} else {
+				ex.op.request(req.GetRequestBody().GetBody())
+			}
+		}
+		return resp, err
+	case *extprocv3.ProcessingRequest_RequestTrailers:
+		return &extprocv3.ProcessingResponse{Response: &extprocv3.ProcessingResponse_RequestTrailers{
+			RequestTrailers: &extprocv3.TrailersResponse{},
+		}}, nil
+	case *extprocv3.ProcessingRequest_ResponseHeaders:
+		headers := &extprocv3.HeadersResponse{}
+		if ex.op != nil {
+			h := req.GetResponseHeaders()
+			ex.op.responseHeaders(headerValue(h.GetHeaders(), ":status"), headerValue(h.GetHeaders(), "content-type"))
+			if h.GetEndOfStream() {
+				s.finish(ex, "")
+			}
+		}
+		if ex.cacheKey != "" {
+			// Only successful responses are cached
+			if headerValue(req.GetResponseHeaders().GetHeaders(), ":status") != "200" {
+				ex.cacheKey = ""
+			} else {
+				headers.Response = &extprocv3.CommonResponse{HeaderMutation: &extprocv3.HeaderMutation{
+					SetHeaders: []*corev3.HeaderValueOption{setHeader(CacheHeader, "miss")},
+				}}
+			}
+		}
+		return &extprocv3.ProcessingResponse{Response: &extprocv3.ProcessingResponse_ResponseHeaders{
+			ResponseHeaders: headers,
+		}}, nil
+	case *extprocv3.ProcessingRequest_ResponseBody:
+		if ex.cacheKey != "" && req.GetResponseBody().GetEndOfStream() {
+			s.responseStore().put(ex.cacheKey, bytes.Clone(req.GetResponseBody().GetBody()))
+			ex.cacheKey = ""
+		}
+		if ex.op != nil {
+			body := req.GetResponseBody()
+			ex.op.responseBody(s.Metrics, body.GetBody(), body.GetEndOfStream())
+			if body.GetEndOfStream() {
+				s.finish(ex, "")
+			}
+		}
+		return &extprocv3.ProcessingResponse{Response: &extprocv3.ProcessingResponse_ResponseBody{
+			ResponseBody: &extprocv3.BodyResponse{},
+		}}, nil
+	case *extprocv3.ProcessingRequest_ResponseTrailers:
+		s.finish(ex, "")
+		return &extprocv3.ProcessingResponse{Response: &extprocv3.ProcessingResponse_ResponseTrailers{
+			ResponseTrailers: &extprocv3.TrailersResponse{},
+		}}, nil
+	}
+	return nil, status.Error(codes.Unimplemented, "unknown request type")
+}
+
+// handleRequestBody screens a buffered request body with the guardrails, then applies the
+// policy to it. A changed body is replaced along with its Content-Length. With the response
+// cache, a request answered before is answered from the cache instead of the model.
+func (s *Server) handleRequestBody(ctx context.Context, body []byte, ex *exchange) (*extprocv3.ProcessingResponse, error) {
+	var headers []*corev3.HeaderValueOption
+	if g := s.Policy.Guardrails; g != nil {
+		verdict, rejected := s.screen(ctx, g, body)
+		if rejected != nil {
+			return rejected, nil
+		}
+		if verdict != "" {
+			headers = append(headers, setHeader(GuardrailsHeader, verdict))
+		}
+	}
+
+	out, changed, err := s.Policy.Apply(body)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot apply prompt policy: %v", err)
+	}
+	if store := s.responseStore(); store != nil {
+		if key := cacheKey(ex.path, out, s.Policy.ResponseCache.NormalizeWhitespace); key != "" {
+			if cached, ok := store.get(key); ok {
+				return cachedResponse(cached), nil
+			}
+			ex.cacheKey = key
+		}
+	}
+	common := &extprocv3.CommonResponse{}
+	if changed {
+		headers = append(headers, setHeader("Content-Length", strconv.Itoa(len(out))))
+		common.BodyMutation = &extprocv3.BodyMutation{Mutation: &extprocv3.BodyMutation_Body{Body: out}}
+	}
+	if len(headers) > 0 {
+		common.HeaderMutation = &extprocv3.HeaderMutation{SetHeaders: headers}
+	}
+	return &extprocv3.ProcessingResponse{Response: &extprocv3.ProcessingResponse_RequestBody{
+		RequestBody: &extprocv3.BodyResponse{Response: common},
+	}}, nil
+}
+
+// screen returns the response rejecting the request, or the GuardrailsHeader value of a
+// forwarded request, empty when it passed. Requests without text are not screened.
+func (s *Server) screen(ctx context.Context, g *Guardrails, body []byte) (string, *extprocv3.ProcessingResponse) {
+	text := requestText(body)
+	if text == "" {
+		return "", nil
+	}
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	flagged, err := g.Screen(ctx, client, text)
+	switch {
+	case err != nil && g.FailureMode == airunwayv1alpha1.GuardrailsFailureModeOpen:
+		s.Log.Info("forwarding unscreened request", "error", err)
+		return "unavailable", nil
+	case err != nil:
+		s.Log.Error(err, "rejecting unscreened request")
+		return "", errorResponse(typev3.StatusCode_ServiceUnavailable, "server_error", "guardrails_unavailable",
+			"The request could not be screened by content guardrails.")
+	case flagged && g.Mode == airunwayv1alpha1.GuardrailsModeFlag:
+		return "flagged", nil
+	case flagged:
+		return "", errorResponse(typev3.StatusCode_BadRequest, "invalid_request_error", "content_filter",
+			"The request was blocked by content guardrails.")
+	}
+	return "", nil
+}
+
+// finish records the metrics of the request of ex, if they have not been recorded yet,
+// with errorType overriding the error type of the response when set
+func (s *Server) finish(ex *exchange, errorType string) {
+	if ex.op == nil {
+		return
+	}
+	provider := ""
+	if s.Policy.GenAIMetrics != nil {
+		provider = s.Policy.GenAIMetrics.ProviderName
+	}
+	s.Metrics.record(ex.op, provider, errorType, s.Policy.ResponseCache != nil)
+	ex.op = nil
+}
+
+// responseStore returns the response cache of the policy, or nil without one
+func (s *Server) responseStore() *responseStore {
+	s.storeOnce.Do(func() {
+		if s.Policy.ResponseCache != nil {
+			s.store = newResponseStore(s.Policy.ResponseCache)
+		}
+	})
+	return s.store
+}
+
+// cachedResponse returns an immediate response with a cached response body
+func cachedResponse(body []byte) *extprocv3.ProcessingResponse {
+	return &extprocv3.ProcessingResponse{Response: &extprocv3.ProcessingResponse_ImmediateResponse{
+		ImmediateResponse: &extprocv3.ImmediateResponse{
+			Status: &typev3.HttpStatus{Code: typev3.StatusCode_OK},
+			Headers: &extprocv3.HeaderMutation{SetHeaders: []*corev3.HeaderValueOption{
+				setHeader("Content-Type", "application/json"),
+				setHeader(CacheHeader, "hit"),
+			}},
+			Body: body,
+		},
+	}}
+}
+
+// headerValue returns the value of a header sent by Envoy, which sets either the value or
+// the raw value
+func headerValue(headers *corev3.HeaderMap, key string) string {
+	for _, h := range headers.GetHeaders() {
+		if h.GetKey() == key {
+			if h.GetValue() != "" {
+				return h.GetValue()
+			}
+			return string(h.GetRawValue())
+		}
+	}
+	return ""
+}
+
+// errorResponse returns an immediate response with an OpenAI-style error body
+func errorResponse(code typev3.StatusCode, errType, errCode, message string) *extprocv3.ProcessingResponse {
+	// A map of strings always marshals
+	body, _ := json.Marshal(map[string]map[string]string{
+		"error": {"message": message, "type": errType, "code": errCode},
+	})
+	return &extprocv3.ProcessingResponse{Response: &extprocv3.ProcessingResponse_ImmediateResponse{
+		ImmediateResponse: &extprocv3.ImmediateResponse{
+			Status:  &typev3.HttpStatus{Code: code},
+			Headers: &extprocv3.HeaderMutation{SetHeaders: []*corev3.HeaderValueOption{setHeader("Content-Type", "application/json")}},
+			Body:    body,
+		},
+	}}
+}
+
+// setHeader returns the mutation setting a header
+func setHeader(key, value string) *corev3.HeaderValueOption {
+	return &corev3.HeaderValueOption{Header: &corev3.HeaderValue{Key: key, RawValue: []byte(value)}}
+}
@@ -0,0 +1,53 @@
+package promptpolicy
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	extprocv3 "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+)
+
+func TestHandle_RequestBody(t *testing.T) {
+	s := &Server{Policy: Policy{MaxTokens: 64}}
+	resp, err := s.handle(context.Background(), &extprocv3.ProcessingRequest{Request: &extprocv3.ProcessingRequest_RequestBody{
+		RequestBody: &extprocv3.HttpBody{Body: []byte(`{"prompt":"hi"}`), EndOfStream: true},
+	}}, &exchange{})
+	if err != nil {
+		t.Fatalf("handle: %v", err)
+	}
+	common := resp.GetRequestBody().GetResponse()
+	body := common.GetBodyMutation().GetBody()
+	if string(body) != `{"max_tokens":64,"prompt":"hi"}` {
+		t.Errorf("unexpected body %s", body)
+	}
+	headers := common.GetHeaderMutation().GetSetHeaders()
+	if len(headers) != 1 || headers[0].GetHeader().GetKey() != "Content-Length" ||
+		string(headers[0].GetHeader().GetRawValue()) != strconv.Itoa(len(body)) {
+		t.Errorf("expected Content-Length %d, got %v", len(body), headers)
+	}
+
+	// An unchanged body is continued without mutations
+	resp, err = s.handle(context.Background(), &extprocv3.ProcessingRequest{Request: &extprocv3.ProcessingRequest_RequestBody{
+		RequestBody: &extprocv3.HttpBody{Body: []byte(`{"max_tokens":8}`), EndOfStream: true},
+	}}, &exchange{})
+	if err != nil {
+		t.Fatalf("handle: %v", err)
+	}
+	if common := resp.GetRequestBody().GetResponse(); common.GetBodyMutation() != nil || common.GetHeaderMutation() != nil {
+		t.Errorf("expected no mutations, got %v", common)
+	}
+}
+
+func TestHandle_OtherPhases(t *testing.T) {
+	s := &Server{Policy: Policy{SystemPrompt: "Be concise."}}
+	resp, err := s.handle(context.Background(), &extprocv3.ProcessingRequest{Request: &extprocv3.ProcessingRequest_RequestHeaders{
+		RequestHeaders: &extprocv3.HttpHeaders{},
+	}}, &exchange{})
+	if err != nil || resp.GetRequestHeaders() == nil {
+		t.Errorf("expected request headers to be continued, got %v, %v", resp, err)
+	}
+	if _, err := s.handle(context.Background(), &extprocv3.ProcessingRequest{}, &exchange{}); err == nil {
+		t.Error("expected error for unknown request type")
+	}
+}
@@ -0,0 +1,273 @@
+package recommender
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DefaultDCGMExporterPort is the metrics port of the NVIDIA DCGM exporter.
+	DefaultDCGMExporterPort = 9400
+
+	// dcgmFramebufferUsedMetric is the GPU framebuffer memory in use, in MiB.
+	dcgmFramebufferUsedMetric = "DCGM_FI_DEV_FB_USED"
+
+	// dcgmUtilizationMetric is the GPU utilization, in percent.
+	dcgmUtilizationMetric = "DCGM_FI_DEV_GPU_UTIL"
+
+	// maxDCGMResponseBytes bounds how much of an exporter response is read.
+	maxDCGMResponseBytes = 16 * 1024 * 1024
+)
+
+// DefaultDCGMExporterSelector matches the exporter pods deployed by the NVIDIA GPU Operator.
+var DefaultDCGMExporterSelector = map[string]string{"app": "nvidia-dcgm-exporter"}
+
+// DCGMSource samples GPU memory usage from NVIDIA DCGM exporter pods. The exporter
+// runs as a DaemonSet, so each model pod is looked up on the exporter running on
+// its node. The exporter must attribute GPUs to pods (the GPU Operator default).
+type DCGMSource struct {
+	Reader client.Reader
+	// Namespace is where the exporter pods run.
+	Namespace string
+	// Selector matches the exporter pods. Defaults to DefaultDCGMExporterSelector.
+	Selector map[string]string
+	// Port is the exporter metrics port. Defaults to DefaultDCGMExporterPort.
+	Port int32
+	// HTTPClient is used to scrape exporters. Defaults to a client with a 10s timeout.
+	HTTPClient *http.Client
+}
+
+// Name implements UsageSource.
+func (s *DCGMSource) Name() string {
+	return "dcgm"
+}
+
+// Sample implements UsageSource. Only GPUMemory is reported; framebuffer usage
+// of all GPUs attached to a pod is summed.
+func (s *DCGMSource) Sample(ctx context.Context, pods []corev1.Pod) (Usage, error) {
+	stats, err := s.sampleGPUs(ctx, pods)
+	if err != nil {
+		return Usage{}, err
+	}
+	var peak Usage
+	for _, pod := range stats {
+		peak = peak.Max(Usage{GPUMemory: *resource.NewQuantity(pod.framebufferMiB*1024*1024, resource.BinarySI)})
+	}
+	return peak, nil
+}
+
+// SampleGPUUtilization implements GPUUtilizationSource. The utilization of all GPUs
+// attached to the pods is averaged.
+func (s *DCGMSource) SampleGPUUtilization(ctx context.Context, pods []corev1.Pod) (GPUUtilization, error) {
+	stats, err := s.sampleGPUs(ctx, pods)
+	if err != nil {
+		return GPUUtilization{}, err
+	}
+	var total float64
+	var util GPUUtilization
+	for _, pod := range stats {
+		total += pod.utilization
+		util.GPUs += pod.gpus
+	}
+	if util.GPUs > 0 {
+		util.Percent = total / float64(util.GPUs)
+	}
+	return util, nil
+}
+
+// SampleGPUDevices implements GPUDeviceSource.
+func (s *DCGMSource) SampleGPUDevices(ctx context.Context, pods []corev1.Pod) (map[string][]string, error) {
+	stats, err := s.sampleGPUs(ctx, pods)
+	if err != nil {
+		return nil, err
+	}
+	devices := make(map[string][]string, len(stats))
+	for key, pod := range stats {
+		if len(pod.devices) > 0 {
+			devices[key] = pod.devices
+		}
+	}
+	return devices, nil
+}
+
+// sampleGPUs scrapes the exporters on the nodes of pods and returns the GPU stats of
+// pods, keyed by "namespace/pod". Pods without attributed GPUs are left out.
+func (s *DCGMSource) sampleGPUs(ctx context.Context, pods []corev1.Pod) (map[string]gpuStats, error) {
+	wanted := make(map[string]bool, len(pods))
+	nodes := make(map[string]bool)
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" {
+			continue
+		}
+		wanted[pod.Namespace+"/"+pod.Name] = true
+		nodes[pod.Spec.NodeName] = true
+	}
+	if len(nodes) == 0 {
+		return nil, nil
+	}
+
+	selector := s.Selector
+	if len(selector) == 0 {
+		selector = DefaultDCGMExporterSelector
+	}
+	var exporters corev1.PodList
+	if err := s.Reader.List(ctx, &exporters, client.InNamespace(s.Namespace), client.MatchingLabels(selector)); err != nil {
+		return nil, fmt.Errorf("failed to list DCGM exporter pods: %w", err)
+	}
+
+	stats := make(map[string]gpuStats)
+	scraped := 0
+	for _, exporter := range exporters.Items {
+		if !nodes[exporter.Spec.NodeName] || exporter.Status.PodIP == "" || exporter.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		exported, err := s.scrape(ctx, exporter.Status.PodIP)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scrape DCGM exporter %s: %w", exporter.Name, err)
+		}
+		scraped++
+		for key, pod := range exported {
+			if wanted[key] {
+				stats[key] = pod
+			}
+		}
+	}
+	if scraped == 0 {
+		return nil, fmt.Errorf("no DCGM exporter found in namespace %s on the model nodes", s.Namespace)
+	}
+	return stats, nil
+}
+
+// scrape returns the GPU stats exported by an exporter, keyed by "namespace/pod".
+func (s *DCGMSource) scrape(ctx context.Context, podIP string) (map[string]gpuStats, error) {
+	port := s.Port
+	if port == 0 {
+		port = DefaultDCGMExporterPort
+	}
+	httpClient := s.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	url := "http://" + net.JoinHostPort(podIP, strconv.Itoa(int(port))) + "/metrics"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return parseDCGM(io.LimitReader(resp.Body, maxDCGMResponseBytes))
+}
+
+// gpuStats are the DCGM metrics of the GPUs attached to a pod.
+type gpuStats struct {
+	// framebufferMiB is the framebuffer memory in use, summed across GPUs.
+	framebufferMiB int64
+	// utilization is the utilization in percent, summed across GPUs.
+	utilization float64
+	// gpus is the number of GPUs reporting utilization.
+	gpus int
+	// devices are the indices of the GPUs, in exposition order.
+	devices []string
+}
+
+// parseDCGM extracts DCGM_FI_DEV_FB_USED and DCGM_FI_DEV_GPU_UTIL samples from
+// Prometheus text exposition format, summed per pod.
+func parseDCGM(r io.Reader) (map[string]gpuStats, error) {
+	stats := make(map[string]gpuStats)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		metric, rest, ok := strings.Cut(line, "{")
+		if !ok || (metric != dcgmFramebufferUsedMetric && metric != dcgmUtilizationMetric) {
+			continue
+		}
+		end := strings.LastIndex(rest, "}")
+		if end < 0 {
+			continue
+		}
+		labels := parseLabels(rest[:end])
+		if labels["pod"] == "" {
+			continue // GPU not attributed to a pod
+		}
+		fields := strings.Fields(rest[end+1:])
+		if len(fields) == 0 {
+			continue
+		}
+		value, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			continue
+		}
+		key := labels["namespace"] + "/" + labels["pod"]
+		pod := stats[key]
+		if gpu := labels["gpu"]; gpu != "" && !slices.Contains(pod.devices, gpu) {
+			pod.devices = append(pod.devices, gpu)
+		}
+		if metric == dcgmFramebufferUsedMetric {
+			pod.framebufferMiB += int64(value)
+		} else {
+			pod.utilization += value
+			pod.gpus++
+		}
+		stats[key] = pod
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// parseLabels parses a Prometheus label set such as `a="1",b="x\"y"`.
+func parseLabels(s string) map[string]string {
+	labels := make(map[string]string)
+	for len(s) > 0 {
+		eq := strings.Index(s, "=")
+		if eq < 0 || eq+1 >= len(s) || s[eq+1] != '"' {
+			break
+		}
+		name := strings.TrimSpace(strings.TrimPrefix(s[:eq], ","))
+		var value strings.Builder
+		i := eq + 2
+		for ; i < len(s); i++ {
+			if s[i] == '\\' && i+1 < len(s) {
+				i++
+				switch s[i] {
+				case 'n':
+					value.WriteByte('\n')
+				default:
+					value.WriteByte(s[i])
+				}
+				continue
+			}
+			if s[i] == '"' {
+				break
+			}
+			value.WriteByte(s[i])
+		}
+		labels[name] = value.String()
+		if i >= len(s) {
+			break
+		}
+		s = strings.TrimPrefix(s[i+1:], ",")
+	}
+	return labels
+}
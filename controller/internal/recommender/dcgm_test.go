@@ -0,0 +1,115 @@
+package recommender
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const dcgmOutput = `# HELP DCGM_FI_DEV_FB_USED Framebuffer memory used (in MiB).
+# TYPE DCGM_FI_DEV_FB_USED gauge
+DCGM_FI_DEV_FB_USED{gpu="0",UUID="GPU-a",Hostname="node-a",container="main",namespace="default",pod="model-0"} 30000
+DCGM_FI_DEV_FB_USED{gpu="1",UUID="GPU-b",Hostname="node-a",container="main",namespace="default",pod="model-0"} 20000
+DCGM_FI_DEV_FB_USED{gpu="2",UUID="GPU-c",Hostname="node-a",container="main",namespace="other",pod="model-0"} 70000
+DCGM_FI_DEV_FB_USED{gpu="3",UUID="GPU-d",Hostname="node-a"} 1000
+DCGM_FI_DEV_FB_FREE{gpu="0",UUID="GPU-a",Hostname="node-a",container="main",namespace="default",pod="model-0"} 50000
+# HELP DCGM_FI_DEV_GPU_UTIL GPU utilization (in %).
+# TYPE DCGM_FI_DEV_GPU_UTIL gauge
+DCGM_FI_DEV_GPU_UTIL{gpu="0",UUID="GPU-a",Hostname="node-a",container="main",namespace="default",pod="model-0"} 80
+DCGM_FI_DEV_GPU_UTIL{gpu="1",UUID="GPU-b",Hostname="node-a",container="main",namespace="default",pod="model-0"} 20
+DCGM_FI_DEV_GPU_UTIL{gpu="2",UUID="GPU-c",Hostname="node-a",container="main",namespace="other",pod="model-0"} 0
+DCGM_FI_DEV_GPU_UTIL{gpu="3",UUID="GPU-d",Hostname="node-a"} 100
+`
+
+func TestParseDCGM(t *testing.T) {
+	stats, err := parseDCGM(strings.NewReader(dcgmOutput))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := stats["default/model-0"].framebufferMiB; got != 50000 {
+		t.Errorf("expected 50000 MiB summed across GPUs, got %d", got)
+	}
+	if got := stats["other/model-0"].framebufferMiB; got != 70000 {
+		t.Errorf("expected pods to be keyed by namespace, got %d", got)
+	}
+	if got := stats["default/model-0"]; got.utilization != 100 || got.gpus != 2 {
+		t.Errorf("expected utilization of 2 GPUs summed to 100, got %+v", got)
+	}
+	if got := stats["default/model-0"].devices; !slices.Equal(got, []string{"0", "1"}) {
+		t.Errorf("expected GPUs 0 and 1, got %v", got)
+	}
+	if len(stats) != 2 {
+		t.Errorf("expected unattributed GPUs to be skipped, got %v", stats)
+	}
+}
+
+func TestParseLabels(t *testing.T) {
+	labels := parseLabels(`a="1",b="x\"y,z",c=""`)
+	if labels["a"] != "1" || labels["b"] != `x"y,z` || labels["c"] != "" || len(labels) != 3 {
+		t.Errorf("unexpected labels %v", labels)
+	}
+}
+
+func TestDCGMSourceSample(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/metrics" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = fmt.Fprint(w, dcgmOutput)
+	}))
+	defer server.Close()
+	host, portStr, _ := net.SplitHostPort(strings.TrimPrefix(server.URL, "http://"))
+	port, _ := strconv.Atoi(portStr)
+
+	exporter := func(name, node string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "gpu-operator", Labels: DefaultDCGMExporterSelector},
+			Spec:       corev1.PodSpec{NodeName: node},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning, PodIP: host},
+		}
+	}
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(exporter("dcgm-a", "node-a")).
+		Build()
+
+	source := &DCGMSource{Reader: c, Namespace: "gpu-operator", Port: int32(port)}
+	pods := []corev1.Pod{{
+		ObjectMeta: metav1.ObjectMeta{Name: "model-0", Namespace: "default"},
+		Spec:       corev1.PodSpec{NodeName: "node-a"},
+	}}
+
+	usage, err := source.Sample(context.Background(), pods)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := usage.GPUMemory.Value(); got != 50000*1024*1024 {
+		t.Errorf("expected 50000Mi of GPU memory, got %s", usage.GPUMemory.String())
+	}
+	util, err := source.SampleGPUUtilization(context.Background(), pods)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if util.GPUs != 2 || util.Percent != 50 {
+		t.Errorf("expected 2 GPUs at 50%% mean utilization, got %+v", util)
+	}
+
+	// No exporter on the model node
+	pods[0].Spec.NodeName = "node-b"
+	if _, err := source.Sample(context.Background(), pods); err == nil {
+		t.Error("expected an error when no exporter runs on the model node")
+	}
+}
@@ -0,0 +1,84 @@
+package recommender
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PodMetricsGVK is the GroupVersionKind of metrics.k8s.io PodMetrics, served by metrics-server.
+var PodMetricsGVK = schema.GroupVersionKind{Group: "metrics.k8s.io", Version: "v1beta1", Kind: "PodMetrics"}
+
+// MetricsAPISource samples CPU and memory usage from the Kubernetes resource metrics API.
+type MetricsAPISource struct {
+	// Reader must not be cached: PodMetrics do not support watches.
+	Reader client.Reader
+}
+
+// Name implements UsageSource.
+func (s *MetricsAPISource) Name() string {
+	return "metrics-api"
+}
+
+// Sample implements UsageSource. Container usage is summed per pod.
+func (s *MetricsAPISource) Sample(ctx context.Context, pods []corev1.Pod) (Usage, error) {
+	var peak Usage
+	for i := range pods {
+		pod := &pods[i]
+		pm := &unstructured.Unstructured{}
+		pm.SetGroupVersionKind(PodMetricsGVK)
+		if err := s.Reader.Get(ctx, client.ObjectKey{Namespace: pod.Namespace, Name: pod.Name}, pm); err != nil {
+			if errors.IsNotFound(err) {
+				continue // not scraped yet
+			}
+			if meta.IsNoMatchError(err) {
+				return Usage{}, fmt.Errorf("metrics API is not available: %w", err)
+			}
+			return Usage{}, fmt.Errorf("failed to get metrics for pod %s: %w", pod.Name, err)
+		}
+		usage, err := podMetricsUsage(pm)
+		if err != nil {
+			return Usage{}, fmt.Errorf("invalid metrics for pod %s: %w", pod.Name, err)
+		}
+		peak = peak.Max(usage)
+	}
+	return peak, nil
+}
+
+// podMetricsUsage sums the usage of all containers in a PodMetrics object.
+func podMetricsUsage(pm *unstructured.Unstructured) (Usage, error) {
+	containers, _, err := unstructured.NestedSlice(pm.Object, "containers")
+	if err != nil {
+		return Usage{}, err
+	}
+	var usage Usage
+	for _, c := range containers {
+		container, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		values, _, _ := unstructured.NestedStringMap(container, "usage")
+		if cpu, ok := values["cpu"]; ok {
+			q, err := resource.ParseQuantity(cpu)
+			if err != nil {
+				return Usage{}, fmt.Errorf("cpu: %w", err)
+			}
+			usage.CPU.Add(q)
+		}
+		if memory, ok := values["memory"]; ok {
+			q, err := resource.ParseQuantity(memory)
+			if err != nil {
+				return Usage{}, fmt.Errorf("memory: %w", err)
+			}
+			usage.Memory.Add(q)
+		}
+	}
+	return usage, nil
+}
@@ -0,0 +1,65 @@
+package recommender
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// podMetricsReader serves PodMetrics keyed by pod name.
+type podMetricsReader struct {
+	metrics map[string][]interface{}
+}
+
+func (r *podMetricsReader) Get(_ context.Context, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+	containers, ok := r.metrics[key.Name]
+	if !ok {
+		return apierrors.NewNotFound(schema.GroupResource{Group: "metrics.k8s.io", Resource: "pods"}, key.Name)
+	}
+	obj.(*unstructured.Unstructured).Object["containers"] = containers
+	return nil
+}
+
+func (r *podMetricsReader) List(context.Context, client.ObjectList, ...client.ListOption) error {
+	return nil
+}
+
+func containerUsage(cpu, memory string) map[string]interface{} {
+	return map[string]interface{}{
+		"name":  "c",
+		"usage": map[string]interface{}{"cpu": cpu, "memory": memory},
+	}
+}
+
+func TestMetricsAPISourceSample(t *testing.T) {
+	source := &MetricsAPISource{Reader: &podMetricsReader{metrics: map[string][]interface{}{
+		"model-0": {containerUsage("1500m", "8Gi"), containerUsage("100m", "1Gi")},
+		"model-1": {containerUsage("1", "12Gi")},
+	}}}
+	pods := []corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "model-0", Namespace: "default"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "model-1", Namespace: "default"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "model-2", Namespace: "default"}},
+	}
+
+	usage, err := source.Sample(context.Background(), pods)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// model-0 sums its containers; peaks are taken per resource across pods
+	if got := usage.CPU.String(); got != "1600m" {
+		t.Errorf("expected peak cpu 1600m, got %s", got)
+	}
+	if got := usage.Memory.String(); got != "12Gi" {
+		t.Errorf("expected peak memory 12Gi, got %s", got)
+	}
+	if !usage.GPUMemory.IsZero() {
+		t.Errorf("expected no GPU memory, got %s", usage.GPUMemory.String())
+	}
+}
@@ -0,0 +1,192 @@
+// Package recommender derives resource right-sizing recommendations for
+// ModelDeployments from observed pod usage.
+package recommender
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+const (
+	// headroomPercent is added on top of peak usage when recommending a value.
+	headroomPercent = 20
+
+	// cpuStepMilli is the granularity CPU recommendations are rounded up to.
+	cpuStepMilli = 100
+	// memoryStep is the granularity memory recommendations are rounded up to.
+	memoryStep = 64 * 1024 * 1024
+
+	// applyTolerancePercent is how far a recommendation must differ from the
+	// current value before autotune applies it, to avoid restarting pods for
+	// small changes.
+	applyTolerancePercent = 10
+)
+
+// Usage is the peak resource usage of a single replica. Zero values mean the
+// resource was not observed.
+type Usage struct {
+	CPU       resource.Quantity
+	Memory    resource.Quantity
+	GPUMemory resource.Quantity
+}
+
+// IsZero returns true if no resource was observed.
+func (u Usage) IsZero() bool {
+	return u.CPU.IsZero() && u.Memory.IsZero() && u.GPUMemory.IsZero()
+}
+
+// Max returns the per-resource maximum of u and other.
+func (u Usage) Max(other Usage) Usage {
+	return Usage{
+		CPU:       maxQuantity(u.CPU, other.CPU),
+		Memory:    maxQuantity(u.Memory, other.Memory),
+		GPUMemory: maxQuantity(u.GPUMemory, other.GPUMemory),
+	}
+}
+
+// UsageSource samples the current resource usage of model pods.
+type UsageSource interface {
+	// Name identifies the source in status.recommendations.sources.
+	Name() string
+	// Sample returns the highest current usage across pods. Pods without data
+	// yet are skipped.
+	Sample(ctx context.Context, pods []corev1.Pod) (Usage, error)
+}
+
+// GPUUtilization is the mean utilization of the GPUs attached to a set of pods.
+type GPUUtilization struct {
+	// GPUs is the number of GPUs sampled. Zero means no GPU was observed.
+	GPUs int
+	// Percent is the mean utilization of the GPUs, from 0 to 100.
+	Percent float64
+}
+
+// GPUUtilizationSource samples the GPU utilization of model pods.
+type GPUUtilizationSource interface {
+	// SampleGPUUtilization returns the mean current utilization of the GPUs
+	// attached to pods.
+	SampleGPUUtilization(ctx context.Context, pods []corev1.Pod) (GPUUtilization, error)
+}
+
+// GPUDeviceSource looks up the GPUs attached to model pods.
+type GPUDeviceSource interface {
+	// SampleGPUDevices returns the indices of the GPUs attached to each of pods on its
+	// node, keyed by "namespace/pod". Pods without attributed GPUs are left out.
+	SampleGPUDevices(ctx context.Context, pods []corev1.Pod) (map[string][]string, error)
+}
+
+// Recommendation is a recommended cpu and memory value for a replica.
+// Zero values mean there is no recommendation for that resource.
+type Recommendation struct {
+	CPU    resource.Quantity
+	Memory resource.Quantity
+}
+
+// Recommend returns peak usage plus headroom, rounded up.
+func Recommend(peak Usage) Recommendation {
+	var rec Recommendation
+	if !peak.CPU.IsZero() {
+		milli := withHeadroom(peak.CPU.MilliValue())
+		rec.CPU = *resource.NewMilliQuantity(roundUp(milli, cpuStepMilli), resource.DecimalSI)
+	}
+	if !peak.Memory.IsZero() {
+		bytes := withHeadroom(peak.Memory.Value())
+		rec.Memory = *resource.NewQuantity(roundUp(bytes, memoryStep), resource.BinarySI)
+	}
+	return rec
+}
+
+// Apply returns the cpu and memory values autotune should set on resources,
+// clamped to resources.autotuneBounds. The returned bool is false when no value
+// differs from the current one by more than the apply tolerance.
+func Apply(resources *airunwayv1alpha1.ResourceSpec, rec Recommendation) (cpu, memory string, changed bool, err error) {
+	cpu, memory = resources.CPU, resources.Memory
+	bounds := resources.AutotuneBounds
+	if bounds == nil {
+		bounds = &airunwayv1alpha1.AutotuneBounds{}
+	}
+
+	if !rec.CPU.IsZero() {
+		target, err := clamp(rec.CPU, bounds.MinCPU, bounds.MaxCPU)
+		if err != nil {
+			return "", "", false, fmt.Errorf("invalid cpu bounds: %w", err)
+		}
+		if differs(cpu, target) {
+			cpu, changed = target.String(), true
+		}
+	}
+	if !rec.Memory.IsZero() {
+		target, err := clamp(rec.Memory, bounds.MinMemory, bounds.MaxMemory)
+		if err != nil {
+			return "", "", false, fmt.Errorf("invalid memory bounds: %w", err)
+		}
+		if differs(memory, target) {
+			memory, changed = target.String(), true
+		}
+	}
+	return cpu, memory, changed, nil
+}
+
+// clamp limits q to [min, max]. Empty bounds are unbounded.
+func clamp(q resource.Quantity, min, max string) (resource.Quantity, error) {
+	if min != "" {
+		minQty, err := resource.ParseQuantity(min)
+		if err != nil {
+			return q, err
+		}
+		if q.Cmp(minQty) < 0 {
+			q = minQty
+		}
+	}
+	if max != "" {
+		maxQty, err := resource.ParseQuantity(max)
+		if err != nil {
+			return q, err
+		}
+		if q.Cmp(maxQty) > 0 {
+			q = maxQty
+		}
+	}
+	return q, nil
+}
+
+// differs returns true if target is more than applyTolerancePercent away from
+// current, or current is unset or unparsable.
+func differs(current string, target resource.Quantity) bool {
+	if current == "" {
+		return true
+	}
+	currentQty, err := resource.ParseQuantity(current)
+	if err != nil || currentQty.IsZero() {
+		return true
+	}
+	cur, tgt := currentQty.MilliValue(), target.MilliValue()
+	delta := tgt - cur
+	if delta < 0 {
+		delta = -delta
+	}
+	return delta*100 > cur*applyTolerancePercent
+}
+
+func withHeadroom(v int64) int64 {
+	return v + v*headroomPercent/100
+}
+
+func roundUp(v, step int64) int64 {
+	if rem := v % step; rem != 0 {
+		v += step - rem
+	}
+	return v
+}
+
+func maxQuantity(a, b resource.Quantity) resource.Quantity {
+	if b.Cmp(a) > 0 {
+		return b
+	}
+	return a
+}
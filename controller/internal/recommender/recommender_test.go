@@ -0,0 +1,109 @@
+package recommender
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+func TestRecommend(t *testing.T) {
+	rec := Recommend(Usage{
+		CPU:    resource.MustParse("1500m"),
+		Memory: resource.MustParse("10Gi"),
+	})
+	// 1500m + 20% = 1800m
+	if got := rec.CPU.String(); got != "1800m" {
+		t.Errorf("expected cpu 1800m, got %s", got)
+	}
+	// 10Gi + 20% = 12Gi, already a multiple of 64Mi
+	if got := rec.Memory.String(); got != "12Gi" {
+		t.Errorf("expected memory 12Gi, got %s", got)
+	}
+
+	rec = Recommend(Usage{CPU: resource.MustParse("10m"), Memory: resource.MustParse("100Mi")})
+	if got := rec.CPU.String(); got != "100m" {
+		t.Errorf("expected cpu rounded up to 100m, got %s", got)
+	}
+	if got := rec.Memory.String(); got != "128Mi" {
+		t.Errorf("expected memory rounded up to 128Mi, got %s", got)
+	}
+
+	if rec := Recommend(Usage{}); !rec.CPU.IsZero() || !rec.Memory.IsZero() {
+		t.Errorf("expected no recommendation without usage, got %+v", rec)
+	}
+}
+
+func TestApply(t *testing.T) {
+	rec := Recommendation{CPU: resource.MustParse("2"), Memory: resource.MustParse("100Gi")}
+
+	tests := []struct {
+		name        string
+		resources   *airunwayv1alpha1.ResourceSpec
+		wantCPU     string
+		wantMemory  string
+		wantChanged bool
+		wantErr     bool
+	}{
+		{
+			name: "clamped to max",
+			resources: &airunwayv1alpha1.ResourceSpec{CPU: "4", Memory: "32Gi", AutotuneBounds: &airunwayv1alpha1.AutotuneBounds{
+				MaxMemory: "64Gi",
+			}},
+			wantCPU:     "2",
+			wantMemory:  "64Gi",
+			wantChanged: true,
+		},
+		{
+			name: "clamped to min",
+			resources: &airunwayv1alpha1.ResourceSpec{CPU: "4", Memory: "100Gi", AutotuneBounds: &airunwayv1alpha1.AutotuneBounds{
+				MinCPU: "3",
+			}},
+			wantCPU:     "3",
+			wantMemory:  "100Gi",
+			wantChanged: true,
+		},
+		{
+			name: "within tolerance",
+			resources: &airunwayv1alpha1.ResourceSpec{CPU: "2100m", Memory: "96Gi", AutotuneBounds: &airunwayv1alpha1.AutotuneBounds{
+				MaxCPU: "8",
+			}},
+			wantCPU:    "2100m",
+			wantMemory: "96Gi",
+		},
+		{
+			name:        "unset values",
+			resources:   &airunwayv1alpha1.ResourceSpec{AutotuneBounds: &airunwayv1alpha1.AutotuneBounds{}},
+			wantCPU:     "2",
+			wantMemory:  "100Gi",
+			wantChanged: true,
+		},
+		{
+			name: "invalid bounds",
+			resources: &airunwayv1alpha1.ResourceSpec{AutotuneBounds: &airunwayv1alpha1.AutotuneBounds{
+				MaxCPU: "lots",
+			}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cpu, memory, changed, err := Apply(tt.resources, rec)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cpu != tt.wantCPU || memory != tt.wantMemory || changed != tt.wantChanged {
+				t.Errorf("got cpu=%s memory=%s changed=%v, want cpu=%s memory=%s changed=%v",
+					cpu, memory, changed, tt.wantCPU, tt.wantMemory, tt.wantChanged)
+			}
+		})
+	}
+}
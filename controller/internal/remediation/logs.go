@@ -0,0 +1,70 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remediation
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// defaultTailLines is how many lines of the previous logs are read
+	defaultTailLines = 200
+	// maxLogBytes caps the logs read from a container
+	maxLogBytes = 256 * 1024
+)
+
+// LogSource reads the logs of the previous, crashed instance of a container
+type LogSource interface {
+	PreviousLogs(ctx context.Context, pod *corev1.Pod, container string) (string, error)
+}
+
+// ClientsetLogSource reads logs through the pods/log subresource
+type ClientsetLogSource struct {
+	Client kubernetes.Interface
+
+	// TailLines is how many lines are read from the end of the logs. Defaults to 200.
+	TailLines int64
+}
+
+// PreviousLogs returns the last lines of the logs of the previous instance of container
+func (s *ClientsetLogSource) PreviousLogs(ctx context.Context, pod *corev1.Pod, container string) (string, error) {
+	tail := s.TailLines
+	if tail <= 0 {
+		tail = defaultTailLines
+	}
+	limit := int64(maxLogBytes)
+	stream, err := s.Client.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+		Container:  container,
+		Previous:   true,
+		TailLines:  &tail,
+		LimitBytes: &limit,
+	}).Stream(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to read logs of %s/%s: %w", pod.Name, container, err)
+	}
+	defer func() { _ = stream.Close() }()
+	data, err := io.ReadAll(io.LimitReader(stream, maxLogBytes))
+	if err != nil {
+		return "", fmt.Errorf("failed to read logs of %s/%s: %w", pod.Name, container, err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package remediation recognizes engine crash errors in container logs and derives the
+// engine setting changes that may let the engine start.
+package remediation
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+const (
+	// actionContextLength and actionArgPrefix name the settings in Fallback.Action
+	actionContextLength = "engine.contextLength"
+	actionArgPrefix     = "engine.args."
+
+	// DefaultMemoryFraction is the GPU memory fraction assumed when engine.args does not
+	// set one. It is the vllm default.
+	DefaultMemoryFraction = 0.9
+	// MinMemoryFraction is the lowest GPU memory fraction remediation applies
+	MinMemoryFraction = 0.6
+	// memoryFractionStep is how much each fallback lowers the GPU memory fraction
+	memoryFractionStep = 0.1
+
+	// DefaultContextLength is the context length applied when the engine does not report
+	// the one it tried
+	DefaultContextLength = 8192
+	// MinContextLength is the lowest context length remediation applies
+	MinContextLength = 2048
+
+	// FallbackDtype is the dtype applied for an unsupported dtype
+	FallbackDtype = "float16"
+)
+
+// patterns match the log lines of each recognized error. The most specific errors come
+// first: a KV cache that cannot hold the context length is also reported as a memory
+// error by some engine versions.
+var patterns = []struct {
+	reason airunwayv1alpha1.RemediationReason
+	re     *regexp.Regexp
+}{
+	{airunwayv1alpha1.RemediationReasonKVCacheTooSmall, regexp.MustCompile(
+		`(?i)max seq len \(\d+\) is larger than the maximum number of tokens that can be stored in KV cache|` +
+			`max_model_len.*is larger than.*KV cache|not enough KV cache|No available memory for the cache blocks`)},
+	{airunwayv1alpha1.RemediationReasonCUDAOutOfMemory, regexp.MustCompile(
+		`(?i)CUDA out of memory|torch\.(cuda\.)?OutOfMemoryError|CUDA error: out of memory|Not enough memory`)},
+	{airunwayv1alpha1.RemediationReasonUnsupportedDtype, regexp.MustCompile(
+		`(?i)Bfloat16 is only supported on GPUs with compute capability|dtype.*(is not|not) supported|unsupported dtype`)},
+}
+
+// maxSeqLenRe extracts the context length the engine tried from a KV cache error
+var maxSeqLenRe = regexp.MustCompile(`(?i)max seq len \((\d+)\)`)
+
+// Classify returns the recognized error in the logs of a crashed engine, or "" when
+// none is recognized
+func Classify(logs string) airunwayv1alpha1.RemediationReason {
+	for _, p := range patterns {
+		if p.re.MatchString(logs) {
+			return p.reason
+		}
+	}
+	return ""
+}
+
+// Fallback is a change to the engine settings of a ModelDeployment
+type Fallback struct {
+	// Action describes the change, e.g. engine.args.gpu-memory-utilization=0.8
+	Action string
+
+	// Apply makes the change to a ModelDeploymentSpec
+	Apply func(spec *airunwayv1alpha1.ModelDeploymentSpec)
+}
+
+// Next returns the next fallback for reason, given the current settings of md and the
+// logs of the crash. The bool is false when no fallback is left, e.g. when the memory
+// fraction and context length are already at their minimum.
+func Next(md *airunwayv1alpha1.ModelDeployment, reason airunwayv1alpha1.RemediationReason, logs string) (Fallback, bool) {
+	switch reason {
+	case airunwayv1alpha1.RemediationReasonCUDAOutOfMemory:
+		if f, ok := lowerMemoryFraction(md); ok {
+			return f, true
+		}
+		return lowerContextLength(md, logs)
+	case airunwayv1alpha1.RemediationReasonKVCacheTooSmall:
+		if f, ok := lowerContextLength(md, logs); ok {
+			return f, true
+		}
+		return lowerMemoryFraction(md)
+	case airunwayv1alpha1.RemediationReasonUnsupportedDtype:
+		if md.Spec.Engine.Args["dtype"] == FallbackDtype {
+			return Fallback{}, false
+		}
+		return setArg("dtype", FallbackDtype), true
+	}
+	return Fallback{}, false
+}
+
+// MemoryFractionArg returns the engine arg that sets the fraction of GPU memory the
+// engine may use, or "" for engines remediation does not support
+func MemoryFractionArg(engine airunwayv1alpha1.EngineType) string {
+	switch engine {
+	case airunwayv1alpha1.EngineTypeVLLM:
+		return "gpu-memory-utilization"
+	case airunwayv1alpha1.EngineTypeSGLang:
+		return "mem-fraction-static"
+	}
+	return ""
+}
+
+// lowerMemoryFraction lowers the GPU memory fraction by one step, down to
+// MinMemoryFraction
+func lowerMemoryFraction(md *airunwayv1alpha1.ModelDeployment) (Fallback, bool) {
+	arg := MemoryFractionArg(md.ResolvedEngineType())
+	if arg == "" {
+		return Fallback{}, false
+	}
+	current := DefaultMemoryFraction
+	if v, err := strconv.ParseFloat(md.Spec.Engine.Args[arg], 64); err == nil && v > 0 {
+		current = v
+	}
+	// Round to whole steps so repeated fallbacks do not accumulate float error
+	next := float64(int((current-memoryFractionStep)*100+0.5)) / 100
+	if next < MinMemoryFraction {
+		return Fallback{}, false
+	}
+	return setArg(arg, strconv.FormatFloat(next, 'f', -1, 64)), true
+}
+
+// lowerContextLength halves the context length, down to MinContextLength. Without
+// spec.engine.contextLength, the length the engine reported trying is halved, or
+// DefaultContextLength is applied.
+func lowerContextLength(md *airunwayv1alpha1.ModelDeployment, logs string) (Fallback, bool) {
+	var next int32
+	switch {
+	case md.Spec.Engine.ContextLength != nil:
+		next = *md.Spec.Engine.ContextLength / 2
+	default:
+		next = DefaultContextLength
+		if m := maxSeqLenRe.FindStringSubmatch(logs); m != nil {
+			if tried, err := strconv.ParseInt(m[1], 10, 32); err == nil && int32(tried)/2 < next {
+				next = int32(tried) / 2
+			}
+		}
+	}
+	if next < MinContextLength {
+		return Fallback{}, false
+	}
+	return Fallback{
+		Action: fmt.Sprintf("%s=%d", actionContextLength, next),
+		Apply: func(spec *airunwayv1alpha1.ModelDeploymentSpec) {
+			spec.Engine.ContextLength = &next
+		},
+	}, true
+}
+
+// setArg sets an engine arg
+func setArg(key, value string) Fallback {
+	return Fallback{
+		Action: actionArgPrefix + key + "=" + value,
+		Apply: func(spec *airunwayv1alpha1.ModelDeploymentSpec) {
+			if spec.Engine.Args == nil {
+				spec.Engine.Args = map[string]string{}
+			}
+			spec.Engine.Args[key] = value
+		},
+	}
+}
+
+// KeepApplied copies the settings changed by the applied fallbacks from current to spec,
+// so a controller that regenerates the spec, such as the ModelFleet controller, does not
+// revert them
+func KeepApplied(spec, current *airunwayv1alpha1.ModelDeploymentSpec, attempts []airunwayv1alpha1.RemediationAttempt) {
+	for _, attempt := range attempts {
+		setting, _, _ := strings.Cut(attempt.Action, "=")
+		if setting == actionContextLength {
+			spec.Engine.ContextLength = current.Engine.ContextLength
+			continue
+		}
+		key, ok := strings.CutPrefix(setting, actionArgPrefix)
+		if !ok {
+			continue
+		}
+		value, ok := current.Engine.Args[key]
+		if !ok {
+			delete(spec.Engine.Args, key)
+			continue
+		}
+		if spec.Engine.Args == nil {
+			spec.Engine.Args = map[string]string{}
+		}
+		spec.Engine.Args[key] = value
+	}
+}
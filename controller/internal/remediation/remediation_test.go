@@ -0,0 +1,164 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remediation
+
+import (
+	"testing"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name string
+		logs string
+		want airunwayv1alpha1.RemediationReason
+	}{
+		{
+			name: "cuda oom",
+			logs: "torch.OutOfMemoryError: CUDA out of memory. Tried to allocate 1.50 GiB. GPU 0 has a total capacity of 22.05 GiB",
+			want: airunwayv1alpha1.RemediationReasonCUDAOutOfMemory,
+		},
+		{
+			name: "kv cache",
+			logs: "ValueError: The model's max seq len (131072) is larger than the maximum number of tokens that can be stored in KV cache (43008).",
+			want: airunwayv1alpha1.RemediationReasonKVCacheTooSmall,
+		},
+		{
+			name: "bfloat16",
+			logs: "ValueError: Bfloat16 is only supported on GPUs with compute capability of at least 8.0. Your Tesla T4 GPU has compute capability 7.5.",
+			want: airunwayv1alpha1.RemediationReasonUnsupportedDtype,
+		},
+		{name: "unrecognized", logs: "OSError: org/model does not appear to have a file named config.json", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Classify(tt.logs); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNext(t *testing.T) {
+	newMD := func(engine airunwayv1alpha1.EngineType, args map[string]string, contextLength *int32) *airunwayv1alpha1.ModelDeployment {
+		return &airunwayv1alpha1.ModelDeployment{Spec: airunwayv1alpha1.ModelDeploymentSpec{
+			Engine: airunwayv1alpha1.EngineSpec{Type: engine, Args: args, ContextLength: contextLength},
+		}}
+	}
+	length := func(v int32) *int32 { return &v }
+
+	tests := []struct {
+		name       string
+		md         *airunwayv1alpha1.ModelDeployment
+		reason     airunwayv1alpha1.RemediationReason
+		logs       string
+		wantAction string
+	}{
+		{
+			name:       "vllm oom lowers the default utilization",
+			md:         newMD(airunwayv1alpha1.EngineTypeVLLM, nil, nil),
+			reason:     airunwayv1alpha1.RemediationReasonCUDAOutOfMemory,
+			wantAction: "engine.args.gpu-memory-utilization=0.8",
+		},
+		{
+			name:       "sglang oom lowers the static memory fraction",
+			md:         newMD(airunwayv1alpha1.EngineTypeSGLang, map[string]string{"mem-fraction-static": "0.75"}, nil),
+			reason:     airunwayv1alpha1.RemediationReasonCUDAOutOfMemory,
+			wantAction: "engine.args.mem-fraction-static=0.65",
+		},
+		{
+			name:       "oom at the minimum fraction halves the context length",
+			md:         newMD(airunwayv1alpha1.EngineTypeVLLM, map[string]string{"gpu-memory-utilization": "0.6"}, length(16384)),
+			reason:     airunwayv1alpha1.RemediationReasonCUDAOutOfMemory,
+			wantAction: "engine.contextLength=8192",
+		},
+		{
+			name:       "kv cache halves the reported context length",
+			md:         newMD(airunwayv1alpha1.EngineTypeVLLM, nil, nil),
+			reason:     airunwayv1alpha1.RemediationReasonKVCacheTooSmall,
+			logs:       "The model's max seq len (10240) is larger than the maximum number of tokens that can be stored in KV cache",
+			wantAction: "engine.contextLength=5120",
+		},
+		{
+			name:       "kv cache without a reported length",
+			md:         newMD(airunwayv1alpha1.EngineTypeVLLM, nil, nil),
+			reason:     airunwayv1alpha1.RemediationReasonKVCacheTooSmall,
+			wantAction: "engine.contextLength=8192",
+		},
+		{
+			name:       "unsupported dtype",
+			md:         newMD(airunwayv1alpha1.EngineTypeVLLM, nil, nil),
+			reason:     airunwayv1alpha1.RemediationReasonUnsupportedDtype,
+			wantAction: "engine.args.dtype=float16",
+		},
+		{
+			name:   "float16 already applied",
+			md:     newMD(airunwayv1alpha1.EngineTypeVLLM, map[string]string{"dtype": "float16"}, nil),
+			reason: airunwayv1alpha1.RemediationReasonUnsupportedDtype,
+		},
+		{
+			name:   "nothing left to lower",
+			md:     newMD(airunwayv1alpha1.EngineTypeVLLM, map[string]string{"gpu-memory-utilization": "0.6"}, length(2048)),
+			reason: airunwayv1alpha1.RemediationReasonCUDAOutOfMemory,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fallback, ok := Next(tt.md, tt.reason, tt.logs)
+			if ok != (tt.wantAction != "") || fallback.Action != tt.wantAction {
+				t.Fatalf("expected %q, got %q (ok=%v)", tt.wantAction, fallback.Action, ok)
+			}
+			if !ok {
+				return
+			}
+			spec := tt.md.Spec.DeepCopy()
+			fallback.Apply(spec)
+			if spec.Engine.Args == nil && spec.Engine.ContextLength == tt.md.Spec.Engine.ContextLength {
+				t.Error("expected the fallback to change the spec")
+			}
+		})
+	}
+}
+
+func TestKeepApplied(t *testing.T) {
+	length := int32(4096)
+	current := &airunwayv1alpha1.ModelDeploymentSpec{Engine: airunwayv1alpha1.EngineSpec{
+		Args:          map[string]string{"gpu-memory-utilization": "0.8", "dtype": "float16", "max-num-seqs": "32"},
+		ContextLength: &length,
+	}}
+	spec := &airunwayv1alpha1.ModelDeploymentSpec{Engine: airunwayv1alpha1.EngineSpec{
+		Args: map[string]string{"max-num-seqs": "64"},
+	}}
+	KeepApplied(spec, current, []airunwayv1alpha1.RemediationAttempt{
+		{Action: "engine.args.gpu-memory-utilization=0.8"},
+		{Action: "engine.contextLength=4096"},
+	})
+
+	want := map[string]string{"gpu-memory-utilization": "0.8", "max-num-seqs": "64"}
+	if len(spec.Engine.Args) != len(want) {
+		t.Errorf("expected args %v, got %v", want, spec.Engine.Args)
+	}
+	for k, v := range want {
+		if spec.Engine.Args[k] != v {
+			t.Errorf("expected args %v, got %v", want, spec.Engine.Args)
+		}
+	}
+	if spec.Engine.ContextLength == nil || *spec.Engine.ContextLength != 4096 {
+		t.Errorf("expected the applied context length, got %v", spec.Engine.ContextLength)
+	}
+}
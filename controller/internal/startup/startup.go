@@ -0,0 +1,277 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package startup verifies the environment of the manager when it boots: the CRDs it
+// depends on, the webhook server, and the webhook serving certificate.
+package startup
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/discovery"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/pkg/provider"
+)
+
+// DefaultRetryInterval is how often failed checks are retried
+const DefaultRetryInterval = 15 * time.Second
+
+// checkResult reports the result of each startup check.
+var checkResult = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "kubeairunway_startup_check",
+	Help: "Whether a startup self-check of the manager passed (1) or failed (0). A failed required check keeps the manager unready.",
+}, []string{"check", "required"})
+
+func init() {
+	metrics.Registry.MustRegister(checkResult)
+}
+
+// Check is a single startup check
+type Check struct {
+	// Name identifies the check in logs, the readiness output, and the check label of
+	// the kubeairunway_startup_check metric
+	Name string
+
+	// Required checks keep the manager unready while they fail. Optional checks guard
+	// features that are turned off when they fail.
+	Required bool
+
+	// Disables describes the features an optional check turns off when it fails
+	Disables string
+
+	// Run returns an error when the check fails
+	Run func(ctx context.Context) error
+}
+
+// Checker runs the startup checks when the manager starts and retries the failed ones
+// until all pass. ReadyzCheck reports the failed required checks, so a manager that
+// cannot serve is not marked ready. Checker is a manager.Runnable that runs on every
+// replica.
+type Checker struct {
+	// Checks are the checks to run
+	Checks []Check
+
+	// RetryInterval is how often failed checks are retried. Zero uses
+	// DefaultRetryInterval.
+	RetryInterval time.Duration
+
+	mu      sync.RWMutex
+	ran     bool
+	results map[string]error
+}
+
+// Start runs the checks until all pass or ctx is done
+func (c *Checker) Start(ctx context.Context) error {
+	interval := c.RetryInterval
+	if interval <= 0 {
+		interval = DefaultRetryInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for !c.run(ctx) {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+	log.FromContext(ctx).Info("Startup checks passed", "checks", len(c.Checks))
+	return nil
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, so standby replicas
+// report their readiness too.
+func (c *Checker) NeedLeaderElection() bool {
+	return false
+}
+
+// run runs the checks that have not passed yet and reports whether all passed. Failures
+// and recoveries are logged once.
+func (c *Checker) run(ctx context.Context) bool {
+	logger := log.FromContext(ctx)
+	c.mu.RLock()
+	previous := c.results
+	c.mu.RUnlock()
+
+	results := make(map[string]error, len(c.Checks))
+	for _, check := range c.Checks {
+		prevErr, seen := previous[check.Name]
+		if seen && prevErr == nil {
+			results[check.Name] = nil
+			continue
+		}
+		err := check.Run(ctx)
+		results[check.Name] = err
+
+		value := 1.0
+		if err != nil {
+			value = 0
+		}
+		checkResult.WithLabelValues(check.Name, strconv.FormatBool(check.Required)).Set(value)
+
+		switch {
+		case err == nil && seen:
+			logger.Info("Startup check passed", "check", check.Name)
+		case err == nil || (prevErr != nil && prevErr.Error() == err.Error()):
+		case check.Required:
+			logger.Error(err, "Startup check failed, the manager stays unready", "check", check.Name)
+		default:
+			logger.Info("Startup check failed, features are disabled", "check", check.Name,
+				"disables", check.Disables, "error", err.Error())
+		}
+	}
+
+	c.mu.Lock()
+	c.ran = true
+	c.results = results
+	c.mu.Unlock()
+
+	for _, err := range results {
+		if err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// ReadyzCheck is a healthz.Checker that fails before the checks ran and while a required
+// check fails.
+func (c *Checker) ReadyzCheck(_ *http.Request) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if !c.ran {
+		return errors.New("startup checks have not run yet")
+	}
+	var failed []string
+	for _, check := range c.Checks {
+		if err := c.results[check.Name]; check.Required && err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", check.Name, err))
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("startup checks failed: %s", strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+// CRDsServed returns a check that fails when the cluster serves one of crds in no
+// version.
+func CRDsServed(dc discovery.DiscoveryInterface, crds ...provider.UpstreamCRD) func(context.Context) error {
+	return func(context.Context) error {
+		missing, err := provider.MissingUpstreamCRDs(dc, nil, crds...)
+		if err != nil {
+			return err
+		}
+		if len(missing) == 0 {
+			return nil
+		}
+		names := make([]string, 0, len(missing))
+		for _, crd := range missing {
+			names = append(names, crd.String())
+		}
+		return fmt.Errorf("CRDs not installed: %s", strings.Join(names, ", "))
+	}
+}
+
+// AnyCRDServed returns a check that fails when the cluster serves none of crds, for
+// resources that are installed under different API groups by different versions of
+// their project.
+func AnyCRDServed(dc discovery.DiscoveryInterface, crds ...provider.UpstreamCRD) func(context.Context) error {
+	return func(context.Context) error {
+		missing, err := provider.MissingUpstreamCRDs(dc, nil, crds...)
+		if err != nil {
+			return err
+		}
+		if len(missing) < len(crds) {
+			return nil
+		}
+		names := make([]string, 0, len(missing))
+		for _, crd := range missing {
+			names = append(names, crd.String())
+		}
+		return fmt.Errorf("none of the CRDs installed: %s", strings.Join(names, ", "))
+	}
+}
+
+// ProviderUpstreamsInstalled returns a check that fails when a provider reports missing
+// upstream CRDs in the UpstreamInstalled condition of its InferenceProviderConfig.
+// Providers that have not reported yet pass.
+func ProviderUpstreamsInstalled(reader client.Reader) func(context.Context) error {
+	return func(ctx context.Context) error {
+		var configs airunwayv1alpha1.InferenceProviderConfigList
+		if err := reader.List(ctx, &configs); err != nil {
+			return fmt.Errorf("failed to list InferenceProviderConfigs: %w", err)
+		}
+		var missing []string
+		for _, pc := range configs.Items {
+			if meta.IsStatusConditionFalse(pc.Status.Conditions, airunwayv1alpha1.ConditionTypeUpstreamInstalled) {
+				missing = append(missing, pc.Name)
+			}
+		}
+		if len(missing) == 0 {
+			return nil
+		}
+		slices.Sort(missing)
+		return fmt.Errorf("upstream CRDs of providers not installed: %s", strings.Join(missing, ", "))
+	}
+}
+
+// CertValid returns a check that fails when the PEM certificate at path cannot be read,
+// is outside its validity period, or, when dnsName is set, is not valid for dnsName.
+func CertValid(path, dnsName string) func(context.Context) error {
+	return func(context.Context) error {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read certificate: %w", err)
+		}
+		block, _ := pem.Decode(data)
+		if block == nil || block.Type != "CERTIFICATE" {
+			return fmt.Errorf("no PEM certificate in %s", path)
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return fmt.Errorf("failed to parse certificate: %w", err)
+		}
+		now := time.Now()
+		if now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+			return fmt.Errorf("certificate is valid from %s to %s",
+				cert.NotBefore.Format(time.RFC3339), cert.NotAfter.Format(time.RFC3339))
+		}
+		if dnsName != "" {
+			if err := cert.VerifyHostname(dnsName); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package startup
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/pkg/provider"
+)
+
+func TestChecker(t *testing.T) {
+	ctx := context.Background()
+	certErr := errors.New("certificate not synced")
+	certRuns := 0
+	c := &Checker{Checks: []Check{
+		{Name: "crds", Required: true, Run: func(context.Context) error { return nil }},
+		{Name: "gateway", Disables: "the gateway integration", Run: func(context.Context) error {
+			return errors.New("CRDs not installed")
+		}},
+		{Name: "cert", Required: true, Run: func(context.Context) error {
+			certRuns++
+			return certErr
+		}},
+	}}
+
+	if err := c.ReadyzCheck(nil); err == nil {
+		t.Error("expected the manager to be unready before the checks ran")
+	}
+	if c.run(ctx) {
+		t.Fatal("expected failed checks")
+	}
+	if err := c.ReadyzCheck(nil); err == nil || !strings.Contains(err.Error(), "cert: certificate not synced") ||
+		strings.Contains(err.Error(), "gateway") {
+		t.Errorf("expected only the required cert check to fail readiness, got %v", err)
+	}
+	if got := testutil.ToFloat64(checkResult.WithLabelValues("gateway", "false")); got != 0 {
+		t.Errorf("expected the gateway check metric to be 0, got %v", got)
+	}
+	if got := testutil.ToFloat64(checkResult.WithLabelValues("crds", "true")); got != 1 {
+		t.Errorf("expected the crds check metric to be 1, got %v", got)
+	}
+
+	// Failed checks are retried until they pass; an optional failure does not block
+	// readiness
+	certErr = nil
+	c.run(ctx)
+	if err := c.ReadyzCheck(nil); err != nil {
+		t.Errorf("expected the manager to be ready, got %v", err)
+	}
+	if certRuns != 2 {
+		t.Errorf("expected the cert check to run twice, got %d", certRuns)
+	}
+	if got := testutil.ToFloat64(checkResult.WithLabelValues("cert", "true")); got != 1 {
+		t.Errorf("expected the cert check metric to be 1, got %v", got)
+	}
+}
+
+func TestCRDsServed(t *testing.T) {
+	dc := &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{}}
+	dc.Resources = []*metav1.APIResourceList{{
+		GroupVersion: "gateway.networking.k8s.io/v1",
+		APIResources: []metav1.APIResource{{Name: "httproutes"}},
+	}}
+	check := CRDsServed(dc,
+		provider.UpstreamCRD{Group: "gateway.networking.k8s.io", Resource: "httproutes", Kind: "HTTPRoute"},
+		provider.UpstreamCRD{Group: "inference.networking.k8s.io", Resource: "inferencepools", Kind: "InferencePool"},
+	)
+	err := check(context.Background())
+	if err == nil || err.Error() != "CRDs not installed: inferencepools.inference.networking.k8s.io" {
+		t.Errorf("expected InferencePool to be missing, got %v", err)
+	}
+
+	// Either API group of InferencePool is enough
+	alphaPool := provider.UpstreamCRD{Group: "inference.networking.x-k8s.io", Resource: "inferencepools", Kind: "InferencePool"}
+	v1Pool := provider.UpstreamCRD{Group: "inference.networking.k8s.io", Resource: "inferencepools", Kind: "InferencePool"}
+	if err := AnyCRDServed(dc, v1Pool, alphaPool)(context.Background()); err == nil {
+		t.Error("expected InferencePool to be missing in both groups")
+	}
+	dc.Resources = append(dc.Resources, &metav1.APIResourceList{
+		GroupVersion: "inference.networking.x-k8s.io/v1alpha2",
+		APIResources: []metav1.APIResource{{Name: "inferencepools"}},
+	})
+	if err := AnyCRDServed(dc, v1Pool, alphaPool)(context.Background()); err != nil {
+		t.Errorf("expected the v1alpha2 InferencePool to be enough, got %v", err)
+	}
+}
+
+func TestProviderUpstreamsInstalled(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := airunwayv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	newConfig := func(name string, status metav1.ConditionStatus) *airunwayv1alpha1.InferenceProviderConfig {
+		pc := &airunwayv1alpha1.InferenceProviderConfig{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		meta.SetStatusCondition(&pc.Status.Conditions, metav1.Condition{
+			Type: airunwayv1alpha1.ConditionTypeUpstreamInstalled, Status: status, Reason: "Probed",
+		})
+		return pc
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newConfig("kuberay", metav1.ConditionFalse),
+		newConfig("kaito", metav1.ConditionTrue),
+		&airunwayv1alpha1.InferenceProviderConfig{ObjectMeta: metav1.ObjectMeta{Name: "llmd"}},
+	).Build()
+
+	err := ProviderUpstreamsInstalled(c)(context.Background())
+	if err == nil || err.Error() != "upstream CRDs of providers not installed: kuberay" {
+		t.Errorf("expected kuberay to be reported, got %v", err)
+	}
+}
+
+func TestCertValid(t *testing.T) {
+	dir := t.TempDir()
+	writeCert := func(notBefore, notAfter time.Time, dnsNames ...string) string {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			NotBefore:    notBefore,
+			NotAfter:     notAfter,
+			DNSNames:     dnsNames,
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		path := filepath.Join(dir, "tls.crt")
+		if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	ctx := context.Background()
+	now := time.Now()
+	dnsName := "airunway-webhook-service.airunway-system.svc"
+
+	path := writeCert(now.Add(-time.Hour), now.Add(time.Hour), dnsName)
+	if err := CertValid(path, dnsName)(ctx); err != nil {
+		t.Errorf("expected a valid certificate, got %v", err)
+	}
+
+	// The bootstrap certificate has no DNS names and fails until the rotated one is synced
+	path = writeCert(now.Add(-time.Hour), now.Add(time.Hour))
+	if err := CertValid(path, dnsName)(ctx); err == nil {
+		t.Error("expected a certificate without the service DNS name to fail")
+	}
+	if err := CertValid(path, "")(ctx); err != nil {
+		t.Errorf("expected the DNS name not to be checked, got %v", err)
+	}
+
+	path = writeCert(now.Add(-2*time.Hour), now.Add(-time.Hour), dnsName)
+	if err := CertValid(path, dnsName)(ctx); err == nil || !strings.Contains(err.Error(), "valid from") {
+		t.Errorf("expected an expired certificate to fail, got %v", err)
+	}
+
+	if err := CertValid(filepath.Join(dir, "missing.crt"), "")(ctx); err == nil {
+		t.Error("expected a missing certificate to fail")
+	}
+}
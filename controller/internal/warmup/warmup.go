@@ -0,0 +1,118 @@
+// Package warmup sends synthetic completion requests to a freshly started model server
+// so that compilation and cache warmup costs are not paid by the first user request.
+package warmup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+const (
+	// DefaultPrompt is used when spec.warmup.prompt is empty.
+	DefaultPrompt = "Hello"
+	// DefaultMaxTokens is used when spec.warmup.maxTokens is unset.
+	DefaultMaxTokens = 16
+
+	// maxResponseBytes bounds how much of a completion response is read.
+	maxResponseBytes = 1 << 20
+)
+
+// PromptData is the data available to spec.warmup.prompt.
+type PromptData struct {
+	// Index is the 0-based number of the request.
+	Index int
+	// Model is the served model name.
+	Model string
+}
+
+// RenderPrompt renders a warmup prompt template such as "Request {{ .Index }}: hello".
+func RenderPrompt(tmpl string, data PromptData) (string, error) {
+	if tmpl == "" {
+		tmpl = DefaultPrompt
+	}
+	t, err := template.New("prompt").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("parsing warmup prompt template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("rendering warmup prompt template: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// Run sends spec.requests /v1/completions requests for model to baseURL one after another
+// and reports their latency. It stops at the first failed request; the returned status
+// still describes the requests that succeeded.
+func Run(ctx context.Context, httpClient *http.Client, baseURL, model string, spec airunwayv1alpha1.WarmupSpec) (airunwayv1alpha1.WarmupStatus, error) {
+	var status airunwayv1alpha1.WarmupStatus
+	requests := int(spec.Requests)
+	if requests <= 0 {
+		requests = 1
+	}
+	maxTokens := spec.MaxTokens
+	if maxTokens <= 0 {
+		maxTokens = DefaultMaxTokens
+	}
+
+	var total time.Duration
+	for i := range requests {
+		prompt, err := RenderPrompt(spec.Prompt, PromptData{Index: i, Model: model})
+		if err != nil {
+			return status, err
+		}
+		start := time.Now()
+		if err := sendCompletion(ctx, httpClient, baseURL, model, prompt, maxTokens); err != nil {
+			return status, fmt.Errorf("warmup request %d of %d failed: %w", i+1, requests, err)
+		}
+		latency := time.Since(start).Round(time.Millisecond)
+		if i == 0 {
+			status.FirstRequestLatency = &metav1.Duration{Duration: latency}
+		}
+		total += latency
+		status.CompletedRequests++
+		status.AverageLatency = &metav1.Duration{Duration: (total / time.Duration(status.CompletedRequests)).Round(time.Millisecond)}
+	}
+	return status, nil
+}
+
+// sendCompletion sends a single non-streaming completion request.
+func sendCompletion(ctx context.Context, httpClient *http.Client, baseURL, model, prompt string, maxTokens int32) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"model":      model,
+		"prompt":     prompt,
+		"max_tokens": maxTokens,
+		"stream":     false,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(baseURL, "/")+"/v1/completions", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBytes))
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("model server returned HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package warmup
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+func TestRenderPrompt(t *testing.T) {
+	got, err := RenderPrompt("", PromptData{})
+	if err != nil || got != DefaultPrompt {
+		t.Errorf("expected default prompt, got %q (err=%v)", got, err)
+	}
+
+	got, err = RenderPrompt("{{ .Index }} {{ .Model }}", PromptData{Index: 2, Model: "llama"})
+	if err != nil || got != "2 llama" {
+		t.Errorf("expected rendered prompt, got %q (err=%v)", got, err)
+	}
+
+	if _, err := RenderPrompt("{{ .Unknown }}", PromptData{}); err == nil {
+		t.Error("expected error for unknown field")
+	}
+}
+
+func TestRun(t *testing.T) {
+	var prompts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/completions" {
+			http.NotFound(w, r)
+			return
+		}
+		var body struct {
+			Model     string `json:"model"`
+			Prompt    string `json:"prompt"`
+			MaxTokens int32  `json:"max_tokens"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body.Model != "llama" || body.MaxTokens != 8 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		prompts = append(prompts, body.Prompt)
+		if len(prompts) > 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"choices":[{"text":"hi"}]}`))
+	}))
+	defer server.Close()
+
+	spec := airunwayv1alpha1.WarmupSpec{Requests: 2, Prompt: "warmup {{ .Index }}", MaxTokens: 8}
+	status, err := Run(context.Background(), server.Client(), server.URL, "llama", spec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status.CompletedRequests != 2 || status.FirstRequestLatency == nil || status.AverageLatency == nil {
+		t.Errorf("expected 2 completed requests with latencies, got %+v", status)
+	}
+	if strings.Join(prompts, ",") != "warmup 0,warmup 1" {
+		t.Errorf("expected rendered prompts per request, got %v", prompts)
+	}
+
+	// The server fails from its third request on, so the second request of this run fails
+	spec.Requests = 3
+	prompts = []string{"previous"}
+	status, err = Run(context.Background(), server.Client(), server.URL, "llama", spec)
+	if err == nil || !strings.Contains(err.Error(), "warmup request 2 of 3 failed: model server returned HTTP 503") {
+		t.Errorf("expected second request to fail, got %v", err)
+	}
+	if status.CompletedRequests != 1 {
+		t.Errorf("expected the successful request to be reported, got %+v", status)
+	}
+}
@@ -0,0 +1,254 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+// AdmissionPolicyName is the name of the ValidatingAdmissionPolicy and its binding
+const AdmissionPolicyName = "airunway-modeldeployment-validation"
+
+// admissionPolicyVariables resolve the defaults the webhook applies before its checks
+var admissionPolicyVariables = []admissionregistrationv1.Variable{
+	{Name: "spec", Expression: "object.spec"},
+	{Name: "servingMode", Expression: "has(object.spec.serving) && has(object.spec.serving.mode) ? object.spec.serving.mode : 'aggregated'"},
+	{Name: "engineType", Expression: "has(object.spec.engine.type) ? object.spec.engine.type : ''"},
+	{Name: "device", Expression: "has(object.spec.engine.device) ? object.spec.engine.device : ''"},
+	{Name: "gpuCount", Expression: "has(object.spec.resources) && has(object.spec.resources.gpu) && has(object.spec.resources.gpu.count) ? object.spec.resources.gpu.count : 0"},
+}
+
+// admissionPolicyValidations are the checks of validateSpec and validateImmutableFields that
+// need no cluster state, as CEL. TestAdmissionPolicyMatchesWebhook keeps them in sync.
+var admissionPolicyValidations = []admissionregistrationv1.Validation{
+	{
+		Expression: "(has(variables.spec.model.source) && variables.spec.model.source != 'huggingface') || (has(variables.spec.model.id) && variables.spec.model.id != '')",
+		Message:    "spec.model.id: model.id is required when source is huggingface",
+	},
+	{
+		Expression: fmt.Sprintf("variables.gpuCount <= %d", MaxGPUCount),
+		Message:    fmt.Sprintf("spec.resources.gpu.count: GPU count exceeds maximum allowed (%d)", MaxGPUCount),
+	},
+	{
+		Expression: fmt.Sprintf("!has(variables.spec.scaling) || ((!has(variables.spec.scaling.replicas) || variables.spec.scaling.replicas <= %[1]d) && "+
+			"(!has(variables.spec.scaling.prefill) || !has(variables.spec.scaling.prefill.replicas) || variables.spec.scaling.prefill.replicas <= %[1]d) && "+
+			"(!has(variables.spec.scaling.decode) || !has(variables.spec.scaling.decode.replicas) || variables.spec.scaling.decode.replicas <= %[1]d))", MaxReplicas),
+		Message: fmt.Sprintf("spec.scaling: replicas exceed maximum replicas (%d)", MaxReplicas),
+	},
+	{
+		Expression: fmt.Sprintf("!has(variables.spec.scaling) || "+
+			"((!has(variables.spec.scaling.prefill) || !has(variables.spec.scaling.prefill.gpu) || !has(variables.spec.scaling.prefill.gpu.count) || variables.spec.scaling.prefill.gpu.count <= %[1]d) && "+
+			"(!has(variables.spec.scaling.decode) || !has(variables.spec.scaling.decode.gpu) || !has(variables.spec.scaling.decode.gpu.count) || variables.spec.scaling.decode.gpu.count <= %[1]d))", MaxGPUCount),
+		Message: fmt.Sprintf("spec.scaling: component GPU count exceeds maximum GPU count (%d)", MaxGPUCount),
+	},
+	{
+		Expression: "variables.device != 'cpu' || variables.gpuCount == 0",
+		Message:    "spec.engine.device: cpu device cannot be combined with resources.gpu.count > 0",
+	},
+	{
+		Expression: "variables.device != 'cpu' || variables.servingMode != 'disaggregated'",
+		Message:    "spec.engine.device: cpu device is not supported in disaggregated mode",
+	},
+	{
+		Expression: "variables.device != 'cpu' || !(variables.engineType in ['sglang', 'trtllm'])",
+		Message:    "spec.engine.device: sglang and trtllm engines do not support cpu inference",
+	},
+	{
+		Expression: "variables.device != 'gpu' || variables.servingMode != 'aggregated' || variables.gpuCount > 0",
+		Message:    "spec.resources.gpu.count: gpu device requires resources.gpu.count > 0",
+	},
+	{
+		Expression: "!(variables.engineType in ['vllm', 'sglang', 'trtllm']) || variables.servingMode != 'aggregated' || variables.gpuCount > 0 || variables.device in ['cpu', 'gpu']",
+		Message:    "spec.resources.gpu.count: vllm, sglang and trtllm engines require GPU (set resources.gpu.count > 0, or engine.device: cpu for the vllm CPU backend)",
+	},
+	{
+		Expression: "!has(variables.spec.engine.remediation) || !has(variables.spec.engine.remediation.enabled) || !variables.spec.engine.remediation.enabled || variables.engineType in ['', 'vllm', 'sglang']",
+		Message:    "spec.engine.remediation: remediation is only supported with the vllm and sglang engines",
+	},
+	{
+		Expression: "!has(variables.spec.model.adapters) || size(variables.spec.model.adapters) == 0 || variables.engineType in ['', 'vllm']",
+		Message:    "spec.model.adapters: adapters are only supported with the vllm engine",
+	},
+	{
+		Expression: "!has(variables.spec.model.loadFormat) || variables.engineType in ['', 'vllm']",
+		Message:    "spec.model.loadFormat: loadFormat is only supported with the vllm engine",
+	},
+	{
+		Expression: "(has(variables.spec.model.loadFormat) && variables.spec.model.loadFormat == 'tensorizer') == has(variables.spec.model.tensorizerURI)",
+		Message:    "spec.model.tensorizerURI: required when, and only allowed when, loadFormat is tensorizer",
+	},
+	{
+		Expression: "!has(variables.spec.model.loadCredentialsSecret) || has(variables.spec.model.loadFormat)",
+		Message:    "spec.model.loadCredentialsSecret: only allowed with loadFormat",
+	},
+	{
+		Expression: "variables.servingMode != 'disaggregated' || variables.gpuCount == 0",
+		Message:    "spec.resources.gpu: cannot specify both resources.gpu and scaling.prefill/decode in disaggregated mode",
+	},
+	{
+		Expression: "variables.servingMode != 'disaggregated' || (has(variables.spec.scaling) && " +
+			"has(variables.spec.scaling.prefill) && has(variables.spec.scaling.prefill.gpu) && has(variables.spec.scaling.prefill.gpu.count) && variables.spec.scaling.prefill.gpu.count > 0 && " +
+			"has(variables.spec.scaling.decode) && has(variables.spec.scaling.decode.gpu) && has(variables.spec.scaling.decode.gpu.count) && variables.spec.scaling.decode.gpu.count > 0)",
+		Message: "spec.scaling: disaggregated mode requires scaling.prefill.gpu.count > 0 and scaling.decode.gpu.count > 0",
+	},
+	{
+		Expression: "variables.servingMode == 'disaggregated' || !has(variables.spec.scaling) || " +
+			"((!has(variables.spec.scaling.prefill) || (!has(variables.spec.scaling.prefill.image) && !has(variables.spec.scaling.prefill.scheduling))) && " +
+			"(!has(variables.spec.scaling.decode) || (!has(variables.spec.scaling.decode.image) && !has(variables.spec.scaling.decode.scheduling))))",
+		Message: "spec.scaling: component images and scheduling are only supported in disaggregated mode",
+	},
+	{
+		Expression: "variables.servingMode == 'disaggregated' || !has(variables.spec.serving) || !has(variables.spec.serving.placement)",
+		Message:    "spec.serving.placement: placement is only supported in disaggregated mode",
+	},
+	{
+		Expression: "!has(variables.spec.networking) || !has(variables.spec.networking.egress) || size(variables.spec.networking.egress) == 0 || " +
+			"(has(variables.spec.networking.isolate) && variables.spec.networking.isolate)",
+		Message: "spec.networking.egress: egress requires isolate",
+	},
+	{
+		Expression: "!has(variables.spec.networking) || !has(variables.spec.networking.ipFamilies) || size(variables.spec.networking.ipFamilies) < 2 || " +
+			"variables.spec.networking.ipFamilies[0] != variables.spec.networking.ipFamilies[1]",
+		Message: "spec.networking.ipFamilies: ipFamilies must not contain duplicates",
+	},
+	{
+		Expression: "!has(variables.spec.networking) || !has(variables.spec.networking.ipFamilies) || size(variables.spec.networking.ipFamilies) < 2 || " +
+			"(has(variables.spec.networking.ipFamilyPolicy) && variables.spec.networking.ipFamilyPolicy != 'SingleStack')",
+		Message: "spec.networking.ipFamilyPolicy: two ipFamilies require ipFamilyPolicy PreferDualStack or RequireDualStack",
+	},
+	{
+		Expression: "!has(variables.spec.gateway) || !has(variables.spec.gateway.rateLimit) || " +
+			"((has(variables.spec.gateway.rateLimit.requestsPerMinute) && variables.spec.gateway.rateLimit.requestsPerMinute > 0) || " +
+			"(has(variables.spec.gateway.rateLimit.maxConcurrent) && variables.spec.gateway.rateLimit.maxConcurrent > 0))",
+		Message: "spec.gateway.rateLimit: rateLimit requires requestsPerMinute or maxConcurrent",
+	},
+	{
+		Expression: "!has(variables.spec.gateway) || !has(variables.spec.gateway.rateLimit) || !has(variables.spec.gateway.rateLimit.burst) || " +
+			"variables.spec.gateway.rateLimit.burst == 0 || (has(variables.spec.gateway.rateLimit.requestsPerMinute) && variables.spec.gateway.rateLimit.requestsPerMinute > 0)",
+		Message: "spec.gateway.rateLimit.burst: burst requires requestsPerMinute",
+	},
+	{
+		Expression: "!has(variables.spec.gateway) || !has(variables.spec.gateway.promptPolicy) || " +
+			"(has(variables.spec.gateway.promptPolicy.systemPrompt) && variables.spec.gateway.promptPolicy.systemPrompt != '') || " +
+			"has(variables.spec.gateway.promptPolicy.maxTokens) || (has(variables.spec.gateway.promptPolicy.stop) && size(variables.spec.gateway.promptPolicy.stop) > 0)",
+		Message: "spec.gateway.promptPolicy: promptPolicy requires systemPrompt, maxTokens, or stop",
+	},
+	{
+		Expression: "!has(variables.spec.gateway) || !has(variables.spec.gateway.eppConfig) || variables.spec.gateway.eppConfig == '' || " +
+			"!has(variables.spec.gateway.sessionAffinity) || variables.spec.gateway.sessionAffinity == ''",
+		Message: "spec.gateway.sessionAffinity: cannot be combined with eppConfig; configure the prefix-cache-scorer in eppConfig instead",
+	},
+	{
+		Expression: "!has(variables.spec.gateway) || !has(variables.spec.gateway.fallback) || " +
+			"!has(variables.spec.gateway.httpRouteRef) || variables.spec.gateway.httpRouteRef == ''",
+		Message: "spec.gateway.fallback: cannot be combined with httpRouteRef; add the fallback backendRef to the HTTPRoute instead",
+	},
+	{
+		Expression: "!has(variables.spec.gateway) || !has(variables.spec.gateway.fallback) || " +
+			"has(variables.spec.gateway.fallback.backendRef) != (has(variables.spec.gateway.fallback.url) && variables.spec.gateway.fallback.url != '')",
+		Message: "spec.gateway.fallback: fallback requires exactly one of backendRef and url",
+	},
+	{
+		Expression: "!has(variables.spec.gateway) || !has(variables.spec.gateway.fallback) || !has(variables.spec.gateway.fallback.backendRef) || " +
+			"(variables.spec.gateway.fallback.backendRef.kind == 'Service' ? has(variables.spec.gateway.fallback.backendRef.port) : " +
+			"(!has(variables.spec.gateway.fallback.backendRef.port) && variables.spec.gateway.fallback.backendRef.name != object.metadata.name))",
+		Message: "spec.gateway.fallback.backendRef: port is required for a Service and forbidden for an InferencePool, which must not be the InferencePool of this ModelDeployment",
+	},
+	{
+		Expression: "!has(variables.spec.scheduling) || !has(variables.spec.scheduling.gang) || !variables.spec.scheduling.gang || " +
+			"(has(variables.spec.scheduling.scheduler) && variables.spec.scheduling.scheduler != '')",
+		Message: "spec.scheduling.scheduler: scheduler is required when gang is enabled",
+	},
+	{
+		Expression: "!has(variables.spec.scheduling) || (has(variables.spec.scheduling.queue) && variables.spec.scheduling.queue != '') || " +
+			"((!has(variables.spec.scheduling.kueueAdmission) || !variables.spec.scheduling.kueueAdmission) && " +
+			"(!has(variables.spec.scheduling.gang) || !variables.spec.scheduling.gang || !has(variables.spec.scheduling.scheduler) || !(variables.spec.scheduling.scheduler in ['kueue', 'kai'])))",
+		Message: "spec.scheduling.queue: queue is required for kueueAdmission and the kueue and kai gang schedulers",
+	},
+	{
+		Expression: "!has(variables.spec.scheduling) || !has(variables.spec.scheduling.kueueAdmission) || !variables.spec.scheduling.kueueAdmission || " +
+			"!has(variables.spec.scheduling.gang) || !variables.spec.scheduling.gang || !has(variables.spec.scheduling.scheduler) || variables.spec.scheduling.scheduler != 'kueue'",
+		Message: "spec.scheduling.kueueAdmission: kueueAdmission cannot be combined with the kueue gang scheduler",
+	},
+	{
+		Expression: "request.operation != 'UPDATE' || " +
+			"(has(oldObject.spec.model.source) ? oldObject.spec.model.source : '') == (has(object.spec.model.source) ? object.spec.model.source : '')",
+		Message: "spec.model.source: model.source is immutable (changing it requires delete and recreate)",
+	},
+	{
+		Expression: "request.operation != 'UPDATE' || !has(oldObject.spec.engine.type) || oldObject.spec.engine.type == '' || " +
+			"variables.engineType == '' || oldObject.spec.engine.type == variables.engineType",
+		Message: "spec.engine.type: engine.type is immutable (changing it requires delete and recreate)",
+	},
+	{
+		Expression: "request.operation != 'UPDATE' || !has(oldObject.spec.provider) || !has(oldObject.spec.provider.name) || oldObject.spec.provider.name == '' || " +
+			"!has(object.spec.provider) || !has(object.spec.provider.name) || object.spec.provider.name == '' || oldObject.spec.provider.name == object.spec.provider.name || " +
+			"(has(oldObject.status) && has(oldObject.status.migration) && oldObject.status.migration.phase == 'Completed' && " +
+			"oldObject.status.migration.to == object.spec.provider.name && has(oldObject.status.provider) && " +
+			"has(oldObject.status.provider.name) && oldObject.status.provider.name == object.spec.provider.name)",
+		Message: "spec.provider.name: provider.name is immutable (changing it requires delete and recreate)",
+	},
+}
+
+// AdmissionPolicy returns a ValidatingAdmissionPolicy with the checks of the
+// ModelDeployment webhook that need no cluster state, and a binding that applies it with
+// actions. It keeps basic spec validation in clusters that cannot run webhooks.
+func AdmissionPolicy(actions ...admissionregistrationv1.ValidationAction) (*admissionregistrationv1.ValidatingAdmissionPolicy, *admissionregistrationv1.ValidatingAdmissionPolicyBinding) {
+	if len(actions) == 0 {
+		actions = []admissionregistrationv1.ValidationAction{admissionregistrationv1.Deny}
+	}
+	meta := metav1.ObjectMeta{
+		Name:   AdmissionPolicyName,
+		Labels: map[string]string{"app.kubernetes.io/name": "airunway"},
+	}
+	policy := &admissionregistrationv1.ValidatingAdmissionPolicy{
+		TypeMeta:   metav1.TypeMeta{APIVersion: admissionregistrationv1.SchemeGroupVersion.String(), Kind: "ValidatingAdmissionPolicy"},
+		ObjectMeta: meta,
+		Spec: admissionregistrationv1.ValidatingAdmissionPolicySpec{
+			FailurePolicy: ptr.To(admissionregistrationv1.Fail),
+			MatchConstraints: &admissionregistrationv1.MatchResources{
+				ResourceRules: []admissionregistrationv1.NamedRuleWithOperations{{
+					RuleWithOperations: admissionregistrationv1.RuleWithOperations{
+						Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update},
+						Rule: admissionregistrationv1.Rule{
+							APIGroups:   []string{airunwayv1alpha1.GroupVersion.Group},
+							APIVersions: []string{airunwayv1alpha1.GroupVersion.Version},
+							Resources:   []string{"modeldeployments"},
+						},
+					},
+				}},
+			},
+			Variables:   admissionPolicyVariables,
+			Validations: admissionPolicyValidations,
+		},
+	}
+	binding := &admissionregistrationv1.ValidatingAdmissionPolicyBinding{
+		TypeMeta:   metav1.TypeMeta{APIVersion: admissionregistrationv1.SchemeGroupVersion.String(), Kind: "ValidatingAdmissionPolicyBinding"},
+		ObjectMeta: meta,
+		Spec: admissionregistrationv1.ValidatingAdmissionPolicyBindingSpec{
+			PolicyName:        AdmissionPolicyName,
+			ValidationActions: actions,
+		},
+	}
+	return policy, binding
+}
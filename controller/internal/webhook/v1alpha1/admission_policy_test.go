@@ -0,0 +1,345 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/google/cel-go/cel"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+// evaluateAdmissionPolicy returns the messages of the policy validations obj fails. oldObj
+// is nil for creates.
+func evaluateAdmissionPolicy(t *testing.T, policy *admissionregistrationv1.ValidatingAdmissionPolicy, oldObj, obj *airunwayv1alpha1.ModelDeployment) []string {
+	t.Helper()
+	env, err := cel.NewEnv(
+		cel.Variable("object", cel.DynType),
+		cel.Variable("oldObject", cel.DynType),
+		cel.Variable("request", cel.DynType),
+		cel.Variable("variables", cel.DynType),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	toMap := func(md *airunwayv1alpha1.ModelDeployment) map[string]any {
+		if md == nil {
+			return nil
+		}
+		m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(md)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return m
+	}
+	operation := "CREATE"
+	if oldObj != nil {
+		operation = "UPDATE"
+	}
+	variables := map[string]any{}
+	activation := map[string]any{
+		"object":    toMap(obj),
+		"oldObject": toMap(oldObj),
+		"request":   map[string]any{"operation": operation},
+		"variables": variables,
+	}
+	eval := func(expression string) any {
+		ast, issues := env.Compile(expression)
+		if issues != nil && issues.Err() != nil {
+			t.Fatalf("compiling %q: %v", expression, issues.Err())
+		}
+		prg, err := env.Program(ast)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out, _, err := prg.Eval(activation)
+		if err != nil {
+			t.Fatalf("evaluating %q: %v", expression, err)
+		}
+		return out.Value()
+	}
+
+	for _, v := range policy.Spec.Variables {
+		variables[v.Name] = eval(v.Expression)
+	}
+	var failed []string
+	for _, v := range policy.Spec.Validations {
+		if ok, isBool := eval(v.Expression).(bool); !isBool || !ok {
+			failed = append(failed, v.Message)
+		}
+	}
+	return failed
+}
+
+func newPolicyTestDeployment() *airunwayv1alpha1.ModelDeployment {
+	md := &airunwayv1alpha1.ModelDeployment{}
+	md.Name = "test"
+	md.Namespace = "default"
+	md.Spec.Model.ID = "meta-llama/Llama-3.1-8B-Instruct"
+	md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeVLLM
+	md.Spec.Resources = &airunwayv1alpha1.ResourceSpec{GPU: &airunwayv1alpha1.GPUSpec{Count: 1}}
+	return md
+}
+
+// TestAdmissionPolicyMatchesWebhook checks that the ValidatingAdmissionPolicy accepts and
+// rejects the same deployments as the webhook checks it mirrors
+func TestAdmissionPolicyMatchesWebhook(t *testing.T) {
+	disaggregated := func(md *airunwayv1alpha1.ModelDeployment) {
+		md.Spec.Serving = &airunwayv1alpha1.ServingSpec{Mode: airunwayv1alpha1.ServingModeDisaggregated}
+		md.Spec.Resources = nil
+		md.Spec.Scaling = &airunwayv1alpha1.ScalingSpec{
+			Prefill: &airunwayv1alpha1.ComponentScalingSpec{Replicas: 1, GPU: &airunwayv1alpha1.GPUSpec{Count: 1}},
+			Decode:  &airunwayv1alpha1.ComponentScalingSpec{Replicas: 1, GPU: &airunwayv1alpha1.GPUSpec{Count: 1}},
+		}
+	}
+	tests := []struct {
+		name    string
+		mutate  func(md *airunwayv1alpha1.ModelDeployment)
+		update  func(md *airunwayv1alpha1.ModelDeployment)
+		invalid bool
+	}{
+		{name: "valid", mutate: func(md *airunwayv1alpha1.ModelDeployment) {}},
+		{name: "missing model id", mutate: func(md *airunwayv1alpha1.ModelDeployment) { md.Spec.Model.ID = "" }, invalid: true},
+		{name: "custom source without id", mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+			md.Spec.Model.ID = ""
+			md.Spec.Model.Source = airunwayv1alpha1.ModelSourceCustom
+		}},
+		{name: "too many GPUs", mutate: func(md *airunwayv1alpha1.ModelDeployment) { md.Spec.Resources.GPU.Count = MaxGPUCount + 1 }, invalid: true},
+		{name: "too many replicas", mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+			md.Spec.Scaling = &airunwayv1alpha1.ScalingSpec{Replicas: MaxReplicas + 1}
+		}, invalid: true},
+		{name: "vllm without GPU", mutate: func(md *airunwayv1alpha1.ModelDeployment) { md.Spec.Resources = nil }, invalid: true},
+		{name: "vllm on CPU", mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+			md.Spec.Resources = nil
+			md.Spec.Engine.Device = airunwayv1alpha1.EngineDeviceCPU
+		}},
+		{name: "CPU device with GPUs", mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+			md.Spec.Engine.Device = airunwayv1alpha1.EngineDeviceCPU
+		}, invalid: true},
+		{name: "sglang on CPU", mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+			md.Spec.Resources = nil
+			md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeSGLang
+			md.Spec.Engine.Device = airunwayv1alpha1.EngineDeviceCPU
+		}, invalid: true},
+		{name: "GPU device without GPUs", mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+			md.Spec.Resources = nil
+			md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeLlamaCpp
+			md.Spec.Engine.Device = airunwayv1alpha1.EngineDeviceGPU
+		}, invalid: true},
+		{name: "llamacpp without GPU", mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+			md.Spec.Resources = nil
+			md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeLlamaCpp
+		}},
+		{name: "remediation with trtllm", mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+			md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeTRTLLM
+			md.Spec.Engine.Remediation = &airunwayv1alpha1.EngineRemediationSpec{Enabled: true}
+		}, invalid: true},
+		{name: "adapters with vllm", mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+			md.Spec.Model.Adapters = []airunwayv1alpha1.LoRAAdapter{{Name: "sql", Source: "/adapters/sql"}}
+		}},
+		{name: "adapters with sglang", mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+			md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeSGLang
+			md.Spec.Model.Adapters = []airunwayv1alpha1.LoRAAdapter{{Name: "sql", Source: "/adapters/sql"}}
+		}, invalid: true},
+		{name: "tensorizer with URI", mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+			md.Spec.Model.LoadFormat = airunwayv1alpha1.ModelLoadFormatTensorizer
+			md.Spec.Model.TensorizerURI = "s3://models/llama/model.tensors"
+			md.Spec.Model.LoadCredentialsSecret = "model-store"
+		}},
+		{name: "tensorizer without URI", mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+			md.Spec.Model.LoadFormat = airunwayv1alpha1.ModelLoadFormatTensorizer
+		}, invalid: true},
+		{name: "tensorizer URI with runai_streamer", mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+			md.Spec.Model.LoadFormat = airunwayv1alpha1.ModelLoadFormatRunAIStreamer
+			md.Spec.Model.TensorizerURI = "s3://models/llama/model.tensors"
+		}, invalid: true},
+		{name: "load credentials without loadFormat", mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+			md.Spec.Model.LoadCredentialsSecret = "model-store"
+		}, invalid: true},
+		{name: "loadFormat with sglang", mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+			md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeSGLang
+			md.Spec.Model.LoadFormat = airunwayv1alpha1.ModelLoadFormatSafetensors
+		}, invalid: true},
+		{name: "disaggregated", mutate: disaggregated},
+		{name: "disaggregated with resources.gpu", mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+			disaggregated(md)
+			md.Spec.Resources = &airunwayv1alpha1.ResourceSpec{GPU: &airunwayv1alpha1.GPUSpec{Count: 1}}
+		}, invalid: true},
+		{name: "disaggregated without decode", mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+			disaggregated(md)
+			md.Spec.Scaling.Decode = nil
+		}, invalid: true},
+		{name: "component image when aggregated", mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+			md.Spec.Scaling = &airunwayv1alpha1.ScalingSpec{Prefill: &airunwayv1alpha1.ComponentScalingSpec{Image: "vllm:custom"}}
+		}, invalid: true},
+		{name: "placement when aggregated", mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+			md.Spec.Serving = &airunwayv1alpha1.ServingSpec{Placement: &airunwayv1alpha1.PlacementSpec{Colocate: airunwayv1alpha1.PlacementDomainZone}}
+		}, invalid: true},
+		{name: "egress with isolate", mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+			md.Spec.Networking = &airunwayv1alpha1.NetworkingSpec{Isolate: true, Egress: []networkingv1.NetworkPolicyEgressRule{{}}}
+		}},
+		{name: "egress without isolate", mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+			md.Spec.Networking = &airunwayv1alpha1.NetworkingSpec{Egress: []networkingv1.NetworkPolicyEgressRule{{}}}
+		}, invalid: true},
+		{name: "dual stack", mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+			md.Spec.Networking = &airunwayv1alpha1.NetworkingSpec{
+				IPFamilyPolicy: ptr.To(corev1.IPFamilyPolicyPreferDualStack),
+				IPFamilies:     []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol},
+			}
+		}},
+		{name: "IPv6 single stack", mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+			md.Spec.Networking = &airunwayv1alpha1.NetworkingSpec{IPFamilies: []corev1.IPFamily{corev1.IPv6Protocol}}
+		}},
+		{name: "duplicate ipFamilies", mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+			md.Spec.Networking = &airunwayv1alpha1.NetworkingSpec{
+				IPFamilyPolicy: ptr.To(corev1.IPFamilyPolicyRequireDualStack),
+				IPFamilies:     []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv4Protocol},
+			}
+		}, invalid: true},
+		{name: "two ipFamilies without a dual-stack policy", mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+			md.Spec.Networking = &airunwayv1alpha1.NetworkingSpec{IPFamilies: []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol}}
+		}, invalid: true},
+		{name: "two ipFamilies with SingleStack", mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+			md.Spec.Networking = &airunwayv1alpha1.NetworkingSpec{
+				IPFamilyPolicy: ptr.To(corev1.IPFamilyPolicySingleStack),
+				IPFamilies:     []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol},
+			}
+		}, invalid: true},
+		{name: "empty rate limit", mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+			md.Spec.Gateway = &airunwayv1alpha1.GatewaySpec{RateLimit: &airunwayv1alpha1.RateLimitSpec{}}
+		}, invalid: true},
+		{name: "burst without requestsPerMinute", mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+			md.Spec.Gateway = &airunwayv1alpha1.GatewaySpec{RateLimit: &airunwayv1alpha1.RateLimitSpec{MaxConcurrent: 4, Burst: 2}}
+		}, invalid: true},
+		{name: "prompt policy with maxTokens", mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+			md.Spec.Gateway = &airunwayv1alpha1.GatewaySpec{PromptPolicy: &airunwayv1alpha1.PromptPolicySpec{MaxTokens: ptr.To[int32](256)}}
+		}},
+		{name: "empty prompt policy", mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+			md.Spec.Gateway = &airunwayv1alpha1.GatewaySpec{PromptPolicy: &airunwayv1alpha1.PromptPolicySpec{}}
+		}, invalid: true},
+		{name: "fallback to a Service", mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+			md.Spec.Gateway = &airunwayv1alpha1.GatewaySpec{Fallback: &airunwayv1alpha1.GatewayFallbackSpec{
+				BackendRef: &airunwayv1alpha1.FallbackBackendRef{Kind: airunwayv1alpha1.FallbackBackendKindService, Name: "backup", Port: ptr.To[int32](8000)},
+			}}
+		}},
+		{name: "fallback to an external url", mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+			md.Spec.Gateway = &airunwayv1alpha1.GatewaySpec{Fallback: &airunwayv1alpha1.GatewayFallbackSpec{URL: "https://api.example.com/v1"}}
+		}},
+		{name: "fallback without a backend", mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+			md.Spec.Gateway = &airunwayv1alpha1.GatewaySpec{Fallback: &airunwayv1alpha1.GatewayFallbackSpec{}}
+		}, invalid: true},
+		{name: "fallback with backendRef and url", mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+			md.Spec.Gateway = &airunwayv1alpha1.GatewaySpec{Fallback: &airunwayv1alpha1.GatewayFallbackSpec{
+				BackendRef: &airunwayv1alpha1.FallbackBackendRef{Kind: airunwayv1alpha1.FallbackBackendKindInferencePool, Name: "backup"},
+				URL:        "https://api.example.com/v1",
+			}}
+		}, invalid: true},
+		{name: "fallback Service without port", mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+			md.Spec.Gateway = &airunwayv1alpha1.GatewaySpec{Fallback: &airunwayv1alpha1.GatewayFallbackSpec{
+				BackendRef: &airunwayv1alpha1.FallbackBackendRef{Kind: airunwayv1alpha1.FallbackBackendKindService, Name: "backup"},
+			}}
+		}, invalid: true},
+		{name: "fallback to own InferencePool", mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+			md.Spec.Gateway = &airunwayv1alpha1.GatewaySpec{Fallback: &airunwayv1alpha1.GatewayFallbackSpec{
+				BackendRef: &airunwayv1alpha1.FallbackBackendRef{Kind: airunwayv1alpha1.FallbackBackendKindInferencePool, Name: md.Name},
+			}}
+		}, invalid: true},
+		{name: "fallback with httpRouteRef", mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+			md.Spec.Gateway = &airunwayv1alpha1.GatewaySpec{HTTPRouteRef: "custom", Fallback: &airunwayv1alpha1.GatewayFallbackSpec{URL: "https://api.example.com/v1"}}
+		}, invalid: true},
+		{name: "gang without scheduler", mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+			md.Spec.Scheduling = &airunwayv1alpha1.SchedulingSpec{Gang: true}
+		}, invalid: true},
+		{name: "kueue gang without queue", mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+			md.Spec.Scheduling = &airunwayv1alpha1.SchedulingSpec{Gang: true, Scheduler: airunwayv1alpha1.GangSchedulerKueue}
+		}, invalid: true},
+		{name: "volcano gang without queue", mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+			md.Spec.Scheduling = &airunwayv1alpha1.SchedulingSpec{Gang: true, Scheduler: airunwayv1alpha1.GangSchedulerVolcano}
+		}},
+		{name: "kueue admission with kueue gang", mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+			md.Spec.Scheduling = &airunwayv1alpha1.SchedulingSpec{Gang: true, Scheduler: airunwayv1alpha1.GangSchedulerKueue, KueueAdmission: true, Queue: "team-a"}
+		}, invalid: true},
+		{name: "change model source", update: func(md *airunwayv1alpha1.ModelDeployment) {
+			md.Spec.Model.Source = airunwayv1alpha1.ModelSourceCustom
+		}, invalid: true},
+		{name: "change engine type", update: func(md *airunwayv1alpha1.ModelDeployment) {
+			md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeSGLang
+		}, invalid: true},
+		{name: "change provider", mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+			md.Spec.Provider = &airunwayv1alpha1.ProviderSpec{Name: "kaito", MigrateTo: "llmd"}
+		}, update: func(md *airunwayv1alpha1.ModelDeployment) {
+			md.Spec.Provider.Name = "llmd"
+		}, invalid: true},
+		{name: "change provider after migration", mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+			md.Spec.Provider = &airunwayv1alpha1.ProviderSpec{Name: "kaito", MigrateTo: "llmd"}
+			md.Status.Provider = &airunwayv1alpha1.ProviderStatus{Name: "llmd"}
+			md.Status.Migration = &airunwayv1alpha1.MigrationStatus{From: "kaito", To: "llmd", Phase: airunwayv1alpha1.MigrationPhaseCompleted}
+		}, update: func(md *airunwayv1alpha1.ModelDeployment) {
+			md.Spec.Provider.Name = "llmd"
+		}},
+		{name: "scale up", update: func(md *airunwayv1alpha1.ModelDeployment) {
+			md.Spec.Resources.GPU.Count = 2
+		}},
+	}
+
+	policy, _ := AdmissionPolicy()
+	validator := &ModelDeploymentCustomValidator{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var oldObj *airunwayv1alpha1.ModelDeployment
+			obj := newPolicyTestDeployment()
+			if tt.mutate != nil {
+				tt.mutate(obj)
+			}
+			if tt.update != nil {
+				oldObj = obj.DeepCopy()
+				tt.update(obj)
+			}
+
+			webhookErrs := validator.validateSpec(obj)
+			if oldObj != nil {
+				webhookErrs = append(webhookErrs, validator.validateImmutableFields(oldObj, obj, false)...)
+			}
+			if got := len(webhookErrs) > 0; got != tt.invalid {
+				t.Errorf("webhook: expected invalid=%v, got %v", tt.invalid, webhookErrs)
+			}
+			if failed := evaluateAdmissionPolicy(t, policy, oldObj, obj); (len(failed) > 0) != tt.invalid {
+				t.Errorf("admission policy: expected invalid=%v, got %v", tt.invalid, failed)
+			}
+		})
+	}
+}
+
+func TestAdmissionPolicyBinding(t *testing.T) {
+	policy, binding := AdmissionPolicy()
+	if binding.Spec.PolicyName != policy.Name {
+		t.Errorf("expected the binding to reference %s, got %s", policy.Name, binding.Spec.PolicyName)
+	}
+	if len(binding.Spec.ValidationActions) != 1 || binding.Spec.ValidationActions[0] != admissionregistrationv1.Deny {
+		t.Errorf("expected Deny by default, got %v", binding.Spec.ValidationActions)
+	}
+	_, binding = AdmissionPolicy(admissionregistrationv1.Warn, admissionregistrationv1.Audit)
+	if len(binding.Spec.ValidationActions) != 2 {
+		t.Errorf("expected Warn and Audit, got %v", binding.Spec.ValidationActions)
+	}
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+// llamaCppThreadsEnv sets the number of threads of the llama.cpp server
+const llamaCppThreadsEnv = "LLAMA_ARG_THREADS"
+
+// defaultCPUPinning fills in spec.resources.cpuPinning and derives the resources it needs.
+// The static CPU manager only pins containers of Guaranteed pods with whole CPUs, so cpu
+// requests and limits are set to the exclusive cores, and memory requests to the memory
+// limit. llama.cpp runs one thread per pinned core.
+func defaultCPUPinning(spec *airunwayv1alpha1.ModelDeploymentSpec) {
+	if spec.Resources == nil || spec.Resources.CPUPinning == nil {
+		return
+	}
+	res := spec.Resources
+	pinning := res.CPUPinning
+	if pinning.NUMAPolicy == "" {
+		pinning.NUMAPolicy = airunwayv1alpha1.NUMAPolicySingleNUMANode
+	}
+
+	var requests, limits airunwayv1alpha1.ResourceQuantities
+	if res.Requests != nil {
+		requests = *res.Requests
+	}
+	if res.Limits != nil {
+		limits = *res.Limits
+	}
+	if pinning.ExclusiveCores == 0 {
+		pinning.ExclusiveCores = wholeCores(firstNonEmpty(requests.CPU, res.CPU, limits.CPU))
+	}
+	if pinning.ExclusiveCores <= 0 {
+		// Reported by validateCPUPinning
+		return
+	}
+
+	cores := strconv.Itoa(int(pinning.ExclusiveCores))
+	memory := firstNonEmpty(limits.Memory, requests.Memory, res.Memory)
+	res.Requests = &airunwayv1alpha1.ResourceQuantities{CPU: cores, Memory: memory}
+	res.Limits = &airunwayv1alpha1.ResourceQuantities{CPU: cores, Memory: memory}
+
+	if spec.Engine.Type == "" || spec.Engine.Type == airunwayv1alpha1.EngineTypeLlamaCpp {
+		for _, env := range spec.Env {
+			if env.Name == llamaCppThreadsEnv {
+				return
+			}
+		}
+		spec.Env = append(spec.Env, corev1.EnvVar{Name: llamaCppThreadsEnv, Value: cores})
+	}
+}
+
+// validateCPUPinning validates that spec.resources.cpuPinning applies to CPU inference and
+// that the resources give the pods Guaranteed QoS
+func validateCPUPinning(spec *airunwayv1alpha1.ModelDeploymentSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if spec.Resources == nil || spec.Resources.CPUPinning == nil {
+		return allErrs
+	}
+	res := spec.Resources
+	pinningPath := fldPath.Child("cpuPinning")
+
+	if res.GPU != nil && res.GPU.Count > 0 {
+		allErrs = append(allErrs, field.Forbidden(pinningPath, "cpuPinning applies to CPU inference and cannot be combined with gpu.count > 0"))
+	}
+	if spec.Engine.Device == airunwayv1alpha1.EngineDeviceGPU {
+		allErrs = append(allErrs, field.Forbidden(pinningPath, "cpuPinning applies to CPU inference and cannot be combined with engine.device gpu"))
+	}
+	if spec.Serving != nil && spec.Serving.Mode == airunwayv1alpha1.ServingModeDisaggregated {
+		allErrs = append(allErrs, field.Forbidden(pinningPath, "cpuPinning is not supported in disaggregated mode"))
+	}
+	if res.Autotune {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("autotune"), "autotune cannot change the cpu of pinned pods; remove autotune to use cpuPinning"))
+	}
+
+	cores := res.CPUPinning.ExclusiveCores
+	if cores <= 0 {
+		return append(allErrs, field.Required(pinningPath.Child("exclusiveCores"), "exclusiveCores is required when no cpu is set"))
+	}
+	if maxCores := resource.MustParse(MaxCPU); int64(cores) > maxCores.Value() {
+		allErrs = append(allErrs, field.Invalid(pinningPath.Child("exclusiveCores"), cores, fmt.Sprintf("exceeds maximum allowed (%s)", MaxCPU)))
+	}
+
+	want := strconv.Itoa(int(cores))
+	for _, q := range []struct {
+		quantities *airunwayv1alpha1.ResourceQuantities
+		path       *field.Path
+	}{
+		{res.Requests, fldPath.Child("requests")},
+		{res.Limits, fldPath.Child("limits")},
+	} {
+		if q.quantities == nil || q.quantities.Memory == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("memory"), "memory is required with cpuPinning, since pinned pods need memory requests equal to limits"))
+			break
+		}
+		if !quantityEquals(q.quantities.CPU, want) {
+			allErrs = append(allErrs, field.Invalid(q.path.Child("cpu"), q.quantities.CPU,
+				fmt.Sprintf("must equal cpuPinning.exclusiveCores (%d)", cores)))
+		}
+	}
+	if res.Requests != nil && res.Limits != nil && res.Requests.Memory != "" && !quantityEquals(res.Requests.Memory, res.Limits.Memory) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("requests", "memory"), res.Requests.Memory,
+			"must equal the memory limit with cpuPinning"))
+	}
+	return allErrs
+}
+
+// wholeCores returns a cpu quantity rounded up to whole cores, or 0 when it is unset or
+// invalid
+func wholeCores(cpu string) int32 {
+	if cpu == "" {
+		return 0
+	}
+	q, err := resource.ParseQuantity(cpu)
+	if err != nil || q.Sign() <= 0 {
+		return 0
+	}
+	return int32((q.MilliValue() + 999) / 1000)
+}
+
+// quantityEquals reports whether two quantities are valid and equal
+func quantityEquals(a, b string) bool {
+	qa, errA := resource.ParseQuantity(a)
+	qb, errB := resource.ParseQuantity(b)
+	return errA == nil && errB == nil && qa.Cmp(qb) == 0
+}
+
+// firstNonEmpty returns the first non-empty value
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
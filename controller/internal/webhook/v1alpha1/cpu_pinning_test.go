@@ -0,0 +1,107 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestDefault_CPUPinning(t *testing.T) {
+	md := &airunwayv1alpha1.ModelDeployment{
+		Spec: airunwayv1alpha1.ModelDeploymentSpec{
+			Model: airunwayv1alpha1.ModelSpec{ID: "Qwen/Qwen3-0.6B-GGUF"},
+			Resources: &airunwayv1alpha1.ResourceSpec{
+				CPU:        "3.5",
+				Memory:     "16Gi",
+				CPUPinning: &airunwayv1alpha1.CPUPinningSpec{},
+			},
+		},
+	}
+	if err := (&ModelDeploymentCustomDefaulter{}).Default(context.Background(), md); err != nil {
+		t.Fatalf("Default failed: %v", err)
+	}
+
+	pinning := md.Spec.Resources.CPUPinning
+	if pinning.ExclusiveCores != 4 || pinning.NUMAPolicy != airunwayv1alpha1.NUMAPolicySingleNUMANode {
+		t.Errorf("expected 4 cores on a single NUMA node, got %+v", pinning)
+	}
+	want := airunwayv1alpha1.ResourceQuantities{CPU: "4", Memory: "16Gi"}
+	if md.Spec.Resources.Requests == nil || *md.Spec.Resources.Requests != want {
+		t.Errorf("expected requests %+v, got %+v", want, md.Spec.Resources.Requests)
+	}
+	if md.Spec.Resources.Limits == nil || *md.Spec.Resources.Limits != want {
+		t.Errorf("expected limits %+v, got %+v", want, md.Spec.Resources.Limits)
+	}
+	if len(md.Spec.Env) != 1 || md.Spec.Env[0] != (corev1.EnvVar{Name: llamaCppThreadsEnv, Value: "4"}) {
+		t.Errorf("expected %s=4, got %+v", llamaCppThreadsEnv, md.Spec.Env)
+	}
+	for _, err := range (&ModelDeploymentCustomValidator{}).validateSpec(md) {
+		if strings.HasPrefix(err.Field, "spec.resources") {
+			t.Errorf("unexpected validation error: %v", err)
+		}
+	}
+
+	// An explicit thread count and other engines are left alone
+	md.Spec.Env = []corev1.EnvVar{{Name: llamaCppThreadsEnv, Value: "2"}}
+	defaultCPUPinning(&md.Spec)
+	if len(md.Spec.Env) != 1 || md.Spec.Env[0].Value != "2" {
+		t.Errorf("expected the explicit thread count to be kept, got %+v", md.Spec.Env)
+	}
+	md.Spec.Env = nil
+	md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeVLLM
+	defaultCPUPinning(&md.Spec)
+	if len(md.Spec.Env) != 0 {
+		t.Errorf("expected no thread count for vllm, got %+v", md.Spec.Env)
+	}
+}
+
+func TestValidateSpec_CPUPinning(t *testing.T) {
+	validator := &ModelDeploymentCustomValidator{}
+	md := &airunwayv1alpha1.ModelDeployment{
+		Spec: airunwayv1alpha1.ModelDeploymentSpec{
+			Model: airunwayv1alpha1.ModelSpec{ID: "test-model"},
+			Resources: &airunwayv1alpha1.ResourceSpec{
+				GPU:        &airunwayv1alpha1.GPUSpec{Count: 1},
+				CPUPinning: &airunwayv1alpha1.CPUPinningSpec{},
+			},
+		},
+	}
+	errs := validator.validateSpec(md)
+	requireValidationErrorField(t, errs, "spec.resources.cpuPinning")
+	requireValidationErrorField(t, errs, "spec.resources.cpuPinning.exclusiveCores")
+
+	md.Spec.Resources = &airunwayv1alpha1.ResourceSpec{
+		CPUPinning: &airunwayv1alpha1.CPUPinningSpec{ExclusiveCores: 4},
+	}
+	requireValidationErrorField(t, validator.validateSpec(md), "spec.resources.memory")
+
+	md.Spec.Resources.Requests = &airunwayv1alpha1.ResourceQuantities{CPU: "2", Memory: "8Gi"}
+	md.Spec.Resources.Limits = &airunwayv1alpha1.ResourceQuantities{CPU: "4", Memory: "8Gi"}
+	requireValidationErrorField(t, validator.validateSpec(md), "spec.resources.requests.cpu")
+
+	md.Spec.Resources.Requests.CPU = "4000m"
+	for _, err := range validator.validateSpec(md) {
+		if strings.HasPrefix(err.Field, "spec.resources") {
+			t.Errorf("unexpected validation error: %v", err)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+// supportsHotModelSwap reports whether the model of oldObj can be changed in place, which
+// requires its selected provider to declare hot model swap support for the resolved engine.
+// Deployments with a model cache volume are excluded because the cache holds the original
+// model, downloaded before the provider resource was created or pre-populated.
+func (v *ModelDeploymentCustomValidator) supportsHotModelSwap(ctx context.Context, oldObj *airunwayv1alpha1.ModelDeployment) (bool, error) {
+	if v.Client == nil || oldObj.Status.Provider == nil || oldObj.Status.Provider.Name == "" {
+		return false, nil
+	}
+	if oldObj.Spec.Model.Storage != nil {
+		for _, vol := range oldObj.Spec.Model.Storage.Volumes {
+			if vol.Purpose == airunwayv1alpha1.VolumePurposeModelCache {
+				return false, nil
+			}
+		}
+	}
+
+	var config airunwayv1alpha1.InferenceProviderConfig
+	if err := v.Client.Get(ctx, types.NamespacedName{Name: oldObj.Status.Provider.Name}, &config); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get InferenceProviderConfig %s: %w", oldObj.Status.Provider.Name, err)
+	}
+	return config.Spec.Capabilities.SupportsHotModelSwap(oldObj.ResolvedEngineType()), nil
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+func TestValidateUpdate_HotModelSwap(t *testing.T) {
+	kuberay := &airunwayv1alpha1.InferenceProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "kuberay"},
+		Spec: airunwayv1alpha1.InferenceProviderConfigSpec{
+			Capabilities: &airunwayv1alpha1.ProviderCapabilities{
+				Engines:             []airunwayv1alpha1.EngineType{airunwayv1alpha1.EngineTypeVLLM},
+				HotModelSwapEngines: []airunwayv1alpha1.EngineType{airunwayv1alpha1.EngineTypeVLLM},
+			},
+		},
+	}
+	kaito := &airunwayv1alpha1.InferenceProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "kaito"},
+		Spec: airunwayv1alpha1.InferenceProviderConfigSpec{
+			Capabilities: &airunwayv1alpha1.ProviderCapabilities{
+				Engines: []airunwayv1alpha1.EngineType{airunwayv1alpha1.EngineTypeVLLM},
+			},
+		},
+	}
+	v := newQuotaValidator(kuberay, kaito)
+
+	tests := []struct {
+		name    string
+		mutate  func(md *airunwayv1alpha1.ModelDeployment)
+		wantErr bool
+	}{
+		{
+			name: "provider supports hot swap for the engine",
+		},
+		{
+			name: "provider without hot swap",
+			mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+				md.Status.Provider.Name = "kaito"
+			},
+			wantErr: true,
+		},
+		{
+			name: "unsupported engine",
+			mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+				md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeSGLang
+			},
+			wantErr: true,
+		},
+		{
+			name: "no provider selected yet",
+			mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+				md.Status.Provider = nil
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown provider",
+			mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+				md.Status.Provider.Name = "missing"
+			},
+			wantErr: true,
+		},
+		{
+			name: "model cache volume",
+			mutate: func(md *airunwayv1alpha1.ModelDeployment) {
+				md.Spec.Model.Storage = &airunwayv1alpha1.StorageSpec{
+					Volumes: []airunwayv1alpha1.StorageVolume{{
+						Name:      "cache",
+						MountPath: "/model-cache",
+						Purpose:   airunwayv1alpha1.VolumePurposeModelCache,
+						ClaimName: "models",
+					}},
+				}
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldMD := newQuotaDeployment("swap", "", 1, 1)
+			oldMD.Status.Provider = &airunwayv1alpha1.ProviderStatus{Name: "kuberay"}
+			if tt.mutate != nil {
+				tt.mutate(oldMD)
+			}
+			md := oldMD.DeepCopy()
+			md.Spec.Model.ID = "Qwen/Qwen2.5-7B-Instruct"
+
+			_, err := v.ValidateUpdate(context.Background(), oldMD, md)
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("expected the model swap to be allowed, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), "model.id is immutable") {
+				t.Errorf("expected model.id to be immutable, got %v", err)
+			}
+		})
+	}
+}
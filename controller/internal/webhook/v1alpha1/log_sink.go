@@ -0,0 +1,66 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/pkg/provider"
+)
+
+var (
+	// logSinkLabelKeyRegex matches the label names accepted by both Loki and OTLP
+	logSinkLabelKeyRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+	// logSinkLabelValueRegex keeps label values from escaping the generated Fluent Bit
+	// configuration: no separators, variables, or whitespace
+	logSinkLabelValueRegex = regexp.MustCompile(`^[a-zA-Z0-9._:/@-]*$`)
+)
+
+// validateLogSink validates spec.observability.logSink. Its endpoint and labels are
+// written into the Fluent Bit configuration of the sidecar, so they are restricted to
+// values that cannot change its meaning.
+func validateLogSink(sink *airunwayv1alpha1.LogSinkSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if sink == nil {
+		return allErrs
+	}
+	endpointPath := fldPath.Child("endpoint")
+	allErrs = append(allErrs, validateHTTPEndpoint(sink.Endpoint, endpointPath)...)
+	if strings.ContainsAny(sink.Endpoint, "${}\n\r\t ") {
+		allErrs = append(allErrs, field.Invalid(endpointPath, sink.Endpoint, "must not contain variables or whitespace"))
+	}
+
+	labelsPath := fldPath.Child("labels")
+	for k, v := range sink.Labels {
+		switch k {
+		case provider.LogSinkLabelNamespace, provider.LogSinkLabelModelDeployment, provider.LogSinkLabelPod:
+			allErrs = append(allErrs, field.Forbidden(labelsPath.Key(k), "is set by the log sink to the identity of the ModelDeployment"))
+			continue
+		}
+		if !logSinkLabelKeyRegex.MatchString(k) {
+			allErrs = append(allErrs, field.Invalid(labelsPath.Key(k), k, "must consist of letters, digits, and underscores, and not start with a digit"))
+		}
+		if len(v) > 256 || !logSinkLabelValueRegex.MatchString(v) {
+			allErrs = append(allErrs, field.Invalid(labelsPath.Key(k), v, "must be at most 256 letters, digits, or any of ._:/@-"))
+		}
+	}
+	return allErrs
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"strings"
+	"testing"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+func TestValidateSpec_LogSink(t *testing.T) {
+	validator := &ModelDeploymentCustomValidator{}
+	md := &airunwayv1alpha1.ModelDeployment{
+		Spec: airunwayv1alpha1.ModelDeploymentSpec{
+			Model: airunwayv1alpha1.ModelSpec{ID: "test-model"},
+			Observability: &airunwayv1alpha1.ObservabilitySpec{
+				LogSink: &airunwayv1alpha1.LogSinkSpec{
+					Type:     airunwayv1alpha1.LogSinkTypeLoki,
+					Endpoint: "http://loki.observability:3100",
+					Labels:   map[string]string{"team": "search", "env": "prod-eu"},
+				},
+			},
+		},
+	}
+	for _, err := range validator.validateSpec(md) {
+		if strings.HasPrefix(err.Field, "spec.observability") {
+			t.Errorf("unexpected validation error: %v", err)
+		}
+	}
+
+	sink := md.Spec.Observability.LogSink
+	sink.Endpoint = "http://${HOST}:3100"
+	requireValidationErrorField(t, validator.validateSpec(md), "spec.observability.logSink.endpoint")
+	sink.Endpoint = "loki:3100"
+	requireValidationErrorField(t, validator.validateSpec(md), "spec.observability.logSink.endpoint")
+	sink.Endpoint = "http://loki.observability:3100"
+
+	sink.Labels = map[string]string{"namespace": "other"}
+	requireValidationErrorField(t, validator.validateSpec(md), "spec.observability.logSink.labels[namespace]")
+	sink.Labels = map[string]string{"1team": "search"}
+	requireValidationErrorField(t, validator.validateSpec(md), "spec.observability.logSink.labels[1team]")
+	// Values cannot inject further labels or Fluent Bit variables
+	sink.Labels = map[string]string{"team": "search,namespace=other"}
+	requireValidationErrorField(t, validator.validateSpec(md), "spec.observability.logSink.labels[team]")
+	sink.Labels = map[string]string{"team": "${HOSTNAME}"}
+	requireValidationErrorField(t, validator.validateSpec(md), "spec.observability.logSink.labels[team]")
+}
@@ -0,0 +1,230 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+const (
+	// DefaultHuggingFaceEndpoint is the Hugging Face Hub that model revisions are resolved against
+	DefaultHuggingFaceEndpoint = "https://huggingface.co"
+
+	// revisionResolveTimeout bounds a revision lookup, well within the webhook timeout
+	revisionResolveTimeout = 3 * time.Second
+
+	// maxHuggingFaceRepoNameLength is the longest repository name, without the
+	// organization, the Hugging Face Hub accepts
+	maxHuggingFaceRepoNameLength = 96
+)
+
+var (
+	// huggingFaceRepoID matches an "org/name" repository ID, or a legacy ID without an
+	// organization, as validated by huggingface_hub
+	huggingFaceRepoID = regexp.MustCompile(`^(?:[A-Za-z0-9](?:[\w.-]*[A-Za-z0-9])?/)?[A-Za-z0-9](?:[\w.-]*[A-Za-z0-9])?$`)
+
+	// modelRevision matches a branch, tag, or commit SHA
+	modelRevision = regexp.MustCompile(`^[\w][\w./-]*$`)
+
+	// commitSHA matches a full git commit SHA
+	commitSHA = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+	// huggingFaceURLPrefixes are stripped from model IDs copied from the Hub
+	huggingFaceURLPrefixes = []string{
+		"https://huggingface.co/", "http://huggingface.co/", "https://www.huggingface.co/",
+		"https://hf.co/", "huggingface.co/", "hf.co/", "hf://",
+	}
+)
+
+// RevisionResolver resolves a model revision to its commit SHA
+type RevisionResolver interface {
+	// ResolveRevision returns the commit SHA of revision, or of the default branch when
+	// revision is empty
+	ResolveRevision(ctx context.Context, modelID, revision string) (string, error)
+}
+
+// HuggingFaceRevisionResolver resolves revisions with the Hugging Face Hub API. Only
+// public models can be resolved, since the webhook has no access to model tokens.
+type HuggingFaceRevisionResolver struct {
+	// Endpoint is the Hub URL. Defaults to DefaultHuggingFaceEndpoint.
+	Endpoint string
+
+	// Client sends the requests. Defaults to a client with revisionResolveTimeout.
+	Client *http.Client
+}
+
+// ResolveRevision implements RevisionResolver.
+func (r *HuggingFaceRevisionResolver) ResolveRevision(ctx context.Context, modelID, revision string) (string, error) {
+	endpoint := r.Endpoint
+	if endpoint == "" {
+		endpoint = DefaultHuggingFaceEndpoint
+	}
+	httpClient := r.Client
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: revisionResolveTimeout}
+	}
+
+	// modelID is validated as org/name before it gets here, so it is path safe
+	u := strings.TrimSuffix(endpoint, "/") + "/api/models/" + modelID
+	if revision != "" {
+		u += "/revision/" + url.PathEscape(revision)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("hugging face hub returned %s", resp.Status)
+	}
+	var info struct {
+		SHA string `json:"sha"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return "", fmt.Errorf("failed to decode model info: %w", err)
+	}
+	if !commitSHA.MatchString(info.SHA) {
+		return "", fmt.Errorf("hugging face hub returned invalid commit SHA %q", info.SHA)
+	}
+	return info.SHA, nil
+}
+
+// normalizeModelID strips whitespace, Hub URL prefixes, and a trailing slash or .git from
+// a Hugging Face model ID. A revision given in the ID, as a /tree/<revision> URL path or
+// an @<revision> suffix, is returned separately.
+func normalizeModelID(id string) (string, string) {
+	id = strings.TrimSpace(id)
+	for _, prefix := range huggingFaceURLPrefixes {
+		if len(id) > len(prefix) && strings.EqualFold(id[:len(prefix)], prefix) {
+			id = id[len(prefix):]
+			break
+		}
+	}
+	var revision string
+	if i := strings.Index(id, "/tree/"); i >= 0 {
+		id, revision = id[:i], strings.TrimSuffix(id[i+len("/tree/"):], "/")
+	} else if i := strings.LastIndex(id, "@"); i >= 0 {
+		id, revision = id[:i], id[i+1:]
+	}
+	id = strings.TrimSuffix(strings.TrimSuffix(id, "/"), ".git")
+	return id, revision
+}
+
+// defaultModelID normalizes spec.model.id of a huggingface model, moving a revision in
+// the ID to spec.model.revision unless it is already set
+func defaultModelID(spec *airunwayv1alpha1.ModelDeploymentSpec) {
+	if spec.Model.Source != airunwayv1alpha1.ModelSourceHuggingFace || spec.Model.ID == "" {
+		return
+	}
+	id, revision := normalizeModelID(spec.Model.ID)
+	spec.Model.ID = id
+	if spec.Model.Revision == "" {
+		spec.Model.Revision = revision
+	}
+}
+
+// defaultModelRevision pins spec.model.revision of a huggingface model to its commit SHA
+// when a RevisionResolver is set. It runs on creation, and on updates that change
+// spec.model.id without changing a revision the webhook resolved. The requested branch
+// or tag is recorded in AnnotationModelRevisionRef, empty for the default branch.
+// Resolution is best effort: a model that cannot be resolved, such as a gated model, is
+// admitted with the revision it has.
+func (d *ModelDeploymentCustomDefaulter) defaultModelRevision(ctx context.Context, obj *airunwayv1alpha1.ModelDeployment) {
+	spec := &obj.Spec
+	if d.RevisionResolver == nil || spec.Model.Source != airunwayv1alpha1.ModelSourceHuggingFace ||
+		!huggingFaceRepoID.MatchString(spec.Model.ID) {
+		return
+	}
+
+	ref := spec.Model.Revision
+	swapped := false
+	if req, err := admission.RequestFromContext(ctx); err == nil && req.Operation == admissionv1.Update {
+		var old airunwayv1alpha1.ModelDeployment
+		if err := json.Unmarshal(req.OldObject.Raw, &old); err != nil {
+			return
+		}
+		resolvedRef, resolved := old.Annotations[airunwayv1alpha1.AnnotationModelRevisionRef]
+		if old.Spec.Model.ID == spec.Model.ID || !resolved || spec.Model.Revision != old.Spec.Model.Revision {
+			return
+		}
+		ref, swapped = resolvedRef, true
+	}
+	if commitSHA.MatchString(ref) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, revisionResolveTimeout)
+	defer cancel()
+	sha, err := d.RevisionResolver.ResolveRevision(ctx, spec.Model.ID, ref)
+	if err != nil {
+		modeldeploymentlog.Info("Unable to resolve model revision", "name", obj.GetName(),
+			"model", spec.Model.ID, "revision", ref, "error", err.Error())
+		if swapped {
+			// The pinned SHA belongs to the previous model, so fall back to the requested ref
+			spec.Model.Revision = ref
+			delete(obj.Annotations, airunwayv1alpha1.AnnotationModelRevisionRef)
+		}
+		return
+	}
+	spec.Model.Revision = sha
+	if obj.Annotations == nil {
+		obj.Annotations = map[string]string{}
+	}
+	obj.Annotations[airunwayv1alpha1.AnnotationModelRevisionRef] = ref
+}
+
+// validateModelID checks spec.model.id of a huggingface model and spec.model.revision
+func validateModelID(model *airunwayv1alpha1.ModelSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	huggingFace := model.Source == airunwayv1alpha1.ModelSourceHuggingFace || model.Source == ""
+	if huggingFace && model.ID != "" {
+		name := model.ID[strings.LastIndex(model.ID, "/")+1:]
+		if !huggingFaceRepoID.MatchString(model.ID) || strings.Contains(model.ID, "--") || strings.Contains(model.ID, "..") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("id"), model.ID,
+				"must be a HuggingFace model ID of the form org/name, using letters, digits, '-', '_', and '.'"))
+		} else if len(name) > maxHuggingFaceRepoNameLength {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("id"), model.ID,
+				fmt.Sprintf("model name must be at most %d characters", maxHuggingFaceRepoNameLength)))
+		}
+	}
+	if model.Revision != "" {
+		if !huggingFace {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("revision"), "revision is only supported for huggingface models"))
+		} else if !modelRevision.MatchString(model.Revision) || strings.Contains(model.Revision, "..") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("revision"), model.Revision,
+				"must be a branch, tag, or commit SHA"))
+		}
+	}
+	return allErrs
+}
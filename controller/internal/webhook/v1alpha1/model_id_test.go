@@ -0,0 +1,208 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+const (
+	testSHA      = "0123456789abcdef0123456789abcdef01234567"
+	testOtherSHA = "89abcdef0123456789abcdef0123456789abcdef"
+)
+
+// fakeRevisionResolver resolves revisions from a map keyed by "modelID@revision"
+type fakeRevisionResolver struct {
+	shas  map[string]string
+	calls int
+}
+
+func (f *fakeRevisionResolver) ResolveRevision(_ context.Context, modelID, revision string) (string, error) {
+	f.calls++
+	if sha, ok := f.shas[modelID+"@"+revision]; ok {
+		return sha, nil
+	}
+	return "", errors.New("not found")
+}
+
+func TestNormalizeModelID(t *testing.T) {
+	tests := []struct {
+		id, wantID, wantRevision string
+	}{
+		{"meta-llama/Llama-3.1-8B-Instruct", "meta-llama/Llama-3.1-8B-Instruct", ""},
+		{"  Qwen/Qwen3-0.6B ", "Qwen/Qwen3-0.6B", ""},
+		{"https://huggingface.co/Qwen/Qwen3-0.6B", "Qwen/Qwen3-0.6B", ""},
+		{"HTTPS://HuggingFace.co/Qwen/Qwen3-0.6B/", "Qwen/Qwen3-0.6B", ""},
+		{"hf.co/Qwen/Qwen3-0.6B.git", "Qwen/Qwen3-0.6B", ""},
+		{"hf://Qwen/Qwen3-0.6B", "Qwen/Qwen3-0.6B", ""},
+		{"https://huggingface.co/Qwen/Qwen3-0.6B/tree/v1.0/", "Qwen/Qwen3-0.6B", "v1.0"},
+		{"Qwen/Qwen3-0.6B@" + testSHA, "Qwen/Qwen3-0.6B", testSHA},
+		{"gpt2", "gpt2", ""},
+	}
+	for _, tt := range tests {
+		id, revision := normalizeModelID(tt.id)
+		if id != tt.wantID || revision != tt.wantRevision {
+			t.Errorf("normalizeModelID(%q) = %q, %q, want %q, %q", tt.id, id, revision, tt.wantID, tt.wantRevision)
+		}
+	}
+}
+
+func TestDefault_ModelID(t *testing.T) {
+	md := &airunwayv1alpha1.ModelDeployment{
+		Spec: airunwayv1alpha1.ModelDeploymentSpec{
+			Model: airunwayv1alpha1.ModelSpec{ID: "https://huggingface.co/Qwen/Qwen3-0.6B/tree/v1.0"},
+		},
+	}
+	if err := (&ModelDeploymentCustomDefaulter{}).Default(context.Background(), md); err != nil {
+		t.Fatalf("Default failed: %v", err)
+	}
+	if md.Spec.Model.ID != "Qwen/Qwen3-0.6B" || md.Spec.Model.Revision != "v1.0" {
+		t.Errorf("expected the ID and revision to be split, got %q and %q", md.Spec.Model.ID, md.Spec.Model.Revision)
+	}
+
+	// An explicit revision wins over one in the ID, and custom models are left alone
+	md.Spec.Model = airunwayv1alpha1.ModelSpec{ID: "Qwen/Qwen3-0.6B@v2.0", Source: airunwayv1alpha1.ModelSourceHuggingFace, Revision: "main"}
+	defaultModelID(&md.Spec)
+	if md.Spec.Model.ID != "Qwen/Qwen3-0.6B" || md.Spec.Model.Revision != "main" {
+		t.Errorf("expected the explicit revision to be kept, got %q and %q", md.Spec.Model.ID, md.Spec.Model.Revision)
+	}
+	md.Spec.Model = airunwayv1alpha1.ModelSpec{ID: "/models/my-model/", Source: airunwayv1alpha1.ModelSourceCustom}
+	defaultModelID(&md.Spec)
+	if md.Spec.Model.ID != "/models/my-model/" {
+		t.Errorf("expected custom model IDs to be kept, got %q", md.Spec.Model.ID)
+	}
+}
+
+func TestDefaultModelRevision(t *testing.T) {
+	resolver := &fakeRevisionResolver{shas: map[string]string{
+		"Qwen/Qwen3-0.6B@":     testSHA,
+		"Qwen/Qwen3-0.6B@v1.0": testOtherSHA,
+		"Qwen/Qwen3-1.7B@":     testOtherSHA,
+	}}
+	d := &ModelDeploymentCustomDefaulter{RevisionResolver: resolver}
+	newMD := func(revision string) *airunwayv1alpha1.ModelDeployment {
+		return &airunwayv1alpha1.ModelDeployment{
+			Spec: airunwayv1alpha1.ModelDeploymentSpec{
+				Model: airunwayv1alpha1.ModelSpec{
+					ID:       "Qwen/Qwen3-0.6B",
+					Source:   airunwayv1alpha1.ModelSourceHuggingFace,
+					Revision: revision,
+				},
+			},
+		}
+	}
+	ctx := context.Background()
+
+	// The default branch is pinned and recorded as an empty ref
+	md := newMD("")
+	d.defaultModelRevision(ctx, md)
+	if md.Spec.Model.Revision != testSHA {
+		t.Errorf("expected the default branch SHA, got %q", md.Spec.Model.Revision)
+	}
+	if ref, ok := md.Annotations[airunwayv1alpha1.AnnotationModelRevisionRef]; !ok || ref != "" {
+		t.Errorf("expected an empty revision ref annotation, got %q (%v)", ref, ok)
+	}
+
+	// A tag is pinned to its SHA
+	md = newMD("v1.0")
+	d.defaultModelRevision(ctx, md)
+	if md.Spec.Model.Revision != testOtherSHA || md.Annotations[airunwayv1alpha1.AnnotationModelRevisionRef] != "v1.0" {
+		t.Errorf("expected v1.0 to be pinned, got %q and %v", md.Spec.Model.Revision, md.Annotations)
+	}
+
+	// A commit SHA is kept without a lookup, and an unresolvable revision is admitted as is
+	calls := resolver.calls
+	md = newMD(testSHA)
+	d.defaultModelRevision(ctx, md)
+	if resolver.calls != calls || md.Annotations != nil {
+		t.Error("expected a commit SHA not to be resolved")
+	}
+	md = newMD("missing")
+	d.defaultModelRevision(ctx, md)
+	if md.Spec.Model.Revision != "missing" || md.Annotations != nil {
+		t.Errorf("expected an unresolved revision to be kept, got %q and %v", md.Spec.Model.Revision, md.Annotations)
+	}
+
+	// Swapping the model re-resolves a pinned revision
+	old := newMD(testSHA)
+	old.Annotations = map[string]string{airunwayv1alpha1.AnnotationModelRevisionRef: ""}
+	raw, err := json.Marshal(old)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	updateCtx := admission.NewContextWithRequest(ctx, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+		Operation: admissionv1.Update,
+		OldObject: runtime.RawExtension{Raw: raw},
+	}})
+	md = old.DeepCopy()
+	d.defaultModelRevision(updateCtx, md)
+	if md.Spec.Model.Revision != testSHA {
+		t.Errorf("expected an update without a model change to keep the revision, got %q", md.Spec.Model.Revision)
+	}
+	md.Spec.Model.ID = "Qwen/Qwen3-1.7B"
+	d.defaultModelRevision(updateCtx, md)
+	if md.Spec.Model.Revision != testOtherSHA {
+		t.Errorf("expected the new model's default branch SHA, got %q", md.Spec.Model.Revision)
+	}
+	md = old.DeepCopy()
+	md.Spec.Model.ID = "Qwen/Qwen3-4B"
+	d.defaultModelRevision(updateCtx, md)
+	if md.Spec.Model.Revision != "" {
+		t.Errorf("expected the previous model's SHA to be dropped, got %q", md.Spec.Model.Revision)
+	}
+	if _, ok := md.Annotations[airunwayv1alpha1.AnnotationModelRevisionRef]; ok {
+		t.Error("expected the revision ref annotation to be removed")
+	}
+}
+
+func TestHuggingFaceRevisionResolver(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.EscapedPath() {
+		case "/api/models/Qwen/Qwen3-0.6B":
+			_, _ = w.Write([]byte(`{"id":"Qwen/Qwen3-0.6B","sha":"` + testSHA + `"}`))
+		case "/api/models/Qwen/Qwen3-0.6B/revision/release%2Fv1":
+			_, _ = w.Write([]byte(`{"id":"Qwen/Qwen3-0.6B","sha":"` + testOtherSHA + `"}`))
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+	r := &HuggingFaceRevisionResolver{Endpoint: server.URL}
+	ctx := context.Background()
+
+	if sha, err := r.ResolveRevision(ctx, "Qwen/Qwen3-0.6B", ""); err != nil || sha != testSHA {
+		t.Errorf("expected the default branch SHA, got %q (%v)", sha, err)
+	}
+	if sha, err := r.ResolveRevision(ctx, "Qwen/Qwen3-0.6B", "release/v1"); err != nil || sha != testOtherSHA {
+		t.Errorf("expected the branch SHA, got %q (%v)", sha, err)
+	}
+	if _, err := r.ResolveRevision(ctx, "meta-llama/Llama-3.1-8B", ""); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("expected gated models to fail, got %v", err)
+	}
+}
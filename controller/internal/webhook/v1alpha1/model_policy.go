@@ -0,0 +1,167 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+// +kubebuilder:rbac:groups=airunway.ai,resources=modelpolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+
+// validateModelPolicies checks a ModelDeployment against the ModelPolicies selecting its
+// namespace. Violations of Deny policies are returned as errors and violations of Audit
+// policies as warnings; both are recorded as events on the policy. Updates are only checked
+// when spec.model.id or spec.model.license changes, so deployments admitted before a policy
+// was added can still be updated.
+func (v *ModelDeploymentCustomValidator) validateModelPolicies(ctx context.Context, oldObj, newObj *airunwayv1alpha1.ModelDeployment) (field.ErrorList, admission.Warnings, error) {
+	if v.Client == nil {
+		return nil, nil, nil
+	}
+	if oldObj != nil && oldObj.Spec.Model.ID == newObj.Spec.Model.ID && oldObj.Spec.Model.License == newObj.Spec.Model.License {
+		return nil, nil, nil
+	}
+
+	var policies airunwayv1alpha1.ModelPolicyList
+	if err := v.Client.List(ctx, &policies); err != nil {
+		return nil, nil, fmt.Errorf("failed to list ModelPolicies: %w", err)
+	}
+	if len(policies.Items) == 0 {
+		return nil, nil, nil
+	}
+
+	var namespace corev1.Namespace
+	if err := v.Client.Get(ctx, client.ObjectKey{Name: newObj.Namespace}, &namespace); err != nil {
+		return nil, nil, fmt.Errorf("failed to get namespace %s: %w", newObj.Namespace, err)
+	}
+
+	var allErrs field.ErrorList
+	var warnings admission.Warnings
+	for i := range policies.Items {
+		policy := &policies.Items[i]
+		selected, err := policySelectsNamespace(policy, &namespace)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !selected {
+			continue
+		}
+		fieldPath, violation := modelPolicyViolation(policy, &newObj.Spec.Model)
+		if violation == "" {
+			continue
+		}
+
+		msg := fmt.Sprintf("violates ModelPolicy %s: %s", policy.Name, violation)
+		v.recordPolicyViolation(ctx, policy, newObj, msg)
+		if policy.Spec.Action == airunwayv1alpha1.ModelPolicyActionAudit {
+			warnings = append(warnings, fmt.Sprintf("%s %s", fieldPath, msg))
+			continue
+		}
+		allErrs = append(allErrs, field.Forbidden(fieldPath, msg))
+	}
+	return allErrs, warnings, nil
+}
+
+// policySelectsNamespace reports whether the namespaceSelector of policy matches namespace
+func policySelectsNamespace(policy *airunwayv1alpha1.ModelPolicy, namespace *corev1.Namespace) (bool, error) {
+	if policy.Spec.NamespaceSelector == nil {
+		return true, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(policy.Spec.NamespaceSelector)
+	if err != nil {
+		return false, fmt.Errorf("invalid namespaceSelector in ModelPolicy %s: %w", policy.Name, err)
+	}
+	return selector.Matches(labels.Set(namespace.Labels)), nil
+}
+
+// modelPolicyViolation returns the field that violates policy and why, or an empty string
+// when the model is allowed
+func modelPolicyViolation(policy *airunwayv1alpha1.ModelPolicy, model *airunwayv1alpha1.ModelSpec) (*field.Path, string) {
+	licensePath := field.NewPath("spec", "model", "license")
+	idPath := field.NewPath("spec", "model", "id")
+	spec := &policy.Spec
+
+	if containsFold(spec.DeniedLicenses, model.License) {
+		return licensePath, fmt.Sprintf("license %q is denied", model.License)
+	}
+	if len(spec.AllowedLicenses) > 0 && !containsFold(spec.AllowedLicenses, model.License) {
+		if model.License == "" {
+			return licensePath, fmt.Sprintf("a license is required, allowed: %s", strings.Join(spec.AllowedLicenses, ", "))
+		}
+		return licensePath, fmt.Sprintf("license %q is not allowed, allowed: %s", model.License, strings.Join(spec.AllowedLicenses, ", "))
+	}
+	if pattern, ok := matchModelPattern(spec.DeniedModels, model.ID); ok {
+		return idPath, fmt.Sprintf("model %q matches denied pattern %q", model.ID, pattern)
+	}
+	if len(spec.AllowedModels) > 0 {
+		if _, ok := matchModelPattern(spec.AllowedModels, model.ID); !ok {
+			return idPath, fmt.Sprintf("model %q matches none of the allowed patterns: %s", model.ID, strings.Join(spec.AllowedModels, ", "))
+		}
+	}
+	return nil, ""
+}
+
+// containsFold reports whether values contains value, ignoring case. An empty value is
+// never contained.
+func containsFold(values []string, value string) bool {
+	if value == "" {
+		return false
+	}
+	return slices.ContainsFunc(values, func(v string) bool { return strings.EqualFold(v, value) })
+}
+
+// matchModelPattern returns the first glob pattern in patterns matching id. Malformed
+// patterns never match.
+func matchModelPattern(patterns []string, id string) (string, bool) {
+	for _, pattern := range patterns {
+		if ok, err := path.Match(pattern, id); err == nil && ok {
+			return pattern, true
+		}
+	}
+	return "", false
+}
+
+// recordPolicyViolation emits a warning event on policy for a violating ModelDeployment so
+// cluster admins can audit violations, including rejected deployments that never existed.
+// Dry-run requests are not recorded.
+func (v *ModelDeploymentCustomValidator) recordPolicyViolation(ctx context.Context, policy *airunwayv1alpha1.ModelPolicy, md *airunwayv1alpha1.ModelDeployment, msg string) {
+	if v.Recorder == nil {
+		return
+	}
+	if req, err := admission.RequestFromContext(ctx); err == nil && req.DryRun != nil && *req.DryRun {
+		return
+	}
+	action := "Admit"
+	if policy.Spec.Action != airunwayv1alpha1.ModelPolicyActionAudit {
+		action = "Deny"
+	}
+	v.Recorder.Eventf(policy, md, corev1.EventTypeWarning, airunwayv1alpha1.ReasonModelPolicyViolation, action,
+		"ModelDeployment %s/%s %s", md.Namespace, md.Name, msg)
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+func newPolicyValidator(objs ...client.Object) (*ModelDeploymentCustomValidator, *events.FakeRecorder) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = airunwayv1alpha1.AddToScheme(scheme)
+	objs = append(objs,
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"environment": "production"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "sandbox"}},
+	)
+	recorder := events.NewFakeRecorder(10)
+	return &ModelDeploymentCustomValidator{
+		Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(),
+		Recorder: recorder,
+	}, recorder
+}
+
+func newProductionPolicy(action airunwayv1alpha1.ModelPolicyAction) *airunwayv1alpha1.ModelPolicy {
+	return &airunwayv1alpha1.ModelPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "production"},
+		Spec: airunwayv1alpha1.ModelPolicySpec{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"environment": "production"}},
+			AllowedLicenses:   []string{"apache-2.0", "llama3.1"},
+			DeniedModels:      []string{"evil-org/*"},
+			Action:            action,
+		},
+	}
+}
+
+func TestModelPolicyViolation(t *testing.T) {
+	policy := newProductionPolicy(airunwayv1alpha1.ModelPolicyActionDeny)
+	policy.Spec.DeniedLicenses = []string{"cc-by-nc-4.0"}
+	policy.Spec.AllowedModels = []string{"meta-llama/*", "Qwen/*"}
+
+	tests := []struct {
+		name    string
+		model   airunwayv1alpha1.ModelSpec
+		field   string
+		message string
+	}{
+		{"allowed", airunwayv1alpha1.ModelSpec{ID: "meta-llama/Llama-3.1-8B-Instruct", License: "Llama3.1"}, "", ""},
+		{"denied license", airunwayv1alpha1.ModelSpec{ID: "Qwen/Qwen3-8B", License: "CC-BY-NC-4.0"}, "spec.model.license", "is denied"},
+		{"license not allowed", airunwayv1alpha1.ModelSpec{ID: "Qwen/Qwen3-8B", License: "mit"}, "spec.model.license", "is not allowed"},
+		{"license missing", airunwayv1alpha1.ModelSpec{ID: "Qwen/Qwen3-8B"}, "spec.model.license", "a license is required"},
+		{"denied model", airunwayv1alpha1.ModelSpec{ID: "evil-org/model", License: "apache-2.0"}, "spec.model.id", "denied pattern"},
+		{"model not allowed", airunwayv1alpha1.ModelSpec{ID: "mistralai/Mistral-7B", License: "apache-2.0"}, "spec.model.id", "none of the allowed patterns"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, msg := modelPolicyViolation(policy, &tt.model)
+			if tt.message == "" {
+				if msg != "" {
+					t.Errorf("expected no violation, got %q", msg)
+				}
+				return
+			}
+			if path == nil || path.String() != tt.field || !strings.Contains(msg, tt.message) {
+				t.Errorf("expected %s violation containing %q, got %v: %q", tt.field, tt.message, path, msg)
+			}
+		})
+	}
+}
+
+func TestValidateModelPolicies(t *testing.T) {
+	v, recorder := newPolicyValidator(newProductionPolicy(airunwayv1alpha1.ModelPolicyActionDeny))
+	ctx := context.Background()
+
+	md := newQuotaDeployment("nc-model", "", 1, 1)
+	md.Spec.Model.License = "cc-by-nc-4.0"
+	errs, warnings, err := v.validateModelPolicies(ctx, nil, md)
+	if err != nil {
+		t.Fatalf("validateModelPolicies failed: %v", err)
+	}
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "violates ModelPolicy production") || len(warnings) != 0 {
+		t.Fatalf("expected one ModelPolicy error, got %v and warnings %v", errs, warnings)
+	}
+	select {
+	case e := <-recorder.Events:
+		if !strings.Contains(e, "Warning "+airunwayv1alpha1.ReasonModelPolicyViolation) || !strings.Contains(e, "team-a/nc-model") {
+			t.Errorf("unexpected event %q", e)
+		}
+	default:
+		t.Error("expected a ModelPolicyViolation event")
+	}
+
+	// Namespaces not selected by the policy are not restricted
+	md.Namespace = "sandbox"
+	if errs, _, err := v.validateModelPolicies(ctx, nil, md); err != nil || len(errs) != 0 {
+		t.Errorf("expected sandbox to be unrestricted, got %v, %v", errs, err)
+	}
+
+	// Updates that keep the model and license are admitted
+	md.Namespace = "team-a"
+	updated := md.DeepCopy()
+	updated.Spec.Scaling.Replicas = 2
+	if errs, _, err := v.validateModelPolicies(ctx, md, updated); err != nil || len(errs) != 0 {
+		t.Errorf("expected unchanged model to be admitted, got %v, %v", errs, err)
+	}
+	updated.Spec.Model.License = "apache-2.0"
+	if errs, _, err := v.validateModelPolicies(ctx, md, updated); err != nil || len(errs) != 0 {
+		t.Errorf("expected allowed license to be admitted, got %v, %v", errs, err)
+	}
+}
+
+func TestValidateModelPolicies_Audit(t *testing.T) {
+	v, recorder := newPolicyValidator(newProductionPolicy(airunwayv1alpha1.ModelPolicyActionAudit))
+
+	md := newQuotaDeployment("unlicensed", "", 1, 1)
+	errs, warnings, err := v.validateModelPolicies(context.Background(), nil, md)
+	if err != nil {
+		t.Fatalf("validateModelPolicies failed: %v", err)
+	}
+	if len(errs) != 0 || len(warnings) != 1 || !strings.Contains(warnings[0], "a license is required") {
+		t.Errorf("expected an audit warning only, got %v and warnings %v", errs, warnings)
+	}
+	if len(recorder.Events) != 1 {
+		t.Errorf("expected an audit event, got %d", len(recorder.Events))
+	}
+}
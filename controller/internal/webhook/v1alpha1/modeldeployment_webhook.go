@@ -20,17 +20,31 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"net/url"
 	"reflect"
+	"slices"
 	"strings"
+	"time"
+	"unicode"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/internal/gateway"
+	"github.com/kaito-project/airunway/controller/internal/warmup"
+	"github.com/kaito-project/airunway/controller/pkg/provider"
 )
 
 const (
@@ -49,10 +63,16 @@ const (
 var modeldeploymentlog = logf.Log.WithName("modeldeployment-resource")
 
 // SetupModelDeploymentWebhookWithManager registers the webhook for ModelDeployment in the manager.
-func SetupModelDeploymentWebhookWithManager(mgr ctrl.Manager) error {
+// revisionResolver pins model revisions to commit SHAs; when nil, they are not resolved.
+// allowedProviders are provider names accepted without a registered InferenceProviderConfig.
+func SetupModelDeploymentWebhookWithManager(mgr ctrl.Manager, revisionResolver RevisionResolver, allowedProviders []string) error {
 	return ctrl.NewWebhookManagedBy(mgr, &airunwayv1alpha1.ModelDeployment{}).
-		WithValidator(&ModelDeploymentCustomValidator{}).
-		WithDefaulter(&ModelDeploymentCustomDefaulter{}).
+		WithValidator(&ModelDeploymentCustomValidator{
+			Client:           mgr.GetClient(),
+			Recorder:         mgr.GetEventRecorder("modeldeployment-webhook"),
+			AllowedProviders: allowedProviders,
+		}).
+		WithDefaulter(&ModelDeploymentCustomDefaulter{RevisionResolver: revisionResolver}).
 		Complete()
 }
 
@@ -60,10 +80,14 @@ func SetupModelDeploymentWebhookWithManager(mgr ctrl.Manager) error {
 
 // ModelDeploymentCustomDefaulter struct is responsible for setting default values on the custom resource of the
 // Kind ModelDeployment when those are created or updated.
-type ModelDeploymentCustomDefaulter struct{}
+type ModelDeploymentCustomDefaulter struct {
+	// RevisionResolver pins spec.model.revision to a commit SHA. When nil, revisions are
+	// not resolved.
+	RevisionResolver RevisionResolver
+}
 
 // Default implements webhook.CustomDefaulter so a webhook will be registered for the Kind ModelDeployment.
-func (d *ModelDeploymentCustomDefaulter) Default(_ context.Context, obj *airunwayv1alpha1.ModelDeployment) error {
+func (d *ModelDeploymentCustomDefaulter) Default(ctx context.Context, obj *airunwayv1alpha1.ModelDeployment) error {
 	modeldeploymentlog.Info("Defaulting for ModelDeployment", "name", obj.GetName())
 
 	spec := &obj.Spec
@@ -73,6 +97,10 @@ func (d *ModelDeploymentCustomDefaulter) Default(_ context.Context, obj *airunwa
 		spec.Model.Source = airunwayv1alpha1.ModelSourceHuggingFace
 	}
 
+	// Normalize the model ID and pin its revision
+	defaultModelID(spec)
+	d.defaultModelRevision(ctx, obj)
+
 	// Default serving mode to aggregated
 	if spec.Serving == nil {
 		spec.Serving = &airunwayv1alpha1.ServingSpec{
@@ -80,6 +108,8 @@ func (d *ModelDeploymentCustomDefaulter) Default(_ context.Context, obj *airunwa
 		}
 	} else if spec.Serving.Mode == "" {
 		spec.Serving.Mode = airunwayv1alpha1.ServingModeAggregated
+	} else if spec.Serving.Mode == airunwayv1alpha1.ServingModeAuto {
+		defaultServingMode(obj)
 	}
 
 	// Default scaling replicas to 1 for aggregated mode
@@ -94,8 +124,10 @@ func (d *ModelDeploymentCustomDefaulter) Default(_ context.Context, obj *airunwa
 		}
 	}
 
-	// Default GPU to 1 in aggregated mode when resources are unspecified
-	if spec.Serving.Mode == airunwayv1alpha1.ServingModeAggregated && spec.Resources == nil {
+	// Default GPU to 1 in aggregated mode when resources are unspecified,
+	// unless CPU inference was requested
+	if spec.Serving.Mode == airunwayv1alpha1.ServingModeAggregated && spec.Resources == nil &&
+		spec.Engine.Device != airunwayv1alpha1.EngineDeviceCPU {
 		spec.Resources = &airunwayv1alpha1.ResourceSpec{
 			GPU: &airunwayv1alpha1.GPUSpec{
 				Count: 1,
@@ -119,6 +151,9 @@ func (d *ModelDeploymentCustomDefaulter) Default(_ context.Context, obj *airunwa
 		}
 	}
 
+	// Derive the resources of exclusive CPU cores
+	defaultCPUPinning(spec)
+
 	// Default storage volume fields
 	if spec.Model.Storage != nil {
 		for i := range spec.Model.Storage.Volumes {
@@ -157,10 +192,22 @@ func (d *ModelDeploymentCustomDefaulter) Default(_ context.Context, obj *airunwa
 
 // ModelDeploymentCustomValidator struct is responsible for validating the ModelDeployment resource
 // when it is created, updated, or deleted.
-type ModelDeploymentCustomValidator struct{}
+type ModelDeploymentCustomValidator struct {
+	// Client reads ModelDeploymentQuotas and the ModelDeployments counted against them,
+	// ModelPolicies and the namespaces they select, and InferenceProviderConfigs. When nil,
+	// none of them is enforced.
+	Client client.Reader
+
+	// Recorder emits ModelPolicy violation events. When nil, no events are emitted.
+	Recorder events.EventRecorder
+
+	// AllowedProviders are spec.provider.name values accepted without a registered
+	// InferenceProviderConfig, e.g. providers installed after their deployments.
+	AllowedProviders []string
+}
 
 // ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type ModelDeployment.
-func (v *ModelDeploymentCustomValidator) ValidateCreate(_ context.Context, obj *airunwayv1alpha1.ModelDeployment) (admission.Warnings, error) {
+func (v *ModelDeploymentCustomValidator) ValidateCreate(ctx context.Context, obj *airunwayv1alpha1.ModelDeployment) (admission.Warnings, error) {
 	modeldeploymentlog.Info("Validation for ModelDeployment upon creation", "name", obj.GetName())
 
 	var warnings admission.Warnings
@@ -178,6 +225,28 @@ func (v *ModelDeploymentCustomValidator) ValidateCreate(_ context.Context, obj *
 	// Validate the spec
 	allErrs = append(allErrs, v.validateSpec(obj)...)
 
+	// Reject provider names no InferenceProviderConfig is registered for
+	providerErrs, err := v.validateProviderName(ctx, nil, obj)
+	if err != nil {
+		return warnings, err
+	}
+	allErrs = append(allErrs, providerErrs...)
+
+	// Enforce ModelDeploymentQuotas in the namespace
+	quotaErrs, err := v.validateQuota(ctx, nil, obj)
+	if err != nil {
+		return warnings, err
+	}
+	allErrs = append(allErrs, quotaErrs...)
+
+	// Enforce ModelPolicies selecting the namespace
+	policyErrs, policyWarnings, err := v.validateModelPolicies(ctx, nil, obj)
+	if err != nil {
+		return warnings, err
+	}
+	allErrs = append(allErrs, policyErrs...)
+	warnings = append(warnings, policyWarnings...)
+
 	// Check for warnings
 	warnings = append(warnings, v.checkWarnings(obj)...)
 
@@ -188,7 +257,7 @@ func (v *ModelDeploymentCustomValidator) ValidateCreate(_ context.Context, obj *
 }
 
 // ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type ModelDeployment.
-func (v *ModelDeploymentCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj *airunwayv1alpha1.ModelDeployment) (admission.Warnings, error) {
+func (v *ModelDeploymentCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj *airunwayv1alpha1.ModelDeployment) (admission.Warnings, error) {
 	modeldeploymentlog.Info("Validation for ModelDeployment upon update", "name", newObj.GetName())
 
 	var warnings admission.Warnings
@@ -198,7 +267,36 @@ func (v *ModelDeploymentCustomValidator) ValidateUpdate(_ context.Context, oldOb
 	allErrs = append(allErrs, v.validateSpec(newObj)...)
 
 	// Validate immutable fields (identity fields that trigger delete+recreate)
-	allErrs = append(allErrs, v.validateImmutableFields(oldObj, newObj)...)
+	hotModelSwap := false
+	if oldObj.Spec.Model.ID != newObj.Spec.Model.ID {
+		var err error
+		if hotModelSwap, err = v.supportsHotModelSwap(ctx, oldObj); err != nil {
+			return warnings, err
+		}
+	}
+	allErrs = append(allErrs, v.validateImmutableFields(oldObj, newObj, hotModelSwap)...)
+
+	// Reject provider names no InferenceProviderConfig is registered for
+	providerErrs, err := v.validateProviderName(ctx, oldObj, newObj)
+	if err != nil {
+		return warnings, err
+	}
+	allErrs = append(allErrs, providerErrs...)
+
+	// Enforce ModelDeploymentQuotas in the namespace
+	quotaErrs, err := v.validateQuota(ctx, oldObj, newObj)
+	if err != nil {
+		return warnings, err
+	}
+	allErrs = append(allErrs, quotaErrs...)
+
+	// Enforce ModelPolicies selecting the namespace
+	policyErrs, policyWarnings, err := v.validateModelPolicies(ctx, oldObj, newObj)
+	if err != nil {
+		return warnings, err
+	}
+	allErrs = append(allErrs, policyErrs...)
+	warnings = append(warnings, policyWarnings...)
 
 	// Check for warnings
 	warnings = append(warnings, v.checkWarnings(newObj)...)
@@ -232,6 +330,7 @@ func (v *ModelDeploymentCustomValidator) validateSpec(obj *airunwayv1alpha1.Mode
 			))
 		}
 	}
+	allErrs = append(allErrs, validateModelID(&spec.Model, specPath.Child("model"))...)
 
 	// Validate engine type if set (empty is allowed - controller will auto-select)
 	if spec.Engine.Type != "" {
@@ -255,6 +354,9 @@ func (v *ModelDeploymentCustomValidator) validateSpec(obj *airunwayv1alpha1.Mode
 	if spec.Resources != nil {
 		allErrs = append(allErrs, validateResourceQuantity(spec.Resources.CPU, MaxCPU, specPath.Child("resources", "cpu"))...)
 		allErrs = append(allErrs, validateResourceQuantity(spec.Resources.Memory, MaxMemory, specPath.Child("resources", "memory"))...)
+		allErrs = append(allErrs, validateRequestsLimits(spec.Resources.CPU, spec.Resources.Memory, spec.Resources.Requests, spec.Resources.Limits, specPath.Child("resources"))...)
+		allErrs = append(allErrs, validateAutotune(spec.Resources, specPath.Child("resources"))...)
+		allErrs = append(allErrs, validateCPUPinning(spec, specPath.Child("resources"))...)
 	}
 	if spec.Scaling != nil {
 		if spec.Scaling.Replicas > MaxReplicas {
@@ -268,6 +370,7 @@ func (v *ModelDeploymentCustomValidator) validateSpec(obj *airunwayv1alpha1.Mode
 				allErrs = append(allErrs, field.Invalid(specPath.Child("scaling", "prefill", "gpu", "count"), spec.Scaling.Prefill.GPU.Count, fmt.Sprintf("exceeds maximum GPU count (%d)", MaxGPUCount)))
 			}
 			allErrs = append(allErrs, validateResourceQuantity(spec.Scaling.Prefill.Memory, MaxMemory, specPath.Child("scaling", "prefill", "memory"))...)
+			allErrs = append(allErrs, validateRequestsLimits("", spec.Scaling.Prefill.Memory, spec.Scaling.Prefill.Requests, spec.Scaling.Prefill.Limits, specPath.Child("scaling", "prefill"))...)
 		}
 		if spec.Scaling.Decode != nil {
 			if spec.Scaling.Decode.Replicas > MaxReplicas {
@@ -277,29 +380,111 @@ func (v *ModelDeploymentCustomValidator) validateSpec(obj *airunwayv1alpha1.Mode
 				allErrs = append(allErrs, field.Invalid(specPath.Child("scaling", "decode", "gpu", "count"), spec.Scaling.Decode.GPU.Count, fmt.Sprintf("exceeds maximum GPU count (%d)", MaxGPUCount)))
 			}
 			allErrs = append(allErrs, validateResourceQuantity(spec.Scaling.Decode.Memory, MaxMemory, specPath.Child("scaling", "decode", "memory"))...)
+			allErrs = append(allErrs, validateRequestsLimits("", spec.Scaling.Decode.Memory, spec.Scaling.Decode.Requests, spec.Scaling.Decode.Limits, specPath.Child("scaling", "decode"))...)
 		}
 	}
 
+	allErrs = append(allErrs, validateGPUTypes(spec, specPath)...)
+	allErrs = append(allErrs, validateChatTemplate(&spec.Model, specPath.Child("model"))...)
+
+	if spec.Serving != nil && spec.Serving.Router != nil {
+		allErrs = append(allErrs, validateRouter(spec.Serving.Router, specPath.Child("serving", "router"))...)
+	}
+
 	// Validate provider overrides don't contain dangerous fields
-	allErrs = append(allErrs, v.validateOverrides(spec, specPath)...)
+	if overrideErrs := v.validateOverrides(spec, specPath); len(overrideErrs) > 0 {
+		allErrs = append(allErrs, overrideErrs...)
+	} else if _, err := provider.RenderOverrides(obj); err != nil {
+		// Templates are rendered here too so that typos surface at admission
+		// instead of as provider reconcile failures.
+		allErrs = append(allErrs, field.Invalid(
+			specPath.Child("provider", "overrides"),
+			fmt.Sprintf("<redacted %d bytes>", len(spec.Provider.Overrides.Raw)),
+			err.Error(),
+		))
+	}
 
 	servingMode := airunwayv1alpha1.ServingModeAggregated
 	if spec.Serving != nil && spec.Serving.Mode != "" {
 		servingMode = spec.Serving.Mode
 	}
 
+	switch spec.Engine.Device {
+	case airunwayv1alpha1.EngineDeviceCPU:
+		devicePath := specPath.Child("engine", "device")
+		if gpuCount > 0 {
+			allErrs = append(allErrs, field.Invalid(devicePath, spec.Engine.Device,
+				"cpu device cannot be combined with resources.gpu.count > 0"))
+		}
+		if servingMode == airunwayv1alpha1.ServingModeDisaggregated {
+			allErrs = append(allErrs, field.Invalid(devicePath, spec.Engine.Device,
+				"cpu device is not supported in disaggregated mode"))
+		}
+		switch spec.Engine.Type {
+		case airunwayv1alpha1.EngineTypeSGLang, airunwayv1alpha1.EngineTypeTRTLLM:
+			allErrs = append(allErrs, field.Invalid(devicePath, spec.Engine.Device,
+				fmt.Sprintf("%s engine does not support cpu inference", spec.Engine.Type)))
+		}
+	case airunwayv1alpha1.EngineDeviceGPU:
+		if servingMode == airunwayv1alpha1.ServingModeAggregated && gpuCount == 0 {
+			allErrs = append(allErrs, field.Invalid(
+				specPath.Child("resources", "gpu", "count"),
+				gpuCount,
+				"gpu device requires resources.gpu.count > 0",
+			))
+		}
+	}
+
 	switch spec.Engine.Type {
 	case airunwayv1alpha1.EngineTypeVLLM, airunwayv1alpha1.EngineTypeSGLang, airunwayv1alpha1.EngineTypeTRTLLM:
-		// These engines require GPU (unless in disaggregated mode with component-level GPUs)
-		if servingMode == airunwayv1alpha1.ServingModeAggregated && gpuCount == 0 {
+		// These engines require GPU (unless in disaggregated mode with component-level GPUs,
+		// or vllm explicitly set to its CPU backend)
+		if servingMode == airunwayv1alpha1.ServingModeAggregated && gpuCount == 0 &&
+			spec.Engine.Device != airunwayv1alpha1.EngineDeviceCPU && spec.Engine.Device != airunwayv1alpha1.EngineDeviceGPU {
+			msg := fmt.Sprintf("%s engine requires GPU (set resources.gpu.count > 0)", spec.Engine.Type)
+			if spec.Engine.Type == airunwayv1alpha1.EngineTypeVLLM {
+				msg = "vllm engine requires GPU (set resources.gpu.count > 0, or engine.device: cpu for the CPU backend)"
+			}
 			allErrs = append(allErrs, field.Invalid(
 				specPath.Child("resources", "gpu", "count"),
 				gpuCount,
-				fmt.Sprintf("%s engine requires GPU (set resources.gpu.count > 0)", spec.Engine.Type),
+				msg,
 			))
 		}
 	}
 
+	// Remediation changes vllm and sglang flags only
+	if spec.Engine.Remediation != nil && spec.Engine.Remediation.Enabled {
+		switch spec.Engine.Type {
+		case "", airunwayv1alpha1.EngineTypeVLLM, airunwayv1alpha1.EngineTypeSGLang:
+		default:
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("engine", "remediation"),
+				fmt.Sprintf("remediation is not supported with the %s engine", spec.Engine.Type)))
+		}
+	}
+
+	// Adapters are loaded through the vllm runtime adapter API
+	if len(spec.Model.Adapters) > 0 && spec.Engine.Type != "" && spec.Engine.Type != airunwayv1alpha1.EngineTypeVLLM {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("model", "adapters"),
+			fmt.Sprintf("adapters are not supported with the %s engine", spec.Engine.Type)))
+	}
+
+	// The load format maps to vllm flags; tensorizer needs the serialized weights
+	modelPath := specPath.Child("model")
+	if spec.Model.LoadFormat != "" && spec.Engine.Type != "" && spec.Engine.Type != airunwayv1alpha1.EngineTypeVLLM {
+		allErrs = append(allErrs, field.Forbidden(modelPath.Child("loadFormat"),
+			fmt.Sprintf("loadFormat is not supported with the %s engine", spec.Engine.Type)))
+	}
+	if spec.Model.LoadFormat == airunwayv1alpha1.ModelLoadFormatTensorizer && spec.Model.TensorizerURI == "" {
+		allErrs = append(allErrs, field.Required(modelPath.Child("tensorizerURI"), "required when loadFormat is tensorizer"))
+	}
+	if spec.Model.TensorizerURI != "" && spec.Model.LoadFormat != airunwayv1alpha1.ModelLoadFormatTensorizer {
+		allErrs = append(allErrs, field.Forbidden(modelPath.Child("tensorizerURI"), "only allowed when loadFormat is tensorizer"))
+	}
+	if spec.Model.LoadCredentialsSecret != "" && spec.Model.LoadFormat == "" {
+		allErrs = append(allErrs, field.Forbidden(modelPath.Child("loadCredentialsSecret"), "only allowed with loadFormat"))
+	}
+
 	// Validate disaggregated mode configuration
 	if servingMode == airunwayv1alpha1.ServingModeDisaggregated {
 		// Cannot specify resources.gpu in disaggregated mode
@@ -348,27 +533,335 @@ func (v *ModelDeploymentCustomValidator) validateSpec(obj *airunwayv1alpha1.Mode
 		}
 	}
 
+	// Per-component images and scheduling only apply to disaggregated prefill and decode workers
+	if servingMode != airunwayv1alpha1.ServingModeDisaggregated && spec.Scaling != nil {
+		if spec.Scaling.Prefill != nil && spec.Scaling.Prefill.Image != "" {
+			allErrs = append(allErrs, field.Invalid(
+				specPath.Child("scaling", "prefill", "image"),
+				spec.Scaling.Prefill.Image,
+				"component images are only supported in disaggregated mode",
+			))
+		}
+		if spec.Scaling.Decode != nil && spec.Scaling.Decode.Image != "" {
+			allErrs = append(allErrs, field.Invalid(
+				specPath.Child("scaling", "decode", "image"),
+				spec.Scaling.Decode.Image,
+				"component images are only supported in disaggregated mode",
+			))
+		}
+		if spec.Scaling.Prefill != nil && spec.Scaling.Prefill.Scheduling != nil {
+			allErrs = append(allErrs, field.Forbidden(
+				specPath.Child("scaling", "prefill", "scheduling"),
+				"component scheduling is only supported in disaggregated mode",
+			))
+		}
+		if spec.Scaling.Decode != nil && spec.Scaling.Decode.Scheduling != nil {
+			allErrs = append(allErrs, field.Forbidden(
+				specPath.Child("scaling", "decode", "scheduling"),
+				"component scheduling is only supported in disaggregated mode",
+			))
+		}
+	}
+
+	// Validate disaggregated placement constraints
+	if spec.Serving != nil && spec.Serving.Placement != nil {
+		placementPath := specPath.Child("serving", "placement")
+		if servingMode != airunwayv1alpha1.ServingModeDisaggregated {
+			allErrs = append(allErrs, field.Invalid(
+				placementPath,
+				spec.Serving.Placement.Colocate,
+				"placement is only supported in disaggregated mode",
+			))
+		}
+		if key := spec.Serving.Placement.TopologyKey; key != "" {
+			for _, msg := range validation.IsQualifiedName(key) {
+				allErrs = append(allErrs, field.Invalid(placementPath.Child("topologyKey"), key, msg))
+			}
+		}
+	}
+
 	// Validate storage configuration
 	allErrs = append(allErrs, v.validateStorage(obj)...)
+	if spec.Model.Storage != nil && spec.Model.Storage.KVOffload != nil {
+		allErrs = append(allErrs, validateKVOffload(spec, servingMode, specPath.Child("model", "storage", "kvOffload"))...)
+	}
+	if spec.Caching != nil && spec.Caching.KV != nil {
+		allErrs = append(allErrs, validateKVCache(spec, servingMode, specPath.Child("caching", "kv"))...)
+	}
+
+	// Validate workload identity
+	if spec.Identity != nil {
+		allErrs = append(allErrs, validateIdentity(spec.Identity, specPath.Child("identity"))...)
+	}
+
+	// Validate the tracing collector endpoint
+	if obs := spec.Observability; obs != nil && obs.Tracing != nil && obs.Tracing.Endpoint != "" {
+		allErrs = append(allErrs, validateHTTPEndpoint(obs.Tracing.Endpoint, specPath.Child("observability", "tracing", "endpoint"))...)
+	}
+	if obs := spec.Observability; obs != nil {
+		allErrs = append(allErrs, validateLogSink(obs.LogSink, specPath.Child("observability", "logSink"))...)
+	}
+
+	// Validate the gateway-less exposure
+	if spec.Expose != nil {
+		allErrs = append(allErrs, validateExpose(obj, specPath.Child("expose"))...)
+	}
+
+	// Validate the labels and annotations propagated to generated resources
+	allErrs = append(allErrs, metav1validation.ValidateLabels(spec.Labels, specPath.Child("labels"))...)
+	allErrs = append(allErrs, apivalidation.ValidateAnnotations(spec.Annotations, specPath.Child("annotations"))...)
+
+	// Validate the IP families of generated Services
+	if spec.Networking != nil {
+		allErrs = append(allErrs, validateNetworking(spec.Networking, specPath.Child("networking"))...)
+	}
+
+	// Validate gateway timeouts
+	if spec.Gateway != nil {
+		allErrs = append(allErrs, validateGatewayTimeout(spec.Gateway.Timeout, specPath.Child("gateway", "timeout"))...)
+		if rl := spec.Gateway.RateLimit; rl != nil {
+			rlPath := specPath.Child("gateway", "rateLimit")
+			if rl.RequestsPerMinute == 0 && rl.MaxConcurrent == 0 {
+				allErrs = append(allErrs, field.Required(rlPath, "rateLimit requires requestsPerMinute or maxConcurrent"))
+			}
+			if rl.Burst > 0 && rl.RequestsPerMinute == 0 {
+				allErrs = append(allErrs, field.Invalid(rlPath.Child("burst"), rl.Burst, "burst requires requestsPerMinute"))
+			}
+		}
+		if pp := spec.Gateway.PromptPolicy; pp != nil && pp.SystemPrompt == "" && pp.MaxTokens == nil && len(pp.Stop) == 0 {
+			allErrs = append(allErrs, field.Required(specPath.Child("gateway", "promptPolicy"), "promptPolicy requires systemPrompt, maxTokens, or stop"))
+		}
+		if g := spec.Gateway.Guardrails; g != nil {
+			guardrailsPath := specPath.Child("gateway", "guardrails")
+			allErrs = append(allErrs, validateHTTPEndpoint(g.Endpoint, guardrailsPath.Child("endpoint"))...)
+			if g.Timeout != nil && g.Timeout.Duration <= 0 {
+				allErrs = append(allErrs, field.Invalid(guardrailsPath.Child("timeout"), g.Timeout.Duration.String(), "must be positive"))
+			}
+		}
+		if spec.Gateway.ModelNameTemplate != "" {
+			if _, err := gateway.RenderModelName(spec.Gateway.ModelNameTemplate, gateway.ModelNameTemplateData{
+				Namespace: obj.Namespace,
+				Name:      obj.Name,
+				ModelName: spec.Model.ID,
+			}); err != nil {
+				allErrs = append(allErrs, field.Invalid(specPath.Child("gateway", "modelNameTemplate"), spec.Gateway.ModelNameTemplate, err.Error()))
+			}
+		}
+		if spec.Gateway.EPPConfig != "" {
+			if err := gateway.ValidateEPPConfig(spec.Gateway.EPPConfig); err != nil {
+				allErrs = append(allErrs, field.Invalid(specPath.Child("gateway", "eppConfig"), spec.Gateway.EPPConfig, err.Error()))
+			}
+			if spec.Gateway.SessionAffinity != "" {
+				allErrs = append(allErrs, field.Forbidden(specPath.Child("gateway", "sessionAffinity"), "cannot be combined with eppConfig; configure the prefix-cache-scorer in eppConfig instead"))
+			}
+		}
+		if spec.Gateway.Fallback != nil {
+			allErrs = append(allErrs, validateGatewayFallback(obj, specPath.Child("gateway", "fallback"))...)
+		}
+	}
 
+	if sched := spec.Scheduling; sched != nil {
+		schedPath := specPath.Child("scheduling")
+		if sched.Gang && sched.Scheduler == "" {
+			allErrs = append(allErrs, field.Required(schedPath.Child("scheduler"), "scheduler is required when gang is enabled"))
+		}
+		if sched.Gang && sched.Queue == "" &&
+			(sched.Scheduler == airunwayv1alpha1.GangSchedulerKueue || sched.Scheduler == airunwayv1alpha1.GangSchedulerKAI) {
+			allErrs = append(allErrs, field.Required(schedPath.Child("queue"), fmt.Sprintf("queue is required for the %s scheduler", sched.Scheduler)))
+		}
+		if sched.KueueAdmission && sched.Queue == "" {
+			allErrs = append(allErrs, field.Required(schedPath.Child("queue"), "queue is required when kueueAdmission is enabled"))
+		}
+		if sched.KueueAdmission && sched.Gang && sched.Scheduler == airunwayv1alpha1.GangSchedulerKueue {
+			// Kueue would admit the pod group on top of the ModelDeployment Workload, counting its GPUs twice
+			allErrs = append(allErrs, field.Invalid(schedPath.Child("kueueAdmission"), true,
+				"kueueAdmission cannot be combined with the kueue gang scheduler"))
+		}
+		if sched.SchedulerName != "" {
+			for _, msg := range validation.IsDNS1123Subdomain(sched.SchedulerName) {
+				allErrs = append(allErrs, field.Invalid(schedPath.Child("schedulerName"), sched.SchedulerName, msg))
+			}
+		}
+		if sched.Queue != "" {
+			for _, msg := range validation.IsValidLabelValue(sched.Queue) {
+				allErrs = append(allErrs, field.Invalid(schedPath.Child("queue"), sched.Queue, msg))
+			}
+		}
+	}
+
+	if spec.Warmup != nil && spec.Warmup.Prompt != "" {
+		if _, err := warmup.RenderPrompt(spec.Warmup.Prompt, warmup.PromptData{Model: spec.Model.ID}); err != nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("warmup", "prompt"), spec.Warmup.Prompt, err.Error()))
+		}
+	}
+
+	return allErrs
+}
+
+// validateIdentity checks that identity names an existing ServiceAccount or carries
+// workload identity annotations for a controller-created one, but not both.
+func validateIdentity(identity *airunwayv1alpha1.IdentitySpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if identity.ServiceAccountName != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(identity.ServiceAccountName) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("serviceAccountName"), identity.ServiceAccountName, msg))
+		}
+		if len(identity.Annotations) > 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("annotations"), identity.Annotations,
+				"annotations cannot be combined with serviceAccountName; annotate the existing ServiceAccount instead"))
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(identity.Annotations)) {
+		keyPath := fldPath.Child("annotations").Key(key)
+		for _, msg := range validation.IsQualifiedName(key) {
+			allErrs = append(allErrs, field.Invalid(keyPath, key, msg))
+		}
+		if !airunwayv1alpha1.IsWorkloadIdentityAnnotation(key) {
+			allErrs = append(allErrs, field.NotSupported(keyPath, key,
+				[]string{"azure.workload.identity/*", "eks.amazonaws.com/*", "iam.gke.io/*"}))
+		}
+	}
 	return allErrs
 }
 
+// validateHTTPEndpoint checks that endpoint is an http or https URL with a host, as
+// expected by the OTLP exporter and the guardrails moderation client
+func validateHTTPEndpoint(endpoint string, fldPath *field.Path) field.ErrorList {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return field.ErrorList{field.Invalid(fldPath, endpoint, err.Error())}
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return field.ErrorList{field.Invalid(fldPath, endpoint, "must be an http or https URL")}
+	}
+	if u.Host == "" {
+		return field.ErrorList{field.Invalid(fldPath, endpoint, "must include a host")}
+	}
+	return nil
+}
+
+// validateGatewayFallback checks that the fallback names exactly one backend, that a
+// Service backend has a port, and that the generated HTTPRoute carries the fallback.
+func validateGatewayFallback(obj *airunwayv1alpha1.ModelDeployment, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	fallback := obj.Spec.Gateway.Fallback
+	if obj.Spec.Gateway.HTTPRouteRef != "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "cannot be combined with httpRouteRef; add the fallback backendRef to the HTTPRoute instead"))
+	}
+	switch {
+	case fallback.BackendRef == nil && fallback.URL == "":
+		allErrs = append(allErrs, field.Required(fldPath, "fallback requires backendRef or url"))
+	case fallback.BackendRef != nil && fallback.URL != "":
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("url"), "cannot be combined with backendRef"))
+	}
+	if ref := fallback.BackendRef; ref != nil {
+		refPath := fldPath.Child("backendRef")
+		if ref.Kind == airunwayv1alpha1.FallbackBackendKindService && ref.Port == nil {
+			allErrs = append(allErrs, field.Required(refPath.Child("port"), "port is required when kind is Service"))
+		}
+		if ref.Kind == airunwayv1alpha1.FallbackBackendKindInferencePool && ref.Port != nil {
+			allErrs = append(allErrs, field.Forbidden(refPath.Child("port"), "port is only valid when kind is Service"))
+		}
+		if ref.Kind == airunwayv1alpha1.FallbackBackendKindInferencePool && ref.Name == obj.Name {
+			allErrs = append(allErrs, field.Invalid(refPath.Child("name"), ref.Name, "must not be the InferencePool of this ModelDeployment"))
+		}
+	}
+	if fallback.URL != "" {
+		allErrs = append(allErrs, validateHTTPEndpoint(fallback.URL, fldPath.Child("url"))...)
+		// The url is reached through an ExternalName Service, whose name is a DNS-1035 label
+		if name := obj.Name + "-fallback"; len(name) > validation.DNS1035LabelMaxLength {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("metadata", "name"), obj.Name,
+				fmt.Sprintf("fallback Service name %q exceeds %d characters; use a shorter ModelDeployment name",
+					name, validation.DNS1035LabelMaxLength)))
+		}
+	}
+	return allErrs
+}
+
+// validateExpose checks that the Ingress settings are only used with type Ingress and that
+// the name of the controller-created Service is a valid Service name.
+func validateExpose(obj *airunwayv1alpha1.ModelDeployment, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	expose := obj.Spec.Expose
+	if expose.Type != airunwayv1alpha1.ExposeTypeIngress {
+		if expose.IngressClassName != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("ingressClassName"), "ingressClassName is only valid when type is Ingress"))
+		}
+		if expose.Host != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("host"), "host is only valid when type is Ingress"))
+		}
+	}
+	if expose.IngressClassName != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(expose.IngressClassName) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("ingressClassName"), expose.IngressClassName, msg))
+		}
+	}
+	if expose.Host != "" {
+		allErrs = append(allErrs, validation.IsFullyQualifiedDomainName(fldPath.Child("host"), expose.Host)...)
+	}
+	// Service names are DNS-1035 labels, limited to 63 characters
+	if name := obj.ExposeServiceName(); len(name) > validation.DNS1035LabelMaxLength {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("metadata", "name"), obj.Name,
+			fmt.Sprintf("expose Service name %q exceeds %d characters; use a shorter ModelDeployment name",
+				name, validation.DNS1035LabelMaxLength)))
+	}
+	return allErrs
+}
+
+// validateNetworking checks that the IP families are distinct and that two families are
+// only requested with a dual-stack policy, as the API server does for Services, and that
+// egress rules are only set with isolate.
+func validateNetworking(networking *airunwayv1alpha1.NetworkingSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if len(networking.Egress) > 0 && !networking.Isolate {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("egress"), len(networking.Egress), "egress requires isolate"))
+	}
+	families := networking.IPFamilies
+	if len(families) == 2 && families[0] == families[1] {
+		allErrs = append(allErrs, field.Duplicate(fldPath.Child("ipFamilies").Index(1), families[1]))
+	}
+	policy := corev1.IPFamilyPolicySingleStack
+	if networking.IPFamilyPolicy != nil {
+		policy = *networking.IPFamilyPolicy
+	}
+	if len(families) == 2 && policy == corev1.IPFamilyPolicySingleStack {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("ipFamilyPolicy"), policy,
+			"two ipFamilies require ipFamilyPolicy PreferDualStack or RequireDualStack"))
+	}
+	return allErrs
+}
+
+// validateGatewayTimeout checks that a gateway timeout is non-negative and
+// expressible in the Gateway API duration format (millisecond precision).
+func validateGatewayTimeout(d *metav1.Duration, fldPath *field.Path) field.ErrorList {
+	if d == nil {
+		return nil
+	}
+	if d.Duration < 0 {
+		return field.ErrorList{field.Invalid(fldPath, d.Duration.String(), "must not be negative")}
+	}
+	if d.Duration%time.Millisecond != 0 {
+		return field.ErrorList{field.Invalid(fldPath, d.Duration.String(), "must be a whole number of milliseconds")}
+	}
+	return nil
+}
+
 // validateImmutableFields checks if any immutable (identity) fields have been changed
 // Changing these fields triggers a delete+recreate of the provider resource
-func (v *ModelDeploymentCustomValidator) validateImmutableFields(oldObj, newObj *airunwayv1alpha1.ModelDeployment) field.ErrorList {
+// model.id may change when hotModelSwap is set, as the provider swaps the model in place
+func (v *ModelDeploymentCustomValidator) validateImmutableFields(oldObj, newObj *airunwayv1alpha1.ModelDeployment, hotModelSwap bool) field.ErrorList {
 	var allErrs field.ErrorList
 	specPath := field.NewPath("spec")
 
 	oldSpec := &oldObj.Spec
 	newSpec := &newObj.Spec
 
-	// model.id is an identity field
-	if oldSpec.Model.ID != newSpec.Model.ID {
+	// model.id is an identity field unless the provider swaps models in place
+	if oldSpec.Model.ID != newSpec.Model.ID && !hotModelSwap {
 		allErrs = append(allErrs, field.Invalid(
 			specPath.Child("model", "id"),
 			newSpec.Model.ID,
-			"model.id is immutable (changing it requires delete and recreate)",
+			"model.id is immutable for this provider and engine (changing it requires delete and recreate)",
 		))
 	}
 
@@ -388,6 +881,15 @@ func (v *ModelDeploymentCustomValidator) validateImmutableFields(oldObj, newObj
 			newSpec.Engine.Type,
 			"engine.type is immutable (changing it requires delete and recreate)",
 		))
+	} else if oldObj.Status.Phase == airunwayv1alpha1.DeploymentPhaseRunning && newSpec.Engine.Type != "" &&
+		oldObj.ResolvedEngineType() != "" && newSpec.Engine.Type != oldObj.ResolvedEngineType() {
+		// Setting an explicit engine that differs from the auto-selected one
+		allErrs = append(allErrs, field.Invalid(
+			specPath.Child("engine", "type"),
+			newSpec.Engine.Type,
+			fmt.Sprintf("engine.type is immutable once the deployment is running (auto-selected engine is %s; changing it requires delete and recreate)",
+				oldObj.ResolvedEngineType()),
+		))
 	}
 
 	// provider.name is an identity field (once set)
@@ -399,12 +901,21 @@ func (v *ModelDeploymentCustomValidator) validateImmutableFields(oldObj, newObj
 	if newSpec.Provider != nil {
 		newProvider = newSpec.Provider.Name
 	}
-	if oldProvider != "" && newProvider != "" && oldProvider != newProvider {
+	if oldProvider != "" && newProvider != "" && oldProvider != newProvider && !migratedTo(oldObj, newProvider) {
 		allErrs = append(allErrs, field.Invalid(
 			specPath.Child("provider", "name"),
 			newProvider,
 			"provider.name is immutable (changing it requires delete and recreate)",
 		))
+	} else if oldProvider == "" && newProvider != "" && oldObj.Status.Provider != nil &&
+		oldObj.Status.Provider.Name != "" && newProvider != oldObj.Status.Provider.Name {
+		// Pinning a provider other than the one already selected would switch providers mid-flight
+		allErrs = append(allErrs, field.Invalid(
+			specPath.Child("provider", "name"),
+			newProvider,
+			fmt.Sprintf("provider.name is immutable once a provider is selected (selected provider is %s; changing it requires delete and recreate)",
+				oldObj.Status.Provider.Name),
+		))
 	}
 
 	// serving.mode is an identity field
@@ -481,6 +992,29 @@ func (v *ModelDeploymentCustomValidator) validateImmutableFields(oldObj, newObj
 	return allErrs
 }
 
+// migratedTo reports whether md completed a spec.provider.migrateTo migration to provider,
+// so spec.provider.name may be updated to the provider now serving it
+func migratedTo(md *airunwayv1alpha1.ModelDeployment, provider string) bool {
+	migration := md.Status.Migration
+	return migration != nil && migration.Phase == airunwayv1alpha1.MigrationPhaseCompleted && migration.To == provider &&
+		md.Status.Provider != nil && md.Status.Provider.Name == provider
+}
+
+// reservedMetadataKeys returns the sorted, distinct keys of the maps that are not
+// propagated to generated resources.
+func reservedMetadataKeys(metadata ...map[string]string) []string {
+	var keys []string
+	for _, m := range metadata {
+		for key := range m {
+			if airunwayv1alpha1.IsReservedMetadataKey(key) {
+				keys = append(keys, key)
+			}
+		}
+	}
+	slices.Sort(keys)
+	return slices.Compact(keys)
+}
+
 // checkWarnings returns non-fatal warnings for the spec
 func (v *ModelDeploymentCustomValidator) checkWarnings(obj *airunwayv1alpha1.ModelDeployment) admission.Warnings {
 	var warnings admission.Warnings
@@ -501,6 +1035,27 @@ func (v *ModelDeploymentCustomValidator) checkWarnings(obj *airunwayv1alpha1.Mod
 		warnings = append(warnings, "contextLength is ignored for TensorRT-LLM (must be configured at engine build time)")
 	}
 
+	// Warn if tokenizer is set for trtllm
+	if spec.Engine.Type == airunwayv1alpha1.EngineTypeTRTLLM && spec.Model.Tokenizer != "" {
+		warnings = append(warnings, "tokenizer is ignored for TensorRT-LLM (the tokenizer is part of the built engine)")
+	}
+
+	// Warn about spec.labels and spec.annotations keys that are not propagated
+	for _, key := range reservedMetadataKeys(spec.Labels, spec.Annotations) {
+		warnings = append(warnings, fmt.Sprintf(
+			"%q is in a reserved domain (airunway.ai, kubernetes.io, k8s.io) and is not propagated to generated resources", key))
+	}
+
+	// Warn if disaggregated workers have no co-location constraint
+	if spec.Serving != nil && spec.Serving.Mode == airunwayv1alpha1.ServingModeDisaggregated && spec.Serving.Placement == nil {
+		warnings = append(warnings, "disaggregated prefill and decode workers may be scheduled in different zones, making KV cache transfer slow; set serving.placement.colocate to keep them together")
+	}
+
+	// Warn if tracing is enabled where no controller-created EPP exports spans
+	if obj.TracingEnabled() && spec.Gateway != nil && spec.Gateway.Enabled != nil && !*spec.Gateway.Enabled {
+		warnings = append(warnings, "observability.tracing has no effect with gateway.enabled=false; spans are exported by the gateway Endpoint Picker")
+	}
+
 	// Warn if readOnly is true on a compilationCache volume
 	if spec.Model.Storage != nil {
 		for _, vol := range spec.Model.Storage.Volumes {
@@ -859,6 +1414,250 @@ func checkForbiddenOverrideKeysInValue(val interface{}, fldPath *field.Path, for
 	return nil
 }
 
+// validateAutotune requires bounds when autotune is enabled and checks that each
+// bound is a valid quantity within the resource ceilings and min does not exceed max.
+func validateAutotune(resources *airunwayv1alpha1.ResourceSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	boundsPath := fldPath.Child("autotuneBounds")
+	bounds := resources.AutotuneBounds
+	if resources.Autotune && (resources.Requests != nil || resources.Limits != nil) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("autotune"),
+			"autotune sets cpu and memory, which requests and limits would override; remove requests and limits to use autotune"))
+	}
+	if bounds == nil {
+		if resources.Autotune {
+			allErrs = append(allErrs, field.Required(boundsPath, "autotuneBounds is required when autotune is enabled"))
+		}
+		return allErrs
+	}
+
+	allErrs = append(allErrs, validateResourceQuantity(bounds.MinCPU, MaxCPU, boundsPath.Child("minCPU"))...)
+	allErrs = append(allErrs, validateResourceQuantity(bounds.MaxCPU, MaxCPU, boundsPath.Child("maxCPU"))...)
+	allErrs = append(allErrs, validateResourceQuantity(bounds.MinMemory, MaxMemory, boundsPath.Child("minMemory"))...)
+	allErrs = append(allErrs, validateResourceQuantity(bounds.MaxMemory, MaxMemory, boundsPath.Child("maxMemory"))...)
+	if len(allErrs) > 0 {
+		return allErrs
+	}
+
+	if bounds.MinCPU != "" && bounds.MaxCPU != "" && quantityExceeds(bounds.MinCPU, bounds.MaxCPU) {
+		allErrs = append(allErrs, field.Invalid(boundsPath.Child("minCPU"), bounds.MinCPU, "must not exceed maxCPU"))
+	}
+	if bounds.MinMemory != "" && bounds.MaxMemory != "" && quantityExceeds(bounds.MinMemory, bounds.MaxMemory) {
+		allErrs = append(allErrs, field.Invalid(boundsPath.Child("minMemory"), bounds.MinMemory, "must not exceed maxMemory"))
+	}
+	return allErrs
+}
+
+// validateRouter validates the router replica count, resources and routing mode
+func validateRouter(router *airunwayv1alpha1.RouterSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if router.Replicas != nil {
+		if *router.Replicas < 1 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("replicas"), *router.Replicas, "must be at least 1"))
+		} else if *router.Replicas > MaxReplicas {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("replicas"), *router.Replicas, fmt.Sprintf("exceeds maximum replicas (%d)", MaxReplicas)))
+		}
+	}
+	allErrs = append(allErrs, validateResourceQuantity(router.CPU, MaxCPU, fldPath.Child("cpu"))...)
+	allErrs = append(allErrs, validateResourceQuantity(router.Memory, MaxMemory, fldPath.Child("memory"))...)
+	switch router.RouterMode {
+	case "", airunwayv1alpha1.RouterModeKV, airunwayv1alpha1.RouterModeRoundRobin, airunwayv1alpha1.RouterModeNone:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("routerMode"), router.RouterMode,
+			[]string{string(airunwayv1alpha1.RouterModeKV), string(airunwayv1alpha1.RouterModeRoundRobin), string(airunwayv1alpha1.RouterModeNone)}))
+	}
+	return allErrs
+}
+
+// validateGPUTypes validates the GPU model preference of spec.resources.gpu.types. It is
+// only supported for aggregated serving, where the controller selects one model for all pods.
+func validateGPUTypes(spec *airunwayv1alpha1.ModelDeploymentSpec, specPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if spec.Scaling != nil {
+		if prefill := spec.Scaling.Prefill; prefill != nil && prefill.GPU != nil && len(prefill.GPU.Types) > 0 {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("scaling", "prefill", "gpu", "types"), "only supported in spec.resources.gpu"))
+		}
+		if decode := spec.Scaling.Decode; decode != nil && decode.GPU != nil && len(decode.GPU.Types) > 0 {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("scaling", "decode", "gpu", "types"), "only supported in spec.resources.gpu"))
+		}
+	}
+	if spec.Resources == nil || spec.Resources.GPU == nil || len(spec.Resources.GPU.Types) == 0 {
+		return allErrs
+	}
+	typesPath := specPath.Child("resources", "gpu", "types")
+	if spec.Serving != nil && spec.Serving.Mode == airunwayv1alpha1.ServingModeDisaggregated {
+		allErrs = append(allErrs, field.Forbidden(typesPath, "not supported with serving.mode disaggregated"))
+	}
+	if spec.Resources.GPU.Count == 0 {
+		allErrs = append(allErrs, field.Forbidden(typesPath, "requires resources.gpu.count > 0"))
+	}
+	for i, t := range spec.Resources.GPU.Types {
+		if t == "" {
+			allErrs = append(allErrs, field.Required(typesPath.Index(i), "must not be empty"))
+			continue
+		}
+		for _, msg := range validation.IsValidLabelValue(t) {
+			allErrs = append(allErrs, field.Invalid(typesPath.Index(i), t, msg))
+		}
+	}
+	return allErrs
+}
+
+// validateKVOffload validates spec.model.storage.kvOffload against the engine and serving
+// mode. An unset engine is checked by the provider once it is resolved.
+func validateKVOffload(spec *airunwayv1alpha1.ModelDeploymentSpec, servingMode airunwayv1alpha1.ServingMode, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	kv := spec.Model.Storage.KVOffload
+	if kv.Size.Sign() <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("size"), kv.Size.String(), "must be greater than zero"))
+	}
+	nvme := kv.ResolvedMedium() == airunwayv1alpha1.KVOffloadMediumNVMe
+	if sc := kv.StorageClassName; sc != nil {
+		if !nvme {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("storageClassName"), *sc, "storageClassName is only applicable when medium is nvme"))
+		} else {
+			for _, msg := range validation.IsDNS1123Subdomain(*sc) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("storageClassName"), *sc, msg))
+			}
+		}
+	}
+	if engine := spec.Engine.Type; engine != "" {
+		if !provider.KVOffloadSupported(engine) {
+			allErrs = append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("KV cache offload is not supported with the %s engine", engine)))
+		} else if engine == airunwayv1alpha1.EngineTypeVLLM && nvme && servingMode == airunwayv1alpha1.ServingModeDisaggregated {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("medium"),
+				"nvme offload is not supported with disaggregated vllm serving, whose KV connector transfers the cache between prefill and decode"))
+		}
+	}
+	if nvme {
+		for i, vol := range spec.Model.Storage.Volumes {
+			if vol.MountPath == provider.KVOffloadMountPath {
+				allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "model", "storage", "volumes").Index(i).Child("mountPath"),
+					vol.MountPath, "mountPath is reserved for the KV offload volume"))
+			}
+		}
+	}
+	return allErrs
+}
+
+// validateKVCache validates spec.caching.kv. An unset engine is checked by the provider
+// once it is resolved.
+func validateKVCache(spec *airunwayv1alpha1.ModelDeploymentSpec, servingMode airunwayv1alpha1.ServingMode, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	kv := spec.Caching.KV
+	if ref := kv.ConnectionSecretRef; ref != nil {
+		refPath := fldPath.Child("connectionSecretRef")
+		for _, msg := range validation.IsDNS1123Subdomain(ref.Name) {
+			allErrs = append(allErrs, field.Invalid(refPath.Child("name"), ref.Name, msg))
+		}
+		for _, msg := range validation.IsConfigMapKey(ref.Key) {
+			allErrs = append(allErrs, field.Invalid(refPath.Child("key"), ref.Key, msg))
+		}
+	} else if kv.Backend == airunwayv1alpha1.KVCacheBackendRedis {
+		allErrs = append(allErrs, field.Required(fldPath.Child("connectionSecretRef"), "connectionSecretRef is required for the redis backend"))
+	}
+	if kv.MaxSize != nil && kv.MaxSize.Sign() <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxSize"), kv.MaxSize.String(), "must be greater than zero"))
+	}
+	if engine := spec.Engine.Type; engine != "" && engine != airunwayv1alpha1.EngineTypeVLLM {
+		allErrs = append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("the KV cache is not supported with the %s engine", engine)))
+	}
+	if servingMode == airunwayv1alpha1.ServingModeDisaggregated {
+		allErrs = append(allErrs, field.Forbidden(fldPath,
+			"the KV cache is not supported with disaggregated serving, whose KV connector transfers the cache between prefill and decode"))
+	}
+	return allErrs
+}
+
+// validateChatTemplate validates spec.model.chatTemplate and spec.model.tokenizer. The
+// tokenizer is passed to the engine as a flag value, so it must not look like a flag.
+func validateChatTemplate(model *airunwayv1alpha1.ModelSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if ct := model.ChatTemplate; ct != nil {
+		ctPath := fldPath.Child("chatTemplate")
+		switch {
+		case ct.Inline == "" && ct.ConfigMapKeyRef == nil:
+			allErrs = append(allErrs, field.Required(ctPath, "one of inline or configMapKeyRef must be set"))
+		case ct.Inline != "" && ct.ConfigMapKeyRef != nil:
+			allErrs = append(allErrs, field.Forbidden(ctPath, "only one of inline or configMapKeyRef may be set"))
+		case ct.ConfigMapKeyRef != nil:
+			refPath := ctPath.Child("configMapKeyRef")
+			if ct.ConfigMapKeyRef.Name == "" {
+				allErrs = append(allErrs, field.Required(refPath.Child("name"), "must not be empty"))
+			} else {
+				for _, msg := range validation.IsDNS1123Subdomain(ct.ConfigMapKeyRef.Name) {
+					allErrs = append(allErrs, field.Invalid(refPath.Child("name"), ct.ConfigMapKeyRef.Name, msg))
+				}
+			}
+			for _, msg := range validation.IsConfigMapKey(ct.ConfigMapKeyRef.Key) {
+				allErrs = append(allErrs, field.Invalid(refPath.Child("key"), ct.ConfigMapKeyRef.Key, msg))
+			}
+		}
+	}
+	if tokenizer := model.Tokenizer; tokenizer != "" {
+		if strings.HasPrefix(tokenizer, "-") || strings.ContainsFunc(tokenizer, unicode.IsSpace) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("tokenizer"), tokenizer, "must not start with '-' or contain whitespace"))
+		}
+	}
+	return allErrs
+}
+
+// validateRequestsLimits validates explicit cpu and memory requests and limits and checks
+// that no request exceeds its limit. The shorthand cpu and memory stand in for a request or
+// limit that is not set explicitly, since providers may map them to either.
+func validateRequestsLimits(cpu, memory string, requests, limits *airunwayv1alpha1.ResourceQuantities, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	effectiveRequests := airunwayv1alpha1.ResourceQuantities{CPU: cpu, Memory: memory}
+	effectiveLimits := effectiveRequests
+	if requests != nil {
+		allErrs = append(allErrs, validateResourceQuantity(requests.CPU, MaxCPU, fldPath.Child("requests", "cpu"))...)
+		allErrs = append(allErrs, validateResourceQuantity(requests.Memory, MaxMemory, fldPath.Child("requests", "memory"))...)
+		if requests.CPU != "" {
+			effectiveRequests.CPU = requests.CPU
+		}
+		if requests.Memory != "" {
+			effectiveRequests.Memory = requests.Memory
+		}
+	}
+	if limits != nil {
+		allErrs = append(allErrs, validateResourceQuantity(limits.CPU, MaxCPU, fldPath.Child("limits", "cpu"))...)
+		allErrs = append(allErrs, validateResourceQuantity(limits.Memory, MaxMemory, fldPath.Child("limits", "memory"))...)
+		if limits.CPU != "" {
+			effectiveLimits.CPU = limits.CPU
+		}
+		if limits.Memory != "" {
+			effectiveLimits.Memory = limits.Memory
+		}
+	}
+	if len(allErrs) > 0 || (requests == nil && limits == nil) {
+		return allErrs
+	}
+
+	if effectiveRequests.CPU != "" && effectiveLimits.CPU != "" && quantityExceeds(effectiveRequests.CPU, effectiveLimits.CPU) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("requests", "cpu"), effectiveRequests.CPU,
+			fmt.Sprintf("must not exceed the cpu limit (%s)", effectiveLimits.CPU)))
+	}
+	if effectiveRequests.Memory != "" && effectiveLimits.Memory != "" && quantityExceeds(effectiveRequests.Memory, effectiveLimits.Memory) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("requests", "memory"), effectiveRequests.Memory,
+			fmt.Sprintf("must not exceed the memory limit (%s)", effectiveLimits.Memory)))
+	}
+	return allErrs
+}
+
+// quantityExceeds returns true if quantity a is greater than b. It returns false when
+// either is not a valid quantity, which is reported by the field's own validation.
+func quantityExceeds(a, b string) bool {
+	qa, err := resource.ParseQuantity(a)
+	if err != nil {
+		return false
+	}
+	qb, err := resource.ParseQuantity(b)
+	if err != nil {
+		return false
+	}
+	return qa.Cmp(qb) > 0
+}
+
 // validateResourceQuantity validates that a resource string doesn't exceed a maximum
 func validateResourceQuantity(value string, max string, fldPath *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
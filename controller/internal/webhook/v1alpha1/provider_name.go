@@ -0,0 +1,114 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+// +kubebuilder:rbac:groups=airunway.ai,resources=inferenceproviderconfigs,verbs=get;list;watch
+
+// validateProviderName rejects a spec.provider.name that matches no InferenceProviderConfig
+// and is not in AllowedProviders, since the deployment would stay Pending until a provider
+// with that name registers, or whose namespaceSelector does not match the deployment
+// namespace. Only new or changed names are checked, so deployments of a provider that was
+// uninstalled or restricted can still be updated.
+//
+// spec.provider.migrateTo is checked the same way.
+func (v *ModelDeploymentCustomValidator) validateProviderName(ctx context.Context, oldObj, obj *airunwayv1alpha1.ModelDeployment) (field.ErrorList, error) {
+	if v.Client == nil || obj.Spec.Provider == nil {
+		return nil, nil
+	}
+	var oldName, oldMigrateTo string
+	if oldObj != nil && oldObj.Spec.Provider != nil {
+		oldName, oldMigrateTo = oldObj.Spec.Provider.Name, oldObj.Spec.Provider.MigrateTo
+	}
+	providerPath := field.NewPath("spec", "provider")
+
+	var allErrs field.ErrorList
+	for _, f := range []struct{ name, old, field string }{
+		{obj.Spec.Provider.Name, oldName, "name"},
+		{obj.Spec.Provider.MigrateTo, oldMigrateTo, "migrateTo"},
+	} {
+		if f.name == "" || f.name == f.old {
+			continue
+		}
+		errs, err := v.validateRegisteredProvider(ctx, f.name, obj.Namespace, providerPath.Child(f.field))
+		if err != nil {
+			return nil, err
+		}
+		allErrs = append(allErrs, errs...)
+	}
+	return allErrs, nil
+}
+
+// validateRegisteredProvider rejects a provider name that matches no InferenceProviderConfig
+// and is not in AllowedProviders, or whose InferenceProviderConfig does not serve namespace
+func (v *ModelDeploymentCustomValidator) validateRegisteredProvider(ctx context.Context, name, namespace string, namePath *field.Path) (field.ErrorList, error) {
+	var configs airunwayv1alpha1.InferenceProviderConfigList
+	if err := v.Client.List(ctx, &configs); err != nil {
+		return nil, fmt.Errorf("failed to list InferenceProviderConfigs: %w", err)
+	}
+	registered := make([]string, 0, len(configs.Items))
+	for _, config := range configs.Items {
+		if config.Name == name {
+			return v.validateProviderNamespace(ctx, &config, namespace, namePath)
+		}
+		registered = append(registered, config.Name)
+	}
+	if slices.Contains(v.AllowedProviders, name) {
+		return nil, nil
+	}
+	slices.Sort(registered)
+
+	known := "none"
+	if len(registered) > 0 {
+		known = strings.Join(registered, ", ")
+	}
+	return field.ErrorList{field.Invalid(namePath, name,
+		fmt.Sprintf("no InferenceProviderConfig named %q is registered (registered providers: %s)", name, known))}, nil
+}
+
+// validateProviderNamespace rejects a provider whose namespaceSelector does not match the
+// deployment namespace
+func (v *ModelDeploymentCustomValidator) validateProviderNamespace(ctx context.Context, config *airunwayv1alpha1.InferenceProviderConfig, namespace string, namePath *field.Path) (field.ErrorList, error) {
+	if config.Spec.NamespaceSelector == nil {
+		return nil, nil
+	}
+	var ns corev1.Namespace
+	if err := v.Client.Get(ctx, client.ObjectKey{Name: namespace}, &ns); err != nil {
+		return nil, fmt.Errorf("failed to get namespace %s: %w", namespace, err)
+	}
+	served, err := config.Spec.ServesNamespace(ns.Labels)
+	if err != nil {
+		return nil, fmt.Errorf("InferenceProviderConfig %s: %w", config.Name, err)
+	}
+	if !served {
+		return field.ErrorList{field.Forbidden(namePath,
+			fmt.Sprintf("provider %q does not serve namespace %q: it does not match the namespaceSelector of the InferenceProviderConfig", config.Name, namespace))}, nil
+	}
+	return nil, nil
+}
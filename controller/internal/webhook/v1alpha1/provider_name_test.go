@@ -0,0 +1,118 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+func TestValidateProviderName(t *testing.T) {
+	newConfig := func(name string) *airunwayv1alpha1.InferenceProviderConfig {
+		return &airunwayv1alpha1.InferenceProviderConfig{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	}
+	newMD := func(provider string) *airunwayv1alpha1.ModelDeployment {
+		md := &airunwayv1alpha1.ModelDeployment{}
+		if provider != "" {
+			md.Spec.Provider = &airunwayv1alpha1.ProviderSpec{Name: provider}
+		}
+		return md
+	}
+	v := newQuotaValidator(newConfig("kuberay"), newConfig("dynamo"))
+	v.AllowedProviders = []string{"custom"}
+	ctx := context.Background()
+
+	for _, name := range []string{"", "kuberay", "custom"} {
+		if errs, err := v.validateProviderName(ctx, nil, newMD(name)); err != nil || len(errs) != 0 {
+			t.Errorf("expected provider %q to be accepted, got %v (%v)", name, errs, err)
+		}
+	}
+
+	errs, err := v.validateProviderName(ctx, nil, newMD("kubray"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	requireValidationErrorField(t, errs, "spec.provider.name")
+	if !strings.Contains(errs[0].Detail, "registered providers: dynamo, kuberay") {
+		t.Errorf("expected the registered providers to be listed, got %q", errs[0].Detail)
+	}
+
+	// An unchanged name is not rechecked, so deployments outlive their provider
+	if errs, _ := v.validateProviderName(ctx, newMD("kaito"), newMD("kaito")); len(errs) != 0 {
+		t.Errorf("expected an unchanged provider name to be accepted, got %v", errs)
+	}
+
+	// spec.provider.migrateTo must name a registered provider too
+	migrating := newMD("kuberay")
+	migrating.Spec.Provider.MigrateTo = "dynamoo"
+	errs, _ = v.validateProviderName(ctx, newMD("kuberay"), migrating)
+	requireValidationErrorField(t, errs, "spec.provider.migrateTo")
+	migrating.Spec.Provider.MigrateTo = "dynamo"
+	if errs, _ := v.validateProviderName(ctx, newMD("kuberay"), migrating); len(errs) != 0 {
+		t.Errorf("expected migrateTo dynamo to be accepted, got %v", errs)
+	}
+
+	errs, _ = newQuotaValidator().validateProviderName(ctx, nil, newMD("kuberay"))
+	if len(errs) != 1 || !strings.Contains(errs[0].Detail, "registered providers: none") {
+		t.Errorf("expected no registered providers, got %v", errs)
+	}
+
+	// Without a client the check is disabled
+	if errs, err := (&ModelDeploymentCustomValidator{}).validateProviderName(ctx, nil, newMD("kubray")); err != nil || len(errs) != 0 {
+		t.Errorf("expected no check without a client, got %v (%v)", errs, err)
+	}
+}
+
+func TestValidateProviderName_NamespaceSelector(t *testing.T) {
+	dynamo := &airunwayv1alpha1.InferenceProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "dynamo"},
+		Spec: airunwayv1alpha1.InferenceProviderConfigSpec{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"airunway.ai/tier": "prod"}},
+		},
+	}
+	prod := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ml-prod", Labels: map[string]string{"airunway.ai/tier": "prod"}}}
+	dev := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ml-dev"}}
+	v, _ := newPolicyValidator(dynamo, prod, dev)
+	ctx := context.Background()
+	newMD := func(namespace string) *airunwayv1alpha1.ModelDeployment {
+		return &airunwayv1alpha1.ModelDeployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: namespace},
+			Spec:       airunwayv1alpha1.ModelDeploymentSpec{Provider: &airunwayv1alpha1.ProviderSpec{Name: "dynamo"}},
+		}
+	}
+
+	if errs, err := v.validateProviderName(ctx, nil, newMD("ml-prod")); err != nil || len(errs) != 0 {
+		t.Errorf("expected dynamo to be accepted in ml-prod, got %v (%v)", errs, err)
+	}
+	errs, err := v.validateProviderName(ctx, nil, newMD("ml-dev"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	requireValidationErrorField(t, errs, "spec.provider.name")
+
+	// AllowedProviders does not bypass the selector of a registered provider
+	v.AllowedProviders = []string{"dynamo"}
+	if errs, _ := v.validateProviderName(ctx, nil, newMD("ml-dev")); len(errs) != 1 {
+		t.Errorf("expected dynamo to be rejected in ml-dev, got %v", errs)
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+// validateQuota rejects a ModelDeployment whose GPU usage would exceed a ModelDeploymentQuota
+// in its namespace. Only classes whose usage grows are checked, so a deployment admitted
+// before a quota was lowered can still be updated without adding GPUs.
+func (v *ModelDeploymentCustomValidator) validateQuota(ctx context.Context, oldObj, newObj *airunwayv1alpha1.ModelDeployment) (field.ErrorList, error) {
+	if v.Client == nil {
+		return nil, nil
+	}
+
+	var quotas airunwayv1alpha1.ModelDeploymentQuotaList
+	if err := v.Client.List(ctx, &quotas, client.InNamespace(newObj.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list ModelDeploymentQuotas: %w", err)
+	}
+	if len(quotas.Items) == 0 {
+		return nil, nil
+	}
+
+	requested := newObj.GPUUsage()
+	previous := map[string]int32{}
+	if oldObj != nil {
+		previous = oldObj.GPUUsage()
+	}
+	var growing []string
+	for class, count := range requested {
+		if count > previous[class] {
+			growing = append(growing, class)
+		}
+	}
+	if len(growing) == 0 {
+		return nil, nil
+	}
+	slices.Sort(growing)
+
+	var deployments airunwayv1alpha1.ModelDeploymentList
+	if err := v.Client.List(ctx, &deployments, client.InNamespace(newObj.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list ModelDeployments: %w", err)
+	}
+	used := map[string]int32{}
+	for i := range deployments.Items {
+		if deployments.Items[i].Name == newObj.Name {
+			continue
+		}
+		for class, count := range deployments.Items[i].GPUUsage() {
+			used[class] += count
+		}
+	}
+
+	var allErrs field.ErrorList
+	for _, quota := range quotas.Items {
+		for _, class := range growing {
+			limit, ok := quota.Spec.Limits[class]
+			if !ok {
+				continue
+			}
+			if total := used[class] + requested[class]; total > limit {
+				allErrs = append(allErrs, field.Forbidden(field.NewPath("spec"), fmt.Sprintf(
+					"exceeds ModelDeploymentQuota %s: %s would use %d of %d GPUs (%d already used by other deployments)",
+					quota.Name, class, total, limit, used[class])))
+			}
+		}
+	}
+	return allErrs, nil
+}
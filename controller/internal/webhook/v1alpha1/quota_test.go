@@ -0,0 +1,112 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+func newQuotaDeployment(name, class string, gpus, replicas int32) *airunwayv1alpha1.ModelDeployment {
+	return &airunwayv1alpha1.ModelDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-a"},
+		Spec: airunwayv1alpha1.ModelDeploymentSpec{
+			Model:     airunwayv1alpha1.ModelSpec{ID: "meta-llama/Llama-3.1-8B-Instruct", Source: airunwayv1alpha1.ModelSourceHuggingFace},
+			Engine:    airunwayv1alpha1.EngineSpec{Type: airunwayv1alpha1.EngineTypeVLLM},
+			Scaling:   &airunwayv1alpha1.ScalingSpec{Replicas: replicas},
+			Resources: &airunwayv1alpha1.ResourceSpec{GPU: &airunwayv1alpha1.GPUSpec{Count: gpus, Type: "nvidia.com/gpu", Class: class}},
+		},
+	}
+}
+
+func newQuotaValidator(objs ...client.Object) *ModelDeploymentCustomValidator {
+	scheme := runtime.NewScheme()
+	_ = airunwayv1alpha1.AddToScheme(scheme)
+	return &ModelDeploymentCustomValidator{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(),
+	}
+}
+
+func TestGPUUsage(t *testing.T) {
+	md := newQuotaDeployment("agg", "nvidia-h100", 2, 3)
+	if got := md.GPUUsage(); len(got) != 1 || got["nvidia-h100"] != 6 {
+		t.Errorf("expected 6 nvidia-h100 GPUs, got %v", got)
+	}
+
+	md.Spec.Resources.GPU.Class = ""
+	if got := md.GPUUsage(); got["nvidia.com/gpu"] != 6 {
+		t.Errorf("expected unclassed GPUs to count against their type, got %v", got)
+	}
+
+	md.Spec.Resources = nil
+	md.Spec.Serving = &airunwayv1alpha1.ServingSpec{Mode: airunwayv1alpha1.ServingModeDisaggregated}
+	md.Spec.Scaling = &airunwayv1alpha1.ScalingSpec{
+		Prefill: &airunwayv1alpha1.ComponentScalingSpec{Replicas: 1, GPU: &airunwayv1alpha1.GPUSpec{Count: 4, Class: "nvidia-h100"}},
+		Decode:  &airunwayv1alpha1.ComponentScalingSpec{Replicas: 2, GPU: &airunwayv1alpha1.GPUSpec{Count: 2, Class: "nvidia-a100"}},
+	}
+	if got := md.GPUUsage(); got["nvidia-h100"] != 4 || got["nvidia-a100"] != 4 {
+		t.Errorf("expected prefill and decode usage per class, got %v", got)
+	}
+}
+
+func TestValidateQuota(t *testing.T) {
+	quota := &airunwayv1alpha1.ModelDeploymentQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-gpus", Namespace: "team-a"},
+		Spec:       airunwayv1alpha1.ModelDeploymentQuotaSpec{Limits: map[string]int32{"nvidia-h100": 8}},
+	}
+	existing := newQuotaDeployment("existing", "nvidia-h100", 2, 2)
+	v := newQuotaValidator(quota, existing)
+	ctx := context.Background()
+
+	// 4 used + 4 requested fits the limit of 8
+	if _, err := v.ValidateCreate(ctx, newQuotaDeployment("fits", "nvidia-h100", 4, 1)); err != nil {
+		t.Errorf("expected deployment within quota to be admitted, got %v", err)
+	}
+
+	// 4 used + 6 requested exceeds it
+	_, err := v.ValidateCreate(ctx, newQuotaDeployment("too-big", "nvidia-h100", 2, 3))
+	if err == nil || !strings.Contains(err.Error(), "exceeds ModelDeploymentQuota team-gpus") ||
+		!strings.Contains(err.Error(), "nvidia-h100 would use 10 of 8 GPUs") {
+		t.Errorf("expected quota error, got %v", err)
+	}
+
+	// Other classes are not limited
+	if _, err := v.ValidateCreate(ctx, newQuotaDeployment("other", "nvidia-a100", 16, 1)); err != nil {
+		t.Errorf("expected unlimited class to be admitted, got %v", err)
+	}
+
+	// Updates that do not add GPUs are admitted even when the namespace is over quota
+	quota.Spec.Limits["nvidia-h100"] = 2
+	v = newQuotaValidator(quota, existing)
+	updated := existing.DeepCopy()
+	updated.Spec.Image = "vllm/vllm-openai:latest"
+	if _, err := v.ValidateUpdate(ctx, existing, updated); err != nil {
+		t.Errorf("expected update without GPU growth to be admitted, got %v", err)
+	}
+	updated.Spec.Scaling.Replicas = 3
+	if _, err := v.ValidateUpdate(ctx, existing, updated); err == nil {
+		t.Error("expected scaling up past the quota to be rejected")
+	}
+}
@@ -17,10 +17,17 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"context"
 	"encoding/json"
+	"strings"
 	"testing"
+	"time"
 
 	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
@@ -474,3 +481,1177 @@ func TestValidateOverrides_InvalidJSONRedactsRawPayload(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateSpec_OverrideTemplates(t *testing.T) {
+	v := &ModelDeploymentCustomValidator{}
+	md := &airunwayv1alpha1.ModelDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "default"},
+		Spec: airunwayv1alpha1.ModelDeploymentSpec{
+			Model: airunwayv1alpha1.ModelSpec{ID: "test-model"},
+			Provider: &airunwayv1alpha1.ProviderSpec{
+				Overrides: &runtime.RawExtension{Raw: []byte(`{"labels": {"model": "{{ .Spec.Model.ID }}"}}`)},
+			},
+		},
+	}
+	for _, err := range v.validateSpec(md) {
+		if err.Field == "spec.provider.overrides" {
+			t.Fatalf("expected valid override template to be admitted, got %v", err)
+		}
+	}
+
+	md.Spec.Provider.Overrides.Raw = []byte(`{"labels": {"model": "{{ .Spec.Model.Name }}"}}`)
+	requireValidationErrorField(t, v.validateSpec(md), "spec.provider.overrides")
+}
+
+func TestValidateSpec_WarmupPrompt(t *testing.T) {
+	v := &ModelDeploymentCustomValidator{}
+	md := &airunwayv1alpha1.ModelDeployment{
+		Spec: airunwayv1alpha1.ModelDeploymentSpec{
+			Model:  airunwayv1alpha1.ModelSpec{ID: "test-model"},
+			Warmup: &airunwayv1alpha1.WarmupSpec{Prompt: "Request {{ .Index }} for {{ .Model }}"},
+		},
+	}
+	for _, err := range v.validateSpec(md) {
+		if err.Field == "spec.warmup.prompt" {
+			t.Fatalf("expected valid warmup prompt to be admitted, got %v", err)
+		}
+	}
+
+	md.Spec.Warmup.Prompt = "{{ .Name }}"
+	requireValidationErrorField(t, v.validateSpec(md), "spec.warmup.prompt")
+}
+
+func TestValidateSpec_Scheduling(t *testing.T) {
+	v := &ModelDeploymentCustomValidator{}
+	md := &airunwayv1alpha1.ModelDeployment{
+		Spec: airunwayv1alpha1.ModelDeploymentSpec{
+			Model:      airunwayv1alpha1.ModelSpec{ID: "test-model"},
+			Scheduling: &airunwayv1alpha1.SchedulingSpec{Gang: true, Scheduler: airunwayv1alpha1.GangSchedulerVolcano},
+		},
+	}
+	for _, err := range v.validateSpec(md) {
+		if strings.HasPrefix(err.Field, "spec.scheduling") {
+			t.Fatalf("expected volcano gang without a queue to be admitted, got %v", err)
+		}
+	}
+
+	md.Spec.Scheduling.Scheduler = ""
+	requireValidationErrorField(t, v.validateSpec(md), "spec.scheduling.scheduler")
+
+	md.Spec.Scheduling.Scheduler = airunwayv1alpha1.GangSchedulerKueue
+	requireValidationErrorField(t, v.validateSpec(md), "spec.scheduling.queue")
+
+	md.Spec.Scheduling.Queue = "not a label value"
+	requireValidationErrorField(t, v.validateSpec(md), "spec.scheduling.queue")
+
+	md.Spec.Scheduling.Queue = "gpu-queue"
+	md.Spec.Scheduling.SchedulerName = "Not_A_Name"
+	requireValidationErrorField(t, v.validateSpec(md), "spec.scheduling.schedulerName")
+}
+
+func TestValidateSpec_KueueAdmission(t *testing.T) {
+	v := &ModelDeploymentCustomValidator{}
+	md := &airunwayv1alpha1.ModelDeployment{
+		Spec: airunwayv1alpha1.ModelDeploymentSpec{
+			Model:      airunwayv1alpha1.ModelSpec{ID: "test-model"},
+			Scheduling: &airunwayv1alpha1.SchedulingSpec{KueueAdmission: true},
+		},
+	}
+	requireValidationErrorField(t, v.validateSpec(md), "spec.scheduling.queue")
+
+	md.Spec.Scheduling.Queue = "team-a"
+	for _, err := range v.validateSpec(md) {
+		if strings.HasPrefix(err.Field, "spec.scheduling") {
+			t.Fatalf("expected kueueAdmission with a queue to be admitted, got %v", err)
+		}
+	}
+
+	md.Spec.Scheduling.Gang = true
+	md.Spec.Scheduling.Scheduler = airunwayv1alpha1.GangSchedulerKueue
+	requireValidationErrorField(t, v.validateSpec(md), "spec.scheduling.kueueAdmission")
+}
+
+func TestValidateSpec_GatewayTimeouts(t *testing.T) {
+	validator := &ModelDeploymentCustomValidator{}
+	md := &airunwayv1alpha1.ModelDeployment{
+		Spec: airunwayv1alpha1.ModelDeploymentSpec{
+			Model: airunwayv1alpha1.ModelSpec{ID: "test-model"},
+			Gateway: &airunwayv1alpha1.GatewaySpec{
+				Streaming: true,
+				Timeout:   &metav1.Duration{Duration: -time.Second},
+			},
+		},
+	}
+
+	errs := validator.validateSpec(md)
+	requireValidationErrorField(t, errs, "spec.gateway.timeout")
+
+	md.Spec.Gateway.Timeout = &metav1.Duration{Duration: 0}
+	for _, err := range validator.validateSpec(md) {
+		if strings.HasPrefix(err.Field, "spec.gateway") {
+			t.Errorf("unexpected gateway validation error: %v", err)
+		}
+	}
+}
+
+func TestValidateSpec_GatewayModelNameTemplate(t *testing.T) {
+	validator := &ModelDeploymentCustomValidator{}
+	md := &airunwayv1alpha1.ModelDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "team-a"},
+		Spec: airunwayv1alpha1.ModelDeploymentSpec{
+			Model:   airunwayv1alpha1.ModelSpec{ID: "meta-llama/Llama-3-8B"},
+			Gateway: &airunwayv1alpha1.GatewaySpec{ModelNameTemplate: "{{ .Tenant }}/{{ .Name }}"},
+		},
+	}
+	requireValidationErrorField(t, validator.validateSpec(md), "spec.gateway.modelNameTemplate")
+
+	md.Spec.Gateway.ModelNameTemplate = "{{ .Namespace }}/{{ .Name }}"
+	for _, err := range validator.validateSpec(md) {
+		if err.Field == "spec.gateway.modelNameTemplate" {
+			t.Errorf("unexpected validation error: %v", err)
+		}
+	}
+}
+
+func TestValidateSpec_GatewayEPPConfig(t *testing.T) {
+	validator := &ModelDeploymentCustomValidator{}
+	md := &airunwayv1alpha1.ModelDeployment{
+		Spec: airunwayv1alpha1.ModelDeploymentSpec{
+			Model:   airunwayv1alpha1.ModelSpec{ID: "test-model"},
+			Gateway: &airunwayv1alpha1.GatewaySpec{EPPConfig: "kind: ConfigMap\n"},
+		},
+	}
+	requireValidationErrorField(t, validator.validateSpec(md), "spec.gateway.eppConfig")
+
+	md.Spec.Gateway.EPPConfig = "apiVersion: inference.networking.x-k8s.io/v1alpha1\nkind: EndpointPickerConfig\nplugins:\n- type: queue-scorer\n"
+	for _, err := range validator.validateSpec(md) {
+		if err.Field == "spec.gateway.eppConfig" {
+			t.Errorf("unexpected validation error: %v", err)
+		}
+	}
+}
+
+func TestValidateSpec_GatewaySessionAffinity(t *testing.T) {
+	validator := &ModelDeploymentCustomValidator{}
+	md := &airunwayv1alpha1.ModelDeployment{
+		Spec: airunwayv1alpha1.ModelDeploymentSpec{
+			Model:   airunwayv1alpha1.ModelSpec{ID: "test-model"},
+			Gateway: &airunwayv1alpha1.GatewaySpec{SessionAffinity: airunwayv1alpha1.SessionAffinityPrefixCache},
+		},
+	}
+	for _, err := range validator.validateSpec(md) {
+		if err.Field == "spec.gateway.sessionAffinity" {
+			t.Errorf("unexpected validation error: %v", err)
+		}
+	}
+
+	md.Spec.Gateway.EPPConfig = "apiVersion: inference.networking.x-k8s.io/v1alpha1\nkind: EndpointPickerConfig\n"
+	requireValidationErrorField(t, validator.validateSpec(md), "spec.gateway.sessionAffinity")
+}
+
+func TestValidateSpec_GatewayRateLimit(t *testing.T) {
+	validator := &ModelDeploymentCustomValidator{}
+	md := &airunwayv1alpha1.ModelDeployment{
+		Spec: airunwayv1alpha1.ModelDeploymentSpec{
+			Model:   airunwayv1alpha1.ModelSpec{ID: "test-model"},
+			Gateway: &airunwayv1alpha1.GatewaySpec{RateLimit: &airunwayv1alpha1.RateLimitSpec{}},
+		},
+	}
+	requireValidationErrorField(t, validator.validateSpec(md), "spec.gateway.rateLimit")
+
+	md.Spec.Gateway.RateLimit = &airunwayv1alpha1.RateLimitSpec{MaxConcurrent: 4, Burst: 10}
+	requireValidationErrorField(t, validator.validateSpec(md), "spec.gateway.rateLimit.burst")
+
+	md.Spec.Gateway.RateLimit = &airunwayv1alpha1.RateLimitSpec{RequestsPerMinute: 60, Burst: 10, MaxConcurrent: 4}
+	for _, err := range validator.validateSpec(md) {
+		if strings.HasPrefix(err.Field, "spec.gateway.rateLimit") {
+			t.Errorf("unexpected validation error: %v", err)
+		}
+	}
+}
+
+func TestValidateSpec_GatewayPromptPolicy(t *testing.T) {
+	validator := &ModelDeploymentCustomValidator{}
+	md := &airunwayv1alpha1.ModelDeployment{
+		Spec: airunwayv1alpha1.ModelDeploymentSpec{
+			Model:   airunwayv1alpha1.ModelSpec{ID: "test-model"},
+			Gateway: &airunwayv1alpha1.GatewaySpec{PromptPolicy: &airunwayv1alpha1.PromptPolicySpec{}},
+		},
+	}
+	requireValidationErrorField(t, validator.validateSpec(md), "spec.gateway.promptPolicy")
+
+	md.Spec.Gateway.PromptPolicy = &airunwayv1alpha1.PromptPolicySpec{Stop: []string{"</answer>"}}
+	for _, err := range validator.validateSpec(md) {
+		if strings.HasPrefix(err.Field, "spec.gateway.promptPolicy") {
+			t.Errorf("unexpected validation error: %v", err)
+		}
+	}
+}
+
+func TestValidateSpec_GatewayGuardrails(t *testing.T) {
+	validator := &ModelDeploymentCustomValidator{}
+	md := &airunwayv1alpha1.ModelDeployment{
+		Spec: airunwayv1alpha1.ModelDeploymentSpec{
+			Model: airunwayv1alpha1.ModelSpec{ID: "test-model"},
+			Gateway: &airunwayv1alpha1.GatewaySpec{Guardrails: &airunwayv1alpha1.GuardrailsSpec{
+				Endpoint: "http://",
+			}},
+		},
+	}
+	requireValidationErrorField(t, validator.validateSpec(md), "spec.gateway.guardrails.endpoint")
+
+	md.Spec.Gateway.Guardrails = &airunwayv1alpha1.GuardrailsSpec{
+		Endpoint: "http://llama-guard.safety:8000/v1/moderations",
+		Timeout:  &metav1.Duration{Duration: -time.Second},
+	}
+	requireValidationErrorField(t, validator.validateSpec(md), "spec.gateway.guardrails.timeout")
+
+	md.Spec.Gateway.Guardrails.Timeout = &metav1.Duration{Duration: 2 * time.Second}
+	for _, err := range validator.validateSpec(md) {
+		if strings.HasPrefix(err.Field, "spec.gateway.guardrails") {
+			t.Errorf("unexpected validation error: %v", err)
+		}
+	}
+}
+
+func TestValidateSpec_ServingPlacement(t *testing.T) {
+	validator := &ModelDeploymentCustomValidator{}
+	md := &airunwayv1alpha1.ModelDeployment{
+		Spec: airunwayv1alpha1.ModelDeploymentSpec{
+			Model: airunwayv1alpha1.ModelSpec{ID: "test-model"},
+			Serving: &airunwayv1alpha1.ServingSpec{
+				Mode:      airunwayv1alpha1.ServingModeAggregated,
+				Placement: &airunwayv1alpha1.PlacementSpec{Colocate: airunwayv1alpha1.PlacementDomainZone, TopologyKey: "not a label"},
+			},
+		},
+	}
+	errs := validator.validateSpec(md)
+	requireValidationErrorField(t, errs, "spec.serving.placement")
+	requireValidationErrorField(t, errs, "spec.serving.placement.topologyKey")
+
+	md.Spec.Serving.Mode = airunwayv1alpha1.ServingModeDisaggregated
+	md.Spec.Serving.Placement.TopologyKey = "example.com/rack"
+	for _, err := range validator.validateSpec(md) {
+		if strings.HasPrefix(err.Field, "spec.serving.placement") {
+			t.Errorf("unexpected validation error: %v", err)
+		}
+	}
+}
+
+func TestValidateSpec_ComponentImages(t *testing.T) {
+	validator := &ModelDeploymentCustomValidator{}
+	md := &airunwayv1alpha1.ModelDeployment{
+		Spec: airunwayv1alpha1.ModelDeploymentSpec{
+			Model:   airunwayv1alpha1.ModelSpec{ID: "test-model"},
+			Serving: &airunwayv1alpha1.ServingSpec{Mode: airunwayv1alpha1.ServingModeAggregated},
+			Scaling: &airunwayv1alpha1.ScalingSpec{
+				Prefill: &airunwayv1alpha1.ComponentScalingSpec{Replicas: 1, Image: "example.com/vllm-nixl:1.0"},
+			},
+		},
+	}
+	requireValidationErrorField(t, validator.validateSpec(md), "spec.scaling.prefill.image")
+
+	md.Spec.Serving.Mode = airunwayv1alpha1.ServingModeDisaggregated
+	for _, err := range validator.validateSpec(md) {
+		if err.Field == "spec.scaling.prefill.image" {
+			t.Errorf("unexpected validation error: %v", err)
+		}
+	}
+}
+
+func TestValidateSpec_ComponentScheduling(t *testing.T) {
+	validator := &ModelDeploymentCustomValidator{}
+	md := &airunwayv1alpha1.ModelDeployment{
+		Spec: airunwayv1alpha1.ModelDeploymentSpec{
+			Model:   airunwayv1alpha1.ModelSpec{ID: "test-model"},
+			Serving: &airunwayv1alpha1.ServingSpec{Mode: airunwayv1alpha1.ServingModeAggregated},
+			Scaling: &airunwayv1alpha1.ScalingSpec{
+				Decode: &airunwayv1alpha1.ComponentScalingSpec{
+					Replicas:   1,
+					Scheduling: &airunwayv1alpha1.ComponentSchedulingSpec{NodeSelector: map[string]string{"gpu": "a100"}},
+				},
+			},
+		},
+	}
+	requireValidationErrorField(t, validator.validateSpec(md), "spec.scaling.decode.scheduling")
+
+	md.Spec.Serving.Mode = airunwayv1alpha1.ServingModeDisaggregated
+	for _, err := range validator.validateSpec(md) {
+		if err.Field == "spec.scaling.decode.scheduling" {
+			t.Errorf("unexpected validation error: %v", err)
+		}
+	}
+}
+
+func TestValidateSpec_Router(t *testing.T) {
+	tests := []struct {
+		name      string
+		router    *airunwayv1alpha1.RouterSpec
+		wantField string
+	}{
+		{
+			name:      "too many replicas",
+			router:    &airunwayv1alpha1.RouterSpec{Replicas: int32Ptr(MaxReplicas + 1)},
+			wantField: "spec.serving.router.replicas",
+		},
+		{
+			name:      "zero replicas",
+			router:    &airunwayv1alpha1.RouterSpec{Replicas: int32Ptr(0)},
+			wantField: "spec.serving.router.replicas",
+		},
+		{
+			name:      "invalid cpu",
+			router:    &airunwayv1alpha1.RouterSpec{CPU: "lots"},
+			wantField: "spec.serving.router.cpu",
+		},
+		{
+			name:      "memory above ceiling",
+			router:    &airunwayv1alpha1.RouterSpec{Memory: "100Ti"},
+			wantField: "spec.serving.router.memory",
+		},
+		{
+			name:      "unknown router mode",
+			router:    &airunwayv1alpha1.RouterSpec{RouterMode: "random"},
+			wantField: "spec.serving.router.routerMode",
+		},
+	}
+
+	validator := &ModelDeploymentCustomValidator{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md := &airunwayv1alpha1.ModelDeployment{
+				Spec: airunwayv1alpha1.ModelDeploymentSpec{
+					Model:   airunwayv1alpha1.ModelSpec{ID: "test-model"},
+					Serving: &airunwayv1alpha1.ServingSpec{Router: tt.router},
+				},
+			}
+			requireValidationErrorField(t, validator.validateSpec(md), tt.wantField)
+		})
+	}
+
+	md := &airunwayv1alpha1.ModelDeployment{
+		Spec: airunwayv1alpha1.ModelDeploymentSpec{
+			Model: airunwayv1alpha1.ModelSpec{ID: "test-model"},
+			Serving: &airunwayv1alpha1.ServingSpec{Router: &airunwayv1alpha1.RouterSpec{
+				Replicas: int32Ptr(2), CPU: "2", Memory: "4Gi", RouterMode: airunwayv1alpha1.RouterModeKV,
+			}},
+		},
+	}
+	for _, err := range validator.validateSpec(md) {
+		if strings.HasPrefix(err.Field, "spec.serving.router") {
+			t.Errorf("unexpected validation error: %v", err)
+		}
+	}
+	md.Spec.Serving.Router.RouterMode = airunwayv1alpha1.RouterModeNone
+	for _, err := range validator.validateSpec(md) {
+		if strings.HasPrefix(err.Field, "spec.serving.router") {
+			t.Errorf("unexpected validation error for router mode none: %v", err)
+		}
+	}
+}
+
+func TestValidateSpec_RequestsLimits(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources *airunwayv1alpha1.ResourceSpec
+		scaling   *airunwayv1alpha1.ScalingSpec
+		wantField string
+	}{
+		{
+			name: "invalid request",
+			resources: &airunwayv1alpha1.ResourceSpec{
+				Requests: &airunwayv1alpha1.ResourceQuantities{CPU: "lots"},
+			},
+			wantField: "spec.resources.requests.cpu",
+		},
+		{
+			name: "limit above ceiling",
+			resources: &airunwayv1alpha1.ResourceSpec{
+				Limits: &airunwayv1alpha1.ResourceQuantities{Memory: "100Ti"},
+			},
+			wantField: "spec.resources.limits.memory",
+		},
+		{
+			name: "request above limit",
+			resources: &airunwayv1alpha1.ResourceSpec{
+				Requests: &airunwayv1alpha1.ResourceQuantities{Memory: "64Gi"},
+				Limits:   &airunwayv1alpha1.ResourceQuantities{Memory: "32Gi"},
+			},
+			wantField: "spec.resources.requests.memory",
+		},
+		{
+			name: "request above shorthand",
+			resources: &airunwayv1alpha1.ResourceSpec{
+				CPU:      "4",
+				Requests: &airunwayv1alpha1.ResourceQuantities{CPU: "8"},
+			},
+			wantField: "spec.resources.requests.cpu",
+		},
+		{
+			name: "limit below shorthand",
+			resources: &airunwayv1alpha1.ResourceSpec{
+				Memory: "32Gi",
+				Limits: &airunwayv1alpha1.ResourceQuantities{Memory: "16Gi"},
+			},
+			wantField: "spec.resources.requests.memory",
+		},
+		{
+			name: "invalid shorthand with limit",
+			resources: &airunwayv1alpha1.ResourceSpec{
+				CPU:    "abc",
+				Limits: &airunwayv1alpha1.ResourceQuantities{CPU: "1"},
+			},
+			wantField: "spec.resources.cpu",
+		},
+		{
+			name: "invalid component shorthand with request",
+			scaling: &airunwayv1alpha1.ScalingSpec{
+				Prefill: &airunwayv1alpha1.ComponentScalingSpec{
+					Memory:   "lots",
+					Requests: &airunwayv1alpha1.ResourceQuantities{Memory: "1Gi"},
+				},
+			},
+			wantField: "spec.scaling.prefill.memory",
+		},
+		{
+			name: "component request above limit",
+			scaling: &airunwayv1alpha1.ScalingSpec{
+				Prefill: &airunwayv1alpha1.ComponentScalingSpec{
+					Requests: &airunwayv1alpha1.ResourceQuantities{CPU: "8"},
+					Limits:   &airunwayv1alpha1.ResourceQuantities{CPU: "4"},
+				},
+			},
+			wantField: "spec.scaling.prefill.requests.cpu",
+		},
+		{
+			name: "autotune with explicit requests",
+			resources: &airunwayv1alpha1.ResourceSpec{
+				Autotune:       true,
+				AutotuneBounds: &airunwayv1alpha1.AutotuneBounds{MaxCPU: "8"},
+				Requests:       &airunwayv1alpha1.ResourceQuantities{CPU: "2"},
+			},
+			wantField: "spec.resources.autotune",
+		},
+	}
+
+	validator := &ModelDeploymentCustomValidator{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md := &airunwayv1alpha1.ModelDeployment{
+				Spec: airunwayv1alpha1.ModelDeploymentSpec{
+					Model:     airunwayv1alpha1.ModelSpec{ID: "test-model"},
+					Resources: tt.resources,
+					Scaling:   tt.scaling,
+				},
+			}
+			requireValidationErrorField(t, validator.validateSpec(md), tt.wantField)
+		})
+	}
+
+	md := &airunwayv1alpha1.ModelDeployment{
+		Spec: airunwayv1alpha1.ModelDeploymentSpec{
+			Model: airunwayv1alpha1.ModelSpec{ID: "test-model"},
+			Resources: &airunwayv1alpha1.ResourceSpec{
+				CPU:      "4",
+				Memory:   "32Gi",
+				Requests: &airunwayv1alpha1.ResourceQuantities{CPU: "2"},
+				Limits:   &airunwayv1alpha1.ResourceQuantities{CPU: "8", Memory: "48Gi"},
+			},
+		},
+	}
+	for _, err := range validator.validateSpec(md) {
+		if strings.HasPrefix(err.Field, "spec.resources") {
+			t.Errorf("unexpected validation error: %v", err)
+		}
+	}
+}
+
+func TestCheckWarnings_DisaggregatedWithoutPlacement(t *testing.T) {
+	validator := &ModelDeploymentCustomValidator{}
+	md := &airunwayv1alpha1.ModelDeployment{
+		Spec: airunwayv1alpha1.ModelDeploymentSpec{
+			Model:   airunwayv1alpha1.ModelSpec{ID: "test-model"},
+			Serving: &airunwayv1alpha1.ServingSpec{Mode: airunwayv1alpha1.ServingModeDisaggregated},
+		},
+	}
+	if len(validator.checkWarnings(md)) == 0 {
+		t.Error("expected placement warning for disaggregated mode")
+	}
+
+	md.Spec.Serving.Placement = &airunwayv1alpha1.PlacementSpec{Colocate: airunwayv1alpha1.PlacementDomainNVLinkDomain}
+	for _, w := range validator.checkWarnings(md) {
+		if strings.Contains(w, "serving.placement") {
+			t.Errorf("unexpected warning: %s", w)
+		}
+	}
+}
+
+func TestValidateSpec_PropagatedMetadata(t *testing.T) {
+	validator := &ModelDeploymentCustomValidator{}
+	md := &airunwayv1alpha1.ModelDeployment{
+		Spec: airunwayv1alpha1.ModelDeploymentSpec{
+			Model:       airunwayv1alpha1.ModelSpec{ID: "test-model"},
+			Labels:      map[string]string{"cost-center": "ml-42", "example.com/team": "search"},
+			Annotations: map[string]string{"example.com/owner": "search-team@example.com"},
+		},
+	}
+	for _, err := range validator.validateSpec(md) {
+		if strings.HasPrefix(err.Field, "spec.labels") || strings.HasPrefix(err.Field, "spec.annotations") {
+			t.Errorf("unexpected validation error: %v", err)
+		}
+	}
+
+	md.Spec.Labels["cost center"] = "ml-42"
+	requireValidationErrorField(t, validator.validateSpec(md), "spec.labels")
+	delete(md.Spec.Labels, "cost center")
+	md.Spec.Labels["cost-center"] = "ml 42"
+	requireValidationErrorField(t, validator.validateSpec(md), "spec.labels")
+	md.Spec.Labels["cost-center"] = "ml-42"
+	md.Spec.Annotations["-owner"] = "x"
+	requireValidationErrorField(t, validator.validateSpec(md), "spec.annotations")
+}
+
+func TestCheckWarnings_ReservedPropagatedMetadata(t *testing.T) {
+	validator := &ModelDeploymentCustomValidator{}
+	md := &airunwayv1alpha1.ModelDeployment{
+		Spec: airunwayv1alpha1.ModelDeploymentSpec{
+			Model:       airunwayv1alpha1.ModelSpec{ID: "test-model"},
+			Labels:      map[string]string{"cost-center": "ml-42", "airunway.ai/deployment": "other"},
+			Annotations: map[string]string{"node.kubernetes.io/owner": "x", "airunway.ai/deployment": "other"},
+		},
+	}
+	var reserved []string
+	for _, w := range validator.checkWarnings(md) {
+		if strings.Contains(w, "reserved domain") {
+			reserved = append(reserved, w)
+		}
+	}
+	if len(reserved) != 2 || !strings.Contains(reserved[0], "airunway.ai/deployment") || !strings.Contains(reserved[1], "node.kubernetes.io/owner") {
+		t.Errorf("expected one warning per reserved key, got %v", reserved)
+	}
+}
+
+func TestValidateAutotune(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources *airunwayv1alpha1.ResourceSpec
+		wantField string
+	}{
+		{
+			name:      "autotune without bounds",
+			resources: &airunwayv1alpha1.ResourceSpec{Autotune: true},
+			wantField: "spec.resources.autotuneBounds",
+		},
+		{
+			name: "min cpu above max",
+			resources: &airunwayv1alpha1.ResourceSpec{Autotune: true, AutotuneBounds: &airunwayv1alpha1.AutotuneBounds{
+				MinCPU: "8", MaxCPU: "4",
+			}},
+			wantField: "spec.resources.autotuneBounds.minCPU",
+		},
+		{
+			name: "max memory above ceiling",
+			resources: &airunwayv1alpha1.ResourceSpec{Autotune: true, AutotuneBounds: &airunwayv1alpha1.AutotuneBounds{
+				MaxMemory: "8Ti",
+			}},
+			wantField: "spec.resources.autotuneBounds.maxMemory",
+		},
+		{
+			name: "valid bounds",
+			resources: &airunwayv1alpha1.ResourceSpec{Autotune: true, AutotuneBounds: &airunwayv1alpha1.AutotuneBounds{
+				MinCPU: "1", MaxCPU: "16", MinMemory: "8Gi", MaxMemory: "64Gi",
+			}},
+		},
+		{
+			name:      "autotune disabled",
+			resources: &airunwayv1alpha1.ResourceSpec{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validateAutotune(tt.resources, field.NewPath("spec", "resources"))
+			if tt.wantField == "" {
+				if len(errs) != 0 {
+					t.Fatalf("expected no errors, got %v", errs)
+				}
+				return
+			}
+			if len(errs) != 1 || errs[0].Field != tt.wantField {
+				t.Fatalf("expected one error on %s, got %v", tt.wantField, errs)
+			}
+		})
+	}
+}
+
+func TestValidateIdentity(t *testing.T) {
+	tests := []struct {
+		name      string
+		identity  *airunwayv1alpha1.IdentitySpec
+		wantField string
+	}{
+		{
+			name:     "existing service account",
+			identity: &airunwayv1alpha1.IdentitySpec{ServiceAccountName: "model-reader"},
+		},
+		{
+			name: "workload identity annotations",
+			identity: &airunwayv1alpha1.IdentitySpec{Annotations: map[string]string{
+				"azure.workload.identity/client-id": "00000000-0000-0000-0000-000000000000",
+				"azure.workload.identity/tenant-id": "00000000-0000-0000-0000-000000000000",
+			}},
+		},
+		{
+			name:      "invalid service account name",
+			identity:  &airunwayv1alpha1.IdentitySpec{ServiceAccountName: "Model_Reader"},
+			wantField: "spec.identity.serviceAccountName",
+		},
+		{
+			name: "annotations with service account name",
+			identity: &airunwayv1alpha1.IdentitySpec{
+				ServiceAccountName: "model-reader",
+				Annotations:        map[string]string{"eks.amazonaws.com/role-arn": "arn:aws:iam::111122223333:role/reader"},
+			},
+			wantField: "spec.identity.annotations",
+		},
+		{
+			name:      "unrelated annotation",
+			identity:  &airunwayv1alpha1.IdentitySpec{Annotations: map[string]string{"kubernetes.io/enforce-mountable-secrets": "true"}},
+			wantField: "spec.identity.annotations[kubernetes.io/enforce-mountable-secrets]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validateIdentity(tt.identity, field.NewPath("spec", "identity"))
+			if tt.wantField == "" {
+				if len(errs) != 0 {
+					t.Fatalf("expected no errors, got %v", errs)
+				}
+				return
+			}
+			requireValidationErrorField(t, errs, tt.wantField)
+		})
+	}
+}
+
+func TestValidateExpose(t *testing.T) {
+	tests := []struct {
+		name      string
+		mdName    string
+		expose    *airunwayv1alpha1.ExposeSpec
+		wantField string
+	}{
+		{
+			name:   "load balancer",
+			expose: &airunwayv1alpha1.ExposeSpec{Type: airunwayv1alpha1.ExposeTypeLoadBalancer},
+		},
+		{
+			name: "ingress with class and host",
+			expose: &airunwayv1alpha1.ExposeSpec{
+				Type:             airunwayv1alpha1.ExposeTypeIngress,
+				IngressClassName: "nginx",
+				Host:             "llm.example.com",
+			},
+		},
+		{
+			name:      "host without ingress",
+			expose:    &airunwayv1alpha1.ExposeSpec{Type: airunwayv1alpha1.ExposeTypeNodePort, Host: "llm.example.com"},
+			wantField: "spec.expose.host",
+		},
+		{
+			name:      "ingress class without ingress",
+			expose:    &airunwayv1alpha1.ExposeSpec{Type: airunwayv1alpha1.ExposeTypeClusterIP, IngressClassName: "nginx"},
+			wantField: "spec.expose.ingressClassName",
+		},
+		{
+			name:      "invalid host",
+			expose:    &airunwayv1alpha1.ExposeSpec{Type: airunwayv1alpha1.ExposeTypeIngress, Host: "llm_example"},
+			wantField: "spec.expose.host",
+		},
+		{
+			name:      "service name too long",
+			mdName:    strings.Repeat("a", 60),
+			expose:    &airunwayv1alpha1.ExposeSpec{Type: airunwayv1alpha1.ExposeTypeClusterIP},
+			wantField: "metadata.name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md := &airunwayv1alpha1.ModelDeployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-model"},
+				Spec:       airunwayv1alpha1.ModelDeploymentSpec{Expose: tt.expose},
+			}
+			if tt.mdName != "" {
+				md.Name = tt.mdName
+			}
+			errs := validateExpose(md, field.NewPath("spec", "expose"))
+			if tt.wantField == "" {
+				if len(errs) != 0 {
+					t.Fatalf("expected no errors, got %v", errs)
+				}
+				return
+			}
+			requireValidationErrorField(t, errs, tt.wantField)
+		})
+	}
+}
+
+func TestValidateHTTPEndpoint(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		wantErr  bool
+	}{
+		{endpoint: "http://otel-collector.observability:4317"},
+		{endpoint: "https://otlp.example.com"},
+		{endpoint: "otel-collector:4317", wantErr: true},
+		{endpoint: "grpc://otel-collector:4317", wantErr: true},
+		{endpoint: "http://", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.endpoint, func(t *testing.T) {
+			errs := validateHTTPEndpoint(tt.endpoint, field.NewPath("spec", "observability", "tracing", "endpoint"))
+			if tt.wantErr {
+				requireValidationErrorField(t, errs, "spec.observability.tracing.endpoint")
+			} else if len(errs) != 0 {
+				t.Fatalf("expected no errors, got %v", errs)
+			}
+		})
+	}
+}
+
+func TestValidateEngineDevice(t *testing.T) {
+	newSpec := func(engine airunwayv1alpha1.EngineType, device airunwayv1alpha1.EngineDevice, gpus int32) *airunwayv1alpha1.ModelDeployment {
+		return &airunwayv1alpha1.ModelDeployment{
+			Spec: airunwayv1alpha1.ModelDeploymentSpec{
+				Model:     airunwayv1alpha1.ModelSpec{ID: "test/model", Source: airunwayv1alpha1.ModelSourceHuggingFace},
+				Engine:    airunwayv1alpha1.EngineSpec{Type: engine, Device: device},
+				Resources: &airunwayv1alpha1.ResourceSpec{GPU: &airunwayv1alpha1.GPUSpec{Count: gpus}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		md        *airunwayv1alpha1.ModelDeployment
+		wantField string
+	}{
+		{name: "vllm on cpu", md: newSpec(airunwayv1alpha1.EngineTypeVLLM, airunwayv1alpha1.EngineDeviceCPU, 0)},
+		{name: "vllm auto without gpu", md: newSpec(airunwayv1alpha1.EngineTypeVLLM, "", 0), wantField: "spec.resources.gpu.count"},
+		{name: "gpu device without gpu", md: newSpec(airunwayv1alpha1.EngineTypeVLLM, airunwayv1alpha1.EngineDeviceGPU, 0), wantField: "spec.resources.gpu.count"},
+		{name: "cpu device with gpu", md: newSpec(airunwayv1alpha1.EngineTypeVLLM, airunwayv1alpha1.EngineDeviceCPU, 1), wantField: "spec.engine.device"},
+		{name: "sglang on cpu", md: newSpec(airunwayv1alpha1.EngineTypeSGLang, airunwayv1alpha1.EngineDeviceCPU, 0), wantField: "spec.engine.device"},
+	}
+
+	v := &ModelDeploymentCustomValidator{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := v.validateSpec(tt.md)
+			if tt.wantField == "" {
+				if len(errs) != 0 {
+					t.Fatalf("expected no errors, got %v", errs)
+				}
+				return
+			}
+			requireValidationErrorField(t, errs, tt.wantField)
+		})
+	}
+}
+
+func TestDefaultCPUDeviceSkipsGPU(t *testing.T) {
+	d := &ModelDeploymentCustomDefaulter{}
+	md := &airunwayv1alpha1.ModelDeployment{
+		Spec: airunwayv1alpha1.ModelDeploymentSpec{
+			Model:  airunwayv1alpha1.ModelSpec{ID: "test/model"},
+			Engine: airunwayv1alpha1.EngineSpec{Type: airunwayv1alpha1.EngineTypeVLLM, Device: airunwayv1alpha1.EngineDeviceCPU},
+		},
+	}
+	if err := d.Default(context.Background(), md); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if md.Spec.Resources != nil && md.Spec.Resources.GPU != nil {
+		t.Errorf("expected no GPU default for cpu device, got %+v", md.Spec.Resources.GPU)
+	}
+}
+
+func TestValidateImmutableFields(t *testing.T) {
+	v := &ModelDeploymentCustomValidator{}
+	newMD := func() *airunwayv1alpha1.ModelDeployment {
+		return &airunwayv1alpha1.ModelDeployment{
+			Spec: airunwayv1alpha1.ModelDeploymentSpec{
+				Model: airunwayv1alpha1.ModelSpec{ID: "test/model", Source: airunwayv1alpha1.ModelSourceHuggingFace},
+			},
+			Status: airunwayv1alpha1.ModelDeploymentStatus{
+				Phase:    airunwayv1alpha1.DeploymentPhaseRunning,
+				Engine:   &airunwayv1alpha1.EngineStatus{Type: airunwayv1alpha1.EngineTypeVLLM},
+				Provider: &airunwayv1alpha1.ProviderStatus{Name: "kaito"},
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		mutate    func(oldMD, newMD *airunwayv1alpha1.ModelDeployment)
+		wantField string
+		wantMsg   string
+	}{
+		{
+			name:      "model id changed",
+			mutate:    func(_, md *airunwayv1alpha1.ModelDeployment) { md.Spec.Model.ID = "other/model" },
+			wantField: "spec.model.id",
+		},
+		{
+			name: "explicit engine changed",
+			mutate: func(old, md *airunwayv1alpha1.ModelDeployment) {
+				old.Spec.Engine.Type = airunwayv1alpha1.EngineTypeVLLM
+				md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeSGLang
+			},
+			wantField: "spec.engine.type",
+		},
+		{
+			name:      "auto-selected engine overridden while running",
+			mutate:    func(_, md *airunwayv1alpha1.ModelDeployment) { md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeSGLang },
+			wantField: "spec.engine.type",
+			wantMsg:   "auto-selected engine is vllm",
+		},
+		{
+			name: "auto-selected engine overridden before running",
+			mutate: func(old, md *airunwayv1alpha1.ModelDeployment) {
+				old.Status.Phase = airunwayv1alpha1.DeploymentPhaseDeploying
+				md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeSGLang
+			},
+		},
+		{
+			name:   "auto-selected engine made explicit",
+			mutate: func(_, md *airunwayv1alpha1.ModelDeployment) { md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeVLLM },
+		},
+		{
+			name: "selected provider overridden",
+			mutate: func(_, md *airunwayv1alpha1.ModelDeployment) {
+				md.Spec.Provider = &airunwayv1alpha1.ProviderSpec{Name: "dynamo"}
+			},
+			wantField: "spec.provider.name",
+			wantMsg:   "selected provider is kaito",
+		},
+		{
+			name: "selected provider pinned",
+			mutate: func(_, md *airunwayv1alpha1.ModelDeployment) {
+				md.Spec.Provider = &airunwayv1alpha1.ProviderSpec{Name: "kaito"}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldMD, md := newMD(), newMD()
+			tt.mutate(oldMD, md)
+			errs := v.validateImmutableFields(oldMD, md, false)
+			if tt.wantField == "" {
+				if len(errs) != 0 {
+					t.Fatalf("expected no errors, got %v", errs)
+				}
+				return
+			}
+			requireValidationErrorField(t, errs, tt.wantField)
+			if tt.wantMsg != "" && !strings.Contains(errs.ToAggregate().Error(), tt.wantMsg) {
+				t.Errorf("expected error to mention %q, got %v", tt.wantMsg, errs)
+			}
+		})
+	}
+}
+
+func int32Ptr(i int32) *int32 { return &i }
+
+func TestValidateGPUTypes(t *testing.T) {
+	newSpec := func(gpus int32, types ...string) *airunwayv1alpha1.ModelDeploymentSpec {
+		return &airunwayv1alpha1.ModelDeploymentSpec{
+			Resources: &airunwayv1alpha1.ResourceSpec{GPU: &airunwayv1alpha1.GPUSpec{Count: gpus, Types: types}},
+		}
+	}
+	disaggregated := newSpec(1, "NVIDIA-H100-80GB-HBM3")
+	disaggregated.Serving = &airunwayv1alpha1.ServingSpec{Mode: airunwayv1alpha1.ServingModeDisaggregated}
+	component := newSpec(1)
+	component.Scaling = &airunwayv1alpha1.ScalingSpec{
+		Decode: &airunwayv1alpha1.ComponentScalingSpec{GPU: &airunwayv1alpha1.GPUSpec{Count: 1, Types: []string{"NVIDIA-H100-80GB-HBM3"}}},
+	}
+
+	tests := []struct {
+		name      string
+		spec      *airunwayv1alpha1.ModelDeploymentSpec
+		wantField string
+	}{
+		{name: "no types", spec: newSpec(1)},
+		{name: "valid types", spec: newSpec(1, "NVIDIA-H100-80GB-HBM3", "NVIDIA-A100-SXM4-80GB")},
+		{name: "without gpus", spec: newSpec(0, "NVIDIA-H100-80GB-HBM3"), wantField: "spec.resources.gpu.types"},
+		{name: "invalid label value", spec: newSpec(1, "NVIDIA H100"), wantField: "spec.resources.gpu.types[0]"},
+		{name: "empty type", spec: newSpec(1, "NVIDIA-H100-80GB-HBM3", ""), wantField: "spec.resources.gpu.types[1]"},
+		{name: "disaggregated", spec: disaggregated, wantField: "spec.resources.gpu.types"},
+		{name: "component types", spec: component, wantField: "spec.scaling.decode.gpu.types"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validateGPUTypes(tt.spec, field.NewPath("spec"))
+			if tt.wantField == "" {
+				if len(errs) != 0 {
+					t.Fatalf("expected no errors, got %v", errs)
+				}
+				return
+			}
+			requireValidationErrorField(t, errs, tt.wantField)
+		})
+	}
+}
+
+func TestValidateChatTemplate(t *testing.T) {
+	ref := func(name, key string) *airunwayv1alpha1.ChatTemplateSource {
+		return &airunwayv1alpha1.ChatTemplateSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: name}, Key: key,
+		}}
+	}
+	both := ref("templates", "llama.jinja")
+	both.Inline = "{{ messages }}"
+
+	tests := []struct {
+		name      string
+		model     airunwayv1alpha1.ModelSpec
+		wantField string
+	}{
+		{name: "unset", model: airunwayv1alpha1.ModelSpec{}},
+		{name: "inline", model: airunwayv1alpha1.ModelSpec{ChatTemplate: &airunwayv1alpha1.ChatTemplateSource{Inline: "{{ messages }}"}}},
+		{name: "configmap", model: airunwayv1alpha1.ModelSpec{ChatTemplate: ref("templates", "llama.jinja")}},
+		{name: "empty", model: airunwayv1alpha1.ModelSpec{ChatTemplate: &airunwayv1alpha1.ChatTemplateSource{}}, wantField: "spec.model.chatTemplate"},
+		{name: "both", model: airunwayv1alpha1.ModelSpec{ChatTemplate: both}, wantField: "spec.model.chatTemplate"},
+		{name: "missing name", model: airunwayv1alpha1.ModelSpec{ChatTemplate: ref("", "llama.jinja")}, wantField: "spec.model.chatTemplate.configMapKeyRef.name"},
+		{name: "invalid key", model: airunwayv1alpha1.ModelSpec{ChatTemplate: ref("templates", "../llama.jinja")}, wantField: "spec.model.chatTemplate.configMapKeyRef.key"},
+		{name: "tokenizer", model: airunwayv1alpha1.ModelSpec{Tokenizer: "meta-llama/Llama-3.1-8B-Instruct"}},
+		{name: "tokenizer flag", model: airunwayv1alpha1.ModelSpec{Tokenizer: "--trust-remote-code"}, wantField: "spec.model.tokenizer"},
+		{name: "tokenizer whitespace", model: airunwayv1alpha1.ModelSpec{Tokenizer: "org/tok --enforce-eager"}, wantField: "spec.model.tokenizer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validateChatTemplate(&tt.model, field.NewPath("spec", "model"))
+			if tt.wantField == "" {
+				if len(errs) != 0 {
+					t.Fatalf("expected no errors, got %v", errs)
+				}
+				return
+			}
+			requireValidationErrorField(t, errs, tt.wantField)
+		})
+	}
+}
+
+func TestValidateNetworking(t *testing.T) {
+	policy := func(p corev1.IPFamilyPolicy) *corev1.IPFamilyPolicy { return &p }
+
+	tests := []struct {
+		name       string
+		networking airunwayv1alpha1.NetworkingSpec
+		wantField  string
+	}{
+		{name: "ipv6 only", networking: airunwayv1alpha1.NetworkingSpec{IPFamilies: []corev1.IPFamily{corev1.IPv6Protocol}}},
+		{name: "prefer dual stack", networking: airunwayv1alpha1.NetworkingSpec{IPFamilyPolicy: policy(corev1.IPFamilyPolicyPreferDualStack)}},
+		{
+			name: "dual stack",
+			networking: airunwayv1alpha1.NetworkingSpec{
+				IPFamilyPolicy: policy(corev1.IPFamilyPolicyRequireDualStack),
+				IPFamilies:     []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol},
+			},
+		},
+		{
+			name: "duplicate family",
+			networking: airunwayv1alpha1.NetworkingSpec{
+				IPFamilyPolicy: policy(corev1.IPFamilyPolicyRequireDualStack),
+				IPFamilies:     []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv6Protocol},
+			},
+			wantField: "spec.networking.ipFamilies[1]",
+		},
+		{
+			name:       "two families without policy",
+			networking: airunwayv1alpha1.NetworkingSpec{IPFamilies: []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol}},
+			wantField:  "spec.networking.ipFamilyPolicy",
+		},
+		{
+			name: "two families single stack",
+			networking: airunwayv1alpha1.NetworkingSpec{
+				IPFamilyPolicy: policy(corev1.IPFamilyPolicySingleStack),
+				IPFamilies:     []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol},
+			},
+			wantField: "spec.networking.ipFamilyPolicy",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validateNetworking(&tt.networking, field.NewPath("spec", "networking"))
+			if tt.wantField == "" {
+				if len(errs) != 0 {
+					t.Fatalf("expected no errors, got %v", errs)
+				}
+				return
+			}
+			requireValidationErrorField(t, errs, tt.wantField)
+		})
+	}
+}
+
+func TestValidateSpec_ModelID(t *testing.T) {
+	validator := &ModelDeploymentCustomValidator{}
+	md := &airunwayv1alpha1.ModelDeployment{
+		Spec: airunwayv1alpha1.ModelDeploymentSpec{
+			Model: airunwayv1alpha1.ModelSpec{
+				ID:       "meta-llama/Llama-3.1-8B-Instruct",
+				Source:   airunwayv1alpha1.ModelSourceHuggingFace,
+				Revision: "release/v1.0",
+			},
+		},
+	}
+	for _, err := range validator.validateSpec(md) {
+		if strings.HasPrefix(err.Field, "spec.model") {
+			t.Errorf("unexpected validation error: %v", err)
+		}
+	}
+
+	for _, id := range []string{"org/name/extra", "org/-name", "org/na--me", "org/na..me", "org/name with space", "https://example.com/org/name"} {
+		md.Spec.Model.ID = id
+		requireValidationErrorField(t, validator.validateSpec(md), "spec.model.id")
+	}
+	md.Spec.Model.ID = "org/" + strings.Repeat("a", 97)
+	requireValidationErrorField(t, validator.validateSpec(md), "spec.model.id")
+
+	md.Spec.Model.ID = "gpt2"
+	for _, revision := range []string{"-main", "main branch", "refs/../main"} {
+		md.Spec.Model.Revision = revision
+		requireValidationErrorField(t, validator.validateSpec(md), "spec.model.revision")
+	}
+
+	md.Spec.Model = airunwayv1alpha1.ModelSpec{ID: "/models/custom", Source: airunwayv1alpha1.ModelSourceCustom, Revision: "main"}
+	requireValidationErrorField(t, validator.validateSpec(md), "spec.model.revision")
+}
+
+func TestValidateSpec_KVOffload(t *testing.T) {
+	validator := &ModelDeploymentCustomValidator{}
+	sc := "local-nvme"
+	md := &airunwayv1alpha1.ModelDeployment{
+		Spec: airunwayv1alpha1.ModelDeploymentSpec{
+			Model: airunwayv1alpha1.ModelSpec{
+				ID:     "Qwen/Qwen3-0.6B",
+				Source: airunwayv1alpha1.ModelSourceHuggingFace,
+				Storage: &airunwayv1alpha1.StorageSpec{KVOffload: &airunwayv1alpha1.KVOffloadSpec{
+					Size:             resource.MustParse("200Gi"),
+					Medium:           airunwayv1alpha1.KVOffloadMediumNVMe,
+					StorageClassName: &sc,
+				}},
+			},
+			Engine: airunwayv1alpha1.EngineSpec{Type: airunwayv1alpha1.EngineTypeVLLM},
+		},
+	}
+	for _, err := range validator.validateSpec(md) {
+		if strings.HasPrefix(err.Field, "spec.model.storage") {
+			t.Errorf("unexpected validation error: %v", err)
+		}
+	}
+
+	kv := md.Spec.Model.Storage.KVOffload
+	kv.Medium = airunwayv1alpha1.KVOffloadMediumRAM
+	requireValidationErrorField(t, validator.validateSpec(md), "spec.model.storage.kvOffload.storageClassName")
+	kv.StorageClassName = nil
+	kv.Size = resource.MustParse("0")
+	requireValidationErrorField(t, validator.validateSpec(md), "spec.model.storage.kvOffload.size")
+	kv.Size = resource.MustParse("64Gi")
+
+	md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeTRTLLM
+	requireValidationErrorField(t, validator.validateSpec(md), "spec.model.storage.kvOffload")
+
+	md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeVLLM
+	kv.Medium = airunwayv1alpha1.KVOffloadMediumNVMe
+	md.Spec.Model.Storage.Volumes = []airunwayv1alpha1.StorageVolume{{Name: "scratch", ClaimName: "scratch", MountPath: "/kv-offload"}}
+	requireValidationErrorField(t, validator.validateSpec(md), "spec.model.storage.volumes[0].mountPath")
+	md.Spec.Model.Storage.Volumes = nil
+
+	md.Spec.Serving = &airunwayv1alpha1.ServingSpec{Mode: airunwayv1alpha1.ServingModeDisaggregated}
+	requireValidationErrorField(t, validator.validateSpec(md), "spec.model.storage.kvOffload.medium")
+}
+
+func TestValidateSpec_KVCache(t *testing.T) {
+	validator := &ModelDeploymentCustomValidator{}
+	maxSize := resource.MustParse("20Gi")
+	md := &airunwayv1alpha1.ModelDeployment{
+		Spec: airunwayv1alpha1.ModelDeploymentSpec{
+			Model:  airunwayv1alpha1.ModelSpec{ID: "Qwen/Qwen3-0.6B", Source: airunwayv1alpha1.ModelSourceHuggingFace},
+			Engine: airunwayv1alpha1.EngineSpec{Type: airunwayv1alpha1.EngineTypeVLLM},
+			Caching: &airunwayv1alpha1.CachingSpec{KV: &airunwayv1alpha1.KVCacheSpec{
+				Backend: airunwayv1alpha1.KVCacheBackendRedis,
+				ConnectionSecretRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "kv-cache"}, Key: "url",
+				},
+				MaxSize: &maxSize,
+			}},
+		},
+	}
+	for _, err := range validator.validateSpec(md) {
+		if strings.HasPrefix(err.Field, "spec.caching") {
+			t.Errorf("unexpected validation error: %v", err)
+		}
+	}
+
+	kv := md.Spec.Caching.KV
+	kv.ConnectionSecretRef.Key = "bad key"
+	requireValidationErrorField(t, validator.validateSpec(md), "spec.caching.kv.connectionSecretRef.key")
+	kv.ConnectionSecretRef = nil
+	requireValidationErrorField(t, validator.validateSpec(md), "spec.caching.kv.connectionSecretRef")
+
+	// lmcache runs without a remote cache
+	kv.Backend = airunwayv1alpha1.KVCacheBackendLMCache
+	for _, err := range validator.validateSpec(md) {
+		if strings.HasPrefix(err.Field, "spec.caching") {
+			t.Errorf("unexpected validation error: %v", err)
+		}
+	}
+
+	zero := resource.MustParse("0")
+	kv.MaxSize = &zero
+	requireValidationErrorField(t, validator.validateSpec(md), "spec.caching.kv.maxSize")
+	kv.MaxSize = nil
+
+	md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeSGLang
+	requireValidationErrorField(t, validator.validateSpec(md), "spec.caching.kv")
+	md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeVLLM
+	md.Spec.Serving = &airunwayv1alpha1.ServingSpec{Mode: airunwayv1alpha1.ServingModeDisaggregated}
+	requireValidationErrorField(t, validator.validateSpec(md), "spec.caching.kv")
+}
+
+func TestValidateSpec_EngineRemediation(t *testing.T) {
+	validator := &ModelDeploymentCustomValidator{}
+	md := &airunwayv1alpha1.ModelDeployment{
+		Spec: airunwayv1alpha1.ModelDeploymentSpec{
+			Model: airunwayv1alpha1.ModelSpec{ID: "Qwen/Qwen3-0.6B", Source: airunwayv1alpha1.ModelSourceHuggingFace},
+			Engine: airunwayv1alpha1.EngineSpec{
+				Type:        airunwayv1alpha1.EngineTypeSGLang,
+				Remediation: &airunwayv1alpha1.EngineRemediationSpec{Enabled: true},
+			},
+		},
+	}
+	for _, err := range validator.validateSpec(md) {
+		if strings.HasPrefix(err.Field, "spec.engine.remediation") {
+			t.Errorf("unexpected validation error: %v", err)
+		}
+	}
+
+	md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeLlamaCpp
+	requireValidationErrorField(t, validator.validateSpec(md), "spec.engine.remediation")
+}
+
+func TestValidateNetworking_EgressRequiresIsolate(t *testing.T) {
+	networking := &airunwayv1alpha1.NetworkingSpec{Egress: []networkingv1.NetworkPolicyEgressRule{{}}}
+	requireValidationErrorField(t, validateNetworking(networking, field.NewPath("spec", "networking")), "spec.networking.egress")
+
+	networking.Isolate = true
+	if errs := validateNetworking(networking, field.NewPath("spec", "networking")); len(errs) != 0 {
+		t.Errorf("expected egress with isolate to be valid, got %v", errs)
+	}
+}
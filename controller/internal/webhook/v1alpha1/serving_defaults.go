@@ -0,0 +1,195 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+const (
+	// disaggregationMinParamsB is the smallest model, in billions of parameters,
+	// for which serving.mode auto chooses disaggregated serving. Smaller models
+	// prefill quickly enough that splitting adds KV-transfer cost for little gain.
+	disaggregationMinParamsB = 30
+
+	// disaggregationMinGPUs is the fewest total GPUs serving.mode auto splits into
+	// prefill and decode workers.
+	disaggregationMinGPUs = 4
+
+	// paramsPerGPUB approximates how many billions of parameters one 80GB GPU
+	// serves in bf16 with room left for the KV cache.
+	paramsPerGPUB = 35
+)
+
+// Reasons recorded in AnnotationServingModeReason
+const (
+	servingModeReasonExplicitSplit     = "ExplicitSplit"
+	servingModeReasonCPUInference      = "CPUInference"
+	servingModeReasonEngineUnsupported = "EngineUnsupported"
+	servingModeReasonUnknownModelSize  = "ModelSizeUnknown"
+	servingModeReasonSmallModel        = "SmallModel"
+	servingModeReasonInsufficientGPUs  = "InsufficientGPUs"
+	servingModeReasonLargeModel        = "LargeModel"
+)
+
+// paramCountToken matches a model ID segment naming its size, e.g. "70B" or "8x7B".
+var paramCountToken = regexp.MustCompile(`^(?i)(?:(\d+)x)?(\d+(?:\.\d+)?)b$`)
+
+// servingModeDecision is how serving.mode auto was resolved.
+type servingModeDecision struct {
+	mode    airunwayv1alpha1.ServingMode
+	reason  string
+	message string
+
+	// Set for disaggregated decisions
+	workerGPUs int32
+	prefill    int32
+	decode     int32
+}
+
+// modelParamsB estimates the parameter count of a model, in billions, from size
+// tokens in its ID. Mixture-of-experts sizes such as "8x7B" are multiplied out.
+// Returns 0 when the ID does not name a size.
+func modelParamsB(modelID string) float64 {
+	var largest float64
+	for _, token := range strings.FieldsFunc(modelID, func(r rune) bool {
+		return r == '/' || r == '-' || r == '_' || r == ' ' || r == ':'
+	}) {
+		m := paramCountToken.FindStringSubmatch(token)
+		if m == nil {
+			continue
+		}
+		size, err := strconv.ParseFloat(m[2], 64)
+		if err != nil {
+			continue
+		}
+		if m[1] != "" {
+			experts, _ := strconv.ParseFloat(m[1], 64)
+			size *= experts
+		}
+		if size > largest {
+			largest = size
+		}
+	}
+	return largest
+}
+
+// decideServingMode resolves serving.mode auto. Large models with enough GPUs are
+// split into prefill and decode workers, each sized to hold the model, with about
+// a quarter of the workers doing prefill. Everything else is served aggregated.
+// The total GPU budget is resources.gpu.count per replica times scaling.replicas.
+func decideServingMode(spec *airunwayv1alpha1.ModelDeploymentSpec) servingModeDecision {
+	aggregated := func(reason, message string) servingModeDecision {
+		return servingModeDecision{mode: airunwayv1alpha1.ServingModeAggregated, reason: reason, message: message}
+	}
+
+	// A split without a resources.gpu budget was chosen by the user. With a budget
+	// (e.g. re-applying a manifest after an earlier decision), the split is recomputed.
+	hasGPUBudget := spec.Resources != nil && spec.Resources.GPU != nil && spec.Resources.GPU.Count > 0
+	if !hasGPUBudget && spec.Scaling != nil && spec.Scaling.Prefill != nil && spec.Scaling.Decode != nil {
+		return servingModeDecision{
+			mode:    airunwayv1alpha1.ServingModeDisaggregated,
+			reason:  servingModeReasonExplicitSplit,
+			message: "scaling.prefill and scaling.decode are set",
+		}
+	}
+	if spec.Engine.Device == airunwayv1alpha1.EngineDeviceCPU {
+		return aggregated(servingModeReasonCPUInference, "CPU inference is always aggregated")
+	}
+	if spec.Engine.Type == airunwayv1alpha1.EngineTypeLlamaCpp {
+		return aggregated(servingModeReasonEngineUnsupported, "llamacpp does not support disaggregated serving")
+	}
+
+	params := modelParamsB(spec.Model.ID)
+	if params == 0 {
+		return aggregated(servingModeReasonUnknownModelSize,
+			fmt.Sprintf("model size could not be determined from %q", spec.Model.ID))
+	}
+	if params < disaggregationMinParamsB {
+		return aggregated(servingModeReasonSmallModel,
+			fmt.Sprintf("%gB parameters is below the %dB disaggregation threshold", params, disaggregationMinParamsB))
+	}
+
+	var totalGPUs int32
+	if hasGPUBudget {
+		replicas := int32(1)
+		if spec.Scaling != nil && spec.Scaling.Replicas > 1 {
+			replicas = spec.Scaling.Replicas
+		}
+		totalGPUs = spec.Resources.GPU.Count * replicas
+	}
+	workerGPUs := int32(1)
+	for float64(workerGPUs)*paramsPerGPUB < params {
+		workerGPUs *= 2
+	}
+	if totalGPUs < disaggregationMinGPUs || totalGPUs < 2*workerGPUs {
+		return aggregated(servingModeReasonInsufficientGPUs,
+			fmt.Sprintf("%d GPUs cannot hold separate prefill and decode workers of %d GPUs each for %gB parameters",
+				totalGPUs, workerGPUs, params))
+	}
+
+	workers := totalGPUs / workerGPUs
+	prefill := max(workers/4, 1)
+	decode := workers - prefill
+	return servingModeDecision{
+		mode:   airunwayv1alpha1.ServingModeDisaggregated,
+		reason: servingModeReasonLargeModel,
+		message: fmt.Sprintf("%gB parameters on %d GPUs: %d prefill and %d decode workers with %d GPUs each",
+			params, totalGPUs, prefill, decode, workerGPUs),
+		workerGPUs: workerGPUs,
+		prefill:    prefill,
+		decode:     decode,
+	}
+}
+
+// defaultServingMode resolves serving.mode auto in place and records the decision
+// in annotations. A disaggregated decision moves the GPU budget from
+// resources.gpu to scaling.prefill and scaling.decode.
+func defaultServingMode(md *airunwayv1alpha1.ModelDeployment) {
+	spec := &md.Spec
+	decision := decideServingMode(spec)
+	spec.Serving.Mode = decision.mode
+
+	if decision.reason == servingModeReasonLargeModel {
+		gpuType := spec.Resources.GPU.Type
+		spec.Resources.GPU = nil
+		if spec.Scaling == nil {
+			spec.Scaling = &airunwayv1alpha1.ScalingSpec{}
+		}
+		spec.Scaling.Prefill = &airunwayv1alpha1.ComponentScalingSpec{
+			Replicas: decision.prefill,
+			GPU:      &airunwayv1alpha1.GPUSpec{Count: decision.workerGPUs, Type: gpuType},
+		}
+		spec.Scaling.Decode = &airunwayv1alpha1.ComponentScalingSpec{
+			Replicas: decision.decode,
+			GPU:      &airunwayv1alpha1.GPUSpec{Count: decision.workerGPUs, Type: gpuType},
+		}
+	}
+
+	annotations := md.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[airunwayv1alpha1.AnnotationServingModeReason] = decision.reason
+	annotations[airunwayv1alpha1.AnnotationServingModeMessage] = decision.message
+	md.SetAnnotations(annotations)
+}
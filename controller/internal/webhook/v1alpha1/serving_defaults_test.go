@@ -0,0 +1,176 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newAutoModeDeployment(modelID string, gpus, replicas int32) *airunwayv1alpha1.ModelDeployment {
+	md := &airunwayv1alpha1.ModelDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "auto", Namespace: "default"},
+		Spec: airunwayv1alpha1.ModelDeploymentSpec{
+			Model:   airunwayv1alpha1.ModelSpec{ID: modelID},
+			Engine:  airunwayv1alpha1.EngineSpec{Type: airunwayv1alpha1.EngineTypeVLLM},
+			Serving: &airunwayv1alpha1.ServingSpec{Mode: airunwayv1alpha1.ServingModeAuto},
+		},
+	}
+	if gpus > 0 {
+		md.Spec.Resources = &airunwayv1alpha1.ResourceSpec{GPU: &airunwayv1alpha1.GPUSpec{Count: gpus, Type: "nvidia.com/gpu"}}
+	}
+	if replicas > 0 {
+		md.Spec.Scaling = &airunwayv1alpha1.ScalingSpec{Replicas: replicas}
+	}
+	return md
+}
+
+func TestModelParamsB(t *testing.T) {
+	tests := map[string]float64{
+		"meta-llama/Llama-3.1-70B-Instruct":  70,
+		"mistralai/Mixtral-8x7B-Instruct":    56,
+		"Qwen/Qwen3-30B-A3B":                 30,
+		"Qwen/Qwen3-0.6B":                    0.6,
+		"deepseek-ai/DeepSeek-V3":            0,
+		"TheBloke/Llama-2-7B-GGUF:Q4_K_M":    7,
+		"microsoft/Phi-3-mini-4k-instruct":   0,
+		"meta-llama/Llama-3.1-405B-Instruct": 405,
+	}
+	for id, want := range tests {
+		if got := modelParamsB(id); got != want {
+			t.Errorf("modelParamsB(%q) = %g, want %g", id, got, want)
+		}
+	}
+}
+
+func TestDecideServingMode(t *testing.T) {
+	tests := []struct {
+		name       string
+		md         *airunwayv1alpha1.ModelDeployment
+		mode       airunwayv1alpha1.ServingMode
+		reason     string
+		prefill    int32
+		decode     int32
+		workerGPUs int32
+	}{
+		{
+			name:   "small model",
+			md:     newAutoModeDeployment("meta-llama/Llama-3.1-8B-Instruct", 8, 0),
+			mode:   airunwayv1alpha1.ServingModeAggregated,
+			reason: servingModeReasonSmallModel,
+		},
+		{
+			name:   "unknown size",
+			md:     newAutoModeDeployment("deepseek-ai/DeepSeek-V3", 8, 0),
+			mode:   airunwayv1alpha1.ServingModeAggregated,
+			reason: servingModeReasonUnknownModelSize,
+		},
+		{
+			name:   "too few GPUs",
+			md:     newAutoModeDeployment("meta-llama/Llama-3.1-70B-Instruct", 2, 0),
+			mode:   airunwayv1alpha1.ServingModeAggregated,
+			reason: servingModeReasonInsufficientGPUs,
+		},
+		{
+			name:       "large model",
+			md:         newAutoModeDeployment("meta-llama/Llama-3.1-70B-Instruct", 8, 0),
+			mode:       airunwayv1alpha1.ServingModeDisaggregated,
+			reason:     servingModeReasonLargeModel,
+			prefill:    1,
+			decode:     3,
+			workerGPUs: 2,
+		},
+		{
+			name:       "GPU budget counts replicas",
+			md:         newAutoModeDeployment("Qwen/Qwen3-32B", 4, 2),
+			mode:       airunwayv1alpha1.ServingModeDisaggregated,
+			reason:     servingModeReasonLargeModel,
+			prefill:    2,
+			decode:     6,
+			workerGPUs: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := decideServingMode(&tt.md.Spec)
+			if got.mode != tt.mode || got.reason != tt.reason {
+				t.Fatalf("got %s/%s, want %s/%s (%s)", got.mode, got.reason, tt.mode, tt.reason, got.message)
+			}
+			if got.prefill != tt.prefill || got.decode != tt.decode || got.workerGPUs != tt.workerGPUs {
+				t.Errorf("got %d prefill, %d decode, %d GPUs per worker; want %d, %d, %d",
+					got.prefill, got.decode, got.workerGPUs, tt.prefill, tt.decode, tt.workerGPUs)
+			}
+		})
+	}
+
+	cpu := newAutoModeDeployment("meta-llama/Llama-3.1-70B-Instruct", 0, 0)
+	cpu.Spec.Engine.Device = airunwayv1alpha1.EngineDeviceCPU
+	if got := decideServingMode(&cpu.Spec); got.reason != servingModeReasonCPUInference {
+		t.Errorf("expected CPU inference to stay aggregated, got %s", got.reason)
+	}
+
+	split := newAutoModeDeployment("meta-llama/Llama-3.1-70B-Instruct", 0, 0)
+	split.Spec.Scaling = &airunwayv1alpha1.ScalingSpec{
+		Prefill: &airunwayv1alpha1.ComponentScalingSpec{Replicas: 1, GPU: &airunwayv1alpha1.GPUSpec{Count: 4}},
+		Decode:  &airunwayv1alpha1.ComponentScalingSpec{Replicas: 1, GPU: &airunwayv1alpha1.GPUSpec{Count: 4}},
+	}
+	if got := decideServingMode(&split.Spec); got.mode != airunwayv1alpha1.ServingModeDisaggregated || got.reason != servingModeReasonExplicitSplit {
+		t.Errorf("expected an explicit split to be kept, got %s/%s", got.mode, got.reason)
+	}
+}
+
+func TestDefault_ServingModeAuto(t *testing.T) {
+	defaulter := &ModelDeploymentCustomDefaulter{}
+
+	md := newAutoModeDeployment("meta-llama/Llama-3.1-70B-Instruct", 8, 0)
+	if err := defaulter.Default(context.Background(), md); err != nil {
+		t.Fatalf("Default failed: %v", err)
+	}
+	if md.Spec.Serving.Mode != airunwayv1alpha1.ServingModeDisaggregated {
+		t.Fatalf("expected disaggregated, got %s", md.Spec.Serving.Mode)
+	}
+	if md.Spec.Resources.GPU != nil {
+		t.Errorf("expected resources.gpu to move to prefill/decode, got %+v", md.Spec.Resources.GPU)
+	}
+	prefill, decode := md.Spec.Scaling.Prefill, md.Spec.Scaling.Decode
+	if prefill.Replicas != 1 || prefill.GPU.Count != 2 || prefill.GPU.Type != "nvidia.com/gpu" {
+		t.Errorf("unexpected prefill %+v", prefill)
+	}
+	if decode.Replicas != 3 || decode.GPU.Count != 2 {
+		t.Errorf("unexpected decode %+v", decode)
+	}
+	if md.Annotations[airunwayv1alpha1.AnnotationServingModeReason] != servingModeReasonLargeModel {
+		t.Errorf("expected reason annotation %s, got %q", servingModeReasonLargeModel, md.Annotations[airunwayv1alpha1.AnnotationServingModeReason])
+	}
+	if errs := (&ModelDeploymentCustomValidator{}).validateSpec(md); len(errs) > 0 {
+		t.Errorf("expected defaulted spec to validate, got %v", errs)
+	}
+
+	md = newAutoModeDeployment("Qwen/Qwen3-0.6B", 1, 0)
+	if err := defaulter.Default(context.Background(), md); err != nil {
+		t.Fatalf("Default failed: %v", err)
+	}
+	if md.Spec.Serving.Mode != airunwayv1alpha1.ServingModeAggregated || md.Spec.Scaling.Replicas != 1 {
+		t.Errorf("expected aggregated with defaulted replicas, got %s/%+v", md.Spec.Serving.Mode, md.Spec.Scaling)
+	}
+	if md.Annotations[airunwayv1alpha1.AnnotationServingModeReason] != servingModeReasonSmallModel {
+		t.Errorf("expected reason annotation %s, got %q", servingModeReasonSmallModel, md.Annotations[airunwayv1alpha1.AnnotationServingModeReason])
+	}
+}
@@ -109,7 +109,7 @@ var _ = BeforeSuite(func() {
 	})
 	Expect(err).NotTo(HaveOccurred())
 
-	err = SetupModelDeploymentWebhookWithManager(mgr)
+	err = SetupModelDeploymentWebhookWithManager(mgr, nil, nil)
 	Expect(err).NotTo(HaveOccurred())
 
 	// +kubebuilder:scaffold:webhook
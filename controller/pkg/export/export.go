@@ -0,0 +1,247 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package export bundles a ModelDeployment and the resources generated for it into a
+// single portable YAML artifact, suitable for GitOps promotion between clusters.
+package export
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// GatewayKinds returns the kinds of the gateway objects the controller may create per
+// ModelDeployment, named after the ModelDeployment in its namespace, in every API
+// version it uses. Kinds whose CRD is not installed are skipped on export.
+func GatewayKinds() []schema.GroupVersionKind {
+	return []schema.GroupVersionKind{
+		{Group: "inference.networking.k8s.io", Version: "v1", Kind: "InferencePool"},
+		{Group: "inference.networking.x-k8s.io", Version: "v1alpha2", Kind: "InferencePool"},
+		{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "HTTPRoute"},
+		{Group: "inference.networking.x-k8s.io", Version: "v1alpha2", Kind: "InferenceModelRewrite"},
+		// Rate limit policies
+		{Group: "gateway.envoyproxy.io", Version: "v1alpha1", Kind: "BackendTrafficPolicy"},
+		{Group: "gateway.kgateway.dev", Version: "v1alpha1", Kind: "TrafficPolicy"},
+	}
+}
+
+// strippedAnnotations are cluster-local annotations that must not travel with the bundle.
+var strippedAnnotations = []string{
+	"kubectl.kubernetes.io/last-applied-configuration",
+	airunwayv1alpha1.HTTPRouteCreated,
+	airunwayv1alpha1.BBRRestarted,
+}
+
+// sensitiveEnvName matches env var names whose literal values are treated as secrets.
+var sensitiveEnvName = regexp.MustCompile(`(?i)(token|secret|password|passwd|api[_-]?key|credential)`)
+
+// Options controls what is included in the bundle.
+type Options struct {
+	// IncludeGenerated includes the resolved provider resource and gateway objects.
+	// The controller recreates these from the ModelDeployment, so they are informational
+	// for promotion; disable to produce a bundle that only contains the ModelDeployment.
+	IncludeGenerated bool
+
+	// Warnings receives a line for each API group that cannot be discovered, since the
+	// provider resource may then be missing from the bundle. Nil discards them.
+	Warnings io.Writer
+}
+
+// Exporter builds export bundles from a live cluster.
+type Exporter struct {
+	Client    client.Client
+	Discovery discovery.DiscoveryInterface
+}
+
+// Bundle returns a multi-document YAML bundle for the named ModelDeployment.
+// Status, cluster-assigned metadata, and secret values are stripped; Secrets are never included.
+func (e *Exporter) Bundle(ctx context.Context, namespace, name string, opts Options) ([]byte, error) {
+	md := &airunwayv1alpha1.ModelDeployment{}
+	if err := e.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, md); err != nil {
+		return nil, fmt.Errorf("getting ModelDeployment %s/%s: %w", namespace, name, err)
+	}
+
+	mdObj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(md)
+	if err != nil {
+		return nil, fmt.Errorf("converting ModelDeployment: %w", err)
+	}
+	objects := []*unstructured.Unstructured{{Object: mdObj}}
+	objects[0].SetAPIVersion(airunwayv1alpha1.GroupVersion.String())
+	objects[0].SetKind("ModelDeployment")
+
+	if opts.IncludeGenerated {
+		providerObj, err := e.providerResource(ctx, md, opts.Warnings)
+		if err != nil {
+			return nil, err
+		}
+		if providerObj != nil {
+			objects = append(objects, providerObj)
+		}
+		for _, gvk := range GatewayKinds() {
+			obj, err := e.getOptional(ctx, gvk, md.Namespace, md.Name)
+			if err != nil {
+				return nil, err
+			}
+			if obj != nil {
+				objects = append(objects, obj)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	for i, obj := range objects {
+		Sanitize(obj)
+		data, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return nil, fmt.Errorf("marshaling %s %s: %w", obj.GetKind(), obj.GetName(), err)
+		}
+		if i > 0 {
+			buf.WriteString("---\n")
+		}
+		buf.Write(data)
+	}
+	return buf.Bytes(), nil
+}
+
+// providerResource fetches the provider resource recorded in the ModelDeployment status.
+// The status only records the kind, so the group/version is resolved through discovery
+// and the candidate must be owned by the ModelDeployment.
+func (e *Exporter) providerResource(ctx context.Context, md *airunwayv1alpha1.ModelDeployment, warnings io.Writer) (*unstructured.Unstructured, error) {
+	if md.Status.Provider == nil || md.Status.Provider.ResourceKind == "" || md.Status.Provider.ResourceName == "" {
+		return nil, nil
+	}
+	if md.Status.Provider.ResourceKind == "Secret" {
+		return nil, nil
+	}
+	gvks, err := e.kindCandidates(md.Status.Provider.ResourceKind, warnings)
+	if err != nil {
+		return nil, err
+	}
+	for _, gvk := range gvks {
+		obj, err := e.getOptional(ctx, gvk, md.Namespace, md.Status.Provider.ResourceName)
+		if err != nil {
+			return nil, err
+		}
+		if obj != nil && metav1.IsControlledBy(obj, md) {
+			return obj, nil
+		}
+	}
+	return nil, nil
+}
+
+// kindCandidates returns the preferred GroupVersionKinds served for kind. Groups that
+// fail discovery are reported to warnings.
+func (e *Exporter) kindCandidates(kind string, warnings io.Writer) ([]schema.GroupVersionKind, error) {
+	lists, err := discovery.ServerPreferredResources(e.Discovery)
+	if err != nil && len(lists) == 0 {
+		return nil, fmt.Errorf("discovering API resources: %w", err)
+	}
+	if err != nil && warnings != nil {
+		fmt.Fprintf(warnings, "warning: %s resources of some API groups may be missing: %v\n", kind, err)
+	}
+	var gvks []schema.GroupVersionKind
+	for _, list := range lists {
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil {
+			continue
+		}
+		for _, res := range list.APIResources {
+			if res.Kind == kind && res.Namespaced {
+				gvks = append(gvks, gv.WithKind(kind))
+			}
+		}
+	}
+	return gvks, nil
+}
+
+// getOptional fetches an object, returning nil if it or its kind does not exist. Other
+// errors, such as a missing permission, fail the export rather than drop the object.
+func (e *Exporter) getOptional(ctx context.Context, gvk schema.GroupVersionKind, namespace, name string) (*unstructured.Unstructured, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	if err := e.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, obj); err != nil {
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("getting %s %s/%s: %w", gvk.Kind, namespace, name, err)
+	}
+	return obj, nil
+}
+
+// Sanitize strips status, cluster-assigned metadata, cluster-local annotations,
+// and literal values of secret-looking env vars from obj in place.
+func Sanitize(obj *unstructured.Unstructured) {
+	unstructured.RemoveNestedField(obj.Object, "status")
+	for _, f := range []string{"uid", "resourceVersion", "generation", "creationTimestamp",
+		"deletionTimestamp", "deletionGracePeriodSeconds", "managedFields", "ownerReferences",
+		"finalizers", "selfLink"} {
+		unstructured.RemoveNestedField(obj.Object, "metadata", f)
+	}
+
+	if annotations := obj.GetAnnotations(); annotations != nil {
+		for _, key := range strippedAnnotations {
+			delete(annotations, key)
+		}
+		if len(annotations) == 0 {
+			annotations = nil
+		}
+		obj.SetAnnotations(annotations)
+	}
+
+	redactEnv(obj.Object)
+}
+
+// redactEnv walks v and removes literal values from "env" entries whose names look
+// sensitive. valueFrom references are kept since they carry no secret material.
+func redactEnv(v interface{}) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for key, child := range val {
+			if key == "env" {
+				if list, ok := child.([]interface{}); ok {
+					for _, item := range list {
+						entry, ok := item.(map[string]interface{})
+						if !ok {
+							continue
+						}
+						if name, _ := entry["name"].(string); sensitiveEnvName.MatchString(name) {
+							delete(entry, "value")
+						}
+					}
+				}
+			}
+			redactEnv(child)
+		}
+	case []interface{}:
+		for _, item := range val {
+			redactEnv(item)
+		}
+	}
+}
@@ -0,0 +1,237 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package export
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	k8stesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	alphaPoolGVK    = schema.GroupVersionKind{Group: "inference.networking.x-k8s.io", Version: "v1alpha2", Kind: "InferencePool"}
+	modelRewriteGVK = schema.GroupVersionKind{Group: "inference.networking.x-k8s.io", Version: "v1alpha2", Kind: "InferenceModelRewrite"}
+)
+
+func newTestExporter(t *testing.T, objs ...runtime.Object) *Exporter {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = airunwayv1alpha1.AddToScheme(scheme)
+	scheme.AddKnownTypeWithName(schema.GroupVersionKind{Group: "kaito.sh", Version: "v1beta1", Kind: "Workspace"}, &unstructured.Unstructured{})
+	for _, gvk := range []schema.GroupVersionKind{alphaPoolGVK, modelRewriteGVK} {
+		scheme.AddKnownTypeWithName(gvk, &unstructured.Unstructured{})
+	}
+
+	dc := &fakediscovery.FakeDiscovery{Fake: &k8stesting.Fake{}}
+	dc.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "kaito.sh/v1beta1",
+			APIResources: []metav1.APIResource{{Name: "workspaces", Kind: "Workspace", Namespaced: true}},
+		},
+	}
+	return &Exporter{
+		Client:    fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objs...).Build(),
+		Discovery: dc,
+	}
+}
+
+func newExportModelDeployment() *airunwayv1alpha1.ModelDeployment {
+	return &airunwayv1alpha1.ModelDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "llama",
+			Namespace:  "dev",
+			UID:        "md-uid",
+			Finalizers: []string{"airunway.ai/finalizer"},
+			Annotations: map[string]string{
+				airunwayv1alpha1.HTTPRouteCreated: "true",
+				"team":                            "ml",
+			},
+		},
+		Spec: airunwayv1alpha1.ModelDeploymentSpec{
+			Model: airunwayv1alpha1.ModelSpec{ID: "meta-llama/Llama-3-8B"},
+			Env: []corev1.EnvVar{
+				{Name: "HF_TOKEN", Value: "hf_secret"},
+				{Name: "LOG_LEVEL", Value: "debug"},
+			},
+		},
+		Status: airunwayv1alpha1.ModelDeploymentStatus{
+			Phase: airunwayv1alpha1.DeploymentPhaseRunning,
+			Provider: &airunwayv1alpha1.ProviderStatus{
+				Name:         "kaito",
+				ResourceName: "llama",
+				ResourceKind: "Workspace",
+			},
+		},
+	}
+}
+
+func splitBundle(t *testing.T, bundle []byte) []map[string]interface{} {
+	t.Helper()
+	var docs []map[string]interface{}
+	for _, doc := range strings.Split(string(bundle), "---\n") {
+		var obj map[string]interface{}
+		if err := yaml.Unmarshal([]byte(doc), &obj); err != nil {
+			t.Fatalf("invalid YAML document: %v\n%s", err, doc)
+		}
+		docs = append(docs, obj)
+	}
+	return docs
+}
+
+func TestBundle_ModelDeploymentOnly(t *testing.T) {
+	md := newExportModelDeployment()
+	e := newTestExporter(t, md)
+
+	bundle, err := e.Bundle(context.Background(), "dev", "llama", Options{})
+	if err != nil {
+		t.Fatalf("Bundle failed: %v", err)
+	}
+	docs := splitBundle(t, bundle)
+	if len(docs) != 1 {
+		t.Fatalf("expected 1 document, got %d", len(docs))
+	}
+
+	obj := &unstructured.Unstructured{Object: docs[0]}
+	if obj.GetKind() != "ModelDeployment" || obj.GetAPIVersion() != "airunway.ai/v1alpha1" {
+		t.Errorf("unexpected type %s %s", obj.GetAPIVersion(), obj.GetKind())
+	}
+	if _, ok := obj.Object["status"]; ok {
+		t.Error("expected status to be stripped")
+	}
+	if obj.GetUID() != "" || obj.GetResourceVersion() != "" || len(obj.GetFinalizers()) != 0 {
+		t.Errorf("expected cluster metadata to be stripped, got %v", obj.Object["metadata"])
+	}
+	if _, ok := obj.GetAnnotations()[airunwayv1alpha1.HTTPRouteCreated]; ok {
+		t.Error("expected controller annotation to be stripped")
+	}
+	if obj.GetAnnotations()["team"] != "ml" {
+		t.Error("expected user annotation to be kept")
+	}
+	if strings.Contains(string(bundle), "hf_secret") {
+		t.Error("expected secret env value to be redacted")
+	}
+	if !strings.Contains(string(bundle), "debug") {
+		t.Error("expected non-secret env value to be kept")
+	}
+}
+
+func TestBundle_IncludesGeneratedResources(t *testing.T) {
+	md := newExportModelDeployment()
+
+	controller := true
+	workspace := &unstructured.Unstructured{}
+	workspace.SetAPIVersion("kaito.sh/v1beta1")
+	workspace.SetKind("Workspace")
+	workspace.SetName("llama")
+	workspace.SetNamespace("dev")
+	workspace.SetOwnerReferences([]metav1.OwnerReference{{
+		APIVersion: "airunway.ai/v1alpha1",
+		Kind:       "ModelDeployment",
+		Name:       "llama",
+		UID:        md.UID,
+		Controller: &controller,
+	}})
+
+	e := newTestExporter(t, md, workspace)
+
+	bundle, err := e.Bundle(context.Background(), "dev", "llama", Options{IncludeGenerated: true})
+	if err != nil {
+		t.Fatalf("Bundle failed: %v", err)
+	}
+	docs := splitBundle(t, bundle)
+	if len(docs) != 2 {
+		t.Fatalf("expected 2 documents, got %d:\n%s", len(docs), bundle)
+	}
+	obj := &unstructured.Unstructured{Object: docs[1]}
+	if obj.GetKind() != "Workspace" {
+		t.Errorf("expected Workspace, got %s", obj.GetKind())
+	}
+	if len(obj.GetOwnerReferences()) != 0 {
+		t.Error("expected owner references to be stripped")
+	}
+}
+
+func TestBundle_IncludesExperimentalGatewayObjects(t *testing.T) {
+	md := newExportModelDeployment()
+	md.Status.Provider = nil
+	var objs []runtime.Object
+	for _, gvk := range []schema.GroupVersionKind{alphaPoolGVK, modelRewriteGVK} {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(gvk)
+		obj.SetName("llama")
+		obj.SetNamespace("dev")
+		objs = append(objs, obj)
+	}
+	e := newTestExporter(t, append(objs, md)...)
+
+	bundle, err := e.Bundle(context.Background(), "dev", "llama", Options{IncludeGenerated: true})
+	if err != nil {
+		t.Fatalf("Bundle failed: %v", err)
+	}
+	docs := splitBundle(t, bundle)
+	if len(docs) != 3 {
+		t.Fatalf("expected 3 documents, got %d:\n%s", len(docs), bundle)
+	}
+	for i, want := range []string{"inference.networking.x-k8s.io/v1alpha2/InferencePool", "inference.networking.x-k8s.io/v1alpha2/InferenceModelRewrite"} {
+		obj := &unstructured.Unstructured{Object: docs[i+1]}
+		if got := obj.GetAPIVersion() + "/" + obj.GetKind(); got != want {
+			t.Errorf("expected document %d to be %s, got %s", i+1, want, got)
+		}
+	}
+}
+
+func TestBundle_FailsWhenGatewayKindCannotBeRead(t *testing.T) {
+	md := newExportModelDeployment()
+	md.Status.Provider = nil
+	e := newTestExporter(t, md)
+	e.Client = interceptor.NewClient(e.Client.(client.WithWatch), interceptor.Funcs{
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			if obj.GetObjectKind().GroupVersionKind().Kind == "HTTPRoute" {
+				return apierrors.NewForbidden(schema.GroupResource{Group: "gateway.networking.k8s.io", Resource: "httproutes"}, key.Name, nil)
+			}
+			return c.Get(ctx, key, obj, opts...)
+		},
+	})
+
+	_, err := e.Bundle(context.Background(), "dev", "llama", Options{IncludeGenerated: true})
+	if err == nil || !strings.Contains(err.Error(), "HTTPRoute") {
+		t.Errorf("expected the export to fail on the HTTPRoute, got %v", err)
+	}
+}
+
+func TestBundle_NotFound(t *testing.T) {
+	e := newTestExporter(t)
+	if _, err := e.Bundle(context.Background(), "dev", "missing", Options{}); err == nil {
+		t.Fatal("expected error for missing ModelDeployment")
+	}
+}
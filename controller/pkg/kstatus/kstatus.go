@@ -0,0 +1,87 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package kstatus sets the conditions GitOps tools read to judge the health of a resource,
+// following the kstatus conventions used by Flux, Argo CD and kubectl wait: a Ready
+// condition with normal polarity, and Reconciling and Stalled conditions with abnormal
+// polarity that are only present while true. Together with status.observedGeneration,
+// they tell the tools whether a resource is current, in progress, or failed.
+package kstatus
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+// State is the health of a resource
+type State int
+
+const (
+	// InProgress means the controller is still working towards the spec
+	InProgress State = iota
+	// Current means the resource reached the state its spec asks for
+	Current
+	// Failed means the resource cannot make progress without a change
+	Failed
+)
+
+// SetReady sets the Ready condition from state, True only when Current, and the
+// Reconciling and Stalled conditions with SetProgress
+func SetReady(conditions *[]metav1.Condition, generation int64, state State, reason, message string) {
+	status := metav1.ConditionFalse
+	if state == Current {
+		status = metav1.ConditionTrue
+	}
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               airunwayv1alpha1.ConditionTypeReady,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: generation,
+	})
+	SetProgress(conditions, generation, state, reason, message)
+}
+
+// SetProgress sets the Reconciling condition while state is InProgress and the Stalled
+// condition while it is Failed, and removes them otherwise. Resources whose Ready condition
+// is set elsewhere use it directly.
+func SetProgress(conditions *[]metav1.Condition, generation int64, state State, reason, message string) {
+	for conditionType, active := range map[string]bool{
+		airunwayv1alpha1.ConditionTypeReconciling: state == InProgress,
+		airunwayv1alpha1.ConditionTypeStalled:     state == Failed,
+	} {
+		if !active {
+			meta.RemoveStatusCondition(conditions, conditionType)
+			continue
+		}
+		meta.SetStatusCondition(conditions, metav1.Condition{
+			Type:               conditionType,
+			Status:             metav1.ConditionTrue,
+			Reason:             reason,
+			Message:            message,
+			ObservedGeneration: generation,
+		})
+	}
+}
+
+// ReadyForGeneration reports whether conditions hold a True Ready condition observed for
+// generation
+func ReadyForGeneration(conditions []metav1.Condition, generation int64) bool {
+	ready := meta.FindStatusCondition(conditions, airunwayv1alpha1.ConditionTypeReady)
+	return ready != nil && ready.Status == metav1.ConditionTrue && ready.ObservedGeneration >= generation
+}
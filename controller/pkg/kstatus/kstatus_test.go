@@ -0,0 +1,74 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kstatus
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+func TestSetReady(t *testing.T) {
+	tests := []struct {
+		state           State
+		wantReady       metav1.ConditionStatus
+		wantReconciling bool
+		wantStalled     bool
+	}{
+		{state: InProgress, wantReady: metav1.ConditionFalse, wantReconciling: true},
+		{state: Current, wantReady: metav1.ConditionTrue},
+		{state: Failed, wantReady: metav1.ConditionFalse, wantStalled: true},
+	}
+	var conditions []metav1.Condition
+	for _, tt := range tests {
+		SetReady(&conditions, 3, tt.state, "Reason", "message")
+		ready := meta.FindStatusCondition(conditions, airunwayv1alpha1.ConditionTypeReady)
+		if ready == nil || ready.Status != tt.wantReady || ready.ObservedGeneration != 3 {
+			t.Errorf("state %d: expected Ready=%s for generation 3, got %+v", tt.state, tt.wantReady, ready)
+		}
+		if got := meta.IsStatusConditionTrue(conditions, airunwayv1alpha1.ConditionTypeReconciling); got != tt.wantReconciling {
+			t.Errorf("state %d: expected Reconciling %v, got %v", tt.state, tt.wantReconciling, got)
+		}
+		if got := meta.IsStatusConditionTrue(conditions, airunwayv1alpha1.ConditionTypeStalled); got != tt.wantStalled {
+			t.Errorf("state %d: expected Stalled %v, got %v", tt.state, tt.wantStalled, got)
+		}
+		if len(conditions) != 1+btoi(tt.wantReconciling)+btoi(tt.wantStalled) {
+			t.Errorf("state %d: expected abnormal conditions to be removed, got %+v", tt.state, conditions)
+		}
+	}
+}
+
+func TestReadyForGeneration(t *testing.T) {
+	var conditions []metav1.Condition
+	if ReadyForGeneration(conditions, 1) {
+		t.Error("expected no Ready condition not to be ready")
+	}
+	SetReady(&conditions, 1, Current, "Ready", "")
+	if !ReadyForGeneration(conditions, 1) || ReadyForGeneration(conditions, 2) {
+		t.Error("expected Ready only for the observed generation")
+	}
+}
+
+func btoi(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/discovery"
+)
+
+// DefaultAPIVersionCacheTTL is how long a negotiated upstream API version is reused before
+// discovery is queried again
+const DefaultAPIVersionCacheTTL = time.Minute
+
+// VersionMapping rewrites the fields of an upstream object built for a transformer's
+// canonical API version into the layout of another version. A nil mapping means the
+// fields the transformer sets are identical in both versions.
+type VersionMapping func(obj *unstructured.Unstructured) error
+
+// APIVersionNegotiator picks the upstream API version a transformer emits. It uses the
+// version the cluster prefers for the group when the transformer supports it, and otherwise
+// the first supported version the cluster serves, so an upstream operator upgrade that
+// changes the preferred version does not break every transform.
+type APIVersionNegotiator struct {
+	// Group and Resource identify the upstream resource, e.g. "nvidia.com" and
+	// "dynamographdeployments"
+	Group    string
+	Resource string
+	// Mappings holds the versions the transformer can emit, each with the mapping from the
+	// canonical version
+	Mappings map[string]VersionMapping
+	// Canonical is the version the transformer builds objects in. It is used when the
+	// cluster cannot be queried or does not serve the resource yet.
+	Canonical string
+	// Discovery queries the served versions. When nil, Canonical is always used.
+	Discovery discovery.DiscoveryInterface
+	// TTL is how long a negotiated version is cached, DefaultAPIVersionCacheTTL when zero
+	TTL time.Duration
+
+	mu      sync.Mutex
+	version string
+	expires time.Time
+}
+
+// Version returns the API version to emit. It returns an error when the cluster serves the
+// resource only in versions the transformer cannot produce.
+func (n *APIVersionNegotiator) Version() (string, error) {
+	if n.Discovery == nil {
+		return n.Canonical, nil
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.version != "" && time.Now().Before(n.expires) {
+		return n.version, nil
+	}
+
+	served, preferred, err := discoverVersions(n.Discovery, n.Group, n.Resource)
+	if err != nil {
+		// Keep using the last negotiated version while discovery is unavailable
+		if n.version != "" {
+			return n.version, nil
+		}
+		return n.Canonical, nil
+	}
+	version, err := n.pick(served, preferred)
+	if err != nil {
+		return "", err
+	}
+
+	ttl := n.TTL
+	if ttl == 0 {
+		ttl = DefaultAPIVersionCacheTTL
+	}
+	n.version, n.expires = version, time.Now().Add(ttl)
+	return version, nil
+}
+
+// pick chooses among the served versions: the cluster's preferred version when supported,
+// then the canonical version, then any other supported version in sorted order
+func (n *APIVersionNegotiator) pick(served []string, preferred string) (string, error) {
+	if len(served) == 0 {
+		return n.Canonical, nil
+	}
+	if _, ok := n.Mappings[preferred]; ok && slices.Contains(served, preferred) {
+		return preferred, nil
+	}
+	if slices.Contains(served, n.Canonical) {
+		return n.Canonical, nil
+	}
+	supported := n.SupportedVersions()
+	for _, v := range supported {
+		if slices.Contains(served, v) {
+			return v, nil
+		}
+	}
+	return "", fmt.Errorf("cluster serves %s/%s only in %s, but the provider supports %s",
+		n.Group, n.Resource, strings.Join(served, ", "), strings.Join(supported, ", "))
+}
+
+// SupportedVersions returns the versions the transformer can emit, canonical first
+func (n *APIVersionNegotiator) SupportedVersions() []string {
+	versions := []string{n.Canonical}
+	var others []string
+	for v := range n.Mappings {
+		if v != n.Canonical {
+			others = append(others, v)
+		}
+	}
+	slices.Sort(others)
+	return append(versions, others...)
+}
+
+// SupportedGroupVersions returns SupportedVersions as group/version strings
+func (n *APIVersionNegotiator) SupportedGroupVersions() []string {
+	var gvs []string
+	for _, v := range n.SupportedVersions() {
+		gvs = append(gvs, n.Group+"/"+v)
+	}
+	return gvs
+}
+
+// Apply sets the apiVersion of obj, built in the canonical version, to version and maps
+// its fields. Call it before applying user overrides so they land in the emitted layout.
+func (n *APIVersionNegotiator) Apply(obj *unstructured.Unstructured, version string) error {
+	mapping, ok := n.Mappings[version]
+	if !ok {
+		return fmt.Errorf("unsupported API version %s/%s", n.Group, version)
+	}
+	obj.SetAPIVersion(n.Group + "/" + version)
+	if mapping == nil {
+		return nil
+	}
+	if err := mapping(obj); err != nil {
+		return fmt.Errorf("failed to map fields to %s/%s: %w", n.Group, version, err)
+	}
+	return nil
+}
+
+// discoverVersions returns the versions in which the cluster serves resource of group and
+// the version the cluster prefers for the group
+func discoverVersions(dc discovery.DiscoveryInterface, group, resource string) ([]string, string, error) {
+	groups, err := dc.ServerGroups()
+	if err != nil {
+		return nil, "", err
+	}
+	for _, g := range groups.Groups {
+		if g.Name != group {
+			continue
+		}
+		var served []string
+		for _, v := range g.Versions {
+			resources, err := dc.ServerResourcesForGroupVersion(v.GroupVersion)
+			if err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return nil, "", err
+			}
+			for _, r := range resources.APIResources {
+				if r.Name == resource {
+					served = append(served, v.Version)
+					break
+				}
+			}
+		}
+		return served, g.PreferredVersion.Version, nil
+	}
+	return nil, "", nil
+}
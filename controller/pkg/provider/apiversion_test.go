@@ -0,0 +1,120 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+func newWorkspaceNegotiator(versions ...string) (*APIVersionNegotiator, *fakediscovery.FakeDiscovery) {
+	dc := &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{}}
+	for _, v := range versions {
+		dc.Resources = append(dc.Resources, &metav1.APIResourceList{
+			GroupVersion: "kaito.sh/" + v,
+			APIResources: []metav1.APIResource{{Name: "workspaces"}},
+		})
+	}
+	n := &APIVersionNegotiator{
+		Group:     "kaito.sh",
+		Resource:  "workspaces",
+		Canonical: "v1beta1",
+		Mappings: map[string]VersionMapping{
+			"v1beta1": nil,
+			"v1alpha1": func(obj *unstructured.Unstructured) error {
+				unstructured.RemoveNestedField(obj.Object, "inference", "config")
+				return nil
+			},
+		},
+		Discovery: dc,
+	}
+	return n, dc
+}
+
+func TestAPIVersionNegotiatorVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		served  []string // first entry is the cluster's preferred version
+		want    string
+		wantErr string
+	}{
+		{name: "preferred and supported", served: []string{"v1alpha1", "v1beta1"}, want: "v1alpha1"},
+		{name: "preferred unsupported falls back to canonical", served: []string{"v1", "v1beta1"}, want: "v1beta1"},
+		{name: "only a non-canonical supported version", served: []string{"v1", "v1alpha1"}, want: "v1alpha1"},
+		{name: "not installed uses canonical", want: "v1beta1"},
+		{name: "no supported version served", served: []string{"v1"}, wantErr: "cluster serves kaito.sh/workspaces only in v1, but the provider supports v1beta1, v1alpha1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, _ := newWorkspaceNegotiator(tt.served...)
+			got, err := n.Version()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestAPIVersionNegotiatorCachesVersion(t *testing.T) {
+	n, dc := newWorkspaceNegotiator("v1alpha1")
+	if v, _ := n.Version(); v != "v1alpha1" {
+		t.Fatalf("expected v1alpha1, got %s", v)
+	}
+	dc.Resources = nil
+	if v, _ := n.Version(); v != "v1alpha1" {
+		t.Errorf("expected the cached version, got %s", v)
+	}
+
+	n.Discovery = nil
+	if v, _ := n.Version(); v != "v1beta1" {
+		t.Errorf("expected the canonical version without discovery, got %s", v)
+	}
+}
+
+func TestAPIVersionNegotiatorApply(t *testing.T) {
+	n, _ := newWorkspaceNegotiator()
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"inference": map[string]interface{}{"config": "custom", "preset": map[string]interface{}{"name": "phi-4"}},
+	}}
+
+	if err := n.Apply(obj, "v1alpha1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if obj.GetAPIVersion() != "kaito.sh/v1alpha1" {
+		t.Errorf("expected apiVersion kaito.sh/v1alpha1, got %s", obj.GetAPIVersion())
+	}
+	if _, found, _ := unstructured.NestedFieldNoCopy(obj.Object, "inference", "config"); found {
+		t.Error("expected the v1alpha1 mapping to run")
+	}
+	if err := n.Apply(obj, "v1"); err == nil {
+		t.Error("expected an error for an unsupported version")
+	}
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// applyRanks orders the kinds other resources depend on. Kinds not listed, i.e. the
+// workloads and the resources that route to them, are applied last.
+var applyRanks = map[string]int{
+	"Namespace":             0,
+	"ServiceAccount":        1,
+	"Role":                  1,
+	"RoleBinding":           1,
+	"Secret":                2,
+	"ConfigMap":             2,
+	"PersistentVolumeClaim": 2,
+	"PodGroup":              3,
+	"Service":               4,
+}
+
+// defaultApplyRank is the rank of kinds not in applyRanks
+const defaultApplyRank = 5
+
+// ApplyFunc creates or updates obj and reports whether it created it.
+type ApplyFunc func(ctx context.Context, obj *unstructured.Unstructured) (created bool, err error)
+
+// ApplyError is returned by ApplyResources when a resource fails to apply.
+type ApplyError struct {
+	// Resource is the resource that failed
+	Resource ResourceRef
+	// Applied are the resources applied before the failure, in apply order
+	Applied []ResourceRef
+	// RolledBack are the applied resources that were created by the failed apply and
+	// deleted again
+	RolledBack []ResourceRef
+	// Err is the error of the failed resource
+	Err error
+	// RollbackErr is set when created resources could not be deleted
+	RollbackErr error
+}
+
+func (e *ApplyError) Error() string {
+	msg := fmt.Sprintf("failed to apply %s: %v", e.Resource, e.Err)
+	if e.RollbackErr != nil {
+		msg += fmt.Sprintf("; rollback failed: %v", e.RollbackErr)
+	}
+	return msg
+}
+
+// Unwrap returns the error of the failed resource, so callers can still detect API
+// conflicts and ownership errors.
+func (e *ApplyError) Unwrap() error {
+	return e.Err
+}
+
+// PartialApply returns the status.provider.partialApply state for err, or nil when err
+// is not an ApplyError.
+func PartialApply(err error) *airunwayv1alpha1.PartialApplyStatus {
+	var applyErr *ApplyError
+	if !errors.As(err, &applyErr) {
+		return nil
+	}
+	status := &airunwayv1alpha1.PartialApplyStatus{FailedResource: applyErr.Resource.String()}
+	for _, ref := range applyErr.Applied {
+		status.AppliedResources = append(status.AppliedResources, ref.String())
+	}
+	for _, ref := range applyErr.RolledBack {
+		status.RolledBackResources = append(status.RolledBackResources, ref.String())
+	}
+	return status
+}
+
+// OrderResources returns resources in dependency order: RBAC, then configuration and
+// storage, PodGroups and Services, and then everything else. Resources of the same rank
+// keep their order. The input slice is not modified, so TransformResult.Primary is
+// unaffected.
+func OrderResources(resources []*unstructured.Unstructured) []*unstructured.Unstructured {
+	ordered := slices.Clone(resources)
+	slices.SortStableFunc(ordered, func(a, b *unstructured.Unstructured) int {
+		return applyRank(a) - applyRank(b)
+	})
+	return ordered
+}
+
+func applyRank(obj *unstructured.Unstructured) int {
+	if rank, ok := applyRanks[obj.GetKind()]; ok {
+		return rank
+	}
+	return defaultApplyRank
+}
+
+// ApplyResources applies resources in dependency order with apply. When a resource
+// fails, the resources this call created are deleted in reverse order, so a failed apply
+// does not leave half of the upstream state behind, and an *ApplyError is returned.
+// Resources that were updated rather than created are kept.
+func ApplyResources(ctx context.Context, c client.Writer, resources []*unstructured.Unstructured, apply ApplyFunc) error {
+	var applied []ResourceRef
+	var created []*unstructured.Unstructured
+	for _, obj := range OrderResources(resources) {
+		isNew, err := apply(ctx, obj)
+		if err != nil {
+			applyErr := &ApplyError{Resource: RefFor(obj), Applied: applied, Err: err}
+			applyErr.RolledBack, applyErr.RollbackErr = rollback(ctx, c, created)
+			return applyErr
+		}
+		applied = append(applied, RefFor(obj))
+		if isNew {
+			created = append(created, obj)
+		}
+	}
+	return nil
+}
+
+// rollback deletes created resources in reverse order and returns the deleted ones
+func rollback(ctx context.Context, c client.Writer, created []*unstructured.Unstructured) ([]ResourceRef, error) {
+	var deleted []ResourceRef
+	var errs []error
+	for i := len(created) - 1; i >= 0; i-- {
+		obj := created[i]
+		opts := []client.DeleteOption{client.PropagationPolicy(metav1.DeletePropagationBackground)}
+		if uid := obj.GetUID(); uid != "" {
+			// Only delete the object this apply created
+			opts = append(opts, client.Preconditions{UID: &uid})
+		}
+		if err := c.Delete(ctx, obj, opts...); client.IgnoreNotFound(err) != nil {
+			errs = append(errs, fmt.Errorf("failed to delete %s: %w", RefFor(obj), err))
+			continue
+		}
+		deleted = append(deleted, RefFor(obj))
+	}
+	return deleted, errors.Join(errs...)
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newCoreObject(kind, name string) *unstructured.Unstructured {
+	obj := newObject("v1", kind, name)
+	obj.SetNamespace("default")
+	return obj
+}
+
+func refNames(objs []*unstructured.Unstructured) []string {
+	var names []string
+	for _, obj := range objs {
+		names = append(names, RefFor(obj).String())
+	}
+	return names
+}
+
+func TestOrderResources(t *testing.T) {
+	workload := newObject("kaito.sh/v1beta1", "Workspace", "demo")
+	svc := newCoreObject("Service", "demo")
+	cm := newCoreObject("ConfigMap", "demo-chat-template")
+	podGroup := newObject("scheduling.volcano.sh/v1beta1", "PodGroup", "demo")
+	resources := []*unstructured.Unstructured{workload, svc, cm, podGroup}
+
+	got := refNames(OrderResources(resources))
+	want := refNames([]*unstructured.Unstructured{cm, podGroup, svc, workload})
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if resources[0] != workload {
+		t.Error("expected the input order to be kept")
+	}
+}
+
+func TestApplyResources(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	existing := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "demo", Namespace: "default"}}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing).Build()
+
+	cm := newCoreObject("ConfigMap", "demo-config")
+	svc := newCoreObject("Service", "demo")
+	pod := newCoreObject("Pod", "demo")
+	failure := apierrors.NewConflict(schema.GroupResource{Resource: "pods"}, "demo", errors.New("stale"))
+	apply := func(ctx context.Context, obj *unstructured.Unstructured) (bool, error) {
+		switch obj.GetKind() {
+		case "Pod":
+			return false, failure
+		case "Service":
+			return false, nil
+		}
+		return true, c.Create(ctx, obj)
+	}
+
+	err := ApplyResources(ctx, c, []*unstructured.Unstructured{pod, svc, cm}, apply)
+	var applyErr *ApplyError
+	if !errors.As(err, &applyErr) {
+		t.Fatalf("expected an ApplyError, got %v", err)
+	}
+	if !apierrors.IsConflict(err) {
+		t.Error("expected the error of the failed resource to be unwrapped")
+	}
+
+	status := PartialApply(err)
+	if status.FailedResource != "Pod/demo" ||
+		!reflect.DeepEqual(status.AppliedResources, []string{"ConfigMap/demo-config", "Service/demo"}) ||
+		!reflect.DeepEqual(status.RolledBackResources, []string{"ConfigMap/demo-config"}) {
+		t.Errorf("unexpected partial apply status %+v", status)
+	}
+
+	// The created ConfigMap is deleted, the updated Service kept
+	if err := c.Get(ctx, client.ObjectKey{Name: "demo-config", Namespace: "default"}, &corev1.ConfigMap{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the ConfigMap to be rolled back, got %v", err)
+	}
+	if err := c.Get(ctx, client.ObjectKey{Name: "demo", Namespace: "default"}, &corev1.Service{}); err != nil {
+		t.Errorf("expected the Service to be kept: %v", err)
+	}
+
+	cm = newCoreObject("ConfigMap", "demo-config")
+	if err := ApplyResources(ctx, c, []*unstructured.Unstructured{svc, cm}, apply); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if PartialApply(nil) != nil || PartialApply(errors.New("other")) != nil {
+		t.Error("expected no partial apply status for other errors")
+	}
+}
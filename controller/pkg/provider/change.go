@@ -0,0 +1,112 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"gomodules.xyz/jsonpatch/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/events"
+)
+
+const (
+	// maxChangePaths caps the paths listed in status.lastAppliedChange
+	maxChangePaths = 20
+	// maxChangeNoteLength keeps the ResourceUpdated event note within the 1 KiB the
+	// Events API allows
+	maxChangeNoteLength = 1024
+)
+
+// DiffResource returns the JSON patch (RFC 6902) that turns the given top-level fields of
+// before into those of after, e.g. "spec". Other fields, such as metadata and status, are
+// ignored. Operations are sorted by path.
+func DiffResource(before, after *unstructured.Unstructured, fields ...string) ([]jsonpatch.Operation, error) {
+	pick := func(obj *unstructured.Unstructured) ([]byte, error) {
+		picked := map[string]interface{}{}
+		for _, field := range fields {
+			if value, ok := obj.Object[field]; ok {
+				picked[field] = value
+			}
+		}
+		return json.Marshal(picked)
+	}
+
+	a, err := pick(before)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s %s: %w", before.GetKind(), before.GetName(), err)
+	}
+	b, err := pick(after)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s %s: %w", after.GetKind(), after.GetName(), err)
+	}
+	patch, err := jsonpatch.CreatePatch(a, b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff %s %s: %w", after.GetKind(), after.GetName(), err)
+	}
+	sort.Sort(jsonpatch.ByPath(patch))
+	return patch, nil
+}
+
+// RecordResourceChange diffs the given top-level fields of an upstream resource before and
+// after an update, and records the change in status.lastAppliedChange and, when recorder is
+// set, in a ResourceUpdated event carrying the JSON patch. An update that changed none of the
+// fields, e.g. one that only touched metadata, is not recorded.
+func RecordResourceChange(md *airunwayv1alpha1.ModelDeployment, recorder events.EventRecorder, before, after *unstructured.Unstructured, fields ...string) error {
+	patch, err := DiffResource(before, after, fields...)
+	if err != nil {
+		return err
+	}
+	if len(patch) == 0 {
+		return nil
+	}
+
+	paths := make([]string, 0, min(len(patch), maxChangePaths))
+	for _, op := range patch {
+		if len(paths) == maxChangePaths {
+			break
+		}
+		paths = append(paths, op.Path)
+	}
+	now := metav1.Now()
+	md.Status.LastAppliedChange = &airunwayv1alpha1.AppliedChange{
+		ResourceKind:       after.GetKind(),
+		ResourceName:       after.GetName(),
+		Paths:              paths,
+		Operations:         int32(len(patch)),
+		ObservedGeneration: md.Generation,
+		Time:               &now,
+	}
+
+	if recorder != nil {
+		data, err := json.Marshal(patch)
+		if err != nil {
+			return fmt.Errorf("failed to marshal patch for %s %s: %w", after.GetKind(), after.GetName(), err)
+		}
+		note := fmt.Sprintf("Updated %s %s: %s", after.GetKind(), after.GetName(), data)
+		if len(note) > maxChangeNoteLength {
+			note = note[:maxChangeNoteLength-3] + "..."
+		}
+		recorder.Eventf(md, after, corev1.EventTypeNormal, airunwayv1alpha1.ReasonResourceUpdated, "Update", "%s", note)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"k8s.io/client-go/tools/events"
+)
+
+func TestDiffResource(t *testing.T) {
+	before := newObject("apps/v1", "Deployment", "model")
+	before.Object["spec"] = map[string]interface{}{"replicas": int64(1), "paused": true}
+	before.Object["status"] = map[string]interface{}{"readyReplicas": int64(1)}
+	after := before.DeepCopy()
+	after.Object["spec"] = map[string]interface{}{"replicas": int64(3), "minReadySeconds": int64(10)}
+	after.Object["status"] = map[string]interface{}{"readyReplicas": int64(0)}
+
+	patch, err := DiffResource(before, after, "spec")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []string
+	for _, op := range patch {
+		got = append(got, op.Operation+" "+op.Path)
+	}
+	want := []string{"add /spec/minReadySeconds", "remove /spec/paused", "replace /spec/replicas"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestRecordResourceChange(t *testing.T) {
+	md := &airunwayv1alpha1.ModelDeployment{}
+	md.Generation = 4
+	recorder := events.NewFakeRecorder(1)
+
+	before := newObject("kaito.sh/v1beta1", "Workspace", "model")
+	before.Object["resource"] = map[string]interface{}{"count": int64(1)}
+	after := before.DeepCopy()
+	if err := RecordResourceChange(md, recorder, before, after, "resource"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if md.Status.LastAppliedChange != nil {
+		t.Fatal("expected an unchanged resource not to be recorded")
+	}
+
+	after.Object["resource"] = map[string]interface{}{"count": int64(2)}
+	if err := RecordResourceChange(md, recorder, before, after, "resource"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	change := md.Status.LastAppliedChange
+	if change == nil || change.ResourceKind != "Workspace" || change.ResourceName != "model" ||
+		change.Operations != 1 || change.ObservedGeneration != 4 || change.Time == nil {
+		t.Fatalf("unexpected change summary: %+v", change)
+	}
+	if len(change.Paths) != 1 || change.Paths[0] != "/resource/count" {
+		t.Errorf("expected path /resource/count, got %v", change.Paths)
+	}
+	event := <-recorder.Events
+	if !strings.Contains(event, "ResourceUpdated") || !strings.Contains(event, `{"op":"replace","path":"/resource/count","value":2}`) {
+		t.Errorf("expected event with the JSON patch, got %q", event)
+	}
+}
+
+func TestRecordResourceChangeTruncates(t *testing.T) {
+	md := &airunwayv1alpha1.ModelDeployment{}
+	recorder := events.NewFakeRecorder(1)
+
+	before := newObject("apps/v1", "Deployment", "model")
+	before.Object["spec"] = map[string]interface{}{}
+	after := before.DeepCopy()
+	spec := map[string]interface{}{}
+	for i := 0; i < 100; i++ {
+		spec[fmt.Sprintf("field%03d", i)] = int64(i)
+	}
+	after.Object["spec"] = spec
+
+	if err := RecordResourceChange(md, recorder, before, after, "spec"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := len(md.Status.LastAppliedChange.Paths); got != maxChangePaths {
+		t.Errorf("expected %d paths, got %d", maxChangePaths, got)
+	}
+	if md.Status.LastAppliedChange.Operations != 100 {
+		t.Errorf("expected 100 operations, got %d", md.Status.LastAppliedChange.Operations)
+	}
+	if event := <-recorder.Events; !strings.HasSuffix(event, "...") {
+		t.Errorf("expected a truncated event note, got %q", event)
+	}
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+// The chat template of spec.model.chatTemplate is mounted from a ConfigMap as a file in
+// the engine container, since not every engine accepts a template on the command line.
+const (
+	ChatTemplateVolumeName = "chat-template"
+	ChatTemplateMountPath  = "/etc/airunway/chat-template"
+	ChatTemplateFileName   = "chat_template.jinja"
+
+	// ChatTemplateHashAnnot is set on pod templates to the hash of an inline chat template,
+	// so that pods are restarted with the new template when it changes.
+	ChatTemplateHashAnnot = "airunway.ai/chat-template-hash"
+)
+
+// ChatTemplatePath returns the path of the chat template in the engine container, or ""
+// when spec.model.chatTemplate is not set.
+func ChatTemplatePath(md *airunwayv1alpha1.ModelDeployment) string {
+	if md.Spec.Model.ChatTemplate == nil {
+		return ""
+	}
+	return ChatTemplateMountPath + "/" + ChatTemplateFileName
+}
+
+// ChatTemplateConfigMapName returns the name of the ConfigMap created for an inline chat
+// template of the named ModelDeployment.
+func ChatTemplateConfigMapName(mdName string) string {
+	return mdName + "-chat-template"
+}
+
+// ChatTemplateArgs returns the vllm and sglang flags for spec.model.chatTemplate and
+// spec.model.tokenizer.
+func ChatTemplateArgs(md *airunwayv1alpha1.ModelDeployment) []string {
+	var args []string
+	if path := ChatTemplatePath(md); path != "" {
+		switch md.ResolvedEngineType() {
+		case airunwayv1alpha1.EngineTypeVLLM, airunwayv1alpha1.EngineTypeSGLang:
+			args = append(args, "--chat-template", path)
+		}
+	}
+	return append(args, TokenizerArgs(md)...)
+}
+
+// TokenizerArgs returns the vllm and sglang flags for spec.model.tokenizer.
+func TokenizerArgs(md *airunwayv1alpha1.ModelDeployment) []string {
+	if md.Spec.Model.Tokenizer == "" {
+		return nil
+	}
+	switch md.ResolvedEngineType() {
+	case airunwayv1alpha1.EngineTypeVLLM:
+		return []string{"--tokenizer", md.Spec.Model.Tokenizer}
+	case airunwayv1alpha1.EngineTypeSGLang:
+		return []string{"--tokenizer-path", md.Spec.Model.Tokenizer}
+	}
+	return nil
+}
+
+// AddChatTemplateConfigMap adds the ConfigMap holding an inline chat template to the
+// resources of result. ApplyResources applies it before the pods that mount it. It is a
+// no-op unless spec.model.chatTemplate.inline is set.
+func AddChatTemplateConfigMap(result *TransformResult, md *airunwayv1alpha1.ModelDeployment) {
+	ct := md.Spec.Model.ChatTemplate
+	if ct == nil || ct.Inline == "" {
+		return
+	}
+	cm := &unstructured.Unstructured{}
+	cm.SetAPIVersion("v1")
+	cm.SetKind("ConfigMap")
+	cm.SetName(ChatTemplateConfigMapName(md.Name))
+	cm.SetNamespace(md.Namespace)
+	cm.SetLabels(map[string]string{
+		airunwayv1alpha1.LabelManagedBy:       airunwayv1alpha1.ManagedByAIRunway,
+		airunwayv1alpha1.LabelModelDeployment: md.Name,
+	})
+	controller := true
+	cm.SetOwnerReferences([]metav1.OwnerReference{{
+		APIVersion:         airunwayv1alpha1.GroupVersion.String(),
+		Kind:               "ModelDeployment",
+		Name:               md.Name,
+		UID:                md.UID,
+		Controller:         &controller,
+		BlockOwnerDeletion: &controller,
+	}})
+	cm.Object["data"] = map[string]interface{}{ChatTemplateFileName: ct.Inline}
+	result.Resources = append(result.Resources, cm)
+}
+
+// ChatTemplateVolume returns the pod volume holding the chat template as unstructured
+// content, or nil when spec.model.chatTemplate is not set. A referenced ConfigMap key is
+// projected to the same file name as an inline template.
+func ChatTemplateVolume(md *airunwayv1alpha1.ModelDeployment) map[string]interface{} {
+	ct := md.Spec.Model.ChatTemplate
+	if ct == nil {
+		return nil
+	}
+	configMap := map[string]interface{}{"name": ChatTemplateConfigMapName(md.Name)}
+	if ref := ct.ConfigMapKeyRef; ref != nil {
+		configMap = map[string]interface{}{
+			"name": ref.Name,
+			"items": []interface{}{
+				map[string]interface{}{"key": ref.Key, "path": ChatTemplateFileName},
+			},
+		}
+		if ref.Optional != nil {
+			configMap["optional"] = *ref.Optional
+		}
+	}
+	return map[string]interface{}{
+		"name":      ChatTemplateVolumeName,
+		"configMap": configMap,
+	}
+}
+
+// ChatTemplateVolumeMount returns the container volume mount of the chat template volume
+// as unstructured content.
+func ChatTemplateVolumeMount() map[string]interface{} {
+	return map[string]interface{}{
+		"name":      ChatTemplateVolumeName,
+		"mountPath": ChatTemplateMountPath,
+		"readOnly":  true,
+	}
+}
+
+// ChatTemplateHash returns the hash of an inline chat template, or "" when
+// spec.model.chatTemplate.inline is not set.
+func ChatTemplateHash(md *airunwayv1alpha1.ModelDeployment) string {
+	ct := md.Spec.Model.ChatTemplate
+	if ct == nil || ct.Inline == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(ct.Inline))
+	return hex.EncodeToString(sum[:8])
+}
+
+// ApplyChatTemplateToPodTemplate adds the chat template volume to an unstructured pod
+// template with metadata and spec maps, mounts it in every container, and annotates the
+// template with the hash of an inline template. It is a no-op when
+// spec.model.chatTemplate is not set.
+func ApplyChatTemplateToPodTemplate(template map[string]interface{}, md *airunwayv1alpha1.ModelDeployment) {
+	volume := ChatTemplateVolume(md)
+	if volume == nil {
+		return
+	}
+	if hash := ChatTemplateHash(md); hash != "" {
+		metadata, ok := template["metadata"].(map[string]interface{})
+		if !ok {
+			metadata = map[string]interface{}{}
+			template["metadata"] = metadata
+		}
+		mergeStringMap(metadata, "annotations", map[string]string{ChatTemplateHashAnnot: hash})
+	}
+
+	spec, ok := template["spec"].(map[string]interface{})
+	if !ok {
+		spec = map[string]interface{}{}
+		template["spec"] = spec
+	}
+	volumes, _ := spec["volumes"].([]interface{})
+	spec["volumes"] = append(volumes, volume)
+	containers, _ := spec["containers"].([]interface{})
+	for _, c := range containers {
+		container, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		mounts, _ := container["volumeMounts"].([]interface{})
+		container["volumeMounts"] = append(mounts, ChatTemplateVolumeMount())
+	}
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+func newChatTemplateMD(engine airunwayv1alpha1.EngineType, ct *airunwayv1alpha1.ChatTemplateSource, tokenizer string) *airunwayv1alpha1.ModelDeployment {
+	return &airunwayv1alpha1.ModelDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "team-a", UID: "uid"},
+		Spec: airunwayv1alpha1.ModelDeploymentSpec{
+			Model:  airunwayv1alpha1.ModelSpec{ID: "org/llama-ft", ChatTemplate: ct, Tokenizer: tokenizer},
+			Engine: airunwayv1alpha1.EngineSpec{Type: engine},
+		},
+	}
+}
+
+func TestChatTemplateArgs(t *testing.T) {
+	inline := &airunwayv1alpha1.ChatTemplateSource{Inline: "{{ messages }}"}
+	path := ChatTemplateMountPath + "/" + ChatTemplateFileName
+
+	tests := []struct {
+		name string
+		md   *airunwayv1alpha1.ModelDeployment
+		want []string
+	}{
+		{name: "unset", md: newChatTemplateMD(airunwayv1alpha1.EngineTypeVLLM, nil, ""), want: nil},
+		{
+			name: "vllm",
+			md:   newChatTemplateMD(airunwayv1alpha1.EngineTypeVLLM, inline, "org/tokenizer"),
+			want: []string{"--chat-template", path, "--tokenizer", "org/tokenizer"},
+		},
+		{
+			name: "sglang",
+			md:   newChatTemplateMD(airunwayv1alpha1.EngineTypeSGLang, inline, "org/tokenizer"),
+			want: []string{"--chat-template", path, "--tokenizer-path", "org/tokenizer"},
+		},
+		{name: "trtllm", md: newChatTemplateMD(airunwayv1alpha1.EngineTypeTRTLLM, inline, "org/tokenizer"), want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ChatTemplateArgs(tt.md); !slices.Equal(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestAddChatTemplateConfigMap(t *testing.T) {
+	result := NewTransformResult(newObject("apps/v1", "Deployment", "llama"))
+	ref := &airunwayv1alpha1.ChatTemplateSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "templates"}, Key: "llama.jinja",
+	}}
+	AddChatTemplateConfigMap(result, newChatTemplateMD(airunwayv1alpha1.EngineTypeVLLM, ref, ""))
+	if len(result.Resources) != 1 {
+		t.Fatalf("expected no ConfigMap for a ConfigMap reference, got %d resources", len(result.Resources))
+	}
+
+	md := newChatTemplateMD(airunwayv1alpha1.EngineTypeVLLM, &airunwayv1alpha1.ChatTemplateSource{Inline: "{{ messages }}"}, "")
+	AddChatTemplateConfigMap(result, md)
+	if len(result.Resources) != 2 || result.Resources[1].GetKind() != "ConfigMap" {
+		t.Fatalf("expected the ConfigMap to be added, got %v", result.Resources)
+	}
+	cm := result.Resources[1]
+	if cm.GetName() != "llama-chat-template" || cm.GetNamespace() != "team-a" {
+		t.Errorf("expected team-a/llama-chat-template, got %s/%s", cm.GetNamespace(), cm.GetName())
+	}
+	if data, _, _ := unstructured.NestedString(cm.Object, "data", ChatTemplateFileName); data != "{{ messages }}" {
+		t.Errorf("expected the inline template, got %q", data)
+	}
+	if refs := cm.GetOwnerReferences(); len(refs) != 1 || refs[0].UID != "uid" {
+		t.Errorf("expected the ModelDeployment to own the ConfigMap, got %v", refs)
+	}
+	if result.Primary().GetKind() != "Deployment" {
+		t.Errorf("expected the Deployment to stay primary, got %s", result.Primary().GetKind())
+	}
+}
+
+func TestApplyChatTemplateToPodTemplate(t *testing.T) {
+	newTemplate := func() map[string]interface{} {
+		return map[string]interface{}{
+			"metadata": map[string]interface{}{},
+			"spec": map[string]interface{}{
+				"containers": []interface{}{map[string]interface{}{"name": "vllm"}},
+			},
+		}
+	}
+
+	template := newTemplate()
+	ApplyChatTemplateToPodTemplate(template, newChatTemplateMD(airunwayv1alpha1.EngineTypeVLLM, nil, ""))
+	if _, found, _ := unstructured.NestedSlice(template, "spec", "volumes"); found {
+		t.Error("expected no volume without spec.model.chatTemplate")
+	}
+
+	inline := newChatTemplateMD(airunwayv1alpha1.EngineTypeVLLM, &airunwayv1alpha1.ChatTemplateSource{Inline: "{{ messages }}"}, "")
+	template = newTemplate()
+	ApplyChatTemplateToPodTemplate(template, inline)
+	volumes, _, _ := unstructured.NestedSlice(template, "spec", "volumes")
+	if len(volumes) != 1 {
+		t.Fatalf("expected one volume, got %v", volumes)
+	}
+	if name, _, _ := unstructured.NestedString(volumes[0].(map[string]interface{}), "configMap", "name"); name != "llama-chat-template" {
+		t.Errorf("expected the generated ConfigMap, got %q", name)
+	}
+	containers, _, _ := unstructured.NestedSlice(template, "spec", "containers")
+	mounts, _, _ := unstructured.NestedSlice(containers[0].(map[string]interface{}), "volumeMounts")
+	if len(mounts) != 1 || mounts[0].(map[string]interface{})["mountPath"] != ChatTemplateMountPath {
+		t.Errorf("expected the chat template to be mounted, got %v", mounts)
+	}
+	hash, _, _ := unstructured.NestedString(template, "metadata", "annotations", ChatTemplateHashAnnot)
+	if hash == "" {
+		t.Error("expected the inline template hash annotation")
+	}
+
+	inline.Spec.Model.ChatTemplate.Inline = "{{ messages | tojson }}"
+	template = newTemplate()
+	ApplyChatTemplateToPodTemplate(template, inline)
+	if updated, _, _ := unstructured.NestedString(template, "metadata", "annotations", ChatTemplateHashAnnot); updated == hash {
+		t.Error("expected the hash to change with the template")
+	}
+
+	ref := newChatTemplateMD(airunwayv1alpha1.EngineTypeVLLM, &airunwayv1alpha1.ChatTemplateSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "templates"}, Key: "llama.jinja",
+	}}, "")
+	template = newTemplate()
+	ApplyChatTemplateToPodTemplate(template, ref)
+	volumes, _, _ = unstructured.NestedSlice(template, "spec", "volumes")
+	items, _, _ := unstructured.NestedSlice(volumes[0].(map[string]interface{}), "configMap", "items")
+	if len(items) != 1 || items[0].(map[string]interface{})["key"] != "llama.jinja" || items[0].(map[string]interface{})["path"] != ChatTemplateFileName {
+		t.Errorf("expected the referenced key projected to %s, got %v", ChatTemplateFileName, items)
+	}
+	if _, found, _ := unstructured.NestedString(template, "metadata", "annotations", ChatTemplateHashAnnot); found {
+		t.Error("expected no hash annotation for a ConfigMap reference")
+	}
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"golang.org/x/mod/semver"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ReasonUpstreamCompatible is the UpstreamCompatible condition reason when the cluster
+	// matches spec.compatibility
+	ReasonUpstreamCompatible = "Compatible"
+	// ReasonUpstreamVersionMismatch is the condition reason when the cluster serves upstream
+	// CRD or operator versions outside spec.compatibility
+	ReasonUpstreamVersionMismatch = "UpstreamVersionMismatch"
+	// ReasonUpstreamNotDetected is the UpstreamCompatible condition reason when the upstream
+	// CRDs are not installed, so compatibility cannot be judged
+	ReasonUpstreamNotDetected = "UpstreamNotDetected"
+
+	// operatorVersionLabel is the label upstream charts set on their CRDs to record the
+	// operator version
+	operatorVersionLabel = "app.kubernetes.io/version"
+)
+
+// ValidateCompatibility checks that a provider's declared compatibility is well formed.
+// Providers call it when registering so a bad declaration fails at startup rather than
+// when comparing versions.
+func ValidateCompatibility(c *airunwayv1alpha1.ProviderCompatibility) error {
+	if c == nil {
+		return nil
+	}
+	for _, v := range c.CRDVersions {
+		gv, err := schema.ParseGroupVersion(v)
+		if err != nil || gv.Group == "" || gv.Version == "" {
+			return fmt.Errorf("compatibility.crdVersions: %q is not a group/version", v)
+		}
+	}
+	minVersion, maxVersion := canonicalVersion(c.MinOperatorVersion), canonicalVersion(c.MaxOperatorVersion)
+	if c.MinOperatorVersion != "" && minVersion == "" {
+		return fmt.Errorf("compatibility.minOperatorVersion: %q is not a semantic version", c.MinOperatorVersion)
+	}
+	if c.MaxOperatorVersion != "" && maxVersion == "" {
+		return fmt.Errorf("compatibility.maxOperatorVersion: %q is not a semantic version", c.MaxOperatorVersion)
+	}
+	if minVersion != "" && maxVersion != "" && semver.Compare(minVersion, maxVersion) > 0 {
+		return fmt.Errorf("compatibility.minOperatorVersion %s is newer than maxOperatorVersion %s",
+			c.MinOperatorVersion, c.MaxOperatorVersion)
+	}
+	return nil
+}
+
+// CheckCompatibility compares a provider's declared compatibility with the cluster. served
+// lists the group/versions the cluster serves the upstream kind in, and operatorVersion is
+// the detected upstream operator version, empty when unknown. It returns a message
+// describing the mismatch, or "" when nothing known conflicts.
+func CheckCompatibility(c *airunwayv1alpha1.ProviderCompatibility, served []string, operatorVersion string) string {
+	if c == nil {
+		return ""
+	}
+	var problems []string
+	if len(c.CRDVersions) > 0 && len(served) > 0 && !slices.ContainsFunc(served, func(v string) bool {
+		return slices.Contains(c.CRDVersions, v)
+	}) {
+		problems = append(problems, fmt.Sprintf("cluster serves %s but the provider creates %s",
+			strings.Join(served, ", "), strings.Join(c.CRDVersions, ", ")))
+	}
+	if v := canonicalVersion(operatorVersion); v != "" {
+		if minVersion := canonicalVersion(c.MinOperatorVersion); minVersion != "" && semver.Compare(v, minVersion) < 0 {
+			problems = append(problems, fmt.Sprintf("operator version %s is older than the minimum supported %s",
+				operatorVersion, c.MinOperatorVersion))
+		}
+		if maxVersion := canonicalVersion(c.MaxOperatorVersion); maxVersion != "" && semver.Compare(v, maxVersion) > 0 {
+			problems = append(problems, fmt.Sprintf("operator version %s is newer than the maximum supported %s",
+				operatorVersion, c.MaxOperatorVersion))
+		}
+	}
+	return strings.Join(problems, "; ")
+}
+
+// SetUpstreamCompatibleCondition records the UpstreamCompatible condition and the detected
+// operator version on status, and returns false when the cluster does not match compat.
+// kind names the upstream resource in the message when its CRD is not installed.
+func SetUpstreamCompatibleCondition(status *airunwayv1alpha1.InferenceProviderConfigStatus, compat *airunwayv1alpha1.ProviderCompatibility, kind string, served []string, operatorVersion string) bool {
+	status.UpstreamOperatorVersion = operatorVersion
+	cond := metav1.Condition{Type: airunwayv1alpha1.ConditionTypeUpstreamCompatible}
+	msg := CheckCompatibility(compat, served, operatorVersion)
+	switch {
+	case msg != "":
+		cond.Status, cond.Reason, cond.Message = metav1.ConditionFalse, ReasonUpstreamVersionMismatch, msg
+	case len(served) == 0:
+		cond.Status, cond.Reason, cond.Message = metav1.ConditionUnknown, ReasonUpstreamNotDetected, fmt.Sprintf("%s CRD is not installed", kind)
+	default:
+		cond.Status, cond.Reason, cond.Message = metav1.ConditionTrue, ReasonUpstreamCompatible, fmt.Sprintf("Cluster serves %s", strings.Join(served, ", "))
+	}
+	meta.SetStatusCondition(&status.Conditions, cond)
+	return msg == ""
+}
+
+// ServedVersions returns the group/versions in which the cluster serves resource of group,
+// using fresh discovery results.
+func ServedVersions(dc discovery.DiscoveryInterface, group, resource string) ([]string, error) {
+	versions, _, err := discoverVersions(dc, group, resource)
+	if err != nil {
+		return nil, err
+	}
+	var served []string
+	for _, v := range versions {
+		served = append(served, group+"/"+v)
+	}
+	return served, nil
+}
+
+// MappedVersions returns the group/versions a RESTMapper knows for gk. It is the fallback
+// when no discovery client is available; a cached mapper may lag behind CRD upgrades.
+func MappedVersions(mapper meta.RESTMapper, gk schema.GroupKind) []string {
+	mappings, err := mapper.RESTMappings(gk)
+	if err != nil {
+		return nil
+	}
+	var served []string
+	for _, m := range mappings {
+		if gv := m.GroupVersionKind.GroupVersion().String(); !slices.Contains(served, gv) {
+			served = append(served, gv)
+		}
+	}
+	return served
+}
+
+// OperatorVersionFromCRD returns the operator version recorded in the
+// app.kubernetes.io/version label of the named CRD, or "" when the CRD or label is absent.
+// Only the CRD metadata is read.
+func OperatorVersionFromCRD(ctx context.Context, c client.Reader, crdName string) (string, error) {
+	crd := &metav1.PartialObjectMetadata{}
+	crd.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "apiextensions.k8s.io",
+		Version: "v1",
+		Kind:    "CustomResourceDefinition",
+	})
+	if err := c.Get(ctx, client.ObjectKey{Name: crdName}, crd); err != nil {
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return crd.Labels[operatorVersionLabel], nil
+}
+
+// UpstreamIncompatibility returns the UpstreamCompatible=False message of the named
+// InferenceProviderConfig, or "" when the provider is compatible or not yet checked.
+func UpstreamIncompatibility(ctx context.Context, c client.Reader, providerName string) (string, error) {
+	config := &airunwayv1alpha1.InferenceProviderConfig{}
+	if err := c.Get(ctx, client.ObjectKey{Name: providerName}, config); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	cond := meta.FindStatusCondition(config.Status.Conditions, airunwayv1alpha1.ConditionTypeUpstreamCompatible)
+	if cond == nil || cond.Status != metav1.ConditionFalse {
+		return "", nil
+	}
+	return cond.Message, nil
+}
+
+// canonicalVersion returns v as a "v"-prefixed semantic version, or "" when it is not one
+func canonicalVersion(v string) string {
+	if v == "" {
+		return ""
+	}
+	if !strings.HasPrefix(v, "v") {
+		v = "v" + v
+	}
+	if !semver.IsValid(v) {
+		return ""
+	}
+	return v
+}
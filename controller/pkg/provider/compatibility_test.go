@@ -0,0 +1,152 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+func TestValidateCompatibility(t *testing.T) {
+	tests := []struct {
+		name    string
+		compat  *airunwayv1alpha1.ProviderCompatibility
+		wantErr string
+	}{
+		{name: "nil"},
+		{
+			name: "valid",
+			compat: &airunwayv1alpha1.ProviderCompatibility{
+				CRDVersions:        []string{"nvidia.com/v1alpha1"},
+				MinOperatorVersion: "0.6.0",
+				MaxOperatorVersion: "v1.2.0",
+			},
+		},
+		{
+			name:    "version without group",
+			compat:  &airunwayv1alpha1.ProviderCompatibility{CRDVersions: []string{"v1alpha1"}},
+			wantErr: "not a group/version",
+		},
+		{
+			name:    "invalid semver",
+			compat:  &airunwayv1alpha1.ProviderCompatibility{MinOperatorVersion: "latest"},
+			wantErr: "not a semantic version",
+		},
+		{
+			name: "min newer than max",
+			compat: &airunwayv1alpha1.ProviderCompatibility{
+				MinOperatorVersion: "1.2.0",
+				MaxOperatorVersion: "1.0.0",
+			},
+			wantErr: "newer than maxOperatorVersion",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCompatibility(tt.compat)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestCheckCompatibility(t *testing.T) {
+	compat := &airunwayv1alpha1.ProviderCompatibility{
+		CRDVersions:        []string{"nvidia.com/v1alpha1"},
+		MinOperatorVersion: "1.0.0",
+		MaxOperatorVersion: "1.2.0",
+	}
+
+	if msg := CheckCompatibility(compat, []string{"nvidia.com/v1alpha1", "nvidia.com/v1beta1"}, "1.1.0"); msg != "" {
+		t.Errorf("expected compatible, got %q", msg)
+	}
+	if msg := CheckCompatibility(compat, nil, ""); msg != "" {
+		t.Errorf("expected nothing to conflict when versions are unknown, got %q", msg)
+	}
+
+	msg := CheckCompatibility(compat, []string{"nvidia.com/v1beta1"}, "")
+	if !strings.Contains(msg, "cluster serves nvidia.com/v1beta1 but the provider creates nvidia.com/v1alpha1") {
+		t.Errorf("expected a CRD version mismatch, got %q", msg)
+	}
+	if msg := CheckCompatibility(compat, nil, "0.9.0"); !strings.Contains(msg, "older than the minimum") {
+		t.Errorf("expected an operator too old, got %q", msg)
+	}
+	if msg := CheckCompatibility(compat, nil, "v1.3.0"); !strings.Contains(msg, "newer than the maximum") {
+		t.Errorf("expected an operator too new, got %q", msg)
+	}
+	if msg := CheckCompatibility(compat, nil, "main"); msg != "" {
+		t.Errorf("expected an unparseable operator version to be ignored, got %q", msg)
+	}
+}
+
+func TestServedVersions(t *testing.T) {
+	dc := &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{}}
+	dc.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "nvidia.com/v1alpha1",
+			APIResources: []metav1.APIResource{{Name: "dynamocomponentdeployments"}},
+		},
+		{
+			GroupVersion: "nvidia.com/v1beta1",
+			APIResources: []metav1.APIResource{{Name: "dynamographdeployments"}},
+		},
+	}
+
+	served, err := ServedVersions(dc, "nvidia.com", "dynamographdeployments")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(served) != 1 || served[0] != "nvidia.com/v1beta1" {
+		t.Errorf("expected [nvidia.com/v1beta1], got %v", served)
+	}
+}
+
+func TestSetUpstreamCompatibleCondition(t *testing.T) {
+	compat := &airunwayv1alpha1.ProviderCompatibility{CRDVersions: []string{"nvidia.com/v1alpha1"}}
+	status := &airunwayv1alpha1.InferenceProviderConfigStatus{}
+
+	if !SetUpstreamCompatibleCondition(status, compat, "DynamoGraphDeployment", nil, "") {
+		t.Error("expected a missing CRD not to count as a mismatch")
+	}
+	if cond := meta.FindStatusCondition(status.Conditions, airunwayv1alpha1.ConditionTypeUpstreamCompatible); cond == nil || cond.Reason != ReasonUpstreamNotDetected {
+		t.Errorf("expected %s, got %+v", ReasonUpstreamNotDetected, cond)
+	}
+
+	if SetUpstreamCompatibleCondition(status, compat, "DynamoGraphDeployment", []string{"nvidia.com/v1beta1"}, "1.1.0") {
+		t.Error("expected a CRD version mismatch")
+	}
+	cond := meta.FindStatusCondition(status.Conditions, airunwayv1alpha1.ConditionTypeUpstreamCompatible)
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != ReasonUpstreamVersionMismatch {
+		t.Errorf("expected UpstreamCompatible=False, got %+v", cond)
+	}
+	if status.UpstreamOperatorVersion != "1.1.0" {
+		t.Errorf("expected the operator version to be recorded, got %q", status.UpstreamOperatorVersion)
+	}
+}
@@ -0,0 +1,327 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// cosignSignatureAnnotation is the annotation of a cosign signature layer holding the
+	// base64 signature of the layer
+	cosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+
+	// cosignVerifiedTTL is how long the verified digest of an image is reused before its
+	// tag is resolved and verified again
+	cosignVerifiedTTL = 10 * time.Minute
+
+	// maxRegistryResponse bounds the manifests and signature payloads read from registries
+	maxRegistryResponse = 4 << 20
+)
+
+// manifestMediaTypes are the manifest and index media types accepted from registries
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// CosignVerifier verifies key-based cosign signatures of images through the OCI
+// distribution API of their registry. Signatures are read from the sha256-<digest>.sig
+// tag of the image repository, the cosign default, with anonymous pull access.
+type CosignVerifier struct {
+	client *http.Client
+	now    func() time.Time
+
+	mu sync.Mutex
+	// verified maps the images and keys verified to their verified digest
+	verified map[string]verifiedDigest
+}
+
+// verifiedDigest is the verified manifest digest of an image and when it expires
+type verifiedDigest struct {
+	digest  string
+	expires time.Time
+}
+
+// NewCosignVerifier returns a CosignVerifier sending registry requests with client
+func NewCosignVerifier(client *http.Client) *CosignVerifier {
+	return &CosignVerifier{client: client, now: time.Now, verified: map[string]verifiedDigest{}}
+}
+
+// Verify checks that image carries a cosign signature of its manifest digest that verifies
+// with one of the PEM-encoded public keys, and returns that digest. Tags are resolved to
+// the digest they point to; the digest is what callers must run, since the tag can move.
+func (v *CosignVerifier) Verify(ctx context.Context, image string, publicKeys []string) (string, error) {
+	cacheKey := image + "\x00" + strings.Join(publicKeys, "\x00")
+	v.mu.Lock()
+	cached, ok := v.verified[cacheKey]
+	v.mu.Unlock()
+	if ok && v.now().Before(cached.expires) {
+		return cached.digest, nil
+	}
+
+	keys := make([]crypto.PublicKey, 0, len(publicKeys))
+	for i, data := range publicKeys {
+		key, err := parsePublicKey(data)
+		if err != nil {
+			return "", fmt.Errorf("public key %d: %w", i, err)
+		}
+		keys = append(keys, key)
+	}
+	ref, err := parseImageReference(image)
+	if err != nil {
+		return "", err
+	}
+	reg := &registryClient{client: v.client, ref: ref}
+	digest := ref.digest
+	if digest == "" {
+		if digest, err = reg.manifestDigest(ctx, ref.tag); err != nil {
+			return "", err
+		}
+	}
+	if err := reg.verifySignatures(ctx, digest, keys); err != nil {
+		return "", err
+	}
+
+	v.mu.Lock()
+	v.verified[cacheKey] = verifiedDigest{digest: digest, expires: v.now().Add(cosignVerifiedTTL)}
+	v.mu.Unlock()
+	return digest, nil
+}
+
+// parsePublicKey parses a PEM-encoded PKIX public key
+func parsePublicKey(data string) (crypto.PublicKey, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, errors.New("not PEM-encoded")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	switch key.(type) {
+	case *ecdsa.PublicKey, *rsa.PublicKey, ed25519.PublicKey:
+		return key, nil
+	}
+	return nil, fmt.Errorf("unsupported key type %T", key)
+}
+
+// verifySignature reports whether sig is a signature of payload by key, with SHA-256
+// digests for ECDSA and RSA keys as cosign signs them
+func verifySignature(key crypto.PublicKey, payload, sig []byte) bool {
+	digest := sha256.Sum256(payload)
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(k, digest[:], sig)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(k, crypto.SHA256, digest[:], sig) == nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(k, payload, sig)
+	}
+	return false
+}
+
+// registryClient reads manifests and blobs of one repository
+type registryClient struct {
+	client *http.Client
+	ref    imageReference
+	// token is the bearer token of the repository, once a request was challenged
+	token string
+}
+
+// baseURL returns the API URL of the registry, which for Docker Hub is not docker.io
+func (r *registryClient) baseURL() string {
+	host := r.ref.registry
+	if host == "docker.io" {
+		host = "registry-1.docker.io"
+	}
+	return "https://" + host + "/v2/" + r.ref.repository
+}
+
+// manifestDigest returns the digest of the manifest tagged tag
+func (r *registryClient) manifestDigest(ctx context.Context, tag string) (string, error) {
+	body, err := r.get(ctx, "/manifests/"+tag, manifestMediaTypes)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(body)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
+
+// verifySignatures checks that the cosign signature manifest of digest holds a signature
+// of a payload claiming digest that verifies with one of keys
+func (r *registryClient) verifySignatures(ctx context.Context, digest string, keys []crypto.PublicKey) error {
+	body, err := r.get(ctx, "/manifests/"+strings.Replace(digest, ":", "-", 1)+".sig", manifestMediaTypes)
+	if err != nil {
+		return fmt.Errorf("no signature for %s: %w", digest, err)
+	}
+	var manifest struct {
+		Layers []struct {
+			Digest      string            `json:"digest"`
+			Annotations map[string]string `json:"annotations"`
+		} `json:"layers"`
+	}
+	if err := json.Unmarshal(body, &manifest); err != nil {
+		return fmt.Errorf("invalid signature manifest: %w", err)
+	}
+	for _, layer := range manifest.Layers {
+		sig, err := base64.StdEncoding.DecodeString(layer.Annotations[cosignSignatureAnnotation])
+		if err != nil || len(sig) == 0 {
+			continue
+		}
+		payload, err := r.get(ctx, "/blobs/"+layer.Digest, nil)
+		if err != nil {
+			return err
+		}
+		if sum := sha256.Sum256(payload); "sha256:"+hex.EncodeToString(sum[:]) != layer.Digest {
+			continue
+		}
+		var claims struct {
+			Critical struct {
+				Image struct {
+					DockerManifestDigest string `json:"docker-manifest-digest"`
+				} `json:"image"`
+			} `json:"critical"`
+		}
+		if err := json.Unmarshal(payload, &claims); err != nil || claims.Critical.Image.DockerManifestDigest != digest {
+			continue
+		}
+		for _, key := range keys {
+			if verifySignature(key, payload, sig) {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("no signature of %s verifies with the policy keys", digest)
+}
+
+// get returns the body of a registry API path, answering a bearer token challenge once
+func (r *registryClient) get(ctx context.Context, path string, accept []string) ([]byte, error) {
+	resp, err := r.do(ctx, r.baseURL()+path, accept)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized && r.token == "" {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		_ = resp.Body.Close()
+		if err := r.authenticate(ctx, challenge); err != nil {
+			return nil, err
+		}
+		if resp, err = r.do(ctx, r.baseURL()+path, accept); err != nil {
+			return nil, err
+		}
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("registry returned %s for %s", resp.Status, path)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxRegistryResponse))
+}
+
+// do sends a GET request with the token of the repository
+func (r *registryClient) do(ctx context.Context, target string, accept []string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(accept) > 0 {
+		req.Header.Set("Accept", strings.Join(accept, ", "))
+	}
+	if r.token != "" {
+		req.Header.Set("Authorization", "Bearer "+r.token)
+	}
+	return r.client.Do(req)
+}
+
+// challengeParam matches the parameters of a WWW-Authenticate header
+var challengeParam = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// authenticate gets an anonymous pull token for the repository from the token service of
+// a Bearer challenge
+func (r *registryClient) authenticate(ctx context.Context, challenge string) error {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return fmt.Errorf("registry %s requires unsupported authentication %q", r.ref.registry, scheme)
+	}
+	values := map[string]string{}
+	for _, m := range challengeParam.FindAllStringSubmatch(params, -1) {
+		values[m[1]] = m[2]
+	}
+	realm, err := url.Parse(values["realm"])
+	if err != nil || realm.Scheme != "https" && realm.Scheme != "http" {
+		return fmt.Errorf("registry %s sent an invalid token realm %q", r.ref.registry, values["realm"])
+	}
+	query := realm.Query()
+	if service := values["service"]; service != "" {
+		query.Set("service", service)
+	}
+	scope := values["scope"]
+	if scope == "" {
+		scope = "repository:" + r.ref.repository + ":pull"
+	}
+	query.Set("scope", scope)
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("token service of %s returned %s", r.ref.registry, resp.Status)
+	}
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxRegistryResponse)).Decode(&token); err != nil {
+		return fmt.Errorf("invalid token from %s: %w", r.ref.registry, err)
+	}
+	r.token = token.Token
+	if r.token == "" {
+		r.token = token.AccessToken
+	}
+	if r.token == "" {
+		return fmt.Errorf("token service of %s returned no token", r.ref.registry)
+	}
+	return nil
+}
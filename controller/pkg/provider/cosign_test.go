@@ -0,0 +1,247 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// fakeRegistry serves an image and its cosign signature behind anonymous bearer tokens
+type fakeRegistry struct {
+	server    *httptest.Server
+	manifests map[string][]byte
+	blobs     map[string][]byte
+	requests  int
+}
+
+func sha256Digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func newFakeRegistry(t *testing.T) *fakeRegistry {
+	t.Helper()
+	r := &fakeRegistry{manifests: map[string][]byte{}, blobs: map[string][]byte{}}
+	r.server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r.requests++
+		if req.URL.Path == "/token" {
+			if req.URL.Query().Get("scope") != "repository:org/llm:pull" {
+				http.Error(w, "bad scope", http.StatusBadRequest)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]string{"token": "anonymous"})
+			return
+		}
+		if req.Header.Get("Authorization") != "Bearer anonymous" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="`+r.server.URL+`/token",service="registry"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		path := strings.TrimPrefix(req.URL.Path, "/v2/org/llm")
+		var body []byte
+		if ref, ok := strings.CutPrefix(path, "/manifests/"); ok {
+			body = r.manifests[ref]
+		} else if digest, ok := strings.CutPrefix(path, "/blobs/"); ok {
+			body = r.blobs[digest]
+		}
+		if body == nil {
+			http.NotFound(w, req)
+			return
+		}
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(r.server.Close)
+	return r
+}
+
+// push adds an image manifest tagged tag and returns its digest
+func (r *fakeRegistry) push(tag string) string {
+	manifest := []byte(`{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json","tag":"` + tag + `"}`)
+	digest := sha256Digest(manifest)
+	r.manifests[tag] = manifest
+	r.manifests[digest] = manifest
+	return digest
+}
+
+// sign adds a cosign signature of a payload claiming digest
+func (r *fakeRegistry) sign(t *testing.T, key *ecdsa.PrivateKey, digest string) {
+	t.Helper()
+	payload := []byte(`{"critical":{"identity":{"docker-reference":"org/llm"},"image":{"docker-manifest-digest":"` + digest + `"},"type":"cosign container image signature"},"optional":null}`)
+	hash := sha256.Sum256(payload)
+	sig, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	payloadDigest := sha256Digest(payload)
+	r.blobs[payloadDigest] = payload
+	manifest, err := json.Marshal(map[string]any{
+		"schemaVersion": 2,
+		"layers": []map[string]any{{
+			"mediaType":   "application/vnd.dev.cosign.simplesigning.v1+json",
+			"digest":      payloadDigest,
+			"annotations": map[string]string{cosignSignatureAnnotation: base64.StdEncoding.EncodeToString(sig)},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.manifests[strings.Replace(digest, ":", "-", 1)+".sig"] = manifest
+}
+
+func (r *fakeRegistry) image(ref string) string {
+	return strings.TrimPrefix(r.server.URL, "https://") + "/org/llm" + ref
+}
+
+func newTestKey(t *testing.T) (*ecdsa.PrivateKey, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func TestCosignVerifier(t *testing.T) {
+	ctx := context.Background()
+	registry := newFakeRegistry(t)
+	key, publicKey := newTestKey(t)
+	_, otherKey := newTestKey(t)
+
+	signed := registry.push("v1")
+	registry.sign(t, key, signed)
+	registry.push("unsigned")
+	// the signature of v2 claims the digest of v1, as if copied from it
+	copied := registry.push("v2")
+	registry.manifests[strings.Replace(copied, ":", "-", 1)+".sig"] = registry.manifests[strings.Replace(signed, ":", "-", 1)+".sig"]
+
+	v := NewCosignVerifier(registry.server.Client())
+	tests := []struct {
+		name    string
+		image   string
+		keys    []string
+		wantErr string
+	}{
+		{"signed tag", registry.image(":v1"), []string{publicKey}, ""},
+		{"signed digest", registry.image("@" + signed), []string{otherKey, publicKey}, ""},
+		{"other key", registry.image(":v1"), []string{otherKey}, "no signature"},
+		{"unsigned", registry.image(":unsigned"), []string{publicKey}, "no signature"},
+		{"signature of another digest", registry.image(":v2"), []string{publicKey}, "no signature"},
+		{"missing tag", registry.image(":v3"), []string{publicKey}, "404"},
+		{"invalid key", registry.image(":v1"), []string{"not a key"}, "public key 0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			digest, err := v.Verify(ctx, tt.image, tt.keys)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Verify failed: %v", err)
+				}
+				if digest != signed {
+					t.Errorf("Verify returned digest %s, want %s", digest, signed)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Verify error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCosignVerifierCache(t *testing.T) {
+	ctx := context.Background()
+	registry := newFakeRegistry(t)
+	key, publicKey := newTestKey(t)
+	registry.sign(t, key, registry.push("v1"))
+
+	now := time.Now()
+	v := NewCosignVerifier(registry.server.Client())
+	v.now = func() time.Time { return now }
+	if _, err := v.Verify(ctx, registry.image(":v1"), []string{publicKey}); err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	requests := registry.requests
+	if _, err := v.Verify(ctx, registry.image(":v1"), []string{publicKey}); err != nil {
+		t.Fatalf("cached Verify failed: %v", err)
+	}
+	if registry.requests != requests {
+		t.Errorf("cached Verify sent %d requests, want none", registry.requests-requests)
+	}
+
+	now = now.Add(cosignVerifiedTTL + time.Second)
+	if _, err := v.Verify(ctx, registry.image(":v1"), []string{publicKey}); err != nil {
+		t.Fatalf("Verify after the cache expired failed: %v", err)
+	}
+	if registry.requests == requests {
+		t.Error("Verify after the cache expired sent no requests")
+	}
+}
+
+func TestEnforceImagePolicyPinsVerifiedDigest(t *testing.T) {
+	ctx := context.Background()
+	registry := newFakeRegistry(t)
+	key, publicKey := newTestKey(t)
+	signed := registry.push("v1")
+	registry.sign(t, key, signed)
+
+	defaultVerifier := DefaultCosignVerifier
+	DefaultCosignVerifier = NewCosignVerifier(registry.server.Client())
+	t.Cleanup(func() { DefaultCosignVerifier = defaultVerifier })
+
+	c := newImagePolicyClient(t, &airunwayv1alpha1.ImagePolicySpec{
+		Cosign: &airunwayv1alpha1.CosignPolicy{PublicKeys: []string{publicKey}},
+	})
+	resource := policyResource(registry.image(":v1"))
+	msg, err := EnforceImagePolicy(ctx, c, "kuberay", []*unstructured.Unstructured{resource})
+	if err != nil || msg != "" {
+		t.Fatalf("signed image: got %q, %v, want no violation", msg, err)
+	}
+	containers, _, _ := unstructured.NestedSlice(resource.Object, "spec", "services", "VllmPrefillWorker", "extraPodSpec", "containers")
+	if got, want := containers[0].(map[string]interface{})["image"], registry.image(":v1@"+signed); got != want {
+		t.Errorf("got image %v, want it pinned to the verified digest %s", got, want)
+	}
+
+	// Moving the tag to an unsigned manifest does not change what already runs, and the
+	// new manifest is rejected once the cached digest expires
+	registry.manifests["v1"] = registry.manifests[registry.push("unsigned")]
+	DefaultCosignVerifier.now = func() time.Time { return time.Now().Add(cosignVerifiedTTL + time.Second) }
+	msg, err = EnforceImagePolicy(ctx, c, "kuberay", []*unstructured.Unstructured{policyResource(registry.image(":v1"))})
+	if err != nil || !strings.Contains(msg, "no valid cosign signature") {
+		t.Errorf("moved tag: got %q, %v, want a signature violation", msg, err)
+	}
+}
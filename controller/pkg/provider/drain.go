@@ -0,0 +1,91 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"time"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+const (
+	// StreamDrainDelay is how long a deleted model server pod of a deployment with
+	// spec.gateway.streaming keeps accepting requests before the engine is stopped, so the
+	// gateway sees it leave the InferencePool first
+	StreamDrainDelay = 10 * time.Second
+
+	// DefaultStreamDrainTimeout is how long open streams may run after StreamDrainDelay when
+	// spec.gateway.timeout is not set or disabled, matching the default streaming route timeout
+	DefaultStreamDrainTimeout = time.Hour
+)
+
+// StreamDrainEnabled reports whether the model server pods of md drain open streams
+// before they terminate, which is the case with spec.gateway.streaming
+func StreamDrainEnabled(md *airunwayv1alpha1.ModelDeployment) bool {
+	return md.Spec.Gateway != nil && md.Spec.Gateway.Streaming
+}
+
+// StreamDrainGracePeriod returns the termination grace period of the model server pods
+// of md in seconds: StreamDrainDelay plus the route timeout, so a stream started just
+// before the pod was deleted can run to the timeout
+func StreamDrainGracePeriod(md *airunwayv1alpha1.ModelDeployment) int64 {
+	timeout := DefaultStreamDrainTimeout
+	if gw := md.Spec.Gateway; gw != nil && gw.Timeout != nil && gw.Timeout.Duration > 0 {
+		timeout = gw.Timeout.Duration
+	}
+	return int64((StreamDrainDelay + timeout).Seconds())
+}
+
+// StreamDrainPreStop returns the preStop handler delaying the engine shutdown by
+// StreamDrainDelay as unstructured content
+func StreamDrainPreStop() map[string]interface{} {
+	return map[string]interface{}{
+		"sleep": map[string]interface{}{"seconds": int64(StreamDrainDelay.Seconds())},
+	}
+}
+
+// ApplyStreamDrainToPodSpec adds the drain grace period to an unstructured pod spec, and
+// the drain preStop handler to every container without one. It is a no-op without
+// spec.gateway.streaming.
+func ApplyStreamDrainToPodSpec(spec map[string]interface{}, md *airunwayv1alpha1.ModelDeployment) {
+	if !StreamDrainEnabled(md) || spec == nil {
+		return
+	}
+	spec["terminationGracePeriodSeconds"] = StreamDrainGracePeriod(md)
+	containers, _ := spec["containers"].([]interface{})
+	for _, c := range containers {
+		container, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		lifecycle, _ := container["lifecycle"].(map[string]interface{})
+		if lifecycle == nil {
+			lifecycle = map[string]interface{}{}
+			container["lifecycle"] = lifecycle
+		}
+		if _, ok := lifecycle["preStop"]; !ok {
+			lifecycle["preStop"] = StreamDrainPreStop()
+		}
+	}
+}
+
+// ApplyStreamDrainToPodTemplate applies ApplyStreamDrainToPodSpec to an unstructured pod
+// template with a spec map
+func ApplyStreamDrainToPodTemplate(template map[string]interface{}, md *airunwayv1alpha1.ModelDeployment) {
+	spec, _ := template["spec"].(map[string]interface{})
+	ApplyStreamDrainToPodSpec(spec, md)
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+func TestStreamDrainGracePeriod(t *testing.T) {
+	md := newChatTemplateMD(airunwayv1alpha1.EngineTypeVLLM, nil, "")
+	md.Spec.Gateway = &airunwayv1alpha1.GatewaySpec{Streaming: true}
+	if got := StreamDrainGracePeriod(md); got != 3610 {
+		t.Errorf("expected the default streaming timeout plus the delay, got %d", got)
+	}
+	md.Spec.Gateway.Timeout = &metav1.Duration{Duration: 10 * time.Minute}
+	if got := StreamDrainGracePeriod(md); got != 610 {
+		t.Errorf("expected spec.gateway.timeout plus the delay, got %d", got)
+	}
+	md.Spec.Gateway.Timeout = &metav1.Duration{}
+	if got := StreamDrainGracePeriod(md); got != 3610 {
+		t.Errorf("expected a disabled timeout to use the default, got %d", got)
+	}
+}
+
+func TestApplyStreamDrainToPodTemplate(t *testing.T) {
+	newTemplate := func() map[string]interface{} {
+		return map[string]interface{}{"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{"name": "engine"},
+				map[string]interface{}{"name": "sidecar", "lifecycle": map[string]interface{}{
+					"preStop": map[string]interface{}{"exec": map[string]interface{}{"command": []interface{}{"drain"}}},
+				}},
+			},
+		}}
+	}
+	md := newChatTemplateMD(airunwayv1alpha1.EngineTypeVLLM, nil, "")
+
+	template := newTemplate()
+	ApplyStreamDrainToPodTemplate(template, md)
+	spec := template["spec"].(map[string]interface{})
+	if _, ok := spec["terminationGracePeriodSeconds"]; ok {
+		t.Fatal("expected no grace period without spec.gateway.streaming")
+	}
+
+	md.Spec.Gateway = &airunwayv1alpha1.GatewaySpec{Streaming: true}
+	ApplyStreamDrainToPodTemplate(template, md)
+	if got := spec["terminationGracePeriodSeconds"]; got != int64(3610) {
+		t.Errorf("expected a 3610s grace period, got %v", got)
+	}
+	containers := spec["containers"].([]interface{})
+	engine := containers[0].(map[string]interface{})["lifecycle"].(map[string]interface{})["preStop"].(map[string]interface{})
+	if engine["sleep"].(map[string]interface{})["seconds"] != int64(10) {
+		t.Errorf("expected a 10s preStop sleep, got %v", engine)
+	}
+	sidecar := containers[1].(map[string]interface{})["lifecycle"].(map[string]interface{})["preStop"].(map[string]interface{})
+	if _, ok := sidecar["exec"]; !ok {
+		t.Errorf("expected an existing preStop handler to be kept, got %v", sidecar)
+	}
+}
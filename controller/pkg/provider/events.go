@@ -0,0 +1,193 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DefaultEventMirrorWindow is how recent an upstream event must be to be mirrored, so
+	// a provider restart does not replay old warnings
+	DefaultEventMirrorWindow = 10 * time.Minute
+
+	// EventTypeField is the field selector for the type of core/v1 Events. Clients that
+	// list Events from a cache must index it.
+	EventTypeField = "type"
+
+	// maxMirroredEvents caps the events mirrored per call, keeping the most recent
+	maxMirroredEvents = 5
+	// mirroredEventAction is the action of mirrored events
+	mirroredEventAction = "Upstream"
+)
+
+// MirroredEventReasons are the Warning event reasons mirrored from upstream resources and
+// their pods, covering scheduling, image pull, volume, and probe failures
+var MirroredEventReasons = []string{
+	"FailedScheduling",
+	"FailedCreate",
+	"FailedMount",
+	"FailedAttachVolume",
+	"Failed",
+	"BackOff",
+	"Unhealthy",
+	"Evicted",
+}
+
+// EventMirror re-emits Warning events about the resources of a ModelDeployment as events
+// on the ModelDeployment, so users who can only read ModelDeployments see why pods fail to
+// schedule, pull images, or pass probes. A resource belongs to a deployment when it is
+// named after it, i.e. its name is the deployment name or starts with the name and a dash,
+// which holds for upstream resources and the pods their operators create.
+type EventMirror struct {
+	// Reader lists core/v1 Events. Use an uncached reader, such as the manager's API
+	// reader, to avoid caching every Event in the cluster.
+	Reader client.Reader
+	// Recorder emits the mirrored events
+	Recorder events.EventRecorder
+	// Window is how recent an event must be, DefaultEventMirrorWindow when zero
+	Window time.Duration
+
+	mu sync.Mutex
+	// mirrored maps the UID of each mirrored event to its count and last time when mirrored
+	mirrored map[types.UID]mirroredEvent
+}
+
+type mirroredEvent struct {
+	count    int32
+	lastSeen time.Time
+}
+
+// NewEventMirror returns an EventMirror listing Events with reader and emitting them with
+// recorder
+func NewEventMirror(reader client.Reader, recorder events.EventRecorder) *EventMirror {
+	return &EventMirror{Reader: reader, Recorder: recorder}
+}
+
+// Mirror emits the most recent relevant upstream Warning events of md that were not
+// mirrored yet. An event that repeats, raising its count, is mirrored again.
+func (m *EventMirror) Mirror(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) error {
+	if m == nil || m.Reader == nil || m.Recorder == nil {
+		return nil
+	}
+
+	var list corev1.EventList
+	if err := m.Reader.List(ctx, &list, client.InNamespace(md.Namespace),
+		client.MatchingFields{EventTypeField: corev1.EventTypeWarning}); err != nil {
+		return fmt.Errorf("failed to list events: %w", err)
+	}
+
+	window := m.Window
+	if window == 0 {
+		window = DefaultEventMirrorWindow
+	}
+	now := time.Now()
+	since := now.Add(-window)
+	if created := md.CreationTimestamp.Time; created.After(since) {
+		since = created
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.mirrored == nil {
+		m.mirrored = map[types.UID]mirroredEvent{}
+	}
+	for uid, e := range m.mirrored {
+		if e.lastSeen.Before(now.Add(-2 * window)) {
+			delete(m.mirrored, uid)
+		}
+	}
+
+	var pending []corev1.Event
+	for _, e := range list.Items {
+		if e.Type != corev1.EventTypeWarning || !slices.Contains(MirroredEventReasons, e.Reason) ||
+			!belongsTo(md, e.InvolvedObject) || eventTime(e).Before(since) {
+			continue
+		}
+		if prev, ok := m.mirrored[e.UID]; ok && prev.count >= eventCount(e) {
+			continue
+		}
+		pending = append(pending, e)
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		return eventTime(pending[i]).After(eventTime(pending[j]))
+	})
+	// Older events beyond the cap are dropped rather than emitted out of order later
+	for _, e := range pending {
+		m.mirrored[e.UID] = mirroredEvent{count: eventCount(e), lastSeen: now}
+	}
+	if len(pending) > maxMirroredEvents {
+		pending = pending[:maxMirroredEvents]
+	}
+
+	// Emit oldest first so the ModelDeployment events read in order
+	for i := len(pending) - 1; i >= 0; i-- {
+		e := pending[i]
+		involved := e.InvolvedObject
+		note := fmt.Sprintf("%s %s: %s", involved.Kind, involved.Name, e.Message)
+		if len(note) > maxChangeNoteLength {
+			note = note[:maxChangeNoteLength-3] + "..."
+		}
+		m.Recorder.Eventf(md, &involved, corev1.EventTypeWarning, e.Reason, mirroredEventAction, "%s", note)
+	}
+	return nil
+}
+
+// belongsTo reports whether ref is a resource of md rather than md itself
+func belongsTo(md *airunwayv1alpha1.ModelDeployment, ref corev1.ObjectReference) bool {
+	if ref.Kind == "ModelDeployment" {
+		return false
+	}
+	if ref.Namespace != "" && ref.Namespace != md.Namespace {
+		return false
+	}
+	return ref.Name == md.Name || strings.HasPrefix(ref.Name, md.Name+"-")
+}
+
+// eventTime returns when e last occurred
+func eventTime(e corev1.Event) time.Time {
+	switch {
+	case e.Series != nil && !e.Series.LastObservedTime.IsZero():
+		return e.Series.LastObservedTime.Time
+	case !e.LastTimestamp.IsZero():
+		return e.LastTimestamp.Time
+	case !e.EventTime.IsZero():
+		return e.EventTime.Time
+	default:
+		return e.CreationTimestamp.Time
+	}
+}
+
+// eventCount returns how often e occurred
+func eventCount(e corev1.Event) int32 {
+	if e.Series != nil && e.Series.Count > 0 {
+		return e.Series.Count
+	}
+	return max(e.Count, 1)
+}
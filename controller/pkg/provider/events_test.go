@@ -0,0 +1,167 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newEvent(name, kind, involved, eventType, reason string, count int32, age time.Duration) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", UID: types.UID(name)},
+		InvolvedObject: corev1.ObjectReference{
+			Kind:      kind,
+			Name:      involved,
+			Namespace: "default",
+		},
+		Type:          eventType,
+		Reason:        reason,
+		Message:       reason + " on " + involved,
+		Count:         count,
+		LastTimestamp: metav1.NewTime(time.Now().Add(-age)),
+	}
+}
+
+func newEventClient(t *testing.T, objs ...client.Object) client.WithWatch {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithIndex(&corev1.Event{}, EventTypeField, func(obj client.Object) []string {
+			return []string{obj.(*corev1.Event).Type}
+		}).
+		Build()
+}
+
+func drainEvents(recorder *events.FakeRecorder) []string {
+	var got []string
+	for {
+		select {
+		case e := <-recorder.Events:
+			got = append(got, e)
+		default:
+			return got
+		}
+	}
+}
+
+func TestEventMirror(t *testing.T) {
+	md := &airunwayv1alpha1.ModelDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "default"},
+	}
+	scheduling := newEvent("scheduling", "Pod", "llama-worker-0", corev1.EventTypeWarning, "FailedScheduling", 1, time.Minute)
+	c := newEventClient(t,
+		scheduling,
+		newEvent("pull", "Pod", "llama-frontend-abc", corev1.EventTypeWarning, "BackOff", 3, 2*time.Minute),
+		newEvent("normal", "Pod", "llama-worker-0", corev1.EventTypeNormal, "Pulled", 1, time.Minute),
+		newEvent("irrelevant", "Pod", "llama-worker-0", corev1.EventTypeWarning, "DNSConfigForming", 1, time.Minute),
+		newEvent("other-deployment", "Pod", "llamafile-0", corev1.EventTypeWarning, "FailedScheduling", 1, time.Minute),
+		newEvent("own", "ModelDeployment", "llama", corev1.EventTypeWarning, "Failed", 1, time.Minute),
+		newEvent("old", "Workspace", "llama", corev1.EventTypeWarning, "Failed", 1, time.Hour),
+	)
+	recorder := events.NewFakeRecorder(10)
+	mirror := NewEventMirror(c, recorder)
+
+	if err := mirror.Mirror(context.Background(), md); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := drainEvents(recorder)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 mirrored events, got %v", got)
+	}
+	if !strings.Contains(got[0], "BackOff") || !strings.Contains(got[0], "Pod llama-frontend-abc: BackOff on llama-frontend-abc") {
+		t.Errorf("expected the older BackOff event first, got %q", got[0])
+	}
+	if !strings.HasPrefix(got[1], "Warning FailedScheduling") {
+		t.Errorf("expected a FailedScheduling warning, got %q", got[1])
+	}
+
+	// Events already mirrored are not emitted again until they repeat
+	if err := mirror.Mirror(context.Background(), md); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := drainEvents(recorder); len(got) != 0 {
+		t.Errorf("expected no events on the second call, got %v", got)
+	}
+
+	scheduling.Count = 2
+	scheduling.LastTimestamp = metav1.Now()
+	if err := c.Update(context.Background(), scheduling); err != nil {
+		t.Fatalf("failed to update event: %v", err)
+	}
+	if err := mirror.Mirror(context.Background(), md); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := drainEvents(recorder); len(got) != 1 || !strings.Contains(got[0], "FailedScheduling") {
+		t.Errorf("expected the repeated FailedScheduling event, got %v", got)
+	}
+}
+
+func TestEventMirrorCapsEvents(t *testing.T) {
+	md := &airunwayv1alpha1.ModelDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "default"},
+	}
+	var objs []client.Object
+	for i := 0; i < maxMirroredEvents+3; i++ {
+		objs = append(objs, newEvent(fmt.Sprintf("unhealthy-%d", i), "Pod", fmt.Sprintf("llama-%d", i),
+			corev1.EventTypeWarning, "Unhealthy", 1, time.Duration(i)*time.Second))
+	}
+	recorder := events.NewFakeRecorder(20)
+	mirror := NewEventMirror(newEventClient(t, objs...), recorder)
+
+	if err := mirror.Mirror(context.Background(), md); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := drainEvents(recorder)
+	if len(got) != maxMirroredEvents {
+		t.Fatalf("expected %d events, got %d", maxMirroredEvents, len(got))
+	}
+	if !strings.Contains(got[len(got)-1], "Pod llama-0:") {
+		t.Errorf("expected the most recent event last, got %q", got[len(got)-1])
+	}
+
+	if err := mirror.Mirror(context.Background(), md); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := drainEvents(recorder); len(got) != 0 {
+		t.Errorf("expected dropped events not to be emitted later, got %v", got)
+	}
+}
+
+func TestEventMirrorNil(t *testing.T) {
+	var mirror *EventMirror
+	if err := mirror.Mirror(context.Background(), &airunwayv1alpha1.ModelDeployment{}); err != nil {
+		t.Errorf("expected a nil mirror to be a no-op, got %v", err)
+	}
+}
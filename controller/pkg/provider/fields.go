@@ -0,0 +1,110 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"slices"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+// Spec fields a provider transformer may honor or drop. Providers publish the ones they
+// honor in status.supportedFields of their InferenceProviderConfig.
+const (
+	FieldImage               = "spec.image"
+	FieldEnv                 = "spec.env"
+	FieldPodTemplate         = "spec.podTemplate"
+	FieldHuggingFaceToken    = "spec.secrets.huggingFaceToken"
+	FieldSecretsRollout      = "spec.secrets.rolloutOnChange"
+	FieldIdentity            = "spec.identity"
+	FieldMultinode           = "spec.multinode"
+	FieldNodeSelector        = "spec.nodeSelector"
+	FieldTolerations         = "spec.tolerations"
+	FieldGangScheduling      = "spec.scheduling.gang"
+	FieldCPUPinning          = "spec.resources.cpuPinning"
+	FieldStorageVolumes      = "spec.model.storage.volumes"
+	FieldChatTemplate        = "spec.model.chatTemplate"
+	FieldTokenizer           = "spec.model.tokenizer"
+	FieldServedName          = "spec.model.servedName"
+	FieldAdapters            = "spec.model.adapters"
+	FieldLoadFormat          = "spec.model.loadFormat"
+	FieldContextLength       = "spec.engine.contextLength"
+	FieldEngineArgs          = "spec.engine.args"
+	FieldTrustRemoteCode     = "spec.engine.trustRemoteCode"
+	FieldEnforceEager        = "spec.engine.enforceEager"
+	FieldEnablePrefixCaching = "spec.engine.enablePrefixCaching"
+	FieldLogSink             = "spec.observability.logSink"
+)
+
+// specFields reports, for each field a provider may drop, whether a ModelDeployment sets it.
+// The order is the order IgnoredFields reports fields in.
+var specFields = []struct {
+	path  string
+	isSet func(md *airunwayv1alpha1.ModelDeployment) bool
+}{
+	{FieldImage, func(md *airunwayv1alpha1.ModelDeployment) bool { return md.Spec.Image != "" }},
+	{FieldEnv, func(md *airunwayv1alpha1.ModelDeployment) bool { return len(md.Spec.Env) > 0 }},
+	{FieldPodTemplate, func(md *airunwayv1alpha1.ModelDeployment) bool {
+		return md.Spec.PodTemplate != nil && md.Spec.PodTemplate.Metadata != nil &&
+			(len(md.Spec.PodTemplate.Metadata.Labels) > 0 || len(md.Spec.PodTemplate.Metadata.Annotations) > 0)
+	}},
+	{FieldHuggingFaceToken, func(md *airunwayv1alpha1.ModelDeployment) bool {
+		return md.Spec.Secrets != nil && md.Spec.Secrets.HuggingFaceToken != ""
+	}},
+	{FieldSecretsRollout, func(md *airunwayv1alpha1.ModelDeployment) bool {
+		return md.Spec.Secrets != nil && md.Spec.Secrets.RolloutOnChange
+	}},
+	{FieldIdentity, func(md *airunwayv1alpha1.ModelDeployment) bool { return md.Spec.Identity != nil }},
+	{FieldMultinode, func(md *airunwayv1alpha1.ModelDeployment) bool { return md.Spec.Multinode != nil }},
+	{FieldNodeSelector, func(md *airunwayv1alpha1.ModelDeployment) bool { return len(md.Spec.NodeSelector) > 0 }},
+	{FieldTolerations, func(md *airunwayv1alpha1.ModelDeployment) bool { return len(md.Spec.Tolerations) > 0 }},
+	{FieldGangScheduling, func(md *airunwayv1alpha1.ModelDeployment) bool {
+		return md.Spec.Scheduling != nil && md.Spec.Scheduling.Gang
+	}},
+	{FieldCPUPinning, func(md *airunwayv1alpha1.ModelDeployment) bool {
+		return md.Spec.Resources != nil && md.Spec.Resources.CPUPinning != nil
+	}},
+	{FieldStorageVolumes, func(md *airunwayv1alpha1.ModelDeployment) bool {
+		return md.Spec.Model.Storage != nil && len(md.Spec.Model.Storage.Volumes) > 0
+	}},
+	{FieldChatTemplate, func(md *airunwayv1alpha1.ModelDeployment) bool { return md.Spec.Model.ChatTemplate != nil }},
+	{FieldTokenizer, func(md *airunwayv1alpha1.ModelDeployment) bool { return md.Spec.Model.Tokenizer != "" }},
+	{FieldServedName, func(md *airunwayv1alpha1.ModelDeployment) bool { return md.Spec.Model.ServedName != "" }},
+	{FieldAdapters, func(md *airunwayv1alpha1.ModelDeployment) bool { return len(md.Spec.Model.Adapters) > 0 }},
+	{FieldLoadFormat, func(md *airunwayv1alpha1.ModelDeployment) bool { return md.Spec.Model.LoadFormat != "" }},
+	{FieldContextLength, func(md *airunwayv1alpha1.ModelDeployment) bool { return md.Spec.Engine.ContextLength != nil }},
+	{FieldEngineArgs, func(md *airunwayv1alpha1.ModelDeployment) bool { return len(md.Spec.Engine.Args) > 0 }},
+	{FieldTrustRemoteCode, func(md *airunwayv1alpha1.ModelDeployment) bool { return md.Spec.Engine.TrustRemoteCode }},
+	{FieldEnforceEager, func(md *airunwayv1alpha1.ModelDeployment) bool { return md.Spec.Engine.EnforceEager }},
+	{FieldEnablePrefixCaching, func(md *airunwayv1alpha1.ModelDeployment) bool { return md.Spec.Engine.EnablePrefixCaching }},
+	{FieldLogSink, func(md *airunwayv1alpha1.ModelDeployment) bool {
+		return md.Spec.Observability != nil && md.Spec.Observability.LogSink != nil
+	}},
+}
+
+// IgnoredFields returns the fields md sets that are missing from supported, the fields a
+// provider publishes in status.supportedFields. Fields a provider rejects with an error
+// are left out of supported, since the deployment fails instead of dropping them.
+func IgnoredFields(md *airunwayv1alpha1.ModelDeployment, supported []string) []string {
+	var ignored []string
+	for _, f := range specFields {
+		if f.isSet(md) && !slices.Contains(supported, f.path) {
+			ignored = append(ignored, f.path)
+		}
+	}
+	return ignored
+}
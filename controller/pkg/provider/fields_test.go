@@ -0,0 +1,47 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+func TestIgnoredFields(t *testing.T) {
+	md := newChatTemplateMD(airunwayv1alpha1.EngineTypeVLLM, nil, "")
+	supported := []string{FieldEnv, FieldTolerations}
+	if got := IgnoredFields(md, supported); got != nil {
+		t.Errorf("expected no ignored fields without optional fields, got %v", got)
+	}
+
+	md.Spec.Env = []corev1.EnvVar{{Name: "A", Value: "b"}}
+	md.Spec.Tolerations = []corev1.Toleration{{Key: "gpu", Operator: corev1.TolerationOpExists}}
+	if got := IgnoredFields(md, supported); got != nil {
+		t.Errorf("expected supported fields not to be reported, got %v", got)
+	}
+
+	md.Spec.NodeSelector = map[string]string{"pool": "gpu"}
+	md.Spec.Engine.EnforceEager = true
+	md.Spec.PodTemplate = &airunwayv1alpha1.PodTemplateSpec{}
+	if got := IgnoredFields(md, supported); !slices.Equal(got, []string{FieldNodeSelector, FieldEnforceEager}) {
+		t.Errorf("expected nodeSelector and enforceEager, got %v", got)
+	}
+}
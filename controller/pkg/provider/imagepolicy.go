@@ -0,0 +1,203 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ReasonImagePolicyViolation is the ResourceCreated reason of a deployment whose model
+	// server image violates the spec.imagePolicy of its provider config
+	ReasonImagePolicyViolation = "ImagePolicyViolation"
+
+	// ImagePolicyRecheckInterval is how often a deployment violating the image policy is
+	// checked again, e.g. after its image was signed
+	ImagePolicyRecheckInterval = 5 * time.Minute
+)
+
+// cosignClient sends the registry requests of signature verification, bounded so an
+// unresponsive registry does not hold up reconciles
+var cosignClient = &http.Client{Timeout: 10 * time.Second}
+
+// DefaultCosignVerifier verifies the signatures required by spec.imagePolicy.cosign
+var DefaultCosignVerifier = NewCosignVerifier(cosignClient)
+
+// EnforceImagePolicy checks every container image of resources, the rendered resources of
+// a deployment, against the spec.imagePolicy of a provider's InferenceProviderConfig. This
+// covers the model server image along with component, sidecar and init container images
+// and images set through provider overrides. Images verified with spec.imagePolicy.cosign
+// are rewritten to the digest that was verified, so the kubelet pulls the signed manifest
+// even if the tag moves. It returns why an image violates the policy, or an empty string
+// when all pass or there is no policy. Signatures that cannot be verified, e.g. while the
+// registry is unreachable, violate the policy.
+func EnforceImagePolicy(ctx context.Context, c client.Reader, providerConfigName string, resources []*unstructured.Unstructured) (string, error) {
+	config := &airunwayv1alpha1.InferenceProviderConfig{}
+	if err := c.Get(ctx, client.ObjectKey{Name: providerConfigName}, config); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	policy := config.Spec.ImagePolicy
+	if policy == nil {
+		return "", nil
+	}
+
+	// pinned maps each image to its pinned reference, so repeated images are checked once
+	pinned := map[string]string{}
+	var violation string
+	for _, resource := range resources {
+		walkImages(resource.Object, func(image string) string {
+			if violation != "" {
+				return image
+			}
+			if ref, ok := pinned[image]; ok {
+				return ref
+			}
+			ref, msg := checkImage(ctx, policy, image)
+			if msg != "" {
+				violation = msg
+				return image
+			}
+			pinned[image] = ref
+			return ref
+		})
+		if violation != "" {
+			return violation, nil
+		}
+	}
+	return "", nil
+}
+
+// checkImage checks one image against policy. It returns the reference to run, pinned to
+// the verified digest with a cosign policy, or why the image violates the policy.
+func checkImage(ctx context.Context, policy *airunwayv1alpha1.ImagePolicySpec, image string) (string, string) {
+	ref, err := parseImageReference(image)
+	if err != nil {
+		return "", fmt.Sprintf("Image %s is invalid: %v", image, err)
+	}
+	if len(policy.AllowedRegistries) > 0 && !registryAllowed(ref, policy.AllowedRegistries) {
+		return "", fmt.Sprintf("Image %s is not from an allowed registry (%s)", image, strings.Join(policy.AllowedRegistries, ", "))
+	}
+	if policy.Cosign == nil {
+		return image, ""
+	}
+	digest, err := DefaultCosignVerifier.Verify(ctx, image, policy.Cosign.PublicKeys)
+	if err != nil {
+		return "", fmt.Sprintf("Image %s has no valid cosign signature: %v", image, err)
+	}
+	if ref.digest != "" {
+		return image, ""
+	}
+	return image + "@" + digest, ""
+}
+
+// walkImages replaces the string value of every image field in obj with the result of fn.
+// Container images are the only image fields of the resources providers render.
+func walkImages(obj interface{}, fn func(string) string) {
+	switch v := obj.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if image, ok := value.(string); ok && key == "image" && image != "" {
+				v[key] = fn(image)
+				continue
+			}
+			walkImages(value, fn)
+		}
+	case []interface{}:
+		for _, item := range v {
+			walkImages(item, fn)
+		}
+	}
+}
+
+// imageReference is a parsed container image reference
+type imageReference struct {
+	// registry is the registry host, docker.io for Docker Hub
+	registry string
+	// repository is the repository in the registry, e.g. library/ubuntu
+	repository string
+	tag        string
+	digest     string
+}
+
+// name returns the registry and repository of the reference
+func (r imageReference) name() string {
+	return r.registry + "/" + r.repository
+}
+
+// parseImageReference parses an image reference the way container runtimes do: the first
+// path component is the registry when it contains a dot or a port, or is localhost, and
+// images without one are on Docker Hub, in the library namespace when they have no path.
+func parseImageReference(image string) (imageReference, error) {
+	var ref imageReference
+	name := strings.TrimSpace(image)
+	if before, after, ok := strings.Cut(name, "@"); ok {
+		name, ref.digest = before, after
+		if !strings.HasPrefix(ref.digest, "sha256:") {
+			return ref, fmt.Errorf("unsupported digest %q", ref.digest)
+		}
+	}
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name, ref.tag = name[:i], name[i+1:]
+	}
+	if ref.tag == "" && ref.digest == "" {
+		ref.tag = "latest"
+	}
+	first, rest, ok := strings.Cut(name, "/")
+	if ok && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		ref.registry, ref.repository = first, rest
+	} else {
+		ref.registry, ref.repository = "docker.io", name
+	}
+	if ref.registry == "index.docker.io" {
+		ref.registry = "docker.io"
+	}
+	if ref.registry == "docker.io" && !strings.Contains(ref.repository, "/") {
+		ref.repository = "library/" + ref.repository
+	}
+	if ref.repository == "" || strings.HasSuffix(ref.repository, "/") {
+		return ref, fmt.Errorf("missing repository")
+	}
+	return ref, nil
+}
+
+// registryAllowed reports whether the registry and repository of ref match one of the
+// allowed registries or repository prefixes
+func registryAllowed(ref imageReference, allowed []string) bool {
+	name := ref.name()
+	for _, entry := range allowed {
+		entry = strings.TrimSuffix(strings.TrimSpace(entry), "/")
+		if rest, ok := strings.CutPrefix(entry, "index.docker.io"); ok {
+			entry = "docker.io" + rest
+		}
+		if entry != "" && (name == entry || strings.HasPrefix(name, entry+"/")) {
+			return true
+		}
+	}
+	return false
+}
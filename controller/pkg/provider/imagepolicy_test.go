@@ -0,0 +1,145 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestParseImageReference(t *testing.T) {
+	tests := []struct {
+		image string
+		want  imageReference
+	}{
+		{"ubuntu", imageReference{registry: "docker.io", repository: "library/ubuntu", tag: "latest"}},
+		{"vllm/vllm-openai:v0.11.0", imageReference{registry: "docker.io", repository: "vllm/vllm-openai", tag: "v0.11.0"}},
+		{"index.docker.io/rayproject/ray-llm:2.54.0", imageReference{registry: "docker.io", repository: "rayproject/ray-llm", tag: "2.54.0"}},
+		{"nvcr.io/nvidia/ai-dynamo/vllm-runtime:0.7.0", imageReference{registry: "nvcr.io", repository: "nvidia/ai-dynamo/vllm-runtime", tag: "0.7.0"}},
+		{"localhost:5000/llm@sha256:abc", imageReference{registry: "localhost:5000", repository: "llm", digest: "sha256:abc"}},
+		{"ghcr.io/org/llm:v1@sha256:abc", imageReference{registry: "ghcr.io", repository: "org/llm", tag: "v1", digest: "sha256:abc"}},
+	}
+	for _, tt := range tests {
+		got, err := parseImageReference(tt.image)
+		if err != nil {
+			t.Errorf("parseImageReference(%q) failed: %v", tt.image, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseImageReference(%q) = %+v, want %+v", tt.image, got, tt.want)
+		}
+	}
+
+	for _, image := range []string{"ghcr.io/", "llm@md5:abc"} {
+		if _, err := parseImageReference(image); err == nil {
+			t.Errorf("parseImageReference(%q) succeeded, want error", image)
+		}
+	}
+}
+
+func TestRegistryAllowed(t *testing.T) {
+	allowed := []string{"nvcr.io/nvidia/", "docker.io/vllm", "index.docker.io/rayproject"}
+	tests := []struct {
+		image string
+		want  bool
+	}{
+		{"nvcr.io/nvidia/ai-dynamo/vllm-runtime:0.7.0", true},
+		{"vllm/vllm-openai:v0.11.0", true},
+		{"rayproject/ray-llm:2.54.0", true},
+		{"docker.io/vllm-fork/vllm-openai", false},
+		{"nvcr.io/other/image", false},
+		{"ubuntu", false},
+		{"evil.io/nvcr.io/nvidia/image", false},
+	}
+	for _, tt := range tests {
+		ref, err := parseImageReference(tt.image)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := registryAllowed(ref, allowed); got != tt.want {
+			t.Errorf("registryAllowed(%q) = %v, want %v", tt.image, got, tt.want)
+		}
+	}
+}
+
+func newImagePolicyClient(t *testing.T, policy *airunwayv1alpha1.ImagePolicySpec) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := airunwayv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	config := &airunwayv1alpha1.InferenceProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "kuberay"},
+		Spec:       airunwayv1alpha1.InferenceProviderConfigSpec{ImagePolicy: policy},
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(config).Build()
+}
+
+func policyResource(images ...string) *unstructured.Unstructured {
+	containers := make([]interface{}, 0, len(images))
+	for _, image := range images {
+		containers = append(containers, map[string]interface{}{"name": "main", "image": image})
+	}
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "nvidia.com/v1alpha1",
+		"kind":       "DynamoGraphDeployment",
+		"spec": map[string]interface{}{
+			"services": map[string]interface{}{
+				"VllmPrefillWorker": map[string]interface{}{
+					"extraPodSpec": map[string]interface{}{"containers": containers},
+				},
+			},
+		},
+	}}
+}
+
+func TestEnforceImagePolicy(t *testing.T) {
+	ctx := context.Background()
+	policy := &airunwayv1alpha1.ImagePolicySpec{AllowedRegistries: []string{"docker.io/rayproject"}}
+	c := newImagePolicyClient(t, policy)
+
+	allowed := policyResource("rayproject/ray-llm:2.54.0")
+	msg, err := EnforceImagePolicy(ctx, c, "kuberay", []*unstructured.Unstructured{allowed})
+	if err != nil || msg != "" {
+		t.Errorf("allowed image: got %q, %v, want no violation", msg, err)
+	}
+
+	// A component or sidecar image is checked like the model server image
+	resources := []*unstructured.Unstructured{allowed, policyResource("rayproject/ray-llm:2.54.0", "evil.io/ray-llm:2.54.0")}
+	msg, err = EnforceImagePolicy(ctx, c, "kuberay", resources)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(msg, "evil.io/ray-llm:2.54.0") || !strings.Contains(msg, "allowed registry") {
+		t.Errorf("sidecar from another registry: got %q, want a registry violation", msg)
+	}
+
+	if msg, err := EnforceImagePolicy(ctx, c, "missing", resources); err != nil || msg != "" {
+		t.Errorf("missing provider config: got %q, %v, want no violation", msg, err)
+	}
+	if msg, err := EnforceImagePolicy(ctx, newImagePolicyClient(t, nil), "kuberay", resources); err != nil || msg != "" {
+		t.Errorf("no policy: got %q, %v, want no violation", msg, err)
+	}
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DefaultImageUpgradeInterval is how long a deployment counts as upgrading after it moved
+	// to a new default image when spec.imageUpgrade sets no interval
+	DefaultImageUpgradeInterval = 5 * time.Minute
+
+	// ReasonWaitingForCanary is the ImageUpgradePending reason while the canary namespaces
+	// have not finished upgrading
+	ReasonWaitingForCanary = "WaitingForCanary"
+	// ReasonMaxConcurrentUpgrades is the ImageUpgradePending reason while
+	// spec.imageUpgrade.maxConcurrent deployments are upgrading
+	ReasonMaxConcurrentUpgrades = "MaxConcurrentUpgrades"
+)
+
+// DefaultImageFunc returns the default image a provider transformer builds a deployment
+// with, or an empty string when spec.image is set
+type DefaultImageFunc func(md *airunwayv1alpha1.ModelDeployment) string
+
+// HoldImageUpgrade applies the spec.imageUpgrade of a provider's InferenceProviderConfig to
+// md. It returns the deployment to transform and the default image it builds with: md and
+// its default image when md may roll, or a copy pinned through spec.image to the previous
+// default image while the upgrade waits for its turn. The ImageUpgradePending condition of
+// md reports the wait.
+//
+// A change of default image only counts as an upgrade when the repository stays the same,
+// e.g. a new tag, so switching engines is never held.
+func HoldImageUpgrade(ctx context.Context, c client.Reader, providerConfigName string, md *airunwayv1alpha1.ModelDeployment, defaultImage DefaultImageFunc) (*airunwayv1alpha1.ModelDeployment, string, error) {
+	image := defaultImage(md)
+	reason, message, err := imageUpgradeHold(ctx, c, providerConfigName, md, defaultImage)
+	if err != nil || reason == "" {
+		meta.RemoveStatusCondition(&md.Status.Conditions, airunwayv1alpha1.ConditionTypeImageUpgradePending)
+		return md, image, err
+	}
+
+	meta.SetStatusCondition(&md.Status.Conditions, metav1.Condition{
+		Type:               airunwayv1alpha1.ConditionTypeImageUpgradePending,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: md.Generation,
+		Reason:             reason,
+		Message:            fmt.Sprintf("Keeping %s rather than %s: %s", md.Status.Provider.DefaultImage, image, message),
+	})
+	pinned := md.DeepCopy()
+	pinned.Spec.Image = md.Status.Provider.DefaultImage
+	return pinned, md.Status.Provider.DefaultImage, nil
+}
+
+// RecordDefaultImage records in status.provider the default image the resources of md were
+// applied with. The time is only recorded when the image replaces a previous default image,
+// so new deployments do not count as upgrading.
+func RecordDefaultImage(md *airunwayv1alpha1.ModelDeployment, image string) {
+	status := md.Status.Provider
+	if status == nil || status.DefaultImage == image {
+		return
+	}
+	status.DefaultImageTime = nil
+	if status.DefaultImage != "" && image != "" {
+		now := metav1.Now()
+		status.DefaultImageTime = &now
+	}
+	status.DefaultImage = image
+}
+
+// imageUpgradeHold returns the reason and message for holding md on its previous default
+// image, or an empty reason when it may roll
+func imageUpgradeHold(ctx context.Context, c client.Reader, providerConfigName string, md *airunwayv1alpha1.ModelDeployment, defaultImage DefaultImageFunc) (string, string, error) {
+	if md.Status.Provider == nil || !upgradePending(md, defaultImage(md)) {
+		return "", "", nil
+	}
+	config := &airunwayv1alpha1.InferenceProviderConfig{}
+	if err := c.Get(ctx, client.ObjectKey{Name: providerConfigName}, config); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", "", nil
+		}
+		return "", "", err
+	}
+	policy := config.Spec.ImageUpgrade
+	if policy == nil {
+		return "", "", nil
+	}
+	interval := DefaultImageUpgradeInterval
+	if policy.Interval != nil {
+		interval = policy.Interval.Duration
+	}
+	maxConcurrent := max(policy.MaxConcurrent, 1)
+
+	var mdList airunwayv1alpha1.ModelDeploymentList
+	if err := c.List(ctx, &mdList); err != nil {
+		return "", "", fmt.Errorf("failed to list ModelDeployments: %w", err)
+	}
+	var canaries, inProgress []string
+	canary := len(policy.CanaryNamespaces) == 0 || slices.Contains(policy.CanaryNamespaces, md.Namespace)
+	for i := range mdList.Items {
+		other := &mdList.Items[i]
+		if other.UID == md.UID || other.Status.Provider == nil || other.Status.Provider.Name != md.Status.Provider.Name {
+			continue
+		}
+		key := other.Namespace + "/" + other.Name
+		image := defaultImage(other)
+		isUpgrading := upgrading(other, image, interval)
+		if isUpgrading {
+			inProgress = append(inProgress, key)
+		}
+		if !canary && slices.Contains(policy.CanaryNamespaces, other.Namespace) && (isUpgrading || upgradePending(other, image)) {
+			canaries = append(canaries, key)
+		}
+	}
+	if len(canaries) > 0 {
+		return ReasonWaitingForCanary, fmt.Sprintf("waiting for the canary deployments %s", strings.Join(canaries, ", ")), nil
+	}
+	if int32(len(inProgress)) >= maxConcurrent {
+		return ReasonMaxConcurrentUpgrades, fmt.Sprintf("%d of %d concurrent upgrades in progress (%s)",
+			len(inProgress), maxConcurrent, strings.Join(inProgress, ", ")), nil
+	}
+	return "", "", nil
+}
+
+// upgradePending reports whether md runs an older tag of its default image
+func upgradePending(md *airunwayv1alpha1.ModelDeployment, image string) bool {
+	if md.Status.Provider == nil {
+		return false
+	}
+	current := md.Status.Provider.DefaultImage
+	return current != "" && image != "" && current != image && imageRepository(current) == imageRepository(image)
+}
+
+// upgrading reports whether md moved from a previous default image to image, and is not
+// Running on it yet or moved less than interval ago
+func upgrading(md *airunwayv1alpha1.ModelDeployment, image string, interval time.Duration) bool {
+	status := md.Status.Provider
+	if status == nil || status.DefaultImageTime == nil || status.DefaultImage == "" || status.DefaultImage != image {
+		return false
+	}
+	return md.Status.Phase != airunwayv1alpha1.DeploymentPhaseRunning || time.Since(status.DefaultImageTime.Time) < interval
+}
+
+// imageRepository returns an image reference without its tag or digest
+func imageRepository(image string) string {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+	return image
+}
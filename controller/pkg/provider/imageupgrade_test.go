@@ -0,0 +1,184 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	oldImage = "rayproject/ray-llm:2.54.0"
+	newImage = "rayproject/ray-llm:2.55.0"
+)
+
+func defaultTestImage(md *airunwayv1alpha1.ModelDeployment) string {
+	if md.Spec.Image != "" {
+		return ""
+	}
+	return newImage
+}
+
+func upgradeMD(name, namespace, image string, phase airunwayv1alpha1.DeploymentPhase) *airunwayv1alpha1.ModelDeployment {
+	return &airunwayv1alpha1.ModelDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, UID: types.UID(namespace + "/" + name)},
+		Status: airunwayv1alpha1.ModelDeploymentStatus{
+			Phase:    phase,
+			Provider: &airunwayv1alpha1.ProviderStatus{Name: "kuberay", DefaultImage: image},
+		},
+	}
+}
+
+func newImageUpgradeClient(t *testing.T, policy *airunwayv1alpha1.ImageUpgradeSpec, objs ...client.Object) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := airunwayv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	config := &airunwayv1alpha1.InferenceProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "kuberay"},
+		Spec:       airunwayv1alpha1.InferenceProviderConfigSpec{ImageUpgrade: policy},
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(objs, config)...).Build()
+}
+
+func TestHoldImageUpgrade(t *testing.T) {
+	ctx := context.Background()
+	recent := metav1.NewTime(time.Now())
+	upgraded := upgradeMD("upgraded", "team-a", newImage, airunwayv1alpha1.DeploymentPhaseRunning)
+	upgraded.Status.Provider.DefaultImageTime = &recent
+
+	md := upgradeMD("llama", "team-b", oldImage, airunwayv1alpha1.DeploymentPhaseRunning)
+	c := newImageUpgradeClient(t, &airunwayv1alpha1.ImageUpgradeSpec{MaxConcurrent: 1}, upgraded)
+	target, image, err := HoldImageUpgrade(ctx, c, "kuberay", md, defaultTestImage)
+	if err != nil {
+		t.Fatalf("HoldImageUpgrade failed: %v", err)
+	}
+	if image != oldImage || target.Spec.Image != oldImage || md.Spec.Image != "" {
+		t.Errorf("expected a copy pinned to %s, got %q, %q", oldImage, image, target.Spec.Image)
+	}
+	cond := meta.FindStatusCondition(md.Status.Conditions, airunwayv1alpha1.ConditionTypeImageUpgradePending)
+	if cond == nil || cond.Reason != ReasonMaxConcurrentUpgrades {
+		t.Errorf("expected ImageUpgradePending/%s, got %+v", ReasonMaxConcurrentUpgrades, cond)
+	}
+
+	// Once the interval has passed, the next deployment rolls
+	c = newImageUpgradeClient(t, &airunwayv1alpha1.ImageUpgradeSpec{Interval: &metav1.Duration{}}, upgraded)
+	target, image, err = HoldImageUpgrade(ctx, c, "kuberay", md, defaultTestImage)
+	if err != nil || target != md || image != newImage {
+		t.Errorf("expected md to roll to %s, got %q, %v", newImage, image, err)
+	}
+	if meta.FindStatusCondition(md.Status.Conditions, airunwayv1alpha1.ConditionTypeImageUpgradePending) != nil {
+		t.Error("expected the ImageUpgradePending condition to be removed")
+	}
+
+	// Without a policy, deployments roll at once
+	c = newImageUpgradeClient(t, nil, upgraded)
+	if target, _, err := HoldImageUpgrade(ctx, c, "kuberay", md, defaultTestImage); err != nil || target != md {
+		t.Errorf("expected md to roll without a policy, got %v", err)
+	}
+}
+
+func TestHoldImageUpgradeCanaryNamespaces(t *testing.T) {
+	ctx := context.Background()
+	canary := upgradeMD("canary", "staging", oldImage, airunwayv1alpha1.DeploymentPhaseRunning)
+	md := upgradeMD("llama", "prod", oldImage, airunwayv1alpha1.DeploymentPhaseRunning)
+	policy := &airunwayv1alpha1.ImageUpgradeSpec{MaxConcurrent: 5, CanaryNamespaces: []string{"staging"}}
+
+	c := newImageUpgradeClient(t, policy, canary)
+	if target, _, err := HoldImageUpgrade(ctx, c, "kuberay", md, defaultTestImage); err != nil || target == md {
+		t.Fatalf("expected prod to wait for the canary, got %v", err)
+	}
+	cond := meta.FindStatusCondition(md.Status.Conditions, airunwayv1alpha1.ConditionTypeImageUpgradePending)
+	if cond == nil || cond.Reason != ReasonWaitingForCanary {
+		t.Errorf("expected ImageUpgradePending/%s, got %+v", ReasonWaitingForCanary, cond)
+	}
+	if target, _, err := HoldImageUpgrade(ctx, c, "kuberay", canary, defaultTestImage); err != nil || target != canary {
+		t.Errorf("expected the canary to roll, got %v", err)
+	}
+
+	// A canary that failed on the new image halts the rollout
+	failed := metav1.NewTime(time.Now().Add(-time.Hour))
+	canary.Status.Provider.DefaultImage = newImage
+	canary.Status.Provider.DefaultImageTime = &failed
+	canary.Status.Phase = airunwayv1alpha1.DeploymentPhaseFailed
+	c = newImageUpgradeClient(t, policy, canary)
+	if target, _, err := HoldImageUpgrade(ctx, c, "kuberay", md, defaultTestImage); err != nil || target == md {
+		t.Errorf("expected prod to wait for the failed canary, got %v", err)
+	}
+
+	canary.Status.Phase = airunwayv1alpha1.DeploymentPhaseRunning
+	c = newImageUpgradeClient(t, policy, canary)
+	if target, _, err := HoldImageUpgrade(ctx, c, "kuberay", md, defaultTestImage); err != nil || target != md {
+		t.Errorf("expected prod to roll after the canary, got %v", err)
+	}
+}
+
+func TestHoldImageUpgradeIgnoresOtherChanges(t *testing.T) {
+	ctx := context.Background()
+	c := newImageUpgradeClient(t, &airunwayv1alpha1.ImageUpgradeSpec{CanaryNamespaces: []string{"staging"}},
+		upgradeMD("canary", "staging", oldImage, airunwayv1alpha1.DeploymentPhaseRunning))
+
+	// A new deployment, a custom image, and a different repository are not upgrades
+	for _, md := range []*airunwayv1alpha1.ModelDeployment{
+		upgradeMD("new", "prod", "", airunwayv1alpha1.DeploymentPhasePending),
+		upgradeMD("sglang", "prod", "lmsysorg/sglang:v0.4", airunwayv1alpha1.DeploymentPhaseRunning),
+		func() *airunwayv1alpha1.ModelDeployment {
+			md := upgradeMD("custom", "prod", oldImage, airunwayv1alpha1.DeploymentPhaseRunning)
+			md.Spec.Image = "example.com/ray:custom"
+			return md
+		}(),
+	} {
+		if target, _, err := HoldImageUpgrade(ctx, c, "kuberay", md, defaultTestImage); err != nil || target != md {
+			t.Errorf("expected %s not to be held, got %v", md.Name, err)
+		}
+	}
+}
+
+func TestRecordDefaultImage(t *testing.T) {
+	md := upgradeMD("llama", "prod", "", airunwayv1alpha1.DeploymentPhaseRunning)
+	RecordDefaultImage(md, oldImage)
+	if md.Status.Provider.DefaultImage != oldImage || md.Status.Provider.DefaultImageTime != nil {
+		t.Errorf("expected the first image without a time, got %+v", md.Status.Provider)
+	}
+	RecordDefaultImage(md, newImage)
+	if md.Status.Provider.DefaultImage != newImage || md.Status.Provider.DefaultImageTime == nil {
+		t.Errorf("expected the upgrade time to be recorded, got %+v", md.Status.Provider)
+	}
+}
+
+func TestImageRepository(t *testing.T) {
+	for image, want := range map[string]string{
+		"rayproject/ray-llm:2.55.0":         "rayproject/ray-llm",
+		"localhost:5000/vllm":               "localhost:5000/vllm",
+		"localhost:5000/vllm:v1@sha256:abc": "localhost:5000/vllm",
+		"nvcr.io/nvidia/vllm-runtime":       "nvcr.io/nvidia/vllm-runtime",
+	} {
+		if got := imageRepository(image); got != want {
+			t.Errorf("imageRepository(%q) = %q, want %q", image, got, want)
+		}
+	}
+}
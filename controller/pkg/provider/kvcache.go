@@ -0,0 +1,118 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+// LMCacheKVTransferConfig is the --kv-transfer-config value that enables LMCache in vllm.
+// LMCache reads its configuration from LMCACHE_* environment variables.
+const LMCacheKVTransferConfig = `{"kv_connector":"LMCacheConnectorV1","kv_role":"kv_both"}`
+
+// kvCache returns spec.caching.kv, or nil when it is not set
+func kvCache(md *airunwayv1alpha1.ModelDeployment) *airunwayv1alpha1.KVCacheSpec {
+	if md.Spec.Caching == nil {
+		return nil
+	}
+	return md.Spec.Caching.KV
+}
+
+// KVCacheCheck returns an error when spec.caching.kv is set for an engine other than vllm,
+// or in disaggregated serving, whose KV connector already transfers the cache between
+// prefill and decode.
+func KVCacheCheck(md *airunwayv1alpha1.ModelDeployment) error {
+	if kvCache(md) == nil {
+		return nil
+	}
+	if engine := md.ResolvedEngineType(); engine != airunwayv1alpha1.EngineTypeVLLM {
+		return fmt.Errorf("spec.caching.kv is not supported with the %s engine", engine)
+	}
+	if md.Spec.Serving != nil && md.Spec.Serving.Mode == airunwayv1alpha1.ServingModeDisaggregated {
+		return fmt.Errorf("spec.caching.kv is not supported with disaggregated serving")
+	}
+	return nil
+}
+
+// LMCacheEnabled reports whether the vllm engine runs with the LMCache KV connector, for
+// spec.caching.kv or nvme KV cache offload.
+func LMCacheEnabled(md *airunwayv1alpha1.ModelDeployment) bool {
+	if md.ResolvedEngineType() != airunwayv1alpha1.EngineTypeVLLM {
+		return false
+	}
+	if kvCache(md) != nil {
+		return true
+	}
+	k := kvOffload(md)
+	return k != nil && k.ResolvedMedium() == airunwayv1alpha1.KVOffloadMediumNVMe
+}
+
+// LMCacheArgs returns the vllm flags enabling the LMCache KV connector, or nil when it is
+// not used.
+func LMCacheArgs(md *airunwayv1alpha1.ModelDeployment) []string {
+	if !LMCacheEnabled(md) {
+		return nil
+	}
+	return []string{"--kv-transfer-config", LMCacheKVTransferConfig}
+}
+
+// KVCacheEnv returns the LMCache environment variables for spec.caching.kv as unstructured
+// content, or nil when it is not set. The remote cache URL is read from the connection
+// Secret so credentials in it stay out of the pod spec.
+func KVCacheEnv(md *airunwayv1alpha1.ModelDeployment) []interface{} {
+	kv := kvCache(md)
+	if kv == nil || md.ResolvedEngineType() != airunwayv1alpha1.EngineTypeVLLM {
+		return nil
+	}
+	env := []interface{}{
+		map[string]interface{}{"name": "LMCACHE_LOCAL_CPU", "value": "True"},
+	}
+	if kv.MaxSize != nil {
+		env = append(env, map[string]interface{}{"name": "LMCACHE_MAX_LOCAL_CPU_SIZE", "value": quantityGiB(*kv.MaxSize)})
+	}
+	if ref := kv.ConnectionSecretRef; ref != nil {
+		env = append(env, map[string]interface{}{
+			"name": "LMCACHE_REMOTE_URL",
+			"valueFrom": map[string]interface{}{
+				"secretKeyRef": map[string]interface{}{"name": ref.Name, "key": ref.Key},
+			},
+		})
+	}
+	return env
+}
+
+// ApplyKVCacheToPodTemplate sets the LMCache environment for spec.caching.kv in every
+// container of an unstructured pod template with a spec map. It is a no-op when
+// spec.caching.kv is not set.
+func ApplyKVCacheToPodTemplate(template map[string]interface{}, md *airunwayv1alpha1.ModelDeployment) {
+	env := KVCacheEnv(md)
+	if env == nil {
+		return
+	}
+	spec, _ := template["spec"].(map[string]interface{})
+	containers, _ := spec["containers"].([]interface{})
+	for _, c := range containers {
+		container, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		existing, _ := container["env"].([]interface{})
+		container["env"] = append(existing, env...)
+	}
+}
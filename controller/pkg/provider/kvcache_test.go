@@ -0,0 +1,105 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+func newKVCacheMD(engine airunwayv1alpha1.EngineType) *airunwayv1alpha1.ModelDeployment {
+	md := newChatTemplateMD(engine, nil, "")
+	maxSize := resource.MustParse("20Gi")
+	md.Spec.Caching = &airunwayv1alpha1.CachingSpec{KV: &airunwayv1alpha1.KVCacheSpec{
+		Backend: airunwayv1alpha1.KVCacheBackendRedis,
+		ConnectionSecretRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "kv-cache"}, Key: "url",
+		},
+		MaxSize: &maxSize,
+	}}
+	return md
+}
+
+func TestLMCacheArgs(t *testing.T) {
+	want := []string{"--kv-transfer-config", LMCacheKVTransferConfig}
+	if got := LMCacheArgs(newKVCacheMD(airunwayv1alpha1.EngineTypeVLLM)); !slices.Equal(got, want) {
+		t.Errorf("expected %v for spec.caching.kv, got %v", want, got)
+	}
+	if got := LMCacheArgs(newKVOffloadMD(airunwayv1alpha1.EngineTypeVLLM, airunwayv1alpha1.KVOffloadMediumNVMe, "200Gi")); !slices.Equal(got, want) {
+		t.Errorf("expected %v for nvme offload, got %v", want, got)
+	}
+
+	// The connector is enabled once when both are set
+	md := newKVCacheMD(airunwayv1alpha1.EngineTypeVLLM)
+	md.Spec.Model.Storage = newKVOffloadMD(airunwayv1alpha1.EngineTypeVLLM, airunwayv1alpha1.KVOffloadMediumNVMe, "200Gi").Spec.Model.Storage
+	if got := append(KVOffloadArgs(md), LMCacheArgs(md)...); !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	for _, md := range []*airunwayv1alpha1.ModelDeployment{
+		newChatTemplateMD(airunwayv1alpha1.EngineTypeVLLM, nil, ""),
+		newKVOffloadMD(airunwayv1alpha1.EngineTypeVLLM, airunwayv1alpha1.KVOffloadMediumRAM, "64Gi"),
+		newKVCacheMD(airunwayv1alpha1.EngineTypeSGLang),
+	} {
+		if got := LMCacheArgs(md); got != nil {
+			t.Errorf("expected no LMCache args, got %v", got)
+		}
+	}
+}
+
+func TestKVCacheEnv(t *testing.T) {
+	env := KVCacheEnv(newKVCacheMD(airunwayv1alpha1.EngineTypeVLLM))
+	if len(env) != 3 {
+		t.Fatalf("expected 3 env vars, got %v", env)
+	}
+	if size := env[1].(map[string]interface{}); size["name"] != "LMCACHE_MAX_LOCAL_CPU_SIZE" || size["value"] != "20" {
+		t.Errorf("expected the CPU cache size in GiB, got %v", size)
+	}
+	remote := env[2].(map[string]interface{})
+	ref, _ := remote["valueFrom"].(map[string]interface{})["secretKeyRef"].(map[string]interface{})
+	if remote["name"] != "LMCACHE_REMOTE_URL" || ref["name"] != "kv-cache" || ref["key"] != "url" {
+		t.Errorf("expected the remote URL from the connection Secret, got %v", remote)
+	}
+
+	template := map[string]interface{}{"spec": map[string]interface{}{
+		"containers": []interface{}{map[string]interface{}{"name": "vllm"}},
+	}}
+	ApplyKVCacheToPodTemplate(template, newKVCacheMD(airunwayv1alpha1.EngineTypeVLLM))
+	container := template["spec"].(map[string]interface{})["containers"].([]interface{})[0].(map[string]interface{})
+	if got, _ := container["env"].([]interface{}); len(got) != 3 {
+		t.Errorf("expected the LMCache env in the container, got %v", got)
+	}
+}
+
+func TestKVCacheCheck(t *testing.T) {
+	if err := KVCacheCheck(newKVCacheMD(airunwayv1alpha1.EngineTypeVLLM)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := KVCacheCheck(newKVCacheMD(airunwayv1alpha1.EngineTypeSGLang)); err == nil {
+		t.Error("expected sglang to be rejected")
+	}
+	md := newKVCacheMD(airunwayv1alpha1.EngineTypeVLLM)
+	md.Spec.Serving = &airunwayv1alpha1.ServingSpec{Mode: airunwayv1alpha1.ServingModeDisaggregated}
+	if err := KVCacheCheck(md); err == nil {
+		t.Error("expected disaggregated serving to be rejected")
+	}
+}
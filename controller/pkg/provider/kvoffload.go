@@ -0,0 +1,190 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+// KV cache offload to nvme is backed by a volume mounted in the engine container. vllm
+// offloads through the LMCache connector, enabled by LMCacheArgs, and sglang through its
+// hierarchical cache file backend, both configured with environment variables.
+const (
+	KVOffloadVolumeName = "kv-offload"
+	KVOffloadMountPath  = "/kv-offload"
+)
+
+// KVOffloadSupported reports whether engine can offload its KV cache.
+func KVOffloadSupported(engine airunwayv1alpha1.EngineType) bool {
+	return engine == airunwayv1alpha1.EngineTypeVLLM || engine == airunwayv1alpha1.EngineTypeSGLang
+}
+
+// kvOffload returns spec.model.storage.kvOffload, or nil when it is not set
+func kvOffload(md *airunwayv1alpha1.ModelDeployment) *airunwayv1alpha1.KVOffloadSpec {
+	if md.Spec.Model.Storage == nil {
+		return nil
+	}
+	return md.Spec.Model.Storage.KVOffload
+}
+
+// KVOffloadCheck returns an error when spec.model.storage.kvOffload is set for an engine
+// that cannot offload its KV cache, or offloads to nvme in disaggregated vllm serving,
+// whose KV connector already transfers the cache between prefill and decode.
+func KVOffloadCheck(md *airunwayv1alpha1.ModelDeployment) error {
+	k := kvOffload(md)
+	if k == nil {
+		return nil
+	}
+	engine := md.ResolvedEngineType()
+	if !KVOffloadSupported(engine) {
+		return fmt.Errorf("spec.model.storage.kvOffload is not supported with the %s engine", engine)
+	}
+	if engine == airunwayv1alpha1.EngineTypeVLLM && k.ResolvedMedium() == airunwayv1alpha1.KVOffloadMediumNVMe &&
+		md.Spec.Serving != nil && md.Spec.Serving.Mode == airunwayv1alpha1.ServingModeDisaggregated {
+		return fmt.Errorf("spec.model.storage.kvOffload medium nvme is not supported with disaggregated vllm serving")
+	}
+	return nil
+}
+
+// kvOffloadGiB returns the offload size in whole GiB, rounded up
+func kvOffloadGiB(k *airunwayv1alpha1.KVOffloadSpec) string {
+	return quantityGiB(k.Size)
+}
+
+// quantityGiB returns q in whole GiB, rounded up and at least 1
+func quantityGiB(q resource.Quantity) string {
+	const gib = 1 << 30
+	return strconv.FormatInt(max((q.Value()+gib-1)/gib, 1), 10)
+}
+
+// KVOffloadArgs returns the vllm and sglang flags for spec.model.storage.kvOffload. RAM
+// offload sets the vllm CPU swap space or the sglang host cache size. The LMCache
+// connector used for vllm nvme offload is enabled by LMCacheArgs.
+func KVOffloadArgs(md *airunwayv1alpha1.ModelDeployment) []string {
+	k := kvOffload(md)
+	if k == nil {
+		return nil
+	}
+	nvme := k.ResolvedMedium() == airunwayv1alpha1.KVOffloadMediumNVMe
+	switch md.ResolvedEngineType() {
+	case airunwayv1alpha1.EngineTypeVLLM:
+		if nvme {
+			return nil
+		}
+		return []string{"--swap-space", kvOffloadGiB(k)}
+	case airunwayv1alpha1.EngineTypeSGLang:
+		if nvme {
+			return []string{"--enable-hierarchical-cache", "--hicache-storage-backend", "file"}
+		}
+		return []string{"--enable-hierarchical-cache", "--hicache-size", kvOffloadGiB(k)}
+	}
+	return nil
+}
+
+// KVOffloadEnv returns the environment variables for nvme offload as unstructured content,
+// or nil when the KV cache is not offloaded to nvme.
+func KVOffloadEnv(md *airunwayv1alpha1.ModelDeployment) []interface{} {
+	k := kvOffload(md)
+	if k == nil || k.ResolvedMedium() != airunwayv1alpha1.KVOffloadMediumNVMe {
+		return nil
+	}
+	env := func(name, value string) interface{} {
+		return map[string]interface{}{"name": name, "value": value}
+	}
+	switch md.ResolvedEngineType() {
+	case airunwayv1alpha1.EngineTypeVLLM:
+		return []interface{}{
+			env("LMCACHE_LOCAL_DISK", "file://"+KVOffloadMountPath+"/"),
+			env("LMCACHE_MAX_LOCAL_DISK_SIZE", kvOffloadGiB(k)),
+		}
+	case airunwayv1alpha1.EngineTypeSGLang:
+		return []interface{}{env("SGLANG_HICACHE_FILE_BACKEND_STORAGE_DIR", KVOffloadMountPath)}
+	}
+	return nil
+}
+
+// KVOffloadVolume returns the pod volume backing nvme offload as unstructured content, or
+// nil when the KV cache is not offloaded to nvme. With a storage class it is a generic
+// ephemeral volume, so the claim lives and dies with the pod; otherwise an emptyDir.
+func KVOffloadVolume(md *airunwayv1alpha1.ModelDeployment) map[string]interface{} {
+	k := kvOffload(md)
+	if k == nil || k.ResolvedMedium() != airunwayv1alpha1.KVOffloadMediumNVMe {
+		return nil
+	}
+	if k.StorageClassName == nil {
+		return map[string]interface{}{
+			"name":     KVOffloadVolumeName,
+			"emptyDir": map[string]interface{}{"sizeLimit": k.Size.String()},
+		}
+	}
+	return map[string]interface{}{
+		"name": KVOffloadVolumeName,
+		"ephemeral": map[string]interface{}{
+			"volumeClaimTemplate": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"accessModes":      []interface{}{"ReadWriteOnce"},
+					"storageClassName": *k.StorageClassName,
+					"resources": map[string]interface{}{
+						"requests": map[string]interface{}{"storage": k.Size.String()},
+					},
+				},
+			},
+		},
+	}
+}
+
+// KVOffloadVolumeMount returns the container volume mount of the KV offload volume as
+// unstructured content.
+func KVOffloadVolumeMount() map[string]interface{} {
+	return map[string]interface{}{
+		"name":      KVOffloadVolumeName,
+		"mountPath": KVOffloadMountPath,
+	}
+}
+
+// ApplyKVOffloadToPodTemplate adds the KV offload volume to an unstructured pod template
+// with a spec map, and mounts it and sets the offload environment in every container. It
+// is a no-op unless the KV cache is offloaded to nvme.
+func ApplyKVOffloadToPodTemplate(template map[string]interface{}, md *airunwayv1alpha1.ModelDeployment) {
+	volume := KVOffloadVolume(md)
+	if volume == nil {
+		return
+	}
+	spec, ok := template["spec"].(map[string]interface{})
+	if !ok {
+		spec = map[string]interface{}{}
+		template["spec"] = spec
+	}
+	volumes, _ := spec["volumes"].([]interface{})
+	spec["volumes"] = append(volumes, volume)
+	containers, _ := spec["containers"].([]interface{})
+	for _, c := range containers {
+		container, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		mounts, _ := container["volumeMounts"].([]interface{})
+		container["volumeMounts"] = append(mounts, KVOffloadVolumeMount())
+		env, _ := container["env"].([]interface{})
+		container["env"] = append(env, KVOffloadEnv(md)...)
+	}
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"slices"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+func newKVOffloadMD(engine airunwayv1alpha1.EngineType, medium airunwayv1alpha1.KVOffloadMedium, size string) *airunwayv1alpha1.ModelDeployment {
+	md := newChatTemplateMD(engine, nil, "")
+	md.Spec.Model.Storage = &airunwayv1alpha1.StorageSpec{KVOffload: &airunwayv1alpha1.KVOffloadSpec{
+		Size:   resource.MustParse(size),
+		Medium: medium,
+	}}
+	return md
+}
+
+func TestKVOffloadArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		md   *airunwayv1alpha1.ModelDeployment
+		want []string
+	}{
+		{name: "unset", md: newChatTemplateMD(airunwayv1alpha1.EngineTypeVLLM, nil, ""), want: nil},
+		{
+			name: "vllm ram rounds up",
+			md:   newKVOffloadMD(airunwayv1alpha1.EngineTypeVLLM, "", "1500Mi"),
+			want: []string{"--swap-space", "2"},
+		},
+		{
+			name: "vllm nvme",
+			md:   newKVOffloadMD(airunwayv1alpha1.EngineTypeVLLM, airunwayv1alpha1.KVOffloadMediumNVMe, "200Gi"),
+			want: nil,
+		},
+		{
+			name: "sglang ram",
+			md:   newKVOffloadMD(airunwayv1alpha1.EngineTypeSGLang, airunwayv1alpha1.KVOffloadMediumRAM, "64Gi"),
+			want: []string{"--enable-hierarchical-cache", "--hicache-size", "64"},
+		},
+		{
+			name: "sglang nvme",
+			md:   newKVOffloadMD(airunwayv1alpha1.EngineTypeSGLang, airunwayv1alpha1.KVOffloadMediumNVMe, "200Gi"),
+			want: []string{"--enable-hierarchical-cache", "--hicache-storage-backend", "file"},
+		},
+		{name: "trtllm", md: newKVOffloadMD(airunwayv1alpha1.EngineTypeTRTLLM, "", "64Gi"), want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := KVOffloadArgs(tt.md); !slices.Equal(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestKVOffloadCheck(t *testing.T) {
+	if err := KVOffloadCheck(newKVOffloadMD(airunwayv1alpha1.EngineTypeSGLang, "", "64Gi")); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := KVOffloadCheck(newKVOffloadMD(airunwayv1alpha1.EngineTypeTRTLLM, "", "64Gi")); err == nil {
+		t.Error("expected trtllm to be rejected")
+	}
+	md := newKVOffloadMD(airunwayv1alpha1.EngineTypeVLLM, airunwayv1alpha1.KVOffloadMediumNVMe, "200Gi")
+	md.Spec.Serving = &airunwayv1alpha1.ServingSpec{Mode: airunwayv1alpha1.ServingModeDisaggregated}
+	if err := KVOffloadCheck(md); err == nil {
+		t.Error("expected nvme offload to be rejected in disaggregated vllm serving")
+	}
+}
+
+func TestApplyKVOffloadToPodTemplate(t *testing.T) {
+	newTemplate := func() map[string]interface{} {
+		return map[string]interface{}{"spec": map[string]interface{}{
+			"containers": []interface{}{map[string]interface{}{"name": "vllm"}},
+		}}
+	}
+
+	// RAM offload needs no volume
+	template := newTemplate()
+	ApplyKVOffloadToPodTemplate(template, newKVOffloadMD(airunwayv1alpha1.EngineTypeVLLM, "", "64Gi"))
+	if _, found, _ := unstructured.NestedSlice(template, "spec", "volumes"); found {
+		t.Error("expected no volume for ram offload")
+	}
+
+	md := newKVOffloadMD(airunwayv1alpha1.EngineTypeVLLM, airunwayv1alpha1.KVOffloadMediumNVMe, "200Gi")
+	template = newTemplate()
+	ApplyKVOffloadToPodTemplate(template, md)
+	volumes, _, _ := unstructured.NestedSlice(template, "spec", "volumes")
+	if len(volumes) != 1 {
+		t.Fatalf("expected the offload volume, got %v", volumes)
+	}
+	if limit, _, _ := unstructured.NestedString(volumes[0].(map[string]interface{}), "emptyDir", "sizeLimit"); limit != "200Gi" {
+		t.Errorf("expected an emptyDir limited to 200Gi, got %v", volumes[0])
+	}
+	container := template["spec"].(map[string]interface{})["containers"].([]interface{})[0].(map[string]interface{})
+	mounts, _ := container["volumeMounts"].([]interface{})
+	env, _ := container["env"].([]interface{})
+	if len(mounts) != 1 || len(env) != 2 {
+		t.Errorf("expected the volume mount and LMCache env, got %v and %v", mounts, env)
+	}
+
+	sc := "local-nvme"
+	md.Spec.Model.Storage.KVOffload.StorageClassName = &sc
+	volume := KVOffloadVolume(md)
+	if got, _, _ := unstructured.NestedString(volume, "ephemeral", "volumeClaimTemplate", "spec", "storageClassName"); got != sc {
+		t.Errorf("expected an ephemeral volume of class %s, got %v", sc, volume)
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+// LoadFormatCheck returns an error when spec.model.loadFormat is set for an engine other
+// than vllm, or when tensorizer is selected without the URI of the serialized weights.
+func LoadFormatCheck(md *airunwayv1alpha1.ModelDeployment) error {
+	model := md.Spec.Model
+	if model.LoadFormat == "" {
+		return nil
+	}
+	if engine := md.ResolvedEngineType(); engine != airunwayv1alpha1.EngineTypeVLLM {
+		return fmt.Errorf("spec.model.loadFormat is not supported with the %s engine", engine)
+	}
+	if model.LoadFormat == airunwayv1alpha1.ModelLoadFormatTensorizer && model.TensorizerURI == "" {
+		return fmt.Errorf("spec.model.loadFormat tensorizer requires spec.model.tensorizerURI")
+	}
+	return nil
+}
+
+// LoadFormatArgs returns the vllm flags for spec.model.loadFormat, or nil when it is not
+// set. Tensorizer reads the location of the serialized weights from the model loader
+// config.
+func LoadFormatArgs(md *airunwayv1alpha1.ModelDeployment) []string {
+	model := md.Spec.Model
+	if model.LoadFormat == "" || md.ResolvedEngineType() != airunwayv1alpha1.EngineTypeVLLM {
+		return nil
+	}
+	args := []string{"--load-format", string(model.LoadFormat)}
+	if model.LoadFormat == airunwayv1alpha1.ModelLoadFormatTensorizer {
+		config, _ := json.Marshal(map[string]string{"tensorizer_uri": model.TensorizerURI})
+		args = append(args, "--model-loader-extra-config", string(config))
+	}
+	return args
+}
+
+// LoadFormatEnvFrom returns the envFrom entry exposing spec.model.loadCredentialsSecret as
+// unstructured content, or nil when no credentials are needed.
+func LoadFormatEnvFrom(md *airunwayv1alpha1.ModelDeployment) []interface{} {
+	model := md.Spec.Model
+	if model.LoadFormat == "" || model.LoadCredentialsSecret == "" || md.ResolvedEngineType() != airunwayv1alpha1.EngineTypeVLLM {
+		return nil
+	}
+	return []interface{}{
+		map[string]interface{}{"secretRef": map[string]interface{}{"name": model.LoadCredentialsSecret}},
+	}
+}
+
+// ApplyLoadFormatToPodTemplate exposes the load credentials Secret to every container of
+// an unstructured pod template with a spec map. It is a no-op when
+// spec.model.loadCredentialsSecret is not set.
+func ApplyLoadFormatToPodTemplate(template map[string]interface{}, md *airunwayv1alpha1.ModelDeployment) {
+	envFrom := LoadFormatEnvFrom(md)
+	if envFrom == nil {
+		return
+	}
+	spec, _ := template["spec"].(map[string]interface{})
+	containers, _ := spec["containers"].([]interface{})
+	for _, c := range containers {
+		container, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		existing, _ := container["envFrom"].([]interface{})
+		container["envFrom"] = append(existing, envFrom...)
+	}
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+func newLoadFormatMD(engine airunwayv1alpha1.EngineType, format airunwayv1alpha1.ModelLoadFormat) *airunwayv1alpha1.ModelDeployment {
+	md := newChatTemplateMD(engine, nil, "")
+	md.Spec.Model.LoadFormat = format
+	if format == airunwayv1alpha1.ModelLoadFormatTensorizer {
+		md.Spec.Model.TensorizerURI = "s3://models/llama/model.tensors"
+	}
+	return md
+}
+
+func TestLoadFormatCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		md      *airunwayv1alpha1.ModelDeployment
+		wantErr string
+	}{
+		{name: "not set", md: newLoadFormatMD(airunwayv1alpha1.EngineTypeSGLang, "")},
+		{name: "vllm", md: newLoadFormatMD(airunwayv1alpha1.EngineTypeVLLM, airunwayv1alpha1.ModelLoadFormatRunAIStreamer)},
+		{
+			name:    "sglang",
+			md:      newLoadFormatMD(airunwayv1alpha1.EngineTypeSGLang, airunwayv1alpha1.ModelLoadFormatSafetensors),
+			wantErr: "not supported with the sglang engine",
+		},
+		{
+			name: "tensorizer without URI",
+			md: func() *airunwayv1alpha1.ModelDeployment {
+				md := newLoadFormatMD(airunwayv1alpha1.EngineTypeVLLM, airunwayv1alpha1.ModelLoadFormatTensorizer)
+				md.Spec.Model.TensorizerURI = ""
+				return md
+			}(),
+			wantErr: "requires spec.model.tensorizerURI",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := LoadFormatCheck(tt.md)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestLoadFormatArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		md   *airunwayv1alpha1.ModelDeployment
+		want []string
+	}{
+		{name: "not set", md: newLoadFormatMD(airunwayv1alpha1.EngineTypeVLLM, "")},
+		{
+			name: "safetensors",
+			md:   newLoadFormatMD(airunwayv1alpha1.EngineTypeVLLM, airunwayv1alpha1.ModelLoadFormatSafetensors),
+			want: []string{"--load-format", "safetensors"},
+		},
+		{
+			name: "runai streamer",
+			md:   newLoadFormatMD(airunwayv1alpha1.EngineTypeVLLM, airunwayv1alpha1.ModelLoadFormatRunAIStreamer),
+			want: []string{"--load-format", "runai_streamer"},
+		},
+		{
+			name: "tensorizer",
+			md:   newLoadFormatMD(airunwayv1alpha1.EngineTypeVLLM, airunwayv1alpha1.ModelLoadFormatTensorizer),
+			want: []string{"--load-format", "tensorizer", "--model-loader-extra-config", `{"tensorizer_uri":"s3://models/llama/model.tensors"}`},
+		},
+		{name: "other engine", md: newLoadFormatMD(airunwayv1alpha1.EngineTypeSGLang, airunwayv1alpha1.ModelLoadFormatSafetensors)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LoadFormatArgs(tt.md); !slices.Equal(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestApplyLoadFormatToPodTemplate(t *testing.T) {
+	md := newLoadFormatMD(airunwayv1alpha1.EngineTypeVLLM, airunwayv1alpha1.ModelLoadFormatTensorizer)
+	md.Spec.Model.LoadCredentialsSecret = "model-store"
+	template := map[string]interface{}{"spec": map[string]interface{}{"containers": []interface{}{
+		map[string]interface{}{"name": "vllm", "envFrom": []interface{}{map[string]interface{}{"configMapRef": map[string]interface{}{"name": "env"}}}},
+	}}}
+	ApplyLoadFormatToPodTemplate(template, md)
+
+	container := template["spec"].(map[string]interface{})["containers"].([]interface{})[0].(map[string]interface{})
+	envFrom := container["envFrom"].([]interface{})
+	if len(envFrom) != 2 {
+		t.Fatalf("expected the Secret appended to envFrom, got %v", envFrom)
+	}
+	if ref := envFrom[1].(map[string]interface{})["secretRef"].(map[string]interface{}); ref["name"] != "model-store" {
+		t.Errorf("expected secretRef model-store, got %v", ref)
+	}
+
+	// No credentials without a load format
+	md.Spec.Model.LoadFormat = ""
+	if got := LoadFormatEnvFrom(md); got != nil {
+		t.Errorf("expected no envFrom, got %v", got)
+	}
+}
@@ -0,0 +1,338 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+// The log sink of spec.observability.logSink is a Fluent Bit sidecar that tails the logs
+// of the other containers of its pod from the node and pushes them to the log backend.
+// Its configuration is mounted from a ConfigMap.
+const (
+	// DefaultLogSinkImage is the Fluent Bit image used when spec.observability.logSink.image
+	// is not set
+	DefaultLogSinkImage = "cr.fluentbit.io/fluent/fluent-bit:3.2.10"
+
+	LogSinkContainerName     = "log-sink"
+	LogSinkConfigVolumeName  = "log-sink-config"
+	LogSinkConfigMountPath   = "/fluent-bit/etc/airunway"
+	LogSinkConfigFileName    = "fluent-bit.yaml"
+	LogSinkPodLogsVolumeName = "log-sink-pod-logs"
+	LogSinkPodLogsPath       = "/var/log/pods"
+
+	// LogSinkConfigHashAnnot is set on pod templates to the hash of the log sink
+	// configuration, so that pods are restarted when it changes.
+	LogSinkConfigHashAnnot = "airunway.ai/log-sink-config-hash"
+
+	// LogSinkLabelNamespace, LogSinkLabelModelDeployment, and LogSinkLabelPod are the
+	// labels the log sink sets on every log line. They cannot be overridden by
+	// spec.observability.logSink.labels.
+	LogSinkLabelNamespace       = "namespace"
+	LogSinkLabelModelDeployment = "model_deployment"
+	LogSinkLabelPod             = "pod"
+)
+
+// LogSinkConfigMapName returns the name of the ConfigMap holding the log sink
+// configuration of the named ModelDeployment.
+func LogSinkConfigMapName(mdName string) string {
+	return mdName + "-log-sink"
+}
+
+// LogSinkConfig returns the Fluent Bit configuration of spec.observability.logSink, or ""
+// when it is not set. The pod name, namespace, and UID are read from the environment of
+// the sidecar, so one configuration serves every pod of the deployment.
+func LogSinkConfig(md *airunwayv1alpha1.ModelDeployment) (string, error) {
+	sink := md.LogSink()
+	if sink == nil {
+		return "", nil
+	}
+	u, err := url.Parse(sink.Endpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid spec.observability.logSink.endpoint: %w", err)
+	}
+	tls, port := "off", "80"
+	if u.Scheme == "https" {
+		tls, port = "on", "443"
+	}
+	if u.Port() != "" {
+		port = u.Port()
+	}
+	path := strings.TrimSuffix(u.EscapedPath(), "/")
+
+	labels := map[string]string{
+		LogSinkLabelNamespace:       md.Namespace,
+		LogSinkLabelModelDeployment: md.Name,
+		LogSinkLabelPod:             "${POD_NAME}",
+	}
+	for k, v := range sink.Labels {
+		if _, reserved := labels[k]; !reserved {
+			labels[k] = v
+		}
+	}
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	output := map[string]interface{}{
+		"match": "*",
+		"host":  u.Hostname(),
+		"port":  port,
+		"tls":   tls,
+	}
+	var filters []interface{}
+	switch sink.Type {
+	case airunwayv1alpha1.LogSinkTypeLoki:
+		if path == "" {
+			path = "/loki/api/v1/push"
+		}
+		pairs := make([]string, 0, len(keys))
+		for _, k := range keys {
+			pairs = append(pairs, k+"="+labels[k])
+		}
+		output["name"] = "loki"
+		output["uri"] = path
+		output["labels"] = strings.Join(pairs, ",")
+	case airunwayv1alpha1.LogSinkTypeOTLP:
+		if path == "" {
+			path = "/v1/logs"
+		}
+		output["name"] = "opentelemetry"
+		output["logs_uri"] = path
+		// OTLP has no stream labels; the labels become attributes of each record
+		add := make([]interface{}, 0, len(keys))
+		for _, k := range keys {
+			add = append(add, k+" "+labels[k])
+		}
+		filters = append(filters, map[string]interface{}{"name": "modify", "match": "*", "add": add})
+	default:
+		return "", fmt.Errorf("unsupported log sink type %q", sink.Type)
+	}
+
+	pipeline := map[string]interface{}{
+		"inputs": []interface{}{map[string]interface{}{
+			"name":             "tail",
+			"path":             LogSinkPodLogsPath + "/${POD_NAMESPACE}_${POD_NAME}_${POD_UID}/*/*.log",
+			"exclude_path":     LogSinkPodLogsPath + "/${POD_NAMESPACE}_${POD_NAME}_${POD_UID}/" + LogSinkContainerName + "/*.log",
+			"multiline.parser": "cri",
+			"refresh_interval": 5,
+		}},
+		"outputs": []interface{}{output},
+	}
+	if filters != nil {
+		pipeline["filters"] = filters
+	}
+	data, err := yaml.Marshal(map[string]interface{}{
+		"service":  map[string]interface{}{"flush": 1, "log_level": "warn"},
+		"pipeline": pipeline,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// LogSinkConfigHash returns the hash of a log sink configuration
+func LogSinkConfigHash(config string) string {
+	sum := sha256.Sum256([]byte(config))
+	return hex.EncodeToString(sum[:8])
+}
+
+// LogSinkConfigMap returns the ConfigMap holding the log sink configuration of md, owned
+// by md, or nil when spec.observability.logSink is not set.
+func LogSinkConfigMap(md *airunwayv1alpha1.ModelDeployment) (*corev1.ConfigMap, error) {
+	config, err := LogSinkConfig(md)
+	if err != nil || config == "" {
+		return nil, err
+	}
+	controller := true
+	return &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      LogSinkConfigMapName(md.Name),
+			Namespace: md.Namespace,
+			Labels: map[string]string{
+				airunwayv1alpha1.LabelManagedBy:       airunwayv1alpha1.ManagedByAIRunway,
+				airunwayv1alpha1.LabelModelDeployment: md.Name,
+			},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion:         airunwayv1alpha1.GroupVersion.String(),
+				Kind:               "ModelDeployment",
+				Name:               md.Name,
+				UID:                md.UID,
+				Controller:         &controller,
+				BlockOwnerDeletion: &controller,
+			}},
+		},
+		Data: map[string]string{LogSinkConfigFileName: config},
+	}, nil
+}
+
+// AddLogSinkConfigMap adds the ConfigMap holding the log sink configuration to the
+// resources of result. ApplyResources applies it before the pods that mount it. It is a
+// no-op unless spec.observability.logSink is set.
+func AddLogSinkConfigMap(result *TransformResult, md *airunwayv1alpha1.ModelDeployment) error {
+	cm, err := LogSinkConfigMap(md)
+	if err != nil || cm == nil {
+		return err
+	}
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(cm)
+	if err != nil {
+		return err
+	}
+	result.Resources = append(result.Resources, &unstructured.Unstructured{Object: obj})
+	return nil
+}
+
+// LogSinkContainer returns the log sink sidecar of md, or nil when
+// spec.observability.logSink is not set.
+func LogSinkContainer(md *airunwayv1alpha1.ModelDeployment) *corev1.Container {
+	sink := md.LogSink()
+	if sink == nil {
+		return nil
+	}
+	image := sink.Image
+	if image == "" {
+		image = DefaultLogSinkImage
+	}
+	fieldEnv := func(name, path string) corev1.EnvVar {
+		return corev1.EnvVar{Name: name, ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{FieldPath: path},
+		}}
+	}
+	readOnly := true
+	noEscalation := false
+	return &corev1.Container{
+		Name:  LogSinkContainerName,
+		Image: image,
+		Args:  []string{"-c", LogSinkConfigMountPath + "/" + LogSinkConfigFileName},
+		Env: []corev1.EnvVar{
+			fieldEnv("POD_NAME", "metadata.name"),
+			fieldEnv("POD_NAMESPACE", "metadata.namespace"),
+			fieldEnv("POD_UID", "metadata.uid"),
+		},
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("10m"),
+				corev1.ResourceMemory: resource.MustParse("32Mi"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("128Mi"),
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: LogSinkConfigVolumeName, MountPath: LogSinkConfigMountPath, ReadOnly: true},
+			{Name: LogSinkPodLogsVolumeName, MountPath: LogSinkPodLogsPath, ReadOnly: true},
+		},
+		SecurityContext: &corev1.SecurityContext{
+			ReadOnlyRootFilesystem:   &readOnly,
+			AllowPrivilegeEscalation: &noEscalation,
+			Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+		},
+	}
+}
+
+// LogSinkVolumes returns the pod volumes of the log sink sidecar of md: its configuration
+// and the pod log directory of the node.
+func LogSinkVolumes(md *airunwayv1alpha1.ModelDeployment) []corev1.Volume {
+	if md.LogSink() == nil {
+		return nil
+	}
+	hostPathType := corev1.HostPathDirectory
+	return []corev1.Volume{
+		{
+			Name: LogSinkConfigVolumeName,
+			VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: LogSinkConfigMapName(md.Name)},
+			}},
+		},
+		{
+			Name: LogSinkPodLogsVolumeName,
+			VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{
+				Path: LogSinkPodLogsPath,
+				Type: &hostPathType,
+			}},
+		},
+	}
+}
+
+// LogSinkPodSpec returns the log sink sidecar and volumes of md as unstructured content,
+// or nils when spec.observability.logSink is not set.
+func LogSinkPodSpec(md *airunwayv1alpha1.ModelDeployment) (container map[string]interface{}, volumes []interface{}, err error) {
+	c := LogSinkContainer(md)
+	if c == nil {
+		return nil, nil, nil
+	}
+	if container, err = runtime.DefaultUnstructuredConverter.ToUnstructured(c); err != nil {
+		return nil, nil, err
+	}
+	for _, v := range LogSinkVolumes(md) {
+		volume, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&v)
+		if err != nil {
+			return nil, nil, err
+		}
+		volumes = append(volumes, volume)
+	}
+	return container, volumes, nil
+}
+
+// ApplyLogSinkToPodTemplate adds the log sink sidecar and its volumes to an unstructured
+// pod template with metadata and spec maps, and annotates the template with the hash of
+// the log sink configuration. It is a no-op when spec.observability.logSink is not set.
+func ApplyLogSinkToPodTemplate(template map[string]interface{}, md *airunwayv1alpha1.ModelDeployment) error {
+	config, err := LogSinkConfig(md)
+	if err != nil || config == "" {
+		return err
+	}
+	container, volumes, err := LogSinkPodSpec(md)
+	if err != nil {
+		return err
+	}
+	metadata, ok := template["metadata"].(map[string]interface{})
+	if !ok {
+		metadata = map[string]interface{}{}
+		template["metadata"] = metadata
+	}
+	mergeStringMap(metadata, "annotations", map[string]string{LogSinkConfigHashAnnot: LogSinkConfigHash(config)})
+
+	spec, ok := template["spec"].(map[string]interface{})
+	if !ok {
+		spec = map[string]interface{}{}
+		template["spec"] = spec
+	}
+	existing, _ := spec["volumes"].([]interface{})
+	spec["volumes"] = append(existing, volumes...)
+	containers, _ := spec["containers"].([]interface{})
+	spec["containers"] = append(containers, container)
+	return nil
+}
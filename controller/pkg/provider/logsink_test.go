@@ -0,0 +1,174 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+func newLogSinkMD(sink *airunwayv1alpha1.LogSinkSpec) *airunwayv1alpha1.ModelDeployment {
+	md := &airunwayv1alpha1.ModelDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "team-a", UID: "uid"},
+	}
+	if sink != nil {
+		md.Spec.Observability = &airunwayv1alpha1.ObservabilitySpec{LogSink: sink}
+	}
+	return md
+}
+
+func TestLogSinkConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		sink *airunwayv1alpha1.LogSinkSpec
+		want []string
+	}{
+		{name: "unset", sink: nil, want: nil},
+		{
+			name: "loki",
+			sink: &airunwayv1alpha1.LogSinkSpec{
+				Type:     airunwayv1alpha1.LogSinkTypeLoki,
+				Endpoint: "http://loki.observability:3100",
+				// Reserved labels cannot be overridden
+				Labels: map[string]string{"team": "search", "namespace": "other"},
+			},
+			want: []string{
+				"name: loki", "host: loki.observability", `port: "3100"`, "tls: \"off\"", "uri: /loki/api/v1/push",
+				"labels: model_deployment=llama,namespace=team-a,pod=${POD_NAME},team=search",
+				"path: /var/log/pods/${POD_NAMESPACE}_${POD_NAME}_${POD_UID}/*/*.log",
+			},
+		},
+		{
+			name: "otlp",
+			sink: &airunwayv1alpha1.LogSinkSpec{
+				Type:     airunwayv1alpha1.LogSinkTypeOTLP,
+				Endpoint: "https://collector.example.com/otlp/v1/logs",
+			},
+			want: []string{
+				"name: opentelemetry", "host: collector.example.com", `port: "443"`, "tls: \"on\"",
+				"logs_uri: /otlp/v1/logs", "name: modify", "- model_deployment llama", "- namespace team-a",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := LogSinkConfig(newLogSinkMD(tt.sink))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.want == nil && config != "" {
+				t.Errorf("expected no configuration, got %q", config)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(config, want) {
+					t.Errorf("expected the configuration to contain %q, got:\n%s", want, config)
+				}
+			}
+		})
+	}
+}
+
+func TestAddLogSinkConfigMap(t *testing.T) {
+	result := NewTransformResult(newObject("apps/v1", "Deployment", "llama"))
+	if err := AddLogSinkConfigMap(result, newLogSinkMD(nil)); err != nil || len(result.Resources) != 1 {
+		t.Fatalf("expected no ConfigMap without spec.observability.logSink, got %d resources, %v", len(result.Resources), err)
+	}
+
+	md := newLogSinkMD(&airunwayv1alpha1.LogSinkSpec{Type: airunwayv1alpha1.LogSinkTypeLoki, Endpoint: "http://loki:3100"})
+	if err := AddLogSinkConfigMap(result, md); err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Resources) != 2 || result.Resources[1].GetKind() != "ConfigMap" {
+		t.Fatalf("expected the ConfigMap to be added, got %v", result.Resources)
+	}
+	cm := result.Resources[1]
+	if cm.GetName() != "llama-log-sink" || cm.GetNamespace() != "team-a" {
+		t.Errorf("expected team-a/llama-log-sink, got %s/%s", cm.GetNamespace(), cm.GetName())
+	}
+	if data, _, _ := unstructured.NestedString(cm.Object, "data", LogSinkConfigFileName); !strings.Contains(data, "name: loki") {
+		t.Errorf("expected the Fluent Bit configuration, got %q", data)
+	}
+	if refs := cm.GetOwnerReferences(); len(refs) != 1 || refs[0].UID != "uid" {
+		t.Errorf("expected the ModelDeployment to own the ConfigMap, got %v", refs)
+	}
+	if result.Primary().GetKind() != "Deployment" {
+		t.Errorf("expected the Deployment to stay primary, got %s", result.Primary().GetKind())
+	}
+}
+
+func TestApplyLogSinkToPodTemplate(t *testing.T) {
+	newTemplate := func() map[string]interface{} {
+		return map[string]interface{}{
+			"metadata": map[string]interface{}{},
+			"spec": map[string]interface{}{
+				"containers": []interface{}{map[string]interface{}{"name": "vllm"}},
+			},
+		}
+	}
+
+	template := newTemplate()
+	if err := ApplyLogSinkToPodTemplate(template, newLogSinkMD(nil)); err != nil {
+		t.Fatal(err)
+	}
+	if containers, _, _ := unstructured.NestedSlice(template, "spec", "containers"); len(containers) != 1 {
+		t.Errorf("expected no sidecar without spec.observability.logSink, got %v", containers)
+	}
+
+	md := newLogSinkMD(&airunwayv1alpha1.LogSinkSpec{
+		Type: airunwayv1alpha1.LogSinkTypeLoki, Endpoint: "http://loki:3100", Image: "registry.example.com/fluent-bit:3",
+	})
+	template = newTemplate()
+	if err := ApplyLogSinkToPodTemplate(template, md); err != nil {
+		t.Fatal(err)
+	}
+	containers, _, _ := unstructured.NestedSlice(template, "spec", "containers")
+	if len(containers) != 2 {
+		t.Fatalf("expected the sidecar to be added, got %v", containers)
+	}
+	sidecar := containers[1].(map[string]interface{})
+	if sidecar["name"] != LogSinkContainerName || sidecar["image"] != "registry.example.com/fluent-bit:3" {
+		t.Errorf("expected the log sink sidecar with the configured image, got %v", sidecar)
+	}
+	volumes, _, _ := unstructured.NestedSlice(template, "spec", "volumes")
+	if len(volumes) != 2 {
+		t.Fatalf("expected the config and pod log volumes, got %v", volumes)
+	}
+	if name, _, _ := unstructured.NestedString(volumes[0].(map[string]interface{}), "configMap", "name"); name != "llama-log-sink" {
+		t.Errorf("expected the generated ConfigMap, got %q", name)
+	}
+	if path, _, _ := unstructured.NestedString(volumes[1].(map[string]interface{}), "hostPath", "path"); path != LogSinkPodLogsPath {
+		t.Errorf("expected the pod log directory of the node, got %q", path)
+	}
+	hash, _, _ := unstructured.NestedString(template, "metadata", "annotations", LogSinkConfigHashAnnot)
+	if hash == "" {
+		t.Error("expected the config hash annotation")
+	}
+
+	md.Spec.Observability.LogSink.Labels = map[string]string{"team": "search"}
+	template = newTemplate()
+	if err := ApplyLogSinkToPodTemplate(template, md); err != nil {
+		t.Fatal(err)
+	}
+	if updated, _, _ := unstructured.NestedString(template, "metadata", "annotations", LogSinkConfigHashAnnot); updated == hash {
+		t.Error("expected the hash to change with the configuration")
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+// LoRARuntimeUpdatingEnv is the vllm environment variable enabling the runtime adapter API
+// the controller loads spec.model.adapters through
+const LoRARuntimeUpdatingEnv = "VLLM_ALLOW_RUNTIME_LORA_UPDATING"
+
+// AdaptersCheck returns an error when spec.model.adapters is set for an engine other than
+// vllm, the only engine whose runtime adapter API the controller drives.
+func AdaptersCheck(md *airunwayv1alpha1.ModelDeployment) error {
+	if len(md.Spec.Model.Adapters) == 0 {
+		return nil
+	}
+	if engine := md.ResolvedEngineType(); engine != airunwayv1alpha1.EngineTypeVLLM {
+		return fmt.Errorf("spec.model.adapters is not supported with the %s engine", engine)
+	}
+	return nil
+}
+
+// LoRAEnabled reports whether the vllm engine serves LoRA adapters for spec.model.adapters.
+// It only depends on whether adapters are set, so adding and removing adapters leaves the
+// pod template unchanged.
+func LoRAEnabled(md *airunwayv1alpha1.ModelDeployment) bool {
+	return len(md.Spec.Model.Adapters) > 0 && md.ResolvedEngineType() == airunwayv1alpha1.EngineTypeVLLM
+}
+
+// LoRAArgs returns the vllm flags enabling LoRA adapters, or nil when spec.model.adapters
+// is not set. The adapters themselves are loaded at runtime by the controller.
+func LoRAArgs(md *airunwayv1alpha1.ModelDeployment) []string {
+	if !LoRAEnabled(md) {
+		return nil
+	}
+	return []string{"--enable-lora"}
+}
+
+// LoRAEnv returns the environment variables enabling the vllm runtime adapter API as
+// unstructured content, or nil when spec.model.adapters is not set.
+func LoRAEnv(md *airunwayv1alpha1.ModelDeployment) []interface{} {
+	if !LoRAEnabled(md) {
+		return nil
+	}
+	return []interface{}{
+		map[string]interface{}{"name": LoRARuntimeUpdatingEnv, "value": "True"},
+	}
+}
+
+// ApplyLoRAToPodTemplate enables the runtime adapter API in every container of an
+// unstructured pod template with a spec map. It is a no-op when spec.model.adapters is
+// not set.
+func ApplyLoRAToPodTemplate(template map[string]interface{}, md *airunwayv1alpha1.ModelDeployment) {
+	env := LoRAEnv(md)
+	if env == nil {
+		return
+	}
+	spec, _ := template["spec"].(map[string]interface{})
+	containers, _ := spec["containers"].([]interface{})
+	for _, c := range containers {
+		container, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		existing, _ := container["env"].([]interface{})
+		container["env"] = append(existing, env...)
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"slices"
+	"testing"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+func newLoRAMD(engine airunwayv1alpha1.EngineType) *airunwayv1alpha1.ModelDeployment {
+	md := newChatTemplateMD(engine, nil, "")
+	md.Spec.Model.Adapters = []airunwayv1alpha1.LoRAAdapter{{Name: "sql", Source: "/adapters/sql"}}
+	return md
+}
+
+func TestLoRAArgs(t *testing.T) {
+	if got, want := LoRAArgs(newLoRAMD(airunwayv1alpha1.EngineTypeVLLM)), []string{"--enable-lora"}; !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if got := LoRAArgs(newChatTemplateMD(airunwayv1alpha1.EngineTypeVLLM, nil, "")); got != nil {
+		t.Errorf("expected no flags without adapters, got %v", got)
+	}
+	if got := LoRAArgs(newLoRAMD(airunwayv1alpha1.EngineTypeSGLang)); got != nil {
+		t.Errorf("expected no flags for sglang, got %v", got)
+	}
+}
+
+func TestAdaptersCheck(t *testing.T) {
+	if err := AdaptersCheck(newLoRAMD(airunwayv1alpha1.EngineTypeVLLM)); err != nil {
+		t.Errorf("expected vllm to be accepted, got %v", err)
+	}
+	if err := AdaptersCheck(newLoRAMD(airunwayv1alpha1.EngineTypeSGLang)); err == nil {
+		t.Error("expected an error for sglang")
+	}
+	if err := AdaptersCheck(newChatTemplateMD(airunwayv1alpha1.EngineTypeSGLang, nil, "")); err != nil {
+		t.Errorf("expected no error without adapters, got %v", err)
+	}
+}
+
+func TestApplyLoRAToPodTemplate(t *testing.T) {
+	template := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{"name": "vllm", "env": []interface{}{map[string]interface{}{"name": "A", "value": "1"}}},
+			},
+		},
+	}
+	ApplyLoRAToPodTemplate(template, newLoRAMD(airunwayv1alpha1.EngineTypeVLLM))
+	container := template["spec"].(map[string]interface{})["containers"].([]interface{})[0].(map[string]interface{})
+	env := container["env"].([]interface{})
+	if len(env) != 2 || env[1].(map[string]interface{})["name"] != LoRARuntimeUpdatingEnv {
+		t.Errorf("expected %s appended to the env, got %v", LoRARuntimeUpdatingEnv, env)
+	}
+
+	// Adapters do not change the pod template of other engines
+	template = map[string]interface{}{"spec": map[string]interface{}{"containers": []interface{}{map[string]interface{}{"name": "sglang"}}}}
+	ApplyLoRAToPodTemplate(template, newLoRAMD(airunwayv1alpha1.EngineTypeSGLang))
+	if _, ok := template["spec"].(map[string]interface{})["containers"].([]interface{})[0].(map[string]interface{})["env"]; ok {
+		t.Error("expected no env for sglang")
+	}
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+// podTemplateKinds are the built-in kinds whose spec.template is a pod template
+var podTemplateKinds = map[string]bool{"Deployment": true, "StatefulSet": true, "DaemonSet": true, "Job": true}
+
+// ApplyPropagatedMetadata adds spec.labels and spec.annotations to every resource of
+// result, and to the pod templates of Deployments, StatefulSets, DaemonSets, and Jobs.
+// Keys the provider already set are kept. Providers add the metadata to the pod templates
+// of their custom resources with ApplyPropagatedMetadataToPodTemplate.
+func ApplyPropagatedMetadata(result *TransformResult, md *airunwayv1alpha1.ModelDeployment) {
+	labels, annotations := md.PropagatedLabels(), md.PropagatedAnnotations()
+	if len(labels) == 0 && len(annotations) == 0 && len(md.SecretsPodAnnotations()) == 0 {
+		return
+	}
+	for _, obj := range result.Resources {
+		metadata, ok := obj.Object["metadata"].(map[string]interface{})
+		if !ok {
+			metadata = map[string]interface{}{}
+			obj.Object["metadata"] = metadata
+		}
+		addMissingStringMap(metadata, "labels", labels)
+		addMissingStringMap(metadata, "annotations", annotations)
+
+		if !podTemplateKinds[obj.GetKind()] {
+			continue
+		}
+		spec, _ := obj.Object["spec"].(map[string]interface{})
+		if template, ok := spec["template"].(map[string]interface{}); ok {
+			ApplyPropagatedMetadataToPodTemplate(template, md)
+		}
+	}
+}
+
+// ApplyPropagatedMetadataToPodTemplate adds spec.labels and spec.annotations to an
+// unstructured pod template, keeping keys that are already set. It also stamps the
+// checksum of the referenced Secrets when spec.secrets.rolloutOnChange is set.
+func ApplyPropagatedMetadataToPodTemplate(template map[string]interface{}, md *airunwayv1alpha1.ModelDeployment) {
+	labels, annotations, secrets := md.PropagatedLabels(), md.PropagatedAnnotations(), md.SecretsPodAnnotations()
+	if len(labels) == 0 && len(annotations) == 0 && len(secrets) == 0 {
+		return
+	}
+	metadata, ok := template["metadata"].(map[string]interface{})
+	if !ok {
+		metadata = map[string]interface{}{}
+		template["metadata"] = metadata
+	}
+	addMissingStringMap(metadata, "labels", labels)
+	addMissingStringMap(metadata, "annotations", annotations)
+	mergeStringMap(metadata, "annotations", secrets)
+}
+
+// ApplyPropagatedMetadataToObject adds spec.labels and spec.annotations to the metadata of
+// a typed object, keeping keys that are already set.
+func ApplyPropagatedMetadataToObject(obj metav1.Object, md *airunwayv1alpha1.ModelDeployment) {
+	if labels := md.PropagatedLabels(); len(labels) > 0 {
+		obj.SetLabels(withMissing(obj.GetLabels(), labels))
+	}
+	if annotations := md.PropagatedAnnotations(); len(annotations) > 0 {
+		obj.SetAnnotations(withMissing(obj.GetAnnotations(), annotations))
+	}
+}
+
+// withMissing returns m with the values whose keys it does not have yet.
+func withMissing(m, values map[string]string) map[string]string {
+	if m == nil {
+		m = make(map[string]string, len(values))
+	}
+	for k, v := range values {
+		if _, found := m[k]; !found {
+			m[k] = v
+		}
+	}
+	return m
+}
+
+// addMissingStringMap adds the values whose keys are not yet in the string map stored
+// under key in obj.
+func addMissingStringMap(obj map[string]interface{}, key string, values map[string]string) {
+	if len(values) == 0 {
+		return
+	}
+	m, ok := obj[key].(map[string]interface{})
+	if !ok {
+		m = map[string]interface{}{}
+		obj[key] = m
+	}
+	for k, v := range values {
+		if _, found := m[k]; !found {
+			m[k] = v
+		}
+	}
+}
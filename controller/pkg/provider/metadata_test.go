@@ -0,0 +1,141 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+func newPropagationMD() *airunwayv1alpha1.ModelDeployment {
+	return &airunwayv1alpha1.ModelDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "team-a"},
+		Spec: airunwayv1alpha1.ModelDeploymentSpec{
+			Labels: map[string]string{
+				"cost-center":                    "ml-42",
+				"app":                            "user-app",
+				airunwayv1alpha1.LabelDeployment: "other",
+				"topology.kubernetes.io/zone":    "a",
+			},
+			Annotations: map[string]string{
+				"example.com/owner":                 "search-team",
+				"kubectl.kubernetes.io/restartedAt": "now",
+			},
+		},
+	}
+}
+
+func TestIsReservedMetadataKey(t *testing.T) {
+	tests := map[string]bool{
+		"cost-center":                 false,
+		"example.com/team":            false,
+		"airunway.ai/deployment":      true,
+		"kubernetes.io/arch":          true,
+		"topology.kubernetes.io/zone": true,
+		"k8s.io/foo":                  true,
+		"x.k8s.io/foo":                true,
+		"notkubernetes.io/foo":        false,
+	}
+	for key, want := range tests {
+		if got := airunwayv1alpha1.IsReservedMetadataKey(key); got != want {
+			t.Errorf("IsReservedMetadataKey(%q) = %v, want %v", key, got, want)
+		}
+	}
+}
+
+func TestApplyPropagatedMetadata(t *testing.T) {
+	md := newPropagationMD()
+	deploy := newObject("apps/v1", "Deployment", "llama")
+	deploy.SetLabels(map[string]string{"app": "llama"})
+	_ = unstructured.SetNestedField(deploy.Object, map[string]interface{}{
+		"metadata": map[string]interface{}{"labels": map[string]interface{}{"app": "llama"}},
+	}, "spec", "template")
+	workspace := newObject("kaito.sh/v1beta1", "Workspace", "llama")
+	_ = unstructured.SetNestedField(workspace.Object, map[string]interface{}{}, "spec", "template")
+	result := NewTransformResult(deploy, workspace)
+
+	ApplyPropagatedMetadata(result, md)
+
+	labels := deploy.GetLabels()
+	if labels["cost-center"] != "ml-42" || labels["app"] != "llama" {
+		t.Errorf("expected cost-center added without replacing app, got %v", labels)
+	}
+	if _, found := labels[airunwayv1alpha1.LabelDeployment]; found {
+		t.Errorf("expected reserved labels to be filtered, got %v", labels)
+	}
+	if _, found := labels["topology.kubernetes.io/zone"]; found {
+		t.Errorf("expected kubernetes.io subdomain labels to be filtered, got %v", labels)
+	}
+	if annotations := deploy.GetAnnotations(); len(annotations) != 1 || annotations["example.com/owner"] != "search-team" {
+		t.Errorf("expected only the unreserved annotation, got %v", annotations)
+	}
+	podLabels, _, _ := unstructured.NestedStringMap(deploy.Object, "spec", "template", "metadata", "labels")
+	if podLabels["cost-center"] != "ml-42" || podLabels["app"] != "llama" {
+		t.Errorf("expected the pod template to get propagated labels, got %v", podLabels)
+	}
+	if workspace.GetLabels()["cost-center"] != "ml-42" {
+		t.Errorf("expected every resource to get propagated labels, got %v", workspace.GetLabels())
+	}
+	if _, found, _ := unstructured.NestedMap(workspace.Object, "spec", "template", "metadata"); found {
+		t.Error("expected spec.template of a custom resource to be left to the provider")
+	}
+}
+
+func TestApplyPropagatedMetadataToObject(t *testing.T) {
+	md := newPropagationMD()
+	template := corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "llama"}}}
+	ApplyPropagatedMetadataToObject(&template, md)
+	if template.Labels["app"] != "llama" || template.Labels["cost-center"] != "ml-42" {
+		t.Errorf("unexpected labels %v", template.Labels)
+	}
+	if template.Annotations["example.com/owner"] != "search-team" {
+		t.Errorf("unexpected annotations %v", template.Annotations)
+	}
+
+	svc := &corev1.Service{}
+	ApplyPropagatedMetadataToObject(svc, &airunwayv1alpha1.ModelDeployment{})
+	if svc.Labels != nil || svc.Annotations != nil {
+		t.Errorf("expected no metadata without spec.labels, got %v %v", svc.Labels, svc.Annotations)
+	}
+}
+
+func TestApplyPropagatedMetadataToPodTemplate_SecretsChecksum(t *testing.T) {
+	md := &airunwayv1alpha1.ModelDeployment{
+		Spec:   airunwayv1alpha1.ModelDeploymentSpec{Secrets: &airunwayv1alpha1.SecretsSpec{HuggingFaceToken: "hf", RolloutOnChange: true}},
+		Status: airunwayv1alpha1.ModelDeploymentStatus{SecretsChecksum: "abc123"},
+	}
+	template := map[string]interface{}{
+		"metadata": map[string]interface{}{"annotations": map[string]interface{}{airunwayv1alpha1.AnnotationSecretsChecksum: "old"}},
+	}
+	ApplyPropagatedMetadataToPodTemplate(template, md)
+	annotations, _, _ := unstructured.NestedStringMap(template, "metadata", "annotations")
+	if annotations[airunwayv1alpha1.AnnotationSecretsChecksum] != "abc123" {
+		t.Errorf("expected the secrets checksum to be stamped, got %v", annotations)
+	}
+
+	md.Spec.Secrets.RolloutOnChange = false
+	template = map[string]interface{}{}
+	ApplyPropagatedMetadataToPodTemplate(template, md)
+	if len(template) != 0 {
+		t.Errorf("expected no checksum without rolloutOnChange, got %v", template)
+	}
+}
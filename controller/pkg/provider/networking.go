@@ -0,0 +1,59 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+// ApplyIPFamiliesToService sets the IP family policy and IP families of spec.networking
+// on a Service spec. Fields that are not set in spec.networking are left untouched, so
+// that the values defaulted by the API server are kept on update.
+func ApplyIPFamiliesToService(spec *corev1.ServiceSpec, md *airunwayv1alpha1.ModelDeployment) {
+	networking := md.Spec.Networking
+	if networking == nil {
+		return
+	}
+	if networking.IPFamilyPolicy != nil {
+		policy := *networking.IPFamilyPolicy
+		spec.IPFamilyPolicy = &policy
+	}
+	if len(networking.IPFamilies) > 0 {
+		spec.IPFamilies = append([]corev1.IPFamily(nil), networking.IPFamilies...)
+	}
+}
+
+// ApplyIPFamiliesToUnstructuredService is ApplyIPFamiliesToService for the spec map of an
+// unstructured Service.
+func ApplyIPFamiliesToUnstructuredService(spec map[string]interface{}, md *airunwayv1alpha1.ModelDeployment) {
+	networking := md.Spec.Networking
+	if networking == nil {
+		return
+	}
+	if networking.IPFamilyPolicy != nil {
+		spec["ipFamilyPolicy"] = string(*networking.IPFamilyPolicy)
+	}
+	if len(networking.IPFamilies) > 0 {
+		families := make([]interface{}, len(networking.IPFamilies))
+		for i, family := range networking.IPFamilies {
+			families[i] = string(family)
+		}
+		spec["ipFamilies"] = families
+	}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+func newNetworkingMD(networking *airunwayv1alpha1.NetworkingSpec) *airunwayv1alpha1.ModelDeployment {
+	return &airunwayv1alpha1.ModelDeployment{
+		Spec: airunwayv1alpha1.ModelDeploymentSpec{Networking: networking},
+	}
+}
+
+func TestApplyIPFamiliesToService(t *testing.T) {
+	defaulted := corev1.IPFamilyPolicySingleStack
+	spec := corev1.ServiceSpec{IPFamilyPolicy: &defaulted, IPFamilies: []corev1.IPFamily{corev1.IPv4Protocol}}
+	ApplyIPFamiliesToService(&spec, newNetworkingMD(nil))
+	if *spec.IPFamilyPolicy != corev1.IPFamilyPolicySingleStack || !slices.Equal(spec.IPFamilies, []corev1.IPFamily{corev1.IPv4Protocol}) {
+		t.Errorf("expected the defaulted IP families to be kept, got %v %v", *spec.IPFamilyPolicy, spec.IPFamilies)
+	}
+
+	policy := corev1.IPFamilyPolicyRequireDualStack
+	md := newNetworkingMD(&airunwayv1alpha1.NetworkingSpec{
+		IPFamilyPolicy: &policy,
+		IPFamilies:     []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol},
+	})
+	ApplyIPFamiliesToService(&spec, md)
+	if *spec.IPFamilyPolicy != corev1.IPFamilyPolicyRequireDualStack {
+		t.Errorf("expected RequireDualStack, got %v", *spec.IPFamilyPolicy)
+	}
+	if !slices.Equal(spec.IPFamilies, []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol}) {
+		t.Errorf("expected IPv6 then IPv4, got %v", spec.IPFamilies)
+	}
+	spec.IPFamilies[0] = corev1.IPv4Protocol
+	if md.Spec.Networking.IPFamilies[0] != corev1.IPv6Protocol {
+		t.Error("expected the Service to get a copy of spec.networking.ipFamilies")
+	}
+}
+
+func TestApplyIPFamiliesToUnstructuredService(t *testing.T) {
+	spec := map[string]interface{}{"type": "ClusterIP"}
+	ApplyIPFamiliesToUnstructuredService(spec, newNetworkingMD(nil))
+	if len(spec) != 1 {
+		t.Errorf("expected no IP family fields without spec.networking, got %v", spec)
+	}
+
+	ApplyIPFamiliesToUnstructuredService(spec, newNetworkingMD(&airunwayv1alpha1.NetworkingSpec{
+		IPFamilies: []corev1.IPFamily{corev1.IPv6Protocol},
+	}))
+	if _, found := spec["ipFamilyPolicy"]; found {
+		t.Error("expected no ipFamilyPolicy when it is not set")
+	}
+	families, _, _ := unstructured.NestedStringSlice(spec, "ipFamilies")
+	if !slices.Equal(families, []string{"IPv6"}) {
+		t.Errorf("expected [IPv6], got %v", families)
+	}
+}
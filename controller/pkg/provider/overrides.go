@@ -0,0 +1,93 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+// templateDelim marks a string value in spec.provider.overrides as a template.
+const templateDelim = "{{"
+
+// RenderOverrides returns spec.provider.overrides with every string value expanded as
+// a Go template against the ModelDeployment, e.g. "{{ .Name }}" or "{{ .Spec.Model.ID }}".
+// Keys are never expanded, so the set of fields an override touches is fixed at admission.
+// It returns nil when the deployment has no overrides.
+func RenderOverrides(md *airunwayv1alpha1.ModelDeployment) ([]byte, error) {
+	if md.Spec.Provider == nil || md.Spec.Provider.Overrides == nil {
+		return nil, nil
+	}
+	raw := md.Spec.Provider.Overrides.Raw
+	if !bytes.Contains(raw, []byte(templateDelim)) {
+		return raw, nil
+	}
+
+	var overrides interface{}
+	if err := json.Unmarshal(raw, &overrides); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal overrides: %w", err)
+	}
+	rendered, err := renderValue(overrides, md, "overrides")
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(rendered)
+}
+
+// renderValue expands templates in the string values of a decoded JSON value.
+func renderValue(value interface{}, md *airunwayv1alpha1.ModelDeployment, path string) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, elem := range v {
+			rendered, err := renderValue(elem, md, path+"."+key)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = rendered
+		}
+		return v, nil
+	case []interface{}:
+		for i, elem := range v {
+			rendered, err := renderValue(elem, md, fmt.Sprintf("%s[%d]", path, i))
+			if err != nil {
+				return nil, err
+			}
+			v[i] = rendered
+		}
+		return v, nil
+	case string:
+		if !strings.Contains(v, templateDelim) {
+			return v, nil
+		}
+		tmpl, err := template.New(path).Option("missingkey=error").Parse(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid template in %s: %w", path, err)
+		}
+		var out strings.Builder
+		if err := tmpl.Execute(&out, md); err != nil {
+			return nil, fmt.Errorf("failed to render template in %s: %w", path, err)
+		}
+		return out.String(), nil
+	default:
+		return v, nil
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+func newOverridesMD(overrides string) *airunwayv1alpha1.ModelDeployment {
+	md := &airunwayv1alpha1.ModelDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "team-a"},
+		Spec: airunwayv1alpha1.ModelDeploymentSpec{
+			Model: airunwayv1alpha1.ModelSpec{ID: "meta-llama/Llama-3.1-8B-Instruct"},
+		},
+	}
+	if overrides != "" {
+		md.Spec.Provider = &airunwayv1alpha1.ProviderSpec{
+			Overrides: &runtime.RawExtension{Raw: []byte(overrides)},
+		}
+	}
+	return md
+}
+
+func TestRenderOverrides(t *testing.T) {
+	md := newOverridesMD(`{
+		"labels": {"app": "{{ .Name }}", "team": "{{ .Namespace }}", "{{ .Name }}": "key"},
+		"args": ["--served-model-name={{ .Spec.Model.ID }}", 3],
+		"enabled": true
+	}`)
+
+	got, err := RenderOverrides(md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `{"args":["--served-model-name=meta-llama/Llama-3.1-8B-Instruct",3],"enabled":true,` +
+		`"labels":{"app":"llama","team":"team-a","{{ .Name }}":"key"}}`
+	if string(got) != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestRenderOverridesWithoutTemplates(t *testing.T) {
+	if got, err := RenderOverrides(newOverridesMD("")); err != nil || got != nil {
+		t.Errorf("expected nil for no overrides, got %s (err=%v)", got, err)
+	}
+
+	raw := `{"inference": {"preset": {"accessMode": "private"}}}`
+	got, err := RenderOverrides(newOverridesMD(raw))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(got) != raw {
+		t.Errorf("expected overrides without templates to be returned unchanged, got %s", got)
+	}
+}
+
+func TestRenderOverridesErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides string
+		wantErr   string
+	}{
+		{"invalid json", `{"a": "{{ .Name }}"`, "failed to unmarshal overrides"},
+		{"parse error", `{"a": {"b": "{{ .Name "}}`, "invalid template in overrides.a.b"},
+		{"unknown field", `{"a": ["{{ .Spec.Nope }}"]}`, "failed to render template in overrides.a[0]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := RenderOverrides(newOverridesMD(tt.overrides))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,399 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/openapi"
+)
+
+const (
+	// DefaultSchemaCacheTTL is how long a fetched OpenAPI schema document is reused before
+	// it is fetched again
+	DefaultSchemaCacheTTL = 5 * time.Minute
+
+	// ReasonOverridesValid is the OverridesValid condition reason when the overrides match
+	// the upstream schema
+	ReasonOverridesValid = "OverridesValid"
+	// ReasonInvalidOverrides is the condition reason when the overrides set fields the
+	// upstream schema does not declare, or values of the wrong type
+	ReasonInvalidOverrides = "InvalidOverrides"
+	// ReasonSchemaUnavailable is the OverridesValid condition reason when the upstream schema
+	// could not be fetched, so the overrides are applied unchecked
+	ReasonSchemaUnavailable = "SchemaUnavailable"
+
+	// maxRefDepth bounds the $ref chains followed when resolving a schema
+	maxRefDepth = 16
+	// schemaRefPrefix prefixes the references between schemas of a document
+	schemaRefPrefix = "#/components/schemas/"
+)
+
+// lenientSchemas are the schemas that accept both strings and numbers although the
+// published document declares only one of them
+var lenientSchemas = []string{
+	"io.k8s.apimachinery.pkg.api.resource.Quantity",
+	"io.k8s.apimachinery.pkg.util.intstr.IntOrString",
+}
+
+// overridesPath is the field path reported for errors in spec.provider.overrides
+var overridesPath = field.NewPath("spec", "provider", "overrides")
+
+// OverridesValidator checks spec.provider.overrides against the OpenAPI v3 schema the
+// cluster publishes for the upstream kind, so a misspelled field or a value of the wrong
+// type is reported with its field path before the merged object is applied, rather than
+// as a rejection of the whole object by the API server.
+type OverridesValidator struct {
+	// OpenAPI fetches the published schemas. When nil, overrides are not validated.
+	OpenAPI openapi.Client
+	// TTL is how long a schema document is cached, DefaultSchemaCacheTTL when zero
+	TTL time.Duration
+
+	mu   sync.Mutex
+	docs map[string]schemaDocument
+}
+
+// schemaDocument holds the schemas of one group/version, keyed by definition name
+type schemaDocument struct {
+	schemas map[string]map[string]interface{}
+	expires time.Time
+}
+
+// NewOverridesValidator returns a validator that fetches schemas through dc, or nil when
+// dc is nil.
+func NewOverridesValidator(dc discovery.DiscoveryInterface) *OverridesValidator {
+	if dc == nil {
+		return nil
+	}
+	return &OverridesValidator{OpenAPI: dc.OpenAPIV3()}
+}
+
+// Validate returns the field errors of the rendered overrides of md against the schema of
+// gvk. It returns no errors when md has no overrides or the cluster publishes no schema for
+// gvk, and an error when the schema cannot be fetched.
+func (v *OverridesValidator) Validate(md *airunwayv1alpha1.ModelDeployment, gvk schema.GroupVersionKind) (field.ErrorList, error) {
+	raw, err := RenderOverrides(md)
+	if err != nil || raw == nil {
+		return nil, err
+	}
+	var overrides interface{}
+	if err := json.Unmarshal(raw, &overrides); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal overrides: %w", err)
+	}
+
+	schemas, err := v.schemas(gvk.GroupVersion())
+	if err != nil {
+		return nil, err
+	}
+	root := kindSchema(schemas, gvk)
+	if root == nil {
+		return nil, nil
+	}
+	w := &schemaWalker{schemas: schemas}
+	return w.validate(overrides, root, overridesPath), nil
+}
+
+// Check validates the overrides of md against the schema of obj's kind and records the
+// OverridesValid condition. It returns an error naming the invalid field paths when the
+// overrides do not match, so the provider can fail the deployment instead of applying obj.
+// When the schema cannot be fetched the condition is Unknown and nil is returned, leaving
+// the API server to judge the merged object. A nil validator checks nothing.
+func (v *OverridesValidator) Check(md *airunwayv1alpha1.ModelDeployment, obj *unstructured.Unstructured) error {
+	if v == nil || v.OpenAPI == nil || obj == nil {
+		return nil
+	}
+	if md.Spec.Provider == nil || md.Spec.Provider.Overrides == nil {
+		meta.RemoveStatusCondition(&md.Status.Conditions, airunwayv1alpha1.ConditionTypeOverridesValid)
+		return nil
+	}
+
+	cond := metav1.Condition{
+		Type:               airunwayv1alpha1.ConditionTypeOverridesValid,
+		ObservedGeneration: md.Generation,
+	}
+	gvk := obj.GroupVersionKind()
+	errs, err := v.Validate(md, gvk)
+	switch {
+	case err != nil:
+		cond.Status, cond.Reason = metav1.ConditionUnknown, ReasonSchemaUnavailable
+		cond.Message = fmt.Sprintf("Failed to fetch the %s schema: %v", gvk.Kind, err)
+	case len(errs) > 0:
+		err = fmt.Errorf("overrides do not match the %s schema: %w", gvk.Kind, errs.ToAggregate())
+		cond.Status, cond.Reason, cond.Message = metav1.ConditionFalse, ReasonInvalidOverrides, err.Error()
+	default:
+		cond.Status, cond.Reason = metav1.ConditionTrue, ReasonOverridesValid
+		cond.Message = fmt.Sprintf("Overrides match the %s %s schema", gvk.GroupVersion(), gvk.Kind)
+	}
+	meta.SetStatusCondition(&md.Status.Conditions, cond)
+	if cond.Status == metav1.ConditionFalse {
+		return err
+	}
+	return nil
+}
+
+// schemas returns the schema definitions the cluster publishes for gv, cached for TTL
+func (v *OverridesValidator) schemas(gv schema.GroupVersion) (map[string]map[string]interface{}, error) {
+	key := "apis/" + gv.String()
+	if gv.Group == "" {
+		key = "api/" + gv.Version
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if doc, ok := v.docs[key]; ok && time.Now().Before(doc.expires) {
+		return doc.schemas, nil
+	}
+
+	paths, err := v.OpenAPI.Paths()
+	if err != nil {
+		return nil, err
+	}
+	var doc struct {
+		Components struct {
+			Schemas map[string]map[string]interface{} `json:"schemas"`
+		} `json:"components"`
+	}
+	if gvPath, ok := paths[key]; ok {
+		data, err := gvPath.Schema("application/json")
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("failed to decode the %s schema: %w", gv, err)
+		}
+	}
+
+	ttl := v.TTL
+	if ttl == 0 {
+		ttl = DefaultSchemaCacheTTL
+	}
+	if v.docs == nil {
+		v.docs = map[string]schemaDocument{}
+	}
+	v.docs[key] = schemaDocument{schemas: doc.Components.Schemas, expires: time.Now().Add(ttl)}
+	return doc.Components.Schemas, nil
+}
+
+// kindSchema returns the schema tagged with gvk, or nil when the document has none
+func kindSchema(schemas map[string]map[string]interface{}, gvk schema.GroupVersionKind) map[string]interface{} {
+	for _, s := range schemas {
+		tags, _ := s["x-kubernetes-group-version-kind"].([]interface{})
+		for _, tag := range tags {
+			t, _ := tag.(map[string]interface{})
+			if t["group"] == gvk.Group && t["version"] == gvk.Version && t["kind"] == gvk.Kind {
+				return s
+			}
+		}
+	}
+	return nil
+}
+
+// schemaWalker validates decoded JSON values against the schemas of one document. It
+// checks declared fields, types and enums, and accepts whatever the schema leaves open.
+type schemaWalker struct {
+	schemas map[string]map[string]interface{}
+}
+
+// resolve follows $ref and returns the referenced schema and its definition name
+func (w *schemaWalker) resolve(s map[string]interface{}) (map[string]interface{}, string) {
+	name := ""
+	for range maxRefDepth {
+		ref, ok := s["$ref"].(string)
+		if !ok {
+			return s, name
+		}
+		name = strings.TrimPrefix(ref, schemaRefPrefix)
+		target, ok := w.schemas[name]
+		if !ok {
+			return nil, name
+		}
+		s = target
+	}
+	return nil, name
+}
+
+func (w *schemaWalker) validate(value interface{}, s map[string]interface{}, path *field.Path) field.ErrorList {
+	// A null override deletes the field, so it matches any schema
+	if value == nil {
+		return nil
+	}
+	s, name := w.resolve(s)
+	if s == nil {
+		return nil
+	}
+
+	var errs field.ErrorList
+	for _, sub := range schemaList(s["allOf"]) {
+		errs = append(errs, w.validate(value, sub, path)...)
+	}
+	for _, key := range []string{"anyOf", "oneOf"} {
+		if branches := schemaList(s[key]); len(branches) > 0 {
+			errs = append(errs, w.validateAny(value, branches, path)...)
+		}
+	}
+
+	if slices.Contains(lenientSchemas, name) || s["x-kubernetes-int-or-string"] == true || s["format"] == "int-or-string" {
+		switch value.(type) {
+		case string, float64:
+			return errs
+		}
+		return append(errs, field.Invalid(path, jsonType(value), "must be an integer or a string"))
+	}
+
+	typ, _ := s["type"].(string)
+	properties, _ := s["properties"].(map[string]interface{})
+	if typ == "" && len(properties) > 0 {
+		typ = "object"
+	}
+	switch typ {
+	case "object":
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return append(errs, typeError(path, value, typ))
+		}
+		errs = append(errs, w.validateObject(obj, s, properties, path)...)
+	case "array":
+		list, ok := value.([]interface{})
+		if !ok {
+			return append(errs, typeError(path, value, typ))
+		}
+		if items, ok := s["items"].(map[string]interface{}); ok {
+			for i, item := range list {
+				errs = append(errs, w.validate(item, items, path.Index(i))...)
+			}
+		}
+	case "string", "boolean", "number", "integer":
+		if !scalarMatches(value, typ) {
+			return append(errs, typeError(path, value, typ))
+		}
+	}
+
+	if enum, ok := s["enum"].([]interface{}); ok && len(enum) > 0 && !slices.Contains(enum, value) {
+		var allowed []string
+		for _, e := range enum {
+			allowed = append(allowed, fmt.Sprint(e))
+		}
+		errs = append(errs, field.NotSupported(path, value, allowed))
+	}
+	return errs
+}
+
+// validateObject checks the fields of obj against the declared properties, or against
+// additionalProperties for maps. Fields of objects that declare no properties, or that
+// preserve unknown fields, are accepted.
+func (w *schemaWalker) validateObject(obj map[string]interface{}, s, properties map[string]interface{}, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	additional := s["additionalProperties"]
+	open := len(properties) == 0 || s["x-kubernetes-preserve-unknown-fields"] == true || additional == true
+
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		if prop, ok := properties[key].(map[string]interface{}); ok {
+			errs = append(errs, w.validate(obj[key], prop, path.Child(key))...)
+			continue
+		}
+		if extra, ok := additional.(map[string]interface{}); ok {
+			errs = append(errs, w.validate(obj[key], extra, path.Key(key))...)
+			continue
+		}
+		if !open {
+			errs = append(errs, field.Forbidden(path.Child(key), "unknown field"))
+		}
+	}
+	return errs
+}
+
+// validateAny returns the errors of the first branch when value matches none of them
+func (w *schemaWalker) validateAny(value interface{}, branches []map[string]interface{}, path *field.Path) field.ErrorList {
+	var first field.ErrorList
+	for i, branch := range branches {
+		errs := w.validate(value, branch, path)
+		if len(errs) == 0 {
+			return nil
+		}
+		if i == 0 {
+			first = errs
+		}
+	}
+	return first
+}
+
+// schemaList returns the schemas of an allOf, anyOf or oneOf list
+func schemaList(v interface{}) []map[string]interface{} {
+	list, _ := v.([]interface{})
+	var schemas []map[string]interface{}
+	for _, item := range list {
+		if s, ok := item.(map[string]interface{}); ok {
+			schemas = append(schemas, s)
+		}
+	}
+	return schemas
+}
+
+// scalarMatches reports whether a decoded JSON scalar has the OpenAPI type typ
+func scalarMatches(value interface{}, typ string) bool {
+	switch v := value.(type) {
+	case string:
+		return typ == "string"
+	case bool:
+		return typ == "boolean"
+	case float64:
+		return typ == "number" || (typ == "integer" && v == math.Trunc(v))
+	}
+	return false
+}
+
+func typeError(path *field.Path, value interface{}, typ string) *field.Error {
+	return field.Invalid(path, jsonType(value), fmt.Sprintf("must be of type %s", typ))
+}
+
+// jsonType names the JSON type of a decoded value, so errors do not echo whole objects
+func jsonType(value interface{}) string {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	}
+	return fmt.Sprintf("%T", value)
+}
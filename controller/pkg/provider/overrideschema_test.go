@@ -0,0 +1,261 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/openapi"
+	"k8s.io/client-go/openapi/openapitest"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+// workspaceSchemaDoc is a trimmed OpenAPI v3 document as published for kaito.sh/v1beta1
+const workspaceSchemaDoc = `{"components": {"schemas": {
+	"sh.kaito.v1beta1.Workspace": {
+		"type": "object",
+		"x-kubernetes-group-version-kind": [{"group": "kaito.sh", "version": "v1beta1", "kind": "Workspace"}],
+		"properties": {
+			"apiVersion": {"type": "string"},
+			"kind": {"type": "string"},
+			"metadata": {"allOf": [{"$ref": "#/components/schemas/io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta"}]},
+			"resource": {
+				"type": "object",
+				"properties": {
+					"count": {"type": "integer"},
+					"instanceType": {"type": "string"},
+					"labelSelector": {"type": "object", "x-kubernetes-preserve-unknown-fields": true, "properties": {"matchLabels": {"type": "object", "additionalProperties": {"type": "string"}}}}
+				}
+			},
+			"inference": {
+				"type": "object",
+				"properties": {
+					"preset": {"type": "object", "properties": {"name": {"type": "string"}, "accessMode": {"type": "string", "enum": ["public", "private"]}}},
+					"adapters": {"type": "array", "items": {"type": "object", "properties": {"source": {"type": "object", "properties": {"name": {"type": "string"}}}}}},
+					"config": {"anyOf": [{"type": "integer"}, {"type": "string"}], "x-kubernetes-int-or-string": true},
+					"template": {"type": "object", "x-kubernetes-preserve-unknown-fields": true}
+				}
+			}
+		}
+	},
+	"io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta": {"type": "object", "properties": {"name": {"type": "string"}}}
+}}}`
+
+func newWorkspaceValidator(doc string) *OverridesValidator {
+	client := openapitest.NewFakeClient()
+	client.PathsMap["apis/kaito.sh/v1beta1"] = openapitest.FakeGroupVersion{GVSpec: []byte(doc)}
+	return &OverridesValidator{OpenAPI: client}
+}
+
+func newWorkspaceObject() *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("kaito.sh/v1beta1")
+	obj.SetKind("Workspace")
+	return obj
+}
+
+func TestOverridesValidatorValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides string
+		want      []string
+	}{
+		{name: "no overrides"},
+		{
+			name:      "declared fields",
+			overrides: `{"resource": {"count": 2, "instanceType": "Standard_NC24ads_A100_v4"}, "inference": {"preset": {"accessMode": "private"}}}`,
+		},
+		{
+			name:      "unknown field",
+			overrides: `{"resource": {"instancetype": "Standard_NC24ads_A100_v4"}}`,
+			want:      []string{"spec.provider.overrides.resource.instancetype: Forbidden: unknown field"},
+		},
+		{
+			name:      "wrong scalar type",
+			overrides: `{"resource": {"count": "2"}}`,
+			want:      []string{`spec.provider.overrides.resource.count: Invalid value: "string": must be of type integer`},
+		},
+		{
+			name:      "fractional integer",
+			overrides: `{"resource": {"count": 1.5}}`,
+			want:      []string{`spec.provider.overrides.resource.count: Invalid value: "number": must be of type integer`},
+		},
+		{
+			name:      "object instead of string",
+			overrides: `{"inference": {"preset": {"name": {"id": "llama"}}}}`,
+			want:      []string{`spec.provider.overrides.inference.preset.name: Invalid value: "object": must be of type string`},
+		},
+		{
+			name:      "enum",
+			overrides: `{"inference": {"preset": {"accessMode": "internal"}}}`,
+			want:      []string{`spec.provider.overrides.inference.preset.accessMode: Unsupported value: "internal": supported values: "public", "private"`},
+		},
+		{
+			name:      "array items",
+			overrides: `{"inference": {"adapters": [{"source": {"name": "a"}}, {"source": {"nme": "b"}}]}}`,
+			want:      []string{"spec.provider.overrides.inference.adapters[1].source.nme: Forbidden: unknown field"},
+		},
+		{
+			name:      "map values",
+			overrides: `{"resource": {"labelSelector": {"matchLabels": {"apps": "llama", "tier": 1}}}}`,
+			want:      []string{`spec.provider.overrides.resource.labelSelector.matchLabels[tier]: Invalid value: "integer": must be of type string`},
+		},
+		{
+			name:      "int or string",
+			overrides: `{"inference": {"config": 3}}`,
+		},
+		{
+			name:      "preserved unknown fields",
+			overrides: `{"inference": {"template": {"spec": {"containers": []}}}, "resource": {"labelSelector": {"matchExpressions": []}}}`,
+		},
+		{
+			name:      "referenced schema",
+			overrides: `{"metadata": {"nam": "llama"}}`,
+			want:      []string{"spec.provider.overrides.metadata.nam: Forbidden: unknown field"},
+		},
+		{
+			name:      "null deletes any field",
+			overrides: `{"resource": {"count": null}}`,
+		},
+		{
+			name:      "several errors",
+			overrides: `{"resource": {"count": true}, "tuning": {}}`,
+			want: []string{
+				`spec.provider.overrides.resource.count: Invalid value: "boolean": must be of type integer`,
+				"spec.provider.overrides.tuning: Forbidden: unknown field",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := newWorkspaceValidator(workspaceSchemaDoc)
+			errs, err := v.Validate(newOverridesMD(tt.overrides), newWorkspaceObject().GroupVersionKind())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var got []string
+			for _, e := range errs {
+				got = append(got, e.Error())
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("expected errors %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestOverridesValidatorValidateUnknownKind(t *testing.T) {
+	v := newWorkspaceValidator(workspaceSchemaDoc)
+	obj := newWorkspaceObject()
+	obj.SetAPIVersion("kaito.sh/v1alpha1")
+	errs, err := v.Validate(newOverridesMD(`{"anything": 1}`), obj.GroupVersionKind())
+	if err != nil || len(errs) != 0 {
+		t.Errorf("expected no errors for an unpublished schema, got %v, %v", errs, err)
+	}
+}
+
+func TestOverridesValidatorCheck(t *testing.T) {
+	tests := []struct {
+		name       string
+		validator  *OverridesValidator
+		overrides  string
+		wantErr    bool
+		wantStatus metav1.ConditionStatus
+		wantReason string
+	}{
+		{name: "nil validator", overrides: `{"tuning": {}}`},
+		{name: "no overrides", validator: newWorkspaceValidator(workspaceSchemaDoc)},
+		{
+			name:       "valid",
+			validator:  newWorkspaceValidator(workspaceSchemaDoc),
+			overrides:  `{"resource": {"count": 2}}`,
+			wantStatus: metav1.ConditionTrue,
+			wantReason: ReasonOverridesValid,
+		},
+		{
+			name:       "invalid",
+			validator:  newWorkspaceValidator(workspaceSchemaDoc),
+			overrides:  `{"tuning": {}}`,
+			wantErr:    true,
+			wantStatus: metav1.ConditionFalse,
+			wantReason: ReasonInvalidOverrides,
+		},
+		{
+			name:       "schema unavailable",
+			validator:  &OverridesValidator{OpenAPI: &openapitest.FakeClient{ForcedErr: errors.New("connection refused")}},
+			overrides:  `{"tuning": {}}`,
+			wantStatus: metav1.ConditionUnknown,
+			wantReason: ReasonSchemaUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md := newOverridesMD(tt.overrides)
+			err := tt.validator.Check(md, newWorkspaceObject())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			cond := meta.FindStatusCondition(md.Status.Conditions, airunwayv1alpha1.ConditionTypeOverridesValid)
+			if tt.wantStatus == "" {
+				if cond != nil {
+					t.Fatalf("expected no condition, got %+v", cond)
+				}
+				return
+			}
+			if cond == nil || cond.Status != tt.wantStatus || cond.Reason != tt.wantReason {
+				t.Fatalf("expected %s/%s, got %+v", tt.wantStatus, tt.wantReason, cond)
+			}
+			if tt.wantErr && !strings.Contains(cond.Message, "spec.provider.overrides.tuning") {
+				t.Errorf("expected the field path in the message, got %q", cond.Message)
+			}
+		})
+	}
+}
+
+func TestOverridesValidatorCachesSchema(t *testing.T) {
+	calls := 0
+	client := openapitest.NewFakeClient()
+	client.PathsMap["apis/kaito.sh/v1beta1"] = countingGroupVersion{
+		GroupVersion: openapitest.FakeGroupVersion{GVSpec: []byte(workspaceSchemaDoc)},
+		calls:        &calls,
+	}
+	v := &OverridesValidator{OpenAPI: client}
+	for range 3 {
+		if _, err := v.Validate(newOverridesMD(`{"resource": {}}`), newWorkspaceObject().GroupVersionKind()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("expected the schema to be fetched once, got %d", calls)
+	}
+}
+
+type countingGroupVersion struct {
+	openapi.GroupVersion
+	calls *int
+}
+
+func (g countingGroupVersion) Schema(contentType string) ([]byte, error) {
+	*g.calls++
+	return g.GroupVersion.Schema(contentType)
+}
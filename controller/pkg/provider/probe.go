@@ -0,0 +1,102 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+const (
+	// ReasonUpstreamCRDsInstalled is the UpstreamInstalled condition reason when every
+	// upstream CRD is served
+	ReasonUpstreamCRDsInstalled = "CRDsInstalled"
+	// ReasonUpstreamCRDsMissing is the UpstreamInstalled condition reason when an upstream
+	// CRD is not served, so resources the provider creates would be rejected
+	ReasonUpstreamCRDsMissing = "CRDsMissing"
+	// ReasonUpstreamProbeFailed is the UpstreamInstalled condition reason when the cluster
+	// could not be probed
+	ReasonUpstreamProbeFailed = "ProbeFailed"
+)
+
+// UpstreamCRD identifies an upstream resource a provider creates
+type UpstreamCRD struct {
+	// Group is the API group of the resource, e.g. ray.io
+	Group string
+	// Resource is the plural resource name, e.g. rayservices
+	Resource string
+	// Kind is the kind of the resource, e.g. RayService
+	Kind string
+}
+
+// String returns the CRD name, e.g. rayservices.ray.io
+func (c UpstreamCRD) String() string {
+	return c.Resource + "." + c.Group
+}
+
+// MissingUpstreamCRDs returns the crds the cluster serves in no version. It uses fresh
+// discovery results when dc is set, so CRDs installed or removed after startup are noticed,
+// and falls back to mapper otherwise.
+func MissingUpstreamCRDs(dc discovery.DiscoveryInterface, mapper meta.RESTMapper, crds ...UpstreamCRD) ([]UpstreamCRD, error) {
+	var missing []UpstreamCRD
+	for _, crd := range crds {
+		var served []string
+		switch {
+		case dc != nil:
+			var err error
+			if served, err = ServedVersions(dc, crd.Group, crd.Resource); err != nil {
+				return nil, fmt.Errorf("failed to discover %s: %w", crd, err)
+			}
+		case mapper != nil:
+			served = MappedVersions(mapper, schema.GroupKind{Group: crd.Group, Kind: crd.Kind})
+		}
+		if len(served) == 0 {
+			missing = append(missing, crd)
+		}
+	}
+	return missing, nil
+}
+
+// SetUpstreamInstalledCondition records the UpstreamInstalled condition on status from the
+// result of MissingUpstreamCRDs and returns false when the provider cannot create its
+// resources, either because CRDs are missing or because probing failed.
+func SetUpstreamInstalledCondition(status *airunwayv1alpha1.InferenceProviderConfigStatus, missing []UpstreamCRD, probeErr error) bool {
+	cond := metav1.Condition{Type: airunwayv1alpha1.ConditionTypeUpstreamInstalled}
+	switch {
+	case probeErr != nil:
+		cond.Status, cond.Reason, cond.Message = metav1.ConditionUnknown, ReasonUpstreamProbeFailed, probeErr.Error()
+	case len(missing) > 0:
+		names := make([]string, 0, len(missing))
+		for _, crd := range missing {
+			names = append(names, fmt.Sprintf("%s (%s)", crd.Kind, crd))
+		}
+		cond.Status, cond.Reason = metav1.ConditionFalse, ReasonUpstreamCRDsMissing
+		cond.Message = fmt.Sprintf("Upstream CRDs not installed: %s. Install the upstream operator to use this provider",
+			strings.Join(names, ", "))
+	default:
+		cond.Status, cond.Reason, cond.Message = metav1.ConditionTrue, ReasonUpstreamCRDsInstalled, "Upstream CRDs are installed"
+	}
+	meta.SetStatusCondition(&status.Conditions, cond)
+	return cond.Status == metav1.ConditionTrue
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+var rayServiceCRD = UpstreamCRD{Group: "ray.io", Resource: "rayservices", Kind: "RayService"}
+
+func TestMissingUpstreamCRDs(t *testing.T) {
+	dc := &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{}}
+	workspaces := UpstreamCRD{Group: "kaito.sh", Resource: "workspaces", Kind: "Workspace"}
+	dc.Resources = []*metav1.APIResourceList{{
+		GroupVersion: "kaito.sh/v1beta1",
+		APIResources: []metav1.APIResource{{Name: "workspaces"}},
+	}}
+
+	missing, err := MissingUpstreamCRDs(dc, nil, workspaces, rayServiceCRD)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(missing) != 1 || missing[0] != rayServiceCRD {
+		t.Errorf("expected only RayService to be missing, got %v", missing)
+	}
+
+	// Removal is noticed on the next probe
+	dc.Resources = nil
+	if missing, _ := MissingUpstreamCRDs(dc, nil, workspaces); len(missing) != 1 {
+		t.Errorf("expected Workspace removal to be detected, got %v", missing)
+	}
+
+	// Without a discovery client the RESTMapper is used
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{{Group: "ray.io", Version: "v1"}})
+	mapper.Add(schema.GroupVersionKind{Group: "ray.io", Version: "v1", Kind: "RayService"}, meta.RESTScopeNamespace)
+	if missing, _ := MissingUpstreamCRDs(nil, mapper, rayServiceCRD); len(missing) != 0 {
+		t.Errorf("expected RayService to be found in the RESTMapper, got %v", missing)
+	}
+}
+
+func TestSetUpstreamInstalledCondition(t *testing.T) {
+	status := &airunwayv1alpha1.InferenceProviderConfigStatus{}
+
+	if SetUpstreamInstalledCondition(status, []UpstreamCRD{rayServiceCRD}, nil) {
+		t.Error("expected a missing CRD to make the provider not ready")
+	}
+	cond := meta.FindStatusCondition(status.Conditions, airunwayv1alpha1.ConditionTypeUpstreamInstalled)
+	if cond == nil || cond.Reason != ReasonUpstreamCRDsMissing || !strings.Contains(cond.Message, "RayService (rayservices.ray.io)") {
+		t.Errorf("expected %s naming the CRD, got %+v", ReasonUpstreamCRDsMissing, cond)
+	}
+
+	if SetUpstreamInstalledCondition(status, nil, errors.New("connection refused")) {
+		t.Error("expected a failed probe to make the provider not ready")
+	}
+	if cond := meta.FindStatusCondition(status.Conditions, airunwayv1alpha1.ConditionTypeUpstreamInstalled); cond.Status != metav1.ConditionUnknown {
+		t.Errorf("expected Unknown after a failed probe, got %+v", cond)
+	}
+
+	if !SetUpstreamInstalledCondition(status, nil, nil) {
+		t.Error("expected installed CRDs to leave the provider ready")
+	}
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"time"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// Released reports whether the provider named name must delete its resources for md and
+// remove its finalizer although md may no longer select it: md still carries the
+// provider's finalizer and is either being deleted, or a spec.provider.migrateTo migration
+// moved it to another provider.
+func Released(md *airunwayv1alpha1.ModelDeployment, name, finalizer string) bool {
+	if !controllerutil.ContainsFinalizer(md, finalizer) {
+		return false
+	}
+	return !md.DeletionTimestamp.IsZero() || md.ProviderReleased(name)
+}
+
+// CleanupStart returns when the provider's cleanup of md began, which providers measure
+// their finalizer timeout from: the deletion timestamp, or the last migration phase
+// change when a migration released the provider. It returns the current time when
+// neither is known.
+func CleanupStart(md *airunwayv1alpha1.ModelDeployment) time.Time {
+	if !md.DeletionTimestamp.IsZero() {
+		return md.DeletionTimestamp.Time
+	}
+	if md.Status.Migration != nil && md.Status.Migration.LastTransitionTime != nil {
+		return md.Status.Migration.LastTransitionTime.Time
+	}
+	return time.Now()
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"testing"
+	"time"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestReleased(t *testing.T) {
+	const finalizer = "airunway.ai/kaito-provider"
+	migrating := func(phase airunwayv1alpha1.MigrationPhase) *airunwayv1alpha1.ModelDeployment {
+		return &airunwayv1alpha1.ModelDeployment{
+			ObjectMeta: metav1.ObjectMeta{Finalizers: []string{finalizer}},
+			Status: airunwayv1alpha1.ModelDeploymentStatus{
+				Provider:  &airunwayv1alpha1.ProviderStatus{Name: "llmd"},
+				Migration: &airunwayv1alpha1.MigrationStatus{From: "kaito", To: "llmd", Phase: phase},
+			},
+		}
+	}
+
+	tests := []struct {
+		name string
+		md   *airunwayv1alpha1.ModelDeployment
+		want bool
+	}{
+		{"still serving while the new provider comes up", migrating(airunwayv1alpha1.MigrationPhaseProvisioning), false},
+		{"still serving until the gateway switched", migrating(airunwayv1alpha1.MigrationPhaseSwitching), false},
+		{"draining", migrating(airunwayv1alpha1.MigrationPhaseDraining), true},
+		{"no finalizer", func() *airunwayv1alpha1.ModelDeployment {
+			md := migrating(airunwayv1alpha1.MigrationPhaseDraining)
+			md.Finalizers = nil
+			return md
+		}(), false},
+		{"deleted mid-migration", func() *airunwayv1alpha1.ModelDeployment {
+			md := migrating(airunwayv1alpha1.MigrationPhaseProvisioning)
+			md.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			return md
+		}(), true},
+		{"rolled back migration to this provider", func() *airunwayv1alpha1.ModelDeployment {
+			md := migrating(airunwayv1alpha1.MigrationPhaseFailed)
+			md.Status.Migration = &airunwayv1alpha1.MigrationStatus{From: "llmd", To: "kaito", Phase: airunwayv1alpha1.MigrationPhaseFailed}
+			return md
+		}(), true},
+		{"selected provider", func() *airunwayv1alpha1.ModelDeployment {
+			md := migrating(airunwayv1alpha1.MigrationPhaseCompleted)
+			md.Status.Provider.Name = "kaito"
+			md.Status.Migration = nil
+			return md
+		}(), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Released(tt.md, "kaito", finalizer); got != tt.want {
+				t.Errorf("Released() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCleanupStart(t *testing.T) {
+	switched := time.Now().Add(-time.Hour).Truncate(time.Second)
+	md := &airunwayv1alpha1.ModelDeployment{}
+	md.Status.Migration = &airunwayv1alpha1.MigrationStatus{LastTransitionTime: &metav1.Time{Time: switched}}
+	if got := CleanupStart(md); !got.Equal(switched) {
+		t.Errorf("expected the migration transition time, got %v", got)
+	}
+	deleted := switched.Add(time.Minute)
+	md.DeletionTimestamp = &metav1.Time{Time: deleted}
+	if got := CleanupStart(md); !got.Equal(deleted) {
+		t.Errorf("expected the deletion timestamp, got %v", got)
+	}
+}
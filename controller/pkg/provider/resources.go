@@ -0,0 +1,44 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+// ApplyResourceQuantities sets the explicit cpu and memory of requests and limits on the
+// container requests and limits a transformer derived from the cpu and memory shorthand,
+// so explicit quantities take precedence. Nil maps are allocated when a quantity is set.
+func ApplyResourceQuantities(requests, limits map[string]interface{}, explicitRequests, explicitLimits *airunwayv1alpha1.ResourceQuantities) (map[string]interface{}, map[string]interface{}) {
+	return setResourceQuantities(requests, explicitRequests), setResourceQuantities(limits, explicitLimits)
+}
+
+func setResourceQuantities(resources map[string]interface{}, q *airunwayv1alpha1.ResourceQuantities) map[string]interface{} {
+	if q == nil {
+		return resources
+	}
+	for name, value := range map[string]string{"cpu": q.CPU, "memory": q.Memory} {
+		if value == "" {
+			continue
+		}
+		if resources == nil {
+			resources = map[string]interface{}{}
+		}
+		resources[name] = value
+	}
+	return resources
+}
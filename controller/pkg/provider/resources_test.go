@@ -0,0 +1,44 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+func TestApplyResourceQuantities(t *testing.T) {
+	requests, limits := ApplyResourceQuantities(
+		nil,
+		map[string]interface{}{"memory": "16Gi", "gpu": "1"},
+		&airunwayv1alpha1.ResourceQuantities{CPU: "2", Memory: "8Gi"},
+		&airunwayv1alpha1.ResourceQuantities{Memory: "32Gi"},
+	)
+	if want := map[string]interface{}{"cpu": "2", "memory": "8Gi"}; !reflect.DeepEqual(requests, want) {
+		t.Errorf("expected requests %v, got %v", want, requests)
+	}
+	if want := map[string]interface{}{"memory": "32Gi", "gpu": "1"}; !reflect.DeepEqual(limits, want) {
+		t.Errorf("expected limits %v, got %v", want, limits)
+	}
+
+	requests, limits = ApplyResourceQuantities(nil, nil, nil, &airunwayv1alpha1.ResourceQuantities{})
+	if requests != nil || limits != nil {
+		t.Errorf("expected nil maps without explicit quantities, got %v and %v", requests, limits)
+	}
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+// RevisionArgs returns the vllm and sglang flags for spec.model.revision.
+func RevisionArgs(md *airunwayv1alpha1.ModelDeployment) []string {
+	if md.Spec.Model.Revision == "" {
+		return nil
+	}
+	switch md.ResolvedEngineType() {
+	case airunwayv1alpha1.EngineTypeVLLM, airunwayv1alpha1.EngineTypeSGLang:
+		return []string{"--revision", md.Spec.Model.Revision}
+	}
+	return nil
+}
+
+// RevisionRequested reports whether spec.model.revision asks for a specific branch, tag,
+// or commit, rather than being the default branch the admission webhook pinned. Providers
+// that cannot select a revision reject requested ones and ignore pinned ones.
+func RevisionRequested(md *airunwayv1alpha1.ModelDeployment) bool {
+	if md.Spec.Model.Revision == "" {
+		return false
+	}
+	ref, pinned := md.Annotations[airunwayv1alpha1.AnnotationModelRevisionRef]
+	return !pinned || ref != ""
+}
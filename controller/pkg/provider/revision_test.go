@@ -0,0 +1,62 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"slices"
+	"testing"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+func TestRevisionArgs(t *testing.T) {
+	md := newChatTemplateMD(airunwayv1alpha1.EngineTypeVLLM, nil, "")
+	if got := RevisionArgs(md); got != nil {
+		t.Errorf("expected no flags without a revision, got %v", got)
+	}
+	md.Spec.Model.Revision = "v1.0"
+	if got := RevisionArgs(md); !slices.Equal(got, []string{"--revision", "v1.0"}) {
+		t.Errorf("expected --revision for vllm, got %v", got)
+	}
+	md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeSGLang
+	if got := RevisionArgs(md); !slices.Equal(got, []string{"--revision", "v1.0"}) {
+		t.Errorf("expected --revision for sglang, got %v", got)
+	}
+	md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeLlamaCpp
+	if got := RevisionArgs(md); got != nil {
+		t.Errorf("expected no flags for llamacpp, got %v", got)
+	}
+}
+
+func TestRevisionRequested(t *testing.T) {
+	md := newChatTemplateMD(airunwayv1alpha1.EngineTypeVLLM, nil, "")
+	if RevisionRequested(md) {
+		t.Error("expected no revision to be requested")
+	}
+	md.Spec.Model.Revision = "0123456789abcdef0123456789abcdef01234567"
+	if !RevisionRequested(md) {
+		t.Error("expected an explicit revision to be requested")
+	}
+	md.Annotations = map[string]string{airunwayv1alpha1.AnnotationModelRevisionRef: ""}
+	if RevisionRequested(md) {
+		t.Error("expected a pinned default branch not to be requested")
+	}
+	md.Annotations[airunwayv1alpha1.AnnotationModelRevisionRef] = "v1.0"
+	if !RevisionRequested(md) {
+		t.Error("expected a pinned tag to be requested")
+	}
+}
@@ -0,0 +1,287 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"fmt"
+	"strconv"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+// Labels, annotations, and resources the supported batch schedulers read to form a gang.
+const (
+	KueueQueueNameLabel          = "kueue.x-k8s.io/queue-name"
+	KueuePodGroupNameLabel       = "kueue.x-k8s.io/pod-group-name"
+	KueuePodGroupTotalCountAnnot = "kueue.x-k8s.io/pod-group-total-count"
+
+	VolcanoSchedulerName   = "volcano"
+	VolcanoGroupNameAnnot  = "scheduling.k8s.io/group-name"
+	VolcanoPodGroupVersion = "scheduling.volcano.sh/v1beta1"
+	VolcanoPodGroupKind    = "PodGroup"
+
+	KAISchedulerName = "kai-scheduler"
+	KAIQueueLabel    = "kai.scheduler/queue"
+)
+
+// GangScheduling is how the model server pods of a ModelDeployment are submitted to a
+// batch scheduler as a single gang, translated from spec.scheduling.
+type GangScheduling struct {
+	// SchedulerName is set as the pod spec.schedulerName when non-empty.
+	SchedulerName string
+	// Labels and Annotations are added to every pod of the gang.
+	Labels      map[string]string
+	Annotations map[string]string
+	// PodGroup must be applied before the workloads when the scheduler needs one.
+	PodGroup *unstructured.Unstructured
+}
+
+// NewGangScheduling returns the gang for minMember model server pods of md, or nil when
+// spec.scheduling.gang is not enabled. The gang is named after the ModelDeployment.
+func NewGangScheduling(md *airunwayv1alpha1.ModelDeployment, minMember int32) *GangScheduling {
+	spec := md.Spec.Scheduling
+	if spec == nil || !spec.Gang {
+		return nil
+	}
+
+	g := &GangScheduling{
+		SchedulerName: spec.SchedulerName,
+		Labels:        map[string]string{},
+		Annotations:   map[string]string{},
+	}
+	switch spec.Scheduler {
+	case airunwayv1alpha1.GangSchedulerKueue:
+		g.Labels[KueueQueueNameLabel] = spec.Queue
+		g.Labels[KueuePodGroupNameLabel] = md.Name
+		g.Annotations[KueuePodGroupTotalCountAnnot] = strconv.Itoa(int(minMember))
+	case airunwayv1alpha1.GangSchedulerVolcano:
+		if g.SchedulerName == "" {
+			g.SchedulerName = VolcanoSchedulerName
+		}
+		g.Annotations[VolcanoGroupNameAnnot] = md.Name
+		g.PodGroup = newVolcanoPodGroup(md, minMember)
+	case airunwayv1alpha1.GangSchedulerKAI:
+		// KAI forms the gang from the owning workload, so only the queue is needed
+		if g.SchedulerName == "" {
+			g.SchedulerName = KAISchedulerName
+		}
+		g.Labels[KAIQueueLabel] = spec.Queue
+	}
+	return g
+}
+
+// ApplyToPodTemplate adds the scheduler name, labels, and annotations of the gang to an
+// unstructured pod template with metadata and spec maps. It is a no-op on a nil gang.
+func (g *GangScheduling) ApplyToPodTemplate(template map[string]interface{}) {
+	if g == nil {
+		return
+	}
+	metadata, ok := template["metadata"].(map[string]interface{})
+	if !ok {
+		metadata = map[string]interface{}{}
+		template["metadata"] = metadata
+	}
+	mergeStringMap(metadata, "labels", g.Labels)
+	mergeStringMap(metadata, "annotations", g.Annotations)
+
+	if g.SchedulerName != "" {
+		spec, ok := template["spec"].(map[string]interface{})
+		if !ok {
+			spec = map[string]interface{}{}
+			template["spec"] = spec
+		}
+		spec["schedulerName"] = g.SchedulerName
+	}
+}
+
+// AddPodGroup adds the PodGroup of the gang, if any, to the resources of result.
+// ApplyResources applies it before the pods that reference it. It is a no-op on a nil gang.
+func (g *GangScheduling) AddPodGroup(result *TransformResult) {
+	if g == nil || g.PodGroup == nil {
+		return
+	}
+	result.Resources = append(result.Resources, g.PodGroup)
+}
+
+// mergeStringMap adds values to the string map stored under key in obj.
+func mergeStringMap(obj map[string]interface{}, key string, values map[string]string) {
+	if len(values) == 0 {
+		return
+	}
+	m, ok := obj[key].(map[string]interface{})
+	if !ok {
+		m = map[string]interface{}{}
+		obj[key] = m
+	}
+	for k, v := range values {
+		m[k] = v
+	}
+}
+
+// newVolcanoPodGroup returns the Volcano PodGroup for the gang of md.
+func newVolcanoPodGroup(md *airunwayv1alpha1.ModelDeployment, minMember int32) *unstructured.Unstructured {
+	pg := &unstructured.Unstructured{}
+	pg.SetAPIVersion(VolcanoPodGroupVersion)
+	pg.SetKind(VolcanoPodGroupKind)
+	pg.SetName(md.Name)
+	pg.SetNamespace(md.Namespace)
+	pg.SetLabels(map[string]string{
+		airunwayv1alpha1.LabelManagedBy:       airunwayv1alpha1.ManagedByAIRunway,
+		airunwayv1alpha1.LabelModelDeployment: md.Name,
+	})
+	controller := true
+	pg.SetOwnerReferences([]metav1.OwnerReference{{
+		APIVersion:         airunwayv1alpha1.GroupVersion.String(),
+		Kind:               "ModelDeployment",
+		Name:               md.Name,
+		UID:                md.UID,
+		Controller:         &controller,
+		BlockOwnerDeletion: &controller,
+	}})
+
+	spec := map[string]interface{}{"minMember": int64(minMember)}
+	if md.Spec.Scheduling.Queue != "" {
+		spec["queue"] = md.Spec.Scheduling.Queue
+	}
+	pg.Object["spec"] = spec
+	return pg
+}
+
+// ComponentAffinity returns the affinity of a disaggregated component's pods as unstructured
+// content: the affinity of the component's scheduling, with the pod affinity terms of
+// placement, such as the co-location affinity from spec.serving.placement, appended.
+// Returns nil when neither is set.
+func ComponentAffinity(scheduling *airunwayv1alpha1.ComponentSchedulingSpec, placement map[string]interface{}) (map[string]interface{}, error) {
+	if scheduling == nil || scheduling.Affinity == nil {
+		return placement, nil
+	}
+	affinity, err := runtime.DefaultUnstructuredConverter.ToUnstructured(scheduling.Affinity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert component affinity: %w", err)
+	}
+	placementTerms, _ := placement["podAffinity"].(map[string]interface{})
+	if len(placementTerms) == 0 {
+		return affinity, nil
+	}
+	podAffinity, ok := affinity["podAffinity"].(map[string]interface{})
+	if !ok {
+		podAffinity = map[string]interface{}{}
+		affinity["podAffinity"] = podAffinity
+	}
+	for key, terms := range placementTerms {
+		existing, _ := podAffinity[key].([]interface{})
+		added, _ := terms.([]interface{})
+		podAffinity[key] = append(existing, added...)
+	}
+	return affinity, nil
+}
+
+// WithGPUTypeAffinity adds the node affinity for spec.resources.gpu.types to the unstructured
+// affinity of a pod: a required nvidia.com/gpu.product In types expression, ANDed into every
+// existing node selector term, and a preferred term per type weighted by md.GPUTypePreference.
+// affinity may be nil. It returns affinity unchanged when no types are set.
+func WithGPUTypeAffinity(affinity map[string]interface{}, md *airunwayv1alpha1.ModelDeployment) map[string]interface{} {
+	preference := md.GPUTypePreference()
+	if len(preference) == 0 {
+		return affinity
+	}
+	if affinity == nil {
+		affinity = map[string]interface{}{}
+	}
+	nodeAffinity, ok := affinity["nodeAffinity"].(map[string]interface{})
+	if !ok {
+		nodeAffinity = map[string]interface{}{}
+		affinity["nodeAffinity"] = nodeAffinity
+	}
+
+	values := make([]interface{}, 0, len(md.Spec.Resources.GPU.Types))
+	for _, t := range md.Spec.Resources.GPU.Types {
+		values = append(values, t)
+	}
+	expression := map[string]interface{}{
+		"key":      airunwayv1alpha1.LabelGPUProduct,
+		"operator": "In",
+		"values":   values,
+	}
+	required, ok := nodeAffinity["requiredDuringSchedulingIgnoredDuringExecution"].(map[string]interface{})
+	if !ok {
+		required = map[string]interface{}{}
+		nodeAffinity["requiredDuringSchedulingIgnoredDuringExecution"] = required
+	}
+	terms, _ := required["nodeSelectorTerms"].([]interface{})
+	if len(terms) == 0 {
+		terms = []interface{}{map[string]interface{}{}}
+	}
+	for _, t := range terms {
+		term, ok := t.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		expressions, _ := term["matchExpressions"].([]interface{})
+		term["matchExpressions"] = append(expressions, expression)
+	}
+	required["nodeSelectorTerms"] = terms
+
+	preferred, _ := nodeAffinity["preferredDuringSchedulingIgnoredDuringExecution"].([]interface{})
+	for i, t := range preference {
+		preferred = append(preferred, map[string]interface{}{
+			"weight": int64(100 - 10*i),
+			"preference": map[string]interface{}{
+				"matchExpressions": []interface{}{map[string]interface{}{
+					"key":      airunwayv1alpha1.LabelGPUProduct,
+					"operator": "In",
+					"values":   []interface{}{t},
+				}},
+			},
+		})
+	}
+	nodeAffinity["preferredDuringSchedulingIgnoredDuringExecution"] = preferred
+	return affinity
+}
+
+// PlacementAffinity returns a pod affinity that co-locates disaggregated workers
+// in the failure domain from spec.serving.placement, or nil when no placement is set.
+// selector must match the pods of every disaggregated component.
+func PlacementAffinity(md *airunwayv1alpha1.ModelDeployment, selector map[string]interface{}) map[string]interface{} {
+	if md.Spec.Serving == nil || md.Spec.Serving.Mode != airunwayv1alpha1.ServingModeDisaggregated || md.Spec.Serving.Placement == nil {
+		return nil
+	}
+	placement := md.Spec.Serving.Placement
+	term := map[string]interface{}{
+		"labelSelector": map[string]interface{}{
+			"matchLabels": selector,
+		},
+		"topologyKey": placement.ResolvedTopologyKey(),
+	}
+
+	podAffinity := map[string]interface{}{}
+	if placement.Preferred {
+		podAffinity["preferredDuringSchedulingIgnoredDuringExecution"] = []interface{}{
+			map[string]interface{}{
+				"weight":          int64(100),
+				"podAffinityTerm": term,
+			},
+		}
+	} else {
+		podAffinity["requiredDuringSchedulingIgnoredDuringExecution"] = []interface{}{term}
+	}
+	return map[string]interface{}{"podAffinity": podAffinity}
+}
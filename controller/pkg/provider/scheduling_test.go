@@ -0,0 +1,258 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+func newGangMD(scheduling *airunwayv1alpha1.SchedulingSpec) *airunwayv1alpha1.ModelDeployment {
+	return &airunwayv1alpha1.ModelDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "team-a", UID: "uid"},
+		Spec:       airunwayv1alpha1.ModelDeploymentSpec{Scheduling: scheduling},
+	}
+}
+
+func TestNewGangSchedulingDisabled(t *testing.T) {
+	if g := NewGangScheduling(newGangMD(nil), 2); g != nil {
+		t.Errorf("expected no gang without spec.scheduling, got %+v", g)
+	}
+	md := newGangMD(&airunwayv1alpha1.SchedulingSpec{Scheduler: airunwayv1alpha1.GangSchedulerVolcano})
+	g := NewGangScheduling(md, 2)
+	if g != nil {
+		t.Errorf("expected no gang when gang is false, got %+v", g)
+	}
+
+	// A nil gang leaves templates and results untouched
+	template := map[string]interface{}{}
+	g.ApplyToPodTemplate(template)
+	result := NewTransformResult(newObject("apps/v1", "Deployment", "llama"))
+	g.AddPodGroup(result)
+	if len(template) != 0 || len(result.Resources) != 1 {
+		t.Errorf("expected nil gang to be a no-op, got %v and %d resources", template, len(result.Resources))
+	}
+}
+
+func TestNewGangSchedulingKueue(t *testing.T) {
+	md := newGangMD(&airunwayv1alpha1.SchedulingSpec{Gang: true, Scheduler: airunwayv1alpha1.GangSchedulerKueue, Queue: "gpu-queue"})
+	g := NewGangScheduling(md, 3)
+
+	template := map[string]interface{}{
+		"metadata": map[string]interface{}{"labels": map[string]interface{}{"app": "llama"}},
+		"spec":     map[string]interface{}{},
+	}
+	g.ApplyToPodTemplate(template)
+
+	labels, _, _ := unstructured.NestedStringMap(template, "metadata", "labels")
+	if labels["app"] != "llama" || labels[KueueQueueNameLabel] != "gpu-queue" || labels[KueuePodGroupNameLabel] != "llama" {
+		t.Errorf("expected kueue labels to be merged, got %v", labels)
+	}
+	if count, _, _ := unstructured.NestedString(template, "metadata", "annotations", KueuePodGroupTotalCountAnnot); count != "3" {
+		t.Errorf("expected pod group total count 3, got %q", count)
+	}
+	if _, found, _ := unstructured.NestedString(template, "spec", "schedulerName"); found {
+		t.Error("expected kueue pods to keep the default scheduler")
+	}
+	if g.PodGroup != nil {
+		t.Error("expected no PodGroup for kueue")
+	}
+}
+
+func TestNewGangSchedulingVolcano(t *testing.T) {
+	md := newGangMD(&airunwayv1alpha1.SchedulingSpec{Gang: true, Scheduler: airunwayv1alpha1.GangSchedulerVolcano, Queue: "research"})
+	g := NewGangScheduling(md, 4)
+
+	template := map[string]interface{}{}
+	g.ApplyToPodTemplate(template)
+	if name, _, _ := unstructured.NestedString(template, "spec", "schedulerName"); name != VolcanoSchedulerName {
+		t.Errorf("expected schedulerName volcano, got %q", name)
+	}
+	if group, _, _ := unstructured.NestedString(template, "metadata", "annotations", VolcanoGroupNameAnnot); group != "llama" {
+		t.Errorf("expected group-name annotation, got %q", group)
+	}
+
+	pg := g.PodGroup
+	if pg == nil || pg.GetKind() != VolcanoPodGroupKind || pg.GetName() != "llama" || pg.GetNamespace() != "team-a" {
+		t.Fatalf("expected PodGroup llama in team-a, got %v", pg)
+	}
+	if minMember, _, _ := unstructured.NestedInt64(pg.Object, "spec", "minMember"); minMember != 4 {
+		t.Errorf("expected minMember 4, got %d", minMember)
+	}
+	if queue, _, _ := unstructured.NestedString(pg.Object, "spec", "queue"); queue != "research" {
+		t.Errorf("expected queue research, got %q", queue)
+	}
+	if refs := pg.GetOwnerReferences(); len(refs) != 1 || refs[0].UID != "uid" {
+		t.Errorf("expected PodGroup to be owned by the ModelDeployment, got %v", refs)
+	}
+
+	deploy := newObject("apps/v1", "Deployment", "llama")
+	result := NewTransformResult(deploy)
+	g.AddPodGroup(result)
+	if len(result.Resources) != 2 || result.Resources[1] != pg || result.Primary() != deploy {
+		t.Errorf("expected the PodGroup to be added without changing the primary resource, got %v", result.Resources)
+	}
+	if ordered := OrderResources(result.Resources); ordered[0] != pg {
+		t.Errorf("expected the PodGroup to be applied first, got %v", ordered)
+	}
+}
+
+func TestNewGangSchedulingKAI(t *testing.T) {
+	md := newGangMD(&airunwayv1alpha1.SchedulingSpec{Gang: true, Scheduler: airunwayv1alpha1.GangSchedulerKAI, Queue: "team-a", SchedulerName: "custom-kai"})
+	g := NewGangScheduling(md, 2)
+	if g.SchedulerName != "custom-kai" || g.Labels[KAIQueueLabel] != "team-a" || g.PodGroup != nil {
+		t.Errorf("expected kai queue label and schedulerName override, got %+v", g)
+	}
+}
+
+func TestComponentAffinity(t *testing.T) {
+	placement := map[string]interface{}{
+		"podAffinity": map[string]interface{}{
+			"requiredDuringSchedulingIgnoredDuringExecution": []interface{}{
+				map[string]interface{}{"topologyKey": "topology.kubernetes.io/zone"},
+			},
+		},
+	}
+
+	got, err := ComponentAffinity(nil, placement)
+	if err != nil || got["podAffinity"] == nil {
+		t.Fatalf("expected the placement affinity without component scheduling, got %v, %v", got, err)
+	}
+
+	scheduling := &airunwayv1alpha1.ComponentSchedulingSpec{
+		Affinity: &corev1.Affinity{
+			NodeAffinity: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+						MatchExpressions: []corev1.NodeSelectorRequirement{{
+							Key:      "gpu.product",
+							Operator: corev1.NodeSelectorOpIn,
+							Values:   []string{"H100"},
+						}},
+					}},
+				},
+			},
+			PodAffinity: &corev1.PodAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{{TopologyKey: "kubernetes.io/hostname"}},
+			},
+		},
+	}
+	got, err = ComponentAffinity(scheduling, placement)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := got["nodeAffinity"]; !ok {
+		t.Errorf("expected the component node affinity, got %v", got)
+	}
+	terms, _, _ := unstructured.NestedSlice(got, "podAffinity", "requiredDuringSchedulingIgnoredDuringExecution")
+	if len(terms) != 2 {
+		t.Errorf("expected the component and placement pod affinity terms, got %v", terms)
+	}
+
+	got, err = ComponentAffinity(&airunwayv1alpha1.ComponentSchedulingSpec{}, nil)
+	if err != nil || got != nil {
+		t.Errorf("expected no affinity, got %v, %v", got, err)
+	}
+}
+
+func TestWithGPUTypeAffinity(t *testing.T) {
+	md := newGangMD(nil)
+	if got := WithGPUTypeAffinity(nil, md); got != nil {
+		t.Errorf("expected no affinity without GPU types, got %v", got)
+	}
+
+	md.Spec.Resources = &airunwayv1alpha1.ResourceSpec{
+		GPU: &airunwayv1alpha1.GPUSpec{Count: 1, Types: []string{"NVIDIA-H100-80GB-HBM3", "NVIDIA-A100-SXM4-80GB"}},
+	}
+	md.Status.GPUType = "NVIDIA-A100-SXM4-80GB"
+	existing := map[string]interface{}{
+		"nodeAffinity": map[string]interface{}{
+			"requiredDuringSchedulingIgnoredDuringExecution": map[string]interface{}{
+				"nodeSelectorTerms": []interface{}{
+					map[string]interface{}{"matchExpressions": []interface{}{
+						map[string]interface{}{"key": "zone", "operator": "In", "values": []interface{}{"a"}},
+					}},
+					map[string]interface{}{"matchExpressions": []interface{}{
+						map[string]interface{}{"key": "zone", "operator": "In", "values": []interface{}{"b"}},
+					}},
+				},
+			},
+		},
+	}
+	got := WithGPUTypeAffinity(existing, md)
+
+	terms, _, _ := unstructured.NestedSlice(got, "nodeAffinity", "requiredDuringSchedulingIgnoredDuringExecution", "nodeSelectorTerms")
+	if len(terms) != 2 {
+		t.Fatalf("expected the existing node selector terms, got %v", terms)
+	}
+	for _, term := range terms {
+		expressions, _, _ := unstructured.NestedSlice(term.(map[string]interface{}), "matchExpressions")
+		if len(expressions) != 2 || expressions[1].(map[string]interface{})["key"] != airunwayv1alpha1.LabelGPUProduct {
+			t.Errorf("expected the GPU product expression ANDed into %v", term)
+		}
+	}
+
+	preferred, _, _ := unstructured.NestedSlice(got, "nodeAffinity", "preferredDuringSchedulingIgnoredDuringExecution")
+	if len(preferred) != 2 {
+		t.Fatalf("expected a preferred term per GPU type, got %v", preferred)
+	}
+	first := preferred[0].(map[string]interface{})
+	values, _, _ := unstructured.NestedSlice(first, "preference", "matchExpressions")
+	if first["weight"] != int64(100) || values[0].(map[string]interface{})["values"].([]interface{})[0] != "NVIDIA-A100-SXM4-80GB" {
+		t.Errorf("expected status.gpuType to be most preferred, got %v", first)
+	}
+
+	got = WithGPUTypeAffinity(nil, md)
+	terms, _, _ = unstructured.NestedSlice(got, "nodeAffinity", "requiredDuringSchedulingIgnoredDuringExecution", "nodeSelectorTerms")
+	if len(terms) != 1 {
+		t.Errorf("expected a new node selector term, got %v", terms)
+	}
+}
+
+func TestPlacementAffinity(t *testing.T) {
+	md := newGangMD(nil)
+	selector := map[string]interface{}{airunwayv1alpha1.LabelModelDeployment: "llama"}
+	if got := PlacementAffinity(md, selector); got != nil {
+		t.Errorf("expected no affinity without placement, got %v", got)
+	}
+
+	md.Spec.Serving = &airunwayv1alpha1.ServingSpec{
+		Mode:      airunwayv1alpha1.ServingModeDisaggregated,
+		Placement: &airunwayv1alpha1.PlacementSpec{Colocate: airunwayv1alpha1.PlacementDomainNode},
+	}
+	affinity := PlacementAffinity(md, selector)
+	terms, _, _ := unstructured.NestedSlice(affinity, "podAffinity", "requiredDuringSchedulingIgnoredDuringExecution")
+	if len(terms) != 1 || terms[0].(map[string]interface{})["topologyKey"] != corev1.LabelHostname {
+		t.Errorf("expected a required term on the node, got %v", affinity)
+	}
+
+	md.Spec.Serving.Placement.Preferred = true
+	affinity = PlacementAffinity(md, selector)
+	if _, ok, _ := unstructured.NestedSlice(affinity, "podAffinity", "preferredDuringSchedulingIgnoredDuringExecution"); !ok {
+		t.Errorf("expected a preferred term, got %v", affinity)
+	}
+
+	md.Spec.Serving.Mode = airunwayv1alpha1.ServingModeAggregated
+	if got := PlacementAffinity(md, selector); got != nil {
+		t.Errorf("expected no affinity for aggregated serving, got %v", got)
+	}
+}
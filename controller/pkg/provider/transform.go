@@ -0,0 +1,105 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package provider defines the contract between provider controllers and the
+// resources they generate from a ModelDeployment.
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Transformer converts a ModelDeployment into the resources a provider manages.
+type Transformer interface {
+	Transform(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) (*TransformResult, error)
+}
+
+// ResourceRef identifies a resource within a TransformResult. All resources of a
+// result live in the ModelDeployment namespace, so group, kind, and name are unique.
+type ResourceRef struct {
+	schema.GroupKind
+	Name string
+}
+
+// String returns the reference as "Kind.group/name".
+func (r ResourceRef) String() string {
+	return r.GroupKind.String() + "/" + r.Name
+}
+
+// RefFor returns the reference for obj.
+func RefFor(obj *unstructured.Unstructured) ResourceRef {
+	return ResourceRef{GroupKind: obj.GroupVersionKind().GroupKind(), Name: obj.GetName()}
+}
+
+// TransformResult is the output of a Transformer.
+type TransformResult struct {
+	// Resources are applied by ApplyResources in dependency order. Resources of the
+	// same kind rank, such as the workloads, are applied in the order given. The first
+	// resource is the primary resource recorded in status.provider.
+	Resources []*unstructured.Unstructured
+}
+
+// NewTransformResult returns a result for resources whose first entry is the primary
+// resource.
+func NewTransformResult(resources ...*unstructured.Unstructured) *TransformResult {
+	return &TransformResult{Resources: resources}
+}
+
+// Get returns the resource for ref, or nil if the result does not contain it.
+func (r *TransformResult) Get(ref ResourceRef) *unstructured.Unstructured {
+	for _, obj := range r.Resources {
+		if RefFor(obj) == ref {
+			return obj
+		}
+	}
+	return nil
+}
+
+// Primary returns the resource recorded in status.provider, or nil if the result is empty.
+func (r *TransformResult) Primary() *unstructured.Unstructured {
+	if len(r.Resources) > 0 {
+		return r.Resources[0]
+	}
+	return nil
+}
+
+// Validate checks that the result has resources, and that every resource is named and
+// unique.
+func (r *TransformResult) Validate() error {
+	if len(r.Resources) == 0 {
+		return fmt.Errorf("transform produced no resources")
+	}
+	seen := make(map[ResourceRef]bool, len(r.Resources))
+	for i, obj := range r.Resources {
+		if obj == nil {
+			return fmt.Errorf("resource %d is nil", i)
+		}
+		if obj.GetKind() == "" || obj.GetName() == "" {
+			return fmt.Errorf("resource %d must have a kind and name", i)
+		}
+		ref := RefFor(obj)
+		if seen[ref] {
+			return fmt.Errorf("duplicate resource %s", ref)
+		}
+		seen[ref] = true
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func newObject(apiVersion, kind, name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetName(name)
+	return obj
+}
+
+func TestNewTransformResult(t *testing.T) {
+	deploy := newObject("apps/v1", "Deployment", "model")
+	svc := newObject("v1", "Service", "model")
+
+	result := NewTransformResult(deploy, svc)
+	if err := result.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Primary() != deploy {
+		t.Errorf("expected the first resource to be primary, got %v", result.Primary())
+	}
+	if got := RefFor(deploy).String(); got != "Deployment.apps/model" {
+		t.Errorf("unexpected ref string %q", got)
+	}
+}
+
+func TestTransformResultPrimary(t *testing.T) {
+	deploy := newObject("apps/v1", "Deployment", "model")
+	secret := newObject("v1", "Secret", "model-config")
+
+	result := &TransformResult{Resources: []*unstructured.Unstructured{deploy, secret}}
+	if result.Primary() != deploy {
+		t.Errorf("expected the first resource to be primary, got %v", result.Primary())
+	}
+	if result.Get(RefFor(secret)) != secret || result.Get(ResourceRef{Name: "other"}) != nil {
+		t.Error("expected Get to find resources by reference")
+	}
+
+	if (&TransformResult{}).Primary() != nil {
+		t.Error("expected no primary for an empty result")
+	}
+}
+
+func TestTransformResultValidate(t *testing.T) {
+	deploy := newObject("apps/v1", "Deployment", "model")
+	svc := newObject("v1", "Service", "model")
+
+	tests := []struct {
+		name    string
+		result  *TransformResult
+		wantErr string
+	}{
+		{
+			name:    "empty",
+			result:  &TransformResult{},
+			wantErr: "no resources",
+		},
+		{
+			name:    "unnamed resource",
+			result:  &TransformResult{Resources: []*unstructured.Unstructured{newObject("v1", "Service", "")}},
+			wantErr: "kind and name",
+		},
+		{
+			name:    "duplicate resource",
+			result:  &TransformResult{Resources: []*unstructured.Unstructured{deploy, newObject("apps/v1", "Deployment", "model")}},
+			wantErr: "duplicate resource Deployment.apps/model",
+		},
+		{
+			name:   "same name different kinds",
+			result: &TransformResult{Resources: []*unstructured.Unstructured{deploy, svc}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.result.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package selection picks the InferenceProviderConfig a ModelDeployment runs on from the
+// providers that passed every selection criterion. Strategies are registered by name and
+// chosen with --provider-selection-strategy or a SelectionPolicy. Controllers built from
+// this module can add their own placement logic with Register before the manager starts.
+package selection
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+// Built-in strategy names
+const (
+	// Priority selects the provider with the highest selection rule priority
+	Priority = "priority"
+	// WeightedCapacity selects the provider with the fewest deployments per unit of weight
+	WeightedCapacity = "weighted-capacity"
+	// RoundRobin rotates through the eligible providers as deployments are placed
+	RoundRobin = "round-robin"
+	// CostAware selects the provider with the lowest cost
+	CostAware = "cost-aware"
+
+	// Default is the strategy used when none is configured
+	Default = Priority
+)
+
+// Candidate is a provider that passed every selection criterion
+type Candidate struct {
+	// Name is the name of the InferenceProviderConfig
+	Name string
+	// Priority is the highest priority of the provider's matched selection rules
+	Priority int32
+	// Deployments is the number of ModelDeployments already placed on the provider
+	Deployments int
+	// Weight is the relative capacity of the provider, at least 1
+	Weight int32
+	// Cost is the relative cost of the provider, lower is cheaper
+	Cost int32
+}
+
+// Strategy picks the provider a ModelDeployment runs on
+type Strategy interface {
+	// Select returns the name of the selected candidate and why it was selected.
+	// candidates is sorted by name and never empty. Select must not keep state between
+	// calls, since the selection report also calls it to simulate a selection.
+	Select(md *airunwayv1alpha1.ModelDeployment, candidates []Candidate) (name, reason string)
+}
+
+// StrategyFunc adapts a function to a Strategy
+type StrategyFunc func(md *airunwayv1alpha1.ModelDeployment, candidates []Candidate) (string, string)
+
+// Select calls f
+func (f StrategyFunc) Select(md *airunwayv1alpha1.ModelDeployment, candidates []Candidate) (string, string) {
+	return f(md, candidates)
+}
+
+var (
+	strategiesMu sync.RWMutex
+	strategies   = map[string]Strategy{
+		Priority:         StrategyFunc(selectPriority),
+		WeightedCapacity: StrategyFunc(selectWeightedCapacity),
+		RoundRobin:       StrategyFunc(selectRoundRobin),
+		CostAware:        StrategyFunc(selectCostAware),
+	}
+)
+
+// Register makes a strategy available under name. It panics if name is empty or
+// already registered.
+func Register(name string, s Strategy) {
+	strategiesMu.Lock()
+	defer strategiesMu.Unlock()
+	if name == "" || s == nil {
+		panic("selection: Register requires a name and a strategy")
+	}
+	if _, exists := strategies[name]; exists {
+		panic(fmt.Sprintf("selection: strategy %q is already registered", name))
+	}
+	strategies[name] = s
+}
+
+// Get returns the strategy registered under name, or the default strategy when name is empty
+func Get(name string) (Strategy, error) {
+	if name == "" {
+		name = Default
+	}
+	strategiesMu.RLock()
+	defer strategiesMu.RUnlock()
+	s, ok := strategies[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown provider selection strategy %q, must be one of [%s]",
+			name, strings.Join(slices.Sorted(maps.Keys(strategies)), ", "))
+	}
+	return s, nil
+}
+
+// Names returns the registered strategy names, sorted
+func Names() []string {
+	strategiesMu.RLock()
+	defer strategiesMu.RUnlock()
+	return slices.Sorted(maps.Keys(strategies))
+}
+
+// best returns the candidate that less orders first, breaking ties by higher priority
+// and then by name
+func best(candidates []Candidate, less func(a, b Candidate) int) Candidate {
+	return slices.MinFunc(candidates, func(a, b Candidate) int {
+		if c := less(a, b); c != 0 {
+			return c
+		}
+		if a.Priority != b.Priority {
+			if a.Priority > b.Priority {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+}
+
+func selectPriority(_ *airunwayv1alpha1.ModelDeployment, candidates []Candidate) (string, string) {
+	c := best(candidates, func(a, b Candidate) int { return 0 })
+	return c.Name, fmt.Sprintf("highest priority %d", c.Priority)
+}
+
+func selectWeightedCapacity(_ *airunwayv1alpha1.ModelDeployment, candidates []Candidate) (string, string) {
+	c := best(candidates, func(a, b Candidate) int {
+		// Compare deployments/weight without dividing
+		left, right := int64(a.Deployments)*int64(weight(b)), int64(b.Deployments)*int64(weight(a))
+		switch {
+		case left < right:
+			return -1
+		case left > right:
+			return 1
+		}
+		return 0
+	})
+	return c.Name, fmt.Sprintf("lowest load %d deployments for weight %d", c.Deployments, weight(c))
+}
+
+func selectCostAware(_ *airunwayv1alpha1.ModelDeployment, candidates []Candidate) (string, string) {
+	c := best(candidates, func(a, b Candidate) int { return int(a.Cost) - int(b.Cost) })
+	return c.Name, fmt.Sprintf("lowest cost %d", c.Cost)
+}
+
+func weight(c Candidate) int32 {
+	return max(c.Weight, 1)
+}
+
+// selectRoundRobin rotates through the candidates by the number of deployments already
+// placed on them, so each new deployment moves to the next provider. It keeps no state,
+// so the rotation survives controller restarts and is the same on every shard.
+func selectRoundRobin(_ *airunwayv1alpha1.ModelDeployment, candidates []Candidate) (string, string) {
+	placed := 0
+	for _, c := range candidates {
+		placed += c.Deployments
+	}
+	c := candidates[placed%len(candidates)]
+	return c.Name, fmt.Sprintf("round-robin position %d of %d providers", placed%len(candidates)+1, len(candidates))
+}
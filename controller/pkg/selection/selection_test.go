@@ -0,0 +1,110 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package selection
+
+import (
+	"slices"
+	"testing"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+func TestBuiltinStrategies(t *testing.T) {
+	candidates := []Candidate{
+		{Name: "dynamo", Priority: 50, Deployments: 4, Weight: 4, Cost: 3},
+		{Name: "kaito", Priority: 100, Deployments: 3, Weight: 1, Cost: 2},
+		{Name: "kuberay", Priority: 100, Deployments: 1, Weight: 2, Cost: 2},
+	}
+	tests := []struct {
+		strategy string
+		want     string
+	}{
+		// Ties on priority go to the first name
+		{strategy: Priority, want: "kaito"},
+		// kuberay has 0.5 deployments per weight, dynamo 1 and kaito 3
+		{strategy: WeightedCapacity, want: "kuberay"},
+		// kaito and kuberay cost the same and have the same priority
+		{strategy: CostAware, want: "kaito"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.strategy, func(t *testing.T) {
+			s, err := Get(tt.strategy)
+			if err != nil {
+				t.Fatal(err)
+			}
+			name, reason := s.Select(&airunwayv1alpha1.ModelDeployment{}, candidates)
+			if name != tt.want {
+				t.Errorf("expected %s, got %s (%s)", tt.want, name, reason)
+			}
+			if reason == "" {
+				t.Error("expected a reason")
+			}
+		})
+	}
+}
+
+func TestRoundRobin(t *testing.T) {
+	candidates := []Candidate{{Name: "a"}, {Name: "b"}, {Name: "c"}}
+	var got []string
+	for range 4 {
+		name, _ := selectRoundRobin(&airunwayv1alpha1.ModelDeployment{}, candidates)
+		got = append(got, name)
+		// Place the deployment on the selected provider
+		candidates[slices.IndexFunc(candidates, func(c Candidate) bool { return c.Name == name })].Deployments++
+	}
+	if want := []string{"a", "b", "c", "a"}; !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestWeightedCapacityDefaultsWeight(t *testing.T) {
+	// A zero weight counts as 1
+	candidates := []Candidate{{Name: "a", Deployments: 2}, {Name: "b", Deployments: 3, Weight: 2}}
+	if name, _ := selectWeightedCapacity(nil, candidates); name != "b" {
+		t.Errorf("expected b, got %s", name)
+	}
+}
+
+func TestRegisterAndGet(t *testing.T) {
+	if s, err := Get(""); err != nil || s == nil {
+		t.Fatalf("expected the default strategy, got %v, %v", s, err)
+	}
+	if _, err := Get("nearest"); err == nil {
+		t.Fatal("expected an error for an unknown strategy")
+	}
+
+	Register("test-last", StrategyFunc(func(_ *airunwayv1alpha1.ModelDeployment, c []Candidate) (string, string) {
+		return c[len(c)-1].Name, "last"
+	}))
+	s, err := Get("test-last")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name, _ := s.Select(nil, []Candidate{{Name: "a"}, {Name: "b"}}); name != "b" {
+		t.Errorf("expected b, got %s", name)
+	}
+	if !slices.Contains(Names(), "test-last") {
+		t.Errorf("expected test-last in %v", Names())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic when registering a name twice")
+		}
+	}()
+	Register(Priority, StrategyFunc(selectPriority))
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/pkg/provider"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -187,6 +188,11 @@ func buildDownloadJob(md *airunwayv1alpha1.ModelDeployment, vol *airunwayv1alpha
 	completions := int32(1)
 	parallelism := int32(1)
 
+	args := []string{"download", md.Spec.Model.ID}
+	if md.Spec.Model.Revision != "" {
+		args = append(args, "--revision", md.Spec.Model.Revision)
+	}
+
 	envVars := []corev1.EnvVar{
 		{
 			Name:  "HF_HOME",
@@ -199,7 +205,7 @@ func buildDownloadJob(md *airunwayv1alpha1.ModelDeployment, vol *airunwayv1alpha
 			Name:      downloadJobName(md.Name),
 			Namespace: md.Namespace,
 			Labels: map[string]string{
-				airunwayv1alpha1.LabelManagedBy:       "airunway",
+				airunwayv1alpha1.LabelManagedBy:       airunwayv1alpha1.ManagedByAIRunway,
 				airunwayv1alpha1.LabelModelDeployment: md.Name,
 				airunwayv1alpha1.LabelJobType:         "model-download",
 			},
@@ -220,12 +226,13 @@ func buildDownloadJob(md *airunwayv1alpha1.ModelDeployment, vol *airunwayv1alpha
 			Parallelism:  &parallelism,
 			Template: corev1.PodTemplateSpec{
 				Spec: corev1.PodSpec{
-					RestartPolicy: corev1.RestartPolicyNever,
+					RestartPolicy:      corev1.RestartPolicyNever,
+					ServiceAccountName: md.ServiceAccountName(),
 					Containers: []corev1.Container{
 						{
 							Name:  "model-download",
 							Image: downloadJobImage,
-							Args:  []string{"download", md.Spec.Model.ID},
+							Args:  args,
 							Env:   envVars,
 							Resources: corev1.ResourceRequirements{
 								Requests: corev1.ResourceList{
@@ -271,6 +278,8 @@ func buildDownloadJob(md *airunwayv1alpha1.ModelDeployment, vol *airunwayv1alpha
 			},
 		}
 	}
+	provider.ApplyPropagatedMetadataToObject(job, md)
+	provider.ApplyPropagatedMetadataToObject(&job.Spec.Template, md)
 
 	return job
 }
@@ -290,7 +299,7 @@ func DeleteManagedJobs(ctx context.Context, c client.Client, md *airunwayv1alpha
 	if err := c.List(ctx, jobList,
 		client.InNamespace(md.Namespace),
 		client.MatchingLabels{
-			airunwayv1alpha1.LabelManagedBy:       "airunway",
+			airunwayv1alpha1.LabelManagedBy:       airunwayv1alpha1.ManagedByAIRunway,
 			airunwayv1alpha1.LabelModelDeployment: md.Name,
 		},
 	); err != nil {
@@ -278,6 +278,59 @@ func TestEnsureDownloadJobWithHFToken(t *testing.T) {
 	}
 }
 
+func TestEnsureDownloadJobWithRevision(t *testing.T) {
+	scheme := newScheme()
+	_ = batchv1.AddToScheme(scheme)
+
+	md := newDownloadMD("my-model", "default")
+	md.Spec.Model.Revision = "0123456789abcdef0123456789abcdef01234567"
+
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	if _, err := EnsureDownloadJob(context.Background(), c, md, DefaultDownloadJobImage); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	job := &batchv1.Job{}
+	if err := c.Get(context.Background(), types.NamespacedName{
+		Name:      "my-model-model-download",
+		Namespace: "default",
+	}, job); err != nil {
+		t.Fatalf("expected Job to be created: %v", err)
+	}
+	args := job.Spec.Template.Spec.Containers[0].Args
+	if len(args) != 4 || args[2] != "--revision" || args[3] != md.Spec.Model.Revision {
+		t.Errorf("expected the download to be pinned to the revision, got %v", args)
+	}
+}
+
+func TestEnsureDownloadJobWithIdentity(t *testing.T) {
+	scheme := newScheme()
+	_ = batchv1.AddToScheme(scheme)
+
+	md := newDownloadMD("my-model", "default")
+	md.Spec.Identity = &airunwayv1alpha1.IdentitySpec{
+		Annotations: map[string]string{"eks.amazonaws.com/role-arn": "arn:aws:iam::111122223333:role/model-reader"},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	if _, err := EnsureDownloadJob(context.Background(), c, md, DefaultDownloadJobImage); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	job := &batchv1.Job{}
+	if err := c.Get(context.Background(), types.NamespacedName{
+		Name:      "my-model-model-download",
+		Namespace: "default",
+	}, job); err != nil {
+		t.Fatalf("expected Job to be created: %v", err)
+	}
+	if sa := job.Spec.Template.Spec.ServiceAccountName; sa != "my-model" {
+		t.Errorf("expected serviceAccountName my-model, got %q", sa)
+	}
+}
+
 func TestEnsureDownloadJobCompleted(t *testing.T) {
 	scheme := newScheme()
 	_ = batchv1.AddToScheme(scheme)
@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/pkg/provider"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -132,7 +133,7 @@ func EnsurePVCs(ctx context.Context, c client.Client, md *airunwayv1alpha1.Model
 			// Safety guard: only delete PVCs that were created by airunway.
 			// A PVC without the managed-by label was created by another controller
 			// or manually — deleting it would be destructive and unintended.
-			if existing.Labels[airunwayv1alpha1.LabelManagedBy] != "airunway" {
+			if existing.Labels[airunwayv1alpha1.LabelManagedBy] != airunwayv1alpha1.ManagedByAIRunway {
 				return false, fmt.Errorf(
 					"PVC %s exists but was not created by airunway (missing %s label); "+
 						"refusing to delete — remove the PVC manually or change the volume claimName",
@@ -185,7 +186,7 @@ func buildPVC(md *airunwayv1alpha1.ModelDeployment, vol *airunwayv1alpha1.Storag
 			Name:      claimName,
 			Namespace: md.Namespace,
 			Labels: map[string]string{
-				airunwayv1alpha1.LabelManagedBy:       "airunway",
+				airunwayv1alpha1.LabelManagedBy:       airunwayv1alpha1.ManagedByAIRunway,
 				airunwayv1alpha1.LabelModelDeployment: md.Name,
 			},
 			OwnerReferences: []metav1.OwnerReference{
@@ -211,6 +212,7 @@ func buildPVC(md *airunwayv1alpha1.ModelDeployment, vol *airunwayv1alpha1.Storag
 
 	// Set storage class name directly (nil→cluster default, ""→no class, "x"→named class)
 	pvc.Spec.StorageClassName = vol.StorageClassName
+	provider.ApplyPropagatedMetadataToObject(pvc, md)
 
 	return pvc, nil
 }
@@ -236,7 +238,7 @@ func DeleteManagedPVCs(ctx context.Context, c client.Client, md *airunwayv1alpha
 	if err := c.List(ctx, pvcList,
 		client.InNamespace(md.Namespace),
 		client.MatchingLabels{
-			airunwayv1alpha1.LabelManagedBy:       "airunway",
+			airunwayv1alpha1.LabelManagedBy:       airunwayv1alpha1.ManagedByAIRunway,
 			airunwayv1alpha1.LabelModelDeployment: md.Name,
 		},
 	); err != nil {
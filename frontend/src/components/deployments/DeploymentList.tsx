@@ -25,6 +25,7 @@ function getStatusDotColor(phase: DeploymentStatus['phase']): string {
   switch (phase) {
     case 'Running':     return 'bg-green-500'
     case 'Pending':     return 'bg-amber-400 animate-pulse'
+    case 'Queued':      return 'bg-amber-400'
     case 'Deploying':   return 'bg-blue-500 animate-pulse'
     case 'Failed':      return 'bg-red-400'
     case 'Terminating': return 'bg-slate-400 animate-pulse'
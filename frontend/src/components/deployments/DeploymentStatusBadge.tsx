@@ -1,6 +1,6 @@
 import { Badge } from '@/components/ui/badge'
 import { type DeploymentStatus } from '@/lib/api'
-import { CheckCircle2, Clock, Loader2, XCircle, Power } from 'lucide-react'
+import { CheckCircle2, Clock, Hourglass, Loader2, XCircle, Power } from 'lucide-react'
 import { cn } from '@/lib/utils'
 
 interface DeploymentStatusBadgeProps {
@@ -23,6 +23,12 @@ const statusConfig: Record<DeploymentStatus['phase'], {
     pulse: true,
     label: 'Pending',
   },
+  Queued: {
+    className: 'bg-amber-400/10 text-amber-400',
+    icon: Hourglass,
+    pulse: false,
+    label: 'Queued',
+  },
   Deploying: {
     className: 'bg-blue-500/10 text-blue-500',
     icon: Loader2,
@@ -0,0 +1,456 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package conformance is a test suite that provider implementations run against their
+// Transformer and status translator to show they honor the ModelDeployment contract.
+// Out-of-tree providers call Suite.Run from a test:
+//
+//	func TestConformance(t *testing.T) {
+//		conformance.Suite{
+//			Transformer:      NewTransformer(),
+//			StatusTranslator: statusAdapter{NewStatusTranslator()},
+//		}.Run(t)
+//	}
+package conformance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/pkg/provider"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Names of the checks, usable as Suite.Skip keys
+const (
+	CheckTransformResult  = "TransformResult"
+	CheckDeterministic    = "Deterministic"
+	CheckLabels           = "Labels"
+	CheckOwnerReferences  = "OwnerReferences"
+	CheckOverrideEscaping = "OverrideEscaping"
+	CheckGPUCount         = "GPUCount"
+	CheckStatus           = "Status"
+)
+
+// DefaultGPUCount is the GPU count of the deployment returned by NewModelDeployment. It
+// is above one so a provider that ignores the count is caught.
+const DefaultGPUCount = 2
+
+// StatusResult is the provider-neutral result of translating upstream status. Providers
+// declare their own result type with the same fields, which converts to it directly.
+type StatusResult struct {
+	Phase        airunwayv1alpha1.DeploymentPhase
+	Message      string
+	Replicas     *airunwayv1alpha1.ReplicaStatus
+	Endpoint     *airunwayv1alpha1.EndpointStatus
+	ResourceName string
+	ResourceKind string
+}
+
+// StatusTranslator maps the status of a provider's primary upstream resource onto the
+// ModelDeployment status
+type StatusTranslator interface {
+	TranslateStatus(upstream *unstructured.Unstructured) (*StatusResult, error)
+}
+
+// ReadinessChecker is implemented by status translators that report readiness
+// separately from the phase. It must agree with the phase being Running.
+type ReadinessChecker interface {
+	IsReady(upstream *unstructured.Unstructured) bool
+}
+
+// ErrorReporter is implemented by status translators that extract upstream failure
+// messages
+type ErrorReporter interface {
+	GetErrorMessage(upstream *unstructured.Unstructured) string
+}
+
+// StatusCase is an upstream status the translator must map to a phase
+type StatusCase struct {
+	// Name describes the case
+	Name string
+	// Status is set as the status of the primary resource produced by the Transformer.
+	// Integers must be int64, as in decoded unstructured objects.
+	Status map[string]interface{}
+	// Phase is the expected deployment phase
+	Phase airunwayv1alpha1.DeploymentPhase
+}
+
+// Suite checks a provider against the ModelDeployment contract
+type Suite struct {
+	// Transformer is the provider's transformer
+	Transformer provider.Transformer
+	// StatusTranslator translates the status of the primary resource. The Status check is
+	// skipped when nil.
+	StatusTranslator StatusTranslator
+	// NewModelDeployment returns a deployment the provider accepts, with
+	// DefaultGPUCount GPUs. NewModelDeployment is used when nil.
+	NewModelDeployment func() *airunwayv1alpha1.ModelDeployment
+	// GPUCount returns the GPUs per replica a result requests. When nil, the largest GPU
+	// quantity under any resources.limits in the result is used.
+	GPUCount func(result *provider.TransformResult) (int64, error)
+	// StatusCases are provider-specific upstream statuses and the phases they map to
+	StatusCases []StatusCase
+	// Skip maps the names of checks that do not apply to the provider to the reason
+	Skip map[string]string
+}
+
+// NewModelDeployment returns an aggregated vLLM deployment of a HuggingFace model with
+// DefaultGPUCount GPUs
+func NewModelDeployment() *airunwayv1alpha1.ModelDeployment {
+	return &airunwayv1alpha1.ModelDeployment{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: airunwayv1alpha1.GroupVersion.String(),
+			Kind:       "ModelDeployment",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "conformance",
+			Namespace: "conformance",
+			UID:       types.UID("conformance-uid"),
+		},
+		Spec: airunwayv1alpha1.ModelDeploymentSpec{
+			Model: airunwayv1alpha1.ModelSpec{
+				ID:     "Qwen/Qwen3-0.6B",
+				Source: airunwayv1alpha1.ModelSourceHuggingFace,
+			},
+			Engine: airunwayv1alpha1.EngineSpec{
+				Type: airunwayv1alpha1.EngineTypeVLLM,
+			},
+			Resources: &airunwayv1alpha1.ResourceSpec{
+				GPU: &airunwayv1alpha1.GPUSpec{Count: DefaultGPUCount},
+			},
+		},
+	}
+}
+
+// Run runs every check as a subtest of t
+func (s Suite) Run(t *testing.T) {
+	t.Helper()
+	if s.Transformer == nil {
+		t.Fatal("conformance: Suite.Transformer is required")
+	}
+	checks := []struct {
+		name string
+		run  func(*testing.T)
+	}{
+		{CheckTransformResult, s.checkTransformResult},
+		{CheckDeterministic, s.checkDeterministic},
+		{CheckLabels, s.checkLabels},
+		{CheckOwnerReferences, s.checkOwnerReferences},
+		{CheckOverrideEscaping, s.checkOverrideEscaping},
+		{CheckGPUCount, s.checkGPUCount},
+		{CheckStatus, s.checkStatus},
+	}
+	for _, check := range checks {
+		t.Run(check.name, func(t *testing.T) {
+			if reason, ok := s.Skip[check.name]; ok {
+				t.Skip(reason)
+			}
+			check.run(t)
+		})
+	}
+}
+
+func (s Suite) modelDeployment() *airunwayv1alpha1.ModelDeployment {
+	if s.NewModelDeployment != nil {
+		return s.NewModelDeployment()
+	}
+	return NewModelDeployment()
+}
+
+// transform runs the transformer and fails the test when it errors
+func (s Suite) transform(t *testing.T, md *airunwayv1alpha1.ModelDeployment) *provider.TransformResult {
+	t.Helper()
+	result, err := s.Transformer.Transform(context.Background(), md)
+	if err != nil {
+		t.Fatalf("Transform failed: %v", err)
+	}
+	if result == nil {
+		t.Fatal("Transform returned a nil result")
+	}
+	return result
+}
+
+// checkTransformResult requires a valid result whose resources live in the deployment
+// namespace
+func (s Suite) checkTransformResult(t *testing.T) {
+	md := s.modelDeployment()
+	result := s.transform(t, md)
+	if err := result.Validate(); err != nil {
+		t.Fatalf("invalid transform result: %v", err)
+	}
+	for _, obj := range result.Resources {
+		if obj.GetAPIVersion() == "" {
+			t.Errorf("%s has no apiVersion", provider.RefFor(obj))
+		}
+		if obj.GetNamespace() != md.Namespace {
+			t.Errorf("%s is in namespace %q, want %q", provider.RefFor(obj), obj.GetNamespace(), md.Namespace)
+		}
+	}
+}
+
+// checkDeterministic requires the same deployment to transform to the same resources, so
+// reconciles do not flap
+func (s Suite) checkDeterministic(t *testing.T) {
+	first := s.transform(t, s.modelDeployment())
+	second := s.transform(t, s.modelDeployment())
+	if len(first.Resources) != len(second.Resources) {
+		t.Fatalf("transform produced %d then %d resources", len(first.Resources), len(second.Resources))
+	}
+	for i := range first.Resources {
+		if !reflect.DeepEqual(first.Resources[i].Object, second.Resources[i].Object) {
+			t.Errorf("%s differs between transforms", provider.RefFor(first.Resources[i]))
+		}
+	}
+}
+
+// checkLabels requires every resource to carry the managed-by label and the labels of
+// spec.labels
+func (s Suite) checkLabels(t *testing.T) {
+	const propagated = "conformance.example.com/propagated"
+	md := s.modelDeployment()
+	md.Spec.Labels = map[string]string{propagated: "true"}
+	for _, obj := range s.transform(t, md).Resources {
+		if got := obj.GetLabels()[airunwayv1alpha1.LabelManagedBy]; got != airunwayv1alpha1.ManagedByAIRunway {
+			t.Errorf("%s has label %s=%q, want %q", provider.RefFor(obj), airunwayv1alpha1.LabelManagedBy, got, airunwayv1alpha1.ManagedByAIRunway)
+		}
+		if got := obj.GetLabels()[propagated]; got != "true" {
+			t.Errorf("%s has label %s=%q, want the value from spec.labels", provider.RefFor(obj), propagated, got)
+		}
+	}
+}
+
+// checkOwnerReferences requires every resource to be controlled by the deployment, so
+// it is garbage collected with it
+func (s Suite) checkOwnerReferences(t *testing.T) {
+	md := s.modelDeployment()
+	for _, obj := range s.transform(t, md).Resources {
+		checkOwner(t, obj, md)
+	}
+}
+
+func checkOwner(t *testing.T, obj *unstructured.Unstructured, md *airunwayv1alpha1.ModelDeployment) {
+	t.Helper()
+	owner := metav1.GetControllerOfNoCopy(obj)
+	if owner == nil {
+		t.Errorf("%s has no controller owner reference", provider.RefFor(obj))
+		return
+	}
+	if owner.UID != md.UID || owner.Name != md.Name || owner.Kind != md.Kind || owner.APIVersion != md.APIVersion {
+		t.Errorf("%s is controlled by %s %s/%s (uid %s), want the ModelDeployment",
+			provider.RefFor(obj), owner.APIVersion, owner.Kind, owner.Name, owner.UID)
+	}
+	if owner.BlockOwnerDeletion == nil || !*owner.BlockOwnerDeletion {
+		t.Errorf("%s owner reference does not block owner deletion", provider.RefFor(obj))
+	}
+}
+
+// escapingOverrides are overrides that try to change the identity or ownership of the
+// generated resources
+var escapingOverrides = []map[string]interface{}{
+	{"apiVersion": "v1"},
+	{"kind": "Secret"},
+	{"metadata": map[string]interface{}{
+		"name":            "escaped",
+		"namespace":       "kube-system",
+		"ownerReferences": []interface{}{},
+	}},
+	{"status": map[string]interface{}{"phase": "Running"}},
+}
+
+// checkOverrideEscaping requires spec.provider.overrides that target apiVersion, kind,
+// metadata, or status to be rejected or to leave the resources unchanged
+func (s Suite) checkOverrideEscaping(t *testing.T) {
+	md := s.modelDeployment()
+	baseline := s.transform(t, md)
+	for _, overrides := range escapingOverrides {
+		raw, err := json.Marshal(overrides)
+		if err != nil {
+			t.Fatalf("failed to marshal overrides: %v", err)
+		}
+		md := s.modelDeployment()
+		if md.Spec.Provider == nil {
+			md.Spec.Provider = &airunwayv1alpha1.ProviderSpec{}
+		}
+		md.Spec.Provider.Overrides = &runtime.RawExtension{Raw: raw}
+
+		result, err := s.Transformer.Transform(context.Background(), md)
+		if err != nil {
+			continue
+		}
+		if len(result.Resources) != len(baseline.Resources) {
+			t.Errorf("overrides %s changed the resource count from %d to %d", raw, len(baseline.Resources), len(result.Resources))
+			continue
+		}
+		for i, obj := range result.Resources {
+			want := baseline.Resources[i]
+			if obj.GetAPIVersion() != want.GetAPIVersion() || obj.GetKind() != want.GetKind() ||
+				obj.GetName() != want.GetName() || obj.GetNamespace() != want.GetNamespace() {
+				t.Errorf("overrides %s changed %s to %s %s/%s", raw, provider.RefFor(want),
+					obj.GetAPIVersion(), obj.GetNamespace(), obj.GetName())
+			}
+			if _, found := obj.Object["status"]; found {
+				t.Errorf("overrides %s set the status of %s", raw, provider.RefFor(want))
+			}
+			checkOwner(t, obj, md)
+		}
+	}
+}
+
+// checkGPUCount requires the GPUs requested by the deployment to reach the resources
+func (s Suite) checkGPUCount(t *testing.T) {
+	md := s.modelDeployment()
+	if md.Spec.Resources == nil || md.Spec.Resources.GPU == nil || md.Spec.Resources.GPU.Count == 0 {
+		t.Fatal("NewModelDeployment must request GPUs")
+	}
+	result := s.transform(t, md)
+	gpuCount := s.GPUCount
+	if gpuCount == nil {
+		gpuCount = LimitsGPUCount
+	}
+	got, err := gpuCount(result)
+	if err != nil {
+		t.Fatalf("failed to read the GPU count: %v", err)
+	}
+	if want := int64(md.Spec.Resources.GPU.Count); got != want {
+		t.Errorf("resources request %d GPUs, want %d", got, want)
+	}
+}
+
+// LimitsGPUCount returns the largest GPU quantity under any resources.limits in the
+// result. Keys named "gpu" or ending in "/gpu" are GPU resources.
+func LimitsGPUCount(result *provider.TransformResult) (int64, error) {
+	var largest int64
+	var walk func(value interface{}) error
+	walk = func(value interface{}) error {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			for key, elem := range v {
+				if limits, ok := elem.(map[string]interface{}); ok && key == "limits" {
+					for name, quantity := range limits {
+						if name != "gpu" && !strings.HasSuffix(name, "/gpu") {
+							continue
+						}
+						count, err := parseCount(quantity)
+						if err != nil {
+							return fmt.Errorf("limits.%s: %w", name, err)
+						}
+						largest = max(largest, count)
+					}
+				}
+				if err := walk(elem); err != nil {
+					return err
+				}
+			}
+		case []interface{}:
+			for _, elem := range v {
+				if err := walk(elem); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	for _, obj := range result.Resources {
+		if err := walk(obj.Object); err != nil {
+			return 0, fmt.Errorf("%s: %w", provider.RefFor(obj), err)
+		}
+	}
+	return largest, nil
+}
+
+// parseCount parses a resource quantity given as a string or number
+func parseCount(value interface{}) (int64, error) {
+	switch v := value.(type) {
+	case string:
+		q, err := resource.ParseQuantity(v)
+		if err != nil {
+			return 0, err
+		}
+		return q.Value(), nil
+	case int64:
+		return v, nil
+	case float64:
+		return int64(v), nil
+	default:
+		return strconv.ParseInt(fmt.Sprint(v), 10, 64)
+	}
+}
+
+// checkStatus requires the translator to reject a nil upstream, keep a resource without
+// status out of Running, and map the provider's status cases
+func (s Suite) checkStatus(t *testing.T) {
+	if s.StatusTranslator == nil {
+		t.Skip("no StatusTranslator")
+	}
+	if _, err := s.StatusTranslator.TranslateStatus(nil); err == nil {
+		t.Error("TranslateStatus(nil) did not return an error")
+	}
+
+	primary := s.transform(t, s.modelDeployment()).Primary()
+	fresh := primary.DeepCopy()
+	unstructured.RemoveNestedField(fresh.Object, "status")
+	result, err := s.StatusTranslator.TranslateStatus(fresh)
+	if err != nil {
+		t.Fatalf("TranslateStatus of a new resource failed: %v", err)
+	}
+	if result.ResourceName != primary.GetName() {
+		t.Errorf("ResourceName is %q, want %q", result.ResourceName, primary.GetName())
+	}
+	if result.ResourceKind != primary.GetKind() {
+		t.Errorf("ResourceKind is %q, want %q", result.ResourceKind, primary.GetKind())
+	}
+	if result.Phase == airunwayv1alpha1.DeploymentPhaseRunning {
+		t.Error("a resource without status is reported Running")
+	}
+	readiness, hasReadiness := s.StatusTranslator.(ReadinessChecker)
+	if hasReadiness && readiness.IsReady(fresh) {
+		t.Error("a resource without status is reported ready")
+	}
+	errors, hasErrors := s.StatusTranslator.(ErrorReporter)
+
+	for _, tc := range s.StatusCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			upstream := primary.DeepCopy()
+			upstream.Object["status"] = runtime.DeepCopyJSONValue(tc.Status)
+			result, err := s.StatusTranslator.TranslateStatus(upstream)
+			if err != nil {
+				t.Fatalf("TranslateStatus failed: %v", err)
+			}
+			if result.Phase != tc.Phase {
+				t.Errorf("phase is %s, want %s", result.Phase, tc.Phase)
+			}
+			running := tc.Phase == airunwayv1alpha1.DeploymentPhaseRunning
+			if hasReadiness && readiness.IsReady(upstream) != running {
+				t.Errorf("IsReady is %t for phase %s", !running, tc.Phase)
+			}
+			if tc.Phase == airunwayv1alpha1.DeploymentPhaseFailed && result.Message == "" &&
+				(!hasErrors || errors.GetErrorMessage(upstream) == "") {
+				t.Error("a failed resource has no message")
+			}
+		})
+	}
+}
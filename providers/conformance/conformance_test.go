@@ -0,0 +1,137 @@
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/pkg/provider"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// deploymentTransformer is a minimal provider that runs the model as a Deployment
+type deploymentTransformer struct{}
+
+func (deploymentTransformer) Transform(_ context.Context, md *airunwayv1alpha1.ModelDeployment) (*provider.TransformResult, error) {
+	if md.Spec.Provider != nil && md.Spec.Provider.Overrides != nil {
+		return nil, fmt.Errorf("overrides are not supported")
+	}
+	d := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{
+							"name": "server",
+							"resources": map[string]interface{}{
+								"limits": map[string]interface{}{
+									"nvidia.com/gpu": fmt.Sprintf("%d", md.Spec.Resources.GPU.Count),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}}
+	d.SetAPIVersion("apps/v1")
+	d.SetKind("Deployment")
+	d.SetName(md.Name)
+	d.SetNamespace(md.Namespace)
+	d.SetLabels(map[string]string{airunwayv1alpha1.LabelManagedBy: airunwayv1alpha1.ManagedByAIRunway})
+	d.SetOwnerReferences([]metav1.OwnerReference{
+		*metav1.NewControllerRef(md, airunwayv1alpha1.GroupVersion.WithKind("ModelDeployment")),
+	})
+	result := provider.NewTransformResult(d)
+	provider.ApplyPropagatedMetadata(result, md)
+	return result, nil
+}
+
+// deploymentStatus maps Deployment availability to a phase
+type deploymentStatus struct{}
+
+func (deploymentStatus) TranslateStatus(upstream *unstructured.Unstructured) (*StatusResult, error) {
+	if upstream == nil {
+		return nil, fmt.Errorf("upstream resource is nil")
+	}
+	result := &StatusResult{
+		Phase:        airunwayv1alpha1.DeploymentPhasePending,
+		ResourceName: upstream.GetName(),
+		ResourceKind: upstream.GetKind(),
+	}
+	if available, _, _ := unstructured.NestedInt64(upstream.Object, "status", "availableReplicas"); available > 0 {
+		result.Phase = airunwayv1alpha1.DeploymentPhaseRunning
+	}
+	return result, nil
+}
+
+func (s deploymentStatus) IsReady(upstream *unstructured.Unstructured) bool {
+	result, err := s.TranslateStatus(upstream)
+	return err == nil && result.Phase == airunwayv1alpha1.DeploymentPhaseRunning
+}
+
+var _ ReadinessChecker = deploymentStatus{}
+
+func TestSuite(t *testing.T) {
+	Suite{
+		Transformer:      deploymentTransformer{},
+		StatusTranslator: deploymentStatus{},
+		StatusCases: []StatusCase{
+			{
+				Name:   "available",
+				Status: map[string]interface{}{"availableReplicas": int64(1)},
+				Phase:  airunwayv1alpha1.DeploymentPhaseRunning,
+			},
+			{
+				Name:   "unavailable",
+				Status: map[string]interface{}{"availableReplicas": int64(0)},
+				Phase:  airunwayv1alpha1.DeploymentPhasePending,
+			},
+		},
+	}.Run(t)
+}
+
+func TestLimitsGPUCount(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"kind": "Graph",
+		"spec": map[string]interface{}{
+			"services": map[string]interface{}{
+				"Frontend": map[string]interface{}{
+					"resources": map[string]interface{}{
+						"limits": map[string]interface{}{"cpu": "2"},
+					},
+				},
+				"Worker": map[string]interface{}{
+					"resources": map[string]interface{}{
+						"limits": map[string]interface{}{"gpu": "4"},
+					},
+				},
+				"Sidecar": map[string]interface{}{
+					"resources": map[string]interface{}{
+						"limits": map[string]interface{}{"amd.com/gpu": int64(2)},
+					},
+				},
+			},
+		},
+	}}
+	obj.SetName("graph")
+
+	got, err := LimitsGPUCount(provider.NewTransformResult(obj))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != 4 {
+		t.Errorf("expected 4 GPUs, got %d", got)
+	}
+
+	obj.Object["spec"] = map[string]interface{}{
+		"resources": map[string]interface{}{
+			"limits": map[string]interface{}{"nvidia.com/gpu": "many"},
+		},
+	}
+	if _, err := LimitsGPUCount(provider.NewTransformResult(obj)); err == nil {
+		t.Error("expected an error for an invalid GPU quantity")
+	}
+}
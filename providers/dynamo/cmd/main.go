@@ -24,6 +24,7 @@ import (
 
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/discovery"
@@ -36,6 +37,7 @@ import (
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
 	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/pkg/provider"
 	"github.com/kaito-project/airunway/controller/pkg/storage"
 	dynamo "github.com/kaito-project/airunway/providers/dynamo"
 )
@@ -58,6 +60,9 @@ func main() {
 	var secureMetrics bool
 	var enableHTTP2 bool
 	var downloadJobImage string
+	var platformNamespace string
+	var platformEtcdSelector, platformNATSSelector string
+	var checkPlatformDependencies, installDevPlatformDependencies bool
 	var tlsOpts []func(*tls.Config)
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8443", "The address the metrics endpoint binds to.")
@@ -73,6 +78,17 @@ func main() {
 		"If set, HTTP/2 will be enabled for the metrics server.")
 	flag.StringVar(&downloadJobImage, "download-job-image", storage.DefaultDownloadJobImage,
 		"Container image for model download jobs.")
+	flag.StringVar(&platformNamespace, "platform-namespace", dynamo.DefaultPlatformNamespace,
+		"The namespace of the Dynamo platform, where etcd and NATS are expected.")
+	flag.StringVar(&platformEtcdSelector, "platform-etcd-selector", "app.kubernetes.io/name=etcd",
+		"The label selector of the etcd Services in the platform namespace.")
+	flag.StringVar(&platformNATSSelector, "platform-nats-selector", "app.kubernetes.io/name=nats",
+		"The label selector of the NATS Services in the platform namespace.")
+	flag.BoolVar(&checkPlatformDependencies, "check-platform-dependencies", false,
+		"If set, deployments wait in Pending until etcd and NATS are serving in the platform namespace.")
+	flag.BoolVar(&installDevPlatformDependencies, "install-dev-platform-dependencies", false,
+		"If set, a single-replica, non-persistent etcd and NATS are installed in the platform namespace when missing. "+
+			"For development clusters only.")
 
 	opts := zap.Options{Development: true}
 	opts.BindFlags(flag.CommandLine)
@@ -122,6 +138,29 @@ func main() {
 
 	// Set up the Dynamo provider reconciler
 	reconciler := dynamo.NewDynamoProviderReconciler(mgr.GetClient(), mgr.GetScheme(), downloadJobImage)
+	// Emit DynamoGraphDeployments in the API version the installed Dynamo operator serves
+	reconciler.Transformer = dynamo.NewTransformer(discoveryClient)
+	reconciler.Recorder = mgr.GetEventRecorder(dynamo.FieldManager)
+	reconciler.Events = provider.NewEventMirror(mgr.GetAPIReader(), reconciler.Recorder)
+	reconciler.Overrides = provider.NewOverridesValidator(discoveryClient)
+	if checkPlatformDependencies {
+		selectors := map[string]labels.Selector{}
+		for name, value := range map[string]string{"etcd": platformEtcdSelector, "nats": platformNATSSelector} {
+			selector, err := labels.Parse(value)
+			if err != nil {
+				setupLog.Error(err, "invalid platform dependency selector", "dependency", name)
+				os.Exit(1)
+			}
+			selectors[name] = selector
+		}
+		reconciler.Platform = &dynamo.PlatformDependencies{
+			Reader:          mgr.GetAPIReader(),
+			Writer:          mgr.GetClient(),
+			Namespace:       platformNamespace,
+			Selectors:       selectors,
+			InstallDevStack: installDevPlatformDependencies,
+		}
+	}
 	if err := reconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "DynamoProvider")
 		os.Exit(1)
@@ -129,6 +168,7 @@ func main() {
 
 	// Set up the ProviderConfigManager for self-registration and heartbeat
 	configManager := dynamo.NewProviderConfigManager(mgr.GetClient(), discoveryClient)
+	configManager.PlatformNamespace = platformNamespace
 	if err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
 		setupLog.Info("registering Dynamo provider config")
 		if err := configManager.Register(ctx); err != nil {
@@ -32,6 +32,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/pkg/provider"
 )
 
 const (
@@ -55,12 +56,21 @@ const (
 
 	dynamoPlatformValuesJSON      = `{"global.grove.install":true}`
 	dynamoGraphDeploymentResource = "dynamographdeployments"
+	dynamoGraphDeploymentCRD      = dynamoGraphDeploymentResource + "." + DynamoAPIGroup
+
+	// minDynamoOperatorVersion is the oldest Dynamo operator whose DynamoGraphDeployment
+	// schema matches the transformer output
+	minDynamoOperatorVersion = "1.0.0"
 )
 
 // ProviderConfigManager handles registration and heartbeat for the Dynamo provider
 type ProviderConfigManager struct {
 	client          client.Client
 	discoveryClient discovery.DiscoveryInterface
+
+	// PlatformNamespace is the namespace of the Dynamo platform, registered as the network
+	// dependency of model pods. DefaultPlatformNamespace when empty.
+	PlatformNamespace string
 }
 
 // NewProviderConfigManager creates a new provider config manager
@@ -74,6 +84,31 @@ func NewProviderConfigManager(c client.Client, discoveryClients ...discovery.Dis
 	return manager
 }
 
+// SupportedFields lists the ModelDeployment spec fields the transformer carries into the
+// DynamoGraphDeployment. It is published in status.supportedFields so the core controller can
+// warn about fields this provider drops.
+var SupportedFields = []string{
+	provider.FieldImage,
+	provider.FieldEnv,
+	provider.FieldHuggingFaceToken,
+	provider.FieldSecretsRollout,
+	provider.FieldIdentity,
+	provider.FieldNodeSelector,
+	provider.FieldTolerations,
+	provider.FieldGangScheduling,
+	provider.FieldStorageVolumes,
+	provider.FieldChatTemplate,
+	provider.FieldTokenizer,
+	provider.FieldServedName,
+	provider.FieldLoadFormat,
+	provider.FieldContextLength,
+	provider.FieldEngineArgs,
+	provider.FieldTrustRemoteCode,
+	provider.FieldEnforceEager,
+	provider.FieldEnablePrefixCaching,
+	provider.FieldLogSink,
+}
+
 // GetProviderConfigSpec returns the InferenceProviderConfigSpec for Dynamo
 func GetProviderConfigSpec() airunwayv1alpha1.InferenceProviderConfigSpec {
 	return airunwayv1alpha1.InferenceProviderConfigSpec{
@@ -90,6 +125,9 @@ func GetProviderConfigSpec() airunwayv1alpha1.InferenceProviderConfigSpec {
 			CPUSupport: false,
 			GPUSupport: true,
 			Gateway: &airunwayv1alpha1.GatewayCapabilities{
+				// Dynamo runs its own KV-aware EPP, so the core controller must not
+				// create a second one; it only wires the HTTPRoute to Dynamo's pool.
+				GatewayManagement: airunwayv1alpha1.GatewayManagementProvider,
 				// The Dynamo operator creates the InferencePool as
 				// "{DynamoGraphDeployment.metadata.name}-pool" in the same
 				// namespace as the DGD.
@@ -119,6 +157,10 @@ func GetProviderConfigSpec() airunwayv1alpha1.InferenceProviderConfigSpec {
 				Priority:  50,
 			},
 		},
+		Compatibility: &airunwayv1alpha1.ProviderCompatibility{
+			CRDVersions:        NewTransformer().Versions.SupportedGroupVersions(),
+			MinOperatorVersion: minDynamoOperatorVersion,
+		},
 	}
 }
 
@@ -160,12 +202,18 @@ func (m *ProviderConfigManager) Register(ctx context.Context) error {
 		return fmt.Errorf("failed to build annotations: %w", err)
 	}
 
+	spec := GetProviderConfigSpec()
+	spec.Capabilities.NetworkDependencies = NetworkDependencies(m.PlatformNamespace)
+	if err := provider.ValidateCompatibility(spec.Compatibility); err != nil {
+		return fmt.Errorf("invalid provider compatibility: %w", err)
+	}
+
 	config := &airunwayv1alpha1.InferenceProviderConfig{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        ProviderConfigName,
 			Annotations: annotations,
 		},
-		Spec: GetProviderConfigSpec(),
+		Spec: spec,
 	}
 
 	existing := &airunwayv1alpha1.InferenceProviderConfig{}
@@ -179,7 +227,12 @@ func (m *ProviderConfigManager) Register(ctx context.Context) error {
 	} else if err != nil {
 		return fmt.Errorf("failed to get InferenceProviderConfig: %w", err)
 	} else {
+		// namespaceSelector, imageUpgrade and imagePolicy are set by cluster admins, not the provider
+		namespaceSelector, imageUpgrade, imagePolicy := existing.Spec.NamespaceSelector, existing.Spec.ImageUpgrade, existing.Spec.ImagePolicy
 		existing.Spec = config.Spec
+		existing.Spec.NamespaceSelector = namespaceSelector
+		existing.Spec.ImageUpgrade = imageUpgrade
+		existing.Spec.ImagePolicy = imagePolicy
 		if existing.Annotations == nil {
 			existing.Annotations = make(map[string]string)
 		}
@@ -192,47 +245,44 @@ func (m *ProviderConfigManager) Register(ctx context.Context) error {
 		}
 	}
 
-	// Update status — check if backend CRD is installed
-	ready := m.checkBackendCRDInstalled()
-	if !ready {
-		logger.Info("Backend CRD not installed, provider registered as not ready", "group", DynamoAPIGroup, "kind", DynamoGraphDeploymentKind)
-	}
-	return m.UpdateStatus(ctx, ready)
+	// Update status — not ready until the backend CRD is installed
+	return m.UpdateStatus(ctx, true)
 }
 
-// checkBackendCRDInstalled checks if the upstream DynamoGraphDeployment CRD is installed
-func (m *ProviderConfigManager) checkBackendCRDInstalled() bool {
-	if m.discoveryClient != nil {
-		return hasAPIResource(m.discoveryClient, DynamoAPIGroup, DynamoAPIVersion, dynamoGraphDeploymentResource)
-	}
-
-	mapper := m.client.RESTMapper()
-	if mapper == nil {
-		return false
-	}
-	_, err := mapper.RESTMapping(schema.GroupKind{
-		Group: DynamoAPIGroup,
-		Kind:  DynamoGraphDeploymentKind,
-	}, DynamoAPIVersion)
-	return err == nil
+// missingUpstreamCRDs probes the cluster for the upstream CRDs the provider creates
+// resources of
+func (m *ProviderConfigManager) missingUpstreamCRDs() ([]provider.UpstreamCRD, error) {
+	return provider.MissingUpstreamCRDs(m.discoveryClient, m.client.RESTMapper(), provider.UpstreamCRD{
+		Group:    DynamoAPIGroup,
+		Resource: dynamoGraphDeploymentResource,
+		Kind:     DynamoGraphDeploymentKind,
+	})
 }
 
-func hasAPIResource(discoveryClient discovery.DiscoveryInterface, group, version, resource string) bool {
-	resources, err := discoveryClient.ServerResourcesForGroupVersion(fmt.Sprintf("%s/%s", group, version))
-	if err != nil {
-		return false
-	}
+// detectUpstreamVersions returns the API versions the cluster serves DynamoGraphDeployments
+// in and the Dynamo operator version, when detectable. Detection failures leave them empty.
+func (m *ProviderConfigManager) detectUpstreamVersions(ctx context.Context) ([]string, string) {
+	logger := log.FromContext(ctx)
 
-	for _, apiResource := range resources.APIResources {
-		if apiResource.Name == resource {
-			return true
+	var served []string
+	if m.discoveryClient != nil {
+		var err error
+		if served, err = provider.ServedVersions(m.discoveryClient, DynamoAPIGroup, dynamoGraphDeploymentResource); err != nil {
+			logger.Info("Could not discover DynamoGraphDeployment API versions", "error", err.Error())
 		}
+	} else if mapper := m.client.RESTMapper(); mapper != nil {
+		served = provider.MappedVersions(mapper, schema.GroupKind{Group: DynamoAPIGroup, Kind: DynamoGraphDeploymentKind})
 	}
 
-	return false
+	operatorVersion, err := provider.OperatorVersionFromCRD(ctx, m.client, dynamoGraphDeploymentCRD)
+	if err != nil {
+		logger.Info("Could not read Dynamo operator version", "error", err.Error())
+	}
+	return served, operatorVersion
 }
 
-// UpdateStatus updates the status of the InferenceProviderConfig
+// UpdateStatus updates the status of the InferenceProviderConfig. The provider is reported
+// not ready when the upstream CRDs are missing or do not match spec.compatibility.
 func (m *ProviderConfigManager) UpdateStatus(ctx context.Context, ready bool) error {
 	config := &airunwayv1alpha1.InferenceProviderConfig{}
 	if err := m.client.Get(ctx, types.NamespacedName{Name: ProviderConfigName}, config); err != nil {
@@ -240,12 +290,31 @@ func (m *ProviderConfigManager) UpdateStatus(ctx context.Context, ready bool) er
 	}
 
 	now := metav1.Now()
-	config.Status = airunwayv1alpha1.InferenceProviderConfigStatus{
-		Ready:              ready,
-		Version:            ProviderVersion,
-		LastHeartbeat:      &now,
-		UpstreamCRDVersion: fmt.Sprintf("%s/%s", DynamoAPIGroup, DynamoAPIVersion),
+	status := airunwayv1alpha1.InferenceProviderConfigStatus{
+		Ready:                   ready,
+		Version:                 ProviderVersion,
+		LastHeartbeat:           &now,
+		LastHeartbeatTime:       &now,
+		ObservedProviderVersion: ProviderVersion,
+		UpstreamCRDVersion:      fmt.Sprintf("%s/%s", DynamoAPIGroup, DynamoAPIVersion),
+		SupportedFields:         SupportedFields,
+		ObservedGeneration:      config.Status.ObservedGeneration,
+		Conditions:              config.Status.Conditions,
+	}
+	served, operatorVersion := m.detectUpstreamVersions(ctx)
+	if !provider.SetUpstreamCompatibleCondition(&status, config.Spec.Compatibility, DynamoGraphDeploymentKind, served, operatorVersion) {
+		// Applying DynamoGraphDeployments would fail on the API version, so take the
+		// provider out of selection instead
+		status.Ready = false
 	}
+	missing, probeErr := m.missingUpstreamCRDs()
+	if !provider.SetUpstreamInstalledCondition(&status, missing, probeErr) {
+		// DynamoGraphDeployments would be rejected at apply time, so take the provider out of selection
+		// until the CRD is installed
+		log.FromContext(ctx).Info("Upstream CRDs not available, reporting not ready", "missing", missing, "error", probeErr)
+		status.Ready = false
+	}
+	config.Status = status
 
 	if err := m.client.Status().Update(ctx, config); err != nil {
 		return fmt.Errorf("failed to update InferenceProviderConfig status: %w", err)
@@ -268,11 +337,7 @@ func (m *ProviderConfigManager) StartHeartbeat(ctx context.Context) {
 				logger.Info("Stopping heartbeat goroutine")
 				return
 			case <-ticker.C:
-				ready := m.checkBackendCRDInstalled()
-				if !ready {
-					logger.Info("Backend CRD not installed, reporting not ready", "group", DynamoAPIGroup, "kind", DynamoGraphDeploymentKind)
-				}
-				if err := m.UpdateStatus(ctx, ready); err != nil {
+				if err := m.UpdateStatus(ctx, true); err != nil {
 					logger.Error(err, "Failed to update heartbeat")
 				}
 			}
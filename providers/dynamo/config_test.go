@@ -3,9 +3,13 @@ package dynamo
 import (
 	"context"
 	"encoding/json"
+	"slices"
+	"strings"
 	"testing"
 
 	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/pkg/provider"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	fakediscovery "k8s.io/client-go/discovery/fake"
@@ -54,6 +58,9 @@ func TestGetProviderConfigSpec(t *testing.T) {
 	if spec.Capabilities.Gateway.InferencePoolNamespace != "{namespace}" {
 		t.Errorf("expected inference pool namespace to be '{namespace}', got %s", spec.Capabilities.Gateway.InferencePoolNamespace)
 	}
+	if spec.Capabilities.Gateway.GatewayManagement != airunwayv1alpha1.GatewayManagementProvider {
+		t.Errorf("expected gateway management to be 'provider', got %s", spec.Capabilities.Gateway.GatewayManagement)
+	}
 }
 
 func TestGetInstallationInfo(t *testing.T) {
@@ -126,6 +133,9 @@ func TestRegisterExisting(t *testing.T) {
 
 	existing := &airunwayv1alpha1.InferenceProviderConfig{
 		ObjectMeta: metav1.ObjectMeta{Name: ProviderConfigName},
+		Spec: airunwayv1alpha1.InferenceProviderConfigSpec{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "ml-prod"}},
+		},
 	}
 
 	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing).WithStatusSubresource(existing).Build()
@@ -135,6 +145,22 @@ func TestRegisterExisting(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
+
+	// The admin-managed namespaceSelector survives re-registration
+	updated := &airunwayv1alpha1.InferenceProviderConfig{}
+	if err := c.Get(context.Background(), client.ObjectKey{Name: ProviderConfigName}, updated); err != nil {
+		t.Fatalf("failed to get config: %v", err)
+	}
+	if updated.Spec.NamespaceSelector == nil || updated.Spec.NamespaceSelector.MatchLabels["team"] != "ml-prod" {
+		t.Errorf("expected namespaceSelector to be preserved, got %+v", updated.Spec.NamespaceSelector)
+	}
+	if updated.Spec.Capabilities == nil {
+		t.Fatal("expected capabilities to be registered")
+	}
+	deps := updated.Spec.Capabilities.NetworkDependencies
+	if len(deps) != 2 || deps[0].Namespace != DefaultPlatformNamespace || deps[0].Ports[0] != 2379 || deps[1].Ports[0] != 4222 {
+		t.Errorf("expected etcd and NATS in the platform namespace as network dependencies, got %+v", deps)
+	}
 }
 
 func TestRegisterAnnotatesInstallationMetadata(t *testing.T) {
@@ -169,10 +195,14 @@ func TestRegisterAnnotatesInstallationMetadata(t *testing.T) {
 	}
 }
 
-func TestCheckBackendCRDInstalledUsesDiscoveryFreshResults(t *testing.T) {
+func TestUpdateStatusProbesUpstreamCRDs(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = airunwayv1alpha1.AddToScheme(scheme)
 
+	existing := &airunwayv1alpha1.InferenceProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: ProviderConfigName},
+		Spec:       GetProviderConfigSpec(),
+	}
 	discoveryClient := &fakediscovery.FakeDiscovery{
 		Fake: &k8stesting.Fake{},
 	}
@@ -185,17 +215,34 @@ func TestCheckBackendCRDInstalledUsesDiscoveryFreshResults(t *testing.T) {
 		},
 	}
 
-	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing).WithStatusSubresource(existing).Build()
 	mgr := NewProviderConfigManager(c, discoveryClient)
+	updated := &airunwayv1alpha1.InferenceProviderConfig{}
 
-	if !mgr.checkBackendCRDInstalled() {
-		t.Fatal("expected backend CRD to be detected")
+	if err := mgr.UpdateStatus(context.Background(), true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.Get(context.Background(), client.ObjectKey{Name: ProviderConfigName}, updated); err != nil {
+		t.Fatalf("failed to get updated provider config: %v", err)
+	}
+	if !updated.Status.Ready {
+		t.Fatal("expected provider to be ready when the upstream CRD is installed")
 	}
 
+	// Removal is detected on the next heartbeat with a reason naming the CRD
 	discoveryClient.Resources = []*metav1.APIResourceList{}
-
-	if mgr.checkBackendCRDInstalled() {
-		t.Fatal("expected backend CRD removal to be detected on the next check")
+	if err := mgr.UpdateStatus(context.Background(), true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.Get(context.Background(), client.ObjectKey{Name: ProviderConfigName}, updated); err != nil {
+		t.Fatalf("failed to get updated provider config: %v", err)
+	}
+	if updated.Status.Ready {
+		t.Error("expected provider to be not ready when the upstream CRD is missing")
+	}
+	cond := meta.FindStatusCondition(updated.Status.Conditions, airunwayv1alpha1.ConditionTypeUpstreamInstalled)
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != provider.ReasonUpstreamCRDsMissing || !strings.Contains(cond.Message, dynamoGraphDeploymentResource) {
+		t.Errorf("expected %s condition naming the CRD, got %+v", provider.ReasonUpstreamCRDsMissing, cond)
 	}
 }
 
@@ -207,8 +254,21 @@ func TestUpdateStatus(t *testing.T) {
 		ObjectMeta: metav1.ObjectMeta{Name: ProviderConfigName},
 	}
 
+	// The provider is only ready once the DynamoGraphDeployment CRD is served
+	discoveryClient := &fakediscovery.FakeDiscovery{
+		Fake: &k8stesting.Fake{},
+	}
+	discoveryClient.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: DynamoAPIGroup + "/" + DynamoAPIVersion,
+			APIResources: []metav1.APIResource{
+				{Name: dynamoGraphDeploymentResource},
+			},
+		},
+	}
+
 	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing).WithStatusSubresource(existing).Build()
-	mgr := NewProviderConfigManager(c)
+	mgr := NewProviderConfigManager(c, discoveryClient)
 
 	err := mgr.UpdateStatus(context.Background(), true)
 	if err != nil {
@@ -229,6 +289,14 @@ func TestUpdateStatus(t *testing.T) {
 	if updated.Status.LastHeartbeat == nil {
 		t.Fatal("expected provider status to include last heartbeat")
 	}
+	if updated.Status.LastHeartbeatTime == nil || updated.Status.ObservedProviderVersion != ProviderVersion {
+		t.Fatalf("expected heartbeat time and observed version %q, got %v and %q",
+			ProviderVersion, updated.Status.LastHeartbeatTime, updated.Status.ObservedProviderVersion)
+	}
+	if !slices.Contains(updated.Status.SupportedFields, provider.FieldTolerations) ||
+		slices.Contains(updated.Status.SupportedFields, provider.FieldPodTemplate) {
+		t.Fatalf("expected the supported fields to be published, got %v", updated.Status.SupportedFields)
+	}
 }
 
 func TestUnregister(t *testing.T) {
@@ -264,6 +332,43 @@ func TestStartHeartbeat(t *testing.T) {
 	cancel()
 }
 
+func TestUpdateStatusUpstreamVersionMismatch(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = airunwayv1alpha1.AddToScheme(scheme)
+
+	existing := &airunwayv1alpha1.InferenceProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: ProviderConfigName},
+		Spec:       GetProviderConfigSpec(),
+	}
+	discoveryClient := &fakediscovery.FakeDiscovery{
+		Fake: &k8stesting.Fake{},
+	}
+	discoveryClient.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: DynamoAPIGroup + "/v1beta1",
+			APIResources: []metav1.APIResource{
+				{Name: dynamoGraphDeploymentResource},
+			},
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing).WithStatusSubresource(existing).Build()
+	mgr := NewProviderConfigManager(c, discoveryClient)
+
+	if err := mgr.UpdateStatus(context.Background(), true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	updated := &airunwayv1alpha1.InferenceProviderConfig{}
+	if err := c.Get(context.Background(), client.ObjectKey{Name: ProviderConfigName}, updated); err != nil {
+		t.Fatalf("failed to get updated provider config: %v", err)
+	}
+	if updated.Status.Ready {
+		t.Error("expected provider to be not ready on an upstream version mismatch")
+	}
+	assertCondition(t, updated.Status.Conditions, airunwayv1alpha1.ConditionTypeUpstreamCompatible, metav1.ConditionFalse, provider.ReasonUpstreamVersionMismatch)
+}
+
 func TestUpdateStatusNotFound(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = airunwayv1alpha1.AddToScheme(scheme)
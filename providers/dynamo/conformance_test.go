@@ -0,0 +1,46 @@
+package dynamo
+
+import (
+	"testing"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/providers/conformance"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// conformanceStatus adapts StatusTranslator to the conformance suite
+type conformanceStatus struct {
+	*StatusTranslator
+}
+
+func (s conformanceStatus) TranslateStatus(upstream *unstructured.Unstructured) (*conformance.StatusResult, error) {
+	result, err := s.StatusTranslator.TranslateStatus(upstream)
+	if err != nil {
+		return nil, err
+	}
+	return (*conformance.StatusResult)(result), nil
+}
+
+func TestConformance(t *testing.T) {
+	conformance.Suite{
+		Transformer:      NewTransformer(),
+		StatusTranslator: conformanceStatus{NewStatusTranslator()},
+		StatusCases: []conformance.StatusCase{
+			{
+				Name:   "successful",
+				Status: map[string]interface{}{"state": string(DynamoStateSuccessful)},
+				Phase:  airunwayv1alpha1.DeploymentPhaseRunning,
+			},
+			{
+				Name:   "deploying",
+				Status: map[string]interface{}{"state": string(DynamoStateDeploying)},
+				Phase:  airunwayv1alpha1.DeploymentPhaseDeploying,
+			},
+			{
+				Name:   "failed",
+				Status: map[string]interface{}{"state": string(DynamoStateFailed), "message": "worker crashed"},
+				Phase:  airunwayv1alpha1.DeploymentPhaseFailed,
+			},
+		},
+	}.Run(t)
+}
@@ -32,6 +32,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
 	ctrlbuilder "sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -43,6 +44,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/pkg/provider"
 	"github.com/kaito-project/airunway/controller/pkg/storage"
 )
 
@@ -70,6 +72,20 @@ type DynamoProviderReconciler struct {
 	Transformer      *Transformer
 	StatusTranslator *StatusTranslator
 	DownloadJobImage string
+
+	// Recorder emits ResourceUpdated events on ModelDeployments. When nil, no events are emitted.
+	Recorder events.EventRecorder
+
+	// Events mirrors upstream Warning events onto ModelDeployments. When nil, none are mirrored.
+	Events *provider.EventMirror
+
+	// Overrides checks spec.provider.overrides against the published DynamoGraphDeployment schema. When nil,
+	// overrides are applied unchecked.
+	Overrides *provider.OverridesValidator
+
+	// Platform checks for the etcd and NATS services the Dynamo runtime needs before a
+	// DynamoGraphDeployment is applied. When nil, they are not checked.
+	Platform *PlatformDependencies
 }
 
 // NewDynamoProviderReconciler creates a new Dynamo provider reconciler
@@ -94,6 +110,13 @@ func NewDynamoProviderReconciler(client client.Client, scheme *runtime.Scheme, d
 // +kubebuilder:rbac:groups=nvidia.com,resources=dynamographdeployments,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch;create;delete
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=scheduling.volcano.sh,resources=podgroups,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=events,verbs=list
+// +kubebuilder:rbac:groups="",resources=services,verbs=list;create
+// +kubebuilder:rbac:groups=discovery.k8s.io,resources=endpointslices,verbs=list
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=create
 
 // Reconcile handles the reconciliation loop for ModelDeployments assigned to the Dynamo provider
 func (r *DynamoProviderReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -107,20 +130,33 @@ func (r *DynamoProviderReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 
 	// Only process if this provider is selected
 	if md.Status.Provider == nil || md.Status.Provider.Name != ProviderName {
+		// Delete the resources of a deployment migrated to another provider, or deleted
+		// while migrating away from this one
+		if provider.Released(&md, ProviderName, FinalizerName) {
+			return r.handleDeletion(ctx, &md)
+		}
 		return ctrl.Result{}, nil
 	}
 
 	logger.Info("Reconciling ModelDeployment for Dynamo provider", "name", md.Name, "namespace", md.Namespace)
 
-	// Check for pause annotation
-	if md.Annotations != nil && md.Annotations["airunway.ai/reconcile-paused"] == "true" {
+	// Handle deletion, even when paused, so the finalizer never blocks removal
+	if !md.DeletionTimestamp.IsZero() {
+		return r.handleDeletion(ctx, &md)
+	}
+
+	// Leave provider resources untouched while paused; the core controller
+	// reports the PausedReconciliation condition
+	if md.IsPaused() {
 		logger.Info("Reconciliation paused", "name", md.Name)
 		return ctrl.Result{}, nil
 	}
 
-	// Handle deletion
-	if !md.DeletionTimestamp.IsZero() {
-		return r.handleDeletion(ctx, &md)
+	// Create nothing until Kueue admits the deployment; the core controller moves it out of
+	// the Queued phase once admitted
+	if md.IsQueued() {
+		logger.Info("Waiting for Kueue admission", "name", md.Name)
+		return ctrl.Result{}, nil
 	}
 
 	// Add finalizer if not present
@@ -140,8 +176,40 @@ func (r *DynamoProviderReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		md.Status.Message = err.Error()
 		return ctrl.Result{}, r.Status().Update(ctx, &md)
 	}
+
+	// Refuse to apply while the cluster runs Dynamo CRD or operator versions the transformer
+	// does not support, rather than failing on the API version during apply
+	mismatch, err := provider.UpstreamIncompatibility(ctx, r.Client, ProviderConfigName)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if mismatch != "" {
+		message := fmt.Sprintf("Dynamo upstream version mismatch: %s", mismatch)
+		logger.Info("Upstream versions incompatible", "name", md.Name, "message", mismatch)
+		r.setCondition(&md, airunwayv1alpha1.ConditionTypeProviderCompatible, metav1.ConditionFalse, provider.ReasonUpstreamVersionMismatch, message)
+		md.Status.Phase = airunwayv1alpha1.DeploymentPhaseFailed
+		md.Status.Message = message
+		if statusErr := r.Status().Update(ctx, &md); statusErr != nil {
+			return ctrl.Result{}, statusErr
+		}
+		return ctrl.Result{RequeueAfter: HeartbeatInterval}, nil
+	}
 	r.setCondition(&md, airunwayv1alpha1.ConditionTypeProviderCompatible, metav1.ConditionTrue, "CompatibilityVerified", "Configuration compatible with Dynamo")
 
+	// Wait for etcd and NATS rather than creating workers that cannot register with the
+	// runtime and fail without saying why
+	ready, err := r.ensurePlatformDependencies(ctx, &md)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !ready {
+		logger.Info("Dynamo platform dependencies missing", "name", md.Name, "message", md.Status.Message)
+		if statusErr := r.Status().Update(ctx, &md); statusErr != nil {
+			return ctrl.Result{}, statusErr
+		}
+		return ctrl.Result{RequeueAfter: RequeueInterval}, nil
+	}
+
 	// --- Phase 1: Ensure PVCs ---
 	if storage.HasStorageVolumes(&md) {
 		allReady, err := storage.EnsurePVCs(ctx, r.Client, &md)
@@ -189,7 +257,16 @@ func (r *DynamoProviderReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	// --- Phase 3: Create/update DGD ---
 
 	// Transform ModelDeployment to DynamoGraphDeployment
-	resources, err := r.Transformer.Transform(ctx, &md)
+	// Keep the previous default image while spec.imageUpgrade of the provider config staggers
+	// the rollout of a new one
+	target, image, err := provider.HoldImageUpgrade(ctx, r.Client, ProviderConfigName, &md, r.Transformer.DefaultImage)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	result, err := r.Transformer.Transform(ctx, target)
+	if err == nil {
+		err = result.Validate()
+	}
 	if err != nil {
 		logger.Error(err, "Failed to transform ModelDeployment", "name", md.Name)
 		r.setCondition(&md, airunwayv1alpha1.ConditionTypeResourceCreated, metav1.ConditionFalse, "TransformFailed", err.Error())
@@ -198,46 +275,78 @@ func (r *DynamoProviderReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return ctrl.Result{}, r.Status().Update(ctx, &md)
 	}
 
-	// Create or update the DynamoGraphDeployment
-	for _, resource := range resources {
-		if err := r.createOrUpdateResource(ctx, resource, &md); err != nil {
-			logger.Error(err, "Failed to create/update resource", "name", resource.GetName(), "kind", resource.GetKind())
-			// requeue to retry with the latest version rather than marking
-			// the deployment as Failed to prevent triggering gateway resource cleanup and
-			// invalidate the EPP pod's ServiceAccount token.
-			if errors.IsConflict(err) {
-				r.setCondition(&md, airunwayv1alpha1.ConditionTypeResourceCreated, metav1.ConditionFalse, "ResourceConflict", err.Error())
-				if statusErr := r.Status().Update(ctx, &md); statusErr != nil {
-					return ctrl.Result{}, statusErr
-				}
-				return ctrl.Result{RequeueAfter: time.Second}, nil
-			}
-			reason := "CreateFailed"
-			if isResourceConflict(err) {
-				reason = "ResourceConflict"
-				r.setCondition(&md, airunwayv1alpha1.ConditionTypeReady, metav1.ConditionFalse, "ResourceConflict", err.Error())
+	// Check the images of the rendered resources, which include component, sidecar and
+	// override images, and pin signed images to their verified digest
+	violation, err := provider.EnforceImagePolicy(ctx, r.Client, ProviderConfigName, result.Resources)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if violation != "" {
+		r.setCondition(&md, airunwayv1alpha1.ConditionTypeResourceCreated, metav1.ConditionFalse, provider.ReasonImagePolicyViolation, violation)
+		md.Status.Phase = airunwayv1alpha1.DeploymentPhaseFailed
+		md.Status.Message = violation
+		return ctrl.Result{RequeueAfter: provider.ImagePolicyRecheckInterval}, r.Status().Update(ctx, &md)
+	}
+
+	// Report overrides the DynamoGraphDeployment schema does not declare with their field paths, rather
+	// than as an API server rejection of the merged object
+	if err := r.Overrides.Check(&md, result.Primary()); err != nil {
+		logger.Error(err, "Invalid provider overrides", "name", md.Name)
+		r.setCondition(&md, airunwayv1alpha1.ConditionTypeResourceCreated, metav1.ConditionFalse, provider.ReasonInvalidOverrides, err.Error())
+		md.Status.Phase = airunwayv1alpha1.DeploymentPhaseFailed
+		md.Status.Message = err.Error()
+		return ctrl.Result{}, r.Status().Update(ctx, &md)
+	}
+
+	// Create or update the DynamoGraphDeployment after the resources it depends on. Resources
+	// created by this apply are deleted again if a later one fails.
+	err = provider.ApplyResources(ctx, r.Client, result.Resources, func(ctx context.Context, resource *unstructured.Unstructured) (bool, error) {
+		return r.createOrUpdateResource(ctx, resource, &md)
+	})
+	md.Status.Provider.PartialApply = provider.PartialApply(err)
+	if err != nil {
+		logger.Error(err, "Failed to create/update resources", "name", md.Name)
+		// requeue to retry with the latest version rather than marking
+		// the deployment as Failed to prevent triggering gateway resource cleanup and
+		// invalidate the EPP pod's ServiceAccount token.
+		if errors.IsConflict(err) {
+			r.setCondition(&md, airunwayv1alpha1.ConditionTypeResourceCreated, metav1.ConditionFalse, "ResourceConflict", err.Error())
+			if statusErr := r.Status().Update(ctx, &md); statusErr != nil {
+				return ctrl.Result{}, statusErr
 			}
-			r.setCondition(&md, airunwayv1alpha1.ConditionTypeResourceCreated, metav1.ConditionFalse, reason, err.Error())
-			md.Status.Phase = airunwayv1alpha1.DeploymentPhaseFailed
-			md.Status.Message = fmt.Sprintf("Failed to create DynamoGraphDeployment: %s", err.Error())
-			return ctrl.Result{}, r.Status().Update(ctx, &md)
+			return ctrl.Result{RequeueAfter: time.Second}, nil
+		}
+		reason := "CreateFailed"
+		if isResourceConflict(err) {
+			reason = "ResourceConflict"
+			r.setCondition(&md, airunwayv1alpha1.ConditionTypeReady, metav1.ConditionFalse, "ResourceConflict", err.Error())
 		}
+		r.setCondition(&md, airunwayv1alpha1.ConditionTypeResourceCreated, metav1.ConditionFalse, reason, err.Error())
+		md.Status.Phase = airunwayv1alpha1.DeploymentPhaseFailed
+		md.Status.Message = fmt.Sprintf("Failed to create DynamoGraphDeployment: %s", err.Error())
+		return ctrl.Result{}, r.Status().Update(ctx, &md)
 	}
 
 	r.setCondition(&md, airunwayv1alpha1.ConditionTypeResourceCreated, metav1.ConditionTrue, "ResourceCreated", "DynamoGraphDeployment created successfully")
+	provider.RecordDefaultImage(&md, image)
 
 	// Update provider status
 	md.Status.Provider.ResourceName = md.Name
 	md.Status.Provider.ResourceKind = DynamoGraphDeploymentKind
 
 	// Sync status from upstream resource
-	if len(resources) > 0 {
-		if err := r.syncStatus(ctx, &md, resources[0]); err != nil {
+	if primary := result.Primary(); primary != nil {
+		if err := r.syncStatus(ctx, &md, primary); err != nil {
 			logger.Error(err, "Failed to sync status", "name", md.Name)
 			// Don't fail the reconciliation, just log the error
 		}
 	}
 
+	// Mirror upstream warnings, e.g. on pods users cannot read, onto the ModelDeployment
+	if err := r.Events.Mirror(ctx, &md); err != nil {
+		logger.Error(err, "Failed to mirror upstream events", "name", md.Name)
+	}
+
 	// Set phase to Deploying if not already Running or Failed
 	if md.Status.Phase != airunwayv1alpha1.DeploymentPhaseRunning &&
 		md.Status.Phase != airunwayv1alpha1.DeploymentPhaseFailed {
@@ -310,7 +419,7 @@ func verifyDynamoOwnership(existing *unstructured.Unstructured, mdUID types.UID)
 }
 
 // createOrUpdateResource creates or updates an unstructured resource
-func (r *DynamoProviderReconciler) createOrUpdateResource(ctx context.Context, resource *unstructured.Unstructured, md *airunwayv1alpha1.ModelDeployment) error {
+func (r *DynamoProviderReconciler) createOrUpdateResource(ctx context.Context, resource *unstructured.Unstructured, md *airunwayv1alpha1.ModelDeployment) (bool, error) {
 	logger := log.FromContext(ctx)
 
 	// Check if resource exists
@@ -325,32 +434,40 @@ func (r *DynamoProviderReconciler) createOrUpdateResource(ctx context.Context, r
 	if errors.IsNotFound(err) {
 		// Create new resource
 		logger.Info("Creating resource", "kind", resource.GetKind(), "name", resource.GetName())
-		return r.Create(ctx, resource)
+		return true, r.Create(ctx, resource)
 	}
 	if err != nil {
-		return fmt.Errorf("failed to get existing resource: %w", err)
+		return false, fmt.Errorf("failed to get existing resource: %w", err)
 	}
 
 	// Verify ownership before updating
 	if err := verifyDynamoOwnership(existing, md.UID); err != nil {
-		return err
+		return false, err
 	}
 
-	// Update existing resource if spec has changed.
+	// Update existing resource if spec, or data for a ConfigMap, has changed.
 	// The Dynamo CRD API server adds zero-value defaults (e.g. name: "",
 	// resources: {}) that the provider never sets. Comparing raw specs would
 	// trigger an infinite update loop. Strip server-added zero-values
 	// from the existing spec before comparing.
 	existingSpec, _, _ := unstructured.NestedMap(existing.Object, "spec")
 	newSpec, _, _ := unstructured.NestedMap(resource.Object, "spec")
+	existingData, _, _ := unstructured.NestedMap(existing.Object, "data")
+	newData, _, _ := unstructured.NestedMap(resource.Object, "data")
 
-	if !equality.Semantic.DeepEqual(stripEmptyDefaults(existingSpec), stripEmptyDefaults(newSpec)) {
+	if !equality.Semantic.DeepEqual(stripEmptyDefaults(existingSpec), stripEmptyDefaults(newSpec)) ||
+		!equality.Semantic.DeepEqual(existingData, newData) {
 		logger.Info("Updating resource", "kind", resource.GetKind(), "name", resource.GetName())
 		resource.SetResourceVersion(existing.GetResourceVersion())
-		return r.Update(ctx, resource)
+		if err := r.Update(ctx, resource); err != nil {
+			return false, err
+		}
+		if err := provider.RecordResourceChange(md, r.Recorder, existing, resource, "spec", "data"); err != nil {
+			logger.Error(err, "Failed to record resource change", "kind", resource.GetKind(), "name", resource.GetName())
+		}
 	}
 
-	return nil
+	return false, nil
 }
 
 // stripEmptyDefaults recursively removes zero-value fields (empty strings,
@@ -438,7 +555,7 @@ func (r *DynamoProviderReconciler) syncStatus(ctx context.Context, md *airunwayv
 	return nil
 }
 
-// handleDeletion handles the deletion of a ModelDeployment
+// handleDeletion handles the deletion of a ModelDeployment, or its migration to another provider
 func (r *DynamoProviderReconciler) handleDeletion(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
@@ -448,22 +565,29 @@ func (r *DynamoProviderReconciler) handleDeletion(ctx context.Context, md *airun
 
 	logger.Info("Handling deletion", "name", md.Name, "namespace", md.Namespace)
 
-	// Update phase to Terminating
-	md.Status.Phase = airunwayv1alpha1.DeploymentPhaseTerminating
-	if err := r.Status().Update(ctx, md); err != nil {
-		logger.Error(err, "Failed to update status to Terminating")
+	// Update phase to Terminating, unless the deployment was only migrated to another provider
+	if !md.DeletionTimestamp.IsZero() {
+		md.Status.Phase = airunwayv1alpha1.DeploymentPhaseTerminating
+		if err := r.Status().Update(ctx, md); err != nil {
+			logger.Error(err, "Failed to update status to Terminating")
+		}
 	}
 
-	// Delete the DGD first so its Pods terminate before we remove PVCs/Jobs
+	// Delete the DGD first so its Pods terminate before we remove PVCs/Jobs, in the
+	// version the cluster was negotiated to serve
+	version, err := r.Transformer.Versions.Version()
+	if err != nil {
+		version = DynamoAPIVersion
+	}
 	dgd := &unstructured.Unstructured{}
 	dgd.SetGroupVersionKind(schema.GroupVersionKind{
 		Group:   DynamoAPIGroup,
-		Version: DynamoAPIVersion,
+		Version: version,
 		Kind:    DynamoGraphDeploymentKind,
 	})
 
 	dgdName := md.Name
-	err := r.Get(ctx, types.NamespacedName{
+	err = r.Get(ctx, types.NamespacedName{
 		Name:      dgdName,
 		Namespace: md.Namespace,
 	}, dgd)
@@ -485,7 +609,7 @@ func (r *DynamoProviderReconciler) handleDeletion(ctx context.Context, md *airun
 				logger.Error(err, "Failed to delete DynamoGraphDeployment")
 
 				// Check if we should force-remove the finalizer
-				deletionTime := md.DeletionTimestamp.Time
+				deletionTime := provider.CleanupStart(md)
 				if time.Since(deletionTime) > FinalizerTimeout {
 					logger.Info("Finalizer timeout reached, removing finalizer without cleanup")
 					controllerutil.RemoveFinalizer(md, FinalizerName)
@@ -503,7 +627,7 @@ func (r *DynamoProviderReconciler) handleDeletion(ctx context.Context, md *airun
 
 	if !upstreamResourceUnavailable(err) {
 		// Unexpected error fetching DGD — check timeout before requeueing
-		deletionTime := md.DeletionTimestamp.Time
+		deletionTime := provider.CleanupStart(md)
 		if time.Since(deletionTime) > FinalizerTimeout {
 			logger.Info("Finalizer timeout reached, removing finalizer without cleanup")
 			controllerutil.RemoveFinalizer(md, FinalizerName)
@@ -525,7 +649,7 @@ func (r *DynamoProviderReconciler) handleDeletion(ctx context.Context, md *airun
 	}
 	if err := stderrors.Join(cleanupErrs...); err != nil {
 		// Check if we should force-remove the finalizer
-		deletionTime := md.DeletionTimestamp.Time
+		deletionTime := provider.CleanupStart(md)
 		if time.Since(deletionTime) > FinalizerTimeout {
 			logger.Info("Finalizer timeout reached, removing finalizer without cleanup")
 			controllerutil.RemoveFinalizer(md, FinalizerName)
@@ -653,12 +777,16 @@ func (r *DynamoProviderReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	// Without this check, the manager crashes at startup when
 	// the backend CRDs are not present (see #178).
 	mapper := mgr.GetRESTMapper()
-	if _, err := mapper.RESTMapping(schema.GroupKind{Group: DynamoAPIGroup, Kind: DynamoGraphDeploymentKind}, DynamoAPIVersion); err == nil {
+	version, err := r.Transformer.Versions.Version()
+	if err != nil {
+		version = DynamoAPIVersion
+	}
+	if _, err := mapper.RESTMapping(schema.GroupKind{Group: DynamoAPIGroup, Kind: DynamoGraphDeploymentKind}, version); err == nil {
 		logger := mgr.GetLogger()
 		logger.Info("DynamoGraphDeployment CRD detected, enabling event-driven watch")
 		builder = builder.Watches(
 			&unstructured.Unstructured{Object: map[string]interface{}{
-				"apiVersion": fmt.Sprintf("%s/%s", DynamoAPIGroup, DynamoAPIVersion),
+				"apiVersion": fmt.Sprintf("%s/%s", DynamoAPIGroup, version),
 				"kind":       DynamoGraphDeploymentKind,
 			}},
 			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
@@ -676,7 +804,7 @@ func (r *DynamoProviderReconciler) SetupWithManager(mgr ctrl.Manager) error {
 					}
 				}
 				labels := obj.GetLabels()
-				if labels[airunwayv1alpha1.LabelManagedBy] == "airunway" {
+				if labels[airunwayv1alpha1.LabelManagedBy] == airunwayv1alpha1.ManagedByAIRunway {
 					if deployment := labels[airunwayv1alpha1.LabelModelDeployment]; deployment != "" {
 						return []reconcile.Request{
 							{
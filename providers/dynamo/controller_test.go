@@ -3,10 +3,12 @@ package dynamo
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/pkg/provider"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -311,6 +313,27 @@ func TestReconcilePaused(t *testing.T) {
 	}
 }
 
+func TestReconcileQueued(t *testing.T) {
+	scheme := newScheme()
+	md := newMDForController("test", "default")
+	md.Status.Phase = airunwayv1alpha1.DeploymentPhaseQueued
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(md).WithStatusSubresource(md).Build()
+	r := NewDynamoProviderReconciler(c, scheme, "")
+
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{
+		NamespacedName: types.NamespacedName{Name: "test", Namespace: "default"},
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var updated airunwayv1alpha1.ModelDeployment
+	_ = c.Get(context.Background(), types.NamespacedName{Name: "test", Namespace: "default"}, &updated)
+	if controllerutil.ContainsFinalizer(&updated, FinalizerName) {
+		t.Error("expected no finalizer to be added while waiting for Kueue admission")
+	}
+}
+
 func TestReconcileAddsFinalizer(t *testing.T) {
 	scheme := newScheme()
 	md := newMDForController("test", "default")
@@ -358,6 +381,49 @@ func TestReconcileIncompatibleEngine(t *testing.T) {
 	}
 }
 
+func TestReconcileUpstreamVersionMismatch(t *testing.T) {
+	scheme := newScheme()
+	md := newMDForController("test", "default")
+	controllerutil.AddFinalizer(md, FinalizerName)
+	config := &airunwayv1alpha1.InferenceProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: ProviderConfigName},
+		Status: airunwayv1alpha1.InferenceProviderConfigStatus{
+			Conditions: []metav1.Condition{{
+				Type:    airunwayv1alpha1.ConditionTypeUpstreamCompatible,
+				Status:  metav1.ConditionFalse,
+				Reason:  provider.ReasonUpstreamVersionMismatch,
+				Message: "cluster serves nvidia.com/v1beta1 but the provider creates nvidia.com/v1alpha1",
+			}},
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(md, config).WithStatusSubresource(md).Build()
+	r := NewDynamoProviderReconciler(c, scheme, "")
+
+	result, err := r.Reconcile(context.Background(), ctrl.Request{
+		NamespacedName: types.NamespacedName{Name: "test", Namespace: "default"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.RequeueAfter != HeartbeatInterval {
+		t.Errorf("expected requeue after %s, got %s", HeartbeatInterval, result.RequeueAfter)
+	}
+
+	var updated airunwayv1alpha1.ModelDeployment
+	_ = c.Get(context.Background(), types.NamespacedName{Name: "test", Namespace: "default"}, &updated)
+	if updated.Status.Phase != airunwayv1alpha1.DeploymentPhaseFailed {
+		t.Errorf("expected Failed phase, got %s", updated.Status.Phase)
+	}
+	assertCondition(t, updated.Status.Conditions, airunwayv1alpha1.ConditionTypeProviderCompatible, metav1.ConditionFalse, provider.ReasonUpstreamVersionMismatch)
+
+	dgd := &unstructured.Unstructured{}
+	setDGDGVK(dgd)
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "test", Namespace: "default"}, dgd); err == nil {
+		t.Error("expected no DynamoGraphDeployment to be applied")
+	}
+}
+
 func TestReconcileNilProvider(t *testing.T) {
 	scheme := newScheme()
 	md := newMDForController("test", "default")
@@ -403,6 +469,49 @@ func TestReconcileSuccessfulCreate(t *testing.T) {
 	}
 }
 
+func TestReconcileImagePolicyChecksComponentImages(t *testing.T) {
+	scheme := newScheme()
+	md := newMDForController("test", "default")
+	md.Spec.Serving = &airunwayv1alpha1.ServingSpec{Mode: airunwayv1alpha1.ServingModeDisaggregated}
+	md.Spec.Scaling = &airunwayv1alpha1.ScalingSpec{
+		Prefill: &airunwayv1alpha1.ComponentScalingSpec{Replicas: 1, GPU: &airunwayv1alpha1.GPUSpec{Count: 1}, Image: "evil.io/vllm-runtime:latest"},
+		Decode:  &airunwayv1alpha1.ComponentScalingSpec{Replicas: 1, GPU: &airunwayv1alpha1.GPUSpec{Count: 1}},
+	}
+	controllerutil.AddFinalizer(md, FinalizerName)
+	config := &airunwayv1alpha1.InferenceProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: ProviderConfigName},
+		Spec: airunwayv1alpha1.InferenceProviderConfigSpec{
+			ImagePolicy: &airunwayv1alpha1.ImagePolicySpec{AllowedRegistries: []string{"nvcr.io/nvidia", "registry.k8s.io"}},
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(md, config).WithStatusSubresource(md).Build()
+	r := NewDynamoProviderReconciler(c, scheme, "")
+	result, err := r.Reconcile(context.Background(), ctrl.Request{
+		NamespacedName: types.NamespacedName{Name: "test", Namespace: "default"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.RequeueAfter != provider.ImagePolicyRecheckInterval {
+		t.Errorf("expected a recheck after %v, got %v", provider.ImagePolicyRecheckInterval, result.RequeueAfter)
+	}
+
+	dgd := &unstructured.Unstructured{}
+	setDGDGVK(dgd)
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "test", Namespace: "default"}, dgd); !apierrors.IsNotFound(err) {
+		t.Errorf("expected no DynamoGraphDeployment, got %v", err)
+	}
+	var updated airunwayv1alpha1.ModelDeployment
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "test", Namespace: "default"}, &updated); err != nil {
+		t.Fatal(err)
+	}
+	assertCondition(t, updated.Status.Conditions, airunwayv1alpha1.ConditionTypeResourceCreated, metav1.ConditionFalse, provider.ReasonImagePolicyViolation)
+	if !strings.Contains(updated.Status.Message, "evil.io/vllm-runtime:latest") {
+		t.Errorf("expected the prefill image in the message, got %q", updated.Status.Message)
+	}
+}
+
 func TestReconcileHandleDeletion(t *testing.T) {
 	scheme := newScheme()
 	md := newMDForController("test", "default")
@@ -585,7 +694,7 @@ func TestCreateOrUpdateResourceNew(t *testing.T) {
 	dgd.SetNamespace("default")
 	dgd.Object["spec"] = map[string]interface{}{"backendFramework": "vllm"}
 
-	err := r.createOrUpdateResource(context.Background(), dgd, md)
+	_, err := r.createOrUpdateResource(context.Background(), dgd, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -620,10 +729,14 @@ func TestCreateOrUpdateResourceUpdate(t *testing.T) {
 	updated.SetNamespace("default")
 	updated.Object["spec"] = map[string]interface{}{"backendFramework": "sglang"}
 
-	err := r.createOrUpdateResource(context.Background(), updated, md)
+	_, err := r.createOrUpdateResource(context.Background(), updated, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
+	if md.Status.LastAppliedChange == nil || len(md.Status.LastAppliedChange.Paths) != 1 ||
+		md.Status.LastAppliedChange.Paths[0] != "/spec/backendFramework" {
+		t.Errorf("expected change to /spec/backendFramework to be recorded, got %+v", md.Status.LastAppliedChange)
+	}
 }
 
 func TestCreateOrUpdateResourceNoChange(t *testing.T) {
@@ -655,7 +768,7 @@ func TestCreateOrUpdateResourceNoChange(t *testing.T) {
 	same.SetNamespace("default")
 	same.Object["spec"] = map[string]interface{}{"backendFramework": "vllm"}
 
-	err := r.createOrUpdateResource(context.Background(), same, md)
+	_, err := r.createOrUpdateResource(context.Background(), same, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
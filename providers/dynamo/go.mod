@@ -4,6 +4,7 @@ go 1.25.3
 
 require (
 	github.com/kaito-project/airunway/controller v0.0.0
+	github.com/kaito-project/airunway/providers/conformance v0.0.0
 	k8s.io/api v0.35.0
 	k8s.io/apiextensions-apiserver v0.35.0
 	k8s.io/apimachinery v0.35.0
@@ -66,6 +67,7 @@ require (
 	go.yaml.in/yaml/v2 v2.4.3 // indirect
 	go.yaml.in/yaml/v3 v3.0.4 // indirect
 	golang.org/x/exp v0.0.0-20250808145144-a408d31f581a // indirect
+	golang.org/x/mod v0.30.0 // indirect
 	golang.org/x/net v0.48.0 // indirect
 	golang.org/x/oauth2 v0.34.0 // indirect
 	golang.org/x/sync v0.19.0 // indirect
@@ -94,3 +96,5 @@ require (
 )
 
 replace github.com/kaito-project/airunway/controller => ../../controller
+
+replace github.com/kaito-project/airunway/providers/conformance => ../conformance
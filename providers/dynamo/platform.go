@@ -0,0 +1,282 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dynamo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+const (
+	// DefaultPlatformNamespace is the namespace the dynamo-platform chart is installed in
+	DefaultPlatformNamespace = "dynamo-system"
+
+	// ConditionTypePlatformDependenciesReady indicates the etcd and NATS services the Dynamo
+	// runtime needs are serving
+	ConditionTypePlatformDependenciesReady = "PlatformDependenciesReady"
+	// ReasonPlatformDependenciesMissing is the condition reason when etcd or NATS is not
+	// installed or has no ready endpoint
+	ReasonPlatformDependenciesMissing = "PlatformDependenciesMissing"
+
+	// platformCacheTTL is how long a dependency check is reused across reconciles
+	platformCacheTTL = 30 * time.Second
+
+	// devStackImageEtcd and devStackImageNATS are the images of the dev-mode stack
+	devStackImageEtcd = "quay.io/coreos/etcd:v3.5.21"
+	devStackImageNATS = "nats:2.11-alpine"
+)
+
+// platformDependency is a service the Dynamo runtime connects to. The dynamo-platform chart
+// installs both with the release name as prefix, which is where the operator looks for them
+// by default.
+type platformDependency struct {
+	// Name is the app.kubernetes.io/name label of the dependency's Service, which is how it
+	// is found unless PlatformDependencies.Selectors has another selector for it
+	Name string
+	// ServiceName is the Service the dev-mode stack creates
+	ServiceName string
+	// Port is the client port
+	Port int32
+}
+
+var platformDependencies = []platformDependency{
+	{Name: "etcd", ServiceName: "dynamo-platform-etcd", Port: 2379},
+	{Name: "nats", ServiceName: "dynamo-platform-nats", Port: 4222},
+}
+
+// PlatformDependencies detects whether the etcd and NATS services the Dynamo runtime needs
+// are serving in the platform namespace, and with InstallDevStack installs a minimal
+// single-replica, non-persistent stack when they are not.
+type PlatformDependencies struct {
+	// Reader lists Services and EndpointSlices. An uncached reader avoids watching every
+	// Service in the cluster.
+	Reader client.Reader
+	// Writer creates the dev-mode stack
+	Writer client.Writer
+	// Namespace is the namespace of the Dynamo platform, DefaultPlatformNamespace when empty
+	Namespace string
+	// Selectors maps a dependency, etcd or nats, to the label selector of its Services.
+	// Dependencies without one are found by their app.kubernetes.io/name label.
+	Selectors map[string]labels.Selector
+	// InstallDevStack installs etcd and NATS for development clusters when they are missing.
+	// The stack keeps no data across restarts and is not meant for production.
+	InstallDevStack bool
+
+	mu      sync.Mutex
+	missing []string
+	expires time.Time
+}
+
+// Missing returns the dependencies that are not serving, installing the dev-mode stack for
+// them first when enabled. Results are cached for platformCacheTTL.
+func (p *PlatformDependencies) Missing(ctx context.Context) ([]string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if time.Now().Before(p.expires) {
+		return p.missing, nil
+	}
+
+	var missing []string
+	for _, dep := range platformDependencies {
+		serving, err := p.serving(ctx, dep)
+		if err != nil {
+			return nil, err
+		}
+		if serving {
+			continue
+		}
+		if p.InstallDevStack {
+			if err := p.installDevStack(ctx, dep); err != nil {
+				return nil, fmt.Errorf("failed to install dev-mode %s: %w", dep.Name, err)
+			}
+		}
+		missing = append(missing, dep.Name)
+	}
+	p.missing, p.expires = missing, time.Now().Add(platformCacheTTL)
+	return missing, nil
+}
+
+// Message describes the missing dependencies and how to install them
+func (p *PlatformDependencies) Message(missing []string) string {
+	names := strings.Join(missing, " and ")
+	if p.InstallDevStack {
+		return fmt.Sprintf("Dynamo requires %s in namespace %s; waiting for the dev-mode stack to become ready", names, p.namespace())
+	}
+	return fmt.Sprintf("Dynamo requires %s in namespace %s, which the dynamo-platform Helm chart installs; "+
+		"run the provider with --install-dev-platform-dependencies to deploy a non-persistent stack for development", names, p.namespace())
+}
+
+func (p *PlatformDependencies) namespace() string {
+	return platformNamespace(p.Namespace)
+}
+
+// platformNamespace returns namespace, or DefaultPlatformNamespace when it is empty
+func platformNamespace(namespace string) string {
+	if namespace == "" {
+		return DefaultPlatformNamespace
+	}
+	return namespace
+}
+
+// NetworkDependencies returns the etcd and NATS services in the platform namespace, which
+// the NetworkPolicy of isolated deployments must let model pods reach
+func NetworkDependencies(namespace string) []airunwayv1alpha1.NetworkDependency {
+	deps := make([]airunwayv1alpha1.NetworkDependency, 0, len(platformDependencies))
+	for _, dep := range platformDependencies {
+		deps = append(deps, airunwayv1alpha1.NetworkDependency{
+			Name:      dep.Name,
+			Namespace: platformNamespace(namespace),
+			Ports:     []int32{dep.Port},
+		})
+	}
+	return deps
+}
+
+// serving reports whether a Service of dep in the platform namespace has a ready endpoint
+func (p *PlatformDependencies) serving(ctx context.Context, dep platformDependency) (bool, error) {
+	selector, ok := p.Selectors[dep.Name]
+	if !ok {
+		selector = labels.SelectorFromSet(labels.Set{"app.kubernetes.io/name": dep.Name})
+	}
+	var services corev1.ServiceList
+	if err := p.Reader.List(ctx, &services, client.InNamespace(p.namespace()),
+		client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return false, err
+	}
+	for _, svc := range services.Items {
+		var slices discoveryv1.EndpointSliceList
+		if err := p.Reader.List(ctx, &slices, client.InNamespace(p.namespace()),
+			client.MatchingLabels{discoveryv1.LabelServiceName: svc.Name}); err != nil {
+			return false, err
+		}
+		for _, slice := range slices.Items {
+			for _, ep := range slice.Endpoints {
+				if ep.Conditions.Ready == nil || *ep.Conditions.Ready {
+					return true, nil
+				}
+			}
+		}
+	}
+	return false, nil
+}
+
+// installDevStack creates or updates the Deployment and Service of the dev-mode dep
+func (p *PlatformDependencies) installDevStack(ctx context.Context, dep platformDependency) error {
+	labels := map[string]string{
+		"app.kubernetes.io/name":        dep.Name,
+		"app.kubernetes.io/instance":    dep.ServiceName,
+		airunwayv1alpha1.LabelManagedBy: "airunway",
+	}
+	selector := map[string]string{"app.kubernetes.io/instance": dep.ServiceName}
+
+	replicas := int32(1)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: dep.ServiceName, Namespace: p.namespace(), Labels: labels},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: selector},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec:       devStackPodSpec(dep),
+			},
+		},
+	}
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: dep.ServiceName, Namespace: p.namespace(), Labels: labels},
+		Spec: corev1.ServiceSpec{
+			Selector: selector,
+			Ports: []corev1.ServicePort{{
+				Name:       "client",
+				Port:       dep.Port,
+				TargetPort: intstr.FromInt32(dep.Port),
+			}},
+		},
+	}
+	for _, obj := range []client.Object{deployment, service} {
+		if err := p.Writer.Create(ctx, obj); client.IgnoreAlreadyExists(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// devStackPodSpec runs a single in-memory member of dep
+func devStackPodSpec(dep platformDependency) corev1.PodSpec {
+	container := corev1.Container{
+		Name:  dep.Name,
+		Ports: []corev1.ContainerPort{{Name: "client", ContainerPort: dep.Port}},
+		ReadinessProbe: &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt32(dep.Port)}},
+		},
+	}
+	switch dep.Name {
+	case "etcd":
+		container.Image = devStackImageEtcd
+		container.Command = []string{"etcd",
+			"--data-dir=/var/lib/etcd",
+			fmt.Sprintf("--listen-client-urls=http://0.0.0.0:%d", dep.Port),
+			fmt.Sprintf("--advertise-client-urls=http://%s:%d", dep.ServiceName, dep.Port),
+		}
+		container.VolumeMounts = []corev1.VolumeMount{{Name: "data", MountPath: "/var/lib/etcd"}}
+	case "nats":
+		container.Image = devStackImageNATS
+		// Dynamo uses JetStream for its event plane
+		container.Args = []string{"--jetstream", "--store_dir=/data", fmt.Sprintf("--port=%d", dep.Port)}
+		container.VolumeMounts = []corev1.VolumeMount{{Name: "data", MountPath: "/data"}}
+	}
+	return corev1.PodSpec{
+		Containers: []corev1.Container{container},
+		Volumes: []corev1.Volume{{
+			Name:         "data",
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		}},
+	}
+}
+
+// ensurePlatformDependencies records the PlatformDependenciesReady condition and reports
+// whether the DynamoGraphDeployment can be applied. A nil Platform checks nothing.
+func (r *DynamoProviderReconciler) ensurePlatformDependencies(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) (bool, error) {
+	if r.Platform == nil {
+		return true, nil
+	}
+	missing, err := r.Platform.Missing(ctx)
+	if err != nil {
+		return false, err
+	}
+	if len(missing) > 0 {
+		message := r.Platform.Message(missing)
+		r.setCondition(md, ConditionTypePlatformDependenciesReady, metav1.ConditionFalse, ReasonPlatformDependenciesMissing, message)
+		md.Status.Phase = airunwayv1alpha1.DeploymentPhasePending
+		md.Status.Message = message
+		return false, nil
+	}
+	r.setCondition(md, ConditionTypePlatformDependenciesReady, metav1.ConditionTrue, "PlatformDependenciesReady", "etcd and NATS are serving")
+	return true, nil
+}
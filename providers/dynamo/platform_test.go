@@ -0,0 +1,188 @@
+package dynamo
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+func newPlatformScheme() *runtime.Scheme {
+	s := newScheme()
+	_ = appsv1.AddToScheme(s)
+	_ = discoveryv1.AddToScheme(s)
+	return s
+}
+
+// platformService returns a Service of the named dependency and its EndpointSlice
+func platformService(name, app string, ready bool) []client.Object {
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+		Name:      name,
+		Namespace: DefaultPlatformNamespace,
+		Labels:    map[string]string{"app.kubernetes.io/name": app},
+	}}
+	slice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name + "-abcde",
+			Namespace: DefaultPlatformNamespace,
+			Labels:    map[string]string{discoveryv1.LabelServiceName: name},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Endpoints: []discoveryv1.Endpoint{{
+			Addresses:  []string{"10.0.0.1"},
+			Conditions: discoveryv1.EndpointConditions{Ready: &ready},
+		}},
+	}
+	return []client.Object{svc, slice}
+}
+
+func TestPlatformDependenciesMissing(t *testing.T) {
+	tests := []struct {
+		name    string
+		objects []client.Object
+		want    []string
+	}{
+		{name: "none installed", want: []string{"etcd", "nats"}},
+		{
+			name:    "both serving",
+			objects: append(platformService("dynamo-platform-etcd", "etcd", true), platformService("my-nats", "nats", true)...),
+		},
+		{
+			name:    "nats not ready",
+			objects: append(platformService("dynamo-platform-etcd", "etcd", true), platformService("dynamo-platform-nats", "nats", false)...),
+			want:    []string{"nats"},
+		},
+		{
+			name: "service without endpoints",
+			objects: append(platformService("dynamo-platform-nats", "nats", true), &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+				Name:      "dynamo-platform-etcd",
+				Namespace: DefaultPlatformNamespace,
+				Labels:    map[string]string{"app.kubernetes.io/name": "etcd"},
+			}}),
+			want: []string{"etcd"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(newPlatformScheme()).WithObjects(tt.objects...).Build()
+			p := &PlatformDependencies{Reader: c, Writer: c}
+			got, err := p.Missing(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("expected missing %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestPlatformDependenciesSelectors(t *testing.T) {
+	etcd := platformService("external-etcd", "etcd", true)
+	etcd[0].SetLabels(map[string]string{"app": "etcd-cluster"})
+	c := fake.NewClientBuilder().WithScheme(newPlatformScheme()).
+		WithObjects(append(etcd, platformService("dynamo-platform-nats", "nats", true)...)...).Build()
+
+	p := &PlatformDependencies{Reader: c, Writer: c}
+	if missing, err := p.Missing(context.Background()); err != nil || strings.Join(missing, ",") != "etcd" {
+		t.Fatalf("expected etcd to be missing without its selector, got %v, %v", missing, err)
+	}
+
+	p = &PlatformDependencies{Reader: c, Writer: c, Selectors: map[string]labels.Selector{
+		"etcd": labels.SelectorFromSet(labels.Set{"app": "etcd-cluster"}),
+	}}
+	if missing, err := p.Missing(context.Background()); err != nil || len(missing) != 0 {
+		t.Errorf("expected etcd to be found by its selector, got %v, %v", missing, err)
+	}
+}
+
+func TestPlatformDependenciesInstallDevStack(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(newPlatformScheme()).
+		WithObjects(platformService("dynamo-platform-etcd", "etcd", true)...).Build()
+	p := &PlatformDependencies{Reader: c, Writer: c, InstallDevStack: true}
+
+	missing, err := p.Missing(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The stack is reported missing until its pods are ready
+	if strings.Join(missing, ",") != "nats" {
+		t.Fatalf("expected nats to be missing, got %v", missing)
+	}
+	if msg := p.Message(missing); !strings.Contains(msg, "waiting for the dev-mode stack") {
+		t.Errorf("unexpected message %q", msg)
+	}
+
+	key := types.NamespacedName{Name: "dynamo-platform-nats", Namespace: DefaultPlatformNamespace}
+	var svc corev1.Service
+	if err := c.Get(context.Background(), key, &svc); err != nil {
+		t.Fatalf("expected the NATS Service: %v", err)
+	}
+	if svc.Labels["app.kubernetes.io/name"] != "nats" || svc.Spec.Ports[0].Port != 4222 {
+		t.Errorf("unexpected Service %+v", svc)
+	}
+	var deployment appsv1.Deployment
+	if err := c.Get(context.Background(), key, &deployment); err != nil {
+		t.Fatalf("expected the NATS Deployment: %v", err)
+	}
+	container := deployment.Spec.Template.Spec.Containers[0]
+	if container.Image != devStackImageNATS || container.Args[0] != "--jetstream" {
+		t.Errorf("unexpected container %+v", container)
+	}
+
+	// etcd is serving, so no dev-mode etcd is installed
+	err = c.Get(context.Background(), types.NamespacedName{Name: "dynamo-platform-etcd", Namespace: DefaultPlatformNamespace}, &deployment)
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("expected no etcd Deployment, got %v", err)
+	}
+}
+
+func TestReconcilePlatformDependenciesMissing(t *testing.T) {
+	scheme := newPlatformScheme()
+	md := newMDForController("test", "default")
+	controllerutil.AddFinalizer(md, FinalizerName)
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(md).WithStatusSubresource(md).Build()
+	r := NewDynamoProviderReconciler(c, scheme, "")
+	r.Platform = &PlatformDependencies{Reader: c, Writer: c}
+
+	result, err := r.Reconcile(context.Background(), ctrl.Request{
+		NamespacedName: types.NamespacedName{Name: "test", Namespace: "default"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.RequeueAfter != RequeueInterval {
+		t.Errorf("expected requeue after %v, got %v", RequeueInterval, result.RequeueAfter)
+	}
+
+	var updated airunwayv1alpha1.ModelDeployment
+	_ = c.Get(context.Background(), types.NamespacedName{Name: "test", Namespace: "default"}, &updated)
+	if updated.Status.Phase != airunwayv1alpha1.DeploymentPhasePending {
+		t.Errorf("expected Pending phase, got %s", updated.Status.Phase)
+	}
+	assertCondition(t, updated.Status.Conditions, ConditionTypePlatformDependenciesReady, metav1.ConditionFalse, ReasonPlatformDependenciesMissing)
+	if !strings.Contains(updated.Status.Message, "etcd and nats") || !strings.Contains(updated.Status.Message, "--install-dev-platform-dependencies") {
+		t.Errorf("unexpected message %q", updated.Status.Message)
+	}
+
+	dgd := &unstructured.Unstructured{}
+	setDGDGVK(dgd)
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "test", Namespace: "default"}, dgd); !apierrors.IsNotFound(err) {
+		t.Errorf("expected no DynamoGraphDeployment, got %v", err)
+	}
+}
@@ -24,14 +24,16 @@ import (
 	"strings"
 
 	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/pkg/provider"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/discovery"
 )
 
 const (
 	// DynamoAPIGroup is the API group for Dynamo CRDs
 	DynamoAPIGroup = "nvidia.com"
-	// DynamoAPIVersion is the current API version for Dynamo CRDs
+	// DynamoAPIVersion is the API version the transformer builds DynamoGraphDeployments in
 	DynamoAPIVersion = "v1alpha1"
 	// DynamoGraphDeploymentKind is the kind for DynamoGraphDeployment
 	DynamoGraphDeploymentKind = "DynamoGraphDeployment"
@@ -94,21 +96,59 @@ type ResourceOverrides struct {
 	Memory string `json:"memory,omitempty"`
 }
 
+// dgdVersionMappings lists the DynamoGraphDeployment API versions the transformer can
+// emit. Add an entry with its field mapping when a new upstream version is supported.
+var dgdVersionMappings = map[string]provider.VersionMapping{
+	DynamoAPIVersion: nil,
+}
+
 // Transformer handles transformation of ModelDeployment to DynamoGraphDeployment
-type Transformer struct{}
+type Transformer struct {
+	// Versions negotiates the DynamoGraphDeployment API version with the cluster
+	Versions *provider.APIVersionNegotiator
+}
 
-// NewTransformer creates a new Dynamo transformer
-func NewTransformer() *Transformer {
-	return &Transformer{}
+var _ provider.Transformer = (*Transformer)(nil)
+
+// NewTransformer creates a new Dynamo transformer. Without a discovery client it emits
+// DynamoAPIVersion.
+func NewTransformer(discoveryClient ...discovery.DiscoveryInterface) *Transformer {
+	versions := &provider.APIVersionNegotiator{
+		Group:     DynamoAPIGroup,
+		Resource:  dynamoGraphDeploymentResource,
+		Canonical: DynamoAPIVersion,
+		Mappings:  dgdVersionMappings,
+	}
+	if len(discoveryClient) > 0 {
+		versions.Discovery = discoveryClient[0]
+	}
+	return &Transformer{Versions: versions}
 }
 
 // Transform converts a ModelDeployment to a DynamoGraphDeployment
-func (t *Transformer) Transform(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) ([]*unstructured.Unstructured, error) {
+func (t *Transformer) Transform(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) (*provider.TransformResult, error) {
+	if md.Spec.Engine.Device == airunwayv1alpha1.EngineDeviceCPU {
+		return nil, fmt.Errorf("dynamo provider does not support cpu inference")
+	}
+	if err := provider.KVOffloadCheck(md); err != nil {
+		return nil, err
+	}
+	if err := provider.KVCacheCheck(md); err != nil {
+		return nil, err
+	}
+	if err := provider.LoadFormatCheck(md); err != nil {
+		return nil, err
+	}
+
 	// Parse overrides if present
 	overrides, err := t.parseOverrides(md)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse provider overrides: %w", err)
 	}
+	version, err := t.Versions.Version()
+	if err != nil {
+		return nil, err
+	}
 
 	// Create the DynamoGraphDeployment
 	dgd := &unstructured.Unstructured{}
@@ -131,10 +171,10 @@ func (t *Transformer) Transform(ctx context.Context, md *airunwayv1alpha1.ModelD
 
 	// Add labels
 	labels := map[string]string{
-		airunwayv1alpha1.LabelManagedBy:       "airunway",
+		airunwayv1alpha1.LabelManagedBy:       airunwayv1alpha1.ManagedByAIRunway,
 		airunwayv1alpha1.LabelModelDeployment: md.Name,
-		"airunway.ai/model-id":                sanitizeLabelValue(md.Spec.Model.ID),
-		"airunway.ai/engine-type":             string(md.ResolvedEngineType()),
+		airunwayv1alpha1.LabelModelID:         sanitizeLabelValue(md.Spec.Model.ID),
+		airunwayv1alpha1.LabelEngineType:      string(md.ResolvedEngineType()),
 	}
 	dgd.SetLabels(labels)
 
@@ -143,7 +183,8 @@ func (t *Transformer) Transform(ctx context.Context, md *airunwayv1alpha1.ModelD
 		"backendFramework": t.mapEngineType(md.ResolvedEngineType()),
 	}
 
-	services, err := t.buildServices(md, overrides)
+	gang := provider.NewGangScheduling(md, workerPods(md))
+	services, err := t.buildServices(md, overrides, gang)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build services: %w", err)
 	}
@@ -158,22 +199,59 @@ func (t *Transformer) Transform(ctx context.Context, md *airunwayv1alpha1.ModelD
 		return nil, fmt.Errorf("failed to set spec: %w", err)
 	}
 
+	// Map to the negotiated API version before overrides, which are written for it
+	if err := t.Versions.Apply(dgd, version); err != nil {
+		return nil, err
+	}
+
 	// Apply escape hatch overrides last so they can override any field
 	if err := applyOverrides(dgd, md); err != nil {
 		return nil, fmt.Errorf("failed to apply provider overrides: %w", err)
 	}
 
-	return []*unstructured.Unstructured{dgd}, nil
+	result := provider.NewTransformResult(dgd)
+	gang.AddPodGroup(result)
+	provider.AddChatTemplateConfigMap(result, md)
+	if err := provider.AddLogSinkConfigMap(result, md); err != nil {
+		return nil, fmt.Errorf("failed to build the log sink ConfigMap: %w", err)
+	}
+	provider.ApplyPropagatedMetadata(result, md)
+	return result, nil
+}
+
+// workerPods returns the number of worker pods, which hold the GPUs and form the gang
+func workerPods(md *airunwayv1alpha1.ModelDeployment) int32 {
+	scaling := md.Spec.Scaling
+	if md.Spec.Serving != nil && md.Spec.Serving.Mode == airunwayv1alpha1.ServingModeDisaggregated {
+		var pods int32
+		if scaling != nil && scaling.Prefill != nil {
+			pods += scaling.Prefill.Replicas
+		}
+		if scaling != nil && scaling.Decode != nil {
+			pods += scaling.Decode.Replicas
+		}
+		return pods
+	}
+	if scaling != nil && scaling.Replicas > 0 {
+		return scaling.Replicas
+	}
+	return 1
 }
 
-// parseOverrides parses the provider.overrides field into DynamoOverrides
+// parseOverrides parses the provider.overrides field into DynamoOverrides after
+// expanding templates in its string values
 func (t *Transformer) parseOverrides(md *airunwayv1alpha1.ModelDeployment) (*DynamoOverrides, error) {
 	if md.Spec.Provider == nil || md.Spec.Provider.Overrides == nil {
 		return &DynamoOverrides{}, nil
 	}
 
+	raw, err := provider.RenderOverrides(md)
+	if err != nil {
+		return nil, err
+	}
+
 	var overrides DynamoOverrides
-	if err := json.Unmarshal(md.Spec.Provider.Overrides.Raw, &overrides); err != nil {
+	if err := json.Unmarshal(raw, &overrides); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal overrides: %w", err)
 	}
 
@@ -194,8 +272,9 @@ func (t *Transformer) mapEngineType(engineType airunwayv1alpha1.EngineType) stri
 	}
 }
 
-// buildServices creates the services map for DynamoGraphDeployment
-func (t *Transformer) buildServices(md *airunwayv1alpha1.ModelDeployment, overrides *DynamoOverrides) (map[string]interface{}, error) {
+// buildServices creates the services map for DynamoGraphDeployment. Worker pods join gang
+// when spec.scheduling.gang is enabled.
+func (t *Transformer) buildServices(md *airunwayv1alpha1.ModelDeployment, overrides *DynamoOverrides, gang *provider.GangScheduling) (map[string]interface{}, error) {
 	services := map[string]interface{}{}
 
 	// Determine serving mode
@@ -234,11 +313,13 @@ func (t *Transformer) buildServices(md *airunwayv1alpha1.ModelDeployment, overri
 		if err != nil {
 			return nil, fmt.Errorf("failed to build prefill worker: %w", err)
 		}
+		t.addGangConfig(prefillWorker, gang)
 		services["VllmPrefillWorker"] = prefillWorker
 		decodeWorker, err := t.buildDecodeWorker(md, image, gatewayEnabled)
 		if err != nil {
 			return nil, fmt.Errorf("failed to build decode worker: %w", err)
 		}
+		t.addGangConfig(decodeWorker, gang)
 		services["VllmDecodeWorker"] = decodeWorker
 	} else {
 		// Aggregated mode: single worker
@@ -246,28 +327,54 @@ func (t *Transformer) buildServices(md *airunwayv1alpha1.ModelDeployment, overri
 		if err != nil {
 			return nil, fmt.Errorf("failed to build aggregated worker: %w", err)
 		}
+		t.addGangConfig(aggregatedWorker, gang)
 		services["VllmWorker"] = aggregatedWorker
 	}
 
+	for _, service := range services {
+		t.addPropagatedMetadata(service.(map[string]interface{}), md)
+	}
 	return services, nil
 }
 
 // buildFrontendService creates the standalone frontend service for non-GAIE
 // deployments (gateway disabled). The Frontend handles request routing when
-// there is no InferencePool/EPP path.
+// there is no InferencePool/EPP path. spec.serving.router sets its replicas,
+// resources and router mode; Dynamo overrides take precedence. Only the frontend reads
+// the router mode, so changing it rolls the frontend and leaves the workers running.
 func (t *Transformer) buildFrontendService(md *airunwayv1alpha1.ModelDeployment, overrides *DynamoOverrides) map[string]interface{} {
+	var router *airunwayv1alpha1.RouterSpec
+	if md.Spec.Serving != nil {
+		router = md.Spec.Serving.Router
+	}
+
 	replicas := int64(1)
 	if overrides.Frontend != nil && overrides.Frontend.Replicas != nil {
 		replicas = int64(*overrides.Frontend.Replicas)
+	} else if router != nil && router.Replicas != nil {
+		replicas = int64(*router.Replicas)
 	}
 
-	routerMode := "round-robin"
+	routerMode := string(airunwayv1alpha1.RouterModeRoundRobin)
 	if overrides.RouterMode != "" {
 		routerMode = overrides.RouterMode
+	} else if router != nil && router.RouterMode == airunwayv1alpha1.RouterModeNone {
+		// The frontend always picks a worker; random is its mode without scoring
+		routerMode = "random"
+	} else if router != nil && router.RouterMode != "" {
+		routerMode = string(router.RouterMode)
 	}
 
 	cpu := "2"
 	memory := "4Gi"
+	if router != nil {
+		if router.CPU != "" {
+			cpu = router.CPU
+		}
+		if router.Memory != "" {
+			memory = router.Memory
+		}
+	}
 	if overrides.Frontend != nil && overrides.Frontend.Resources != nil {
 		if overrides.Frontend.Resources.CPU != "" {
 			cpu = overrides.Frontend.Resources.CPU
@@ -514,11 +621,23 @@ func (t *Transformer) buildAggregatedWorker(md *airunwayv1alpha1.ModelDeployment
 		worker["envFromSecret"] = md.Spec.Secrets.HuggingFaceToken
 	}
 
-	// Add node selector and tolerations
-	t.addSchedulingConfig(worker, md)
+	// Add node selector, tolerations, and the node affinity for spec.resources.gpu.types
+	t.addSchedulingConfig(worker, md, nil)
+	if affinity := provider.WithGPUTypeAffinity(nil, md); affinity != nil {
+		worker["extraPodSpec"].(map[string]interface{})["affinity"] = affinity
+	}
+	t.addIdentityConfig(worker, md)
 
 	// Add storage configuration (PVC volume mounts and HF_HOME)
 	t.addStorageConfig(worker, md)
+	t.addChatTemplateConfig(worker, md)
+	t.addKVOffloadConfig(worker, md)
+	t.addKVCacheConfig(worker, md)
+	t.addLoadFormatConfig(worker, md)
+	t.addStreamDrainConfig(worker, md)
+	if err := t.addLogSinkConfig(worker, md); err != nil {
+		return nil, err
+	}
 	t.maybeInjectVLLMSideChannelHost(worker, md)
 
 	return worker, nil
@@ -548,9 +667,13 @@ func (t *Transformer) buildFrontendSidecar(md *airunwayv1alpha1.ModelDeployment,
 	return sidecar
 }
 
-// buildPrefillWorker creates the prefill worker for disaggregated mode.
+// buildPrefillWorker creates the prefill worker for disaggregated mode. scaling.prefill.image
+// takes precedence over image.
 func (t *Transformer) buildPrefillWorker(md *airunwayv1alpha1.ModelDeployment, image string, gatewayEnabled bool) (map[string]interface{}, error) {
 	prefillSpec := md.Spec.Scaling.Prefill
+	if prefillSpec.Image != "" {
+		image = prefillSpec.Image
+	}
 
 	// Build resource limits and requests from component spec
 	limits := map[string]interface{}{}
@@ -564,6 +687,7 @@ func (t *Transformer) buildPrefillWorker(md *airunwayv1alpha1.ModelDeployment, i
 	if prefillSpec.Memory != "" {
 		limits["memory"] = prefillSpec.Memory
 	}
+	requests, limits = provider.ApplyResourceQuantities(requests, limits, prefillSpec.Requests, prefillSpec.Limits)
 
 	resources := map[string]interface{}{
 		"limits":   limits,
@@ -603,19 +727,35 @@ func (t *Transformer) buildPrefillWorker(md *airunwayv1alpha1.ModelDeployment, i
 		worker["envFromSecret"] = md.Spec.Secrets.HuggingFaceToken
 	}
 
-	// Add node selector and tolerations
-	t.addSchedulingConfig(worker, md)
+	// Add node selector, tolerations, and affinity
+	t.addSchedulingConfig(worker, md, prefillSpec.Scheduling)
+	if err := t.addPlacementConfig(worker, md, prefillSpec.Scheduling); err != nil {
+		return nil, err
+	}
+	t.addIdentityConfig(worker, md)
 
 	// Add storage configuration (PVC volume mounts and HF_HOME)
 	t.addStorageConfig(worker, md)
+	t.addChatTemplateConfig(worker, md)
+	t.addKVOffloadConfig(worker, md)
+	t.addKVCacheConfig(worker, md)
+	t.addLoadFormatConfig(worker, md)
+	t.addStreamDrainConfig(worker, md)
+	if err := t.addLogSinkConfig(worker, md); err != nil {
+		return nil, err
+	}
 	t.maybeInjectVLLMSideChannelHost(worker, md)
 
 	return worker, nil
 }
 
-// buildDecodeWorker creates the decode worker for disaggregated mode.
+// buildDecodeWorker creates the decode worker for disaggregated mode. scaling.decode.image
+// takes precedence over image.
 func (t *Transformer) buildDecodeWorker(md *airunwayv1alpha1.ModelDeployment, image string, gatewayEnabled bool) (map[string]interface{}, error) {
 	decodeSpec := md.Spec.Scaling.Decode
+	if decodeSpec.Image != "" {
+		image = decodeSpec.Image
+	}
 
 	// Build resource limits and requests from component spec
 	limits := map[string]interface{}{}
@@ -629,6 +769,7 @@ func (t *Transformer) buildDecodeWorker(md *airunwayv1alpha1.ModelDeployment, im
 	if decodeSpec.Memory != "" {
 		limits["memory"] = decodeSpec.Memory
 	}
+	requests, limits = provider.ApplyResourceQuantities(requests, limits, decodeSpec.Requests, decodeSpec.Limits)
 
 	resources := map[string]interface{}{
 		"limits":   limits,
@@ -668,11 +809,23 @@ func (t *Transformer) buildDecodeWorker(md *airunwayv1alpha1.ModelDeployment, im
 		worker["envFromSecret"] = md.Spec.Secrets.HuggingFaceToken
 	}
 
-	// Add node selector and tolerations
-	t.addSchedulingConfig(worker, md)
+	// Add node selector, tolerations, and affinity
+	t.addSchedulingConfig(worker, md, decodeSpec.Scheduling)
+	if err := t.addPlacementConfig(worker, md, decodeSpec.Scheduling); err != nil {
+		return nil, err
+	}
+	t.addIdentityConfig(worker, md)
 
 	// Add storage configuration (PVC volume mounts and HF_HOME)
 	t.addStorageConfig(worker, md)
+	t.addChatTemplateConfig(worker, md)
+	t.addKVOffloadConfig(worker, md)
+	t.addKVCacheConfig(worker, md)
+	t.addLoadFormatConfig(worker, md)
+	t.addStreamDrainConfig(worker, md)
+	if err := t.addLogSinkConfig(worker, md); err != nil {
+		return nil, err
+	}
 	t.maybeInjectVLLMSideChannelHost(worker, md)
 
 	return worker, nil
@@ -703,6 +856,7 @@ func (t *Transformer) buildResourceLimits(spec *airunwayv1alpha1.ResourceSpec) m
 	if spec.CPU != "" {
 		limits["cpu"] = spec.CPU
 	}
+	requests, limits = provider.ApplyResourceQuantities(requests, limits, spec.Requests, spec.Limits)
 
 	return map[string]interface{}{
 		"limits":   limits,
@@ -762,6 +916,20 @@ func (t *Transformer) buildEngineArgs(md *airunwayv1alpha1.ModelDeployment) ([]s
 		}
 	}
 
+	// Add chat template and tokenizer overrides. Dynamo applies the chat template in its
+	// preprocessor rather than the engine, for every backend.
+	if path := provider.ChatTemplatePath(md); path != "" {
+		args = append(args, "--custom-jinja-template", path)
+	}
+	args = append(args, provider.TokenizerArgs(md)...)
+	if md.ResolvedEngineType() == airunwayv1alpha1.EngineTypeTRTLLM && provider.RevisionRequested(md) {
+		return nil, fmt.Errorf("dynamo provider does not support spec.model.revision with the trtllm engine")
+	}
+	args = append(args, provider.RevisionArgs(md)...)
+	args = append(args, provider.KVOffloadArgs(md)...)
+	args = append(args, provider.LMCacheArgs(md)...)
+	args = append(args, provider.LoadFormatArgs(md)...)
+
 	// Add custom engine args with key validation (sorted for deterministic output)
 	keys := make([]string, 0, len(md.Spec.Engine.Args))
 	for k := range md.Spec.Engine.Args {
@@ -859,6 +1027,15 @@ func (t *Transformer) getImage(md *airunwayv1alpha1.ModelDeployment) string {
 	return defaultVLLMRuntimeImage
 }
 
+// DefaultImage returns the default runtime image of the engine, or an empty string when
+// spec.image is set
+func (t *Transformer) DefaultImage(md *airunwayv1alpha1.ModelDeployment) string {
+	if md.Spec.Image != "" {
+		return ""
+	}
+	return t.getImage(md)
+}
+
 // buildPVCs creates the pvcs list for DynamoGraphDeployment from StorageSpec volumes.
 // Each entry maps to {name: claimName, create: false} since PVCs are either pre-existing
 // or created by the controller separately.
@@ -994,25 +1171,26 @@ func (t *Transformer) injectEnvVarFromFieldRef(service map[string]interface{}, n
 	mainContainer["env"] = envList
 }
 
-// addSchedulingConfig adds node selector and tolerations to a service
-func (t *Transformer) addSchedulingConfig(service map[string]interface{}, md *airunwayv1alpha1.ModelDeployment) {
+// addSchedulingConfig adds node selector and tolerations to a service, merged with the
+// scheduling constraints of a disaggregated component when set
+func (t *Transformer) addSchedulingConfig(service map[string]interface{}, md *airunwayv1alpha1.ModelDeployment, scheduling *airunwayv1alpha1.ComponentSchedulingSpec) {
 	extraPodSpec, ok := service["extraPodSpec"].(map[string]interface{})
 	if !ok {
 		extraPodSpec = map[string]interface{}{}
 		service["extraPodSpec"] = extraPodSpec
 	}
 
-	if len(md.Spec.NodeSelector) > 0 {
-		ns := make(map[string]interface{}, len(md.Spec.NodeSelector))
-		for k, v := range md.Spec.NodeSelector {
+	if nodeSelector := md.ComponentNodeSelector(scheduling); len(nodeSelector) > 0 {
+		ns := make(map[string]interface{}, len(nodeSelector))
+		for k, v := range nodeSelector {
 			ns[k] = v
 		}
 		extraPodSpec["nodeSelector"] = ns
 	}
 
-	if len(md.Spec.Tolerations) > 0 {
-		tolerations := make([]interface{}, len(md.Spec.Tolerations))
-		for i, t := range md.Spec.Tolerations {
+	if componentTolerations := md.ComponentTolerations(scheduling); len(componentTolerations) > 0 {
+		tolerations := make([]interface{}, len(componentTolerations))
+		for i, t := range componentTolerations {
 			toleration := map[string]interface{}{
 				"key":      t.Key,
 				"operator": string(t.Operator),
@@ -1032,6 +1210,277 @@ func (t *Transformer) addSchedulingConfig(service map[string]interface{}, md *ai
 	}
 }
 
+// addGangConfig adds the scheduler name, labels, and annotations of the gang from
+// spec.scheduling to the pods of a worker.
+func (t *Transformer) addGangConfig(worker map[string]interface{}, gang *provider.GangScheduling) {
+	if gang == nil {
+		return
+	}
+	for key, values := range map[string]map[string]string{"labels": gang.Labels, "annotations": gang.Annotations} {
+		if len(values) == 0 {
+			continue
+		}
+		m, ok := worker[key].(map[string]interface{})
+		if !ok {
+			m = map[string]interface{}{}
+			worker[key] = m
+		}
+		for k, v := range values {
+			m[k] = v
+		}
+	}
+	if gang.SchedulerName != "" {
+		extraPodSpec, ok := worker["extraPodSpec"].(map[string]interface{})
+		if !ok {
+			extraPodSpec = map[string]interface{}{}
+			worker["extraPodSpec"] = extraPodSpec
+		}
+		extraPodSpec["schedulerName"] = gang.SchedulerName
+	}
+}
+
+// addPropagatedMetadata adds spec.labels and spec.annotations to the pods of a component,
+// keeping the labels and annotations it already has, and the checksum of the referenced
+// Secrets when spec.secrets.rolloutOnChange is set.
+func (t *Transformer) addPropagatedMetadata(service map[string]interface{}, md *airunwayv1alpha1.ModelDeployment) {
+	for key, values := range map[string]map[string]string{"labels": md.PropagatedLabels(), "annotations": md.PropagatedAnnotations()} {
+		if len(values) == 0 {
+			continue
+		}
+		m, ok := service[key].(map[string]interface{})
+		if !ok {
+			m = map[string]interface{}{}
+			service[key] = m
+		}
+		for k, v := range values {
+			if _, found := m[k]; !found {
+				m[k] = v
+			}
+		}
+	}
+	if secrets := md.SecretsPodAnnotations(); len(secrets) > 0 {
+		annotations, ok := service["annotations"].(map[string]interface{})
+		if !ok {
+			annotations = map[string]interface{}{}
+			service["annotations"] = annotations
+		}
+		for k, v := range secrets {
+			annotations[k] = v
+		}
+	}
+}
+
+// addPlacementConfig labels a disaggregated worker with its ModelDeployment and adds a pod
+// affinity that keeps prefill and decode workers in the failure domain from spec.serving.placement,
+// combined with the affinity of the component's scheduling.
+func (t *Transformer) addPlacementConfig(worker map[string]interface{}, md *airunwayv1alpha1.ModelDeployment, scheduling *airunwayv1alpha1.ComponentSchedulingSpec) error {
+	selector := map[string]interface{}{airunwayv1alpha1.LabelModelDeployment: md.Name}
+	placement := provider.PlacementAffinity(md, selector)
+	if placement != nil {
+		worker["labels"] = selector
+	}
+	affinity, err := provider.ComponentAffinity(scheduling, placement)
+	if err != nil || affinity == nil {
+		return err
+	}
+
+	extraPodSpec, ok := worker["extraPodSpec"].(map[string]interface{})
+	if !ok {
+		extraPodSpec = map[string]interface{}{}
+		worker["extraPodSpec"] = extraPodSpec
+	}
+	extraPodSpec["affinity"] = affinity
+	return nil
+}
+
+// addIdentityConfig runs a worker, which loads the model weights, as the workload identity
+// ServiceAccount from spec.identity.
+func (t *Transformer) addIdentityConfig(worker map[string]interface{}, md *airunwayv1alpha1.ModelDeployment) {
+	sa := md.ServiceAccountName()
+	if sa == "" {
+		return
+	}
+	extraPodSpec, ok := worker["extraPodSpec"].(map[string]interface{})
+	if !ok {
+		extraPodSpec = map[string]interface{}{}
+		worker["extraPodSpec"] = extraPodSpec
+	}
+	extraPodSpec["serviceAccountName"] = sa
+
+	if identityLabels := md.IdentityPodLabels(); len(identityLabels) > 0 {
+		labels, ok := worker["labels"].(map[string]interface{})
+		if !ok {
+			labels = map[string]interface{}{}
+			worker["labels"] = labels
+		}
+		for k, v := range identityLabels {
+			labels[k] = v
+		}
+	}
+}
+
+// addChatTemplateConfig mounts the chat template from spec.model.chatTemplate in the main
+// container of a worker, and annotates its pods with the hash of an inline template so
+// they restart when it changes.
+func (t *Transformer) addChatTemplateConfig(worker map[string]interface{}, md *airunwayv1alpha1.ModelDeployment) {
+	volume := provider.ChatTemplateVolume(md)
+	if volume == nil {
+		return
+	}
+	extraPodSpec, ok := worker["extraPodSpec"].(map[string]interface{})
+	if !ok {
+		extraPodSpec = map[string]interface{}{}
+		worker["extraPodSpec"] = extraPodSpec
+	}
+	volumes, _ := extraPodSpec["volumes"].([]interface{})
+	extraPodSpec["volumes"] = append(volumes, volume)
+
+	mainContainer, ok := extraPodSpec["mainContainer"].(map[string]interface{})
+	if !ok {
+		mainContainer = map[string]interface{}{}
+		extraPodSpec["mainContainer"] = mainContainer
+	}
+	mounts, _ := mainContainer["volumeMounts"].([]interface{})
+	mainContainer["volumeMounts"] = append(mounts, provider.ChatTemplateVolumeMount())
+
+	if hash := provider.ChatTemplateHash(md); hash != "" {
+		annotations, ok := worker["annotations"].(map[string]interface{})
+		if !ok {
+			annotations = map[string]interface{}{}
+			worker["annotations"] = annotations
+		}
+		annotations[provider.ChatTemplateHashAnnot] = hash
+	}
+}
+
+// addKVOffloadConfig mounts the volume backing nvme KV cache offload in the main
+// container of a worker and sets the offload environment.
+func (t *Transformer) addKVOffloadConfig(worker map[string]interface{}, md *airunwayv1alpha1.ModelDeployment) {
+	volume := provider.KVOffloadVolume(md)
+	if volume == nil {
+		return
+	}
+	extraPodSpec, ok := worker["extraPodSpec"].(map[string]interface{})
+	if !ok {
+		extraPodSpec = map[string]interface{}{}
+		worker["extraPodSpec"] = extraPodSpec
+	}
+	volumes, _ := extraPodSpec["volumes"].([]interface{})
+	extraPodSpec["volumes"] = append(volumes, volume)
+
+	mainContainer, ok := extraPodSpec["mainContainer"].(map[string]interface{})
+	if !ok {
+		mainContainer = map[string]interface{}{}
+		extraPodSpec["mainContainer"] = mainContainer
+	}
+	mounts, _ := mainContainer["volumeMounts"].([]interface{})
+	mainContainer["volumeMounts"] = append(mounts, provider.KVOffloadVolumeMount())
+	env, _ := mainContainer["env"].([]interface{})
+	mainContainer["env"] = append(env, provider.KVOffloadEnv(md)...)
+}
+
+// addLogSinkConfig adds the log sink sidecar of spec.observability.logSink and its volumes
+// to a worker, and annotates its pods with the hash of the sink configuration so they
+// restart when it changes.
+func (t *Transformer) addLogSinkConfig(worker map[string]interface{}, md *airunwayv1alpha1.ModelDeployment) error {
+	config, err := provider.LogSinkConfig(md)
+	if err != nil || config == "" {
+		return err
+	}
+	container, volumes, err := provider.LogSinkPodSpec(md)
+	if err != nil {
+		return fmt.Errorf("failed to add the log sink: %w", err)
+	}
+	extraPodSpec, ok := worker["extraPodSpec"].(map[string]interface{})
+	if !ok {
+		extraPodSpec = map[string]interface{}{}
+		worker["extraPodSpec"] = extraPodSpec
+	}
+	existing, _ := extraPodSpec["volumes"].([]interface{})
+	extraPodSpec["volumes"] = append(existing, volumes...)
+	containers, _ := extraPodSpec["containers"].([]interface{})
+	extraPodSpec["containers"] = append(containers, container)
+
+	annotations, ok := worker["annotations"].(map[string]interface{})
+	if !ok {
+		annotations = map[string]interface{}{}
+		worker["annotations"] = annotations
+	}
+	annotations[provider.LogSinkConfigHashAnnot] = provider.LogSinkConfigHash(config)
+	return nil
+}
+
+// addKVCacheConfig sets the LMCache environment for spec.caching.kv in the main container
+// of a worker.
+func (t *Transformer) addKVCacheConfig(worker map[string]interface{}, md *airunwayv1alpha1.ModelDeployment) {
+	env := provider.KVCacheEnv(md)
+	if env == nil {
+		return
+	}
+	extraPodSpec, ok := worker["extraPodSpec"].(map[string]interface{})
+	if !ok {
+		extraPodSpec = map[string]interface{}{}
+		worker["extraPodSpec"] = extraPodSpec
+	}
+	mainContainer, ok := extraPodSpec["mainContainer"].(map[string]interface{})
+	if !ok {
+		mainContainer = map[string]interface{}{}
+		extraPodSpec["mainContainer"] = mainContainer
+	}
+	existing, _ := mainContainer["env"].([]interface{})
+	mainContainer["env"] = append(existing, env...)
+}
+
+// addLoadFormatConfig exposes spec.model.loadCredentialsSecret to the main container of a
+// worker.
+func (t *Transformer) addLoadFormatConfig(worker map[string]interface{}, md *airunwayv1alpha1.ModelDeployment) {
+	envFrom := provider.LoadFormatEnvFrom(md)
+	if envFrom == nil {
+		return
+	}
+	extraPodSpec, ok := worker["extraPodSpec"].(map[string]interface{})
+	if !ok {
+		extraPodSpec = map[string]interface{}{}
+		worker["extraPodSpec"] = extraPodSpec
+	}
+	mainContainer, ok := extraPodSpec["mainContainer"].(map[string]interface{})
+	if !ok {
+		mainContainer = map[string]interface{}{}
+		extraPodSpec["mainContainer"] = mainContainer
+	}
+	existing, _ := mainContainer["envFrom"].([]interface{})
+	mainContainer["envFrom"] = append(existing, envFrom...)
+}
+
+// addStreamDrainConfig adds the grace period and preStop delay that let
+// the open streams of a worker finish before it terminates, with spec.gateway.streaming.
+// The operator-injected frontend sidecar is stopped right away; the engine keeps serving
+// the requests it already accepted.
+func (t *Transformer) addStreamDrainConfig(worker map[string]interface{}, md *airunwayv1alpha1.ModelDeployment) {
+	if !provider.StreamDrainEnabled(md) {
+		return
+	}
+	extraPodSpec, ok := worker["extraPodSpec"].(map[string]interface{})
+	if !ok {
+		extraPodSpec = map[string]interface{}{}
+		worker["extraPodSpec"] = extraPodSpec
+	}
+	provider.ApplyStreamDrainToPodSpec(extraPodSpec, md)
+	mainContainer, ok := extraPodSpec["mainContainer"].(map[string]interface{})
+	if !ok {
+		mainContainer = map[string]interface{}{}
+		extraPodSpec["mainContainer"] = mainContainer
+	}
+	lifecycle, _ := mainContainer["lifecycle"].(map[string]interface{})
+	if lifecycle == nil {
+		lifecycle = map[string]interface{}{}
+		mainContainer["lifecycle"] = lifecycle
+	}
+	if _, ok := lifecycle["preStop"]; !ok {
+		lifecycle["preStop"] = provider.StreamDrainPreStop()
+	}
+}
+
 // sanitizeLabelValue ensures a value is valid for a Kubernetes label
 func sanitizeLabelValue(value string) string {
 	// Labels must be 63 chars or less, start and end with alphanumeric
@@ -1055,15 +1504,21 @@ func boolPtr(b bool) *bool {
 	return &b
 }
 
-// applyOverrides deep-merges spec.provider.overrides into the unstructured object.
+// applyOverrides deep-merges spec.provider.overrides into the unstructured object,
+// after expanding templates in its string values against the ModelDeployment.
 // This is the escape hatch that lets users set arbitrary fields on the provider CRD.
 func applyOverrides(obj *unstructured.Unstructured, md *airunwayv1alpha1.ModelDeployment) error {
 	if md.Spec.Provider == nil || md.Spec.Provider.Overrides == nil {
 		return nil
 	}
 
+	raw, err := provider.RenderOverrides(md)
+	if err != nil {
+		return err
+	}
+
 	var overrides map[string]interface{}
-	if err := json.Unmarshal(md.Spec.Provider.Overrides.Raw, &overrides); err != nil {
+	if err := json.Unmarshal(raw, &overrides); err != nil {
 		return fmt.Errorf("failed to unmarshal overrides: %w", err)
 	}
 
@@ -3,15 +3,19 @@ package dynamo
 import (
 	"context"
 	"encoding/json"
+	"reflect"
 	"strings"
 	"testing"
 
 	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	k8stesting "k8s.io/client-go/testing"
 )
 
 func newTestMD(name, namespace string) *airunwayv1alpha1.ModelDeployment {
@@ -38,11 +42,20 @@ func newTestMD(name, namespace string) *airunwayv1alpha1.ModelDeployment {
 	}
 }
 
+// transformResources runs the transformer and returns the ordered resources.
+func transformResources(tr *Transformer, md *airunwayv1alpha1.ModelDeployment) ([]*unstructured.Unstructured, error) {
+	result, err := tr.Transform(context.Background(), md)
+	if err != nil {
+		return nil, err
+	}
+	return result.Resources, nil
+}
+
 func TestTransformAggregated(t *testing.T) {
 	tr := NewTransformer()
 	md := newTestMD("test-model", "default")
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -118,6 +131,29 @@ func TestTransformAggregated(t *testing.T) {
 	}
 }
 
+func TestTransformNegotiatesAPIVersion(t *testing.T) {
+	dc := &fakediscovery.FakeDiscovery{Fake: &k8stesting.Fake{}}
+	dc.Resources = []*metav1.APIResourceList{
+		{GroupVersion: "nvidia.com/v1beta1", APIResources: []metav1.APIResource{{Name: dynamoGraphDeploymentResource}}},
+		{GroupVersion: "nvidia.com/v1alpha1", APIResources: []metav1.APIResource{{Name: dynamoGraphDeploymentResource}}},
+	}
+
+	// The cluster prefers v1beta1, which the transformer cannot emit; v1alpha1 is still served
+	result, err := NewTransformer(dc).Transform(context.Background(), newTestMD("test-model", "default"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := result.Primary().GetAPIVersion(); got != "nvidia.com/v1alpha1" {
+		t.Errorf("expected apiVersion 'nvidia.com/v1alpha1', got %s", got)
+	}
+
+	dc.Resources = dc.Resources[:1]
+	_, err = NewTransformer(dc).Transform(context.Background(), newTestMD("test-model", "default"))
+	if err == nil || !strings.Contains(err.Error(), "cluster serves nvidia.com/dynamographdeployments only in v1beta1") {
+		t.Errorf("expected an unsupported version error, got %v", err)
+	}
+}
+
 func TestTransformDisaggregated(t *testing.T) {
 	tr := NewTransformer()
 	md := newTestMD("test-model", "default")
@@ -137,7 +173,7 @@ func TestTransformDisaggregated(t *testing.T) {
 		},
 	}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -173,6 +209,41 @@ func TestTransformDisaggregated(t *testing.T) {
 	}
 }
 
+func TestTransformDisaggregatedComponentImages(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Image = "example.com/vllm-runtime:custom"
+	md.Spec.Serving = &airunwayv1alpha1.ServingSpec{
+		Mode: airunwayv1alpha1.ServingModeDisaggregated,
+	}
+	md.Spec.Scaling = &airunwayv1alpha1.ScalingSpec{
+		Prefill: &airunwayv1alpha1.ComponentScalingSpec{
+			Replicas: 1,
+			GPU:      &airunwayv1alpha1.GPUSpec{Count: 1},
+			Image:    "example.com/vllm-runtime:nixl",
+		},
+		Decode: &airunwayv1alpha1.ComponentScalingSpec{
+			Replicas: 1,
+			GPU:      &airunwayv1alpha1.GPUSpec{Count: 1},
+		},
+	}
+
+	resources, err := transformResources(tr, md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for service, want := range map[string]string{
+		"VllmPrefillWorker": "example.com/vllm-runtime:nixl",
+		"VllmDecodeWorker":  "example.com/vllm-runtime:custom",
+	} {
+		image, _, _ := unstructured.NestedString(resources[0].Object, "spec", "services", service, "extraPodSpec", "mainContainer", "image")
+		if image != want {
+			t.Errorf("expected %s image %q, got %q", service, want, image)
+		}
+	}
+}
+
 func TestMapEngineType(t *testing.T) {
 	tr := NewTransformer()
 
@@ -573,7 +644,7 @@ func TestAddSchedulingConfig(t *testing.T) {
 	service := map[string]interface{}{
 		"extraPodSpec": map[string]interface{}{},
 	}
-	tr.addSchedulingConfig(service, md)
+	tr.addSchedulingConfig(service, md, nil)
 	eps, _ := service["extraPodSpec"].(map[string]interface{})
 	ns, _ := eps["nodeSelector"].(map[string]interface{})
 	if ns["gpu"] != "a100" {
@@ -595,7 +666,7 @@ func TestAddSchedulingConfig(t *testing.T) {
 		},
 	}
 	service = map[string]interface{}{}
-	tr.addSchedulingConfig(service, md)
+	tr.addSchedulingConfig(service, md, nil)
 	eps, _ = service["extraPodSpec"].(map[string]interface{})
 	tolerations, _ := eps["tolerations"].([]interface{})
 	if len(tolerations) != 1 {
@@ -614,7 +685,7 @@ func TestAddSchedulingConfig(t *testing.T) {
 		},
 	}
 	service = map[string]interface{}{}
-	tr.addSchedulingConfig(service, md)
+	tr.addSchedulingConfig(service, md, nil)
 	eps, _ = service["extraPodSpec"].(map[string]interface{})
 	tolerations, _ = eps["tolerations"].([]interface{})
 	tol, _ := tolerations[0].(map[string]interface{})
@@ -914,7 +985,7 @@ func TestApplyOverridesEscapeHatch(t *testing.T) {
 		},
 	}
 
-	results, err := tr.Transform(context.Background(), md)
+	results, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -941,7 +1012,7 @@ func TestTransformAggregatedNoGPU(t *testing.T) {
 		CPU:    "4",
 	}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -973,7 +1044,7 @@ func TestTransformAggregatedNilResources(t *testing.T) {
 	md := newTestMD("test-model", "default")
 	md.Spec.Resources = nil
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -996,7 +1067,7 @@ func TestTransformAggregatedGPUCount0(t *testing.T) {
 		GPU: &airunwayv1alpha1.GPUSpec{Count: 0},
 	}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1017,7 +1088,7 @@ func TestTransformSGLangEngine(t *testing.T) {
 	md := newTestMD("test-model", "default")
 	md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeSGLang
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1046,7 +1117,7 @@ func TestTransformTRTLLMEngine(t *testing.T) {
 	md := newTestMD("test-model", "default")
 	md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeTRTLLM
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1085,7 +1156,7 @@ func TestTransformWithCustomScalingReplicas(t *testing.T) {
 		Replicas: 5,
 	}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1116,7 +1187,7 @@ func TestTransformDisaggregatedGPURequests(t *testing.T) {
 		},
 	}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1168,7 +1239,7 @@ func TestTransformOverrideCanOverwriteServices(t *testing.T) {
 		},
 	}
 
-	results, err := tr.Transform(context.Background(), md)
+	results, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1193,7 +1264,7 @@ func TestTransformWithCustomImage(t *testing.T) {
 	md := newTestMD("test-model", "default")
 	md.Spec.Image = "my-registry.io/custom-vllm:v1"
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1213,7 +1284,7 @@ func TestTransformAggregatedVLLMWorkersDoNotInjectNixlSideChannelHostByDefault(t
 	tr := NewTransformer()
 	md := newTestMD("test-model", "default")
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1245,7 +1316,7 @@ func TestTransformDisaggregatedVLLMWorkersInjectNixlSideChannelHost(t *testing.T
 		},
 	}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1274,7 +1345,7 @@ func TestTransformNonVLLMWorkersDoNotInjectNixlSideChannelHost(t *testing.T) {
 	md := newTestMD("test-model", "default")
 	md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeSGLang
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1317,6 +1388,53 @@ func TestBuildResourceLimitsWithAllFields(t *testing.T) {
 	}
 }
 
+func TestBuildResourceLimitsWithRequestsAndLimits(t *testing.T) {
+	tr := NewTransformer()
+	result := tr.buildResourceLimits(&airunwayv1alpha1.ResourceSpec{
+		Memory:   "64Gi",
+		CPU:      "16",
+		Requests: &airunwayv1alpha1.ResourceQuantities{CPU: "4", Memory: "48Gi"},
+		Limits:   &airunwayv1alpha1.ResourceQuantities{Memory: "80Gi"},
+	})
+	limits, _ := result["limits"].(map[string]interface{})
+	requests, _ := result["requests"].(map[string]interface{})
+
+	if limits["memory"] != "80Gi" || limits["cpu"] != "16" {
+		t.Errorf("expected explicit memory limit and shorthand cpu limit, got %v", limits)
+	}
+	if requests["memory"] != "48Gi" || requests["cpu"] != "4" {
+		t.Errorf("expected explicit requests, got %v", requests)
+	}
+}
+
+func TestBuildDecodeWorkerWithRequestsAndLimits(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test", "default")
+	md.Spec.Scaling = &airunwayv1alpha1.ScalingSpec{
+		Decode: &airunwayv1alpha1.ComponentScalingSpec{
+			Replicas: 1,
+			GPU:      &airunwayv1alpha1.GPUSpec{Count: 1},
+			Memory:   "32Gi",
+			Requests: &airunwayv1alpha1.ResourceQuantities{CPU: "4", Memory: "24Gi"},
+			Limits:   &airunwayv1alpha1.ResourceQuantities{CPU: "8"},
+		},
+	}
+
+	worker, err := tr.buildDecodeWorker(md, "img", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resources, _ := worker["resources"].(map[string]interface{})
+	limits, _ := resources["limits"].(map[string]interface{})
+	requests, _ := resources["requests"].(map[string]interface{})
+	if limits["memory"] != "32Gi" || limits["cpu"] != "8" || limits["gpu"] != "1" {
+		t.Errorf("unexpected limits %v", limits)
+	}
+	if requests["memory"] != "24Gi" || requests["cpu"] != "4" || requests["gpu"] != "1" {
+		t.Errorf("unexpected requests %v", requests)
+	}
+}
+
 // --- Storage Tests ---
 
 func TestTransformWithModelCacheStorage(t *testing.T) {
@@ -1333,7 +1451,7 @@ func TestTransformWithModelCacheStorage(t *testing.T) {
 		},
 	}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1410,7 +1528,7 @@ func TestTransformWithCompilationCacheStorage(t *testing.T) {
 		},
 	}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1449,7 +1567,7 @@ func TestTransformWithBothCaches(t *testing.T) {
 		},
 	}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1502,7 +1620,7 @@ func TestTransformNoStorageNoPVCs(t *testing.T) {
 	md := newTestMD("test-model", "default")
 	// No storage configured
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1541,7 +1659,7 @@ func TestTransformHFHomeNotInjectedWhenUserSetsIt(t *testing.T) {
 		{Name: "HF_HOME", Value: "/custom/hf/home"},
 	}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1577,7 +1695,7 @@ func TestTransformFrontendHasNoVolumeMounts(t *testing.T) {
 		},
 	}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1593,6 +1711,86 @@ func TestTransformFrontendHasNoVolumeMounts(t *testing.T) {
 	}
 }
 
+func TestBuildFrontendServiceRouter(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	replicas := int32(3)
+	md.Spec.Serving = &airunwayv1alpha1.ServingSpec{
+		Router: &airunwayv1alpha1.RouterSpec{
+			Replicas:   &replicas,
+			CPU:        "4",
+			Memory:     "8Gi",
+			RouterMode: airunwayv1alpha1.RouterModeKV,
+		},
+	}
+
+	frontend := tr.buildFrontendService(md, &DynamoOverrides{})
+	if frontend["replicas"] != int64(3) {
+		t.Errorf("expected frontend replicas 3, got %v", frontend["replicas"])
+	}
+	requests, _, _ := unstructured.NestedStringMap(frontend, "resources", "requests")
+	if requests["cpu"] != "4" || requests["memory"] != "8Gi" {
+		t.Errorf("expected router resources on the frontend, got %v", requests)
+	}
+	env, _, _ := unstructured.NestedSlice(frontend, "extraPodSpec", "mainContainer", "env")
+	if mode, _ := env[0].(map[string]interface{})["value"].(string); mode != "kv" {
+		t.Errorf("expected DYN_ROUTER_MODE kv, got %q", mode)
+	}
+
+	// Dynamo overrides take precedence over spec.serving.router
+	overrideReplicas := int32(5)
+	frontend = tr.buildFrontendService(md, &DynamoOverrides{
+		RouterMode: "round-robin",
+		Frontend: &FrontendOverrides{
+			Replicas:  &overrideReplicas,
+			Resources: &ResourceOverrides{Memory: "16Gi"},
+		},
+	})
+	if frontend["replicas"] != int64(5) {
+		t.Errorf("expected override replicas 5, got %v", frontend["replicas"])
+	}
+	requests, _, _ = unstructured.NestedStringMap(frontend, "resources", "requests")
+	if requests["cpu"] != "4" || requests["memory"] != "16Gi" {
+		t.Errorf("expected router cpu and override memory, got %v", requests)
+	}
+	env, _, _ = unstructured.NestedSlice(frontend, "extraPodSpec", "mainContainer", "env")
+	if mode, _ := env[0].(map[string]interface{})["value"].(string); mode != "round-robin" {
+		t.Errorf("expected DYN_ROUTER_MODE round-robin, got %q", mode)
+	}
+}
+
+func TestTransformRouterModeOnlyChangesFrontend(t *testing.T) {
+	tr := NewTransformer()
+	servicesFor := func(mode airunwayv1alpha1.RouterMode) map[string]interface{} {
+		md := newTestMD("test-model", "default")
+		md.Spec.Serving = &airunwayv1alpha1.ServingSpec{Router: &airunwayv1alpha1.RouterSpec{RouterMode: mode}}
+		// The standalone frontend routes requests when the gateway is disabled
+		disabled := false
+		md.Spec.Gateway = &airunwayv1alpha1.GatewaySpec{Enabled: &disabled}
+		resources, err := transformResources(tr, md)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		services, _, _ := unstructured.NestedMap(resources[0].Object, "spec", "services")
+		return services
+	}
+
+	kv := servicesFor(airunwayv1alpha1.RouterModeKV)
+	none := servicesFor(airunwayv1alpha1.RouterModeNone)
+	env, _, _ := unstructured.NestedSlice(none, "Frontend", "extraPodSpec", "mainContainer", "env")
+	if mode, _ := env[0].(map[string]interface{})["value"].(string); mode != "random" {
+		t.Errorf("expected DYN_ROUTER_MODE random for router mode none, got %q", mode)
+	}
+	if reflect.DeepEqual(kv["Frontend"], none["Frontend"]) {
+		t.Error("expected the frontend to change with the router mode")
+	}
+	for name := range kv {
+		if name != "Frontend" && !reflect.DeepEqual(kv[name], none[name]) {
+			t.Errorf("expected service %s not to change with the router mode", name)
+		}
+	}
+}
+
 func TestTransformDisaggregatedBothWorkersGetVolumeMounts(t *testing.T) {
 	tr := NewTransformer()
 	md := newTestMD("test-model", "default")
@@ -1620,7 +1818,7 @@ func TestTransformDisaggregatedBothWorkersGetVolumeMounts(t *testing.T) {
 		},
 	}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1683,7 +1881,7 @@ func TestTransformWithReadOnlyVolume(t *testing.T) {
 		},
 	}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1765,3 +1963,433 @@ func assertNoArg(t *testing.T, args []string, flag string) {
 		}
 	}
 }
+
+func TestTransformDisaggregatedPlacement(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Serving = &airunwayv1alpha1.ServingSpec{
+		Mode: airunwayv1alpha1.ServingModeDisaggregated,
+		Placement: &airunwayv1alpha1.PlacementSpec{
+			Colocate:    airunwayv1alpha1.PlacementDomainZone,
+			TopologyKey: "example.com/rack",
+		},
+	}
+	md.Spec.Scaling = &airunwayv1alpha1.ScalingSpec{
+		Prefill: &airunwayv1alpha1.ComponentScalingSpec{Replicas: 1, GPU: &airunwayv1alpha1.GPUSpec{Count: 1}},
+		Decode:  &airunwayv1alpha1.ComponentScalingSpec{Replicas: 1, GPU: &airunwayv1alpha1.GPUSpec{Count: 1}},
+	}
+
+	resources, err := transformResources(tr, md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	services, _, _ := unstructured.NestedMap(resources[0].Object, "spec", "services")
+	for _, name := range []string{"VllmPrefillWorker", "VllmDecodeWorker"} {
+		worker, _ := services[name].(map[string]interface{})
+		labels, _ := worker["labels"].(map[string]interface{})
+		if labels[airunwayv1alpha1.LabelModelDeployment] != "test-model" {
+			t.Errorf("expected %s to carry the model deployment label, got %v", name, labels)
+		}
+		terms, found, _ := unstructured.NestedSlice(worker, "extraPodSpec", "affinity", "podAffinity", "requiredDuringSchedulingIgnoredDuringExecution")
+		if !found || len(terms) != 1 {
+			t.Fatalf("expected required pod affinity on %s, got %v", name, terms)
+		}
+		term, _ := terms[0].(map[string]interface{})
+		if term["topologyKey"] != "example.com/rack" {
+			t.Errorf("expected topologyKey override on %s, got %v", name, term["topologyKey"])
+		}
+	}
+
+	frontend, _ := services["Frontend"].(map[string]interface{})
+	if _, found, _ := unstructured.NestedMap(frontend, "extraPodSpec", "affinity"); found {
+		t.Error("expected no placement affinity on the frontend")
+	}
+}
+
+func TestTransformDisaggregatedComponentScheduling(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.NodeSelector = map[string]string{"pool": "gpu", "zone": "a"}
+	md.Spec.Tolerations = []corev1.Toleration{{Key: "gpu", Operator: corev1.TolerationOpExists}}
+	md.Spec.Serving = &airunwayv1alpha1.ServingSpec{
+		Mode:      airunwayv1alpha1.ServingModeDisaggregated,
+		Placement: &airunwayv1alpha1.PlacementSpec{Colocate: airunwayv1alpha1.PlacementDomainZone},
+	}
+	md.Spec.Scaling = &airunwayv1alpha1.ScalingSpec{
+		Prefill: &airunwayv1alpha1.ComponentScalingSpec{
+			Replicas: 1,
+			GPU:      &airunwayv1alpha1.GPUSpec{Count: 1},
+			Scheduling: &airunwayv1alpha1.ComponentSchedulingSpec{
+				NodeSelector: map[string]string{"pool": "h100"},
+				Tolerations:  []corev1.Toleration{{Key: "h100", Operator: corev1.TolerationOpExists}},
+				Affinity: &corev1.Affinity{
+					PodAntiAffinity: &corev1.PodAntiAffinity{
+						RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{{TopologyKey: "kubernetes.io/hostname"}},
+					},
+				},
+			},
+		},
+		Decode: &airunwayv1alpha1.ComponentScalingSpec{Replicas: 1, GPU: &airunwayv1alpha1.GPUSpec{Count: 1}},
+	}
+
+	resources, err := transformResources(tr, md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	services, _, _ := unstructured.NestedMap(resources[0].Object, "spec", "services")
+
+	prefill, _ := services["VllmPrefillWorker"].(map[string]interface{})
+	nodeSelector, _, _ := unstructured.NestedStringMap(prefill, "extraPodSpec", "nodeSelector")
+	if nodeSelector["pool"] != "h100" || nodeSelector["zone"] != "a" {
+		t.Errorf("expected the prefill node selector merged over the deployment's, got %v", nodeSelector)
+	}
+	tolerations, _, _ := unstructured.NestedSlice(prefill, "extraPodSpec", "tolerations")
+	if len(tolerations) != 2 {
+		t.Errorf("expected deployment and prefill tolerations, got %v", tolerations)
+	}
+	if _, found, _ := unstructured.NestedSlice(prefill, "extraPodSpec", "affinity", "podAntiAffinity", "requiredDuringSchedulingIgnoredDuringExecution"); !found {
+		t.Error("expected the prefill pod anti-affinity")
+	}
+	if _, found, _ := unstructured.NestedSlice(prefill, "extraPodSpec", "affinity", "podAffinity", "requiredDuringSchedulingIgnoredDuringExecution"); !found {
+		t.Error("expected the placement pod affinity to be kept on prefill")
+	}
+
+	decode, _ := services["VllmDecodeWorker"].(map[string]interface{})
+	nodeSelector, _, _ = unstructured.NestedStringMap(decode, "extraPodSpec", "nodeSelector")
+	if nodeSelector["pool"] != "gpu" {
+		t.Errorf("expected decode to keep the deployment node selector, got %v", nodeSelector)
+	}
+	if _, found, _ := unstructured.NestedMap(decode, "extraPodSpec", "affinity", "podAntiAffinity"); found {
+		t.Error("expected no prefill anti-affinity on decode")
+	}
+}
+
+func TestTransformRejectsCPUDevice(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Engine.Device = airunwayv1alpha1.EngineDeviceCPU
+
+	if _, err := tr.Transform(context.Background(), md); err == nil || !strings.Contains(err.Error(), "cpu inference") {
+		t.Errorf("expected cpu device to be rejected, got %v", err)
+	}
+}
+
+func TestTransformIdentity(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Identity = &airunwayv1alpha1.IdentitySpec{
+		Annotations: map[string]string{"eks.amazonaws.com/role-arn": "arn:aws:iam::111122223333:role/model-reader"},
+	}
+
+	resources, err := transformResources(tr, md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	services, _, _ := unstructured.NestedMap(resources[0].Object, "spec", "services")
+	worker, _ := services["VllmWorker"].(map[string]interface{})
+	sa, _, _ := unstructured.NestedString(worker, "extraPodSpec", "serviceAccountName")
+	if sa != "test-model" {
+		t.Errorf("expected worker serviceAccountName test-model, got %q", sa)
+	}
+	if _, found := worker["labels"]; found {
+		t.Errorf("expected no identity labels for IRSA, got %v", worker["labels"])
+	}
+	frontend, _ := services["Frontend"].(map[string]interface{})
+	if _, found, _ := unstructured.NestedString(frontend, "extraPodSpec", "serviceAccountName"); found {
+		t.Error("expected the frontend to keep the default ServiceAccount")
+	}
+}
+
+func TestTransformGangScheduling(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Scaling = &airunwayv1alpha1.ScalingSpec{Replicas: 3}
+	md.Spec.Scheduling = &airunwayv1alpha1.SchedulingSpec{
+		Gang:      true,
+		Scheduler: airunwayv1alpha1.GangSchedulerVolcano,
+		Queue:     "inference",
+	}
+
+	result, err := tr.Transform(context.Background(), md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Resources) != 2 {
+		t.Fatalf("expected PodGroup and DynamoGraphDeployment, got %d resources", len(result.Resources))
+	}
+
+	podGroup := result.Resources[1]
+	if podGroup.GetKind() != "PodGroup" {
+		t.Fatalf("expected the second resource to be a PodGroup, got %s", podGroup.GetKind())
+	}
+	minMember, _, _ := unstructured.NestedInt64(podGroup.Object, "spec", "minMember")
+	if minMember != 3 {
+		t.Errorf("expected minMember 3, got %d", minMember)
+	}
+	if result.Primary().GetKind() != DynamoGraphDeploymentKind {
+		t.Errorf("expected primary resource to be the DynamoGraphDeployment, got %s", result.Primary().GetKind())
+	}
+
+	services, _, _ := unstructured.NestedMap(result.Primary().Object, "spec", "services")
+	worker, _ := services["VllmWorker"].(map[string]interface{})
+	group, _, _ := unstructured.NestedString(worker, "annotations", "scheduling.k8s.io/group-name")
+	if group != "test-model" {
+		t.Errorf("expected worker group-name annotation test-model, got %q", group)
+	}
+	scheduler, _, _ := unstructured.NestedString(worker, "extraPodSpec", "schedulerName")
+	if scheduler != "volcano" {
+		t.Errorf("expected worker schedulerName volcano, got %q", scheduler)
+	}
+}
+
+func TestTransformGPUTypes(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Resources.GPU.Types = []string{"NVIDIA-H100-80GB-HBM3", "NVIDIA-A100-SXM4-80GB"}
+
+	resources, err := transformResources(tr, md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	services, _, _ := unstructured.NestedMap(resources[0].Object, "spec", "services")
+	worker, _ := services["VllmWorker"].(map[string]interface{})
+	terms, found, _ := unstructured.NestedSlice(worker, "extraPodSpec", "affinity", "nodeAffinity", "requiredDuringSchedulingIgnoredDuringExecution", "nodeSelectorTerms")
+	if !found || len(terms) != 1 {
+		t.Fatalf("expected a required node selector term on the worker, got %v", terms)
+	}
+	preferred, _, _ := unstructured.NestedSlice(worker, "extraPodSpec", "affinity", "nodeAffinity", "preferredDuringSchedulingIgnoredDuringExecution")
+	if len(preferred) != 2 {
+		t.Errorf("expected a preferred term per GPU type, got %v", preferred)
+	}
+}
+
+func TestTransformChatTemplate(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeSGLang
+	md.Spec.Model.ChatTemplate = &airunwayv1alpha1.ChatTemplateSource{Inline: "{{ messages }}"}
+	md.Spec.Model.Tokenizer = "org/tokenizer"
+
+	resources, err := transformResources(tr, md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resources) != 2 || resources[1].GetKind() != "ConfigMap" {
+		t.Fatalf("expected the chat template ConfigMap next to the DGD, got %d resources", len(resources))
+	}
+	services, _, _ := unstructured.NestedMap(resources[0].Object, "spec", "services")
+	worker, _ := services["VllmWorker"].(map[string]interface{})
+	args, _, _ := unstructured.NestedStringSlice(worker, "extraPodSpec", "mainContainer", "args")
+	joined := strings.Join(args, " ")
+	if !strings.Contains(joined, "--custom-jinja-template /etc/airunway/chat-template/chat_template.jinja") {
+		t.Errorf("expected --custom-jinja-template, got %s", joined)
+	}
+	if !strings.Contains(joined, "--tokenizer-path org/tokenizer") {
+		t.Errorf("expected --tokenizer-path for sglang, got %s", joined)
+	}
+	if volumes, _, _ := unstructured.NestedSlice(worker, "extraPodSpec", "volumes"); len(volumes) != 1 {
+		t.Errorf("expected the chat template volume, got %v", volumes)
+	}
+	if mounts, _, _ := unstructured.NestedSlice(worker, "extraPodSpec", "mainContainer", "volumeMounts"); len(mounts) != 1 {
+		t.Errorf("expected the chat template to be mounted, got %v", mounts)
+	}
+	if hash, _, _ := unstructured.NestedString(worker, "annotations", "airunway.ai/chat-template-hash"); hash == "" {
+		t.Error("expected the chat template hash annotation on the worker")
+	}
+}
+
+func TestTransformLogSink(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Observability = &airunwayv1alpha1.ObservabilitySpec{LogSink: &airunwayv1alpha1.LogSinkSpec{
+		Type: airunwayv1alpha1.LogSinkTypeLoki, Endpoint: "http://loki.observability:3100",
+	}}
+
+	resources, err := transformResources(tr, md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resources) != 2 || resources[1].GetName() != "test-model-log-sink" {
+		t.Fatalf("expected the log sink ConfigMap next to the DGD, got %d resources", len(resources))
+	}
+	services, _, _ := unstructured.NestedMap(resources[0].Object, "spec", "services")
+	worker, _ := services["VllmWorker"].(map[string]interface{})
+	containers, _, _ := unstructured.NestedSlice(worker, "extraPodSpec", "containers")
+	if len(containers) != 1 || containers[0].(map[string]interface{})["name"] != "log-sink" {
+		t.Errorf("expected the log sink sidecar, got %v", containers)
+	}
+	if volumes, _, _ := unstructured.NestedSlice(worker, "extraPodSpec", "volumes"); len(volumes) != 2 {
+		t.Errorf("expected the log sink volumes, got %v", volumes)
+	}
+	if hash, _, _ := unstructured.NestedString(worker, "annotations", "airunway.ai/log-sink-config-hash"); hash == "" {
+		t.Error("expected the log sink config hash annotation on the worker")
+	}
+}
+
+func TestTransformKVOffload(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeSGLang
+	sc := "local-nvme"
+	md.Spec.Model.Storage = &airunwayv1alpha1.StorageSpec{KVOffload: &airunwayv1alpha1.KVOffloadSpec{
+		Size:             resource.MustParse("200Gi"),
+		Medium:           airunwayv1alpha1.KVOffloadMediumNVMe,
+		StorageClassName: &sc,
+	}}
+
+	resources, err := transformResources(tr, md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	services, _, _ := unstructured.NestedMap(resources[0].Object, "spec", "services")
+	worker, _ := services["VllmWorker"].(map[string]interface{})
+	args, _, _ := unstructured.NestedStringSlice(worker, "extraPodSpec", "mainContainer", "args")
+	if joined := strings.Join(args, " "); !strings.Contains(joined, "--enable-hierarchical-cache --hicache-storage-backend file") {
+		t.Errorf("expected the sglang hierarchical cache file backend, got %s", joined)
+	}
+	volumes, _, _ := unstructured.NestedSlice(worker, "extraPodSpec", "volumes")
+	if len(volumes) != 1 {
+		t.Fatalf("expected the KV offload volume, got %v", volumes)
+	}
+	if class, _, _ := unstructured.NestedString(volumes[0].(map[string]interface{}), "ephemeral", "volumeClaimTemplate", "spec", "storageClassName"); class != sc {
+		t.Errorf("expected an ephemeral volume of class %s, got %v", sc, volumes[0])
+	}
+	if mounts, _, _ := unstructured.NestedSlice(worker, "extraPodSpec", "mainContainer", "volumeMounts"); len(mounts) != 1 {
+		t.Errorf("expected the KV offload volume to be mounted, got %v", mounts)
+	}
+
+	md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeTRTLLM
+	if _, err := tr.Transform(context.Background(), md); err == nil {
+		t.Error("expected KV offload to be rejected for trtllm")
+	}
+}
+
+func TestTransformKVCache(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeVLLM
+	md.Spec.Caching = &airunwayv1alpha1.CachingSpec{KV: &airunwayv1alpha1.KVCacheSpec{Backend: airunwayv1alpha1.KVCacheBackendLMCache}}
+
+	resources, err := transformResources(tr, md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	services, _, _ := unstructured.NestedMap(resources[0].Object, "spec", "services")
+	worker, _ := services["VllmWorker"].(map[string]interface{})
+	args, _, _ := unstructured.NestedStringSlice(worker, "extraPodSpec", "mainContainer", "args")
+	if joined := strings.Join(args, " "); !strings.Contains(joined, "LMCacheConnectorV1") {
+		t.Errorf("expected the LMCache connector, got %s", joined)
+	}
+	env, _, _ := unstructured.NestedSlice(worker, "extraPodSpec", "mainContainer", "env")
+	found := false
+	for _, e := range env {
+		if e.(map[string]interface{})["name"] == "LMCACHE_LOCAL_CPU" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected the LMCache env on the worker, got %v", env)
+	}
+
+	md.Spec.Serving = &airunwayv1alpha1.ServingSpec{Mode: airunwayv1alpha1.ServingModeDisaggregated}
+	if _, err := tr.Transform(context.Background(), md); err == nil {
+		t.Error("expected the KV cache to be rejected in disaggregated mode")
+	}
+}
+
+func TestTransformLoadFormat(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeVLLM
+	md.Spec.Model.LoadFormat = airunwayv1alpha1.ModelLoadFormatTensorizer
+	md.Spec.Model.TensorizerURI = "s3://models/llama/model.tensors"
+	md.Spec.Model.LoadCredentialsSecret = "model-store"
+
+	resources, err := transformResources(tr, md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	services, _, _ := unstructured.NestedMap(resources[0].Object, "spec", "services")
+	worker, _ := services["VllmWorker"].(map[string]interface{})
+	args, _, _ := unstructured.NestedStringSlice(worker, "extraPodSpec", "mainContainer", "args")
+	if joined := strings.Join(args, " "); !strings.Contains(joined, `--load-format tensorizer --model-loader-extra-config {"tensorizer_uri":"s3://models/llama/model.tensors"}`) {
+		t.Errorf("expected the tensorizer flags, got %s", joined)
+	}
+	envFrom, _, _ := unstructured.NestedSlice(worker, "extraPodSpec", "mainContainer", "envFrom")
+	if len(envFrom) != 1 || envFrom[0].(map[string]interface{})["secretRef"].(map[string]interface{})["name"] != "model-store" {
+		t.Errorf("expected the load credentials Secret on the worker, got %v", envFrom)
+	}
+
+	md.Spec.Model.TensorizerURI = ""
+	if _, err := tr.Transform(context.Background(), md); err == nil {
+		t.Error("expected tensorizer without a URI to be rejected")
+	}
+}
+
+func TestTransformStreamDrain(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeVLLM
+	md.Spec.Gateway = &airunwayv1alpha1.GatewaySpec{Streaming: true}
+
+	resources, err := transformResources(tr, md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	services, _, _ := unstructured.NestedMap(resources[0].Object, "spec", "services")
+	worker, _ := services["VllmWorker"].(map[string]interface{})
+	if _, ok, _ := unstructured.NestedSlice(worker, "extraPodSpec", "readinessGates"); ok {
+		t.Errorf("expected no readiness gate, got %v", worker["extraPodSpec"])
+	}
+	if grace, _, _ := unstructured.NestedInt64(worker, "extraPodSpec", "terminationGracePeriodSeconds"); grace != 3610 {
+		t.Errorf("expected a 3610s grace period, got %d", grace)
+	}
+	if _, ok, _ := unstructured.NestedMap(worker, "extraPodSpec", "mainContainer", "lifecycle", "preStop", "sleep"); !ok {
+		t.Errorf("expected a preStop sleep on the main container, got %v", worker["extraPodSpec"])
+	}
+}
+
+func TestTransformPropagatedMetadata(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Labels = map[string]string{"cost-center": "ml-42"}
+	md.Spec.Annotations = map[string]string{"example.com/owner": "search-team"}
+
+	resources, err := transformResources(tr, md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	dgd := resources[0]
+	if dgd.GetLabels()["cost-center"] != "ml-42" || dgd.GetAnnotations()["example.com/owner"] != "search-team" {
+		t.Errorf("expected propagated metadata on the DGD, got %v %v", dgd.GetLabels(), dgd.GetAnnotations())
+	}
+	services, _, _ := unstructured.NestedMap(dgd.Object, "spec", "services")
+	for name, service := range services {
+		labels, _, _ := unstructured.NestedStringMap(service.(map[string]interface{}), "labels")
+		annotations, _, _ := unstructured.NestedStringMap(service.(map[string]interface{}), "annotations")
+		if labels["cost-center"] != "ml-42" || annotations["example.com/owner"] != "search-team" {
+			t.Errorf("expected propagated pod metadata on %s, got %v %v", name, labels, annotations)
+		}
+	}
+}
+
+func TestTransformSecretsChecksum(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Secrets = &airunwayv1alpha1.SecretsSpec{HuggingFaceToken: "hf-token", RolloutOnChange: true}
+	md.Status.SecretsChecksum = "abc123"
+
+	resources, err := transformResources(tr, md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	services, _, _ := unstructured.NestedMap(resources[0].Object, "spec", "services")
+	for name, service := range services {
+		annotations, _, _ := unstructured.NestedStringMap(service.(map[string]interface{}), "annotations")
+		if annotations[airunwayv1alpha1.AnnotationSecretsChecksum] != "abc123" {
+			t.Errorf("expected the secrets checksum on %s, got %v", name, annotations)
+		}
+	}
+}
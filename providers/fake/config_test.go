@@ -0,0 +1,44 @@
+package fake
+
+import (
+	"testing"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+)
+
+func TestGetProviderConfigSpec(t *testing.T) {
+	spec := GetProviderConfigSpec()
+
+	if spec.Capabilities == nil {
+		t.Fatal("expected capabilities to be set")
+	}
+	if !spec.Capabilities.CPUSupport || !spec.Capabilities.GPUSupport {
+		t.Error("expected CPU and GPU support")
+	}
+	if spec.Capabilities.RequiresCRD == nil || *spec.Capabilities.RequiresCRD {
+		t.Error("expected the fake provider to not require CRDs")
+	}
+	for _, engine := range []airunwayv1alpha1.EngineType{
+		airunwayv1alpha1.EngineTypeVLLM,
+		airunwayv1alpha1.EngineTypeSGLang,
+		airunwayv1alpha1.EngineTypeTRTLLM,
+		airunwayv1alpha1.EngineTypeLlamaCpp,
+	} {
+		if !spec.Capabilities.SupportsCPUEngine(engine) {
+			t.Errorf("expected CPU support for %s", engine)
+		}
+	}
+	if len(spec.Capabilities.ServingModes) != 2 {
+		t.Errorf("expected both serving modes, got %v", spec.Capabilities.ServingModes)
+	}
+	if len(spec.SelectionRules) != 0 {
+		t.Errorf("expected no selection rules (never auto-selected), got %d", len(spec.SelectionRules))
+	}
+}
+
+func TestGetInstallationInfo(t *testing.T) {
+	info := GetInstallationInfo()
+	if info == nil || info.Description == "" || len(info.Steps) == 0 {
+		t.Errorf("expected installation info with steps, got %+v", info)
+	}
+}
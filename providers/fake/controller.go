@@ -0,0 +1,279 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/pkg/provider"
+)
+
+const (
+	// ProviderName is the name of this provider
+	ProviderName = "fake"
+
+	// FinalizerName is the finalizer used by this controller
+	FinalizerName = "airunway.ai/fake-provider"
+
+	// RequeueInterval is the default requeue interval for periodic reconciliation
+	RequeueInterval = 30 * time.Second
+
+	// AnnotationPhase forces the phase reported for a ModelDeployment, so tests can
+	// exercise Pending, Deploying and Failed handling. Defaults to Running.
+	AnnotationPhase = "fake.airunway.ai/phase"
+)
+
+// FakeProviderReconciler reconciles ModelDeployment resources for the fake provider.
+// It creates a stub OpenAI server per ModelDeployment and reports Running immediately,
+// without waiting for the stub pods to become ready.
+type FakeProviderReconciler struct {
+	client.Client
+	Scheme      *runtime.Scheme
+	Transformer *Transformer
+}
+
+// NewFakeProviderReconciler creates a new fake provider reconciler
+func NewFakeProviderReconciler(c client.Client, scheme *runtime.Scheme, stubImage string) *FakeProviderReconciler {
+	return &FakeProviderReconciler{
+		Client:      c,
+		Scheme:      scheme,
+		Transformer: NewTransformer(stubImage),
+	}
+}
+
+// +kubebuilder:rbac:groups=airunway.ai,resources=modeldeployments,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=airunway.ai,resources=modeldeployments/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=airunway.ai,resources=modeldeployments/finalizers,verbs=update
+// +kubebuilder:rbac:groups=airunway.ai,resources=inferenceproviderconfigs,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=airunway.ai,resources=inferenceproviderconfigs/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile handles the reconciliation loop for ModelDeployments assigned to the fake provider
+func (r *FakeProviderReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	// Fetch the ModelDeployment
+	var md airunwayv1alpha1.ModelDeployment
+	if err := r.Get(ctx, req.NamespacedName, &md); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// Only process if this provider is selected
+	if md.Status.Provider == nil || md.Status.Provider.Name != ProviderName {
+		// Delete the stub of a deployment migrated to another provider, or deleted while
+		// migrating away from this one
+		if provider.Released(&md, ProviderName, FinalizerName) {
+			return ctrl.Result{}, r.releaseStub(ctx, &md)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	logger.Info("Reconciling ModelDeployment for fake provider", "name", md.Name, "namespace", md.Namespace)
+
+	// Stub resources are garbage collected through their OwnerReferences
+	if !md.DeletionTimestamp.IsZero() {
+		if controllerutil.RemoveFinalizer(&md, FinalizerName) {
+			return ctrl.Result{}, r.Update(ctx, &md)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// Leave resources untouched while paused; the core controller
+	// reports the PausedReconciliation condition
+	if md.IsPaused() {
+		logger.Info("Reconciliation paused", "name", md.Name)
+		return ctrl.Result{}, nil
+	}
+
+	// Create nothing until Kueue admits the deployment; the core controller moves it out of
+	// the Queued phase once admitted
+	if md.IsQueued() {
+		logger.Info("Waiting for Kueue admission", "name", md.Name)
+		return ctrl.Result{}, nil
+	}
+
+	// Add finalizer if not present
+	if !controllerutil.ContainsFinalizer(&md, FinalizerName) {
+		controllerutil.AddFinalizer(&md, FinalizerName)
+		if err := r.Update(ctx, &md); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	r.setCondition(&md, airunwayv1alpha1.ConditionTypeProviderCompatible, metav1.ConditionTrue, "CompatibilityVerified", "The fake provider accepts every configuration")
+
+	if err := r.reconcileStub(ctx, &md); err != nil {
+		if errors.IsConflict(err) {
+			logger.Info("Resource conflict during reconcile, requeueing", "name", md.Name)
+			return ctrl.Result{Requeue: true}, nil
+		}
+		logger.Error(err, "Failed to create/update stub resources", "name", md.Name)
+		r.setCondition(&md, airunwayv1alpha1.ConditionTypeResourceCreated, metav1.ConditionFalse, "CreateFailed", err.Error())
+		md.Status.Phase = airunwayv1alpha1.DeploymentPhaseFailed
+		md.Status.Message = fmt.Sprintf("Failed to create stub resources: %s", err.Error())
+		return ctrl.Result{}, r.Status().Update(ctx, &md)
+	}
+	r.setCondition(&md, airunwayv1alpha1.ConditionTypeResourceCreated, metav1.ConditionTrue, "ResourceCreated", "Stub Deployment and Service created successfully")
+
+	r.syncStatus(&md)
+	if err := r.Status().Update(ctx, &md); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	logger.Info("Reconciliation complete", "name", md.Name, "phase", md.Status.Phase)
+	return ctrl.Result{RequeueAfter: RequeueInterval}, nil
+}
+
+// reconcileStub creates or updates the stub server Deployment and Service, both named after
+// the ModelDeployment
+func (r *FakeProviderReconciler) reconcileStub(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) error {
+	deploy := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: md.Name, Namespace: md.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, deploy, func() error {
+		if err := verifyOwner(deploy, md); err != nil {
+			return err
+		}
+		r.Transformer.MutateDeployment(md, deploy)
+		return controllerutil.SetControllerReference(md, deploy, r.Scheme)
+	}); err != nil {
+		return err
+	}
+
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: md.Name, Namespace: md.Namespace}}
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, svc, func() error {
+		if err := verifyOwner(svc, md); err != nil {
+			return err
+		}
+		r.Transformer.MutateService(md, svc)
+		return controllerutil.SetControllerReference(md, svc, r.Scheme)
+	})
+	return err
+}
+
+// releaseStub deletes the stub server Deployment and Service, which garbage collection
+// leaves in place when the ModelDeployment only migrated to another provider, and removes
+// the finalizer
+func (r *FakeProviderReconciler) releaseStub(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) error {
+	for _, obj := range []client.Object{
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: md.Name, Namespace: md.Namespace}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: md.Name, Namespace: md.Namespace}},
+	} {
+		if err := r.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if !metav1.IsControlledBy(obj, md) {
+			continue
+		}
+		if err := r.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	controllerutil.RemoveFinalizer(md, FinalizerName)
+	return r.Update(ctx, md)
+}
+
+// verifyOwner rejects existing resources that are not controlled by the ModelDeployment
+func verifyOwner(obj client.Object, md *airunwayv1alpha1.ModelDeployment) error {
+	if obj.GetResourceVersion() != "" && !metav1.IsControlledBy(obj, md) {
+		return fmt.Errorf("resource %s/%s exists but is not managed by this ModelDeployment", obj.GetNamespace(), obj.GetName())
+	}
+	return nil
+}
+
+// syncStatus reports the phase requested by AnnotationPhase, Running by default, along with
+// the replica counts and endpoint of the stub server
+func (r *FakeProviderReconciler) syncStatus(md *airunwayv1alpha1.ModelDeployment) {
+	md.Status.Provider.ResourceName = md.Name
+	md.Status.Provider.ResourceKind = "Deployment"
+	md.Status.Endpoint = &airunwayv1alpha1.EndpointStatus{Service: md.Name, Port: DefaultStubPort}
+
+	replicas := Replicas(md)
+	phase := airunwayv1alpha1.DeploymentPhase(md.Annotations[AnnotationPhase])
+	switch phase {
+	case airunwayv1alpha1.DeploymentPhasePending, airunwayv1alpha1.DeploymentPhaseDeploying:
+		md.Status.Phase = phase
+		md.Status.Message = "Phase forced by the " + AnnotationPhase + " annotation"
+		md.Status.Replicas = &airunwayv1alpha1.ReplicaStatus{Desired: replicas}
+		r.setCondition(md, airunwayv1alpha1.ConditionTypeReady, metav1.ConditionFalse, "DeploymentInProgress", md.Status.Message)
+	case airunwayv1alpha1.DeploymentPhaseFailed:
+		md.Status.Phase = phase
+		md.Status.Message = "Phase forced by the " + AnnotationPhase + " annotation"
+		md.Status.Replicas = &airunwayv1alpha1.ReplicaStatus{Desired: replicas}
+		r.setCondition(md, airunwayv1alpha1.ConditionTypeReady, metav1.ConditionFalse, "DeploymentFailed", md.Status.Message)
+	default:
+		md.Status.Phase = airunwayv1alpha1.DeploymentPhaseRunning
+		md.Status.Message = "Fake deployment is running"
+		md.Status.Replicas = &airunwayv1alpha1.ReplicaStatus{Desired: replicas, Ready: replicas, Available: replicas}
+		r.setCondition(md, airunwayv1alpha1.ConditionTypeReady, metav1.ConditionTrue, "DeploymentReady", "All replicas are ready")
+	}
+}
+
+// setCondition sets a condition on the ModelDeployment
+func (r *FakeProviderReconciler) setCondition(md *airunwayv1alpha1.ModelDeployment, conditionType string, status metav1.ConditionStatus, reason, message string) {
+	condition := metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: metav1.Now(),
+		ObservedGeneration: md.Generation,
+	}
+	meta.SetStatusCondition(&md.Status.Conditions, condition)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *FakeProviderReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&airunwayv1alpha1.ModelDeployment{}).
+		WithEventFilter(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			md, ok := obj.(*airunwayv1alpha1.ModelDeployment)
+			if !ok {
+				return false
+			}
+			// Process if provider is fake OR if being deleted (to handle finalizer)
+			if md.Status.Provider != nil && md.Status.Provider.Name == ProviderName {
+				return true
+			}
+			// Also process if spec explicitly requests fake
+			if md.Spec.Provider != nil && md.Spec.Provider.Name == ProviderName {
+				return true
+			}
+			// Process if we have our finalizer (for deletion handling)
+			return controllerutil.ContainsFinalizer(md, FinalizerName)
+		})).
+		Named("fake-provider").
+		Complete(r)
+}
@@ -0,0 +1,249 @@
+package fake
+
+import (
+	"context"
+	"testing"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+func newScheme() *runtime.Scheme {
+	s := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(s)
+	_ = airunwayv1alpha1.AddToScheme(s)
+	return s
+}
+
+func newTestMD(name, ns string) *airunwayv1alpha1.ModelDeployment {
+	return &airunwayv1alpha1.ModelDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: ns,
+			UID:       "md-uid",
+		},
+		Spec: airunwayv1alpha1.ModelDeploymentSpec{
+			Model:  airunwayv1alpha1.ModelSpec{ID: "Qwen/Qwen3-0.6B", Source: airunwayv1alpha1.ModelSourceHuggingFace},
+			Engine: airunwayv1alpha1.EngineSpec{Type: airunwayv1alpha1.EngineTypeSGLang},
+		},
+		Status: airunwayv1alpha1.ModelDeploymentStatus{
+			Provider: &airunwayv1alpha1.ProviderStatus{Name: ProviderName},
+		},
+	}
+}
+
+func newTestReconciler(objs ...client.Object) *FakeProviderReconciler {
+	scheme := newScheme()
+	c := fakeclient.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&airunwayv1alpha1.ModelDeployment{}).
+		Build()
+	return NewFakeProviderReconciler(c, scheme, "")
+}
+
+// reconcileTwice runs the finalizer pass and the main pass
+func reconcileTwice(t *testing.T, r *FakeProviderReconciler, key types.NamespacedName) {
+	t.Helper()
+	for i := 0; i < 2; i++ {
+		if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+			t.Fatalf("Reconcile failed: %v", err)
+		}
+	}
+}
+
+func TestReconcile_Running(t *testing.T) {
+	md := newTestMD("test", "default")
+	md.Spec.Model.ServedName = "qwen"
+	md.Spec.Scaling = &airunwayv1alpha1.ScalingSpec{Replicas: 2}
+	r := newTestReconciler(md)
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "test", Namespace: "default"}
+
+	reconcileTwice(t, r, key)
+
+	var updated airunwayv1alpha1.ModelDeployment
+	if err := r.Get(ctx, key, &updated); err != nil {
+		t.Fatalf("failed to get ModelDeployment: %v", err)
+	}
+	if !controllerutil.ContainsFinalizer(&updated, FinalizerName) {
+		t.Error("expected finalizer to be added")
+	}
+	if updated.Status.Phase != airunwayv1alpha1.DeploymentPhaseRunning {
+		t.Errorf("expected phase Running, got %s", updated.Status.Phase)
+	}
+	if updated.Status.Replicas == nil || updated.Status.Replicas.Ready != 2 {
+		t.Errorf("expected 2 ready replicas, got %+v", updated.Status.Replicas)
+	}
+	if updated.Status.Endpoint == nil || updated.Status.Endpoint.Service != "test" || updated.Status.Endpoint.Port != DefaultStubPort {
+		t.Errorf("unexpected endpoint %+v", updated.Status.Endpoint)
+	}
+	if !meta.IsStatusConditionTrue(updated.Status.Conditions, airunwayv1alpha1.ConditionTypeReady) {
+		t.Error("expected Ready condition to be True")
+	}
+
+	var deploy appsv1.Deployment
+	if err := r.Get(ctx, key, &deploy); err != nil {
+		t.Fatalf("expected stub Deployment: %v", err)
+	}
+	if *deploy.Spec.Replicas != 2 {
+		t.Errorf("expected 2 replicas, got %d", *deploy.Spec.Replicas)
+	}
+	container := deploy.Spec.Template.Spec.Containers[0]
+	if container.Image != DefaultStubImage {
+		t.Errorf("expected stub image %s, got %s", DefaultStubImage, container.Image)
+	}
+	if container.Args[0] != "serve" || container.Args[1] != "--model=qwen" {
+		t.Errorf("unexpected args %v", container.Args)
+	}
+	if deploy.Spec.Template.Labels[airunwayv1alpha1.LabelModelDeployment] != "test" {
+		t.Errorf("expected pods to carry the model deployment label, got %v", deploy.Spec.Template.Labels)
+	}
+
+	var svc corev1.Service
+	if err := r.Get(ctx, key, &svc); err != nil {
+		t.Fatalf("expected stub Service: %v", err)
+	}
+	if !metav1.IsControlledBy(&svc, &updated) {
+		t.Error("expected Service to be owned by the ModelDeployment")
+	}
+}
+
+func TestReconcile_PhaseAnnotation(t *testing.T) {
+	md := newTestMD("test", "default")
+	md.Annotations = map[string]string{AnnotationPhase: string(airunwayv1alpha1.DeploymentPhaseFailed)}
+	r := newTestReconciler(md)
+	key := types.NamespacedName{Name: "test", Namespace: "default"}
+
+	reconcileTwice(t, r, key)
+
+	var updated airunwayv1alpha1.ModelDeployment
+	if err := r.Get(context.Background(), key, &updated); err != nil {
+		t.Fatalf("failed to get ModelDeployment: %v", err)
+	}
+	if updated.Status.Phase != airunwayv1alpha1.DeploymentPhaseFailed {
+		t.Errorf("expected phase Failed, got %s", updated.Status.Phase)
+	}
+	cond := meta.FindStatusCondition(updated.Status.Conditions, airunwayv1alpha1.ConditionTypeReady)
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != "DeploymentFailed" {
+		t.Errorf("expected Ready False/DeploymentFailed, got %+v", cond)
+	}
+}
+
+func TestReconcile_OtherProvider(t *testing.T) {
+	md := newTestMD("test", "default")
+	md.Status.Provider.Name = "llmd"
+	r := newTestReconciler(md)
+	key := types.NamespacedName{Name: "test", Namespace: "default"}
+
+	reconcileTwice(t, r, key)
+
+	var deploy appsv1.Deployment
+	if err := r.Get(context.Background(), key, &deploy); err == nil {
+		t.Error("expected no Deployment for a ModelDeployment owned by another provider")
+	}
+}
+
+func TestReconcile_MigratedToOtherProvider(t *testing.T) {
+	md := newTestMD("test", "default")
+	r := newTestReconciler(md)
+	key := types.NamespacedName{Name: "test", Namespace: "default"}
+	ctx := context.Background()
+	reconcileTwice(t, r, key)
+
+	// The deployment migrates to llmd, which keeps the stub serving until the gateway switched
+	var migrating airunwayv1alpha1.ModelDeployment
+	if err := r.Get(ctx, key, &migrating); err != nil {
+		t.Fatalf("failed to get ModelDeployment: %v", err)
+	}
+	migrating.Status.Provider = &airunwayv1alpha1.ProviderStatus{Name: "llmd"}
+	migrating.Status.Migration = &airunwayv1alpha1.MigrationStatus{From: ProviderName, To: "llmd", Phase: airunwayv1alpha1.MigrationPhaseVerifying}
+	if err := r.Status().Update(ctx, &migrating); err != nil {
+		t.Fatalf("failed to update status: %v", err)
+	}
+	reconcileTwice(t, r, key)
+	var deploy appsv1.Deployment
+	if err := r.Get(ctx, key, &deploy); err != nil {
+		t.Fatalf("expected the stub Deployment to be kept while verifying: %v", err)
+	}
+
+	// Once draining, the stub is deleted and the finalizer removed
+	migrating.Status.Migration.Phase = airunwayv1alpha1.MigrationPhaseDraining
+	if err := r.Status().Update(ctx, &migrating); err != nil {
+		t.Fatalf("failed to update status: %v", err)
+	}
+	reconcileTwice(t, r, key)
+	if err := r.Get(ctx, key, &deploy); !errors.IsNotFound(err) {
+		t.Errorf("expected the stub Deployment to be deleted, got %v", err)
+	}
+	var svc corev1.Service
+	if err := r.Get(ctx, key, &svc); !errors.IsNotFound(err) {
+		t.Errorf("expected the stub Service to be deleted, got %v", err)
+	}
+	var updated airunwayv1alpha1.ModelDeployment
+	if err := r.Get(ctx, key, &updated); err != nil || controllerutil.ContainsFinalizer(&updated, FinalizerName) {
+		t.Errorf("expected the finalizer to be removed, got %v (%v)", updated.Finalizers, err)
+	}
+}
+
+func TestReconcile_ExistingUnmanagedDeployment(t *testing.T) {
+	md := newTestMD("test", "default")
+	existing := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}}
+	r := newTestReconciler(md, existing)
+	key := types.NamespacedName{Name: "test", Namespace: "default"}
+
+	reconcileTwice(t, r, key)
+
+	var updated airunwayv1alpha1.ModelDeployment
+	if err := r.Get(context.Background(), key, &updated); err != nil {
+		t.Fatalf("failed to get ModelDeployment: %v", err)
+	}
+	if updated.Status.Phase != airunwayv1alpha1.DeploymentPhaseFailed {
+		t.Errorf("expected phase Failed for an unmanaged Deployment, got %s", updated.Status.Phase)
+	}
+}
+
+func TestReconcile_Deletion(t *testing.T) {
+	md := newTestMD("test", "default")
+	now := metav1.Now()
+	md.DeletionTimestamp = &now
+	md.Finalizers = []string{FinalizerName}
+	r := newTestReconciler(md)
+	key := types.NamespacedName{Name: "test", Namespace: "default"}
+
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+
+	// The fake client deletes the object once its last finalizer is removed
+	var updated airunwayv1alpha1.ModelDeployment
+	if err := r.Get(context.Background(), key, &updated); err == nil && controllerutil.ContainsFinalizer(&updated, FinalizerName) {
+		t.Error("expected finalizer to be removed")
+	}
+}
+
+func TestReplicas(t *testing.T) {
+	md := newTestMD("test", "default")
+	if got := Replicas(md); got != 1 {
+		t.Errorf("expected 1 replica by default, got %d", got)
+	}
+	md.Spec.Serving = &airunwayv1alpha1.ServingSpec{Mode: airunwayv1alpha1.ServingModeDisaggregated}
+	md.Spec.Scaling = &airunwayv1alpha1.ScalingSpec{
+		Prefill: &airunwayv1alpha1.ComponentScalingSpec{Replicas: 1},
+		Decode:  &airunwayv1alpha1.ComponentScalingSpec{Replicas: 3},
+	}
+	if got := Replicas(md); got != 3 {
+		t.Errorf("expected decode replicas for disaggregated mode, got %d", got)
+	}
+}
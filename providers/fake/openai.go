@@ -0,0 +1,177 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	// maxRequestBytes caps request bodies accepted by the stub server
+	maxRequestBytes = 1 << 20
+
+	// stubOwner is reported as the owner of the served model
+	stubOwner = "airunway-fake"
+)
+
+// OpenAIServer is a stub OpenAI-compatible server. It answers every completion with a
+// fixed reply so gateway routing and client wiring can be tested without a real engine.
+type OpenAIServer struct {
+	model string
+	mux   *http.ServeMux
+	now   func() time.Time
+}
+
+// NewOpenAIServer creates a stub server that serves the given model name
+func NewOpenAIServer(model string) *OpenAIServer {
+	s := &OpenAIServer{model: model, mux: http.NewServeMux(), now: time.Now}
+	s.mux.HandleFunc("GET /health", s.handleHealth)
+	s.mux.HandleFunc("GET /v1/models", s.handleModels)
+	s.mux.HandleFunc("POST /v1/chat/completions", s.handleChatCompletions)
+	s.mux.HandleFunc("POST /v1/completions", s.handleCompletions)
+	return s
+}
+
+// ServeHTTP implements http.Handler
+func (s *OpenAIServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type completionRequest struct {
+	Model    string        `json:"model"`
+	Messages []chatMessage `json:"messages,omitempty"`
+	Prompt   any           `json:"prompt,omitempty"`
+	Stream   bool          `json:"stream,omitempty"`
+}
+
+type usage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+func (s *OpenAIServer) handleHealth(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *OpenAIServer) handleModels(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"object": "list",
+		"data": []map[string]any{{
+			"id":       s.model,
+			"object":   "model",
+			"created":  s.now().Unix(),
+			"owned_by": stubOwner,
+		}},
+	})
+}
+
+func (s *OpenAIServer) handleChatCompletions(w http.ResponseWriter, r *http.Request) {
+	req, ok := s.decodeRequest(w, r)
+	if !ok {
+		return
+	}
+	if len(req.Messages) == 0 {
+		writeError(w, http.StatusBadRequest, "messages must not be empty")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"id":      fmt.Sprintf("chatcmpl-fake-%d", s.now().UnixNano()),
+		"object":  "chat.completion",
+		"created": s.now().Unix(),
+		"model":   s.model,
+		"choices": []map[string]any{{
+			"index":         0,
+			"message":       chatMessage{Role: "assistant", Content: s.reply()},
+			"finish_reason": "stop",
+		}},
+		"usage": stubUsage(),
+	})
+}
+
+func (s *OpenAIServer) handleCompletions(w http.ResponseWriter, r *http.Request) {
+	req, ok := s.decodeRequest(w, r)
+	if !ok {
+		return
+	}
+	if req.Prompt == nil {
+		writeError(w, http.StatusBadRequest, "prompt is required")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"id":      fmt.Sprintf("cmpl-fake-%d", s.now().UnixNano()),
+		"object":  "text_completion",
+		"created": s.now().Unix(),
+		"model":   s.model,
+		"choices": []map[string]any{{
+			"index":         0,
+			"text":          s.reply(),
+			"finish_reason": "stop",
+		}},
+		"usage": stubUsage(),
+	})
+}
+
+// decodeRequest parses a completion request and checks it targets the served model.
+// It writes an OpenAI-style error response and returns false when the request is rejected.
+func (s *OpenAIServer) decodeRequest(w http.ResponseWriter, r *http.Request) (*completionRequest, bool) {
+	var req completionRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes)).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return nil, false
+	}
+	if req.Stream {
+		writeError(w, http.StatusBadRequest, "streaming is not supported by the fake provider")
+		return nil, false
+	}
+	if req.Model != s.model {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("model %q not found", req.Model))
+		return nil, false
+	}
+	return &req, true
+}
+
+func (s *OpenAIServer) reply() string {
+	return fmt.Sprintf("This is a stub response from %s.", s.model)
+}
+
+func stubUsage() usage {
+	return usage{PromptTokens: 1, CompletionTokens: 1, TotalTokens: 2}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]any{
+		"error": map[string]any{
+			"message": message,
+			"type":    "invalid_request_error",
+		},
+	})
+}
@@ -0,0 +1,87 @@
+package fake
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func doRequest(t *testing.T, s *OpenAIServer, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestOpenAIServer_Models(t *testing.T) {
+	s := NewOpenAIServer("qwen")
+	rec := doRequest(t, s, http.MethodGet, "/v1/models", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	var resp struct {
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Data) != 1 || resp.Data[0].ID != "qwen" {
+		t.Errorf("unexpected models %+v", resp.Data)
+	}
+}
+
+func TestOpenAIServer_ChatCompletions(t *testing.T) {
+	s := NewOpenAIServer("qwen")
+	rec := doRequest(t, s, http.MethodPost, "/v1/chat/completions",
+		`{"model":"qwen","messages":[{"role":"user","content":"hi"}]}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp struct {
+		Model   string `json:"model"`
+		Choices []struct {
+			Message chatMessage `json:"message"`
+		} `json:"choices"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Model != "qwen" || len(resp.Choices) != 1 || resp.Choices[0].Message.Role != "assistant" {
+		t.Errorf("unexpected response %+v", resp)
+	}
+}
+
+func TestOpenAIServer_Errors(t *testing.T) {
+	s := NewOpenAIServer("qwen")
+	tests := []struct {
+		name string
+		path string
+		body string
+		code int
+	}{
+		{"unknown model", "/v1/chat/completions", `{"model":"other","messages":[{"role":"user","content":"hi"}]}`, http.StatusNotFound},
+		{"invalid json", "/v1/chat/completions", `{`, http.StatusBadRequest},
+		{"no messages", "/v1/chat/completions", `{"model":"qwen"}`, http.StatusBadRequest},
+		{"streaming", "/v1/completions", `{"model":"qwen","prompt":"hi","stream":true}`, http.StatusBadRequest},
+		{"no prompt", "/v1/completions", `{"model":"qwen"}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if rec := doRequest(t, s, http.MethodPost, tt.path, tt.body); rec.Code != tt.code {
+				t.Errorf("expected %d, got %d", tt.code, rec.Code)
+			}
+		})
+	}
+
+	if rec := doRequest(t, s, http.MethodPost, "/v1/completions", `{"model":"qwen","prompt":"hi"}`); rec.Code != http.StatusOK {
+		t.Errorf("expected completions to succeed, got %d", rec.Code)
+	}
+	if rec := doRequest(t, s, http.MethodGet, "/health", ""); rec.Code != http.StatusOK {
+		t.Errorf("expected health to succeed, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"strconv"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/pkg/provider"
+)
+
+const (
+	// DefaultStubImage is the image that runs the stub OpenAI server; it is the provider image itself
+	DefaultStubImage = "ghcr.io/kaito-project/airunway/fake-provider:latest"
+
+	// DefaultStubPort is the port the stub OpenAI server listens on, matching vLLM's default
+	DefaultStubPort = int32(8000)
+)
+
+// Transformer builds the stub Deployment and Service for a ModelDeployment
+type Transformer struct {
+	// StubImage is the container image running the stub OpenAI server
+	StubImage string
+}
+
+// NewTransformer creates a new transformer that runs the given stub image
+func NewTransformer(stubImage string) *Transformer {
+	if stubImage == "" {
+		stubImage = DefaultStubImage
+	}
+	return &Transformer{StubImage: stubImage}
+}
+
+// ServedModelName returns the model name the stub server answers to
+func ServedModelName(md *airunwayv1alpha1.ModelDeployment) string {
+	if md.Spec.Model.ServedName != "" {
+		return md.Spec.Model.ServedName
+	}
+	return md.Spec.Model.ID
+}
+
+// Replicas returns the number of stub replicas; disaggregated deployments are served
+// by a single pool sized like the decode component
+func Replicas(md *airunwayv1alpha1.ModelDeployment) int32 {
+	if md.Spec.Scaling == nil {
+		return 1
+	}
+	if md.Spec.Scaling.Decode != nil && md.Spec.Scaling.Decode.Replicas > 0 {
+		return md.Spec.Scaling.Decode.Replicas
+	}
+	if md.Spec.Scaling.Replicas > 0 {
+		return md.Spec.Scaling.Replicas
+	}
+	return 1
+}
+
+// buildLabels returns the labels applied to the Deployment, Service and stub pods
+func (t *Transformer) buildLabels(md *airunwayv1alpha1.ModelDeployment) map[string]string {
+	return map[string]string{
+		airunwayv1alpha1.LabelManagedBy:       airunwayv1alpha1.ManagedByAIRunway,
+		airunwayv1alpha1.LabelModelDeployment: md.Name,
+		airunwayv1alpha1.LabelEngineType:      string(md.ResolvedEngineType()),
+	}
+}
+
+// selectorLabels returns the labels the Deployment and Service select stub pods by
+func selectorLabels(md *airunwayv1alpha1.ModelDeployment) map[string]string {
+	return map[string]string{
+		airunwayv1alpha1.LabelDeployment: md.Name,
+		"app":                            "fake-model-server",
+	}
+}
+
+// MutateDeployment sets the desired state of the stub Deployment, preserving fields
+// defaulted by the API server
+func (t *Transformer) MutateDeployment(md *airunwayv1alpha1.ModelDeployment, deploy *appsv1.Deployment) {
+	labels := t.buildLabels(md)
+	if deploy.Labels == nil {
+		deploy.Labels = map[string]string{}
+	}
+	for k, v := range labels {
+		deploy.Labels[k] = v
+	}
+	provider.ApplyPropagatedMetadataToObject(deploy, md)
+
+	podLabels := selectorLabels(md)
+	for k, v := range labels {
+		podLabels[k] = v
+	}
+	for k, v := range md.IdentityPodLabels() {
+		podLabels[k] = v
+	}
+
+	replicas := Replicas(md)
+	deploy.Spec.Replicas = &replicas
+	deploy.Spec.Selector = &metav1.LabelSelector{MatchLabels: selectorLabels(md)}
+	deploy.Spec.Template.Labels = podLabels
+	provider.ApplyPropagatedMetadataToObject(&deploy.Spec.Template, md)
+
+	nonRoot := true
+	readOnly := true
+	noEscalation := false
+	deploy.Spec.Template.Spec.ServiceAccountName = md.ServiceAccountName()
+	deploy.Spec.Template.Spec.SecurityContext = &corev1.PodSecurityContext{
+		RunAsNonRoot:   &nonRoot,
+		SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+	}
+	deploy.Spec.Template.Spec.Containers = []corev1.Container{{
+		Name:  "model-server",
+		Image: t.StubImage,
+		Args: []string{
+			"serve",
+			"--model=" + ServedModelName(md),
+			"--port=" + strconv.Itoa(int(DefaultStubPort)),
+		},
+		Ports: []corev1.ContainerPort{{
+			Name:          "http",
+			ContainerPort: DefaultStubPort,
+			Protocol:      corev1.ProtocolTCP,
+		}},
+		ReadinessProbe: &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{Path: "/health", Port: intstr.FromInt32(DefaultStubPort)},
+			},
+			PeriodSeconds: 5,
+		},
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("10m"),
+				corev1.ResourceMemory: resource.MustParse("16Mi"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("64Mi"),
+			},
+		},
+		SecurityContext: &corev1.SecurityContext{
+			AllowPrivilegeEscalation: &noEscalation,
+			ReadOnlyRootFilesystem:   &readOnly,
+			Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+		},
+	}}
+}
+
+// MutateService sets the desired state of the stub Service
+func (t *Transformer) MutateService(md *airunwayv1alpha1.ModelDeployment, svc *corev1.Service) {
+	if svc.Labels == nil {
+		svc.Labels = map[string]string{}
+	}
+	for k, v := range t.buildLabels(md) {
+		svc.Labels[k] = v
+	}
+	provider.ApplyPropagatedMetadataToObject(svc, md)
+	svc.Spec.Type = corev1.ServiceTypeClusterIP
+	svc.Spec.Selector = selectorLabels(md)
+	svc.Spec.Ports = []corev1.ServicePort{{
+		Name:       "http",
+		Port:       DefaultStubPort,
+		TargetPort: intstr.FromInt32(DefaultStubPort),
+		Protocol:   corev1.ProtocolTCP,
+	}}
+	provider.ApplyIPFamiliesToService(&svc.Spec, md)
+}
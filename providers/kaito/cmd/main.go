@@ -26,6 +26,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/discovery"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
@@ -35,6 +36,7 @@ import (
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
 	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/pkg/provider"
 	kaito "github.com/kaito-project/airunway/providers/kaito"
 )
 
@@ -108,15 +110,26 @@ func main() {
 		os.Exit(1)
 	}
 
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create discovery client")
+		os.Exit(1)
+	}
+
 	// Set up the KAITO provider reconciler
 	reconciler := kaito.NewKaitoProviderReconciler(mgr.GetClient(), mgr.GetScheme())
+	// Emit Workspaces in the API version the installed KAITO operator serves
+	reconciler.Transformer = kaito.NewTransformer(discoveryClient)
+	reconciler.Recorder = mgr.GetEventRecorder(kaito.FieldManager)
+	reconciler.Events = provider.NewEventMirror(mgr.GetAPIReader(), reconciler.Recorder)
+	reconciler.Overrides = provider.NewOverridesValidator(discoveryClient)
 	if err := reconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "KaitoProvider")
 		os.Exit(1)
 	}
 
 	// Set up the ProviderConfigManager for self-registration and heartbeat
-	configManager := kaito.NewProviderConfigManager(mgr.GetClient())
+	configManager := kaito.NewProviderConfigManager(mgr.GetClient(), discoveryClient)
 	if err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
 		setupLog.Info("registering KAITO provider config")
 		if err := configManager.Register(ctx); err != nil {
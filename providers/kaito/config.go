@@ -25,10 +25,12 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/pkg/provider"
 )
 
 const (
@@ -47,14 +49,36 @@ const (
 
 // ProviderConfigManager handles registration and heartbeat for the KAITO provider
 type ProviderConfigManager struct {
-	client client.Client
+	client          client.Client
+	discoveryClient discovery.DiscoveryInterface
 }
 
-// NewProviderConfigManager creates a new provider config manager
-func NewProviderConfigManager(c client.Client) *ProviderConfigManager {
-	return &ProviderConfigManager{
+// NewProviderConfigManager creates a new provider config manager. With a discovery client
+// the Workspace CRD is probed with fresh discovery results instead of the cached RESTMapper.
+func NewProviderConfigManager(c client.Client, discoveryClients ...discovery.DiscoveryInterface) *ProviderConfigManager {
+	manager := &ProviderConfigManager{
 		client: c,
 	}
+	if len(discoveryClients) > 0 {
+		manager.discoveryClient = discoveryClients[0]
+	}
+	return manager
+}
+
+// SupportedFields lists the ModelDeployment spec fields the transformer carries into the
+// KAITO workspace. It is published in status.supportedFields so the core controller can
+// warn about fields this provider drops.
+var SupportedFields = []string{
+	provider.FieldImage,
+	provider.FieldEnv,
+	provider.FieldPodTemplate,
+	provider.FieldHuggingFaceToken,
+	provider.FieldSecretsRollout,
+	provider.FieldIdentity,
+	provider.FieldMultinode,
+	provider.FieldNodeSelector,
+	provider.FieldCPUPinning,
+	provider.FieldEngineArgs,
 }
 
 // GetProviderConfigSpec returns the InferenceProviderConfigSpec for KAITO
@@ -149,7 +173,11 @@ func (m *ProviderConfigManager) Register(ctx context.Context) error {
 	} else if err != nil {
 		return fmt.Errorf("failed to get InferenceProviderConfig: %w", err)
 	} else {
+		// namespaceSelector and imagePolicy are set by cluster admins, not the provider
+		namespaceSelector, imagePolicy := existing.Spec.NamespaceSelector, existing.Spec.ImagePolicy
 		existing.Spec = config.Spec
+		existing.Spec.NamespaceSelector = namespaceSelector
+		existing.Spec.ImagePolicy = imagePolicy
 		if existing.Annotations == nil {
 			existing.Annotations = make(map[string]string)
 		}
@@ -174,7 +202,8 @@ func (m *ProviderConfigManager) Register(ctx context.Context) error {
 	return statusErr
 }
 
-// UpdateStatus updates the status of the InferenceProviderConfig
+// UpdateStatus updates the status of the InferenceProviderConfig. The provider is reported
+// not ready when the Workspace CRD is missing.
 func (m *ProviderConfigManager) UpdateStatus(ctx context.Context, ready bool) error {
 	config := &airunwayv1alpha1.InferenceProviderConfig{}
 	if err := m.client.Get(ctx, types.NamespacedName{Name: ProviderConfigName}, config); err != nil {
@@ -182,12 +211,25 @@ func (m *ProviderConfigManager) UpdateStatus(ctx context.Context, ready bool) er
 	}
 
 	now := metav1.Now()
-	config.Status = airunwayv1alpha1.InferenceProviderConfigStatus{
-		Ready:              ready,
-		Version:            ProviderVersion,
-		LastHeartbeat:      &now,
-		UpstreamCRDVersion: "kaito.sh/v1beta1",
+	status := airunwayv1alpha1.InferenceProviderConfigStatus{
+		Ready:                   ready,
+		Version:                 ProviderVersion,
+		LastHeartbeat:           &now,
+		LastHeartbeatTime:       &now,
+		ObservedProviderVersion: ProviderVersion,
+		UpstreamCRDVersion:      "kaito.sh/v1beta1",
+		SupportedFields:         SupportedFields,
+		ObservedGeneration:      config.Status.ObservedGeneration,
+		Conditions:              config.Status.Conditions,
 	}
+	missing, probeErr := m.missingUpstreamCRDs()
+	if !provider.SetUpstreamInstalledCondition(&status, missing, probeErr) {
+		// Workspaces would be rejected at apply time, so take the provider out of selection
+		// until the CRD is installed
+		log.FromContext(ctx).Info("Upstream CRDs not available, reporting not ready", "missing", missing, "error", probeErr)
+		status.Ready = false
+	}
+	config.Status = status
 
 	if err := m.client.Status().Update(ctx, config); err != nil {
 		return fmt.Errorf("failed to update InferenceProviderConfig status: %w", err)
@@ -196,6 +238,16 @@ func (m *ProviderConfigManager) UpdateStatus(ctx context.Context, ready bool) er
 	return nil
 }
 
+// missingUpstreamCRDs probes the cluster for the upstream CRDs the provider creates
+// resources of
+func (m *ProviderConfigManager) missingUpstreamCRDs() ([]provider.UpstreamCRD, error) {
+	return provider.MissingUpstreamCRDs(m.discoveryClient, m.client.RESTMapper(), provider.UpstreamCRD{
+		Group:    KaitoAPIGroup,
+		Resource: WorkspaceResource,
+		Kind:     WorkspaceKind,
+	})
+}
+
 // StartHeartbeat starts a goroutine that periodically updates the provider heartbeat
 func (m *ProviderConfigManager) StartHeartbeat(ctx context.Context) {
 	logger := log.FromContext(ctx)
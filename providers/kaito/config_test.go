@@ -2,11 +2,17 @@ package kaito
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/pkg/provider"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -106,6 +112,9 @@ func TestRegisterExisting(t *testing.T) {
 
 	existing := &airunwayv1alpha1.InferenceProviderConfig{
 		ObjectMeta: metav1.ObjectMeta{Name: ProviderConfigName},
+		Spec: airunwayv1alpha1.InferenceProviderConfigSpec{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "ml-prod"}},
+		},
 	}
 
 	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing).WithStatusSubresource(existing).Build()
@@ -115,6 +124,69 @@ func TestRegisterExisting(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
+
+	// The admin-managed namespaceSelector survives re-registration
+	updated := &airunwayv1alpha1.InferenceProviderConfig{}
+	if err := c.Get(context.Background(), client.ObjectKey{Name: ProviderConfigName}, updated); err != nil {
+		t.Fatalf("failed to get config: %v", err)
+	}
+	if updated.Spec.NamespaceSelector == nil || updated.Spec.NamespaceSelector.MatchLabels["team"] != "ml-prod" {
+		t.Errorf("expected namespaceSelector to be preserved, got %+v", updated.Spec.NamespaceSelector)
+	}
+	if updated.Spec.Capabilities == nil {
+		t.Error("expected capabilities to be registered")
+	}
+}
+
+func TestUpdateStatusProbesUpstreamCRDs(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = airunwayv1alpha1.AddToScheme(scheme)
+
+	existing := &airunwayv1alpha1.InferenceProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: ProviderConfigName},
+		Spec:       GetProviderConfigSpec(),
+	}
+	discoveryClient := &fakediscovery.FakeDiscovery{
+		Fake: &k8stesting.Fake{},
+	}
+	discoveryClient.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: KaitoAPIGroup + "/" + KaitoAPIVersion,
+			APIResources: []metav1.APIResource{
+				{Name: WorkspaceResource},
+			},
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing).WithStatusSubresource(existing).Build()
+	mgr := NewProviderConfigManager(c, discoveryClient)
+	updated := &airunwayv1alpha1.InferenceProviderConfig{}
+
+	if err := mgr.UpdateStatus(context.Background(), true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.Get(context.Background(), client.ObjectKey{Name: ProviderConfigName}, updated); err != nil {
+		t.Fatalf("failed to get updated provider config: %v", err)
+	}
+	if !updated.Status.Ready {
+		t.Fatal("expected provider to be ready when the upstream CRD is installed")
+	}
+
+	// Removal is detected on the next heartbeat with a reason naming the CRD
+	discoveryClient.Resources = []*metav1.APIResourceList{}
+	if err := mgr.UpdateStatus(context.Background(), true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.Get(context.Background(), client.ObjectKey{Name: ProviderConfigName}, updated); err != nil {
+		t.Fatalf("failed to get updated provider config: %v", err)
+	}
+	if updated.Status.Ready {
+		t.Error("expected provider to be not ready when the upstream CRD is missing")
+	}
+	cond := meta.FindStatusCondition(updated.Status.Conditions, airunwayv1alpha1.ConditionTypeUpstreamInstalled)
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != provider.ReasonUpstreamCRDsMissing || !strings.Contains(cond.Message, WorkspaceResource) {
+		t.Errorf("expected %s condition naming the CRD, got %+v", provider.ReasonUpstreamCRDsMissing, cond)
+	}
 }
 
 func TestUpdateStatus(t *testing.T) {
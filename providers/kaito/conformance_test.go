@@ -0,0 +1,57 @@
+package kaito
+
+import (
+	"testing"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/providers/conformance"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// conformanceStatus adapts StatusTranslator to the conformance suite
+type conformanceStatus struct {
+	*StatusTranslator
+}
+
+func (s conformanceStatus) TranslateStatus(upstream *unstructured.Unstructured) (*conformance.StatusResult, error) {
+	result, err := s.StatusTranslator.TranslateStatus(upstream)
+	if err != nil {
+		return nil, err
+	}
+	return (*conformance.StatusResult)(result), nil
+}
+
+func TestConformance(t *testing.T) {
+	conformance.Suite{
+		Transformer:      NewTransformer(),
+		StatusTranslator: conformanceStatus{NewStatusTranslator()},
+		StatusCases: []conformance.StatusCase{
+			{
+				Name:   "ready",
+				Status: map[string]interface{}{"state": "Ready"},
+				Phase:  airunwayv1alpha1.DeploymentPhaseRunning,
+			},
+			{
+				Name: "resources ready",
+				Status: map[string]interface{}{
+					"conditions": []interface{}{
+						map[string]interface{}{"type": conditionResourceReady, "status": "True"},
+					},
+				},
+				Phase: airunwayv1alpha1.DeploymentPhaseDeploying,
+			},
+			{
+				Name: "workspace failed",
+				Status: map[string]interface{}{
+					"conditions": []interface{}{
+						map[string]interface{}{"type": conditionWorkspaceSucceeded, "status": "False", "message": "preset not found"},
+					},
+				},
+				Phase: airunwayv1alpha1.DeploymentPhaseFailed,
+			},
+		},
+		Skip: map[string]string{
+			conformance.CheckGPUCount: "KAITO sizes GPUs through the Workspace instanceType",
+		},
+	}.Run(t)
+}
@@ -31,6 +31,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -38,6 +39,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/pkg/provider"
 )
 
 const (
@@ -66,6 +68,16 @@ type KaitoProviderReconciler struct {
 	Scheme           *runtime.Scheme
 	Transformer      *Transformer
 	StatusTranslator *StatusTranslator
+
+	// Recorder emits ResourceUpdated events on ModelDeployments. When nil, no events are emitted.
+	Recorder events.EventRecorder
+
+	// Events mirrors upstream Warning events onto ModelDeployments. When nil, none are mirrored.
+	Events *provider.EventMirror
+
+	// Overrides checks spec.provider.overrides against the published Workspace schema. When nil,
+	// overrides are applied unchecked.
+	Overrides *provider.OverridesValidator
 }
 
 // NewKaitoProviderReconciler creates a new KAITO provider reconciler
@@ -84,6 +96,7 @@ func NewKaitoProviderReconciler(client client.Client, scheme *runtime.Scheme) *K
 // +kubebuilder:rbac:groups=airunway.ai,resources=inferenceproviderconfigs,verbs=get;list;watch;create;update;patch
 // +kubebuilder:rbac:groups=airunway.ai,resources=inferenceproviderconfigs/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=kaito.sh,resources=workspaces,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=events,verbs=list
 
 // Reconcile handles the reconciliation loop for ModelDeployments assigned to the KAITO provider
 func (r *KaitoProviderReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -97,20 +110,33 @@ func (r *KaitoProviderReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 
 	// Only process if this provider is selected
 	if md.Status.Provider == nil || md.Status.Provider.Name != ProviderName {
+		// Delete the resources of a deployment migrated to another provider, or deleted
+		// while migrating away from this one
+		if provider.Released(&md, ProviderName, FinalizerName) {
+			return r.handleDeletion(ctx, &md)
+		}
 		return ctrl.Result{}, nil
 	}
 
 	logger.Info("Reconciling ModelDeployment for KAITO provider", "name", md.Name, "namespace", md.Namespace)
 
-	// Check for pause annotation
-	if md.Annotations != nil && md.Annotations["airunway.ai/reconcile-paused"] == "true" {
+	// Handle deletion, even when paused, so the finalizer never blocks removal
+	if !md.DeletionTimestamp.IsZero() {
+		return r.handleDeletion(ctx, &md)
+	}
+
+	// Leave provider resources untouched while paused; the core controller
+	// reports the PausedReconciliation condition
+	if md.IsPaused() {
 		logger.Info("Reconciliation paused", "name", md.Name)
 		return ctrl.Result{}, nil
 	}
 
-	// Handle deletion
-	if !md.DeletionTimestamp.IsZero() {
-		return r.handleDeletion(ctx, &md)
+	// Create nothing until Kueue admits the deployment; the core controller moves it out of
+	// the Queued phase once admitted
+	if md.IsQueued() {
+		logger.Info("Waiting for Kueue admission", "name", md.Name)
+		return ctrl.Result{}, nil
 	}
 
 	// Add finalizer if not present
@@ -133,7 +159,10 @@ func (r *KaitoProviderReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	r.setCondition(&md, airunwayv1alpha1.ConditionTypeProviderCompatible, metav1.ConditionTrue, "CompatibilityVerified", "Configuration compatible with KAITO")
 
 	// Transform ModelDeployment to KAITO Workspace
-	resources, err := r.Transformer.Transform(ctx, &md)
+	result, err := r.Transformer.Transform(ctx, &md)
+	if err == nil {
+		err = result.Validate()
+	}
 	if err != nil {
 		logger.Error(err, "Failed to transform ModelDeployment", "name", md.Name)
 		r.setCondition(&md, airunwayv1alpha1.ConditionTypeResourceCreated, metav1.ConditionFalse, "TransformFailed", err.Error())
@@ -142,26 +171,52 @@ func (r *KaitoProviderReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		return ctrl.Result{}, r.Status().Update(ctx, &md)
 	}
 
-	// Create or update the Workspace
-	for _, resource := range resources {
-		if err := r.createOrUpdateResource(ctx, resource, &md); err != nil {
-			// API conflict errors (stale resourceVersion) are transient — requeue to retry
-			if errors.IsConflict(err) {
-				logger.Info("Resource version conflict, requeuing", "name", resource.GetName(), "kind", resource.GetKind())
-				return ctrl.Result{Requeue: true}, nil
-			}
+	// Check the images of the rendered resources, which include component, sidecar and
+	// override images, and pin signed images to their verified digest
+	violation, err := provider.EnforceImagePolicy(ctx, r.Client, ProviderConfigName, result.Resources)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if violation != "" {
+		r.setCondition(&md, airunwayv1alpha1.ConditionTypeResourceCreated, metav1.ConditionFalse, provider.ReasonImagePolicyViolation, violation)
+		md.Status.Phase = airunwayv1alpha1.DeploymentPhaseFailed
+		md.Status.Message = violation
+		return ctrl.Result{RequeueAfter: provider.ImagePolicyRecheckInterval}, r.Status().Update(ctx, &md)
+	}
 
-			logger.Error(err, "Failed to create/update resource", "name", resource.GetName(), "kind", resource.GetKind())
-			reason := "CreateFailed"
-			if isResourceConflict(err) {
-				reason = "ResourceConflict"
-				r.setCondition(&md, airunwayv1alpha1.ConditionTypeReady, metav1.ConditionFalse, "ResourceConflict", err.Error())
-			}
-			r.setCondition(&md, airunwayv1alpha1.ConditionTypeResourceCreated, metav1.ConditionFalse, reason, err.Error())
-			md.Status.Phase = airunwayv1alpha1.DeploymentPhaseFailed
-			md.Status.Message = fmt.Sprintf("Failed to create Workspace: %s", err.Error())
-			return ctrl.Result{}, r.Status().Update(ctx, &md)
+	// Report overrides the Workspace schema does not declare with their field paths, rather
+	// than as an API server rejection of the merged object
+	if err := r.Overrides.Check(&md, result.Primary()); err != nil {
+		logger.Error(err, "Invalid provider overrides", "name", md.Name)
+		r.setCondition(&md, airunwayv1alpha1.ConditionTypeResourceCreated, metav1.ConditionFalse, provider.ReasonInvalidOverrides, err.Error())
+		md.Status.Phase = airunwayv1alpha1.DeploymentPhaseFailed
+		md.Status.Message = err.Error()
+		return ctrl.Result{}, r.Status().Update(ctx, &md)
+	}
+
+	// Create or update the Workspace after the resources it depends on. Resources
+	// created by this apply are deleted again if a later one fails.
+	err = provider.ApplyResources(ctx, r.Client, result.Resources, func(ctx context.Context, resource *unstructured.Unstructured) (bool, error) {
+		return r.createOrUpdateResource(ctx, resource, &md)
+	})
+	md.Status.Provider.PartialApply = provider.PartialApply(err)
+	if err != nil {
+		// API conflict errors (stale resourceVersion) are transient — requeue to retry
+		if errors.IsConflict(err) {
+			logger.Info("Resource version conflict, requeuing", "name", md.Name)
+			return ctrl.Result{Requeue: true}, nil
 		}
+
+		logger.Error(err, "Failed to create/update resources", "name", md.Name)
+		reason := "CreateFailed"
+		if isResourceConflict(err) {
+			reason = "ResourceConflict"
+			r.setCondition(&md, airunwayv1alpha1.ConditionTypeReady, metav1.ConditionFalse, "ResourceConflict", err.Error())
+		}
+		r.setCondition(&md, airunwayv1alpha1.ConditionTypeResourceCreated, metav1.ConditionFalse, reason, err.Error())
+		md.Status.Phase = airunwayv1alpha1.DeploymentPhaseFailed
+		md.Status.Message = fmt.Sprintf("Failed to create Workspace: %s", err.Error())
+		return ctrl.Result{}, r.Status().Update(ctx, &md)
 	}
 
 	r.setCondition(&md, airunwayv1alpha1.ConditionTypeResourceCreated, metav1.ConditionTrue, "ResourceCreated", "Workspace created successfully")
@@ -171,12 +226,17 @@ func (r *KaitoProviderReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	md.Status.Provider.ResourceKind = WorkspaceKind
 
 	// Sync status from upstream resource
-	if len(resources) > 0 {
-		if err := r.syncStatus(ctx, &md, resources[0]); err != nil {
+	if primary := result.Primary(); primary != nil {
+		if err := r.syncStatus(ctx, &md, primary); err != nil {
 			logger.Error(err, "Failed to sync status", "name", md.Name)
 		}
 	}
 
+	// Mirror upstream warnings, e.g. on pods users cannot read, onto the ModelDeployment
+	if err := r.Events.Mirror(ctx, &md); err != nil {
+		logger.Error(err, "Failed to mirror upstream events", "name", md.Name)
+	}
+
 	// Set phase to Deploying if not already Running or Failed
 	if md.Status.Phase != airunwayv1alpha1.DeploymentPhaseRunning &&
 		md.Status.Phase != airunwayv1alpha1.DeploymentPhaseFailed {
@@ -246,11 +306,11 @@ func verifyOwnerReference(existing *unstructured.Unstructured, mdUID types.UID)
 }
 
 // createOrUpdateResource creates or updates an unstructured resource
-func (r *KaitoProviderReconciler) createOrUpdateResource(ctx context.Context, resource *unstructured.Unstructured, md *airunwayv1alpha1.ModelDeployment) error {
+func (r *KaitoProviderReconciler) createOrUpdateResource(ctx context.Context, resource *unstructured.Unstructured, md *airunwayv1alpha1.ModelDeployment) (bool, error) {
 	logger := log.FromContext(ctx)
 
 	if err := setLastAppliedManagedFields(resource); err != nil {
-		return err
+		return false, err
 	}
 
 	// Check if resource exists
@@ -265,15 +325,15 @@ func (r *KaitoProviderReconciler) createOrUpdateResource(ctx context.Context, re
 	if errors.IsNotFound(err) {
 		// Create new resource
 		logger.Info("Creating resource", "kind", resource.GetKind(), "name", resource.GetName())
-		return r.Create(ctx, resource)
+		return true, r.Create(ctx, resource)
 	}
 	if err != nil {
-		return fmt.Errorf("failed to get existing resource: %w", err)
+		return false, fmt.Errorf("failed to get existing resource: %w", err)
 	}
 
 	// Verify ownership before updating
 	if err := verifyOwnerReference(existing, md.UID); err != nil {
-		return err
+		return false, err
 	}
 
 	// Update existing resource if managed fields or desired metadata have changed. Compare only the fields we manage.
@@ -289,10 +349,16 @@ func (r *KaitoProviderReconciler) createOrUpdateResource(ctx context.Context, re
 	metadataMatches := desiredMetadataMatches(resource, existing, lastAppliedLabels, lastAppliedAnnotations)
 	if !resourceMatches || !inferenceMatches || !metadataMatches {
 		logger.Info("Updating resource", "kind", resource.GetKind(), "name", resource.GetName())
-		return r.updateManagedWorkspaceFields(ctx, existing, resource, lastAppliedResource, lastAppliedInference, lastAppliedLabels, lastAppliedAnnotations)
+		updated, err := r.updateManagedWorkspaceFields(ctx, existing, resource, lastAppliedResource, lastAppliedInference, lastAppliedLabels, lastAppliedAnnotations)
+		if err != nil {
+			return false, err
+		}
+		if err := provider.RecordResourceChange(md, r.Recorder, existing, updated, "resource", "inference"); err != nil {
+			logger.Error(err, "Failed to record resource change", "kind", resource.GetKind(), "name", resource.GetName())
+		}
 	}
 
-	return nil
+	return false, nil
 }
 
 func desiredMetadataMatches(desired, existing *unstructured.Unstructured, lastAppliedLabels, lastAppliedAnnotations map[string]string) bool {
@@ -321,19 +387,22 @@ func managedStringMapMatches(desired, existing, lastApplied map[string]string) b
 	return true
 }
 
-func (r *KaitoProviderReconciler) updateManagedWorkspaceFields(ctx context.Context, existing, desired *unstructured.Unstructured, lastAppliedResource, lastAppliedInference map[string]interface{}, lastAppliedLabels, lastAppliedAnnotations map[string]string) error {
+func (r *KaitoProviderReconciler) updateManagedWorkspaceFields(ctx context.Context, existing, desired *unstructured.Unstructured, lastAppliedResource, lastAppliedInference map[string]interface{}, lastAppliedLabels, lastAppliedAnnotations map[string]string) (*unstructured.Unstructured, error) {
 	base := existing.DeepCopy()
 	updated := existing.DeepCopy()
 
 	if err := mergeManagedTopLevelMap(updated, desired, lastAppliedResource, "resource"); err != nil {
-		return err
+		return nil, err
 	}
 	if err := mergeManagedTopLevelMap(updated, desired, lastAppliedInference, "inference"); err != nil {
-		return err
+		return nil, err
 	}
 	mergeManagedMetadata(updated, desired, lastAppliedLabels, lastAppliedAnnotations)
 
-	return r.Patch(ctx, updated, client.MergeFrom(base))
+	if err := r.Patch(ctx, updated, client.MergeFrom(base)); err != nil {
+		return nil, err
+	}
+	return updated, nil
 }
 
 func mergeManagedTopLevelMap(target, desired *unstructured.Unstructured, lastApplied map[string]interface{}, field string) error {
@@ -663,7 +732,7 @@ func (r *KaitoProviderReconciler) syncStatus(ctx context.Context, md *airunwayv1
 	return nil
 }
 
-// handleDeletion handles the deletion of a ModelDeployment
+// handleDeletion handles the deletion of a ModelDeployment, or its migration to another provider
 func (r *KaitoProviderReconciler) handleDeletion(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
@@ -673,21 +742,27 @@ func (r *KaitoProviderReconciler) handleDeletion(ctx context.Context, md *airunw
 
 	logger.Info("Handling deletion", "name", md.Name, "namespace", md.Namespace)
 
-	// Update phase to Terminating
-	md.Status.Phase = airunwayv1alpha1.DeploymentPhaseTerminating
-	if err := r.Status().Update(ctx, md); err != nil {
-		logger.Error(err, "Failed to update status to Terminating")
+	// Update phase to Terminating, unless the deployment was only migrated to another provider
+	if !md.DeletionTimestamp.IsZero() {
+		md.Status.Phase = airunwayv1alpha1.DeploymentPhaseTerminating
+		if err := r.Status().Update(ctx, md); err != nil {
+			logger.Error(err, "Failed to update status to Terminating")
+		}
 	}
 
-	// Delete the upstream resource
+	// Delete the upstream resource, in the version the cluster was negotiated to serve
+	version, err := r.Transformer.Versions.Version()
+	if err != nil {
+		version = KaitoAPIVersion
+	}
 	ws := &unstructured.Unstructured{}
 	ws.SetGroupVersionKind(schema.GroupVersionKind{
 		Group:   KaitoAPIGroup,
-		Version: KaitoAPIVersion,
+		Version: version,
 		Kind:    WorkspaceKind,
 	})
 
-	err := r.Get(ctx, types.NamespacedName{
+	err = r.Get(ctx, types.NamespacedName{
 		Name:      md.Name,
 		Namespace: md.Namespace,
 	}, ws)
@@ -712,7 +787,7 @@ func (r *KaitoProviderReconciler) handleDeletion(ctx context.Context, md *airunw
 			logger.Error(deleteErr, "Failed to delete Workspace")
 
 			// Check if we should force-remove the finalizer
-			deletionTime := md.DeletionTimestamp.Time
+			deletionTime := provider.CleanupStart(md)
 			if time.Since(deletionTime) > FinalizerTimeout {
 				logger.Info("Finalizer timeout reached, removing finalizer without cleanup")
 				controllerutil.RemoveFinalizer(md, FinalizerName)
@@ -732,7 +807,7 @@ func (r *KaitoProviderReconciler) handleDeletion(ctx context.Context, md *airunw
 		// server failure). Honor the finalizer timeout so the ModelDeployment
 		// can still be removed if the error persists, instead of requeueing
 		// forever.
-		deletionTime := md.DeletionTimestamp.Time
+		deletionTime := provider.CleanupStart(md)
 		if time.Since(deletionTime) > FinalizerTimeout {
 			logger.Info("Finalizer timeout reached, removing finalizer without cleanup")
 			controllerutil.RemoveFinalizer(md, FinalizerName)
@@ -3,10 +3,12 @@ package kaito
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
 	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/pkg/provider"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -14,6 +16,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/openapi/openapitest"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -256,6 +259,76 @@ func TestReconcilePaused(t *testing.T) {
 	}
 }
 
+func TestReconcilePausedSpec(t *testing.T) {
+	scheme := newScheme()
+	md := newMDForController("test", "default")
+	md.Spec.Paused = true
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(md).WithStatusSubresource(md).Build()
+	r := NewKaitoProviderReconciler(c, scheme)
+
+	result, err := r.Reconcile(context.Background(), ctrl.Request{
+		NamespacedName: types.NamespacedName{Name: "test", Namespace: "default"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Requeue {
+		t.Error("should not requeue when paused")
+	}
+
+	var updated airunwayv1alpha1.ModelDeployment
+	_ = c.Get(context.Background(), types.NamespacedName{Name: "test", Namespace: "default"}, &updated)
+	if controllerutil.ContainsFinalizer(&updated, FinalizerName) {
+		t.Error("expected no finalizer to be added while paused")
+	}
+}
+
+func TestReconcileQueued(t *testing.T) {
+	scheme := newScheme()
+	md := newMDForController("test", "default")
+	md.Status.Phase = airunwayv1alpha1.DeploymentPhaseQueued
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(md).WithStatusSubresource(md).Build()
+	r := NewKaitoProviderReconciler(c, scheme)
+
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{
+		NamespacedName: types.NamespacedName{Name: "test", Namespace: "default"},
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var updated airunwayv1alpha1.ModelDeployment
+	_ = c.Get(context.Background(), types.NamespacedName{Name: "test", Namespace: "default"}, &updated)
+	if controllerutil.ContainsFinalizer(&updated, FinalizerName) {
+		t.Error("expected no finalizer to be added while waiting for Kueue admission")
+	}
+}
+
+func TestReconcilePausedStillHandlesDeletion(t *testing.T) {
+	scheme := newScheme()
+	md := newMDForController("test", "default")
+	md.Spec.Paused = true
+	controllerutil.AddFinalizer(md, FinalizerName)
+	now := metav1.Now()
+	md.DeletionTimestamp = &now
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(md).WithStatusSubresource(md).Build()
+	r := NewKaitoProviderReconciler(c, scheme)
+
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{
+		NamespacedName: types.NamespacedName{Name: "test", Namespace: "default"},
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var updated airunwayv1alpha1.ModelDeployment
+	_ = c.Get(context.Background(), types.NamespacedName{Name: "test", Namespace: "default"}, &updated)
+	if controllerutil.ContainsFinalizer(&updated, FinalizerName) {
+		t.Error("expected finalizer to be removed even when paused")
+	}
+}
+
 func TestReconcileAddsFinalizer(t *testing.T) {
 	scheme := newScheme()
 	md := newMDForController("test", "default")
@@ -330,6 +403,51 @@ func TestReconcileTransformFailure(t *testing.T) {
 	}
 }
 
+func TestReconcileInvalidOverrides(t *testing.T) {
+	scheme := newScheme()
+	md := newMDForController("test", "default")
+	md.Spec.Provider = &airunwayv1alpha1.ProviderSpec{
+		Name:      ProviderName,
+		Overrides: &runtime.RawExtension{Raw: []byte(`{"resource": {"instancetype": "Standard_NC24ads_A100_v4"}}`)},
+	}
+	controllerutil.AddFinalizer(md, FinalizerName)
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(md).WithStatusSubresource(md).Build()
+	r := NewKaitoProviderReconciler(c, scheme)
+	openAPI := openapitest.NewFakeClient()
+	openAPI.PathsMap["apis/kaito.sh/v1beta1"] = openapitest.FakeGroupVersion{GVSpec: []byte(`{"components": {"schemas": {
+		"sh.kaito.v1beta1.Workspace": {
+			"type": "object",
+			"x-kubernetes-group-version-kind": [{"group": "kaito.sh", "version": "v1beta1", "kind": "Workspace"}],
+			"properties": {"resource": {"type": "object", "properties": {"instanceType": {"type": "string"}}}}
+		}
+	}}}`)}
+	r.Overrides = &provider.OverridesValidator{OpenAPI: openAPI}
+
+	_, err := r.Reconcile(context.Background(), ctrl.Request{
+		NamespacedName: types.NamespacedName{Name: "test", Namespace: "default"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var updated airunwayv1alpha1.ModelDeployment
+	_ = c.Get(context.Background(), types.NamespacedName{Name: "test", Namespace: "default"}, &updated)
+	if updated.Status.Phase != airunwayv1alpha1.DeploymentPhaseFailed {
+		t.Errorf("expected Failed phase, got %s", updated.Status.Phase)
+	}
+	cond := apimeta.FindStatusCondition(updated.Status.Conditions, airunwayv1alpha1.ConditionTypeOverridesValid)
+	if cond == nil || cond.Status != metav1.ConditionFalse || !strings.Contains(cond.Message, "spec.provider.overrides.resource.instancetype") {
+		t.Errorf("expected OverridesValid=False naming the field, got %+v", cond)
+	}
+
+	ws := &unstructured.Unstructured{}
+	setWorkspaceGVK(ws)
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "test", Namespace: "default"}, ws); !apierrors.IsNotFound(err) {
+		t.Errorf("expected no Workspace to be created, got %v", err)
+	}
+}
+
 func TestReconcileNilProvider(t *testing.T) {
 	scheme := newScheme()
 	md := newMDForController("test", "default")
@@ -722,6 +840,70 @@ func TestReconcileDeletionWithUpstreamResource(t *testing.T) {
 	}
 }
 
+func TestReconcileMigratedToOtherProviderDeletesWorkspace(t *testing.T) {
+	scheme := newScheme()
+	md := newMDForController("test", "default")
+	md.UID = "test-uid"
+	controllerutil.AddFinalizer(md, FinalizerName)
+	md.Status.Phase = airunwayv1alpha1.DeploymentPhaseRunning
+	md.Status.Provider = &airunwayv1alpha1.ProviderStatus{Name: "llmd"}
+	md.Status.Migration = &airunwayv1alpha1.MigrationStatus{From: ProviderName, To: "llmd", Phase: airunwayv1alpha1.MigrationPhaseSwitching}
+
+	ws := &unstructured.Unstructured{}
+	setWorkspaceGVK(ws)
+	ws.SetName("test")
+	ws.SetNamespace("default")
+	ws.SetOwnerReferences([]metav1.OwnerReference{
+		{UID: "test-uid", APIVersion: "airunway.ai/v1alpha1", Kind: "ModelDeployment", Name: "test"},
+	})
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(md, ws).WithStatusSubresource(md).Build()
+	r := NewKaitoProviderReconciler(c, scheme)
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "test", Namespace: "default"}}
+
+	// The Workspace keeps serving until the gateway switched to the new provider
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	existing := &unstructured.Unstructured{}
+	setWorkspaceGVK(existing)
+	if err := c.Get(ctx, req.NamespacedName, existing); err != nil {
+		t.Fatalf("expected the Workspace to be kept while switching: %v", err)
+	}
+
+	var updated airunwayv1alpha1.ModelDeployment
+	if err := c.Get(ctx, req.NamespacedName, &updated); err != nil {
+		t.Fatalf("failed to get ModelDeployment: %v", err)
+	}
+	updated.Status.Migration.Phase = airunwayv1alpha1.MigrationPhaseDraining
+	updated.Status.Migration.LastTransitionTime = &metav1.Time{Time: time.Now()}
+	if err := c.Status().Update(ctx, &updated); err != nil {
+		t.Fatalf("failed to update status: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.Get(ctx, req.NamespacedName, existing); !apierrors.IsNotFound(err) {
+		t.Fatalf("expected the Workspace to be deleted once draining, got %v", err)
+	}
+	if err := c.Get(ctx, req.NamespacedName, &updated); err != nil {
+		t.Fatalf("failed to get ModelDeployment: %v", err)
+	}
+	// The status belongs to the new provider and is not marked Terminating
+	if updated.Status.Phase != airunwayv1alpha1.DeploymentPhaseRunning {
+		t.Errorf("expected phase Running, got %s", updated.Status.Phase)
+	}
+
+	// The finalizer is removed once the Workspace is gone
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.Get(ctx, req.NamespacedName, &updated); err != nil || controllerutil.ContainsFinalizer(&updated, FinalizerName) {
+		t.Errorf("expected the finalizer to be removed, got %v (%v)", updated.Finalizers, err)
+	}
+}
+
 func TestManagedFieldsMatch(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -873,7 +1055,7 @@ func TestCreateOrUpdateResourceNew(t *testing.T) {
 	ws.SetNamespace("default")
 	ws.Object["resource"] = map[string]interface{}{"count": int64(1)}
 
-	err := r.createOrUpdateResource(context.Background(), ws, md)
+	_, err := r.createOrUpdateResource(context.Background(), ws, md)
 	if err != nil {
 		t.Fatalf("unexpected error creating resource: %v", err)
 	}
@@ -914,10 +1096,18 @@ func TestCreateOrUpdateResourceUpdate(t *testing.T) {
 	updated.SetNamespace("default")
 	updated.Object["resource"] = map[string]interface{}{"count": int64(3)}
 
-	err := r.createOrUpdateResource(context.Background(), updated, md)
+	_, err := r.createOrUpdateResource(context.Background(), updated, md)
 	if err != nil {
 		t.Fatalf("unexpected error updating resource: %v", err)
 	}
+
+	change := md.Status.LastAppliedChange
+	if change == nil || change.ResourceKind != WorkspaceKind || change.ResourceName != "test" {
+		t.Fatalf("expected the Workspace update to be recorded, got %+v", change)
+	}
+	if len(change.Paths) != 1 || change.Paths[0] != "/resource/count" {
+		t.Errorf("expected change to /resource/count, got %v", change.Paths)
+	}
 }
 
 func TestCreateOrUpdateResourceNoChange(t *testing.T) {
@@ -949,10 +1139,13 @@ func TestCreateOrUpdateResourceNoChange(t *testing.T) {
 	same.Object["resource"] = map[string]interface{}{"count": int64(1)}
 	same.Object["inference"] = map[string]interface{}{"preset": map[string]interface{}{"name": "test"}}
 
-	err := r.createOrUpdateResource(context.Background(), same, md)
+	_, err := r.createOrUpdateResource(context.Background(), same, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
+	if md.Status.LastAppliedChange != nil {
+		t.Errorf("expected no change to be recorded, got %+v", md.Status.LastAppliedChange)
+	}
 }
 
 func TestCreateOrUpdateResourceBackfillsLastAppliedForLegacyWorkspace(t *testing.T) {
@@ -995,7 +1188,7 @@ func TestCreateOrUpdateResourceBackfillsLastAppliedForLegacyWorkspace(t *testing
 		},
 	}
 
-	if err := r.createOrUpdateResource(context.Background(), desired, md); err != nil {
+	if _, err := r.createOrUpdateResource(context.Background(), desired, md); err != nil {
 		t.Fatalf("unexpected error backfilling annotation: %v", err)
 	}
 
@@ -1029,7 +1222,7 @@ func TestCreateOrUpdateResourceBackfillsLastAppliedForLegacyWorkspace(t *testing
 		"preset": map[string]interface{}{"name": "test"},
 	}
 
-	if err := r.createOrUpdateResource(context.Background(), desiredWithoutOverride, md); err != nil {
+	if _, err := r.createOrUpdateResource(context.Background(), desiredWithoutOverride, md); err != nil {
 		t.Fatalf("unexpected error removing managed override after backfill: %v", err)
 	}
 
@@ -1090,7 +1283,7 @@ func TestCreateOrUpdateResourceRemovesStaleManagedLabel(t *testing.T) {
 		},
 	}
 
-	if err := r.createOrUpdateResource(context.Background(), desired, md); err != nil {
+	if _, err := r.createOrUpdateResource(context.Background(), desired, md); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
@@ -1152,7 +1345,7 @@ func TestCreateOrUpdateResourceAppliesMetadataOnlyChanges(t *testing.T) {
 	desired.Object["resource"] = map[string]interface{}{"count": int64(1)}
 	desired.Object["inference"] = map[string]interface{}{"preset": map[string]interface{}{"name": "test"}}
 
-	if err := r.createOrUpdateResource(context.Background(), desired, md); err != nil {
+	if _, err := r.createOrUpdateResource(context.Background(), desired, md); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
@@ -1238,7 +1431,7 @@ func TestCreateOrUpdateResourceRemovesDeletedManagedMetadata(t *testing.T) {
 	desired.Object["resource"] = map[string]interface{}{"count": int64(1)}
 	desired.Object["inference"] = map[string]interface{}{"preset": map[string]interface{}{"name": "test"}}
 
-	if err := r.createOrUpdateResource(context.Background(), desired, md); err != nil {
+	if _, err := r.createOrUpdateResource(context.Background(), desired, md); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
@@ -1316,7 +1509,7 @@ func TestCreateOrUpdateResourceIgnoresUnmanagedOperatorDefaults(t *testing.T) {
 		"preset": map[string]interface{}{"name": "test"},
 	}
 
-	if err := r.createOrUpdateResource(context.Background(), desired, md); err != nil {
+	if _, err := r.createOrUpdateResource(context.Background(), desired, md); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
@@ -1381,7 +1574,7 @@ func TestCreateOrUpdateResourceRemovesDeletedManagedInferenceOverride(t *testing
 		"preset": map[string]interface{}{"name": "test"},
 	}
 
-	if err := r.createOrUpdateResource(context.Background(), desired, md); err != nil {
+	if _, err := r.createOrUpdateResource(context.Background(), desired, md); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
@@ -0,0 +1,24 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package rbac holds the RBAC markers of the ClusterRole the KAITO provider aggregates into
+// the AI Runway controller role. The role grants the controller read access to the upstream
+// resources the provider creates. Run `make manifests` to regenerate
+// config/rbac/aggregate/role.yaml after changing them.
+package rbac
+
+// +kubebuilder:rbac:groups=kaito.sh,resources=workspaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups=kaito.sh,resources=workspaces/status,verbs=get
@@ -209,6 +209,12 @@ func (t *StatusTranslator) IsReady(upstream *unstructured.Unstructured) bool {
 		return false
 	}
 
+	// status.state takes precedence, as in TranslateStatus
+	if state, found, _ := unstructured.NestedString(upstream.Object, "status", "state"); found && state != "" {
+		phase, _ := t.mapStateToPhase(state)
+		return phase == airunwayv1alpha1.DeploymentPhaseRunning
+	}
+
 	conditions, found, err := unstructured.NestedSlice(upstream.Object, "status", "conditions")
 	if err != nil || !found {
 		return false
@@ -281,6 +281,22 @@ func TestIsReady(t *testing.T) {
 	if !st.IsReady(ws) {
 		t.Error("expected ready even with invalid entries before valid one")
 	}
+
+	// status.state takes precedence over conditions
+	ws = newWorkspaceWithStatus([]interface{}{
+		map[string]interface{}{
+			"type":   "WorkspaceSucceeded",
+			"status": "False",
+		},
+	})
+	_ = unstructured.SetNestedField(ws.Object, "Ready", "status", "state")
+	if !st.IsReady(ws) {
+		t.Error("expected ready when state is Ready")
+	}
+	_ = unstructured.SetNestedField(ws.Object, "NotReady", "status", "state")
+	if st.IsReady(ws) {
+		t.Error("expected not ready when state is NotReady")
+	}
 }
 
 func TestGetErrorMessage(t *testing.T) {
@@ -24,17 +24,21 @@ import (
 	"strings"
 
 	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/pkg/provider"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/discovery"
 )
 
 const (
 	// KaitoAPIGroup is the API group for KAITO CRDs
 	KaitoAPIGroup = "kaito.sh"
-	// KaitoAPIVersion is the current API version for KAITO CRDs
+	// KaitoAPIVersion is the API version the transformer builds Workspaces in
 	KaitoAPIVersion = "v1beta1"
 	// WorkspaceKind is the kind for KAITO Workspace
 	WorkspaceKind = "Workspace"
+	// WorkspaceResource is the resource name of KAITO Workspaces
+	WorkspaceResource = "workspaces"
 
 	// defaultLlamaCppPort is the default serving port for llamacpp containers
 	defaultLlamaCppPort = 5000
@@ -47,18 +51,88 @@ const (
 	cpuInstanceTypeEnv = "AIRUNWAY_KAITO_CPU_INSTANCE_TYPE"
 	// gpuInstanceTypeEnv supplies the KAITO instanceType for GPU deployments.
 	gpuInstanceTypeEnv = "AIRUNWAY_KAITO_GPU_INSTANCE_TYPE"
+	// distributedPresetsEnv adds comma-separated KAITO presets to distributedPresets, for
+	// presets newer than the provider.
+	distributedPresetsEnv = "AIRUNWAY_KAITO_DISTRIBUTED_PRESETS"
 )
 
+// distributedPresets lists the KAITO presets whose metadata enables multi-node (torch
+// distributed) inference. KAITO rejects a Workspace with resource.count > 1 for other
+// presets, so the transformer fails early instead.
+var distributedPresets = []string{
+	"deepseek-r1-0528",
+	"deepseek-v3-0324",
+	"gpt-oss-120b",
+	"llama-3.3-70b-instruct",
+}
+
+// workspaceVersionMappings lists the Workspace API versions the transformer can emit.
+// v1alpha1 has the same resource and inference fields as v1beta1, so no mapping is needed.
+var workspaceVersionMappings = map[string]provider.VersionMapping{
+	KaitoAPIVersion: nil,
+	"v1alpha1":      nil,
+}
+
 // Transformer handles transformation of ModelDeployment to KAITO Workspace
-type Transformer struct{}
+type Transformer struct {
+	// Versions negotiates the Workspace API version with the cluster
+	Versions *provider.APIVersionNegotiator
+}
+
+var _ provider.Transformer = (*Transformer)(nil)
 
-// NewTransformer creates a new KAITO transformer
-func NewTransformer() *Transformer {
-	return &Transformer{}
+// NewTransformer creates a new KAITO transformer. Without a discovery client it emits
+// KaitoAPIVersion.
+func NewTransformer(discoveryClient ...discovery.DiscoveryInterface) *Transformer {
+	versions := &provider.APIVersionNegotiator{
+		Group:     KaitoAPIGroup,
+		Resource:  WorkspaceResource,
+		Canonical: KaitoAPIVersion,
+		Mappings:  workspaceVersionMappings,
+	}
+	if len(discoveryClient) > 0 {
+		versions.Discovery = discoveryClient[0]
+	}
+	return &Transformer{Versions: versions}
 }
 
 // Transform converts a ModelDeployment to a KAITO Workspace
-func (t *Transformer) Transform(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) ([]*unstructured.Unstructured, error) {
+func (t *Transformer) Transform(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) (*provider.TransformResult, error) {
+	if md.Spec.Engine.Device == airunwayv1alpha1.EngineDeviceCPU && md.ResolvedEngineType() != airunwayv1alpha1.EngineTypeLlamaCpp {
+		return nil, fmt.Errorf("kaito provider only supports cpu inference with the llamacpp engine")
+	}
+	if md.ServiceAccountName() != "" && md.ResolvedEngineType() != airunwayv1alpha1.EngineTypeLlamaCpp {
+		return nil, fmt.Errorf("kaito provider only supports spec.identity with the llamacpp engine; preset workspaces run as the default ServiceAccount")
+	}
+	if md.Spec.Scheduling != nil && md.Spec.Scheduling.Gang {
+		return nil, fmt.Errorf("kaito provider does not support spec.scheduling.gang; workspace pods are scheduled by the KAITO operator")
+	}
+	if md.Spec.Model.ChatTemplate != nil || md.Spec.Model.Tokenizer != "" {
+		return nil, fmt.Errorf("kaito provider does not support spec.model.chatTemplate or spec.model.tokenizer; KAITO presets configure the engine")
+	}
+	if provider.RevisionRequested(md) {
+		return nil, fmt.Errorf("kaito provider does not support spec.model.revision; KAITO presets pin the model weights")
+	}
+	if md.Spec.Model.Storage != nil && md.Spec.Model.Storage.KVOffload != nil {
+		return nil, fmt.Errorf("kaito provider does not support spec.model.storage.kvOffload; KAITO presets configure the engine")
+	}
+	if md.Spec.Caching != nil && md.Spec.Caching.KV != nil {
+		return nil, fmt.Errorf("kaito provider does not support spec.caching.kv; KAITO presets configure the engine")
+	}
+	if md.RemediationEnabled() {
+		return nil, fmt.Errorf("kaito provider does not support spec.engine.remediation; KAITO presets configure the engine")
+	}
+	if md.LogSink() != nil {
+		return nil, fmt.Errorf("kaito provider does not support spec.observability.logSink; workspace pods are created by the KAITO operator")
+	}
+	if err := validateMultinode(md); err != nil {
+		return nil, err
+	}
+	version, err := t.Versions.Version()
+	if err != nil {
+		return nil, err
+	}
+
 	ws := &unstructured.Unstructured{}
 	ws.SetAPIVersion(fmt.Sprintf("%s/%s", KaitoAPIGroup, KaitoAPIVersion))
 	ws.SetKind(WorkspaceKind)
@@ -79,11 +153,11 @@ func (t *Transformer) Transform(ctx context.Context, md *airunwayv1alpha1.ModelD
 
 	// Set labels
 	labels := map[string]string{
-		"airunway.ai/managed-by":       "airunway",
-		"airunway.ai/deployment":       md.Name,
-		"airunway.ai/model-source":     string(md.Spec.Model.Source),
-		"airunway.ai/engine-type":      string(md.ResolvedEngineType()),
-		"airunway.ai/model-deployment": md.Name,
+		airunwayv1alpha1.LabelManagedBy:       airunwayv1alpha1.ManagedByAIRunway,
+		airunwayv1alpha1.LabelDeployment:      md.Name,
+		airunwayv1alpha1.LabelModelSource:     string(md.Spec.Model.Source),
+		airunwayv1alpha1.LabelEngineType:      string(md.ResolvedEngineType()),
+		airunwayv1alpha1.LabelModelDeployment: md.Name,
 	}
 	// Merge podTemplate labels onto the Workspace
 	if md.Spec.PodTemplate != nil && md.Spec.PodTemplate.Metadata != nil {
@@ -115,24 +189,35 @@ func (t *Transformer) Transform(ctx context.Context, md *airunwayv1alpha1.ModelD
 		return nil, fmt.Errorf("failed to set inference: %w", err)
 	}
 
+	// Map to the negotiated API version before overrides, which are written for it
+	if err := t.Versions.Apply(ws, version); err != nil {
+		return nil, err
+	}
+
 	// Apply escape hatch overrides last so they can override any field.
 	// Setting an override value to null deletes that field from the generated Workspace.
 	if err := applyOverrides(ws, md); err != nil {
 		return nil, fmt.Errorf("failed to apply provider overrides: %w", err)
 	}
 
-	return []*unstructured.Unstructured{ws}, nil
+	result := provider.NewTransformResult(ws)
+	provider.ApplyPropagatedMetadata(result, md)
+	return result, nil
 }
 
 // buildResource creates the resource section of the Workspace spec
 func (t *Transformer) buildResource(md *airunwayv1alpha1.ModelDeployment) map[string]interface{} {
 	resource := map[string]interface{}{}
 
-	// Map scaling.replicas → spec.resource.count
+	// Map scaling.replicas → spec.resource.count. A multi-node Workspace is a single
+	// distributed replica whose count is the number of nodes.
 	count := int64(1)
 	if md.Spec.Scaling != nil && md.Spec.Scaling.Replicas > 0 {
 		count = int64(md.Spec.Scaling.Replicas)
 	}
+	if md.Spec.Multinode != nil {
+		count = int64(md.Spec.Multinode.NodeCount)
+	}
 	resource["count"] = count
 
 	// Node auto-provisioning mode: emit instanceType when it is explicitly
@@ -169,9 +254,25 @@ func (t *Transformer) buildResource(md *airunwayv1alpha1.ModelDeployment) map[st
 	if kaitoHasGPU(md) {
 		matchLabels["nvidia.com/gpu.present"] = "true"
 	}
-	resource["labelSelector"] = map[string]interface{}{
+	labelSelector := map[string]interface{}{
 		"matchLabels": matchLabels,
 	}
+	// The Workspace label selector has no preferred terms, so spec.resources.gpu.types
+	// only restricts the nodes to the listed GPU models without ordering them.
+	if kaitoHasGPU(md) && len(md.Spec.Resources.GPU.Types) > 0 {
+		types := make([]interface{}, 0, len(md.Spec.Resources.GPU.Types))
+		for _, t := range md.Spec.Resources.GPU.Types {
+			types = append(types, t)
+		}
+		labelSelector["matchExpressions"] = []interface{}{
+			map[string]interface{}{
+				"key":      airunwayv1alpha1.LabelGPUProduct,
+				"operator": "In",
+				"values":   types,
+			},
+		}
+	}
+	resource["labelSelector"] = labelSelector
 
 	return resource
 }
@@ -198,6 +299,50 @@ func kaitoInstanceTypeForMD(md *airunwayv1alpha1.ModelDeployment) string {
 	return strings.TrimSpace(os.Getenv(cpuInstanceTypeEnv))
 }
 
+// validateMultinode checks that spec.multinode can run as a distributed preset Workspace:
+// one replica of a vLLM preset that supports distributed inference
+func validateMultinode(md *airunwayv1alpha1.ModelDeployment) error {
+	if md.Spec.Multinode == nil {
+		return nil
+	}
+	if md.ResolvedEngineType() != airunwayv1alpha1.EngineTypeVLLM {
+		return fmt.Errorf("kaito provider only supports spec.multinode with the vllm engine")
+	}
+	if md.Spec.Scaling != nil && md.Spec.Scaling.Replicas > 1 {
+		return fmt.Errorf("kaito provider does not support spec.multinode with more than one replica; a distributed workspace is a single replica")
+	}
+	if !isDistributedPreset(md.Spec.Model.ID) {
+		return fmt.Errorf("kaito provider does not support spec.multinode for preset %q; supported presets are %s",
+			md.Spec.Model.ID, strings.Join(distributedPresetNames(), ", "))
+	}
+	return nil
+}
+
+// distributedPresetNames returns distributedPresets and the presets of
+// AIRUNWAY_KAITO_DISTRIBUTED_PRESETS
+func distributedPresetNames() []string {
+	names := append([]string{}, distributedPresets...)
+	for _, name := range strings.Split(os.Getenv(distributedPresetsEnv), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// isDistributedPreset reports whether a preset supports distributed inference. Presets
+// match case-insensitively, so HuggingFace IDs such as meta-llama/Llama-3.3-70B-Instruct
+// match on their model name.
+func isDistributedPreset(preset string) bool {
+	name := preset[strings.LastIndex(preset, "/")+1:]
+	for _, p := range distributedPresetNames() {
+		if strings.EqualFold(p, preset) || strings.EqualFold(p, name) {
+			return true
+		}
+	}
+	return false
+}
+
 // buildInference creates the inference section of the Workspace spec
 func (t *Transformer) buildInference(md *airunwayv1alpha1.ModelDeployment) (map[string]interface{}, error) {
 	inference := map[string]interface{}{}
@@ -264,16 +409,34 @@ func (t *Transformer) buildLlamaCppTemplate(md *airunwayv1alpha1.ModelDeployment
 		container["env"] = envVars
 	}
 
+	labels := map[string]interface{}{
+		airunwayv1alpha1.LabelModelDeployment: md.Name,
+	}
+	for k, v := range md.IdentityPodLabels() {
+		labels[k] = v
+	}
+	podSpec := map[string]interface{}{
+		"containers": []interface{}{container},
+	}
+	if sa := md.ServiceAccountName(); sa != "" {
+		podSpec["serviceAccountName"] = sa
+	}
+
+	metadata := map[string]interface{}{
+		"labels": labels,
+	}
+	if pinning := md.CPUPinningPodAnnotations(); len(pinning) > 0 {
+		annotations := map[string]interface{}{}
+		for k, v := range pinning {
+			annotations[k] = v
+		}
+		metadata["annotations"] = annotations
+	}
 	template := map[string]interface{}{
-		"metadata": map[string]interface{}{
-			"labels": map[string]interface{}{
-				"airunway.ai/model-deployment": md.Name,
-			},
-		},
-		"spec": map[string]interface{}{
-			"containers": []interface{}{container},
-		},
+		"metadata": metadata,
+		"spec":     podSpec,
 	}
+	provider.ApplyPropagatedMetadataToPodTemplate(template, md)
 
 	return template, nil
 }
@@ -292,7 +455,8 @@ func resolveLlamaCppModelArg(md *airunwayv1alpha1.ModelDeployment) string {
 	return fmt.Sprintf("huggingface://%s", md.Spec.Model.ID)
 }
 
-// buildResourceRequests creates resource requests from ResourceSpec
+// buildResourceRequests creates resource requests from ResourceSpec, plus limits when
+// they are set explicitly
 func (t *Transformer) buildResourceRequests(spec *airunwayv1alpha1.ResourceSpec) map[string]interface{} {
 	if spec == nil {
 		return nil
@@ -306,14 +470,20 @@ func (t *Transformer) buildResourceRequests(spec *airunwayv1alpha1.ResourceSpec)
 	if spec.CPU != "" {
 		requests["cpu"] = spec.CPU
 	}
+	requests, limits := provider.ApplyResourceQuantities(requests, nil, spec.Requests, spec.Limits)
 
-	if len(requests) == 0 {
+	if len(requests) == 0 && len(limits) == 0 {
 		return nil
 	}
 
-	return map[string]interface{}{
-		"requests": requests,
+	result := map[string]interface{}{}
+	if len(requests) > 0 {
+		result["requests"] = requests
+	}
+	if len(limits) > 0 {
+		result["limits"] = limits
 	}
+	return result
 }
 
 // buildEnvVars constructs environment variables including HF_TOKEN from secrets
@@ -378,15 +548,21 @@ func boolPtr(b bool) *bool {
 	return &b
 }
 
-// applyOverrides deep-merges spec.provider.overrides into the unstructured object.
+// applyOverrides deep-merges spec.provider.overrides into the unstructured object,
+// after expanding templates in its string values against the ModelDeployment.
 // This is the escape hatch that lets users set arbitrary fields on the provider CRD.
 func applyOverrides(obj *unstructured.Unstructured, md *airunwayv1alpha1.ModelDeployment) error {
 	if md.Spec.Provider == nil || md.Spec.Provider.Overrides == nil {
 		return nil
 	}
 
+	raw, err := provider.RenderOverrides(md)
+	if err != nil {
+		return err
+	}
+
 	var overrides map[string]interface{}
-	if err := json.Unmarshal(md.Spec.Provider.Overrides.Raw, &overrides); err != nil {
+	if err := json.Unmarshal(raw, &overrides); err != nil {
 		return fmt.Errorf("failed to unmarshal overrides: %w", err)
 	}
 
@@ -3,6 +3,7 @@ package kaito
 import (
 	"context"
 	"os"
+	"strings"
 	"testing"
 
 	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
@@ -11,6 +12,8 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	k8stesting "k8s.io/client-go/testing"
 )
 
 func TestMain(m *testing.M) {
@@ -57,11 +60,44 @@ func newTestMD(name, namespace string) *airunwayv1alpha1.ModelDeployment {
 	}
 }
 
+// transformResources runs the transformer and returns the ordered resources.
+func transformResources(tr *Transformer, md *airunwayv1alpha1.ModelDeployment) ([]*unstructured.Unstructured, error) {
+	result, err := tr.Transform(context.Background(), md)
+	if err != nil {
+		return nil, err
+	}
+	return result.Resources, nil
+}
+
+func TestTransformNegotiatesAPIVersion(t *testing.T) {
+	dc := &fakediscovery.FakeDiscovery{Fake: &k8stesting.Fake{}}
+	dc.Resources = []*metav1.APIResourceList{
+		{GroupVersion: "kaito.sh/v1alpha1", APIResources: []metav1.APIResource{{Name: WorkspaceResource}}},
+	}
+	tr := NewTransformer(dc)
+
+	resources, err := transformResources(tr, newTestMD("test-model", "default"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := resources[0].GetAPIVersion(); got != "kaito.sh/v1alpha1" {
+		t.Errorf("expected apiVersion 'kaito.sh/v1alpha1' served by the cluster, got %s", got)
+	}
+
+	dc.Resources = []*metav1.APIResourceList{
+		{GroupVersion: "kaito.sh/v1", APIResources: []metav1.APIResource{{Name: WorkspaceResource}}},
+	}
+	tr = NewTransformer(dc)
+	if _, err := transformResources(tr, newTestMD("test-model", "default")); err == nil || !strings.Contains(err.Error(), "only in v1") {
+		t.Errorf("expected an unsupported version error, got %v", err)
+	}
+}
+
 func TestTransformVLLM(t *testing.T) {
 	tr := NewTransformer()
 	md := newTestMD("test-model", "default")
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -129,7 +165,7 @@ func TestTransformVLLMWithScaling(t *testing.T) {
 		Replicas: 3,
 	}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -141,13 +177,67 @@ func TestTransformVLLMWithScaling(t *testing.T) {
 	}
 }
 
+func TestTransformMultinode(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Model.ID = "meta-llama/Llama-3.3-70B-Instruct"
+	md.Spec.Resources = &airunwayv1alpha1.ResourceSpec{GPU: &airunwayv1alpha1.GPUSpec{Count: 4}}
+	md.Spec.Multinode = &airunwayv1alpha1.MultinodeSpec{NodeCount: 2}
+
+	resources, err := transformResources(tr, md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	count, _, _ := unstructured.NestedInt64(resources[0].Object, "resource", "count")
+	if count != 2 {
+		t.Errorf("expected count 2, got %d", count)
+	}
+	preset, _, _ := unstructured.NestedString(resources[0].Object, "inference", "preset", "name")
+	if preset != md.Spec.Model.ID {
+		t.Errorf("expected preset %s, got %s", md.Spec.Model.ID, preset)
+	}
+}
+
+func TestTransformMultinodeValidation(t *testing.T) {
+	tr := NewTransformer()
+	newMultinodeMD := func() *airunwayv1alpha1.ModelDeployment {
+		md := newTestMD("test-model", "default")
+		md.Spec.Model.ID = "llama-3.3-70b-instruct"
+		md.Spec.Multinode = &airunwayv1alpha1.MultinodeSpec{NodeCount: 2}
+		return md
+	}
+
+	md := newMultinodeMD()
+	md.Spec.Model.ID = "meta-llama/Llama-2-7b-chat-hf"
+	if _, err := tr.Transform(context.Background(), md); err == nil || !strings.Contains(err.Error(), "supported presets are") {
+		t.Errorf("expected an unsupported preset to be rejected, got %v", err)
+	}
+	t.Setenv(distributedPresetsEnv, " llama-2-7b-chat-hf ,")
+	if _, err := tr.Transform(context.Background(), md); err != nil {
+		t.Errorf("expected a preset from %s to be accepted, got %v", distributedPresetsEnv, err)
+	}
+
+	md = newMultinodeMD()
+	md.Spec.Scaling = &airunwayv1alpha1.ScalingSpec{Replicas: 2}
+	if _, err := tr.Transform(context.Background(), md); err == nil || !strings.Contains(err.Error(), "more than one replica") {
+		t.Errorf("expected multiple replicas to be rejected, got %v", err)
+	}
+
+	md = newMultinodeMD()
+	md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeLlamaCpp
+	md.Spec.Image = "ghcr.io/kaito-project/aikit/llama3.1:8b"
+	if _, err := tr.Transform(context.Background(), md); err == nil || !strings.Contains(err.Error(), "vllm engine") {
+		t.Errorf("expected llamacpp to be rejected, got %v", err)
+	}
+}
+
 func TestTransformLlamaCpp(t *testing.T) {
 	tr := NewTransformer()
 	md := newTestMD("test-model", "default")
 	md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeLlamaCpp
 	md.Spec.Image = "my-image:latest"
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -209,7 +299,7 @@ func TestTransformLlamaCppUsesExplicitGGUFURL(t *testing.T) {
 		"ggufUrl": "https://huggingface.co/unsloth/NVIDIA-Nemotron-3-Nano-4B-GGUF/resolve/main/NVIDIA-Nemotron-3-Nano-4B-Q4_K_M.gguf",
 	}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -270,7 +360,7 @@ func TestTransformWithNodeSelector(t *testing.T) {
 		"gpu-type": "a100",
 	}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -299,7 +389,7 @@ func TestTransformWithEnvVars(t *testing.T) {
 		HuggingFaceToken: "my-hf-secret",
 	}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -346,7 +436,7 @@ func TestTransformWithEnvFromSecret(t *testing.T) {
 		},
 	}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -384,7 +474,7 @@ func TestTransformWithResources(t *testing.T) {
 		CPU:    "4",
 	}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -420,7 +510,7 @@ func TestTransformWithPodTemplateLabels(t *testing.T) {
 		},
 	}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -448,7 +538,7 @@ func TestTransformWithPodTemplateAnnotationsCopiesMap(t *testing.T) {
 		},
 	}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -496,6 +586,25 @@ func TestBuildResourceRequests(t *testing.T) {
 	if requests["cpu"] != "2" {
 		t.Errorf("expected cpu 2, got %v", requests["cpu"])
 	}
+	if _, ok := result["limits"]; ok {
+		t.Errorf("expected no limits without explicit limits, got %v", result["limits"])
+	}
+
+	// Explicit requests and limits
+	result = tr.buildResourceRequests(&airunwayv1alpha1.ResourceSpec{
+		Memory:   "8Gi",
+		CPU:      "2",
+		Requests: &airunwayv1alpha1.ResourceQuantities{CPU: "1"},
+		Limits:   &airunwayv1alpha1.ResourceQuantities{CPU: "4", Memory: "16Gi"},
+	})
+	requests, _ = result["requests"].(map[string]interface{})
+	if requests["cpu"] != "1" || requests["memory"] != "8Gi" {
+		t.Errorf("expected explicit cpu request and shorthand memory request, got %v", requests)
+	}
+	limits, _ := result["limits"].(map[string]interface{})
+	if limits["cpu"] != "4" || limits["memory"] != "16Gi" {
+		t.Errorf("expected explicit limits, got %v", limits)
+	}
 }
 
 func TestSanitizeLabelValue(t *testing.T) {
@@ -550,7 +659,7 @@ func TestApplyOverrides(t *testing.T) {
 	md := newTestMD("test-model", "default")
 
 	// No overrides - should succeed without changes
-	results, err := tr.Transform(context.Background(), md)
+	results, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -575,7 +684,7 @@ func TestApplyOverrides(t *testing.T) {
 		},
 	}
 
-	results, err = tr.Transform(context.Background(), md)
+	results, err = transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -615,12 +724,38 @@ func TestApplyOverridesInvalidJSON(t *testing.T) {
 	}
 }
 
+func TestApplyOverridesTemplates(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "team-a")
+	md.Spec.Provider = &airunwayv1alpha1.ProviderSpec{
+		Overrides: &runtime.RawExtension{
+			Raw: []byte(`{"resource": {"labelSelector": {"matchLabels": {
+				"model": "{{ .Name }}", "team": "{{ .Namespace }}"
+			}}}}`),
+		},
+	}
+
+	results, err := transformResources(tr, md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	labels, _, _ := unstructured.NestedStringMap(results[0].Object, "resource", "labelSelector", "matchLabels")
+	if labels["model"] != "test-model" || labels["team"] != "team-a" {
+		t.Errorf("expected templated labels to be expanded, got %v", labels)
+	}
+
+	md.Spec.Provider.Overrides.Raw = []byte(`{"resource": {"count": "{{ .Spec.Unknown }}"}}`)
+	if _, err := tr.Transform(context.Background(), md); err == nil {
+		t.Fatal("expected error for override template referencing an unknown field")
+	}
+}
+
 func TestTransformVLLMDefaultReplicas(t *testing.T) {
 	tr := NewTransformer()
 	md := newTestMD("test-model", "default")
 	// No Scaling spec at all — should default to count=1
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -639,7 +774,7 @@ func TestTransformVLLMZeroReplicas(t *testing.T) {
 		Replicas: 0,
 	}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -659,7 +794,7 @@ func TestTransformLlamaCppDoesNotInjectServedNameFlag(t *testing.T) {
 	md.Spec.Image = "my-image:latest"
 	md.Spec.Model.ServedName = "my-alias"
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -683,7 +818,7 @@ func TestTransformEmptyNodeSelector(t *testing.T) {
 	md := newTestMD("test-model", "default")
 	md.Spec.NodeSelector = map[string]string{}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -708,7 +843,7 @@ func TestTransformGPUAddsNvidiaLabel(t *testing.T) {
 		GPU: &airunwayv1alpha1.GPUSpec{Count: 1},
 	}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -727,7 +862,7 @@ func TestTransformDefaultBYONodeResourceOmitsInstanceType(t *testing.T) {
 	tr := NewTransformer()
 	md := newTestMD("test-model", "default")
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -753,7 +888,7 @@ func TestTransformCPUNodeAutoProvisioningUsesCPUInstanceType(t *testing.T) {
 		GPU: &airunwayv1alpha1.GPUSpec{Count: 0},
 	}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -778,7 +913,7 @@ func TestTransformGPUNodeAutoProvisioningUsesGPUInstanceType(t *testing.T) {
 		GPU: &airunwayv1alpha1.GPUSpec{Count: 1},
 	}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -806,7 +941,7 @@ func TestTransformNodeAutoProvisioningWithoutInstanceTypeFallsBackToLabelSelecto
 		GPU: &airunwayv1alpha1.GPUSpec{Count: 1},
 	}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -843,7 +978,7 @@ func TestTransformOverrideCanDeleteGeneratedInstanceType(t *testing.T) {
 		},
 	}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -866,7 +1001,7 @@ func TestTransformNoGPUOmitsNvidiaLabel(t *testing.T) {
 		GPU: &airunwayv1alpha1.GPUSpec{Count: 0},
 	}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -891,7 +1026,7 @@ func TestTransformGPULabelWinsOverNodeSelector(t *testing.T) {
 		"nvidia.com/gpu.present": "false",
 	}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -924,7 +1059,7 @@ func TestTransformOverrideCanDeleteNvidiaGPULabel(t *testing.T) {
 		},
 	}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -973,7 +1108,7 @@ func TestTransformWithNilResources(t *testing.T) {
 	md.Spec.Image = "my-image:latest"
 	md.Spec.Resources = nil
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -999,7 +1134,7 @@ func TestTransformWithHFSecret(t *testing.T) {
 		HuggingFaceToken: "my-hf-secret",
 	}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1041,7 +1176,7 @@ func TestTransformOverrideCanSetRootFields(t *testing.T) {
 		},
 	}
 
-	results, err := tr.Transform(context.Background(), md)
+	results, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1076,7 +1211,7 @@ func TestTransformPreservesOwnerReference(t *testing.T) {
 	md.APIVersion = "airunway.ai/v1alpha1"
 	md.Kind = "ModelDeployment"
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1093,3 +1228,199 @@ func TestTransformPreservesOwnerReference(t *testing.T) {
 		t.Error("expected controller=true on owner ref")
 	}
 }
+
+func TestTransformLlamaCppCPUPinning(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeLlamaCpp
+	md.Spec.Engine.Device = airunwayv1alpha1.EngineDeviceCPU
+	md.Spec.Image = "my-image:latest"
+	// As defaulted by the admission webhook
+	md.Spec.Resources = &airunwayv1alpha1.ResourceSpec{
+		Requests:   &airunwayv1alpha1.ResourceQuantities{CPU: "8", Memory: "16Gi"},
+		Limits:     &airunwayv1alpha1.ResourceQuantities{CPU: "8", Memory: "16Gi"},
+		CPUPinning: &airunwayv1alpha1.CPUPinningSpec{ExclusiveCores: 8, NUMAPolicy: airunwayv1alpha1.NUMAPolicySingleNUMANode},
+	}
+
+	resources, err := transformResources(tr, md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	template, _, _ := unstructured.NestedMap(resources[0].Object, "inference", "template")
+
+	annotations, _, _ := unstructured.NestedStringMap(template, "metadata", "annotations")
+	if annotations[airunwayv1alpha1.AnnotationCPUPinning] != "8" || annotations[airunwayv1alpha1.AnnotationNUMAPolicy] != "single-numa-node" {
+		t.Errorf("expected CPU pinning pod annotations, got %v", annotations)
+	}
+
+	containers, _, _ := unstructured.NestedSlice(template, "spec", "containers")
+	container, _ := containers[0].(map[string]interface{})
+	for _, kind := range []string{"requests", "limits"} {
+		cpu, _, _ := unstructured.NestedString(container, "resources", kind, "cpu")
+		memory, _, _ := unstructured.NestedString(container, "resources", kind, "memory")
+		if cpu != "8" || memory != "16Gi" {
+			t.Errorf("expected %s of 8 cpu and 16Gi for Guaranteed QoS, got %s and %s", kind, cpu, memory)
+		}
+	}
+}
+
+func TestTransformCPUDeviceRequiresLlamaCpp(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeVLLM
+	md.Spec.Engine.Device = airunwayv1alpha1.EngineDeviceCPU
+
+	if _, err := tr.Transform(context.Background(), md); err == nil || !strings.Contains(err.Error(), "llamacpp") {
+		t.Errorf("expected vllm on cpu to be rejected, got %v", err)
+	}
+}
+
+func TestTransformIdentity(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Identity = &airunwayv1alpha1.IdentitySpec{
+		Annotations: map[string]string{"azure.workload.identity/client-id": "00000000-0000-0000-0000-000000000000"},
+	}
+
+	// Preset workspaces have no pod template to carry the ServiceAccount
+	if _, err := tr.Transform(context.Background(), md); err == nil || !strings.Contains(err.Error(), "spec.identity") {
+		t.Errorf("expected identity to be rejected for preset models, got %v", err)
+	}
+
+	md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeLlamaCpp
+	md.Spec.Image = "my-image:latest"
+	resources, err := transformResources(tr, md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sa, _, _ := unstructured.NestedString(resources[0].Object, "inference", "template", "spec", "serviceAccountName")
+	if sa != "test-model" {
+		t.Errorf("expected serviceAccountName test-model, got %q", sa)
+	}
+	use, _, _ := unstructured.NestedString(resources[0].Object, "inference", "template", "metadata", "labels", "azure.workload.identity/use")
+	if use != "true" {
+		t.Errorf("expected azure.workload.identity/use label, got %q", use)
+	}
+}
+
+func TestTransformRejectsGangScheduling(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Scheduling = &airunwayv1alpha1.SchedulingSpec{
+		Gang:      true,
+		Scheduler: airunwayv1alpha1.GangSchedulerKueue,
+		Queue:     "inference",
+	}
+
+	if _, err := tr.Transform(context.Background(), md); err == nil || !strings.Contains(err.Error(), "spec.scheduling.gang") {
+		t.Errorf("expected gang scheduling to be rejected, got %v", err)
+	}
+}
+
+func TestTransformRejectsChatTemplate(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Model.Tokenizer = "org/tokenizer"
+
+	if _, err := tr.Transform(context.Background(), md); err == nil || !strings.Contains(err.Error(), "spec.model.tokenizer") {
+		t.Errorf("expected tokenizer to be rejected, got %v", err)
+	}
+}
+
+func TestTransformRejectsEngineRemediation(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Engine.Remediation = &airunwayv1alpha1.EngineRemediationSpec{Enabled: true}
+
+	if _, err := tr.Transform(context.Background(), md); err == nil || !strings.Contains(err.Error(), "spec.engine.remediation") {
+		t.Errorf("expected remediation to be rejected, got %v", err)
+	}
+}
+
+func TestTransformRejectsLogSink(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Observability = &airunwayv1alpha1.ObservabilitySpec{LogSink: &airunwayv1alpha1.LogSinkSpec{
+		Type: airunwayv1alpha1.LogSinkTypeLoki, Endpoint: "http://loki.observability:3100",
+	}}
+
+	if _, err := tr.Transform(context.Background(), md); err == nil || !strings.Contains(err.Error(), "spec.observability.logSink") {
+		t.Errorf("expected the log sink to be rejected, got %v", err)
+	}
+}
+
+func TestTransformRejectsRevision(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Model.Revision = "v1.0"
+
+	if _, err := tr.Transform(context.Background(), md); err == nil || !strings.Contains(err.Error(), "spec.model.revision") {
+		t.Errorf("expected a requested revision to be rejected, got %v", err)
+	}
+
+	// A default branch pinned at admission is ignored, since presets pin their own weights
+	md.Spec.Model.Revision = "0123456789abcdef0123456789abcdef01234567"
+	md.Annotations = map[string]string{airunwayv1alpha1.AnnotationModelRevisionRef: ""}
+	if _, err := tr.Transform(context.Background(), md); err != nil {
+		t.Errorf("expected a pinned default branch to be ignored, got %v", err)
+	}
+}
+
+func TestTransformRejectsKVCacheSettings(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Model.Storage = &airunwayv1alpha1.StorageSpec{KVOffload: &airunwayv1alpha1.KVOffloadSpec{}}
+
+	if _, err := tr.Transform(context.Background(), md); err == nil || !strings.Contains(err.Error(), "kvOffload") {
+		t.Errorf("expected KV offload to be rejected, got %v", err)
+	}
+
+	md.Spec.Model.Storage = nil
+	md.Spec.Caching = &airunwayv1alpha1.CachingSpec{KV: &airunwayv1alpha1.KVCacheSpec{Backend: airunwayv1alpha1.KVCacheBackendLMCache}}
+	if _, err := tr.Transform(context.Background(), md); err == nil || !strings.Contains(err.Error(), "spec.caching.kv") {
+		t.Errorf("expected the KV cache to be rejected, got %v", err)
+	}
+}
+
+func TestTransformGPUTypes(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Resources = &airunwayv1alpha1.ResourceSpec{
+		GPU: &airunwayv1alpha1.GPUSpec{Count: 1, Types: []string{"NVIDIA-H100-80GB-HBM3", "NVIDIA-A100-SXM4-80GB"}},
+	}
+
+	resources, err := transformResources(tr, md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expressions, found, _ := unstructured.NestedSlice(resources[0].Object, "resource", "labelSelector", "matchExpressions")
+	if !found || len(expressions) != 1 {
+		t.Fatalf("expected a GPU product expression, got %v", expressions)
+	}
+	expression, _ := expressions[0].(map[string]interface{})
+	if expression["key"] != airunwayv1alpha1.LabelGPUProduct || len(expression["values"].([]interface{})) != 2 {
+		t.Errorf("expected the GPU types on %s, got %v", airunwayv1alpha1.LabelGPUProduct, expression)
+	}
+}
+
+func TestTransformPropagatedMetadata(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeLlamaCpp
+	md.Spec.Image = "my-image:latest"
+	md.Spec.Labels = map[string]string{"cost-center": "ml-42"}
+	md.Spec.Annotations = map[string]string{"example.com/owner": "search-team"}
+
+	resources, err := transformResources(tr, md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ws := resources[0]
+	if ws.GetLabels()["cost-center"] != "ml-42" || ws.GetAnnotations()["example.com/owner"] != "search-team" {
+		t.Errorf("expected propagated metadata on the Workspace, got %v %v", ws.GetLabels(), ws.GetAnnotations())
+	}
+	labels, _, _ := unstructured.NestedStringMap(ws.Object, "inference", "template", "metadata", "labels")
+	if labels["cost-center"] != "ml-42" || labels[airunwayv1alpha1.LabelModelDeployment] != "test-model" {
+		t.Errorf("expected propagated labels on the llama.cpp pod template, got %v", labels)
+	}
+}
@@ -36,6 +36,7 @@ import (
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
 	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/pkg/provider"
 	kuberay "github.com/kaito-project/airunway/providers/kuberay"
 )
 
@@ -118,6 +119,8 @@ func main() {
 
 	// Set up the KubeRay provider reconciler
 	reconciler := kuberay.NewKubeRayProviderReconciler(mgr.GetClient(), mgr.GetScheme())
+	reconciler.Recorder = mgr.GetEventRecorder(kuberay.FieldManager)
+	reconciler.Events = provider.NewEventMirror(mgr.GetAPIReader(), reconciler.Recorder)
 	if err := reconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "KubeRayProvider")
 		os.Exit(1)
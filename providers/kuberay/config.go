@@ -31,6 +31,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/pkg/provider"
 )
 
 const (
@@ -47,6 +48,10 @@ const (
 	HeartbeatInterval = 1 * time.Minute
 
 	rayServiceResource = "rayservices"
+	rayServiceCRD      = rayServiceResource + "." + RayAPIGroup
+
+	// minKubeRayOperatorVersion is the first KubeRay operator serving the ray.io/v1 API
+	minKubeRayOperatorVersion = "1.0.0"
 )
 
 // ProviderConfigManager handles registration and heartbeat for the KubeRay provider
@@ -66,6 +71,27 @@ func NewProviderConfigManager(c client.Client, discoveryClients ...discovery.Dis
 	return manager
 }
 
+// SupportedFields lists the ModelDeployment spec fields the transformer carries into the
+// RayService. It is published in status.supportedFields so the core controller can
+// warn about fields this provider drops.
+var SupportedFields = []string{
+	provider.FieldImage,
+	provider.FieldEnv,
+	provider.FieldPodTemplate,
+	provider.FieldHuggingFaceToken,
+	provider.FieldSecretsRollout,
+	provider.FieldIdentity,
+	provider.FieldGangScheduling,
+	provider.FieldChatTemplate,
+	provider.FieldTokenizer,
+	provider.FieldServedName,
+	provider.FieldLoadFormat,
+	provider.FieldContextLength,
+	provider.FieldEngineArgs,
+	provider.FieldTrustRemoteCode,
+	provider.FieldLogSink,
+}
+
 // GetProviderConfigSpec returns the InferenceProviderConfigSpec for KubeRay
 func GetProviderConfigSpec() airunwayv1alpha1.InferenceProviderConfigSpec {
 	return airunwayv1alpha1.InferenceProviderConfigSpec{
@@ -79,6 +105,10 @@ func GetProviderConfigSpec() airunwayv1alpha1.InferenceProviderConfigSpec {
 			},
 			CPUSupport: false,
 			GPUSupport: true,
+			// The model is part of serveConfigV2, which KubeRay applies to the running cluster
+			HotModelSwapEngines: []airunwayv1alpha1.EngineType{
+				airunwayv1alpha1.EngineTypeVLLM,
+			},
 		},
 		SelectionRules: []airunwayv1alpha1.SelectionRule{
 			{
@@ -86,6 +116,10 @@ func GetProviderConfigSpec() airunwayv1alpha1.InferenceProviderConfigSpec {
 				Priority:  80,
 			},
 		},
+		Compatibility: &airunwayv1alpha1.ProviderCompatibility{
+			CRDVersions:        []string{RayAPIGroup + "/" + RayAPIVersion},
+			MinOperatorVersion: minKubeRayOperatorVersion,
+		},
 	}
 }
 
@@ -135,12 +169,17 @@ func (m *ProviderConfigManager) Register(ctx context.Context) error {
 		return fmt.Errorf("failed to build annotations: %w", err)
 	}
 
+	spec := GetProviderConfigSpec()
+	if err := provider.ValidateCompatibility(spec.Compatibility); err != nil {
+		return fmt.Errorf("invalid provider compatibility: %w", err)
+	}
+
 	config := &airunwayv1alpha1.InferenceProviderConfig{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        ProviderConfigName,
 			Annotations: annotations,
 		},
-		Spec: GetProviderConfigSpec(),
+		Spec: spec,
 	}
 
 	existing := &airunwayv1alpha1.InferenceProviderConfig{}
@@ -154,7 +193,12 @@ func (m *ProviderConfigManager) Register(ctx context.Context) error {
 	} else if err != nil {
 		return fmt.Errorf("failed to get InferenceProviderConfig: %w", err)
 	} else {
+		// namespaceSelector, imageUpgrade and imagePolicy are set by cluster admins, not the provider
+		namespaceSelector, imageUpgrade, imagePolicy := existing.Spec.NamespaceSelector, existing.Spec.ImageUpgrade, existing.Spec.ImagePolicy
 		existing.Spec = config.Spec
+		existing.Spec.NamespaceSelector = namespaceSelector
+		existing.Spec.ImageUpgrade = imageUpgrade
+		existing.Spec.ImagePolicy = imagePolicy
 		if existing.Annotations == nil {
 			existing.Annotations = make(map[string]string)
 		}
@@ -168,22 +212,19 @@ func (m *ProviderConfigManager) Register(ctx context.Context) error {
 	}
 
 	// Update status — retry briefly after create to allow cache to sync
-	ready := m.checkBackendCRDInstalled()
 	var statusErr error
 	for i := 0; i < 5; i++ {
-		statusErr = m.UpdateStatus(ctx, ready)
+		statusErr = m.UpdateStatus(ctx, true)
 		if statusErr == nil {
 			break
 		}
 		time.Sleep(time.Duration(i+1) * 200 * time.Millisecond)
 	}
-	if !ready {
-		logger.Info("Backend CRD not installed, provider registered as not ready", "group", RayAPIGroup, "kind", RayServiceKind)
-	}
 	return statusErr
 }
 
-// UpdateStatus updates the status of the InferenceProviderConfig
+// UpdateStatus updates the status of the InferenceProviderConfig. The provider is reported
+// not ready when the upstream CRDs are missing or do not match spec.compatibility.
 func (m *ProviderConfigManager) UpdateStatus(ctx context.Context, ready bool) error {
 	config := &airunwayv1alpha1.InferenceProviderConfig{}
 	if err := m.client.Get(ctx, types.NamespacedName{Name: ProviderConfigName}, config); err != nil {
@@ -191,12 +232,31 @@ func (m *ProviderConfigManager) UpdateStatus(ctx context.Context, ready bool) er
 	}
 
 	now := metav1.Now()
-	config.Status = airunwayv1alpha1.InferenceProviderConfigStatus{
-		Ready:              ready,
-		Version:            ProviderVersion,
-		LastHeartbeat:      &now,
-		UpstreamCRDVersion: "ray.io/v1",
+	status := airunwayv1alpha1.InferenceProviderConfigStatus{
+		Ready:                   ready,
+		Version:                 ProviderVersion,
+		LastHeartbeat:           &now,
+		LastHeartbeatTime:       &now,
+		ObservedProviderVersion: ProviderVersion,
+		UpstreamCRDVersion:      "ray.io/v1",
+		SupportedFields:         SupportedFields,
+		ObservedGeneration:      config.Status.ObservedGeneration,
+		Conditions:              config.Status.Conditions,
+	}
+	served, operatorVersion := m.detectUpstreamVersions(ctx)
+	if !provider.SetUpstreamCompatibleCondition(&status, config.Spec.Compatibility, RayServiceKind, served, operatorVersion) {
+		// Applying RayServices would fail on the API version, so take the provider out of
+		// selection instead
+		status.Ready = false
+	}
+	missing, probeErr := m.missingUpstreamCRDs()
+	if !provider.SetUpstreamInstalledCondition(&status, missing, probeErr) {
+		// RayServices would be rejected at apply time, so take the provider out of selection
+		// until the CRD is installed
+		log.FromContext(ctx).Info("Upstream CRDs not available, reporting not ready", "missing", missing, "error", probeErr)
+		status.Ready = false
 	}
+	config.Status = status
 
 	if err := m.client.Status().Update(ctx, config); err != nil {
 		return fmt.Errorf("failed to update InferenceProviderConfig status: %w", err)
@@ -205,36 +265,36 @@ func (m *ProviderConfigManager) UpdateStatus(ctx context.Context, ready bool) er
 	return nil
 }
 
-// checkBackendCRDInstalled checks if the upstream RayService CRD is installed
-func (m *ProviderConfigManager) checkBackendCRDInstalled() bool {
-	if m.discoveryClient != nil {
-		return hasAPIResource(m.discoveryClient, RayAPIGroup, RayAPIVersion, rayServiceResource)
-	}
+// detectUpstreamVersions returns the API versions the cluster serves RayServices in and the
+// KubeRay operator version, when detectable. Detection failures leave them empty.
+func (m *ProviderConfigManager) detectUpstreamVersions(ctx context.Context) ([]string, string) {
+	logger := log.FromContext(ctx)
 
-	mapper := m.client.RESTMapper()
-	if mapper == nil {
-		return false
+	var served []string
+	if m.discoveryClient != nil {
+		var err error
+		if served, err = provider.ServedVersions(m.discoveryClient, RayAPIGroup, rayServiceResource); err != nil {
+			logger.Info("Could not discover RayService API versions", "error", err.Error())
+		}
+	} else if mapper := m.client.RESTMapper(); mapper != nil {
+		served = provider.MappedVersions(mapper, schema.GroupKind{Group: RayAPIGroup, Kind: RayServiceKind})
 	}
-	_, err := mapper.RESTMapping(schema.GroupKind{
-		Group: RayAPIGroup,
-		Kind:  RayServiceKind,
-	}, RayAPIVersion)
-	return err == nil
-}
 
-func hasAPIResource(discoveryClient discovery.DiscoveryInterface, group, version, resource string) bool {
-	resources, err := discoveryClient.ServerResourcesForGroupVersion(fmt.Sprintf("%s/%s", group, version))
+	operatorVersion, err := provider.OperatorVersionFromCRD(ctx, m.client, rayServiceCRD)
 	if err != nil {
-		return false
-	}
-
-	for _, apiResource := range resources.APIResources {
-		if apiResource.Name == resource {
-			return true
-		}
+		logger.Info("Could not read KubeRay operator version", "error", err.Error())
 	}
+	return served, operatorVersion
+}
 
-	return false
+// missingUpstreamCRDs probes the cluster for the upstream CRDs the provider creates
+// resources of
+func (m *ProviderConfigManager) missingUpstreamCRDs() ([]provider.UpstreamCRD, error) {
+	return provider.MissingUpstreamCRDs(m.discoveryClient, m.client.RESTMapper(), provider.UpstreamCRD{
+		Group:    RayAPIGroup,
+		Resource: rayServiceResource,
+		Kind:     RayServiceKind,
+	})
 }
 
 // StartHeartbeat starts a goroutine that periodically updates the provider heartbeat
@@ -251,11 +311,7 @@ func (m *ProviderConfigManager) StartHeartbeat(ctx context.Context) {
 				logger.Info("Stopping heartbeat goroutine")
 				return
 			case <-ticker.C:
-				ready := m.checkBackendCRDInstalled()
-				if !ready {
-					logger.Info("Backend CRD not installed, reporting not ready", "group", RayAPIGroup, "kind", RayServiceKind)
-				}
-				if err := m.UpdateStatus(ctx, ready); err != nil {
+				if err := m.UpdateStatus(ctx, true); err != nil {
 					logger.Error(err, "Failed to update heartbeat")
 				}
 			}
@@ -0,0 +1,62 @@
+package kuberay
+
+import (
+	"testing"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/providers/conformance"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// conformanceStatus adapts StatusTranslator to the conformance suite
+type conformanceStatus struct {
+	*StatusTranslator
+}
+
+func (s conformanceStatus) TranslateStatus(upstream *unstructured.Unstructured) (*conformance.StatusResult, error) {
+	result, err := s.StatusTranslator.TranslateStatus(upstream)
+	if err != nil {
+		return nil, err
+	}
+	return (*conformance.StatusResult)(result), nil
+}
+
+func TestConformance(t *testing.T) {
+	conformance.Suite{
+		Transformer:      NewTransformer(),
+		StatusTranslator: conformanceStatus{NewStatusTranslator()},
+		StatusCases: []conformance.StatusCase{
+			{
+				Name: "ready",
+				Status: map[string]interface{}{
+					"conditions": []interface{}{
+						map[string]interface{}{"type": conditionRayServiceReady, "status": "True"},
+					},
+				},
+				Phase: airunwayv1alpha1.DeploymentPhaseRunning,
+			},
+			{
+				Name: "applications deploying",
+				Status: map[string]interface{}{
+					"activeServiceStatus": map[string]interface{}{
+						"applicationStatuses": map[string]interface{}{
+							"llm": map[string]interface{}{"status": string(AppStatusDeploying)},
+						},
+					},
+				},
+				Phase: airunwayv1alpha1.DeploymentPhaseDeploying,
+			},
+			{
+				Name: "application failed",
+				Status: map[string]interface{}{
+					"activeServiceStatus": map[string]interface{}{
+						"applicationStatuses": map[string]interface{}{
+							"llm": map[string]interface{}{"status": string(AppStatusDeployFailed), "message": "out of memory"},
+						},
+					},
+				},
+				Phase: airunwayv1alpha1.DeploymentPhaseFailed,
+			},
+		},
+	}.Run(t)
+}
@@ -30,6 +30,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
 	ctrlbuilder "sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -41,6 +42,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/pkg/provider"
 )
 
 const (
@@ -66,6 +68,12 @@ type KubeRayProviderReconciler struct {
 	Scheme           *runtime.Scheme
 	Transformer      *Transformer
 	StatusTranslator *StatusTranslator
+
+	// Recorder emits ResourceUpdated events on ModelDeployments. When nil, no events are emitted.
+	Recorder events.EventRecorder
+
+	// Events mirrors upstream Warning events onto ModelDeployments. When nil, none are mirrored.
+	Events *provider.EventMirror
 }
 
 // NewKubeRayProviderReconciler creates a new KubeRay provider reconciler
@@ -85,6 +93,10 @@ func NewKubeRayProviderReconciler(client client.Client, scheme *runtime.Scheme)
 // +kubebuilder:rbac:groups=airunway.ai,resources=inferenceproviderconfigs/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=ray.io,resources=rayservices,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=ray.io,resources=rayservices/status,verbs=get
+// +kubebuilder:rbac:groups=scheduling.volcano.sh,resources=podgroups,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=events,verbs=list
 
 // Reconcile handles the reconciliation loop for ModelDeployments assigned to the KubeRay provider
 func (r *KubeRayProviderReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -98,20 +110,33 @@ func (r *KubeRayProviderReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 
 	// Only process if this provider is selected
 	if md.Status.Provider == nil || md.Status.Provider.Name != ProviderName {
+		// Delete the resources of a deployment migrated to another provider, or deleted
+		// while migrating away from this one
+		if provider.Released(&md, ProviderName, FinalizerName) {
+			return r.handleDeletion(ctx, &md)
+		}
 		return ctrl.Result{}, nil
 	}
 
 	logger.Info("Reconciling ModelDeployment for KubeRay provider", "name", md.Name, "namespace", md.Namespace)
 
-	// Check for pause annotation
-	if md.Annotations != nil && md.Annotations["airunway.ai/reconcile-paused"] == "true" {
+	// Handle deletion, even when paused, so the finalizer never blocks removal
+	if !md.DeletionTimestamp.IsZero() {
+		return r.handleDeletion(ctx, &md)
+	}
+
+	// Leave provider resources untouched while paused; the core controller
+	// reports the PausedReconciliation condition
+	if md.IsPaused() {
 		logger.Info("Reconciliation paused", "name", md.Name)
 		return ctrl.Result{}, nil
 	}
 
-	// Handle deletion
-	if !md.DeletionTimestamp.IsZero() {
-		return r.handleDeletion(ctx, &md)
+	// Create nothing until Kueue admits the deployment; the core controller moves it out of
+	// the Queued phase once admitted
+	if md.IsQueued() {
+		logger.Info("Waiting for Kueue admission", "name", md.Name)
+		return ctrl.Result{}, nil
 	}
 
 	// Add finalizer if not present
@@ -131,10 +156,37 @@ func (r *KubeRayProviderReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		md.Status.Message = err.Error()
 		return ctrl.Result{}, r.Status().Update(ctx, &md)
 	}
+
+	// Refuse to apply while the cluster runs KubeRay CRD or operator versions the transformer
+	// does not support, rather than failing on the API version during apply
+	mismatch, err := provider.UpstreamIncompatibility(ctx, r.Client, ProviderConfigName)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if mismatch != "" {
+		message := fmt.Sprintf("KubeRay upstream version mismatch: %s", mismatch)
+		logger.Info("Upstream versions incompatible", "name", md.Name, "message", mismatch)
+		r.setCondition(&md, airunwayv1alpha1.ConditionTypeProviderCompatible, metav1.ConditionFalse, provider.ReasonUpstreamVersionMismatch, message)
+		md.Status.Phase = airunwayv1alpha1.DeploymentPhaseFailed
+		md.Status.Message = message
+		if statusErr := r.Status().Update(ctx, &md); statusErr != nil {
+			return ctrl.Result{}, statusErr
+		}
+		return ctrl.Result{RequeueAfter: HeartbeatInterval}, nil
+	}
 	r.setCondition(&md, airunwayv1alpha1.ConditionTypeProviderCompatible, metav1.ConditionTrue, "CompatibilityVerified", "Configuration compatible with KubeRay")
 
 	// Transform ModelDeployment to RayService
-	resources, err := r.Transformer.Transform(ctx, &md)
+	// Keep the previous default image while spec.imageUpgrade of the provider config staggers
+	// the rollout of a new one
+	target, image, err := provider.HoldImageUpgrade(ctx, r.Client, ProviderConfigName, &md, r.Transformer.DefaultImage)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	result, err := r.Transformer.Transform(ctx, target)
+	if err == nil {
+		err = result.Validate()
+	}
 	if err != nil {
 		logger.Error(err, "Failed to transform ModelDeployment", "name", md.Name)
 		r.setCondition(&md, airunwayv1alpha1.ConditionTypeResourceCreated, metav1.ConditionFalse, "TransformFailed", err.Error())
@@ -143,35 +195,57 @@ func (r *KubeRayProviderReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return ctrl.Result{}, r.Status().Update(ctx, &md)
 	}
 
-	// Create or update the RayService
-	for _, resource := range resources {
-		if err := r.createOrUpdateResource(ctx, resource, &md); err != nil {
-			logger.Error(err, "Failed to create/update resource", "name", resource.GetName(), "kind", resource.GetKind())
-			reason := "CreateFailed"
-			if isResourceConflict(err) {
-				reason = "ResourceConflict"
-				r.setCondition(&md, airunwayv1alpha1.ConditionTypeReady, metav1.ConditionFalse, "ResourceConflict", err.Error())
-			}
-			r.setCondition(&md, airunwayv1alpha1.ConditionTypeResourceCreated, metav1.ConditionFalse, reason, err.Error())
-			md.Status.Phase = airunwayv1alpha1.DeploymentPhaseFailed
-			md.Status.Message = fmt.Sprintf("Failed to create RayService: %s", err.Error())
-			return ctrl.Result{}, r.Status().Update(ctx, &md)
+	// Check the images of the rendered resources, which include component, sidecar and
+	// override images, and pin signed images to their verified digest
+	violation, err := provider.EnforceImagePolicy(ctx, r.Client, ProviderConfigName, result.Resources)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if violation != "" {
+		r.setCondition(&md, airunwayv1alpha1.ConditionTypeResourceCreated, metav1.ConditionFalse, provider.ReasonImagePolicyViolation, violation)
+		md.Status.Phase = airunwayv1alpha1.DeploymentPhaseFailed
+		md.Status.Message = violation
+		return ctrl.Result{RequeueAfter: provider.ImagePolicyRecheckInterval}, r.Status().Update(ctx, &md)
+	}
+
+	// Create or update the RayService after the resources it depends on. Resources
+	// created by this apply are deleted again if a later one fails.
+	err = provider.ApplyResources(ctx, r.Client, result.Resources, func(ctx context.Context, resource *unstructured.Unstructured) (bool, error) {
+		return r.createOrUpdateResource(ctx, resource, &md)
+	})
+	md.Status.Provider.PartialApply = provider.PartialApply(err)
+	if err != nil {
+		logger.Error(err, "Failed to create/update resources", "name", md.Name)
+		reason := "CreateFailed"
+		if isResourceConflict(err) {
+			reason = "ResourceConflict"
+			r.setCondition(&md, airunwayv1alpha1.ConditionTypeReady, metav1.ConditionFalse, "ResourceConflict", err.Error())
 		}
+		r.setCondition(&md, airunwayv1alpha1.ConditionTypeResourceCreated, metav1.ConditionFalse, reason, err.Error())
+		md.Status.Phase = airunwayv1alpha1.DeploymentPhaseFailed
+		md.Status.Message = fmt.Sprintf("Failed to create RayService: %s", err.Error())
+		return ctrl.Result{}, r.Status().Update(ctx, &md)
 	}
 
 	r.setCondition(&md, airunwayv1alpha1.ConditionTypeResourceCreated, metav1.ConditionTrue, "ResourceCreated", "RayService created successfully")
+	provider.RecordDefaultImage(&md, image)
 
 	// Update provider status
 	md.Status.Provider.ResourceName = md.Name
 	md.Status.Provider.ResourceKind = RayServiceKind
 
 	// Sync status from upstream resource
-	if len(resources) > 0 {
-		if err := r.syncStatus(ctx, &md, resources[0]); err != nil {
+	if primary := result.Primary(); primary != nil {
+		if err := r.syncStatus(ctx, &md, primary); err != nil {
 			logger.Error(err, "Failed to sync status", "name", md.Name)
 		}
 	}
 
+	// Mirror upstream warnings, e.g. on pods users cannot read, onto the ModelDeployment
+	if err := r.Events.Mirror(ctx, &md); err != nil {
+		logger.Error(err, "Failed to mirror upstream events", "name", md.Name)
+	}
+
 	// Set phase to Deploying if not already Running or Failed
 	if md.Status.Phase != airunwayv1alpha1.DeploymentPhaseRunning &&
 		md.Status.Phase != airunwayv1alpha1.DeploymentPhaseFailed {
@@ -243,7 +317,7 @@ func verifyOwnerReference(existing *unstructured.Unstructured, mdUID types.UID)
 }
 
 // createOrUpdateResource creates or updates an unstructured resource
-func (r *KubeRayProviderReconciler) createOrUpdateResource(ctx context.Context, resource *unstructured.Unstructured, md *airunwayv1alpha1.ModelDeployment) error {
+func (r *KubeRayProviderReconciler) createOrUpdateResource(ctx context.Context, resource *unstructured.Unstructured, md *airunwayv1alpha1.ModelDeployment) (bool, error) {
 	logger := log.FromContext(ctx)
 
 	// Check if resource exists
@@ -258,28 +332,35 @@ func (r *KubeRayProviderReconciler) createOrUpdateResource(ctx context.Context,
 	if errors.IsNotFound(err) {
 		// Create new resource
 		logger.Info("Creating resource", "kind", resource.GetKind(), "name", resource.GetName())
-		return r.Create(ctx, resource)
+		return true, r.Create(ctx, resource)
 	}
 	if err != nil {
-		return fmt.Errorf("failed to get existing resource: %w", err)
+		return false, fmt.Errorf("failed to get existing resource: %w", err)
 	}
 
 	// Verify ownership before updating
 	if err := verifyOwnerReference(existing, md.UID); err != nil {
-		return err
+		return false, err
 	}
 
-	// Update existing resource if spec has changed
+	// Update existing resource if spec, or data for a ConfigMap, has changed
 	existingSpec, _, _ := unstructured.NestedMap(existing.Object, "spec")
 	newSpec, _, _ := unstructured.NestedMap(resource.Object, "spec")
+	existingData, _, _ := unstructured.NestedMap(existing.Object, "data")
+	newData, _, _ := unstructured.NestedMap(resource.Object, "data")
 
-	if !equality.Semantic.DeepEqual(existingSpec, newSpec) {
+	if !equality.Semantic.DeepEqual(existingSpec, newSpec) || !equality.Semantic.DeepEqual(existingData, newData) {
 		logger.Info("Updating resource", "kind", resource.GetKind(), "name", resource.GetName())
 		resource.SetResourceVersion(existing.GetResourceVersion())
-		return r.Update(ctx, resource)
+		if err := r.Update(ctx, resource); err != nil {
+			return false, err
+		}
+		if err := provider.RecordResourceChange(md, r.Recorder, existing, resource, "spec", "data"); err != nil {
+			logger.Error(err, "Failed to record resource change", "kind", resource.GetKind(), "name", resource.GetName())
+		}
 	}
 
-	return nil
+	return false, nil
 }
 
 // syncStatus fetches the upstream resource and syncs its status to the ModelDeployment
@@ -325,7 +406,7 @@ func (r *KubeRayProviderReconciler) syncStatus(ctx context.Context, md *airunway
 	return nil
 }
 
-// handleDeletion handles the deletion of a ModelDeployment
+// handleDeletion handles the deletion of a ModelDeployment, or its migration to another provider
 func (r *KubeRayProviderReconciler) handleDeletion(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
@@ -335,10 +416,12 @@ func (r *KubeRayProviderReconciler) handleDeletion(ctx context.Context, md *airu
 
 	logger.Info("Handling deletion", "name", md.Name, "namespace", md.Namespace)
 
-	// Update phase to Terminating
-	md.Status.Phase = airunwayv1alpha1.DeploymentPhaseTerminating
-	if err := r.Status().Update(ctx, md); err != nil {
-		logger.Error(err, "Failed to update status to Terminating")
+	// Update phase to Terminating, unless the deployment was only migrated to another provider
+	if !md.DeletionTimestamp.IsZero() {
+		md.Status.Phase = airunwayv1alpha1.DeploymentPhaseTerminating
+		if err := r.Status().Update(ctx, md); err != nil {
+			logger.Error(err, "Failed to update status to Terminating")
+		}
 	}
 
 	// Delete the upstream resource
@@ -371,7 +454,7 @@ func (r *KubeRayProviderReconciler) handleDeletion(ctx context.Context, md *airu
 				logger.Error(err, "Failed to delete RayService")
 
 				// Check if we should force-remove the finalizer
-				deletionTime := md.DeletionTimestamp.Time
+				deletionTime := provider.CleanupStart(md)
 				if time.Since(deletionTime) > FinalizerTimeout {
 					logger.Info("Finalizer timeout reached, removing finalizer without cleanup")
 					controllerutil.RemoveFinalizer(md, FinalizerName)
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/pkg/provider"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -280,6 +281,27 @@ func TestReconcilePaused(t *testing.T) {
 	}
 }
 
+func TestReconcileQueued(t *testing.T) {
+	scheme := newScheme()
+	md := newMDForController("test", "default")
+	md.Status.Phase = airunwayv1alpha1.DeploymentPhaseQueued
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(md).WithStatusSubresource(md).Build()
+	r := NewKubeRayProviderReconciler(c, scheme)
+
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{
+		NamespacedName: types.NamespacedName{Name: "test", Namespace: "default"},
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var updated airunwayv1alpha1.ModelDeployment
+	_ = c.Get(context.Background(), types.NamespacedName{Name: "test", Namespace: "default"}, &updated)
+	if controllerutil.ContainsFinalizer(&updated, FinalizerName) {
+		t.Error("expected no finalizer to be added while waiting for Kueue admission")
+	}
+}
+
 func TestReconcileAddsFinalizer(t *testing.T) {
 	scheme := newScheme()
 	md := newMDForController("test", "default")
@@ -327,6 +349,46 @@ func TestReconcileIncompatibleEngine(t *testing.T) {
 	}
 }
 
+func TestReconcileUpstreamVersionMismatch(t *testing.T) {
+	scheme := newScheme()
+	md := newMDForController("test", "default")
+	controllerutil.AddFinalizer(md, FinalizerName)
+	config := &airunwayv1alpha1.InferenceProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: ProviderConfigName},
+		Status: airunwayv1alpha1.InferenceProviderConfigStatus{
+			Conditions: []metav1.Condition{{
+				Type:    airunwayv1alpha1.ConditionTypeUpstreamCompatible,
+				Status:  metav1.ConditionFalse,
+				Reason:  provider.ReasonUpstreamVersionMismatch,
+				Message: "operator version 0.9.0 is older than the minimum supported 1.0.0",
+			}},
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(md, config).WithStatusSubresource(md).Build()
+	r := NewKubeRayProviderReconciler(c, scheme)
+
+	result, err := r.Reconcile(context.Background(), ctrl.Request{
+		NamespacedName: types.NamespacedName{Name: "test", Namespace: "default"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.RequeueAfter != HeartbeatInterval {
+		t.Errorf("expected requeue after %s, got %s", HeartbeatInterval, result.RequeueAfter)
+	}
+
+	var updated airunwayv1alpha1.ModelDeployment
+	_ = c.Get(context.Background(), types.NamespacedName{Name: "test", Namespace: "default"}, &updated)
+	if updated.Status.Phase != airunwayv1alpha1.DeploymentPhaseFailed {
+		t.Errorf("expected Failed phase, got %s", updated.Status.Phase)
+	}
+	cond := apimeta.FindStatusCondition(updated.Status.Conditions, airunwayv1alpha1.ConditionTypeProviderCompatible)
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != provider.ReasonUpstreamVersionMismatch {
+		t.Errorf("expected ProviderCompatible=False with reason %s, got %+v", provider.ReasonUpstreamVersionMismatch, cond)
+	}
+}
+
 func TestReconcileNilProvider(t *testing.T) {
 	scheme := newScheme()
 	md := newMDForController("test", "default")
@@ -372,6 +434,149 @@ func TestReconcileSuccessfulCreate(t *testing.T) {
 	}
 }
 
+func TestReconcileHoldsImageUpgrade(t *testing.T) {
+	scheme := newScheme()
+	const previousImage = "rayproject/ray-llm:2.54.0-py311-cu128"
+	recent := metav1.Now()
+	upgrading := newMDForController("upgrading", "default")
+	upgrading.UID = "upgrading"
+	upgrading.Status.Provider.DefaultImage = DefaultImage
+	upgrading.Status.Provider.DefaultImageTime = &recent
+	md := newMDForController("test", "default")
+	md.UID = "test"
+	md.Status.Provider.DefaultImage = previousImage
+	controllerutil.AddFinalizer(md, FinalizerName)
+	config := &airunwayv1alpha1.InferenceProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: ProviderConfigName},
+		Spec: airunwayv1alpha1.InferenceProviderConfigSpec{
+			ImageUpgrade: &airunwayv1alpha1.ImageUpgradeSpec{MaxConcurrent: 1},
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(md, upgrading, config).WithStatusSubresource(md).Build()
+	r := NewKubeRayProviderReconciler(c, scheme)
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{
+		NamespacedName: types.NamespacedName{Name: "test", Namespace: "default"},
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rs := &unstructured.Unstructured{}
+	setRayServiceGVK(rs)
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "test", Namespace: "default"}, rs); err != nil {
+		t.Fatalf("expected RayService to be created: %v", err)
+	}
+	head, _, _ := unstructured.NestedSlice(rs.Object, "spec", "rayClusterConfig", "headGroupSpec", "template", "spec", "containers")
+	if image := head[0].(map[string]interface{})["image"]; image != previousImage {
+		t.Errorf("expected the previous image %s to be kept, got %v", previousImage, image)
+	}
+
+	var updated airunwayv1alpha1.ModelDeployment
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "test", Namespace: "default"}, &updated); err != nil {
+		t.Fatal(err)
+	}
+	if updated.Status.Provider.DefaultImage != previousImage || updated.Spec.Image != "" {
+		t.Errorf("expected status.provider.defaultImage %s and no spec.image, got %+v", previousImage, updated.Status.Provider)
+	}
+	if !apimeta.IsStatusConditionTrue(updated.Status.Conditions, airunwayv1alpha1.ConditionTypeImageUpgradePending) {
+		t.Error("expected the ImageUpgradePending condition")
+	}
+}
+
+func TestReconcileImagePolicyViolation(t *testing.T) {
+	scheme := newScheme()
+	md := newMDForController("test", "default")
+	md.Spec.Image = "evil.io/ray-llm:latest"
+	controllerutil.AddFinalizer(md, FinalizerName)
+	config := &airunwayv1alpha1.InferenceProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: ProviderConfigName},
+		Spec: airunwayv1alpha1.InferenceProviderConfigSpec{
+			ImagePolicy: &airunwayv1alpha1.ImagePolicySpec{AllowedRegistries: []string{"docker.io/rayproject"}},
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(md, config).WithStatusSubresource(md).Build()
+	r := NewKubeRayProviderReconciler(c, scheme)
+	result, err := r.Reconcile(context.Background(), ctrl.Request{
+		NamespacedName: types.NamespacedName{Name: "test", Namespace: "default"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.RequeueAfter != provider.ImagePolicyRecheckInterval {
+		t.Errorf("expected a recheck after %v, got %v", provider.ImagePolicyRecheckInterval, result.RequeueAfter)
+	}
+
+	rs := &unstructured.Unstructured{}
+	setRayServiceGVK(rs)
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "test", Namespace: "default"}, rs); !apierrors.IsNotFound(err) {
+		t.Errorf("expected no RayService, got %v", err)
+	}
+
+	var updated airunwayv1alpha1.ModelDeployment
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "test", Namespace: "default"}, &updated); err != nil {
+		t.Fatal(err)
+	}
+	cond := apimeta.FindStatusCondition(updated.Status.Conditions, airunwayv1alpha1.ConditionTypeResourceCreated)
+	if cond == nil || cond.Reason != provider.ReasonImagePolicyViolation {
+		t.Errorf("expected ResourceCreated reason %s, got %+v", provider.ReasonImagePolicyViolation, cond)
+	}
+	if updated.Status.Phase != airunwayv1alpha1.DeploymentPhaseFailed {
+		t.Errorf("expected phase Failed, got %s", updated.Status.Phase)
+	}
+}
+
+func TestReconcileRollsBackPartialApply(t *testing.T) {
+	scheme := newScheme()
+	md := newMDForController("test", "default")
+	md.Spec.Model.ChatTemplate = &airunwayv1alpha1.ChatTemplateSource{Inline: "{{ messages }}"}
+	controllerutil.AddFinalizer(md, FinalizerName)
+
+	interceptorFuncs := interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			if u, ok := obj.(*unstructured.Unstructured); ok && u.GetKind() == RayServiceKind {
+				return apierrors.NewForbidden(schema.GroupResource{Group: RayAPIGroup, Resource: "rayservices"}, obj.GetName(), nil)
+			}
+			return c.Create(ctx, obj, opts...)
+		},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(md).
+		WithStatusSubresource(md).
+		WithInterceptorFuncs(interceptorFuncs).
+		Build()
+	r := NewKubeRayProviderReconciler(c, scheme)
+
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{
+		NamespacedName: types.NamespacedName{Name: "test", Namespace: "default"},
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The chat template ConfigMap applied before the RayService is deleted again
+	cm := &unstructured.Unstructured{}
+	cm.SetAPIVersion("v1")
+	cm.SetKind("ConfigMap")
+	err := c.Get(context.Background(), types.NamespacedName{Name: provider.ChatTemplateConfigMapName("test"), Namespace: "default"}, cm)
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("expected the ConfigMap to be rolled back, got %v", err)
+	}
+
+	var updated airunwayv1alpha1.ModelDeployment
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "test", Namespace: "default"}, &updated); err != nil {
+		t.Fatal(err)
+	}
+	if updated.Status.Phase != airunwayv1alpha1.DeploymentPhaseFailed {
+		t.Errorf("expected phase Failed, got %s", updated.Status.Phase)
+	}
+	partial := updated.Status.Provider.PartialApply
+	if partial == nil || partial.FailedResource != "RayService.ray.io/test" ||
+		len(partial.AppliedResources) != 1 || len(partial.RolledBackResources) != 1 {
+		t.Errorf("unexpected partial apply status %+v", partial)
+	}
+}
+
 func TestReconcileHandleDeletion(t *testing.T) {
 	scheme := newScheme()
 	md := newMDForController("test", "default")
@@ -506,7 +711,7 @@ func TestCreateOrUpdateResourceNew(t *testing.T) {
 	rs.SetNamespace("default")
 	rs.Object["spec"] = map[string]interface{}{"serveConfigV2": "test"}
 
-	err := r.createOrUpdateResource(context.Background(), rs, md)
+	_, err := r.createOrUpdateResource(context.Background(), rs, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -538,10 +743,14 @@ func TestCreateOrUpdateResourceUpdate(t *testing.T) {
 	updated.SetNamespace("default")
 	updated.Object["spec"] = map[string]interface{}{"serveConfigV2": "new"}
 
-	err := r.createOrUpdateResource(context.Background(), updated, md)
+	_, err := r.createOrUpdateResource(context.Background(), updated, md)
 	if err != nil {
 		t.Fatalf("unexpected error updating resource: %v", err)
 	}
+	if md.Status.LastAppliedChange == nil || len(md.Status.LastAppliedChange.Paths) != 1 ||
+		md.Status.LastAppliedChange.Paths[0] != "/spec/serveConfigV2" {
+		t.Errorf("expected change to /spec/serveConfigV2 to be recorded, got %+v", md.Status.LastAppliedChange)
+	}
 }
 
 func TestCreateOrUpdateResourceNoChange(t *testing.T) {
@@ -570,7 +779,7 @@ func TestCreateOrUpdateResourceNoChange(t *testing.T) {
 	same.SetNamespace("default")
 	same.Object["spec"] = map[string]interface{}{"serveConfigV2": "same"}
 
-	err := r.createOrUpdateResource(context.Background(), same, md)
+	_, err := r.createOrUpdateResource(context.Background(), same, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
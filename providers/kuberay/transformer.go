@@ -23,8 +23,10 @@ import (
 	"strings"
 
 	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/pkg/provider"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
 )
 
 const (
@@ -47,18 +49,41 @@ const (
 	DefaultHeadMemory = "16Gi"
 	// DefaultWorkerMemory is the default memory limit for worker nodes
 	DefaultWorkerMemory = "32Gi"
+
+	// ServeApplicationName is the name of the Ray Serve application serving the model
+	ServeApplicationName = "llm"
+	// ServeDeploymentName is the name of the vLLM deployment of the application
+	ServeDeploymentName = "VLLMDeployment"
+	// serveImportPath is the Serve application the image builds from MODEL_ID and
+	// VLLM_ENGINE_ARGS
+	serveImportPath = "vllm_serve:deployment"
 )
 
 // Transformer handles transformation of ModelDeployment to RayService
 type Transformer struct{}
 
+var _ provider.Transformer = (*Transformer)(nil)
+
 // NewTransformer creates a new KubeRay transformer
 func NewTransformer() *Transformer {
 	return &Transformer{}
 }
 
 // Transform converts a ModelDeployment to a RayService
-func (t *Transformer) Transform(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) ([]*unstructured.Unstructured, error) {
+func (t *Transformer) Transform(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) (*provider.TransformResult, error) {
+	if md.Spec.Engine.Device == airunwayv1alpha1.EngineDeviceCPU {
+		return nil, fmt.Errorf("kuberay provider does not support cpu inference")
+	}
+	if err := provider.KVOffloadCheck(md); err != nil {
+		return nil, err
+	}
+	if err := provider.KVCacheCheck(md); err != nil {
+		return nil, err
+	}
+	if err := provider.LoadFormatCheck(md); err != nil {
+		return nil, err
+	}
+
 	rs := &unstructured.Unstructured{}
 	rs.SetAPIVersion(fmt.Sprintf("%s/%s", RayAPIGroup, RayAPIVersion))
 	rs.SetKind(RayServiceKind)
@@ -79,10 +104,10 @@ func (t *Transformer) Transform(ctx context.Context, md *airunwayv1alpha1.ModelD
 
 	// Set labels
 	labels := map[string]string{
-		"airunway.ai/managed-by":   "airunway",
-		"airunway.ai/deployment":   md.Name,
-		"airunway.ai/model-source": string(md.Spec.Model.Source),
-		"airunway.ai/engine-type":  string(md.ResolvedEngineType()),
+		airunwayv1alpha1.LabelManagedBy:   airunwayv1alpha1.ManagedByAIRunway,
+		airunwayv1alpha1.LabelDeployment:  md.Name,
+		airunwayv1alpha1.LabelModelSource: string(md.Spec.Model.Source),
+		airunwayv1alpha1.LabelEngineType:  string(md.ResolvedEngineType()),
 	}
 	if md.Spec.PodTemplate != nil && md.Spec.PodTemplate.Metadata != nil {
 		for k, v := range md.Spec.PodTemplate.Metadata.Labels {
@@ -97,7 +122,8 @@ func (t *Transformer) Transform(ctx context.Context, md *airunwayv1alpha1.ModelD
 	}
 
 	// Build the spec
-	spec, err := t.buildSpec(md)
+	gang := provider.NewGangScheduling(md, rayClusterPods(md))
+	spec, err := t.buildSpec(md, gang)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build RayService spec: %w", err)
 	}
@@ -106,32 +132,47 @@ func (t *Transformer) Transform(ctx context.Context, md *airunwayv1alpha1.ModelD
 		return nil, fmt.Errorf("failed to set spec: %w", err)
 	}
 
-	return []*unstructured.Unstructured{rs}, nil
+	result := provider.NewTransformResult(rs)
+	gang.AddPodGroup(result)
+	provider.AddChatTemplateConfigMap(result, md)
+	if err := provider.AddLogSinkConfigMap(result, md); err != nil {
+		return nil, fmt.Errorf("failed to build the log sink ConfigMap: %w", err)
+	}
+	provider.ApplyPropagatedMetadata(result, md)
+	return result, nil
+}
+
+// rayClusterPods returns the number of pods in the Ray cluster: the head and all workers
+func rayClusterPods(md *airunwayv1alpha1.ModelDeployment) int32 {
+	scaling := md.Spec.Scaling
+	if md.Spec.Serving != nil && md.Spec.Serving.Mode == airunwayv1alpha1.ServingModeDisaggregated {
+		pods := int32(1)
+		if scaling != nil && scaling.Prefill != nil {
+			pods += scaling.Prefill.Replicas
+		}
+		if scaling != nil && scaling.Decode != nil {
+			pods += scaling.Decode.Replicas
+		}
+		return pods
+	}
+	if scaling != nil && scaling.Replicas > 0 {
+		return 1 + scaling.Replicas
+	}
+	return 2
 }
 
 // buildSpec creates the spec for a RayService
-func (t *Transformer) buildSpec(md *airunwayv1alpha1.ModelDeployment) (map[string]interface{}, error) {
+func (t *Transformer) buildSpec(md *airunwayv1alpha1.ModelDeployment, gang *provider.GangScheduling) (map[string]interface{}, error) {
 	spec := map[string]interface{}{}
 
-	// Build serveConfigV2
-	replicas := int64(1)
-	if md.Spec.Scaling != nil && md.Spec.Scaling.Replicas > 0 {
-		replicas = int64(md.Spec.Scaling.Replicas)
+	serveConfig, err := t.buildServeConfig(md)
+	if err != nil {
+		return nil, err
 	}
-
-	serveConfig := fmt.Sprintf(`applications:
-  - name: llm
-    route_prefix: /
-    import_path: vllm_serve:deployment
-    deployments:
-      - name: VLLMDeployment
-        num_replicas: %d
-`, replicas)
-
 	spec["serveConfigV2"] = serveConfig
 
 	// Build rayClusterConfig
-	rayClusterConfig, err := t.buildRayClusterConfig(md)
+	rayClusterConfig, err := t.buildRayClusterConfig(md, gang)
 	if err != nil {
 		return nil, err
 	}
@@ -140,8 +181,92 @@ func (t *Transformer) buildSpec(md *airunwayv1alpha1.ModelDeployment) (map[strin
 	return spec, nil
 }
 
+// serveConfig is the Ray Serve config of serveConfigV2
+type serveConfig struct {
+	Applications []serveApplication `json:"applications"`
+}
+
+type serveApplication struct {
+	Name        string            `json:"name"`
+	RoutePrefix string            `json:"route_prefix"`
+	ImportPath  string            `json:"import_path"`
+	RuntimeEnv  serveRuntimeEnv   `json:"runtime_env"`
+	Deployments []serveDeployment `json:"deployments"`
+}
+
+type serveRuntimeEnv struct {
+	EnvVars map[string]string `json:"env_vars"`
+}
+
+type serveDeployment struct {
+	Name            string           `json:"name"`
+	NumReplicas     int32            `json:"num_replicas"`
+	RayActorOptions *rayActorOptions `json:"ray_actor_options,omitempty"`
+}
+
+type rayActorOptions struct {
+	NumGPUs int32 `json:"num_gpus"`
+}
+
+// buildServeConfig generates serveConfigV2 from the spec. Each worker pod runs one replica of
+// the vLLM deployment with the GPUs of the pod, so scaling and GPU changes are applied to the
+// Serve application along with the worker groups.
+//
+// The model and engine args are passed through the application's runtime_env rather than
+// the cluster pods, so changing the model only changes serveConfigV2 and KubeRay redeploys
+// the Serve application on the running cluster instead of creating a new one.
+func (t *Transformer) buildServeConfig(md *airunwayv1alpha1.ModelDeployment) (string, error) {
+	deployment := serveDeployment{
+		Name:        ServeDeploymentName,
+		NumReplicas: rayClusterPods(md) - 1,
+	}
+	if gpus := serveReplicaGPUs(md); gpus > 0 {
+		deployment.RayActorOptions = &rayActorOptions{NumGPUs: gpus}
+	}
+	config := serveConfig{Applications: []serveApplication{{
+		Name:        ServeApplicationName,
+		RoutePrefix: "/",
+		ImportPath:  serveImportPath,
+		RuntimeEnv: serveRuntimeEnv{EnvVars: map[string]string{
+			"MODEL_ID":         md.Spec.Model.ID,
+			"VLLM_ENGINE_ARGS": t.buildEngineArgs(md),
+		}},
+		Deployments: []serveDeployment{deployment},
+	}}}
+	out, err := yaml.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal serveConfigV2: %w", err)
+	}
+	return string(out), nil
+}
+
+// serveReplicaGPUs returns the GPUs of one Serve replica: the GPUs of a worker pod, or in
+// disaggregated mode of the smaller of the prefill and decode pods, so every replica fits
+// on any worker
+func serveReplicaGPUs(md *airunwayv1alpha1.ModelDeployment) int32 {
+	if md.Spec.Serving != nil && md.Spec.Serving.Mode == airunwayv1alpha1.ServingModeDisaggregated {
+		var gpus int32
+		if md.Spec.Scaling == nil {
+			return 0
+		}
+		for _, comp := range []*airunwayv1alpha1.ComponentScalingSpec{md.Spec.Scaling.Prefill, md.Spec.Scaling.Decode} {
+			if comp == nil || comp.GPU == nil || comp.GPU.Count == 0 {
+				continue
+			}
+			if gpus == 0 || comp.GPU.Count < gpus {
+				gpus = comp.GPU.Count
+			}
+		}
+		return gpus
+	}
+	if md.Spec.Resources != nil && md.Spec.Resources.GPU != nil {
+		return md.Spec.Resources.GPU.Count
+	}
+	return 0
+}
+
 // buildRayClusterConfig creates the rayClusterConfig section
-func (t *Transformer) buildRayClusterConfig(md *airunwayv1alpha1.ModelDeployment) (map[string]interface{}, error) {
+func (t *Transformer) buildRayClusterConfig(md *airunwayv1alpha1.ModelDeployment, gang *provider.GangScheduling) (map[string]interface{}, error) {
 	config := map[string]interface{}{}
 
 	// Build head group spec
@@ -153,40 +278,95 @@ func (t *Transformer) buildRayClusterConfig(md *airunwayv1alpha1.ModelDeployment
 		servingMode = md.Spec.Serving.Mode
 	}
 
+	var workerGroups []interface{}
 	if servingMode == airunwayv1alpha1.ServingModeDisaggregated {
-		config["workerGroupSpecs"] = t.buildDisaggregatedWorkerGroups(md)
+		workerGroups = t.buildDisaggregatedWorkerGroups(md)
 	} else {
-		config["workerGroupSpecs"] = t.buildAggregatedWorkerGroup(md)
+		workerGroups = t.buildAggregatedWorkerGroup(md)
+	}
+	config["workerGroupSpecs"] = workerGroups
+
+	// Run head and workers as the workload identity ServiceAccount
+	applyIdentity(md, config["headGroupSpec"].(map[string]interface{}))
+	for _, group := range workerGroups {
+		applyIdentity(md, group.(map[string]interface{}))
+	}
+
+	// Schedule head and workers as one gang so a partial cluster cannot hold GPUs
+	gang.ApplyToPodTemplate(config["headGroupSpec"].(map[string]interface{})["template"].(map[string]interface{}))
+	for _, group := range workerGroups {
+		gang.ApplyToPodTemplate(group.(map[string]interface{})["template"].(map[string]interface{}))
+	}
+
+	// Mount the chat template and KV offload volume, and configure the KV cache, load
+	// credentials and stream draining, wherever the Serve application may run
+	provider.ApplyChatTemplateToPodTemplate(config["headGroupSpec"].(map[string]interface{})["template"].(map[string]interface{}), md)
+	provider.ApplyKVOffloadToPodTemplate(config["headGroupSpec"].(map[string]interface{})["template"].(map[string]interface{}), md)
+	provider.ApplyKVCacheToPodTemplate(config["headGroupSpec"].(map[string]interface{})["template"].(map[string]interface{}), md)
+	provider.ApplyLoadFormatToPodTemplate(config["headGroupSpec"].(map[string]interface{})["template"].(map[string]interface{}), md)
+	provider.ApplyStreamDrainToPodTemplate(config["headGroupSpec"].(map[string]interface{})["template"].(map[string]interface{}), md)
+	for _, group := range workerGroups {
+		provider.ApplyChatTemplateToPodTemplate(group.(map[string]interface{})["template"].(map[string]interface{}), md)
+		provider.ApplyKVOffloadToPodTemplate(group.(map[string]interface{})["template"].(map[string]interface{}), md)
+		provider.ApplyKVCacheToPodTemplate(group.(map[string]interface{})["template"].(map[string]interface{}), md)
+		provider.ApplyLoadFormatToPodTemplate(group.(map[string]interface{})["template"].(map[string]interface{}), md)
+		provider.ApplyStreamDrainToPodTemplate(group.(map[string]interface{})["template"].(map[string]interface{}), md)
+	}
+
+	// Ship the logs of head and workers to spec.observability.logSink
+	if err := provider.ApplyLogSinkToPodTemplate(config["headGroupSpec"].(map[string]interface{})["template"].(map[string]interface{}), md); err != nil {
+		return nil, fmt.Errorf("failed to add the log sink: %w", err)
+	}
+	for _, group := range workerGroups {
+		if err := provider.ApplyLogSinkToPodTemplate(group.(map[string]interface{})["template"].(map[string]interface{}), md); err != nil {
+			return nil, fmt.Errorf("failed to add the log sink: %w", err)
+		}
+	}
+
+	provider.ApplyPropagatedMetadataToPodTemplate(config["headGroupSpec"].(map[string]interface{})["template"].(map[string]interface{}), md)
+	for _, group := range workerGroups {
+		provider.ApplyPropagatedMetadataToPodTemplate(group.(map[string]interface{})["template"].(map[string]interface{}), md)
 	}
 
 	return config, nil
 }
 
-// buildHeadGroupSpec creates the head group spec
+// applyIdentity sets the ServiceAccount and workload identity labels from spec.identity
+// on the pod template of a head or worker group.
+func applyIdentity(md *airunwayv1alpha1.ModelDeployment, group map[string]interface{}) {
+	sa := md.ServiceAccountName()
+	if sa == "" {
+		return
+	}
+	template := group["template"].(map[string]interface{})
+	template["spec"].(map[string]interface{})["serviceAccountName"] = sa
+	labels := template["metadata"].(map[string]interface{})["labels"].(map[string]interface{})
+	for k, v := range md.IdentityPodLabels() {
+		labels[k] = v
+	}
+}
+
+// buildHeadGroupSpec creates the head group spec. The head runs the Ray Serve
+// proxy, so spec.serving.router cpu and memory size its container.
 func (t *Transformer) buildHeadGroupSpec(md *airunwayv1alpha1.ModelDeployment) map[string]interface{} {
 	image := t.getImage(md)
+	headCPU := DefaultHeadCPU
 	headMemory := DefaultHeadMemory
 	if md.Spec.Resources != nil && md.Spec.Resources.Memory != "" {
 		headMemory = md.Spec.Resources.Memory
 	}
-
-	// Build engine args
-	engineArgs := t.buildEngineArgs(md)
-
-	// Build env vars
-	envVars := []interface{}{
-		map[string]interface{}{
-			"name":  "MODEL_ID",
-			"value": md.Spec.Model.ID,
-		},
-		map[string]interface{}{
-			"name":  "VLLM_ENGINE_ARGS",
-			"value": engineArgs,
-		},
+	if md.Spec.Serving != nil && md.Spec.Serving.Router != nil {
+		if md.Spec.Serving.Router.CPU != "" {
+			headCPU = md.Spec.Serving.Router.CPU
+		}
+		if md.Spec.Serving.Router.Memory != "" {
+			headMemory = md.Spec.Serving.Router.Memory
+		}
 	}
 
-	// Add HF_TOKEN from secret if specified
-	envVars = append(envVars, t.buildEnvVars(md)...)
+	// Build env vars, including HF_TOKEN from secret if specified. The model is configured
+	// in serveConfigV2 so it can change without replacing the cluster.
+	envVars := append([]interface{}{}, t.buildEnvVars(md)...)
 
 	headGroupSpec := map[string]interface{}{
 		"rayStartParams": map[string]interface{}{
@@ -195,7 +375,7 @@ func (t *Transformer) buildHeadGroupSpec(md *airunwayv1alpha1.ModelDeployment) m
 		"template": map[string]interface{}{
 			"metadata": map[string]interface{}{
 				"labels": map[string]interface{}{
-					"airunway.ai/model-deployment": md.Name,
+					airunwayv1alpha1.LabelModelDeployment: md.Name,
 				},
 			},
 			"spec": map[string]interface{}{
@@ -205,7 +385,7 @@ func (t *Transformer) buildHeadGroupSpec(md *airunwayv1alpha1.ModelDeployment) m
 						"image": image,
 						"resources": map[string]interface{}{
 							"limits": map[string]interface{}{
-								"cpu":    DefaultHeadCPU,
+								"cpu":    headCPU,
 								"memory": headMemory,
 							},
 						},
@@ -253,7 +433,7 @@ func (t *Transformer) buildAggregatedWorkerGroup(md *airunwayv1alpha1.ModelDeplo
 		"template": map[string]interface{}{
 			"metadata": map[string]interface{}{
 				"labels": map[string]interface{}{
-					"airunway.ai/model-deployment": md.Name,
+					airunwayv1alpha1.LabelModelDeployment: md.Name,
 				},
 			},
 			"spec": map[string]interface{}{
@@ -261,21 +441,21 @@ func (t *Transformer) buildAggregatedWorkerGroup(md *airunwayv1alpha1.ModelDeplo
 					map[string]interface{}{
 						"name":  "ray-worker",
 						"image": image,
-						"resources": map[string]interface{}{
-							"limits": limits,
-						},
+						"resources": workerResources(limits, md.Spec.Resources),
 					},
 				},
 			},
 		},
 	}
+	if affinity := provider.WithGPUTypeAffinity(nil, md); affinity != nil {
+		workerGroup["template"].(map[string]interface{})["spec"].(map[string]interface{})["affinity"] = affinity
+	}
 
 	return []interface{}{workerGroup}
 }
 
 // buildDisaggregatedWorkerGroups creates separate prefill and decode worker groups
 func (t *Transformer) buildDisaggregatedWorkerGroups(md *airunwayv1alpha1.ModelDeployment) []interface{} {
-	image := t.getImage(md)
 	var workerGroups []interface{}
 
 	// Build prefill worker group
@@ -304,22 +484,23 @@ func (t *Transformer) buildDisaggregatedWorkerGroups(md *airunwayv1alpha1.ModelD
 			"template": map[string]interface{}{
 				"metadata": map[string]interface{}{
 					"labels": map[string]interface{}{
-						"airunway.ai/model-deployment": md.Name,
+						airunwayv1alpha1.LabelModelDeployment: md.Name,
 					},
 				},
 				"spec": map[string]interface{}{
 					"containers": []interface{}{
 						map[string]interface{}{
 							"name":  "ray-worker",
-							"image": image,
-							"resources": map[string]interface{}{
-								"limits": prefillLimits,
-							},
+							"image": t.componentImage(md, prefillSpec),
+							"resources": workerResources(prefillLimits, componentResources(prefillSpec)),
 						},
 					},
 				},
 			},
 		}
+		if affinity := provider.PlacementAffinity(md, map[string]interface{}{airunwayv1alpha1.LabelModelDeployment: md.Name}); affinity != nil {
+			prefillGroup["template"].(map[string]interface{})["spec"].(map[string]interface{})["affinity"] = affinity
+		}
 		workerGroups = append(workerGroups, prefillGroup)
 	}
 
@@ -349,28 +530,50 @@ func (t *Transformer) buildDisaggregatedWorkerGroups(md *airunwayv1alpha1.ModelD
 			"template": map[string]interface{}{
 				"metadata": map[string]interface{}{
 					"labels": map[string]interface{}{
-						"airunway.ai/model-deployment": md.Name,
+						airunwayv1alpha1.LabelModelDeployment: md.Name,
 					},
 				},
 				"spec": map[string]interface{}{
 					"containers": []interface{}{
 						map[string]interface{}{
 							"name":  "ray-worker",
-							"image": image,
-							"resources": map[string]interface{}{
-								"limits": decodeLimits,
-							},
+							"image": t.componentImage(md, decodeSpec),
+							"resources": workerResources(decodeLimits, componentResources(decodeSpec)),
 						},
 					},
 				},
 			},
 		}
+		if affinity := provider.PlacementAffinity(md, map[string]interface{}{airunwayv1alpha1.LabelModelDeployment: md.Name}); affinity != nil {
+			decodeGroup["template"].(map[string]interface{})["spec"].(map[string]interface{})["affinity"] = affinity
+		}
 		workerGroups = append(workerGroups, decodeGroup)
 	}
 
 	return workerGroups
 }
 
+// workerResources returns the resources of a worker container with the given limits,
+// applying the explicit requests and limits of spec
+func workerResources(limits map[string]interface{}, spec *airunwayv1alpha1.ResourceSpec) map[string]interface{} {
+	var requests map[string]interface{}
+	if spec != nil {
+		requests, limits = provider.ApplyResourceQuantities(nil, limits, spec.Requests, spec.Limits)
+	}
+	resources := map[string]interface{}{
+		"limits": limits,
+	}
+	if len(requests) > 0 {
+		resources["requests"] = requests
+	}
+	return resources
+}
+
+// componentResources returns the explicit requests and limits of a disaggregated component
+func componentResources(comp *airunwayv1alpha1.ComponentScalingSpec) *airunwayv1alpha1.ResourceSpec {
+	return &airunwayv1alpha1.ResourceSpec{Requests: comp.Requests, Limits: comp.Limits}
+}
+
 // buildEngineArgs constructs the vLLM engine arguments string
 func (t *Transformer) buildEngineArgs(md *airunwayv1alpha1.ModelDeployment) string {
 	var args []string
@@ -392,6 +595,14 @@ func (t *Transformer) buildEngineArgs(md *airunwayv1alpha1.ModelDeployment) stri
 		args = append(args, "--trust-remote-code")
 	}
 
+	// Add chat template and tokenizer overrides, the model revision, KV cache offload and
+	// sharing, and the weight load format
+	args = append(args, provider.ChatTemplateArgs(md)...)
+	args = append(args, provider.RevisionArgs(md)...)
+	args = append(args, provider.KVOffloadArgs(md)...)
+	args = append(args, provider.LMCacheArgs(md)...)
+	args = append(args, provider.LoadFormatArgs(md)...)
+
 	// Add custom engine args (sorted for deterministic output)
 	keys := make([]string, 0, len(md.Spec.Engine.Args))
 	for k := range md.Spec.Engine.Args {
@@ -457,6 +668,24 @@ func (t *Transformer) getImage(md *airunwayv1alpha1.ModelDeployment) string {
 	return DefaultImage
 }
 
+// DefaultImage returns the default image the RayService runs, or an empty string when
+// spec.image is set
+func (t *Transformer) DefaultImage(md *airunwayv1alpha1.ModelDeployment) string {
+	if md.Spec.Image != "" {
+		return ""
+	}
+	return t.getImage(md)
+}
+
+// componentImage returns the image for a disaggregated prefill or decode worker group:
+// the component image when set, otherwise the deployment image
+func (t *Transformer) componentImage(md *airunwayv1alpha1.ModelDeployment, component *airunwayv1alpha1.ComponentScalingSpec) string {
+	if component != nil && component.Image != "" {
+		return component.Image
+	}
+	return t.getImage(md)
+}
+
 // sanitizeLabelValue ensures a value is valid for a Kubernetes label
 func sanitizeLabelValue(value string) string {
 	if len(value) > 63 {
@@ -3,6 +3,7 @@ package kuberay
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -11,6 +12,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
 )
 
 func newTestMD(name, namespace string) *airunwayv1alpha1.ModelDeployment {
@@ -37,11 +39,34 @@ func newTestMD(name, namespace string) *airunwayv1alpha1.ModelDeployment {
 	}
 }
 
+// parseServeConfig parses the serveConfigV2 of a RayService spec
+func parseServeConfig(t *testing.T, spec map[string]interface{}) serveConfig {
+	t.Helper()
+	raw, _ := spec["serveConfigV2"].(string)
+	var config serveConfig
+	if err := yaml.Unmarshal([]byte(raw), &config); err != nil {
+		t.Fatalf("failed to parse serveConfigV2: %v", err)
+	}
+	if len(config.Applications) != 1 || len(config.Applications[0].Deployments) != 1 {
+		t.Fatalf("expected one application with one deployment, got %s", raw)
+	}
+	return config
+}
+
+// transformResources runs the transformer and returns the ordered resources.
+func transformResources(tr *Transformer, md *airunwayv1alpha1.ModelDeployment) ([]*unstructured.Unstructured, error) {
+	result, err := tr.Transform(context.Background(), md)
+	if err != nil {
+		return nil, err
+	}
+	return result.Resources, nil
+}
+
 func TestTransformAggregated(t *testing.T) {
 	tr := NewTransformer()
 	md := newTestMD("test-model", "default")
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -77,9 +102,9 @@ func TestTransformAggregated(t *testing.T) {
 
 	// Check spec
 	spec, _, _ := unstructured.NestedMap(rs.Object, "spec")
-	serveConfig, _ := spec["serveConfigV2"].(string)
-	if !strings.Contains(serveConfig, "num_replicas: 1") {
-		t.Errorf("expected num_replicas: 1 in serveConfig, got: %s", serveConfig)
+	deployment := parseServeConfig(t, spec).Applications[0].Deployments[0]
+	if deployment.NumReplicas != 1 {
+		t.Errorf("expected num_replicas 1, got %d", deployment.NumReplicas)
 	}
 
 	// Check rayClusterConfig exists
@@ -106,16 +131,66 @@ func TestTransformWithScaling(t *testing.T) {
 	md := newTestMD("test-model", "default")
 	md.Spec.Scaling = &airunwayv1alpha1.ScalingSpec{Replicas: 3}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
 	rs := resources[0]
 	spec, _, _ := unstructured.NestedMap(rs.Object, "spec")
-	serveConfig, _ := spec["serveConfigV2"].(string)
-	if !strings.Contains(serveConfig, "num_replicas: 3") {
-		t.Errorf("expected num_replicas: 3 in serveConfig")
+	deployment := parseServeConfig(t, spec).Applications[0].Deployments[0]
+	if deployment.NumReplicas != 3 {
+		t.Errorf("expected num_replicas 3, got %d", deployment.NumReplicas)
+	}
+}
+
+func TestBuildServeConfig(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Scaling = &airunwayv1alpha1.ScalingSpec{Replicas: 2}
+	md.Spec.Resources.GPU.Count = 4
+
+	spec, err := tr.buildSpec(md, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	app := parseServeConfig(t, spec).Applications[0]
+	if app.Name != ServeApplicationName || app.ImportPath != serveImportPath || app.RoutePrefix != "/" {
+		t.Errorf("unexpected application %+v", app)
+	}
+	deployment := app.Deployments[0]
+	if deployment.Name != ServeDeploymentName || deployment.NumReplicas != 2 {
+		t.Errorf("expected 2 replicas of %s, got %+v", ServeDeploymentName, deployment)
+	}
+	if deployment.RayActorOptions == nil || deployment.RayActorOptions.NumGPUs != 4 {
+		t.Errorf("expected 4 GPUs per replica, got %+v", deployment.RayActorOptions)
+	}
+
+	// One replica per prefill and decode worker, sized to fit the smaller pods
+	md.Spec.Resources = nil
+	md.Spec.Serving = &airunwayv1alpha1.ServingSpec{Mode: airunwayv1alpha1.ServingModeDisaggregated}
+	md.Spec.Scaling = &airunwayv1alpha1.ScalingSpec{
+		Prefill: &airunwayv1alpha1.ComponentScalingSpec{Replicas: 1, GPU: &airunwayv1alpha1.GPUSpec{Count: 2}},
+		Decode:  &airunwayv1alpha1.ComponentScalingSpec{Replicas: 3, GPU: &airunwayv1alpha1.GPUSpec{Count: 1}},
+	}
+	spec, err = tr.buildSpec(md, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	deployment = parseServeConfig(t, spec).Applications[0].Deployments[0]
+	if deployment.NumReplicas != 4 || deployment.RayActorOptions == nil || deployment.RayActorOptions.NumGPUs != 1 {
+		t.Errorf("expected 4 replicas with 1 GPU, got %+v", deployment)
+	}
+
+	// Without GPUs the replicas reserve none
+	md.Spec.Serving = nil
+	md.Spec.Scaling = nil
+	spec, err = tr.buildSpec(md, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if options := parseServeConfig(t, spec).Applications[0].Deployments[0].RayActorOptions; options != nil {
+		t.Errorf("expected no ray_actor_options, got %+v", options)
 	}
 }
 
@@ -138,7 +213,7 @@ func TestTransformDisaggregated(t *testing.T) {
 		},
 	}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -187,7 +262,7 @@ func TestTransformDisaggregatedDefaultMemory(t *testing.T) {
 		},
 	}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -218,7 +293,7 @@ func TestTransformWithPodTemplateLabels(t *testing.T) {
 		},
 	}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -406,27 +481,45 @@ func TestBuildHeadGroupSpec(t *testing.T) {
 		t.Errorf("expected name 'ray-head', got %v", container["name"])
 	}
 
-	// Check env vars
+	// The model is configured in serveConfigV2, not on the cluster pods
 	envVars, _ := container["env"].([]interface{})
-	foundModelID := false
-	foundEngineArgs := false
 	for _, ev := range envVars {
 		e, _ := ev.(map[string]interface{})
-		if e["name"] == "MODEL_ID" {
-			foundModelID = true
-			if e["value"] != "meta-llama/Llama-2-7b-chat-hf" {
-				t.Errorf("expected MODEL_ID value, got %v", e["value"])
-			}
-		}
-		if e["name"] == "VLLM_ENGINE_ARGS" {
-			foundEngineArgs = true
+		if e["name"] == "MODEL_ID" || e["name"] == "VLLM_ENGINE_ARGS" {
+			t.Errorf("expected %v to be set in serveConfigV2, not the head container", e["name"])
 		}
 	}
-	if !foundModelID {
-		t.Error("expected MODEL_ID env var")
+}
+
+func TestBuildSpecServeConfigModel(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test", "default")
+	md.Spec.Engine.Args = map[string]string{"quantization": `a"b`}
+
+	spec, err := tr.buildSpec(md, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	envVars := parseServeConfig(t, spec).Applications[0].RuntimeEnv.EnvVars
+	if envVars["MODEL_ID"] != "meta-llama/Llama-2-7b-chat-hf" {
+		t.Errorf("expected MODEL_ID in the runtime_env, got: %v", envVars)
+	}
+	if envVars["VLLM_ENGINE_ARGS"] != `--model meta-llama/Llama-2-7b-chat-hf --quantization a"b` {
+		t.Errorf("expected VLLM_ENGINE_ARGS in the runtime_env, got: %v", envVars)
+	}
+
+	// Changing the model only changes serveConfigV2, so KubeRay keeps the running cluster
+	swapped := md.DeepCopy()
+	swapped.Spec.Model.ID = "Qwen/Qwen2.5-7B-Instruct"
+	swappedSpec, err := tr.buildSpec(swapped, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if swappedSpec["serveConfigV2"] == spec["serveConfigV2"] {
+		t.Error("expected serveConfigV2 to change with the model")
 	}
-	if !foundEngineArgs {
-		t.Error("expected VLLM_ENGINE_ARGS env var")
+	if !reflect.DeepEqual(swappedSpec["rayClusterConfig"], spec["rayClusterConfig"]) {
+		t.Error("expected rayClusterConfig to be unchanged by a model swap")
 	}
 }
 
@@ -450,6 +543,26 @@ func TestBuildHeadGroupSpecWithCustomMemory(t *testing.T) {
 	}
 }
 
+func TestBuildHeadGroupSpecWithRouter(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test", "default")
+	md.Spec.Resources = &airunwayv1alpha1.ResourceSpec{
+		Memory: "64Gi",
+		GPU:    &airunwayv1alpha1.GPUSpec{Count: 1},
+	}
+	md.Spec.Serving = &airunwayv1alpha1.ServingSpec{
+		Router: &airunwayv1alpha1.RouterSpec{CPU: "8", Memory: "32Gi"},
+	}
+
+	headSpec := tr.buildHeadGroupSpec(md)
+	containers, _, _ := unstructured.NestedSlice(headSpec, "template", "spec", "containers")
+	container, _ := containers[0].(map[string]interface{})
+	limits, _, _ := unstructured.NestedStringMap(container, "resources", "limits")
+	if limits["cpu"] != "8" || limits["memory"] != "32Gi" {
+		t.Errorf("expected router resources on the head, got %v", limits)
+	}
+}
+
 func TestBuildAggregatedWorkerGroup(t *testing.T) {
 	tr := NewTransformer()
 	md := newTestMD("test", "default")
@@ -496,6 +609,50 @@ func TestBuildAggregatedWorkerGroupCustomGPU(t *testing.T) {
 	}
 }
 
+func TestBuildWorkerGroupsWithRequestsAndLimits(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test", "default")
+	md.Spec.Resources = &airunwayv1alpha1.ResourceSpec{
+		GPU:      &airunwayv1alpha1.GPUSpec{Count: 1},
+		Memory:   "64Gi",
+		Requests: &airunwayv1alpha1.ResourceQuantities{CPU: "4", Memory: "48Gi"},
+		Limits:   &airunwayv1alpha1.ResourceQuantities{CPU: "8"},
+	}
+
+	containerResources := func(group interface{}) (map[string]interface{}, map[string]interface{}) {
+		g, _ := group.(map[string]interface{})
+		template, _ := g["template"].(map[string]interface{})
+		spec, _ := template["spec"].(map[string]interface{})
+		containers, _ := spec["containers"].([]interface{})
+		container, _ := containers[0].(map[string]interface{})
+		res, _ := container["resources"].(map[string]interface{})
+		requests, _ := res["requests"].(map[string]interface{})
+		limits, _ := res["limits"].(map[string]interface{})
+		return requests, limits
+	}
+
+	requests, limits := containerResources(tr.buildAggregatedWorkerGroup(md)[0])
+	if requests["cpu"] != "4" || requests["memory"] != "48Gi" {
+		t.Errorf("expected explicit requests, got %v", requests)
+	}
+	if limits["cpu"] != "8" || limits["memory"] != "64Gi" || limits["nvidia.com/gpu"] != "1" {
+		t.Errorf("expected explicit cpu limit with shorthand memory and gpu limits, got %v", limits)
+	}
+
+	md.Spec.Resources = nil
+	md.Spec.Scaling = &airunwayv1alpha1.ScalingSpec{
+		Prefill: &airunwayv1alpha1.ComponentScalingSpec{Replicas: 1, Limits: &airunwayv1alpha1.ResourceQuantities{Memory: "96Gi"}},
+		Decode:  &airunwayv1alpha1.ComponentScalingSpec{Replicas: 1, Requests: &airunwayv1alpha1.ResourceQuantities{CPU: "2"}},
+	}
+	groups := tr.buildDisaggregatedWorkerGroups(md)
+	if _, limits := containerResources(groups[0]); limits["memory"] != "96Gi" {
+		t.Errorf("expected explicit prefill memory limit, got %v", limits)
+	}
+	if requests, _ := containerResources(groups[1]); requests["cpu"] != "2" {
+		t.Errorf("expected explicit decode cpu request, got %v", requests)
+	}
+}
+
 func TestSanitizeLabelValue(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -557,3 +714,263 @@ func TestBuildDisaggregatedWorkerGroupsWithCustomGPUType(t *testing.T) {
 		t.Errorf("expected prefill amd.com/gpu=2, got %v", pLimits["amd.com/gpu"])
 	}
 }
+
+func TestBuildDisaggregatedWorkerGroupsWithComponentImage(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test", "default")
+	md.Spec.Image = "custom:v1"
+	md.Spec.Serving = &airunwayv1alpha1.ServingSpec{
+		Mode: airunwayv1alpha1.ServingModeDisaggregated,
+	}
+	md.Spec.Scaling = &airunwayv1alpha1.ScalingSpec{
+		Prefill: &airunwayv1alpha1.ComponentScalingSpec{
+			Replicas: 1,
+			GPU:      &airunwayv1alpha1.GPUSpec{Count: 1},
+			Image:    "custom:nixl",
+		},
+		Decode: &airunwayv1alpha1.ComponentScalingSpec{
+			Replicas: 1,
+			GPU:      &airunwayv1alpha1.GPUSpec{Count: 1},
+		},
+	}
+
+	groups := tr.buildDisaggregatedWorkerGroups(md)
+	if len(groups) != 2 {
+		t.Fatalf("expected 2 groups, got %d", len(groups))
+	}
+	for i, want := range []string{"custom:nixl", "custom:v1"} {
+		group, _ := groups[i].(map[string]interface{})
+		containers, _, _ := unstructured.NestedSlice(group, "template", "spec", "containers")
+		container, _ := containers[0].(map[string]interface{})
+		if container["image"] != want {
+			t.Errorf("expected %s image %q, got %v", group["groupName"], want, container["image"])
+		}
+	}
+}
+
+func TestTransformDisaggregatedPlacement(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Serving = &airunwayv1alpha1.ServingSpec{
+		Mode: airunwayv1alpha1.ServingModeDisaggregated,
+		Placement: &airunwayv1alpha1.PlacementSpec{
+			Colocate: airunwayv1alpha1.PlacementDomainZone,
+		},
+	}
+	md.Spec.Scaling = &airunwayv1alpha1.ScalingSpec{
+		Prefill: &airunwayv1alpha1.ComponentScalingSpec{Replicas: 1, GPU: &airunwayv1alpha1.GPUSpec{Count: 1}},
+		Decode:  &airunwayv1alpha1.ComponentScalingSpec{Replicas: 1, GPU: &airunwayv1alpha1.GPUSpec{Count: 1}},
+	}
+
+	resources, err := transformResources(tr, md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	workerGroups, _, _ := unstructured.NestedSlice(resources[0].Object, "spec", "rayClusterConfig", "workerGroupSpecs")
+	if len(workerGroups) != 2 {
+		t.Fatalf("expected 2 worker groups, got %d", len(workerGroups))
+	}
+	for _, wg := range workerGroups {
+		group, _ := wg.(map[string]interface{})
+		terms, found, _ := unstructured.NestedSlice(group, "template", "spec", "affinity", "podAffinity", "requiredDuringSchedulingIgnoredDuringExecution")
+		if !found || len(terms) != 1 {
+			t.Fatalf("expected required pod affinity on %v, got %v", group["groupName"], terms)
+		}
+		term, _ := terms[0].(map[string]interface{})
+		if term["topologyKey"] != airunwayv1alpha1.TopologyKeyZone {
+			t.Errorf("expected topologyKey %q, got %v", airunwayv1alpha1.TopologyKeyZone, term["topologyKey"])
+		}
+		deployment, _, _ := unstructured.NestedString(term, "labelSelector", "matchLabels", "airunway.ai/model-deployment")
+		if deployment != "test-model" {
+			t.Errorf("expected selector on model deployment, got %q", deployment)
+		}
+	}
+}
+
+func TestTransformRejectsCPUDevice(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Engine.Device = airunwayv1alpha1.EngineDeviceCPU
+
+	if _, err := tr.Transform(context.Background(), md); err == nil || !strings.Contains(err.Error(), "cpu inference") {
+		t.Errorf("expected cpu device to be rejected, got %v", err)
+	}
+}
+
+func TestTransformIdentity(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Identity = &airunwayv1alpha1.IdentitySpec{ServiceAccountName: "model-reader"}
+
+	resources, err := transformResources(tr, md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sa, _, _ := unstructured.NestedString(resources[0].Object, "spec", "rayClusterConfig", "headGroupSpec", "template", "spec", "serviceAccountName")
+	if sa != "model-reader" {
+		t.Errorf("expected head serviceAccountName model-reader, got %q", sa)
+	}
+	workerGroups, _, _ := unstructured.NestedSlice(resources[0].Object, "spec", "rayClusterConfig", "workerGroupSpecs")
+	for _, wg := range workerGroups {
+		group, _ := wg.(map[string]interface{})
+		sa, _, _ := unstructured.NestedString(group, "template", "spec", "serviceAccountName")
+		if sa != "model-reader" {
+			t.Errorf("expected %v serviceAccountName model-reader, got %q", group["groupName"], sa)
+		}
+	}
+}
+
+func TestTransformGangScheduling(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Scaling = &airunwayv1alpha1.ScalingSpec{Replicas: 3}
+	md.Spec.Scheduling = &airunwayv1alpha1.SchedulingSpec{
+		Gang:      true,
+		Scheduler: airunwayv1alpha1.GangSchedulerKueue,
+		Queue:     "gpu-queue",
+	}
+
+	result, err := tr.Transform(context.Background(), md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Resources) != 1 {
+		t.Fatalf("expected no PodGroup for kueue, got %d resources", len(result.Resources))
+	}
+
+	rs := result.Resources[0]
+	head, _, _ := unstructured.NestedMap(rs.Object, "spec", "rayClusterConfig", "headGroupSpec", "template")
+	templates := []map[string]interface{}{head}
+	workerGroups, _, _ := unstructured.NestedSlice(rs.Object, "spec", "rayClusterConfig", "workerGroupSpecs")
+	for _, wg := range workerGroups {
+		template, _, _ := unstructured.NestedMap(wg.(map[string]interface{}), "template")
+		templates = append(templates, template)
+	}
+	for _, template := range templates {
+		queue, _, _ := unstructured.NestedString(template, "metadata", "labels", "kueue.x-k8s.io/queue-name")
+		count, _, _ := unstructured.NestedString(template, "metadata", "annotations", "kueue.x-k8s.io/pod-group-total-count")
+		if queue != "gpu-queue" || count != "4" {
+			t.Errorf("expected head and workers in a kueue pod group of 4, got queue %q count %q", queue, count)
+		}
+	}
+
+	md.Spec.Scheduling.Scheduler = airunwayv1alpha1.GangSchedulerVolcano
+	result, err = tr.Transform(context.Background(), md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Resources) != 2 || result.Resources[1].GetKind() != "PodGroup" || result.Primary().GetKind() != RayServiceKind {
+		t.Errorf("expected a PodGroup next to the primary RayService, got %v", result.Resources)
+	}
+}
+
+func TestTransformGPUTypes(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Resources = &airunwayv1alpha1.ResourceSpec{
+		GPU: &airunwayv1alpha1.GPUSpec{Count: 1, Types: []string{"NVIDIA-H100-80GB-HBM3", "NVIDIA-A100-SXM4-80GB"}},
+	}
+
+	resources, err := transformResources(tr, md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	workerGroups, _, _ := unstructured.NestedSlice(resources[0].Object, "spec", "rayClusterConfig", "workerGroupSpecs")
+	if len(workerGroups) != 1 {
+		t.Fatalf("expected 1 worker group, got %d", len(workerGroups))
+	}
+	group, _ := workerGroups[0].(map[string]interface{})
+	terms, found, _ := unstructured.NestedSlice(group, "template", "spec", "affinity", "nodeAffinity", "requiredDuringSchedulingIgnoredDuringExecution", "nodeSelectorTerms")
+	if !found || len(terms) != 1 {
+		t.Fatalf("expected a required node selector term on the GPU workers, got %v", terms)
+	}
+	if _, found, _ := unstructured.NestedMap(resources[0].Object, "spec", "rayClusterConfig", "headGroupSpec", "template", "spec", "affinity"); found {
+		t.Error("expected no GPU type affinity on the head")
+	}
+}
+
+func TestTransformChatTemplate(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Model.ChatTemplate = &airunwayv1alpha1.ChatTemplateSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "templates"}, Key: "llama.jinja",
+	}}
+	md.Spec.Model.Tokenizer = "org/tokenizer"
+
+	resources, err := transformResources(tr, md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resources) != 1 {
+		t.Fatalf("expected no ConfigMap for a ConfigMap reference, got %d resources", len(resources))
+	}
+	spec, _, _ := unstructured.NestedMap(resources[0].Object, "spec")
+	engineArgs := parseServeConfig(t, spec).Applications[0].RuntimeEnv.EnvVars["VLLM_ENGINE_ARGS"]
+	if !strings.Contains(engineArgs, "--chat-template /etc/airunway/chat-template/chat_template.jinja --tokenizer org/tokenizer") {
+		t.Errorf("expected chat template and tokenizer engine args, got %s", engineArgs)
+	}
+
+	head, _, _ := unstructured.NestedMap(resources[0].Object, "spec", "rayClusterConfig", "headGroupSpec")
+	workerGroups, _, _ := unstructured.NestedSlice(resources[0].Object, "spec", "rayClusterConfig", "workerGroupSpecs")
+	for _, group := range append([]interface{}{head}, workerGroups...) {
+		volumes, _, _ := unstructured.NestedSlice(group.(map[string]interface{}), "template", "spec", "volumes")
+		if len(volumes) != 1 {
+			t.Fatalf("expected the chat template volume on every group, got %v", volumes)
+		}
+		if name, _, _ := unstructured.NestedString(volumes[0].(map[string]interface{}), "configMap", "name"); name != "templates" {
+			t.Errorf("expected the referenced ConfigMap, got %q", name)
+		}
+	}
+}
+
+func TestTransformLogSink(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Observability = &airunwayv1alpha1.ObservabilitySpec{LogSink: &airunwayv1alpha1.LogSinkSpec{
+		Type: airunwayv1alpha1.LogSinkTypeLoki, Endpoint: "http://loki.observability:3100",
+	}}
+
+	resources, err := transformResources(tr, md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resources) != 2 || resources[1].GetName() != "test-model-log-sink" {
+		t.Fatalf("expected the log sink ConfigMap next to the RayService, got %d resources", len(resources))
+	}
+	head, _, _ := unstructured.NestedMap(resources[0].Object, "spec", "rayClusterConfig", "headGroupSpec")
+	workerGroups, _, _ := unstructured.NestedSlice(resources[0].Object, "spec", "rayClusterConfig", "workerGroupSpecs")
+	for _, group := range append([]interface{}{head}, workerGroups...) {
+		containers, _, _ := unstructured.NestedSlice(group.(map[string]interface{}), "template", "spec", "containers")
+		last := containers[len(containers)-1].(map[string]interface{})
+		if last["name"] != "log-sink" {
+			t.Errorf("expected the log sink sidecar on every group, got %v", containers)
+		}
+	}
+}
+
+func TestTransformPropagatedMetadata(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Labels = map[string]string{"cost-center": "ml-42"}
+	md.Spec.Annotations = map[string]string{"example.com/owner": "search-team"}
+
+	resources, err := transformResources(tr, md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rs := resources[0]
+	if rs.GetLabels()["cost-center"] != "ml-42" || rs.GetAnnotations()["example.com/owner"] != "search-team" {
+		t.Errorf("expected propagated metadata on the RayService, got %v %v", rs.GetLabels(), rs.GetAnnotations())
+	}
+	head, _, _ := unstructured.NestedMap(rs.Object, "spec", "rayClusterConfig", "headGroupSpec")
+	workerGroups, _, _ := unstructured.NestedSlice(rs.Object, "spec", "rayClusterConfig", "workerGroupSpecs")
+	for _, group := range append([]interface{}{head}, workerGroups...) {
+		labels, _, _ := unstructured.NestedStringMap(group.(map[string]interface{}), "template", "metadata", "labels")
+		annotations, _, _ := unstructured.NestedStringMap(group.(map[string]interface{}), "template", "metadata", "annotations")
+		if labels["cost-center"] != "ml-42" || annotations["example.com/owner"] != "search-team" {
+			t.Errorf("expected propagated pod metadata on every group, got %v %v", labels, annotations)
+		}
+	}
+}
@@ -26,6 +26,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/discovery"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
@@ -35,6 +36,7 @@ import (
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
 	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/pkg/provider"
 	llmd "github.com/kaito-project/airunway/providers/llmd"
 )
 
@@ -108,8 +110,17 @@ func main() {
 		os.Exit(1)
 	}
 
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create discovery client")
+		os.Exit(1)
+	}
+
 	// Set up the llm-d provider reconciler
 	reconciler := llmd.NewLLMDProviderReconciler(mgr.GetClient(), mgr.GetScheme())
+	reconciler.Recorder = mgr.GetEventRecorder(llmd.FieldManager)
+	reconciler.Events = provider.NewEventMirror(mgr.GetAPIReader(), reconciler.Recorder)
+	reconciler.Overrides = provider.NewOverridesValidator(discoveryClient)
 	if err := reconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "LLMDProvider")
 		os.Exit(1)
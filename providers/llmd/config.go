@@ -29,6 +29,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/pkg/provider"
 )
 
 const (
@@ -57,6 +58,30 @@ func NewProviderConfigManager(c client.Client) *ProviderConfigManager {
 	}
 }
 
+// SupportedFields lists the ModelDeployment spec fields the transformer carries into the
+// llm-d Deployment. It is published in status.supportedFields so the core controller can
+// warn about fields this provider drops.
+var SupportedFields = []string{
+	provider.FieldImage,
+	provider.FieldEnv,
+	provider.FieldPodTemplate,
+	provider.FieldHuggingFaceToken,
+	provider.FieldSecretsRollout,
+	provider.FieldIdentity,
+	provider.FieldNodeSelector,
+	provider.FieldTolerations,
+	provider.FieldGangScheduling,
+	provider.FieldChatTemplate,
+	provider.FieldTokenizer,
+	provider.FieldServedName,
+	provider.FieldAdapters,
+	provider.FieldLoadFormat,
+	provider.FieldContextLength,
+	provider.FieldEngineArgs,
+	provider.FieldTrustRemoteCode,
+	provider.FieldLogSink,
+}
+
 // GetProviderConfigSpec returns the InferenceProviderConfigSpec for llm-d
 func GetProviderConfigSpec() airunwayv1alpha1.InferenceProviderConfigSpec {
 	requiresCRD := false
@@ -70,8 +95,9 @@ func GetProviderConfigSpec() airunwayv1alpha1.InferenceProviderConfigSpec {
 				airunwayv1alpha1.ServingModeAggregated,
 				airunwayv1alpha1.ServingModeDisaggregated,
 			},
-			CPUSupport:  false,
+			CPUSupport:  true,
 			GPUSupport:  true,
+			CPUEngines:  []airunwayv1alpha1.EngineType{airunwayv1alpha1.EngineTypeVLLM},
 			RequiresCRD: &requiresCRD,
 		},
 		SelectionRules: []airunwayv1alpha1.SelectionRule{},
@@ -81,7 +107,7 @@ func GetProviderConfigSpec() airunwayv1alpha1.InferenceProviderConfigSpec {
 // GetInstallationInfo returns the installation metadata for llm-d
 func GetInstallationInfo() *airunwayv1alpha1.InstallationInfo {
 	return &airunwayv1alpha1.InstallationInfo{
-		Description: "llm-d provider: deploys vLLM Deployments + Services directly. Requires GPU nodes with the NVIDIA device plugin.",
+		Description: "llm-d provider: deploys vLLM Deployments + Services directly. Requires GPU nodes with the NVIDIA device plugin, unless engine.device is cpu.",
 		Steps: []airunwayv1alpha1.InstallationStep{
 			{
 				Title:       "Install NVIDIA GPU Device Plugin",
@@ -125,7 +151,12 @@ func (m *ProviderConfigManager) Register(ctx context.Context) error {
 	} else if err != nil {
 		return fmt.Errorf("failed to get InferenceProviderConfig: %w", err)
 	} else {
+		// namespaceSelector, imageUpgrade and imagePolicy are set by cluster admins, not the provider
+		namespaceSelector, imageUpgrade, imagePolicy := existing.Spec.NamespaceSelector, existing.Spec.ImageUpgrade, existing.Spec.ImagePolicy
 		existing.Spec = config.Spec
+		existing.Spec.NamespaceSelector = namespaceSelector
+		existing.Spec.ImageUpgrade = imageUpgrade
+		existing.Spec.ImagePolicy = imagePolicy
 		if existing.Annotations == nil {
 			existing.Annotations = make(map[string]string)
 		}
@@ -159,10 +190,15 @@ func (m *ProviderConfigManager) UpdateStatus(ctx context.Context, ready bool) er
 
 	now := metav1.Now()
 	config.Status = airunwayv1alpha1.InferenceProviderConfigStatus{
-		Ready:              ready,
-		Version:            ProviderVersion,
-		LastHeartbeat:      &now,
-		UpstreamCRDVersion: "apps/v1",
+		Ready:                   ready,
+		Version:                 ProviderVersion,
+		LastHeartbeat:           &now,
+		LastHeartbeatTime:       &now,
+		ObservedProviderVersion: ProviderVersion,
+		UpstreamCRDVersion:      "apps/v1",
+		SupportedFields:         SupportedFields,
+		ObservedGeneration:      config.Status.ObservedGeneration,
+		Conditions:              config.Status.Conditions,
 	}
 
 	if err := m.client.Status().Update(ctx, config); err != nil {
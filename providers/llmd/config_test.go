@@ -16,8 +16,8 @@ func TestGetProviderConfigSpec(t *testing.T) {
 	if !spec.Capabilities.GPUSupport {
 		t.Error("expected GPU support")
 	}
-	if spec.Capabilities.CPUSupport {
-		t.Error("expected no CPU support")
+	if !spec.Capabilities.SupportsCPUEngine(airunwayv1alpha1.EngineTypeVLLM) {
+		t.Error("expected vllm CPU backend support")
 	}
 	if spec.Capabilities.RequiresCRD == nil || *spec.Capabilities.RequiresCRD {
 		t.Error("expected LLMD to not require CRDs")
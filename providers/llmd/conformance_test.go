@@ -0,0 +1,59 @@
+package llmd
+
+import (
+	"testing"
+
+	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/providers/conformance"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// conformanceStatus adapts StatusTranslator to the conformance suite
+type conformanceStatus struct {
+	*StatusTranslator
+}
+
+func (s conformanceStatus) TranslateStatus(upstream *unstructured.Unstructured) (*conformance.StatusResult, error) {
+	result, err := s.StatusTranslator.TranslateStatus(upstream)
+	if err != nil {
+		return nil, err
+	}
+	return (*conformance.StatusResult)(result), nil
+}
+
+func TestConformance(t *testing.T) {
+	conformance.Suite{
+		Transformer:      NewTransformer(),
+		StatusTranslator: conformanceStatus{NewStatusTranslator()},
+		StatusCases: []conformance.StatusCase{
+			{
+				Name: "available",
+				Status: map[string]interface{}{
+					"conditions": []interface{}{
+						map[string]interface{}{"type": "Available", "status": "True"},
+					},
+				},
+				Phase: airunwayv1alpha1.DeploymentPhaseRunning,
+			},
+			{
+				Name: "rolling out",
+				Status: map[string]interface{}{
+					"conditions": []interface{}{
+						map[string]interface{}{"type": "Available", "status": "False"},
+						map[string]interface{}{"type": "Progressing", "status": "True"},
+					},
+				},
+				Phase: airunwayv1alpha1.DeploymentPhaseDeploying,
+			},
+			{
+				Name: "deadline exceeded",
+				Status: map[string]interface{}{
+					"conditions": []interface{}{
+						map[string]interface{}{"type": "Progressing", "status": "False", "reason": "ProgressDeadlineExceeded"},
+					},
+				},
+				Phase: airunwayv1alpha1.DeploymentPhaseFailed,
+			},
+		},
+	}.Run(t)
+}
@@ -29,6 +29,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -36,6 +37,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/pkg/provider"
 )
 
 const (
@@ -66,6 +68,16 @@ type LLMDProviderReconciler struct {
 	Scheme           *runtime.Scheme
 	Transformer      *Transformer
 	StatusTranslator *StatusTranslator
+
+	// Recorder emits ResourceUpdated events on ModelDeployments. When nil, no events are emitted.
+	Recorder events.EventRecorder
+
+	// Events mirrors upstream Warning events onto ModelDeployments. When nil, none are mirrored.
+	Events *provider.EventMirror
+
+	// Overrides checks spec.provider.overrides against the published Deployment schema. When nil,
+	// overrides are applied unchecked.
+	Overrides *provider.OverridesValidator
 }
 
 // NewLLMDProviderReconciler creates a new llm-d provider reconciler
@@ -86,6 +98,9 @@ func NewLLMDProviderReconciler(c client.Client, scheme *runtime.Scheme) *LLMDPro
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps,resources=deployments/status,verbs=get
 // +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=scheduling.volcano.sh,resources=podgroups,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=events,verbs=list
 
 // Reconcile handles the reconciliation loop for ModelDeployments assigned to the llm-d provider
 func (r *LLMDProviderReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -99,20 +114,33 @@ func (r *LLMDProviderReconciler) Reconcile(ctx context.Context, req ctrl.Request
 
 	// Only process if this provider is selected
 	if md.Status.Provider == nil || md.Status.Provider.Name != ProviderName {
+		// Delete the resources of a deployment migrated to another provider, or deleted
+		// while migrating away from this one
+		if provider.Released(&md, ProviderName, FinalizerName) {
+			return r.handleDeletion(ctx, &md)
+		}
 		return ctrl.Result{}, nil
 	}
 
 	logger.Info("Reconciling ModelDeployment for llm-d provider", "name", md.Name, "namespace", md.Namespace)
 
-	// Check for pause annotation
-	if md.Annotations != nil && md.Annotations["airunway.ai/reconcile-paused"] == "true" {
+	// Handle deletion, even when paused, so the finalizer never blocks removal
+	if !md.DeletionTimestamp.IsZero() {
+		return r.handleDeletion(ctx, &md)
+	}
+
+	// Leave provider resources untouched while paused; the core controller
+	// reports the PausedReconciliation condition
+	if md.IsPaused() {
 		logger.Info("Reconciliation paused", "name", md.Name)
 		return ctrl.Result{}, nil
 	}
 
-	// Handle deletion
-	if !md.DeletionTimestamp.IsZero() {
-		return r.handleDeletion(ctx, &md)
+	// Create nothing until Kueue admits the deployment; the core controller moves it out of
+	// the Queued phase once admitted
+	if md.IsQueued() {
+		logger.Info("Waiting for Kueue admission", "name", md.Name)
+		return ctrl.Result{}, nil
 	}
 
 	// Add finalizer if not present
@@ -135,7 +163,16 @@ func (r *LLMDProviderReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	r.setCondition(&md, airunwayv1alpha1.ConditionTypeProviderCompatible, metav1.ConditionTrue, "CompatibilityVerified", "Configuration compatible with llm-d")
 
 	// Transform ModelDeployment to Deployments + Services
-	resources, err := r.Transformer.Transform(ctx, &md)
+	// Keep the previous default image while spec.imageUpgrade of the provider config staggers
+	// the rollout of a new one
+	target, image, err := provider.HoldImageUpgrade(ctx, r.Client, ProviderConfigName, &md, r.Transformer.DefaultImage)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	result, err := r.Transformer.Transform(ctx, target)
+	if err == nil {
+		err = result.Validate()
+	}
 	if err != nil {
 		logger.Error(err, "Failed to transform ModelDeployment", "name", md.Name)
 		r.setCondition(&md, airunwayv1alpha1.ConditionTypeResourceCreated, metav1.ConditionFalse, "TransformFailed", err.Error())
@@ -144,42 +181,70 @@ func (r *LLMDProviderReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return ctrl.Result{}, r.Status().Update(ctx, &md)
 	}
 
-	// Create or update all resources
-	for _, resource := range resources {
-		if err := r.createOrUpdateResource(ctx, resource, &md); err != nil {
-			// Transient API conflict — requeue instead of marking as failed
-			if errors.IsConflict(err) {
-				logger.Info("Resource conflict during reconcile, requeueing", "name", resource.GetName())
-				return ctrl.Result{Requeue: true}, nil
-			}
-			logger.Error(err, "Failed to create/update resource", "name", resource.GetName(), "kind", resource.GetKind())
-			reason := "CreateFailed"
-			if isResourceConflict(err) {
-				reason = "ResourceConflict"
-				r.setCondition(&md, airunwayv1alpha1.ConditionTypeReady, metav1.ConditionFalse, "ResourceConflict", err.Error())
-			}
-			r.setCondition(&md, airunwayv1alpha1.ConditionTypeResourceCreated, metav1.ConditionFalse, reason, err.Error())
-			md.Status.Phase = airunwayv1alpha1.DeploymentPhaseFailed
-			md.Status.Message = fmt.Sprintf("Failed to create/update resource %s: %s", resource.GetName(), err.Error())
-			return ctrl.Result{}, r.Status().Update(ctx, &md)
-		}
+	// Check the images of the rendered resources, which include component, sidecar and
+	// override images, and pin signed images to their verified digest
+	violation, err := provider.EnforceImagePolicy(ctx, r.Client, ProviderConfigName, result.Resources)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if violation != "" {
+		r.setCondition(&md, airunwayv1alpha1.ConditionTypeResourceCreated, metav1.ConditionFalse, provider.ReasonImagePolicyViolation, violation)
+		md.Status.Phase = airunwayv1alpha1.DeploymentPhaseFailed
+		md.Status.Message = violation
+		return ctrl.Result{RequeueAfter: provider.ImagePolicyRecheckInterval}, r.Status().Update(ctx, &md)
 	}
 
-	r.setCondition(&md, airunwayv1alpha1.ConditionTypeResourceCreated, metav1.ConditionTrue, "ResourceCreated", "Deployments and Services created successfully")
+	// Report overrides the Deployment schema does not declare with their field paths, rather
+	// than as an API server rejection of the merged object
+	if err := r.Overrides.Check(&md, result.Primary()); err != nil {
+		logger.Error(err, "Invalid provider overrides", "name", md.Name)
+		r.setCondition(&md, airunwayv1alpha1.ConditionTypeResourceCreated, metav1.ConditionFalse, provider.ReasonInvalidOverrides, err.Error())
+		md.Status.Phase = airunwayv1alpha1.DeploymentPhaseFailed
+		md.Status.Message = err.Error()
+		return ctrl.Result{}, r.Status().Update(ctx, &md)
+	}
 
-	// Update provider status — use the primary Deployment (resources[0]) for tracking
-	if len(resources) > 0 {
-		md.Status.Provider.ResourceName = resources[0].GetName()
-		md.Status.Provider.ResourceKind = resources[0].GetKind()
+	// Create or update the resources in dependency order. Resources
+	// created by this apply are deleted again if a later one fails.
+	err = provider.ApplyResources(ctx, r.Client, result.Resources, func(ctx context.Context, resource *unstructured.Unstructured) (bool, error) {
+		return r.createOrUpdateResource(ctx, resource, &md)
+	})
+	md.Status.Provider.PartialApply = provider.PartialApply(err)
+	if err != nil {
+		// Transient API conflict — requeue instead of marking as failed
+		if errors.IsConflict(err) {
+			logger.Info("Resource conflict during reconcile, requeueing", "name", md.Name)
+			return ctrl.Result{Requeue: true}, nil
+		}
+		logger.Error(err, "Failed to create/update resources", "name", md.Name)
+		reason := "CreateFailed"
+		if isResourceConflict(err) {
+			reason = "ResourceConflict"
+			r.setCondition(&md, airunwayv1alpha1.ConditionTypeReady, metav1.ConditionFalse, "ResourceConflict", err.Error())
+		}
+		r.setCondition(&md, airunwayv1alpha1.ConditionTypeResourceCreated, metav1.ConditionFalse, reason, err.Error())
+		md.Status.Phase = airunwayv1alpha1.DeploymentPhaseFailed
+		md.Status.Message = fmt.Sprintf("Failed to create/update resources: %s", err.Error())
+		return ctrl.Result{}, r.Status().Update(ctx, &md)
 	}
 
-	// Sync status from the primary Deployment
-	if len(resources) > 0 {
-		if err := r.syncStatus(ctx, &md, resources[0]); err != nil {
+	r.setCondition(&md, airunwayv1alpha1.ConditionTypeResourceCreated, metav1.ConditionTrue, "ResourceCreated", "Deployments and Services created successfully")
+	provider.RecordDefaultImage(&md, image)
+
+	// Update provider status and sync status from the primary Deployment
+	if primary := result.Primary(); primary != nil {
+		md.Status.Provider.ResourceName = primary.GetName()
+		md.Status.Provider.ResourceKind = primary.GetKind()
+		if err := r.syncStatus(ctx, &md, primary); err != nil {
 			logger.Error(err, "Failed to sync status", "name", md.Name)
 		}
 	}
 
+	// Mirror upstream warnings, e.g. on pods users cannot read, onto the ModelDeployment
+	if err := r.Events.Mirror(ctx, &md); err != nil {
+		logger.Error(err, "Failed to mirror upstream events", "name", md.Name)
+	}
+
 	// Set phase to Deploying if not already Running or Failed
 	if md.Status.Phase != airunwayv1alpha1.DeploymentPhaseRunning &&
 		md.Status.Phase != airunwayv1alpha1.DeploymentPhaseFailed {
@@ -257,7 +322,7 @@ func verifyOwnerReference(existing *unstructured.Unstructured, mdUID types.UID)
 // createOrUpdateResource creates or updates an unstructured resource using server-side apply.
 // Server-side apply avoids resourceVersion conflicts that occur when Kubernetes defaults
 // fields between our Get and Update calls.
-func (r *LLMDProviderReconciler) createOrUpdateResource(ctx context.Context, resource *unstructured.Unstructured, md *airunwayv1alpha1.ModelDeployment) error {
+func (r *LLMDProviderReconciler) createOrUpdateResource(ctx context.Context, resource *unstructured.Unstructured, md *airunwayv1alpha1.ModelDeployment) (bool, error) {
 	logger := log.FromContext(ctx)
 
 	// For existing resources, verify ownership before applying
@@ -267,18 +332,29 @@ func (r *LLMDProviderReconciler) createOrUpdateResource(ctx context.Context, res
 		Name:      resource.GetName(),
 		Namespace: resource.GetNamespace(),
 	}, existing)
-	if err == nil {
+	found := err == nil
+	if found {
 		if err := verifyOwnerReference(existing, md.UID); err != nil {
-			return err
+			return false, err
 		}
 	} else if !errors.IsNotFound(err) {
-		return fmt.Errorf("failed to get existing resource: %w", err)
+		return false, fmt.Errorf("failed to get existing resource: %w", err)
 	}
 
 	// Server-side apply: handles both create and update without needing resourceVersion.
 	// ForceOwnership ensures our field manager wins over any conflicting field managers.
 	logger.Info("Applying resource", "kind", resource.GetKind(), "name", resource.GetName())
-	return r.Patch(ctx, resource, client.Apply, client.FieldOwner(FieldManager), client.ForceOwnership)
+	if err := r.Patch(ctx, resource, client.Apply, client.FieldOwner(FieldManager), client.ForceOwnership); err != nil {
+		return false, err
+	}
+
+	// The apply runs on every reconcile; only updates that changed the spec are recorded
+	if found {
+		if err := provider.RecordResourceChange(md, r.Recorder, existing, resource, "spec"); err != nil {
+			logger.Error(err, "Failed to record resource change", "kind", resource.GetKind(), "name", resource.GetName())
+		}
+	}
+	return !found, nil
 }
 
 // syncStatus fetches the primary Deployment and syncs its status to the ModelDeployment
@@ -320,7 +396,7 @@ func (r *LLMDProviderReconciler) syncStatus(ctx context.Context, md *airunwayv1a
 	return nil
 }
 
-// handleDeletion handles the deletion of a ModelDeployment
+// handleDeletion handles the deletion of a ModelDeployment, or its migration to another provider
 func (r *LLMDProviderReconciler) handleDeletion(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
@@ -330,10 +406,12 @@ func (r *LLMDProviderReconciler) handleDeletion(ctx context.Context, md *airunwa
 
 	logger.Info("Handling deletion", "name", md.Name, "namespace", md.Namespace)
 
-	// Update phase to Terminating
-	md.Status.Phase = airunwayv1alpha1.DeploymentPhaseTerminating
-	if err := r.Status().Update(ctx, md); err != nil {
-		logger.Error(err, "Failed to update status to Terminating")
+	// Update phase to Terminating, unless the deployment was only migrated to another provider
+	if !md.DeletionTimestamp.IsZero() {
+		md.Status.Phase = airunwayv1alpha1.DeploymentPhaseTerminating
+		if err := r.Status().Update(ctx, md); err != nil {
+			logger.Error(err, "Failed to update status to Terminating")
+		}
 	}
 
 	// Determine primary Deployment name (decode suffix for disaggregated mode)
@@ -363,7 +441,7 @@ func (r *LLMDProviderReconciler) handleDeletion(ctx context.Context, md *airunwa
 		if err := r.Delete(ctx, deploy); err != nil && !errors.IsNotFound(err) {
 			logger.Error(err, "Failed to delete Deployment")
 
-			if time.Since(md.DeletionTimestamp.Time) > FinalizerTimeout {
+			if time.Since(provider.CleanupStart(md)) > FinalizerTimeout {
 				logger.Info("Finalizer timeout reached, removing finalizer without cleanup")
 				controllerutil.RemoveFinalizer(md, FinalizerName)
 				return ctrl.Result{}, r.Update(ctx, md)
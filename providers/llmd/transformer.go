@@ -24,6 +24,8 @@ import (
 	"strings"
 
 	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
+	"github.com/kaito-project/airunway/controller/pkg/provider"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
@@ -32,6 +34,10 @@ const (
 	// DefaultVLLMImage is the default container image for llm-d vLLM deployments
 	DefaultVLLMImage = "vllm/vllm-openai:v0.9.1"
 
+	// DefaultVLLMCPUImage is the default container image for vLLM's CPU backend,
+	// used when engine.device is cpu
+	DefaultVLLMCPUImage = "public.ecr.aws/q9t5s3a7/vllm-cpu-release-repo:v0.9.1"
+
 	// DefaultVLLMPort is the default serving port for vLLM
 	DefaultVLLMPort = int64(8000)
 
@@ -48,6 +54,8 @@ const (
 // Transformer handles transformation of ModelDeployment to llm-d Deployments and Services
 type Transformer struct{}
 
+var _ provider.Transformer = (*Transformer)(nil)
+
 // NewTransformer creates a new llm-d transformer
 func NewTransformer() *Transformer {
 	return &Transformer{}
@@ -57,11 +65,24 @@ func NewTransformer() *Transformer {
 //
 // Aggregated mode returns [Deployment, Service].
 // Disaggregated mode returns [decode Deployment, prefill Deployment, decode Service, prefill Service].
-// resources[0] is always the primary resource used for status tracking.
-func (t *Transformer) Transform(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) ([]*unstructured.Unstructured, error) {
+// The decode (or only) Deployment is the primary resource used for status tracking.
+// With Volcano gang scheduling, the PodGroup of the gang precedes the Deployments.
+func (t *Transformer) Transform(ctx context.Context, md *airunwayv1alpha1.ModelDeployment) (*provider.TransformResult, error) {
 	if md.ResolvedEngineType() != airunwayv1alpha1.EngineTypeVLLM {
 		return nil, fmt.Errorf("llm-d provider only supports vllm engine, got %s", md.ResolvedEngineType())
 	}
+	if err := provider.KVOffloadCheck(md); err != nil {
+		return nil, err
+	}
+	if err := provider.KVCacheCheck(md); err != nil {
+		return nil, err
+	}
+	if err := provider.LoadFormatCheck(md); err != nil {
+		return nil, err
+	}
+	if err := provider.AdaptersCheck(md); err != nil {
+		return nil, err
+	}
 
 	servingMode := airunwayv1alpha1.ServingModeAggregated
 	if md.Spec.Serving != nil && md.Spec.Serving.Mode != "" {
@@ -69,13 +90,16 @@ func (t *Transformer) Transform(ctx context.Context, md *airunwayv1alpha1.ModelD
 	}
 
 	if servingMode == airunwayv1alpha1.ServingModeDisaggregated {
+		if md.ResolvedDevice() == airunwayv1alpha1.EngineDeviceCPU {
+			return nil, fmt.Errorf("llm-d provider does not support cpu inference in disaggregated mode")
+		}
 		return t.transformDisaggregated(md)
 	}
 	return t.transformAggregated(md)
 }
 
 // transformAggregated creates a single Deployment + Service for aggregated serving.
-func (t *Transformer) transformAggregated(md *airunwayv1alpha1.ModelDeployment) ([]*unstructured.Unstructured, error) {
+func (t *Transformer) transformAggregated(md *airunwayv1alpha1.ModelDeployment) (*provider.TransformResult, error) {
 	replicas := int64(1)
 	if md.Spec.Scaling != nil && md.Spec.Scaling.Replicas > 0 {
 		replicas = int64(md.Spec.Scaling.Replicas)
@@ -86,18 +110,26 @@ func (t *Transformer) transformAggregated(md *airunwayv1alpha1.ModelDeployment)
 		return nil, fmt.Errorf("failed to build vLLM args: %w", err)
 	}
 
-	deployment, err := t.buildDeployment(md, md.Name, replicas, md.Spec.Resources, args)
+	gang := provider.NewGangScheduling(md, int32(replicas))
+	deployment, err := t.buildDeployment(md, md.Name, t.getImage(md), replicas, md.Spec.Resources, args, gang, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build Deployment: %w", err)
 	}
 
 	svc := t.buildService(md, md.Name, md.Name)
 
-	return []*unstructured.Unstructured{deployment, svc}, nil
+	result := provider.NewTransformResult(deployment, svc)
+	gang.AddPodGroup(result)
+	provider.AddChatTemplateConfigMap(result, md)
+	if err := provider.AddLogSinkConfigMap(result, md); err != nil {
+		return nil, fmt.Errorf("failed to build the log sink ConfigMap: %w", err)
+	}
+	provider.ApplyPropagatedMetadata(result, md)
+	return result, nil
 }
 
 // transformDisaggregated creates separate decode + prefill Deployments and Services.
-func (t *Transformer) transformDisaggregated(md *airunwayv1alpha1.ModelDeployment) ([]*unstructured.Unstructured, error) {
+func (t *Transformer) transformDisaggregated(md *airunwayv1alpha1.ModelDeployment) (*provider.TransformResult, error) {
 	if md.Spec.Scaling == nil {
 		return nil, fmt.Errorf("spec.scaling is required for disaggregated serving mode")
 	}
@@ -124,12 +156,15 @@ func (t *Transformer) transformDisaggregated(md *airunwayv1alpha1.ModelDeploymen
 	decodeName := md.Name + "-decode"
 	prefillName := md.Name + "-prefill"
 
-	decodeDeployment, err := t.buildDeployment(md, decodeName, int64(md.Spec.Scaling.Decode.Replicas), decodeResources, decodeArgs)
+	// Prefill and decode pods form one gang: neither can serve without the other
+	gang := provider.NewGangScheduling(md, md.Spec.Scaling.Decode.Replicas+md.Spec.Scaling.Prefill.Replicas)
+
+	decodeDeployment, err := t.buildDeployment(md, decodeName, t.componentImage(md, md.Spec.Scaling.Decode), int64(md.Spec.Scaling.Decode.Replicas), decodeResources, decodeArgs, gang, md.Spec.Scaling.Decode.Scheduling)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build decode Deployment: %w", err)
 	}
 
-	prefillDeployment, err := t.buildDeployment(md, prefillName, int64(md.Spec.Scaling.Prefill.Replicas), prefillResources, prefillArgs)
+	prefillDeployment, err := t.buildDeployment(md, prefillName, t.componentImage(md, md.Spec.Scaling.Prefill), int64(md.Spec.Scaling.Prefill.Replicas), prefillResources, prefillArgs, gang, md.Spec.Scaling.Prefill.Scheduling)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build prefill Deployment: %w", err)
 	}
@@ -138,12 +173,21 @@ func (t *Transformer) transformDisaggregated(md *airunwayv1alpha1.ModelDeploymen
 	decodeSvc := t.buildService(md, decodeName, decodeName)
 	prefillSvc := t.buildService(md, prefillName, prefillName)
 
-	// decode Deployment is resources[0] (primary for status tracking)
-	return []*unstructured.Unstructured{decodeDeployment, prefillDeployment, decodeSvc, prefillSvc}, nil
+	// The decode Deployment is primary for status tracking
+	result := provider.NewTransformResult(decodeDeployment, prefillDeployment, decodeSvc, prefillSvc)
+	gang.AddPodGroup(result)
+	provider.AddChatTemplateConfigMap(result, md)
+	if err := provider.AddLogSinkConfigMap(result, md); err != nil {
+		return nil, fmt.Errorf("failed to build the log sink ConfigMap: %w", err)
+	}
+	provider.ApplyPropagatedMetadata(result, md)
+	return result, nil
 }
 
-// buildDeployment constructs an apps/v1 Deployment as unstructured.
-func (t *Transformer) buildDeployment(md *airunwayv1alpha1.ModelDeployment, name string, replicas int64, resources *airunwayv1alpha1.ResourceSpec, args []string) (*unstructured.Unstructured, error) {
+// buildDeployment constructs an apps/v1 Deployment as unstructured running image. Its pods
+// join gang when spec.scheduling.gang is enabled, and are constrained by the scheduling of a
+// disaggregated component when set.
+func (t *Transformer) buildDeployment(md *airunwayv1alpha1.ModelDeployment, name, image string, replicas int64, resources *airunwayv1alpha1.ResourceSpec, args []string, gang *provider.GangScheduling, scheduling *airunwayv1alpha1.ComponentSchedulingSpec) (*unstructured.Unstructured, error) {
 	d := &unstructured.Unstructured{}
 	d.SetAPIVersion("apps/v1")
 	d.SetKind("Deployment")
@@ -179,26 +223,29 @@ func (t *Transformer) buildDeployment(md *airunwayv1alpha1.ModelDeployment, name
 
 	// Pod selector labels (must be a stable subset)
 	selectorLabels := map[string]interface{}{
-		"airunway.ai/deployment": md.Name,
-		"app":                        name,
+		airunwayv1alpha1.LabelDeployment: md.Name,
+		"app":                            name,
 	}
 
 	// Pod template labels (must include selector labels)
 	podLabels := map[string]interface{}{
-		"airunway.ai/deployment": md.Name,
-		"app":                        name,
+		airunwayv1alpha1.LabelDeployment: md.Name,
+		"app":                            name,
 	}
 	if md.Spec.PodTemplate != nil && md.Spec.PodTemplate.Metadata != nil {
 		for k, v := range md.Spec.PodTemplate.Metadata.Labels {
 			podLabels[k] = v
 		}
 	}
+	for k, v := range md.IdentityPodLabels() {
+		podLabels[k] = v
+	}
 	// Re-apply selector labels to prevent user overrides from breaking selectors
 	for k, v := range selectorLabels {
 		podLabels[k] = v
 	}
-
-	image := t.getImage(md)
+	// The InferencePool and the spec.networking.isolate NetworkPolicy select pods by this label
+	podLabels[airunwayv1alpha1.LabelModelDeployment] = md.Name
 
 	container, err := t.buildContainer(md, image, args, resources)
 	if err != nil {
@@ -209,16 +256,29 @@ func (t *Transformer) buildDeployment(md *airunwayv1alpha1.ModelDeployment, name
 		"containers": []interface{}{container},
 	}
 
-	if len(md.Spec.NodeSelector) > 0 {
+	if componentNodeSelector := md.ComponentNodeSelector(scheduling); len(componentNodeSelector) > 0 {
 		nodeSelector := make(map[string]interface{})
-		for k, v := range md.Spec.NodeSelector {
+		for k, v := range componentNodeSelector {
 			nodeSelector[k] = v
 		}
 		podSpec["nodeSelector"] = nodeSelector
 	}
 
-	if len(md.Spec.Tolerations) > 0 {
-		podSpec["tolerations"] = t.buildTolerations(md)
+	if tolerations := md.ComponentTolerations(scheduling); len(tolerations) > 0 {
+		podSpec["tolerations"] = t.buildTolerations(tolerations)
+	}
+
+	placement := provider.PlacementAffinity(md, map[string]interface{}{airunwayv1alpha1.LabelDeployment: md.Name})
+	affinity, err := provider.ComponentAffinity(scheduling, placement)
+	if err != nil {
+		return nil, err
+	}
+	if affinity = provider.WithGPUTypeAffinity(affinity, md); affinity != nil {
+		podSpec["affinity"] = affinity
+	}
+
+	if sa := md.ServiceAccountName(); sa != "" {
+		podSpec["serviceAccountName"] = sa
 	}
 
 	podTemplateAnnotations := map[string]interface{}{}
@@ -228,18 +288,30 @@ func (t *Transformer) buildDeployment(md *airunwayv1alpha1.ModelDeployment, name
 		}
 	}
 
+	template := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels":      podLabels,
+			"annotations": podTemplateAnnotations,
+		},
+		"spec": podSpec,
+	}
+	gang.ApplyToPodTemplate(template)
+	provider.ApplyChatTemplateToPodTemplate(template, md)
+	provider.ApplyKVOffloadToPodTemplate(template, md)
+	provider.ApplyKVCacheToPodTemplate(template, md)
+	provider.ApplyLoRAToPodTemplate(template, md)
+	provider.ApplyLoadFormatToPodTemplate(template, md)
+	provider.ApplyStreamDrainToPodTemplate(template, md)
+	if err := provider.ApplyLogSinkToPodTemplate(template, md); err != nil {
+		return nil, fmt.Errorf("failed to add the log sink: %w", err)
+	}
+
 	spec := map[string]interface{}{
 		"replicas": replicas,
 		"selector": map[string]interface{}{
 			"matchLabels": selectorLabels,
 		},
-		"template": map[string]interface{}{
-			"metadata": map[string]interface{}{
-				"labels":      podLabels,
-				"annotations": podTemplateAnnotations,
-			},
-			"spec": podSpec,
-		},
+		"template": template,
 	}
 
 	if err := unstructured.SetNestedField(d.Object, spec, "spec"); err != nil {
@@ -279,8 +351,8 @@ func (t *Transformer) buildService(md *airunwayv1alpha1.ModelDeployment, name, s
 	spec := map[string]interface{}{
 		"type": "ClusterIP",
 		"selector": map[string]interface{}{
-			"airunway.ai/deployment": md.Name,
-			"app":                        selectorApp,
+			airunwayv1alpha1.LabelDeployment: md.Name,
+			"app":                            selectorApp,
 		},
 		"ports": []interface{}{
 			map[string]interface{}{
@@ -291,6 +363,7 @@ func (t *Transformer) buildService(md *airunwayv1alpha1.ModelDeployment, name, s
 			},
 		},
 	}
+	provider.ApplyIPFamiliesToUnstructuredService(spec, md)
 
 	_ = unstructured.SetNestedField(svc.Object, spec, "spec")
 	return svc
@@ -357,6 +430,15 @@ func (t *Transformer) buildVLLMArgs(md *airunwayv1alpha1.ModelDeployment, kvTran
 		args = append(args, "--trust-remote-code")
 	}
 
+	// Chat template and tokenizer overrides, the model revision, KV cache offload and
+	// sharing, LoRA adapters, and the weight load format
+	args = append(args, provider.ChatTemplateArgs(md)...)
+	args = append(args, provider.RevisionArgs(md)...)
+	args = append(args, provider.KVOffloadArgs(md)...)
+	args = append(args, provider.LMCacheArgs(md)...)
+	args = append(args, provider.LoRAArgs(md)...)
+	args = append(args, provider.LoadFormatArgs(md)...)
+
 	// Tensor parallelism from GPU count
 	tpCount := gpuCount
 	if tpCount == 0 && md.Spec.Resources != nil && md.Spec.Resources.GPU != nil {
@@ -413,6 +495,7 @@ func (t *Transformer) buildResourceLimits(spec *airunwayv1alpha1.ResourceSpec) m
 	if spec.CPU != "" {
 		requests["cpu"] = spec.CPU
 	}
+	requests, limits = provider.ApplyResourceQuantities(requests, limits, spec.Requests, spec.Limits)
 
 	if len(limits) == 0 && len(requests) == 0 {
 		return nil
@@ -467,10 +550,10 @@ func (t *Transformer) buildEnvVars(md *airunwayv1alpha1.ModelDeployment) []inter
 	return envVars
 }
 
-// buildTolerations converts tolerations from ModelDeployment to unstructured format.
-func (t *Transformer) buildTolerations(md *airunwayv1alpha1.ModelDeployment) []interface{} {
-	tolerations := make([]interface{}, len(md.Spec.Tolerations))
-	for i, tol := range md.Spec.Tolerations {
+// buildTolerations converts tolerations to unstructured format.
+func (t *Transformer) buildTolerations(tols []corev1.Toleration) []interface{} {
+	tolerations := make([]interface{}, len(tols))
+	for i, tol := range tols {
 		tolMap := map[string]interface{}{
 			"key":      tol.Key,
 			"operator": string(tol.Operator),
@@ -492,10 +575,10 @@ func (t *Transformer) buildTolerations(md *airunwayv1alpha1.ModelDeployment) []i
 // buildLabels creates the standard set of labels for llm-d resources.
 func (t *Transformer) buildLabels(md *airunwayv1alpha1.ModelDeployment) map[string]string {
 	return map[string]string{
-		"airunway.ai/managed-by":   "airunway",
-		"airunway.ai/deployment":   md.Name,
-		"airunway.ai/model-source": string(md.Spec.Model.Source),
-		"airunway.ai/engine-type":  string(md.ResolvedEngineType()),
+		airunwayv1alpha1.LabelManagedBy:   airunwayv1alpha1.ManagedByAIRunway,
+		airunwayv1alpha1.LabelDeployment:  md.Name,
+		airunwayv1alpha1.LabelModelSource: string(md.Spec.Model.Source),
+		airunwayv1alpha1.LabelEngineType:  string(md.ResolvedEngineType()),
 	}
 }
 
@@ -504,9 +587,30 @@ func (t *Transformer) getImage(md *airunwayv1alpha1.ModelDeployment) string {
 	if md.Spec.Image != "" {
 		return md.Spec.Image
 	}
+	if md.ResolvedDevice() == airunwayv1alpha1.EngineDeviceCPU {
+		return DefaultVLLMCPUImage
+	}
 	return DefaultVLLMImage
 }
 
+// DefaultImage returns the default image the Deployments run, or an empty string when
+// spec.image is set.
+func (t *Transformer) DefaultImage(md *airunwayv1alpha1.ModelDeployment) string {
+	if md.Spec.Image != "" {
+		return ""
+	}
+	return t.getImage(md)
+}
+
+// componentImage returns the image for a disaggregated prefill or decode Deployment:
+// the component image when set, otherwise the deployment image.
+func (t *Transformer) componentImage(md *airunwayv1alpha1.ModelDeployment, component *airunwayv1alpha1.ComponentScalingSpec) string {
+	if component != nil && component.Image != "" {
+		return component.Image
+	}
+	return t.getImage(md)
+}
+
 // componentToResourceSpec converts a ComponentScalingSpec to a ResourceSpec
 // for use in building container resources.
 func componentToResourceSpec(comp *airunwayv1alpha1.ComponentScalingSpec) *airunwayv1alpha1.ResourceSpec {
@@ -514,7 +618,9 @@ func componentToResourceSpec(comp *airunwayv1alpha1.ComponentScalingSpec) *airun
 		return nil
 	}
 	spec := &airunwayv1alpha1.ResourceSpec{
-		Memory: comp.Memory,
+		Memory:   comp.Memory,
+		Requests: comp.Requests,
+		Limits:   comp.Limits,
 	}
 	if comp.GPU != nil {
 		spec.GPU = &airunwayv1alpha1.GPUSpec{
@@ -561,15 +667,21 @@ func boolPtr(b bool) *bool {
 	return &b
 }
 
-// applyOverrides deep-merges spec.provider.overrides into the unstructured object.
+// applyOverrides deep-merges spec.provider.overrides into the unstructured object,
+// after expanding templates in its string values against the ModelDeployment.
 // This is the escape hatch that lets users set arbitrary fields on the provider resource.
 func applyOverrides(obj *unstructured.Unstructured, md *airunwayv1alpha1.ModelDeployment) error {
 	if md.Spec.Provider == nil || md.Spec.Provider.Overrides == nil {
 		return nil
 	}
 
+	raw, err := provider.RenderOverrides(md)
+	if err != nil {
+		return err
+	}
+
 	var overrides map[string]interface{}
-	if err := json.Unmarshal(md.Spec.Provider.Overrides.Raw, &overrides); err != nil {
+	if err := json.Unmarshal(raw, &overrides); err != nil {
 		return fmt.Errorf("failed to unmarshal overrides: %w", err)
 	}
 
@@ -2,11 +2,14 @@ package llmd
 
 import (
 	"context"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	airunwayv1alpha1 "github.com/kaito-project/airunway/controller/api/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -39,11 +42,20 @@ func newTestMD(name, namespace string) *airunwayv1alpha1.ModelDeployment {
 	}
 }
 
+// transformResources runs the transformer and returns the ordered resources.
+func transformResources(tr *Transformer, md *airunwayv1alpha1.ModelDeployment) ([]*unstructured.Unstructured, error) {
+	result, err := tr.Transform(context.Background(), md)
+	if err != nil {
+		return nil, err
+	}
+	return result.Resources, nil
+}
+
 func TestTransformAggregatedBasic(t *testing.T) {
 	tr := NewTransformer()
 	md := newTestMD("test-model", "default")
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -82,7 +94,7 @@ func TestTransformAggregatedOwnerReference(t *testing.T) {
 	tr := NewTransformer()
 	md := newTestMD("test-model", "default")
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -107,7 +119,7 @@ func TestTransformAggregatedLabels(t *testing.T) {
 	tr := NewTransformer()
 	md := newTestMD("test-model", "default")
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -130,7 +142,7 @@ func TestTransformAggregatedReplicas(t *testing.T) {
 	md := newTestMD("test-model", "default")
 	md.Spec.Scaling = &airunwayv1alpha1.ScalingSpec{Replicas: 3}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -147,7 +159,7 @@ func TestTransformAggregatedDefaultReplicas(t *testing.T) {
 	md := newTestMD("test-model", "default")
 	// No scaling spec
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -167,7 +179,7 @@ func TestTransformAggregatedVLLMArgs(t *testing.T) {
 	md.Spec.Engine.ContextLength = &contextLen
 	md.Spec.Engine.TrustRemoteCode = true
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -193,7 +205,7 @@ func TestTransformAggregatedTensorParallelism(t *testing.T) {
 		GPU: &airunwayv1alpha1.GPUSpec{Count: 4},
 	}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -211,7 +223,7 @@ func TestTransformAggregatedNoTensorParallelForSingleGPU(t *testing.T) {
 	md := newTestMD("test-model", "default")
 	// 1 GPU — no tensor-parallel-size needed
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -232,7 +244,7 @@ func TestTransformAggregatedDefaultImage(t *testing.T) {
 	tr := NewTransformer()
 	md := newTestMD("test-model", "default")
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -250,7 +262,7 @@ func TestTransformAggregatedCustomImage(t *testing.T) {
 	md := newTestMD("test-model", "default")
 	md.Spec.Image = "my-custom-vllm:latest"
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -271,7 +283,7 @@ func TestTransformAggregatedGPUResources(t *testing.T) {
 		Memory: "32Gi",
 	}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -299,7 +311,7 @@ func TestTransformAggregatedHFTokenSecret(t *testing.T) {
 		HuggingFaceToken: "my-hf-secret",
 	}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -345,7 +357,7 @@ func TestTransformAggregatedEnvVars(t *testing.T) {
 		},
 	}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -375,7 +387,7 @@ func TestTransformAggregatedNodeSelector(t *testing.T) {
 		"gpu-type": "a100",
 	}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -401,7 +413,7 @@ func TestTransformAggregatedPodTemplateLabels(t *testing.T) {
 		},
 	}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -426,7 +438,7 @@ func TestTransformAggregatedServicePort(t *testing.T) {
 	tr := NewTransformer()
 	md := newTestMD("test-model", "default")
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -442,6 +454,30 @@ func TestTransformAggregatedServicePort(t *testing.T) {
 	}
 }
 
+func TestTransformServiceIPFamilies(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	policy := corev1.IPFamilyPolicyRequireDualStack
+	md.Spec.Networking = &airunwayv1alpha1.NetworkingSpec{
+		IPFamilyPolicy: &policy,
+		IPFamilies:     []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol},
+	}
+
+	resources, err := transformResources(tr, md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	svc := resources[1]
+	if got, _, _ := unstructured.NestedString(svc.Object, "spec", "ipFamilyPolicy"); got != "RequireDualStack" {
+		t.Errorf("expected ipFamilyPolicy RequireDualStack, got %q", got)
+	}
+	families, _, _ := unstructured.NestedStringSlice(svc.Object, "spec", "ipFamilies")
+	if len(families) != 2 || families[0] != "IPv6" || families[1] != "IPv4" {
+		t.Errorf("expected ipFamilies [IPv6 IPv4], got %v", families)
+	}
+}
+
 func TestTransformAggregatedCustomEngineArgs(t *testing.T) {
 	tr := NewTransformer()
 	md := newTestMD("test-model", "default")
@@ -450,7 +486,7 @@ func TestTransformAggregatedCustomEngineArgs(t *testing.T) {
 		"disable-log-requests":   "",
 	}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -505,7 +541,7 @@ func TestTransformDisaggregatedBasic(t *testing.T) {
 		},
 	}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -550,7 +586,7 @@ func TestTransformDisaggregatedKVTransferConfig(t *testing.T) {
 		},
 	}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -582,7 +618,7 @@ func TestTransformDisaggregatedReplicas(t *testing.T) {
 		},
 	}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -601,6 +637,78 @@ func TestTransformDisaggregatedReplicas(t *testing.T) {
 	}
 }
 
+func TestTransformDisaggregatedComponentImages(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Serving = &airunwayv1alpha1.ServingSpec{
+		Mode: airunwayv1alpha1.ServingModeDisaggregated,
+	}
+	md.Spec.Scaling = &airunwayv1alpha1.ScalingSpec{
+		Prefill: &airunwayv1alpha1.ComponentScalingSpec{
+			Replicas: 1,
+			GPU:      &airunwayv1alpha1.GPUSpec{Count: 1},
+			Image:    "example.com/vllm:nixl",
+		},
+		Decode: &airunwayv1alpha1.ComponentScalingSpec{
+			Replicas: 1,
+			GPU:      &airunwayv1alpha1.GPUSpec{Count: 1},
+		},
+	}
+
+	resources, err := transformResources(tr, md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for i, want := range []string{DefaultVLLMImage, "example.com/vllm:nixl"} {
+		containers, _, _ := unstructured.NestedSlice(resources[i].Object, "spec", "template", "spec", "containers")
+		container, _ := containers[0].(map[string]interface{})
+		if container["image"] != want {
+			t.Errorf("expected %s image %q, got %v", resources[i].GetName(), want, container["image"])
+		}
+	}
+}
+
+func TestTransformDisaggregatedGangScheduling(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Serving = &airunwayv1alpha1.ServingSpec{
+		Mode: airunwayv1alpha1.ServingModeDisaggregated,
+	}
+	md.Spec.Scaling = &airunwayv1alpha1.ScalingSpec{
+		Prefill: &airunwayv1alpha1.ComponentScalingSpec{Replicas: 2, GPU: &airunwayv1alpha1.GPUSpec{Count: 1}},
+		Decode:  &airunwayv1alpha1.ComponentScalingSpec{Replicas: 1, GPU: &airunwayv1alpha1.GPUSpec{Count: 2}},
+	}
+	md.Spec.Scheduling = &airunwayv1alpha1.SchedulingSpec{
+		Gang:      true,
+		Scheduler: airunwayv1alpha1.GangSchedulerVolcano,
+		Queue:     "research",
+	}
+
+	result, err := tr.Transform(context.Background(), md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Resources) != 5 || result.Resources[4].GetKind() != "PodGroup" {
+		t.Fatalf("expected the PodGroup after the Deployments and Services, got %d resources", len(result.Resources))
+	}
+	minMember, _, _ := unstructured.NestedInt64(result.Resources[4].Object, "spec", "minMember")
+	if minMember != 3 {
+		t.Errorf("expected minMember to cover prefill and decode pods, got %d", minMember)
+	}
+	if primary := result.Primary(); primary.GetName() != "test-model-decode" {
+		t.Errorf("expected the decode Deployment to stay primary, got %s", primary.GetName())
+	}
+
+	for _, deploy := range result.Resources[0:2] {
+		schedulerName, _, _ := unstructured.NestedString(deploy.Object, "spec", "template", "spec", "schedulerName")
+		group, _, _ := unstructured.NestedString(deploy.Object, "spec", "template", "metadata", "annotations", "scheduling.k8s.io/group-name")
+		if schedulerName != "volcano" || group != "test-model" {
+			t.Errorf("expected %s pods in the volcano gang, got schedulerName %q group %q", deploy.GetName(), schedulerName, group)
+		}
+	}
+}
+
 func TestTransformDisaggregatedMissingScaling(t *testing.T) {
 	tr := NewTransformer()
 	md := newTestMD("test-model", "default")
@@ -624,7 +732,7 @@ func TestTransformAggregatedOverride(t *testing.T) {
 		},
 	}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -740,6 +848,21 @@ func TestBuildResourceLimits(t *testing.T) {
 	if limits["memory"] != "16Gi" {
 		t.Errorf("expected memory 16Gi, got %v", limits["memory"])
 	}
+
+	// Explicit requests and limits win over the shorthand
+	r = tr.buildResourceLimits(componentToResourceSpec(&airunwayv1alpha1.ComponentScalingSpec{
+		Memory:   "16Gi",
+		Requests: &airunwayv1alpha1.ResourceQuantities{CPU: "2", Memory: "12Gi"},
+		Limits:   &airunwayv1alpha1.ResourceQuantities{CPU: "4"},
+	}))
+	limits = r["limits"].(map[string]interface{})
+	requests := r["requests"].(map[string]interface{})
+	if limits["memory"] != "16Gi" || limits["cpu"] != "4" {
+		t.Errorf("expected shorthand memory and explicit cpu limits, got %v", limits)
+	}
+	if requests["memory"] != "12Gi" || requests["cpu"] != "2" {
+		t.Errorf("expected explicit requests, got %v", requests)
+	}
 }
 
 func TestSanitizeLabelValue(t *testing.T) {
@@ -814,7 +937,7 @@ func TestTransformDisaggregatedTensorParallelism(t *testing.T) {
 		},
 	}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -844,7 +967,7 @@ func TestTransformUserLabelsCannotClobberSelectors(t *testing.T) {
 		},
 	}
 
-	resources, err := tr.Transform(context.Background(), md)
+	resources, err := transformResources(tr, md)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -861,6 +984,10 @@ func TestTransformUserLabelsCannotClobberSelectors(t *testing.T) {
 		t.Errorf("selector deployment=%v but pod deployment=%v", selectorLabels["airunway.ai/deployment"], podLabels["airunway.ai/deployment"])
 	}
 
+	if podLabels["airunway.ai/model-deployment"] != md.Name {
+		t.Errorf("expected pod label airunway.ai/model-deployment=%s, got %v", md.Name, podLabels["airunway.ai/model-deployment"])
+	}
+
 	// Custom labels should still be present
 	if podLabels["my-label"] != "my-value" {
 		t.Errorf("custom label my-label not preserved, got %v", podLabels["my-label"])
@@ -886,3 +1013,508 @@ func getContainerArgs(t *testing.T, deploy *unstructured.Unstructured) []string
 	container := containers[0].(map[string]interface{})
 	return argsToStrings(container["args"].([]interface{}))
 }
+
+func TestTransformDisaggregatedPlacement(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Serving = &airunwayv1alpha1.ServingSpec{
+		Mode: airunwayv1alpha1.ServingModeDisaggregated,
+		Placement: &airunwayv1alpha1.PlacementSpec{
+			Colocate:  airunwayv1alpha1.PlacementDomainNVLinkDomain,
+			Preferred: true,
+		},
+	}
+	md.Spec.Scaling = &airunwayv1alpha1.ScalingSpec{
+		Prefill: &airunwayv1alpha1.ComponentScalingSpec{Replicas: 1, GPU: &airunwayv1alpha1.GPUSpec{Count: 1}},
+		Decode:  &airunwayv1alpha1.ComponentScalingSpec{Replicas: 1, GPU: &airunwayv1alpha1.GPUSpec{Count: 1}},
+	}
+
+	resources, err := transformResources(tr, md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, deployment := range resources[:2] {
+		terms, found, _ := unstructured.NestedSlice(deployment.Object, "spec", "template", "spec", "affinity", "podAffinity", "preferredDuringSchedulingIgnoredDuringExecution")
+		if !found || len(terms) != 1 {
+			t.Fatalf("expected preferred pod affinity on %s, got %v", deployment.GetName(), terms)
+		}
+		weighted, _ := terms[0].(map[string]interface{})
+		if weighted["weight"] != int64(100) {
+			t.Errorf("expected weight 100, got %v", weighted["weight"])
+		}
+		topologyKey, _, _ := unstructured.NestedString(weighted, "podAffinityTerm", "topologyKey")
+		if topologyKey != airunwayv1alpha1.TopologyKeyNVLinkDomain {
+			t.Errorf("expected topologyKey %q, got %q", airunwayv1alpha1.TopologyKeyNVLinkDomain, topologyKey)
+		}
+	}
+}
+
+func TestTransformDisaggregatedComponentScheduling(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.NodeSelector = map[string]string{"pool": "gpu", "zone": "a"}
+	md.Spec.Tolerations = []corev1.Toleration{{Key: "gpu", Operator: corev1.TolerationOpExists}}
+	md.Spec.Serving = &airunwayv1alpha1.ServingSpec{
+		Mode:      airunwayv1alpha1.ServingModeDisaggregated,
+		Placement: &airunwayv1alpha1.PlacementSpec{Colocate: airunwayv1alpha1.PlacementDomainZone},
+	}
+	md.Spec.Scaling = &airunwayv1alpha1.ScalingSpec{
+		Prefill: &airunwayv1alpha1.ComponentScalingSpec{
+			Replicas: 1,
+			GPU:      &airunwayv1alpha1.GPUSpec{Count: 1},
+			Scheduling: &airunwayv1alpha1.ComponentSchedulingSpec{
+				NodeSelector: map[string]string{"pool": "compute"},
+				Tolerations:  []corev1.Toleration{{Key: "compute", Operator: corev1.TolerationOpExists}},
+				Affinity: &corev1.Affinity{
+					NodeAffinity: &corev1.NodeAffinity{
+						RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+							NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+								MatchExpressions: []corev1.NodeSelectorRequirement{{
+									Key: "gpu-type", Operator: corev1.NodeSelectorOpIn, Values: []string{"h100"},
+								}},
+							}},
+						},
+					},
+				},
+			},
+		},
+		Decode: &airunwayv1alpha1.ComponentScalingSpec{Replicas: 1, GPU: &airunwayv1alpha1.GPUSpec{Count: 1}},
+	}
+
+	resources, err := transformResources(tr, md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	decode, prefill := resources[0], resources[1]
+
+	nodeSelector, _, _ := unstructured.NestedStringMap(prefill.Object, "spec", "template", "spec", "nodeSelector")
+	if nodeSelector["pool"] != "compute" || nodeSelector["zone"] != "a" {
+		t.Errorf("expected prefill nodeSelector merged with the component's, got %v", nodeSelector)
+	}
+	tolerations, _, _ := unstructured.NestedSlice(prefill.Object, "spec", "template", "spec", "tolerations")
+	if len(tolerations) != 2 {
+		t.Errorf("expected 2 prefill tolerations, got %v", tolerations)
+	}
+	if _, found, _ := unstructured.NestedMap(prefill.Object, "spec", "template", "spec", "affinity", "nodeAffinity"); !found {
+		t.Error("expected prefill node affinity from the component scheduling")
+	}
+	if _, found, _ := unstructured.NestedMap(prefill.Object, "spec", "template", "spec", "affinity", "podAffinity"); !found {
+		t.Error("expected prefill pod affinity from placement to be kept")
+	}
+
+	nodeSelector, _, _ = unstructured.NestedStringMap(decode.Object, "spec", "template", "spec", "nodeSelector")
+	if nodeSelector["pool"] != "gpu" {
+		t.Errorf("expected decode nodeSelector from spec.nodeSelector, got %v", nodeSelector)
+	}
+	if _, found, _ := unstructured.NestedMap(decode.Object, "spec", "template", "spec", "affinity", "nodeAffinity"); found {
+		t.Error("expected no decode node affinity")
+	}
+}
+
+func TestTransformAggregatedIgnoresPlacement(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Serving = &airunwayv1alpha1.ServingSpec{
+		Placement: &airunwayv1alpha1.PlacementSpec{Colocate: airunwayv1alpha1.PlacementDomainZone},
+	}
+
+	resources, err := transformResources(tr, md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, found, _ := unstructured.NestedMap(resources[0].Object, "spec", "template", "spec", "affinity"); found {
+		t.Error("expected no affinity in aggregated mode")
+	}
+}
+
+func TestTransformDisaggregatedResultHints(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Serving = &airunwayv1alpha1.ServingSpec{
+		Mode: airunwayv1alpha1.ServingModeDisaggregated,
+	}
+	md.Spec.Scaling = &airunwayv1alpha1.ScalingSpec{
+		Prefill: &airunwayv1alpha1.ComponentScalingSpec{Replicas: 1, GPU: &airunwayv1alpha1.GPUSpec{Count: 1}},
+		Decode:  &airunwayv1alpha1.ComponentScalingSpec{Replicas: 1, GPU: &airunwayv1alpha1.GPUSpec{Count: 1}},
+	}
+
+	result, err := tr.Transform(context.Background(), md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := result.Validate(); err != nil {
+		t.Fatalf("expected a valid result, got %v", err)
+	}
+
+	primary := result.Primary()
+	if primary.GetKind() != "Deployment" || primary.GetName() != "test-model-decode" {
+		t.Errorf("expected decode Deployment as primary, got %s %s", primary.GetKind(), primary.GetName())
+	}
+}
+
+func TestTransformCPUDevice(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Engine.Device = airunwayv1alpha1.EngineDeviceCPU
+	md.Spec.Resources = &airunwayv1alpha1.ResourceSpec{CPU: "8", Memory: "32Gi"}
+
+	resources, err := transformResources(tr, md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	containers, _, _ := unstructured.NestedSlice(resources[0].Object, "spec", "template", "spec", "containers")
+	container := containers[0].(map[string]interface{})
+	if container["image"] != DefaultVLLMCPUImage {
+		t.Errorf("expected CPU image %s, got %v", DefaultVLLMCPUImage, container["image"])
+	}
+	limits, _, _ := unstructured.NestedStringMap(container, "resources", "limits")
+	if _, ok := limits[GPUResourceKey]; ok {
+		t.Error("expected no GPU limit for cpu device")
+	}
+	requests, _, _ := unstructured.NestedStringMap(container, "resources", "requests")
+	if requests["cpu"] != "8" || requests["memory"] != "32Gi" {
+		t.Errorf("expected cpu and memory requests, got %v", requests)
+	}
+
+	// An explicit image still wins
+	md.Spec.Image = "example.com/vllm-cpu:custom"
+	resources, err = transformResources(tr, md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	containers, _, _ = unstructured.NestedSlice(resources[0].Object, "spec", "template", "spec", "containers")
+	if image := containers[0].(map[string]interface{})["image"]; image != md.Spec.Image {
+		t.Errorf("expected explicit image, got %v", image)
+	}
+
+	md.Spec.Serving = &airunwayv1alpha1.ServingSpec{Mode: airunwayv1alpha1.ServingModeDisaggregated}
+	if _, err := tr.Transform(context.Background(), md); err == nil {
+		t.Error("expected an error for cpu inference in disaggregated mode")
+	}
+}
+
+func TestTransformIdentity(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Identity = &airunwayv1alpha1.IdentitySpec{
+		Annotations: map[string]string{"azure.workload.identity/client-id": "00000000-0000-0000-0000-000000000000"},
+	}
+
+	resources, err := transformResources(tr, md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sa, _, _ := unstructured.NestedString(resources[0].Object, "spec", "template", "spec", "serviceAccountName")
+	if sa != "test-model" {
+		t.Errorf("expected serviceAccountName test-model, got %q", sa)
+	}
+	use, _, _ := unstructured.NestedString(resources[0].Object, "spec", "template", "metadata", "labels", "azure.workload.identity/use")
+	if use != "true" {
+		t.Errorf("expected azure.workload.identity/use label, got %q", use)
+	}
+
+	md.Spec.Identity = &airunwayv1alpha1.IdentitySpec{ServiceAccountName: "model-reader"}
+	resources, err = transformResources(tr, md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sa, _, _ = unstructured.NestedString(resources[0].Object, "spec", "template", "spec", "serviceAccountName")
+	if sa != "model-reader" {
+		t.Errorf("expected serviceAccountName model-reader, got %q", sa)
+	}
+}
+
+func TestTransformGPUTypes(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Resources = &airunwayv1alpha1.ResourceSpec{
+		GPU: &airunwayv1alpha1.GPUSpec{Count: 1, Types: []string{"NVIDIA-H100-80GB-HBM3", "NVIDIA-A100-SXM4-80GB"}},
+	}
+	md.Status.GPUType = "NVIDIA-A100-SXM4-80GB"
+
+	resources, err := transformResources(tr, md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	terms, found, _ := unstructured.NestedSlice(resources[0].Object, "spec", "template", "spec", "affinity", "nodeAffinity", "requiredDuringSchedulingIgnoredDuringExecution", "nodeSelectorTerms")
+	if !found || len(terms) != 1 {
+		t.Fatalf("expected a required node selector term, got %v", terms)
+	}
+	preferred, _, _ := unstructured.NestedSlice(resources[0].Object, "spec", "template", "spec", "affinity", "nodeAffinity", "preferredDuringSchedulingIgnoredDuringExecution")
+	if len(preferred) != 2 {
+		t.Fatalf("expected a preferred term per GPU type, got %v", preferred)
+	}
+	expressions, _, _ := unstructured.NestedSlice(preferred[0].(map[string]interface{}), "preference", "matchExpressions")
+	if values := expressions[0].(map[string]interface{})["values"].([]interface{}); values[0] != "NVIDIA-A100-SXM4-80GB" {
+		t.Errorf("expected status.gpuType to be most preferred, got %v", values)
+	}
+}
+
+func TestTransformChatTemplate(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Model.ChatTemplate = &airunwayv1alpha1.ChatTemplateSource{Inline: "{{ messages }}"}
+	md.Spec.Model.Tokenizer = "org/tokenizer"
+
+	resources, err := transformResources(tr, md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cm := resources[len(resources)-1]
+	if cm.GetKind() != "ConfigMap" || cm.GetName() != "test-model-chat-template" {
+		t.Fatalf("expected the chat template ConfigMap, got %s %s", cm.GetKind(), cm.GetName())
+	}
+	deployment := resources[0]
+	containers, _, _ := unstructured.NestedSlice(deployment.Object, "spec", "template", "spec", "containers")
+	container := containers[0].(map[string]interface{})
+	args := strings.Join(argsToStrings(container["args"].([]interface{})), " ")
+	if !strings.Contains(args, "--chat-template /etc/airunway/chat-template/chat_template.jinja") {
+		t.Errorf("expected --chat-template to point at the mounted template, got %s", args)
+	}
+	if !strings.Contains(args, "--tokenizer org/tokenizer") {
+		t.Errorf("expected --tokenizer, got %s", args)
+	}
+	if mounts, _, _ := unstructured.NestedSlice(container, "volumeMounts"); len(mounts) != 1 {
+		t.Errorf("expected the chat template to be mounted, got %v", mounts)
+	}
+	if volumes, _, _ := unstructured.NestedSlice(deployment.Object, "spec", "template", "spec", "volumes"); len(volumes) != 1 {
+		t.Errorf("expected the chat template volume, got %v", volumes)
+	}
+	if hash, _, _ := unstructured.NestedString(deployment.Object, "spec", "template", "metadata", "annotations", "airunway.ai/chat-template-hash"); hash == "" {
+		t.Error("expected the chat template hash annotation on the pod template")
+	}
+}
+
+func TestTransformModelRevision(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Model.Revision = "0123456789abcdef0123456789abcdef01234567"
+
+	resources, err := transformResources(tr, md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	containers, _, _ := unstructured.NestedSlice(resources[0].Object, "spec", "template", "spec", "containers")
+	args := strings.Join(argsToStrings(containers[0].(map[string]interface{})["args"].([]interface{})), " ")
+	if !strings.Contains(args, "--revision "+md.Spec.Model.Revision) {
+		t.Errorf("expected --revision, got %s", args)
+	}
+}
+
+func TestTransformLogSink(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Observability = &airunwayv1alpha1.ObservabilitySpec{LogSink: &airunwayv1alpha1.LogSinkSpec{
+		Type: airunwayv1alpha1.LogSinkTypeLoki, Endpoint: "http://loki.observability:3100",
+	}}
+
+	resources, err := transformResources(tr, md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cm := resources[len(resources)-1]
+	if cm.GetKind() != "ConfigMap" || cm.GetName() != "test-model-log-sink" {
+		t.Fatalf("expected the log sink ConfigMap, got %s %s", cm.GetKind(), cm.GetName())
+	}
+	deployment := resources[0]
+	containers, _, _ := unstructured.NestedSlice(deployment.Object, "spec", "template", "spec", "containers")
+	if len(containers) != 2 || containers[1].(map[string]interface{})["name"] != "log-sink" {
+		t.Errorf("expected the log sink sidecar after the model server, got %v", containers)
+	}
+	if hash, _, _ := unstructured.NestedString(deployment.Object, "spec", "template", "metadata", "annotations", "airunway.ai/log-sink-config-hash"); hash == "" {
+		t.Error("expected the log sink config hash annotation on the pod template")
+	}
+}
+
+func TestTransformKVOffload(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Model.Storage = &airunwayv1alpha1.StorageSpec{KVOffload: &airunwayv1alpha1.KVOffloadSpec{Size: resource.MustParse("64Gi")}}
+
+	resources, err := transformResources(tr, md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	containers, _, _ := unstructured.NestedSlice(resources[0].Object, "spec", "template", "spec", "containers")
+	args := strings.Join(argsToStrings(containers[0].(map[string]interface{})["args"].([]interface{})), " ")
+	if !strings.Contains(args, "--swap-space 64") {
+		t.Errorf("expected --swap-space for ram offload, got %s", args)
+	}
+
+	md.Spec.Model.Storage.KVOffload.Medium = airunwayv1alpha1.KVOffloadMediumNVMe
+	resources, err = transformResources(tr, md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	containers, _, _ = unstructured.NestedSlice(resources[0].Object, "spec", "template", "spec", "containers")
+	container := containers[0].(map[string]interface{})
+	args = strings.Join(argsToStrings(container["args"].([]interface{})), " ")
+	if !strings.Contains(args, "LMCacheConnectorV1") {
+		t.Errorf("expected the LMCache connector for nvme offload, got %s", args)
+	}
+	volumes, _, _ := unstructured.NestedSlice(resources[0].Object, "spec", "template", "spec", "volumes")
+	if len(volumes) != 1 || volumes[0].(map[string]interface{})["name"] != "kv-offload" {
+		t.Errorf("expected the KV offload volume, got %v", volumes)
+	}
+	env, _ := container["env"].([]interface{})
+	found := false
+	for _, e := range env {
+		if e.(map[string]interface{})["name"] == "LMCACHE_LOCAL_DISK" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected LMCACHE_LOCAL_DISK, got %v", env)
+	}
+
+	// nvme offload conflicts with the NIXL connector of disaggregated serving
+	md.Spec.Serving = &airunwayv1alpha1.ServingSpec{Mode: airunwayv1alpha1.ServingModeDisaggregated}
+	if _, err := tr.Transform(context.Background(), md); err == nil {
+		t.Error("expected nvme offload to be rejected in disaggregated mode")
+	}
+}
+
+func TestTransformKVCache(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Caching = &airunwayv1alpha1.CachingSpec{KV: &airunwayv1alpha1.KVCacheSpec{
+		Backend: airunwayv1alpha1.KVCacheBackendLMCache,
+		ConnectionSecretRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "lmcache"}, Key: "url",
+		},
+	}}
+
+	resources, err := transformResources(tr, md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	containers, _, _ := unstructured.NestedSlice(resources[0].Object, "spec", "template", "spec", "containers")
+	container := containers[0].(map[string]interface{})
+	args := strings.Join(argsToStrings(container["args"].([]interface{})), " ")
+	if strings.Count(args, "LMCacheConnectorV1") != 1 {
+		t.Errorf("expected the LMCache connector once, got %s", args)
+	}
+	var remote map[string]interface{}
+	env, _ := container["env"].([]interface{})
+	for _, e := range env {
+		if e.(map[string]interface{})["name"] == "LMCACHE_REMOTE_URL" {
+			remote = e.(map[string]interface{})
+		}
+	}
+	if remote == nil || remote["valueFrom"] == nil {
+		t.Errorf("expected LMCACHE_REMOTE_URL from the connection Secret, got %v", env)
+	}
+}
+
+func TestTransformLoRAAdapters(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Model.Adapters = []airunwayv1alpha1.LoRAAdapter{{Name: "sql", Source: "org/sql-lora"}}
+
+	resources, err := transformResources(tr, md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	containers, _, _ := unstructured.NestedSlice(resources[0].Object, "spec", "template", "spec", "containers")
+	container := containers[0].(map[string]interface{})
+	args := argsToStrings(container["args"].([]interface{}))
+	if !slices.Contains(args, "--enable-lora") {
+		t.Errorf("expected --enable-lora, got %v", args)
+	}
+	// Adapters are loaded at runtime, never passed to the engine
+	if strings.Contains(strings.Join(args, " "), "org/sql-lora") {
+		t.Errorf("expected no adapter in the args, got %v", args)
+	}
+	found := false
+	env, _ := container["env"].([]interface{})
+	for _, e := range env {
+		if e.(map[string]interface{})["name"] == "VLLM_ALLOW_RUNTIME_LORA_UPDATING" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected VLLM_ALLOW_RUNTIME_LORA_UPDATING, got %v", env)
+	}
+
+	md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeSGLang
+	if _, err := transformResources(tr, md); err == nil {
+		t.Error("expected an error for adapters with sglang")
+	}
+}
+
+func TestTransformLoadFormat(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Model.LoadFormat = airunwayv1alpha1.ModelLoadFormatRunAIStreamer
+	md.Spec.Model.LoadCredentialsSecret = "model-store"
+
+	resources, err := transformResources(tr, md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	containers, _, _ := unstructured.NestedSlice(resources[0].Object, "spec", "template", "spec", "containers")
+	container := containers[0].(map[string]interface{})
+	args := argsToStrings(container["args"].([]interface{}))
+	if !strings.Contains(strings.Join(args, " "), "--load-format runai_streamer") {
+		t.Errorf("expected --load-format runai_streamer, got %v", args)
+	}
+	envFrom, _ := container["envFrom"].([]interface{})
+	if len(envFrom) != 1 || envFrom[0].(map[string]interface{})["secretRef"].(map[string]interface{})["name"] != "model-store" {
+		t.Errorf("expected the load credentials Secret, got %v", envFrom)
+	}
+
+	md.Spec.Engine.Type = airunwayv1alpha1.EngineTypeSGLang
+	if _, err := transformResources(tr, md); err == nil {
+		t.Error("expected an error for a load format with sglang")
+	}
+}
+
+func TestTransformStreamDrain(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Gateway = &airunwayv1alpha1.GatewaySpec{Streaming: true, Timeout: &metav1.Duration{Duration: 30 * time.Minute}}
+
+	resources, err := transformResources(tr, md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok, _ := unstructured.NestedSlice(resources[0].Object, "spec", "template", "spec", "readinessGates"); ok {
+		t.Error("expected no readiness gate")
+	}
+	if grace, _, _ := unstructured.NestedInt64(resources[0].Object, "spec", "template", "spec", "terminationGracePeriodSeconds"); grace != 1810 {
+		t.Errorf("expected a 1810s grace period, got %d", grace)
+	}
+	containers, _, _ := unstructured.NestedSlice(resources[0].Object, "spec", "template", "spec", "containers")
+	if _, ok, _ := unstructured.NestedMap(containers[0].(map[string]interface{}), "lifecycle", "preStop", "sleep"); !ok {
+		t.Errorf("expected a preStop sleep on the engine container, got %v", containers[0])
+	}
+}
+
+func TestTransformPropagatedMetadata(t *testing.T) {
+	tr := NewTransformer()
+	md := newTestMD("test-model", "default")
+	md.Spec.Labels = map[string]string{"cost-center": "ml-42", "app": "user-app", "airunway.ai/deployment": "other"}
+	md.Spec.Annotations = map[string]string{"example.com/owner": "search-team"}
+
+	resources, err := transformResources(tr, md)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, obj := range resources {
+		if obj.GetLabels()["cost-center"] != "ml-42" || obj.GetAnnotations()["example.com/owner"] != "search-team" {
+			t.Errorf("expected propagated metadata on %s, got %v %v", obj.GetKind(), obj.GetLabels(), obj.GetAnnotations())
+		}
+		if obj.GetLabels()["airunway.ai/deployment"] != "test-model" {
+			t.Errorf("expected the reserved label to be kept on %s, got %v", obj.GetKind(), obj.GetLabels())
+		}
+	}
+	podLabels, _, _ := unstructured.NestedStringMap(resources[0].Object, "spec", "template", "metadata", "labels")
+	if podLabels["cost-center"] != "ml-42" || podLabels["app"] == "user-app" {
+		t.Errorf("expected propagated pod labels without overriding the selector, got %v", podLabels)
+	}
+	podAnnotations, _, _ := unstructured.NestedStringMap(resources[0].Object, "spec", "template", "metadata", "annotations")
+	if podAnnotations["example.com/owner"] != "search-team" {
+		t.Errorf("expected propagated pod annotations, got %v", podAnnotations)
+	}
+}
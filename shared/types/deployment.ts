@@ -4,9 +4,10 @@ import { Engine } from './model';
 // These types mirror the Go CRD types in controller/api/v1alpha1/
 
 export type ModelSource = 'huggingface' | 'custom';
+export type ModelLoadFormat = 'safetensors' | 'tensorizer' | 'runai_streamer';
 export type EngineType = 'vllm' | 'sglang' | 'trtllm' | 'llamacpp';
-export type ServingMode = 'aggregated' | 'disaggregated';
-export type DeploymentPhase = 'Pending' | 'Deploying' | 'Running' | 'Failed' | 'Terminating';
+export type ServingMode = 'aggregated' | 'disaggregated' | 'auto';
+export type DeploymentPhase = 'Pending' | 'Queued' | 'Deploying' | 'Running' | 'Failed' | 'Terminating';
 export type PodPhase = 'Pending' | 'Running' | 'Succeeded' | 'Failed' | 'Unknown';
 
 // Storage types (mirrors controller StorageSpec / StorageVolume)
@@ -24,14 +25,23 @@ export interface StorageVolume {
   accessMode?: PersistentVolumeAccessMode;
 }
 
+export type KVOffloadMedium = 'ram' | 'nvme';
+
+export interface KVOffloadSpec {
+  size: string;
+  medium?: KVOffloadMedium;
+  storageClassName?: string;
+}
+
 export interface StorageSpec {
   volumes?: StorageVolume[];
+  kvOffload?: KVOffloadSpec;
 }
 
 // Legacy types for backward compatibility
-export type DeploymentMode = ServingMode;
+export type DeploymentMode = Exclude<ServingMode, 'auto'>;
 export type GgufRunMode = 'build' | 'direct';
-export type RouterMode = 'default' | 'kv' | 'round-robin';
+export type RouterMode = 'default' | 'kv' | 'round-robin' | 'none';
 export type KaitoResourceType = 'workspace' | 'inferenceset';
 
 export interface DeploymentConfig {
@@ -73,43 +83,148 @@ export interface DeploymentConfig {
 
 export interface ModelSpec {
   id: string;
+  revision?: string;
   servedName?: string;
   source?: ModelSource;
   storage?: StorageSpec;
+  license?: string;
+  chatTemplate?: ChatTemplateSource;
+  tokenizer?: string;
+  loadFormat?: ModelLoadFormat;
+  tensorizerURI?: string;
+  loadCredentialsSecret?: string;
+  adapters?: LoRAAdapter[];
+}
+
+export interface LoRAAdapter {
+  name: string;
+  source: string;
+}
+
+export interface AdapterStatus {
+  name: string;
+  loadedReplicas: number;
+}
+
+export interface PodPlacement {
+  pod: string;
+  node: string;
+  gpus?: number;
+  gpuType?: string;
+  gpuIndices?: string[];
+}
+
+export interface ChatTemplateSource {
+  inline?: string;
+  configMapKeyRef?: {
+    name: string;
+    key: string;
+    optional?: boolean;
+  };
 }
 
 export interface ProviderSpec {
   name?: string;
   overrides?: Record<string, unknown>;
+  migrateTo?: string;
 }
 
+export type EngineDevice = 'gpu' | 'cpu' | 'auto';
+
 export interface EngineSpec {
   type: EngineType;
+  device?: EngineDevice;
   contextLength?: number;
   trustRemoteCode?: boolean;
   enablePrefixCaching?: boolean;
   enforceEager?: boolean;
   args?: Record<string, string>;
+  remediation?: EngineRemediationSpec;
+}
+
+export interface EngineRemediationSpec {
+  enabled?: boolean;
+  maxAttempts?: number;
+}
+
+export type PlacementDomain = 'zone' | 'nvlinkDomain' | 'node';
+
+export interface PlacementSpec {
+  colocate: PlacementDomain;
+  topologyKey?: string;
+  preferred?: boolean;
+}
+
+export interface RouterSpec {
+  replicas?: number;
+  cpu?: string;
+  memory?: string;
+  routerMode?: Exclude<RouterMode, 'default'>;
 }
 
 export interface ServingSpec {
   mode?: ServingMode;
+  placement?: PlacementSpec;
+  router?: RouterSpec;
 }
 
 export interface GPUSpec {
   count: number;
   type?: string;
+  class?: string;
+  types?: string[];
+}
+
+export interface AutotuneBounds {
+  minCPU?: string;
+  maxCPU?: string;
+  minMemory?: string;
+  maxMemory?: string;
+}
+
+export interface ResourceQuantities {
+  cpu?: string;
+  memory?: string;
+}
+
+export type NUMAPolicy = 'none' | 'single-numa-node';
+
+export interface CPUPinningSpec {
+  exclusiveCores?: number;
+  numaPolicy?: NUMAPolicy;
 }
 
 export interface ResourceSpec {
   gpu?: GPUSpec;
   memory?: string;
   cpu?: string;
+  requests?: ResourceQuantities;
+  limits?: ResourceQuantities;
+  autotune?: boolean;
+  autotuneBounds?: AutotuneBounds;
+  cpuPinning?: CPUPinningSpec;
 }
 
 export interface ComponentScalingSpec {
   replicas: number;
   gpu?: GPUSpec;
+  memory?: string;
+  requests?: ResourceQuantities;
+  limits?: ResourceQuantities;
+  image?: string;
+  scheduling?: ComponentSchedulingSpec;
+}
+
+export interface ComponentSchedulingSpec {
+  nodeSelector?: Record<string, string>;
+  tolerations?: Array<{
+    key?: string;
+    operator?: string;
+    value?: string;
+    effect?: string;
+    tolerationSeconds?: number;
+  }>;
+  affinity?: Record<string, unknown>;
 }
 
 export interface ScalingSpec {
@@ -118,6 +233,11 @@ export interface ScalingSpec {
   maxReplicas?: number;
   prefill?: ComponentScalingSpec;
   decode?: ComponentScalingSpec;
+  podDeletionCost?: boolean;
+}
+
+export interface MultinodeSpec {
+  nodeCount: number;
 }
 
 export interface PodTemplateSpec {
@@ -135,13 +255,152 @@ export interface PodTemplateSpec {
 
 export interface SecretSpec {
   huggingFaceToken?: string;
+  rolloutOnChange?: boolean;
   custom?: string[];
 }
 
+export interface IdentitySpec {
+  serviceAccountName?: string;
+  annotations?: Record<string, string>;
+}
+
+export interface RateLimitSpec {
+  requestsPerMinute?: number;
+  burst?: number;
+  maxConcurrent?: number;
+}
+
+export interface PromptPolicySpec {
+  systemPrompt?: string;
+  maxTokens?: number;
+  stop?: string[];
+}
+
+export interface GuardrailsSpec {
+  endpoint: string;
+  mode?: 'block' | 'flag';
+  failureMode?: 'open' | 'closed';
+  timeout?: string;
+}
+
+export interface ResponseCacheSpec {
+  ttl?: string;
+  maxEntries?: number;
+  normalizeWhitespace?: boolean;
+}
+
+export interface FallbackBackendRef {
+  kind: 'InferencePool' | 'Service';
+  name: string;
+  port?: number;
+}
+
+export interface GatewayFallbackSpec {
+  backendRef?: FallbackBackendRef;
+  url?: string;
+  overflowPercent?: number;
+  saturationThreshold?: number;
+}
+
+export type GatewayFallbackState = 'Standby' | 'Overflow' | 'Active';
+
 export interface GatewaySpec {
   enabled?: boolean;
   modelName?: string;
+  modelNameTemplate?: string;
+  modelAliases?: string[];
   httpRouteRef?: string;
+  streaming?: boolean;
+  timeout?: string;
+  rateLimit?: RateLimitSpec;
+  promptPolicy?: PromptPolicySpec;
+  guardrails?: GuardrailsSpec;
+  responseCache?: ResponseCacheSpec;
+  fallback?: GatewayFallbackSpec;
+  eppConfig?: string;
+  sessionAffinity?: 'prefixCache' | 'none';
+  engineMetrics?: boolean;
+  responseHeaders?: ('model' | 'deployment' | 'provider')[];
+}
+
+export interface TracingSpec {
+  enabled?: boolean;
+  endpoint?: string;
+  samplingPercent?: number;
+}
+
+export type LogSinkType = 'loki' | 'otlp';
+
+export interface LogSinkSpec {
+  type: LogSinkType;
+  endpoint: string;
+  labels?: Record<string, string>;
+  image?: string;
+}
+
+export interface GenAIMetricsSpec {
+  enabled?: boolean;
+}
+
+export interface ObservabilitySpec {
+  tracing?: TracingSpec;
+  logSink?: LogSinkSpec;
+  genAIMetrics?: GenAIMetricsSpec;
+}
+
+export type KVCacheBackend = 'lmcache' | 'redis';
+
+export interface KVCacheSpec {
+  backend: KVCacheBackend;
+  connectionSecretRef?: {
+    name: string;
+    key: string;
+  };
+  maxSize?: string;
+}
+
+export interface CachingSpec {
+  kv?: KVCacheSpec;
+}
+
+export interface KVCacheStatus {
+  prefixHitPercent?: number;
+  externalHitPercent?: number;
+}
+
+export interface MetricsSnapshot {
+  time: string;
+  window: string;
+  requestsPerSecond?: string;
+  p50Latency?: string;
+  p95Latency?: string;
+  errorRate?: string;
+}
+
+export interface ExposeSpec {
+  type: 'ClusterIP' | 'NodePort' | 'LoadBalancer' | 'Ingress';
+  ingressClassName?: string;
+  host?: string;
+}
+
+export interface NetworkingSpec {
+  ipFamilyPolicy?: 'SingleStack' | 'PreferDualStack' | 'RequireDualStack';
+  ipFamilies?: ('IPv4' | 'IPv6')[];
+  isolate?: boolean;
+}
+
+export interface SchedulingSpec {
+  gang?: boolean;
+  scheduler?: 'kueue' | 'volcano' | 'kai';
+  schedulerName?: string;
+  queue?: string;
+  kueueAdmission?: boolean;
+}
+
+export interface WarmupSpec {
+  requests?: number;
+  prompt?: string;
+  maxTokens?: number;
 }
 
 export interface ModelDeploymentSpec {
@@ -150,12 +409,26 @@ export interface ModelDeploymentSpec {
   engine: EngineSpec;
   serving?: ServingSpec;
   scaling?: ScalingSpec;
+  multinode?: MultinodeSpec;
   resources?: ResourceSpec;
   image?: string;
   env?: Record<string, string>;
   podTemplate?: PodTemplateSpec;
+  labels?: Record<string, string>;
+  annotations?: Record<string, string>;
+  scheduling?: SchedulingSpec;
   secrets?: SecretSpec;
+  identity?: IdentitySpec;
   gateway?: GatewaySpec;
+  expose?: ExposeSpec;
+  networking?: NetworkingSpec;
+  warmup?: WarmupSpec;
+  caching?: CachingSpec;
+  observability?: ObservabilitySpec;
+  progressDeadlineSeconds?: number;
+  ttlSecondsAfterCreation?: number;
+  ttlSecondsAfterLastRequest?: number;
+  paused?: boolean;
 }
 
 export interface ReplicaStatus {
@@ -164,9 +437,18 @@ export interface ReplicaStatus {
   available: number;
 }
 
+export interface PartialApplyStatus {
+  failedResource: string;
+  appliedResources?: string[];
+  rolledBackResources?: string[];
+}
+
 export interface ProviderStatus {
   name?: string;
   selectedReason?: string;
+  partialApply?: PartialApplyStatus;
+  defaultImage?: string;
+  defaultImageTime?: string;
   resourceRef?: {
     apiVersion?: string;
     kind?: string;
@@ -175,6 +457,19 @@ export interface ProviderStatus {
   };
 }
 
+export type MigrationPhase = 'Provisioning' | 'Verifying' | 'Switching' | 'Draining' | 'Completed' | 'Failed';
+
+export interface MigrationStatus {
+  from: string;
+  to: string;
+  phase: MigrationPhase;
+  fromEndpoint?: EndpointStatus;
+  message?: string;
+  startTime?: string;
+  lastTransitionTime?: string;
+  completionTime?: string;
+}
+
 export interface Condition {
   type: string;
   status: 'True' | 'False' | 'Unknown';
@@ -186,6 +481,7 @@ export interface Condition {
 export interface GatewayStatus {
   endpoint?: string;
   modelName?: string;
+  fallback?: GatewayFallbackState;
 }
 
 export interface GatewayInfo {
@@ -211,11 +507,109 @@ export interface EngineStatus {
   type?: string;
 }
 
+export interface ExposeStatus {
+  service?: string;
+  nodePort?: number;
+  url?: string;
+}
+
+export interface WarmupStatus {
+  completedRequests?: number;
+  firstRequestLatency?: string;
+  averageLatency?: string;
+  completionTime?: string;
+  observedGeneration?: number;
+}
+
+export interface AdmissionStatus {
+  workloadName?: string;
+  clusterQueue?: string;
+  flavors?: Record<string, string>;
+  admissionTime?: string;
+}
+
+export interface AppliedChange {
+  resourceKind?: string;
+  resourceName?: string;
+  paths?: string[];
+  operations?: number;
+  observedGeneration?: number;
+  time?: string;
+}
+
+export interface ResourceRecommendations {
+  cpu?: string;
+  memory?: string;
+  peakCPU?: string;
+  peakMemory?: string;
+  peakGPUMemory?: string;
+  sources?: string[];
+  observedGeneration?: number;
+  observedSince?: string;
+  lastUpdateTime?: string;
+  lastAppliedTime?: string;
+}
+
+export interface GPUUtilizationStatus {
+  percent: string;
+  peakPercent?: string;
+  gpus: number;
+  idleSince?: string;
+  observedGeneration?: number;
+  lastUpdateTime: string;
+}
+
+export type RemediationReason = 'CUDAOutOfMemory' | 'KVCacheTooSmall' | 'UnsupportedDtype';
+
+export interface RemediationAttempt {
+  time: string;
+  reason: RemediationReason;
+  action: string;
+  pod?: string;
+}
+
+export interface RemediationStatus {
+  attempts?: RemediationAttempt[];
+  exhausted?: boolean;
+  message?: string;
+  observedGeneration?: number;
+}
+
+export interface SelectionCriterion {
+  name: string;
+  passed: boolean;
+  message?: string;
+}
+
+export interface SelectionRuleResult {
+  condition: string;
+  priority?: number;
+  matched: boolean;
+  error?: string;
+}
+
+export interface ProviderEvaluation {
+  name: string;
+  eligible: boolean;
+  criteria?: SelectionCriterion[];
+  rules?: SelectionRuleResult[];
+  score?: number;
+}
+
+export interface SelectionReport {
+  engine?: EngineType;
+  selected?: string;
+  strategy?: string;
+  providers?: ProviderEvaluation[];
+  evaluatedTime?: string;
+}
+
 export interface ModelDeploymentStatus {
   phase?: DeploymentPhase;
   message?: string;
   engine?: EngineStatus;
   provider?: ProviderStatus;
+  migration?: MigrationStatus;
   replicas?: ReplicaStatus;
   prefillReplicas?: {
     desired: number;
@@ -227,6 +621,21 @@ export interface ModelDeploymentStatus {
   };
   endpoint?: EndpointStatus;
   gateway?: GatewayStatus;
+  expose?: ExposeStatus;
+  gpuType?: string;
+  secretsChecksum?: string;
+  warmup?: WarmupStatus;
+  admission?: AdmissionStatus;
+  lastAppliedChange?: AppliedChange;
+  lastRequestTime?: string;
+  kvCache?: KVCacheStatus;
+  adapters?: AdapterStatus[];
+  placement?: PodPlacement[];
+  metricsSnapshot?: MetricsSnapshot;
+  recommendations?: ResourceRecommendations;
+  gpuUtilization?: GPUUtilizationStatus;
+  selectionReport?: SelectionReport;
+  remediation?: RemediationStatus;
   conditions?: Condition[];
   observedGeneration?: number;
 }